@@ -0,0 +1,119 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	KindAuthorizationReview = "AuthorizationReview"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuthorizationReview is a create-only resource that calculates the namespaces and tiers in which
+// a user is authorized to act on a set of resource types. The review is performed for the requesting
+// user, or for the user specified in the spec if the requesting user is allowed to impersonate them.
+type AuthorizationReview struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Specification of the AuthorizationReview.
+	Spec AuthorizationReviewSpec `json:"spec,omitempty"`
+	// Status of the AuthorizationReview, filled in by the API server.
+	Status AuthorizationReviewStatus `json:"status,omitempty"`
+}
+
+// AuthorizationReviewSpec contains the resource types and verbs to review.
+type AuthorizationReviewSpec struct {
+	// The set of resource attributes that are being checked. Each resource attribute is expanded into
+	// individual kind/resource and verbs.
+	ResourceAttributes []AuthorizationReviewResourceAttributes `json:"resourceAttributes,omitempty"`
+
+	// User is the user to perform the review for. If not specified, the review is performed for the
+	// requesting user. The requesting user must be allowed to impersonate the specified user, and any
+	// specified groups.
+	User string `json:"user,omitempty"`
+
+	// UID is the UID of the user to perform the review for.
+	UID string `json:"uid,omitempty"`
+
+	// Groups is the set of groups the user belongs to. Only used when User is specified.
+	Groups []string `json:"groups,omitempty"`
+
+	// Extra holds additional information about the user. Only used when User is specified.
+	Extra map[string][]string `json:"extra,omitempty"`
+}
+
+// AuthorizationReviewResourceAttributes is a set of resource types and verbs within a single API group.
+type AuthorizationReviewResourceAttributes struct {
+	// The API Group to check.
+	APIGroup string `json:"apiGroup,omitempty"`
+	// The set of resources to check within the same API Group.
+	Resources []string `json:"resources,omitempty"`
+	// The set of verbs to check. This is expanded for each resource within the same API Group.
+	Verbs []string `json:"verbs,omitempty"`
+}
+
+// AuthorizationReviewStatus contains the results of the review.
+type AuthorizationReviewStatus struct {
+	// The set of authorized resource actions. A given API Group and resource combination will appear at most once in
+	// this slice.
+	AuthorizedResourceVerbs []AuthorizedResourceVerbs `json:"authorizedResourceVerbs,omitempty"`
+}
+
+// AuthorizedResourceVerbs contains the authorized verbs for a single resource type.
+type AuthorizedResourceVerbs struct {
+	// The API group.
+	APIGroup string `json:"apiGroup,omitempty"`
+	// The resource.
+	Resource string `json:"resource,omitempty"`
+	// The set of authorized actions for this resource. For a specific verb, this contains the set of resources for
+	// which the user is authorized to perform that action. A verb only appears if the user is authorized to perform
+	// it on at least one namespace or tier.
+	Verbs []AuthorizedResourceVerb `json:"verbs,omitempty"`
+}
+
+// AuthorizedResourceVerb contains the scopes in which a single verb is authorized for a resource type.
+type AuthorizedResourceVerb struct {
+	// The verb.
+	Verb string `json:"verb"`
+	// The group of resource instances that are authorized for this verb.
+	ResourceGroups []AuthorizedResourceGroup `json:"resourceGroups"`
+}
+
+// AuthorizedResourceGroup is a namespace and tier combination in which an action is authorized.
+type AuthorizedResourceGroup struct {
+	// The tier. This is only valid for tiered policies, and tiers. A blank value indicates all tiers.
+	Tier string `json:"tier,omitempty"`
+	// The namespace. This is only valid for namespaced resource types. A blank value indicates all namespaces
+	// (or cluster-wide for cluster-scoped resource types).
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// NewAuthorizationReview creates a new (zeroed) AuthorizationReview struct with the TypeMetadata initialised to the
+// current version.
+func NewAuthorizationReview() *AuthorizationReview {
+	return &AuthorizationReview{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindAuthorizationReview,
+			APIVersion: GroupVersionCurrent,
+		},
+	}
+}
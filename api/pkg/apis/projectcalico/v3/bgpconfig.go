@@ -83,6 +83,13 @@ type BGPConfigurationSpec struct {
 	// PrefixAdvertisements contains per-prefix advertisement configuration.
 	PrefixAdvertisements []PrefixAdvertisement `json:"prefixAdvertisements,omitempty" validate:"omitempty,dive" confignamev1:"prefix_advertisements"`
 
+	// WorkloadAdvertisements selects workloads whose individual addresses are advertised, as /32 and
+	// /128 routes, from the node that each workload runs on.  By default, only the IPAM blocks that
+	// contain workload addresses are advertised.  This field can only be set on the default
+	// BGPConfiguration instance.
+	// +optional
+	WorkloadAdvertisements []WorkloadAdvertisement `json:"workloadAdvertisements,omitempty" validate:"omitempty,dive"`
+
 	// ListenPort is the port where BGP protocol should listen. Defaults to 179
 	// +kubebuilder:validation:Minimum:=1
 	// +kubebuilder:validation:Maximum:=65535
@@ -108,6 +115,25 @@ type BGPConfigurationSpec struct {
 	// IgnoredInterfaces indicates the network interfaces that needs to be excluded when reading device routes.
 	// +optional
 	IgnoredInterfaces []string `json:"ignoredInterfaces,omitempty" validate:"omitempty,dive,ignoredInterface"`
+
+	// PrometheusMetricsEnabled enables the Prometheus metrics server in calico/node, which exports the
+	// state, uptime, flap count and prefix counts of each BGP session. [Default: false]
+	// +optional
+	PrometheusMetricsEnabled *bool `json:"prometheusMetricsEnabled,omitempty"`
+
+	// PrometheusMetricsHost is the host that the BGP metrics server should bind to. [Default: empty]
+	// +optional
+	PrometheusMetricsHost string `json:"prometheusMetricsHost,omitempty" validate:"omitempty,prometheusHost"`
+
+	// PrometheusMetricsPort is the TCP port that the BGP metrics server should bind to. [Default: 9900]
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	PrometheusMetricsPort *int `json:"prometheusMetricsPort,omitempty" validate:"omitempty,gt=0,lte=65535"`
+
+	// PrometheusMetricsPollInterval is how often BIRD is queried for the BGP metrics. [Default: 10s]
+	// +optional
+	PrometheusMetricsPollInterval *metav1.Duration `json:"prometheusMetricsPollInterval,omitempty"`
 }
 
 // ServiceLoadBalancerIPBlock represents a single allowed LoadBalancer IP CIDR block.
@@ -148,6 +174,18 @@ type PrefixAdvertisement struct {
 	Communities []string `json:"communities,omitempty" validate:"required"`
 }
 
+// WorkloadAdvertisement configures the advertisement of the addresses of the workloads matching a selector.
+type WorkloadAdvertisement struct {
+	// Selector selects the workload endpoints whose addresses are advertised.
+	Selector string `json:"selector" validate:"required,selector"`
+	// Communities can be list of either community names already defined in `Specs.Communities` or community value of format `aa:nn` or `aa:nn:mm`.
+	// For standard community use `aa:nn` format, where `aa` and `nn` are 16 bit number.
+	// For large community use `aa:nn:mm` format, where `aa`, `nn` and `mm` are 32 bit number.
+	// Where,`aa` is an AS Number, `nn` and `mm` are per-AS identifier.
+	// +optional
+	Communities []string `json:"communities,omitempty"`
+}
+
 // New BGPConfiguration creates a new (zeroed) BGPConfiguration struct with the TypeMetadata
 // initialized to the current version.
 func NewBGPConfiguration() *BGPConfiguration {
@@ -114,8 +114,26 @@ type BGPPeerSpec struct {
 	// The ordered set of BGPFilters applied on this BGP peer.
 	// +optional
 	Filters []string `json:"filters,omitempty" validate:"omitempty,dive,name"`
+
+	// The address families of the routes exchanged with the peer.  By default, only the routes
+	// of the family of the session's addresses are exchanged.  Listing both IPv4 and IPv6
+	// exchanges both over a single session, and IPv4 routes are advertised over an IPv6 session
+	// with IPv6 next hops, using the extended next hop capability (RFC 8950).  When PeerSelector
+	// is set, the session with each selected node is made over its address in the first listed
+	// family.  Only the native BGP daemon exchanges a family over a session of the other family.
+	// +optional
+	AddressFamilies []BGPAddressFamily `json:"addressFamilies,omitempty" validate:"omitempty"`
 }
 
+// BGPAddressFamily is an address family of the routes exchanged with a BGP peer.
+// +kubebuilder:validation:Enum=IPv4;IPv6
+type BGPAddressFamily string
+
+const (
+	BGPAddressFamilyIPv4 BGPAddressFamily = "IPv4"
+	BGPAddressFamilyIPv6 BGPAddressFamily = "IPv6"
+)
+
 type SourceAddress string
 
 const (
@@ -103,6 +103,17 @@ const (
 
 // FelixConfigurationSpec contains the values of the Felix configuration.
 type FelixConfigurationSpec struct {
+	// NodeSelector selects the nodes that this FelixConfiguration applies to.  It may not be set on
+	// the "default" and "node.<nodename>" FelixConfigurations.  Any other FelixConfiguration
+	// without a NodeSelector is not applied to any node.  The values in the FelixConfigurations
+	// that select a node override those in "default", and are overridden by those in
+	// "node.<nodename>". [Default: none]
+	NodeSelector string `json:"nodeSelector,omitempty" validate:"omitempty,selector" confignamev1:"-"`
+	// Priority orders the FelixConfigurations that select the same node.  Where more than one
+	// sets a value, the value from the FelixConfiguration with the highest priority is used, and
+	// then from the one whose name sorts first. [Default: 0]
+	Priority *int `json:"priority,omitempty" confignamev1:"-"`
+
 	// UseInternalDataplaneDriver, if true, Felix will use its internal dataplane programming logic.  If false, it
 	// will launch an external dataplane driver and communicate with it over protobuf.
 	UseInternalDataplaneDriver *bool `json:"useInternalDataplaneDriver,omitempty"`
@@ -327,6 +338,12 @@ type FelixConfigurationSpec struct {
 
 	DisableConntrackInvalidCheck *bool `json:"disableConntrackInvalidCheck,omitempty"`
 
+	// ConntrackTimeouts overrides the idle timeouts of connection tracking entries, per protocol and
+	// TCP state.  In BPF mode, the timeouts are applied by Felix's BPF conntrack cleaner.  In all modes,
+	// Felix also programs the equivalent nf_conntrack_* sysctls so that the kernel's conntrack table uses
+	// the same timeouts.  Timeouts that are not specified keep their defaults.
+	ConntrackTimeouts *ConntrackTimeouts `json:"conntrackTimeouts,omitempty" validate:"omitempty"`
+
 	HealthEnabled *bool   `json:"healthEnabled,omitempty"`
 	HealthHost    *string `json:"healthHost,omitempty"`
 	HealthPort    *int    `json:"healthPort,omitempty"`
@@ -598,6 +615,16 @@ type FelixConfigurationSpec struct {
 	// RouteTableRange specifies the indices of the route tables that Calico should use.
 	RouteTableRange *RouteTableRange `json:"routeTableRange,omitempty" validate:"omitempty"`
 
+	// RoutePolicyRouteTableRange specifies the indices of the route tables that may be used by RoutePolicy
+	// resources. Felix claims a table in this range when a RoutePolicy first uses it, provided that no other
+	// software has routes or routing rules in the table, and then owns the routes and rules of that table.
+	// The range must not overlap with RouteTableRanges, which default to 1-250. [Default: 1000-1249]
+	RoutePolicyRouteTableRange *RouteTableIDRange `json:"routePolicyRouteTableRange,omitempty" validate:"omitempty"`
+
+	// RoutePolicyRoutingRulePriority controls the priority value to use for the routing rules that steer workload
+	// traffic into RoutePolicy route tables. [Default: 100]
+	RoutePolicyRoutingRulePriority *int `json:"routePolicyRoutingRulePriority,omitempty" validate:"omitempty,gt=0,lt=32766"`
+
 	// RouteSyncDisabled will disable all operations performed on the route table. Set to true to
 	// run in network-policy mode only.
 	RouteSyncDisabled *bool `json:"routeSyncDisabled,omitempty"`
@@ -698,6 +725,38 @@ type HealthTimeoutOverride struct {
 	Timeout metav1.Duration `json:"timeout"`
 }
 
+// ConntrackTimeouts contains the per-protocol idle timeouts for connection tracking entries.
+type ConntrackTimeouts struct {
+	// TCPSynSent is the timeout for TCP connections that have not yet completed their handshake.
+	// Sets nf_conntrack_tcp_timeout_syn_sent and nf_conntrack_tcp_timeout_syn_recv.
+	// [Default: 20s in BPF mode; kernel default otherwise]
+	TCPSynSent *metav1.Duration `json:"tcpSynSent,omitempty"`
+	// TCPEstablished is the timeout for established TCP connections.
+	// Sets nf_conntrack_tcp_timeout_established.
+	// [Default: 1h in BPF mode; kernel default otherwise]
+	TCPEstablished *metav1.Duration `json:"tcpEstablished,omitempty"`
+	// TCPFinsSeen is the timeout for TCP connections that are being closed.
+	// Sets nf_conntrack_tcp_timeout_fin_wait, _close_wait, _last_ack and _time_wait.
+	// [Default: 30s in BPF mode; kernel default otherwise]
+	TCPFinsSeen *metav1.Duration `json:"tcpFinsSeen,omitempty"`
+	// TCPResetSeen is the timeout for TCP connections that have seen a RST.
+	// Sets nf_conntrack_tcp_timeout_close.
+	// [Default: 40s in BPF mode; kernel default otherwise]
+	TCPResetSeen *metav1.Duration `json:"tcpResetSeen,omitempty"`
+	// UDPTimeout is the timeout for UDP flows.
+	// Sets nf_conntrack_udp_timeout and nf_conntrack_udp_timeout_stream.
+	// [Default: 60s in BPF mode; kernel default otherwise]
+	UDPTimeout *metav1.Duration `json:"udpTimeout,omitempty"`
+	// ICMPTimeout is the timeout for ICMP flows.
+	// Sets nf_conntrack_icmp_timeout and nf_conntrack_icmpv6_timeout.
+	// [Default: 5s in BPF mode; kernel default otherwise]
+	ICMPTimeout *metav1.Duration `json:"icmpTimeout,omitempty"`
+	// GenericTimeout is the timeout for flows of other IP protocols.
+	// Sets nf_conntrack_generic_timeout.
+	// [Default: 10m in BPF mode; kernel default otherwise]
+	GenericTimeout *metav1.Duration `json:"genericTimeout,omitempty"`
+}
+
 type RouteTableRange struct {
 	Min int `json:"min"`
 	Max int `json:"max"`
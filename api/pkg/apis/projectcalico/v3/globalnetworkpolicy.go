@@ -43,6 +43,8 @@ type GlobalNetworkPolicy struct {
 	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
 
 	Spec GlobalNetworkPolicySpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+	// Status of the policy, as programmed into the dataplane by the nodes that the policy applies to.
+	Status PolicyStatus `json:"status,omitempty"`
 }
 
 type GlobalNetworkPolicySpec struct {
@@ -54,6 +54,13 @@ type IPAMConfigurationSpec struct {
 	// MaxBlocksPerHost, if non-zero, is the max number of blocks that can be
 	// affine to each host.
 	MaxBlocksPerHost int32 `json:"maxBlocksPerHost,omitempty"`
+
+	// EventLog, if true, records each IP address assignment and release, along with the pod,
+	// namespace, node and container that the address belongs to, so that the history of an address
+	// can be queried with "calicoctl ipam history".  Old events are deleted by the Calico
+	// Kubernetes controllers according to the node controller's IPAM event retention settings.
+	// [Default: false]
+	EventLog bool `json:"eventLog,omitempty"`
 }
 
 // NewIPAMConfiguration creates a new (zeroed) IPAMConfiguration struct with the TypeMetadata initialised to the current
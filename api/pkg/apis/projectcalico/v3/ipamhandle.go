@@ -0,0 +1,82 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	KindIPAMHandle     = "IPAMHandle"
+	KindIPAMHandleList = "IPAMHandleList"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPAMHandle is a read-only view of a Calico IPAM handle, which tracks the IP addresses
+// allocated to a single owner, such as a pod.
+type IPAMHandle struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Specification of the IPAMHandle.
+	Spec IPAMHandleSpec `json:"spec,omitempty"`
+}
+
+// IPAMHandleSpec contains the specification for an IPAMHandle resource.
+type IPAMHandleSpec struct {
+	// The ID of the handle.
+	HandleID string `json:"handleID"`
+
+	// Map of block name to the number of addresses allocated to this handle from that block.
+	Block map[string]int `json:"block"`
+
+	// Deleted indicates that the handle is in the process of being deleted.
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPAMHandleList contains a list of IPAMHandle resources.
+type IPAMHandleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []IPAMHandle `json:"items"`
+}
+
+// NewIPAMHandle creates a new (zeroed) IPAMHandle struct with the TypeMetadata initialised to the current
+// version.
+func NewIPAMHandle() *IPAMHandle {
+	return &IPAMHandle{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindIPAMHandle,
+			APIVersion: GroupVersionCurrent,
+		},
+	}
+}
+
+// NewIPAMHandleList creates a new (zeroed) IPAMHandleList struct with the TypeMetadata initialised to the current
+// version.
+func NewIPAMHandleList() *IPAMHandleList {
+	return &IPAMHandleList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindIPAMHandleList,
+			APIVersion: GroupVersionCurrent,
+		},
+	}
+}
@@ -85,6 +85,82 @@ type IPPoolSpec struct {
 	// AllowedUse controls what the IP pool will be used for.  If not specified or empty, defaults to
 	// ["Tunnel", "Workload"] for back-compatibility
 	AllowedUses []IPPoolAllowedUse `json:"allowedUses,omitempty" validate:"omitempty"`
+
+	// ExternalIPAM delegates the choice of the blocks that are claimed from this pool to an external
+	// IPAM system.  When set, Calico only creates a block once the external system has confirmed the
+	// allocation of the block's CIDR, and releases the allocation back to the external system when the
+	// block is deleted.  Calico continues to manage block affinities, handles and garbage collection.
+	ExternalIPAM *ExternalIPAMSpec `json:"externalIPAM,omitempty" validate:"omitempty"`
+
+	// Quotas limit the number of workload addresses that each namespace or node may hold in this
+	// pool.  Calico IPAM refuses to assign an address that would take a namespace or node over any
+	// of the quotas that select it.  Quotas do not apply to tunnel addresses.
+	Quotas []IPPoolQuota `json:"quotas,omitempty" validate:"omitempty,dive"`
+}
+
+// IPPoolQuota limits the number of addresses that each of a set of namespaces, or each of a set of
+// nodes, may hold in an IP pool.  Exactly one of the namespace and node selectors must be set.
+type IPPoolQuota struct {
+	// NamespaceSelector selects the namespaces that the quota applies to.  It is evaluated against
+	// the namespace's labels and the "projectcalico.org/name" label, which holds the namespace's
+	// name.  Each selected namespace may hold at most MaxAddresses addresses in the pool.
+	NamespaceSelector string `json:"namespaceSelector,omitempty" validate:"omitempty,selector"`
+
+	// NodeSelector selects the nodes that the quota applies to.  Each selected node may hold at most
+	// MaxAddresses addresses in the pool for the workloads running on it.
+	NodeSelector string `json:"nodeSelector,omitempty" validate:"omitempty,selector"`
+
+	// MaxAddresses is the maximum number of addresses that each selected namespace or node may hold
+	// in the pool.
+	MaxAddresses int `json:"maxAddresses" validate:"gte=0"`
+}
+
+type ExternalIPAMType string
+
+const (
+	// ExternalIPAMTypeGRPC delegates block allocation to an IPAM plugin that serves the Calico
+	// external IPAM gRPC protocol.
+	ExternalIPAMTypeGRPC ExternalIPAMType = "GRPC"
+	// ExternalIPAMTypeFile allocates blocks from the ranges listed in a local file.  It is intended
+	// as a stand-in for an external IPAM system when testing.
+	ExternalIPAMTypeFile ExternalIPAMType = "File"
+)
+
+// ExternalIPAMSpec configures the external IPAM system that allocates the blocks of an IP pool.
+type ExternalIPAMSpec struct {
+	// Type is the type of the external allocator, one of "GRPC" or "File".
+	Type ExternalIPAMType `json:"type" validate:"oneof=GRPC File"`
+
+	// Endpoint is the address of the gRPC IPAM plugin, either a "unix://" socket path or a host:port.
+	// Required when the type is "GRPC".
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// TLS configures TLS for the connection to the gRPC IPAM plugin.  Required when the endpoint is
+	// a host:port; connections to a unix socket use TLS only if it is set.
+	TLS *ExternalIPAMTLS `json:"tls,omitempty"`
+
+	// Path is the path of the file that backs the "File" allocator.  Required when the type is "File".
+	Path string `json:"path,omitempty"`
+
+	// Timeout is the timeout for each request to the external IPAM system. [Default: 10s]
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ExternalIPAMTLS configures TLS for the connection to a gRPC IPAM plugin.  The files are read by
+// the component that performs IPAM, such as the CNI plugin or kube-controllers.
+type ExternalIPAMTLS struct {
+	// CACertFile is the path of the PEM-encoded CA bundle that verifies the plugin's certificate.
+	// If not set, the host's root CAs are used.
+	CACertFile string `json:"caCertFile,omitempty"`
+
+	// CertFile and KeyFile are the paths of the PEM-encoded client certificate and key that are
+	// presented to the plugin.  They must be set together.
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+
+	// ServerName is the name that the plugin's certificate must match.  If not set, the host of
+	// the endpoint is used.
+	ServerName string `json:"serverName,omitempty"`
 }
 
 type IPPoolAllowedUse string
@@ -0,0 +1,122 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	KindIPPoolMigration     = "IPPoolMigration"
+	KindIPPoolMigrationList = "IPPoolMigrationList"
+)
+
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPPoolMigrationList contains a list of IPPoolMigration resources.
+type IPPoolMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Items []IPPoolMigration `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPPoolMigration moves running workloads from one IP pool to another.  The Calico Kubernetes
+// controllers disable the source pool, then evict the pods that have addresses in it in batches,
+// respecting PodDisruptionBudgets, and wait for each pod to be replaced by one with an address from
+// the destination pool.  Once every pod has been moved, the empty blocks in the source pool are
+// released.  Progress is reported in the status.
+type IPPoolMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec   IPPoolMigrationSpec   `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+	Status IPPoolMigrationStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// IPPoolMigrationSpec contains the specification for an IPPoolMigration resource.
+type IPPoolMigrationSpec struct {
+	// From is the CIDR of the IP pool that workloads are moved out of.
+	From string `json:"from" validate:"net"`
+
+	// To is the CIDR of the IP pool that workloads are moved into.  It must be enabled and of the
+	// same IP family as the source pool.
+	To string `json:"to" validate:"net"`
+
+	// BatchSize is the number of pods that are evicted at a time.  The next batch is only evicted
+	// once every pod in the current batch has been replaced.  [Default: 1]
+	BatchSize *int `json:"batchSize,omitempty" validate:"omitempty,gte=1"`
+
+	// PodTimeout is how long to wait for an evicted pod to be replaced by one with an address from
+	// the destination pool, including any time spent waiting for a PodDisruptionBudget to allow the
+	// eviction.  The migration fails if a pod is not replaced in time.  [Default: 10m]
+	PodTimeout *metav1.Duration `json:"podTimeout,omitempty" validate:"omitempty"`
+}
+
+type IPPoolMigrationPhase string
+
+const (
+	IPPoolMigrationPhasePending   IPPoolMigrationPhase = "Pending"
+	IPPoolMigrationPhaseRunning   IPPoolMigrationPhase = "Running"
+	IPPoolMigrationPhaseCompleted IPPoolMigrationPhase = "Completed"
+	IPPoolMigrationPhaseFailed    IPPoolMigrationPhase = "Failed"
+)
+
+// IPPoolMigrationStatus contains the progress of an IPPoolMigration.
+type IPPoolMigrationStatus struct {
+	// Phase is the state of the migration.
+	Phase IPPoolMigrationPhase `json:"phase,omitempty"`
+
+	// Message describes the current state of the migration, or why it failed.
+	Message string `json:"message,omitempty"`
+
+	// StartTime is when the migration started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the migration completed or failed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// TotalPods is the number of pods that had addresses in the source pool when the migration
+	// started.
+	TotalPods int `json:"totalPods,omitempty"`
+
+	// MigratedPods is the number of pods that have been replaced by pods with addresses in the
+	// destination pool.
+	MigratedPods int `json:"migratedPods,omitempty"`
+
+	// SkippedPods is the number of pods that were not evicted because they have no controller to
+	// recreate them.  They keep their addresses in the source pool until they are deleted.
+	SkippedPods int `json:"skippedPods,omitempty"`
+
+	// ReleasedBlocks is the number of empty blocks in the source pool that were released once
+	// every pod had been moved.
+	ReleasedBlocks int `json:"releasedBlocks,omitempty"`
+}
+
+// NewIPPoolMigration creates a new (zeroed) IPPoolMigration struct with the TypeMetadata initialised to the current
+// version.
+func NewIPPoolMigration() *IPPoolMigration {
+	return &IPPoolMigration{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindIPPoolMigration,
+			APIVersion: GroupVersionCurrent,
+		},
+	}
+}
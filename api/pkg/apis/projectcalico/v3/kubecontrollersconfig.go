@@ -84,6 +84,12 @@ type ControllersConfig struct {
 
 	// Namespace enables and configures the namespace controller. Enabled by default, set to nil to disable.
 	Namespace *NamespaceControllerConfig `json:"namespace,omitempty"`
+
+	// RouteReflector enables and configures the route reflector controller, which elects route
+	// reflectors and manages the BGP topology between them and the other nodes. Disabled by default,
+	// set to nil to disable.
+	// +optional
+	RouteReflector *RouteReflectorControllerConfig `json:"routeReflector,omitempty"`
 }
 
 // NodeControllerConfig configures the node controller, which automatically cleans up configuration
@@ -102,6 +108,42 @@ type NodeControllerConfig struct {
 	// Set to 0 to disable IP garbage collection. [Default: 15m]
 	// +optional
 	LeakGracePeriod *metav1.Duration `json:"leakGracePeriod,omitempty"`
+
+	// IPAMDefrag configures periodic defragmentation of IPAM blocks, which releases the affinity of
+	// empty and sparsely used blocks so that their address space can be reused by other nodes.
+	// Disabled by default, set to nil to disable.
+	// +optional
+	IPAMDefrag *IPAMDefragConfig `json:"ipamDefrag,omitempty"`
+
+	// IPAMEventRetention configures how much of the IPAM event log is kept, when the event log is
+	// enabled in the IPAM configuration.  Older events are deleted periodically.
+	// +optional
+	IPAMEventRetention *IPAMEventRetentionConfig `json:"ipamEventRetention,omitempty"`
+}
+
+type IPAMEventRetentionConfig struct {
+	// MaxAge is how long IPAM events are kept for. [Default: 168h]
+	MaxAge *metav1.Duration `json:"maxAge,omitempty" validate:"omitempty"`
+
+	// MaxEvents is the maximum number of IPAM events that are kept.  When there are more, the
+	// oldest are deleted first. [Default: 100000]
+	MaxEvents *int `json:"maxEvents,omitempty" validate:"omitempty,gte=1"`
+}
+
+type IPAMDefragConfig struct {
+	// Interval is the period between defragmentation passes. [Default: 1h]
+	Interval *metav1.Duration `json:"interval,omitempty" validate:"omitempty"`
+
+	// SparseThresholdPercent is the percentage of a block's addresses that its node must be using for
+	// the node to keep its affinity to the block.  Blocks that are less used than this have their
+	// affinity released when the node's other blocks have room for the addresses in use. [Default: 25]
+	SparseThresholdPercent *int `json:"sparseThresholdPercent,omitempty" validate:"omitempty,gte=0,lte=100"`
+
+	// ConsolidateBorrowed controls whether the pods on other nodes that have borrowed addresses from
+	// the released blocks are evicted, when their own nodes' blocks have room for them, so that the
+	// blocks drain.  Pods are evicted through the eviction API, so PodDisruptionBudgets are
+	// respected. [Default: Disabled]
+	ConsolidateBorrowed string `json:"consolidateBorrowed,omitempty" validate:"omitempty,oneof=Enabled Disabled"`
 }
 
 type AutoHostEndpointConfig struct {
@@ -137,6 +179,32 @@ type NamespaceControllerConfig struct {
 	ReconcilerPeriod *metav1.Duration `json:"reconcilerPeriod,omitempty" validate:"omitempty"`
 }
 
+// RouteReflectorControllerConfig configures the route reflector controller.  The controller divides
+// the nodes into zones by a node label, elects route reflectors in each zone, and creates the BGPPeer
+// resources that peer every other node with the route reflectors in its zone, and the route
+// reflectors with each other.  Once every zone has a route reflector and the route reflectors report
+// established BGP sessions with all of their peers, the node-to-node mesh is disabled.  Route
+// reflectors that become NotReady or are cordoned are replaced.
+type RouteReflectorControllerConfig struct {
+	// ReconcilerPeriod is the period to perform reconciliation with the Calico datastore. [Default: 30s]
+	ReconcilerPeriod *metav1.Duration `json:"reconcilerPeriod,omitempty" validate:"omitempty"`
+
+	// ZoneLabel is the node label that divides the nodes into zones.  Nodes without the label
+	// form a zone of their own. [Default: topology.kubernetes.io/zone]
+	ZoneLabel string `json:"zoneLabel,omitempty" validate:"omitempty"`
+
+	// ReflectorsPerZone is the number of route reflectors to elect in each zone. [Default: 2]
+	ReflectorsPerZone *int `json:"reflectorsPerZone,omitempty" validate:"omitempty,gte=1"`
+
+	// NodeSelector selects the nodes that may be elected as route reflectors. [Default: all()]
+	NodeSelector string `json:"nodeSelector,omitempty" validate:"omitempty,selector"`
+
+	// ClusterIDBase is the route reflector cluster ID of the first zone.  Each zone's route
+	// reflectors share a cluster ID, and further zones are given the following addresses in turn.
+	// [Default: 244.0.0.1]
+	ClusterIDBase string `json:"clusterIDBase,omitempty" validate:"omitempty,ipv4"`
+}
+
 // KubeControllersConfigurationStatus represents the status of the configuration. It's useful for admins to
 // be able to see the actual config that was applied, which can be modified by environment variables on the
 // kube-controllers process.
@@ -41,6 +41,8 @@ type NetworkPolicy struct {
 	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
 
 	Spec NetworkPolicySpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+	// Status of the policy, as programmed into the dataplane by the nodes that the policy applies to.
+	Status PolicyStatus `json:"status,omitempty"`
 }
 
 type NetworkPolicySpec struct {
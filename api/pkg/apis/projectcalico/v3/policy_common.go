@@ -15,6 +15,8 @@
 package v3
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/projectcalico/api/pkg/lib/numorstring"
 )
 
@@ -26,6 +28,57 @@ const (
 	PolicyTypeEgress  PolicyType = "Egress"
 )
 
+const (
+	// PolicyConditionProgrammed is the type of the policy status condition that indicates whether the
+	// current version of the policy has been programmed into the dataplane on every node that it applies to.
+	PolicyConditionProgrammed = "Programmed"
+
+	// Reasons for the Programmed policy status condition.
+	PolicyReasonProgrammed  = "Programmed"
+	PolicyReasonPending     = "Pending"
+	PolicyReasonFailed      = "Failed"
+	PolicyReasonNotReported = "NotReported"
+)
+
+// PolicyStatus contains the status of a NetworkPolicy or GlobalNetworkPolicy, aggregated from the
+// policy status reported by Felix on each node that the policy applies to.  Felix only reports policy
+// status when endpoint status reporting is enabled in the FelixConfiguration, and only into the etcdv3
+// datastore; the status is not populated when using the Kubernetes datastore.
+type PolicyStatus struct {
+	// Conditions describe the current state of the policy.  The Programmed condition is true once the
+	// current version of the policy has been programmed on all of the nodes that it applies to.  It is
+	// unknown while no node has reported on the policy, which is the case if the policy does not apply to
+	// any nodes.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// NodesSelected is the number of nodes that the policy applies to, i.e. the number of nodes that have
+	// at least one local endpoint that is selected by the policy.  Felix reports the policy as pending on a
+	// node as soon as it applies to the node, so this includes the nodes that have not programmed the
+	// policy yet.
+	NodesSelected int `json:"nodesSelected,omitempty"`
+
+	// NodesProgrammed is the number of selected nodes that have programmed the current version of the
+	// policy into the dataplane.
+	NodesProgrammed int `json:"nodesProgrammed,omitempty"`
+
+	// NodesFailed is the number of selected nodes that have failed to program the current version of the
+	// policy into the dataplane.
+	NodesFailed int `json:"nodesFailed,omitempty"`
+
+	// NodeErrors contains an entry for each node that has failed to program the current version of the
+	// policy.
+	NodeErrors []PolicyNodeError `json:"nodeErrors,omitempty"`
+}
+
+// PolicyNodeError records that a node has failed to program a policy into its dataplane.
+type PolicyNodeError struct {
+	// Node is the name of the node.
+	Node string `json:"node"`
+
+	// Errors is the number of consecutive failed attempts to program the policy on the node.
+	Errors int `json:"errors"`
+}
+
 // A Rule encapsulates a set of match criteria and an action.  Both selector-based security Policy
 // and security Profiles reference rules - separated out as a list of rules for both
 // ingress and egress packet matching.
@@ -61,6 +114,12 @@ type Rule struct {
 	// HTTP contains match criteria that apply to HTTP requests.
 	HTTP *HTTPMatch `json:"http,omitempty" validate:"omitempty"`
 
+	// ConnLimit is an optional field that restricts the rule to only match connections from
+	// sources that would exceed the given number of concurrent connections.  It is typically used
+	// with a Deny action to cap the number of connections per client.  In BPF mode, the per-source
+	// counts are refreshed periodically so the limit is approximate.
+	ConnLimit *ConnLimitMatch `json:"connLimit,omitempty" validate:"omitempty"`
+
 	// Metadata contains additional information for this rule
 	Metadata *RuleMetadata `json:"metadata,omitempty" validate:"omitempty"`
 }
@@ -74,7 +133,7 @@ type HTTPPath struct {
 }
 
 // HTTPMatch is an optional field that apply only to HTTP requests
-// The Methods and Path fields are joined with AND
+// The Methods, Paths, Headers, Hosts, GRPC and JWTClaims fields are joined with AND
 type HTTPMatch struct {
 	// Methods is an optional field that restricts the rule to apply only to HTTP requests that use one of the listed
 	// HTTP Methods (e.g. GET, PUT, etc.)
@@ -88,6 +147,65 @@ type HTTPMatch struct {
 	// - prefix: /bar
 	// NOTE: Each entry may ONLY specify either a `exact` or a `prefix` match. The validator will check for it.
 	Paths []HTTPPath `json:"paths,omitempty" validate:"omitempty"`
+	// Headers is an optional field that restricts the rule to apply only to HTTP requests whose headers match
+	// all of the listed header matches.
+	// Multiple headers are AND'd together.
+	Headers []HTTPHeaderMatch `json:"headers,omitempty" validate:"omitempty,dive"`
+	// Hosts is an optional field that restricts the rule to apply only to HTTP requests whose host (the
+	// HTTP/2 authority) is one of the listed hosts, ignoring any port.  A host may start with "*." to match
+	// any subdomain.
+	// Multiple hosts are OR'd together.
+	Hosts []string `json:"hosts,omitempty" validate:"omitempty"`
+	// GRPC is an optional field that restricts the rule to apply only to gRPC requests, optionally for
+	// particular services and methods.
+	GRPC *GRPCMatch `json:"grpc,omitempty" validate:"omitempty"`
+	// JWTClaims is an optional field that restricts the rule to apply only to HTTP requests with a JWT that
+	// the Envoy JWT authentication filter has verified, and whose claims match all of the listed claim
+	// matches.  Unverified tokens are never matched.
+	// Multiple claims are AND'd together.
+	JWTClaims []JWTClaimMatch `json:"jwtClaims,omitempty" validate:"omitempty,dive"`
+}
+
+// HTTPHeaderMatch specifies an HTTP header to match. At most one of exact, prefix and regex may be
+// specified; if none are, the header only needs to be present.
+type HTTPHeaderMatch struct {
+	// Name is the name of the header, which is matched case-insensitively.
+	Name string `json:"name" validate:"required"`
+	// Exact matches a header whose value is exactly this value.
+	Exact string `json:"exact,omitempty" validate:"omitempty"`
+	// Prefix matches a header whose value starts with this value.
+	Prefix string `json:"prefix,omitempty" validate:"omitempty"`
+	// Regex matches a header whose whole value matches this regular expression, in RE2 syntax.
+	Regex string `json:"regex,omitempty" validate:"omitempty"`
+}
+
+// GRPCMatch specifies the gRPC services and methods to match.  A request is a gRPC request if its content
+// type is application/grpc.
+type GRPCMatch struct {
+	// Services is an optional list of fully-qualified gRPC service names, such as "helloworld.Greeter".
+	// Multiple services are OR'd together.
+	Services []string `json:"services,omitempty" validate:"omitempty"`
+	// Methods is an optional list of gRPC method names, such as "SayHello".
+	// Multiple methods are OR'd together.
+	Methods []string `json:"methods,omitempty" validate:"omitempty"`
+}
+
+// JWTClaimMatch specifies a claim of a verified JWT to match.
+type JWTClaimMatch struct {
+	// Name is the name of the claim, such as "sub" or "iss".  Nested claims may be matched by joining their
+	// names with ".", for example "realm_access.roles".
+	Name string `json:"name" validate:"required"`
+	// Values is the list of values that the claim must have one of.  If the claim is a list, such as a list
+	// of roles, one of its elements must be one of the values.
+	Values []string `json:"values" validate:"required"`
+}
+
+// ConnLimitMatch specifies a limit on the number of concurrent connections from a single source
+// IP address.
+type ConnLimitMatch struct {
+	// MaxConnections is the number of concurrent connections that a source IP may have; the rule
+	// matches connections beyond that number.
+	MaxConnections int `json:"maxConnections" validate:"gte=1"`
 }
 
 // ICMPFields defines structure for ICMP and NotICMP sub-struct for ICMP code and type
@@ -58,6 +58,17 @@ var (
 		&BGPFilterList{},
 		&Tier{},
 		&TierList{},
+		&RoutePolicy{},
+		&RoutePolicyList{},
+		&IPPoolMigration{},
+		&IPPoolMigrationList{},
+		&WorkloadEndpoint{},
+		&WorkloadEndpointList{},
+		&IPAMBlock{},
+		&IPAMBlockList{},
+		&IPAMHandle{},
+		&IPAMHandleList{},
+		&AuthorizationReview{},
 	}
 )
 
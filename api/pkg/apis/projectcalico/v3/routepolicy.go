@@ -0,0 +1,86 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	KindRoutePolicy     = "RoutePolicy"
+	KindRoutePolicyList = "RoutePolicyList"
+)
+
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RoutePolicyList contains a list of RoutePolicy resources.
+type RoutePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Items []RoutePolicy `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RoutePolicy steers traffic from selected workloads through a dedicated routing table.  For each
+// local workload endpoint that matches the selector, Felix programs a routing rule that sends
+// traffic sourced from the workload's addresses to the given table, and programs routes to the
+// destination CIDRs via the next hop and/or interface in that table.  Traffic to destinations
+// that are not covered by the policy continues to use the main routing table.
+type RoutePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec RoutePolicySpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// RoutePolicySpec contains the specification for a RoutePolicy resource.
+type RoutePolicySpec struct {
+	// Selector is an expression used to pick out the workload endpoints whose traffic is steered by
+	// this policy.
+	Selector string `json:"selector" validate:"selector"`
+
+	// Destinations is the list of destination CIDRs that are routed via the next hop and/or interface.
+	// IPv4 and IPv6 CIDRs may be mixed; each is only applied to workload traffic of the same IP family.
+	Destinations []string `json:"destinations" validate:"cidrs"`
+
+	// NextHop is the IP address of the gateway that matching traffic is sent to.  If Interface is
+	// not specified, the outgoing interface is resolved from the host's main routing table.
+	NextHop string `json:"nextHop,omitempty" validate:"omitempty,ip"`
+
+	// Interface is the name of the host interface that matching traffic is sent out of.
+	Interface string `json:"interface,omitempty" validate:"omitempty,interface"`
+
+	// Table is the index of the routing table used for the policy's routes.  It must be within the
+	// RoutePolicyRouteTableRange configured in FelixConfiguration, which defaults to 1000-1249, and
+	// must not be one of the kernel's reserved tables 253-255.  Policies may share a table provided
+	// that their destinations do not overlap.
+	Table int `json:"table" validate:"gte=1,lte=4294967295"`
+}
+
+// NewRoutePolicy creates a new (zeroed) RoutePolicy struct with the TypeMetadata initialised to the current
+// version.
+func NewRoutePolicy() *RoutePolicy {
+	return &RoutePolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindRoutePolicy,
+			APIVersion: GroupVersionCurrent,
+		},
+	}
+}
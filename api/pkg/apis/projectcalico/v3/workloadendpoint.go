@@ -0,0 +1,127 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcalico/api/pkg/lib/numorstring"
+)
+
+const (
+	KindWorkloadEndpoint     = "WorkloadEndpoint"
+	KindWorkloadEndpointList = "WorkloadEndpointList"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkloadEndpoint is a read-only view of a Calico workload endpoint.  Workload endpoints are
+// managed by the orchestrator plugins (for example, the Calico CNI plugin), and cannot be modified
+// through this API.
+type WorkloadEndpoint struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Specification of the WorkloadEndpoint.
+	Spec WorkloadEndpointSpec `json:"spec,omitempty"`
+}
+
+// WorkloadEndpointSpec contains the specification for a WorkloadEndpoint resource.
+type WorkloadEndpointSpec struct {
+	// The name of the orchestrator.
+	Orchestrator string `json:"orchestrator,omitempty"`
+	// The name of the workload.
+	Workload string `json:"workload,omitempty"`
+	// The node name identifying the Calico node instance.
+	Node string `json:"node,omitempty"`
+	// The container ID.
+	ContainerID string `json:"containerID,omitempty"`
+	// The Pod name.
+	Pod string `json:"pod,omitempty"`
+	// The Endpoint name.
+	Endpoint string `json:"endpoint,omitempty"`
+	// ServiceAccountName, if specified, is the name of the k8s ServiceAccount for this pod.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// IPNetworks is a list of subnets allocated to this endpoint. IP packets will only be
+	// allowed to leave this interface if they come from an address in one of these subnets.
+	IPNetworks []string `json:"ipNetworks,omitempty"`
+	// IPNATs is a list of 1:1 NAT mappings to apply to the endpoint.
+	IPNATs []IPNAT `json:"ipNATs,omitempty"`
+	// IPv4Gateway is the gateway IPv4 address for traffic from the workload.
+	IPv4Gateway string `json:"ipv4Gateway,omitempty"`
+	// IPv6Gateway is the gateway IPv6 address for traffic from the workload.
+	IPv6Gateway string `json:"ipv6Gateway,omitempty"`
+	// A list of security Profile resources that apply to this endpoint.
+	Profiles []string `json:"profiles,omitempty"`
+	// InterfaceName the name of the Linux interface on the host.
+	InterfaceName string `json:"interfaceName,omitempty"`
+	// MAC is the MAC address of the endpoint interface.
+	MAC string `json:"mac,omitempty"`
+	// Ports contains the endpoint's named ports, which may be referenced in security policy rules.
+	Ports []WorkloadEndpointPort `json:"ports,omitempty"`
+	// AllowSpoofedSourcePrefixes is a list of CIDRs that the endpoint should be able to send
+	// traffic from, bypassing the RPF check.
+	AllowSpoofedSourcePrefixes []string `json:"allowSpoofedSourcePrefixes,omitempty"`
+}
+
+// WorkloadEndpointPort is a named port on a workload endpoint.
+type WorkloadEndpointPort struct {
+	Name     string               `json:"name"`
+	Protocol numorstring.Protocol `json:"protocol"`
+	Port     uint16               `json:"port"`
+	HostPort uint16               `json:"hostPort"`
+	HostIP   string               `json:"hostIP"`
+}
+
+// IPNAT contains a single NAT mapping for a WorkloadEndpoint resource.
+type IPNAT struct {
+	// The internal IP address which must be associated with the owning endpoint via the
+	// configured IPNetworks for the endpoint.
+	InternalIP string `json:"internalIP"`
+	// The external IP address.
+	ExternalIP string `json:"externalIP"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkloadEndpointList contains a list of WorkloadEndpoint resources.
+type WorkloadEndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []WorkloadEndpoint `json:"items"`
+}
+
+// NewWorkloadEndpoint creates a new (zeroed) WorkloadEndpoint struct with the TypeMetadata initialised to the current
+// version.
+func NewWorkloadEndpoint() *WorkloadEndpoint {
+	return &WorkloadEndpoint{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindWorkloadEndpoint,
+			APIVersion: GroupVersionCurrent,
+		},
+	}
+}
+
+// NewWorkloadEndpointList creates a new (zeroed) WorkloadEndpointList struct with the TypeMetadata initialised to the current
+// version.
+func NewWorkloadEndpointList() *WorkloadEndpointList {
+	return &WorkloadEndpointList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindWorkloadEndpointList,
+			APIVersion: GroupVersionCurrent,
+		},
+	}
+}
@@ -14,6 +14,214 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocationAttribute) DeepCopyInto(out *AllocationAttribute) {
+	*out = *in
+	if in.AttrPrimary != nil {
+		in, out := &in.AttrPrimary, &out.AttrPrimary
+		*out = new(string)
+		**out = **in
+	}
+	if in.AttrSecondary != nil {
+		in, out := &in.AttrSecondary, &out.AttrSecondary
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocationAttribute.
+func (in *AllocationAttribute) DeepCopy() *AllocationAttribute {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocationAttribute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationReview) DeepCopyInto(out *AuthorizationReview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationReview.
+func (in *AuthorizationReview) DeepCopy() *AuthorizationReview {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationReview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AuthorizationReview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationReviewResourceAttributes) DeepCopyInto(out *AuthorizationReviewResourceAttributes) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationReviewResourceAttributes.
+func (in *AuthorizationReviewResourceAttributes) DeepCopy() *AuthorizationReviewResourceAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationReviewResourceAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationReviewSpec) DeepCopyInto(out *AuthorizationReviewSpec) {
+	*out = *in
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make([]AuthorizationReviewResourceAttributes, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Extra != nil {
+		in, out := &in.Extra, &out.Extra
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationReviewSpec.
+func (in *AuthorizationReviewSpec) DeepCopy() *AuthorizationReviewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationReviewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationReviewStatus) DeepCopyInto(out *AuthorizationReviewStatus) {
+	*out = *in
+	if in.AuthorizedResourceVerbs != nil {
+		in, out := &in.AuthorizedResourceVerbs, &out.AuthorizedResourceVerbs
+		*out = make([]AuthorizedResourceVerbs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationReviewStatus.
+func (in *AuthorizationReviewStatus) DeepCopy() *AuthorizationReviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationReviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizedResourceGroup) DeepCopyInto(out *AuthorizedResourceGroup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizedResourceGroup.
+func (in *AuthorizedResourceGroup) DeepCopy() *AuthorizedResourceGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizedResourceGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizedResourceVerb) DeepCopyInto(out *AuthorizedResourceVerb) {
+	*out = *in
+	if in.ResourceGroups != nil {
+		in, out := &in.ResourceGroups, &out.ResourceGroups
+		*out = make([]AuthorizedResourceGroup, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizedResourceVerb.
+func (in *AuthorizedResourceVerb) DeepCopy() *AuthorizedResourceVerb {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizedResourceVerb)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizedResourceVerbs) DeepCopyInto(out *AuthorizedResourceVerbs) {
+	*out = *in
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]AuthorizedResourceVerb, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizedResourceVerbs.
+func (in *AuthorizedResourceVerbs) DeepCopy() *AuthorizedResourceVerbs {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizedResourceVerbs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoHostEndpointConfig) DeepCopyInto(out *AutoHostEndpointConfig) {
 	*out = *in
@@ -130,6 +338,13 @@ func (in *BGPConfigurationSpec) DeepCopyInto(out *BGPConfigurationSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.WorkloadAdvertisements != nil {
+		in, out := &in.WorkloadAdvertisements, &out.WorkloadAdvertisements
+		*out = make([]WorkloadAdvertisement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.NodeMeshPassword != nil {
 		in, out := &in.NodeMeshPassword, &out.NodeMeshPassword
 		*out = new(BGPPassword)
@@ -150,6 +365,21 @@ func (in *BGPConfigurationSpec) DeepCopyInto(out *BGPConfigurationSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrometheusMetricsEnabled != nil {
+		in, out := &in.PrometheusMetricsEnabled, &out.PrometheusMetricsEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PrometheusMetricsPort != nil {
+		in, out := &in.PrometheusMetricsPort, &out.PrometheusMetricsPort
+		*out = new(int)
+		**out = **in
+	}
+	if in.PrometheusMetricsPollInterval != nil {
+		in, out := &in.PrometheusMetricsPollInterval, &out.PrometheusMetricsPollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -486,6 +716,11 @@ func (in *BGPPeerSpec) DeepCopyInto(out *BGPPeerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AddressFamilies != nil {
+		in, out := &in.AddressFamilies, &out.AddressFamilies
+		*out = make([]BGPAddressFamily, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -898,6 +1133,73 @@ func (in *Community) DeepCopy() *Community {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnLimitMatch) DeepCopyInto(out *ConnLimitMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnLimitMatch.
+func (in *ConnLimitMatch) DeepCopy() *ConnLimitMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnLimitMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConntrackTimeouts) DeepCopyInto(out *ConntrackTimeouts) {
+	*out = *in
+	if in.TCPSynSent != nil {
+		in, out := &in.TCPSynSent, &out.TCPSynSent
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TCPEstablished != nil {
+		in, out := &in.TCPEstablished, &out.TCPEstablished
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TCPFinsSeen != nil {
+		in, out := &in.TCPFinsSeen, &out.TCPFinsSeen
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TCPResetSeen != nil {
+		in, out := &in.TCPResetSeen, &out.TCPResetSeen
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UDPTimeout != nil {
+		in, out := &in.UDPTimeout, &out.UDPTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ICMPTimeout != nil {
+		in, out := &in.ICMPTimeout, &out.ICMPTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GenericTimeout != nil {
+		in, out := &in.GenericTimeout, &out.GenericTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConntrackTimeouts.
+func (in *ConntrackTimeouts) DeepCopy() *ConntrackTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(ConntrackTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
 	*out = *in
@@ -926,6 +1228,11 @@ func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
 		*out = new(NamespaceControllerConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RouteReflector != nil {
+		in, out := &in.RouteReflector, &out.RouteReflector
+		*out = new(RouteReflectorControllerConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1002,6 +1309,48 @@ func (in *EntityRule) DeepCopy() *EntityRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalIPAMSpec) DeepCopyInto(out *ExternalIPAMSpec) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ExternalIPAMTLS)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalIPAMSpec.
+func (in *ExternalIPAMSpec) DeepCopy() *ExternalIPAMSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalIPAMSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalIPAMTLS) DeepCopyInto(out *ExternalIPAMTLS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalIPAMTLS.
+func (in *ExternalIPAMTLS) DeepCopy() *ExternalIPAMTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalIPAMTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FelixConfiguration) DeepCopyInto(out *FelixConfiguration) {
 	*out = *in
@@ -1065,6 +1414,11 @@ func (in *FelixConfigurationList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FelixConfigurationSpec) DeepCopyInto(out *FelixConfigurationSpec) {
 	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int)
+		**out = **in
+	}
 	if in.UseInternalDataplaneDriver != nil {
 		in, out := &in.UseInternalDataplaneDriver, &out.UseInternalDataplaneDriver
 		*out = new(bool)
@@ -1215,6 +1569,11 @@ func (in *FelixConfigurationSpec) DeepCopyInto(out *FelixConfigurationSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ConntrackTimeouts != nil {
+		in, out := &in.ConntrackTimeouts, &out.ConntrackTimeouts
+		*out = new(ConntrackTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.HealthEnabled != nil {
 		in, out := &in.HealthEnabled, &out.HealthEnabled
 		*out = new(bool)
@@ -1523,6 +1882,16 @@ func (in *FelixConfigurationSpec) DeepCopyInto(out *FelixConfigurationSpec) {
 		*out = new(RouteTableRange)
 		**out = **in
 	}
+	if in.RoutePolicyRouteTableRange != nil {
+		in, out := &in.RoutePolicyRouteTableRange, &out.RoutePolicyRouteTableRange
+		*out = new(RouteTableIDRange)
+		**out = **in
+	}
+	if in.RoutePolicyRoutingRulePriority != nil {
+		in, out := &in.RoutePolicyRoutingRulePriority, &out.RoutePolicyRoutingRulePriority
+		*out = new(int)
+		**out = **in
+	}
 	if in.RouteSyncDisabled != nil {
 		in, out := &in.RouteSyncDisabled, &out.RouteSyncDisabled
 		*out = new(bool)
@@ -1617,16 +1986,43 @@ func (in *FelixConfigurationSpec) DeepCopy() *FelixConfigurationSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalNetworkPolicy) DeepCopyInto(out *GlobalNetworkPolicy) {
+func (in *GRPCMatch) DeepCopyInto(out *GRPCMatch) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalNetworkPolicy.
-func (in *GlobalNetworkPolicy) DeepCopy() *GlobalNetworkPolicy {
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCMatch.
+func (in *GRPCMatch) DeepCopy() *GRPCMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalNetworkPolicy) DeepCopyInto(out *GlobalNetworkPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalNetworkPolicy.
+func (in *GlobalNetworkPolicy) DeepCopy() *GlobalNetworkPolicy {
 	if in == nil {
 		return nil
 	}
@@ -1802,6 +2198,22 @@ func (in *GlobalNetworkSetSpec) DeepCopy() *GlobalNetworkSetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeaderMatch) DeepCopyInto(out *HTTPHeaderMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeaderMatch.
+func (in *HTTPHeaderMatch) DeepCopy() *HTTPHeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPMatch) DeepCopyInto(out *HTTPMatch) {
 	*out = *in
@@ -1815,6 +2227,28 @@ func (in *HTTPMatch) DeepCopyInto(out *HTTPMatch) {
 		*out = make([]HTTPPath, len(*in))
 		copy(*out, *in)
 	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeaderMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(GRPCMatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWTClaims != nil {
+		in, out := &in.JWTClaims, &out.JWTClaims
+		*out = make([]JWTClaimMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1978,6 +2412,117 @@ func (in *ICMPFields) DeepCopy() *ICMPFields {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMBlock) DeepCopyInto(out *IPAMBlock) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMBlock.
+func (in *IPAMBlock) DeepCopy() *IPAMBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPAMBlock) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMBlockList) DeepCopyInto(out *IPAMBlockList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IPAMBlock, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMBlockList.
+func (in *IPAMBlockList) DeepCopy() *IPAMBlockList {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMBlockList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPAMBlockList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMBlockSpec) DeepCopyInto(out *IPAMBlockSpec) {
+	*out = *in
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(string)
+		**out = **in
+	}
+	if in.Allocations != nil {
+		in, out := &in.Allocations, &out.Allocations
+		*out = make([]*int, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(int)
+				**out = **in
+			}
+		}
+	}
+	if in.Unallocated != nil {
+		in, out := &in.Unallocated, &out.Unallocated
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make([]AllocationAttribute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SequenceNumberForAllocation != nil {
+		in, out := &in.SequenceNumberForAllocation, &out.SequenceNumberForAllocation
+		*out = make(map[string]uint64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMBlockSpec.
+func (in *IPAMBlockSpec) DeepCopy() *IPAMBlockSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMBlockSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAMConfiguration) DeepCopyInto(out *IPAMConfiguration) {
 	*out = *in
@@ -2054,6 +2599,141 @@ func (in *IPAMConfigurationSpec) DeepCopy() *IPAMConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMDefragConfig) DeepCopyInto(out *IPAMDefragConfig) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SparseThresholdPercent != nil {
+		in, out := &in.SparseThresholdPercent, &out.SparseThresholdPercent
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMDefragConfig.
+func (in *IPAMDefragConfig) DeepCopy() *IPAMDefragConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMDefragConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMEventRetentionConfig) DeepCopyInto(out *IPAMEventRetentionConfig) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxEvents != nil {
+		in, out := &in.MaxEvents, &out.MaxEvents
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMEventRetentionConfig.
+func (in *IPAMEventRetentionConfig) DeepCopy() *IPAMEventRetentionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMEventRetentionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMHandle) DeepCopyInto(out *IPAMHandle) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMHandle.
+func (in *IPAMHandle) DeepCopy() *IPAMHandle {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMHandle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPAMHandle) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMHandleList) DeepCopyInto(out *IPAMHandleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IPAMHandle, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMHandleList.
+func (in *IPAMHandleList) DeepCopy() *IPAMHandleList {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMHandleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPAMHandleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMHandleSpec) DeepCopyInto(out *IPAMHandleSpec) {
+	*out = *in
+	if in.Block != nil {
+		in, out := &in.Block, &out.Block
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMHandleSpec.
+func (in *IPAMHandleSpec) DeepCopy() *IPAMHandleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMHandleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPIPConfiguration) DeepCopyInto(out *IPIPConfiguration) {
 	*out = *in
@@ -2070,6 +2750,22 @@ func (in *IPIPConfiguration) DeepCopy() *IPIPConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPNAT) DeepCopyInto(out *IPNAT) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPNAT.
+func (in *IPNAT) DeepCopy() *IPNAT {
+	if in == nil {
+		return nil
+	}
+	out := new(IPNAT)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPPool) DeepCopyInto(out *IPPool) {
 	*out = *in
@@ -2122,14 +2818,141 @@ func (in *IPPoolList) DeepCopy() *IPPoolList {
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IPPoolList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolMigration) DeepCopyInto(out *IPPoolMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolMigration.
+func (in *IPPoolMigration) DeepCopy() *IPPoolMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPPoolMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolMigrationList) DeepCopyInto(out *IPPoolMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IPPoolMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolMigrationList.
+func (in *IPPoolMigrationList) DeepCopy() *IPPoolMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPPoolMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolMigrationSpec) DeepCopyInto(out *IPPoolMigrationSpec) {
+	*out = *in
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.PodTimeout != nil {
+		in, out := &in.PodTimeout, &out.PodTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolMigrationSpec.
+func (in *IPPoolMigrationSpec) DeepCopy() *IPPoolMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolMigrationStatus) DeepCopyInto(out *IPPoolMigrationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolMigrationStatus.
+func (in *IPPoolMigrationStatus) DeepCopy() *IPPoolMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolQuota) DeepCopyInto(out *IPPoolQuota) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolQuota.
+func (in *IPPoolQuota) DeepCopy() *IPPoolQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
 	*out = *in
@@ -2143,6 +2966,16 @@ func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
 		*out = make([]IPPoolAllowedUse, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExternalIPAM != nil {
+		in, out := &in.ExternalIPAM, &out.ExternalIPAM
+		*out = new(ExternalIPAMSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]IPPoolQuota, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2237,6 +3070,27 @@ func (in *IPReservationSpec) DeepCopy() *IPReservationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimMatch) DeepCopyInto(out *JWTClaimMatch) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimMatch.
+func (in *JWTClaimMatch) DeepCopy() *JWTClaimMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeControllersConfiguration) DeepCopyInto(out *KubeControllersConfiguration) {
 	*out = *in
@@ -2381,6 +3235,7 @@ func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -2579,6 +3434,16 @@ func (in *NodeControllerConfig) DeepCopyInto(out *NodeControllerConfig) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.IPAMDefrag != nil {
+		in, out := &in.IPAMDefrag, &out.IPAMDefrag
+		*out = new(IPAMDefragConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPAMEventRetention != nil {
+		in, out := &in.IPAMEventRetention, &out.IPAMEventRetention
+		*out = new(IPAMEventRetentionConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2613,6 +3478,50 @@ func (in *PolicyControllerConfig) DeepCopy() *PolicyControllerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyNodeError) DeepCopyInto(out *PolicyNodeError) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyNodeError.
+func (in *PolicyNodeError) DeepCopy() *PolicyNodeError {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyNodeError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeErrors != nil {
+		in, out := &in.NodeErrors, &out.NodeErrors
+		*out = make([]PolicyNodeError, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyStatus.
+func (in *PolicyStatus) DeepCopy() *PolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrefixAdvertisement) DeepCopyInto(out *PrefixAdvertisement) {
 	*out = *in
@@ -2747,6 +3656,113 @@ func (in *ProtoPort) DeepCopy() *ProtoPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutePolicy) DeepCopyInto(out *RoutePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutePolicy.
+func (in *RoutePolicy) DeepCopy() *RoutePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoutePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutePolicyList) DeepCopyInto(out *RoutePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RoutePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutePolicyList.
+func (in *RoutePolicyList) DeepCopy() *RoutePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoutePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutePolicySpec) DeepCopyInto(out *RoutePolicySpec) {
+	*out = *in
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutePolicySpec.
+func (in *RoutePolicySpec) DeepCopy() *RoutePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteReflectorControllerConfig) DeepCopyInto(out *RouteReflectorControllerConfig) {
+	*out = *in
+	if in.ReconcilerPeriod != nil {
+		in, out := &in.ReconcilerPeriod, &out.ReconcilerPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ReflectorsPerZone != nil {
+		in, out := &in.ReflectorsPerZone, &out.ReflectorsPerZone
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteReflectorControllerConfig.
+func (in *RouteReflectorControllerConfig) DeepCopy() *RouteReflectorControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteReflectorControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTableIDRange) DeepCopyInto(out *RouteTableIDRange) {
 	*out = *in
@@ -2834,6 +3850,11 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 		*out = new(HTTPMatch)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConnLimit != nil {
+		in, out := &in.ConnLimit, &out.ConnLimit
+		*out = new(ConnLimitMatch)
+		**out = **in
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = new(RuleMetadata)
@@ -3062,6 +4083,54 @@ func (in *TierSpec) DeepCopy() *TierSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadAdvertisement) DeepCopyInto(out *WorkloadAdvertisement) {
+	*out = *in
+	if in.Communities != nil {
+		in, out := &in.Communities, &out.Communities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadAdvertisement.
+func (in *WorkloadAdvertisement) DeepCopy() *WorkloadAdvertisement {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadAdvertisement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadEndpoint) DeepCopyInto(out *WorkloadEndpoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadEndpoint.
+func (in *WorkloadEndpoint) DeepCopy() *WorkloadEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadEndpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadEndpointControllerConfig) DeepCopyInto(out *WorkloadEndpointControllerConfig) {
 	*out = *in
@@ -3082,3 +4151,94 @@ func (in *WorkloadEndpointControllerConfig) DeepCopy() *WorkloadEndpointControll
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadEndpointList) DeepCopyInto(out *WorkloadEndpointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkloadEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadEndpointList.
+func (in *WorkloadEndpointList) DeepCopy() *WorkloadEndpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadEndpointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadEndpointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadEndpointPort) DeepCopyInto(out *WorkloadEndpointPort) {
+	*out = *in
+	out.Protocol = in.Protocol
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadEndpointPort.
+func (in *WorkloadEndpointPort) DeepCopy() *WorkloadEndpointPort {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadEndpointPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadEndpointSpec) DeepCopyInto(out *WorkloadEndpointSpec) {
+	*out = *in
+	if in.IPNetworks != nil {
+		in, out := &in.IPNetworks, &out.IPNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPNATs != nil {
+		in, out := &in.IPNATs, &out.IPNATs
+		*out = make([]IPNAT, len(*in))
+		copy(*out, *in)
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]WorkloadEndpointPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowSpoofedSourcePrefixes != nil {
+		in, out := &in.AllowSpoofedSourcePrefixes, &out.AllowSpoofedSourcePrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadEndpointSpec.
+func (in *WorkloadEndpointSpec) DeepCopy() *WorkloadEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -81,6 +81,17 @@ func (c *FakeGlobalNetworkPolicies) Update(ctx context.Context, globalNetworkPol
 	return obj.(*v3.GlobalNetworkPolicy), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeGlobalNetworkPolicies) UpdateStatus(ctx context.Context, globalNetworkPolicy *v3.GlobalNetworkPolicy, opts v1.UpdateOptions) (*v3.GlobalNetworkPolicy, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(globalnetworkpoliciesResource, "status", globalNetworkPolicy), &v3.GlobalNetworkPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v3.GlobalNetworkPolicy), err
+}
+
 // Delete takes name of the globalNetworkPolicy and deletes it. Returns an error if one occurs.
 func (c *FakeGlobalNetworkPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -14,6 +14,10 @@ type FakeProjectcalicoV3 struct {
 	*testing.Fake
 }
 
+func (c *FakeProjectcalicoV3) AuthorizationReviews() v3.AuthorizationReviewInterface {
+	return &FakeAuthorizationReviews{c}
+}
+
 func (c *FakeProjectcalicoV3) BGPConfigurations() v3.BGPConfigurationInterface {
 	return &FakeBGPConfigurations{c}
 }
@@ -54,14 +58,26 @@ func (c *FakeProjectcalicoV3) HostEndpoints() v3.HostEndpointInterface {
 	return &FakeHostEndpoints{c}
 }
 
+func (c *FakeProjectcalicoV3) IPAMBlocks() v3.IPAMBlockInterface {
+	return &FakeIPAMBlocks{c}
+}
+
 func (c *FakeProjectcalicoV3) IPAMConfigurations() v3.IPAMConfigurationInterface {
 	return &FakeIPAMConfigurations{c}
 }
 
+func (c *FakeProjectcalicoV3) IPAMHandles() v3.IPAMHandleInterface {
+	return &FakeIPAMHandles{c}
+}
+
 func (c *FakeProjectcalicoV3) IPPools() v3.IPPoolInterface {
 	return &FakeIPPools{c}
 }
 
+func (c *FakeProjectcalicoV3) IPPoolMigrations() v3.IPPoolMigrationInterface {
+	return &FakeIPPoolMigrations{c}
+}
+
 func (c *FakeProjectcalicoV3) IPReservations() v3.IPReservationInterface {
 	return &FakeIPReservations{c}
 }
@@ -82,10 +98,18 @@ func (c *FakeProjectcalicoV3) Profiles() v3.ProfileInterface {
 	return &FakeProfiles{c}
 }
 
+func (c *FakeProjectcalicoV3) RoutePolicies() v3.RoutePolicyInterface {
+	return &FakeRoutePolicies{c}
+}
+
 func (c *FakeProjectcalicoV3) Tiers() v3.TierInterface {
 	return &FakeTiers{c}
 }
 
+func (c *FakeProjectcalicoV3) WorkloadEndpoints(namespace string) v3.WorkloadEndpointInterface {
+	return &FakeWorkloadEndpoints{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeProjectcalicoV3) RESTClient() rest.Interface {
@@ -4,6 +4,8 @@
 
 package v3
 
+type AuthorizationReviewExpansion interface{}
+
 type BGPConfigurationExpansion interface{}
 
 type BGPFilterExpansion interface{}
@@ -24,10 +26,16 @@ type GlobalNetworkSetExpansion interface{}
 
 type HostEndpointExpansion interface{}
 
+type IPAMBlockExpansion interface{}
+
 type IPAMConfigurationExpansion interface{}
 
+type IPAMHandleExpansion interface{}
+
 type IPPoolExpansion interface{}
 
+type IPPoolMigrationExpansion interface{}
+
 type IPReservationExpansion interface{}
 
 type KubeControllersConfigurationExpansion interface{}
@@ -38,4 +46,8 @@ type NetworkSetExpansion interface{}
 
 type ProfileExpansion interface{}
 
+type RoutePolicyExpansion interface{}
+
 type TierExpansion interface{}
+
+type WorkloadEndpointExpansion interface{}
@@ -26,6 +26,7 @@ type GlobalNetworkPoliciesGetter interface {
 type GlobalNetworkPolicyInterface interface {
 	Create(ctx context.Context, globalNetworkPolicy *v3.GlobalNetworkPolicy, opts v1.CreateOptions) (*v3.GlobalNetworkPolicy, error)
 	Update(ctx context.Context, globalNetworkPolicy *v3.GlobalNetworkPolicy, opts v1.UpdateOptions) (*v3.GlobalNetworkPolicy, error)
+	UpdateStatus(ctx context.Context, globalNetworkPolicy *v3.GlobalNetworkPolicy, opts v1.UpdateOptions) (*v3.GlobalNetworkPolicy, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v3.GlobalNetworkPolicy, error)
@@ -114,6 +115,21 @@ func (c *globalNetworkPolicies) Update(ctx context.Context, globalNetworkPolicy
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *globalNetworkPolicies) UpdateStatus(ctx context.Context, globalNetworkPolicy *v3.GlobalNetworkPolicy, opts v1.UpdateOptions) (result *v3.GlobalNetworkPolicy, err error) {
+	result = &v3.GlobalNetworkPolicy{}
+	err = c.client.Put().
+		Resource("globalnetworkpolicies").
+		Name(globalNetworkPolicy.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(globalNetworkPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the globalNetworkPolicy and deletes it. Returns an error if one occurs.
 func (c *globalNetworkPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -14,6 +14,7 @@ import (
 
 type ProjectcalicoV3Interface interface {
 	RESTClient() rest.Interface
+	AuthorizationReviewsGetter
 	BGPConfigurationsGetter
 	BGPFiltersGetter
 	BGPPeersGetter
@@ -24,14 +25,19 @@ type ProjectcalicoV3Interface interface {
 	GlobalNetworkPoliciesGetter
 	GlobalNetworkSetsGetter
 	HostEndpointsGetter
+	IPAMBlocksGetter
 	IPAMConfigurationsGetter
+	IPAMHandlesGetter
 	IPPoolsGetter
+	IPPoolMigrationsGetter
 	IPReservationsGetter
 	KubeControllersConfigurationsGetter
 	NetworkPoliciesGetter
 	NetworkSetsGetter
 	ProfilesGetter
+	RoutePoliciesGetter
 	TiersGetter
+	WorkloadEndpointsGetter
 }
 
 // ProjectcalicoV3Client is used to interact with features provided by the projectcalico.org group.
@@ -39,6 +45,10 @@ type ProjectcalicoV3Client struct {
 	restClient rest.Interface
 }
 
+func (c *ProjectcalicoV3Client) AuthorizationReviews() AuthorizationReviewInterface {
+	return newAuthorizationReviews(c)
+}
+
 func (c *ProjectcalicoV3Client) BGPConfigurations() BGPConfigurationInterface {
 	return newBGPConfigurations(c)
 }
@@ -79,14 +89,26 @@ func (c *ProjectcalicoV3Client) HostEndpoints() HostEndpointInterface {
 	return newHostEndpoints(c)
 }
 
+func (c *ProjectcalicoV3Client) IPAMBlocks() IPAMBlockInterface {
+	return newIPAMBlocks(c)
+}
+
 func (c *ProjectcalicoV3Client) IPAMConfigurations() IPAMConfigurationInterface {
 	return newIPAMConfigurations(c)
 }
 
+func (c *ProjectcalicoV3Client) IPAMHandles() IPAMHandleInterface {
+	return newIPAMHandles(c)
+}
+
 func (c *ProjectcalicoV3Client) IPPools() IPPoolInterface {
 	return newIPPools(c)
 }
 
+func (c *ProjectcalicoV3Client) IPPoolMigrations() IPPoolMigrationInterface {
+	return newIPPoolMigrations(c)
+}
+
 func (c *ProjectcalicoV3Client) IPReservations() IPReservationInterface {
 	return newIPReservations(c)
 }
@@ -107,10 +129,18 @@ func (c *ProjectcalicoV3Client) Profiles() ProfileInterface {
 	return newProfiles(c)
 }
 
+func (c *ProjectcalicoV3Client) RoutePolicies() RoutePolicyInterface {
+	return newRoutePolicies(c)
+}
+
 func (c *ProjectcalicoV3Client) Tiers() TierInterface {
 	return newTiers(c)
 }
 
+func (c *ProjectcalicoV3Client) WorkloadEndpoints(namespace string) WorkloadEndpointInterface {
+	return newWorkloadEndpoints(c, namespace)
+}
+
 // NewForConfig creates a new ProjectcalicoV3Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
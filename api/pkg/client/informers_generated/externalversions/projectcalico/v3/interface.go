@@ -30,10 +30,16 @@ type Interface interface {
 	GlobalNetworkSets() GlobalNetworkSetInformer
 	// HostEndpoints returns a HostEndpointInformer.
 	HostEndpoints() HostEndpointInformer
+	// IPAMBlocks returns a IPAMBlockInformer.
+	IPAMBlocks() IPAMBlockInformer
 	// IPAMConfigurations returns a IPAMConfigurationInformer.
 	IPAMConfigurations() IPAMConfigurationInformer
+	// IPAMHandles returns a IPAMHandleInformer.
+	IPAMHandles() IPAMHandleInformer
 	// IPPools returns a IPPoolInformer.
 	IPPools() IPPoolInformer
+	// IPPoolMigrations returns a IPPoolMigrationInformer.
+	IPPoolMigrations() IPPoolMigrationInformer
 	// IPReservations returns a IPReservationInformer.
 	IPReservations() IPReservationInformer
 	// KubeControllersConfigurations returns a KubeControllersConfigurationInformer.
@@ -44,8 +50,12 @@ type Interface interface {
 	NetworkSets() NetworkSetInformer
 	// Profiles returns a ProfileInformer.
 	Profiles() ProfileInformer
+	// RoutePolicies returns a RoutePolicyInformer.
+	RoutePolicies() RoutePolicyInformer
 	// Tiers returns a TierInformer.
 	Tiers() TierInformer
+	// WorkloadEndpoints returns a WorkloadEndpointInformer.
+	WorkloadEndpoints() WorkloadEndpointInformer
 }
 
 type version struct {
@@ -109,16 +119,31 @@ func (v *version) HostEndpoints() HostEndpointInformer {
 	return &hostEndpointInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// IPAMBlocks returns a IPAMBlockInformer.
+func (v *version) IPAMBlocks() IPAMBlockInformer {
+	return &iPAMBlockInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IPAMConfigurations returns a IPAMConfigurationInformer.
 func (v *version) IPAMConfigurations() IPAMConfigurationInformer {
 	return &iPAMConfigurationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// IPAMHandles returns a IPAMHandleInformer.
+func (v *version) IPAMHandles() IPAMHandleInformer {
+	return &iPAMHandleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IPPools returns a IPPoolInformer.
 func (v *version) IPPools() IPPoolInformer {
 	return &iPPoolInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// IPPoolMigrations returns a IPPoolMigrationInformer.
+func (v *version) IPPoolMigrations() IPPoolMigrationInformer {
+	return &iPPoolMigrationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IPReservations returns a IPReservationInformer.
 func (v *version) IPReservations() IPReservationInformer {
 	return &iPReservationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -144,7 +169,17 @@ func (v *version) Profiles() ProfileInformer {
 	return &profileInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// RoutePolicies returns a RoutePolicyInformer.
+func (v *version) RoutePolicies() RoutePolicyInformer {
+	return &routePolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Tiers returns a TierInformer.
 func (v *version) Tiers() TierInformer {
 	return &tierInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// WorkloadEndpoints returns a WorkloadEndpointInformer.
+func (v *version) WorkloadEndpoints() WorkloadEndpointInformer {
+	return &workloadEndpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
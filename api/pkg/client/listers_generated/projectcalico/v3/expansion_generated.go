@@ -44,14 +44,26 @@ type GlobalNetworkSetListerExpansion interface{}
 // HostEndpointLister.
 type HostEndpointListerExpansion interface{}
 
+// IPAMBlockListerExpansion allows custom methods to be added to
+// IPAMBlockLister.
+type IPAMBlockListerExpansion interface{}
+
 // IPAMConfigurationListerExpansion allows custom methods to be added to
 // IPAMConfigurationLister.
 type IPAMConfigurationListerExpansion interface{}
 
+// IPAMHandleListerExpansion allows custom methods to be added to
+// IPAMHandleLister.
+type IPAMHandleListerExpansion interface{}
+
 // IPPoolListerExpansion allows custom methods to be added to
 // IPPoolLister.
 type IPPoolListerExpansion interface{}
 
+// IPPoolMigrationListerExpansion allows custom methods to be added to
+// IPPoolMigrationLister.
+type IPPoolMigrationListerExpansion interface{}
+
 // IPReservationListerExpansion allows custom methods to be added to
 // IPReservationLister.
 type IPReservationListerExpansion interface{}
@@ -80,6 +92,18 @@ type NetworkSetNamespaceListerExpansion interface{}
 // ProfileLister.
 type ProfileListerExpansion interface{}
 
+// RoutePolicyListerExpansion allows custom methods to be added to
+// RoutePolicyLister.
+type RoutePolicyListerExpansion interface{}
+
 // TierListerExpansion allows custom methods to be added to
 // TierLister.
 type TierListerExpansion interface{}
+
+// WorkloadEndpointListerExpansion allows custom methods to be added to
+// WorkloadEndpointLister.
+type WorkloadEndpointListerExpansion interface{}
+
+// WorkloadEndpointNamespaceListerExpansion allows custom methods to be added to
+// WorkloadEndpointNamespaceLister.
+type WorkloadEndpointNamespaceListerExpansion interface{}
@@ -0,0 +1,138 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectcalico
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubernetes/pkg/printers"
+
+	calico "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+)
+
+func IPAMBlockAddHandlers(h printers.PrintHandler) {
+	ipamBlockColumnDefinitions := []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string", Format: "name", Description: metav1.ObjectMeta{}.SwaggerDoc()["name"]},
+		{Name: "CIDR", Type: "string", Description: "The CIDR of the block."},
+		{Name: "Node", Type: "string", Description: "The node that the block is affine to, if any."},
+		{Name: "Allocated", Type: "string", Description: "The number of allocated addresses in the block, out of the total."},
+		{Name: "Age", Type: "string", Description: metav1.ObjectMeta{}.SwaggerDoc()["creationTimestamp"]},
+		{Name: "Handles", Type: "integer", Priority: 1, Description: "The number of distinct handles with allocations in the block."},
+	}
+	_ = h.TableHandler(ipamBlockColumnDefinitions, printIPAMBlockList)
+	_ = h.TableHandler(ipamBlockColumnDefinitions, printIPAMBlock)
+}
+
+func printIPAMBlockList(list *calico.IPAMBlockList, options printers.GenerateOptions) ([]metav1.TableRow, error) {
+	rows := make([]metav1.TableRow, 0, len(list.Items))
+	for i := range list.Items {
+		r, err := printIPAMBlock(&list.Items[i], options)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r...)
+	}
+	return rows, nil
+}
+
+func printIPAMBlock(block *calico.IPAMBlock, options printers.GenerateOptions) ([]metav1.TableRow, error) {
+	row := metav1.TableRow{
+		Object: runtime.RawExtension{Object: block},
+	}
+
+	var node string
+	if block.Spec.Affinity != nil {
+		node = strings.TrimPrefix(*block.Spec.Affinity, "host:")
+	}
+
+	allocated := 0
+	handles := map[string]bool{}
+	for _, a := range block.Spec.Allocations {
+		if a == nil {
+			continue
+		}
+		allocated++
+		if *a < len(block.Spec.Attributes) && block.Spec.Attributes[*a].AttrPrimary != nil {
+			handles[*block.Spec.Attributes[*a].AttrPrimary] = true
+		}
+	}
+
+	row.Cells = append(row.Cells,
+		block.Name,
+		block.Spec.CIDR,
+		node,
+		fmt.Sprintf("%d/%d", allocated, len(block.Spec.Allocations)),
+		translateTimestampSince(block.CreationTimestamp))
+
+	if options.Wide {
+		row.Cells = append(row.Cells, int64(len(handles)))
+	}
+
+	return []metav1.TableRow{row}, nil
+}
+
+func IPAMHandleAddHandlers(h printers.PrintHandler) {
+	ipamHandleColumnDefinitions := []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string", Format: "name", Description: metav1.ObjectMeta{}.SwaggerDoc()["name"]},
+		{Name: "Handle ID", Type: "string", Description: "The ID of the handle."},
+		{Name: "Addresses", Type: "integer", Description: "The number of addresses allocated to the handle."},
+		{Name: "Age", Type: "string", Description: metav1.ObjectMeta{}.SwaggerDoc()["creationTimestamp"]},
+		{Name: "Blocks", Type: "string", Priority: 1, Description: "The blocks that the handle has allocations in."},
+	}
+	_ = h.TableHandler(ipamHandleColumnDefinitions, printIPAMHandleList)
+	_ = h.TableHandler(ipamHandleColumnDefinitions, printIPAMHandle)
+}
+
+func printIPAMHandleList(list *calico.IPAMHandleList, options printers.GenerateOptions) ([]metav1.TableRow, error) {
+	rows := make([]metav1.TableRow, 0, len(list.Items))
+	for i := range list.Items {
+		r, err := printIPAMHandle(&list.Items[i], options)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r...)
+	}
+	return rows, nil
+}
+
+func printIPAMHandle(handle *calico.IPAMHandle, options printers.GenerateOptions) ([]metav1.TableRow, error) {
+	row := metav1.TableRow{
+		Object: runtime.RawExtension{Object: handle},
+	}
+
+	var addresses int64
+	blocks := make([]string, 0, len(handle.Spec.Block))
+	for block, n := range handle.Spec.Block {
+		addresses += int64(n)
+		blocks = append(blocks, block)
+	}
+
+	row.Cells = append(row.Cells,
+		handle.Name,
+		handle.Spec.HandleID,
+		addresses,
+		translateTimestampSince(handle.CreationTimestamp))
+
+	if options.Wide {
+		sort.Strings(blocks)
+		row.Cells = append(row.Cells, strings.Join(blocks, ","))
+	}
+
+	return []metav1.TableRow{row}, nil
+}
@@ -181,3 +181,108 @@ func TestPrintCalicoNodeStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestPrintWorkloadEndpoint(t *testing.T) {
+	past := time.Now().Add(-3 * time.Minute)
+	wep := calico.WorkloadEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "node0-k8s-pod1-eth0",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(past),
+		},
+		Spec: calico.WorkloadEndpointSpec{
+			Node:          "node0",
+			Pod:           "pod1",
+			Endpoint:      "eth0",
+			InterfaceName: "cali1234567890a",
+			MAC:           "01:02:03:04:05:06",
+			IPNetworks:    []string{"10.0.0.1/32", "fd00::1/128"},
+			Profiles:      []string{"kns.default", "ksa.default.default"},
+		},
+	}
+
+	table := []struct {
+		option   printers.GenerateOptions
+		expected []metav1.TableRow
+	}{
+		{
+			option: printers.GenerateOptions{},
+			expected: []metav1.TableRow{{Cells: []interface{}{"node0-k8s-pod1-eth0",
+				"node0", "pod1", "cali1234567890a", "10.0.0.1/32,fd00::1/128", "3m"}}},
+		},
+		{
+			option: printers.GenerateOptions{Wide: true},
+			expected: []metav1.TableRow{{Cells: []interface{}{"node0-k8s-pod1-eth0",
+				"node0", "pod1", "cali1234567890a", "10.0.0.1/32,fd00::1/128", "3m",
+				"eth0", "01:02:03:04:05:06", "kns.default,ksa.default.default",
+			}}},
+		},
+	}
+
+	for i, test := range table {
+		rows, err := printWorkloadEndpoint(&wep, test.option)
+		if err != nil {
+			t.Fatalf("An error occurred generating table rows for WorkloadEndpoint: %#v", err)
+		}
+		for i := range rows {
+			rows[i].Object.Object = nil
+		}
+		if !reflect.DeepEqual(test.expected, rows) {
+			t.Errorf("%d mismatch: %s", i, diff.ObjectReflectDiff(test.expected, rows))
+		}
+	}
+}
+
+func TestPrintIPAMBlockAndHandle(t *testing.T) {
+	past := time.Now().Add(-3 * time.Minute)
+	affinity := "host:node0"
+	handleA := "k8s-pod-network.a"
+	handleB := "k8s-pod-network.b"
+	zero, one := 0, 1
+	block := calico.IPAMBlock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "10-0-0-0-30",
+			CreationTimestamp: metav1.NewTime(past),
+		},
+		Spec: calico.IPAMBlockSpec{
+			CIDR:        "10.0.0.0/30",
+			Affinity:    &affinity,
+			Allocations: []*int{&zero, nil, &one, &one},
+			Unallocated: []int{1},
+			Attributes: []calico.AllocationAttribute{
+				{AttrPrimary: &handleA},
+				{AttrPrimary: &handleB},
+			},
+		},
+	}
+	handle := calico.IPAMHandle{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              handleB,
+			CreationTimestamp: metav1.NewTime(past),
+		},
+		Spec: calico.IPAMHandleSpec{
+			HandleID: handleB,
+			Block:    map[string]int{"10.0.0.0/30": 2, "10.0.1.0/30": 1},
+		},
+	}
+
+	rows, err := printIPAMBlock(&block, printers.GenerateOptions{Wide: true})
+	if err != nil {
+		t.Fatalf("An error occurred generating table rows for IPAMBlock: %#v", err)
+	}
+	rows[0].Object.Object = nil
+	expected := []metav1.TableRow{{Cells: []interface{}{"10-0-0-0-30", "10.0.0.0/30", "node0", "3/4", "3m", int64(2)}}}
+	if !reflect.DeepEqual(expected, rows) {
+		t.Errorf("IPAMBlock mismatch: %s", diff.ObjectReflectDiff(expected, rows))
+	}
+
+	rows, err = printIPAMHandle(&handle, printers.GenerateOptions{Wide: true})
+	if err != nil {
+		t.Fatalf("An error occurred generating table rows for IPAMHandle: %#v", err)
+	}
+	rows[0].Object.Object = nil
+	expected = []metav1.TableRow{{Cells: []interface{}{handleB, handleB, int64(3), "3m", "10.0.0.0/30,10.0.1.0/30"}}}
+	if !reflect.DeepEqual(expected, rows) {
+		t.Errorf("IPAMHandle mismatch: %s", diff.ObjectReflectDiff(expected, rows))
+	}
+}
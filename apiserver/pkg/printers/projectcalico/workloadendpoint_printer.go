@@ -0,0 +1,75 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectcalico
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubernetes/pkg/printers"
+
+	calico "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+)
+
+func WorkloadEndpointAddHandlers(h printers.PrintHandler) {
+	workloadEndpointColumnDefinitions := []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string", Format: "name", Description: metav1.ObjectMeta{}.SwaggerDoc()["name"]},
+		{Name: "Node", Type: "string", Description: "The node hosting the workload endpoint."},
+		{Name: "Pod", Type: "string", Description: "The pod that the workload endpoint belongs to."},
+		{Name: "Interface", Type: "string", Description: "The name of the host side interface for the workload endpoint."},
+		{Name: "Networks", Type: "string", Description: "The IP networks assigned to the workload endpoint."},
+		{Name: "Age", Type: "string", Description: metav1.ObjectMeta{}.SwaggerDoc()["creationTimestamp"]},
+		{Name: "Endpoint", Type: "string", Priority: 1, Description: "The name of the interface within the workload."},
+		{Name: "MAC", Type: "string", Priority: 1, Description: "The MAC address of the workload endpoint interface."},
+		{Name: "Profiles", Type: "string", Priority: 1, Description: "The profiles that apply to the workload endpoint."},
+	}
+	_ = h.TableHandler(workloadEndpointColumnDefinitions, printWorkloadEndpointList)
+	_ = h.TableHandler(workloadEndpointColumnDefinitions, printWorkloadEndpoint)
+}
+
+func printWorkloadEndpointList(list *calico.WorkloadEndpointList, options printers.GenerateOptions) ([]metav1.TableRow, error) {
+	rows := make([]metav1.TableRow, 0, len(list.Items))
+	for i := range list.Items {
+		r, err := printWorkloadEndpoint(&list.Items[i], options)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r...)
+	}
+	return rows, nil
+}
+
+func printWorkloadEndpoint(wep *calico.WorkloadEndpoint, options printers.GenerateOptions) ([]metav1.TableRow, error) {
+	row := metav1.TableRow{
+		Object: runtime.RawExtension{Object: wep},
+	}
+	row.Cells = append(row.Cells,
+		wep.Name,
+		wep.Spec.Node,
+		wep.Spec.Pod,
+		wep.Spec.InterfaceName,
+		strings.Join(wep.Spec.IPNetworks, ","),
+		translateTimestampSince(wep.CreationTimestamp))
+
+	if options.Wide {
+		row.Cells = append(row.Cells,
+			wep.Spec.Endpoint,
+			wep.Spec.MAC,
+			strings.Join(wep.Spec.Profiles, ","))
+	}
+
+	return []metav1.TableRow{row}, nil
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorizationreview
+
+import (
+	"context"
+	"fmt"
+
+	calico "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+	k8sauth "k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+
+	"github.com/projectcalico/calico/apiserver/pkg/rbac"
+)
+
+// REST implements a create-only RESTStorage for AuthorizationReviews. Nothing is persisted; the
+// review is calculated by the RBAC calculator and returned in the status of the created object.
+type REST struct {
+	calculator rbac.Calculator
+	authorizer k8sauth.Authorizer
+}
+
+var (
+	_ rest.Creater              = &REST{}
+	_ rest.Scoper               = &REST{}
+	_ rest.Storage              = &REST{}
+	_ rest.SingularNameProvider = &REST{}
+)
+
+// NewREST returns a RESTStorage object that will work against AuthorizationReviews. The authorizer
+// is used to check whether the requesting user may impersonate the user specified in the review;
+// without one, all impersonation is denied.
+func NewREST(calculator rbac.Calculator, authorizer k8sauth.Authorizer) *REST {
+	return &REST{calculator: calculator, authorizer: authorizer}
+}
+
+func (r *REST) New() runtime.Object {
+	return &calico.AuthorizationReview{}
+}
+
+func (r *REST) Destroy() {
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+func (r *REST) GetSingularName() string {
+	return "authorizationreview"
+}
+
+func (r *REST) Create(
+	ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions,
+) (runtime.Object, error) {
+	review, ok := obj.(*calico.AuthorizationReview)
+	if !ok {
+		return nil, errors.NewBadRequest(fmt.Sprintf("not an AuthorizationReview: %#v", obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	requester, ok := genericapirequest.UserFrom(ctx)
+	if !ok {
+		return nil, errors.NewBadRequest("no user present on request")
+	}
+
+	// Perform the review for the requesting user, unless the spec requests a different user.
+	reviewUser := requester
+	if review.Spec.User != "" {
+		if err := r.authorizeImpersonation(ctx, requester, review.Spec); err != nil {
+			return nil, err
+		}
+		reviewUser = &user.DefaultInfo{
+			Name:   review.Spec.User,
+			UID:    review.Spec.UID,
+			Groups: review.Spec.Groups,
+			Extra:  review.Spec.Extra,
+		}
+	} else if review.Spec.UID != "" || len(review.Spec.Groups) > 0 || len(review.Spec.Extra) > 0 {
+		return nil, errors.NewBadRequest("user must be specified when specifying uid, groups or extra")
+	}
+
+	rvs := resourceVerbsFromSpec(review.Spec)
+	permissions, err := r.calculator.CalculatePermissions(reviewUser, rvs)
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+
+	result := review.DeepCopy()
+	result.Status = statusFromPermissions(rvs, permissions)
+	return result, nil
+}
+
+// authorizeImpersonation checks that the requesting user is allowed to impersonate the user, groups and
+// extra information in the review spec. This uses the same checks as the Kubernetes impersonation filter.
+func (r *REST) authorizeImpersonation(ctx context.Context, requester user.Info, spec calico.AuthorizationReviewSpec) error {
+	if r.authorizer == nil {
+		klog.Error("No authorizer - deny impersonation")
+		return errors.NewForbidden(
+			schema.GroupResource{Resource: "users"}, spec.User, fmt.Errorf("unable to authorize impersonation"),
+		)
+	}
+
+	attrs := []k8sauth.AttributesRecord{{
+		User: requester, Verb: "impersonate", Resource: "users", Name: spec.User, ResourceRequest: true,
+	}}
+	if spec.UID != "" {
+		attrs = append(attrs, k8sauth.AttributesRecord{
+			User: requester, Verb: "impersonate", APIGroup: "authentication.k8s.io", Resource: "uids",
+			Name: spec.UID, ResourceRequest: true,
+		})
+	}
+	for _, group := range spec.Groups {
+		attrs = append(attrs, k8sauth.AttributesRecord{
+			User: requester, Verb: "impersonate", Resource: "groups", Name: group, ResourceRequest: true,
+		})
+	}
+	for key, values := range spec.Extra {
+		for _, value := range values {
+			attrs = append(attrs, k8sauth.AttributesRecord{
+				User: requester, Verb: "impersonate", APIGroup: "authentication.k8s.io", Resource: "userextras",
+				Subresource: key, Name: value, ResourceRequest: true,
+			})
+		}
+	}
+
+	for _, a := range attrs {
+		decision, reason, err := r.authorizer.Authorize(ctx, a)
+		if err != nil {
+			klog.Errorf("Unable to authorize impersonation: %s", err)
+		}
+		if decision != k8sauth.DecisionAllow {
+			return errors.NewForbidden(
+				schema.GroupResource{Group: a.APIGroup, Resource: a.Resource}, a.Name, fmt.Errorf("user cannot impersonate %s: %s", a.Resource, reason),
+			)
+		}
+	}
+	return nil
+}
+
+// resourceVerbsFromSpec expands the resource attributes in the spec into the resource verbs required by the
+// RBAC calculator. Resource types are de-duplicated, preserving the order in which they were first requested.
+func resourceVerbsFromSpec(spec calico.AuthorizationReviewSpec) []rbac.ResourceVerbs {
+	var rvs []rbac.ResourceVerbs
+	indices := map[rbac.ResourceType]int{}
+	for _, ra := range spec.ResourceAttributes {
+		for _, resource := range ra.Resources {
+			rt := rbac.ResourceType{APIGroup: ra.APIGroup, Resource: resource}
+			idx, ok := indices[rt]
+			if !ok {
+				idx = len(rvs)
+				indices[rt] = idx
+				rvs = append(rvs, rbac.ResourceVerbs{ResourceType: rt})
+			}
+			for _, verb := range ra.Verbs {
+				if !containsVerb(rvs[idx].Verbs, rbac.Verb(verb)) {
+					rvs[idx].Verbs = append(rvs[idx].Verbs, rbac.Verb(verb))
+				}
+			}
+		}
+	}
+	return rvs
+}
+
+// statusFromPermissions converts the calculated permissions into the review status, ordered as per the request.
+// Verbs that are not authorized for any namespace or tier are omitted.
+func statusFromPermissions(rvs []rbac.ResourceVerbs, permissions rbac.Permissions) calico.AuthorizationReviewStatus {
+	var status calico.AuthorizationReviewStatus
+	for _, rv := range rvs {
+		arv := calico.AuthorizedResourceVerbs{
+			APIGroup: rv.ResourceType.APIGroup,
+			Resource: rv.ResourceType.Resource,
+		}
+		for _, verb := range rv.Verbs {
+			matches := permissions[rv.ResourceType][verb]
+			if len(matches) == 0 {
+				continue
+			}
+			av := calico.AuthorizedResourceVerb{Verb: string(verb)}
+			for _, m := range matches {
+				av.ResourceGroups = append(av.ResourceGroups, calico.AuthorizedResourceGroup{
+					Tier:      m.Tier,
+					Namespace: m.Namespace,
+				})
+			}
+			arv.Verbs = append(arv.Verbs, av)
+		}
+		status.AuthorizedResourceVerbs = append(status.AuthorizedResourceVerbs, arv)
+	}
+	return status
+}
+
+func containsVerb(verbs []rbac.Verb, verb rbac.Verb) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
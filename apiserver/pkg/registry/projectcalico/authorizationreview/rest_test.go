@@ -0,0 +1,152 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+package authorizationreview_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	calico "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/authentication/user"
+	k8sauth "k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/projectcalico/calico/apiserver/pkg/rbac"
+	"github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/authorizationreview"
+)
+
+type testCalculator struct {
+	user        user.Info
+	rvs         []rbac.ResourceVerbs
+	permissions rbac.Permissions
+}
+
+func (c *testCalculator) CalculatePermissions(user user.Info, rvs []rbac.ResourceVerbs) (rbac.Permissions, error) {
+	c.user = user
+	c.rvs = rvs
+	return c.permissions, nil
+}
+
+type testAuth struct {
+	allowed map[string]bool
+}
+
+func (t *testAuth) Authorize(ctx context.Context, a k8sauth.Attributes) (k8sauth.Decision, string, error) {
+	if a.GetVerb() == "impersonate" && t.allowed[a.GetResource()+"/"+a.GetName()] {
+		return k8sauth.DecisionAllow, "", nil
+	}
+	return k8sauth.DecisionDeny, "denied", nil
+}
+
+var (
+	testUser = &user.DefaultInfo{Name: "testuser", Groups: []string{"group1"}}
+
+	resourcePolicies = rbac.ResourceType{APIGroup: "projectcalico.org", Resource: "networkpolicies"}
+	resourceTiers    = rbac.ResourceType{APIGroup: "projectcalico.org", Resource: "tiers"}
+)
+
+func TestAuthorizationReviewForRequestingUser(t *testing.T) {
+	calc := &testCalculator{
+		permissions: rbac.Permissions{
+			resourcePolicies: {
+				rbac.VerbGet:    {{Tier: "default", Namespace: "ns1"}, {Tier: "default", Namespace: "ns2"}},
+				rbac.VerbDelete: nil,
+			},
+			resourceTiers: {
+				rbac.VerbGet: {{Tier: "default"}},
+			},
+		},
+	}
+	r := authorizationreview.NewREST(calc, &testAuth{})
+
+	review := &calico.AuthorizationReview{
+		Spec: calico.AuthorizationReviewSpec{
+			ResourceAttributes: []calico.AuthorizationReviewResourceAttributes{
+				{APIGroup: "projectcalico.org", Resources: []string{"networkpolicies", "tiers"}, Verbs: []string{"get"}},
+				{APIGroup: "projectcalico.org", Resources: []string{"networkpolicies"}, Verbs: []string{"get", "delete"}},
+			},
+		},
+	}
+	ctx := genericapirequest.WithUser(context.Background(), testUser)
+	obj, err := r.Create(ctx, review, nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if calc.user != testUser {
+		t.Errorf("Expected review for requesting user, got %v", calc.user)
+	}
+	expectedRVs := []rbac.ResourceVerbs{
+		{ResourceType: resourcePolicies, Verbs: []rbac.Verb{rbac.VerbGet, rbac.VerbDelete}},
+		{ResourceType: resourceTiers, Verbs: []rbac.Verb{rbac.VerbGet}},
+	}
+	if !reflect.DeepEqual(calc.rvs, expectedRVs) {
+		t.Errorf("Unexpected resource verbs: %v", calc.rvs)
+	}
+
+	expected := calico.AuthorizationReviewStatus{
+		AuthorizedResourceVerbs: []calico.AuthorizedResourceVerbs{
+			{
+				APIGroup: "projectcalico.org",
+				Resource: "networkpolicies",
+				Verbs: []calico.AuthorizedResourceVerb{{
+					Verb: "get",
+					ResourceGroups: []calico.AuthorizedResourceGroup{
+						{Tier: "default", Namespace: "ns1"}, {Tier: "default", Namespace: "ns2"},
+					},
+				}},
+			},
+			{
+				APIGroup: "projectcalico.org",
+				Resource: "tiers",
+				Verbs: []calico.AuthorizedResourceVerb{{
+					Verb:           "get",
+					ResourceGroups: []calico.AuthorizedResourceGroup{{Tier: "default"}},
+				}},
+			},
+		},
+	}
+	if status := obj.(*calico.AuthorizationReview).Status; !reflect.DeepEqual(status, expected) {
+		t.Errorf("Unexpected status: %#v", status)
+	}
+}
+
+func TestAuthorizationReviewImpersonation(t *testing.T) {
+	calc := &testCalculator{permissions: rbac.Permissions{}}
+	auth := &testAuth{allowed: map[string]bool{"users/other": true}}
+	r := authorizationreview.NewREST(calc, auth)
+	ctx := genericapirequest.WithUser(context.Background(), testUser)
+
+	review := &calico.AuthorizationReview{Spec: calico.AuthorizationReviewSpec{User: "other"}}
+	if _, err := r.Create(ctx, review, nil, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calc.user.GetName() != "other" {
+		t.Errorf("Expected review for impersonated user, got %v", calc.user)
+	}
+
+	// The requesting user is not allowed to impersonate group2.
+	review.Spec.Groups = []string{"group2"}
+	if _, err := r.Create(ctx, review, nil, nil); !errors.IsForbidden(err) {
+		t.Errorf("Expected forbidden error, got %v", err)
+	}
+
+	// Groups may not be specified without a user.
+	review.Spec.User = ""
+	if _, err := r.Create(ctx, review, nil, nil); !errors.IsBadRequest(err) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+}
+
+func TestAuthorizationReviewImpersonationWithoutAuthorizer(t *testing.T) {
+	calc := &testCalculator{permissions: rbac.Permissions{}}
+	r := authorizationreview.NewREST(calc, nil)
+	ctx := genericapirequest.WithUser(context.Background(), testUser)
+
+	review := &calico.AuthorizationReview{Spec: calico.AuthorizationReviewSpec{User: "other"}}
+	if _, err := r.Create(ctx, review, nil, nil); !errors.IsForbidden(err) {
+		t.Errorf("Expected forbidden error, got %v", err)
+	}
+}
@@ -45,10 +45,12 @@ func (policyStrategy) NamespaceScoped() bool {
 
 func (policyStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
 	obj.(*calico.GlobalNetworkPolicy).Name = canonicalizePolicyName(obj)
+	obj.(*calico.GlobalNetworkPolicy).Status = calico.PolicyStatus{}
 }
 
 func (policyStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
 	obj.(*calico.GlobalNetworkPolicy).Name = canonicalizePolicyName(old)
+	obj.(*calico.GlobalNetworkPolicy).Status = old.(*calico.GlobalNetworkPolicy).Status
 }
 
 func canonicalizePolicyName(obj runtime.Object) string {
@@ -105,6 +107,23 @@ func (policyStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Objec
 	// return validation.ValidatePolicyUpdate(obj.(*calico.Policy), old.(*calico.Policy))
 }
 
+type policyStatusStrategy struct {
+	policyStrategy
+}
+
+// NewStatusStrategy returns a strategy for updating the status of a policy.
+func NewStatusStrategy(strategy policyStrategy) policyStatusStrategy {
+	return policyStatusStrategy{strategy}
+}
+
+func (policyStatusStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	newPolicy := obj.(*calico.GlobalNetworkPolicy)
+	oldPolicy := old.(*calico.GlobalNetworkPolicy)
+	newPolicy.Name = oldPolicy.Name
+	newPolicy.Spec = oldPolicy.Spec
+	newPolicy.Labels = oldPolicy.Labels
+}
+
 func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, error) {
 	policy, ok := obj.(*calico.GlobalNetworkPolicy)
 	if !ok {
@@ -56,8 +56,43 @@ func NewList() runtime.Object {
 	}
 }
 
+// StatusREST implements the REST endpoint for updating the status of a policy.
+type StatusREST struct {
+	store      *genericregistry.Store
+	authorizer authorizer.TierAuthorizer
+}
+
+func (r *StatusREST) New() runtime.Object {
+	return &calico.NetworkPolicy{}
+}
+
+func (r *StatusREST) Destroy() {
+	r.store.Destroy()
+}
+
+func (r *StatusREST) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	tierName, _ := util.GetTierFromPolicyName(name)
+	err := r.authorizer.AuthorizeTierOperation(ctx, name, tierName)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.store.Get(ctx, name, options)
+}
+
+func (r *StatusREST) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc,
+	updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	tierName, _ := util.GetTierFromPolicyName(name)
+	err := r.authorizer.AuthorizeTierOperation(ctx, name, tierName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return r.store.Update(ctx, name, objInfo, createValidation, updateValidation, forceAllowCreate, options)
+}
+
 // NewREST returns a RESTStorage object that will work against API services.
-func NewREST(scheme *runtime.Scheme, opts server.Options, calicoResourceLister rbac.CalicoResourceLister) (*REST, error) {
+func NewREST(scheme *runtime.Scheme, opts server.Options, calicoResourceLister rbac.CalicoResourceLister) (*REST, *StatusREST, error) {
 	strategy := NewStrategy(scheme)
 
 	prefix := "/" + opts.ResourcePrefix()
@@ -81,7 +116,7 @@ func NewREST(scheme *runtime.Scheme, opts server.Options, calicoResourceLister r
 		nil,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	store := &genericregistry.Store{
 		NewFunc:     func() runtime.Object { return &calico.NetworkPolicy{} },
@@ -103,7 +138,11 @@ func NewREST(scheme *runtime.Scheme, opts server.Options, calicoResourceLister r
 		DestroyFunc: dFunc,
 	}
 
-	return &REST{store, calicoResourceLister, authorizer.NewTierAuthorizer(opts.Authorizer), []string{}}, nil
+	statusStore := *store
+	statusStore.UpdateStrategy = NewStatusStrategy(strategy)
+
+	tierAuthorizer := authorizer.NewTierAuthorizer(opts.Authorizer)
+	return &REST{store, calicoResourceLister, tierAuthorizer, []string{}}, &StatusREST{&statusStore, tierAuthorizer}, nil
 }
 
 func (r *REST) List(ctx context.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
@@ -45,10 +45,12 @@ func (policyStrategy) NamespaceScoped() bool {
 
 func (policyStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
 	obj.(*calico.NetworkPolicy).Name = canonicalizePolicyName(obj)
+	obj.(*calico.NetworkPolicy).Status = calico.PolicyStatus{}
 }
 
 func (policyStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
 	obj.(*calico.NetworkPolicy).Name = canonicalizePolicyName(old)
+	obj.(*calico.NetworkPolicy).Status = old.(*calico.NetworkPolicy).Status
 }
 
 func canonicalizePolicyName(obj runtime.Object) string {
@@ -103,6 +105,23 @@ func (policyStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Objec
 	// return validation.ValidatePolicyUpdate(obj.(*calico.Policy), old.(*calico.Policy))
 }
 
+type policyStatusStrategy struct {
+	policyStrategy
+}
+
+// NewStatusStrategy returns a strategy for updating the status of a policy.
+func NewStatusStrategy(strategy policyStrategy) policyStatusStrategy {
+	return policyStatusStrategy{strategy}
+}
+
+func (policyStatusStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	newPolicy := obj.(*calico.NetworkPolicy)
+	oldPolicy := old.(*calico.NetworkPolicy)
+	newPolicy.Name = oldPolicy.Name
+	newPolicy.Spec = oldPolicy.Spec
+	newPolicy.Labels = oldPolicy.Labels
+}
+
 func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, error) {
 	policy, ok := obj.(*calico.NetworkPolicy)
 	if !ok {
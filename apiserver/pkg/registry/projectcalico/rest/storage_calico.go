@@ -25,6 +25,7 @@ import (
 	calico "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
 
 	"github.com/projectcalico/calico/apiserver/pkg/rbac"
+	calicoauthorizationreview "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/authorizationreview"
 	calicobgpconfiguration "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/bgpconfiguration"
 	calicobgpfilter "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/bgpfilter"
 	calicobgppeer "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/bgppeer"
@@ -35,15 +36,20 @@ import (
 	calicognetworkset "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/globalnetworkset"
 	calicogpolicy "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/globalpolicy"
 	calicohostendpoint "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/hostendpoint"
+	calicoipamblock "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/ipamblock"
 	calicoipamconfig "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/ipamconfig"
+	calicoipamhandle "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/ipamhandle"
 	calicoippool "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/ippool"
 	calicoipreservation "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/ipreservation"
 	calicokubecontrollersconfig "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/kubecontrollersconfig"
 	calicopolicy "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/networkpolicy"
+	calicoippoolmigration "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/ippoolmigration"
 	caliconetworkset "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/networkset"
 	calicoprofile "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/profile"
+	calicoroutepolicy "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/routepolicy"
 	"github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/server"
 	calicotier "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/tier"
+	calicoworkloadendpoint "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/workloadendpoint"
 	calicostorage "github.com/projectcalico/calico/apiserver/pkg/storage/calico"
 	"github.com/projectcalico/calico/apiserver/pkg/storage/etcd"
 )
@@ -60,6 +66,7 @@ func (p RESTStorageProvider) NewV3Storage(
 	restOptionsGetter generic.RESTOptionsGetter,
 	authorizer authorizer.Authorizer,
 	calicoLister rbac.CalicoResourceLister,
+	calculator rbac.Calculator,
 ) (map[string]rest.Storage, error) {
 	policyRESTOptions, err := restOptionsGetter.GetRESTOptions(calico.Resource("networkpolicies"))
 	if err != nil {
@@ -303,6 +310,28 @@ func (p RESTStorageProvider) NewV3Storage(
 		[]string{},
 	)
 
+	routePolicyRESTOptions, err := restOptionsGetter.GetRESTOptions(calico.Resource("routepolicies"))
+	if err != nil {
+		return nil, err
+	}
+	routePolicyOpts := server.NewOptions(
+		etcd.Options{
+			RESTOptions:   routePolicyRESTOptions,
+			Capacity:      1000,
+			ObjectType:    calicoroutepolicy.EmptyObject(),
+			ScopeStrategy: calicoroutepolicy.NewStrategy(scheme),
+			NewListFunc:   calicoroutepolicy.NewList,
+			GetAttrsFunc:  calicoroutepolicy.GetAttrs,
+			Trigger:       nil,
+		},
+		calicostorage.Options{
+			RESTOptions: routePolicyRESTOptions,
+		},
+		p.StorageType,
+		authorizer,
+		[]string{},
+	)
+
 	profileRESTOptions, err := restOptionsGetter.GetRESTOptions(calico.Resource("profiles"))
 	if err != nil {
 		return nil, err
@@ -369,6 +398,28 @@ func (p RESTStorageProvider) NewV3Storage(
 		[]string{"kcconfig"},
 	)
 
+	ipPoolMigrationRESTOptions, err := restOptionsGetter.GetRESTOptions(calico.Resource("ippoolmigrations"))
+	if err != nil {
+		return nil, err
+	}
+	ipPoolMigrationOpts := server.NewOptions(
+		etcd.Options{
+			RESTOptions:   ipPoolMigrationRESTOptions,
+			Capacity:      1000,
+			ObjectType:    calicoippoolmigration.EmptyObject(),
+			ScopeStrategy: calicoippoolmigration.NewStrategy(scheme),
+			NewListFunc:   calicoippoolmigration.NewList,
+			GetAttrsFunc:  calicoippoolmigration.GetAttrs,
+			Trigger:       nil,
+		},
+		calicostorage.Options{
+			RESTOptions: ipPoolMigrationRESTOptions,
+		},
+		p.StorageType,
+		authorizer,
+		[]string{},
+	)
+
 	clusterInformationRESTOptions, err := restOptionsGetter.GetRESTOptions(calico.Resource("clusterinformations"))
 	if err != nil {
 		return nil, err
@@ -457,10 +508,74 @@ func (p RESTStorageProvider) NewV3Storage(
 		[]string{"blockaffinity", "affinity", "affinities"},
 	)
 
+	workloadEndpointRESTOptions, err := restOptionsGetter.GetRESTOptions(calico.Resource("workloadendpoints"))
+	if err != nil {
+		return nil, err
+	}
+	workloadEndpointOpts := server.NewOptions(
+		etcd.Options{
+			RESTOptions:   workloadEndpointRESTOptions,
+			Capacity:      1000,
+			ObjectType:    calicoworkloadendpoint.EmptyObject(),
+			ScopeStrategy: calicoworkloadendpoint.NewStrategy(scheme),
+			NewListFunc:   calicoworkloadendpoint.NewList,
+			GetAttrsFunc:  calicoworkloadendpoint.GetAttrs,
+			Trigger:       nil,
+		},
+		calicostorage.Options{
+			RESTOptions: workloadEndpointRESTOptions,
+		},
+		p.StorageType,
+		authorizer,
+		[]string{"wep", "weps"},
+	)
+
+	ipamBlockRESTOptions, err := restOptionsGetter.GetRESTOptions(calico.Resource("ipamblocks"))
+	if err != nil {
+		return nil, err
+	}
+	ipamBlockOpts := server.NewOptions(
+		etcd.Options{
+			RESTOptions:   ipamBlockRESTOptions,
+			Capacity:      1000,
+			ObjectType:    calicoipamblock.EmptyObject(),
+			ScopeStrategy: calicoipamblock.NewStrategy(scheme),
+			NewListFunc:   calicoipamblock.NewList,
+			GetAttrsFunc:  calicoipamblock.GetAttrs,
+			Trigger:       nil,
+		},
+		calicostorage.Options{
+			RESTOptions: ipamBlockRESTOptions,
+		},
+		p.StorageType,
+		authorizer,
+		[]string{"ipamblock"},
+	)
+
+	ipamHandleRESTOptions, err := restOptionsGetter.GetRESTOptions(calico.Resource("ipamhandles"))
+	if err != nil {
+		return nil, err
+	}
+	ipamHandleOpts := server.NewOptions(
+		etcd.Options{
+			RESTOptions:   ipamHandleRESTOptions,
+			Capacity:      1000,
+			ObjectType:    calicoipamhandle.EmptyObject(),
+			ScopeStrategy: calicoipamhandle.NewStrategy(scheme),
+			NewListFunc:   calicoipamhandle.NewList,
+			GetAttrsFunc:  calicoipamhandle.GetAttrs,
+			Trigger:       nil,
+		},
+		calicostorage.Options{
+			RESTOptions: ipamHandleRESTOptions,
+		},
+		p.StorageType,
+		authorizer,
+		[]string{"ipamhandle"},
+	)
+
 	storage := map[string]rest.Storage{}
 	storage["tiers"] = rESTInPeace(calicotier.NewREST(scheme, *tierOpts))
-	storage["networkpolicies"] = rESTInPeace(calicopolicy.NewREST(scheme, *policyOpts, calicoLister))
-	storage["globalnetworkpolicies"] = rESTInPeace(calicogpolicy.NewREST(scheme, *gpolicyOpts, calicoLister))
 	storage["globalnetworksets"] = rESTInPeace(calicognetworkset.NewREST(scheme, *gNetworkSetOpts))
 	storage["networksets"] = rESTInPeace(caliconetworkset.NewREST(scheme, *networksetOpts))
 	storage["hostendpoints"] = rESTInPeace(calicohostendpoint.NewREST(scheme, *hostEndpointOpts))
@@ -469,12 +584,33 @@ func (p RESTStorageProvider) NewV3Storage(
 	storage["bgpconfigurations"] = rESTInPeace(calicobgpconfiguration.NewREST(scheme, *bgpConfigurationOpts))
 	storage["bgppeers"] = rESTInPeace(calicobgppeer.NewREST(scheme, *bgpPeerOpts))
 	storage["bgpfilters"] = rESTInPeace(calicobgpfilter.NewREST(scheme, *bgpFilterOpts))
+	storage["routepolicies"] = rESTInPeace(calicoroutepolicy.NewREST(scheme, *routePolicyOpts))
 	storage["profiles"] = rESTInPeace(calicoprofile.NewREST(scheme, *profileOpts))
 	storage["felixconfigurations"] = rESTInPeace(calicofelixconfig.NewREST(scheme, *felixConfigOpts))
 	storage["clusterinformations"] = rESTInPeace(calicoclusterinformation.NewREST(scheme, *clusterInformationOpts))
 	storage["caliconodestatuses"] = rESTInPeace(caliconodestatus.NewREST(scheme, *caliconodestatusOpts))
 	storage["ipamconfigurations"] = rESTInPeace(calicoipamconfig.NewREST(scheme, *ipamconfigOpts))
 	storage["blockaffinities"] = rESTInPeace(calicoblockaffinity.NewREST(scheme, *blockAffinityOpts))
+	storage["workloadendpoints"] = rESTInPeace(calicoworkloadendpoint.NewREST(scheme, *workloadEndpointOpts))
+	storage["ipamblocks"] = rESTInPeace(calicoipamblock.NewREST(scheme, *ipamBlockOpts))
+	storage["ipamhandles"] = rESTInPeace(calicoipamhandle.NewREST(scheme, *ipamHandleOpts))
+	storage["authorizationreviews"] = calicoauthorizationreview.NewREST(calculator, authorizer)
+
+	policyStorage, policyStatusStorage, err := calicopolicy.NewREST(scheme, *policyOpts, calicoLister)
+	if err != nil {
+		err = fmt.Errorf("unable to create REST storage for a resource due to %v, will die", err)
+		panic(err)
+	}
+	storage["networkpolicies"] = policyStorage
+	storage["networkpolicies/status"] = policyStatusStorage
+
+	gpolicyStorage, gpolicyStatusStorage, err := calicogpolicy.NewREST(scheme, *gpolicyOpts, calicoLister)
+	if err != nil {
+		err = fmt.Errorf("unable to create REST storage for a resource due to %v, will die", err)
+		panic(err)
+	}
+	storage["globalnetworkpolicies"] = gpolicyStorage
+	storage["globalnetworkpolicies/status"] = gpolicyStatusStorage
 
 	kubeControllersConfigsStorage, kubeControllersConfigsStatusStorage, err := calicokubecontrollersconfig.NewREST(scheme, *kubeControllersConfigsOpts)
 	if err != nil {
@@ -483,6 +619,14 @@ func (p RESTStorageProvider) NewV3Storage(
 	}
 	storage["kubecontrollersconfigurations"] = kubeControllersConfigsStorage
 	storage["kubecontrollersconfigurations/status"] = kubeControllersConfigsStatusStorage
+
+	ipPoolMigrationStorage, ipPoolMigrationStatusStorage, err := calicoippoolmigration.NewREST(scheme, *ipPoolMigrationOpts)
+	if err != nil {
+		err = fmt.Errorf("unable to create REST storage for a resource due to %v, will die", err)
+		panic(err)
+	}
+	storage["ippoolmigrations"] = ipPoolMigrationStorage
+	storage["ippoolmigrations/status"] = ipPoolMigrationStatusStorage
 	return storage, nil
 }
 
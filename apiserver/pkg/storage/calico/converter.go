@@ -76,6 +76,14 @@ func convertToAAPI(libcalicoObject runtime.Object) (res runtime.Object) {
 		aapi := &v3.BGPFilter{}
 		BGPFilterConverter{}.convertToAAPI(obj, aapi)
 		return aapi
+	case *v3.RoutePolicy:
+		aapi := &v3.RoutePolicy{}
+		RoutePolicyConverter{}.convertToAAPI(obj, aapi)
+		return aapi
+	case *v3.IPPoolMigration:
+		aapi := &v3.IPPoolMigration{}
+		IPPoolMigrationConverter{}.convertToAAPI(obj, aapi)
+		return aapi
 	case *v3.Profile:
 		aapi := &v3.Profile{}
 		ProfileConverter{}.convertToAAPI(obj, aapi)
@@ -106,6 +114,19 @@ func convertToAAPI(libcalicoObject runtime.Object) (res runtime.Object) {
 		aapi := &v3.BlockAffinity{}
 		BlockAffinityConverter{}.convertToAAPI(obj, aapi)
 		return aapi
+	// Likewise, workload endpoints and the IPAM resources are only available as libapi objects.
+	case *libapi.WorkloadEndpoint:
+		aapi := &v3.WorkloadEndpoint{}
+		WorkloadEndpointConverter{}.convertToAAPI(obj, aapi)
+		return aapi
+	case *libapi.IPAMBlock:
+		aapi := &v3.IPAMBlock{}
+		IPAMBlockConverter{}.convertToAAPI(obj, aapi)
+		return aapi
+	case *libapi.IPAMHandle:
+		aapi := &v3.IPAMHandle{}
+		IPAMHandleConverter{}.convertToAAPI(obj, aapi)
+		return aapi
 	default:
 		klog.Infof("Unrecognized libcalico object (type %v)", reflect.TypeOf(libcalicoObject))
 		return nil
@@ -83,6 +83,7 @@ func (gc GlobalNetworkPolicyConverter) convertToLibcalico(aapiObj runtime.Object
 	lcgGlobalNetworkPolicy.Kind = api.KindGlobalNetworkPolicy
 	lcgGlobalNetworkPolicy.APIVersion = api.GroupVersionCurrent
 	lcgGlobalNetworkPolicy.Spec = aapiGlobalNetworkPolicy.Spec
+	lcgGlobalNetworkPolicy.Status = aapiGlobalNetworkPolicy.Status
 	return lcgGlobalNetworkPolicy
 }
 
@@ -90,6 +91,7 @@ func (gc GlobalNetworkPolicyConverter) convertToAAPI(libcalicoObject resourceObj
 	lcgGlobalNetworkPolicy := libcalicoObject.(*api.GlobalNetworkPolicy)
 	aapiGlobalNetworkPolicy := aapiObj.(*aapi.GlobalNetworkPolicy)
 	aapiGlobalNetworkPolicy.Spec = lcgGlobalNetworkPolicy.Spec
+	aapiGlobalNetworkPolicy.Status = lcgGlobalNetworkPolicy.Status
 	// Default the tier field if not specified
 	if aapiGlobalNetworkPolicy.Spec.Tier == "" {
 		aapiGlobalNetworkPolicy.Spec.Tier = "default"
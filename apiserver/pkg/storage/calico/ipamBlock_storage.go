@@ -0,0 +1,191 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calico
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	aapi "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/storagebackend/factory"
+
+	libapi "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/resources"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	"github.com/projectcalico/calico/libcalico-go/lib/watch"
+)
+
+// NewIPAMBlockStorage creates a new libcalico-based storage.Interface implementation for IPAMBlocks
+func NewIPAMBlockStorage(opts Options) (registry.DryRunnableStorage, factory.DestroyFunc) {
+	c := CreateClientFromConfig()
+	createFn := func(ctx context.Context, c clientv3.Interface, obj resourceObject, opts clientOpts) (resourceObject, error) {
+		return nil, fmt.Errorf("Unable to create IPAM block. IPAM block resources are read-only.")
+	}
+	updateFn := func(ctx context.Context, c clientv3.Interface, obj resourceObject, opts clientOpts) (resourceObject, error) {
+		return nil, fmt.Errorf("Unable to update IPAM block. IPAM block resources are read-only.")
+	}
+	getFn := func(ctx context.Context, c clientv3.Interface, ns string, name string, opts clientOpts) (resourceObject, error) {
+		ogo := opts.(options.GetOptions)
+		// Block names are derived from the block CIDR, so find the block by listing.
+		list, err := listIPAMBlocks(ctx, c, ogo.ResourceVersion)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			if list.Items[i].Name == name {
+				return &list.Items[i], nil
+			}
+		}
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: name}
+	}
+	deleteFn := func(ctx context.Context, c clientv3.Interface, ns string, name string, opts clientOpts) (resourceObject, error) {
+		return nil, fmt.Errorf("Unable to delete IPAM block. IPAM block resources are read-only.")
+	}
+	listFn := func(ctx context.Context, c clientv3.Interface, opts clientOpts) (resourceListObject, error) {
+		olo := opts.(options.ListOptions)
+		list, err := listIPAMBlocks(ctx, c, olo.ResourceVersion)
+		if err != nil {
+			return nil, err
+		}
+		if olo.Name != "" {
+			items := list.Items
+			list.Items = nil
+			for _, item := range items {
+				if item.Name == olo.Name {
+					list.Items = append(list.Items, item)
+				}
+			}
+		}
+		return list, nil
+	}
+	watchFn := func(ctx context.Context, c clientv3.Interface, opts clientOpts) (watch.Interface, error) {
+		olo := opts.(options.ListOptions)
+		return newIPAMWatcher(ctx, c, model.BlockListOptions{}, olo.ResourceVersion, olo.Name, ipamBlockKVPToResource)
+	}
+	dryRunnableStorage := registry.DryRunnableStorage{Storage: &resourceStore{
+		client:            c,
+		codec:             opts.RESTOptions.StorageConfig.Codec,
+		versioner:         APIObjectVersioner{},
+		aapiType:          reflect.TypeOf(aapi.IPAMBlock{}),
+		aapiListType:      reflect.TypeOf(aapi.IPAMBlockList{}),
+		libCalicoType:     reflect.TypeOf(libapi.IPAMBlock{}),
+		libCalicoListType: reflect.TypeOf(libapi.IPAMBlockList{}),
+		isNamespaced:      false,
+		create:            createFn,
+		update:            updateFn,
+		get:               getFn,
+		delete:            deleteFn,
+		list:              listFn,
+		watch:             watchFn,
+		resourceName:      "IPAMBlock",
+		converter:         IPAMBlockConverter{},
+	}, Codec: opts.RESTOptions.StorageConfig.Codec}
+	return dryRunnableStorage, func() {}
+}
+
+// listIPAMBlocks lists the IPAM blocks from the backend and converts them to v3 resources.
+func listIPAMBlocks(ctx context.Context, c clientv3.Interface, revision string) (*libapi.IPAMBlockList, error) {
+	b, err := ipamBackend(c)
+	if err != nil {
+		return nil, err
+	}
+	kvps, err := b.List(ctx, model.BlockListOptions{}, revision)
+	if err != nil {
+		return nil, err
+	}
+	list := libapi.NewIPAMBlockList()
+	list.ResourceVersion = kvps.Revision
+	for _, kvp := range kvps.KVPairs {
+		list.Items = append(list.Items, *ipamBlockKVPToResource(kvp).(*libapi.IPAMBlock))
+	}
+	return list, nil
+}
+
+// ipamBlockKVPToResource converts an allocation block KVPair into an IPAMBlock resource.
+func ipamBlockKVPToResource(kvp *model.KVPair) resourceObject {
+	if kvp.Value == nil {
+		// Deletion events may not include the value, so fill in what we can from the key.
+		kvp = &model.KVPair{
+			Key:      kvp.Key,
+			Value:    &model.AllocationBlock{CIDR: kvp.Key.(model.BlockKey).CIDR},
+			Revision: kvp.Revision,
+		}
+	}
+	block := resources.IPAMBlockV1toV3(kvp).Value.(*libapi.IPAMBlock)
+	block.ResourceVersion = kvp.Revision
+	return block
+}
+
+type IPAMBlockConverter struct {
+}
+
+func (gc IPAMBlockConverter) convertToLibcalico(aapiObj runtime.Object) resourceObject {
+	var lcgIPAMBlock *libapi.IPAMBlock
+	// This should not be called since IPAM blocks are read-only through the AAPI.
+	log.Error("IPAM block API is read-only. Should not attempt to create/update IPAM blocks through the API.")
+	return lcgIPAMBlock
+}
+
+func (gc IPAMBlockConverter) convertToAAPI(libcalicoObject resourceObject, aapiObj runtime.Object) {
+	lcgIPAMBlock := libcalicoObject.(*libapi.IPAMBlock)
+	aapiIPAMBlock := aapiObj.(*aapi.IPAMBlock)
+	lcgSpec := lcgIPAMBlock.Spec
+	aapiIPAMBlock.Spec = aapi.IPAMBlockSpec{
+		CIDR:                        lcgSpec.CIDR,
+		Affinity:                    lcgSpec.Affinity,
+		Allocations:                 lcgSpec.Allocations,
+		Unallocated:                 lcgSpec.Unallocated,
+		SequenceNumber:              lcgSpec.SequenceNumber,
+		SequenceNumberForAllocation: lcgSpec.SequenceNumberForAllocation,
+		Deleted:                     lcgSpec.Deleted,
+	}
+	for _, attr := range lcgSpec.Attributes {
+		aapiIPAMBlock.Spec.Attributes = append(aapiIPAMBlock.Spec.Attributes, aapi.AllocationAttribute{
+			AttrPrimary:   attr.AttrPrimary,
+			AttrSecondary: attr.AttrSecondary,
+		})
+	}
+	aapiIPAMBlock.TypeMeta = lcgIPAMBlock.TypeMeta
+	aapiIPAMBlock.ObjectMeta = lcgIPAMBlock.ObjectMeta
+
+	// Make sure that the API version is correct.
+	aapiIPAMBlock.TypeMeta.APIVersion = aapi.GroupVersionCurrent
+}
+
+func (gc IPAMBlockConverter) convertToAAPIList(libcalicoListObject resourceListObject, aapiListObj runtime.Object, pred storage.SelectionPredicate) {
+	aapiIPAMBlockList := aapiListObj.(*aapi.IPAMBlockList)
+	if libcalicoListObject == nil {
+		aapiIPAMBlockList.Items = []aapi.IPAMBlock{}
+		return
+	}
+	lcgIPAMBlockList := libcalicoListObject.(*libapi.IPAMBlockList)
+	aapiIPAMBlockList.TypeMeta = lcgIPAMBlockList.TypeMeta
+	aapiIPAMBlockList.ListMeta = lcgIPAMBlockList.ListMeta
+	for _, item := range lcgIPAMBlockList.Items {
+		aapiIPAMBlock := aapi.IPAMBlock{}
+		gc.convertToAAPI(&item, &aapiIPAMBlock)
+		if matched, err := pred.Matches(&aapiIPAMBlock); err == nil && matched {
+			aapiIPAMBlockList.Items = append(aapiIPAMBlockList.Items, aapiIPAMBlock)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calico
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	aapi "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/storagebackend/factory"
+
+	libapi "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/resources"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	"github.com/projectcalico/calico/libcalico-go/lib/watch"
+)
+
+// NewIPAMHandleStorage creates a new libcalico-based storage.Interface implementation for IPAMHandles
+func NewIPAMHandleStorage(opts Options) (registry.DryRunnableStorage, factory.DestroyFunc) {
+	c := CreateClientFromConfig()
+	createFn := func(ctx context.Context, c clientv3.Interface, obj resourceObject, opts clientOpts) (resourceObject, error) {
+		return nil, fmt.Errorf("Unable to create IPAM handle. IPAM handle resources are read-only.")
+	}
+	updateFn := func(ctx context.Context, c clientv3.Interface, obj resourceObject, opts clientOpts) (resourceObject, error) {
+		return nil, fmt.Errorf("Unable to update IPAM handle. IPAM handle resources are read-only.")
+	}
+	getFn := func(ctx context.Context, c clientv3.Interface, ns string, name string, opts clientOpts) (resourceObject, error) {
+		ogo := opts.(options.GetOptions)
+		b, err := ipamBackend(c)
+		if err != nil {
+			return nil, err
+		}
+		// Handle names are the handle IDs, lower-cased when stored in the Kubernetes datastore.
+		kvp, err := b.Get(ctx, model.IPAMHandleKey{HandleID: name}, ogo.ResourceVersion)
+		if err != nil {
+			return nil, err
+		}
+		return ipamHandleKVPToResource(kvp), nil
+	}
+	deleteFn := func(ctx context.Context, c clientv3.Interface, ns string, name string, opts clientOpts) (resourceObject, error) {
+		return nil, fmt.Errorf("Unable to delete IPAM handle. IPAM handle resources are read-only.")
+	}
+	listFn := func(ctx context.Context, c clientv3.Interface, opts clientOpts) (resourceListObject, error) {
+		olo := opts.(options.ListOptions)
+		b, err := ipamBackend(c)
+		if err != nil {
+			return nil, err
+		}
+		kvps, err := b.List(ctx, model.IPAMHandleListOptions{}, olo.ResourceVersion)
+		if err != nil {
+			return nil, err
+		}
+		list := libapi.NewIPAMHandleList()
+		list.ResourceVersion = kvps.Revision
+		for _, kvp := range kvps.KVPairs {
+			handle := ipamHandleKVPToResource(kvp).(*libapi.IPAMHandle)
+			if olo.Name != "" && handle.Name != olo.Name {
+				continue
+			}
+			list.Items = append(list.Items, *handle)
+		}
+		return list, nil
+	}
+	watchFn := func(ctx context.Context, c clientv3.Interface, opts clientOpts) (watch.Interface, error) {
+		olo := opts.(options.ListOptions)
+		return newIPAMWatcher(ctx, c, model.IPAMHandleListOptions{}, olo.ResourceVersion, olo.Name, ipamHandleKVPToResource)
+	}
+	dryRunnableStorage := registry.DryRunnableStorage{Storage: &resourceStore{
+		client:            c,
+		codec:             opts.RESTOptions.StorageConfig.Codec,
+		versioner:         APIObjectVersioner{},
+		aapiType:          reflect.TypeOf(aapi.IPAMHandle{}),
+		aapiListType:      reflect.TypeOf(aapi.IPAMHandleList{}),
+		libCalicoType:     reflect.TypeOf(libapi.IPAMHandle{}),
+		libCalicoListType: reflect.TypeOf(libapi.IPAMHandleList{}),
+		isNamespaced:      false,
+		create:            createFn,
+		update:            updateFn,
+		get:               getFn,
+		delete:            deleteFn,
+		list:              listFn,
+		watch:             watchFn,
+		resourceName:      "IPAMHandle",
+		converter:         IPAMHandleConverter{},
+	}, Codec: opts.RESTOptions.StorageConfig.Codec}
+	return dryRunnableStorage, func() {}
+}
+
+// ipamHandleKVPToResource converts an IPAM handle KVPair into an IPAMHandle resource.
+func ipamHandleKVPToResource(kvp *model.KVPair) resourceObject {
+	if kvp.Value == nil {
+		// Deletion events may not include the value, so fill in what we can from the key.
+		handleID := kvp.Key.(model.IPAMHandleKey).HandleID
+		kvp = &model.KVPair{
+			Key:      kvp.Key,
+			Value:    &model.IPAMHandle{HandleID: handleID},
+			Revision: kvp.Revision,
+		}
+	}
+	return resources.IPAMHandleV1toV3(kvp).Value.(*libapi.IPAMHandle)
+}
+
+type IPAMHandleConverter struct {
+}
+
+func (gc IPAMHandleConverter) convertToLibcalico(aapiObj runtime.Object) resourceObject {
+	var lcgIPAMHandle *libapi.IPAMHandle
+	// This should not be called since IPAM handles are read-only through the AAPI.
+	log.Error("IPAM handle API is read-only. Should not attempt to create/update IPAM handles through the API.")
+	return lcgIPAMHandle
+}
+
+func (gc IPAMHandleConverter) convertToAAPI(libcalicoObject resourceObject, aapiObj runtime.Object) {
+	lcgIPAMHandle := libcalicoObject.(*libapi.IPAMHandle)
+	aapiIPAMHandle := aapiObj.(*aapi.IPAMHandle)
+	aapiIPAMHandle.Spec.HandleID = lcgIPAMHandle.Spec.HandleID
+	aapiIPAMHandle.Spec.Block = lcgIPAMHandle.Spec.Block
+	aapiIPAMHandle.Spec.Deleted = lcgIPAMHandle.Spec.Deleted
+	aapiIPAMHandle.TypeMeta = lcgIPAMHandle.TypeMeta
+	aapiIPAMHandle.ObjectMeta = lcgIPAMHandle.ObjectMeta
+
+	// Make sure that the API version is correct.
+	aapiIPAMHandle.TypeMeta.APIVersion = aapi.GroupVersionCurrent
+}
+
+func (gc IPAMHandleConverter) convertToAAPIList(libcalicoListObject resourceListObject, aapiListObj runtime.Object, pred storage.SelectionPredicate) {
+	aapiIPAMHandleList := aapiListObj.(*aapi.IPAMHandleList)
+	if libcalicoListObject == nil {
+		aapiIPAMHandleList.Items = []aapi.IPAMHandle{}
+		return
+	}
+	lcgIPAMHandleList := libcalicoListObject.(*libapi.IPAMHandleList)
+	aapiIPAMHandleList.TypeMeta = lcgIPAMHandleList.TypeMeta
+	aapiIPAMHandleList.ListMeta = lcgIPAMHandleList.ListMeta
+	for _, item := range lcgIPAMHandleList.Items {
+		aapiIPAMHandle := aapi.IPAMHandle{}
+		gc.convertToAAPI(&item, &aapiIPAMHandle)
+		if matched, err := pred.Matches(&aapiIPAMHandle); err == nil && matched {
+			aapiIPAMHandleList.Items = append(aapiIPAMHandleList.Items, aapiIPAMHandle)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calico
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/watch"
+)
+
+// The IPAM client does not expose IPAM blocks and handles as v3 resources, so the IPAM storage
+// reads them directly from the backend client that the IPAM client uses, and converts them into
+// their v3 form.
+
+type backendClientAccessor interface {
+	Backend() bapi.Client
+}
+
+// kvpConverter converts an IPAM KVPair returned by the backend into its v3 resource.
+type kvpConverter func(*model.KVPair) resourceObject
+
+func ipamBackend(c clientv3.Interface) (bapi.Client, error) {
+	bc, ok := c.(backendClientAccessor)
+	if !ok {
+		return nil, fmt.Errorf("client does not provide access to the IPAM backend")
+	}
+	return bc.Backend(), nil
+}
+
+// ipamWatcher adapts a backend watch on IPAM resources into a libcalico watch.Interface of
+// v3 resources.  If name is non-empty, only events for the resource with that name are passed on.
+type ipamWatcher struct {
+	backend bapi.WatchInterface
+	convert kvpConverter
+	name    string
+	results chan watch.Event
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func newIPAMWatcher(
+	ctx context.Context, c clientv3.Interface, list model.ListInterface, revision, name string, convert kvpConverter,
+) (watch.Interface, error) {
+	b, err := ipamBackend(c)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	backend, err := b.Watch(ctx, list, revision)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	w := &ipamWatcher{
+		backend: backend,
+		convert: convert,
+		name:    name,
+		results: make(chan watch.Event, watch.DefaultChanSize),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	go w.run()
+	return w, nil
+}
+
+func (w *ipamWatcher) Stop() {
+	w.cancel()
+}
+
+func (w *ipamWatcher) ResultChan() <-chan watch.Event {
+	return w.results
+}
+
+func (w *ipamWatcher) run() {
+	defer func() {
+		w.cancel()
+		w.backend.Stop()
+		close(w.results)
+	}()
+
+	for {
+		select {
+		case e, ok := <-w.backend.ResultChan():
+			if !ok {
+				log.Debug("IPAM backend watcher results channel closed")
+				return
+			}
+			event, ok := w.convertEvent(e)
+			if !ok {
+				continue
+			}
+			select {
+			case w.results <- event:
+			case <-w.ctx.Done():
+				return
+			}
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// convertEvent converts a backend watch event into a libcalico watch event, returning false if
+// the event should be filtered out.
+func (w *ipamWatcher) convertEvent(e bapi.WatchEvent) (watch.Event, bool) {
+	event := watch.Event{Error: e.Error}
+	switch e.Type {
+	case bapi.WatchError:
+		event.Type = watch.Error
+		return event, true
+	case bapi.WatchAdded:
+		event.Type = watch.Added
+	case bapi.WatchModified:
+		event.Type = watch.Modified
+	case bapi.WatchDeleted:
+		event.Type = watch.Deleted
+	}
+	if e.Old != nil {
+		event.Previous = w.convert(e.Old)
+	}
+	if e.New != nil {
+		event.Object = w.convert(e.New)
+	}
+	if w.name != "" {
+		obj := event.Object
+		if obj == nil {
+			obj = event.Previous
+		}
+		if obj == nil || obj.(resourceObject).GetObjectMeta().GetName() != w.name {
+			return event, false
+		}
+	}
+	return event, true
+}
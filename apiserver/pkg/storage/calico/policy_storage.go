@@ -103,6 +103,7 @@ func (rc NetworkPolicyConverter) convertToLibcalico(aapiObj runtime.Object) reso
 	lcgPolicy.Kind = v3.KindNetworkPolicy
 	lcgPolicy.APIVersion = v3.GroupVersionCurrent
 	lcgPolicy.Spec = aapiPolicy.Spec
+	lcgPolicy.Status = aapiPolicy.Status
 	return lcgPolicy
 }
 
@@ -110,6 +111,7 @@ func (rc NetworkPolicyConverter) convertToAAPI(libcalicoObject resourceObject, a
 	lcgPolicy := libcalicoObject.(*v3.NetworkPolicy)
 	aapiPolicy := aapiObj.(*v3.NetworkPolicy)
 	aapiPolicy.Spec = lcgPolicy.Spec
+	aapiPolicy.Status = lcgPolicy.Status
 	// Default the tier field if not specified
 	if aapiPolicy.Spec.Tier == "" {
 		aapiPolicy.Spec.Tier = "default"
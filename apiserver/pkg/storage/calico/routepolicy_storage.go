@@ -0,0 +1,112 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+package calico
+
+import (
+	"reflect"
+
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/storagebackend/factory"
+
+	aapi "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	api "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	"github.com/projectcalico/calico/libcalico-go/lib/watch"
+)
+
+// NewRoutePolicyStorage creates a new libcalico-based storage.Interface implementation for RoutePolicies
+func NewRoutePolicyStorage(opts Options) (registry.DryRunnableStorage, factory.DestroyFunc) {
+	c := CreateClientFromConfig()
+	createFn := func(ctx context.Context, c clientv3.Interface, obj resourceObject, opts clientOpts) (resourceObject, error) {
+		oso := opts.(options.SetOptions)
+		res := obj.(*api.RoutePolicy)
+		return c.RoutePolicies().Create(ctx, res, oso)
+	}
+	updateFn := func(ctx context.Context, c clientv3.Interface, obj resourceObject, opts clientOpts) (resourceObject, error) {
+		oso := opts.(options.SetOptions)
+		res := obj.(*api.RoutePolicy)
+		return c.RoutePolicies().Update(ctx, res, oso)
+	}
+	getFn := func(ctx context.Context, c clientv3.Interface, ns string, name string, opts clientOpts) (resourceObject, error) {
+		ogo := opts.(options.GetOptions)
+		return c.RoutePolicies().Get(ctx, name, ogo)
+	}
+	deleteFn := func(ctx context.Context, c clientv3.Interface, ns string, name string, opts clientOpts) (resourceObject, error) {
+		odo := opts.(options.DeleteOptions)
+		return c.RoutePolicies().Delete(ctx, name, odo)
+	}
+	listFn := func(ctx context.Context, c clientv3.Interface, opts clientOpts) (resourceListObject, error) {
+		olo := opts.(options.ListOptions)
+		return c.RoutePolicies().List(ctx, olo)
+	}
+	watchFn := func(ctx context.Context, c clientv3.Interface, opts clientOpts) (watch.Interface, error) {
+		olo := opts.(options.ListOptions)
+		return c.RoutePolicies().Watch(ctx, olo)
+	}
+	dryRunnableStorage := registry.DryRunnableStorage{Storage: &resourceStore{
+		client:            c,
+		codec:             opts.RESTOptions.StorageConfig.Codec,
+		versioner:         APIObjectVersioner{},
+		aapiType:          reflect.TypeOf(aapi.RoutePolicy{}),
+		aapiListType:      reflect.TypeOf(aapi.RoutePolicyList{}),
+		libCalicoType:     reflect.TypeOf(api.RoutePolicy{}),
+		libCalicoListType: reflect.TypeOf(api.RoutePolicyList{}),
+		isNamespaced:      false,
+		create:            createFn,
+		update:            updateFn,
+		get:               getFn,
+		delete:            deleteFn,
+		list:              listFn,
+		watch:             watchFn,
+		resourceName:      "RoutePolicy",
+		converter:         RoutePolicyConverter{},
+	}, Codec: opts.RESTOptions.StorageConfig.Codec}
+	return dryRunnableStorage, func() {}
+}
+
+type RoutePolicyConverter struct {
+}
+
+func (gc RoutePolicyConverter) convertToLibcalico(aapiObj runtime.Object) resourceObject {
+	aapiRoutePolicy := aapiObj.(*aapi.RoutePolicy)
+	lcgRoutePolicy := &api.RoutePolicy{}
+	lcgRoutePolicy.TypeMeta = aapiRoutePolicy.TypeMeta
+	lcgRoutePolicy.ObjectMeta = aapiRoutePolicy.ObjectMeta
+	lcgRoutePolicy.Kind = api.KindRoutePolicy
+	lcgRoutePolicy.APIVersion = api.GroupVersionCurrent
+	lcgRoutePolicy.Spec = aapiRoutePolicy.Spec
+	return lcgRoutePolicy
+}
+
+func (gc RoutePolicyConverter) convertToAAPI(libcalicoObject resourceObject, aapiObj runtime.Object) {
+	lcgRoutePolicy := libcalicoObject.(*api.RoutePolicy)
+	aapiRoutePolicy := aapiObj.(*aapi.RoutePolicy)
+	aapiRoutePolicy.Spec = lcgRoutePolicy.Spec
+	aapiRoutePolicy.TypeMeta = lcgRoutePolicy.TypeMeta
+	aapiRoutePolicy.ObjectMeta = lcgRoutePolicy.ObjectMeta
+}
+
+func (gc RoutePolicyConverter) convertToAAPIList(libcalicoListObject resourceListObject, aapiListObj runtime.Object, pred storage.SelectionPredicate) {
+	lcgRoutePolicyList := libcalicoListObject.(*api.RoutePolicyList)
+	aapiRoutePolicyList := aapiListObj.(*aapi.RoutePolicyList)
+	if libcalicoListObject == nil {
+		aapiRoutePolicyList.Items = []aapi.RoutePolicy{}
+		return
+	}
+	aapiRoutePolicyList.TypeMeta = lcgRoutePolicyList.TypeMeta
+	aapiRoutePolicyList.ListMeta = lcgRoutePolicyList.ListMeta
+	for _, item := range lcgRoutePolicyList.Items {
+		aapiRoutePolicy := aapi.RoutePolicy{}
+		gc.convertToAAPI(&item, &aapiRoutePolicy)
+		if matched, err := pred.Matches(&aapiRoutePolicy); err == nil && matched {
+			aapiRoutePolicyList.Items = append(aapiRoutePolicyList.Items, aapiRoutePolicy)
+		}
+	}
+}
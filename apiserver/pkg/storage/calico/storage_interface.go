@@ -35,6 +35,10 @@ func NewStorage(opts Options) (registry.DryRunnableStorage, factory.DestroyFunc)
 		return NewBGPPeerStorage(opts)
 	case "projectcalico.org/bgpfilters":
 		return NewBGPFilterStorage(opts)
+	case "projectcalico.org/routepolicies":
+		return NewRoutePolicyStorage(opts)
+	case "projectcalico.org/ippoolmigrations":
+		return NewIPPoolMigrationStorage(opts)
 	case "projectcalico.org/profiles":
 		return NewProfileStorage(opts)
 	case "projectcalico.org/felixconfigurations":
@@ -49,6 +53,12 @@ func NewStorage(opts Options) (registry.DryRunnableStorage, factory.DestroyFunc)
 		return NewIPAMConfigurationStorage(opts)
 	case "projectcalico.org/blockaffinities":
 		return NewBlockAffinityStorage(opts)
+	case "projectcalico.org/workloadendpoints":
+		return NewWorkloadEndpointStorage(opts)
+	case "projectcalico.org/ipamblocks":
+		return NewIPAMBlockStorage(opts)
+	case "projectcalico.org/ipamhandles":
+		return NewIPAMHandleStorage(opts)
 	default:
 		klog.Fatalf("Unable to create storage for resource %v", opts.RESTOptions.ResourcePrefix)
 		return registry.DryRunnableStorage{}, nil
@@ -801,6 +801,78 @@ func testIPReservationClient(client calicoclient.Interface, name string) error {
 	return nil
 }
 
+// TestRoutePolicyClient exercises the RoutePolicy client.
+func TestRoutePolicyClient(t *testing.T) {
+	const name = "test-routepolicy"
+	rootTestFunc := func() func(t *testing.T) {
+		return func(t *testing.T) {
+			client, shutdownServer := getFreshApiserverAndClient(t, func() runtime.Object {
+				return &v3.RoutePolicy{}
+			})
+			defer shutdownServer()
+			if err := testRoutePolicyClient(client, name); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if !t.Run(name, rootTestFunc()) {
+		t.Errorf("test-routepolicy test failed")
+	}
+}
+
+func testRoutePolicyClient(client calicoclient.Interface, name string) error {
+	routepolicyClient := client.ProjectcalicoV3().RoutePolicies()
+	routepolicy := &v3.RoutePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v3.RoutePolicySpec{
+			Selector:     "all()",
+			Destinations: []string{"192.168.0.0/16"},
+			NextHop:      "10.0.0.1",
+			Table:        200,
+		},
+	}
+	ctx := context.Background()
+
+	// start from scratch
+	routepolicies, err := routepolicyClient.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing routepolicies (%s)", err)
+	}
+	if routepolicies.Items == nil {
+		return fmt.Errorf("items field should not be set to nil")
+	}
+
+	routepolicyServer, err := routepolicyClient.Create(ctx, routepolicy, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("error creating the routepolicy '%v' (%v)", routepolicy, err)
+	}
+	if name != routepolicyServer.Name {
+		return fmt.Errorf("didn't get the same routepolicy back from the server \n%+v\n%+v", routepolicy, routepolicyServer)
+	}
+
+	_, err = routepolicyClient.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing routepolicies (%s)", err)
+	}
+
+	routepolicyServer, err = routepolicyClient.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting routepolicy %s (%s)", name, err)
+	}
+	if name != routepolicyServer.Name &&
+		routepolicy.ResourceVersion == routepolicyServer.ResourceVersion {
+		return fmt.Errorf("didn't get the same routepolicy back from the server \n%+v\n%+v", routepolicy, routepolicyServer)
+	}
+
+	err = routepolicyClient.Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("routepolicy should be deleted (%s)", err)
+	}
+
+	return nil
+}
+
 // TestHostEndpointClient exercises the HostEndpoint client.
 func TestHostEndpointClient(t *testing.T) {
 	const name = "test-hostendpoint"
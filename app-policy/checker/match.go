@@ -16,7 +16,10 @@ package checker
 
 import (
 	"net"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/projectcalico/calico/felix/proto"
 	"github.com/projectcalico/calico/libcalico-go/lib/selector"
@@ -26,11 +29,22 @@ import (
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// jwtAuthnFilter is the metadata namespace of Envoy's JWT authentication filter.  The filter only adds the
+	// payloads of tokens that it has verified, under the payload_in_metadata key of each provider.  For Dikastes
+	// to see them, the ext_authz filter must list this namespace in its metadata_context_namespaces.
+	jwtAuthnFilter = "envoy.filters.http.jwt_authn"
 )
 
 var (
 	// Envoy supports TCP only. Add a k:v into this map if more protocol is supported in the future.
 	protocolMapL4 = map[int32]string{6: "tcp"}
+
+	// headerRegexps caches the compiled header match regular expressions, by expression.
+	headerRegexps sync.Map
 )
 
 type namespaceMatch struct {
@@ -61,7 +75,7 @@ func match(rule *proto.Rule, req *requestCache, policyNamespace string) bool {
 	attr := req.Request.GetAttributes()
 	return matchSource(rule, req, policyNamespace) &&
 		matchDestination(rule, req, policyNamespace) &&
-		matchRequest(rule, attr.GetRequest()) &&
+		matchRequest(rule, attr) &&
 		matchL4Protocol(rule, attr.GetDestination())
 }
 
@@ -118,9 +132,11 @@ func matchDestination(r *proto.Rule, req *requestCache, policyNamespace string)
 		matchNet("dst", r.GetDstNet(), addr)
 }
 
-func matchRequest(rule *proto.Rule, req *authz.AttributeContext_Request) bool {
+func matchRequest(rule *proto.Rule, attr *authz.AttributeContext) bool {
+	req := attr.GetRequest()
 	log.WithField("request", req).Debug("Matching request.")
-	return matchHTTP(rule.GetHttpMatch(), req.GetHttp())
+	return matchHTTP(rule.GetHttpMatch(), req.GetHttp()) &&
+		matchJWTClaims(rule.GetHttpMatch().GetJwtClaims(), attr.GetMetadataContext())
 }
 
 func matchServiceAccounts(saMatch *proto.ServiceAccountMatch, p peer) bool {
@@ -197,7 +213,11 @@ func matchHTTP(rule *proto.HTTPMatch, req *authz.AttributeContext_HttpRequest) b
 		log.Debug("nil HTTPRule.  Return true")
 		return true
 	}
-	return matchHTTPMethods(rule.GetMethods(), req.GetMethod()) && matchHTTPPaths(rule.GetPaths(), req.GetPath())
+	return matchHTTPMethods(rule.GetMethods(), req.GetMethod()) &&
+		matchHTTPPaths(rule.GetPaths(), req.GetPath()) &&
+		matchHTTPHeaders(rule.GetHeaders(), req.GetHeaders()) &&
+		matchHTTPHosts(rule.GetHosts(), req.GetHost()) &&
+		matchGRPC(rule.GetGrpc(), req)
 }
 
 func matchHTTPMethods(methods []string, reqMethod string) bool {
@@ -261,6 +281,184 @@ func matchHTTPPaths(paths []*proto.HTTPMatch_PathMatch, reqPath string) bool {
 	return false
 }
 
+func matchHTTPHeaders(headers []*proto.HTTPMatch_HeaderMatch, reqHeaders map[string]string) bool {
+	log.WithFields(log.Fields{
+		"headers":    headers,
+		"reqHeaders": reqHeaders,
+	}).Debug("Matching HTTP Headers")
+	// All header matches must match.
+	for _, headerMatch := range headers {
+		// Envoy passes the header names in lower case, and joins the values of repeated headers with ",".
+		v, ok := reqHeaders[strings.ToLower(headerMatch.GetName())]
+		if !ok {
+			log.Debugf("HTTP Header %s not present.", headerMatch.GetName())
+			return false
+		}
+		switch headerMatch.GetValueMatch().(type) {
+		case *proto.HTTPMatch_HeaderMatch_Exact:
+			if v != headerMatch.GetExact() {
+				log.Debugf("HTTP Header %s exact not matched.", headerMatch.GetName())
+				return false
+			}
+		case *proto.HTTPMatch_HeaderMatch_Prefix:
+			if !strings.HasPrefix(v, headerMatch.GetPrefix()) {
+				log.Debugf("HTTP Header %s prefix not matched.", headerMatch.GetName())
+				return false
+			}
+		case *proto.HTTPMatch_HeaderMatch_Regex:
+			re := headerRegexp(headerMatch.GetRegex())
+			if re == nil || !re.MatchString(v) {
+				log.Debugf("HTTP Header %s regex not matched.", headerMatch.GetName())
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// headerRegexp returns the compiled form of a header match regular expression, which must match the whole
+// header value, or nil if it is invalid.
+func headerRegexp(expr string) *regexp.Regexp {
+	if re, ok := headerRegexps.Load(expr); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		// The validator rejects invalid expressions, so this should never happen.
+		log.WithError(err).Warnf("Could not parse HTTP header regex %v", expr)
+		return nil
+	}
+	headerRegexps.Store(expr, re)
+	return re
+}
+
+func matchHTTPHosts(hosts []string, reqHost string) bool {
+	log.WithFields(log.Fields{
+		"hosts":   hosts,
+		"reqHost": reqHost,
+	}).Debug("Matching HTTP Hosts")
+	if len(hosts) == 0 {
+		log.Debug("Rule has 0 HTTP Hosts, matched.")
+		return true
+	}
+	// Strip out the port, if any.
+	if h, _, err := net.SplitHostPort(reqHost); err == nil {
+		reqHost = h
+	}
+	reqHost = strings.ToLower(reqHost)
+	for _, host := range hosts {
+		host = strings.ToLower(host)
+		if strings.HasPrefix(host, "*.") {
+			if strings.HasSuffix(reqHost, host[1:]) {
+				log.Debugf("HTTP Host wildcard %s matched.", host)
+				return true
+			}
+		} else if reqHost == host {
+			log.Debug("HTTP Host matched.")
+			return true
+		}
+	}
+	log.Debug("HTTP Host not matched.")
+	return false
+}
+
+func matchGRPC(grpc *proto.HTTPMatch_GRPCMatch, req *authz.AttributeContext_HttpRequest) bool {
+	log.WithFields(log.Fields{
+		"grpc":    grpc,
+		"reqPath": req.GetPath(),
+	}).Debug("Matching gRPC")
+	if grpc == nil {
+		log.Debug("nil GRPCMatch.  Return true")
+		return true
+	}
+	// gRPC requests have a content type of application/grpc, optionally followed by "+proto" or similar.
+	if !strings.HasPrefix(req.GetHeaders()["content-type"], "application/grpc") {
+		log.Debug("Not a gRPC request.")
+		return false
+	}
+	// The path of a gRPC request is "/<service>/<method>", where the service is fully-qualified.
+	parts := strings.Split(strings.TrimPrefix(req.GetPath(), "/"), "/")
+	if len(parts) != 2 {
+		log.Debugf("Invalid gRPC path %s.", req.GetPath())
+		return false
+	}
+	return matchName(grpc.GetServices(), parts[0]) && matchName(grpc.GetMethods(), parts[1])
+}
+
+func matchJWTClaims(claims []*proto.HTTPMatch_ClaimMatch, md *core.Metadata) bool {
+	log.WithFields(log.Fields{
+		"claims": claims,
+	}).Debug("Matching JWT claims")
+	if len(claims) == 0 {
+		log.Debug("Rule has 0 JWT claims, matched.")
+		return true
+	}
+	// There is a payload for each verified token; one of them must match all of the claims.
+	for _, v := range md.GetFilterMetadata()[jwtAuthnFilter].GetFields() {
+		payload := v.GetStructValue()
+		if payload == nil {
+			continue
+		}
+		matched := true
+		for _, claim := range claims {
+			if !matchJWTClaim(claim, payload) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			log.Debug("JWT claims matched.")
+			return true
+		}
+	}
+	log.Debug("JWT claims not matched.")
+	return false
+}
+
+func matchJWTClaim(claim *proto.HTTPMatch_ClaimMatch, payload *structpb.Struct) bool {
+	// Look for the claim by its whole name first, since claim names may contain "." themselves, then as a
+	// path of nested claims.
+	v, ok := payload.GetFields()[claim.GetName()]
+	if !ok {
+		v = &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: payload}}
+		for _, part := range strings.Split(claim.GetName(), ".") {
+			v, ok = v.GetStructValue().GetFields()[part]
+			if !ok {
+				return false
+			}
+		}
+	}
+	if l := v.GetListValue(); l != nil {
+		for _, e := range l.GetValues() {
+			if matchJWTClaimValue(claim.GetValues(), e) {
+				return true
+			}
+		}
+		return false
+	}
+	return matchJWTClaimValue(claim.GetValues(), v)
+}
+
+func matchJWTClaimValue(values []string, v *structpb.Value) bool {
+	var s string
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		s = k.StringValue
+	case *structpb.Value_NumberValue:
+		s = strconv.FormatFloat(k.NumberValue, 'f', -1, 64)
+	case *structpb.Value_BoolValue:
+		s = strconv.FormatBool(k.BoolValue)
+	default:
+		return false
+	}
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
+
 func matchSrcIPSets(r *proto.Rule, req *requestCache) bool {
 	log.WithFields(log.Fields{
 		"SrcIpSetIds":    r.SrcIpSetIds,
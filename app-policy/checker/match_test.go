@@ -21,6 +21,8 @@ import (
 	auth "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	. "github.com/onsi/gomega"
 
+	"google.golang.org/protobuf/types/known/structpb"
+
 	"github.com/projectcalico/calico/app-policy/policystore"
 	"github.com/projectcalico/calico/felix/proto"
 )
@@ -158,6 +160,174 @@ func TestPanicHTTPPaths(t *testing.T) {
 	matchHTTPPaths(paths, "foo")
 }
 
+// HTTP Headers clause with empty list will match any headers.
+func TestMatchHTTPHeaders(t *testing.T) {
+	exact := &proto.HTTPMatch_HeaderMatch{Name: "X-Tenant", ValueMatch: &proto.HTTPMatch_HeaderMatch_Exact{Exact: "blue"}}
+	prefix := &proto.HTTPMatch_HeaderMatch{Name: "user-agent", ValueMatch: &proto.HTTPMatch_HeaderMatch_Prefix{Prefix: "curl/"}}
+	regex := &proto.HTTPMatch_HeaderMatch{Name: "x-version", ValueMatch: &proto.HTTPMatch_HeaderMatch_Regex{Regex: "v[12]"}}
+	present := &proto.HTTPMatch_HeaderMatch{Name: "x-debug"}
+	testCases := []struct {
+		title      string
+		headers    []*proto.HTTPMatch_HeaderMatch
+		reqHeaders map[string]string
+		result     bool
+	}{
+		{"empty", nil, map[string]string{"x-tenant": "blue"}, true},
+		{"exact", []*proto.HTTPMatch_HeaderMatch{exact}, map[string]string{"x-tenant": "blue"}, true},
+		{"exact fail", []*proto.HTTPMatch_HeaderMatch{exact}, map[string]string{"x-tenant": "blueish"}, false},
+		{"missing", []*proto.HTTPMatch_HeaderMatch{exact}, map[string]string{"x-other": "blue"}, false},
+		{"prefix", []*proto.HTTPMatch_HeaderMatch{prefix}, map[string]string{"user-agent": "curl/8.0"}, true},
+		{"prefix fail", []*proto.HTTPMatch_HeaderMatch{prefix}, map[string]string{"user-agent": "wget/1.0"}, false},
+		{"regex", []*proto.HTTPMatch_HeaderMatch{regex}, map[string]string{"x-version": "v2"}, true},
+		{"regex matches whole value", []*proto.HTTPMatch_HeaderMatch{regex}, map[string]string{"x-version": "v23"}, false},
+		{"present", []*proto.HTTPMatch_HeaderMatch{present}, map[string]string{"x-debug": ""}, true},
+		{"present fail", []*proto.HTTPMatch_HeaderMatch{present}, map[string]string{}, false},
+		{"all", []*proto.HTTPMatch_HeaderMatch{exact, prefix}, map[string]string{"x-tenant": "blue", "user-agent": "curl/8.0"}, true},
+		{"all fail", []*proto.HTTPMatch_HeaderMatch{exact, prefix}, map[string]string{"x-tenant": "blue", "user-agent": "wget/1.0"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			RegisterTestingT(t)
+			Expect(matchHTTPHeaders(tc.headers, tc.reqHeaders)).To(Equal(tc.result))
+		})
+	}
+}
+
+// HTTP Hosts clause with empty list will match any host.
+func TestMatchHTTPHosts(t *testing.T) {
+	testCases := []struct {
+		title   string
+		hosts   []string
+		reqHost string
+		result  bool
+	}{
+		{"empty", nil, "api.example.com", true},
+		{"match", []string{"www.example.com", "api.example.com"}, "api.example.com", true},
+		{"case-insensitive", []string{"API.example.com"}, "api.Example.com", true},
+		{"port", []string{"api.example.com"}, "api.example.com:8080", true},
+		{"ipv6 port", []string{"::1"}, "[::1]:8080", true},
+		{"no match", []string{"api.example.com"}, "www.example.com", false},
+		{"wildcard", []string{"*.example.com"}, "a.b.example.com", true},
+		{"wildcard needs subdomain", []string{"*.example.com"}, "example.com", false},
+		{"wildcard fail", []string{"*.example.com"}, "api.example.org", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			RegisterTestingT(t)
+			Expect(matchHTTPHosts(tc.hosts, tc.reqHost)).To(Equal(tc.result))
+		})
+	}
+}
+
+// A gRPC clause only matches gRPC requests, for the given services and methods.
+func TestMatchGRPC(t *testing.T) {
+	testCases := []struct {
+		title       string
+		grpc        *proto.HTTPMatch_GRPCMatch
+		contentType string
+		path        string
+		result      bool
+	}{
+		{"nil", nil, "application/json", "/foo", true},
+		{"any gRPC", &proto.HTTPMatch_GRPCMatch{}, "application/grpc", "/helloworld.Greeter/SayHello", true},
+		{"not gRPC", &proto.HTTPMatch_GRPCMatch{}, "application/json", "/helloworld.Greeter/SayHello", false},
+		{"gRPC with codec", &proto.HTTPMatch_GRPCMatch{}, "application/grpc+proto", "/helloworld.Greeter/SayHello", true},
+		{"service", &proto.HTTPMatch_GRPCMatch{Services: []string{"helloworld.Greeter"}}, "application/grpc", "/helloworld.Greeter/SayHello", true},
+		{"service fail", &proto.HTTPMatch_GRPCMatch{Services: []string{"helloworld.Greeter"}}, "application/grpc", "/helloworld.Admin/SayHello", false},
+		{"method", &proto.HTTPMatch_GRPCMatch{Methods: []string{"SayHello"}}, "application/grpc", "/helloworld.Greeter/SayHello", true},
+		{"method fail", &proto.HTTPMatch_GRPCMatch{Methods: []string{"SayHello"}}, "application/grpc", "/helloworld.Greeter/SayGoodbye", false},
+		{"invalid path", &proto.HTTPMatch_GRPCMatch{}, "application/grpc", "/helloworld.Greeter", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			RegisterTestingT(t)
+			rule := &proto.Rule{HttpMatch: &proto.HTTPMatch{Grpc: tc.grpc}}
+			req := &auth.CheckRequest{Attributes: &auth.AttributeContext{
+				Request: &auth.AttributeContext_Request{
+					Http: &auth.AttributeContext_HttpRequest{
+						Method:   "POST",
+						Path:     tc.path,
+						Protocol: "HTTP/2",
+						Headers:  map[string]string{"content-type": tc.contentType},
+					},
+				},
+			}}
+			Expect(matchRequest(rule, req.GetAttributes())).To(Equal(tc.result))
+		})
+	}
+}
+
+// JWT claims clauses match the claims of tokens that Envoy has verified.
+func TestMatchJWTClaims(t *testing.T) {
+	payload, err := structpb.NewStruct(map[string]interface{}{
+		"iss":                         "https://issuer.example.com",
+		"sub":                         "alice",
+		"https://example.com/tenant":  "blue",
+		"admin":                       true,
+		"level":                       3,
+		"realm_access":                map[string]interface{}{"roles": []interface{}{"viewer", "editor"}},
+		"https://example.com/missing": nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verified := &core.Metadata{FilterMetadata: map[string]*structpb.Struct{
+		jwtAuthnFilter: {Fields: map[string]*structpb.Value{
+			"my_provider": structpb.NewStructValue(payload),
+		}},
+	}}
+	// Unverified tokens are only in the request headers, not in the JWT authentication filter's metadata.
+	unverified := &core.Metadata{FilterMetadata: map[string]*structpb.Struct{
+		"envoy.filters.http.other": {Fields: map[string]*structpb.Value{
+			"my_provider": structpb.NewStructValue(payload),
+		}},
+	}}
+	iss := &proto.HTTPMatch_ClaimMatch{Name: "iss", Values: []string{"https://issuer.example.com"}}
+	testCases := []struct {
+		title    string
+		claims   []*proto.HTTPMatch_ClaimMatch
+		metadata *core.Metadata
+		result   bool
+	}{
+		{"empty", nil, nil, true},
+		{"match", []*proto.HTTPMatch_ClaimMatch{iss}, verified, true},
+		{"unverified", []*proto.HTTPMatch_ClaimMatch{iss}, unverified, false},
+		{"no token", []*proto.HTTPMatch_ClaimMatch{iss}, nil, false},
+		{"any value", []*proto.HTTPMatch_ClaimMatch{{Name: "sub", Values: []string{"bob", "alice"}}}, verified, true},
+		{"no match", []*proto.HTTPMatch_ClaimMatch{{Name: "sub", Values: []string{"bob"}}}, verified, false},
+		{"missing claim", []*proto.HTTPMatch_ClaimMatch{{Name: "aud", Values: []string{"alice"}}}, verified, false},
+		{"dotted name", []*proto.HTTPMatch_ClaimMatch{{Name: "https://example.com/tenant", Values: []string{"blue"}}}, verified, true},
+		{"nested list", []*proto.HTTPMatch_ClaimMatch{{Name: "realm_access.roles", Values: []string{"editor"}}}, verified, true},
+		{"nested list fail", []*proto.HTTPMatch_ClaimMatch{{Name: "realm_access.roles", Values: []string{"admin"}}}, verified, false},
+		{"bool", []*proto.HTTPMatch_ClaimMatch{{Name: "admin", Values: []string{"true"}}}, verified, true},
+		{"number", []*proto.HTTPMatch_ClaimMatch{{Name: "level", Values: []string{"3"}}}, verified, true},
+		{"null", []*proto.HTTPMatch_ClaimMatch{{Name: "https://example.com/missing", Values: []string{""}}}, verified, false},
+		{"all", []*proto.HTTPMatch_ClaimMatch{iss, {Name: "sub", Values: []string{"alice"}}}, verified, true},
+		{"all fail", []*proto.HTTPMatch_ClaimMatch{iss, {Name: "sub", Values: []string{"bob"}}}, verified, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			RegisterTestingT(t)
+			rule := &proto.Rule{HttpMatch: &proto.HTTPMatch{JwtClaims: tc.claims}}
+			req := &auth.CheckRequest{Attributes: &auth.AttributeContext{
+				Request: &auth.AttributeContext_Request{
+					Http: &auth.AttributeContext_HttpRequest{
+						Method:  "GET",
+						Path:    "/foo",
+						Headers: map[string]string{"authorization": "Bearer token"},
+					},
+				},
+				MetadataContext: tc.metadata,
+			}}
+			Expect(matchRequest(rule, req.GetAttributes())).To(Equal(tc.result))
+		})
+	}
+}
+
 // Matching a whole rule should require matching all subclauses.
 func TestMatchRule(t *testing.T) {
 	RegisterTestingT(t)
@@ -179,6 +349,8 @@ func TestMatchRule(t *testing.T) {
 		HttpMatch: &proto.HTTPMatch{
 			Methods: []string{"GET", "POST"},
 			Paths:   []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: "/path"}}, {PathMatch: &proto.HTTPMatch_PathMatch_Exact{Exact: "/pathlong"}}},
+			Headers: []*proto.HTTPMatch_HeaderMatch{{Name: "x-tenant", ValueMatch: &proto.HTTPMatch_HeaderMatch_Exact{Exact: "blue"}}},
+			Hosts:   []string{"*.example.com"},
 		},
 		Protocol: &proto.Protocol{
 			NumberOrName: &proto.Protocol_Name{
@@ -217,8 +389,10 @@ func TestMatchRule(t *testing.T) {
 		},
 		Request: &auth.AttributeContext_Request{
 			Http: &auth.AttributeContext_HttpRequest{
-				Method: "GET",
-				Path:   "/path",
+				Method:  "GET",
+				Path:    "/path",
+				Host:    "api.example.com",
+				Headers: map[string]string{"x-tenant": "blue"},
 			},
 		},
 	}}
@@ -292,6 +466,20 @@ func TestMatchRule(t *testing.T) {
 	rule.HttpMatch.Paths = ohp
 	Expect(match(rule, reqCache, "")).To(BeTrue())
 
+	// HTTPHeaders
+	ohh := rule.HttpMatch.Headers
+	rule.HttpMatch.Headers = []*proto.HTTPMatch_HeaderMatch{{Name: "x-tenant", ValueMatch: &proto.HTTPMatch_HeaderMatch_Exact{Exact: "red"}}}
+	Expect(match(rule, reqCache, "")).To(BeFalse())
+	rule.HttpMatch.Headers = ohh
+	Expect(match(rule, reqCache, "")).To(BeTrue())
+
+	// HTTPHosts
+	ohhs := rule.HttpMatch.Hosts
+	rule.HttpMatch.Hosts = []string{"api.example.org"}
+	Expect(match(rule, reqCache, "")).To(BeFalse())
+	rule.HttpMatch.Hosts = ohhs
+	Expect(match(rule, reqCache, "")).To(BeTrue())
+
 	// Protocol
 	op := rule.Protocol.GetName()
 	rule.Protocol.NumberOrName = &proto.Protocol_Name{Name: "UDP"}
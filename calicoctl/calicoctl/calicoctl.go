@@ -44,6 +44,7 @@ func main() {
     convert      Convert config files between different API versions.
     ipam         IP address management.
     node         Calico node management.
+    policy       Network policy testing and analysis.
     version      Display the version of this binary.
     datastore    Calico datastore management.
 
@@ -129,6 +130,8 @@ Description:
 			err = commands.Node(args)
 		case "ipam":
 			err = commands.IPAM(args)
+		case "policy":
+			err = commands.Policy(args)
 		case "datastore":
 			err = commands.Datastore(args)
 		default:
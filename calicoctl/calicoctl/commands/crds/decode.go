@@ -60,6 +60,13 @@ func CalicoCRDs() ([]*v1.CustomResourceDefinition, error) {
 	}
 	crds = append(crds, &clusterInfo)
 
+	felixConfigReport := v1.CustomResourceDefinition{}
+	err = yaml.Unmarshal([]byte(felixconfigreports), &felixConfigReport)
+	if err != nil {
+		return crds, err
+	}
+	crds = append(crds, &felixConfigReport)
+
 	felixConfig := v1.CustomResourceDefinition{}
 	err = yaml.Unmarshal([]byte(felixconfigurations), &felixConfig)
 	if err != nil {
@@ -102,6 +109,13 @@ func CalicoCRDs() ([]*v1.CustomResourceDefinition, error) {
 	}
 	crds = append(crds, &ipamConfig)
 
+	ipamEvent := v1.CustomResourceDefinition{}
+	err = yaml.Unmarshal([]byte(ipamevents), &ipamEvent)
+	if err != nil {
+		return crds, err
+	}
+	crds = append(crds, &ipamEvent)
+
 	ipamHandle := v1.CustomResourceDefinition{}
 	err = yaml.Unmarshal([]byte(ipamhandles), &ipamHandle)
 	if err != nil {
@@ -109,6 +123,13 @@ func CalicoCRDs() ([]*v1.CustomResourceDefinition, error) {
 	}
 	crds = append(crds, &ipamHandle)
 
+	ipamQuotaCounter := v1.CustomResourceDefinition{}
+	err = yaml.Unmarshal([]byte(ipamquotacounters), &ipamQuotaCounter)
+	if err != nil {
+		return crds, err
+	}
+	crds = append(crds, &ipamQuotaCounter)
+
 	ipPool := v1.CustomResourceDefinition{}
 	err = yaml.Unmarshal([]byte(ippools), &ipPool)
 	if err != nil {
@@ -123,6 +144,20 @@ func CalicoCRDs() ([]*v1.CustomResourceDefinition, error) {
 	}
 	crds = append(crds, &ipResv)
 
+	routePolicy := v1.CustomResourceDefinition{}
+	err = yaml.Unmarshal([]byte(routepolicies), &routePolicy)
+	if err != nil {
+		return crds, err
+	}
+	crds = append(crds, &routePolicy)
+
+	ipPoolMigration := v1.CustomResourceDefinition{}
+	err = yaml.Unmarshal([]byte(ippoolmigrations), &ipPoolMigration)
+	if err != nil {
+		return crds, err
+	}
+	crds = append(crds, &ipPoolMigration)
+
 	kubeControllerConfig := v1.CustomResourceDefinition{}
 	err = yaml.Unmarshal([]byte(kubecontrollersconfigurations), &kubeControllerConfig)
 	if err != nil {
@@ -137,6 +172,13 @@ func CalicoCRDs() ([]*v1.CustomResourceDefinition, error) {
 	}
 	crds = append(crds, &policy)
 
+	policyStatusReport := v1.CustomResourceDefinition{}
+	err = yaml.Unmarshal([]byte(policystatusreports), &policyStatusReport)
+	if err != nil {
+		return crds, err
+	}
+	crds = append(crds, &policyStatusReport)
+
 	netset := v1.CustomResourceDefinition{}
 	err = yaml.Unmarshal([]byte(networksets), &netset)
 	if err != nil {
@@ -59,6 +59,7 @@ var allV3Resources []string = []string{
 	"felixconfigs",
 	"ipreservations",
 	"bgpfilters",
+	"routepolicies",
 }
 
 var resourceDisplayMap map[string]string = map[string]string{
@@ -80,6 +81,7 @@ var resourceDisplayMap map[string]string = map[string]string{
 	"nodes":                  "Nodes",
 	"ipreservations":         "IPReservations",
 	"bgpfilters":             "BGPFilters",
+	"routepolicies":          "RoutePolicies",
 }
 
 var namespacedResources map[string]struct{} = map[string]struct{}{
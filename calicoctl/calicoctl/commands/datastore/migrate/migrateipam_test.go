@@ -251,6 +251,16 @@ func (c *MockIPAMClient) BGPFilter() client.BGPFilterInterface {
 	return nil
 }
 
+func (c *MockIPAMClient) RoutePolicies() client.RoutePolicyInterface {
+	// DO NOTHING
+	return nil
+}
+
+func (c *MockIPAMClient) IPPoolMigrations() client.IPPoolMigrationInterface {
+	// DO NOTHING
+	return nil
+}
+
 func (c *MockIPAMClient) IPAM() ipam.Interface {
 	// DO NOTHING
 	return nil
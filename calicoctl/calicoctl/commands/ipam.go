@@ -37,6 +37,11 @@ func IPAM(args []string) error {
     split            Split the IP pool specified by the CIDR into
                      the specified number of smaller IPPools.
     configure        Configure IPAM
+    defrag           Release the affinity of empty and sparsely used
+                     IPAM blocks to reclaim their address space.
+    migrate-pool     Move running workloads from one IP pool to another.
+    history          Show the IP address assignments and releases
+                     recorded in the IPAM event log.
 
 Options:
   -h --help      Show this screen.
@@ -77,6 +82,12 @@ Description:
 		return ipam.Configure(args)
 	case "split":
 		return ipam.Split(args)
+	case "defrag":
+		return ipam.Defrag(args)
+	case "migrate-pool":
+		return ipam.MigratePool(args)
+	case "history":
+		return ipam.History(args)
 	default:
 		fmt.Println(doc)
 	}
@@ -49,15 +49,38 @@ func updateIPAMStrictAffinity(ctx context.Context, ipamClient ipam.Interface, en
 	return nil
 }
 
+func updateIPAMEventLog(ctx context.Context, ipamClient ipam.Interface, enabled bool) error {
+	ipamConfig, err := ipamClient.GetIPAMConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("Error: %v", err)
+	}
+
+	// If EventLog == true => assignments and releases are recorded as IPAMEvent resources.
+	ipamConfig.EventLog = enabled
+
+	err = ipamClient.SetIPAMConfig(ctx, *ipamConfig)
+	if err != nil {
+		return fmt.Errorf("Error: %v", err)
+	}
+
+	fmt.Println("Successfully set EventLog to:", enabled)
+
+	return nil
+}
+
 // Configure IPAM.
 func Configure(args []string) error {
 	doc := constants.DatastoreIntro + `Usage:
   <BINARY_NAME> ipam configure --strictaffinity=<true/false> [--config=<CONFIG>] [--allow-version-mismatch]
+  <BINARY_NAME> ipam configure --event-log=<true/false> [--config=<CONFIG>] [--allow-version-mismatch]
 
 Options:
   -h --help                        Show this screen.
      --strictaffinity=<true/false> Set StrictAffinity to true/false. When StrictAffinity
                                    is true, borrowing IP addresses is not allowed.
+     --event-log=<true/false>      Set EventLog to true/false. When EventLog is true,
+                                   every IP address assignment and release is recorded
+                                   and can be queried with '<BINARY_NAME> ipam history'.
   -c --config=<CONFIG>             Path to the file containing connection configuration in
                                    YAML or JSON format.
                                    [default: ` + constants.DefaultConfigPath + `]
@@ -93,6 +116,14 @@ Description:
 	}
 
 	ipamClient := client.IPAM()
+	if passedValue, ok := parsedArgs["--event-log"].(string); ok {
+		enabled, err := strconv.ParseBool(passedValue)
+		if err != nil {
+			return fmt.Errorf("Invalid value. Use true or false to set event-log")
+		}
+		return updateIPAMEventLog(ctx, ipamClient, enabled)
+	}
+
 	passedValue := parsedArgs["--strictaffinity"].(string)
 	enabled, err := strconv.ParseBool(passedValue)
 	if err != nil {
@@ -0,0 +1,227 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/clientmgr"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/common"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/constants"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/util"
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/defrag"
+)
+
+// Defrag releases the affinity of empty and sparsely used IPAM blocks.
+func Defrag(args []string) error {
+	doc := constants.DatastoreIntro + `Usage:
+  <BINARY_NAME> ipam defrag [--dry-run] [--threshold=<PERCENT>] [--consolidate] [--config=<CONFIG>] [--allow-version-mismatch]
+
+Options:
+  -h --help                    Show this screen.
+     --dry-run                 Print the blocks that would be released without releasing them.
+     --threshold=<PERCENT>     Release the affinity of blocks in which the affine node is using
+                               less than this percentage of the addresses, provided the node's
+                               other blocks have room for them. Set to 0 to only release blocks
+                               that are empty or only in use by other nodes.
+                               [default: 25]
+     --consolidate             Evict the pods on other nodes that have borrowed addresses
+                               from the released blocks, when their own nodes' blocks have
+                               room for them, so that the released blocks drain.
+  -c --config=<CONFIG>         Path to the file containing connection configuration in
+                               YAML or JSON format.
+                               [default: ` + constants.DefaultConfigPath + `]
+     --allow-version-mismatch  Allow client and cluster versions mismatch.
+
+Description:
+  The ipam defrag command reclaims IP address space that is tied up in blocks that
+  their nodes are making little use of. Empty blocks are deleted, and blocks whose
+  addresses are only in use by other nodes, or that are sparsely used, have their
+  affinity released so that their free addresses become available to every node.
+  Such blocks are deleted once their last address is released. Each node keeps at
+  least one block, and a block is only released if the node's other blocks have
+  room for the addresses it is using. When strict affinity is enabled in the IPAM
+  configuration, only empty blocks are released.
+
+  With --consolidate, pods that have borrowed addresses from the released blocks
+  are evicted through the Kubernetes eviction API, so that PodDisruptionBudgets are
+  respected, and are recreated with addresses from their own nodes' blocks. Pods
+  without a controller to recreate them are left alone.
+
+  It is safe to run the command while addresses are being allocated.
+
+Examples:
+  # Show the blocks that would be released.
+  <BINARY_NAME> ipam defrag --dry-run
+
+  # Also release blocks that are less than half used.
+  <BINARY_NAME> ipam defrag --threshold=50
+
+  # Also evict the pods that have borrowed addresses from the released blocks.
+  <BINARY_NAME> ipam defrag --consolidate
+`
+	// Replace all instances of BINARY_NAME with the name of the binary.
+	name, _ := util.NameAndDescription()
+	doc = strings.ReplaceAll(doc, "<BINARY_NAME>", name)
+
+	parsedArgs, err := docopt.ParseArgs(doc, args, "")
+	if err != nil {
+		return fmt.Errorf("Invalid option: 'calicoctl %s'. Use flag '--help' to read about a specific subcommand.", strings.Join(args, " "))
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	err = common.CheckVersionMismatch(parsedArgs["--config"], parsedArgs["--allow-version-mismatch"])
+	if err != nil {
+		return err
+	}
+
+	threshold, err := strconv.Atoi(parsedArgs["--threshold"].(string))
+	if err != nil || threshold < 0 || threshold > 100 {
+		return fmt.Errorf("Invalid threshold %v: must be a percentage between 0 and 100", parsedArgs["--threshold"])
+	}
+	dryRun := parsedArgs["--dry-run"].(bool)
+	consolidate := parsedArgs["--consolidate"].(bool)
+
+	ctx := context.Background()
+	cf := parsedArgs["--config"].(string)
+	client, err := clientmgr.NewClient(cf)
+	if err != nil {
+		return err
+	}
+
+	// Get the backend client to list the blocks.
+	type accessor interface {
+		Backend() bapi.Client
+	}
+	bc := client.(accessor).Backend()
+	kvps, err := bc.List(ctx, model.BlockListOptions{}, "")
+	if err != nil {
+		return fmt.Errorf("Failed to list IPAM blocks: %s", err)
+	}
+	var blocks []*model.AllocationBlock
+	for _, kvp := range kvps.KVPairs {
+		blocks = append(blocks, kvp.Value.(*model.AllocationBlock))
+	}
+
+	ipamCfg, err := client.IPAM().GetIPAMConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to get IPAM configuration: %s", err)
+	}
+
+	var evict defrag.Evictor
+	if consolidate && !dryRun {
+		// Pods are evicted through the Kubernetes API, whichever datastore is in use.
+		cfg, err := clientmgr.LoadClientConfig(cf)
+		if err != nil {
+			return err
+		}
+		_, kubeClient, err := k8s.CreateKubernetesClientset(&cfg.Spec)
+		if err != nil {
+			return fmt.Errorf("Failed to create Kubernetes client: %s", err)
+		}
+		evict = defrag.NewKubernetesEvictor(kubeClient)
+	}
+
+	plan := defrag.NewPlan(blocks, defrag.Config{
+		SparseThreshold:     float64(threshold) / 100,
+		StrictAffinity:      ipamCfg.StrictAffinity,
+		ConsolidateBorrowed: consolidate,
+	})
+	if len(plan.Actions) == 0 {
+		fmt.Printf("Checked %d blocks, nothing to defragment.\n", len(blocks))
+		return nil
+	}
+	printDefragActions(plan.Actions)
+	if len(plan.Consolidations) > 0 {
+		fmt.Println()
+		printDefragConsolidations(plan.Consolidations)
+	}
+	summary := plan.Summary()
+
+	if dryRun {
+		fmt.Printf("\nDry run: would release %d of %d blocks, reclaiming %d addresses in empty blocks "+
+			"and making %d free addresses in blocks that are still in use available to all nodes.\n",
+			summary.BlocksReleased, len(blocks), summary.AddressesReclaimed, summary.AddressesUnpinned)
+		if consolidate {
+			fmt.Printf("Would evict %d pods with borrowed addresses.\n", summary.AddressesConsolidated)
+		}
+		return nil
+	}
+
+	result := plan.Apply(ctx, client.IPAM(), evict)
+	summary = result.Summary()
+	fmt.Printf("\nReleased %d of %d blocks, reclaiming %d addresses in empty blocks "+
+		"and making %d free addresses in blocks that are still in use available to all nodes.\n",
+		summary.BlocksReleased, len(blocks), summary.AddressesReclaimed, summary.AddressesUnpinned)
+	if consolidate {
+		fmt.Printf("Evicted %d pods with borrowed addresses.\n", summary.AddressesConsolidated)
+	}
+	for _, f := range result.FailedConsolidations {
+		fmt.Printf("Failed to evict pod %s/%s: %s\n", f.Consolidation.Namespace, f.Consolidation.Pod, f.Err)
+	}
+	if len(result.Failed) > 0 {
+		for _, f := range result.Failed {
+			fmt.Printf("Failed to release block %s: %s\n", f.Action.Block.CIDR, f.Err)
+		}
+		return fmt.Errorf("Failed to release %d blocks, they may have changed while defragmenting; re-run the command to retry", len(result.Failed))
+	}
+	if len(result.FailedConsolidations) > 0 {
+		return fmt.Errorf("Failed to evict %d pods, their PodDisruptionBudgets may not allow it yet; re-run the command to retry", len(result.FailedConsolidations))
+	}
+	return nil
+}
+
+func printDefragActions(actions []defrag.Action) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"BLOCK", "NODE", "REASON", "IPS IN USE", "IPS BORROWED", "IPS FREE"})
+	for _, a := range actions {
+		table.Append([]string{
+			a.Block.CIDR.String(),
+			a.Node,
+			string(a.Reason),
+			strconv.Itoa(a.InUse),
+			strconv.Itoa(a.Borrowed),
+			strconv.Itoa(a.Free),
+		})
+	}
+	table.Render()
+}
+
+func printDefragConsolidations(consolidations []defrag.Consolidation) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"BORROWED IP", "BLOCK", "NODE", "NAMESPACE", "POD"})
+	for _, c := range consolidations {
+		table.Append([]string{
+			c.IP.String(),
+			c.Block.CIDR.String(),
+			c.Node,
+			c.Namespace,
+			c.Pod,
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docopt/docopt-go"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/clientmgr"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/common"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/constants"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/util"
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+// History shows the IPAM event log.
+func History(args []string) error {
+	doc := constants.DatastoreIntro + `Usage:
+  <BINARY_NAME> ipam history [--ip=<IP>] [--pod=<NAMESPACE/NAME>] [--since=<TIME>] [--config=<CONFIG>] [--allow-version-mismatch]
+
+Options:
+  -h --help                    Show this screen.
+     --ip=<IP>                 Only show the events for this IP address.
+     --pod=<NAMESPACE/NAME>    Only show the events for this pod.
+     --since=<TIME>            Only show the events after this time, either a
+                               duration before now, such as 24h, or an RFC 3339
+                               time, such as 2024-01-02T03:04:05Z.
+  -c --config=<CONFIG>         Path to the file containing connection configuration in
+                               YAML or JSON format.
+                               [default: ` + constants.DefaultConfigPath + `]
+     --allow-version-mismatch  Allow client and cluster versions mismatch.
+
+Description:
+  The ipam history command prints the IP address assignments and releases that
+  have been recorded in the IPAM event log, oldest first. Events are only
+  recorded while the event log is enabled, see
+  '<BINARY_NAME> ipam configure --event-log=true', and are deleted by
+  calico-kube-controllers once they reach the retention limits configured in
+  the KubeControllersConfiguration.
+
+Examples:
+  # Show which pods had 10.4.7.23 over the last two days.
+  <BINARY_NAME> ipam history --ip=10.4.7.23 --since=48h
+`
+	// Replace all instances of BINARY_NAME with the name of the binary.
+	name, _ := util.NameAndDescription()
+	doc = strings.ReplaceAll(doc, "<BINARY_NAME>", name)
+
+	parsedArgs, err := docopt.ParseArgs(doc, args, "")
+	if err != nil {
+		return fmt.Errorf("Invalid option: 'calicoctl %s'. Use flag '--help' to read about a specific subcommand.", strings.Join(args, " "))
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	err = common.CheckVersionMismatch(parsedArgs["--config"], parsedArgs["--allow-version-mismatch"])
+	if err != nil {
+		return err
+	}
+
+	var filter eventFilter
+	if ip, ok := parsedArgs["--ip"].(string); ok {
+		parsed := cnet.ParseIP(ip)
+		if parsed == nil {
+			return fmt.Errorf("Invalid IP address %s", ip)
+		}
+		filter.ip = parsed.String()
+	}
+	if pod, ok := parsedArgs["--pod"].(string); ok {
+		parts := strings.Split(pod, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("Invalid pod %s: must be of the form <namespace>/<name>", pod)
+		}
+		filter.namespace, filter.pod = parts[0], parts[1]
+	}
+	if since, ok := parsedArgs["--since"].(string); ok {
+		filter.since, err = parseSince(since, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	cf := parsedArgs["--config"].(string)
+	client, err := clientmgr.NewClient(cf)
+	if err != nil {
+		return err
+	}
+
+	// Get the backend client.
+	type accessor interface {
+		Backend() bapi.Client
+	}
+	bc := client.(accessor).Backend()
+
+	events, err := ipam.ListEvents(ctx, bc)
+	if err != nil {
+		return fmt.Errorf("Failed to list IPAM events: %s", err)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"TIME", "EVENT", "IP", "NAMESPACE", "POD", "NODE", "CONTAINER", "HANDLE"})
+	var rows [][]string
+	for _, e := range events {
+		if !filter.matches(e) {
+			continue
+		}
+		rows = append(rows, []string{
+			e.Spec.Time.UTC().Format(time.RFC3339),
+			string(e.Spec.Type),
+			e.Spec.IP,
+			e.Spec.Namespace,
+			e.Spec.Pod,
+			e.Spec.Node,
+			e.Spec.ContainerID,
+			e.Spec.HandleID,
+		})
+	}
+	table.AppendBulk(rows)
+	table.Render()
+	return nil
+}
+
+// eventFilter selects the IPAM events to show.  Empty fields match every event.
+type eventFilter struct {
+	ip        string
+	namespace string
+	pod       string
+	since     time.Time
+}
+
+func (f eventFilter) matches(e *libapiv3.IPAMEvent) bool {
+	if f.ip != "" && e.Spec.IP != f.ip {
+		return false
+	}
+	if f.pod != "" && (e.Spec.Namespace != f.namespace || e.Spec.Pod != f.pod) {
+		return false
+	}
+	if !f.since.IsZero() && e.Spec.Time.Time.Before(f.since) {
+		return false
+	}
+	return true
+}
+
+// parseSince parses the --since option, which is either a duration before now or an RFC 3339 time.
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid time %s: must be a duration, such as 24h, or an RFC 3339 time", since)
+	}
+	return t, nil
+}
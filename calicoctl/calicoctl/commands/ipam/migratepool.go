@@ -0,0 +1,174 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docopt/docopt-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/clientmgr"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/common"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/constants"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/util"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/migrate"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+// MigratePool moves running workloads from one IP pool to another.
+func MigratePool(args []string) error {
+	doc := constants.DatastoreIntro + `Usage:
+  <BINARY_NAME> ipam migrate-pool --from=<CIDR> --to=<CIDR> [--batch-size=<N>] [--timeout=<DURATION>] [--controller] [--config=<CONFIG>] [--allow-version-mismatch]
+
+Options:
+  -h --help                    Show this screen.
+     --from=<CIDR>             CIDR of the IP pool to move workloads out of.
+     --to=<CIDR>               CIDR of the IP pool to move workloads into.
+     --batch-size=<N>          Number of pods to evict at a time.
+                               [default: 1]
+     --timeout=<DURATION>      How long to wait for each evicted pod to be replaced
+                               before stopping the migration.
+                               [default: 10m]
+     --controller              Create an IPPoolMigration resource and leave
+                               calico-kube-controllers to perform the migration,
+                               rather than performing it from this command.
+  -c --config=<CONFIG>         Path to the file containing connection configuration in
+                               YAML or JSON format.
+                               [default: ` + constants.DefaultConfigPath + `]
+     --allow-version-mismatch  Allow client and cluster versions mismatch.
+
+Description:
+  The ipam migrate-pool command moves the pods with addresses in one IP pool to
+  another. It disables the source pool, then evicts the pods with addresses in it
+  in batches and waits for each pod to be replaced by a ready pod with an address
+  from the destination pool before evicting the next batch. Pods are evicted using
+  the Kubernetes eviction API, so PodDisruptionBudgets are respected. Once every
+  pod has been moved, the empty blocks in the source pool are released.
+
+  Pods that have no controller to recreate them are not evicted and must be moved
+  manually. If a pod is not replaced in time the migration stops; running the
+  command again resumes it.
+
+  With --controller, the progress of the migration is reported in the status of
+  the IPPoolMigration resource, see '<BINARY_NAME> get ippoolmigrations -o wide'.
+
+Examples:
+  # Move all pods from 192.168.0.0/16 to 10.10.0.0/16, two at a time.
+  <BINARY_NAME> ipam migrate-pool --from=192.168.0.0/16 --to=10.10.0.0/16 --batch-size=2
+`
+	// Replace all instances of BINARY_NAME with the name of the binary.
+	name, _ := util.NameAndDescription()
+	doc = strings.ReplaceAll(doc, "<BINARY_NAME>", name)
+
+	parsedArgs, err := docopt.ParseArgs(doc, args, "")
+	if err != nil {
+		return fmt.Errorf("Invalid option: 'calicoctl %s'. Use flag '--help' to read about a specific subcommand.", strings.Join(args, " "))
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	err = common.CheckVersionMismatch(parsedArgs["--config"], parsedArgs["--allow-version-mismatch"])
+	if err != nil {
+		return err
+	}
+
+	from := parsedArgs["--from"].(string)
+	to := parsedArgs["--to"].(string)
+	batchSize, err := strconv.Atoi(parsedArgs["--batch-size"].(string))
+	if err != nil || batchSize < 1 {
+		return fmt.Errorf("Invalid batch size %v: must be a positive integer", parsedArgs["--batch-size"])
+	}
+	timeout, err := time.ParseDuration(parsedArgs["--timeout"].(string))
+	if err != nil || timeout <= 0 {
+		return fmt.Errorf("Invalid timeout %v: must be a positive duration, such as 10m", parsedArgs["--timeout"])
+	}
+
+	ctx := context.Background()
+	cf := parsedArgs["--config"].(string)
+	client, err := clientmgr.NewClient(cf)
+	if err != nil {
+		return err
+	}
+
+	if parsedArgs["--controller"].(bool) {
+		m := api.NewIPPoolMigration()
+		m.Name, err = migrationName(from, to)
+		if err != nil {
+			return err
+		}
+		m.Spec = api.IPPoolMigrationSpec{
+			From:       from,
+			To:         to,
+			BatchSize:  &batchSize,
+			PodTimeout: &metav1.Duration{Duration: timeout},
+		}
+		if _, err := client.IPPoolMigrations().Create(ctx, m, options.SetOptions{}); err != nil {
+			return fmt.Errorf("Failed to create IPPoolMigration: %s", err)
+		}
+		fmt.Printf("Created IPPoolMigration %s, check its progress with '%s get ippoolmigration %s -o yaml'\n", m.Name, name, m.Name)
+		return nil
+	}
+
+	// Pods are evicted through the Kubernetes API, whichever datastore is in use.
+	cfg, err := clientmgr.LoadClientConfig(cf)
+	if err != nil {
+		return err
+	}
+	_, kubeClient, err := k8s.CreateKubernetesClientset(&cfg.Spec)
+	if err != nil {
+		return fmt.Errorf("Failed to create Kubernetes client: %s", err)
+	}
+
+	migrator, err := migrate.New(client, kubeClient, migrate.Config{
+		From:       from,
+		To:         to,
+		BatchSize:  batchSize,
+		PodTimeout: timeout,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = migrator.Run(ctx, func(p migrate.Progress) {
+		fmt.Println(p.Message)
+	})
+	if err != nil {
+		return fmt.Errorf("Migration from %s to %s did not complete: %s", from, to, err)
+	}
+	return nil
+}
+
+// migrationName returns the name of the IPPoolMigration resource for migrating between the given
+// pools, e.g. "10-0-0-0-16-to-10-1-0-0-16".
+func migrationName(from, to string) (string, error) {
+	var parts []string
+	for _, c := range []string{from, to} {
+		_, cidr, err := cnet.ParseCIDR(c)
+		if err != nil {
+			return "", fmt.Errorf("Invalid CIDR %s: %s", c, err)
+		}
+		parts = append(parts, strings.Trim(strings.NewReplacer(".", "-", ":", "-", "/", "-").Replace(cidr.String()), "-"))
+	}
+	return strings.Join(parts, "-to-"), nil
+}
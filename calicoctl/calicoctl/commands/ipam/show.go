@@ -215,6 +215,33 @@ func showBlockUtilization(ctx context.Context, ipamClient ipam.Interface, showBl
 	return nil
 }
 
+func showQuotaUtilization(ctx context.Context, ipamClient ipam.Interface) error {
+	usage, err := ipamClient.GetUtilization(ctx, ipam.GetUtilizationArgs{})
+	if err != nil {
+		return err
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"IP POOL", "SELECTOR", "NAMESPACE/NODE", "IPS IN USE", "MAX IPS"})
+	for _, poolUse := range usage {
+		for _, q := range poolUse.Quotas {
+			sel, holder := "node: "+q.Quota.NodeSelector, q.Node
+			if q.Quota.NamespaceSelector != "" {
+				sel, holder = "namespace: "+q.Quota.NamespaceSelector, q.Namespace
+			}
+			table.Append([]string{
+				poolUse.Name,
+				sel,
+				holder,
+				fmt.Sprint(q.InUse),
+				fmt.Sprint(q.Quota.MaxAddresses),
+			})
+		}
+	}
+	table.Render()
+
+	return nil
+}
+
 func showConfiguration(ctx context.Context, ipamClient ipam.Interface) error {
 	ipamConfig, err := ipamClient.GetIPAMConfig(ctx)
 	if err != nil {
@@ -246,13 +273,15 @@ func showConfiguration(ctx context.Context, ipamClient ipam.Interface) error {
 // IPAM takes keyword with an IP address then calls the subcommands.
 func Show(args []string) error {
 	doc := constants.DatastoreIntro + `Usage:
-  <BINARY_NAME> ipam show [--ip=<IP> | --show-blocks | --show-borrowed | --show-configuration] [--config=<CONFIG>] [--allow-version-mismatch]
+  <BINARY_NAME> ipam show [--ip=<IP> | --show-blocks | --show-borrowed | --show-quotas | --show-configuration] [--config=<CONFIG>] [--allow-version-mismatch]
 
 Options:
   -h --help                    Show this screen.
      --ip=<IP>                 Report whether this specific IP address is in use.
      --show-blocks             Show detailed information for IP blocks as well as pools.
      --show-borrowed           Show detailed information for "borrowed" IP addresses.
+     --show-quotas             Show the IP addresses held by each namespace and node
+                               that is subject to an IP pool quota.
      --show-configuration      Show current Calico IPAM configuration.
   -c --config=<CONFIG>         Path to the file containing connection configuration in
                                YAML or JSON format.
@@ -301,6 +330,7 @@ Description:
 	passedIP := parsedArgs["--ip"]
 	showBlocks := parsedArgs["--show-blocks"].(bool)
 	showBorrowed := parsedArgs["--show-borrowed"].(bool)
+	showQuotas := parsedArgs["--show-quotas"].(bool)
 	configuration := parsedArgs["--show-configuration"].(bool)
 
 	if passedIP != nil {
@@ -309,6 +339,8 @@ Description:
 		return showBlockUtilization(ctx, ipamClient, true)
 	} else if showBorrowed {
 		return showBorrowedDetails(ctx, ippoolClient, bc)
+	} else if showQuotas {
+		return showQuotaUtilization(ctx, ipamClient)
 	} else if configuration {
 		return showConfiguration(ctx, ipamClient)
 	}
@@ -0,0 +1,219 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docopt/docopt-go"
+	"github.com/olekukonko/tablewriter"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/clientmgr"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/common"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/constants"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/util"
+	"github.com/projectcalico/calico/felix/config"
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/syncersv1/updateprocessors"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+// Config prints the Felix configuration of a node, and where each value comes from.
+func Config(args []string) error {
+	doc := constants.DatastoreIntro + `Usage:
+  <BINARY_NAME> node config <NAME> [--config=<CONFIG>] [--allow-version-mismatch]
+
+Options:
+  -h --help                    Show this screen.
+  -c --config=<CONFIG>         Path to the file containing connection configuration in
+                               YAML or JSON format.
+                               [default: ` + constants.DefaultConfigPath + `]
+     --allow-version-mismatch  Allow client and cluster versions mismatch.
+
+Description:
+  The node config command prints the configuration parameters that Felix is
+  running with on the named node, as reported by Felix, and where each value
+  comes from.  The "default" FelixConfiguration is overridden by the
+  FelixConfigurations whose nodeSelector selects the node, in order of priority,
+  which are overridden by the "node.<NAME>" FelixConfiguration, and then by
+  Felix's local config file and environment variables.
+
+  If Felix has not reported its configuration, for example because it is not
+  running, the command prints the parameters that the FelixConfiguration
+  resources set for the node instead, and the FelixConfiguration that each value
+  comes from.
+
+  Parameters that are not shown take their default values.
+
+Examples:
+  # Show the Felix configuration of node1.
+  <BINARY_NAME> node config node1
+`
+	// Replace all instances of BINARY_NAME with the name of the binary.
+	name, _ := util.NameAndDescription()
+	doc = strings.ReplaceAll(doc, "<BINARY_NAME>", name)
+
+	parsedArgs, err := docopt.ParseArgs(doc, args, "")
+	if err != nil {
+		return fmt.Errorf("Invalid option: 'calicoctl %s'. Use flag '--help' to read about a specific subcommand.", strings.Join(args, " "))
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	err = common.CheckVersionMismatch(parsedArgs["--config"], parsedArgs["--allow-version-mismatch"])
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	cf := parsedArgs["--config"].(string)
+	client, err := clientmgr.NewClient(cf)
+	if err != nil {
+		return err
+	}
+
+	nodeName := parsedArgs["<NAME>"].(string)
+	node, err := client.Nodes().Get(ctx, nodeName, options.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to get node %s: %s", nodeName, err)
+	}
+
+	// Get the backend client.
+	type accessor interface {
+		Backend() bapi.Client
+	}
+	bc := client.(accessor).Backend()
+
+	// Prefer the config that Felix reported, which includes its local config.
+	var params []configParam
+	kvp, err := bc.Get(ctx, model.ResourceKey{Kind: libapiv3.KindFelixConfigReport, Name: nodeName}, "")
+	switch err.(type) {
+	case nil:
+		report := kvp.Value.(*libapiv3.FelixConfigReport)
+		fmt.Printf("Configuration reported by Felix at %s\n\n", report.Spec.Time.Format(time.RFC3339))
+		params = reportedConfig(report)
+	case cerrors.ErrorResourceDoesNotExist:
+		fmt.Print("Felix has not reported its configuration, showing the configuration from the datastore\n\n")
+		fcs, err := client.FelixConfigurations().List(ctx, options.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("Failed to list FelixConfigurations: %s", err)
+		}
+		params, err = effectiveConfig(fcs.Items, node)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("Failed to get the Felix config report of node %s: %s", nodeName, err)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"PARAMETER", "VALUE", "SOURCE"})
+	var rows [][]string
+	for _, p := range params {
+		rows = append(rows, []string{p.name, p.value, p.source})
+	}
+	table.AppendBulk(rows)
+	table.Render()
+	return nil
+}
+
+// configParam is a Felix configuration parameter and where its value comes from.
+type configParam struct {
+	name   string
+	value  string
+	source string
+}
+
+// reportedConfig returns the parameters from the config that Felix reported.
+func reportedConfig(report *libapiv3.FelixConfigReport) []configParam {
+	var params []configParam
+	for _, p := range report.Spec.Parameters {
+		params = append(params, configParam{name: p.Name, value: p.Value, source: p.Source})
+	}
+	return params
+}
+
+// effectiveConfig merges the FelixConfigurations in the same way as Felix does for the node, and
+// returns the parameters sorted by name.
+func effectiveConfig(fcs []apiv3.FelixConfiguration, node *libapiv3.Node) ([]configParam, error) {
+	var defaultConfig, nodeConfig *apiv3.FelixConfiguration
+	var selectorConfigs []*config.NodeSelectorConfig
+	priorities := map[string]int{}
+	for i := range fcs {
+		fc := &fcs[i]
+		switch {
+		case fc.Name == "default":
+			defaultConfig = fc
+		case fc.Name == "node."+node.Name:
+			nodeConfig = fc
+		case updateprocessors.IsNodeSelectorFelixConfigName(fc.Name) && fc.Spec.NodeSelector != "":
+			c, err := config.NodeSelectorConfigFromResource(fc)
+			if err != nil {
+				return nil, err
+			}
+			selectorConfigs = append(selectorConfigs, c)
+			priorities[c.Name] = c.Priority
+		}
+	}
+
+	// Apply the configuration in increasing order of precedence.
+	params := map[string]configParam{}
+	setFrom := func(fc *apiv3.FelixConfiguration) error {
+		if fc == nil {
+			return nil
+		}
+		values, err := updateprocessors.FelixConfigValues(fc)
+		if err != nil {
+			return err
+		}
+		for name, value := range values {
+			params[name] = configParam{name: name, value: value, source: "FelixConfiguration " + fc.Name}
+		}
+		return nil
+	}
+	if err := setFrom(defaultConfig); err != nil {
+		return nil, err
+	}
+	values, sources := config.MergeNodeSelectorConfigs(selectorConfigs, node.Labels)
+	for name, value := range values {
+		params[name] = configParam{
+			name:   name,
+			value:  value,
+			source: fmt.Sprintf("FelixConfiguration %s (priority %d)", sources[name], priorities[sources[name]]),
+		}
+	}
+	if err := setFrom(nodeConfig); err != nil {
+		return nil, err
+	}
+
+	var sorted []configParam
+	for _, p := range params {
+		sorted = append(sorted, p)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].name < sorted[j].name
+	})
+	return sorted, nil
+}
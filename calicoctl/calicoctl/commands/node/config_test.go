@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+)
+
+func felixConfig(name, sel string, priority *int, logSeverity, interfacePrefix string) apiv3.FelixConfiguration {
+	fc := apiv3.NewFelixConfiguration()
+	fc.Name = name
+	fc.Spec.NodeSelector = sel
+	fc.Spec.Priority = priority
+	fc.Spec.LogSeverityScreen = logSeverity
+	fc.Spec.InterfacePrefix = interfacePrefix
+	return *fc
+}
+
+var _ = Describe("node config", func() {
+	It("should merge the FelixConfigurations that apply to the node", func() {
+		high := 10
+		node := libapiv3.NewNode()
+		node.Name = "node1"
+		node.Labels = map[string]string{"rack": "a"}
+
+		params, err := effectiveConfig([]apiv3.FelixConfiguration{
+			felixConfig("default", "", nil, "Info", "cali"),
+			felixConfig("rack-a", "rack == 'a'", nil, "Debug", "foo"),
+			felixConfig("rack-a-high", "rack == 'a'", &high, "Warning", ""),
+			felixConfig("rack-b", "rack == 'b'", &high, "Error", ""),
+			felixConfig("node.node1", "", nil, "", "bar"),
+			felixConfig("node.node2", "", nil, "Fatal", ""),
+		}, node)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(params).To(Equal([]configParam{
+			{name: "InterfacePrefix", value: "bar", source: "FelixConfiguration node.node1"},
+			{name: "LogSeverityScreen", value: "Warning", source: "FelixConfiguration rack-a-high (priority 10)"},
+		}))
+	})
+
+	It("should show the config that Felix reported", func() {
+		report := libapiv3.NewFelixConfigReport()
+		report.Name = "node1"
+		report.Spec.Parameters = []libapiv3.FelixConfigParameter{
+			{Name: "InterfacePrefix", Value: "bar", Source: "datastore (per-host)"},
+			{Name: "LogSeverityScreen", Value: "Info", Source: "environment variable"},
+		}
+		Expect(reportedConfig(report)).To(Equal([]configParam{
+			{name: "InterfacePrefix", value: "bar", source: "datastore (per-host)"},
+			{name: "LogSeverityScreen", value: "Info", source: "environment variable"},
+		}))
+	})
+})
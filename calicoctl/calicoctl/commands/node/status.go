@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/netip"
 	"os"
 	"regexp"
 	"strings"
@@ -32,6 +33,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/projectcalico/calico/calicoctl/calicoctl/util"
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
 )
 
 // Status prints status of the node and returns error (if any)
@@ -78,7 +80,13 @@ Description:
 
 	fmt.Printf("Calico process is running.\n")
 
-	if psContains([]string{"bird"}, processes) || psContains([]string{"bird6"}, processes) {
+	if status, err := bgpstatus.Read(bgpstatus.SocketPath); err == nil {
+		// The native BGP daemon runs instead of BIRD.
+		printNativePeers(status, "4")
+		printNativePeers(status, "6")
+	} else if !errors.Is(err, bgpstatus.ErrNotRunning) {
+		return fmt.Errorf("Error querying the BGP daemon: %v", err)
+	} else if psContains([]string{"bird"}, processes) || psContains([]string{"bird6"}, processes) {
 		// Check if birdv4 process is running, print the BGP peer table if it is, else print a warning
 		if psContains([]string{"bird"}, processes) {
 			if err := printBIRDPeers("4"); err != nil {
@@ -260,6 +268,34 @@ func printBIRDPeers(ipv string) error {
 	return nil
 }
 
+// printNativePeers prints the peers of the native BGP daemon in the given IP version.
+func printNativePeers(status *bgpstatus.Status, ipv string) {
+	fmt.Printf("\nIPv%s BGP status\n", ipv)
+	var peers []bgpPeer
+	for _, p := range status.Peers {
+		addr, err := netip.ParseAddr(p.Address)
+		if err != nil || addr.Is6() != (ipv == "6") {
+			continue
+		}
+		state := "start"
+		if p.Established() {
+			state = "up"
+		}
+		peers = append(peers, bgpPeer{
+			PeerIP:   p.Address,
+			PeerType: bgpTypeMap[p.Type],
+			State:    state,
+			Since:    p.Since.Local().Format(time.DateTime),
+			BGPState: p.State,
+		})
+	}
+	if len(peers) == 0 {
+		fmt.Printf("No IPv%s peers found.\n", ipv)
+		return
+	}
+	printPeers(peers)
+}
+
 // scanBIRDPeers scans through BIRD output to return a slice of bgpPeer
 // structs.
 //
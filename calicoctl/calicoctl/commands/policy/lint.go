@@ -0,0 +1,150 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"github.com/olekukonko/tablewriter"
+	"sigs.k8s.io/yaml"
+
+	"github.com/projectcalico/calico/calicoctl/calicoctl/util"
+	"github.com/projectcalico/calico/felix/policytest"
+)
+
+// Lint analyses the policies in a file or directory for rules that never match and duplicate
+// policies.
+func Lint(args []string) error {
+	doc := `Usage:
+  <BINARY_NAME> policy lint --filename=<FILENAME> [--output=<OUTPUT>] [--allow-version-mismatch]
+
+Options:
+  -h --help                    Show this screen.
+  -f --filename=<FILENAME>     Filename, or directory, of the resources to lint.
+  -o --output=<OUTPUT>         Output format, one of: table, json or yaml.
+                               [default: table]
+     --allow-version-mismatch  Allow client and cluster versions mismatch.
+
+Description:
+  The policy lint command analyses a set of Calico and Kubernetes network
+  policies statically, without a cluster, and reports:
+
+  - ShadowedRule: a rule that never matches, because earlier rules in the same
+    or an earlier tier, or the end of an earlier tier, handle all of its
+    traffic differently.
+  - RedundantRule: a rule that never matches, because earlier rules, or the end
+    of an earlier tier, handle all of its traffic in the same way.
+  - ContradictorySelector: a policy or rule selector that can never match, such
+    as "app == 'a' && app == 'b'".
+  - DuplicatePolicy: a policy with the same selector, types and rules as an
+    earlier policy.
+
+  The analysis is conservative: a rule is only reported if the selectors'
+  label restrictions and the rules' CIDRs, ports and protocols prove that it
+  never matches.
+
+  The resources may be projectcalico.org/v3 NetworkPolicy, GlobalNetworkPolicy,
+  Tier, NetworkSet, GlobalNetworkSet and Profile; networking.k8s.io/v1
+  NetworkPolicy; and v1 Namespace, ServiceAccount and Pod.
+
+  The command exits with an error if it finds any problems.
+
+Examples:
+  # Lint the policies in the policies directory.
+  <BINARY_NAME> policy lint -f policies/
+
+  # Lint the policies in policies.yaml, and print the findings as JSON.
+  <BINARY_NAME> policy lint -f policies.yaml -o json
+`
+	// Replace all instances of BINARY_NAME with the name of the binary.
+	name, _ := util.NameAndDescription()
+	doc = strings.ReplaceAll(doc, "<BINARY_NAME>", name)
+
+	parsedArgs, err := docopt.ParseArgs(doc, args, "")
+	if err != nil {
+		return fmt.Errorf("Invalid option: 'calicoctl %s'. Use flag '--help' to read about a specific subcommand.", strings.Join(args, " "))
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	output := parsedArgs["--output"].(string)
+	switch output {
+	case "table", "json", "yaml":
+	default:
+		return fmt.Errorf("unrecognised output format '%s'", output)
+	}
+
+	objs, err := policytest.LoadResources(parsedArgs["--filename"].(string))
+	if err != nil {
+		return err
+	}
+	findings, err := policytest.Lint(objs)
+	if err != nil {
+		return err
+	}
+	if err := printFindings(os.Stdout, findings, output); err != nil {
+		return err
+	}
+	if len(findings) > 0 {
+		return fmt.Errorf("found %d problems", len(findings))
+	}
+	return nil
+}
+
+// printFindings prints the lint findings in the given output format.
+func printFindings(w io.Writer, findings []*policytest.Finding, output string) error {
+	if findings == nil {
+		findings = []*policytest.Finding{}
+	}
+	switch output {
+	case "json":
+		b, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(b))
+		return nil
+	case "yaml":
+		b, err := yaml.Marshal(findings)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(b))
+		return nil
+	}
+
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "No problems found")
+		return nil
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"TYPE", "TIER", "POLICY", "RULE", "MESSAGE"})
+	table.SetAutoWrapText(false)
+	for _, f := range findings {
+		rule := ""
+		if f.Rule != 0 {
+			rule = fmt.Sprintf("%s %d", strings.ToLower(f.Direction), f.Rule)
+		}
+		table.Append([]string{string(f.Type), f.Tier, f.Policy, rule, f.Message})
+	}
+	table.Render()
+	return nil
+}
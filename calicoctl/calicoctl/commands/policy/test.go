@@ -0,0 +1,147 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/projectcalico/calico/calicoctl/calicoctl/util"
+	"github.com/projectcalico/calico/felix/policytest"
+)
+
+// Test runs the connectivity tests in a file against the policies that it refers to.
+func Test(args []string) error {
+	doc := `Usage:
+  <BINARY_NAME> policy test --filename=<FILENAME> [--allow-version-mismatch]
+
+Options:
+  -h --help                    Show this screen.
+  -f --filename=<FILENAME>     Filename of the tests to run.
+     --allow-version-mismatch  Allow client and cluster versions mismatch.
+
+Description:
+  The policy test command checks connectivity assertions against a set of
+  Calico and Kubernetes network policies, without a cluster.  The policies and
+  the endpoints are evaluated with the same calculation and rule semantics as
+  Felix, as if all the endpoints were on one node.  Only layer 3 and 4 matches
+  are evaluated; HTTP matches are ignored, and rules that match Kubernetes
+  services never match.
+
+  The tests file lists the files or directories of resources to test, relative
+  to the tests file; the namespaces, service accounts and endpoints (fake pods)
+  to test; and the tests.  For example:
+
+    resources:
+    - policies/
+    namespaces:
+    - name: shop
+      labels:
+        team: retail
+    endpoints:
+    - name: frontend
+      namespace: shop
+      labels:
+        app: frontend
+      ips: [10.0.0.1]
+    - name: backend
+      namespace: shop
+      labels:
+        app: backend
+      ips: [10.0.0.2]
+      ports:
+      - name: http
+        port: 8080
+    tests:
+    - name: frontend can reach backend
+      from: {endpoint: shop/frontend}
+      to: {endpoint: shop/backend}
+      protocol: TCP
+      port: 8080
+      expect: Allow
+    - name: backend cannot reach the internet
+      from: {endpoint: shop/backend}
+      to: {ip: 203.0.113.1}
+      port: 443
+      expect: Deny
+
+  The resources may be projectcalico.org/v3 NetworkPolicy, GlobalNetworkPolicy,
+  Tier, NetworkSet, GlobalNetworkSet and Profile; networking.k8s.io/v1
+  NetworkPolicy; and v1 Namespace, ServiceAccount and Pod.
+
+  The command prints the result of each test, and exits with an error if any
+  test fails.
+
+Examples:
+  # Run the tests in tests.yaml.
+  <BINARY_NAME> policy test -f tests.yaml
+`
+	// Replace all instances of BINARY_NAME with the name of the binary.
+	name, _ := util.NameAndDescription()
+	doc = strings.ReplaceAll(doc, "<BINARY_NAME>", name)
+
+	parsedArgs, err := docopt.ParseArgs(doc, args, "")
+	if err != nil {
+		return fmt.Errorf("Invalid option: 'calicoctl %s'. Use flag '--help' to read about a specific subcommand.", strings.Join(args, " "))
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	filename := parsedArgs["--filename"].(string)
+	suite, err := policytest.LoadSuite(filename)
+	if err != nil {
+		return err
+	}
+	results, err := policytest.Run(suite)
+	if err != nil {
+		return err
+	}
+	return printResults(os.Stdout, results)
+}
+
+// printResults prints a table of the test results, and returns an error if any failed.
+func printResults(w io.Writer, results []*policytest.Result) error {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"TEST", "RESULT", "EXPECTED", "VERDICT", "EGRESS", "INGRESS"})
+	failed := 0
+	for _, r := range results {
+		result := "PASS"
+		if !r.Passed() {
+			result = "FAIL"
+			failed++
+		}
+		table.Append([]string{
+			r.Test.Name,
+			result,
+			string(r.Test.Expect),
+			string(r.Verdict),
+			r.Egress.String(),
+			r.Ingress.String(),
+		})
+	}
+	table.Render()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tests failed", failed, len(results))
+	}
+	fmt.Fprintf(w, "All %d tests passed\n", len(results))
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcemgr
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+func init() {
+	registerResource(
+		api.NewIPPoolMigration(),
+		newIPPoolMigrationList(),
+		false,
+		[]string{"ippoolmigration", "ippoolmigrations", "ipm", "ipms"},
+		[]string{"NAME", "FROM", "TO", "PHASE"},
+		[]string{"NAME", "FROM", "TO", "PHASE", "MIGRATED", "TOTAL", "SKIPPED", "MESSAGE"},
+		map[string]string{
+			"NAME":     "{{.ObjectMeta.Name}}",
+			"FROM":     "{{.Spec.From}}",
+			"TO":       "{{.Spec.To}}",
+			"PHASE":    "{{.Status.Phase}}",
+			"MIGRATED": "{{.Status.MigratedPods}}",
+			"TOTAL":    "{{.Status.TotalPods}}",
+			"SKIPPED":  "{{.Status.SkippedPods}}",
+			"MESSAGE":  "{{.Status.Message}}",
+		},
+		func(ctx context.Context, client client.Interface, resource ResourceObject) (ResourceObject, error) {
+			r := resource.(*api.IPPoolMigration)
+			return client.IPPoolMigrations().Create(ctx, r, options.SetOptions{})
+		},
+		func(ctx context.Context, client client.Interface, resource ResourceObject) (ResourceObject, error) {
+			r := resource.(*api.IPPoolMigration)
+			return client.IPPoolMigrations().Update(ctx, r, options.SetOptions{})
+		},
+		func(ctx context.Context, client client.Interface, resource ResourceObject) (ResourceObject, error) {
+			r := resource.(*api.IPPoolMigration)
+			return client.IPPoolMigrations().Delete(ctx, r.Name, options.DeleteOptions{ResourceVersion: r.ResourceVersion})
+		},
+		func(ctx context.Context, client client.Interface, resource ResourceObject) (ResourceObject, error) {
+			r := resource.(*api.IPPoolMigration)
+			return client.IPPoolMigrations().Get(ctx, r.Name, options.GetOptions{ResourceVersion: r.ResourceVersion})
+		},
+		func(ctx context.Context, client client.Interface, resource ResourceObject) (ResourceListObject, error) {
+			r := resource.(*api.IPPoolMigration)
+			return client.IPPoolMigrations().List(ctx, options.ListOptions{ResourceVersion: r.ResourceVersion, Name: r.Name})
+		},
+	)
+}
+
+// newIPPoolMigrationList creates a new (zeroed) IPPoolMigrationList struct with the TypeMetadata initialised to the current
+// version.
+func newIPPoolMigrationList() *api.IPPoolMigrationList {
+	return &api.IPPoolMigrationList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       api.KindIPPoolMigrationList,
+			APIVersion: api.GroupVersionCurrent,
+		},
+	}
+}
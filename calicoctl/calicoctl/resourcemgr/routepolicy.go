@@ -0,0 +1,76 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcemgr
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+func init() {
+	registerResource(
+		api.NewRoutePolicy(),
+		newRoutePolicyList(),
+		false,
+		[]string{"routepolicy", "routepolicies", "rpol", "rpols"},
+		[]string{"NAME", "SELECTOR", "TABLE"},
+		[]string{"NAME", "SELECTOR", "TABLE", "NEXTHOP", "INTERFACE", "DESTINATIONS"},
+		map[string]string{
+			"NAME":         "{{.ObjectMeta.Name}}",
+			"SELECTOR":     "{{.Spec.Selector}}",
+			"TABLE":        "{{.Spec.Table}}",
+			"NEXTHOP":      "{{.Spec.NextHop}}",
+			"INTERFACE":    "{{.Spec.Interface}}",
+			"DESTINATIONS": "{{joinAndTruncate .Spec.Destinations \",\" 80}}",
+		},
+		func(ctx context.Context, client client.Interface, resource ResourceObject) (ResourceObject, error) {
+			r := resource.(*api.RoutePolicy)
+			return client.RoutePolicies().Create(ctx, r, options.SetOptions{})
+		},
+		func(ctx context.Context, client client.Interface, resource ResourceObject) (ResourceObject, error) {
+			r := resource.(*api.RoutePolicy)
+			return client.RoutePolicies().Update(ctx, r, options.SetOptions{})
+		},
+		func(ctx context.Context, client client.Interface, resource ResourceObject) (ResourceObject, error) {
+			r := resource.(*api.RoutePolicy)
+			return client.RoutePolicies().Delete(ctx, r.Name, options.DeleteOptions{ResourceVersion: r.ResourceVersion})
+		},
+		func(ctx context.Context, client client.Interface, resource ResourceObject) (ResourceObject, error) {
+			r := resource.(*api.RoutePolicy)
+			return client.RoutePolicies().Get(ctx, r.Name, options.GetOptions{ResourceVersion: r.ResourceVersion})
+		},
+		func(ctx context.Context, client client.Interface, resource ResourceObject) (ResourceListObject, error) {
+			r := resource.(*api.RoutePolicy)
+			return client.RoutePolicies().List(ctx, options.ListOptions{ResourceVersion: r.ResourceVersion, Name: r.Name})
+		},
+	)
+}
+
+// newRoutePolicyList creates a new (zeroed) RoutePolicyList struct with the TypeMetadata initialised to the current
+// version.
+func newRoutePolicyList() *api.RoutePolicyList {
+	return &api.RoutePolicyList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       api.KindRoutePolicyList,
+			APIVersion: api.GroupVersionCurrent,
+		},
+	}
+}
@@ -422,6 +422,28 @@ func DeleteContainerWithIdAndIfaceName(netconf, netnspath, podName, podNamespace
 	return
 }
 
+// RunCNIPluginCommand runs a CNI command that doesn't return a result, such as CHECK, GC or
+// STATUS, and returns the error that the plugin reported, if any.
+func RunCNIPluginCommand(netconf, command, containerId, netnspath, ifaceName string) error {
+	env := []string{
+		fmt.Sprintf("CNI_COMMAND=%s", command),
+		fmt.Sprintf("CNI_CONTAINERID=%s", containerId),
+		fmt.Sprintf("CNI_NETNS=%s", netnspath),
+		fmt.Sprintf("CNI_IFNAME=%s", ifaceName),
+		fmt.Sprintf("CNI_PATH=%s", os.Getenv("BIN")),
+	}
+	args := &cniArgs{env}
+
+	// Capture stderr (CNI plugin logs) so we can properly emit them with ginkgo.
+	var customExec = &invoke.DefaultExec{
+		RawExec: &invoke.RawExec{Stderr: ginkgo.GinkgoWriter},
+	}
+
+	log.Debugf("Calling CNI plugin with the following env vars: %v", env)
+	pluginPath := fmt.Sprintf("%s/%s", os.Getenv("BIN"), os.Getenv("PLUGIN"))
+	return invoke.ExecPluginWithoutResult(context.Background(), pluginPath, []byte(netconf), args, customExec)
+}
+
 func Cmd(cmd string) string {
 	_, _ = ginkgo.GinkgoWriter.Write([]byte(fmt.Sprintf("Running command [%s]\n", cmd)))
 	out, err := exec.Command("bash", "-c", cmd).Output()
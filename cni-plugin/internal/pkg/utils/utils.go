@@ -714,6 +714,61 @@ func ReleaseIPAllocation(logger *logrus.Entry, conf types.NetConf, args *skel.Cm
 	}
 }
 
+// Error codes defined by the CNI 1.1 spec for the STATUS verb.
+const (
+	// ErrPluginNotAvailable indicates that the plugin can't currently service ADD requests.
+	ErrPluginNotAvailable uint = 50
+	// ErrLimitedConnectivity indicates that the plugin is not available and that existing
+	// containers in the network may have limited connectivity.
+	ErrLimitedConnectivity uint = 51
+)
+
+// Calico-specific error codes.  The CNI spec reserves codes of 100 and above for plugins.
+const (
+	// ErrIPQuotaExceeded indicates that assigning an address would take the pod's namespace or
+	// node over one of the quotas of the IP pools.
+	ErrIPQuotaExceeded uint = 100
+)
+
+// CheckDatastoreReady checks that the datastore is reachable and that Calico has marked it as
+// ready to process requests.
+func CheckDatastoreReady(ctx context.Context, c client.Interface) error {
+	ci, err := c.ClusterInformation().Get(ctx, "default", options.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting ClusterInformation: %v", err)
+	}
+	if ci.Spec.DatastoreReady == nil || !*ci.Spec.DatastoreReady {
+		logrus.Info("Upgrade may be in progress, ready flag is not set")
+		return fmt.Errorf("Calico is currently not ready to process requests")
+	}
+	return nil
+}
+
+// ValidAttachments returns the set of container IDs with attachments that the runtime passed to
+// a CNI GC request.  Resources that belong to any other container on this network may be
+// released.
+func ValidAttachments(stdinData []byte) (map[string]bool, error) {
+	conf := cnitypes.NetConf{}
+	if err := json.Unmarshal(stdinData, &conf); err != nil {
+		return nil, fmt.Errorf("failed to load netconf: %v", err)
+	}
+	valid := map[string]bool{}
+	for _, a := range conf.ValidAttachments {
+		valid[a.ContainerID] = true
+	}
+	return valid, nil
+}
+
+// ContainerIDFromHandle returns the container ID from an IPAM handle generated by GetHandleID for
+// the given network, or false if the handle doesn't belong to that network.
+func ContainerIDFromHandle(netName, handleID string) (string, bool) {
+	containerID, found := strings.CutPrefix(handleID, netName+".")
+	if !found || containerID == "" {
+		return "", false
+	}
+	return containerID, true
+}
+
 // Set up logging for both Calico and libcalico using the provided log level,
 func ConfigureLogging(conf types.NetConf) {
 	if strings.EqualFold(conf.LogLevel, "debug") {
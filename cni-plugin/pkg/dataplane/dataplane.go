@@ -45,6 +45,12 @@ type Dataplane interface {
 	CleanUpNamespace(args *skel.CmdArgs) error
 }
 
+// Checker is implemented by dataplanes that can verify, for the CNI CHECK command, that the
+// networking set up by DoNetworking is still in place.
+type Checker interface {
+	CheckNetworking(args *skel.CmdArgs, result *cniv1.Result, endpoint *api.WorkloadEndpoint) error
+}
+
 func GetDataplane(conf types.NetConf, logger *logrus.Entry) (Dataplane, error) {
 	name, ok := conf.DataplaneOptions["type"]
 	if !ok {
@@ -0,0 +1,140 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linux
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	cniv1 "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+
+	api "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+)
+
+// CheckNetworking verifies that the veth pair, addresses and routes that DoNetworking set up for
+// the endpoint are still present.
+func (d *linuxDataplane) CheckNetworking(args *skel.CmdArgs, result *cniv1.Result, endpoint *api.WorkloadEndpoint) error {
+	hostVethName := endpoint.Spec.InterfaceName
+	if hostVethName == "" {
+		return fmt.Errorf("WorkloadEndpoint %s has no interface name", endpoint.Name)
+	}
+
+	hostNlHandle, err := netlink.NewHandle(syscall.NETLINK_ROUTE)
+	if err != nil {
+		return fmt.Errorf("failed to create host netlink handle: %v", err)
+	}
+	defer hostNlHandle.Close()
+
+	hostVeth, err := hostNlHandle.LinkByName(hostVethName)
+	if err != nil {
+		return fmt.Errorf("failed to lookup %q: %v", hostVethName, err)
+	}
+	if _, ok := hostVeth.(*netlink.Veth); !ok {
+		return fmt.Errorf("host interface %q is of type %q, expected a veth", hostVethName, hostVeth.Type())
+	}
+	if hostVeth.Attrs().Flags&net.FlagUp == 0 {
+		return fmt.Errorf("host interface %q is not up", hostVethName)
+	}
+	if err := checkHostRoutes(hostNlHandle, hostVeth, result); err != nil {
+		return err
+	}
+
+	return ns.WithNetNSPath(args.Netns, func(_ ns.NetNS) error {
+		contVeth, err := netlink.LinkByName(args.IfName)
+		if err != nil {
+			return fmt.Errorf("failed to lookup %q: %v", args.IfName, err)
+		}
+		if contVeth.Attrs().Flags&net.FlagUp == 0 {
+			return fmt.Errorf("container interface %q is not up", args.IfName)
+		}
+		if endpoint.Spec.MAC != "" && contVeth.Attrs().HardwareAddr.String() != endpoint.Spec.MAC {
+			return fmt.Errorf("container interface %q has MAC %s, expected %s",
+				args.IfName, contVeth.Attrs().HardwareAddr, endpoint.Spec.MAC)
+		}
+		return checkContainerAddrsAndRoutes(contVeth, result)
+	})
+}
+
+// checkHostRoutes checks that there is a route to each of the workload's IPs via the host side of
+// the veth.
+func checkHostRoutes(hostNlHandle *netlink.Handle, hostVeth netlink.Link, result *cniv1.Result) error {
+	routes, err := hostNlHandle.RouteList(hostVeth, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list routes for %q: %v", hostVeth.Attrs().Name, err)
+	}
+	for _, ipAddr := range result.IPs {
+		found := false
+		for _, r := range routes {
+			if r.Dst != nil && r.Dst.IP.Equal(ipAddr.Address.IP) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no route to %s via host interface %q", ipAddr.Address.IP, hostVeth.Attrs().Name)
+		}
+	}
+	return nil
+}
+
+// checkContainerAddrsAndRoutes checks that the container side of the veth has each of the
+// workload's IPs, and that there is at least one route via the host for each IP family in use.
+func checkContainerAddrsAndRoutes(contVeth netlink.Link, result *cniv1.Result) error {
+	addrs, err := netlink.AddrList(contVeth, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list addresses for %q: %v", contVeth.Attrs().Name, err)
+	}
+	families := map[int]bool{}
+	for _, ipAddr := range result.IPs {
+		found := false
+		for _, a := range addrs {
+			if a.IP.Equal(ipAddr.Address.IP) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("container interface %q is missing address %s", contVeth.Attrs().Name, ipAddr.Address.IP)
+		}
+		if ipAddr.Address.IP.To4() != nil {
+			families[netlink.FAMILY_V4] = true
+		} else {
+			families[netlink.FAMILY_V6] = true
+		}
+	}
+
+	for family := range families {
+		routes, err := netlink.RouteList(contVeth, family)
+		if err != nil {
+			return fmt.Errorf("failed to list routes for %q: %v", contVeth.Attrs().Name, err)
+		}
+		found := false
+		for _, r := range routes {
+			if r.Gw != nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("container interface %q has no routes via the host for address family %d",
+				contVeth.Attrs().Name, family)
+		}
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ package linux
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -206,7 +207,15 @@ func (d *linuxDataplane) DoNetworking(
 				},
 			)
 
-			if err != nil {
+			// If the pod has more than one Calico interface then the route to the gateway
+			// already exists via the first interface.  Routes via this interface then need to
+			// be marked as on-link, since the gateway isn't reachable via this interface as far
+			// as the kernel is concerned.
+			sharedGateway := false
+			if errors.Is(err, syscall.EEXIST) {
+				d.logger.Info("Gateway route already exists via another interface, using on-link routes")
+				sharedGateway = true
+			} else if err != nil {
 				return fmt.Errorf("failed to add route inside the container: %v", err)
 			}
 
@@ -216,7 +225,17 @@ func (d *linuxDataplane) DoNetworking(
 					continue
 				}
 				d.logger.WithField("route", r).Debug("Adding IPv4 route")
-				if err = ip.AddRoute(r, gw, contVeth); err != nil {
+				if sharedGateway {
+					err = netlink.RouteAdd(&netlink.Route{
+						LinkIndex: contVeth.Attrs().Index,
+						Dst:       r,
+						Gw:        gw,
+						Flags:     int(netlink.FLAG_ONLINK),
+					})
+				} else {
+					err = ip.AddRoute(r, gw, contVeth)
+				}
+				if err != nil {
 					return fmt.Errorf("failed to add IPv4 route for %v via %v: %v", r, gw, err)
 				}
 			}
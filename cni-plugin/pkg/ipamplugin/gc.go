@@ -0,0 +1,189 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipamplugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	cniv1 "github.com/containernetworking/cni/pkg/types/100"
+	cniSpecVersion "github.com/containernetworking/cni/pkg/version"
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/cni-plugin/internal/pkg/utils"
+	"github.com/projectcalico/calico/cni-plugin/pkg/types"
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
+)
+
+type backendClientAccessor interface {
+	Backend() bapi.Client
+}
+
+// cmdCheck verifies that the IPs in the previous result are still allocated to the container's
+// handle.
+func cmdCheck(args *skel.CmdArgs) error {
+	conf := types.NetConf{}
+	if err := json.Unmarshal(args.StdinData, &conf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+	utils.ConfigureLogging(conf)
+
+	netConf := cnitypes.NetConf{}
+	if err := json.Unmarshal(args.StdinData, &netConf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+	if err := cniSpecVersion.ParsePrevResult(&netConf); err != nil {
+		return err
+	}
+	if netConf.PrevResult == nil {
+		return fmt.Errorf("CHECK requires a prevResult")
+	}
+	result, err := cniv1.NewResultFromResult(netConf.PrevResult)
+	if err != nil {
+		return err
+	}
+
+	calicoClient, err := utils.CreateClient(conf)
+	if err != nil {
+		return err
+	}
+
+	handleID := utils.GetHandleID(conf.Name, args.ContainerID, "")
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+	ips, err := calicoClient.IPAM().IPsByHandle(ctx, handleID)
+	if err != nil {
+		return fmt.Errorf("error getting IPs for handle %s: %v", handleID, err)
+	}
+	allocated := map[string]bool{}
+	for _, ip := range ips {
+		allocated[ip.String()] = true
+	}
+	for _, ipc := range result.IPs {
+		if !allocated[ipc.Address.IP.String()] {
+			return fmt.Errorf("IP %s is not allocated to handle %s", ipc.Address.IP, handleID)
+		}
+	}
+	return nil
+}
+
+// cmdGC releases the IPs allocated to this node, on this network, for containers that aren't in
+// the runtime's list of valid attachments.
+func cmdGC(args *skel.CmdArgs) error {
+	conf := types.NetConf{}
+	if err := json.Unmarshal(args.StdinData, &conf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+	utils.ConfigureLogging(conf)
+
+	valid, err := utils.ValidAttachments(args.StdinData)
+	if err != nil {
+		return err
+	}
+	nodename := utils.DetermineNodename(conf)
+	logger := logrus.WithFields(logrus.Fields{"Node": nodename, "Network": conf.Name})
+
+	calicoClient, err := utils.CreateClient(conf)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	handles, err := staleHandles(ctx, calicoClient, conf.Name, nodename, valid)
+	if err != nil {
+		return err
+	}
+	if len(handles) == 0 {
+		logger.Debug("No leaked IPAM handles found")
+		return nil
+	}
+
+	// Serialise with ADD and DEL in the same way as those commands.
+	unlock := acquireIPAMLockBestEffort(conf.IPAMLockFile)
+	defer unlock()
+
+	for _, handleID := range handles {
+		if err := calicoClient.IPAM().ReleaseByHandle(ctx, handleID); err != nil {
+			if _, ok := err.(errors.ErrorResourceDoesNotExist); !ok {
+				logger.WithError(err).WithField("HandleID", handleID).Error("Failed to release address")
+				return err
+			}
+			continue
+		}
+		logger.WithField("HandleID", handleID).Info("Released leaked address using handleID")
+	}
+	return nil
+}
+
+// staleHandles returns the handles for the network's allocations on this node that belong to
+// containers which aren't in the set of valid attachments.
+func staleHandles(ctx context.Context, c client.Interface, network, nodename string, valid map[string]bool) ([]string, error) {
+	accessor, ok := c.(backendClientAccessor)
+	if !ok {
+		return nil, fmt.Errorf("client does not provide access to the backend")
+	}
+	blocks, err := accessor.Backend().List(ctx, model.BlockListOptions{}, "")
+	if err != nil {
+		return nil, fmt.Errorf("error listing IPAM blocks: %v", err)
+	}
+
+	seen := map[string]bool{}
+	var handles []string
+	for _, kvp := range blocks.KVPairs {
+		block := kvp.Value.(*model.AllocationBlock)
+		for _, attr := range block.Attributes {
+			if attr.AttrPrimary == nil || attr.AttrSecondary[ipam.AttributeNode] != nodename {
+				continue
+			}
+			handleID := *attr.AttrPrimary
+			containerID, ok := utils.ContainerIDFromHandle(network, handleID)
+			if !ok || valid[containerID] || seen[handleID] {
+				continue
+			}
+			seen[handleID] = true
+			handles = append(handles, handleID)
+		}
+	}
+	return handles, nil
+}
+
+// cmdStatus reports whether the datastore is reachable and ready.
+func cmdStatus(args *skel.CmdArgs) error {
+	conf := types.NetConf{}
+	if err := json.Unmarshal(args.StdinData, &conf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+	utils.ConfigureLogging(conf)
+
+	calicoClient, err := utils.CreateClient(conf)
+	if err != nil {
+		return cnitypes.NewError(utils.ErrPluginNotAvailable, "Calico IPAM is not ready", err.Error())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := utils.CheckDatastoreReady(ctx, calicoClient); err != nil {
+		return cnitypes.NewError(utils.ErrPluginNotAvailable, "Calico IPAM is not ready", err.Error())
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ package ipamplugin
 import (
 	"context"
 	"encoding/json"
+	goerrors "errors"
 	"flag"
 	"fmt"
 	"net"
@@ -102,13 +103,15 @@ func Main(version string) {
 	}
 
 	funcs := skel.CNIFuncs{
-		Add:   cmdAdd,
-		Check: nil,
-		Del:   cmdDel,
+		Add:    cmdAdd,
+		Check:  cmdCheck,
+		Del:    cmdDel,
+		GC:     cmdGC,
+		Status: cmdStatus,
 	}
 
 	skel.PluginMainFuncs(funcs,
-		cniSpecVersion.PluginSupports("0.1.0", "0.2.0", "0.3.0", "0.3.1", "0.4.0", "1.0.0"),
+		cniSpecVersion.PluginSupports("0.1.0", "0.2.0", "0.3.0", "0.3.1", "0.4.0", "1.0.0", "1.1.0"),
 		"Calico CNI IPAM "+version)
 }
 
@@ -281,7 +284,9 @@ func cmdAdd(args *skel.CmdArgs) error {
 			v6ips = v6Assignments.IPs
 		}
 		logger.Infof("Calico CNI IPAM assigned addresses IPv4=%v IPv6=%v", v4ips, v6ips)
-		if err != nil {
+		if goerrors.Is(err, ipam.ErrQuotaExceeded) {
+			return cnitypes.NewError(utils.ErrIPQuotaExceeded, "IP address quota exceeded", err.Error())
+		} else if err != nil {
 			return err
 		}
 
@@ -0,0 +1,297 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	cniv1 "github.com/containernetworking/cni/pkg/types/100"
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/cni-plugin/internal/pkg/utils"
+	"github.com/projectcalico/calico/cni-plugin/pkg/dataplane"
+	"github.com/projectcalico/calico/cni-plugin/pkg/types"
+	libapi "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	k8sconversion "github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/conversion"
+	k8sresources "github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/resources"
+	calicoclient "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	libipam "github.com/projectcalico/calico/libcalico-go/lib/ipam"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+// addAdditionalNetworks attaches the pod to the additional networks listed in its annotations.
+// Each network gets its own veth and WorkloadEndpoint.  The IPs are assigned from the network's
+// pools under the same IPAM handle as the pod's primary interface, so they are released along with
+// the primary IPs when the pod is deleted.
+func addAdditionalNetworks(
+	ctx context.Context,
+	args *skel.CmdArgs,
+	conf types.NetConf,
+	epIDs utils.WEPIdentifiers,
+	calicoClient calicoclient.Interface,
+	d dataplane.Dataplane,
+	primary *libapi.WorkloadEndpoint,
+	annot map[string]string,
+	logger *logrus.Entry,
+) error {
+	networks, err := k8sconversion.ParseAdditionalNetworks(annot)
+	if err != nil {
+		return err
+	}
+	if len(networks) == 0 {
+		return nil
+	}
+	if conf.IPAM.Type != "calico-ipam" {
+		return fmt.Errorf("additional networks are not compatible with configured IPAM: %s", conf.IPAM.Type)
+	}
+	if args.IfName != k8sconversion.DefaultPodInterface {
+		return fmt.Errorf("additional networks require the primary interface to be %s, not %s",
+			k8sconversion.DefaultPodInterface, args.IfName)
+	}
+
+	for _, network := range networks {
+		netLogger := logger.WithFields(logrus.Fields{"network": network.Name, "interface": network.Interface})
+		if err := addAdditionalNetwork(ctx, args, conf, epIDs, calicoClient, d, primary, network, netLogger); err != nil {
+			return fmt.Errorf("failed to add additional network %s: %w", network.Name, err)
+		}
+	}
+	return nil
+}
+
+func addAdditionalNetwork(
+	ctx context.Context,
+	args *skel.CmdArgs,
+	conf types.NetConf,
+	epIDs utils.WEPIdentifiers,
+	calicoClient calicoclient.Interface,
+	d dataplane.Dataplane,
+	primary *libapi.WorkloadEndpoint,
+	network k8sconversion.AdditionalNetwork,
+	logger *logrus.Entry,
+) error {
+	v4Pools, err := utils.ResolvePools(ctx, calicoClient, network.IPv4Pools, true)
+	if err != nil {
+		return err
+	}
+	v6Pools, err := utils.ResolvePools(ctx, calicoClient, network.IPv6Pools, false)
+	if err != nil {
+		return err
+	}
+
+	result, err := assignAdditionalNetworkIPs(ctx, conf, epIDs, calicoClient, v4Pools, v6Pools, logger)
+	if err != nil {
+		return err
+	}
+
+	// Route the network's pools via the new interface, leaving the default route on the primary
+	// interface.
+	var routes []*net.IPNet
+	for _, pool := range append(v4Pools, v6Pools...) {
+		routes = append(routes, &net.IPNet{IP: pool.IP, Mask: pool.Mask})
+	}
+
+	endpointArgs := *args
+	endpointArgs.IfName = network.Interface
+	wepIDs := epIDs.WorkloadEndpointIdentifiers
+	wepIDs.Endpoint = network.Interface
+	wepName, err := wepIDs.CalculateWorkloadEndpointName(false)
+	if err != nil {
+		return err
+	}
+
+	labels := make(map[string]string, len(primary.Labels)+1)
+	for k, v := range primary.Labels {
+		labels[k] = v
+	}
+	labels[k8sconversion.LabelNetwork] = network.Name
+
+	endpoint := libapi.NewWorkloadEndpoint()
+	endpoint.Name = wepName
+	endpoint.Namespace = primary.Namespace
+	endpoint.Labels = labels
+	endpoint.GenerateName = primary.GenerateName
+	endpoint.Spec.Endpoint = network.Interface
+	endpoint.Spec.Node = primary.Spec.Node
+	endpoint.Spec.Orchestrator = primary.Spec.Orchestrator
+	endpoint.Spec.Pod = primary.Spec.Pod
+	endpoint.Spec.ContainerID = epIDs.ContainerID
+	endpoint.Spec.Profiles = primary.Spec.Profiles
+	endpoint.Spec.ServiceAccountName = primary.Spec.ServiceAccountName
+	if err = utils.PopulateEndpointNets(endpoint, result); err != nil {
+		return err
+	}
+
+	desiredVethName := k8sconversion.NewConverter().VethNameForEndpoint(epIDs.Namespace, epIDs.Pod, network.Interface)
+	hostVethName, contVethMac, err := d.DoNetworking(
+		ctx, calicoClient, &endpointArgs, result, desiredVethName, routes, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	// The endpoint hasn't been written yet, so the caller's clean up won't find this interface;
+	// remove it here if we fail from now on.
+	cleanUpInterface := func() {
+		if err := d.CleanUpNamespace(&endpointArgs); err != nil {
+			logger.WithError(err).Warn("Failed to clean up interface for additional network")
+		}
+	}
+	mac, err := net.ParseMAC(contVethMac)
+	if err != nil {
+		cleanUpInterface()
+		return err
+	}
+	endpoint.Spec.MAC = mac.String()
+	endpoint.Spec.InterfaceName = hostVethName
+
+	ctxPatchCNI := k8sresources.ContextWithPatchMode(ctx, k8sresources.PatchModeCNI)
+	if _, err = utils.CreateOrUpdate(ctxPatchCNI, calicoClient, endpoint); err != nil {
+		cleanUpInterface()
+		return err
+	}
+	logger.WithField("endpoint", endpoint).Info("Wrote endpoint for additional network to datastore")
+	return nil
+}
+
+// assignAdditionalNetworkIPs assigns an IP from each of the given pool families.
+func assignAdditionalNetworkIPs(
+	ctx context.Context,
+	conf types.NetConf,
+	epIDs utils.WEPIdentifiers,
+	calicoClient calicoclient.Interface,
+	v4Pools, v6Pools []cnet.IPNet,
+	logger *logrus.Entry,
+) (*cniv1.Result, error) {
+	handleID := utils.GetHandleID(conf.Name, epIDs.ContainerID, epIDs.WEPName)
+	assignArgs := libipam.AutoAssignArgs{
+		HandleID:  &handleID,
+		Hostname:  epIDs.Node,
+		IPv4Pools: v4Pools,
+		IPv6Pools: v6Pools,
+		Attrs: map[string]string{
+			libipam.AttributeNode:      epIDs.Node,
+			libipam.AttributePod:       epIDs.Pod,
+			libipam.AttributeNamespace: epIDs.Namespace,
+			libipam.AttributeTimestamp: time.Now().UTC().String(),
+		},
+		IntendedUse: v3.IPPoolAllowedUseWorkload,
+	}
+	if len(v4Pools) > 0 {
+		assignArgs.Num4 = 1
+	}
+	if len(v6Pools) > 0 {
+		assignArgs.Num6 = 1
+	}
+
+	v4, v6, err := calicoClient.IPAM().AutoAssign(ctx, assignArgs)
+	if err != nil {
+		return nil, err
+	}
+	result := &cniv1.Result{}
+	for _, a := range []*libipam.IPAMAssignments{v4, v6} {
+		if a == nil {
+			continue
+		}
+		if err := a.PartialFulfillmentError(); err != nil {
+			return nil, err
+		}
+		for _, ip := range a.IPs {
+			result.IPs = append(result.IPs, &cniv1.IPConfig{Address: net.IPNet{IP: ip.IP, Mask: ip.Mask}})
+		}
+	}
+	logger.WithField("IPs", result.IPs).Info("Assigned IPs for additional network")
+	return result, nil
+}
+
+// cleanUpFailedAdd removes the endpoints and interfaces that a CNI ADD has created when it fails
+// after writing the primary endpoint, as a DEL would.  The caller releases the IPs.  Errors are
+// logged rather than returned so that the caller reports the original failure.
+func cleanUpFailedAdd(
+	ctx context.Context,
+	c calicoclient.Interface,
+	epIDs utils.WEPIdentifiers,
+	args *skel.CmdArgs,
+	d dataplane.Dataplane,
+	primary *libapi.WorkloadEndpoint,
+	logger *logrus.Entry,
+) {
+	if err := delAdditionalNetworks(ctx, c, epIDs, args, d, logger); err != nil {
+		logger.WithError(err).Warn("Failed to clean up additional networks after failure")
+	}
+
+	logger.WithField("WorkloadEndpoint", primary.Name).Info("Deleting endpoint after failure")
+	_, err := c.WorkloadEndpoints().Delete(ctx, primary.Namespace, primary.Name, options.DeleteOptions{
+		UID: &primary.UID,
+	})
+	if _, ok := err.(cerrors.ErrorResourceDoesNotExist); err != nil && !ok {
+		logger.WithError(err).Warn("Failed to delete endpoint after failure")
+	}
+
+	if err := d.CleanUpNamespace(args); err != nil {
+		logger.WithError(err).Warn("Failed to clean up netns after failure")
+	}
+}
+
+// delAdditionalNetworks removes the WorkloadEndpoints and interfaces for the pod's additional
+// networks.  Their IPs are released with the primary interface's IPs.
+func delAdditionalNetworks(
+	ctx context.Context,
+	c calicoclient.Interface,
+	epIDs utils.WEPIdentifiers,
+	args *skel.CmdArgs,
+	d dataplane.Dataplane,
+	logger *logrus.Entry,
+) error {
+	wepPrefix, err := epIDs.CalculateWorkloadEndpointName(true)
+	if err != nil {
+		return err
+	}
+	weps, err := c.WorkloadEndpoints().List(ctx, options.ListOptions{Name: wepPrefix, Namespace: epIDs.Namespace, Prefix: true})
+	if err != nil {
+		return err
+	}
+	for i := range weps.Items {
+		wep := &weps.Items[i]
+		if _, ok := wep.Labels[k8sconversion.LabelNetwork]; !ok || wep.Spec.Pod != epIDs.Pod {
+			continue
+		}
+		if wep.Spec.ContainerID != "" && wep.Spec.ContainerID != args.ContainerID {
+			// As for the primary endpoint, this belongs to a newer sandbox for the pod.
+			continue
+		}
+		wepLogger := logger.WithField("WorkloadEndpoint", wep.Name)
+		_, err := c.WorkloadEndpoints().Delete(ctx, wep.Namespace, wep.Name, options.DeleteOptions{
+			ResourceVersion: wep.ResourceVersion,
+			UID:             &wep.UID,
+		})
+		if _, ok := err.(cerrors.ErrorResourceDoesNotExist); err != nil && !ok {
+			return err
+		}
+		wepLogger.Info("Deleted endpoint for additional network")
+
+		endpointArgs := *args
+		endpointArgs.IfName = wep.Spec.Endpoint
+		if err := d.CleanUpNamespace(&endpointArgs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -499,6 +499,14 @@ func CmdAddK8s(ctx context.Context, args *skel.CmdArgs, conf types.NetConf, epID
 	}
 	logger.Info("Wrote updated endpoint to datastore")
 
+	// Attach the pod to any additional networks that it has asked for.
+	if err = addAdditionalNetworks(ctx, args, conf, epIDs, calicoClient, d, endpoint, annot, logger); err != nil {
+		logger.WithError(err).Error("Error adding additional networks")
+		cleanUpFailedAdd(ctx, calicoClient, epIDs, args, d, endpointOut, logger)
+		releaseIPAM()
+		return nil, err
+	}
+
 	// Add the interface created above to the CNI result.
 	result.Interfaces = append(result.Interfaces, &cniv1.Interface{
 		Name: endpoint.Spec.InterfaceName},
@@ -604,6 +612,11 @@ func CmdDelK8s(ctx context.Context, c calicoclient.Interface, epIDs utils.WEPIde
 		break
 	}
 
+	// Remove the endpoints and interfaces for any additional networks.
+	if err = delAdditionalNetworks(ctx, c, epIDs, args, d, logger); err != nil {
+		return err
+	}
+
 	// Clean up namespace by removing the interfaces.
 	logger.Info("Cleaning up netns")
 	err = d.CleanUpNamespace(args)
@@ -0,0 +1,168 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"runtime/debug"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	cniv1 "github.com/containernetworking/cni/pkg/types/100"
+	cniSpecVersion "github.com/containernetworking/cni/pkg/version"
+	"github.com/containernetworking/plugins/pkg/ipam"
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/cni-plugin/internal/pkg/utils"
+	"github.com/projectcalico/calico/cni-plugin/pkg/dataplane"
+	"github.com/projectcalico/calico/cni-plugin/pkg/types"
+	libapi "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+// cmdCheck verifies that the networking for a container still matches the result returned by
+// the ADD, i.e. that the WorkloadEndpoint exists with the same IPs and that the veth, addresses
+// and routes are still programmed.
+func cmdCheck(args *skel.CmdArgs) (err error) {
+	// Defer a panic recover, so that in case we panic we can still return
+	// a proper error to the runtime.
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("Calico CNI panicked during CHECK: %s\nStack trace:\n%s", e, string(debug.Stack()))
+			if err != nil {
+				// If we're recovering and there was also an error, then we need to
+				// present both.
+				msg = fmt.Sprintf("%s: error=%s", msg, err)
+			}
+			err = fmt.Errorf(msg)
+		}
+		if err != nil {
+			logrus.WithError(err).Error("Final result of CNI CHECK was an error.")
+		}
+	}()
+
+	conf := types.NetConf{}
+	if err = json.Unmarshal(args.StdinData, &conf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+	utils.ConfigureLogging(conf)
+
+	if err = checkNodenameFile(conf); err != nil {
+		return err
+	}
+
+	result, err := parsePrevResult(args.StdinData)
+	if err != nil {
+		return err
+	}
+
+	nodename := utils.DetermineNodename(conf)
+	epIDs, err := utils.GetIdentifiers(args, nodename)
+	if err != nil {
+		return err
+	}
+	epIDs.WEPName, err = epIDs.CalculateWorkloadEndpointName(false)
+	if err != nil {
+		return fmt.Errorf("error constructing WorkloadEndpoint name: %s", err)
+	}
+	logger := logrus.WithFields(logrus.Fields{
+		"ContainerID":      epIDs.ContainerID,
+		"WorkloadEndpoint": epIDs.WEPName,
+	})
+
+	calicoClient, err := utils.CreateClient(conf)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	wep, err := calicoClient.WorkloadEndpoints().Get(ctx, epIDs.Namespace, epIDs.WEPName, options.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting WorkloadEndpoint %s: %v", epIDs.WEPName, err)
+	}
+	if err = checkEndpoint(wep, epIDs.ContainerID, result); err != nil {
+		return err
+	}
+
+	d, err := dataplane.GetDataplane(conf, logger)
+	if err != nil {
+		return err
+	}
+	if checker, ok := d.(dataplane.Checker); ok {
+		if err = checker.CheckNetworking(args, result, wep); err != nil {
+			return err
+		}
+	} else {
+		logger.Debug("Dataplane doesn't support CHECK, skipping dataplane checks")
+	}
+
+	// Give the IPAM plugin the chance to check its allocations too.
+	if !conf.FeatureControl.IPAddrsNoIpam {
+		if err = ipam.ExecCheck(conf.IPAM.Type, args.StdinData); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("CNI CHECK passed")
+	return nil
+}
+
+// parsePrevResult extracts the result of the ADD, which the runtime passes to CHECK.
+func parsePrevResult(stdinData []byte) (*cniv1.Result, error) {
+	netConf := cnitypes.NetConf{}
+	if err := json.Unmarshal(stdinData, &netConf); err != nil {
+		return nil, fmt.Errorf("failed to load netconf: %v", err)
+	}
+	if err := cniSpecVersion.ParsePrevResult(&netConf); err != nil {
+		return nil, err
+	}
+	if netConf.PrevResult == nil {
+		return nil, errors.New("CHECK requires a prevResult")
+	}
+	return cniv1.NewResultFromResult(netConf.PrevResult)
+}
+
+// checkEndpoint checks that the WorkloadEndpoint belongs to the container and has the IPs from
+// the previous result.
+func checkEndpoint(wep *libapi.WorkloadEndpoint, containerID string, result *cniv1.Result) error {
+	if wep.Spec.ContainerID != "" && wep.Spec.ContainerID != containerID {
+		return fmt.Errorf("WorkloadEndpoint %s belongs to container %s, not %s", wep.Name, wep.Spec.ContainerID, containerID)
+	}
+	if wep.Spec.InterfaceName == "" {
+		return fmt.Errorf("WorkloadEndpoint %s has no interface name", wep.Name)
+	}
+
+	wepIPs := map[string]bool{}
+	for _, n := range wep.Spec.IPNetworks {
+		ip, _, err := net.ParseCIDR(n)
+		if err != nil {
+			return fmt.Errorf("WorkloadEndpoint %s has invalid IP network %s: %v", wep.Name, n, err)
+		}
+		wepIPs[ip.String()] = true
+	}
+	if len(wepIPs) != len(result.IPs) {
+		return fmt.Errorf("WorkloadEndpoint %s has IPs %v, expected %d IPs from the previous result",
+			wep.Name, wep.Spec.IPNetworks, len(result.IPs))
+	}
+	for _, ipc := range result.IPs {
+		if !wepIPs[ipc.Address.IP.String()] {
+			return fmt.Errorf("WorkloadEndpoint %s is missing IP %s from the previous result", wep.Name, ipc.Address.IP)
+		}
+	}
+	return nil
+}
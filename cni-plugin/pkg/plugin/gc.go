@@ -0,0 +1,149 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/containernetworking/cni/pkg/skel"
+	api "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/cni-plugin/internal/pkg/utils"
+	"github.com/projectcalico/calico/cni-plugin/pkg/types"
+	libapi "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+// cmdGC releases the resources on this node that belong to containers which the runtime no
+// longer has attached to the network: the WorkloadEndpoints are deleted here and then the IPAM
+// plugin is asked to release the corresponding IP allocations.
+func cmdGC(args *skel.CmdArgs) (err error) {
+	// Defer a panic recover, so that in case we panic we can still return
+	// a proper error to the runtime.
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("Calico CNI panicked during GC: %s\nStack trace:\n%s", e, string(debug.Stack()))
+			if err != nil {
+				// If we're recovering and there was also an error, then we need to
+				// present both.
+				msg = fmt.Sprintf("%s: error=%s", msg, err)
+			}
+			err = fmt.Errorf(msg)
+		}
+		if err != nil {
+			logrus.WithError(err).Error("Final result of CNI GC was an error.")
+		}
+	}()
+
+	conf := types.NetConf{}
+	if err = json.Unmarshal(args.StdinData, &conf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+	utils.ConfigureLogging(conf)
+
+	if err = checkNodenameFile(conf); err != nil {
+		return err
+	}
+	valid, err := utils.ValidAttachments(args.StdinData)
+	if err != nil {
+		return err
+	}
+	nodename := utils.DetermineNodename(conf)
+	logger := logrus.WithFields(logrus.Fields{"Node": nodename, "Network": conf.Name})
+
+	calicoClient, err := utils.CreateClient(conf)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if err = utils.CheckDatastoreReady(ctx, calicoClient); err != nil {
+		return err
+	}
+
+	if err = gcEndpoints(ctx, calicoClient, conf.Name, nodename, valid, logger); err != nil {
+		return err
+	}
+
+	// Release the IP allocations.  The IPAM plugin receives the same list of valid attachments.
+	if conf.FeatureControl.IPAddrsNoIpam {
+		return nil
+	}
+	if err = invoke.DelegateGC(ctx, conf.IPAM.Type, args.StdinData, nil); err != nil {
+		if conf.IPAM.Type == "calico-ipam" {
+			return err
+		}
+		// Other IPAM plugins may not support GC; that shouldn't stop us from cleaning up.
+		logger.WithError(err).WithField("type", conf.IPAM.Type).Warn("IPAM plugin GC failed")
+	}
+	return nil
+}
+
+// gcEndpoints deletes the WorkloadEndpoints on this node that belong to containers which aren't
+// in the set of valid attachments.  Kubernetes endpoints are deleted if their container isn't
+// valid; other endpoints are only deleted if the network's profile is their only profile, since
+// such an endpoint may be shared between several networks.
+func gcEndpoints(
+	ctx context.Context,
+	c clientv3.Interface,
+	network, nodename string,
+	valid map[string]bool,
+	logger *logrus.Entry,
+) error {
+	weps, err := c.WorkloadEndpoints().List(ctx, options.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing WorkloadEndpoints: %v", err)
+	}
+	for i := range weps.Items {
+		wep := &weps.Items[i]
+		if !isStaleEndpoint(wep, network, nodename, valid) {
+			continue
+		}
+		wepLogger := logger.WithFields(logrus.Fields{
+			"WorkloadEndpoint": wep.Name,
+			"ContainerID":      wep.Spec.ContainerID,
+		})
+		wepLogger.Info("Deleting WorkloadEndpoint for container that is no longer attached")
+		_, err := c.WorkloadEndpoints().Delete(ctx, wep.Namespace, wep.Name, options.DeleteOptions{
+			ResourceVersion: wep.ResourceVersion,
+			UID:             &wep.UID,
+		})
+		switch err.(type) {
+		case nil:
+		case cerrors.ErrorResourceDoesNotExist, cerrors.ErrorResourceUpdateConflict:
+			// Deleted or replaced by someone else since we listed it; leave it alone.
+			wepLogger.WithError(err).Info("WorkloadEndpoint changed before it could be deleted, skipping")
+		default:
+			return fmt.Errorf("error deleting WorkloadEndpoint %s: %v", wep.Name, err)
+		}
+	}
+	return nil
+}
+
+func isStaleEndpoint(wep *libapi.WorkloadEndpoint, network, nodename string, valid map[string]bool) bool {
+	if wep.Spec.Node != nodename || wep.Spec.ContainerID == "" || valid[wep.Spec.ContainerID] {
+		return false
+	}
+	if wep.Spec.Orchestrator == api.OrchestratorKubernetes {
+		return true
+	}
+	return len(wep.Spec.Profiles) == 1 && wep.Spec.Profiles[0] == network
+}
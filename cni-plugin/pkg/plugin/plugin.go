@@ -45,6 +45,7 @@ import (
 	"github.com/projectcalico/calico/cni-plugin/pkg/k8s"
 	"github.com/projectcalico/calico/cni-plugin/pkg/types"
 	libapi "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	k8sconversion "github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/conversion"
 	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
 	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
 	"github.com/projectcalico/calico/libcalico-go/lib/logutils"
@@ -79,13 +80,8 @@ func testConnection() error {
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), testConnectionTimeout)
 	defer cancel()
-	ci, err := calicoClient.ClusterInformation().Get(ctx, "default", options.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("error getting ClusterInformation: %v", err)
-	}
-	if !*ci.Spec.DatastoreReady {
-		logrus.Info("Upgrade may be in progress, ready flag is not set")
-		return fmt.Errorf("Calico is currently not ready to process requests")
+	if err := utils.CheckDatastoreReady(ctx, calicoClient); err != nil {
+		return err
 	}
 
 	// If we have a kubeconfig, test connection to the APIServer
@@ -172,7 +168,7 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 		conf.CNIVersion = "0.2.0"
 	}
 
-	if version.Compare(conf.CNIVersion, "1.0.0", ">") {
+	if version.Compare(conf.CNIVersion, "1.1.0", ">") {
 		return fmt.Errorf("unsupported CNI version %s", conf.CNIVersion)
 	}
 
@@ -292,7 +288,8 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 	// Note we don't use the interface name (endpoint) for this match.
 	// If we find a match from the returned list then we've found the workload endpoint,
 	// and we reuse that even if it has a different interface name, because
-	// we only support one interface per pod right now.
+	// we only support one primary interface per pod.  The endpoints for a pod's additional
+	// networks are labelled with their network and are skipped here.
 	// For example, you have a WEP for a k8s pod "mypod-1", and IfName "eth0" on node "node1", that will result in
 	// a WEP name "node1-k8s-mypod--1-eth0" in the datastore, now you're trying to schedule another pod "mypod",
 	// IfName "eth0" and node "node1", so we do a prefix list to get all the endpoints for that workload, with
@@ -306,6 +303,9 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 	if len(endpoints.Items) > 0 {
 		logger.Debugf("List of WorkloadEndpoints %v", endpoints.Items)
 		for _, ep := range endpoints.Items {
+			if _, ok := ep.Labels[k8sconversion.LabelNetwork]; ok {
+				continue
+			}
 			var match bool
 			match, err = wepIDs.WorkloadEndpointIdentifiers.NameMatches(ep.Name)
 			if err != nil {
@@ -683,11 +683,6 @@ func cmdDel(args *skel.CmdArgs) (err error) {
 	return
 }
 
-func cmdDummyCheck(args *skel.CmdArgs) (err error) {
-	fmt.Println("OK")
-	return nil
-}
-
 func Main(version string) {
 	// Set up logging formatting.
 	logutils.ConfigureFormatter("cni-plugin")
@@ -746,11 +741,13 @@ func Main(version string) {
 	}
 
 	funcs := skel.CNIFuncs{
-		Add:   cmdAdd,
-		Del:   cmdDel,
-		Check: cmdDummyCheck,
+		Add:    cmdAdd,
+		Del:    cmdDel,
+		Check:  cmdCheck,
+		GC:     cmdGC,
+		Status: cmdStatus,
 	}
 	skel.PluginMainFuncs(funcs,
-		cniSpecVersion.PluginSupports("0.1.0", "0.2.0", "0.3.0", "0.3.1", "0.4.0", "1.0.0"),
+		cniSpecVersion.PluginSupports("0.1.0", "0.2.0", "0.3.0", "0.3.1", "0.4.0", "1.0.0", "1.1.0"),
 		"Calico CNI plugin "+version)
 }
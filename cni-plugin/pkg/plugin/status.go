@@ -0,0 +1,102 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/containernetworking/cni/pkg/skel"
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/cni-plugin/internal/pkg/utils"
+	"github.com/projectcalico/calico/cni-plugin/pkg/types"
+)
+
+// cmdStatus reports whether the plugin is ready to handle ADD requests.  It checks that
+// calico/node has written the nodename file, that the datastore is reachable and ready and, if
+// configured, that Felix's readiness endpoint and the readiness gates report ready.
+func cmdStatus(args *skel.CmdArgs) error {
+	conf := types.NetConf{}
+	if err := json.Unmarshal(args.StdinData, &conf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+	utils.ConfigureLogging(conf)
+
+	if err := checkStatus(conf); err != nil {
+		logrus.WithError(err).Warn("Calico CNI plugin is not ready")
+		return cnitypes.NewError(utils.ErrPluginNotAvailable, "Calico CNI plugin is not ready", err.Error())
+	}
+
+	if conf.IPAM.Type == "calico-ipam" {
+		if err := invoke.DelegateStatus(context.Background(), conf.IPAM.Type, args.StdinData, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkStatus(conf types.NetConf) error {
+	if err := checkNodenameFile(conf); err != nil {
+		return err
+	}
+
+	calicoClient, err := utils.CreateClient(conf)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), testConnectionTimeout)
+	defer cancel()
+	if err := utils.CheckDatastoreReady(ctx, calicoClient); err != nil {
+		return err
+	}
+
+	endpoints := conf.ReadinessGates
+	if conf.FelixReadinessURL != "" {
+		// Felix's health endpoint is disabled by default, and its host and port are
+		// configurable, so only check it if we've been told where it is.
+		endpoints = append(endpoints, conf.FelixReadinessURL)
+	}
+	for _, endpoint := range endpoints {
+		if ready, err := isEndpointReady(endpoint, testConnectionTimeout); !ready {
+			if err != nil {
+				return fmt.Errorf("endpoint %s is not ready: %v", endpoint, err)
+			}
+			return fmt.Errorf("endpoint %s is not ready", endpoint)
+		}
+	}
+	return nil
+}
+
+// checkNodenameFile returns an error if we're configured to require the nodename file and
+// calico/node hasn't written it yet.
+func checkNodenameFile(conf types.NetConf) error {
+	if conf.NodenameFileOptional {
+		return nil
+	}
+	nodeNameFile := "/var/lib/calico/nodename"
+	if conf.NodenameFile != "" {
+		nodeNameFile = conf.NodenameFile
+	}
+	if _, err := os.Stat(nodeNameFile); err != nil {
+		s := "%s: check that the calico/node container is running and has mounted /var/lib/calico/"
+		return fmt.Errorf(s, err)
+	}
+	return nil
+}
@@ -125,6 +125,12 @@ type NetConf struct {
 	// The CNI plugin waits until all the endpoints specified in ReadinessGates are ready
 	ReadinessGates []string `json:"readiness_gates"`
 
+	// FelixReadinessURL is Felix's readiness endpoint, for example
+	// http://localhost:9099/readiness when Felix's health endpoint is enabled with the default
+	// host and port.  If set, the CNI STATUS command reports that the plugin is not available if
+	// the endpoint is not ready.
+	FelixReadinessURL string `json:"felix_readiness_url,omitempty"`
+
 	// PolicySetupTimeoutSeconds is the maximum duration to delay
 	// pod startup when waiting for Felix to program policy for the endpoint.
 	//
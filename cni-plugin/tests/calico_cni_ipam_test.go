@@ -482,4 +482,85 @@ var _ = Describe("Calico IPAM Tests", func() {
 			})
 		})
 	})
+
+	Describe("Run IPAM GC", func() {
+		gcNetconf := func(validIDs ...string) string {
+			var attachments string
+			for i, id := range validIDs {
+				if i > 0 {
+					attachments += ","
+				}
+				attachments += fmt.Sprintf(`{"containerID": "%s", "ifname": "eth0"}`, id)
+			}
+			return fmt.Sprintf(`
+                    {
+                      "cniVersion": "1.1.0",
+                      "name": "net1",
+                      "type": "calico",
+                      "etcd_endpoints": "http://%s:2379",
+                      "kubernetes": {
+                        "kubeconfig": "/home/user/certs/kubeconfig"
+                      },
+                      "datastore_type": "%s",
+                      "ipam": {
+                        "type": "%s"
+                      },
+                      "cni.dev/valid-attachments": [%s]
+                    }`, os.Getenv("ETCD_IP"), os.Getenv("DATASTORE_TYPE"), plugin, attachments)
+		}
+
+		assign := func(handleID, ip, node string) {
+			err := calicoClient.IPAM().AssignIP(context.Background(), ipam.AssignIPArgs{
+				IP:       cnet.MustParseIP(ip),
+				HandleID: &handleID,
+				Attrs:    map[string]string{ipam.AttributeNode: node},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("should release only the allocations for containers that are no longer attached", func() {
+			hostname, err := names.Hostname()
+			Expect(err).NotTo(HaveOccurred())
+			leaked := uuid.NewString()
+			assign("net1."+cid, "192.168.123.1", hostname)
+			assign("net1."+leaked, "192.168.123.2", hostname)
+			assign("net2."+leaked, "192.168.123.3", hostname)
+			assign("net1.other-node", "192.168.123.4", "other-node")
+
+			_, e, rc := testutils.RunIPAMPlugin(gcNetconf(cid), "GC", "", cid, "1.1.0")
+			Expect(e).To(Equal(types.Error{}))
+			Expect(rc).To(Equal(0))
+
+			ctx := context.Background()
+			_, err = calicoClient.IPAM().IPsByHandle(ctx, "net1."+leaked)
+			Expect(err).To(HaveOccurred())
+			for _, handle := range []string{"net1." + cid, "net2." + leaked, "net1.other-node"} {
+				ips, err := calicoClient.IPAM().IPsByHandle(ctx, handle)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(HaveLen(1))
+			}
+		})
+	})
+
+	Describe("Run IPAM STATUS", func() {
+		It("should report ready when the datastore is ready", func() {
+			netconf := fmt.Sprintf(`
+                    {
+                      "cniVersion": "1.1.0",
+                      "name": "net1",
+                      "type": "calico",
+                      "etcd_endpoints": "http://%s:2379",
+                      "kubernetes": {
+                        "kubeconfig": "/home/user/certs/kubeconfig"
+                      },
+                      "datastore_type": "%s",
+                      "ipam": {
+                        "type": "%s"
+                      }
+                    }`, os.Getenv("ETCD_IP"), os.Getenv("DATASTORE_TYPE"), plugin)
+			_, e, rc := testutils.RunIPAMPlugin(netconf, "STATUS", "", cid, "1.1.0")
+			Expect(e).To(Equal(types.Error{}))
+			Expect(rc).To(Equal(0))
+		})
+	})
 })
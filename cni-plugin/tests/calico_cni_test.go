@@ -4,10 +4,13 @@ package main_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/rand"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"strings"
@@ -29,6 +32,7 @@ import (
 	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
 	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
 	"github.com/projectcalico/calico/libcalico-go/lib/names"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
 )
 
@@ -868,6 +872,165 @@ var _ = Describe("CalicoCni", func() {
 		})
 	})
 
+	Describe("CHECK, GC and STATUS", func() {
+		calicoNetconf := func(version, extra string) string {
+			return fmt.Sprintf(`
+			{
+			  "cniVersion": "%s",
+			  "name": "net1",
+			  "type": "calico",
+			  "etcd_endpoints": "http://%s:2379",
+			  "datastore_type": "%s",
+			  "log_level": "info",
+			  "nodename_file_optional": true,
+			  "ipam": { "type": "calico-ipam" }%s
+			}`, version, os.Getenv("ETCD_IP"), os.Getenv("DATASTORE_TYPE"), extra)
+		}
+		netconf := calicoNetconf("1.0.0", "")
+
+		var containerID string
+		var workloadName string
+		var result *cniv1.Result
+		var contNs ns.NetNS
+
+		BeforeEach(func() {
+			testutils.MustCreateNewIPPool(calicoClient, "10.0.0.0/24", false, false, true)
+
+			var err error
+			containerID, result, _, _, _, contNs, err = testutils.CreateContainerWithId(netconf, "", testutils.TEST_DEFAULT_NS, "", "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.IPs).To(HaveLen(1))
+
+			ids := names.WorkloadEndpointIdentifiers{
+				Node:         hostname,
+				Orchestrator: "cni",
+				Endpoint:     "eth0",
+				ContainerID:  containerID,
+			}
+			workloadName, err = ids.CalculateWorkloadEndpointName(false)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			_, err := testutils.DeleteContainerWithId(netconf, contNs.Path(), "", testutils.TEST_DEFAULT_NS, containerID)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		runCheck := func() error {
+			prevResult, err := json.Marshal(result)
+			Expect(err).NotTo(HaveOccurred())
+			checkNetconf := calicoNetconf("1.0.0", fmt.Sprintf(`,
+			  "prevResult": %s`, prevResult))
+			return testutils.RunCNIPluginCommand(checkNetconf, "CHECK", containerID, contNs.Path(), "eth0")
+		}
+
+		handleIPs := func() []cnet.IP {
+			handleID := utils.GetHandleID("net1", containerID, workloadName)
+			ips, err := calicoClient.IPAM().IPsByHandle(ctx, handleID)
+			if err != nil {
+				return nil
+			}
+			return ips
+		}
+
+		It("CHECK passes for a container that is networked as in the previous result", func() {
+			Expect(runCheck()).To(Succeed())
+		})
+
+		It("CHECK fails if the WorkloadEndpoint has been deleted", func() {
+			_, err := calicoClient.WorkloadEndpoints().Delete(ctx, testutils.TEST_DEFAULT_NS, workloadName, options.DeleteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(runCheck()).To(MatchError(ContainSubstring("error getting WorkloadEndpoint")))
+		})
+
+		It("CHECK fails if the container's address has been removed", func() {
+			err := contNs.Do(func(_ ns.NetNS) error {
+				link, err := netlink.LinkByName("eth0")
+				if err != nil {
+					return err
+				}
+				return netlink.AddrDel(link, &netlink.Addr{IPNet: &result.IPs[0].Address})
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(runCheck()).To(MatchError(ContainSubstring("missing address")))
+		})
+
+		It("CHECK fails if the route to the container has been removed", func() {
+			wep, err := calicoClient.WorkloadEndpoints().Get(ctx, testutils.TEST_DEFAULT_NS, workloadName, options.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			hostVeth, err := netlink.LinkByName(wep.Spec.InterfaceName)
+			Expect(err).NotTo(HaveOccurred())
+			routes, err := netlink.RouteList(hostVeth, syscall.AF_INET)
+			Expect(err).NotTo(HaveOccurred())
+			for i := range routes {
+				Expect(netlink.RouteDel(&routes[i])).To(Succeed())
+			}
+			Expect(runCheck()).To(MatchError(ContainSubstring("no route to")))
+		})
+
+		It("GC keeps the WorkloadEndpoint and IPs of a container that is still attached", func() {
+			gcNetconf := calicoNetconf("1.1.0", fmt.Sprintf(`,
+			  "cni.dev/valid-attachments": [{"containerID": "%s", "ifname": "eth0"}]`, containerID))
+			Expect(testutils.RunCNIPluginCommand(gcNetconf, "GC", "", "", "")).To(Succeed())
+
+			_, err := calicoClient.WorkloadEndpoints().Get(ctx, testutils.TEST_DEFAULT_NS, workloadName, options.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handleIPs()).To(HaveLen(1))
+		})
+
+		It("GC releases the WorkloadEndpoint and IPs of a container that is no longer attached", func() {
+			gcNetconf := calicoNetconf("1.1.0", `,
+			  "cni.dev/valid-attachments": []`)
+			Expect(testutils.RunCNIPluginCommand(gcNetconf, "GC", "", "", "")).To(Succeed())
+
+			_, err := calicoClient.WorkloadEndpoints().Get(ctx, testutils.TEST_DEFAULT_NS, workloadName, options.GetOptions{})
+			Expect(err).To(HaveOccurred())
+			Expect(handleIPs()).To(BeEmpty())
+		})
+
+		Context("with a fake Felix readiness endpoint", func() {
+			var felixStatus int
+			var felix *httptest.Server
+
+			BeforeEach(func() {
+				felixStatus = http.StatusOK
+				felix = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(felixStatus)
+				}))
+			})
+
+			AfterEach(func() {
+				felix.Close()
+			})
+
+			runStatus := func(url string) error {
+				statusNetconf := calicoNetconf("1.1.0", fmt.Sprintf(`,
+				  "felix_readiness_url": "%s"`, url))
+				return testutils.RunCNIPluginCommand(statusNetconf, "STATUS", "", "", "")
+			}
+
+			It("STATUS reports ready when the datastore and Felix are ready", func() {
+				Expect(runStatus(felix.URL)).To(Succeed())
+			})
+
+			It("STATUS reports not ready when Felix is not ready", func() {
+				felixStatus = http.StatusServiceUnavailable
+				Expect(runStatus(felix.URL)).To(MatchError(ContainSubstring("not ready")))
+			})
+
+			It("STATUS reports not ready when Felix is unreachable", func() {
+				url := felix.URL
+				felix.Close()
+				Expect(runStatus(url)).To(MatchError(ContainSubstring("not ready")))
+			})
+
+			It("STATUS skips the Felix check if no URL is configured", func() {
+				felix.Close()
+				Expect(runStatus("")).To(Succeed())
+			})
+		})
+	})
+
 	Describe("testConnection tests", func() {
 
 		It("successfully connects to the datastore", func(done Done) {
@@ -48,6 +48,7 @@ import (
 	lerr "github.com/projectcalico/calico/libcalico-go/lib/errors"
 	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector"
 	"github.com/projectcalico/calico/libcalico-go/lib/set"
 	"github.com/projectcalico/calico/typha/pkg/syncclientutils"
 	"github.com/projectcalico/calico/typha/pkg/syncproto"
@@ -347,6 +348,9 @@ type client struct {
 	loadBalancerIPs    []string
 	loadBalancerIPNets []*net.IPNet // same as externalIPs but parsed
 
+	// Parsed value of <bgpconfig>.spec.workloadAdvertisements.
+	workloadAdvertisements []workloadAdvertisement
+
 	// Subcomponent for accessing and watching secrets (that hold BGP passwords).
 	secretWatcher *secretWatcher
 
@@ -358,6 +362,13 @@ type client struct {
 	globalBGPConfig *apiv3.BGPConfiguration
 }
 
+// workloadAdvertisement is a parsed WorkloadAdvertisement, with its communities resolved to
+// community values.
+type workloadAdvertisement struct {
+	selector    selector.Selector
+	communities []string
+}
+
 // SetPrefixes is called from confd to notify this client of the full set of prefixes that will
 // be watched.
 // This client uses this information to initialize the revision map used to keep track of the
@@ -464,6 +475,9 @@ type bgpPeer struct {
 	TTLSecurity     uint8                `json:"ttl_security"`
 	ReachableBy     string               `json:"reachable_by"`
 	Filters         []string             `json:"filters"`
+	// AddressFamilies are the families of the routes exchanged with the peer, comma-separated,
+	// or empty for the family of the peer's address.
+	AddressFamilies string `json:"address_families"`
 }
 
 type bgpPrefix struct {
@@ -553,8 +567,9 @@ func (c *client) updatePeersV1() {
 
 			var peers []*bgpPeer
 			if v3res.Spec.PeerSelector != "" {
+				v4, v6 := sessionAddressFamilies(v3res)
 				for _, peerNodeName := range c.nodeLabelManager.nodesMatching(v3res.Spec.PeerSelector) {
-					peers = append(peers, c.nodeAsBGPPeers(peerNodeName, true, true, v3res)...)
+					peers = append(peers, c.nodeAsBGPPeers(peerNodeName, v4, v6, v3res)...)
 				}
 			} else {
 				// Separate port from Ip if it uses <ip>:<port> format
@@ -655,9 +670,9 @@ func (c *client) updatePeersV1() {
 		var includeV4, includeV6 bool
 		if v3res.Spec.PeerSelector != "" {
 			localNodeNames = c.nodeLabelManager.nodesMatching(v3res.Spec.PeerSelector)
-			// Peering on label selector, so we should reverse the peering over IPv4 and IPv6.
-			includeV4 = true
-			includeV6 = true
+			// Peering on label selector, so we should reverse the peering over IPv4 and IPv6,
+			// or over the session family if the peering lists address families.
+			includeV4, includeV6 = sessionAddressFamilies(v3res)
 		} else {
 			ip, port := parseIPPort(v3res.Spec.PeerIP)
 			localNodeNames = c.nodesWithIPPortAndAS(ip, v3res.Spec.ASNumber, port)
@@ -796,6 +811,17 @@ func (c *client) globalAS() string {
 	return c.cache[asKey]
 }
 
+// sessionAddressFamilies returns the families of the node addresses to peer with for a BGPPeer
+// with a peer selector.  A peering that lists address families is made over a single session,
+// with the address in the first of them.
+func sessionAddressFamilies(v3Peer *apiv3.BGPPeer) (v4 bool, v6 bool) {
+	if len(v3Peer.Spec.AddressFamilies) == 0 {
+		return true, true
+	}
+	first := v3Peer.Spec.AddressFamilies[0]
+	return first == apiv3.BGPAddressFamilyIPv4, first == apiv3.BGPAddressFamilyIPv6
+}
+
 func (c *client) nodeAsBGPPeers(nodeName string, v4 bool, v6 bool, v3Peer *apiv3.BGPPeer) (peers []*bgpPeer) {
 	ipv4Str, ipv6Str, asNum, rrClusterID := c.nodeToBGPFields(nodeName)
 	versions := map[string]string{}
@@ -1112,6 +1138,7 @@ func (c *client) updateBGPConfigCache(resName string, v3res *apiv3.BGPConfigurat
 		c.getNodeMeshRestartTimeKVPair(v3res, model.GlobalBGPConfigKey{})
 		c.getNodeMeshPasswordKVPair(v3res, model.GlobalBGPConfigKey{})
 		c.getIgnoredInterfacesKVPair(v3res, model.GlobalBGPConfigKey{})
+		c.updateWorkloadAdvertisements(v3res, svcAdvertisement)
 
 		// Cache the updated BGP configuration
 		c.globalBGPConfig = v3res
@@ -1166,20 +1193,7 @@ func (c *client) getPrefixAdvertisementsKVPair(v3res *apiv3.BGPConfiguration, ke
 			cidr := prefixAdvertisement.CIDR
 
 			communitiesSet := set.New[string]()
-			for _, c := range prefixAdvertisement.Communities {
-				isCommunity := isValidCommunity(c)
-				// if c is a community value, use it directly, else get the community value from defined definedCommunities.
-				if !isCommunity {
-					for _, definedCommunity := range definedCommunities {
-						if definedCommunity.Name == c {
-							communitiesSet.Add(definedCommunity.Value)
-							break
-						}
-					}
-				} else {
-					communitiesSet.Add(c)
-				}
-			}
+			addCommunityValues(communitiesSet, prefixAdvertisement.Communities, definedCommunities)
 
 			if strings.Contains(cidr, ":") {
 				ipv6PrefixToAdvertise = append(ipv6PrefixToAdvertise, bgpPrefix{
@@ -1211,6 +1225,31 @@ func (c *client) getPrefixAdvertisementsKVPair(v3res *apiv3.BGPConfiguration, ke
 	}
 }
 
+// updateWorkloadAdvertisements parses the workload advertisements in the default BGP
+// configuration.  The route generator advertises the addresses of the selected local workloads
+// when it next resyncs.
+func (c *client) updateWorkloadAdvertisements(v3res *apiv3.BGPConfiguration, svcAdvertisement *bool) {
+	var workloadAdvertisements []workloadAdvertisement
+	if v3res != nil {
+		for _, wa := range v3res.Spec.WorkloadAdvertisements {
+			sel, err := selector.Parse(wa.Selector)
+			if err != nil {
+				// Shouldn't ever happen, given prior validation.
+				log.WithError(err).WithField("selector", wa.Selector).Warn("Ignoring workload advertisement with invalid selector")
+				continue
+			}
+			communitiesSet := set.New[string]()
+			addCommunityValues(communitiesSet, wa.Communities, v3res.Spec.Communities)
+			workloadAdvertisements = append(workloadAdvertisements, workloadAdvertisement{
+				selector:    sel,
+				communities: getCommunitiesArray(communitiesSet),
+			})
+		}
+	}
+	c.workloadAdvertisements = workloadAdvertisements
+	*svcAdvertisement = true
+}
+
 func (c *client) getListenPortKVPair(v3res *apiv3.BGPConfiguration, key interface{}, updatePeersV1 *bool, updateReasons *[]string) {
 	listenPortKey := getBGPConfigKey("listen_port", key)
 
@@ -1393,6 +1432,24 @@ func getNodeName(nodeName string) string {
 	return strings.TrimPrefix(nodeName, perNodeConfigNamePrefix)
 }
 
+// addCommunityValues adds the values of the given communities to the set.  Each community is
+// either a community value, or the name of one of the defined communities.
+func addCommunityValues(communitiesSet set.Set[string], communities []string, definedCommunities []apiv3.Community) {
+	for _, c := range communities {
+		// if c is a community value, use it directly, else get the community value from defined definedCommunities.
+		if isValidCommunity(c) {
+			communitiesSet.Add(c)
+			continue
+		}
+		for _, definedCommunity := range definedCommunities {
+			if definedCommunity.Name == c {
+				communitiesSet.Add(definedCommunity.Value)
+				break
+			}
+		}
+	}
+}
+
 func getCommunitiesArray(communitiesSet set.Set[string]) []string {
 	communityValue := communitiesSet.Slice()
 	sort.Strings(communityValue)
@@ -1460,6 +1517,12 @@ func (c *client) GetLoadBalancerIPs() []*net.IPNet {
 	return c.loadBalancerIPNets
 }
 
+func (c *client) getWorkloadAdvertisements() []workloadAdvertisement {
+	c.cacheLock.Lock()
+	defer c.cacheLock.Unlock()
+	return c.workloadAdvertisements
+}
+
 // updateGlobalRoutes updates programs and withdraws routes based on the given CIDRs as provided via
 // the BGPConfiguration API, and this node's service advertisement status as configured via
 // the per-node Service advertisement exclusion label.
@@ -1780,20 +1843,25 @@ func (c *client) updateLogLevel() {
 }
 
 var (
-	routeKeyPrefix    = "/calico/staticroutes/"
-	rejectKeyPrefix   = "/calico/rejectcidrs/"
-	routeKeyPrefixV6  = "/calico/staticroutesv6/"
-	rejectKeyPrefixV6 = "/calico/rejectcidrsv6/"
+	routeKeyPrefix           = "/calico/staticroutes/"
+	rejectKeyPrefix          = "/calico/rejectcidrs/"
+	workloadRouteKeyPrefix   = "/calico/workloadroutes/"
+	routeKeyPrefixV6         = "/calico/staticroutesv6/"
+	rejectKeyPrefixV6        = "/calico/rejectcidrsv6/"
+	workloadRouteKeyPrefixV6 = "/calico/workloadroutesv6/"
 )
 
+// routeKey returns the cache key for the given route under the prefix for its IP version.
+func routeKey(prefixV4, prefixV6, cidr string) string {
+	if strings.Contains(cidr, ":") {
+		return prefixV6 + strings.Replace(cidr, "/", "-", 1)
+	}
+	return prefixV4 + strings.Replace(cidr, "/", "-", 1)
+}
+
 func (c *client) addRoutesLockHeld(prefixV4, prefixV6 string, cidrs []string) {
 	for _, cidr := range cidrs {
-		var k string
-		if strings.Contains(cidr, ":") {
-			k = prefixV6 + strings.Replace(cidr, "/", "-", 1)
-		} else {
-			k = prefixV4 + strings.Replace(cidr, "/", "-", 1)
-		}
+		k := routeKey(prefixV4, prefixV6, cidr)
 
 		// Update the cache and increment the reference count for this key.
 		c.cache[k] = cidr
@@ -1804,12 +1872,7 @@ func (c *client) addRoutesLockHeld(prefixV4, prefixV6 string, cidrs []string) {
 
 func (c *client) deleteRoutesLockHeld(prefixV4, prefixV6 string, cidrs []string) {
 	for _, cidr := range cidrs {
-		var k string
-		if strings.Contains(cidr, ":") {
-			k = prefixV6 + strings.Replace(cidr, "/", "-", 1)
-		} else {
-			k = prefixV4 + strings.Replace(cidr, "/", "-", 1)
-		}
+		k := routeKey(prefixV4, prefixV6, cidr)
 
 		if c.programmedRouteRefCount[k] <= 1 {
 			// This is the last reference for this route. We can remove it.
@@ -1845,13 +1908,47 @@ func (c *client) DeleteStaticRoutes(cidrs []string) {
 	c.onNewUpdates()
 }
 
+// AddWorkloadRoute exports the given workload route, tagged with the given comma-separated
+// community values, from this node.  Unlike the static routes, the route itself is the one that
+// Felix programs to the workload, so it is only exported while the workload is on this node.
+func (c *client) AddWorkloadRoute(cidr, communities string) {
+	c.cacheLock.Lock()
+	defer c.cacheLock.Unlock()
+
+	c.incrementCacheRevision()
+	k := routeKey(workloadRouteKeyPrefix, workloadRouteKeyPrefixV6, cidr)
+	c.cache[k] = communities
+	c.programmedRouteRefCount[k]++
+	c.keyUpdated(k)
+	c.onNewUpdates()
+}
+
+// DeleteWorkloadRoute stops exporting the given workload route from this node.
+func (c *client) DeleteWorkloadRoute(cidr string) {
+	c.cacheLock.Lock()
+	defer c.cacheLock.Unlock()
+
+	c.incrementCacheRevision()
+	c.deleteRoutesLockHeld(workloadRouteKeyPrefix, workloadRouteKeyPrefixV6, []string{cidr})
+	c.onNewUpdates()
+}
+
 func (c *client) setPeerConfigFieldsFromV3Resource(peers []*bgpPeer, v3res *apiv3.BGPPeer) {
 	// Get the password, if one is configured
 	password := c.getPassword(v3res)
 
+	var families string
+	for i, f := range v3res.Spec.AddressFamilies {
+		if i > 0 {
+			families += ","
+		}
+		families += string(f)
+	}
+
 	for _, peer := range peers {
 		peer.Password = password
 		peer.SourceAddr = withDefault(string(v3res.Spec.SourceAddress), string(apiv3.SourceAddressUseNodeIP))
+		peer.AddressFamilies = families
 		if v3res.Spec.MaxRestartTime != nil {
 			peer.RestartTime = fmt.Sprintf("%v", int(math.Round(v3res.Spec.MaxRestartTime.Duration.Seconds())))
 		}
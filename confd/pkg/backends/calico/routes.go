@@ -28,6 +28,9 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/projectcalico/calico/confd/pkg/resource/template"
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/conversion"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
 	"github.com/projectcalico/calico/libcalico-go/lib/winutils"
 )
 
@@ -37,16 +40,22 @@ const (
 
 // routeGenerator defines the data fields
 // necessary for monitoring the services/endpoints resources for
-// valid service ips to advertise
+// valid service ips to advertise, and the local pods for
+// workload addresses to advertise
 type routeGenerator struct {
 	sync.Mutex
-	client                     *client
-	nodeName                   string
-	svcInformer, epInformer    cache.Controller
-	svcIndexer, epIndexer      cache.Indexer
-	svcRouteMap                map[string]map[string]bool
-	routeAdvertisementRefCount map[string]int
-	resyncKnownRoutesTrigger   chan struct{}
+	client                               *client
+	nodeName                             string
+	converter                            conversion.Converter
+	svcInformer, epInformer, podInformer cache.Controller
+	svcIndexer, epIndexer, podIndexer    cache.Indexer
+	svcRouteMap                          map[string]map[string]bool
+	routeAdvertisementRefCount           map[string]int
+	resyncKnownRoutesTrigger             chan struct{}
+
+	// workloadRouteMap maps each local pod to its advertised workload routes,
+	// and the communities that they are advertised with.
+	workloadRouteMap map[string]map[string]string
 }
 
 // NewRouteGenerator initializes a kube-api client and the informers
@@ -64,9 +73,11 @@ func NewRouteGenerator(c *client) (rg *routeGenerator, err error) {
 	rg = &routeGenerator{
 		client:                     c,
 		nodeName:                   nodename,
+		converter:                  conversion.NewConverter(),
 		svcRouteMap:                make(map[string]map[string]bool),
 		routeAdvertisementRefCount: make(map[string]int),
 		resyncKnownRoutesTrigger:   make(chan struct{}, 1),
+		workloadRouteMap:           make(map[string]map[string]string),
 	}
 
 	// set up k8s client
@@ -95,6 +106,11 @@ func NewRouteGenerator(c *client) (rg *routeGenerator, err error) {
 	epHandler := cache.ResourceEventHandlerFuncs{AddFunc: rg.onEPAdd, UpdateFunc: rg.onEPUpdate, DeleteFunc: rg.onEPDelete}
 	rg.epIndexer, rg.epInformer = cache.NewIndexerInformer(epWatcher, &v1.Endpoints{}, 0, epHandler, cache.Indexers{})
 
+	// set up informer for the pods on this node
+	podWatcher := cache.NewListWatchFromClient(client.CoreV1().RESTClient(), "pods", "", fields.OneTermEqualSelector("spec.nodeName", nodename))
+	podHandler := cache.ResourceEventHandlerFuncs{AddFunc: rg.onPodAdd, UpdateFunc: rg.onPodUpdate, DeleteFunc: rg.onPodDelete}
+	rg.podIndexer, rg.podInformer = cache.NewIndexerInformer(podWatcher, &v1.Pod{}, 0, podHandler, cache.Indexers{})
+
 	return
 }
 
@@ -103,11 +119,12 @@ func (rg *routeGenerator) Start() {
 	ch := make(chan struct{})
 	go rg.svcInformer.Run(ch)
 	go rg.epInformer.Run(ch)
+	go rg.podInformer.Run(ch)
 
 	// Wait for informers to sync, then notify the main client.
 	log.Info("Starting RouteGenerator for Kubernetes services")
 	go func() {
-		for !rg.svcInformer.HasSynced() || !rg.epInformer.HasSynced() {
+		for !rg.svcInformer.HasSynced() || !rg.epInformer.HasSynced() || !rg.podInformer.HasSynced() {
 			time.Sleep(100 * time.Millisecond)
 		}
 
@@ -225,6 +242,17 @@ func (rg *routeGenerator) resyncKnownRoutes() {
 		// Update the routes advertised for this service
 		rg.setRouteForSvc(svc, nil)
 	}
+
+	// Likewise for the pods on this node, since the workload advertisements
+	// may have changed.
+	for _, podIface := range rg.podIndexer.List() {
+		pod, ok := podIface.(*v1.Pod)
+		if !ok {
+			log.Error("Type assertion failed for rg.podIndexer result member. Will not process updates to routes advertised for pod.")
+			continue
+		}
+		rg.setRoutesForPod(pod)
+	}
 }
 
 // getAllRoutesForService returns all the routes that should be advertised
@@ -574,6 +602,131 @@ func (rg *routeGenerator) onEPDelete(obj interface{}) {
 	rg.unsetRouteForSvc(obj)
 }
 
+// setRoutesForPod advertises the addresses of the pod's workload endpoints
+// that are selected by a workload advertisement, and withdraws any of the
+// pod's routes that are no longer selected.
+func (rg *routeGenerator) setRoutesForPod(pod *v1.Pod) {
+	key, err := cache.MetaNamespaceKeyFunc(pod)
+	if err != nil {
+		log.WithField("pod", pod.Name).WithError(err).Warn("setRoutesForPod: error on retrieving key for pod, passing")
+		return
+	}
+
+	rg.Lock()
+	defer rg.Unlock()
+
+	rg.setWorkloadRoutesForKey(key, rg.getWorkloadRoutesForPod(pod))
+}
+
+// unsetRoutesForPod withdraws all the routes advertised for a pod.
+func (rg *routeGenerator) unsetRoutesForPod(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.WithError(err).Warn("unsetRoutesForPod: error on retrieving key for object, passing")
+		return
+	}
+
+	rg.Lock()
+	defer rg.Unlock()
+
+	rg.setWorkloadRoutesForKey(key, nil)
+}
+
+// getWorkloadRoutesForPod returns the routes that should be advertised for
+// the pod's workload endpoints, mapped to the comma-separated communities to
+// advertise each one with.
+func (rg *routeGenerator) getWorkloadRoutesForPod(pod *v1.Pod) map[string]string {
+	routes := make(map[string]string)
+	workloadAdvertisements := rg.client.getWorkloadAdvertisements()
+	if len(workloadAdvertisements) == 0 {
+		return routes
+	}
+	if !rg.converter.IsReadyCalicoPod(pod) || conversion.IsFinished(pod) {
+		// The pod has no addresses that Felix routes to this node.
+		return routes
+	}
+
+	kvps, err := rg.converter.PodToWorkloadEndpoints(pod)
+	if err != nil {
+		log.WithField("pod", fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)).WithError(err).Warn("Failed to convert pod to workload endpoints")
+		return routes
+	}
+	for _, kvp := range kvps {
+		wep := kvp.Value.(*libapiv3.WorkloadEndpoint)
+		selected := false
+		communitiesSet := set.New[string]()
+		for _, wa := range workloadAdvertisements {
+			if wa.selector.Evaluate(wep.Labels) {
+				selected = true
+				communitiesSet.AddAll(wa.communities)
+			}
+		}
+		if !selected {
+			continue
+		}
+		communities := strings.Join(getCommunitiesArray(communitiesSet), ",")
+		for _, ipNet := range wep.Spec.IPNetworks {
+			routes[ipNet] = communities
+		}
+	}
+	return routes
+}
+
+// setWorkloadRoutesForKey advertises only the given workload routes for the
+// given pod key, withdrawing any others.  A route whose communities have
+// changed is withdrawn and advertised again.
+func (rg *routeGenerator) setWorkloadRoutesForKey(key string, routes map[string]string) {
+	advertisedRoutes := rg.workloadRouteMap[key]
+	if advertisedRoutes == nil {
+		advertisedRoutes = make(map[string]string)
+	}
+	log.WithFields(log.Fields{"key": key, "routes": routes}).Debug("Setting workload routes for key")
+
+	for route, communities := range advertisedRoutes {
+		if newCommunities, ok := routes[route]; !ok || newCommunities != communities {
+			rg.client.DeleteWorkloadRoute(route)
+			delete(advertisedRoutes, route)
+		}
+	}
+	for route, communities := range routes {
+		if _, ok := advertisedRoutes[route]; !ok {
+			rg.client.AddWorkloadRoute(route, communities)
+			advertisedRoutes[route] = communities
+		}
+	}
+
+	if len(advertisedRoutes) == 0 {
+		delete(rg.workloadRouteMap, key)
+	} else {
+		rg.workloadRouteMap[key] = advertisedRoutes
+	}
+}
+
+// onPodAdd is called when a k8s pod is created on this node
+func (rg *routeGenerator) onPodAdd(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		log.Warn("onPodAdd: failed to assert type to pod, passing")
+		return
+	}
+	rg.setRoutesForPod(pod)
+}
+
+// onPodUpdate is called when a k8s pod on this node is updated
+func (rg *routeGenerator) onPodUpdate(_, obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		log.Warn("onPodUpdate: failed to assert type to pod, passing")
+		return
+	}
+	rg.setRoutesForPod(pod)
+}
+
+// onPodDelete is called when a k8s pod on this node is deleted
+func (rg *routeGenerator) onPodDelete(obj interface{}) {
+	rg.unsetRoutesForPod(obj)
+}
+
 // parseIPNets takes a v1 formatted, comma separated string of CIDRs and
 // returns a list of net.IPNet object pointers.
 func parseIPNets(ipCIDRs []string) []*net.IPNet {
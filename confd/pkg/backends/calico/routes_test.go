@@ -15,6 +15,7 @@ import (
 
 	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
 
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/conversion"
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
 )
 
@@ -118,8 +119,11 @@ var _ = Describe("RouteGenerator", func() {
 			nodeName:                   "foobar",
 			svcIndexer:                 cache.NewIndexer(cache.MetaNamespaceKeyFunc, nil),
 			epIndexer:                  cache.NewIndexer(cache.MetaNamespaceKeyFunc, nil),
+			podIndexer:                 cache.NewIndexer(cache.MetaNamespaceKeyFunc, nil),
+			converter:                  conversion.NewConverter(),
 			svcRouteMap:                make(map[string]map[string]bool),
 			routeAdvertisementRefCount: make(map[string]int),
+			workloadRouteMap:           make(map[string]map[string]string),
 			client: &client{
 				cache:                    make(map[string]string),
 				syncedOnce:               true,
@@ -631,6 +635,104 @@ var _ = Describe("RouteGenerator", func() {
 	})
 })
 
+var _ = Describe("RouteGenerator workload advertisements", func() {
+	var rg *routeGenerator
+	var pod *v1.Pod
+
+	setWorkloadAdvertisements := func(spec apiv3.BGPConfigurationSpec) {
+		svcAdvertisement := false
+		rg.client.updateWorkloadAdvertisements(&apiv3.BGPConfiguration{Spec: spec}, &svcAdvertisement)
+		Expect(svcAdvertisement).To(BeTrue())
+	}
+
+	BeforeEach(func() {
+		rg = &routeGenerator{
+			nodeName:         "foobar",
+			svcIndexer:       cache.NewIndexer(cache.MetaNamespaceKeyFunc, nil),
+			epIndexer:        cache.NewIndexer(cache.MetaNamespaceKeyFunc, nil),
+			podIndexer:       cache.NewIndexer(cache.MetaNamespaceKeyFunc, nil),
+			converter:        conversion.NewConverter(),
+			workloadRouteMap: make(map[string]map[string]string),
+			client: &client{
+				cache:                   make(map[string]string),
+				programmedRouteRefCount: make(map[string]int),
+			},
+		}
+		rg.client.watcherCond = sync.NewCond(&rg.client.cacheLock)
+
+		pod = &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dns",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "dns"},
+			},
+			Spec: v1.PodSpec{NodeName: "foobar"},
+			Status: v1.PodStatus{
+				Phase:  v1.PodRunning,
+				PodIP:  "10.0.0.5",
+				PodIPs: []v1.PodIP{{IP: "10.0.0.5"}, {IP: "fd00::5"}},
+			},
+		}
+		Expect(rg.podIndexer.Add(pod)).To(Succeed())
+
+		setWorkloadAdvertisements(apiv3.BGPConfigurationSpec{
+			Communities: []apiv3.Community{{Name: "anycast", Value: "65000:100"}},
+			WorkloadAdvertisements: []apiv3.WorkloadAdvertisement{
+				{Selector: "app == 'dns'", Communities: []string{"anycast"}},
+				{Selector: "projectcalico.org/namespace == 'default'", Communities: []string{"65000:1:2"}},
+			},
+		})
+	})
+
+	It("should advertise the addresses of selected pods with the communities of each advertisement", func() {
+		rg.setRoutesForPod(pod)
+		Expect(rg.client.cache).To(HaveKeyWithValue("/calico/workloadroutes/10.0.0.5-32", "65000:100,65000:1:2"))
+		Expect(rg.client.cache).To(HaveKeyWithValue("/calico/workloadroutesv6/fd00::5-128", "65000:100,65000:1:2"))
+	})
+
+	It("should withdraw the addresses when the pod is deleted", func() {
+		rg.setRoutesForPod(pod)
+		rg.unsetRoutesForPod(pod)
+		Expect(rg.client.cache).To(BeEmpty())
+		Expect(rg.client.programmedRouteRefCount).To(BeEmpty())
+		Expect(rg.workloadRouteMap).To(BeEmpty())
+	})
+
+	It("should withdraw the addresses when the pod finishes", func() {
+		rg.setRoutesForPod(pod)
+		pod.Status.Phase = v1.PodSucceeded
+		rg.setRoutesForPod(pod)
+		Expect(rg.client.cache).To(BeEmpty())
+	})
+
+	It("should update the routes when the advertisements change", func() {
+		rg.setRoutesForPod(pod)
+
+		By("changing the communities")
+		setWorkloadAdvertisements(apiv3.BGPConfigurationSpec{
+			WorkloadAdvertisements: []apiv3.WorkloadAdvertisement{{Selector: "app == 'dns'", Communities: []string{"65000:200"}}},
+		})
+		rg.resyncKnownRoutes()
+		Expect(rg.client.cache).To(HaveKeyWithValue("/calico/workloadroutes/10.0.0.5-32", "65000:200"))
+		Expect(rg.client.programmedRouteRefCount).To(HaveKeyWithValue("/calico/workloadroutes/10.0.0.5-32", 1))
+
+		By("no longer selecting the pod")
+		setWorkloadAdvertisements(apiv3.BGPConfigurationSpec{
+			WorkloadAdvertisements: []apiv3.WorkloadAdvertisement{{Selector: "app == 'web'"}},
+		})
+		rg.resyncKnownRoutes()
+		Expect(rg.client.cache).To(BeEmpty())
+	})
+
+	It("should advertise routes without communities", func() {
+		setWorkloadAdvertisements(apiv3.BGPConfigurationSpec{
+			WorkloadAdvertisements: []apiv3.WorkloadAdvertisement{{Selector: "app == 'dns'"}},
+		})
+		rg.setRoutesForPod(pod)
+		Expect(rg.client.cache).To(HaveKeyWithValue("/calico/workloadroutes/10.0.0.5-32", ""))
+	})
+})
+
 var _ = Describe("Update BGP Config Cache", func() {
 	c := &client{cache: make(map[string]string)}
 
@@ -591,6 +591,7 @@ execute_tests_daemon() {
         run_individual_test 'explicit_peering/global'
         run_individual_test 'explicit_peering/global-external'
         run_individual_test 'explicit_peering/global-ipv6'
+        run_individual_test 'explicit_peering/address-families'
         run_individual_test 'explicit_peering/specific_node'
         run_individual_test 'explicit_peering/selectors'
         run_individual_test 'explicit_peering/route_reflector'
@@ -629,6 +630,7 @@ execute_tests_oneshot() {
         run_individual_test_oneshot 'explicit_peering/selectors'
         run_individual_test_oneshot 'explicit_peering/route_reflector'
         run_individual_test_oneshot 'explicit_peering/route_reflector_v6_by_ip'
+        run_individual_test_oneshot 'explicit_peering/address-families'
         run_individual_test_oneshot 'mesh/static-routes'
         run_individual_test_oneshot 'mesh/static-routes-exclude-node'
         run_individual_test_oneshot 'mesh/communities'
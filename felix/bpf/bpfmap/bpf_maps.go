@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"github.com/projectcalico/calico/felix/bpf/arp"
+	"github.com/projectcalico/calico/felix/bpf/connlimit"
 	"github.com/projectcalico/calico/felix/bpf/conntrack"
 	"github.com/projectcalico/calico/felix/bpf/counters"
 	"github.com/projectcalico/calico/felix/bpf/failsafes"
@@ -45,6 +46,7 @@ type IPMaps struct {
 	CtMap        maps.Map
 	SrMsgMap     maps.Map
 	CtNatsMap    maps.Map
+	ConnLimitMap maps.Map
 }
 
 type CommonMaps struct {
@@ -116,6 +118,7 @@ func getIPMaps(ipFamily int) *IPMaps {
 		CtMap:        getmap(conntrack.Map, conntrack.MapV6),
 		SrMsgMap:     getmap(nat.SendRecvMsgMap, nat.SendRecvMsgMapV6),
 		CtNatsMap:    getmap(nat.AllNATsMsgMap, nat.AllNATsMsgMapV6),
+		ConnLimitMap: getmap(connlimit.Map, connlimit.MapV6),
 	}
 }
 
@@ -183,6 +186,7 @@ func (i *IPMaps) slice() []maps.Map {
 		i.CtMap,
 		i.SrMsgMap,
 		i.CtNatsMap,
+		i.ConnLimitMap,
 	}
 }
 
@@ -0,0 +1,75 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connlimit maintains the per-source connection counts that BPF policy programs use to
+// implement the connLimit rule match.
+package connlimit
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/projectcalico/calico/felix/bpf/maps"
+)
+
+func init() {
+	maps.SetSize(MapParams.VersionedName(), MapParams.MaxEntries)
+	maps.SetSize(MapV6Params.VersionedName(), MapV6Params.MaxEntries)
+}
+
+const (
+	// KeySize is the size of an IPv4 key: the source IP.
+	KeySize = 4
+	// KeyV6Size is the size of an IPv6 key: the source IP.
+	KeyV6Size = 16
+	// ValueSize is the size of a value: the number of tracked connections opened by the source.
+	ValueSize = 4
+)
+
+var MapParams = maps.MapParameters{
+	Type:       "hash",
+	KeySize:    KeySize,
+	ValueSize:  ValueSize,
+	MaxEntries: 65536,
+	Name:       "cali_v4_cnlim",
+	Flags:      unix.BPF_F_NO_PREALLOC,
+}
+
+var MapV6Params = maps.MapParameters{
+	Type:       "hash",
+	KeySize:    KeyV6Size,
+	ValueSize:  ValueSize,
+	MaxEntries: 65536,
+	Name:       "cali_v6_cnlim",
+	Flags:      unix.BPF_F_NO_PREALLOC,
+}
+
+func Map() maps.Map {
+	return maps.NewPinnedMap(MapParams)
+}
+
+func MapV6() maps.Map {
+	return maps.NewPinnedMap(MapV6Params)
+}
+
+func ValueFromCount(count uint32) []byte {
+	v := make([]byte, ValueSize)
+	binary.LittleEndian.PutUint32(v, count)
+	return v
+}
+
+func CountFromValue(v []byte) uint32 {
+	return binary.LittleEndian.Uint32(v)
+}
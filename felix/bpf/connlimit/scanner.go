@@ -0,0 +1,116 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connlimit
+
+import (
+	"net"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/bpf/conntrack"
+	"github.com/projectcalico/calico/felix/bpf/maps"
+)
+
+// Scanner is a conntrack.EntryScannerSynced that counts the tracked connections opened by each
+// source IP and, at the end of each scan, writes the counts to the connection limit map.  The
+// counts are therefore only as fresh as the last conntrack scan.
+//
+// It should be added to the conntrack scanner after any scanners that delete expired entries so
+// that only live connections are counted.
+type Scanner struct {
+	countsMap maps.Map
+	ipVersion int
+
+	counts  map[string]uint32
+	written map[string]uint32
+}
+
+func NewScanner(countsMap maps.Map, ipVersion int) *Scanner {
+	return &Scanner{
+		countsMap: countsMap,
+		ipVersion: ipVersion,
+		counts:    map[string]uint32{},
+	}
+}
+
+// Check implements conntrack.EntryScanner.  It never deletes entries.
+func (s *Scanner) Check(ctKey conntrack.KeyInterface, ctVal conntrack.ValueInterface, _ conntrack.EntryGet) conntrack.ScanVerdict {
+	if ctVal.Type() == conntrack.TypeNATForward {
+		// NAT connections have a forward and a reverse entry; count the reverse entry only.
+		return conntrack.ScanVerdictOK
+	}
+
+	var src net.IP
+	data := ctVal.Data()
+	if data.A2B.Opener {
+		src = ctKey.AddrA()
+	} else if data.B2A.Opener {
+		src = ctKey.AddrB()
+	} else {
+		return conntrack.ScanVerdictOK
+	}
+
+	if s.ipVersion == 4 {
+		src = src.To4()
+	} else {
+		src = src.To16()
+	}
+	if src == nil {
+		return conntrack.ScanVerdictOK
+	}
+	s.counts[string(src)]++
+	return conntrack.ScanVerdictOK
+}
+
+// IterationStart implements conntrack.EntryScannerSynced.
+func (s *Scanner) IterationStart() {
+	s.counts = map[string]uint32{}
+}
+
+// IterationEnd implements conntrack.EntryScannerSynced.
+func (s *Scanner) IterationEnd() {
+	if s.written == nil {
+		// First scan, load whatever a previous Felix left in the map so that we clean it up.
+		s.written = map[string]uint32{}
+		err := s.countsMap.Iter(func(k, v []byte) maps.IteratorAction {
+			s.written[string(k)] = CountFromValue(v)
+			return maps.IterNone
+		})
+		if err != nil {
+			log.WithError(err).Warn("Failed to load connection limit map.")
+		}
+	}
+
+	for k, count := range s.counts {
+		if s.written[k] == count {
+			continue
+		}
+		if err := s.countsMap.Update([]byte(k), ValueFromCount(count)); err != nil {
+			log.WithError(err).WithField("ip", net.IP(k)).Warn("Failed to update connection count.")
+			continue
+		}
+		s.written[k] = count
+	}
+	for k := range s.written {
+		if _, ok := s.counts[k]; ok {
+			continue
+		}
+		if err := s.countsMap.Delete([]byte(k)); err != nil && !maps.IsNotExists(err) {
+			log.WithError(err).WithField("ip", net.IP(k)).Warn("Failed to delete connection count.")
+			continue
+		}
+		delete(s.written, k)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connlimit
+
+import (
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/bpf/conntrack"
+	"github.com/projectcalico/calico/felix/bpf/mock"
+)
+
+var (
+	client1 = net.ParseIP("10.0.0.1").To4()
+	client2 = net.ParseIP("10.0.0.2").To4()
+	server  = net.ParseIP("10.0.1.1").To4()
+)
+
+func scan(s *Scanner, entries map[conntrack.Key]conntrack.Value) {
+	s.IterationStart()
+	for k, v := range entries {
+		s.Check(k, v, nil)
+	}
+	s.IterationEnd()
+}
+
+func TestScannerCountsOpeners(t *testing.T) {
+	RegisterTestingT(t)
+
+	m := mock.NewMockMap(MapParams)
+	m.Contents[string(net.ParseIP("10.0.0.9").To4())] = string(ValueFromCount(3))
+	s := NewScanner(m, 4)
+
+	opener := conntrack.Leg{Opener: true}
+	entries := map[conntrack.Key]conntrack.Value{
+		// client1 opened two connections to the server, one of them NATted.
+		conntrack.NewKey(6, client1, 1000, server, 80): conntrack.NewValueNormal(0, 0, 0, opener, conntrack.Leg{}),
+		conntrack.NewKey(6, server, 80, client1, 1001): conntrack.NewValueNormal(0, 0, 0, conntrack.Leg{}, opener),
+		conntrack.NewKey(6, client1, 1002, server, 90): conntrack.NewValueNATForward(0, 0, 0,
+			conntrack.NewKey(6, client1, 1002, server, 80)),
+		// The server opened one connection to client2.
+		conntrack.NewKey(17, server, 53, client2, 2000): conntrack.NewValueNormal(0, 0, 0, opener, conntrack.Leg{}),
+	}
+	scan(s, entries)
+
+	Expect(m.Contents).To(Equal(map[string]string{
+		string(client1): string(ValueFromCount(2)),
+		string(server):  string(ValueFromCount(1)),
+	}))
+
+	// Connections going away should remove the count.
+	delete(entries, conntrack.NewKey(17, server, 53, client2, 2000))
+	scan(s, entries)
+	Expect(m.Contents).To(Equal(map[string]string{
+		string(client1): string(ValueFromCount(2)),
+	}))
+}
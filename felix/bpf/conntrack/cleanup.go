@@ -54,6 +54,43 @@ func DefaultTimeouts() Timeouts {
 	}
 }
 
+// Names of the timeouts that can be overridden via the ConntrackTimeouts config parameter.
+const (
+	TimeoutNameTCPSynSent     = "TCPSynSent"
+	TimeoutNameTCPEstablished = "TCPEstablished"
+	TimeoutNameTCPFinsSeen    = "TCPFinsSeen"
+	TimeoutNameTCPResetSeen   = "TCPResetSeen"
+	TimeoutNameUDP            = "UDPTimeout"
+	TimeoutNameICMP           = "ICMPTimeout"
+	TimeoutNameGeneric        = "GenericTimeout"
+)
+
+// WithOverrides returns a copy of the timeouts with the named timeouts replaced by the given
+// values.  Unknown names are logged and ignored.
+func (t Timeouts) WithOverrides(overrides map[string]time.Duration) Timeouts {
+	for name, d := range overrides {
+		switch name {
+		case TimeoutNameTCPSynSent:
+			t.TCPPreEstablished = d
+		case TimeoutNameTCPEstablished:
+			t.TCPEstablished = d
+		case TimeoutNameTCPFinsSeen:
+			t.TCPFinsSeen = d
+		case TimeoutNameTCPResetSeen:
+			t.TCPResetSeen = d
+		case TimeoutNameUDP:
+			t.UDPLastSeen = d
+		case TimeoutNameICMP:
+			t.ICMPLastSeen = d
+		case TimeoutNameGeneric:
+			t.GenericIPLastSeen = d
+		default:
+			log.WithField("name", name).Warn("Ignoring unknown conntrack timeout override.")
+		}
+	}
+	return t
+}
+
 type LivenessScanner struct {
 	timeouts Timeouts
 	dsr      bool
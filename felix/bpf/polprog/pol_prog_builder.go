@@ -50,6 +50,7 @@ type Builder struct {
 
 	ipSetMapFD         maps.FD
 	stateMapFD         maps.FD
+	connLimitMapFD     maps.FD
 	staticJumpMapFD    maps.FD
 	policyJumpMapFD    maps.FD
 	policyMapIndex     int
@@ -118,6 +119,8 @@ var (
 	// port+proto+pad, the dst key is also aligned in the same way. <sweat :-)>
 	offSrcIPSetKey = nextOffset(ipsets.IPSetEntryV6Size, 4)
 	offDstIPSetKey = nextOffset(ipsets.IPSetEntryV6Size, 4)
+	// Key for the connection limit map; the source IP.  Sized for IPv6.
+	offConnLimitKey = nextOffset(16, 8)
 
 	// Offsets within the cal_tc_state struct.
 	// WARNING: must be kept in sync with the definitions in bpf-gpl/types.h.
@@ -727,12 +730,49 @@ func (p *Builder) writeRule(r Rule, actionLabel string, destLeg matchLeg) {
 		}
 	}
 
+	if rule.ConnLimit > 0 {
+		log.WithField("connLimit", rule.ConnLimit).Debugf("ConnLimit match")
+		p.writeConnLimitMatch(rule.ConnLimit)
+	}
+
 	p.writeEndOfRule(r, actionLabel)
 	p.ruleID++
 	p.rulePartID = 0
 	p.numRulesInProgram++
 }
 
+// writeConnLimitMatch matches if the source IP already has at least limit tracked connections,
+// which means that the connection being matched would take it above the limit.  The counts are
+// maintained by Felix's conntrack scanner.
+func (p *Builder) writeConnLimitMatch(limit int32) {
+	if p.connLimitMapFD == 0 {
+		log.Warn("Rule has a connection limit but no connection limit map was provided, rule will not match.")
+		p.b.Jump(p.endOfRuleLabel())
+		return
+	}
+
+	p.b.AddCommentF("If source has fewer than %d connections, skip to next rule", limit)
+	if !p.forIPv6 {
+		p.b.Load32(R1, R9, stateOffIPSrc)
+		p.b.StoreStack32(R1, offConnLimitKey)
+	} else {
+		ipOffset := stateOffIPSrc
+		p.b.Load64(R1, R9, ipOffset)
+		p.b.StoreStack64(R1, offConnLimitKey)
+		ipOffset.Offset += 8
+		p.b.Load64(R1, R9, ipOffset)
+		p.b.StoreStack64(R1, offConnLimitKey+8)
+	}
+	p.b.LoadMapFD(R1, uint32(p.connLimitMapFD))
+	p.b.Mov64(R2, R10)
+	p.b.AddImm64(R2, int32(offConnLimitKey))
+	p.b.Call(HelperMapLookupElem)
+	// No entry means no tracked connections.
+	p.b.JumpEqImm64(R0, 0, p.endOfRuleLabel())
+	p.b.Load32(R1, R0, FieldOffset{Offset: 0, Field: "conn_count"})
+	p.b.JumpLTImm64(R1, limit, p.endOfRuleLabel())
+}
+
 func (p *Builder) writeStartOfRule() {
 }
 
@@ -1224,6 +1264,14 @@ func WithPolicyMapIndexAndStride(entryPointIdx, stride int) Option {
 	}
 }
 
+// WithConnLimitMap provides the map of per-source connection counts that is used to implement
+// the connection limit match.
+func WithConnLimitMap(fd maps.FD) Option {
+	return func(b *Builder) {
+		b.connLimitMapFD = fd
+	}
+}
+
 func WithIPv6() Option {
 	return func(p *Builder) {
 		p.forIPv6 = true
@@ -169,6 +169,32 @@ func TestPolicyDump(t *testing.T) {
 	checkLabelsAndComments(proto.Rule{NotIcmp: &proto.Rule_NotIcmpType{NotIcmpType: 10}}, "If ICMP type == 10, skip to next rule", "comment")
 }
 
+func TestConnLimitMatch(t *testing.T) {
+	RegisterTestingT(t)
+	alloc := idalloc.New()
+
+	build := func(opts ...Option) []string {
+		pg := NewBuilder(alloc, 1, 2, 3, 4, append(opts, WithPolicyDebugEnabled())...)
+		insns, err := pg.Instructions(Rules{
+			Tiers: []Tier{{
+				Policies: []Policy{{
+					Rules: []Rule{{Rule: &proto.Rule{
+						Action:    "Deny",
+						ConnLimit: 10,
+					}}},
+				}},
+			}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, comments := aggregateCommentsAndLabels(&insns[0])
+		return comments
+	}
+
+	Expect(build(WithConnLimitMap(5))).To(ContainElement("If source has fewer than 10 connections, skip to next rule"))
+	Expect(build(WithConnLimitMap(5), WithIPv6())).To(ContainElement("If source has fewer than 10 connections, skip to next rule"))
+	Expect(build()).NotTo(ContainElement("If source has fewer than 10 connections, skip to next rule"))
+}
+
 func aggregateCommentsAndLabels(insns *asm.Insns) ([]string, []string) {
 	labels := []string{}
 	comments := []string{}
@@ -60,7 +60,7 @@ type endpointCallbacks interface {
 }
 
 type configCallbacks interface {
-	OnConfigUpdate(globalConfig, hostConfig map[string]string)
+	OnConfigUpdate(globalConfig, selectedConfig, hostConfig map[string]string)
 	OnDatastoreNotReady()
 }
 
@@ -98,6 +98,11 @@ type vxlanCallbacks interface {
 	OnVTEPRemove(node string)
 }
 
+type routePolicyCallbacks interface {
+	OnRoutePolicyUpdate(update *proto.RoutePolicyUpdate)
+	OnRoutePolicyRemove(name string)
+}
+
 type PipelineCallbacks interface {
 	ipSetUpdateCallbacks
 	rulesUpdateCallbacks
@@ -107,6 +112,7 @@ type PipelineCallbacks interface {
 	passthruCallbacks
 	routeCallbacks
 	vxlanCallbacks
+	routePolicyCallbacks
 }
 
 type CalcGraph struct {
@@ -130,6 +136,7 @@ type CalcGraph struct {
 	profileDecoder          *ProfileDecoder
 	encapsulationResolver   *EncapsulationResolver
 	policyResolver          *PolicyResolver
+	routePolicyResolver     *RoutePolicyResolver
 }
 
 func (g *CalcGraph) OnUpdates(updates []api.Update) {
@@ -142,6 +149,7 @@ func (g *CalcGraph) OnStatusUpdated(update api.SyncStatus) {
 
 func (g *CalcGraph) Flush() {
 	g.policyResolver.Flush()
+	g.routePolicyResolver.Flush()
 }
 
 func NewCalculationGraph(callbacks PipelineCallbacks, conf *config.Config, liveCallback func()) *CalcGraph {
@@ -214,6 +222,14 @@ func NewCalculationGraph(callbacks PipelineCallbacks, conf *config.Config, liveC
 	//                   | Locally active policies/profiles
 	//             ...
 	//
+	// Policies whose rules use label() to refer to the labels of the endpoint that they apply to
+	// are expanded into one policy per combination of those labels' values on the local
+	// endpoints.  The expander has to see policies and local endpoints before the active rules
+	// calculator, and anything else downstream, so that it can replace the policies with their
+	// variants.
+	labelRefExpander := NewLabelRefExpander(allUpdDispatcher.OnUpdate)
+	labelRefExpander.RegisterWith(localEndpointDispatcher, allUpdDispatcher)
+
 	activeRulesCalc := NewActiveRulesCalculator()
 	activeRulesCalc.RegisterWith(localEndpointDispatcher, allUpdDispatcher)
 	cg.activeRulesCalculator = activeRulesCalc
@@ -239,6 +255,9 @@ func NewCalculationGraph(callbacks PipelineCallbacks, conf *config.Config, liveC
 	//     <dataplane>
 	//
 	ruleScanner := NewRuleScanner()
+	// Only the nftables dataplane can match the port ranges in an IP set.  The BPF dataplane and
+	// the policy sync API expect a single port per IP set member.
+	ruleScanner.CombineSelectorsAndPorts = conf.NFTablesMode == "Enabled" && !conf.BPFEnabled && conf.PolicySyncPathPrefix == ""
 	// Wire up the rule scanner's inputs.
 	activeRulesCalc.RuleScanner = ruleScanner
 	// Send IP set added/removed events to the dataplane.  We'll hook up the other outputs
@@ -304,6 +323,8 @@ func NewCalculationGraph(callbacks PipelineCallbacks, conf *config.Config, liveC
 		callbacks.OnIPSetAdded(ipSet.UniqueID(), ipSet.DataplaneProtocolType())
 		if ipSet.Service != "" {
 			serviceIndex.UpdateIPSet(ipSet.UniqueID(), ipSet.Service)
+		} else if len(ipSet.Ports) > 0 {
+			ipsetMemberIndex.UpdateIPPortIPSet(ipSet.UniqueID(), ipSet.Selector, ipSet.NamedPortProtocol, ipSet.Ports)
 		} else {
 			ipsetMemberIndex.UpdateIPSet(ipSet.UniqueID(), ipSet.Selector, ipSet.NamedPortProtocol, ipSet.NamedPort)
 		}
@@ -370,6 +391,29 @@ func NewCalculationGraph(callbacks PipelineCallbacks, conf *config.Config, liveC
 	polResolver.Callbacks = callbacks
 	cg.policyResolver = polResolver
 
+	// The route policy resolver matches RoutePolicy selectors against local workload endpoints
+	// to calculate the workload addresses that each policy steers.
+	//
+	//        ...
+	//     Dispatcher (all updates)
+	//      |
+	//      | RoutePolicies, Profiles
+	//      |
+	//      |       Dispatcher (local updates)
+	//       \          /
+	//        \        / Local Workload Endpoints
+	//         \      /
+	//    Route policy resolver
+	//            |
+	//            | RoutePolicy X applies to workload addresses [A, B]
+	//            |
+	//       <dataplane>
+	//
+	routePolicyResolver := NewRoutePolicyResolver()
+	routePolicyResolver.RegisterWith(allUpdDispatcher, localEndpointDispatcher)
+	routePolicyResolver.Callbacks = callbacks
+	cg.routePolicyResolver = routePolicyResolver
+
 	// Register for host IP updates.
 	//
 	//        ...
@@ -15,9 +15,14 @@
 package calc
 
 import (
+	"maps"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/projectcalico/calico/felix/config"
 	"github.com/projectcalico/calico/felix/dispatcher"
+	libv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
 )
@@ -30,15 +35,23 @@ type ConfigBatcher struct {
 	hostConfig      map[string]string
 	datastoreReady  bool
 	callbacks       configCallbacks
+
+	// nodeSelectorConfigs are the FelixConfigurations with a NodeSelector, by name, and
+	// selectedConfig is the merged config of those that select this host's labels.
+	nodeSelectorConfigs map[string]*config.NodeSelectorConfig
+	hostLabels          map[string]string
+	selectedConfig      map[string]string
 }
 
 func NewConfigBatcher(hostname string, callbacks configCallbacks) *ConfigBatcher {
 	return &ConfigBatcher{
-		hostname:     hostname,
-		configDirty:  true,
-		globalConfig: make(map[string]string),
-		hostConfig:   make(map[string]string),
-		callbacks:    callbacks,
+		hostname:            hostname,
+		configDirty:         true,
+		globalConfig:        make(map[string]string),
+		hostConfig:          make(map[string]string),
+		callbacks:           callbacks,
+		nodeSelectorConfigs: make(map[string]*config.NodeSelectorConfig),
+		selectedConfig:      make(map[string]string),
 	}
 }
 
@@ -46,6 +59,7 @@ func (cb *ConfigBatcher) RegisterWith(allUpdDispatcher *dispatcher.Dispatcher) {
 	allUpdDispatcher.Register(model.GlobalConfigKey{}, cb.OnUpdate)
 	allUpdDispatcher.Register(model.HostConfigKey{}, cb.OnUpdate)
 	allUpdDispatcher.Register(model.ReadyFlagKey{}, cb.OnUpdate)
+	allUpdDispatcher.Register(model.ResourceKey{}, cb.OnResourceUpdate)
 	allUpdDispatcher.RegisterStatusHandler(cb.OnDatamodelStatus)
 }
 
@@ -97,6 +111,71 @@ func (cb *ConfigBatcher) OnUpdate(update api.Update) (filterOut bool) {
 	return
 }
 
+// OnResourceUpdate handles the FelixConfigurations with a NodeSelector, and the labels of this
+// host's Node, which determine the FelixConfigurations that select it.
+func (cb *ConfigBatcher) OnResourceUpdate(update api.Update) (filterOut bool) {
+	switch key := update.Key.(type) {
+	case model.ResourceKey:
+		switch key.Kind {
+		case apiv3.KindFelixConfiguration:
+			if update.Value == nil {
+				log.WithField("name", key.Name).Info("FelixConfiguration with a node selector deleted")
+				delete(cb.nodeSelectorConfigs, key.Name)
+				break
+			}
+			c, err := config.NodeSelectorConfigFromResource(update.Value.(*apiv3.FelixConfiguration))
+			if err != nil {
+				log.WithError(err).Warn("Ignoring invalid FelixConfiguration")
+				delete(cb.nodeSelectorConfigs, key.Name)
+				break
+			}
+			log.WithField("name", key.Name).Info("FelixConfiguration with a node selector updated")
+			cb.nodeSelectorConfigs[key.Name] = c
+		case libv3.KindNode:
+			if key.Name != cb.hostname {
+				return
+			}
+			var labels map[string]string
+			if update.Value != nil {
+				labels = update.Value.(*libv3.Node).Labels
+			}
+			if maps.Equal(labels, cb.hostLabels) {
+				return
+			}
+			cb.hostLabels = labels
+		default:
+			return
+		}
+	default:
+		return
+	}
+	cb.updateSelectedConfig()
+	cb.maybeSendCachedConfig()
+	return
+}
+
+// updateSelectedConfig recalculates the merged config of the FelixConfigurations that select this
+// host.
+func (cb *ConfigBatcher) updateSelectedConfig() {
+	configs := make([]*config.NodeSelectorConfig, 0, len(cb.nodeSelectorConfigs))
+	for _, c := range cb.nodeSelectorConfigs {
+		configs = append(configs, c)
+	}
+	values, sources := config.MergeNodeSelectorConfigs(configs, cb.hostLabels)
+	if maps.Equal(values, cb.selectedConfig) {
+		return
+	}
+	for name, value := range values {
+		log.WithFields(log.Fields{
+			"name":              name,
+			"value":             value,
+			"felixConfigSource": sources[name],
+		}).Info("Config selected for this host")
+	}
+	cb.selectedConfig = values
+	cb.configDirty = true
+}
+
 func (cb *ConfigBatcher) OnDatamodelStatus(status api.SyncStatus) {
 	if !cb.datastoreInSync && status == api.InSync {
 		log.Infof("Datamodel in sync, flushing config update")
@@ -109,19 +188,23 @@ func (cb *ConfigBatcher) maybeSendCachedConfig() {
 	if !cb.configDirty || !cb.datastoreInSync {
 		return
 	}
-	log.Infof("Sending config update global: %v, host: %v.",
-		cb.globalConfig, cb.hostConfig)
+	log.Infof("Sending config update global: %v, selected: %v, host: %v.",
+		cb.globalConfig, cb.selectedConfig, cb.hostConfig)
 	globalConfigCopy := make(map[string]string)
+	selectedConfigCopy := make(map[string]string)
 	hostConfigCopy := make(map[string]string)
 	for k, v := range cb.globalConfig {
 		globalConfigCopy[k] = v
 	}
+	for k, v := range cb.selectedConfig {
+		selectedConfigCopy[k] = v
+	}
 	for k, v := range cb.hostConfig {
 		hostConfigCopy[k] = v
 	}
 	if !cb.datastoreReady {
 		cb.callbacks.OnDatastoreNotReady()
 	}
-	cb.callbacks.OnConfigUpdate(globalConfigCopy, hostConfigCopy)
+	cb.callbacks.OnConfigUpdate(globalConfigCopy, selectedConfigCopy, hostConfigCopy)
 	cb.configDirty = false
 }
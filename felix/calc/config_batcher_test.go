@@ -19,7 +19,9 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
 
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
 )
@@ -75,6 +77,7 @@ var _ = Describe("ConfigBatcher", func() {
 			})
 			It("should emit one event", func() {
 				Expect(recorder.Updates).To(ConsistOf(configUpdate{
+					selected: map[string]string{},
 					host: map[string]string{
 						"foo": "bar",
 					},
@@ -92,6 +95,7 @@ var _ = Describe("ConfigBatcher", func() {
 				})
 				It("should emit one event", func() {
 					Expect(recorder.Updates).To(ConsistOf(configUpdate{
+						selected: map[string]string{},
 						host: map[string]string{
 							"foo": "biz",
 						},
@@ -110,7 +114,8 @@ var _ = Describe("ConfigBatcher", func() {
 				})
 				It("should emit one event", func() {
 					Expect(recorder.Updates).To(ConsistOf(configUpdate{
-						host: map[string]string{},
+						selected: map[string]string{},
+						host:     map[string]string{},
 						global: map[string]string{
 							"biff": "bop",
 						},
@@ -124,8 +129,9 @@ var _ = Describe("ConfigBatcher", func() {
 					})
 					It("should emit one event", func() {
 						Expect(recorder.Updates).To(ConsistOf(configUpdate{
-							host:   map[string]string{},
-							global: map[string]string{},
+							selected: map[string]string{},
+							host:     map[string]string{},
+							global:   map[string]string{},
 						}))
 						Expect(recorder.NotReady).To(BeFalse())
 					})
@@ -169,6 +175,81 @@ var _ = Describe("ConfigBatcher", func() {
 		})
 	})
 
+	Context("with FelixConfigurations that select nodes", func() {
+		sendFelixConfig := func(name, sel string, priority int, values map[string]string) {
+			fc := apiv3.NewFelixConfiguration()
+			fc.Name = name
+			fc.Spec.NodeSelector = sel
+			fc.Spec.Priority = &priority
+			fc.Annotations = map[string]string{}
+			for k, v := range values {
+				fc.Annotations["config.projectcalico.org/"+k] = v
+			}
+			cb.OnResourceUpdate(api.Update{
+				KVPair: model.KVPair{
+					Key:   model.ResourceKey{Kind: apiv3.KindFelixConfiguration, Name: name},
+					Value: fc,
+				},
+			})
+		}
+		sendNode := func(name string, labels map[string]string) {
+			node := libapiv3.NewNode()
+			node.Name = name
+			node.Labels = labels
+			cb.OnResourceUpdate(api.Update{
+				KVPair: model.KVPair{
+					Key:   model.ResourceKey{Kind: libapiv3.KindNode, Name: name},
+					Value: node,
+				},
+			})
+		}
+
+		BeforeEach(func() {
+			sendGlobalUpdate("biff", "bop")
+			sendReady(true)
+			sendFelixConfig("rack-a", "rack == 'a'", 0, map[string]string{"foo": "a", "bar": "a"})
+			sendFelixConfig("rack-a-high", "rack == 'a'", 10, map[string]string{"foo": "high"})
+			sendFelixConfig("rack-b", "rack == 'b'", 0, map[string]string{"foo": "b"})
+			sendNode("otherhost", map[string]string{"rack": "b"})
+			sendNode("myhost", map[string]string{"rack": "a"})
+			cb.OnDatamodelStatus(api.InSync)
+		})
+
+		It("should emit the config of the FelixConfigurations that select this host", func() {
+			Expect(recorder.Updates).To(ConsistOf(configUpdate{
+				selected: map[string]string{"foo": "high", "bar": "a"},
+				host:     map[string]string{},
+				global:   map[string]string{"biff": "bop"},
+			}))
+		})
+
+		It("should update the config when the host's labels change", func() {
+			recorder.Reset()
+			sendNode("otherhost", map[string]string{"rack": "a"})
+			Expect(recorder.Updates).To(BeEmpty())
+			sendNode("myhost", map[string]string{"rack": "b"})
+			Expect(recorder.Updates).To(ConsistOf(configUpdate{
+				selected: map[string]string{"foo": "b"},
+				host:     map[string]string{},
+				global:   map[string]string{"biff": "bop"},
+			}))
+		})
+
+		It("should update the config when a FelixConfiguration is deleted", func() {
+			recorder.Reset()
+			cb.OnResourceUpdate(api.Update{
+				KVPair: model.KVPair{
+					Key: model.ResourceKey{Kind: apiv3.KindFelixConfiguration, Name: "rack-a-high"},
+				},
+			})
+			Expect(recorder.Updates).To(ConsistOf(configUpdate{
+				selected: map[string]string{"foo": "a", "bar": "a"},
+				host:     map[string]string{},
+				global:   map[string]string{"biff": "bop"},
+			}))
+		})
+	})
+
 	Context("after sending in-sync with no config", func() {
 		BeforeEach(func() {
 			cb.OnDatamodelStatus(api.InSync)
@@ -176,16 +257,18 @@ var _ = Describe("ConfigBatcher", func() {
 		It("should emit a not-ready and empty config", func() {
 			Expect(recorder.NotReady).To(BeTrue())
 			Expect(recorder.Updates).To(ConsistOf(configUpdate{
-				host:   map[string]string{},
-				global: map[string]string{},
+				selected: map[string]string{},
+				host:     map[string]string{},
+				global:   map[string]string{},
 			}))
 		})
 	})
 })
 
 type configUpdate struct {
-	host   map[string]string
-	global map[string]string
+	host     map[string]string
+	selected map[string]string
+	global   map[string]string
 }
 
 type configRecorder struct {
@@ -193,10 +276,11 @@ type configRecorder struct {
 	NotReady bool
 }
 
-func (cr *configRecorder) OnConfigUpdate(globalConfig, hostConfig map[string]string) {
+func (cr *configRecorder) OnConfigUpdate(globalConfig, selectedConfig, hostConfig map[string]string) {
 	cr.Updates = append(cr.Updates, configUpdate{
-		host:   hostConfig,
-		global: globalConfig,
+		host:     hostConfig,
+		selected: selectedConfig,
+		global:   globalConfig,
 	})
 }
 
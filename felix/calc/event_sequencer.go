@@ -69,6 +69,7 @@ type EventSequencer struct {
 	pendingIPPoolDeletes         set.Set[ip.CIDR]
 	pendingNotReady              bool
 	pendingGlobalConfig          map[string]string
+	pendingSelectedConfig        map[string]string
 	pendingHostConfig            map[string]string
 	pendingServiceAccountUpdates map[proto.ServiceAccountID]*proto.ServiceAccountUpdate
 	pendingServiceAccountDeletes set.Set[proto.ServiceAccountID]
@@ -83,6 +84,8 @@ type EventSequencer struct {
 	pendingGlobalBGPConfig       *proto.GlobalBGPConfigUpdate
 	pendingServiceUpdates        map[serviceID]*proto.ServiceUpdate
 	pendingServiceDeletes        set.Set[serviceID]
+	pendingRoutePolicyUpdates    map[string]*proto.RoutePolicyUpdate
+	pendingRoutePolicyDeletes    set.Set[string]
 
 	// Sets to record what we've sent downstream. Updated whenever we flush.
 	sentIPSets          set.Set[string]
@@ -100,6 +103,7 @@ type EventSequencer struct {
 	sentWireguard       set.Set[string]
 	sentWireguardV6     set.Set[string]
 	sentServices        set.Set[serviceID]
+	sentRoutePolicies   set.Set[string]
 
 	Callback EventHandler
 }
@@ -161,6 +165,8 @@ func NewEventSequencer(conf configInterface) *EventSequencer {
 		pendingWireguardDeletes:      set.New[string](),
 		pendingServiceUpdates:        map[serviceID]*proto.ServiceUpdate{},
 		pendingServiceDeletes:        set.New[serviceID](),
+		pendingRoutePolicyUpdates:    map[string]*proto.RoutePolicyUpdate{},
+		pendingRoutePolicyDeletes:    set.New[string](),
 
 		// Sets to record what we've sent downstream. Updated whenever we flush.
 		sentIPSets:          set.New[string](),
@@ -178,6 +184,7 @@ func NewEventSequencer(conf configInterface) *EventSequencer {
 		sentWireguard:       set.New[string](),
 		sentWireguardV6:     set.New[string](),
 		sentServices:        set.New[serviceID](),
+		sentRoutePolicies:   set.New[string](),
 	}
 	return buf
 }
@@ -257,8 +264,9 @@ func (buf *EventSequencer) flushReadyFlag() {
 
 type DatastoreNotReady struct{}
 
-func (buf *EventSequencer) OnConfigUpdate(globalConfig, hostConfig map[string]string) {
+func (buf *EventSequencer) OnConfigUpdate(globalConfig, selectedConfig, hostConfig map[string]string) {
 	buf.pendingGlobalConfig = globalConfig
+	buf.pendingSelectedConfig = selectedConfig
 	buf.pendingHostConfig = hostConfig
 }
 
@@ -267,24 +275,30 @@ func (buf *EventSequencer) flushConfigUpdate() {
 		return
 	}
 	logCxt := log.WithFields(log.Fields{
-		"global": buf.pendingGlobalConfig,
-		"host":   buf.pendingHostConfig,
+		"global":   buf.pendingGlobalConfig,
+		"selected": buf.pendingSelectedConfig,
+		"host":     buf.pendingHostConfig,
 	})
 	logCxt.Info("Possible config update.")
 	globalChanged, err := buf.config.UpdateFrom(buf.pendingGlobalConfig, config.DatastoreGlobal)
 	if err != nil {
 		logCxt.WithError(err).Panic("Failed to parse config update")
 	}
+	selectedChanged, err := buf.config.UpdateFrom(buf.pendingSelectedConfig, config.DatastoreNodeSelector)
+	if err != nil {
+		logCxt.WithError(err).Panic("Failed to parse config update")
+	}
 	hostChanged, err := buf.config.UpdateFrom(buf.pendingHostConfig, config.DatastorePerHost)
 	if err != nil {
 		logCxt.WithError(err).Panic("Failed to parse config update")
 	}
-	if globalChanged || hostChanged {
+	if globalChanged || selectedChanged || hostChanged {
 		rawConfig := buf.config.RawValues()
 		log.WithField("merged", rawConfig).Info("Config changed. Sending ConfigUpdate message.")
 		buf.Callback(buf.config.ToConfigUpdate())
 	}
 	buf.pendingGlobalConfig = nil
+	buf.pendingSelectedConfig = nil
 	buf.pendingHostConfig = nil
 }
 
@@ -320,6 +334,7 @@ func ParsedRulesToActivePolicyUpdate(key model.PolicyKey, rules *ParsedRules) *p
 			Untracked:        rules.Untracked,
 			PreDnat:          rules.PreDNAT,
 			OriginalSelector: rules.OriginalSelector,
+			ContentHash:      rules.ContentHash,
 		},
 	}
 }
@@ -782,12 +797,17 @@ func memberToProto(member labelindex.IPSetMember) string {
 	switch member.Protocol {
 	case labelindex.ProtocolNone:
 		return member.CIDR.String()
-	case labelindex.ProtocolTCP:
-		return fmt.Sprintf("%s,tcp:%d", member.CIDR.Addr(), member.PortNumber)
-	case labelindex.ProtocolUDP:
-		return fmt.Sprintf("%s,udp:%d", member.CIDR.Addr(), member.PortNumber)
-	case labelindex.ProtocolSCTP:
-		return fmt.Sprintf("%s,sctp:%d", member.CIDR.Addr(), member.PortNumber)
+	case labelindex.ProtocolTCP, labelindex.ProtocolUDP, labelindex.ProtocolSCTP:
+		// Named port members are always single addresses and ports.  Members of numeric port IP
+		// sets may have a CIDR from a network set, and a range of ports.
+		addr := member.CIDR.Addr().String()
+		if !member.CIDR.IsSingleAddress() {
+			addr = member.CIDR.String()
+		}
+		if member.PortMax > member.PortNumber {
+			return fmt.Sprintf("%s,%s:%d-%d", addr, member.Protocol, member.PortNumber, member.PortMax)
+		}
+		return fmt.Sprintf("%s,%s:%d", addr, member.Protocol, member.PortNumber)
 	}
 	log.WithField("member", member).Panic("Unknown IP set member type")
 	return ""
@@ -825,6 +845,11 @@ func (buf *EventSequencer) Flush() {
 	buf.flushVTEPAdds()
 	buf.flushRouteAdds()
 
+	// Flush RoutePolicy updates.  These refer to local workload addresses but the dataplane
+	// doesn't need the endpoints to be in place before programming them.
+	buf.flushRoutePolicyRemoves()
+	buf.flushRoutePolicyUpdates()
+
 	// Flush (rare) cluster-wide updates.  There's no particular ordering to these so we might
 	// as well do deletions first to minimise occupancy.
 	buf.flushHostWireguardDeletes()
@@ -1034,6 +1059,39 @@ func (buf *EventSequencer) flushVTEPAdds() {
 	log.Debug("Done flushing VTEP adds")
 }
 
+func (buf *EventSequencer) OnRoutePolicyUpdate(update *proto.RoutePolicyUpdate) {
+	log.WithField("name", update.Name).Debug("RoutePolicy update")
+	buf.pendingRoutePolicyDeletes.Discard(update.Name)
+	buf.pendingRoutePolicyUpdates[update.Name] = update
+}
+
+func (buf *EventSequencer) OnRoutePolicyRemove(name string) {
+	log.WithField("name", name).Debug("RoutePolicy removed")
+	delete(buf.pendingRoutePolicyUpdates, name)
+	if buf.sentRoutePolicies.Contains(name) {
+		buf.pendingRoutePolicyDeletes.Add(name)
+	}
+}
+
+func (buf *EventSequencer) flushRoutePolicyRemoves() {
+	buf.pendingRoutePolicyDeletes.Iter(func(name string) error {
+		buf.Callback(&proto.RoutePolicyRemove{Name: name})
+		buf.sentRoutePolicies.Discard(name)
+		return nil
+	})
+	buf.pendingRoutePolicyDeletes.Clear()
+	log.Debug("Done flushing RoutePolicy removes")
+}
+
+func (buf *EventSequencer) flushRoutePolicyUpdates() {
+	for name, msg := range buf.pendingRoutePolicyUpdates {
+		buf.Callback(msg)
+		buf.sentRoutePolicies.Add(name)
+	}
+	buf.pendingRoutePolicyUpdates = make(map[string]*proto.RoutePolicyUpdate)
+	log.Debug("Done flushing RoutePolicy updates")
+}
+
 func (buf *EventSequencer) OnRouteUpdate(update *proto.RouteUpdate) {
 	routeID := routeID{
 		dst: update.Dst,
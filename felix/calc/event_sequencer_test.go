@@ -94,6 +94,7 @@ var _ = Describe("ParsedRulesToActivePolicyUpdate", func() {
 			PreDNAT:          true,
 			Untracked:        true,
 			OriginalSelector: "all()",
+			ContentHash:      "hash",
 		}
 		fullyLoadedProtoRules = proto.ActivePolicyUpdate{
 			Id: &proto.PolicyID{
@@ -107,6 +108,7 @@ var _ = Describe("ParsedRulesToActivePolicyUpdate", func() {
 				Untracked:        true,
 				PreDnat:          true,
 				OriginalSelector: "all()",
+				ContentHash:      "hash",
 			},
 		}
 	)
@@ -0,0 +1,377 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/dispatcher"
+	"github.com/projectcalico/calico/felix/labelindex"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/hash"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector/parser"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+// LabelRefExpander expands policies whose rule selectors use label(name), which refers to a label
+// of the endpoint that the policy applies to.  Such a rule means something different for each
+// endpoint, so the rest of the calculation graph can't render it once per policy.
+//
+// Instead, the expander replaces each such policy with one variant for each combination of the
+// referenced labels' values among the local endpoints.  A variant applies to the endpoints that
+// the policy applies to and that have that combination of values, and its rule selectors have
+// the references replaced by those values.  For example, a policy that applies to all() with an
+// ingress rule from "tenant == label(tenant)", and local endpoints with tenant=red and
+// tenant=blue, becomes two policies:
+//
+//   - one that applies to "tenant == 'red'" with a rule from "tenant == 'red'"
+//   - one that applies to "tenant == 'blue'" with a rule from "tenant == 'blue'".
+//
+// Endpoints that don't have a referenced label get a variant that applies to "!has(label)", in
+// which "x == label(label)" never matches and "x != label(label)" always does.
+//
+// The expander must be registered with the dispatchers before any other receivers of policies
+// and local endpoints.  It filters out the original policy and dispatches the variants, which
+// never use label(), in its place; it passes all other policies through unchanged.  The names of
+// the variants are the policy's name with a suffix that identifies the combination of values.
+type LabelRefExpander struct {
+	// policies maps the key of each policy that uses label() to its expansion.
+	policies map[model.PolicyKey]*labelRefPolicy
+	// passedThrough contains the keys of the policies that we've passed through unchanged,
+	// including our own variants, so that we know to delete them downstream if they start using
+	// label().
+	passedThrough set.Set[model.PolicyKey]
+
+	// labelIndex tracks the labels of the local endpoints, including the labels that they
+	// inherit from their profiles.  It has no selectors.
+	labelIndex *labelindex.InheritIndex
+	endpoints  set.Set[model.Key]
+
+	// dispatch sends the variants to the rest of the calculation graph.
+	dispatch func(update api.Update) bool
+}
+
+// labelRefPolicy is the expansion of a policy that uses label().
+type labelRefPolicy struct {
+	key    model.PolicyKey
+	policy *model.Policy
+	// refs are the names of the labels that the policy's rules refer to.
+	refs []string
+	// variants maps the ID of each variant to the variant.
+	variants map[string]*labelRefVariant
+	// endpointVariants maps the key of each local endpoint to the ID of its variant.
+	endpointVariants map[model.Key]string
+}
+
+// labelRefVariant is the variant of a policy for one combination of the referenced labels' values.
+type labelRefVariant struct {
+	key model.PolicyKey
+	// values holds the values of the referenced labels that are present.
+	values map[string]string
+	// numEndpoints is the number of local endpoints that the variant is needed for.
+	numEndpoints int
+}
+
+func NewLabelRefExpander(dispatch func(update api.Update) bool) *LabelRefExpander {
+	return &LabelRefExpander{
+		policies:      map[model.PolicyKey]*labelRefPolicy{},
+		passedThrough: set.New[model.PolicyKey](),
+		labelIndex:    labelindex.NewInheritIndex(nil, nil),
+		endpoints:     set.New[model.Key](),
+		dispatch:      dispatch,
+	}
+}
+
+func (e *LabelRefExpander) RegisterWith(localEndpointDispatcher, allUpdDispatcher *dispatcher.Dispatcher) {
+	localEndpointDispatcher.Register(model.WorkloadEndpointKey{}, e.OnEndpointUpdate)
+	localEndpointDispatcher.Register(model.HostEndpointKey{}, e.OnEndpointUpdate)
+	allUpdDispatcher.Register(model.ResourceKey{}, e.OnProfileUpdate)
+	allUpdDispatcher.Register(model.PolicyKey{}, e.OnPolicyUpdate)
+}
+
+func (e *LabelRefExpander) OnEndpointUpdate(update api.Update) (_ bool) {
+	e.labelIndex.OnUpdate(update)
+	if update.Value != nil {
+		e.endpoints.Add(update.Key)
+	} else {
+		e.endpoints.Discard(update.Key)
+	}
+	for _, p := range e.policies {
+		e.updateEndpointVariant(p, update.Key)
+	}
+	return
+}
+
+func (e *LabelRefExpander) OnProfileUpdate(update api.Update) (_ bool) {
+	e.labelIndex.OnUpdate(update)
+	if len(e.policies) == 0 {
+		return
+	}
+	// The profile's labels may be inherited by any of the endpoints.  Profile labels change
+	// rarely so we simply recheck all of them.
+	e.endpoints.Iter(func(epKey model.Key) error {
+		for _, p := range e.policies {
+			e.updateEndpointVariant(p, epKey)
+		}
+		return nil
+	})
+	return
+}
+
+func (e *LabelRefExpander) OnPolicyUpdate(update api.Update) (filterOut bool) {
+	key := update.Key.(model.PolicyKey)
+	var policy *model.Policy
+	var refs []string
+	if update.Value != nil {
+		policy = update.Value.(*model.Policy)
+		refs = policyLabelRefs(policy)
+	}
+	old := e.policies[key]
+
+	if len(refs) == 0 {
+		// Policy doesn't use label(); pass it through, after removing the variants if it used
+		// to use label().
+		if old != nil {
+			log.WithField("policy", key).Info("Policy no longer uses label(), removing its variants")
+			delete(e.policies, key)
+			for _, v := range old.variants {
+				e.sendVariantDeletion(v)
+			}
+		}
+		if update.Value != nil {
+			e.passedThrough.Add(key)
+		} else if old == nil {
+			e.passedThrough.Discard(key)
+		} else {
+			// We never sent the policy itself downstream.
+			filterOut = true
+		}
+		return
+	}
+
+	if e.passedThrough.Contains(key) {
+		log.WithField("policy", key).Info("Policy now uses label(), replacing it with its variants")
+		e.passedThrough.Discard(key)
+		e.dispatch(api.Update{KVPair: model.KVPair{Key: key}, UpdateType: api.UpdateTypeKVDeleted})
+	}
+
+	p := &labelRefPolicy{
+		key:              key,
+		policy:           policy,
+		refs:             refs,
+		variants:         map[string]*labelRefVariant{},
+		endpointVariants: map[model.Key]string{},
+	}
+	e.policies[key] = p
+	e.endpoints.Iter(func(epKey model.Key) error {
+		id, values := p.variantFor(e.labelIndex, epKey)
+		v := p.variants[id]
+		if v == nil {
+			v = &labelRefVariant{key: p.variantKey(id), values: values}
+			p.variants[id] = v
+		}
+		v.numEndpoints++
+		p.endpointVariants[epKey] = id
+		return nil
+	})
+
+	// Send the new variants, which also updates the ones that already existed, before removing
+	// the ones that are no longer needed.
+	for _, v := range p.variants {
+		e.sendVariant(p, v)
+	}
+	if old != nil {
+		for id, v := range old.variants {
+			if _, ok := p.variants[id]; !ok {
+				e.sendVariantDeletion(v)
+			}
+		}
+	}
+	return true
+}
+
+// updateEndpointVariant moves the endpoint to the right variant of the policy, after its labels
+// have changed or it has been added or removed.
+func (e *LabelRefExpander) updateEndpointVariant(p *labelRefPolicy, epKey model.Key) {
+	oldID, hadVariant := p.endpointVariants[epKey]
+	newID, values := "", map[string]string(nil)
+	if e.endpoints.Contains(epKey) {
+		newID, values = p.variantFor(e.labelIndex, epKey)
+		if hadVariant && newID == oldID {
+			return
+		}
+		v := p.variants[newID]
+		if v == nil {
+			v = &labelRefVariant{key: p.variantKey(newID), values: values}
+			p.variants[newID] = v
+			e.sendVariant(p, v)
+		}
+		v.numEndpoints++
+		p.endpointVariants[epKey] = newID
+	} else {
+		delete(p.endpointVariants, epKey)
+	}
+	if hadVariant {
+		v := p.variants[oldID]
+		v.numEndpoints--
+		if v.numEndpoints == 0 {
+			delete(p.variants, oldID)
+			e.sendVariantDeletion(v)
+		}
+	}
+}
+
+func (e *LabelRefExpander) sendVariant(p *labelRefPolicy, v *labelRefVariant) {
+	log.WithFields(log.Fields{
+		"policy":  p.key,
+		"variant": v.key,
+		"values":  v.values,
+	}).Debug("Sending policy variant")
+	e.dispatch(api.Update{
+		KVPair:     model.KVPair{Key: v.key, Value: p.variantPolicy(v)},
+		UpdateType: api.UpdateTypeKVUpdated,
+	})
+}
+
+func (e *LabelRefExpander) sendVariantDeletion(v *labelRefVariant) {
+	log.WithField("variant", v.key).Debug("Deleting policy variant")
+	e.dispatch(api.Update{KVPair: model.KVPair{Key: v.key}, UpdateType: api.UpdateTypeKVDeleted})
+}
+
+// variantFor returns the ID of the endpoint's variant of the policy, and the values of the
+// referenced labels that the endpoint has.
+func (p *labelRefPolicy) variantFor(labelIndex *labelindex.InheritIndex, epKey model.Key) (string, map[string]string) {
+	labels, _ := labelIndex.Labels(epKey)
+	values := map[string]string{}
+	idParts := make([]*string, len(p.refs))
+	for i, name := range p.refs {
+		if labels == nil {
+			continue
+		}
+		if value, ok := labels.Get(name); ok {
+			values[name] = value
+			idParts[i] = &value
+		}
+	}
+	id, err := json.Marshal(idParts)
+	if err != nil {
+		log.WithError(err).Panic("Failed to marshal label values")
+	}
+	return string(id), values
+}
+
+func (p *labelRefPolicy) variantKey(id string) model.PolicyKey {
+	// Policy names can't contain "@", so the variants can't clash with other policies.
+	return model.PolicyKey{
+		Tier: p.key.Tier,
+		Name: p.key.Name + "@" + hash.MakeUniqueID("lr", id),
+	}
+}
+
+// variantPolicy calculates the variant of the policy: it applies to the endpoints that have the
+// variant's values, and the label references in its rules are resolved to those values.
+func (p *labelRefPolicy) variantPolicy(v *labelRefVariant) *model.Policy {
+	refLabels := parser.MapAsLabels(v.values)
+
+	policySelector := p.policy.Selector
+	if strings.TrimSpace(policySelector) == "" {
+		policySelector = "all()"
+	}
+	parts := []string{"(" + policySelector + ")"}
+	for _, name := range p.refs {
+		if _, ok := v.values[name]; ok {
+			parts = append(parts, fmt.Sprintf("%s == label(%s)", name, name))
+		} else {
+			parts = append(parts, fmt.Sprintf("!has(%s)", name))
+		}
+	}
+
+	sel, err := parser.Parse(strings.Join(parts, " && "))
+	if err != nil {
+		log.WithError(err).WithField("selector", p.policy.Selector).Panic("Failed to parse policy selector")
+	}
+
+	policy := *p.policy
+	policy.Selector = parser.ResolveLabelRefs(sel, refLabels).String()
+	policy.InboundRules = resolveRuleLabelRefs(p.policy.InboundRules, refLabels)
+	policy.OutboundRules = resolveRuleLabelRefs(p.policy.OutboundRules, refLabels)
+	return &policy
+}
+
+func resolveRuleLabelRefs(rules []model.Rule, refLabels parser.Labels) []model.Rule {
+	if rules == nil {
+		return nil
+	}
+	resolved := make([]model.Rule, len(rules))
+	for i, r := range rules {
+		for _, s := range []*string{
+			&r.SrcSelector, &r.DstSelector, &r.NotSrcSelector, &r.NotDstSelector,
+			&r.OriginalSrcSelector, &r.OriginalDstSelector, &r.OriginalNotSrcSelector, &r.OriginalNotDstSelector,
+			&r.OriginalSrcNamespaceSelector, &r.OriginalDstNamespaceSelector,
+		} {
+			*s = resolveLabelRefs(*s, refLabels)
+		}
+		resolved[i] = r
+	}
+	return resolved
+}
+
+// resolveLabelRefs replaces the label references in the selector with the given values.  It
+// returns selectors that don't use label() unchanged.
+func resolveLabelRefs(s string, refLabels parser.Labels) string {
+	if !strings.Contains(s, "label(") {
+		return s
+	}
+	sel, err := parser.Parse(s)
+	if err != nil {
+		// The validation filter only lets through valid selectors.
+		log.WithError(err).WithField("selector", s).Panic("Failed to parse selector")
+	}
+	if len(parser.LabelRefs(sel)) == 0 {
+		return s
+	}
+	return parser.ResolveLabelRefs(sel, refLabels).String()
+}
+
+// policyLabelRefs returns the names of the labels that the policy's rules refer to with label().
+func policyLabelRefs(policy *model.Policy) []string {
+	var refs []string
+	seen := set.New[string]()
+	for _, rules := range [][]model.Rule{policy.InboundRules, policy.OutboundRules} {
+		for _, r := range rules {
+			for _, s := range []string{r.SrcSelector, r.DstSelector, r.NotSrcSelector, r.NotDstSelector} {
+				if !strings.Contains(s, "label(") {
+					continue
+				}
+				sel, err := parser.Parse(s)
+				if err != nil {
+					continue
+				}
+				for _, name := range parser.LabelRefs(sel) {
+					if !seen.Contains(name) {
+						seen.Add(name)
+						refs = append(refs, name)
+					}
+				}
+			}
+		}
+	}
+	return refs
+}
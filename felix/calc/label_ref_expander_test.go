@@ -0,0 +1,227 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/felix/dispatcher"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+var _ = Describe("LabelRefExpander", func() {
+	var (
+		disp *dispatcher.Dispatcher
+		// downstream is the state of the policies that reached the receivers after the expander.
+		downstream map[model.PolicyKey]*model.Policy
+	)
+
+	polKey := model.PolicyKey{Tier: "default", Name: "default.tenant-isolation"}
+	plainKey := model.PolicyKey{Tier: "default", Name: "default.plain"}
+	wepKey1 := model.WorkloadEndpointKey{Hostname: "localhost", OrchestratorID: "k8s", WorkloadID: "ns/pod1", EndpointID: "eth0"}
+	wepKey2 := model.WorkloadEndpointKey{Hostname: "localhost", OrchestratorID: "k8s", WorkloadID: "ns/pod2", EndpointID: "eth0"}
+	wepKey3 := model.WorkloadEndpointKey{Hostname: "localhost", OrchestratorID: "k8s", WorkloadID: "ns/pod3", EndpointID: "eth0"}
+
+	order := 10.0
+
+	refPolicy := func(srcSelector string) *model.Policy {
+		return &model.Policy{
+			Order:    &order,
+			Selector: "has(app)",
+			InboundRules: []model.Rule{
+				{Action: "allow", SrcSelector: srcSelector},
+				{Action: "deny", SrcSelector: "all()"},
+			},
+			OutboundRules: []model.Rule{{Action: "allow"}},
+		}
+	}
+
+	updatePolicy := func(key model.PolicyKey, pol *model.Policy) {
+		upd := api.Update{KVPair: model.KVPair{Key: key}, UpdateType: api.UpdateTypeKVUpdated}
+		if pol != nil {
+			upd.Value = pol
+		} else {
+			upd.UpdateType = api.UpdateTypeKVDeleted
+		}
+		disp.OnUpdate(upd)
+	}
+
+	updateWEP := func(key model.WorkloadEndpointKey, labels map[string]string, profiles ...string) {
+		upd := api.Update{KVPair: model.KVPair{Key: key}, UpdateType: api.UpdateTypeKVUpdated}
+		if labels != nil {
+			upd.Value = &model.WorkloadEndpoint{Labels: labels, ProfileIDs: profiles}
+		} else {
+			upd.UpdateType = api.UpdateTypeKVDeleted
+		}
+		disp.OnUpdate(upd)
+	}
+
+	updateProfileLabels := func(name string, labels map[string]string) {
+		disp.OnUpdate(api.Update{
+			KVPair: model.KVPair{
+				Key: model.ResourceKey{Kind: v3.KindProfile, Name: name},
+				Value: &v3.Profile{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Spec:       v3.ProfileSpec{LabelsToApply: labels},
+				},
+			},
+			UpdateType: api.UpdateTypeKVUpdated,
+		})
+	}
+
+	// variantSelectors returns the policy selector and first rule's source selector of each
+	// variant of polKey.
+	variantSelectors := func() map[string]string {
+		sels := map[string]string{}
+		for k, p := range downstream {
+			Expect(k.Tier).To(Equal(polKey.Tier))
+			if k == plainKey {
+				continue
+			}
+			Expect(k.Name).To(HavePrefix(polKey.Name + "@"))
+			sels[p.Selector] = p.InboundRules[0].SrcSelector
+		}
+		return sels
+	}
+
+	BeforeEach(func() {
+		disp = dispatcher.NewDispatcher()
+		downstream = map[model.PolicyKey]*model.Policy{}
+		expander := NewLabelRefExpander(disp.OnUpdate)
+		expander.RegisterWith(disp, disp)
+		disp.Register(model.PolicyKey{}, func(update api.Update) (_ bool) {
+			key := update.Key.(model.PolicyKey)
+			if update.Value != nil {
+				downstream[key] = update.Value.(*model.Policy)
+			} else {
+				Expect(downstream).To(HaveKey(key), "deletion of policy that wasn't sent")
+				delete(downstream, key)
+			}
+			return
+		})
+	})
+
+	It("should pass through policies that don't use label()", func() {
+		updateWEP(wepKey1, map[string]string{"app": "a", "tenant": "red"})
+		pol := refPolicy("tenant == 'red'")
+		updatePolicy(plainKey, pol)
+		Expect(downstream).To(Equal(map[model.PolicyKey]*model.Policy{plainKey: pol}))
+		updatePolicy(plainKey, nil)
+		Expect(downstream).To(BeEmpty())
+	})
+
+	It("should send nothing for a policy that uses label() until there are local endpoints", func() {
+		updatePolicy(polKey, refPolicy("tenant == label(tenant)"))
+		Expect(downstream).To(BeEmpty())
+	})
+
+	Describe("with local endpoints in two tenants", func() {
+		BeforeEach(func() {
+			updateWEP(wepKey1, map[string]string{"app": "a", "tenant": "red"})
+			updateWEP(wepKey2, map[string]string{"app": "b", "tenant": "blue"})
+			updateWEP(wepKey3, map[string]string{"app": "c", "tenant": "red"})
+			updatePolicy(polKey, refPolicy("tenant == label(tenant)"))
+		})
+
+		It("should send one variant per tenant", func() {
+			Expect(variantSelectors()).To(Equal(map[string]string{
+				`(has(app) && tenant == "red")`:  `tenant == "red"`,
+				`(has(app) && tenant == "blue")`: `tenant == "blue"`,
+			}))
+		})
+
+		It("should only resolve the rules that use label()", func() {
+			for _, p := range downstream {
+				Expect(p.InboundRules[1].SrcSelector).To(Equal("all()"))
+				Expect(p.OutboundRules).To(Equal([]model.Rule{{Action: "allow"}}))
+				Expect(*p.Order).To(Equal(order))
+			}
+		})
+
+		It("should remove a variant once no endpoint needs it", func() {
+			updateWEP(wepKey2, nil)
+			Expect(variantSelectors()).To(Equal(map[string]string{
+				`(has(app) && tenant == "red")`: `tenant == "red"`,
+			}))
+		})
+
+		It("should keep a variant while another endpoint needs it", func() {
+			updateWEP(wepKey1, nil)
+			Expect(variantSelectors()).To(HaveLen(2))
+		})
+
+		It("should move an endpoint to a new variant when its label changes", func() {
+			updateWEP(wepKey2, map[string]string{"app": "b", "tenant": "green"})
+			Expect(variantSelectors()).To(Equal(map[string]string{
+				`(has(app) && tenant == "red")`:   `tenant == "red"`,
+				`(has(app) && tenant == "green")`: `tenant == "green"`,
+			}))
+		})
+
+		It("should send a variant for endpoints without the label", func() {
+			updateWEP(wepKey2, map[string]string{"app": "b"})
+			Expect(variantSelectors()).To(Equal(map[string]string{
+				`(has(app) && tenant == "red")`: `tenant == "red"`,
+				`(has(app) && !has(tenant))`:    `!all()`,
+			}))
+		})
+
+		It("should update the variants when the policy changes", func() {
+			updatePolicy(polKey, refPolicy("tenant != label(tenant)"))
+			Expect(variantSelectors()).To(Equal(map[string]string{
+				`(has(app) && tenant == "red")`:  `tenant != "red"`,
+				`(has(app) && tenant == "blue")`: `tenant != "blue"`,
+			}))
+		})
+
+		It("should remove the variants when the policy is deleted", func() {
+			updatePolicy(polKey, nil)
+			Expect(downstream).To(BeEmpty())
+		})
+
+		It("should replace the variants with the policy when it stops using label()", func() {
+			pol := refPolicy("tenant == 'red'")
+			updatePolicy(polKey, pol)
+			Expect(downstream).To(Equal(map[model.PolicyKey]*model.Policy{polKey: pol}))
+		})
+
+		It("should replace the policy with variants when it starts using label()", func() {
+			updatePolicy(polKey, refPolicy("tenant == 'red'"))
+			updatePolicy(polKey, refPolicy("tenant == label(tenant)"))
+			Expect(downstream).NotTo(HaveKey(polKey))
+			Expect(variantSelectors()).To(HaveLen(2))
+		})
+	})
+
+	It("should use labels inherited from profiles", func() {
+		updateProfileLabels("kns.ns", map[string]string{"pcns.tenant": "red"})
+		updateWEP(wepKey1, map[string]string{"app": "a"}, "kns.ns")
+		updatePolicy(polKey, refPolicy("pcns.tenant == label(pcns.tenant)"))
+		Expect(variantSelectors()).To(Equal(map[string]string{
+			`(has(app) && pcns.tenant == "red")`: `pcns.tenant == "red"`,
+		}))
+
+		By("updating the variant when the profile's labels change")
+		updateProfileLabels("kns.ns", map[string]string{"pcns.tenant": "blue"})
+		Expect(variantSelectors()).To(Equal(map[string]string{
+			`(has(app) && pcns.tenant == "blue")`: `pcns.tenant == "blue"`,
+		}))
+	})
+})
@@ -0,0 +1,240 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"reflect"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/felix/dispatcher"
+	"github.com/projectcalico/calico/felix/labelindex"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+// RoutePolicyResolver matches RoutePolicy resources against the local workload endpoints and
+// calculates, for each policy, the set of workload addresses whose traffic should be steered
+// by the policy.  It registers for:
+//
+//   - model.WorkloadEndpointKey (local endpoints only)
+//   - model.ResourceKey for RoutePolicy and Profile resources (profile labels are inherited
+//     by endpoints so they can affect selector matches).
+//
+// For each RoutePolicy that matches at least one local workload endpoint with an address,
+// it emits a *proto.RoutePolicyUpdate.  Once a policy no longer matches any local endpoints
+// (or is deleted) it emits a *proto.RoutePolicyRemove.  Updates are batched until Flush() is
+// called.
+type RoutePolicyResolver struct {
+	Callbacks routePolicyCallbacks
+
+	labelIndex *labelindex.InheritIndex
+
+	policies         map[string]*v3.RoutePolicySpec
+	endpoints        map[model.WorkloadEndpointKey]*model.WorkloadEndpoint
+	policyToEPs      map[string]set.Set[model.WorkloadEndpointKey]
+	epToPolicies     map[model.WorkloadEndpointKey]set.Set[string]
+	dirtyPolicies    set.Set[string]
+	sentPolicyByName map[string]*proto.RoutePolicyUpdate
+}
+
+func NewRoutePolicyResolver() *RoutePolicyResolver {
+	r := &RoutePolicyResolver{
+		policies:         map[string]*v3.RoutePolicySpec{},
+		endpoints:        map[model.WorkloadEndpointKey]*model.WorkloadEndpoint{},
+		policyToEPs:      map[string]set.Set[model.WorkloadEndpointKey]{},
+		epToPolicies:     map[model.WorkloadEndpointKey]set.Set[string]{},
+		dirtyPolicies:    set.New[string](),
+		sentPolicyByName: map[string]*proto.RoutePolicyUpdate{},
+	}
+	r.labelIndex = labelindex.NewInheritIndex(r.onMatchStarted, r.onMatchStopped)
+	return r
+}
+
+func (r *RoutePolicyResolver) RegisterWith(allUpdDispatcher, localEndpointDispatcher *dispatcher.Dispatcher) {
+	allUpdDispatcher.Register(model.ResourceKey{}, r.OnResourceUpdate)
+	localEndpointDispatcher.Register(model.WorkloadEndpointKey{}, r.OnWorkloadEndpointUpdate)
+}
+
+func (r *RoutePolicyResolver) OnResourceUpdate(update api.Update) (_ bool) {
+	key := update.Key.(model.ResourceKey)
+	switch key.Kind {
+	case v3.KindProfile:
+		// Profile labels are inherited by endpoints, pass them through to the label index.
+		r.labelIndex.OnUpdate(update)
+	case v3.KindRoutePolicy:
+		if update.Value != nil {
+			r.onPolicyUpdate(key.Name, update.Value.(*v3.RoutePolicy))
+		} else {
+			r.onPolicyRemove(key.Name)
+		}
+	}
+	return
+}
+
+func (r *RoutePolicyResolver) onPolicyUpdate(name string, policy *v3.RoutePolicy) {
+	logCxt := log.WithField("name", name)
+	sel, err := selector.Parse(policy.Spec.Selector)
+	if err != nil {
+		// Validation should prevent this, treat the policy as deleted.
+		logCxt.WithError(err).Warn("Failed to parse RoutePolicy selector, ignoring policy")
+		r.onPolicyRemove(name)
+		return
+	}
+	logCxt.Debug("RoutePolicy updated")
+	r.policies[name] = &policy.Spec
+	r.dirtyPolicies.Add(name)
+	r.labelIndex.UpdateSelector(name, sel)
+}
+
+func (r *RoutePolicyResolver) onPolicyRemove(name string) {
+	if _, ok := r.policies[name]; !ok {
+		return
+	}
+	log.WithField("name", name).Debug("RoutePolicy removed")
+	r.labelIndex.DeleteSelector(name)
+	delete(r.policies, name)
+	r.dirtyPolicies.Add(name)
+}
+
+func (r *RoutePolicyResolver) OnWorkloadEndpointUpdate(update api.Update) (_ bool) {
+	key := update.Key.(model.WorkloadEndpointKey)
+	if update.Value != nil {
+		ep := update.Value.(*model.WorkloadEndpoint)
+		if old := r.endpoints[key]; old == nil ||
+			!reflect.DeepEqual(old.IPv4Nets, ep.IPv4Nets) ||
+			!reflect.DeepEqual(old.IPv6Nets, ep.IPv6Nets) {
+			// The endpoint's addresses have changed, any policies that already match it need
+			// to be recalculated.  (Label changes are handled via the label index.)
+			r.markEndpointPoliciesDirty(key)
+		}
+		r.endpoints[key] = ep
+	} else {
+		r.markEndpointPoliciesDirty(key)
+		delete(r.endpoints, key)
+	}
+	r.labelIndex.OnUpdate(update)
+	return
+}
+
+func (r *RoutePolicyResolver) markEndpointPoliciesDirty(key model.WorkloadEndpointKey) {
+	if pols, ok := r.epToPolicies[key]; ok {
+		pols.Iter(func(name string) error {
+			r.dirtyPolicies.Add(name)
+			return nil
+		})
+	}
+}
+
+func (r *RoutePolicyResolver) onMatchStarted(selID, labelID interface{}) {
+	name := selID.(string)
+	key, ok := labelID.(model.WorkloadEndpointKey)
+	if !ok {
+		return
+	}
+	log.WithFields(log.Fields{"policy": name, "endpoint": key}).Debug("RoutePolicy now matches endpoint")
+	if r.policyToEPs[name] == nil {
+		r.policyToEPs[name] = set.New[model.WorkloadEndpointKey]()
+	}
+	r.policyToEPs[name].Add(key)
+	if r.epToPolicies[key] == nil {
+		r.epToPolicies[key] = set.New[string]()
+	}
+	r.epToPolicies[key].Add(name)
+	r.dirtyPolicies.Add(name)
+}
+
+func (r *RoutePolicyResolver) onMatchStopped(selID, labelID interface{}) {
+	name := selID.(string)
+	key, ok := labelID.(model.WorkloadEndpointKey)
+	if !ok {
+		return
+	}
+	log.WithFields(log.Fields{"policy": name, "endpoint": key}).Debug("RoutePolicy no longer matches endpoint")
+	if eps := r.policyToEPs[name]; eps != nil {
+		eps.Discard(key)
+		if eps.Len() == 0 {
+			delete(r.policyToEPs, name)
+		}
+	}
+	if pols := r.epToPolicies[key]; pols != nil {
+		pols.Discard(name)
+		if pols.Len() == 0 {
+			delete(r.epToPolicies, key)
+		}
+	}
+	r.dirtyPolicies.Add(name)
+}
+
+// Flush sends any pending RoutePolicyUpdate/Remove messages to the callbacks.
+func (r *RoutePolicyResolver) Flush() {
+	r.dirtyPolicies.Iter(func(name string) error {
+		msg := r.calculateUpdate(name)
+		sent := r.sentPolicyByName[name]
+		if msg == nil {
+			if sent != nil {
+				r.Callbacks.OnRoutePolicyRemove(name)
+				delete(r.sentPolicyByName, name)
+			}
+		} else if sent == nil || !reflect.DeepEqual(sent, msg) {
+			r.Callbacks.OnRoutePolicyUpdate(msg)
+			r.sentPolicyByName[name] = msg
+		}
+		return set.RemoveItem
+	})
+}
+
+// calculateUpdate returns the RoutePolicyUpdate for the named policy or nil if the policy
+// doesn't exist or doesn't apply to any local workload addresses.
+func (r *RoutePolicyResolver) calculateUpdate(name string) *proto.RoutePolicyUpdate {
+	spec := r.policies[name]
+	if spec == nil {
+		return nil
+	}
+	var srcAddrs []string
+	if eps := r.policyToEPs[name]; eps != nil {
+		eps.Iter(func(key model.WorkloadEndpointKey) error {
+			ep := r.endpoints[key]
+			if ep == nil {
+				return nil
+			}
+			for _, n := range ep.IPv4Nets {
+				srcAddrs = append(srcAddrs, n.String())
+			}
+			for _, n := range ep.IPv6Nets {
+				srcAddrs = append(srcAddrs, n.String())
+			}
+			return nil
+		})
+	}
+	if len(srcAddrs) == 0 {
+		return nil
+	}
+	sort.Strings(srcAddrs)
+	return &proto.RoutePolicyUpdate{
+		Name:         name,
+		SrcAddrs:     srcAddrs,
+		Destinations: spec.Destinations,
+		NextHop:      spec.NextHop,
+		Interface:    spec.Interface,
+		Table:        uint32(spec.Table),
+	}
+}
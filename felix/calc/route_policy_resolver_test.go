@@ -0,0 +1,193 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+type routePolicyRecorder struct {
+	updates map[string]*proto.RoutePolicyUpdate
+	removes []string
+}
+
+func (r *routePolicyRecorder) OnRoutePolicyUpdate(update *proto.RoutePolicyUpdate) {
+	r.updates[update.Name] = update
+}
+
+func (r *routePolicyRecorder) OnRoutePolicyRemove(name string) {
+	delete(r.updates, name)
+	r.removes = append(r.removes, name)
+}
+
+var _ = Describe("RoutePolicyResolver", func() {
+	var (
+		resolver *RoutePolicyResolver
+		recorder *routePolicyRecorder
+	)
+
+	wepKey1 := model.WorkloadEndpointKey{Hostname: "localhost", OrchestratorID: "k8s", WorkloadID: "ns/pod1", EndpointID: "eth0"}
+	wepKey2 := model.WorkloadEndpointKey{Hostname: "localhost", OrchestratorID: "k8s", WorkloadID: "ns/pod2", EndpointID: "eth0"}
+
+	wep := func(labels map[string]string, profiles []string, addrs ...string) *model.WorkloadEndpoint {
+		ep := &model.WorkloadEndpoint{Labels: labels, ProfileIDs: profiles}
+		for _, a := range addrs {
+			n := net.MustParseCIDR(a)
+			if n.Version() == 4 {
+				ep.IPv4Nets = append(ep.IPv4Nets, n)
+			} else {
+				ep.IPv6Nets = append(ep.IPv6Nets, n)
+			}
+		}
+		return ep
+	}
+
+	routePolicy := func(name, sel string) *v3.RoutePolicy {
+		return &v3.RoutePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v3.RoutePolicySpec{
+				Selector:     sel,
+				Destinations: []string{"0.0.0.0/0"},
+				NextHop:      "10.0.0.5",
+				Table:        200,
+			},
+		}
+	}
+
+	updateWEP := func(key model.WorkloadEndpointKey, ep *model.WorkloadEndpoint) {
+		upd := api.Update{KVPair: model.KVPair{Key: key}, UpdateType: api.UpdateTypeKVNew}
+		if ep != nil {
+			upd.Value = ep
+		} else {
+			upd.UpdateType = api.UpdateTypeKVDeleted
+		}
+		resolver.OnWorkloadEndpointUpdate(upd)
+	}
+
+	updateResource := func(kind, name string, value interface{}) {
+		upd := api.Update{
+			KVPair:     model.KVPair{Key: model.ResourceKey{Kind: kind, Name: name}, Value: value},
+			UpdateType: api.UpdateTypeKVNew,
+		}
+		if value == nil {
+			upd.UpdateType = api.UpdateTypeKVDeleted
+		}
+		resolver.OnResourceUpdate(upd)
+	}
+
+	BeforeEach(func() {
+		recorder = &routePolicyRecorder{updates: map[string]*proto.RoutePolicyUpdate{}}
+		resolver = NewRoutePolicyResolver()
+		resolver.Callbacks = recorder
+	})
+
+	It("should not send anything for a policy with no matching endpoints", func() {
+		updateResource(v3.KindRoutePolicy, "rp1", routePolicy("rp1", "app == 'scanner'"))
+		updateWEP(wepKey1, wep(map[string]string{"app": "web"}, nil, "10.65.0.1/32"))
+		resolver.Flush()
+		Expect(recorder.updates).To(BeEmpty())
+		Expect(recorder.removes).To(BeEmpty())
+	})
+
+	It("should send an update with the addresses of matching endpoints", func() {
+		updateResource(v3.KindRoutePolicy, "rp1", routePolicy("rp1", "app == 'scanner'"))
+		updateWEP(wepKey1, wep(map[string]string{"app": "scanner"}, nil, "10.65.0.1/32", "fd00::1/128"))
+		updateWEP(wepKey2, wep(map[string]string{"app": "scanner"}, nil, "10.65.0.2/32"))
+		resolver.Flush()
+		Expect(recorder.updates).To(HaveKey("rp1"))
+		Expect(recorder.updates["rp1"]).To(Equal(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.1/32", "10.65.0.2/32", "fd00::1/128"},
+			Destinations: []string{"0.0.0.0/0"},
+			NextHop:      "10.0.0.5",
+			Table:        200,
+		}))
+	})
+
+	It("should match on labels inherited from profiles", func() {
+		updateResource(v3.KindRoutePolicy, "rp1", routePolicy("rp1", "team == 'blue'"))
+		updateWEP(wepKey1, wep(nil, []string{"prof1"}, "10.65.0.1/32"))
+		resolver.Flush()
+		Expect(recorder.updates).To(BeEmpty())
+
+		prof := v3.NewProfile()
+		prof.Name = "prof1"
+		prof.Spec.LabelsToApply = map[string]string{"team": "blue"}
+		updateResource(v3.KindProfile, "prof1", prof)
+		resolver.Flush()
+		Expect(recorder.updates).To(HaveKey("rp1"))
+		Expect(recorder.updates["rp1"].SrcAddrs).To(Equal([]string{"10.65.0.1/32"}))
+	})
+
+	Context("with a policy matching one endpoint", func() {
+		BeforeEach(func() {
+			updateResource(v3.KindRoutePolicy, "rp1", routePolicy("rp1", "app == 'scanner'"))
+			updateWEP(wepKey1, wep(map[string]string{"app": "scanner"}, nil, "10.65.0.1/32"))
+			resolver.Flush()
+			Expect(recorder.updates).To(HaveKey("rp1"))
+		})
+
+		It("should send an update when the endpoint's address changes", func() {
+			updateWEP(wepKey1, wep(map[string]string{"app": "scanner"}, nil, "10.65.0.9/32"))
+			resolver.Flush()
+			Expect(recorder.updates["rp1"].SrcAddrs).To(Equal([]string{"10.65.0.9/32"}))
+		})
+
+		It("should send an update when the policy changes", func() {
+			rp := routePolicy("rp1", "app == 'scanner'")
+			rp.Spec.Table = 201
+			updateResource(v3.KindRoutePolicy, "rp1", rp)
+			resolver.Flush()
+			Expect(recorder.updates["rp1"].Table).To(Equal(uint32(201)))
+		})
+
+		It("should not resend an unchanged update", func() {
+			recorder.updates = map[string]*proto.RoutePolicyUpdate{}
+			updateResource(v3.KindRoutePolicy, "rp1", routePolicy("rp1", "app == 'scanner'"))
+			resolver.Flush()
+			Expect(recorder.updates).To(BeEmpty())
+		})
+
+		It("should send a remove when the endpoint stops matching", func() {
+			updateWEP(wepKey1, wep(map[string]string{"app": "web"}, nil, "10.65.0.1/32"))
+			resolver.Flush()
+			Expect(recorder.updates).To(BeEmpty())
+			Expect(recorder.removes).To(Equal([]string{"rp1"}))
+		})
+
+		It("should send a remove when the endpoint is deleted", func() {
+			updateWEP(wepKey1, nil)
+			resolver.Flush()
+			Expect(recorder.updates).To(BeEmpty())
+			Expect(recorder.removes).To(Equal([]string{"rp1"}))
+		})
+
+		It("should send a remove when the policy is deleted", func() {
+			updateResource(v3.KindRoutePolicy, "rp1", nil)
+			resolver.Flush()
+			Expect(recorder.updates).To(BeEmpty())
+			Expect(recorder.removes).To(Equal([]string{"rp1"}))
+		})
+	})
+})
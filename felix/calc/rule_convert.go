@@ -23,6 +23,7 @@ import (
 	"github.com/projectcalico/api/pkg/lib/numorstring"
 
 	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
 	"github.com/projectcalico/calico/libcalico-go/lib/net"
 )
 
@@ -42,6 +43,13 @@ func parsedRulesToProtoRules(in []*ParsedRule, ruleIDSeed string) (out []*proto.
 	return
 }
 
+// RuleToProtoRule converts a datamodel rule to the rule that the calculation graph sends to the
+// dataplane, and returns the IP sets that the rule refers to.  The rule's ID is left empty.
+func RuleToProtoRule(rule *model.Rule) (*proto.Rule, []*IPSetData) {
+	parsedRule, ipSets := ruleToParsedRule(rule, false)
+	return parsedRuleToProtoRule(parsedRule), ipSets
+}
+
 func fillInRuleIDs(rules []*proto.Rule, ruleIDSeed string) {
 	s := sha256.New224()
 	_, err := s.Write([]byte(ruleIDSeed))
@@ -105,6 +113,7 @@ func parsedRuleToProtoRule(in *ParsedRule) *proto.Rule {
 		SrcIpSetIds:          in.SrcIPSetIDs,
 		DstIpSetIds:          in.DstIPSetIDs,
 		DstIpPortSetIds:      in.DstIPPortSetIDs,
+		SrcIpDstPortIpSetIds: in.SrcIPDstPortIPSetIDs,
 
 		NotProtocol:             protocolToProtoProtocol(in.NotProtocol),
 		NotSrcNet:               ipNetsToProtoStrings(in.NotSrcNets),
@@ -116,6 +125,8 @@ func parsedRuleToProtoRule(in *ParsedRule) *proto.Rule {
 		NotSrcIpSetIds:          in.NotSrcIPSetIDs,
 		NotDstIpSetIds:          in.NotDstIPSetIDs,
 
+		ConnLimit: int32(in.ConnLimit),
+
 		// Pass through fields for the policy sync API.
 		OriginalSrcSelector:          in.OriginalSrcSelector,
 		OriginalSrcNamespaceSelector: in.OriginalSrcNamespaceSelector,
@@ -164,6 +175,32 @@ func parsedRuleToProtoRule(in *ParsedRule) *proto.Rule {
 		if len(in.HTTPMatch.Methods) > 0 {
 			out.HttpMatch.Methods = in.HTTPMatch.Methods
 		}
+		for _, headerMatch := range in.HTTPMatch.Headers {
+			protoMatch := &proto.HTTPMatch_HeaderMatch{Name: headerMatch.Name}
+			if headerMatch.Exact != "" {
+				protoMatch.ValueMatch = &proto.HTTPMatch_HeaderMatch_Exact{Exact: headerMatch.Exact}
+			} else if headerMatch.Prefix != "" {
+				protoMatch.ValueMatch = &proto.HTTPMatch_HeaderMatch_Prefix{Prefix: headerMatch.Prefix}
+			} else if headerMatch.Regex != "" {
+				protoMatch.ValueMatch = &proto.HTTPMatch_HeaderMatch_Regex{Regex: headerMatch.Regex}
+			}
+			out.HttpMatch.Headers = append(out.HttpMatch.Headers, protoMatch)
+		}
+		if len(in.HTTPMatch.Hosts) > 0 {
+			out.HttpMatch.Hosts = in.HTTPMatch.Hosts
+		}
+		if in.HTTPMatch.GRPC != nil {
+			out.HttpMatch.Grpc = &proto.HTTPMatch_GRPCMatch{
+				Services: in.HTTPMatch.GRPC.Services,
+				Methods:  in.HTTPMatch.GRPC.Methods,
+			}
+		}
+		for _, claimMatch := range in.HTTPMatch.JWTClaims {
+			out.HttpMatch.JwtClaims = append(out.HttpMatch.JwtClaims, &proto.HTTPMatch_ClaimMatch{
+				Name:   claimMatch.Name,
+				Values: claimMatch.Values,
+			})
+		}
 	}
 
 	if in.Metadata != nil {
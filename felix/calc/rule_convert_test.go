@@ -90,7 +90,17 @@ var fullyLoadedParsedRule = ParsedRule{
 	HTTPMatch: &model.HTTPMatch{Methods: []string{"GET", "POST"}, Paths: []v3.HTTPPath{
 		{Exact: "/foo"},
 		{Prefix: "/bar"},
-	}},
+	},
+		Headers: []v3.HTTPHeaderMatch{
+			{Name: "x-exact", Exact: "a"},
+			{Name: "x-prefix", Prefix: "b"},
+			{Name: "x-regex", Regex: "c.*"},
+			{Name: "x-present"},
+		},
+		Hosts:     []string{"*.example.com"},
+		GRPC:      &v3.GRPCMatch{Services: []string{"helloworld.Greeter"}, Methods: []string{"SayHello"}},
+		JWTClaims: []v3.JWTClaimMatch{{Name: "sub", Values: []string{"alice", "bob"}}},
+	},
 
 	Metadata: &model.RuleMetadata{Annotations: map[string]string{"key": "value"}},
 }
@@ -157,7 +167,17 @@ var fullyLoadedProtoRule = proto.Rule{
 	HttpMatch: &proto.HTTPMatch{Methods: []string{"GET", "POST"},
 		Paths: []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Exact{Exact: "/foo"}},
 			{PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: "/bar"}},
-		}},
+		},
+		Headers: []*proto.HTTPMatch_HeaderMatch{
+			{Name: "x-exact", ValueMatch: &proto.HTTPMatch_HeaderMatch_Exact{Exact: "a"}},
+			{Name: "x-prefix", ValueMatch: &proto.HTTPMatch_HeaderMatch_Prefix{Prefix: "b"}},
+			{Name: "x-regex", ValueMatch: &proto.HTTPMatch_HeaderMatch_Regex{Regex: "c.*"}},
+			{Name: "x-present"},
+		},
+		Hosts:     []string{"*.example.com"},
+		Grpc:      &proto.HTTPMatch_GRPCMatch{Services: []string{"helloworld.Greeter"}, Methods: []string{"SayHello"}},
+		JwtClaims: []*proto.HTTPMatch_ClaimMatch{{Name: "sub", Values: []string{"alice", "bob"}}},
+	},
 
 	Metadata: &proto.RuleMetadata{Annotations: map[string]string{"key": "value"}},
 }
@@ -219,6 +239,13 @@ var _ = DescribeTable("ParsedRulesToProtoRules",
 		proto.Rule{
 			DstIpPortSetIds: []string{"ipPortSetID"},
 		}),
+	Entry("connLimit rule",
+		ParsedRule{
+			ConnLimit: 10,
+		},
+		proto.Rule{
+			ConnLimit: 10,
+		}),
 	Entry("fully-loaded rule",
 		fullyLoadedParsedRule,
 		fullyLoadedProtoRule),
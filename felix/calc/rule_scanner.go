@@ -16,6 +16,7 @@ package calc
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -79,6 +80,11 @@ type RuleScanner struct {
 	// uidsToRulesIDs maps from IP set UID to the set of policy/profile IDs that use it.
 	uidsToRulesIDs multidict.Multidict[string, any]
 
+	// CombineSelectorsAndPorts, if true, makes the RuleScanner render a selector and the numeric
+	// ports of the same rule as a single IP and port IP set.  Only the nftables dataplane supports
+	// the port ranges in such IP sets.
+	CombineSelectorsAndPorts bool
+
 	OnIPSetActive   func(ipSet *IPSetData)
 	OnIPSetInactive func(ipSet *IPSetData)
 
@@ -91,12 +97,15 @@ type IPSetData struct {
 	// this IP set represents a selector only, with no named port component.
 	Selector selector.Selector
 
-	// NamedPortProtocol identifies the protocol (TCP or UDP) for a named port IP set.  It is
-	// set to ProtocolNone for a selector-only IP set.
+	// NamedPortProtocol identifies the protocol (TCP or UDP) for a named port or numeric ports IP
+	// set.  It is set to ProtocolNone for a selector-only IP set.
 	NamedPortProtocol labelindex.IPSetPortProtocol
 	// NamedPort contains the name of the named port represented by this IP set or "" for a
 	// selector-only IP set
 	NamedPort string
+	// Ports contains the numeric ports represented by this IP set, sorted and with no overlaps, or
+	// nil if the IP set doesn't represent numeric ports.
+	Ports []numorstring.Port
 	// The service that this IP set represents, in namespace/name format.
 	Service string
 	// Type of the ip set to represent for this service. This allows us to create service
@@ -115,6 +124,9 @@ func (d *IPSetData) String() string {
 	if d.NamedPort != "" {
 		parts = append(parts, fmt.Sprintf("namedPort:%s(%s)", d.NamedPort, d.NamedPortProtocol.String()))
 	}
+	if len(d.Ports) > 0 {
+		parts = append(parts, fmt.Sprintf("ports:%s(%s)", portsToString(d.Ports), d.NamedPortProtocol.String()))
+	}
 	if d.Service != "" {
 		parts = append(parts, fmt.Sprintf("service:%q", d.Service))
 	}
@@ -141,6 +153,9 @@ func (d *IPSetData) UniqueID() string {
 			selID := d.Selector.UniqueID()
 			if d.NamedPortProtocol == labelindex.ProtocolNone {
 				d.cachedUID = selID
+			} else if len(d.Ports) > 0 {
+				idToHash := selID + "," + d.NamedPortProtocol.String() + "," + portsToString(d.Ports)
+				d.cachedUID = hash.MakeUniqueID("p", idToHash)
 			} else {
 				idToHash := selID + "," + d.NamedPortProtocol.String() + "," + d.NamedPort
 				d.cachedUID = hash.MakeUniqueID("n", idToHash)
@@ -193,6 +208,7 @@ func (rs *RuleScanner) OnPolicyActive(key model.PolicyKey, policy *model.Policy)
 		policy.Namespace,
 		selector.Normalise(policy.Selector),
 	)
+	parsedRules.ContentHash = model.PolicyContentHash(policy)
 	rs.RulesUpdateCallbacks.OnPolicyActive(key, parsedRules)
 }
 
@@ -214,7 +230,7 @@ func (rs *RuleScanner) updateRules(
 	currentUIDToIPSet := make(map[string]*IPSetData)
 	parsedInbound := make([]*ParsedRule, len(inbound))
 	for ii, rule := range inbound {
-		parsed, allIPSets := ruleToParsedRule(&rule)
+		parsed, allIPSets := ruleToParsedRule(&rule, rs.CombineSelectorsAndPorts)
 		parsedInbound[ii] = parsed
 		for _, ipSet := range allIPSets {
 			// Note: there may be more than one entry in allIPSets for the same UID, but that's only
@@ -225,7 +241,7 @@ func (rs *RuleScanner) updateRules(
 	}
 	parsedOutbound := make([]*ParsedRule, len(outbound))
 	for ii, rule := range outbound {
-		parsed, allIPSets := ruleToParsedRule(&rule)
+		parsed, allIPSets := ruleToParsedRule(&rule, rs.CombineSelectorsAndPorts)
 		parsedOutbound[ii] = parsed
 		for _, ipSet := range allIPSets {
 			// Note: there may be more than one entry in allIPSets for the same UID, but that's only
@@ -313,6 +329,10 @@ type ParsedRules struct {
 	PreDNAT bool
 
 	OriginalSelector string
+
+	// ContentHash is the hash of the policy's content (see model.PolicyContentHash), used to
+	// identify the version of the policy in policy status reports.  Empty for profiles.
+	ContentHash string
 }
 
 // ParsedRule is like a backend.model.Rule, except the selector matches and named ports are
@@ -335,6 +355,9 @@ type ParsedRule struct {
 	SrcIPSetIDs          []string
 	DstIPSetIDs          []string
 	DstIPPortSetIDs      []string
+	// SrcIPDstPortIPSetIDs are IP and port IP sets to match against the source IP and the
+	// destination port.
+	SrcIPDstPortIPSetIDs []string
 
 	NotProtocol             *numorstring.Protocol
 	NotSrcNets              []*net.IPNet
@@ -348,6 +371,8 @@ type ParsedRule struct {
 	NotSrcIPSetIDs          []string
 	NotDstIPSetIDs          []string
 
+	ConnLimit int
+
 	// These fields allow us to pass through the raw match criteria from the V3 datamodel,
 	// unmodified. The selectors above are formed in the update processor layer by combining the
 	// original selectors, namespace selectors an service account matches into one.
@@ -373,7 +398,7 @@ type ParsedRule struct {
 	Metadata *model.RuleMetadata
 }
 
-func ruleToParsedRule(rule *model.Rule) (parsedRule *ParsedRule, allIPSets []*IPSetData) {
+func ruleToParsedRule(rule *model.Rule, combineSelectorsAndPorts bool) (parsedRule *ParsedRule, allIPSets []*IPSetData) {
 	srcSel, dstSel, notSrcSels, notDstSels := extractSelectors(rule)
 
 	// In the datamodel, named ports are included in the list of ports as an "or" match; i.e. the
@@ -417,16 +442,44 @@ func ruleToParsedRule(rule *model.Rule) (parsedRule *ParsedRule, allIPSets []*IP
 	notSrcNamedPortIPSets := namedPortsToIPSets(notSrcNamedPorts, srcSel, namedPortProto)
 	notDstNamedPortIPSets := namedPortsToIPSets(notDstNamedPorts, dstSel, namedPortProto)
 
+	// If the dataplane supports it, combine the positive selector with the numeric ports of the
+	// same rule into a single IP set that contains each matching address with each port range.
+	// The dataplane can then match the address, protocol and port with one set lookup.  We
+	// prefer to combine the ports with the selector for the same direction; failing that, the
+	// source selector can be combined with the destination ports, which is the common case for
+	// ingress rules.  We don't combine ports that are listed along with named ports since the
+	// ports are "or"ed together, nor more than maxCombinedPortRanges port ranges (see below).
+	var srcIPDstPortIPSets []*IPSetData
+	var srcSelCombined, dstSelCombined bool
+	if portProto, ok := portsIPSetProtocol(rule.Protocol); ok && combineSelectorsAndPorts {
+		if len(dstSel) == 1 && canCombinePorts(dstNumericPorts) && len(dstNamedPorts) == 0 {
+			dstNamedPortIPSets = append(dstNamedPortIPSets, portsToIPSet(dstNumericPorts, dstSel[0], portProto))
+			dstNumericPorts = nil
+			dstSelCombined = true
+		}
+		if len(srcSel) == 1 && canCombinePorts(srcNumericPorts) && len(srcNamedPorts) == 0 {
+			srcNamedPortIPSets = append(srcNamedPortIPSets, portsToIPSet(srcNumericPorts, srcSel[0], portProto))
+			srcNumericPorts = nil
+			srcSelCombined = true
+		}
+		if len(srcSel) == 1 && !srcSelCombined && len(dstSel) == 0 && canCombinePorts(dstNumericPorts) && len(dstNamedPorts) == 0 {
+			srcIPDstPortIPSets = append(srcIPDstPortIPSets, portsToIPSet(dstNumericPorts, srcSel[0], portProto))
+			dstNumericPorts = nil
+			srcSelCombined = true
+		}
+	}
+
 	// Optimization: only include the selectors if we haven't already covered them with a named
 	// port match above.  If we have some named ports then we've already filtered the named port
 	// by the selector above.  If we have numeric ports, we can't make the optimization
-	// because we can't filter numeric ports by selector in the same way.
+	// because we can't filter numeric ports by selector in the same way (unless we combined them
+	// above).
 	var srcSelIPSets, dstSelIPSets []*IPSetData
 
-	if len(srcNumericPorts) > 0 || len(srcNamedPorts) == 0 {
+	if !srcSelCombined && (len(srcNumericPorts) > 0 || len(srcNamedPorts) == 0) {
 		srcSelIPSets = selectorsToIPSets(srcSel)
 	}
-	if len(dstNumericPorts) > 0 || len(dstNamedPorts) == 0 {
+	if !dstSelCombined && (len(dstNumericPorts) > 0 || len(dstNamedPorts) == 0) {
 		dstSelIPSets = selectorsToIPSets(dstSel)
 	}
 
@@ -462,6 +515,7 @@ func ruleToParsedRule(rule *model.Rule) (parsedRule *ParsedRule, allIPSets []*IP
 		DstNamedPortIPSetIDs: ipSetsToUIDs(dstNamedPortIPSets),
 		DstIPSetIDs:          ipSetsToUIDs(dstSelIPSets),
 		DstIPPortSetIDs:      ipSetsToUIDs(dstIPPortSets),
+		SrcIPDstPortIPSetIDs: ipSetsToUIDs(srcIPDstPortIPSets),
 
 		ICMPType: rule.ICMPType,
 		ICMPCode: rule.ICMPCode,
@@ -481,6 +535,8 @@ func ruleToParsedRule(rule *model.Rule) (parsedRule *ParsedRule, allIPSets []*IP
 		NotICMPType: rule.NotICMPType,
 		NotICMPCode: rule.NotICMPCode,
 
+		ConnLimit: rule.ConnLimit,
+
 		// Pass through original values of some fields for the policy API.
 		OriginalSrcSelector:               rule.OriginalSrcSelector,
 		OriginalSrcNamespaceSelector:      rule.OriginalSrcNamespaceSelector,
@@ -509,6 +565,7 @@ func ruleToParsedRule(rule *model.Rule) (parsedRule *ParsedRule, allIPSets []*IP
 	allIPSets = append(allIPSets, srcSelIPSets...)
 	allIPSets = append(allIPSets, dstSelIPSets...)
 	allIPSets = append(allIPSets, dstIPPortSets...)
+	allIPSets = append(allIPSets, srcIPDstPortIPSets...)
 	allIPSets = append(allIPSets, notSrcSelIPSets...)
 	allIPSets = append(allIPSets, notDstSelIPSets...)
 
@@ -537,6 +594,73 @@ func namedPortsToIPSets(namedPorts []string, positiveSelectors []selector.Select
 	return ipSets
 }
 
+// portsIPSetProtocol returns the IP set protocol to use for the numeric ports of a rule with the
+// given protocol, or false if the rule's protocol doesn't have ports.
+func portsIPSetProtocol(protocol *numorstring.Protocol) (labelindex.IPSetPortProtocol, bool) {
+	if protocol == nil || (protocol.Type == numorstring.NumOrStringNum && protocol.NumVal == 0) {
+		return labelindex.ProtocolNone, false
+	}
+	for _, p := range []labelindex.IPSetPortProtocol{labelindex.ProtocolTCP, labelindex.ProtocolUDP, labelindex.ProtocolSCTP} {
+		if p.MatchesModelProtocol(*protocol) {
+			return p, true
+		}
+	}
+	return labelindex.ProtocolNone, false
+}
+
+// maxCombinedPortRanges limits the number of port ranges that we combine with a selector.  An IP set
+// that combines a selector with numeric ports has an element for each matching address and each
+// port range, so it is that many times larger than the selector's own IP set, and every endpoint
+// that starts or stops matching the selector adds or removes that many elements.  The single set
+// lookup is a win for the common rules that list a few ports; a rule that lists many port ranges
+// is better served by the selector's IP set (which is shared with other rules) and a separate
+// match on an anonymous set of ports.
+const maxCombinedPortRanges = 8
+
+// canCombinePorts returns true if the given numeric ports can be combined with a selector into a
+// single IP and port IP set.
+func canCombinePorts(ports []numorstring.Port) bool {
+	return len(ports) > 0 && len(mergePorts(ports)) <= maxCombinedPortRanges
+}
+
+// portsToIPSet converts a selector and a list of numeric ports to an IP and port IP set.
+func portsToIPSet(ports []numorstring.Port, sel selector.Selector, proto labelindex.IPSetPortProtocol) *IPSetData {
+	return &IPSetData{
+		Selector:          sel,
+		NamedPortProtocol: proto,
+		Ports:             mergePorts(ports),
+	}
+}
+
+// mergePorts sorts the given numeric ports and merges any that overlap or are adjacent, since the
+// port ranges in an IP set must not overlap.
+func mergePorts(ports []numorstring.Port) []numorstring.Port {
+	sorted := make([]numorstring.Port, len(ports))
+	copy(sorted, ports)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MinPort < sorted[j].MinPort
+	})
+	var merged []numorstring.Port
+	for _, p := range sorted {
+		if n := len(merged); n > 0 && uint32(p.MinPort) <= uint32(merged[n-1].MaxPort)+1 {
+			if p.MaxPort > merged[n-1].MaxPort {
+				merged[n-1].MaxPort = p.MaxPort
+			}
+			continue
+		}
+		merged = append(merged, numorstring.Port{MinPort: p.MinPort, MaxPort: p.MaxPort})
+	}
+	return merged
+}
+
+func portsToString(ports []numorstring.Port) string {
+	parts := make([]string, len(ports))
+	for i, p := range ports {
+		parts[i] = p.String()
+	}
+	return strings.Join(parts, ",")
+}
+
 // Converts a list of selectors to a list of IPSets.
 func selectorsToIPSets(selectors []selector.Selector) []*IPSetData {
 	var ipSets []*IPSetData
@@ -103,6 +103,7 @@ var _ = DescribeTable("RuleScanner rule conversion should generate correct Parse
 				InboundRules:     []*ParsedRule{&expectedParsedRule},
 				OutboundRules:    []*ParsedRule{},
 				OriginalSelector: "a == \"A\"",
+				ContentHash:      model.PolicyContentHash(policy),
 			},
 		}))
 		rs.OnPolicyInactive(policyKey)
@@ -197,6 +198,67 @@ var _ = DescribeTable("RuleScanner rule conversion should generate correct Parse
 	),
 )
 
+var (
+	protocolTCP  = numorstring.ProtocolFromString("TCP")
+	webPorts     = []numorstring.Port{numorstring.SinglePort(8080), numorstring.SinglePort(80), {MinPort: 8081, MaxPort: 8090}}
+	httpAndPorts = []numorstring.Port{numorstring.SinglePort(80), numorstring.NamedPort("http")}
+	// manyPorts has one more port range than we combine with a selector; the adjacent ports
+	// 1000 and 1001 merge so manyMergedPorts is exactly the limit.
+	manyPorts = []numorstring.Port{
+		numorstring.SinglePort(1), numorstring.SinglePort(3), numorstring.SinglePort(5),
+		numorstring.SinglePort(7), numorstring.SinglePort(9), numorstring.SinglePort(11),
+		numorstring.SinglePort(13), numorstring.SinglePort(15), numorstring.SinglePort(17),
+	}
+	manyMergedPorts = append(manyPorts[:7:7], numorstring.SinglePort(1000), numorstring.SinglePort(1001))
+)
+
+var _ = DescribeTable("RuleScanner with CombineSelectorsAndPorts should generate correct ParsedRule for",
+	func(modelRule model.Rule, expectedParsedRule ParsedRule) {
+		rs, ur := newHookedRulesScanner()
+		rs.CombineSelectorsAndPorts = true
+		policyKey := model.PolicyKey{Name: "policy"}
+		rs.OnPolicyActive(policyKey, &model.Policy{InboundRules: []model.Rule{modelRule}})
+		Expect(ur.activeRules[policyKey].InboundRules).To(Equal([]*ParsedRule{&expectedParsedRule}))
+	},
+	Entry("dest selector and dest ports",
+		model.Rule{Protocol: &protocolTCP, DstSelector: sel1, DstPorts: webPorts},
+		ParsedRule{Protocol: &protocolTCP, DstNamedPortIPSetIDs: []string{portsID(sel1, "tcp", "80,8080:8090")}},
+	),
+	Entry("source selector and source ports",
+		model.Rule{Protocol: &protocolTCP, SrcSelector: sel1, SrcPorts: webPorts},
+		ParsedRule{Protocol: &protocolTCP, SrcNamedPortIPSetIDs: []string{portsID(sel1, "tcp", "80,8080:8090")}},
+	),
+	Entry("source selector and dest ports",
+		model.Rule{Protocol: &protocolTCP, SrcSelector: sel1, DstPorts: webPorts},
+		ParsedRule{Protocol: &protocolTCP, SrcIPDstPortIPSetIDs: []string{portsID(sel1, "tcp", "80,8080:8090")}},
+	),
+	Entry("source and dest selectors and dest ports",
+		model.Rule{Protocol: &protocolTCP, SrcSelector: sel1, DstSelector: sel3, DstPorts: webPorts},
+		ParsedRule{
+			Protocol:             &protocolTCP,
+			SrcIPSetIDs:          []string{sel1ID},
+			DstNamedPortIPSetIDs: []string{portsID(sel3, "tcp", "80,8080:8090")},
+		},
+	),
+	Entry("dest selector and too many dest port ranges",
+		model.Rule{Protocol: &protocolTCP, DstSelector: sel1, DstPorts: manyPorts},
+		ParsedRule{Protocol: &protocolTCP, DstPorts: manyPorts, DstIPSetIDs: []string{sel1ID}},
+	),
+	Entry("dest selector and dest ports that merge into the maximum number of ranges",
+		model.Rule{Protocol: &protocolTCP, DstSelector: sel1, DstPorts: manyMergedPorts},
+		ParsedRule{Protocol: &protocolTCP, DstNamedPortIPSetIDs: []string{portsID(sel1, "tcp", "1,3,5,7,9,11,13,1000:1001")}},
+	),
+	Entry("dest selector and named and numeric dest ports",
+		model.Rule{Protocol: &protocolTCP, DstSelector: sel1, DstPorts: httpAndPorts},
+		ParsedRule{
+			Protocol:             &protocolTCP,
+			DstPorts:             []numorstring.Port{numorstring.SinglePort(80)},
+			DstNamedPortIPSetIDs: []string{namedPortID(sel1, "tcp", "http")},
+			DstIPSetIDs:          []string{sel1ID},
+		},
+	),
+)
+
 var _ = Describe("ParsedRule", func() {
 	It("should have correct fields relative to model.Rule", func() {
 		// We expect all the fields to have the same name, except for
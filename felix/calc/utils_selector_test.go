@@ -34,3 +34,9 @@ func namedPortID(selector, protocol, portName string) string {
 	idToHash := selID + "," + protocol + "," + portName
 	return hash.MakeUniqueID("n", idToHash)
 }
+
+func portsID(selector, protocol, ports string) string {
+	selID := selectorID(selector)
+	idToHash := selID + "," + protocol + "," + ports
+	return hash.MakeUniqueID("p", idToHash)
+}
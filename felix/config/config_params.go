@@ -64,13 +64,14 @@ type Source uint8
 const (
 	Default Source = iota
 	DatastoreGlobal
+	DatastoreNodeSelector
 	DatastorePerHost
 	ConfigFile
 	EnvironmentVariable
 	InternalOverride
 )
 
-var SourcesInDescendingOrder = []Source{InternalOverride, EnvironmentVariable, ConfigFile, DatastorePerHost, DatastoreGlobal}
+var SourcesInDescendingOrder = []Source{InternalOverride, EnvironmentVariable, ConfigFile, DatastorePerHost, DatastoreNodeSelector, DatastoreGlobal}
 
 func (source Source) String() string {
 	switch source {
@@ -78,6 +79,8 @@ func (source Source) String() string {
 		return "<default>"
 	case DatastoreGlobal:
 		return "datastore (global)"
+	case DatastoreNodeSelector:
+		return "datastore (node selector)"
 	case DatastorePerHost:
 		return "datastore (per-host)"
 	case ConfigFile:
@@ -157,6 +160,14 @@ type Config struct {
 	DataplaneDriver            string        `config:"file(must-exist,executable);calico-iptables-plugin;non-zero,die-on-fail,skip-default-validation"`
 	DataplaneWatchdogTimeout   time.Duration `config:"seconds;90"`
 
+	// DataplaneSnapshotFile is the file in which the internal dataplane driver persists the
+	// calculation graph output that it last applied.  On restart, a snapshot that matches the
+	// kernel's policy and IP sets lets the dataplane start incremental updates without waiting
+	// for a full resync.  Only supported by the iptables dataplane.  Empty disables the snapshot.
+	DataplaneSnapshotFile string `config:"file;;local"`
+	// DataplaneSnapshotInterval is the minimum interval between writes of the dataplane snapshot.
+	DataplaneSnapshotInterval time.Duration `config:"seconds;30;local"`
+
 	// Wireguard configuration
 	WireguardEnabled               bool          `config:"bool;false"`
 	WireguardEnabledV6             bool          `config:"bool;false"`
@@ -341,7 +352,8 @@ type Config struct {
 
 	IptablesMarkMask uint32 `config:"mark-bitmask;0xffff0000;non-zero,die-on-fail"`
 
-	DisableConntrackInvalidCheck bool `config:"bool;false"`
+	DisableConntrackInvalidCheck bool                     `config:"bool;false"`
+	ConntrackTimeouts            map[string]time.Duration `config:"keydurationlist;;"`
 
 	HealthEnabled          bool                     `config:"bool;false"`
 	HealthPort             int                      `config:"int(0,65535);9099"`
@@ -394,6 +406,13 @@ type Config struct {
 	RouteTableRanges  []idalloc.IndexRange `config:"route-table-ranges;;die-on-fail"`
 	RouteSyncDisabled bool                 `config:"bool;false"`
 
+	// RoutePolicyRouteTableRange is the range of route table indices that RoutePolicy resources may
+	// use.  It must not overlap RouteTableRanges or the kernel's reserved tables.  The range is on by
+	// default, so Felix only claims a table in it once a RoutePolicy uses the table, and only if the
+	// table isn't in use by other software.  Setting it to "none" disables RoutePolicy programming.
+	RoutePolicyRouteTableRange     idalloc.IndexRange `config:"route-table-id-range;1000-1249;die-on-fail"`
+	RoutePolicyRoutingRulePriority int                `config:"int;100"`
+
 	IptablesNATOutgoingInterfaceFilter string `config:"iface-param;"`
 
 	SidecarAccelerationEnabled bool `config:"bool;false"`
@@ -427,6 +446,8 @@ type Config struct {
 	sourceToRawConfig map[Source]map[string]string
 	// rawValues maps keys to the current highest-priority raw value.
 	rawValues map[string]string
+	// rawValueSources maps the keys of rawValues to the source of their values.
+	rawValueSources map[string]Source
 	// Err holds the most recent error from a config update.
 	Err error
 
@@ -460,6 +481,11 @@ func (config *Config) Copy() *Config {
 		cp.rawValues[k] = v
 	}
 
+	cp.rawValueSources = map[string]Source{}
+	for k, v := range config.rawValueSources {
+		cp.rawValueSources[k] = v
+	}
+
 	return &cp
 }
 
@@ -612,6 +638,7 @@ func (config *Config) resolve() (changedFields set.Set[string], err error) {
 	config.applyDefaults()
 
 	newRawValues := make(map[string]string)
+	newRawValueSources := make(map[string]Source)
 	// Map from lower-case version of name to the highest-priority source found so far.
 	// We use the lower-case version of the name since we can calculate it both for
 	// expected and "raw" parameters, which may be used by plugins.
@@ -628,6 +655,7 @@ func (config *Config) resolve() (changedFields set.Set[string], err error) {
 					// dataplane driver.  Use the raw name since the driver may
 					// want it.
 					newRawValues[rawName] = rawValue
+					newRawValueSources[rawName] = source
 					nameToSource[lowerCaseName] = source
 				}
 				log.WithField("raw name", rawName).Info(
@@ -689,6 +717,7 @@ func (config *Config) resolve() (changedFields set.Set[string], err error) {
 			field := reflect.ValueOf(config).Elem().FieldByName(name)
 			field.Set(reflect.ValueOf(value))
 			newRawValues[name] = rawValue
+			newRawValueSources[name] = source
 			nameToSource[lowerCaseName] = source
 		}
 	}
@@ -713,6 +742,7 @@ func (config *Config) resolve() (changedFields set.Set[string], err error) {
 	}
 
 	config.rawValues = newRawValues
+	config.rawValueSources = newRawValueSources
 	return
 }
 
@@ -862,6 +892,16 @@ func (config *Config) Validate() (err error) {
 		}
 	}
 
+	// The RoutePolicy route tables are owned exclusively by the RoutePolicy manager, so they can't
+	// be shared with the tables that Felix allocates for itself or with the kernel's tables.  The
+	// default range is only a problem if the user has chosen RouteTableRanges that include it; in
+	// that case RoutePolicyRouteTableIndices disables RoutePolicy instead.
+	if _, set := config.rawValues["RoutePolicyRouteTableRange"]; set {
+		if rerr := config.checkRoutePolicyRouteTableRange(); rerr != nil {
+			err = rerr
+		}
+	}
+
 	if err != nil {
 		config.Err = err
 	}
@@ -998,6 +1038,8 @@ func loadParams() {
 			param = &RouteTableRangeParam{}
 		case "route-table-ranges":
 			param = &RouteTableRangesParam{}
+		case "route-table-id-range":
+			param = &RouteTableIDRangeParam{}
 		case "keyvaluelist":
 			param = &KeyValueListParam{}
 		case "keydurationlist":
@@ -1070,6 +1112,15 @@ func (config *Config) RawValues() map[string]string {
 	return cp
 }
 
+// RawValueSources returns the source of each of the RawValues.
+func (config *Config) RawValueSources() map[string]Source {
+	cp := map[string]Source{}
+	for k, v := range config.rawValueSources {
+		cp[k] = v
+	}
+	return cp
+}
+
 func (config *Config) SetLoadClientConfigFromEnvironmentFunction(fnc func() (*apiconfig.CalicoAPIConfig, error)) {
 	config.loadClientConfigFromEnvironment = fnc
 }
@@ -1103,12 +1154,45 @@ func (config *Config) RouteTableIndices() []idalloc.IndexRange {
 	return config.RouteTableRanges
 }
 
+// RoutePolicyRouteTableIndices returns the range of route tables that may be used by RoutePolicy
+// resources, or an empty range if RoutePolicy programming is disabled.  If the range was left at
+// its default and it clashes with RouteTableRanges, RoutePolicy programming is disabled.
+func (config *Config) RoutePolicyRouteTableIndices() idalloc.IndexRange {
+	if err := config.checkRoutePolicyRouteTableRange(); err != nil {
+		log.WithError(err).Warn("Default RoutePolicyRouteTableRange can't be used, disabling RoutePolicy. " +
+			"Set RoutePolicyRouteTableRange to a range outside of RouteTableRanges to enable it.")
+		return idalloc.IndexRange{}
+	}
+	return config.RoutePolicyRouteTableRange
+}
+
+func (config *Config) checkRoutePolicyRouteTableRange() error {
+	r := config.RoutePolicyRouteTableRange
+	if r == (idalloc.IndexRange{}) {
+		return nil
+	}
+	for _, o := range config.RouteTableIndices() {
+		if r.Min <= o.Max && o.Min <= r.Max {
+			return fmt.Errorf("RoutePolicyRouteTableRange %d-%d overlaps RouteTableRanges %d-%d",
+				r.Min, r.Max, o.Min, o.Max)
+		}
+	}
+	for _, t := range routeTablesReservedLinux {
+		if r.Min <= t && t <= r.Max {
+			return fmt.Errorf("RoutePolicyRouteTableRange %d-%d includes reserved route table %d",
+				r.Min, r.Max, t)
+		}
+	}
+	return nil
+}
+
 func New() *Config {
 	if knownParams == nil {
 		loadParams()
 	}
 	p := &Config{
 		rawValues:         map[string]string{},
+		rawValueSources:   map[string]Source{},
 		sourceToRawConfig: map[Source]map[string]string{},
 		internalOverrides: map[string]string{},
 	}
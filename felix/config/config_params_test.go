@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/projectcalico/calico/felix/config"
+	"github.com/projectcalico/calico/felix/idalloc"
 	"github.com/projectcalico/calico/felix/testutils"
 	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
 	"github.com/projectcalico/calico/libcalico-go/lib/set"
@@ -43,6 +44,7 @@ var _ = Describe("FelixConfig vs ConfigParams parity", func() {
 	cpFieldsToIgnore := []string{
 		"sourceToRawConfig",
 		"rawValues",
+		"rawValueSources",
 		"Err",
 		"numIptablesBitsAllocated",
 
@@ -67,6 +69,11 @@ var _ = Describe("FelixConfig vs ConfigParams parity", func() {
 		// Temporary field to implement and test IPv6 in BPF dataplane
 		"BpfIpv6Support",
 	}
+	fcFieldsToIgnore := []string{
+		// Select the nodes that the FelixConfiguration applies to.
+		"NodeSelector",
+		"Priority",
+	}
 	cpFieldNameToFC := map[string]string{
 		"IpInIpEnabled":                      "IPIPEnabled",
 		"VXLANEnabled":                       "VXLANEnabled",
@@ -89,6 +96,9 @@ var _ = Describe("FelixConfig vs ConfigParams parity", func() {
 
 	BeforeEach(func() {
 		fcFields = fieldsByName(v3.FelixConfigurationSpec{})
+		for _, name := range fcFieldsToIgnore {
+			delete(fcFields, name)
+		}
 
 		cpFields = fieldsByName(config.Config{})
 		for _, name := range cpFieldsToIgnore {
@@ -765,6 +775,37 @@ var _ = DescribeTable("Config validation",
 	Entry("invalid RouteTableRanges", map[string]string{
 		"RouteTableRanges": "abcde",
 	}, false),
+	Entry("RoutePolicyRouteTableRange overlapping the default RouteTableRanges", map[string]string{
+		"RoutePolicyRouteTableRange": "200-249",
+	}, false),
+	Entry("RoutePolicyRouteTableRange overlapping RouteTableRanges", map[string]string{
+		"RouteTableRanges":           "1-199,240-1000",
+		"RoutePolicyRouteTableRange": "200-249",
+	}, false),
+	Entry("RoutePolicyRouteTableRange outside RouteTableRanges", map[string]string{
+		"RouteTableRanges":           "1-199,256-1000",
+		"RoutePolicyRouteTableRange": "200-249",
+	}, true),
+	Entry("RoutePolicyRouteTableRange outside the deprecated RouteTableRange", map[string]string{
+		"RouteTableRange":            "1-199",
+		"RoutePolicyRouteTableRange": "200-249",
+	}, true),
+	Entry("RoutePolicyRouteTableRange above the kernel's reserved tables", map[string]string{
+		"RoutePolicyRouteTableRange": "256-1000",
+	}, true),
+	Entry("RoutePolicyRouteTableRange including the kernel's reserved tables", map[string]string{
+		"RouteTableRanges":           "1-199",
+		"RoutePolicyRouteTableRange": "200-300",
+	}, false),
+	Entry("RoutePolicyRouteTableRange targeting too many tables", map[string]string{
+		"RoutePolicyRouteTableRange": "1000-100000000",
+	}, false),
+	Entry("RoutePolicyRouteTableRange disabled", map[string]string{
+		"RoutePolicyRouteTableRange": "none",
+	}, true),
+	Entry("default RoutePolicyRouteTableRange overlapping RouteTableRanges", map[string]string{
+		"RouteTableRanges": "1-10000",
+	}, true),
 )
 
 var _ = DescribeTable("Config InterfaceExclude",
@@ -806,6 +847,30 @@ var _ = DescribeTable("Config InterfaceExclude",
 	}),
 )
 
+var _ = Describe("RoutePolicyRouteTableIndices", func() {
+	It("should default to a range outside the default RouteTableRanges", func() {
+		cfg := config.New()
+		Expect(cfg.Validate()).To(Succeed())
+		Expect(cfg.RoutePolicyRouteTableIndices()).To(Equal(idalloc.IndexRange{Min: 1000, Max: 1249}))
+	})
+
+	It("should disable RoutePolicy if RouteTableRanges include the default range", func() {
+		cfg := config.New()
+		_, err := cfg.UpdateFrom(map[string]string{"RouteTableRanges": "1-10000"}, config.EnvironmentVariable)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Validate()).To(Succeed())
+		Expect(cfg.RoutePolicyRouteTableIndices()).To(Equal(idalloc.IndexRange{}))
+	})
+
+	It("should return an explicitly configured range", func() {
+		cfg := config.New()
+		_, err := cfg.UpdateFrom(map[string]string{"RoutePolicyRouteTableRange": "70000-70099"}, config.EnvironmentVariable)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Validate()).To(Succeed())
+		Expect(cfg.RoutePolicyRouteTableIndices()).To(Equal(idalloc.IndexRange{Min: 70000, Max: 70099}))
+	})
+})
+
 var _ = Describe("Config copy tests", func() {
 	var conf *config.Config
 
@@ -828,6 +893,15 @@ var _ = Describe("Config copy tests", func() {
 
 	It("should copy correctly", func() {
 		Expect(conf.RawValues()).To(Equal(conf.Copy().RawValues()))
+		Expect(conf.RawValueSources()).To(Equal(conf.Copy().RawValueSources()))
+	})
+
+	It("should record the source of each value", func() {
+		Expect(conf.RawValueSources()).To(Equal(map[string]config.Source{
+			"LogSeverityScreen":            config.EnvironmentVariable,
+			"HealthTimeoutOverrides":       config.EnvironmentVariable,
+			"DisableConntrackInvalidCheck": config.DatastoreGlobal,
+		}))
 	})
 
 	It("should copy via protobuf correctly", func() {
@@ -840,6 +914,7 @@ var _ = Describe("Config copy tests", func() {
 			"DisableConntrackInvalidCheck",
 		)))
 		Expect(confCp.RawValues()).To(Equal(conf.RawValues()))
+		Expect(confCp.RawValueSources()).To(Equal(conf.RawValueSources()))
 
 		changedFields, err = confCp.UpdateFromConfigUpdate(pb)
 		Expect(err).To(Succeed())
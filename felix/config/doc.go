@@ -27,9 +27,10 @@
 // Config from higher-priority sources overrides config from lower-priority
 // sources.  The priorities, in increasing order of priority, are:
 //
-//	Default              // Default value of a parameter
-//	DatastoreGlobal      // Cluster-wide config parameters from the datastore.
-//	DatastorePerHost     // Per-host overrides from the datastore.
-//	ConfigFile           // The local config file.
-//	EnvironmentVariable  // Environment variables.
+//	Default                // Default value of a parameter
+//	DatastoreGlobal        // Cluster-wide config parameters from the datastore.
+//	DatastoreNodeSelector  // Overrides from the datastore that select this host.
+//	DatastorePerHost       // Per-host overrides from the datastore.
+//	ConfigFile             // The local config file.
+//	EnvironmentVariable    // Environment variables.
 package config
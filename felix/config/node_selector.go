@@ -0,0 +1,87 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/syncersv1/updateprocessors"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector"
+)
+
+// NodeSelectorConfig is the config from a FelixConfiguration that applies to the nodes that its
+// NodeSelector selects.
+type NodeSelectorConfig struct {
+	Name     string
+	Selector selector.Selector
+	Priority int
+	Values   map[string]string
+}
+
+// NodeSelectorConfigFromResource converts a FelixConfiguration with a NodeSelector.
+func NodeSelectorConfigFromResource(res *apiv3.FelixConfiguration) (*NodeSelectorConfig, error) {
+	sel, err := selector.Parse(res.Spec.NodeSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node selector %q in FelixConfiguration %s: %w", res.Spec.NodeSelector, res.Name, err)
+	}
+	values, err := updateprocessors.FelixConfigValues(res)
+	if err != nil {
+		return nil, err
+	}
+	c := &NodeSelectorConfig{
+		Name:     res.Name,
+		Selector: sel,
+		Values:   values,
+	}
+	if res.Spec.Priority != nil {
+		c.Priority = *res.Spec.Priority
+	}
+	return c, nil
+}
+
+// MergeNodeSelectorConfigs merges the values of the configs that select a node with the given
+// labels.  Where more than one config sets a value, the value is taken from the config with the
+// highest priority, and then from the one whose name sorts first.  It returns the merged values,
+// and the name of the config that each value is taken from.
+func MergeNodeSelectorConfigs(configs []*NodeSelectorConfig, labels map[string]string) (values, sources map[string]string) {
+	var selected []*NodeSelectorConfig
+	for _, c := range configs {
+		if c.Selector.Evaluate(labels) {
+			selected = append(selected, c)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Priority != selected[j].Priority {
+			return selected[i].Priority > selected[j].Priority
+		}
+		return selected[i].Name < selected[j].Name
+	})
+
+	values = map[string]string{}
+	sources = map[string]string{}
+	for _, c := range selected {
+		for name, value := range c.Values {
+			if _, ok := values[name]; ok {
+				continue
+			}
+			values[name] = value
+			sources[name] = c.Name
+		}
+	}
+	return values, sources
+}
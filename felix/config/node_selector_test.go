@@ -0,0 +1,92 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/felix/config"
+)
+
+func nodeSelectorConfig(name, sel string, priority int, values map[string]string) *config.NodeSelectorConfig {
+	res := apiv3.NewFelixConfiguration()
+	res.Name = name
+	res.Spec.NodeSelector = sel
+	if priority != 0 {
+		res.Spec.Priority = &priority
+	}
+	res.Annotations = map[string]string{}
+	for k, v := range values {
+		res.Annotations["config.projectcalico.org/"+k] = v
+	}
+	c, err := config.NodeSelectorConfigFromResource(res)
+	Expect(err).NotTo(HaveOccurred())
+	return c
+}
+
+var _ = Describe("FelixConfiguration node selectors", func() {
+	It("should convert a FelixConfiguration", func() {
+		res := apiv3.NewFelixConfiguration()
+		res.Name = "rack-a"
+		res.Spec.NodeSelector = "rack == 'a'"
+		priority := 10
+		res.Spec.Priority = &priority
+		res.Spec.InterfacePrefix = "cali"
+		c, err := config.NodeSelectorConfigFromResource(res)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Name).To(Equal("rack-a"))
+		Expect(c.Priority).To(Equal(10))
+		Expect(c.Values).To(Equal(map[string]string{"InterfacePrefix": "cali"}))
+		Expect(c.Selector.String()).To(Equal("rack == \"a\""))
+	})
+
+	It("should reject an invalid selector", func() {
+		res := apiv3.NewFelixConfiguration()
+		res.Name = "bad"
+		res.Spec.NodeSelector = "rack == "
+		_, err := config.NodeSelectorConfigFromResource(res)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should merge the selected configs by priority and then name", func() {
+		configs := []*config.NodeSelectorConfig{
+			nodeSelectorConfig("b", "has(rack)", 0, map[string]string{"A": "b", "B": "b", "C": "b"}),
+			nodeSelectorConfig("a", "rack == 'a'", 0, map[string]string{"B": "a"}),
+			nodeSelectorConfig("z", "rack == 'a'", 5, map[string]string{"C": "z"}),
+			nodeSelectorConfig("y", "rack == 'b'", 10, map[string]string{"A": "y"}),
+		}
+		values, sources := config.MergeNodeSelectorConfigs(configs, map[string]string{"rack": "a"})
+		Expect(values).To(Equal(map[string]string{"A": "b", "B": "a", "C": "z"}))
+		Expect(sources).To(Equal(map[string]string{"A": "b", "B": "a", "C": "z"}))
+
+		values, sources = config.MergeNodeSelectorConfigs(configs, nil)
+		Expect(values).To(BeEmpty())
+		Expect(sources).To(BeEmpty())
+	})
+
+	It("should rank node selector config between the global and per-host config", func() {
+		cp := config.New()
+		_, err := cp.UpdateFrom(map[string]string{"InterfacePrefix": "global", "LogSeverityScreen": "Debug"}, config.DatastoreGlobal)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cp.UpdateFrom(map[string]string{"InterfacePrefix": "selected", "LogSeverityScreen": "Warning"}, config.DatastoreNodeSelector)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cp.UpdateFrom(map[string]string{"InterfacePrefix": "host"}, config.DatastorePerHost)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cp.InterfacePrefix).To(Equal("host"))
+		Expect(cp.LogSeverityScreen).To(Equal("WARNING"))
+	})
+})
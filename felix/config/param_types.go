@@ -624,6 +624,44 @@ func (p *RouteTableRangeParam) Parse(raw string) (result interface{}, err error)
 	return
 }
 
+// RouteTableIDRangeParam is a single range of route table indices.  Unlike RouteTableRangeParam,
+// it isn't limited to the first 250 tables.
+type RouteTableIDRangeParam struct {
+	Metadata
+}
+
+func (p *RouteTableIDRangeParam) Parse(raw string) (result interface{}, err error) {
+	m := regexp.MustCompile(`^(\d+)-(\d+)$`).FindStringSubmatch(raw)
+	if m == nil {
+		err = p.parseFailed(raw, "must be a range of route table indices")
+		return
+	}
+	min, serr := strconv.Atoi(m[1])
+	if serr != nil || min <= 0 {
+		err = p.parseFailed(raw, "min value is not a valid number")
+		return
+	}
+	max, serr := strconv.Atoi(m[2])
+	if serr != nil {
+		err = p.parseFailed(raw, "max value is not a valid number")
+		return
+	}
+	if min > max {
+		err = p.parseFailed(raw, "min value is greater than max value")
+		return
+	}
+	if int64(max) > int64(routeTableMaxLinux) {
+		err = p.parseFailed(raw, "max value is too high")
+		return
+	}
+	if max-min > routeTableRangeMaxTables {
+		err = p.parseFailed(raw, "targets too many tables")
+		return
+	}
+	result = idalloc.IndexRange{Min: min, Max: max}
+	return
+}
+
 type RouteTableRangesParam struct {
 	Metadata
 }
@@ -222,7 +222,7 @@ configRetry:
 		numClientsCreated++
 		backendClient = v3Client.(interface{ Backend() bapi.Client }).Backend()
 		for {
-			globalConfig, hostConfig, err := loadConfigFromDatastore(
+			globalConfig, selectedConfig, hostConfig, err := loadConfigFromDatastore(
 				ctx, backendClient, datastoreConfig, configParams.FelixHostname)
 			if err == ErrNotReady {
 				log.Warn("Waiting for datastore to be initialized (or migrated)")
@@ -240,6 +240,12 @@ configRetry:
 				time.Sleep(1 * time.Second)
 				continue configRetry
 			}
+			_, err = configParams.UpdateFrom(selectedConfig, config.DatastoreNodeSelector)
+			if err != nil {
+				log.WithError(err).Error("Failed update node selector config from datastore")
+				time.Sleep(1 * time.Second)
+				continue configRetry
+			}
 			_, err = configParams.UpdateFrom(hostConfig, config.DatastorePerHost)
 			if err != nil {
 				log.WithError(err).Error("Failed update host config from datastore")
@@ -435,9 +441,18 @@ configRetry:
 		// (Otherwise, we pass in a nil channel, which disables such updates.)
 		connToUsageRepUpdChan = make(chan map[string]string, 1)
 	}
+	var configReporter *statusrep.ConfigReporter
+	if configParams.IsLeader() {
+		// Publish the config that we're running with, so that it can be inspected with
+		// calicoctl node config.
+		configReporter = statusrep.NewConfigReporter(configParams.FelixHostname, backendClient)
+		configReporter.OnConfigUpdate(configParams)
+		configReporter.Start()
+	}
 	dpConnector := newConnector(
 		configParams.Copy(), // Copy to avoid concurrent access.
 		connToUsageRepUpdChan,
+		configReporter,
 		backendClient,
 		v3Client,
 		dpDriver,
@@ -873,12 +888,13 @@ var ErrNotReady = errors.New("datastore is not ready or has not been initialised
 
 func loadConfigFromDatastore(
 	ctx context.Context, client bapi.Client, cfg apiconfig.CalicoAPIConfig, hostname string,
-) (globalConfig, hostConfig map[string]string, err error) {
+) (globalConfig, selectedConfig, hostConfig map[string]string, err error) {
 
-	// The configuration is split over 3 different resource types and 4 different resource
-	// instances in the v3 data model:
+	// The configuration is split over 3 different resource types and 4 different kinds of
+	// resource instances in the v3 data model:
 	// -  ClusterInformation (global): name "default"
 	// -  FelixConfiguration (global): name "default"
+	// -  FelixConfiguration (selected by the Node's labels): any other name
 	// -  FelixConfiguration (per-host): name "node.<hostname>"
 	// -  Node (per-host): name: <hostname>
 	// Get the global values and host specific values separately.  We re-use the updateprocessor
@@ -909,6 +925,10 @@ func loadConfigFromDatastore(
 	if err != nil {
 		return
 	}
+	selectedConfig, err = getNodeSelectorConfig(ctx, client, hostname)
+	if err != nil {
+		return
+	}
 	err = getAndMergeConfig(
 		ctx, client, hostConfig,
 		apiv3.KindFelixConfiguration, "node."+hostname,
@@ -988,13 +1008,57 @@ func getAndMergeConfig(
 	return nil
 }
 
+// getNodeSelectorConfig returns the merged config of the FelixConfigurations whose NodeSelector
+// selects this host's Node.
+func getNodeSelectorConfig(ctx context.Context, client bapi.Client, hostname string) (map[string]string, error) {
+	var labels map[string]string
+	node, err := client.Get(ctx, model.ResourceKey{Kind: libapiv3.KindNode, Name: hostname}, "")
+	if err == nil {
+		labels = node.Value.(*libapiv3.Node).Labels
+	} else if _, ok := err.(cerrors.ErrorResourceDoesNotExist); !ok {
+		log.WithError(err).Info("Failed to load Node from datastore")
+		return nil, err
+	}
+
+	fcs, err := client.List(ctx, model.ResourceListOptions{Kind: apiv3.KindFelixConfiguration}, "")
+	if err != nil {
+		log.WithError(err).Info("Failed to list FelixConfigurations from datastore")
+		return nil, err
+	}
+	var configs []*config.NodeSelectorConfig
+	for _, kvp := range fcs.KVPairs {
+		fc := kvp.Value.(*apiv3.FelixConfiguration)
+		if !updateprocessors.IsNodeSelectorFelixConfigName(fc.Name) || fc.Spec.NodeSelector == "" {
+			continue
+		}
+		c, err := config.NodeSelectorConfigFromResource(fc)
+		if err != nil {
+			// Log the error, but don't fail completely to avoid cyclic restarts.
+			log.WithError(err).Error("Failed to convert configuration")
+			continue
+		}
+		configs = append(configs, c)
+	}
+
+	values, sources := config.MergeNodeSelectorConfigs(configs, labels)
+	for name, value := range values {
+		log.WithFields(log.Fields{
+			"name":              name,
+			"value":             value,
+			"felixConfigSource": sources[name],
+		}).Info("Loaded config selected for this host")
+	}
+	return values, nil
+}
+
 type DataplaneConnector struct {
 	configLock sync.Mutex
 	config     *config.Config
 
-	configUpdChan chan<- map[string]string
-	ToDataplane   chan interface{}
-	InSync        chan bool
+	configUpdChan  chan<- map[string]string
+	configReporter *statusrep.ConfigReporter
+	ToDataplane    chan interface{}
+	InSync         chan bool
 
 	// Input channel for msgs from the dataplane.
 	// Msgs popped off this channel are dispatched to all StatusUpdatesFromDataplaneConsumers.
@@ -1018,6 +1082,7 @@ type Startable interface {
 
 func newConnector(configParams *config.Config,
 	configUpdChan chan<- map[string]string,
+	configReporter *statusrep.ConfigReporter,
 	datastore bapi.Client,
 	datastorev3 client.Interface,
 	dataplane dp.DataplaneDriver,
@@ -1026,6 +1091,7 @@ func newConnector(configParams *config.Config,
 	felixConn := &DataplaneConnector{
 		config:                              configParams,
 		configUpdChan:                       configUpdChan,
+		configReporter:                      configReporter,
 		datastore:                           datastore,
 		datastorev3:                         datastorev3,
 		ToDataplane:                         make(chan interface{}),
@@ -1089,6 +1155,14 @@ func (fc *DataplaneConnector) readMessagesFromDataplane() {
 			if len(fc.statusUpdatesFromDataplaneConsumers) > 0 {
 				fc.statusUpdatesFromDataplane <- msg
 			}
+		case *proto.PolicyStatusUpdate:
+			if len(fc.statusUpdatesFromDataplaneConsumers) > 0 {
+				fc.statusUpdatesFromDataplane <- msg
+			}
+		case *proto.PolicyStatusRemove:
+			if len(fc.statusUpdatesFromDataplaneConsumers) > 0 {
+				fc.statusUpdatesFromDataplane <- msg
+			}
 
 		case *proto.WireguardStatusUpdate:
 			fc.wireguardStatUpdateFromDataplane <- msg
@@ -1379,6 +1453,10 @@ func (fc *DataplaneConnector) handleConfigUpdate(msg *proto.ConfigUpdate) {
 		fc.ApplyNoRestartConfig(oldConfigCopy, newConfigCopy)
 	}
 
+	if fc.configReporter != nil {
+		fc.configReporter.OnConfigUpdate(newConfigCopy)
+	}
+
 	if fc.configUpdChan != nil {
 		// Send the config over to the usage reporter.
 		fc.configUpdChan <- newRawConfig
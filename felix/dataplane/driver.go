@@ -42,6 +42,7 @@ import (
 	extdataplane "github.com/projectcalico/calico/felix/dataplane/external"
 	"github.com/projectcalico/calico/felix/dataplane/inactive"
 	intdataplane "github.com/projectcalico/calico/felix/dataplane/linux"
+	"github.com/projectcalico/calico/felix/dataplane/snapshot"
 	"github.com/projectcalico/calico/felix/idalloc"
 	"github.com/projectcalico/calico/felix/ifacemonitor"
 	"github.com/projectcalico/calico/felix/ipsets"
@@ -329,6 +330,13 @@ func StartDataplaneDriver(configParams *config.Config,
 			ConfigChangedRestartCallback: configChangedRestartCallback,
 			FatalErrorRestartCallback:    fatalErrorCallback,
 
+			SnapshotFile:       configParams.DataplaneSnapshotFile,
+			SnapshotInterval:   configParams.DataplaneSnapshotInterval,
+			SnapshotConfigHash: snapshot.ConfigHash(configParams.RawValues()),
+
+			// Policy status is written by the endpoint status reporter.
+			PolicyStatusReportingEnabled: configParams.EndpointReportingEnabled,
+
 			PostInSyncCallback: func() {
 				// The initial resync uses a lot of scratch space so now is
 				// a good time to force a GC and return any RAM that we can.
@@ -373,8 +381,11 @@ func StartDataplaneDriver(configParams *config.Config,
 			BPFDisableGROForIfaces:             configParams.BPFDisableGROForIfaces,
 			XDPEnabled:                         configParams.XDPEnabled,
 			XDPAllowGeneric:                    configParams.GenericXDPEnabled,
-			BPFConntrackTimeouts:               conntrack.DefaultTimeouts(), // FIXME make timeouts configurable
+			BPFConntrackTimeouts:               conntrack.DefaultTimeouts().WithOverrides(configParams.ConntrackTimeouts),
+			ConntrackTimeouts:                  configParams.ConntrackTimeouts,
 			RouteTableManager:                  routeTableIndexAllocator,
+			RoutePolicyRouteTableRange:         configParams.RoutePolicyRouteTableIndices(),
+			RoutePolicyRoutingRulePriority:     configParams.RoutePolicyRoutingRulePriority,
 			MTUIfacePattern:                    configParams.MTUIfacePattern,
 			BPFExcludeCIDRsFromNAT:             configParams.BPFExcludeCIDRsFromNAT,
 			ServiceLoopPrevention:              configParams.ServiceLoopPrevention,
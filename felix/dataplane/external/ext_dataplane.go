@@ -125,6 +125,10 @@ func (c *extDataplaneConn) RecvMessage() (msg interface{}, err error) {
 		msg = payload.HostEndpointStatusRemove
 	case *proto.FromDataplane_WireguardStatusUpdate:
 		msg = payload.WireguardStatusUpdate
+	case *proto.FromDataplane_PolicyStatusUpdate:
+		msg = payload.PolicyStatusUpdate
+	case *proto.FromDataplane_PolicyStatusRemove:
+		msg = payload.PolicyStatusRemove
 
 	default:
 		log.WithField("payload", payload).Warn("Ignoring unknown message from dataplane")
@@ -250,6 +254,10 @@ func WrapPayloadWithEnvelope(msg interface{}, seqNo uint64) (*proto.ToDataplane,
 		envelope.Payload = &proto.ToDataplane_ServiceUpdate{ServiceUpdate: msg}
 	case *proto.ServiceRemove:
 		envelope.Payload = &proto.ToDataplane_ServiceRemove{ServiceRemove: msg}
+	case *proto.RoutePolicyUpdate:
+		envelope.Payload = &proto.ToDataplane_RoutePolicyUpdate{RoutePolicyUpdate: msg}
+	case *proto.RoutePolicyRemove:
+		envelope.Payload = &proto.ToDataplane_RoutePolicyRemove{RoutePolicyRemove: msg}
 
 	default:
 		return nil, fmt.Errorf("Unknown message type: %T", msg)
@@ -3701,12 +3701,15 @@ func (m *bpfEndpointManager) loadPolicyProgram(
 	}).Debug("Generating policy program...")
 
 	ipsetsMapFD := m.v4.IpsetsMap.MapFD()
+	connLimitMapFD := m.v4.ConnLimitMap.MapFD()
 	ipSetIDAlloc := m.v4.ipSetIDAlloc
 	if ipFamily == proto.IPVersion_IPV6 {
 		opts = append(opts, polprog.WithIPv6())
 		ipsetsMapFD = m.v6.IpsetsMap.MapFD()
+		connLimitMapFD = m.v6.ConnLimitMap.MapFD()
 		ipSetIDAlloc = m.v6.ipSetIDAlloc
 	}
+	opts = append(opts, polprog.WithConnLimitMap(connLimitMapFD))
 
 	pg := polprog.NewBuilder(
 		ipSetIDAlloc,
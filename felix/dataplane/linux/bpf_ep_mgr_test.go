@@ -35,6 +35,7 @@ import (
 	"github.com/projectcalico/calico/felix/bpf"
 	"github.com/projectcalico/calico/felix/bpf/asm"
 	"github.com/projectcalico/calico/felix/bpf/bpfmap"
+	"github.com/projectcalico/calico/felix/bpf/connlimit"
 	"github.com/projectcalico/calico/felix/bpf/conntrack"
 	"github.com/projectcalico/calico/felix/bpf/counters"
 	"github.com/projectcalico/calico/felix/bpf/hook"
@@ -377,9 +378,11 @@ var _ = Describe("BPF Endpoint Manager", func() {
 
 		v4Maps.IpsetsMap = bpfipsets.Map()
 		v4Maps.CtMap = conntrack.Map()
+		v4Maps.ConnLimitMap = connlimit.Map()
 
 		v6Maps.IpsetsMap = bpfipsets.MapV6()
 		v6Maps.CtMap = conntrack.MapV6()
+		v6Maps.ConnLimitMap = connlimit.MapV6()
 
 		commonMaps.StateMap = state.Map()
 		ifStateMap = mock.NewMockMap(ifstate.MapParams)
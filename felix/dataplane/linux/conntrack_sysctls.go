@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intdataplane
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	bpfconntrack "github.com/projectcalico/calico/felix/bpf/conntrack"
+)
+
+// conntrackTimeoutSysctls maps the names of the configurable conntrack timeouts to the kernel
+// sysctls that control the equivalent timeouts.
+var conntrackTimeoutSysctls = map[string][]string{
+	bpfconntrack.TimeoutNameTCPSynSent: {
+		"nf_conntrack_tcp_timeout_syn_sent",
+		"nf_conntrack_tcp_timeout_syn_recv",
+	},
+	bpfconntrack.TimeoutNameTCPEstablished: {
+		"nf_conntrack_tcp_timeout_established",
+	},
+	bpfconntrack.TimeoutNameTCPFinsSeen: {
+		"nf_conntrack_tcp_timeout_fin_wait",
+		"nf_conntrack_tcp_timeout_close_wait",
+		"nf_conntrack_tcp_timeout_last_ack",
+		"nf_conntrack_tcp_timeout_time_wait",
+	},
+	bpfconntrack.TimeoutNameTCPResetSeen: {
+		"nf_conntrack_tcp_timeout_close",
+	},
+	bpfconntrack.TimeoutNameUDP: {
+		"nf_conntrack_udp_timeout",
+		"nf_conntrack_udp_timeout_stream",
+	},
+	bpfconntrack.TimeoutNameICMP: {
+		"nf_conntrack_icmp_timeout",
+		"nf_conntrack_icmpv6_timeout",
+	},
+	bpfconntrack.TimeoutNameGeneric: {
+		"nf_conntrack_generic_timeout",
+	},
+}
+
+const (
+	conntrackSysctlDir = "/proc/sys/net/netfilter"
+
+	// conntrackSysctlStateFile records the kernel's values of the conntrack sysctls that Felix
+	// has changed.  It lives on a tmpfs so that it goes away on reboot, along with the changes.
+	conntrackSysctlStateFile = "/var/run/calico/conntrack-sysctls.json"
+)
+
+// conntrackSysctls sets the conntrack timeout sysctls.  The sysctls are global to the host and
+// outlive Felix, so before it changes a sysctl, it saves the kernel's value to a state file.  If a
+// timeout is later removed from the configuration, the saved value is restored.
+type conntrackSysctls struct {
+	sysctlDir string
+	stateFile string
+
+	// writeSysctl is a shim for writeProcSys, for testing.
+	writeSysctl func(path, value string) error
+}
+
+func newConntrackSysctls() *conntrackSysctls {
+	return &conntrackSysctls{
+		sysctlDir:   conntrackSysctlDir,
+		stateFile:   conntrackSysctlStateFile,
+		writeSysctl: writeProcSys,
+	}
+}
+
+// Apply sets the sysctls for the given timeouts and restores the sysctls of any timeouts that
+// were set previously but are no longer configured.
+func (c *conntrackSysctls) Apply(timeouts map[string]time.Duration) {
+	saved := c.loadSavedValues()
+
+	desired := map[string]string{}
+	for name, timeout := range timeouts {
+		sysctls, ok := conntrackTimeoutSysctls[name]
+		if !ok {
+			log.WithField("name", name).Warn("Ignoring unknown conntrack timeout.")
+			continue
+		}
+		secs := int64(timeout / time.Second)
+		if secs < 1 {
+			secs = 1
+		}
+		for _, sysctl := range sysctls {
+			desired[sysctl] = strconv.FormatInt(secs, 10)
+		}
+	}
+
+	// Restore the sysctls that we no longer need to set.
+	for sysctl, value := range saved {
+		if _, ok := desired[sysctl]; ok {
+			continue
+		}
+		log.WithFields(log.Fields{"sysctl": sysctl, "value": value}).Info("Restoring conntrack timeout.")
+		if err := c.writeSysctl(filepath.Join(c.sysctlDir, sysctl), value); err != nil {
+			log.WithError(err).WithField("sysctl", sysctl).Warn("Failed to restore conntrack timeout sysctl")
+			continue
+		}
+		delete(saved, sysctl)
+	}
+
+	// Save the kernel's value of each sysctl before we change it for the first time.  Save the
+	// state before we write the sysctls so that we can't lose the original values.
+	for sysctl := range desired {
+		if _, ok := saved[sysctl]; ok {
+			continue
+		}
+		value, err := os.ReadFile(filepath.Join(c.sysctlDir, sysctl))
+		if err != nil {
+			log.WithError(err).WithField("sysctl", sysctl).Warn("Failed to read conntrack timeout sysctl")
+			continue
+		}
+		saved[sysctl] = strings.TrimSpace(string(value))
+	}
+	c.storeSavedValues(saved)
+
+	sysctls := make([]string, 0, len(desired))
+	for sysctl := range desired {
+		sysctls = append(sysctls, sysctl)
+	}
+	sort.Strings(sysctls)
+	for _, sysctl := range sysctls {
+		value := desired[sysctl]
+		log.WithFields(log.Fields{"sysctl": sysctl, "seconds": value}).Info("Setting conntrack timeout.")
+		if err := c.writeSysctl(filepath.Join(c.sysctlDir, sysctl), value); err != nil {
+			log.WithError(err).WithField("sysctl", sysctl).Warn("Failed to set conntrack timeout sysctl")
+		}
+	}
+}
+
+func (c *conntrackSysctls) loadSavedValues() map[string]string {
+	saved := map[string]string{}
+	data, err := os.ReadFile(c.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return saved
+	} else if err != nil {
+		log.WithError(err).WithField("file", c.stateFile).Warn("Failed to read saved conntrack sysctls")
+		return saved
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		log.WithError(err).WithField("file", c.stateFile).Warn("Failed to parse saved conntrack sysctls")
+		return map[string]string{}
+	}
+	return saved
+}
+
+func (c *conntrackSysctls) storeSavedValues(saved map[string]string) {
+	if len(saved) == 0 {
+		if err := os.Remove(c.stateFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.WithError(err).WithField("file", c.stateFile).Warn("Failed to remove saved conntrack sysctls")
+		}
+		return
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		log.WithError(err).Panic("Failed to marshal saved conntrack sysctls")
+	}
+	if err := os.MkdirAll(filepath.Dir(c.stateFile), 0o755); err != nil {
+		log.WithError(err).WithField("file", c.stateFile).Warn("Failed to save conntrack sysctls")
+		return
+	}
+	// Write to a temporary file and rename it so that a crash can't leave a partial file.
+	tmp := c.stateFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		log.WithError(err).WithField("file", tmp).Warn("Failed to save conntrack sysctls")
+		return
+	}
+	if err := os.Rename(tmp, c.stateFile); err != nil {
+		log.WithError(err).WithField("file", c.stateFile).Warn("Failed to save conntrack sysctls")
+	}
+}
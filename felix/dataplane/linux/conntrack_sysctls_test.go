@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intdataplane
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	bpfconntrack "github.com/projectcalico/calico/felix/bpf/conntrack"
+)
+
+var _ = Describe("conntrackSysctls", func() {
+	var (
+		dir     string
+		sysctls *conntrackSysctls
+	)
+
+	readSysctl := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, "proc", name))
+		Expect(err).NotTo(HaveOccurred())
+		return strings.TrimSpace(string(data))
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "conntrack-sysctls")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Mkdir(filepath.Join(dir, "proc"), 0o755)).To(Succeed())
+		for _, names := range conntrackTimeoutSysctls {
+			for _, name := range names {
+				Expect(os.WriteFile(filepath.Join(dir, "proc", name), []byte("120\n"), 0o644)).To(Succeed())
+			}
+		}
+		sysctls = &conntrackSysctls{
+			sysctlDir: filepath.Join(dir, "proc"),
+			stateFile: filepath.Join(dir, "run", "conntrack-sysctls.json"),
+			writeSysctl: func(path, value string) error {
+				return os.WriteFile(path, []byte(value+"\n"), 0o644)
+			},
+		}
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	It("should set the configured timeouts and leave the others alone", func() {
+		sysctls.Apply(map[string]time.Duration{bpfconntrack.TimeoutNameUDP: 30 * time.Second})
+		Expect(readSysctl("nf_conntrack_udp_timeout")).To(Equal("30"))
+		Expect(readSysctl("nf_conntrack_udp_timeout_stream")).To(Equal("30"))
+		Expect(readSysctl("nf_conntrack_generic_timeout")).To(Equal("120"))
+	})
+
+	It("should restore a timeout that is no longer configured", func() {
+		sysctls.Apply(map[string]time.Duration{
+			bpfconntrack.TimeoutNameUDP:     30 * time.Second,
+			bpfconntrack.TimeoutNameGeneric: 60 * time.Second,
+		})
+		Expect(readSysctl("nf_conntrack_generic_timeout")).To(Equal("60"))
+
+		// Simulate a restart with the UDP timeout removed.
+		sysctls.Apply(map[string]time.Duration{bpfconntrack.TimeoutNameGeneric: 90 * time.Second})
+		Expect(readSysctl("nf_conntrack_udp_timeout")).To(Equal("120"))
+		Expect(readSysctl("nf_conntrack_udp_timeout_stream")).To(Equal("120"))
+		Expect(readSysctl("nf_conntrack_generic_timeout")).To(Equal("90"))
+
+		sysctls.Apply(nil)
+		Expect(readSysctl("nf_conntrack_generic_timeout")).To(Equal("120"))
+		Expect(sysctls.stateFile).NotTo(BeAnExistingFile())
+	})
+})
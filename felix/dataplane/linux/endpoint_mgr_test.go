@@ -1748,6 +1748,87 @@ func endpointManagerTests(ipVersion uint8) func() {
 					}))
 				})
 
+				Context("with a second endpoint for an additional interface in the same workload", func() {
+					wlEPID1Eth1 := proto.WorkloadEndpointID{
+						OrchestratorId: "k8s",
+						WorkloadId:     "pod-11",
+						EndpointId:     "eth1",
+					}
+
+					JustBeforeEach(func() {
+						epMgr.OnUpdate(&proto.WorkloadEndpointUpdate{
+							Id: &wlEPID1Eth1,
+							Endpoint: &proto.WorkloadEndpoint{
+								State:      "active",
+								Mac:        "01:02:03:04:05:07",
+								Name:       "cali67890-ef",
+								ProfileIds: []string{},
+								Tiers: []*proto.TierInfo{{
+									Name:            "default",
+									IngressPolicies: []string{"policy1"},
+									EgressPolicies:  []string{"policy1"},
+								}},
+								Ipv4Nets: []string{"10.0.250.2/32"},
+								Ipv6Nets: []string{"2001:db8:3::2/128"},
+							},
+						})
+						epMgr.OnUpdate(&ifaceStateUpdate{
+							Name:  "cali67890-ef",
+							State: "up",
+						})
+						epMgr.OnUpdate(&ifaceAddrsUpdate{
+							Name:  "cali67890-ef",
+							Addrs: set.New[string](),
+						})
+						applyUpdates(epMgr)
+					})
+
+					It("should have independent chains for each interface", expectWlChainsFor(ipVersion, "cali12345-ab", "cali67890-ef_policy1"))
+
+					It("should set routes for each interface", func() {
+						if ipVersion == 6 {
+							routeTable.checkRoutes("cali67890-ef", []routetable.Target{{
+								CIDR:    ip.MustParseCIDROrIP("2001:db8:3::2/128"),
+								DestMAC: testutils.MustParseMAC("01:02:03:04:05:07"),
+							}})
+							routeTable.checkRoutes("cali12345-ab", []routetable.Target{{
+								CIDR:    ip.MustParseCIDROrIP("2001:db8:2::2/128"),
+								DestMAC: testutils.MustParseMAC("01:02:03:04:05:06"),
+							}})
+						} else {
+							routeTable.checkRoutes("cali67890-ef", []routetable.Target{{
+								CIDR:    ip.MustParseCIDROrIP("10.0.250.2/32"),
+								DestMAC: testutils.MustParseMAC("01:02:03:04:05:07"),
+							}})
+							routeTable.checkRoutes("cali12345-ab", []routetable.Target{{
+								CIDR:    ip.MustParseCIDROrIP("10.0.240.0/24"),
+								DestMAC: testutils.MustParseMAC("01:02:03:04:05:06"),
+							}})
+						}
+					})
+
+					It("should report the status of each endpoint", func() {
+						Expect(statusReportRec.currentState).To(Equal(map[interface{}]string{
+							wlEPID1:     "down",
+							wlEPID1Eth1: "up",
+						}))
+					})
+
+					Context("with the additional endpoint removed", func() {
+						JustBeforeEach(func() {
+							epMgr.OnUpdate(&proto.WorkloadEndpointRemove{
+								Id: &wlEPID1Eth1,
+							})
+							applyUpdates(epMgr)
+						})
+
+						It("should leave the primary endpoint in place", expectWlChainsFor(ipVersion, "cali12345-ab"))
+						It("should remove the additional endpoint's routes", func() {
+							routeTable.checkRoutes("cali67890-ef", nil)
+						})
+					})
+				})
+
 				Context("with updates for the workload's iface and proc/sys failure", func() {
 					JustBeforeEach(func() {
 						mockProcSys.Fail = true
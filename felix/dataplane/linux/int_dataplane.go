@@ -38,6 +38,7 @@ import (
 
 	"github.com/projectcalico/calico/felix/bpf"
 	"github.com/projectcalico/calico/felix/bpf/bpfmap"
+	bpfconnlimit "github.com/projectcalico/calico/felix/bpf/connlimit"
 	"github.com/projectcalico/calico/felix/bpf/conntrack"
 	bpfconntrack "github.com/projectcalico/calico/felix/bpf/conntrack"
 	"github.com/projectcalico/calico/felix/bpf/failsafes"
@@ -50,10 +51,12 @@ import (
 	bpfroutes "github.com/projectcalico/calico/felix/bpf/routes"
 	"github.com/projectcalico/calico/felix/bpf/tc"
 	tcdefs "github.com/projectcalico/calico/felix/bpf/tc/defs"
+	"github.com/projectcalico/calico/felix/buildinfo"
 	"github.com/projectcalico/calico/felix/config"
 	"github.com/projectcalico/calico/felix/dataplane/common"
 	dpsets "github.com/projectcalico/calico/felix/dataplane/ipsets"
 	"github.com/projectcalico/calico/felix/dataplane/linux/dataplanedefs"
+	"github.com/projectcalico/calico/felix/dataplane/snapshot"
 	"github.com/projectcalico/calico/felix/environment"
 	"github.com/projectcalico/calico/felix/generictables"
 	"github.com/projectcalico/calico/felix/idalloc"
@@ -186,6 +189,22 @@ type Config struct {
 	WatchdogTimeout    time.Duration
 	RouteTableManager  *idalloc.IndexAllocator
 
+	// SnapshotFile, if non-empty, is the file used to persist the calculation graph output
+	// that was last applied to the dataplane.  See restoreSnapshot().
+	SnapshotFile string
+	// SnapshotInterval is the minimum interval between snapshot writes.
+	SnapshotInterval time.Duration
+	// SnapshotConfigHash identifies the Felix configuration; snapshots written with a different
+	// configuration are discarded.
+	SnapshotConfigHash string
+
+	// PolicyStatusReportingEnabled enables reporting of the programming status of each active
+	// policy back to Felix.
+	PolicyStatusReportingEnabled bool
+
+	RoutePolicyRouteTableRange     idalloc.IndexRange
+	RoutePolicyRoutingRulePriority int
+
 	DebugSimulateDataplaneHangAfter  time.Duration
 	DebugSimulateDataplaneApplyDelay time.Duration
 
@@ -204,6 +223,7 @@ type Config struct {
 	XDPEnabled                         bool
 	XDPAllowGeneric                    bool
 	BPFConntrackTimeouts               bpfconntrack.Timeouts
+	ConntrackTimeouts                  map[string]time.Duration
 	BPFCgroupV2                        string
 	BPFConnTimeLBEnabled               bool
 	BPFConnTimeLB                      string
@@ -308,6 +328,7 @@ type InternalDataplane struct {
 	ifaceUpdates chan any
 
 	endpointStatusCombiner *endpointStatusCombiner
+	policyStatusReporter   *policyStatusReporter
 
 	allManagers             []Manager
 	managersWithRouteTables []ManagerWithRouteTables
@@ -320,6 +341,21 @@ type InternalDataplane struct {
 	// ifaceMonitorInSync is set to true after the interface monitor reports that it is in sync.
 	// As above, we block dataplane updates until we get that message.
 	ifaceMonitorInSync bool
+	// restoredFromSnapshot is set to true if we replayed a valid dataplane snapshot at start of
+	// day.  In that case, we don't wait for the datastore to be in sync before programming the
+	// dataplane.
+	restoredFromSnapshot bool
+	// snapshotHeldUpdates holds the updates from the calculation graph that arrive while we're
+	// running from the snapshot, before the datastore is in sync.  The calculation graph's state
+	// is incomplete until then (for example, IP sets are sent before all of their members are
+	// known) so the updates are held back from the managers and passed on together at InSync.
+	snapshotHeldUpdates []interface{}
+
+	// snapshotCache, if non-nil, tracks the calculation graph output for the dataplane snapshot.
+	snapshotCache     *snapshot.Cache
+	snapshotMetadata  snapshot.Metadata
+	snapshotDirty     bool
+	lastSnapshotWrite time.Time
 
 	// dataplaneNeedsSync is set if the dataplane is dirty in some way, i.e. we need to
 	// call apply().
@@ -648,6 +684,10 @@ func NewIntDataplaneDriver(config Config) *InternalDataplane {
 	}
 
 	dp.endpointStatusCombiner = newEndpointStatusCombiner(dp.fromDataplane, config.IPv6Enabled)
+	if config.PolicyStatusReportingEnabled {
+		dp.policyStatusReporter = newPolicyStatusReporter(dp.fromDataplane)
+		dp.RegisterManager(dp.policyStatusReporter)
+	}
 
 	callbacks := common.NewCallbacks()
 	dp.callbacks = callbacks
@@ -948,6 +988,12 @@ func NewIntDataplaneDriver(config Config) *InternalDataplane {
 	dp.wireguardManager = newWireguardManager(cryptoRouteTableWireguard, config, 4)
 	dp.RegisterManager(dp.wireguardManager) // IPv4
 
+	// Without a route table range, Felix leaves policy routing alone.
+	routePolicyEnabled := !config.RouteSyncDisabled && config.RoutePolicyRouteTableRange != (idalloc.IndexRange{})
+	if routePolicyEnabled {
+		dp.RegisterManager(newRoutePolicyManager(config, dp.loopSummarizer, featureDetector, 4))
+	}
+
 	dp.RegisterManager(newServiceLoopManager(filterTableV4, ruleRenderer, 4))
 
 	if config.IPv6Enabled {
@@ -1066,6 +1112,10 @@ func NewIntDataplaneDriver(config Config) *InternalDataplane {
 			featureDetector)
 		dp.wireguardManagerV6 = newWireguardManager(cryptoRouteTableWireguardV6, config, 6)
 		dp.RegisterManager(dp.wireguardManagerV6)
+
+		if routePolicyEnabled {
+			dp.RegisterManager(newRoutePolicyManager(config, dp.loopSummarizer, featureDetector, 6))
+		}
 	}
 
 	if config.RulesConfig.NFTables {
@@ -1902,6 +1952,10 @@ func (d *InternalDataplane) loopUpdatingDataplane() {
 	healthTicks := time.NewTicker(healthInterval).C
 	d.reportHealth()
 
+	// If we have a valid snapshot from before we restarted, load it before we start
+	// processing updates.
+	d.restoreSnapshot()
+
 	// Retry any failed operations every 10s.
 	retryTicker := time.NewTicker(10 * time.Second)
 
@@ -1957,7 +2011,7 @@ func (d *InternalDataplane) loopUpdatingDataplane() {
 			log.Panic("Woke up after 1 hour, something's probably wrong with the test.")
 		}
 
-		if d.datastoreInSync && d.ifaceMonitorInSync && d.dataplaneNeedsSync {
+		if (d.datastoreInSync || d.restoredFromSnapshot) && d.ifaceMonitorInSync && d.dataplaneNeedsSync {
 			// Dataplane is out-of-sync, check if we're throttled.
 			if d.applyThrottle.Admit() {
 				if beingThrottled && d.applyThrottle.WouldAdmit() {
@@ -1981,7 +2035,7 @@ func (d *InternalDataplane) loopUpdatingDataplane() {
 				if d.dataplaneNeedsSync {
 					// Dataplane is still dirty, record an error.
 					countDataplaneSyncErrors.Inc()
-				} else {
+				} else if d.datastoreInSync {
 					d.sendDataplaneInSyncOnce.Do(func() {
 						d.fromDataplane <- &proto.DataplaneInSync{}
 					})
@@ -2007,7 +2061,230 @@ func (d *InternalDataplane) loopUpdatingDataplane() {
 				}
 			}
 		}
+
+		d.maybeWriteSnapshot()
+	}
+}
+
+// restoreSnapshot loads the dataplane snapshot, if there is one, and replays it into the managers.
+// It then checks the replayed policy against the kernel (see checkSnapshotAgainstKernel); if it
+// matches, the dataplane can take ownership of the kernel state and handle incremental updates,
+// such as interface changes, straight away, without waiting for the datastore to be in sync.
+// Since the snapshot's policy is what the kernel is already enforcing, nothing stale is
+// programmed.  The updates from the calculation graph's resync are held back until the datastore
+// is in sync; then they are passed on to the managers and any objects from the snapshot that
+// weren't resent are removed.  If the snapshot is missing, invalid or doesn't match the kernel, we
+// fall back to the normal full resync.
+func (d *InternalDataplane) restoreSnapshot() {
+	if d.config.SnapshotFile == "" {
+		return
+	}
+	if d.config.BPFEnabled || d.config.RulesConfig.NFTables {
+		// We can only check the snapshot against the kernel's iptables and IP sets.
+		log.Warn("Dataplane snapshot is only supported by the iptables dataplane, disabling it.")
+		return
+	}
+	d.snapshotCache = snapshot.NewCache()
+	bootID, err := snapshot.CurrentBootID()
+	if err != nil {
+		log.WithError(err).Warn("Failed to read kernel boot ID, disabling dataplane snapshot.")
+		d.snapshotCache = nil
+		return
+	}
+	d.snapshotMetadata = snapshot.Metadata{
+		FelixVersion: buildinfo.GitVersion,
+		BootID:       bootID,
+		Hostname:     d.config.Hostname,
+		ConfigHash:   d.config.SnapshotConfigHash,
+	}
+
+	logCxt := log.WithField("file", d.config.SnapshotFile)
+	snap, err := snapshot.Load(d.config.SnapshotFile)
+	if os.IsNotExist(err) {
+		logCxt.Info("No dataplane snapshot, waiting for full resync.")
+		return
+	} else if err != nil {
+		logCxt.WithError(err).Warn("Failed to load dataplane snapshot, waiting for full resync.")
+		return
+	}
+	interfaceExists := func(name string) bool {
+		_, err := net.InterfaceByName(name)
+		return err == nil
+	}
+	if err := snapshot.Validate(snap, d.snapshotMetadata, interfaceExists); err != nil {
+		logCxt.WithError(err).Info("Dataplane snapshot doesn't match the kernel state, waiting for full resync.")
+		return
+	}
+	cache, err := snapshot.LoadCache(snap)
+	if err != nil {
+		logCxt.WithError(err).Warn("Failed to load dataplane snapshot, waiting for full resync.")
+		return
+	}
+
+	logCxt.WithField("numObjects", cache.Len()).Info("Replaying dataplane snapshot.")
+	for _, msg := range cache.Messages() {
+		for _, mgr := range d.allManagers {
+			mgr.OnUpdate(msg)
+		}
+	}
+	cache.StartTrackingRefreshes()
+	if err := d.checkSnapshotAgainstKernel(cache); err != nil {
+		// Nothing has been written to the dataplane yet, so we can back out of the snapshot by
+		// removing everything that we replayed.
+		logCxt.WithError(err).Info("Dataplane snapshot doesn't match the kernel state, waiting for full resync.")
+		for _, removal := range cache.StaleRemovals() {
+			for _, mgr := range d.allManagers {
+				mgr.OnUpdate(removal)
+			}
+		}
+		return
+	}
+	d.snapshotCache = cache
+	d.restoredFromSnapshot = true
+	d.dataplaneNeedsSync = true
+}
+
+// chainComparer is implemented by tables that can compare our chains with the ones in the
+// dataplane, see iptables.Table.CompareChainsWithDataplane.
+type chainComparer interface {
+	CompareChainsWithDataplane(chainNames, prefixes []string) (present, mismatched, unexpected []string)
+}
+
+// policyChainPrefixes are the prefixes of the policy and profile chains.
+var policyChainPrefixes = []string{
+	string(rules.PolicyInboundPfx),
+	string(rules.PolicyOutboundPfx),
+	string(rules.ProfileInboundPfx),
+	string(rules.ProfileOutboundPfx),
+}
+
+// ipSetComparer is implemented by IP sets dataplanes that can compare our IP sets with the ones in
+// the dataplane, see ipsets.IPSets.MismatchedIPSets.
+type ipSetComparer interface {
+	MismatchedIPSets(setIDs []string) ([]string, error)
+}
+
+// checkSnapshotAgainstKernel checks that the policy in the snapshot, which has been replayed into
+// the managers, is what the kernel is already enforcing.  Each of the snapshot's IP sets must be
+// in the kernel with the same members, and each of its policies and profiles must have its chains
+// in the kernel, with the same rules.  Conversely, the kernel mustn't have policy chains or
+// workload interfaces that aren't in the snapshot, which happens if the snapshot was written
+// before the last changes that Felix made.  If the check passes, applying the snapshot doesn't
+// program any policy, the dataplane only takes ownership of what the previous Felix left behind.
+func (d *InternalDataplane) checkSnapshotAgainstKernel(cache *snapshot.Cache) error {
+	var setIDs, allChainNames []string
+	chainNames := map[string][]string{}
+	workloadIfaces := set.New[string]()
+	for _, msg := range cache.Messages() {
+		var owner string
+		var names []string
+		switch msg := msg.(type) {
+		case *proto.IPSetUpdate:
+			setIDs = append(setIDs, msg.Id)
+			continue
+		case *proto.WorkloadEndpointUpdate:
+			workloadIfaces.Add(msg.Endpoint.GetName())
+			continue
+		case *proto.ActivePolicyUpdate:
+			owner = fmt.Sprintf("policy %s/%s", msg.Id.Tier, msg.Id.Name)
+			names = []string{
+				rules.PolicyChainName(rules.PolicyInboundPfx, msg.Id),
+				rules.PolicyChainName(rules.PolicyOutboundPfx, msg.Id),
+			}
+		case *proto.ActiveProfileUpdate:
+			owner = fmt.Sprintf("profile %s", msg.Id.Name)
+			names = []string{
+				rules.ProfileChainName(rules.ProfileInboundPfx, msg.Id),
+				rules.ProfileChainName(rules.ProfileOutboundPfx, msg.Id),
+			}
+		default:
+			continue
+		}
+		chainNames[owner] = names
+		allChainNames = append(allChainNames, names...)
+	}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return fmt.Errorf("failed to list interfaces: %w", err)
+	}
+	for _, iface := range ifaces {
+		if workloadIfaces.Contains(iface.Name) {
+			continue
+		}
+		for _, prefix := range d.config.RulesConfig.WorkloadIfacePrefixes {
+			if strings.HasPrefix(iface.Name, prefix) {
+				return fmt.Errorf("workload interface %q isn't in the snapshot", iface.Name)
+			}
+		}
+	}
+
+	for _, ipSets := range d.ipSets {
+		comparer, ok := ipSets.(ipSetComparer)
+		if !ok {
+			return fmt.Errorf("can't compare %T with the kernel", ipSets)
+		}
+		mismatched, err := comparer.MismatchedIPSets(setIDs)
+		if err != nil {
+			return fmt.Errorf("failed to read IP sets from the kernel: %w", err)
+		}
+		if len(mismatched) > 0 {
+			return fmt.Errorf("IP sets %v don't match the kernel", mismatched)
+		}
 	}
+
+	// Policy chains are only programmed into the tables where they're used, so each policy
+	// only needs to be present in one of them, but it must match wherever it's present.
+	presentChains := set.New[string]()
+	for _, table := range d.allTables {
+		comparer, ok := table.(chainComparer)
+		if !ok {
+			return fmt.Errorf("can't compare %T with the kernel", table)
+		}
+		present, mismatched, unexpected := comparer.CompareChainsWithDataplane(allChainNames, policyChainPrefixes)
+		if len(mismatched) > 0 {
+			return fmt.Errorf("chains %v in the %s table don't match the kernel", mismatched, table.Name())
+		}
+		if len(unexpected) > 0 {
+			return fmt.Errorf("chains %v in the %s table aren't in the snapshot", unexpected, table.Name())
+		}
+		presentChains.AddAll(present)
+	}
+	for owner, names := range chainNames {
+		found := false
+		for _, name := range names {
+			if presentChains.Contains(name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("chains of %s are missing from the kernel", owner)
+		}
+	}
+	return nil
+}
+
+// maybeWriteSnapshot writes the dataplane snapshot if the dataplane is in sync and the snapshot
+// is out of date, rate limited to once per SnapshotInterval.
+func (d *InternalDataplane) maybeWriteSnapshot() {
+	if d.snapshotCache == nil || !d.snapshotDirty || !d.datastoreInSync || d.dataplaneNeedsSync {
+		return
+	}
+	if time.Since(d.lastSnapshotWrite) < d.config.SnapshotInterval {
+		return
+	}
+	start := time.Now()
+	d.lastSnapshotWrite = start
+	if err := snapshot.Save(d.config.SnapshotFile, d.snapshotMetadata, d.snapshotCache); err != nil {
+		log.WithError(err).Warn("Failed to write dataplane snapshot.")
+		return
+	}
+	d.snapshotDirty = false
+	log.WithFields(log.Fields{
+		"numObjects": d.snapshotCache.Len(),
+		"duration":   time.Since(start),
+	}).Debug("Wrote dataplane snapshot.")
 }
 
 func newRefreshTicker(name string, interval time.Duration) <-chan time.Time {
@@ -2037,16 +2314,48 @@ func (d *InternalDataplane) onDatastoreMessage(msg interface{}) {
 func (d *InternalDataplane) processMsgFromCalcGraph(msg interface{}) {
 	log.WithField("msg", proto.MsgStringer{Msg: msg}).Infof("Received %T update from calculation graph", msg)
 	d.datastoreBatchSize++
-	d.dataplaneNeedsSync = true
 	d.recordMsgStat(msg)
+	if d.snapshotCache != nil {
+		d.snapshotCache.OnUpdate(msg)
+		d.snapshotDirty = true
+	}
+	_, inSync := msg.(*proto.InSync)
+	if d.restoredFromSnapshot && !d.datastoreInSync {
+		if !inSync {
+			// Keep the dataplane on the snapshot until the calculation graph has finished its
+			// resync.
+			d.snapshotHeldUpdates = append(d.snapshotHeldUpdates, msg)
+			return
+		}
+		log.WithField("numUpdates", len(d.snapshotHeldUpdates)).Info(
+			"Datastore in sync, passing on the updates that were held back while running from the dataplane snapshot.")
+		for _, held := range d.snapshotHeldUpdates {
+			for _, mgr := range d.allManagers {
+				mgr.OnUpdate(held)
+			}
+		}
+		d.snapshotHeldUpdates = nil
+	}
+	d.dataplaneNeedsSync = true
 	for _, mgr := range d.allManagers {
 		mgr.OnUpdate(msg)
 	}
-	switch msg.(type) {
-	case *proto.InSync:
+	if inSync {
 		log.WithField("timeSinceStart", time.Since(processStartTime)).Info(
 			"Datastore in sync, flushing the dataplane for the first time...")
 		d.datastoreInSync = true
+		if d.restoredFromSnapshot {
+			// Anything from the snapshot that the calculation graph didn't resend was deleted
+			// while we were down.
+			removals := d.snapshotCache.StaleRemovals()
+			log.WithField("numRemovals", len(removals)).Info(
+				"Removing objects from dataplane snapshot that are no longer present.")
+			for _, removal := range removals {
+				for _, mgr := range d.allManagers {
+					mgr.OnUpdate(removal)
+				}
+			}
+		}
 	}
 }
 
@@ -2171,6 +2480,7 @@ func (d *InternalDataplane) configureKernel() {
 			log.WithError(err).Error("Failed to set unprivileged_bpf_disabled sysctl")
 		}
 	}
+	d.configureConntrackTimeouts()
 	if d.config.Wireguard.Enabled || d.config.Wireguard.EnabledV6 {
 		// wireguard module is available in linux kernel >= 5.6
 		mpwg := newModProbe(moduleWireguard, newRealCmd)
@@ -2179,6 +2489,13 @@ func (d *InternalDataplane) configureKernel() {
 	}
 }
 
+// configureConntrackTimeouts programs the kernel's conntrack timeout sysctls to match the
+// configured conntrack timeouts.  Timeouts that aren't configured are left at, or restored to,
+// the kernel's values.
+func (d *InternalDataplane) configureConntrackTimeouts() {
+	newConntrackSysctls().Apply(d.config.ConntrackTimeouts)
+}
+
 func (d *InternalDataplane) recordMsgStat(msg interface{}) {
 	typeName := reflect.ValueOf(msg).Elem().Type().Name()
 	countMessages.WithLabelValues(typeName).Inc()
@@ -2383,6 +2700,9 @@ func (d *InternalDataplane) apply() {
 
 	// And publish and status updates.
 	d.endpointStatusCombiner.Apply()
+	if d.policyStatusReporter != nil {
+		d.policyStatusReporter.Apply(!d.dataplaneNeedsSync)
+	}
 
 	// Set up any needed rescheduling kick.
 	if d.reschedC != nil {
@@ -2536,7 +2856,8 @@ func startBPFDataplaneComponents(
 	dp.RegisterManager(bpfRTMgr)
 
 	conntrackScanner := bpfconntrack.NewScanner(bpfmaps.CtMap, ctKey, ctVal,
-		bpfconntrack.NewLivenessScanner(config.BPFConntrackTimeouts, config.BPFNodePortDSREnabled))
+		bpfconntrack.NewLivenessScanner(config.BPFConntrackTimeouts, config.BPFNodePortDSREnabled),
+		bpfconnlimit.NewScanner(bpfmaps.ConnLimitMap, int(ipFamily)))
 
 	// Before we start, scan for all finished / timed out connections to
 	// free up the conntrack table asap as it may take time to sync up the
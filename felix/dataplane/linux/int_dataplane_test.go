@@ -85,6 +85,9 @@ var _ = Describe("Constructor test", func() {
 			IPIPMTU:          configParams.IpInIpMtu,
 			HealthAggregator: healthAggregator,
 
+			RoutePolicyRouteTableRange:     configParams.RoutePolicyRouteTableIndices(),
+			RoutePolicyRoutingRulePriority: configParams.RoutePolicyRoutingRulePriority,
+
 			MTUIfacePattern: regexp.MustCompile(".*"),
 
 			LookPathOverride: func(file string) (string, error) {
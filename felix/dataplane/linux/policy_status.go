@@ -0,0 +1,118 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intdataplane
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// policyStatusReporter tracks the active policies and reports their programming status.  A policy is
+// reported as pending as soon as it becomes active, or is updated, so that the node is counted as
+// one that the policy applies to before the policy is programmed.  It is reported as programmed once
+// an apply() that follows its most recent update succeeds; if the apply() fails, the policy is
+// reported as in error, along with the number of consecutive failures.
+//
+// It is registered as a Manager so that it sees the same stream of updates as the managers that
+// program the policies, but it has no dataplane state of its own.
+type policyStatusReporter struct {
+	// pending maps the ID of each policy that has been updated since it was last reported as
+	// programmed to its content hash.
+	pending map[proto.PolicyID]string
+	// errors counts the consecutive failed applies for each pending policy.
+	errors map[proto.PolicyID]int
+	// reported maps the ID of each policy that we've reported as programmed to its content hash, or
+	// to "" if we've only reported it as pending or in error.
+	reported map[proto.PolicyID]string
+	// removed contains the policies that have been removed since the last apply().
+	removed map[proto.PolicyID]bool
+
+	fromDataplane chan<- interface{}
+}
+
+func newPolicyStatusReporter(fromDataplane chan<- interface{}) *policyStatusReporter {
+	return &policyStatusReporter{
+		pending:       map[proto.PolicyID]string{},
+		errors:        map[proto.PolicyID]int{},
+		reported:      map[proto.PolicyID]string{},
+		removed:       map[proto.PolicyID]bool{},
+		fromDataplane: fromDataplane,
+	}
+}
+
+func (r *policyStatusReporter) OnUpdate(msg interface{}) {
+	switch msg := msg.(type) {
+	case *proto.ActivePolicyUpdate:
+		id := *msg.Id
+		delete(r.removed, id)
+		hash := msg.Policy.GetContentHash()
+		if pendingHash, ok := r.pending[id]; ok && pendingHash == hash {
+			// Already reported this version of the policy as pending.
+			return
+		} else if !ok && r.reported[id] == hash {
+			// Already reported this version of the policy as programmed.
+			return
+		}
+		r.pending[id] = hash
+		delete(r.errors, id)
+		r.reported[id] = ""
+		log.WithField("id", id).Debug("Reporting policy status pending.")
+		r.fromDataplane <- &proto.PolicyStatusUpdate{
+			Id:     &id,
+			Status: &proto.PolicyStatus{Status: model.PolicyStatusPending, ContentHash: hash},
+		}
+	case *proto.ActivePolicyRemove:
+		id := *msg.Id
+		delete(r.pending, id)
+		delete(r.errors, id)
+		if _, ok := r.reported[id]; ok {
+			r.removed[id] = true
+		}
+	}
+}
+
+func (r *policyStatusReporter) CompleteDeferredWork() error {
+	return nil
+}
+
+// Apply reports the status of the policies that have changed since the last call.  succeeded
+// indicates whether the dataplane apply() that has just completed was successful.
+func (r *policyStatusReporter) Apply(succeeded bool) {
+	for id := range r.removed {
+		id := id
+		log.WithField("id", id).Debug("Reporting policy status removed.")
+		r.fromDataplane <- &proto.PolicyStatusRemove{Id: &id}
+		delete(r.reported, id)
+	}
+	r.removed = map[proto.PolicyID]bool{}
+
+	for id, hash := range r.pending {
+		id := id
+		status := &proto.PolicyStatus{Status: model.PolicyStatusProgrammed, ContentHash: hash}
+		if succeeded {
+			r.reported[id] = hash
+			delete(r.pending, id)
+			delete(r.errors, id)
+		} else {
+			r.errors[id]++
+			status.Status = model.PolicyStatusError
+			status.Errors = int32(r.errors[id])
+		}
+		log.WithFields(log.Fields{"id": id, "status": status}).Debug("Reporting policy status.")
+		r.fromDataplane <- &proto.PolicyStatusUpdate{Id: &id, Status: status}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intdataplane
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+var _ = Describe("Policy status reporter", func() {
+	var (
+		reporter      *policyStatusReporter
+		fromDataplane chan interface{}
+		policyID      = proto.PolicyID{Tier: "default", Name: "default.policy"}
+	)
+
+	BeforeEach(func() {
+		fromDataplane = make(chan interface{}, 10)
+		reporter = newPolicyStatusReporter(fromDataplane)
+	})
+
+	updatePolicy := func(hash string) {
+		id := policyID
+		reporter.OnUpdate(&proto.ActivePolicyUpdate{Id: &id, Policy: &proto.Policy{ContentHash: hash}})
+	}
+	removePolicy := func() {
+		id := policyID
+		reporter.OnUpdate(&proto.ActivePolicyRemove{Id: &id})
+	}
+	statusUpdate := func(status, hash string, errors int32) *proto.PolicyStatusUpdate {
+		id := policyID
+		return &proto.PolicyStatusUpdate{
+			Id:     &id,
+			Status: &proto.PolicyStatus{Status: status, ContentHash: hash, Errors: errors},
+		}
+	}
+
+	It("should report a policy as pending until a successful apply", func() {
+		updatePolicy("hash1")
+		Expect(fromDataplane).To(Receive(Equal(statusUpdate(model.PolicyStatusPending, "hash1", 0))))
+		reporter.Apply(true)
+		Expect(fromDataplane).To(Receive(Equal(statusUpdate(model.PolicyStatusProgrammed, "hash1", 0))))
+		Expect(fromDataplane).NotTo(Receive())
+	})
+
+	It("should report a new version of a policy as pending", func() {
+		updatePolicy("hash1")
+		reporter.Apply(true)
+		Expect(fromDataplane).To(Receive())
+		Expect(fromDataplane).To(Receive())
+		updatePolicy("hash2")
+		Expect(fromDataplane).To(Receive(Equal(statusUpdate(model.PolicyStatusPending, "hash2", 0))))
+		reporter.Apply(true)
+		Expect(fromDataplane).To(Receive(Equal(statusUpdate(model.PolicyStatusProgrammed, "hash2", 0))))
+	})
+
+	It("should not report the same version of a policy twice", func() {
+		updatePolicy("hash1")
+		updatePolicy("hash1")
+		reporter.Apply(true)
+		Expect(fromDataplane).To(Receive())
+		Expect(fromDataplane).To(Receive())
+		updatePolicy("hash1")
+		reporter.Apply(true)
+		Expect(fromDataplane).NotTo(Receive())
+	})
+
+	It("should count consecutive failures", func() {
+		updatePolicy("hash1")
+		Expect(fromDataplane).To(Receive(Equal(statusUpdate(model.PolicyStatusPending, "hash1", 0))))
+		reporter.Apply(false)
+		Expect(fromDataplane).To(Receive(Equal(statusUpdate(model.PolicyStatusError, "hash1", 1))))
+		reporter.Apply(false)
+		Expect(fromDataplane).To(Receive(Equal(statusUpdate(model.PolicyStatusError, "hash1", 2))))
+		reporter.Apply(true)
+		Expect(fromDataplane).To(Receive(Equal(statusUpdate(model.PolicyStatusProgrammed, "hash1", 0))))
+		Expect(fromDataplane).NotTo(Receive())
+	})
+
+	It("should report the removal of a reported policy", func() {
+		updatePolicy("hash1")
+		reporter.Apply(true)
+		Expect(fromDataplane).To(Receive())
+		Expect(fromDataplane).To(Receive())
+		removePolicy()
+		reporter.Apply(true)
+		id := policyID
+		Expect(fromDataplane).To(Receive(Equal(&proto.PolicyStatusRemove{Id: &id})))
+		Expect(fromDataplane).NotTo(Receive())
+	})
+
+	It("should report the removal of a policy that was only reported as pending", func() {
+		updatePolicy("hash1")
+		Expect(fromDataplane).To(Receive())
+		removePolicy()
+		reporter.Apply(true)
+		id := policyID
+		Expect(fromDataplane).To(Receive(Equal(&proto.PolicyStatusRemove{Id: &id})))
+		Expect(fromDataplane).NotTo(Receive())
+	})
+})
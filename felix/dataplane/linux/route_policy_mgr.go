@@ -0,0 +1,422 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intdataplane
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+
+	"github.com/projectcalico/calico/felix/environment"
+	"github.com/projectcalico/calico/felix/idalloc"
+	"github.com/projectcalico/calico/felix/ip"
+	"github.com/projectcalico/calico/felix/logutils"
+	"github.com/projectcalico/calico/felix/netlinkshim"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/felix/routerule"
+	"github.com/projectcalico/calico/felix/routetable"
+	"github.com/projectcalico/calico/felix/routetable/ownershippol"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+var errNoRouteToNextHop = errors.New("no route to next hop")
+
+// routePolicyManager programs the routing rules and routes for RoutePolicy resources.  For each
+// RoutePolicy that applies to local workloads, it programs:
+//   - A routing rule per workload address that sends traffic from that address to the policy's
+//     route table.
+//   - A route in that table for each of the policy's destinations, via the policy's next hop
+//     and/or interface.
+//
+// The manager is only created if a RoutePolicy table range is configured.  The range is on by
+// default, so the manager doesn't assume that every table in it is free: it claims a table when a
+// policy first uses it, and only if the table isn't already in use by other software (see
+// tableInUse).  Once claimed, Felix owns the routes in the table and the routing rules that point at
+// it until it restarts, so that it can clean up after the last policy that uses the table goes away.
+// Policies that reference a table outside the range, or a table that is in use, are ignored.  One
+// manager is created per IP version; it ignores addresses and destinations of the other IP version.
+type routePolicyManager struct {
+	ipVersion    uint8
+	tableRange   idalloc.IndexRange
+	rulePriority int
+
+	policies map[string]*proto.RoutePolicyUpdate
+	dirty    bool
+
+	// routeRules and routeTables hold the rule manager and route table for each claimed table.
+	routeRules    map[int]routeRules
+	activeRules   []*routerule.Rule
+	routeTables   map[int]routetable.Interface
+	activeIfaces  map[int]set.Set[string]
+	newRouteRules func(tableIndex int) routeRules
+	newRouteTable func(tableIndex int) routetable.Interface
+
+	// tableInUse returns true if the given table has routes or routing rules that weren't
+	// programmed by Felix.  Used to check a table before claiming it.
+	tableInUse func(tableIndex int) (bool, error)
+
+	// resolveNextHopIface returns the name of the interface that the kernel would use to reach the
+	// given next hop.  Used for policies that specify a next hop but no interface.
+	resolveNextHopIface func(nextHop net.IP) (string, error)
+
+	logCtx *log.Entry
+}
+
+func newRoutePolicyManager(
+	dpConfig Config,
+	opRecorder logutils.OpRecorder,
+	featureDetector environment.FeatureDetectorIface,
+	ipVersion uint8,
+) *routePolicyManager {
+	newRouteRules := func(tableIndex int) routeRules {
+		rr, err := routerule.New(
+			int(ipVersion),
+			set.From(tableIndex),
+			routerule.RulesMatchSrcFWMarkTable,
+			routerule.RulesMatchSrcFWMarkTable,
+			dpConfig.NetlinkTimeout,
+			func() (routerule.HandleIface, error) {
+				return netlinkshim.NewRealNetlink()
+			},
+			opRecorder,
+		)
+		if err != nil {
+			log.WithError(err).WithField("table", tableIndex).Panic("Unexpected error creating RoutePolicy rule manager")
+		}
+		return rr
+	}
+
+	newRouteTable := func(tableIndex int) routetable.Interface {
+		return routetable.New(
+			&ownershippol.ExclusiveOwnershipPolicy{},
+			ipVersion,
+			dpConfig.NetlinkTimeout,
+			nil,
+			dpConfig.DeviceRouteProtocol,
+			true,
+			tableIndex,
+			opRecorder,
+			featureDetector,
+		)
+	}
+
+	tableInUse := func(tableIndex int) (bool, error) {
+		return routeTableInUse(ipVersion, tableIndex, dpConfig.RoutePolicyRoutingRulePriority)
+	}
+
+	return newRoutePolicyManagerWithShims(dpConfig, newRouteRules, newRouteTable, tableInUse, resolveNextHopIface, ipVersion)
+}
+
+func newRoutePolicyManagerWithShims(
+	dpConfig Config,
+	newRouteRules func(tableIndex int) routeRules,
+	newRouteTable func(tableIndex int) routetable.Interface,
+	tableInUse func(tableIndex int) (bool, error),
+	resolveNextHopIface func(nextHop net.IP) (string, error),
+	ipVersion uint8,
+) *routePolicyManager {
+	return &routePolicyManager{
+		ipVersion:           ipVersion,
+		tableRange:          dpConfig.RoutePolicyRouteTableRange,
+		rulePriority:        dpConfig.RoutePolicyRoutingRulePriority,
+		policies:            map[string]*proto.RoutePolicyUpdate{},
+		dirty:               true,
+		routeRules:          map[int]routeRules{},
+		routeTables:         map[int]routetable.Interface{},
+		activeIfaces:        map[int]set.Set[string]{},
+		newRouteRules:       newRouteRules,
+		newRouteTable:       newRouteTable,
+		tableInUse:          tableInUse,
+		resolveNextHopIface: resolveNextHopIface,
+		logCtx:              log.WithField("ipVersion", ipVersion),
+	}
+}
+
+func (m *routePolicyManager) OnUpdate(protoBufMsg interface{}) {
+	switch msg := protoBufMsg.(type) {
+	case *proto.RoutePolicyUpdate:
+		m.logCtx.WithField("msg", msg).Debug("RoutePolicy update")
+		m.policies[msg.Name] = msg
+		m.dirty = true
+	case *proto.RoutePolicyRemove:
+		m.logCtx.WithField("msg", msg).Debug("RoutePolicy remove")
+		delete(m.policies, msg.Name)
+		m.dirty = true
+	case *ifaceStateUpdate, *ifaceAddrsUpdate:
+		// Interface changes may change the interface that a next hop resolves to.
+		if m.needsNextHopResolution() {
+			m.dirty = true
+		}
+	}
+}
+
+func (m *routePolicyManager) needsNextHopResolution() bool {
+	for _, p := range m.policies {
+		if p.Interface == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *routePolicyManager) CompleteDeferredWork() error {
+	if !m.dirty {
+		return nil
+	}
+
+	var lastErr error
+	var rules []*routerule.Rule
+	routesByTable := map[int]map[string][]routetable.Target{}
+
+	// Process policies in name order so that conflicts are resolved consistently.
+	names := make([]string, 0, len(m.policies))
+	for name := range m.policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		policy := m.policies[name]
+		logCtx := m.logCtx.WithFields(log.Fields{"policy": name, "table": policy.Table})
+
+		table := int(policy.Table)
+		if table < m.tableRange.Min || table > m.tableRange.Max {
+			logCtx.WithField("range", m.tableRange).Warn(
+				"RoutePolicy table is outside of the RoutePolicyRouteTableRange, ignoring policy")
+			continue
+		}
+
+		var gw ip.Addr
+		if policy.NextHop != "" {
+			gw = ip.FromString(policy.NextHop)
+			if gw == nil {
+				logCtx.WithField("nextHop", policy.NextHop).Warn("Failed to parse RoutePolicy next hop, ignoring policy")
+				continue
+			}
+			if gw.Version() != m.ipVersion {
+				// Policy applies to the other IP version only.
+				continue
+			}
+		}
+
+		ifaceName := policy.Interface
+		if ifaceName == "" {
+			var err error
+			ifaceName, err = m.resolveNextHopIface(gw.AsNetIP())
+			if err != nil {
+				logCtx.WithError(err).WithField("nextHop", policy.NextHop).Warn(
+					"Failed to resolve interface for RoutePolicy next hop, will retry")
+				lastErr = err
+				continue
+			}
+		}
+
+		var targets []routetable.Target
+		for _, dest := range policy.Destinations {
+			cidr, err := ip.CIDRFromString(dest)
+			if err != nil {
+				logCtx.WithError(err).WithField("destination", dest).Warn("Failed to parse RoutePolicy destination, skipping")
+				continue
+			}
+			if cidr.Version() != m.ipVersion {
+				continue
+			}
+			target := routetable.Target{
+				Type: routetable.TargetTypeLinkLocalUnicast,
+				CIDR: cidr,
+			}
+			if gw != nil {
+				target.Type = routetable.TargetTypeGlobalUnicast
+				target.GW = gw
+			}
+			targets = append(targets, target)
+		}
+		if len(targets) == 0 {
+			continue
+		}
+		// Only claim the table once we know that the policy applies to this IP version.
+		if claimed, err := m.claimTable(table); err != nil {
+			logCtx.WithError(err).Warn("Failed to check whether RoutePolicy table is in use, will retry")
+			lastErr = err
+			continue
+		} else if !claimed {
+			logCtx.Warn("RoutePolicy table has routes or routing rules that weren't programmed by Calico, " +
+				"ignoring policy.  Use a different table, or remove the routes and rules from the table.")
+			continue
+		}
+
+		if routesByTable[table] == nil {
+			routesByTable[table] = map[string][]routetable.Target{}
+		}
+		routesByTable[table][ifaceName] = append(routesByTable[table][ifaceName], targets...)
+
+		for _, src := range policy.SrcAddrs {
+			cidr, err := ip.CIDRFromString(src)
+			if err != nil {
+				logCtx.WithError(err).WithField("src", src).Warn("Failed to parse RoutePolicy source address, skipping")
+				continue
+			}
+			if cidr.Version() != m.ipVersion {
+				continue
+			}
+			rules = append(rules, routerule.NewRule(int(m.ipVersion), m.rulePriority).
+				MatchSrcAddress(cidr.ToIPNet()).
+				GoToTable(table))
+		}
+	}
+
+	m.updateRoutes(routesByTable)
+	m.updateRules(rules)
+
+	if lastErr != nil {
+		// Leave the manager dirty so that we retry resolving the next hop.
+		return fmt.Errorf("failed to program all RoutePolicies: %w", lastErr)
+	}
+	m.dirty = false
+	return nil
+}
+
+// claimTable returns true if the given table is owned by this manager, claiming it if it isn't in
+// use.  Route tables and rule managers are created when a table is claimed and kept thereafter so
+// that we clean up their routes and rules when the last policy that used them goes away.
+func (m *routePolicyManager) claimTable(table int) (bool, error) {
+	if _, ok := m.routeTables[table]; ok {
+		return true, nil
+	}
+	inUse, err := m.tableInUse(table)
+	if err != nil || inUse {
+		return false, err
+	}
+	m.logCtx.WithField("table", table).Info("Claiming route table for RoutePolicy")
+	m.routeTables[table] = m.newRouteTable(table)
+	m.routeRules[table] = m.newRouteRules(table)
+	m.activeIfaces[table] = set.New[string]()
+	return true, nil
+}
+
+func (m *routePolicyManager) updateRoutes(routesByTable map[int]map[string][]routetable.Target) {
+	for table, routesByIface := range routesByTable {
+		rt := m.routeTables[table]
+		for ifaceName, targets := range routesByIface {
+			rt.SetRoutes(routetable.RouteClassRoutePolicy, ifaceName, targets)
+		}
+	}
+
+	for table, ifaces := range m.activeIfaces {
+		desired := routesByTable[table]
+		ifaces.Iter(func(ifaceName string) error {
+			if _, ok := desired[ifaceName]; !ok {
+				m.routeTables[table].SetRoutes(routetable.RouteClassRoutePolicy, ifaceName, nil)
+				return set.RemoveItem
+			}
+			return nil
+		})
+		for ifaceName := range desired {
+			ifaces.Add(ifaceName)
+		}
+	}
+}
+
+func (m *routePolicyManager) updateRules(rules []*routerule.Rule) {
+	for _, old := range m.activeRules {
+		stillActive := false
+		for _, r := range rules {
+			if routerule.RulesMatchSrcFWMarkTable(old, r) {
+				stillActive = true
+				break
+			}
+		}
+		if !stillActive {
+			m.routeRules[old.NetLinkRule().Table].RemoveRule(old)
+		}
+	}
+	for _, r := range rules {
+		m.routeRules[r.NetLinkRule().Table].SetRule(r)
+	}
+	m.activeRules = rules
+}
+
+func (m *routePolicyManager) GetRouteTableSyncers() []routetable.SyncerInterface {
+	tables := make([]routetable.SyncerInterface, 0, len(m.routeTables))
+	for _, rt := range m.routeTables {
+		tables = append(tables, rt)
+	}
+	return tables
+}
+
+func (m *routePolicyManager) GetRouteRules() []routeRules {
+	rules := make([]routeRules, 0, len(m.routeRules))
+	for _, rr := range m.routeRules {
+		rules = append(rules, rr)
+	}
+	return rules
+}
+
+// routeTableInUse checks whether a table in the RoutePolicy range is used by other software.  The
+// range is on by default, so it may overlap tables that the administrator or another agent
+// configured.  A table is in use if a routing rule at a priority other than ours points at it, or
+// if it has routes but none of our rules point at it.  (If a previous run of Felix left routes and
+// rules behind, we reclaim the table and clean them up.)  We can't use the route protocol to
+// recognise our own routes because the default DeviceRouteProtocol is the same as that of routes
+// added by "ip route add".
+func routeTableInUse(ipVersion uint8, table, rulePriority int) (bool, error) {
+	family := netlink.FAMILY_V4
+	if ipVersion == 6 {
+		family = netlink.FAMILY_V6
+	}
+	nlRules, err := netlink.RuleList(family)
+	if err != nil {
+		return false, fmt.Errorf("failed to list routing rules: %w", err)
+	}
+	haveOurRule := false
+	for _, r := range nlRules {
+		if r.Table != table {
+			continue
+		}
+		if r.Priority != rulePriority {
+			return true, nil
+		}
+		haveOurRule = true
+	}
+	if haveOurRule {
+		return false, nil
+	}
+	routes, err := netlink.RouteListFiltered(family, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return false, fmt.Errorf("failed to list routes in table %d: %w", table, err)
+	}
+	return len(routes) > 0, nil
+}
+
+// resolveNextHopIface uses the kernel's routing table to find the interface used to reach the
+// given next hop.
+func resolveNextHopIface(nextHop net.IP) (string, error) {
+	routes, err := netlink.RouteGet(nextHop)
+	if err != nil {
+		return "", err
+	}
+	if len(routes) == 0 || routes[0].LinkIndex == 0 {
+		return "", errNoRouteToNextHop
+	}
+	link, err := netlink.LinkByIndex(routes[0].LinkIndex)
+	if err != nil {
+		return "", err
+	}
+	return link.Attrs().Name, nil
+}
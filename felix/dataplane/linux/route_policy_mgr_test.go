@@ -0,0 +1,410 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intdataplane
+
+import (
+	"errors"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/config"
+	"github.com/projectcalico/calico/felix/idalloc"
+	"github.com/projectcalico/calico/felix/ifacemonitor"
+	"github.com/projectcalico/calico/felix/ip"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/felix/routerule"
+	"github.com/projectcalico/calico/felix/routetable"
+)
+
+type mockRouteRules struct {
+	rules []*routerule.Rule
+}
+
+func (r *mockRouteRules) SetRule(rule *routerule.Rule) {
+	for _, existing := range r.rules {
+		if routerule.RulesMatchSrcFWMarkTable(existing, rule) {
+			return
+		}
+	}
+	r.rules = append(r.rules, rule)
+}
+
+func (r *mockRouteRules) RemoveRule(rule *routerule.Rule) {
+	for i, existing := range r.rules {
+		if routerule.RulesMatchSrcFWMarkTable(existing, rule) {
+			r.rules = append(r.rules[:i], r.rules[i+1:]...)
+			return
+		}
+	}
+}
+
+func (r *mockRouteRules) QueueResync() {}
+
+func (r *mockRouteRules) Apply() error {
+	return nil
+}
+
+// mockRouteRulesByTable holds the mock rule manager for each table that the manager claims.
+type mockRouteRulesByTable map[int]*mockRouteRules
+
+func (t mockRouteRulesByTable) allRules() []*routerule.Rule {
+	var rules []*routerule.Rule
+	for _, r := range t {
+		rules = append(rules, r.rules...)
+	}
+	return rules
+}
+
+func (t mockRouteRulesByTable) srcToTable() map[string]int {
+	m := map[string]int{}
+	for table, r := range t {
+		for _, rule := range r.rules {
+			nlRule := rule.NetLinkRule()
+			Expect(nlRule.Table).To(Equal(table), "Rule programmed in another table's rule manager")
+			m[nlRule.Src.String()] = nlRule.Table
+		}
+	}
+	return m
+}
+
+var _ = Describe("RoutePolicyManager", func() {
+	var (
+		manager     *routePolicyManager
+		rules       mockRouteRulesByTable
+		tables      map[int]*mockRouteTable
+		tablesInUse map[int]bool
+		inUseErr    error
+		nextHopIfcs map[string]string
+		tableRange  idalloc.IndexRange
+	)
+
+	newManager := func(ipVersion uint8) *routePolicyManager {
+		return newRoutePolicyManagerWithShims(
+			Config{
+				RoutePolicyRouteTableRange:     tableRange,
+				RoutePolicyRoutingRulePriority: 100,
+			},
+			func(tableIndex int) routeRules {
+				rr := &mockRouteRules{}
+				rules[tableIndex] = rr
+				return rr
+			},
+			func(tableIndex int) routetable.Interface {
+				rt := &mockRouteTable{index: tableIndex, currentRoutes: map[string][]routetable.Target{}}
+				tables[tableIndex] = rt
+				return rt
+			},
+			func(tableIndex int) (bool, error) {
+				return tablesInUse[tableIndex], inUseErr
+			},
+			func(nextHop net.IP) (string, error) {
+				if iface, ok := nextHopIfcs[nextHop.String()]; ok {
+					return iface, nil
+				}
+				return "", errors.New("no route")
+			},
+			ipVersion,
+		)
+	}
+
+	BeforeEach(func() {
+		rules = mockRouteRulesByTable{}
+		tables = map[int]*mockRouteTable{}
+		tablesInUse = map[int]bool{}
+		inUseErr = nil
+		nextHopIfcs = map[string]string{"10.0.0.5": "eth0", "fd00::5": "eth0"}
+		tableRange = idalloc.IndexRange{Min: 200, Max: 249}
+		manager = newManager(4)
+	})
+
+	Describe("with the default configuration", func() {
+		BeforeEach(func() {
+			tableRange = config.New().RoutePolicyRouteTableIndices()
+			manager = newManager(4)
+		})
+
+		It("should program policies that use a table in the default range", func() {
+			Expect(tableRange).To(Equal(idalloc.IndexRange{Min: 1000, Max: 1249}))
+			manager.OnUpdate(&proto.RoutePolicyUpdate{
+				Name:         "rp1",
+				SrcAddrs:     []string{"10.65.0.1/32"},
+				Destinations: []string{"0.0.0.0/0"},
+				NextHop:      "10.0.0.5",
+				Table:        1000,
+			})
+			Expect(manager.CompleteDeferredWork()).To(Succeed())
+
+			Expect(rules.srcToTable()).To(Equal(map[string]int{"10.65.0.1/32": 1000}))
+			Expect(tables).To(HaveKey(1000))
+		})
+	})
+
+	It("should program rules and routes via the next hop", func() {
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.1/32", "10.65.0.2/32", "fd00::1/128"},
+			Destinations: []string{"0.0.0.0/0", "dead:beef::/64"},
+			NextHop:      "10.0.0.5",
+			Table:        200,
+		})
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+
+		Expect(rules.srcToTable()).To(Equal(map[string]int{
+			"10.65.0.1/32": 200,
+			"10.65.0.2/32": 200,
+		}))
+		Expect(rules.allRules()[0].NetLinkRule().Priority).To(Equal(100))
+		Expect(tables).To(HaveKey(200))
+		tables[200].checkRoutes("eth0", []routetable.Target{{
+			Type: routetable.TargetTypeGlobalUnicast,
+			CIDR: ip.MustParseCIDROrIP("0.0.0.0/0"),
+			GW:   ip.FromString("10.0.0.5"),
+		}})
+		Expect(manager.GetRouteTableSyncers()).To(HaveLen(1))
+	})
+
+	It("should only create the route tables that policies use", func() {
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+		Expect(tables).To(BeEmpty())
+		Expect(manager.GetRouteTableSyncers()).To(BeEmpty())
+
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.1/32"},
+			Destinations: []string{"0.0.0.0/0"},
+			NextHop:      "10.0.0.5",
+			Table:        210,
+		})
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+		Expect(tables).To(HaveLen(1))
+		Expect(tables).To(HaveKey(210))
+	})
+
+	It("should not claim a table that is used by other software", func() {
+		tablesInUse[220] = true
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.1/32"},
+			Destinations: []string{"0.0.0.0/0"},
+			NextHop:      "10.0.0.5",
+			Table:        220,
+		})
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp2",
+			SrcAddrs:     []string{"10.65.0.2/32"},
+			Destinations: []string{"0.0.0.0/0"},
+			NextHop:      "10.0.0.5",
+			Table:        221,
+		})
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+
+		// Only the free table should be claimed; nothing should touch the other table's rules or
+		// routes.
+		Expect(rules).NotTo(HaveKey(220))
+		Expect(tables).NotTo(HaveKey(220))
+		Expect(rules.srcToTable()).To(Equal(map[string]int{"10.65.0.2/32": 221}))
+		Expect(manager.GetRouteRules()).To(HaveLen(1))
+		Expect(manager.GetRouteTableSyncers()).To(HaveLen(1))
+
+		// Once the table is freed up, a later update should claim it.
+		tablesInUse[220] = false
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.1/32", "10.65.0.3/32"},
+			Destinations: []string{"0.0.0.0/0"},
+			NextHop:      "10.0.0.5",
+			Table:        220,
+		})
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+		Expect(rules.srcToTable()).To(Equal(map[string]int{
+			"10.65.0.1/32": 220,
+			"10.65.0.3/32": 220,
+			"10.65.0.2/32": 221,
+		}))
+	})
+
+	It("should retry if it can't check whether a table is in use", func() {
+		inUseErr = errors.New("netlink failure")
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.1/32"},
+			Destinations: []string{"0.0.0.0/0"},
+			NextHop:      "10.0.0.5",
+			Table:        200,
+		})
+		Expect(manager.CompleteDeferredWork()).NotTo(Succeed())
+		Expect(tables).To(BeEmpty())
+
+		inUseErr = nil
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+		Expect(rules.srcToTable()).To(Equal(map[string]int{"10.65.0.1/32": 200}))
+	})
+
+	It("should keep a claimed table without checking it again", func() {
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.1/32"},
+			Destinations: []string{"0.0.0.0/0"},
+			NextHop:      "10.0.0.5",
+			Table:        200,
+		})
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+
+		// Our own routes and rules now make the table look in use.
+		tablesInUse[200] = true
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.2/32"},
+			Destinations: []string{"0.0.0.0/0"},
+			NextHop:      "10.0.0.5",
+			Table:        200,
+		})
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+		Expect(rules.srcToTable()).To(Equal(map[string]int{"10.65.0.2/32": 200}))
+	})
+
+	It("should program interface routes when no next hop is given", func() {
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.1/32"},
+			Destinations: []string{"192.168.0.0/16"},
+			Interface:    "eth1",
+			Table:        201,
+		})
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+
+		Expect(rules.srcToTable()).To(Equal(map[string]int{"10.65.0.1/32": 201}))
+		tables[201].checkRoutes("eth1", []routetable.Target{{
+			Type: routetable.TargetTypeLinkLocalUnicast,
+			CIDR: ip.MustParseCIDROrIP("192.168.0.0/16"),
+		}})
+	})
+
+	It("should ignore policies with a table outside the range", func() {
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.1/32"},
+			Destinations: []string{"0.0.0.0/0"},
+			NextHop:      "10.0.0.5",
+			Table:        10,
+		})
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+		Expect(rules.allRules()).To(BeEmpty())
+		Expect(tables).To(BeEmpty())
+	})
+
+	It("should ignore policies for the other IP version", func() {
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"fd00::1/128"},
+			Destinations: []string{"::/0"},
+			NextHop:      "fd00::5",
+			Table:        200,
+		})
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+		Expect(rules.allRules()).To(BeEmpty())
+		Expect(tables).To(BeEmpty())
+	})
+
+	It("should program IPv6 policies in the IPv6 manager", func() {
+		manager = newManager(6)
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.1/32", "fd00::1/128"},
+			Destinations: []string{"::/0"},
+			NextHop:      "fd00::5",
+			Table:        200,
+		})
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+		Expect(rules.srcToTable()).To(Equal(map[string]int{"fd00::1/128": 200}))
+		tables[200].checkRoutes("eth0", []routetable.Target{{
+			Type: routetable.TargetTypeGlobalUnicast,
+			CIDR: ip.MustParseCIDROrIP("::/0"),
+			GW:   ip.FromString("fd00::5"),
+		}})
+	})
+
+	It("should retry when the next hop can't be resolved", func() {
+		manager.OnUpdate(&proto.RoutePolicyUpdate{
+			Name:         "rp1",
+			SrcAddrs:     []string{"10.65.0.1/32"},
+			Destinations: []string{"0.0.0.0/0"},
+			NextHop:      "10.0.0.6",
+			Table:        200,
+		})
+		Expect(manager.CompleteDeferredWork()).NotTo(Succeed())
+		Expect(rules.allRules()).To(BeEmpty())
+
+		nextHopIfcs["10.0.0.6"] = "eth2"
+		manager.OnUpdate(&ifaceStateUpdate{Name: "eth2", State: ifacemonitor.StateUp, Index: 5})
+		Expect(manager.CompleteDeferredWork()).To(Succeed())
+		Expect(rules.srcToTable()).To(Equal(map[string]int{"10.65.0.1/32": 200}))
+		tables[200].checkRoutes("eth2", []routetable.Target{{
+			Type: routetable.TargetTypeGlobalUnicast,
+			CIDR: ip.MustParseCIDROrIP("0.0.0.0/0"),
+			GW:   ip.FromString("10.0.0.6"),
+		}})
+	})
+
+	Context("with a programmed policy", func() {
+		BeforeEach(func() {
+			manager.OnUpdate(&proto.RoutePolicyUpdate{
+				Name:         "rp1",
+				SrcAddrs:     []string{"10.65.0.1/32", "10.65.0.2/32"},
+				Destinations: []string{"0.0.0.0/0"},
+				NextHop:      "10.0.0.5",
+				Table:        200,
+			})
+			Expect(manager.CompleteDeferredWork()).To(Succeed())
+		})
+
+		It("should remove rules for addresses that are no longer selected", func() {
+			manager.OnUpdate(&proto.RoutePolicyUpdate{
+				Name:         "rp1",
+				SrcAddrs:     []string{"10.65.0.2/32"},
+				Destinations: []string{"0.0.0.0/0"},
+				NextHop:      "10.0.0.5",
+				Table:        200,
+			})
+			Expect(manager.CompleteDeferredWork()).To(Succeed())
+			Expect(rules.srcToTable()).To(Equal(map[string]int{"10.65.0.2/32": 200}))
+		})
+
+		It("should move routes when the interface changes", func() {
+			manager.OnUpdate(&proto.RoutePolicyUpdate{
+				Name:         "rp1",
+				SrcAddrs:     []string{"10.65.0.1/32", "10.65.0.2/32"},
+				Destinations: []string{"0.0.0.0/0"},
+				Interface:    "eth1",
+				Table:        200,
+			})
+			Expect(manager.CompleteDeferredWork()).To(Succeed())
+			tables[200].checkRoutes("eth0", nil)
+			tables[200].checkRoutes("eth1", []routetable.Target{{
+				Type: routetable.TargetTypeLinkLocalUnicast,
+				CIDR: ip.MustParseCIDROrIP("0.0.0.0/0"),
+			}})
+		})
+
+		It("should remove rules and routes when the policy is removed", func() {
+			manager.OnUpdate(&proto.RoutePolicyRemove{Name: "rp1"})
+			Expect(manager.CompleteDeferredWork()).To(Succeed())
+			Expect(rules.allRules()).To(BeEmpty())
+			tables[200].checkRoutes("eth0", nil)
+		})
+	})
+})
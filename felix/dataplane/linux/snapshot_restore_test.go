@@ -0,0 +1,214 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intdataplane
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/buildinfo"
+	dpsets "github.com/projectcalico/calico/felix/dataplane/ipsets"
+	"github.com/projectcalico/calico/felix/dataplane/snapshot"
+	"github.com/projectcalico/calico/felix/generictables"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/felix/rules"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+// recordingManager records the updates that are passed to it.
+type recordingManager struct {
+	updates []interface{}
+}
+
+func (m *recordingManager) OnUpdate(msg interface{}) {
+	m.updates = append(m.updates, msg)
+}
+
+func (m *recordingManager) CompleteDeferredWork() error {
+	return nil
+}
+
+// comparingIPSets fakes the comparison of IP sets with the kernel.
+type comparingIPSets struct {
+	dpsets.IPSetsDataplane
+	mismatched []string
+}
+
+func (s *comparingIPSets) MismatchedIPSets(setIDs []string) ([]string, error) {
+	return s.mismatched, nil
+}
+
+// comparingTable fakes the comparison of chains with the kernel.
+type comparingTable struct {
+	*generictables.NoopTable
+	kernelChains set.Set[string]
+	unexpected   []string
+}
+
+func (t *comparingTable) CompareChainsWithDataplane(chainNames, prefixes []string) (present, mismatched, unexpected []string) {
+	for _, name := range chainNames {
+		if t.kernelChains.Contains(name) {
+			present = append(present, name)
+		}
+	}
+	return present, nil, t.unexpected
+}
+
+var _ = Describe("Dataplane snapshot restore", func() {
+	var (
+		dir    string
+		path   string
+		mgr    *recordingManager
+		dp     *InternalDataplane
+		ipSets *comparingIPSets
+		table  *comparingTable
+	)
+
+	ipSet := &proto.IPSetUpdate{Id: "s1", Type: proto.IPSetUpdate_IP, Members: []string{"10.0.0.1", "10.0.0.2"}}
+	wepID := &proto.WorkloadEndpointID{OrchestratorId: "k8s", WorkloadId: "ns/pod1", EndpointId: "eth0"}
+	wep := &proto.WorkloadEndpointUpdate{Id: wepID, Endpoint: &proto.WorkloadEndpoint{Name: "lo"}}
+	staleWEPID := &proto.WorkloadEndpointID{OrchestratorId: "k8s", WorkloadId: "ns/pod2", EndpointId: "eth0"}
+	staleWEP := &proto.WorkloadEndpointUpdate{Id: staleWEPID, Endpoint: &proto.WorkloadEndpoint{Name: "lo"}}
+	polID := &proto.PolicyID{Tier: "default", Name: "pol1"}
+	pol := &proto.ActivePolicyUpdate{Id: polID, Policy: &proto.Policy{}}
+
+	newDataplane := func(configHash string) {
+		mgr = &recordingManager{}
+		dp = &InternalDataplane{
+			config: Config{
+				SnapshotFile:       path,
+				Hostname:           "host-a",
+				SnapshotConfigHash: configHash,
+			},
+			allManagers: []Manager{mgr},
+			ipSets:      []dpsets.IPSetsDataplane{ipSets},
+			allTables:   []generictables.Table{table},
+		}
+		dp.restoreSnapshot()
+	}
+
+	BeforeEach(func() {
+		bootID, err := snapshot.CurrentBootID()
+		if err != nil {
+			Skip("kernel boot ID not available")
+		}
+		dir, err = os.MkdirTemp("", "felix-snapshot")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, "snapshot")
+
+		c := snapshot.NewCache()
+		c.OnUpdate(ipSet)
+		c.OnUpdate(pol)
+		c.OnUpdate(wep)
+		c.OnUpdate(staleWEP)
+		Expect(snapshot.Save(path, snapshot.Metadata{
+			FelixVersion: buildinfo.GitVersion,
+			BootID:       bootID,
+			Hostname:     "host-a",
+			ConfigHash:   "config-1",
+		}, c)).To(Succeed())
+
+		// By default, the kernel matches the snapshot.
+		ipSets = &comparingIPSets{}
+		table = &comparingTable{kernelChains: set.From(rules.PolicyChainName(rules.PolicyInboundPfx, polID))}
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	It("should hold back the calculation graph's resync until it is in sync", func() {
+		newDataplane("config-1")
+		Expect(dp.restoredFromSnapshot).To(BeTrue())
+		Expect(dp.dataplaneNeedsSync).To(BeTrue())
+		Expect(mgr.updates).To(Equal([]interface{}{ipSet, pol, wep, staleWEP}))
+		mgr.updates = nil
+
+		// Part way through the resync, the IP set only has some of its members.  That mustn't
+		// reach the managers or the next apply() would remove the other members.
+		partialIPSet := &proto.IPSetUpdate{Id: "s1", Type: proto.IPSetUpdate_IP, Members: []string{"10.0.0.1"}}
+		delta := &proto.IPSetDeltaUpdate{Id: "s1", AddedMembers: []string{"10.0.0.2"}}
+		dp.processMsgFromCalcGraph(partialIPSet)
+		dp.processMsgFromCalcGraph(delta)
+		dp.processMsgFromCalcGraph(pol)
+		dp.processMsgFromCalcGraph(wep)
+		Expect(mgr.updates).To(BeEmpty())
+		Expect(dp.datastoreInSync).To(BeFalse())
+
+		// At InSync, the resync is passed on, followed by the removal of the endpoint that
+		// wasn't resent.
+		dp.processMsgFromCalcGraph(&proto.InSync{})
+		Expect(dp.datastoreInSync).To(BeTrue())
+		Expect(mgr.updates).To(Equal([]interface{}{
+			partialIPSet,
+			delta,
+			pol,
+			wep,
+			&proto.InSync{},
+			&proto.WorkloadEndpointRemove{Id: staleWEPID},
+		}))
+		mgr.updates = nil
+
+		// After that, updates go straight through.
+		dp.processMsgFromCalcGraph(wep)
+		Expect(mgr.updates).To(Equal([]interface{}{wep}))
+	})
+
+	expectFallBackToResync := func() {
+		Expect(dp.restoredFromSnapshot).To(BeFalse())
+		// The replay is backed out before anything is applied.
+		Expect(mgr.updates).To(Equal([]interface{}{
+			ipSet, pol, wep, staleWEP,
+			&proto.WorkloadEndpointRemove{Id: staleWEPID},
+			&proto.WorkloadEndpointRemove{Id: wepID},
+			&proto.ActivePolicyRemove{Id: polID},
+			&proto.IPSetRemove{Id: "s1"},
+		}))
+		mgr.updates = nil
+
+		dp.processMsgFromCalcGraph(wep)
+		Expect(mgr.updates).To(Equal([]interface{}{wep}))
+	}
+
+	It("should fall back to a full resync if the IP sets don't match the kernel", func() {
+		ipSets.mismatched = []string{"s1"}
+		newDataplane("config-1")
+		expectFallBackToResync()
+	})
+
+	It("should fall back to a full resync if a policy is missing from the kernel", func() {
+		table.kernelChains = set.New[string]()
+		newDataplane("config-1")
+		expectFallBackToResync()
+	})
+
+	It("should fall back to a full resync if the kernel has policy that isn't in the snapshot", func() {
+		table.unexpected = []string{"cali-pi-newer-policy"}
+		newDataplane("config-1")
+		expectFallBackToResync()
+	})
+
+	It("should not restore a snapshot written with a different configuration", func() {
+		newDataplane("config-2")
+		Expect(dp.restoredFromSnapshot).To(BeFalse())
+		Expect(mgr.updates).To(BeEmpty())
+
+		dp.processMsgFromCalcGraph(wep)
+		Expect(mgr.updates).To(Equal([]interface{}{wep}))
+	})
+})
@@ -1474,12 +1474,15 @@ func isValidRuleForXDP(rule *proto.Rule) bool {
 		// have no icmp stuff
 		rule.Icmp == nil &&
 		rule.NotIcmp == nil &&
+		// have no conntrack-based matches; XDP runs before conntrack
+		rule.ConnLimit == 0 &&
 		// have no destination stuff
 		len(rule.DstNet) == 0 &&
 		len(rule.DstPorts) == 0 &&
 		len(rule.DstNamedPortIpSetIds) == 0 &&
 		len(rule.DstIpSetIds) == 0 &&
 		len(rule.DstIpPortSetIds) == 0 &&
+		len(rule.SrcIpDstPortIpSetIds) == 0 &&
 		len(rule.NotDstNet) == 0 &&
 		len(rule.NotDstPorts) == 0 &&
 		len(rule.NotDstIpSetIds) == 0 &&
@@ -121,6 +121,8 @@ var knownProtoRuleFields = set.From(
 	"HttpMatch",
 	"Metadata",
 	"DstIpPortSetIds",
+	"ConnLimit",
+	"SrcIpDstPortIpSetIds",
 )
 
 func testAllProtoRuleFieldsAreKnown() {
@@ -1532,6 +1534,14 @@ var _ = Describe("XDP state", func() {
 							name: "notIcmpDefined",
 							rule: modifiedRule("NotIcmp", &proto.Rule_NotIcmpType{}),
 						},
+						{
+							name: "connLimitDefined",
+							rule: modifiedRule("ConnLimit", int32(10)),
+						},
+						{
+							name: "srcIPDstPortIPSetIdsDefined",
+							rule: modifiedRule("SrcIpDstPortIpSetIds", []string{"ipset2"}),
+						},
 						{
 							name: "srcNetDefined",
 							rule: modifiedRule("SrcNet", []string{"net"}),
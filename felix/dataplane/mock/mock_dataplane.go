@@ -42,6 +42,7 @@ type MockDataplane struct {
 	activeWireguardEndpoints       map[string]proto.WireguardEndpointUpdate
 	activeWireguardV6Endpoints     map[string]proto.WireguardEndpointV6Update
 	activeHostMetadataV4V6         map[string]proto.HostMetadataV4V6Update
+	activeRoutePolicies            map[string]*proto.RoutePolicyUpdate
 	activeRoutes                   set.Set[proto.RouteUpdate]
 	endpointToPolicyOrder          map[string][]TierInfo
 	endpointToUntrackedPolicyOrder map[string][]TierInfo
@@ -260,6 +261,17 @@ func (d *MockDataplane) Config() map[string]string {
 	return localCopy
 }
 
+func (d *MockDataplane) ActiveRoutePolicies() map[string]*proto.RoutePolicyUpdate {
+	d.Lock()
+	defer d.Unlock()
+
+	cp := map[string]*proto.RoutePolicyUpdate{}
+	for k, v := range d.activeRoutePolicies {
+		cp[k] = v
+	}
+
+	return cp
+}
 func NewMockDataplane() *MockDataplane {
 	s := &MockDataplane{
 		ipSets:                         make(map[string]set.Set[string]),
@@ -272,6 +284,7 @@ func NewMockDataplane() *MockDataplane {
 		activeWireguardEndpoints:       make(map[string]proto.WireguardEndpointUpdate),
 		activeWireguardV6Endpoints:     make(map[string]proto.WireguardEndpointV6Update),
 		activeHostMetadataV4V6:         make(map[string]proto.HostMetadataV4V6Update),
+		activeRoutePolicies:            make(map[string]*proto.RoutePolicyUpdate),
 		endpointToPolicyOrder:          make(map[string][]TierInfo),
 		endpointToUntrackedPolicyOrder: make(map[string][]TierInfo),
 		endpointToPreDNATPolicyOrder:   make(map[string][]TierInfo),
@@ -500,6 +513,11 @@ func (d *MockDataplane) OnEvent(event interface{}) {
 	case *proto.HostMetadataV4V6Remove:
 		Expect(d.activeHostMetadataV4V6).To(HaveKey(event.Hostname), "delete for unknown HostmetadataV4V6")
 		delete(d.activeHostMetadataV4V6, event.Hostname)
+	case *proto.RoutePolicyUpdate:
+		d.activeRoutePolicies[event.Name] = event
+	case *proto.RoutePolicyRemove:
+		Expect(d.activeRoutePolicies).To(HaveKey(event.Name), "delete for unknown RoutePolicy")
+		delete(d.activeRoutePolicies, event.Name)
 	}
 }
 
@@ -0,0 +1,334 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snapshot records the output of the calculation graph so that the dataplane driver can
+// persist it across restarts.  On restart, a validated snapshot is replayed into the dataplane so
+// that it can start applying incremental updates without waiting for the calculation graph to
+// resync.
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+// kind identifies a type of calculation graph state.  The order of the constants is the order in
+// which the state is replayed; it mirrors the calculation graph, which sends IP sets before the
+// policies that use them and policies before the endpoints that use them.
+type kind int
+
+const (
+	kindHostMetadata kind = iota
+	kindHostMetadataV6
+	kindHostMetadataV4V6
+	kindIPAMPool
+	kindEncapsulation
+	kindGlobalBGPConfig
+	kindVTEP
+	kindWireguardEndpoint
+	kindWireguardEndpointV6
+	kindIPSet
+	kindProfile
+	kindPolicy
+	kindServiceAccount
+	kindNamespace
+	kindService
+	kindHostEndpoint
+	kindWorkloadEndpoint
+	kindRoute
+	kindRoutePolicy
+)
+
+type key struct {
+	kind kind
+	id   string
+}
+
+// Cache tracks the latest state sent by the calculation graph, keyed by object.  It keeps only
+// the most recent update for each object and folds IP set deltas into the IP set's membership.
+//
+// Cache is not thread safe.
+type Cache struct {
+	updates      map[key]interface{}
+	ipSetMembers map[string]set.Set[string]
+
+	// refreshed, if non-nil, records the keys that have been updated or removed since the
+	// cache was loaded from a snapshot.
+	refreshed set.Set[key]
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		updates:      map[key]interface{}{},
+		ipSetMembers: map[string]set.Set[string]{},
+	}
+}
+
+// OnUpdate records a message from the calculation graph.  Messages that don't carry
+// per-object state, such as InSync and ConfigUpdate, are ignored.
+func (c *Cache) OnUpdate(msg interface{}) {
+	k, isUpdate, ok := describe(msg)
+	if !ok {
+		return
+	}
+	if c.refreshed != nil {
+		c.refreshed.Add(k)
+	}
+
+	switch msg := msg.(type) {
+	case *proto.IPSetUpdate:
+		// Store the members separately so that deltas can be applied without modifying the
+		// message, which is shared with the dataplane managers.
+		members := set.New[string]()
+		members.AddAll(msg.Members)
+		c.ipSetMembers[msg.Id] = members
+		c.updates[k] = &proto.IPSetUpdate{Id: msg.Id, Type: msg.Type}
+		return
+	case *proto.IPSetDeltaUpdate:
+		members, ok := c.ipSetMembers[msg.Id]
+		if !ok {
+			log.WithField("id", msg.Id).Warn("IP set delta for unknown IP set, ignoring.")
+			return
+		}
+		members.AddAll(msg.AddedMembers)
+		for _, m := range msg.RemovedMembers {
+			members.Discard(m)
+		}
+		return
+	case *proto.IPSetRemove:
+		delete(c.ipSetMembers, msg.Id)
+	}
+
+	if isUpdate {
+		c.updates[k] = msg
+	} else {
+		delete(c.updates, k)
+	}
+}
+
+// Len returns the number of objects in the cache.
+func (c *Cache) Len() int {
+	return len(c.updates)
+}
+
+// Messages returns a sequence of updates that recreates the cached state, in the order that
+// the calculation graph would send them.
+func (c *Cache) Messages() []interface{} {
+	keys := c.sortedKeys()
+	msgs := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		msgs = append(msgs, c.message(k))
+	}
+	return msgs
+}
+
+// StartTrackingRefreshes starts recording which objects are updated or removed.  It is called
+// after the cache has been loaded from a snapshot so that StaleRemovals can find the objects
+// that the calculation graph didn't resend.
+func (c *Cache) StartTrackingRefreshes() {
+	c.refreshed = set.New[key]()
+}
+
+// StaleRemovals returns removal messages for any objects that haven't been refreshed since
+// StartTrackingRefreshes was called and removes them from the cache.  It should be called once
+// the calculation graph is in sync; at that point, any object that wasn't resent must have been
+// deleted while Felix was down.  Removals are returned in the reverse of the replay order so that,
+// for example, endpoints are removed before the policies that they use.
+func (c *Cache) StaleRemovals() []interface{} {
+	if c.refreshed == nil {
+		return nil
+	}
+	keys := c.sortedKeys()
+	var removals []interface{}
+	for i := len(keys) - 1; i >= 0; i-- {
+		k := keys[i]
+		if c.refreshed.Contains(k) {
+			continue
+		}
+		removal := removalFor(c.updates[k])
+		if removal == nil {
+			// Singletons, such as the encapsulation config, are always resent.
+			continue
+		}
+		removals = append(removals, removal)
+		c.OnUpdate(removal)
+	}
+	c.refreshed = nil
+	return removals
+}
+
+func (c *Cache) sortedKeys() []key {
+	keys := make([]key, 0, len(c.updates))
+	for k := range c.updates {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+		return keys[i].id < keys[j].id
+	})
+	return keys
+}
+
+func (c *Cache) message(k key) interface{} {
+	msg := c.updates[k]
+	if u, ok := msg.(*proto.IPSetUpdate); ok {
+		members := c.ipSetMembers[u.Id].Slice()
+		sort.Strings(members)
+		return &proto.IPSetUpdate{Id: u.Id, Type: u.Type, Members: members}
+	}
+	return msg
+}
+
+// describe returns the cache key for the given message and whether it is an update (as opposed to
+// a removal).  ok is false for messages that aren't cached.
+func describe(msg interface{}) (k key, isUpdate bool, ok bool) {
+	switch msg := msg.(type) {
+	case *proto.HostMetadataUpdate:
+		return key{kindHostMetadata, msg.Hostname}, true, true
+	case *proto.HostMetadataRemove:
+		return key{kindHostMetadata, msg.Hostname}, false, true
+	case *proto.HostMetadataV6Update:
+		return key{kindHostMetadataV6, msg.Hostname}, true, true
+	case *proto.HostMetadataV6Remove:
+		return key{kindHostMetadataV6, msg.Hostname}, false, true
+	case *proto.HostMetadataV4V6Update:
+		return key{kindHostMetadataV4V6, msg.Hostname}, true, true
+	case *proto.HostMetadataV4V6Remove:
+		return key{kindHostMetadataV4V6, msg.Hostname}, false, true
+	case *proto.IPAMPoolUpdate:
+		return key{kindIPAMPool, msg.Id}, true, true
+	case *proto.IPAMPoolRemove:
+		return key{kindIPAMPool, msg.Id}, false, true
+	case *proto.Encapsulation:
+		return key{kindEncapsulation, ""}, true, true
+	case *proto.GlobalBGPConfigUpdate:
+		return key{kindGlobalBGPConfig, ""}, true, true
+	case *proto.VXLANTunnelEndpointUpdate:
+		return key{kindVTEP, msg.Node}, true, true
+	case *proto.VXLANTunnelEndpointRemove:
+		return key{kindVTEP, msg.Node}, false, true
+	case *proto.WireguardEndpointUpdate:
+		return key{kindWireguardEndpoint, msg.Hostname}, true, true
+	case *proto.WireguardEndpointRemove:
+		return key{kindWireguardEndpoint, msg.Hostname}, false, true
+	case *proto.WireguardEndpointV6Update:
+		return key{kindWireguardEndpointV6, msg.Hostname}, true, true
+	case *proto.WireguardEndpointV6Remove:
+		return key{kindWireguardEndpointV6, msg.Hostname}, false, true
+	case *proto.IPSetUpdate:
+		return key{kindIPSet, msg.Id}, true, true
+	case *proto.IPSetDeltaUpdate:
+		return key{kindIPSet, msg.Id}, true, true
+	case *proto.IPSetRemove:
+		return key{kindIPSet, msg.Id}, false, true
+	case *proto.ActiveProfileUpdate:
+		return key{kindProfile, msg.Id.GetName()}, true, true
+	case *proto.ActiveProfileRemove:
+		return key{kindProfile, msg.Id.GetName()}, false, true
+	case *proto.ActivePolicyUpdate:
+		return key{kindPolicy, policyKey(msg.Id)}, true, true
+	case *proto.ActivePolicyRemove:
+		return key{kindPolicy, policyKey(msg.Id)}, false, true
+	case *proto.ServiceAccountUpdate:
+		return key{kindServiceAccount, serviceAccountKey(msg.Id)}, true, true
+	case *proto.ServiceAccountRemove:
+		return key{kindServiceAccount, serviceAccountKey(msg.Id)}, false, true
+	case *proto.NamespaceUpdate:
+		return key{kindNamespace, msg.Id.GetName()}, true, true
+	case *proto.NamespaceRemove:
+		return key{kindNamespace, msg.Id.GetName()}, false, true
+	case *proto.ServiceUpdate:
+		return key{kindService, msg.Namespace + "/" + msg.Name}, true, true
+	case *proto.ServiceRemove:
+		return key{kindService, msg.Namespace + "/" + msg.Name}, false, true
+	case *proto.HostEndpointUpdate:
+		return key{kindHostEndpoint, msg.Id.GetEndpointId()}, true, true
+	case *proto.HostEndpointRemove:
+		return key{kindHostEndpoint, msg.Id.GetEndpointId()}, false, true
+	case *proto.WorkloadEndpointUpdate:
+		return key{kindWorkloadEndpoint, workloadEndpointKey(msg.Id)}, true, true
+	case *proto.WorkloadEndpointRemove:
+		return key{kindWorkloadEndpoint, workloadEndpointKey(msg.Id)}, false, true
+	case *proto.RouteUpdate:
+		return key{kindRoute, msg.Dst}, true, true
+	case *proto.RouteRemove:
+		return key{kindRoute, msg.Dst}, false, true
+	case *proto.RoutePolicyUpdate:
+		return key{kindRoutePolicy, msg.Name}, true, true
+	case *proto.RoutePolicyRemove:
+		return key{kindRoutePolicy, msg.Name}, false, true
+	}
+	return key{}, false, false
+}
+
+// removalFor returns the message that removes the object created by the given update, or nil if
+// the object can't be removed.
+func removalFor(update interface{}) interface{} {
+	switch u := update.(type) {
+	case *proto.HostMetadataUpdate:
+		return &proto.HostMetadataRemove{Hostname: u.Hostname, Ipv4Addr: u.Ipv4Addr}
+	case *proto.HostMetadataV6Update:
+		return &proto.HostMetadataV6Remove{Hostname: u.Hostname, Ipv6Addr: u.Ipv6Addr}
+	case *proto.HostMetadataV4V6Update:
+		return &proto.HostMetadataV4V6Remove{Hostname: u.Hostname, Ipv4Addr: u.Ipv4Addr}
+	case *proto.IPAMPoolUpdate:
+		return &proto.IPAMPoolRemove{Id: u.Id}
+	case *proto.VXLANTunnelEndpointUpdate:
+		return &proto.VXLANTunnelEndpointRemove{Node: u.Node}
+	case *proto.WireguardEndpointUpdate:
+		return &proto.WireguardEndpointRemove{Hostname: u.Hostname}
+	case *proto.WireguardEndpointV6Update:
+		return &proto.WireguardEndpointV6Remove{Hostname: u.Hostname}
+	case *proto.IPSetUpdate:
+		return &proto.IPSetRemove{Id: u.Id}
+	case *proto.ActiveProfileUpdate:
+		return &proto.ActiveProfileRemove{Id: u.Id}
+	case *proto.ActivePolicyUpdate:
+		return &proto.ActivePolicyRemove{Id: u.Id}
+	case *proto.ServiceAccountUpdate:
+		return &proto.ServiceAccountRemove{Id: u.Id}
+	case *proto.NamespaceUpdate:
+		return &proto.NamespaceRemove{Id: u.Id}
+	case *proto.ServiceUpdate:
+		return &proto.ServiceRemove{Name: u.Name, Namespace: u.Namespace}
+	case *proto.HostEndpointUpdate:
+		return &proto.HostEndpointRemove{Id: u.Id}
+	case *proto.WorkloadEndpointUpdate:
+		return &proto.WorkloadEndpointRemove{Id: u.Id}
+	case *proto.RouteUpdate:
+		return &proto.RouteRemove{Dst: u.Dst}
+	case *proto.RoutePolicyUpdate:
+		return &proto.RoutePolicyRemove{Name: u.Name}
+	}
+	return nil
+}
+
+func policyKey(id *proto.PolicyID) string {
+	return fmt.Sprintf("%s/%s", id.GetTier(), id.GetName())
+}
+
+func serviceAccountKey(id *proto.ServiceAccountID) string {
+	return fmt.Sprintf("%s/%s", id.GetNamespace(), id.GetName())
+}
+
+func workloadEndpointKey(id *proto.WorkloadEndpointID) string {
+	return fmt.Sprintf("%s/%s/%s", id.GetOrchestratorId(), id.GetWorkloadId(), id.GetEndpointId())
+}
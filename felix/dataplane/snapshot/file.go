@@ -0,0 +1,204 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	pb "github.com/gogo/protobuf/proto"
+
+	extdataplane "github.com/projectcalico/calico/felix/dataplane/external"
+	"github.com/projectcalico/calico/felix/proto"
+)
+
+// FormatVersion is the version of the snapshot format.  It should be incremented if the meaning of
+// the snapshotted messages changes in a way that older snapshots can't be replayed.
+const FormatVersion = 1
+
+const bootIDFile = "/proc/sys/kernel/random/boot_id"
+
+// Metadata identifies the Felix and the kernel that a snapshot applies to.
+type Metadata struct {
+	FelixVersion string
+	BootID       string
+	Hostname     string
+	// ConfigHash is the hash of the Felix configuration, see ConfigHash.  Felix restarts when its
+	// configuration changes, and the snapshot may not be valid for the new configuration.
+	ConfigHash string
+}
+
+// ConfigHash returns a hash of the given raw Felix configuration.
+func ConfigHash(rawConfig map[string]string) string {
+	names := make([]string, 0, len(rawConfig))
+	for name := range rawConfig {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s=%s\n", name, rawConfig[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// CurrentBootID returns the kernel's boot ID, which changes on every reboot.
+func CurrentBootID() (string, error) {
+	b, err := os.ReadFile(bootIDFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// Save atomically writes the contents of the cache to the given file.
+func Save(path string, meta Metadata, c *Cache) error {
+	snap := &proto.DataplaneSnapshot{
+		Version:      FormatVersion,
+		FelixVersion: meta.FelixVersion,
+		BootId:       meta.BootID,
+		Hostname:     meta.Hostname,
+		ConfigHash:   meta.ConfigHash,
+	}
+	for _, msg := range c.Messages() {
+		envelope, err := extdataplane.WrapPayloadWithEnvelope(msg, 0)
+		if err != nil {
+			return err
+		}
+		snap.Messages = append(snap.Messages, envelope)
+	}
+	data, err := pb.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dataplane snapshot: %w", err)
+	}
+
+	// Write to a temporary file and then rename it so that we never leave a partial snapshot
+	// behind if we're killed mid-write.
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads a snapshot from the given file.  It returns an error satisfying os.IsNotExist if
+// there is no snapshot.
+func Load(path string) (*proto.DataplaneSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	snap := &proto.DataplaneSnapshot{}
+	if err := pb.Unmarshal(data, snap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dataplane snapshot: %w", err)
+	}
+	return snap, nil
+}
+
+// Validate checks that the snapshot was written by the same version of Felix, with the same
+// configuration, on the same host, since the kernel last booted.  It also checks that the
+// interfaces of the snapshotted workload and host endpoints still exist; if any workload was
+// deleted while Felix was down then the kernel state no longer matches the snapshot.
+//
+// Validate only checks the snapshot's metadata and endpoints; the dataplane driver also checks the
+// snapshot's policy and IP sets against the kernel before it uses it.  Neither can tell whether
+// the datastore has changed while Felix was down, so the snapshot is only a starting point: the
+// dataplane keeps running from it until the calculation graph is back in sync, and then moves
+// straight to the calculation graph's state.
+func Validate(snap *proto.DataplaneSnapshot, meta Metadata, interfaceExists func(name string) bool) error {
+	if snap.Version != FormatVersion {
+		return fmt.Errorf("snapshot has format version %d, expected %d", snap.Version, FormatVersion)
+	}
+	if snap.FelixVersion != meta.FelixVersion {
+		return fmt.Errorf("snapshot was written by Felix %q, this is Felix %q", snap.FelixVersion, meta.FelixVersion)
+	}
+	if snap.Hostname != meta.Hostname {
+		return fmt.Errorf("snapshot was written for host %q, this is host %q", snap.Hostname, meta.Hostname)
+	}
+	if snap.BootId == "" || snap.BootId != meta.BootID {
+		return errors.New("snapshot was written before the last reboot")
+	}
+	if snap.ConfigHash != meta.ConfigHash {
+		return errors.New("snapshot was written with a different Felix configuration")
+	}
+	for _, envelope := range snap.Messages {
+		if wep := envelope.GetWorkloadEndpointUpdate(); wep != nil {
+			name := wep.GetEndpoint().GetName()
+			if name != "" && !interfaceExists(name) {
+				return fmt.Errorf("interface %q of workload endpoint %v no longer exists", name, wep.Id)
+			}
+		}
+		if hep := envelope.GetHostEndpointUpdate(); hep != nil {
+			// Host endpoints with a name of "*" or no name at all apply to all interfaces, or are
+			// matched by IP.
+			name := hep.GetEndpoint().GetName()
+			if name != "" && name != "*" && !interfaceExists(name) {
+				return fmt.Errorf("interface %q of host endpoint %v no longer exists", name, hep.Id)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadCache returns a cache containing the state from the given snapshot.
+func LoadCache(snap *proto.DataplaneSnapshot) (*Cache, error) {
+	c := NewCache()
+	for _, envelope := range snap.Messages {
+		msg := unwrap(envelope)
+		if msg == nil {
+			return nil, fmt.Errorf("snapshot contains unknown message: %v", envelope)
+		}
+		c.OnUpdate(msg)
+	}
+	return c, nil
+}
+
+// unwrap returns the payload of the given envelope.  Each payload type is a struct generated for
+// the oneof with a single field containing the message so, rather than listing every type here,
+// we extract the field by reflection.
+func unwrap(envelope *proto.ToDataplane) interface{} {
+	if envelope.Payload == nil {
+		return nil
+	}
+	v := reflect.ValueOf(envelope.Payload)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct || v.Elem().NumField() != 1 {
+		return nil
+	}
+	return v.Elem().Field(0).Interface()
+}
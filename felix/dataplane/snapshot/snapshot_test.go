@@ -0,0 +1,165 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/proto"
+)
+
+var (
+	wepID = &proto.WorkloadEndpointID{OrchestratorId: "k8s", WorkloadId: "ns/pod", EndpointId: "eth0"}
+	wep   = &proto.WorkloadEndpointUpdate{
+		Id:       wepID,
+		Endpoint: &proto.WorkloadEndpoint{Name: "cali1234", Ipv4Nets: []string{"10.0.0.1/32"}},
+	}
+	polID = &proto.PolicyID{Tier: "default", Name: "allow-all"}
+	pol   = &proto.ActivePolicyUpdate{Id: polID, Policy: &proto.Policy{}}
+	hepID = &proto.HostEndpointID{EndpointId: "eth0-hep"}
+	hep   = &proto.HostEndpointUpdate{Id: hepID, Endpoint: &proto.HostEndpoint{Name: "eth0"}}
+	meta  = Metadata{FelixVersion: "v3.99", BootID: "boot-1", Hostname: "host-a", ConfigHash: "config-1"}
+)
+
+func TestCacheFoldsUpdates(t *testing.T) {
+	RegisterTestingT(t)
+
+	c := NewCache()
+	c.OnUpdate(&proto.InSync{})
+	c.OnUpdate(wep)
+	c.OnUpdate(pol)
+	ipSetUpdate := &proto.IPSetUpdate{Id: "s1", Members: []string{"10.0.0.2", "10.0.0.1"}, Type: proto.IPSetUpdate_IP}
+	c.OnUpdate(ipSetUpdate)
+	c.OnUpdate(&proto.IPSetDeltaUpdate{Id: "s1", AddedMembers: []string{"10.0.0.3"}, RemovedMembers: []string{"10.0.0.2"}})
+	c.OnUpdate(&proto.IPSetUpdate{Id: "s2"})
+	c.OnUpdate(&proto.IPSetRemove{Id: "s2"})
+
+	// The message passed to the managers must not be modified.
+	Expect(ipSetUpdate.Members).To(Equal([]string{"10.0.0.2", "10.0.0.1"}))
+
+	// IP sets should come before policies, which come before endpoints.
+	Expect(c.Messages()).To(Equal([]interface{}{
+		&proto.IPSetUpdate{Id: "s1", Members: []string{"10.0.0.1", "10.0.0.3"}, Type: proto.IPSetUpdate_IP},
+		pol,
+		wep,
+	}))
+}
+
+func TestStaleRemovals(t *testing.T) {
+	RegisterTestingT(t)
+
+	c := NewCache()
+	c.OnUpdate(&proto.IPSetUpdate{Id: "s1"})
+	c.OnUpdate(&proto.IPSetUpdate{Id: "s2"})
+	c.OnUpdate(pol)
+	c.OnUpdate(wep)
+	c.OnUpdate(&proto.Encapsulation{IpipEnabled: true})
+	c.StartTrackingRefreshes()
+
+	// After the restart, the calculation graph resends s1 and the policy only.
+	c.OnUpdate(&proto.IPSetUpdate{Id: "s1"})
+	c.OnUpdate(pol)
+
+	Expect(c.StaleRemovals()).To(Equal([]interface{}{
+		&proto.WorkloadEndpointRemove{Id: wepID},
+		&proto.IPSetRemove{Id: "s2"},
+	}))
+	Expect(c.Len()).To(Equal(3))
+	Expect(c.StaleRemovals()).To(BeEmpty())
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	RegisterTestingT(t)
+
+	c := NewCache()
+	c.OnUpdate(&proto.IPSetUpdate{Id: "s1", Members: []string{"10.0.0.1"}})
+	c.OnUpdate(pol)
+	c.OnUpdate(wep)
+	c.OnUpdate(&proto.RouteUpdate{Dst: "10.0.1.0/26", DstNodeName: "host-b"})
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+	Expect(Save(path, meta, c)).To(Succeed())
+	snap, err := Load(path)
+	Expect(err).NotTo(HaveOccurred())
+
+	loaded, err := LoadCache(snap)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(loaded.Messages()).To(Equal(c.Messages()))
+
+	// No temporary files should be left behind.
+	entries, err := os.ReadDir(filepath.Dir(path))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(entries).To(HaveLen(1))
+}
+
+func TestLoadMissing(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := Load(filepath.Join(t.TempDir(), "snapshot"))
+	Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+func TestValidate(t *testing.T) {
+	RegisterTestingT(t)
+
+	c := NewCache()
+	c.OnUpdate(wep)
+	c.OnUpdate(hep)
+	path := filepath.Join(t.TempDir(), "snapshot")
+	Expect(Save(path, meta, c)).To(Succeed())
+	snap, err := Load(path)
+	Expect(err).NotTo(HaveOccurred())
+
+	allExist := func(string) bool { return true }
+	Expect(Validate(snap, meta, allExist)).To(Succeed())
+
+	rebooted := meta
+	rebooted.BootID = "boot-2"
+	Expect(Validate(snap, rebooted, allExist)).To(MatchError(ContainSubstring("reboot")))
+
+	upgraded := meta
+	upgraded.FelixVersion = "v4.0"
+	Expect(Validate(snap, upgraded, allExist)).To(MatchError(ContainSubstring("written by Felix")))
+
+	otherHost := meta
+	otherHost.Hostname = "host-b"
+	Expect(Validate(snap, otherHost, allExist)).To(MatchError(ContainSubstring("written for host")))
+
+	reconfigured := meta
+	reconfigured.ConfigHash = "config-2"
+	Expect(Validate(snap, reconfigured, allExist)).To(MatchError(ContainSubstring("configuration")))
+
+	noWorkloadIfaces := func(name string) bool { return name != "cali1234" }
+	Expect(Validate(snap, meta, noWorkloadIfaces)).To(MatchError(ContainSubstring("cali1234")))
+
+	noHostIfaces := func(name string) bool { return name != "eth0" }
+	Expect(Validate(snap, meta, noHostIfaces)).To(MatchError(ContainSubstring("eth0")))
+
+	snap.Version = FormatVersion + 1
+	Expect(Validate(snap, meta, allExist)).To(MatchError(ContainSubstring("format version")))
+}
+
+func TestConfigHash(t *testing.T) {
+	RegisterTestingT(t)
+
+	h := ConfigHash(map[string]string{"A": "1", "B": "2"})
+	Expect(h).To(Equal(ConfigHash(map[string]string{"B": "2", "A": "1"})))
+	Expect(h).NotTo(Equal(ConfigHash(map[string]string{"A": "1", "B": "3"})))
+	Expect(h).NotTo(Equal(ConfigHash(map[string]string{"A": "1"})))
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build fvtests
+
+package fv_test
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+
+	api "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/felix/fv/infrastructure"
+	"github.com/projectcalico/calico/felix/fv/tcpdump"
+	"github.com/projectcalico/calico/felix/fv/utils"
+	"github.com/projectcalico/calico/felix/fv/workload"
+	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	options2 "github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+// The RoutePolicy manager is registered in every dataplane mode.  In BPF mode, the workload's traffic
+// is forwarded by the BPF programs rather than the kernel, so these tests check that the programs'
+// FIB lookups honour the routing rules that steer the traffic into the RoutePolicy table.
+var _ = infrastructure.DatastoreDescribe(
+	"_BPF-SAFE_ RoutePolicy tests",
+	[]apiconfig.DatastoreType{apiconfig.EtcdV3, apiconfig.Kubernetes},
+	func(getInfra infrastructure.InfraFactory) {
+		const (
+			// A destination that the host's main routing table sends out of eth0.
+			steeredDest = "192.168.30.1"
+			nextHop     = "192.168.20.1"
+		)
+
+		var (
+			infra        infrastructure.DatastoreInfra
+			tc           infrastructure.TopologyContainers
+			calicoClient client.Interface
+			w            *workload.Workload
+			external     *workload.Workload
+			eth20Dump    *tcpdump.TCPDump
+		)
+
+		BeforeEach(func() {
+			infra = getInfra()
+			options := infrastructure.DefaultTopologyOptions()
+			tc, calicoClient = infrastructure.StartNNodeTopology(1, options, infra)
+
+			w = workload.Run(tc.Felixes[0], "w0", "default", "10.65.0.2", "8055", "udp")
+			w.WorkloadEndpoint.Labels = map[string]string{"app": "scanner"}
+			w.ConfigureInInfra(infra)
+
+			pol := api.NewGlobalNetworkPolicy()
+			pol.Name = "allow-all"
+			pol.Spec.Ingress = []api.Rule{{Action: "Allow"}}
+			pol.Spec.Egress = []api.Rule{{Action: "Allow"}}
+			pol.Spec.Selector = "all()"
+			_, err := calicoClient.GlobalNetworkPolicies().Create(utils.Ctx, pol, utils.NoOptions)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("setting up a fake external iface for the next hop")
+			// We name the iface ethXY since such ifaces are treated by felix as external to the
+			// node.
+			external = &workload.Workload{
+				Name:          "eth20",
+				C:             tc.Felixes[0].Container,
+				IP:            nextHop,
+				Ports:         "57005", // 0xdead
+				Protocol:      "udp",
+				InterfaceName: "eth20",
+				MTU:           1500, // Need to match host MTU or felix will restart.
+			}
+			Expect(external.Start()).To(Succeed())
+			tc.Felixes[0].Exec("ip", "route", "add", "192.168.20.0/24", "dev", "eth20")
+		})
+
+		JustAfterEach(func() {
+			if CurrentGinkgoTestDescription().Failed {
+				for _, felix := range tc.Felixes {
+					felix.Exec("ip", "rule")
+					felix.Exec("ip", "route", "show", "table", "all")
+				}
+			}
+		})
+
+		AfterEach(func() {
+			if eth20Dump != nil {
+				eth20Dump.Stop()
+				eth20Dump = nil
+			}
+			for _, f := range tc.Felixes {
+				f.Stop()
+			}
+			infra.Stop()
+		})
+
+		createRoutePolicy := func(name string, table int) {
+			rp := api.NewRoutePolicy()
+			rp.Name = name
+			rp.Spec.Selector = "app == 'scanner'"
+			rp.Spec.Destinations = []string{"192.168.30.0/24"}
+			rp.Spec.NextHop = nextHop
+			rp.Spec.Table = table
+			log.WithField("routePolicy", rp).Info("Creating RoutePolicy")
+			_, err := calicoClient.RoutePolicies().Create(utils.Ctx, rp, utils.NoOptions)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		// sendToSteeredDest starts capturing on the next hop's interface and returns a function that
+		// sends a packet from the workload to the steered destination and counts the packets seen.
+		sendToSteeredDest := func() func() int {
+			eth20Dump = tc.Felixes[0].AttachTCPDump("eth20")
+			eth20Dump.SetLogEnabled(true)
+			matcher := fmt.Sprintf("IP %s\\.30444 > %s\\.30444: UDP", w.IP, steeredDest)
+			eth20Dump.AddMatcher("steered", regexp.MustCompile(matcher))
+			eth20Dump.Start()
+
+			return func() int {
+				_, err := w.RunCmd("pktgen", w.IP, steeredDest, "udp", "--port-src", "30444", "--port-dst", "30444")
+				Expect(err).NotTo(HaveOccurred())
+				return eth20Dump.MatchCount("steered")
+			}
+		}
+
+		It("should steer the workload's traffic via the next hop", func() {
+			createRoutePolicy("scanner", 1100)
+
+			Eventually(tc.Felixes[0].ExecOutputFn("ip", "rule"), "10s").Should(
+				MatchRegexp(`from 10\.65\.0\.2 lookup 1100`))
+			Eventually(tc.Felixes[0].ExecOutputFn("ip", "route", "show", "table", "1100"), "10s").Should(
+				ContainSubstring("192.168.30.0/24 via 192.168.20.1 dev eth20"))
+
+			Eventually(sendToSteeredDest(), "10s", "1s").Should(BeNumerically(">", 0))
+
+			By("removing the policy")
+			_, err := calicoClient.RoutePolicies().Delete(utils.Ctx, "scanner", options2.DeleteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(tc.Felixes[0].ExecOutputFn("ip", "rule"), "10s").ShouldNot(ContainSubstring("lookup 1100"))
+			Eventually(tc.Felixes[0].ExecOutputFn("ip", "route", "show", "table", "1100"), "10s").Should(BeEmpty())
+		})
+
+		It("should not take over a table that is in use", func() {
+			tc.Felixes[0].Exec("ip", "route", "add", "192.168.31.0/24", "dev", "eth20", "table", "1101")
+			createRoutePolicy("scanner", 1101)
+
+			Consistently(tc.Felixes[0].ExecOutputFn("ip", "rule"), "5s").ShouldNot(ContainSubstring("lookup 1101"))
+			Expect(tc.Felixes[0].ExecOutput("ip", "route", "show", "table", "1101")).To(
+				Equal("192.168.31.0/24 dev eth20 scope link \n"))
+			Consistently(sendToSteeredDest(), "3s", "1s").Should(BeZero())
+		})
+	})
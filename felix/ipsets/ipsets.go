@@ -475,6 +475,28 @@ func (s *IPSets) CalicoIPSets() ([]string, error) {
 	return ipSets, nil
 }
 
+// MismatchedIPSets scans the IP sets in the dataplane and returns the IDs of the given IP sets
+// that are missing from the dataplane, or that don't have exactly their desired members.  Like a
+// resync, it only updates our view of the dataplane; it doesn't make any changes to the dataplane.
+func (s *IPSets) MismatchedIPSets(setIDs []string) ([]string, error) {
+	if err := s.tryResync(); err != nil {
+		return nil, err
+	}
+	var mismatched []string
+	for _, setID := range setIDs {
+		name := s.nameForMainIPSet(setID)
+		if !s.ipSetNeeded(name) {
+			continue
+		}
+		_, desired := s.setNameToAllMetadata[name]
+		_, metadataPending := s.setNameToProgrammedMetadata.PendingUpdates().Get(name)
+		if !desired || metadataPending || s.ipSetsWithDirtyMembers.Contains(name) {
+			mismatched = append(mismatched, setID)
+		}
+	}
+	return mismatched, nil
+}
+
 func (s *IPSets) resyncIPSet(ipSetName string) error {
 	// If ipSetName == "", it will run 'ipset list' which will return the list and details of all ipsets.
 	// We should prevent this to not hit ipset protocol mismatch from non-calico ipsets.
@@ -318,6 +318,29 @@ var _ = Describe("IP sets dataplane", func() {
 		Expect(dataplane.CmdNames).To(BeNil(), "updates should have been no-ops")
 	})
 
+	It("should report IP sets that don't match the dataplane", func() {
+		ipsets.AddOrReplaceIPSet(meta, []string{"10.0.0.1", "10.0.0.2"})
+		ipsets.AddOrReplaceIPSet(meta2, []string{"10.0.0.3"})
+		apply()
+		numRestoreCalls := dataplane.NumRestoreCalls()
+
+		// Simulate a restart with different desired state.
+		ipsets = NewIPSetsWithShims(
+			v4VersionConf,
+			logutils.NewSummarizer("test loop"),
+			dataplane.newCmd,
+			dataplane.sleep,
+		)
+		ipsets.AddOrReplaceIPSet(meta, []string{"10.0.0.1", "10.0.0.2"})
+		ipsets.AddOrReplaceIPSet(meta2, []string{"10.0.0.3", "10.0.0.4"})
+		ipsets.AddOrReplaceIPSet(meta3, []string{"10.0.0.5"})
+
+		mismatched, err := ipsets.MismatchedIPSets([]string{ipSetID, ipSetID2, ipSetID3})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mismatched).To(Equal([]string{ipSetID2, ipSetID3}))
+		Expect(dataplane.NumRestoreCalls()).To(Equal(numRestoreCalls), "should only read the dataplane")
+	})
+
 	Describe("with left-over IP sets in place", func() {
 		BeforeEach(func() {
 			dataplane.IPSetMembers = map[string]set.Set[string]{
@@ -149,6 +149,13 @@ func (m matchCriteria) NotConntrackState(stateNames string) generictables.MatchC
 	return append(m, fmt.Sprintf("-m conntrack ! --ctstate %s", stateNames))
 }
 
+// ConnLimitAbove matches connections from source addresses that have more than limit tracked
+// connections (counting the connection being matched).  xt_connlimit keeps its counts per rule,
+// so the ID isn't needed.
+func (m matchCriteria) ConnLimitAbove(_ string, limit uint32) generictables.MatchCriteria {
+	return append(m, fmt.Sprintf("-m connlimit --connlimit-above %d --connlimit-saddr", limit))
+}
+
 func (m matchCriteria) Protocol(name string) generictables.MatchCriteria {
 	return append(m, fmt.Sprintf("-p %s", name))
 }
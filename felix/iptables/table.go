@@ -23,6 +23,7 @@ import (
 	"os/exec"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -1480,6 +1481,45 @@ func (t *Table) CheckRulesPresent(chain string, rules []generictables.Rule) []ge
 	return present
 }
 
+// CompareChainsWithDataplane reads the table from the dataplane and checks the given chains
+// against it.  Chains that we don't have in our cache are ignored.  It returns the chains that are
+// present in the dataplane, the ones whose rules differ from the ones that we would program, and
+// any chains in the dataplane that have one of the given prefixes but aren't in chainNames.  It
+// doesn't change the dataplane, or our view of it.
+func (t *Table) CompareChainsWithDataplane(chainNames, prefixes []string) (present, mismatched, unexpected []string) {
+	features := t.featureDetector.GetFeatures()
+	dpHashes, _ := t.getHashesAndRulesFromDataplane()
+	expected := set.FromArray(chainNames)
+	for _, chainName := range chainNames {
+		chain, ok := t.chainNameToChain[chainName]
+		if !ok {
+			continue
+		}
+		hashes, ok := dpHashes[chainName]
+		if !ok {
+			continue
+		}
+		present = append(present, chainName)
+		expectedHashes := t.render.RuleHashes(chain, features)
+		if (len(hashes) > 0 || len(expectedHashes) > 0) && !reflect.DeepEqual(hashes, expectedHashes) {
+			mismatched = append(mismatched, chainName)
+		}
+	}
+	for chainName := range dpHashes {
+		if expected.Contains(chainName) {
+			continue
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(chainName, prefix) {
+				unexpected = append(unexpected, chainName)
+				break
+			}
+		}
+	}
+	sort.Strings(unexpected)
+	return
+}
+
 // InsertRulesNow insets the given rules immediately without removing or syncing
 // other rules. This is primarily useful when bootstrapping and we cannot wait
 // until we have the full state.
@@ -173,6 +173,39 @@ func describeEmptyDataplaneTests(dataplaneMode string) {
 		Expect(dataplane.DeletedChains).To(BeEmpty())
 	})
 
+	It("should compare chains with the dataplane", func() {
+		table.InsertOrAppendRules("FORWARD", []generictables.Rule{
+			{Match: Match(), Action: JumpAction{Target: "cali-pi-a"}},
+		})
+		table.UpdateChain(&generictables.Chain{
+			Name: "cali-pi-a", Rules: []generictables.Rule{{Match: Match(), Action: AcceptAction{}}},
+		})
+		table.Apply()
+		dataplane.Chains["cali-pi-extra"] = []string{}
+		// Not referenced, so not programmed.
+		table.UpdateChain(&generictables.Chain{
+			Name: "cali-pi-b", Rules: []generictables.Rule{{Match: Match(), Action: AcceptAction{}}},
+		})
+		dataplane.ResetCmds()
+
+		present, mismatched, unexpected := table.CompareChainsWithDataplane(
+			[]string{"cali-pi-a", "cali-pi-b", "cali-pi-unknown"}, []string{"cali-pi-"})
+		Expect(present).To(Equal([]string{"cali-pi-a"}))
+		Expect(mismatched).To(BeEmpty())
+		Expect(unexpected).To(Equal([]string{"cali-pi-extra"}))
+
+		By("detecting a chain that differs")
+		table.UpdateChain(&generictables.Chain{
+			Name: "cali-pi-a", Rules: []generictables.Rule{{Match: Match(), Action: DropAction{}}},
+		})
+		present, mismatched, _ = table.CompareChainsWithDataplane([]string{"cali-pi-a"}, nil)
+		Expect(present).To(Equal([]string{"cali-pi-a"}))
+		Expect(mismatched).To(Equal([]string{"cali-pi-a"}))
+
+		// Only reads the dataplane.
+		Expect(dataplane.CmdNames).NotTo(ContainElement(HaveSuffix("restore")))
+	})
+
 	It("should police the insert mode", func() {
 		Expect(func() {
 			NewTable(
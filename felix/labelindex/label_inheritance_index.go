@@ -245,6 +245,16 @@ func (idx *InheritIndex) DeleteLabels(id interface{}) {
 	idx.flushUpdates()
 }
 
+// Labels returns the labels of the given item, including those that it inherits from its
+// parents.  The returned value reflects later updates to the item's parents.
+func (idx *InheritIndex) Labels(id interface{}) (parser.Labels, bool) {
+	itemData, ok := idx.itemDataByID[id]
+	if !ok {
+		return nil, false
+	}
+	return itemData, true
+}
+
 func (idx *InheritIndex) getOrCreateParent(id string) *parentData {
 	parent := idx.parentDataByParentID[id]
 	if parent == nil {
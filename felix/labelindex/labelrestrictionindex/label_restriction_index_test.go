@@ -138,6 +138,47 @@ func TestLabelRestrictionIndex(t *testing.T) {
 	Expect(idx.labelToValueToIDs).To(BeEmpty())
 }
 
+func TestLabelRestrictionIndexExtendedOperators(t *testing.T) {
+	RegisterTestingT(t)
+
+	var optGauge, unoptGauge dummyGauge
+	idx := New[string](WithGauges[string](&optGauge, &unoptGauge))
+
+	// Numeric comparisons and regular expressions both require their labels to be present, so
+	// they should all be optimised.
+	idx.AddSelector("tierGt", mustParseSelector("tier > 2"))
+	idx.AddSelector("envMatches", mustParseSelector("env matches 'prod|staging'"))
+	idx.AddSelector("appMatches", mustParseSelector("app matches 'web-.*'"))
+	Expect(optGauge).To(BeNumerically("==", 3))
+	Expect(unoptGauge).To(BeNumerically("==", 0))
+
+	// A negated regular expression can't be optimised.
+	idx.AddSelector("envNotMatches", mustParseSelector("!env matches 'prod|staging'"))
+	Expect(optGauge).To(BeNumerically("==", 3))
+	Expect(unoptGauge).To(BeNumerically("==", 1))
+
+	potentialMatches := func(labels map[string]string) []string {
+		var out []string
+		idx.IterPotentialMatches(labeledAdapter(labels), func(s string, _ selector.Selector) {
+			out = append(out, s)
+		})
+		return out
+	}
+	Expect(potentialMatches(map[string]string{"tier": "3"})).To(ConsistOf("tierGt", "envNotMatches"))
+	Expect(potentialMatches(map[string]string{"env": "prod"})).To(ConsistOf("envMatches", "envNotMatches"))
+	Expect(potentialMatches(map[string]string{"env": "dev"})).To(ConsistOf("envNotMatches"),
+		"a regular expression that only matches a few values should be indexed by value")
+	Expect(potentialMatches(map[string]string{"app": "db"})).To(ConsistOf("appMatches", "envNotMatches"))
+	Expect(potentialMatches(map[string]string{"tier": "a"})).To(ConsistOf("tierGt", "envNotMatches"))
+
+	for _, id := range []string{"tierGt", "envMatches", "appMatches", "envNotMatches"} {
+		idx.DeleteSelector(id)
+	}
+	Expect(optGauge).To(BeNumerically("==", 0))
+	Expect(unoptGauge).To(BeNumerically("==", 0))
+	Expect(idx.labelToValueToIDs).To(BeEmpty())
+}
+
 type labeledAdapter map[string]string
 
 func (l labeledAdapter) IterOwnAndParentLabels(f func(k string, v string)) {
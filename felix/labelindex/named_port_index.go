@@ -15,6 +15,7 @@
 package labelindex
 
 import (
+	"fmt"
 	"math"
 	"reflect"
 	"strings"
@@ -166,6 +167,9 @@ type IPSetMember struct {
 	CIDR       ip.CIDR
 	Protocol   IPSetPortProtocol
 	PortNumber uint16
+	// PortMax is the last port of a range of ports starting at PortNumber, or 0 if the member has
+	// a single port.  Only members of numeric port IP sets have port ranges.
+	PortMax uint16
 }
 
 type ipSetData struct {
@@ -175,6 +179,10 @@ type ipSetData struct {
 	selector          selector.Selector
 	namedPortProtocol IPSetPortProtocol
 	namedPort         string
+	// ports, if non-empty, are the numeric ports that this IP set represents, with protocol
+	// namedPortProtocol.  Such an IP set contains every (CIDR, port range) pair of its matching
+	// endpoints.
+	ports []numorstring.Port
 
 	// memberToRefCount stores a reference count for each member in the IP set.  Reference counts
 	// may be >1 if an IP address is shared by more than one endpoint.
@@ -481,13 +489,36 @@ func extractCIDRsFromNetworkSet(netSet *model.NetworkSet) []ip.CIDR {
 var defaultLogCtx = log.WithField("fieldsSuppressedAtThisLogLevel", "true")
 
 func (idx *SelectorAndNamedPortIndex) UpdateIPSet(ipSetID string, sel selector.Selector, namedPortProtocol IPSetPortProtocol, namedPort string) {
+	idx.updateIPSet(ipSetID, &ipSetData{
+		selector:          sel,
+		namedPort:         namedPort,
+		namedPortProtocol: namedPortProtocol,
+	})
+}
+
+// UpdateIPPortIPSet adds or updates an IP set that contains the addresses of the endpoints that
+// match the selector, combined with each of the given numeric ports.  The ports must not overlap.
+func (idx *SelectorAndNamedPortIndex) UpdateIPPortIPSet(ipSetID string, sel selector.Selector, protocol IPSetPortProtocol, ports []numorstring.Port) {
+	if protocol == ProtocolNone || len(ports) == 0 {
+		log.WithField("id", ipSetID).Panic("IP and port IP set needs a protocol and ports")
+	}
+	idx.updateIPSet(ipSetID, &ipSetData{
+		selector:          sel,
+		namedPortProtocol: protocol,
+		ports:             ports,
+	})
+}
+
+func (idx *SelectorAndNamedPortIndex) updateIPSet(ipSetID string, newIPSetData *ipSetData) {
+	sel := newIPSetData.selector
 	logCxt := defaultLogCtx
 	if log.IsLevelEnabled(log.DebugLevel) {
 		logCxt = log.WithFields(log.Fields{
 			"ipSetID":           ipSetID,
 			"selector":          sel,
-			"namedPort":         namedPort,
-			"namedPortProtocol": namedPortProtocol,
+			"namedPort":         newIPSetData.namedPort,
+			"namedPortProtocol": newIPSetData.namedPortProtocol,
+			"ports":             newIPSetData.ports,
 		})
 		logCxt.Debug("Updating IP set")
 	}
@@ -499,8 +530,9 @@ func (idx *SelectorAndNamedPortIndex) UpdateIPSet(ipSetID string, sel selector.S
 	oldIPSetData := idx.ipSetDataByID[ipSetID]
 	if oldIPSetData != nil {
 		if oldIPSetData.selector.UniqueID() == sel.UniqueID() &&
-			oldIPSetData.namedPortProtocol == namedPortProtocol &&
-			oldIPSetData.namedPort == namedPort {
+			oldIPSetData.namedPortProtocol == newIPSetData.namedPortProtocol &&
+			oldIPSetData.namedPort == newIPSetData.namedPort &&
+			reflect.DeepEqual(oldIPSetData.ports, newIPSetData.ports) {
 			// Spurious refresh of existing IP set.
 			logCxt.Debug("Skipping unchanged IP set")
 			return
@@ -520,12 +552,7 @@ func (idx *SelectorAndNamedPortIndex) UpdateIPSet(ipSetID string, sel selector.S
 
 	// If we get here, we have a new IP set, and we need to scan endpoints
 	// against its selector.
-	newIPSetData := &ipSetData{
-		selector:          sel,
-		namedPort:         namedPort,
-		namedPortProtocol: namedPortProtocol,
-		memberToRefCount:  map[IPSetMember]uint64{},
-	}
+	newIPSetData.memberToRefCount = map[IPSetMember]uint64{}
 	idx.ipSetDataByID[ipSetID] = newIPSetData
 	idx.selectorCandidatesIdx.AddSelector(ipSetID, sel)
 
@@ -584,6 +611,9 @@ func (idx *SelectorAndNamedPortIndex) DeleteIPSet(setID string) {
 	delete(idx.ipSetDataByID, setID)
 	idx.selectorCandidatesIdx.DeleteSelector(setID)
 	idx.suppressor.DeleteIPSet(setID)
+	for _, p := range ipSetData.ports {
+		idx.suppressor.DeleteIPSet(portRangeSuppressorKey(setID, p.MinPort, p.MaxPort))
+	}
 }
 
 func (idx *SelectorAndNamedPortIndex) UpdateEndpointOrSet(
@@ -671,22 +701,22 @@ func (idx *SelectorAndNamedPortIndex) UpdateEndpointOrSet(
 // removals for previously sent members that are now masked.
 // For example, we don't need to send updates for both 10.0.0.0/24 and 10.0.0.1/32.
 func (idx *SelectorAndNamedPortIndex) onMemberAdded(ipSetID string, member IPSetMember) {
-	if member.Protocol == ProtocolNone && member.PortNumber == 0 {
-		// We only deduplicate for IP set members that are CIDRs. Named port members are always unique.
-		add, removes := idx.suppressor.Add(ipSetID, member.CIDR)
-		if add != nil {
-			idx.OnMemberAdded(ipSetID, IPSetMember{CIDR: add})
-		}
-		for _, r := range removes {
-			log.WithField("ipSetID", ipSetID).
-				WithField("cidr", r).
-				WithField("reason", member.CIDR).
-				Debug("Removing now-masked CIDR from IP set.")
-			idx.OnMemberRemoved(ipSetID, IPSetMember{CIDR: r})
-		}
-	} else {
+	suppressorKey, ok := idx.suppressorKeyFor(ipSetID, member)
+	if !ok {
 		// No need to de-duplicate.
 		idx.OnMemberAdded(ipSetID, member)
+		return
+	}
+	add, removes := idx.suppressor.Add(suppressorKey, member.CIDR)
+	if add != nil {
+		idx.OnMemberAdded(ipSetID, member.withCIDR(add))
+	}
+	for _, r := range removes {
+		log.WithField("ipSetID", ipSetID).
+			WithField("cidr", r).
+			WithField("reason", member.CIDR).
+			Debug("Removing now-masked CIDR from IP set.")
+		idx.OnMemberRemoved(ipSetID, member.withCIDR(r))
 	}
 }
 
@@ -694,23 +724,49 @@ func (idx *SelectorAndNamedPortIndex) onMemberAdded(ipSetID string, member IPSet
 // deduplicate any members that are masked by another member of the set, sending any necessary IPSet member
 // IPSet member adds for members that were previously masked by the removed member.
 func (idx *SelectorAndNamedPortIndex) onMemberRemoved(ipSetID string, member IPSetMember) {
-	if member.Protocol == ProtocolNone && member.PortNumber == 0 {
-		// We only deduplicate for IP set members that are CIDRs. Named port members are always unique.
-		rem, adds := idx.suppressor.Remove(ipSetID, member.CIDR)
-		if rem != nil {
-			idx.OnMemberRemoved(ipSetID, IPSetMember{CIDR: rem})
-		}
-		for _, a := range adds {
-			log.WithField("ipSetID", ipSetID).
-				WithField("cidr", a).
-				WithField("reason", member.CIDR).
-				Debug("Adding previously masked CIDR to IP set.")
-			idx.OnMemberAdded(ipSetID, IPSetMember{CIDR: a})
-		}
-	} else {
+	suppressorKey, ok := idx.suppressorKeyFor(ipSetID, member)
+	if !ok {
 		// No need to de-duplicate.
 		idx.OnMemberRemoved(ipSetID, member)
+		return
+	}
+	rem, adds := idx.suppressor.Remove(suppressorKey, member.CIDR)
+	if rem != nil {
+		idx.OnMemberRemoved(ipSetID, member.withCIDR(rem))
+	}
+	for _, a := range adds {
+		log.WithField("ipSetID", ipSetID).
+			WithField("cidr", a).
+			WithField("reason", member.CIDR).
+			Debug("Adding previously masked CIDR to IP set.")
+		idx.OnMemberAdded(ipSetID, member.withCIDR(a))
+	}
+}
+
+// suppressorKeyFor returns the key under which the overlap suppressor tracks the given member, and
+// false if the member doesn't need de-duplicating.  CIDR members are de-duplicated per IP set and
+// members of numeric port IP sets per IP set and port range.  Named port members are always unique.
+func (idx *SelectorAndNamedPortIndex) suppressorKeyFor(ipSetID string, member IPSetMember) (string, bool) {
+	if member.Protocol == ProtocolNone && member.PortNumber == 0 {
+		return ipSetID, true
+	}
+	if ipSetData := idx.ipSetDataByID[ipSetID]; ipSetData != nil && len(ipSetData.ports) > 0 {
+		portMax := member.PortMax
+		if portMax == 0 {
+			portMax = member.PortNumber
+		}
+		return portRangeSuppressorKey(ipSetID, member.PortNumber, portMax), true
 	}
+	return "", false
+}
+
+func portRangeSuppressorKey(ipSetID string, minPort, maxPort uint16) string {
+	return fmt.Sprintf("%s,%d-%d", ipSetID, minPort, maxPort)
+}
+
+func (m IPSetMember) withCIDR(cidr ip.CIDR) IPSetMember {
+	m.CIDR = cidr
+	return m
 }
 
 func (idx *SelectorAndNamedPortIndex) scanEndpointAgainstIPSets(
@@ -862,7 +918,24 @@ func (idx *SelectorAndNamedPortIndex) DeleteParentLabels(parentID string) {
 // If the IP set represents a named port then the returned members will have a named port component.
 // Returns nil if the endpoint doesn't contribute to the IP set.
 func (idx *SelectorAndNamedPortIndex) CalculateEndpointContribution(d *endpointData, ipSetData *ipSetData) (contrib []IPSetMember) {
-	if ipSetData.namedPortProtocol != ProtocolNone {
+	if len(ipSetData.ports) > 0 {
+		// This IP set represents a selector and numeric ports, calculate the cross product of
+		// the ports by CIDR.
+		for _, p := range ipSetData.ports {
+			var portMax uint16
+			if p.MaxPort > p.MinPort {
+				portMax = p.MaxPort
+			}
+			for _, addr := range d.nets {
+				contrib = append(contrib, IPSetMember{
+					CIDR:       addr,
+					Protocol:   ipSetData.namedPortProtocol,
+					PortNumber: p.MinPort,
+					PortMax:    portMax,
+				})
+			}
+		}
+	} else if ipSetData.namedPortProtocol != ProtocolNone {
 		// This IP set represents a named port match, calculate the cross product of
 		// matching named ports by IP address.
 		portNumbers := d.LookupNamedPorts(ipSetData.namedPort, ipSetData.namedPortProtocol)
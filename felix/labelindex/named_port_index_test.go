@@ -1422,6 +1422,60 @@ var _ = Describe("SelectorAndNamedPortIndex", func() {
 			Expect(set).To(HaveLen(0))
 		})
 	})
+
+	Describe("numeric port IP sets", func() {
+		netSetKVP := model.KVPair{
+			Key: model.NetworkSetKey{Name: "ghosts"},
+			Value: &model.NetworkSet{
+				Nets:   []calinet.IPNet{calinet.MustParseCIDR("10.0.0.0/8")},
+				Labels: map[string]string{"villain": "ghost"},
+			},
+		}
+		wepKVP := model.KVPair{
+			Key: model.WorkloadEndpointKey{Hostname: "host", OrchestratorID: "k8s", WorkloadID: "blinky", EndpointID: "eth0"},
+			Value: &model.WorkloadEndpoint{
+				IPv4Nets: []calinet.IPNet{calinet.MustParseCIDR("10.0.0.1/32")},
+				Labels:   map[string]string{"villain": "ghost"},
+			},
+		}
+		ports := []numorstring.Port{numorstring.SinglePort(80), {MinPort: 8080, MaxPort: 8090}}
+		member := func(cidr string, port, portMax uint16) IPSetMember {
+			return IPSetMember{CIDR: ip.MustParseCIDROrIP(cidr), Protocol: ProtocolTCP, PortNumber: port, PortMax: portMax}
+		}
+
+		BeforeEach(func() {
+			// Use the overlap suppressor, as in nftables mode.
+			uut = NewSelectorAndNamedPortIndex(true)
+			uut.OnMemberAdded = recorder.OnMemberAdded
+			uut.OnMemberRemoved = recorder.OnMemberRemoved
+
+			uut.OnUpdate(api.Update{KVPair: wepKVP})
+			s, err := selector.Parse("villain == 'ghost'")
+			Expect(err).ToNot(HaveOccurred())
+			uut.UpdateIPPortIPSet("ghostports", s, ProtocolTCP, ports)
+		})
+
+		It("should contain each address with each port range", func() {
+			Expect(recorder.ipsets["ghostports"]).To(Equal(map[IPSetMember]bool{
+				member("10.0.0.1/32", 80, 0):      true,
+				member("10.0.0.1/32", 8080, 8090): true,
+			}))
+		})
+
+		It("should suppress addresses that are covered by a CIDR with the same ports", func() {
+			uut.OnUpdate(api.Update{KVPair: netSetKVP})
+			Expect(recorder.ipsets["ghostports"]).To(Equal(map[IPSetMember]bool{
+				member("10.0.0.0/8", 80, 0):      true,
+				member("10.0.0.0/8", 8080, 8090): true,
+			}))
+
+			uut.OnUpdate(api.Update{KVPair: model.KVPair{Key: netSetKVP.Key}})
+			Expect(recorder.ipsets["ghostports"]).To(Equal(map[IPSetMember]bool{
+				member("10.0.0.1/32", 80, 0):      true,
+				member("10.0.0.1/32", 8080, 8090): true,
+			}))
+		})
+	})
 })
 
 func newRecorder() *testRecorder {
@@ -0,0 +1,246 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nftables
+
+import (
+	"net/netip"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// addrInterval is an inclusive range of IP addresses.  It is used to reason about the contents of
+// interval sets with auto-merge enabled, where the kernel is free to coalesce the CIDRs that we
+// program into larger CIDRs or ranges.
+type addrInterval struct {
+	first, last netip.Addr
+}
+
+// parseInterval parses an interval set element, which may be a single IP, a CIDR or a range of the
+// form "<first>-<last>", as returned by nft when it has merged adjacent elements.
+func parseInterval(s string) (addrInterval, bool) {
+	if first, last, found := strings.Cut(s, "-"); found {
+		f, err := netip.ParseAddr(strings.TrimSpace(first))
+		if err != nil {
+			return addrInterval{}, false
+		}
+		l, err := netip.ParseAddr(strings.TrimSpace(last))
+		if err != nil || l.BitLen() != f.BitLen() || l.Less(f) {
+			return addrInterval{}, false
+		}
+		return addrInterval{first: f, last: l}, true
+	}
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return addrInterval{}, false
+		}
+		p = p.Masked()
+		return addrInterval{first: p.Addr(), last: lastAddr(p)}, true
+	}
+	a, err := netip.ParseAddr(s)
+	if err != nil {
+		return addrInterval{}, false
+	}
+	return addrInterval{first: a, last: a}, true
+}
+
+// lastAddr returns the highest address in the given (masked) prefix.
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Addr().AsSlice()
+	for i := p.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+	a, _ := netip.AddrFromSlice(b)
+	return a
+}
+
+func (i addrInterval) overlaps(o addrInterval) bool {
+	return !i.last.Less(o.first) && !o.last.Less(i.first)
+}
+
+// mergeIntervals sorts the given intervals and merges any that overlap or are adjacent, mirroring
+// what the kernel does for a set with auto-merge enabled.
+func mergeIntervals(in []addrInterval) []addrInterval {
+	if len(in) == 0 {
+		return nil
+	}
+	sorted := make([]addrInterval, len(in))
+	copy(sorted, in)
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].first.Less(sorted[b].first)
+	})
+	out := []addrInterval{sorted[0]}
+	for _, i := range sorted[1:] {
+		cur := &out[len(out)-1]
+		next := cur.last.Next()
+		if next.IsValid() && next.Less(i.first) {
+			// Gap between the current interval and this one.
+			out = append(out, i)
+			continue
+		}
+		if cur.last.Less(i.last) {
+			cur.last = i.last
+		}
+	}
+	return out
+}
+
+// portInterval is an inclusive range of ports.
+type portInterval struct {
+	first, last uint16
+}
+
+func (i portInterval) overlaps(o portInterval) bool {
+	return i.last >= o.first && o.last >= i.first
+}
+
+// parsePortInterval parses a port or a range of ports of the form "<first>-<last>".
+func parsePortInterval(s string) (portInterval, bool) {
+	first, last, found := strings.Cut(s, "-")
+	if !found {
+		last = first
+	}
+	f, err := strconv.ParseUint(strings.TrimSpace(first), 10, 16)
+	if err != nil {
+		return portInterval{}, false
+	}
+	l, err := strconv.ParseUint(strings.TrimSpace(last), 10, 16)
+	if err != nil || l < f {
+		return portInterval{}, false
+	}
+	return portInterval{first: uint16(f), last: uint16(l)}, true
+}
+
+// intervalElem is an element of an interval set: the interval of addresses in its first field and,
+// for an IP and port set, the protocol and the interval of ports.  The elements of a net set have an
+// empty protocol and a zero port interval.
+type intervalElem struct {
+	addrs addrInterval
+	proto string
+	ports portInterval
+}
+
+// parseIntervalElem parses the key of an interval set element.  nft lists a range of addresses or
+// ports when it has merged adjacent elements.
+func parseIntervalElem(key []string) (intervalElem, bool) {
+	if len(key) != 1 && len(key) != 3 {
+		return intervalElem{}, false
+	}
+	addrs, ok := parseInterval(key[0])
+	if !ok {
+		return intervalElem{}, false
+	}
+	if len(key) == 1 {
+		return intervalElem{addrs: addrs}, true
+	}
+	ports, ok := parsePortInterval(key[2])
+	if !ok {
+		return intervalElem{}, false
+	}
+	return intervalElem{addrs: addrs, proto: strings.TrimSpace(key[1]), ports: ports}, true
+}
+
+// overlaps returns true if the two elements have some addresses and ports in common, and the same
+// protocol.
+func (e intervalElem) overlaps(o intervalElem) bool {
+	return e.proto == o.proto && e.addrs.overlaps(o.addrs) && e.ports.overlaps(o.ports)
+}
+
+// portSlice is a range of ports along with the merged intervals of addresses that are in the set
+// for every port in the range.
+type portSlice struct {
+	ports portInterval
+	addrs []addrInterval
+}
+
+// canonicalElems converts the elements with a particular protocol into a canonical form, so that
+// two lists of elements cover the same addresses and ports if and only if their canonical forms are
+// equal.  The kernel may merge elements along either dimension, so we cut the port space at every
+// port range boundary, merge the addresses within each slice of ports, and then merge adjacent
+// slices that have the same addresses.  This is quadratic in the number of distinct port ranges,
+// which is small in practice: the named ports of a set share a handful of port numbers, and the
+// calculation graph limits the number of port ranges in the sets that combine a selector with
+// numeric ports.
+func canonicalElems(elems []intervalElem) []portSlice {
+	var bounds []uint32
+	for _, e := range elems {
+		bounds = append(bounds, uint32(e.ports.first), uint32(e.ports.last)+1)
+	}
+	slices.Sort(bounds)
+	bounds = slices.Compact(bounds)
+
+	var out []portSlice
+	for i := 0; i+1 < len(bounds); i++ {
+		ports := portInterval{first: uint16(bounds[i]), last: uint16(bounds[i+1] - 1)}
+		var addrs []addrInterval
+		for _, e := range elems {
+			if e.ports.overlaps(ports) {
+				addrs = append(addrs, e.addrs)
+			}
+		}
+		addrs = mergeIntervals(addrs)
+		if len(addrs) == 0 {
+			continue
+		}
+		if n := len(out); n > 0 && uint32(out[n-1].ports.last)+1 == uint32(ports.first) && slices.Equal(out[n-1].addrs, addrs) {
+			out[n-1].ports.last = ports.last
+			continue
+		}
+		out = append(out, portSlice{ports: ports, addrs: addrs})
+	}
+	return out
+}
+
+// intervalsEquivalent returns true if the two lists of interval set element keys cover exactly the
+// same elements.  Keys that cannot be parsed make the lists non-equivalent.
+func intervalsEquivalent(a, b [][]string) bool {
+	parseAll := func(keys [][]string) (map[string][]portSlice, bool) {
+		byProto := map[string][]intervalElem{}
+		for _, k := range keys {
+			e, ok := parseIntervalElem(k)
+			if !ok {
+				return nil, false
+			}
+			byProto[e.proto] = append(byProto[e.proto], e)
+		}
+		canonical := map[string][]portSlice{}
+		for proto, elems := range byProto {
+			canonical[proto] = canonicalElems(elems)
+		}
+		return canonical, true
+	}
+	ma, ok := parseAll(a)
+	if !ok {
+		return false
+	}
+	mb, ok := parseAll(b)
+	if !ok {
+		return false
+	}
+	if len(ma) != len(mb) {
+		return false
+	}
+	for proto, slicesA := range ma {
+		slicesB, ok := mb[proto]
+		if !ok || !slices.EqualFunc(slicesA, slicesB, func(x, y portSlice) bool {
+			return x.ports == y.ports && slices.Equal(x.addrs, y.addrs)
+		}) {
+			return false
+		}
+	}
+	return true
+}
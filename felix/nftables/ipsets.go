@@ -391,7 +391,7 @@ func (s *IPSets) tryResync() error {
 	// For any set that doesn't match the desired data plane state, we'll queue up an update.
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
 	defer cancel()
-	sets, err := s.nft.List(ctx, "set")
+	allSets, err := s.nft.List(ctx, "set")
 	if err != nil {
 		if knftables.IsNotFound(err) {
 			// Table doesn't exist - nothing to resync.
@@ -400,6 +400,17 @@ func (s *IPSets) tryResync() error {
 		return fmt.Errorf("error listing nftables sets: %s", err)
 	}
 
+	// The connlimit meters are sets too, but they belong to the rules that use them.  The kernel
+	// won't delete them while they're in use, so trying to clean them up here would fail the
+	// whole deletion transaction.
+	var sets []string
+	for _, name := range allSets {
+		if strings.HasPrefix(name, ConnLimitMeterPrefix) {
+			continue
+		}
+		sets = append(sets, name)
+	}
+
 	// We'll process each set in parallel, so we need a struct to hold the results.
 	// Once knftables is augmented to support reading many sets at once, we can remove this.
 	type setData struct {
@@ -470,15 +481,16 @@ func (s *IPSets) tryResync() error {
 		for _, e := range setData.elems {
 			switch metadata.Type {
 			case ipsets.IPSetTypeHashIP, ipsets.IPSetTypeHashNet:
-				if len(e.Key) == 1 {
+				if len(e.Key) == 1 && !strings.Contains(e.Key[0], "-") {
 					// These types are just IP addresses / CIDRs.
 					strElems = append(strElems, e.Key[0])
 				} else {
 					unknownElems.Add(UnknownMember(e.Key))
 				}
 			case ipsets.IPSetTypeHashIPPort:
-				if len(e.Key) == 3 {
-					// This is a concatination of IP, protocol and port. Format it back into Felix's internal representation.
+				if len(e.Key) == 3 && !strings.Contains(e.Key[0], "-") {
+					// This is a concatination of IP (or CIDR), protocol and port (or port range).
+					// Format it back into Felix's internal representation.
 					strElems = append(strElems, fmt.Sprintf("%s,%s:%s", e.Key[0], e.Key[1], e.Key[2]))
 				} else {
 					unknownElems.Add(UnknownMember(e.Key))
@@ -505,6 +517,15 @@ func (s *IPSets) tryResync() error {
 		elemsSet.AddAll(unknownElems.Slice())
 
 		memberTracker := s.getOrCreateMemberTracker(setName)
+		if usesAutoMerge(metadata.Type) && s.autoMergedSetInSync(memberTracker, setData.elems) {
+			// The kernel has merged some of our CIDRs (or ranges may be listed in a different
+			// form) but the set covers exactly the addresses we want.  Treat it as in sync rather
+			// than churning the merged elements.
+			elemsSet = set.New[SetMember]()
+			memberTracker.Desired().Iter(func(m SetMember) {
+				elemsSet.Add(m)
+			})
+		}
 		numExtrasExpected := memberTracker.PendingDeletions().Len()
 		err = memberTracker.Dataplane().ReplaceFromIter(func(f func(k SetMember)) error {
 			elemsSet.Iter(func(item SetMember) error {
@@ -559,10 +580,30 @@ func (s *IPSets) tryResync() error {
 	return nil
 }
 
+// autoMergedSetInSync returns true if the elements listed from an auto-merge interval set cover
+// exactly the same elements as the desired members of the set.
+func (s *IPSets) autoMergedSetInSync(members *deltatracker.SetDeltaTracker[SetMember], elems []*knftables.Element) bool {
+	var programmed, desired [][]string
+	for _, e := range elems {
+		programmed = append(programmed, e.Key)
+	}
+	members.Desired().Iter(func(m SetMember) {
+		desired = append(desired, m.Key())
+	})
+	return intervalsEquivalent(programmed, desired)
+}
+
 func LegalizeSetName(setName string) string {
 	return strings.Replace(setName, ":", "-", -1)
 }
 
+var autoMergeEnabled = true
+
+// usesAutoMerge returns true if sets of the given type are programmed with auto-merge enabled.
+func usesAutoMerge(t ipsets.IPSetType) bool {
+	return t == ipsets.IPSetTypeHashNet || t == ipsets.IPSetTypeHashIPPort
+}
+
 func (s *IPSets) NFTablesSet(name string) *knftables.Set {
 	metadata, ok := s.setNameToAllMetadata[name]
 	if !ok {
@@ -570,9 +611,15 @@ func (s *IPSets) NFTablesSet(name string) *knftables.Set {
 	}
 
 	var flags []knftables.SetFlag
+	var autoMerge *bool
 	switch metadata.Type {
 	case ipsets.IPSetTypeHashIPPort:
-		// IP and port sets don't support the interval flag.
+		// IP and port sets use the interval flag so that the sets that combine a selector with
+		// numeric ports can hold CIDRs and port ranges.  They use auto-merge too: the pods with the
+		// same named port collapse into ranges of addresses, and a CIDR that overlaps another
+		// member (for example, a network set that contains a pod IP) isn't an error.
+		flags = append(flags, knftables.IntervalFlag)
+		autoMerge = &autoMergeEnabled
 	case ipsets.IPSetTypeHashIP:
 		// IP addr sets don't use the interval flag.
 	case ipsets.IPSetTypeBitmapPort:
@@ -580,16 +627,20 @@ func (s *IPSets) NFTablesSet(name string) *knftables.Set {
 	case ipsets.IPSetTypeHashNetNet:
 		// Net sets don't use the interval flag.
 	case ipsets.IPSetTypeHashNet:
-		// Net sets require the interval flag.
+		// Net sets require the interval flag.  We also enable auto-merge so that overlapping and
+		// adjacent CIDRs (common in large network sets) are coalesced by the kernel; this keeps the
+		// set small and means that adding a CIDR that overlaps an existing one isn't an error.
 		flags = append(flags, knftables.IntervalFlag)
+		autoMerge = &autoMergeEnabled
 	default:
 		log.WithField("type", metadata.Type).Panic("Unexpected IP set type")
 	}
 
 	return &knftables.Set{
-		Name:  name,
-		Type:  setType(metadata.Type, s.IPVersionConfig.Family.Version()),
-		Flags: flags,
+		Name:      name,
+		Type:      setType(metadata.Type, s.IPVersionConfig.Family.Version()),
+		Flags:     flags,
+		AutoMerge: autoMerge,
 	}
 }
 
@@ -647,9 +698,17 @@ func (s *IPSets) tryUpdates() error {
 			return deltatracker.IterActionNoOp
 		})
 
+		// In an auto-merge set, deleting a member removes its addresses from whatever merged
+		// interval now contains them, even if another member also covers those addresses.  Re-add
+		// any remaining members that overlap a deleted member so that they're restored.
+		var overlapping set.Set[SetMember]
+		if meta, _ := s.setNameToProgrammedMetadata.Desired().Get(setName); usesAutoMerge(meta.Type) {
+			overlapping = s.membersOverlappingDeletions(members)
+		}
+
 		// Add desired members to the set.
 		members.Desired().Iter(func(member SetMember) {
-			if members.Dataplane().Contains(member) {
+			if members.Dataplane().Contains(member) && (overlapping == nil || !overlapping.Contains(member)) {
 				return
 			}
 			tx.Add(&knftables.Element{
@@ -685,6 +744,35 @@ func (s *IPSets) tryUpdates() error {
 	return nil
 }
 
+// membersOverlappingDeletions returns the desired members of an interval set that overlap one of
+// the set's pending deletions.
+func (s *IPSets) membersOverlappingDeletions(members *deltatracker.SetDeltaTracker[SetMember]) set.Set[SetMember] {
+	var deleted []intervalElem
+	members.PendingDeletions().Iter(func(member SetMember) deltatracker.IterAction {
+		if e, ok := parseIntervalElem(member.Key()); ok {
+			deleted = append(deleted, e)
+		}
+		return deltatracker.IterActionNoOp
+	})
+	overlapping := set.New[SetMember]()
+	if len(deleted) == 0 {
+		return overlapping
+	}
+	members.Desired().Iter(func(member SetMember) {
+		e, ok := parseIntervalElem(member.Key())
+		if !ok {
+			return
+		}
+		for _, d := range deleted {
+			if e.overlaps(d) {
+				overlapping.Add(member)
+				return
+			}
+		}
+	})
+	return overlapping
+}
+
 // ApplyDeletions tries to delete any IP sets that are no longer needed.
 // Failures are ignored, deletions will be retried the next time we do a resync.
 func (s *IPSets) ApplyDeletions() bool {
@@ -822,28 +910,47 @@ func CanonicaliseMember(t ipsets.IPSetType, member string) SetMember {
 		}
 		return simpleMember(ipAddr.String())
 	case ipsets.IPSetTypeHashIPPort:
-		// The member should be of the format "IP,protocol:port"
+		// The member should be of the format "IP,protocol:port".  The IP sets that combine a
+		// selector with numeric ports may also have members with a CIDR and/or a range of ports,
+		// of the format "CIDR,protocol:first-last".
 		parts := strings.Split(member, ",")
 		if len(parts) != 2 {
 			log.WithField("member", member).Panic("Failed to parse IP,proto:port set member")
 		}
-		ipAddr := ip.FromIPOrCIDRString(parts[0])
-		if ipAddr == nil {
+		cidr, err := ip.ParseCIDROrIP(parts[0])
+		if err != nil {
 			// This should be prevented by validation.
-			log.WithField("member", member).Panic("Failed to parse IP part of IP,port member")
+			log.WithField("member", member).WithError(err).Panic("Failed to parse IP part of IP,port member")
 		}
 		parts = strings.Split(parts[1], ":")
 		if len(parts) != 2 {
 			log.WithField("member", member).Panic("Failed to parse IP part of IP,port member")
 		}
 		proto := parts[0]
-		port, err := strconv.Atoi(parts[1])
+		firstPort, lastPort, isRange := strings.Cut(parts[1], "-")
+		port, err := strconv.Atoi(firstPort)
 		if err != nil {
 			log.WithField("member", member).WithError(err).Panic("Bad port")
 		}
 		if port > math.MaxUint16 || port < 0 {
 			log.WithField("member", member).Panic("Bad port range (should be between 0 and 65535)")
 		}
+		if isRange || !cidr.IsSingleAddress() {
+			portMax := port
+			if isRange {
+				portMax, err = strconv.Atoi(lastPort)
+				if err != nil || portMax > math.MaxUint16 || portMax < port {
+					log.WithField("member", member).WithError(err).Panic("Bad port range")
+				}
+			}
+			return netPortMember{
+				CIDR:     cidr,
+				Protocol: proto,
+				Port:     uint16(port),
+				PortMax:  uint16(portMax),
+			}
+		}
+		ipAddr := cidr.Addr()
 
 		// Return a dedicated struct for V4 or V6.  This slightly reduces occupancy over storing
 		// the address as an interface by storing one fewer interface headers.  That is worthwhile
@@ -42,6 +42,21 @@ var _ = Describe("IPSets with empty data plane", func() {
 		Expect(s.ApplyUpdates).NotTo(Panic())
 	})
 
+	It("should leave connlimit meters alone", func() {
+		tx := f.NewTransaction()
+		tx.Add(&knftables.Table{})
+		tx.Add(&knftables.Set{Name: "cali-connlimit-rule1", Type: "ipv4_addr"})
+		Expect(f.Run(context.TODO(), tx)).To(Succeed())
+
+		s.AddOrReplaceIPSet(ipsets.IPSetMetadata{SetID: "m1", Type: ipsets.IPSetTypeHashIP}, []string{"10.0.0.1"})
+		s.ApplyUpdates()
+		s.ApplyDeletions()
+
+		sets, err := f.List(context.TODO(), "set")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sets).To(ConsistOf("cali-connlimit-rule1", "cali40m1"))
+	})
+
 	It("should handle a failed ListElements call", func() {
 		// Create a number of different sets.
 		m1 := ipsets.IPSetMetadata{SetID: "m1", Type: ipsets.IPSetTypeHashIP}
@@ -145,6 +160,175 @@ var _ = Describe("IPSets with empty data plane", func() {
 		))
 	})
 
+	It("should treat merged elements in a net set as in sync", func() {
+		meta := ipsets.IPSetMetadata{SetID: "test", Type: ipsets.IPSetTypeHashNet}
+		s.AddOrReplaceIPSet(meta, []string{"10.0.0.0/25", "10.0.0.128/25", "10.0.1.0"})
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		// Replace the elements with the merged form that the kernel would list.
+		tx := f.NewTransaction()
+		tx.Flush(&knftables.Set{Name: "cali40test"})
+		tx.Add(&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.0-10.0.1.0"}})
+		Expect(f.Run(context.Background(), tx)).NotTo(HaveOccurred())
+
+		s.QueueResync()
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		// Nothing should have been rewritten.
+		elements, err := f.ListElements(context.Background(), "set", "cali40test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(elements).To(ConsistOf(
+			&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.0-10.0.1.0"}},
+		))
+	})
+
+	It("should replace merged elements in a net set that don't match", func() {
+		meta := ipsets.IPSetMetadata{SetID: "test", Type: ipsets.IPSetTypeHashNet}
+		s.AddOrReplaceIPSet(meta, []string{"10.0.0.0/25", "10.0.0.128/25"})
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		tx := f.NewTransaction()
+		tx.Flush(&knftables.Set{Name: "cali40test"})
+		tx.Add(&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.0-10.0.1.0"}})
+		Expect(f.Run(context.Background(), tx)).NotTo(HaveOccurred())
+
+		s.QueueResync()
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		elements, err := f.ListElements(context.Background(), "set", "cali40test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(elements).To(ConsistOf(
+			&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.0/25"}},
+			&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.128/25"}},
+		))
+	})
+
+	It("should re-add overlapping members when deleting from a net set", func() {
+		meta := ipsets.IPSetMetadata{SetID: "test", Type: ipsets.IPSetTypeHashNet}
+		s.AddOrReplaceIPSet(meta, []string{"10.0.0.0/8", "10.1.0.0/16", "11.0.0.0/8"})
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		// With auto-merge, deleting 10.1.0.0/16 would punch a hole in 10.0.0.0/8 so we expect
+		// the latter to be re-added in the same transaction.
+		f.Reset()
+		s.RemoveMembers("test", []string{"10.1.0.0/16"})
+		Expect(s.ApplyUpdates).NotTo(Panic())
+		Expect(f.transactions).To(HaveLen(1))
+		Expect(f.transactions[0].String()).To(Equal(
+			"delete element ip calico cali40test { 10.1.0.0/16 }\n" +
+				"add element ip calico cali40test { 10.0.0.0/8 }\n",
+		))
+
+		elements, err := f.ListElements(context.Background(), "set", "cali40test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(elements).To(ConsistOf(
+			&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.0/8"}},
+			&knftables.Element{Set: "cali40test", Key: []string{"11.0.0.0/8"}},
+		))
+	})
+
+	It("should program CIDRs and port ranges in an IP and port set", func() {
+		meta := ipsets.IPSetMetadata{SetID: "test", Type: ipsets.IPSetTypeHashIPPort}
+		s.AddOrReplaceIPSet(meta, []string{"10.0.0.1,tcp:80", "10.0.0.2,tcp:8080-8090", "10.1.0.0/16,tcp:80"})
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		elements, err := f.ListElements(context.Background(), "set", "cali40test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(elements).To(ConsistOf(
+			&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.1", "tcp", "80"}},
+			&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.2", "tcp", "8080-8090"}},
+			&knftables.Element{Set: "cali40test", Key: []string{"10.1.0.0/16", "tcp", "80"}},
+		))
+
+		// The port range should be read back as a single element, so a resync has nothing to do.
+		f.Reset()
+		s.QueueResync()
+		Expect(s.ApplyUpdates).NotTo(Panic())
+		Expect(f.transactions).To(BeEmpty())
+	})
+
+	It("should treat merged elements in an IP and port set as in sync", func() {
+		meta := ipsets.IPSetMetadata{SetID: "test", Type: ipsets.IPSetTypeHashIPPort}
+		s.AddOrReplaceIPSet(meta, []string{
+			"10.0.0.1,tcp:80-85", "10.0.0.1,tcp:86-90", "10.0.0.2,tcp:80-90",
+			"10.0.0.3,tcp:80", "10.0.0.3,udp:53",
+		})
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		// Replace the elements with the merged form that the kernel would list, with ranges of
+		// both addresses and ports.
+		tx := f.NewTransaction()
+		tx.Flush(&knftables.Set{Name: "cali40test"})
+		tx.Add(&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.1-10.0.0.2", "tcp", "80-90"}})
+		tx.Add(&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.3", "tcp", "80"}})
+		tx.Add(&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.3", "udp", "53"}})
+		Expect(f.Run(context.Background(), tx)).NotTo(HaveOccurred())
+
+		f.Reset()
+		s.QueueResync()
+		Expect(s.ApplyUpdates).NotTo(Panic())
+		Expect(f.transactions).To(BeEmpty())
+	})
+
+	It("should replace merged elements in an IP and port set that cover other ports", func() {
+		meta := ipsets.IPSetMetadata{SetID: "test", Type: ipsets.IPSetTypeHashIPPort}
+		s.AddOrReplaceIPSet(meta, []string{"10.0.0.1,tcp:80-85", "10.0.0.2,tcp:80-90"})
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		// 10.0.0.1 isn't meant to have ports 86-90.
+		tx := f.NewTransaction()
+		tx.Flush(&knftables.Set{Name: "cali40test"})
+		tx.Add(&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.1-10.0.0.2", "tcp", "80-90"}})
+		Expect(f.Run(context.Background(), tx)).NotTo(HaveOccurred())
+
+		s.QueueResync()
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		elements, err := f.ListElements(context.Background(), "set", "cali40test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(elements).To(ConsistOf(
+			&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.1", "tcp", "80-85"}},
+			&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.2", "tcp", "80-90"}},
+		))
+	})
+
+	It("should re-add overlapping members when deleting from an IP and port set", func() {
+		meta := ipsets.IPSetMetadata{SetID: "test", Type: ipsets.IPSetTypeHashIPPort}
+		s.AddOrReplaceIPSet(meta, []string{"10.0.0.0/24,tcp:80-90", "10.0.0.1,tcp:85", "10.0.0.1,tcp:443", "10.0.0.1,udp:85"})
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		// Only the member with an overlapping address, protocol and port needs to be re-added.
+		f.Reset()
+		s.RemoveMembers("test", []string{"10.0.0.1,tcp:85"})
+		Expect(s.ApplyUpdates).NotTo(Panic())
+		Expect(f.transactions).To(HaveLen(1))
+		Expect(f.transactions[0].String()).To(Equal(
+			"delete element ip calico cali40test { 10.0.0.1 . tcp . 85 }\n" +
+				"add element ip calico cali40test { 10.0.0.0/24 . tcp . 80-90 }\n",
+		))
+	})
+
+	It("should replace IP and port set elements that don't match", func() {
+		meta := ipsets.IPSetMetadata{SetID: "test", Type: ipsets.IPSetTypeHashIPPort}
+		s.AddOrReplaceIPSet(meta, []string{"10.0.0.1,tcp:80", "10.0.0.2,tcp:80"})
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		tx := f.NewTransaction()
+		tx.Flush(&knftables.Set{Name: "cali40test"})
+		tx.Add(&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.1", "tcp", "80-81"}})
+		Expect(f.Run(context.Background(), tx)).NotTo(HaveOccurred())
+
+		s.QueueResync()
+		Expect(s.ApplyUpdates).NotTo(Panic())
+
+		elements, err := f.ListElements(context.Background(), "set", "cali40test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(elements).To(ConsistOf(
+			&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.1", "tcp", "80"}},
+			&knftables.Element{Set: "cali40test", Key: []string{"10.0.0.2", "tcp", "80"}},
+		))
+	})
+
 	It("should handle unexpected sets with types that are not supported", func() {
 		// Create an IP set direclty in the dataplane, with a type that is not supported by the IPSets object.
 		tx := f.NewTransaction()
@@ -325,6 +509,9 @@ var _ = DescribeTable("CanonicalizeMember",
 	Entry(ipsets.IPSetTypeHashNet, ipsets.IPSetTypeHashNet, "2001:db8::/64", "2001:db8::/64", []string{"2001:db8::/64"}),
 	Entry(ipsets.IPSetTypeHashIPPort, ipsets.IPSetTypeHashIPPort, "192.168.0.1,tcp:80", "192.168.0.1,tcp:80", []string{"192.168.0.1", "tcp", "80"}),
 	Entry(ipsets.IPSetTypeHashIPPort, ipsets.IPSetTypeHashIPPort, "fe80::1,udp:53", "fe80::1,udp:53", []string{"fe80::1", "udp", "53"}),
+	Entry(ipsets.IPSetTypeHashIPPort, ipsets.IPSetTypeHashIPPort, "192.168.0.1,tcp:80-90", "192.168.0.1,tcp:80-90", []string{"192.168.0.1", "tcp", "80-90"}),
+	Entry(ipsets.IPSetTypeHashIPPort, ipsets.IPSetTypeHashIPPort, "10.0.0.0/8,tcp:80", "10.0.0.0/8,tcp:80", []string{"10.0.0.0/8", "tcp", "80"}),
+	Entry(ipsets.IPSetTypeHashIPPort, ipsets.IPSetTypeHashIPPort, "2001:db8::/64,sctp:1-100", "2001:db8::/64,sctp:1-100", []string{"2001:db8::/64", "sctp", "1-100"}),
 	Entry(ipsets.IPSetTypeHashNetNet, ipsets.IPSetTypeHashNetNet, "10.0.0.1/32,10.0.0.1/32", "10.0.0.1/32 . 10.0.0.1/32", []string{"10.0.0.1", "10.0.0.1"}),
 	Entry(ipsets.IPSetTypeHashNetNet, ipsets.IPSetTypeHashNetNet, "2001:db8::1/128,2001:db8::1/128", "2001:db8::1/128 . 2001:db8::1/128", []string{"2001:db8::1", "2001:db8::1"}),
 	Entry(ipsets.IPSetTypeBitmapPort, ipsets.IPSetTypeBitmapPort, "v4,80", "80", []string{"80"}),
@@ -350,12 +537,12 @@ var _ = DescribeTable("NFTablesSet",
 	Entry(
 		ipsets.IPSetTypeHashNet,
 		ipsets.IPSetMetadata{SetID: "test", Type: ipsets.IPSetTypeHashNet},
-		&knftables.Set{Name: "cali40test", Type: "ipv4_addr", Flags: []knftables.SetFlag{knftables.IntervalFlag}},
+		&knftables.Set{Name: "cali40test", Type: "ipv4_addr", Flags: []knftables.SetFlag{knftables.IntervalFlag}, AutoMerge: ptr(true)},
 	),
 	Entry(
 		ipsets.IPSetTypeHashIPPort,
 		ipsets.IPSetMetadata{SetID: "test", Type: ipsets.IPSetTypeHashIPPort},
-		&knftables.Set{Name: "cali40test", Type: "ipv4_addr . inet_proto . inet_service"},
+		&knftables.Set{Name: "cali40test", Type: "ipv4_addr . inet_proto . inet_service", Flags: []knftables.SetFlag{knftables.IntervalFlag}, AutoMerge: ptr(true)},
 	),
 	Entry(
 		ipsets.IPSetTypeHashNetNet,
@@ -35,8 +35,16 @@ var (
 
 	// ipSetMatch matches clauses that contain an IP set reference.
 	ipSetMatch = regexp.MustCompile("<IPV>.*@(.*)")
+
+	// connLimitMeterMatch matches clauses that use a connlimit meter.
+	connLimitMeterMatch = regexp.MustCompile("^meter (" + ConnLimitMeterPrefix + `\S+) `)
 )
 
+// ConnLimitMeterPrefix is the prefix of the names of the meters that hold the per-source
+// connection counts for connlimit matches.  The meters are created by the kernel when the rules
+// that use them are added, so they are cleaned up with the rules rather than as IP sets.
+const ConnLimitMeterPrefix = "cali-connlimit-"
+
 const (
 	ProtoIPIP   = 4
 	ProtoTCP    = 6
@@ -53,6 +61,19 @@ type NFTMatchCriteria interface {
 
 	ConntrackStatus(statusNames string) generictables.MatchCriteria
 	NotConntrackStatus(statusNames string) generictables.MatchCriteria
+
+	// SourceNets, DestNets and their negations match any of the given CIDRs using a single
+	// anonymous interval set lookup.  iptables can only match one CIDR per rule so the
+	// iptables renderer has to emit a block of rules for each list of CIDRs instead.
+	SourceNets(nets []string) generictables.MatchCriteria
+	NotSourceNets(nets []string) generictables.MatchCriteria
+	DestNets(nets []string) generictables.MatchCriteria
+	NotDestNets(nets []string) generictables.MatchCriteria
+
+	// SourceIPDestPortSet matches the source IP, protocol and destination port against an IP
+	// and port set.  iptables IP sets can do the same but the calculation graph only uses such
+	// sets with nftables.
+	SourceIPDestPortSet(name string) generictables.MatchCriteria
 }
 
 // nftMatch implements the MatchCriteria interface for nftables.
@@ -256,6 +277,31 @@ func (m nftMatch) NotConntrackState(stateNames string) generictables.MatchCriter
 	return m
 }
 
+// ConnLimitAbove matches connections from source addresses that have more than limit tracked
+// connections (counting the connection being matched).  Like xt_connlimit, the counts are kept
+// per rule, in a meter named after the given ID.
+func (m nftMatch) ConnLimitAbove(id string, limit uint32) generictables.MatchCriteria {
+	m.clauses = append(m.clauses, fmt.Sprintf("meter %s%s { <IPV> saddr ct count over %d }", ConnLimitMeterPrefix, id, limit))
+	return m
+}
+
+// ConnLimitMeterNames returns the names of the connlimit meters that the rules use.
+func ConnLimitMeterNames(rules []generictables.Rule) []string {
+	var names []string
+	for _, r := range rules {
+		m, ok := r.Match.(nftMatch)
+		if !ok {
+			continue
+		}
+		for _, clause := range m.clauses {
+			if match := connLimitMeterMatch.FindStringSubmatch(clause); match != nil {
+				names = append(names, match[1])
+			}
+		}
+	}
+	return names
+}
+
 func (m nftMatch) Protocol(name string) generictables.MatchCriteria {
 	if m.proto != "" {
 		logrus.WithField("protocol", m.proto).Fatal("Protocol already set")
@@ -316,6 +362,26 @@ func (m nftMatch) NotDestNet(net string) generictables.MatchCriteria {
 	return m
 }
 
+func (m nftMatch) SourceNets(nets []string) generictables.MatchCriteria {
+	m.clauses = append(m.clauses, fmt.Sprintf("<IPV> saddr %s", NetsToAnonymousSet(nets)))
+	return m
+}
+
+func (m nftMatch) NotSourceNets(nets []string) generictables.MatchCriteria {
+	m.clauses = append(m.clauses, fmt.Sprintf("<IPV> saddr != %s", NetsToAnonymousSet(nets)))
+	return m
+}
+
+func (m nftMatch) DestNets(nets []string) generictables.MatchCriteria {
+	m.clauses = append(m.clauses, fmt.Sprintf("<IPV> daddr %s", NetsToAnonymousSet(nets)))
+	return m
+}
+
+func (m nftMatch) NotDestNets(nets []string) generictables.MatchCriteria {
+	m.clauses = append(m.clauses, fmt.Sprintf("<IPV> daddr != %s", NetsToAnonymousSet(nets)))
+	return m
+}
+
 func (m nftMatch) SourceIPSet(name string) generictables.MatchCriteria {
 	m.clauses = append(m.clauses, fmt.Sprintf("<IPV> saddr @%s", LegalizeSetName(name)))
 	return m
@@ -334,6 +400,13 @@ func (m nftMatch) SourceIPPortSet(name string) generictables.MatchCriteria {
 	return m
 }
 
+func (m nftMatch) SourceIPDestPortSet(name string) generictables.MatchCriteria {
+	// The set includes the IP, protocol, and port, in that order.  The set's members are the same
+	// as for a destination IP and port set; only the match differs.
+	m.clauses = append(m.clauses, fmt.Sprintf("<IPV> saddr . meta l4proto . th dport @%s", LegalizeSetName(name)))
+	return m
+}
+
 func (m nftMatch) NotSourceIPPortSet(name string) generictables.MatchCriteria {
 	// IPPort sets include the IP, protocol, and port, in that order.
 	// Note that "th sport" is only compatible with protocols that have their destination port in
@@ -497,3 +570,13 @@ func PortRangesToMultiport(ports []*proto.PortRange) string {
 	portsString := strings.Join(portFragments, ", ")
 	return fmt.Sprintf("{ %s }", portsString)
 }
+
+// NetsToAnonymousSet converts a list of CIDRs to an anonymous set suitable for inline use in nftables
+// rules.  nft implicitly makes anonymous sets of CIDRs interval sets and merges overlapping elements,
+// so the CIDRs don't need to be disjoint.  A single CIDR is rendered without the braces.
+func NetsToAnonymousSet(nets []string) string {
+	if len(nets) == 1 {
+		return nets[0]
+	}
+	return fmt.Sprintf("{ %s }", strings.Join(nets, ", "))
+}
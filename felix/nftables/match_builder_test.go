@@ -63,6 +63,7 @@ var _ = DescribeTable("MatchBuilder",
 
 	// Conntrack.
 	Entry("ConntrackState", Match().ConntrackState("INVALID"), "ct state invalid"),
+	Entry("ConnLimitAbove", Match().ConnLimitAbove("abcd-EFG_1234", 10), "meter cali-connlimit-abcd-EFG_1234 { ip saddr ct count over 10 }"),
 
 	// Interfaces.
 	Entry("InInterface", Match().InInterface("tap1234abcd"), "iifname tap1234abcd"),
@@ -87,6 +88,10 @@ var _ = DescribeTable("MatchBuilder",
 	Entry("NotSourceNet", Match().NotSourceNet("10.0.0.4"), "ip saddr != 10.0.0.4"),
 	Entry("DestNet", Match().DestNet("10.0.0.4"), "ip daddr 10.0.0.4"),
 	Entry("NotDestNet", Match().NotDestNet("10.0.0.4"), "ip daddr != 10.0.0.4"),
+	Entry("SourceNets", Match().(nftables.NFTMatchCriteria).SourceNets([]string{"10.0.0.0/24", "10.1.0.1"}), "ip saddr { 10.0.0.0/24, 10.1.0.1 }"),
+	Entry("NotSourceNets", Match().(nftables.NFTMatchCriteria).NotSourceNets([]string{"10.0.0.0/24", "10.1.0.1"}), "ip saddr != { 10.0.0.0/24, 10.1.0.1 }"),
+	Entry("DestNets", Match().(nftables.NFTMatchCriteria).DestNets([]string{"10.0.0.0/24", "10.1.0.1"}), "ip daddr { 10.0.0.0/24, 10.1.0.1 }"),
+	Entry("NotDestNets", Match().(nftables.NFTMatchCriteria).NotDestNets([]string{"10.0.0.0/24"}), "ip daddr != 10.0.0.0/24"),
 
 	// IP sets.
 	Entry("SourceIPSet", Match().SourceIPSet("calits:12345abc-_"), "ip saddr @calits-12345abc-_"),
@@ -99,6 +104,7 @@ var _ = DescribeTable("MatchBuilder",
 	Entry("NotSourceIPPortSet", Match().NotSourceIPPortSet("calitn:12345abc-_"), "ip saddr . meta l4proto . th sport != @calitn-12345abc-_"),
 	Entry("DestIPPortSet", Match().DestIPPortSet("calitn:12345abc-_"), "ip daddr . meta l4proto . th dport @calitn-12345abc-_"),
 	Entry("NotDestIPPortSet", Match().NotDestIPPortSet("calitn:12345abc-_"), "ip daddr . meta l4proto . th dport != @calitn-12345abc-_"),
+	Entry("SourceIPDestPortSet", Match().(nftables.NFTMatchCriteria).SourceIPDestPortSet("calitn:12345abc-_"), "ip saddr . meta l4proto . th dport @calitn-12345abc-_"),
 
 	// Ports.
 	Entry("SourcePorts", Match().Protocol("tcp").SourcePorts(1234, 5678), "meta l4proto tcp tcp sport { 1234, 5678 }"),
@@ -150,11 +156,13 @@ var _ = DescribeTable("IPSetNames",
 	Entry("NotSourceIPPortSet", Match().NotSourceIPPortSet("calits:12345abc-_"), []string{"calits-12345abc-_"}),
 	Entry("DestIPPortSet", Match().DestIPPortSet("calits:12345abc-_"), []string{"calits-12345abc-_"}),
 	Entry("NotDestIPPortSet", Match().NotDestIPPortSet("calits:12345abc-_"), []string{"calits-12345abc-_"}),
+	Entry("SourceIPDestPortSet", Match().(nftables.NFTMatchCriteria).SourceIPDestPortSet("calits:12345abc-_"), []string{"calits-12345abc-_"}),
 
 	// No IP set matches.
 	Entry("empty match", Match(), nil),
 	Entry("ICMPType", Match().ICMPType(123), nil),
 	Entry("SourceNet", Match().SourceNet("10.0.0.0/24"), nil),
+	Entry("SourceNets", Match().(nftables.NFTMatchCriteria).SourceNets([]string{"10.0.0.0/24", "10.1.0.0/24"}), nil),
 
 	// Multiple IP set matches.
 	Entry("Multiple matches", Match().SourceIPSet("calits:12345abc-_").DestIPSet("calits:54321cba-_"), []string{"calits-12345abc-_", "calits-54321cba-_"}),
@@ -36,6 +36,7 @@ var (
 	_ SetMember = simpleMember("")
 	_ SetMember = v4IPPortMember{}
 	_ SetMember = v6IPPortMember{}
+	_ SetMember = netPortMember{}
 	_ SetMember = netNet{}
 	_ SetMember = unknownMember{}
 )
@@ -106,6 +107,35 @@ func (p v6IPPortMember) String() string {
 	return fmt.Sprintf("%s,%s:%d", p.IP, p.Protocol, p.Port)
 }
 
+// netPortMember represents a CIDR, protocol and range of ports.  It is used for the members of IP
+// and port sets that don't fit in the more compact v4IPPortMember and v6IPPortMember.
+type netPortMember struct {
+	CIDR     ip.CIDR
+	Protocol string
+	Port     uint16
+	PortMax  uint16
+}
+
+func (p netPortMember) Key() []string {
+	addr := p.CIDR.String()
+	if p.CIDR.IsSingleAddress() {
+		addr = p.CIDR.Addr().String()
+	}
+	return []string{addr, p.Protocol, p.portString()}
+}
+
+func (p netPortMember) String() string {
+	// Format it back into Felix's internal representation.
+	return fmt.Sprintf("%s,%s:%s", p.Key()[0], p.Protocol, p.portString())
+}
+
+func (p netPortMember) portString() string {
+	if p.PortMax > p.Port {
+		return fmt.Sprintf("%d-%d", p.Port, p.PortMax)
+	}
+	return strconv.Itoa(int(p.Port))
+}
+
 func UnknownMember(k []string) SetMember {
 	logrus.WithField("key", k).Warn("Unknown member type")
 	return unknownMember{
@@ -203,6 +203,11 @@ type nftablesTable struct {
 
 	inSyncWithDataPlane bool
 
+	// connLimitMetersMayLeak is set when a rule that used a connlimit meter may have been removed
+	// and so the meter may need to be deleted.  The kernel only allows a meter to be deleted once
+	// no rules refer to it, so the meters are cleaned up after the rules have been updated.
+	connLimitMetersMayLeak bool
+
 	// chainToDataplaneHashes contains the rule hashes that we think are in the dataplane.
 	// it is updated when we write to the dataplane but it can also be read back and compared
 	// to what we calculate from chainToContents.
@@ -370,6 +375,9 @@ func NewTable(
 		opReporter:     options.OpRecorder,
 
 		contextTimeout: defaultTimeout,
+
+		// Clean up any meters left over from a previous run.
+		connLimitMetersMayLeak: true,
 	}
 
 	if options.OnStillAlive != nil {
@@ -446,6 +454,9 @@ func (t *nftablesTable) UpdateChain(chain *generictables.Chain) {
 	if oldChain := t.chainNameToChain[chain.Name]; oldChain != nil {
 		oldNumRules = len(oldChain.Rules)
 		t.maybeDecrefReferredChains(chain.Name, oldChain.Rules)
+		if len(ConnLimitMeterNames(oldChain.Rules)) > 0 {
+			t.connLimitMetersMayLeak = true
+		}
 	}
 	t.chainNameToChain[chain.Name] = chain
 	numRulesDelta := len(chain.Rules) - oldNumRules
@@ -466,6 +477,9 @@ func (t *nftablesTable) RemoveChainByName(name string) {
 	if oldChain, known := t.chainNameToChain[name]; known {
 		t.gaugeNumRules.Sub(float64(len(oldChain.Rules)))
 		t.maybeDecrefReferredChains(name, oldChain.Rules)
+		if len(ConnLimitMeterNames(oldChain.Rules)) > 0 {
+			t.connLimitMetersMayLeak = true
+		}
 		delete(t.chainNameToChain, name)
 		if t.chainIsReferenced(name) {
 			t.dirtyChains.Add(name)
@@ -1048,6 +1062,10 @@ func (t *nftablesTable) applyUpdates() error {
 	}
 	t.chainToFullRules = newChainToFullRules
 
+	if t.connLimitMetersMayLeak {
+		t.cleanUpConnLimitMeters()
+	}
+
 	// Invalidate the in-memory dataplane state so that we reload on the next write. This ensures we have the correct handles
 	// in-memory for each of the objects we've just written. nftables requires an object's handle in order to
 	// perform update or delete operations.
@@ -1055,6 +1073,43 @@ func (t *nftablesTable) applyUpdates() error {
 	return nil
 }
 
+// cleanUpConnLimitMeters deletes the connlimit meters that are no longer used by any of our rules.
+// It runs in its own transaction so that a failure doesn't hold up the rule updates; if it fails,
+// we try again after the next update.
+func (t *nftablesTable) cleanUpConnLimitMeters() {
+	inUse := set.New[string]()
+	for chainName, chain := range t.chainNameToChain {
+		if _, programmed := t.chainToDataplaneHashes[chainName]; !programmed {
+			continue
+		}
+		inUse.AddAll(ConnLimitMeterNames(chain.Rules))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.contextTimeout)
+	defer cancel()
+	sets, err := t.nft.List(ctx, "set")
+	if err != nil && !knftables.IsNotFound(err) {
+		t.logCxt.WithError(err).Warn("Failed to list sets, will retry connlimit meter cleanup later")
+		return
+	}
+
+	tx := t.nft.NewTransaction()
+	for _, name := range sets {
+		if !strings.HasPrefix(name, ConnLimitMeterPrefix) || inUse.Contains(name) {
+			continue
+		}
+		t.logCxt.WithField("meter", name).Debug("Deleting connlimit meter that is no longer used")
+		tx.Delete(&knftables.Set{Name: name})
+	}
+	if tx.NumOperations() > 0 {
+		if err := t.runTransaction(tx); err != nil {
+			t.logCxt.WithError(err).Warn("Failed to delete connlimit meters, will retry later")
+			return
+		}
+	}
+	t.connLimitMetersMayLeak = false
+}
+
 func (t *nftablesTable) runTransaction(tx *knftables.Transaction) error {
 	startTime := t.timeNow()
 	defer func() {
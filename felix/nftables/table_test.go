@@ -127,6 +127,31 @@ var _ = Describe("Table with an empty dataplane", func() {
 		Expect(chains).NotTo(ContainElement(chain.Name))
 	})
 
+	It("should delete connlimit meters that are no longer used", func() {
+		tx := f.NewTransaction()
+		tx.Add(&knftables.Table{})
+		tx.Add(&knftables.Set{Name: "cali-connlimit-old", Type: "ipv4_addr"})
+		tx.Add(&knftables.Set{Name: "cali-connlimit-rule1", Type: "ipv4_addr"})
+		Expect(f.Run(context.TODO(), tx)).To(Succeed())
+
+		table.UpdateChain(&generictables.Chain{
+			Name: "filter-FORWARD",
+			Rules: []generictables.Rule{
+				{Match: Match().ConnLimitAbove("rule1", 10), Action: DropAction{}},
+			},
+		})
+		table.Apply()
+		sets, err := f.List(context.TODO(), "set")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sets).To(ConsistOf("cali-connlimit-rule1"))
+
+		table.UpdateChain(&generictables.Chain{Name: "filter-FORWARD"})
+		table.Apply()
+		sets, err = f.List(context.TODO(), "set")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sets).To(BeEmpty())
+	})
+
 	It("should ignore delete of nonexistent chain", func() {
 		// Apply the base chains.
 		table.Apply()
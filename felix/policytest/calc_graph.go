@@ -0,0 +1,430 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policytest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	log "github.com/sirupsen/logrus"
+	kapiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/felix/config"
+	"github.com/projectcalico/calico/felix/proto"
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/conversion"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/syncersv1/updateprocessors"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/watchersyncer"
+	"github.com/projectcalico/calico/libcalico-go/lib/names"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+	validator "github.com/projectcalico/calico/libcalico-go/lib/validator/v3"
+)
+
+// hostname is the name of the node that all the endpoints are on.
+const hostname = "policy-test"
+
+// calcState is the state that the calculation graph computes for the endpoints.
+type calcState struct {
+	policies  map[proto.PolicyID]*proto.Policy
+	profiles  map[string]*proto.Profile
+	ipSets    map[string]set.Set[string]
+	endpoints map[string]*proto.WorkloadEndpoint
+}
+
+// buildCalcState feeds the suite's resources and endpoints through the calculation graph, and returns
+// the policies, profiles, IP sets and endpoints that it computes.
+func buildCalcState(s *Suite) (*calcState, error) {
+	updates, err := suiteUpdates(s)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &calcState{
+		policies:  map[proto.PolicyID]*proto.Policy{},
+		profiles:  map[string]*proto.Profile{},
+		ipSets:    map[string]set.Set[string]{},
+		endpoints: map[string]*proto.WorkloadEndpoint{},
+	}
+	conf := config.New()
+	conf.FelixHostname = hostname
+	eventBuf := calc.NewEventSequencer(conf)
+	eventBuf.Callback = m.onEvent
+	cg := calc.NewCalculationGraph(eventBuf, conf, func() {})
+	cg.OnUpdates(updates)
+	cg.OnStatusUpdated(api.InSync)
+	cg.Flush()
+	eventBuf.Flush()
+	return m, nil
+}
+
+func (m *calcState) onEvent(msg interface{}) {
+	switch msg := msg.(type) {
+	case *proto.ActivePolicyUpdate:
+		m.policies[*msg.Id] = msg.Policy
+	case *proto.ActivePolicyRemove:
+		delete(m.policies, *msg.Id)
+	case *proto.ActiveProfileUpdate:
+		m.profiles[msg.Id.Name] = msg.Profile
+	case *proto.ActiveProfileRemove:
+		delete(m.profiles, msg.Id.Name)
+	case *proto.IPSetUpdate:
+		m.ipSets[msg.Id] = set.FromArray(msg.Members)
+	case *proto.IPSetDeltaUpdate:
+		members := m.ipSets[msg.Id]
+		members.AddAll(msg.AddedMembers)
+		for _, member := range msg.RemovedMembers {
+			members.Discard(member)
+		}
+	case *proto.IPSetRemove:
+		delete(m.ipSets, msg.Id)
+	case *proto.WorkloadEndpointUpdate:
+		m.endpoints[msg.Id.WorkloadId] = msg.Endpoint
+	case *proto.WorkloadEndpointRemove:
+		delete(m.endpoints, msg.Id.WorkloadId)
+	default:
+		log.WithField("msg", msg).Debug("Ignoring message from calculation graph")
+	}
+}
+
+// suiteUpdates converts the suite's resources and endpoints to the updates that the Felix syncer
+// would send for them, in the same way as the Calico API and Kubernetes datastore.
+func suiteUpdates(s *Suite) ([]api.Update, error) {
+	objs := append([]runtime.Object{}, s.Objects...)
+	for _, ns := range s.Namespaces {
+		objs = append(objs, &kapiv1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: ns.Name, Labels: ns.Labels},
+		})
+	}
+	for _, sa := range s.ServiceAccounts {
+		objs = append(objs, &kapiv1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: sa.Name, Namespace: namespaceOrDefault(sa.Namespace), Labels: sa.Labels},
+		})
+	}
+	for _, ep := range s.Endpoints {
+		pod, err := endpointToPod(ep)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, pod)
+	}
+	return resourceUpdates(objs)
+}
+
+// resourceUpdates converts resources to the updates that the Felix syncer would send for them,
+// creating the namespaces, service accounts and default tier that they use but that don't exist.
+func resourceUpdates(objs []runtime.Object) ([]api.Update, error) {
+	c := &converter{
+		converter:           conversion.NewConverter(),
+		processors:          map[string]watchersyncer.SyncerUpdateProcessor{},
+		namespaces:          set.New[string](),
+		usedNamespaces:      set.New[string](),
+		serviceAccounts:     set.New[types.NamespacedName](),
+		usedServiceAccounts: set.New[types.NamespacedName](),
+		tiers:               set.New[string](),
+		usedTiers:           set.New[string](),
+	}
+	for _, obj := range objs {
+		if err := c.convert(obj); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.addImplicitResources(); err != nil {
+		return nil, err
+	}
+	return c.updates, nil
+}
+
+// converter converts resources to v1 model updates.
+type converter struct {
+	converter  conversion.Converter
+	processors map[string]watchersyncer.SyncerUpdateProcessor
+	updates    []api.Update
+
+	// The namespaces, service accounts and tiers that exist, and that are used.
+	namespaces, usedNamespaces           set.Set[string]
+	serviceAccounts, usedServiceAccounts set.Set[types.NamespacedName]
+	tiers, usedTiers                     set.Set[string]
+}
+
+func (c *converter) convert(obj runtime.Object) error {
+	switch obj := obj.(type) {
+	case *apiv3.NetworkPolicy:
+		p := obj.DeepCopy()
+		p.Namespace = namespaceOrDefault(p.Namespace)
+		defaultPolicyTypes(p.Spec.Ingress, p.Spec.Egress, &p.Spec.Types)
+		name, err := c.tieredPolicyName(p, p.Name, p.Spec.Tier)
+		if err != nil {
+			return err
+		}
+		p.Name = name
+		c.usedNamespaces.Add(p.Namespace)
+		return c.process(apiv3.KindNetworkPolicy, p.Namespace, p)
+	case *apiv3.GlobalNetworkPolicy:
+		p := obj.DeepCopy()
+		defaultPolicyTypes(p.Spec.Ingress, p.Spec.Egress, &p.Spec.Types)
+		name, err := c.tieredPolicyName(p, p.Name, p.Spec.Tier)
+		if err != nil {
+			return err
+		}
+		p.Name = name
+		return c.process(apiv3.KindGlobalNetworkPolicy, "", p)
+	case *apiv3.Tier:
+		c.tiers.Add(obj.Name)
+		return c.processValid(apiv3.KindTier, "", obj)
+	case *apiv3.NetworkSet:
+		ns := obj.DeepCopy()
+		ns.Namespace = namespaceOrDefault(ns.Namespace)
+		c.usedNamespaces.Add(ns.Namespace)
+		return c.processValid(apiv3.KindNetworkSet, ns.Namespace, ns)
+	case *apiv3.GlobalNetworkSet:
+		return c.processValid(apiv3.KindGlobalNetworkSet, "", obj)
+	case *apiv3.Profile:
+		return c.processValid(apiv3.KindProfile, "", obj)
+	case *networkingv1.NetworkPolicy:
+		np := obj.DeepCopy()
+		np.Namespace = namespaceOrDefault(np.Namespace)
+		kvp, err := c.converter.K8sNetworkPolicyToCalico(np)
+		if err != nil {
+			return fmt.Errorf("failed to convert Kubernetes NetworkPolicy %s/%s: %w", np.Namespace, np.Name, err)
+		}
+		c.usedNamespaces.Add(np.Namespace)
+		c.usedTiers.Add(names.DefaultTierName)
+		return c.processKVPair(kvp)
+	case *kapiv1.Namespace:
+		ns := obj.DeepCopy()
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		// The API server adds this label to every namespace.
+		ns.Labels[kapiv1.LabelMetadataName] = ns.Name
+		setUID(ns)
+		kvp, err := c.converter.NamespaceToProfile(ns)
+		if err != nil {
+			return err
+		}
+		c.namespaces.Add(ns.Name)
+		return c.processKVPair(kvp)
+	case *kapiv1.ServiceAccount:
+		sa := obj.DeepCopy()
+		sa.Namespace = namespaceOrDefault(sa.Namespace)
+		setUID(sa)
+		kvp, err := c.converter.ServiceAccountToProfile(sa)
+		if err != nil {
+			return err
+		}
+		c.serviceAccounts.Add(types.NamespacedName{Namespace: sa.Namespace, Name: sa.Name})
+		return c.processKVPair(kvp)
+	case *kapiv1.Pod:
+		pod := obj.DeepCopy()
+		pod.Namespace = namespaceOrDefault(pod.Namespace)
+		pod.Spec.NodeName = hostname
+		kvps, err := c.converter.PodToWorkloadEndpoints(pod)
+		if err != nil {
+			return fmt.Errorf("failed to convert pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+		c.usedNamespaces.Add(pod.Namespace)
+		if pod.Spec.ServiceAccountName != "" {
+			c.usedServiceAccounts.Add(types.NamespacedName{Namespace: pod.Namespace, Name: pod.Spec.ServiceAccountName})
+		}
+		for _, kvp := range kvps {
+			if err := c.processKVPair(kvp); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported resource type %T", obj)
+}
+
+// addImplicitResources creates the namespaces, service accounts and tiers that are used but that
+// don't exist.
+func (c *converter) addImplicitResources() error {
+	for _, ns := range sortedStrings(c.usedNamespaces) {
+		if !c.namespaces.Contains(ns) {
+			if err := c.convert(&kapiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}); err != nil {
+				return err
+			}
+		}
+	}
+	usedServiceAccounts := c.usedServiceAccounts.Slice()
+	sort.Slice(usedServiceAccounts, func(i, j int) bool {
+		return usedServiceAccounts[i].String() < usedServiceAccounts[j].String()
+	})
+	for _, sa := range usedServiceAccounts {
+		if !c.serviceAccounts.Contains(sa) {
+			if err := c.convert(&kapiv1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: sa.Name, Namespace: sa.Namespace}}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, tier := range sortedStrings(c.usedTiers) {
+		if c.tiers.Contains(tier) {
+			continue
+		}
+		if tier != names.DefaultTierName {
+			return fmt.Errorf("tier %s does not exist", tier)
+		}
+		t := apiv3.NewTier()
+		t.Name = names.DefaultTierName
+		order := apiv3.DefaultTierOrder
+		t.Spec.Order = &order
+		if err := c.convert(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tieredPolicyName validates a Calico policy and returns its name in the datastore, in the same
+// way as the Calico API.
+func (c *converter) tieredPolicyName(p interface{}, name, tier string) (string, error) {
+	if err := validator.Validate(p); err != nil {
+		return "", err
+	}
+	c.usedTiers.Add(names.TierOrDefault(tier))
+	return names.BackendTieredPolicyName(name, tier)
+}
+
+func (c *converter) processValid(kind, namespace string, res metav1.Object) error {
+	if err := validator.Validate(res); err != nil {
+		return err
+	}
+	return c.process(kind, namespace, res)
+}
+
+func (c *converter) process(kind, namespace string, res metav1.Object) error {
+	return c.processKVPair(&model.KVPair{
+		Key:   model.ResourceKey{Kind: kind, Name: res.GetName(), Namespace: namespace},
+		Value: res,
+	})
+}
+
+// processKVPair converts a v3 resource to v1 model updates with the same update processor as the
+// Felix syncer.
+func (c *converter) processKVPair(kvp *model.KVPair) error {
+	kind := kvp.Key.(model.ResourceKey).Kind
+	p, ok := c.processors[kind]
+	if !ok {
+		switch kind {
+		case apiv3.KindNetworkPolicy:
+			p = updateprocessors.NewNetworkPolicyUpdateProcessor()
+		case apiv3.KindGlobalNetworkPolicy:
+			p = updateprocessors.NewGlobalNetworkPolicyUpdateProcessor()
+		case apiv3.KindTier:
+			p = updateprocessors.NewTierUpdateProcessor()
+		case apiv3.KindNetworkSet:
+			p = updateprocessors.NewNetworkSetUpdateProcessor()
+		case apiv3.KindGlobalNetworkSet:
+			p = updateprocessors.NewGlobalNetworkSetUpdateProcessor()
+		case apiv3.KindProfile:
+			p = updateprocessors.NewProfileUpdateProcessor()
+		case libapiv3.KindWorkloadEndpoint:
+			p = updateprocessors.NewWorkloadEndpointUpdateProcessor()
+		default:
+			return fmt.Errorf("no update processor for %s", kind)
+		}
+		c.processors[kind] = p
+	}
+	kvps, err := p.Process(kvp)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s %s: %w", kind, kvp.Key.(model.ResourceKey).Name, err)
+	}
+	for _, kvp := range kvps {
+		c.updates = append(c.updates, api.Update{KVPair: *kvp, UpdateType: api.UpdateTypeKVNew})
+	}
+	return nil
+}
+
+// endpointToPod converts an Endpoint to the pod that it represents.
+func endpointToPod(ep Endpoint) (*kapiv1.Pod, error) {
+	pod := &kapiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ep.Name,
+			Namespace: namespaceOrDefault(ep.Namespace),
+			Labels:    ep.Labels,
+		},
+		Spec: kapiv1.PodSpec{
+			ServiceAccountName: ep.ServiceAccount,
+		},
+	}
+	container := kapiv1.Container{Name: ep.Name}
+	for _, port := range ep.Ports {
+		protocol := kapiv1.Protocol(strings.ToUpper(port.Protocol))
+		switch protocol {
+		case "":
+			protocol = kapiv1.ProtocolTCP
+		case kapiv1.ProtocolTCP, kapiv1.ProtocolUDP, kapiv1.ProtocolSCTP:
+		default:
+			return nil, fmt.Errorf("endpoint %s has port %s with unsupported protocol %q", ep.Name, port.Name, port.Protocol)
+		}
+		container.Ports = append(container.Ports, kapiv1.ContainerPort{
+			Name:          port.Name,
+			Protocol:      protocol,
+			ContainerPort: port.Port,
+		})
+	}
+	pod.Spec.Containers = []kapiv1.Container{container}
+	for _, ip := range ep.IPs {
+		pod.Status.PodIPs = append(pod.Status.PodIPs, kapiv1.PodIP{IP: ip})
+	}
+	if len(ep.IPs) > 0 {
+		pod.Status.PodIP = ep.IPs[0]
+	}
+	return pod, nil
+}
+
+// defaultPolicyTypes defaults the types of a Calico policy in the same way as the Calico API.
+func defaultPolicyTypes(ingress, egress []apiv3.Rule, policyTypes *[]apiv3.PolicyType) {
+	if len(*policyTypes) > 0 {
+		return
+	}
+	if len(egress) == 0 {
+		*policyTypes = []apiv3.PolicyType{apiv3.PolicyTypeIngress}
+	} else if len(ingress) == 0 {
+		*policyTypes = []apiv3.PolicyType{apiv3.PolicyTypeEgress}
+	} else {
+		*policyTypes = []apiv3.PolicyType{apiv3.PolicyTypeIngress, apiv3.PolicyTypeEgress}
+	}
+}
+
+// setUID sets the UID of a resource that doesn't have one, as the API server would.
+func setUID(res metav1.Object) {
+	if res.GetUID() == "" {
+		res.SetUID(types.UID(uuid.NewString()))
+	}
+}
+
+func sortedStrings(s set.Set[string]) []string {
+	sorted := s.Slice()
+	sort.Strings(sorted)
+	return sorted
+}
+
+func namespaceOrDefault(ns string) string {
+	if ns == "" {
+		return "default"
+	}
+	return ns
+}
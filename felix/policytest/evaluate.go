@@ -0,0 +1,608 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policytest
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+const (
+	protocolICMP   = 1
+	protocolTCP    = 6
+	protocolUDP    = 17
+	protocolICMPv6 = 58
+	protocolSCTP   = 132
+
+	// defaultSrcPort is the first of the dynamic ports.
+	defaultSrcPort = 49152
+)
+
+var protocolNumbers = map[string]uint8{
+	"icmp":    protocolICMP,
+	"tcp":     protocolTCP,
+	"udp":     protocolUDP,
+	"icmpv6":  protocolICMPv6,
+	"sctp":    protocolSCTP,
+	"udplite": 136,
+}
+
+// Result is the outcome of a Test.
+type Result struct {
+	Test    Test
+	Verdict Verdict
+	// Egress is the decision of the source endpoint's egress policy, or nil if the source is not
+	// an endpoint.
+	Egress *Decision
+	// Ingress is the decision of the destination endpoint's ingress policy, or nil if the
+	// destination is not an endpoint.
+	Ingress *Decision
+}
+
+// Passed returns true if the verdict is the one that the test expects.
+func (r *Result) Passed() bool {
+	return r.Verdict == r.Test.Expect
+}
+
+// Decision is the verdict of one endpoint's policy in one direction, and the reason for it.
+type Decision struct {
+	Verdict Verdict
+	Reason  string
+}
+
+func (d *Decision) String() string {
+	if d == nil {
+		return "-"
+	}
+	return d.Reason
+}
+
+// Run runs the suite's tests.  It returns an error if the resources or endpoints are invalid, or if
+// a test is malformed, rather than a failed Result.
+func Run(s *Suite) ([]*Result, error) {
+	state, err := buildCalcState(s)
+	if err != nil {
+		return nil, err
+	}
+	var results []*Result
+	for _, t := range s.Tests {
+		r, err := state.evaluate(t)
+		if err != nil {
+			return nil, fmt.Errorf("test %q: %w", t.Name, err)
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// packet is the first packet of a Test's connection.
+type packet struct {
+	ipVersion        uint8
+	srcIP, dstIP     net.IP
+	protocol         uint8
+	srcPort, dstPort uint16
+	icmpType         uint8
+	icmpCode         uint8
+}
+
+func (p *packet) hasPorts() bool {
+	return p.protocol == protocolTCP || p.protocol == protocolUDP || p.protocol == protocolSCTP
+}
+
+func (p *packet) isICMP() bool {
+	if p.ipVersion == 4 {
+		return p.protocol == protocolICMP
+	}
+	return p.protocol == protocolICMPv6
+}
+
+func (m *calcState) evaluate(t Test) (*Result, error) {
+	switch t.Expect {
+	case Allow, Deny:
+	default:
+		return nil, fmt.Errorf("expect must be %s or %s", Allow, Deny)
+	}
+	srcEP, srcIPs, err := m.resolvePeer(t.From)
+	if err != nil {
+		return nil, fmt.Errorf("from: %w", err)
+	}
+	dstEP, dstIPs, err := m.resolvePeer(t.To)
+	if err != nil {
+		return nil, fmt.Errorf("to: %w", err)
+	}
+	if srcEP == nil && dstEP == nil {
+		return nil, fmt.Errorf("neither %s nor %s is an endpoint", t.From, t.To)
+	}
+
+	pkt, err := newPacket(t, srcIPs, dstIPs)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Result{Test: t, Verdict: Allow}
+	if srcEP != nil {
+		r.Egress = m.endpointDecision(srcEP, pkt, false)
+		if r.Egress.Verdict == Deny {
+			r.Verdict = Deny
+		}
+	}
+	if dstEP != nil {
+		r.Ingress = m.endpointDecision(dstEP, pkt, true)
+		if r.Ingress.Verdict == Deny {
+			r.Verdict = Deny
+		}
+	}
+	return r, nil
+}
+
+// resolvePeer returns the endpoint of a peer, if it is one, and its IPs.
+func (m *calcState) resolvePeer(p Peer) (*proto.WorkloadEndpoint, []net.IP, error) {
+	if (p.Endpoint == "") == (p.IP == "") {
+		return nil, nil, fmt.Errorf("exactly one of endpoint and ip must be set")
+	}
+	if p.IP != "" {
+		ip := net.ParseIP(p.IP)
+		if ip == nil {
+			return nil, nil, fmt.Errorf("invalid IP %q", p.IP)
+		}
+		return nil, []net.IP{ip}, nil
+	}
+
+	id := p.Endpoint
+	if !strings.Contains(id, "/") {
+		id = "default/" + id
+	}
+	ep, ok := m.endpoints[id]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown endpoint %s, or the endpoint has no IPs", p.Endpoint)
+	}
+	var ips []net.IP
+	for _, cidr := range append(append([]string{}, ep.Ipv4Nets...), ep.Ipv6Nets...) {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, nil, err
+		}
+		ips = append(ips, ip)
+	}
+	return ep, ips, nil
+}
+
+func newPacket(t Test, srcIPs, dstIPs []net.IP) (*packet, error) {
+	pkt := &packet{
+		srcPort: t.SrcPort,
+		dstPort: t.Port,
+	}
+
+	versions := []uint8{4, 6}
+	switch t.IPVersion {
+	case 0:
+	case 4, 6:
+		versions = []uint8{uint8(t.IPVersion)}
+	default:
+		return nil, fmt.Errorf("invalid IP version %d", t.IPVersion)
+	}
+	for _, v := range versions {
+		src, dst := ipOfVersion(srcIPs, v), ipOfVersion(dstIPs, v)
+		if src != nil && dst != nil {
+			pkt.ipVersion, pkt.srcIP, pkt.dstIP = v, src, dst
+			break
+		}
+	}
+	if pkt.ipVersion == 0 {
+		return nil, fmt.Errorf("%s and %s have no IPs of the same version", t.From, t.To)
+	}
+
+	protocol := t.Protocol
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	if n, err := strconv.ParseUint(protocol, 10, 8); err == nil {
+		pkt.protocol = uint8(n)
+	} else if n, ok := protocolNumbers[strings.ToLower(protocol)]; ok {
+		pkt.protocol = n
+	} else {
+		return nil, fmt.Errorf("unknown protocol %q", t.Protocol)
+	}
+
+	if pkt.hasPorts() {
+		if pkt.dstPort == 0 {
+			return nil, fmt.Errorf("port is required for protocol %s", protocol)
+		}
+		if pkt.srcPort == 0 {
+			pkt.srcPort = defaultSrcPort
+		}
+	} else if t.Port != 0 || t.SrcPort != 0 {
+		return nil, fmt.Errorf("protocol %s does not have ports", protocol)
+	}
+
+	if pkt.isICMP() {
+		// Default to an echo request.
+		pkt.icmpType = 8
+		if pkt.ipVersion == 6 {
+			pkt.icmpType = 128
+		}
+		if t.ICMPType != nil {
+			pkt.icmpType = *t.ICMPType
+		}
+		if t.ICMPCode != nil {
+			pkt.icmpCode = *t.ICMPCode
+		}
+	} else if t.ICMPType != nil || t.ICMPCode != nil {
+		return nil, fmt.Errorf("ICMP type and code require protocol ICMP for IPv4 or ICMPv6 for IPv6")
+	}
+	return pkt, nil
+}
+
+func ipOfVersion(ips []net.IP, version uint8) net.IP {
+	for _, ip := range ips {
+		if (ip.To4() != nil) == (version == 4) {
+			return ip
+		}
+	}
+	return nil
+}
+
+// endpointDecision applies an endpoint's policies and profiles in one direction, in the same way as
+// the endpoint chains that Felix renders:
+//
+//   - The tiers that have policies in that direction are applied in order.  Within a tier, the
+//     policies' rules are applied in order until one allows, denies or passes the packet.  If a
+//     rule allows the packet, it is allowed; if it denies it, or no rule in the tier matches, it
+//     is denied; and if it passes it, the next tier is applied.
+//   - If the last tier passes the packet, or there are no tiers, the endpoint's profiles are
+//     applied in order.  The packet is allowed if a profile allows it, and denied otherwise.
+func (m *calcState) endpointDecision(ep *proto.WorkloadEndpoint, pkt *packet, ingress bool) *Decision {
+	direction := "egress"
+	if ingress {
+		direction = "ingress"
+	}
+	for _, tier := range ep.Tiers {
+		policyNames := tier.EgressPolicies
+		if ingress {
+			policyNames = tier.IngressPolicies
+		}
+		if len(policyNames) == 0 {
+			continue
+		}
+		passed := false
+		for _, name := range policyNames {
+			policy := m.policies[proto.PolicyID{Tier: tier.Name, Name: name}]
+			if policy == nil {
+				continue
+			}
+			rules := policy.OutboundRules
+			if ingress {
+				rules = policy.InboundRules
+			}
+			action, i := m.firstMatch(rules, pkt)
+			if action == "" {
+				continue
+			}
+			reason := fmt.Sprintf("%s rule %d of policy %s in tier %s", direction, i+1, name, tier.Name)
+			switch action {
+			case "allow":
+				return &Decision{Verdict: Allow, Reason: "allowed by " + reason}
+			case "deny":
+				return &Decision{Verdict: Deny, Reason: "denied by " + reason}
+			}
+			passed = true
+			break
+		}
+		if !passed {
+			return &Decision{
+				Verdict: Deny,
+				Reason:  fmt.Sprintf("no %s policy in tier %s allowed or passed the packet", direction, tier.Name),
+			}
+		}
+	}
+
+	for _, name := range ep.ProfileIds {
+		profile := m.profiles[name]
+		if profile == nil {
+			continue
+		}
+		rules := profile.OutboundRules
+		if ingress {
+			rules = profile.InboundRules
+		}
+		action, i := m.firstMatch(rules, pkt)
+		switch action {
+		case "allow":
+			return &Decision{Verdict: Allow, Reason: fmt.Sprintf("allowed by %s rule %d of profile %s", direction, i+1, name)}
+		case "deny":
+			return &Decision{Verdict: Deny, Reason: fmt.Sprintf("denied by %s rule %d of profile %s", direction, i+1, name)}
+		}
+	}
+	return &Decision{Verdict: Deny, Reason: fmt.Sprintf("no %s policy or profile allowed the packet", direction)}
+}
+
+// firstMatch returns the action and index of the first rule that matches the packet and that
+// allows, denies or passes it, or "" if there is none.  Log rules don't stop the search.
+func (m *calcState) firstMatch(rules []*proto.Rule, pkt *packet) (string, int) {
+	for i, r := range rules {
+		if !m.ruleMatches(r, pkt) {
+			continue
+		}
+		switch r.Action {
+		case "", "allow":
+			return "allow", i
+		case "deny":
+			return "deny", i
+		case "next-tier", "pass":
+			return "pass", i
+		}
+	}
+	return "", 0
+}
+
+// ruleMatches returns true if a rule matches a packet, with the same semantics as the iptables and
+// nftables rules that Felix renders for it.  It can't use the rules package, which only builds on
+// Linux, as calicoctl uses this package; instead, the tests check that it agrees with the iptables
+// rules that the rules package renders.
+func (m *calcState) ruleMatches(r *proto.Rule, pkt *packet) bool {
+	r = filterRuleToIPVersion(pkt.ipVersion, r)
+	if r == nil {
+		return false
+	}
+
+	if r.Protocol != nil && !protocolMatches(r.Protocol, pkt.protocol) {
+		return false
+	}
+	if r.NotProtocol != nil && protocolMatches(r.NotProtocol, pkt.protocol) {
+		return false
+	}
+
+	if len(r.SrcNet) > 0 && !netsContain(r.SrcNet, pkt.srcIP) {
+		return false
+	}
+	if netsContain(r.NotSrcNet, pkt.srcIP) {
+		return false
+	}
+	if len(r.DstNet) > 0 && !netsContain(r.DstNet, pkt.dstIP) {
+		return false
+	}
+	if netsContain(r.NotDstNet, pkt.dstIP) {
+		return false
+	}
+
+	for _, id := range r.SrcIpSetIds {
+		if !m.ipSetContains(id, pkt.srcIP) {
+			return false
+		}
+	}
+	for _, id := range r.NotSrcIpSetIds {
+		if m.ipSetContains(id, pkt.srcIP) {
+			return false
+		}
+	}
+	for _, id := range r.DstIpSetIds {
+		if !m.ipSetContains(id, pkt.dstIP) {
+			return false
+		}
+	}
+	for _, id := range r.NotDstIpSetIds {
+		if m.ipSetContains(id, pkt.dstIP) {
+			return false
+		}
+	}
+	for _, id := range r.DstIpPortSetIds {
+		if !m.ipPortSetContains(id, pkt.dstIP, pkt.protocol, pkt.dstPort) {
+			return false
+		}
+	}
+
+	// Numeric and named ports are or-ed together.
+	if !m.portsMatch(r.SrcPorts, r.SrcNamedPortIpSetIds, r.NotSrcPorts, r.NotSrcNamedPortIpSetIds, pkt.srcIP, pkt.srcPort, pkt) {
+		return false
+	}
+	if !m.portsMatch(r.DstPorts, r.DstNamedPortIpSetIds, r.NotDstPorts, r.NotDstNamedPortIpSetIds, pkt.dstIP, pkt.dstPort, pkt) {
+		return false
+	}
+
+	if r.Icmp != nil {
+		if !pkt.isICMP() {
+			return false
+		}
+		switch icmp := r.Icmp.(type) {
+		case *proto.Rule_IcmpType:
+			if uint8(icmp.IcmpType) != pkt.icmpType {
+				return false
+			}
+		case *proto.Rule_IcmpTypeCode:
+			if uint8(icmp.IcmpTypeCode.Type) != pkt.icmpType || uint8(icmp.IcmpTypeCode.Code) != pkt.icmpCode {
+				return false
+			}
+		}
+	}
+	if r.NotIcmp != nil {
+		if !pkt.isICMP() {
+			return false
+		}
+		switch icmp := r.NotIcmp.(type) {
+		case *proto.Rule_NotIcmpType:
+			if uint8(icmp.NotIcmpType) == pkt.icmpType {
+				return false
+			}
+		case *proto.Rule_NotIcmpTypeCode:
+			if uint8(icmp.NotIcmpTypeCode.Type) == pkt.icmpType && uint8(icmp.NotIcmpTypeCode.Code) == pkt.icmpCode {
+				return false
+			}
+		}
+	}
+
+	// A connection limit only matches once there are more connections than the limit, so it
+	// never matches a single connection.
+	return r.ConnLimit <= 0
+}
+
+func (m *calcState) portsMatch(
+	ports []*proto.PortRange,
+	namedPortIPSetIDs []string,
+	notPorts []*proto.PortRange,
+	notNamedPortIPSetIDs []string,
+	ip net.IP,
+	port uint16,
+	pkt *packet,
+) bool {
+	if len(ports)+len(namedPortIPSetIDs)+len(notPorts)+len(notNamedPortIPSetIDs) == 0 {
+		return true
+	}
+	if !pkt.hasPorts() {
+		// Port matches require a protocol that has ports.
+		return false
+	}
+	if len(ports)+len(namedPortIPSetIDs) > 0 {
+		matched := portRangesContain(ports, port)
+		for _, id := range namedPortIPSetIDs {
+			matched = matched || m.ipPortSetContains(id, ip, pkt.protocol, port)
+		}
+		if !matched {
+			return false
+		}
+	}
+	if portRangesContain(notPorts, port) {
+		return false
+	}
+	for _, id := range notNamedPortIPSetIDs {
+		if m.ipPortSetContains(id, ip, pkt.protocol, port) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *calcState) ipSetContains(id string, ip net.IP) bool {
+	members := m.ipSets[id]
+	if members == nil {
+		return false
+	}
+	contains := false
+	members.Iter(func(member string) error {
+		if _, cidr, err := net.ParseCIDR(member); err == nil && cidr.Contains(ip) {
+			contains = true
+			return set.StopIteration
+		}
+		return nil
+	})
+	return contains
+}
+
+func (m *calcState) ipPortSetContains(id string, ip net.IP, protocol uint8, port uint16) bool {
+	members := m.ipSets[id]
+	if members == nil {
+		return false
+	}
+	contains := false
+	members.Iter(func(member string) error {
+		// Members have the form "<IP>,<protocol>:<port>".
+		memberIP, protoPort, ok := strings.Cut(member, ",")
+		if !ok {
+			return nil
+		}
+		memberProto, memberPort, ok := strings.Cut(protoPort, ":")
+		if !ok {
+			return nil
+		}
+		if net.ParseIP(memberIP).Equal(ip) &&
+			protocolNumbers[memberProto] == protocol &&
+			memberPort == strconv.Itoa(int(port)) {
+			contains = true
+			return set.StopIteration
+		}
+		return nil
+	})
+	return contains
+}
+
+func protocolMatches(p *proto.Protocol, protocol uint8) bool {
+	return protocolNumber(p) == protocol
+}
+
+func protocolNumber(p *proto.Protocol) uint8 {
+	switch p := p.NumberOrName.(type) {
+	case *proto.Protocol_Number:
+		return uint8(p.Number)
+	case *proto.Protocol_Name:
+		return protocolNumbers[strings.ToLower(p.Name)]
+	}
+	return 0
+}
+
+func netsContain(nets []string, ip net.IP) bool {
+	for _, n := range nets {
+		if cidr := parseNet(n); cidr != nil && cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNet(n string) *net.IPNet {
+	_, cidr, err := net.ParseCIDR(n)
+	if err == nil {
+		return cidr
+	}
+	ip := net.ParseIP(n)
+	if ip == nil {
+		return nil
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}
+}
+
+func portRangesContain(ranges []*proto.PortRange, port uint16) bool {
+	for _, r := range ranges {
+		if int32(port) >= r.First && int32(port) <= r.Last {
+			return true
+		}
+	}
+	return false
+}
+
+// filterRuleToIPVersion filters a rule's CIDRs to one IP version, or returns nil if the rule
+// doesn't apply to that version, in the same way as rules.FilterRuleToIPVersion.
+func filterRuleToIPVersion(ipVersion uint8, r *proto.Rule) *proto.Rule {
+	if r.IpVersion != 0 && r.IpVersion != proto.IPVersion(ipVersion) {
+		return nil
+	}
+	ruleCopy := *r
+	var filteredAll bool
+	for _, nets := range []*[]string{&ruleCopy.SrcNet, &ruleCopy.NotSrcNet, &ruleCopy.DstNet, &ruleCopy.NotDstNet} {
+		*nets, filteredAll = filterNets(*nets, ipVersion)
+		if filteredAll {
+			return nil
+		}
+	}
+	return &ruleCopy
+}
+
+func filterNets(nets []string, ipVersion uint8) (filtered []string, filteredAll bool) {
+	if len(nets) == 0 {
+		return nets, false
+	}
+	for _, n := range nets {
+		if strings.Contains(n, ":") == (ipVersion == 6) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered, len(filtered) == 0
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policytest
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/generictables"
+	"github.com/projectcalico/calico/felix/ipsets"
+	"github.com/projectcalico/calico/felix/iptables"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/felix/rules"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+const (
+	markAccept   = 0x8
+	markPass     = 0x10
+	markScratch0 = 0x20
+	markScratch1 = 0x40
+)
+
+// The evaluator doesn't use the rules package, which only builds on Linux, so that calicoctl can
+// use it.  These tests check that it agrees with the iptables rules that the rules package renders,
+// by running the rendered rules against the same packets.
+var _ = Describe("Evaluating rules", func() {
+	renderer := rules.NewRenderer(rules.Config{
+		IPSetConfigV4:        ipsets.NewIPVersionConfig(ipsets.IPFamilyV4, "cali", nil, nil),
+		IPSetConfigV6:        ipsets.NewIPVersionConfig(ipsets.IPFamilyV6, "cali", nil, nil),
+		IptablesMarkAccept:   markAccept,
+		IptablesMarkPass:     markPass,
+		IptablesMarkScratch0: markScratch0,
+		IptablesMarkScratch1: markScratch1,
+		IptablesMarkEndpoint: 0xff00,
+	})
+	state := &calcState{ipSets: map[string]set.Set[string]{
+		"clients":  set.From("10.0.0.1/32", "fd00::1/128"),
+		"servers":  set.From("10.0.1.0/24", "fd00:1::/64"),
+		"http":     set.From("10.0.1.1,tcp:8080", "fd00:1::1,tcp:8080"),
+		"dns":      set.From("10.0.1.1,udp:53"),
+		"svc-port": set.From("10.0.1.1,tcp:80"),
+	}}
+
+	var packets []*packet
+	for _, p := range []struct {
+		src, dst         string
+		protocol         uint8
+		srcPort, dstPort uint16
+		icmpType         uint8
+	}{
+		{"10.0.0.1", "10.0.1.1", protocolTCP, 40000, 80, 0},
+		{"10.0.0.1", "10.0.1.1", protocolTCP, 1, 8080, 0},
+		{"10.0.0.2", "10.0.1.1", protocolTCP, 40000, 443, 0},
+		{"10.0.0.2", "10.0.2.1", protocolTCP, 40000, 95, 0},
+		{"10.0.0.1", "10.0.1.1", protocolUDP, 40000, 53, 0},
+		{"10.0.0.1", "10.0.1.2", protocolUDP, 40000, 8080, 0},
+		{"10.0.0.1", "10.0.1.1", protocolICMP, 0, 0, 8},
+		{"10.0.0.1", "10.0.1.1", protocolICMP, 0, 0, 3},
+		{"10.0.0.3", "192.0.2.1", protocolSCTP, 40000, 80, 0},
+		{"fd00::1", "fd00:1::1", protocolTCP, 40000, 8080, 0},
+		{"fd00::2", "fd00:2::1", protocolTCP, 40000, 80, 0},
+		{"fd00::1", "fd00:1::1", protocolICMPv6, 0, 0, 128},
+	} {
+		pkt := &packet{
+			ipVersion: 4,
+			srcIP:     net.ParseIP(p.src),
+			dstIP:     net.ParseIP(p.dst),
+			protocol:  p.protocol,
+			srcPort:   p.srcPort,
+			dstPort:   p.dstPort,
+			icmpType:  p.icmpType,
+		}
+		if pkt.srcIP.To4() == nil {
+			pkt.ipVersion = 6
+		} else {
+			pkt.srcIP, pkt.dstIP = pkt.srcIP.To4(), pkt.dstIP.To4()
+		}
+		packets = append(packets, pkt)
+	}
+
+	DescribeTable("should agree with the rendered iptables rules",
+		func(r *proto.Rule) {
+			r.Action = "allow"
+			matches := 0
+			for _, pkt := range packets {
+				rendered := renderer.ProtoRuleToIptablesRules(r, pkt.ipVersion)
+				expected, err := iptablesRulesMatch(state, rendered, pkt)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.ruleMatches(r, pkt)).To(Equal(expected),
+					"packet %+v, rendered rules %s", *pkt, renderedRules(rendered))
+				if expected {
+					matches++
+				}
+			}
+			// Check that the rule is exercised by the packets.
+			Expect(matches).To(BeNumerically(">", 0))
+			Expect(matches).To(BeNumerically("<", len(packets)))
+		},
+		Entry("protocol", &proto.Rule{Protocol: protoName("tcp")}),
+		Entry("protocol number", &proto.Rule{Protocol: protoNumber(17)}),
+		Entry("not protocol", &proto.Rule{NotProtocol: protoName("tcp")}),
+		Entry("IP version", &proto.Rule{IpVersion: proto.IPVersion_IPV6}),
+		Entry("source CIDR", &proto.Rule{SrcNet: []string{"10.0.0.1/32"}}),
+		Entry("source CIDRs", &proto.Rule{SrcNet: []string{"10.0.0.1/32", "10.0.0.3", "fd00::/120"}}),
+		Entry("source CIDRs of one version", &proto.Rule{SrcNet: []string{"10.0.0.0/24"}, DstNet: []string{"10.0.1.0/24", "fd00:1::/64"}}),
+		Entry("not destination CIDRs", &proto.Rule{NotDstNet: []string{"10.0.1.0/24", "fd00:1::/64"}}),
+		Entry("destination and not destination CIDRs", &proto.Rule{DstNet: []string{"10.0.0.0/8"}, NotDstNet: []string{"10.0.1.2/32", "10.0.2.0/24"}}),
+		Entry("source IP set", &proto.Rule{SrcIpSetIds: []string{"clients"}}),
+		Entry("not destination IP set", &proto.Rule{NotDstIpSetIds: []string{"servers"}}),
+		Entry("destination ports", &proto.Rule{Protocol: protoName("tcp"), DstPorts: []*proto.PortRange{{First: 80, Last: 80}, {First: 90, Last: 100}}}),
+		Entry("source ports", &proto.Rule{Protocol: protoName("tcp"), SrcPorts: []*proto.PortRange{{First: 1, Last: 1024}}}),
+		Entry("not destination ports", &proto.Rule{Protocol: protoName("tcp"), NotDstPorts: []*proto.PortRange{{First: 80, Last: 80}}}),
+		Entry("named port", &proto.Rule{Protocol: protoName("tcp"), DstNamedPortIpSetIds: []string{"http"}}),
+		Entry("named and numeric ports", &proto.Rule{Protocol: protoName("tcp"), DstPorts: []*proto.PortRange{{First: 443, Last: 443}}, DstNamedPortIpSetIds: []string{"http"}}),
+		Entry("named ports", &proto.Rule{Protocol: protoName("udp"), DstNamedPortIpSetIds: []string{"http", "dns"}}),
+		Entry("not named port", &proto.Rule{Protocol: protoName("tcp"), NotDstNamedPortIpSetIds: []string{"http"}}),
+		Entry("destination IP and port set", &proto.Rule{DstIpPortSetIds: []string{"svc-port"}}),
+		Entry("ICMP type", &proto.Rule{Protocol: protoName("icmp"), Icmp: &proto.Rule_IcmpType{IcmpType: 8}}),
+		Entry("ICMP type and code", &proto.Rule{Protocol: protoName("icmp"), Icmp: &proto.Rule_IcmpTypeCode{IcmpTypeCode: &proto.IcmpTypeAndCode{Type: 3, Code: 0}}}),
+		Entry("not ICMP type", &proto.Rule{Protocol: protoName("icmp"), NotIcmp: &proto.Rule_NotIcmpType{NotIcmpType: 8}}),
+		Entry("ICMPv6 type", &proto.Rule{Protocol: protoName("icmpv6"), Icmp: &proto.Rule_IcmpType{IcmpType: 128}}),
+	)
+})
+
+func protoName(name string) *proto.Protocol {
+	return &proto.Protocol{NumberOrName: &proto.Protocol_Name{Name: name}}
+}
+
+func protoNumber(n int32) *proto.Protocol {
+	return &proto.Protocol{NumberOrName: &proto.Protocol_Number{Number: n}}
+}
+
+func renderedRules(rendered []generictables.Rule) string {
+	var lines []string
+	for _, r := range rendered {
+		m := ""
+		if r.Match != nil {
+			m = r.Match.Render()
+		}
+		lines = append(lines, fmt.Sprintf("%q -> %T", m, r.Action))
+	}
+	return strings.Join(lines, "; ")
+}
+
+// iptablesRulesMatch runs the packet through the iptables rules rendered for an allow rule, and
+// returns true if they allow it.
+func iptablesRulesMatch(m *calcState, rendered []generictables.Rule, pkt *packet) (bool, error) {
+	var mark uint32
+	for _, r := range rendered {
+		matches := true
+		if r.Match != nil {
+			var err error
+			matches, err = iptablesMatch(m, r.Match.Render(), pkt, mark)
+			if err != nil {
+				return false, err
+			}
+		}
+		if !matches {
+			continue
+		}
+		switch a := r.Action.(type) {
+		case iptables.SetMarkAction:
+			mark |= a.Mark
+		case iptables.SetMaskedMarkAction:
+			mark = mark&^a.Mask | a.Mark
+		case iptables.ClearMarkAction:
+			mark &^= a.Mark
+		case iptables.ReturnAction:
+			return mark&markAccept != 0, nil
+		default:
+			return false, fmt.Errorf("unexpected action %#v", r.Action)
+		}
+	}
+	return false, nil
+}
+
+// iptablesMatch returns true if a packet matches the criteria of an iptables rule.  It only
+// understands the criteria that policy rules are rendered with.
+func iptablesMatch(m *calcState, match string, pkt *packet, mark uint32) (bool, error) {
+	words := strings.Fields(match)
+	next := func() string {
+		if len(words) == 0 {
+			return ""
+		}
+		w := words[0]
+		words = words[1:]
+		return w
+	}
+	for len(words) > 0 {
+		w := next()
+		negate := w == "!"
+		if negate {
+			w = next()
+		}
+		var matched bool
+		switch w {
+		case "-m":
+			// The options of the module follow it, possibly negated.
+			next()
+			continue
+		case "-p":
+			p := next()
+			if n, err := strconv.Atoi(p); err == nil {
+				matched = uint8(n) == pkt.protocol
+			} else {
+				matched = protocolNumbers[p] == pkt.protocol
+			}
+		case "--source", "--destination":
+			ip := pkt.srcIP
+			if w == "--destination" {
+				ip = pkt.dstIP
+			}
+			matched = netsContain([]string{next()}, ip)
+		case "--match-set":
+			name, dirs := next(), next()
+			id := strings.TrimPrefix(strings.TrimPrefix(name, "cali40"), "cali60")
+			switch dirs {
+			case "src":
+				matched = m.ipSetContains(id, pkt.srcIP)
+			case "dst":
+				matched = m.ipSetContains(id, pkt.dstIP)
+			case "src,src":
+				matched = pkt.hasPorts() && m.ipPortSetContains(id, pkt.srcIP, pkt.protocol, pkt.srcPort)
+			case "dst,dst":
+				matched = pkt.hasPorts() && m.ipPortSetContains(id, pkt.dstIP, pkt.protocol, pkt.dstPort)
+			default:
+				return false, fmt.Errorf("unexpected IP set directions %q", dirs)
+			}
+		case "--source-ports", "--destination-ports":
+			port := pkt.srcPort
+			if w == "--destination-ports" {
+				port = pkt.dstPort
+			}
+			for _, r := range strings.Split(next(), ",") {
+				first, last, _ := strings.Cut(r, ":")
+				if last == "" {
+					last = first
+				}
+				f, _ := strconv.Atoi(first)
+				l, _ := strconv.Atoi(last)
+				matched = matched || (int(port) >= f && int(port) <= l)
+			}
+			// The multiport module only matches protocols with ports, even when negated.
+			if !pkt.hasPorts() {
+				return false, nil
+			}
+		case "--icmp-type", "--icmpv6-type":
+			typ, code, hasCode := strings.Cut(next(), "/")
+			t, _ := strconv.Atoi(typ)
+			matched = pkt.isICMP() && uint8(t) == pkt.icmpType
+			if hasCode {
+				c, _ := strconv.Atoi(code)
+				matched = matched && uint8(c) == pkt.icmpCode
+			}
+			// The icmp module only matches ICMP packets, even when negated.
+			if !pkt.isICMP() {
+				return false, nil
+			}
+		case "--mark":
+			value, mask, _ := strings.Cut(next(), "/")
+			v, _ := strconv.ParseUint(value, 0, 32)
+			mk, _ := strconv.ParseUint(mask, 0, 32)
+			matched = mark&uint32(mk) == uint32(v)
+		default:
+			return false, fmt.Errorf("unexpected match %q in %q", w, match)
+		}
+		if matched == negate {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,418 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policytest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector"
+)
+
+// FindingType is the kind of problem that Lint finds.
+type FindingType string
+
+const (
+	// ShadowedRule is a rule that never matches, because earlier rules, or the end of an earlier
+	// tier, handle all of its traffic differently.
+	ShadowedRule FindingType = "ShadowedRule"
+	// RedundantRule is a rule that never matches, because earlier rules, or the end of an earlier
+	// tier, handle all of its traffic in the same way.  It can be removed without any effect.
+	RedundantRule FindingType = "RedundantRule"
+	// ContradictorySelector is a policy or rule selector that can never match.
+	ContradictorySelector FindingType = "ContradictorySelector"
+	// DuplicatePolicy is a policy with the same selector, types and rules as an earlier policy.
+	DuplicatePolicy FindingType = "DuplicatePolicy"
+)
+
+// Location identifies a policy, or one of its rules.
+type Location struct {
+	Tier   string `json:"tier"`
+	Policy string `json:"policy"`
+	// Direction is Ingress or Egress, for a rule.
+	Direction string `json:"direction,omitempty"`
+	// Rule is the index of a rule, counting from 1.
+	Rule int `json:"rule,omitempty"`
+}
+
+func (l Location) String() string {
+	if l.Rule == 0 {
+		return fmt.Sprintf("policy %s in tier %s", l.Policy, l.Tier)
+	}
+	return fmt.Sprintf("%s rule %d of policy %s in tier %s", strings.ToLower(l.Direction), l.Rule, l.Policy, l.Tier)
+}
+
+// Finding is a problem with a policy or rule.
+type Finding struct {
+	Type FindingType `json:"type"`
+	Location
+	// Selector is the selector that can never match, for a ContradictorySelector.
+	Selector string `json:"selector,omitempty"`
+	// Related are the earlier rules or policies that shadow a rule, or the earlier policy that a
+	// policy duplicates.
+	Related []Location `json:"related,omitempty"`
+	Message string     `json:"message"`
+}
+
+// Lint analyses a set of policies statically, without endpoints, and returns the rules that never
+// match because earlier rules shadow them, the selectors that can never match, and the policies
+// that duplicate earlier ones.  The findings are in the order that Felix applies the policies.
+//
+// The analysis is conservative: it only reports a rule as shadowed if it can prove, from the
+// selectors' label restrictions and the rules' CIDRs, ports and protocols, that the earlier rules
+// match all of its traffic.  A rule is shadowed by the end of an earlier tier if a policy in that
+// tier applies to all of the rule's endpoints, and the tier has no pass rules.
+func Lint(objs []runtime.Object) ([]*Finding, error) {
+	updates, err := resourceUpdates(objs)
+	if err != nil {
+		return nil, err
+	}
+	sorter := calc.NewPolicySorter()
+	for _, u := range updates {
+		sorter.OnUpdate(u)
+	}
+
+	l := &linter{
+		ipSets: map[string]*calc.IPSetData{},
+		rules:  map[string][]*lintRule{},
+	}
+	for _, tier := range sorter.Sorted() {
+		t := &lintTier{name: tier.Name, passes: map[string]bool{}}
+		l.tiers = append(l.tiers, t)
+		for i := range tier.OrderedPolicies {
+			if err := l.lintPolicy(t, &tier.OrderedPolicies[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return l.findings, nil
+}
+
+type linter struct {
+	tiers    []*lintTier
+	policies []*lintPolicy
+	// rules are all the rules so far, by direction, in the order that Felix applies them.
+	rules    map[string][]*lintRule
+	ipSets   map[string]*calc.IPSetData
+	findings []*Finding
+}
+
+type lintTier struct {
+	name     string
+	policies []*lintPolicy
+	// passes records whether the tier has any rules, in each direction, that may pass traffic
+	// to the next tier.
+	passes map[string]bool
+}
+
+type lintPolicy struct {
+	tier     *lintTier
+	loc      Location
+	kv       *calc.PolKV
+	selector selector.Selector
+	rules    map[string][]*lintRule
+	// neverMatches is true if the policy's selector can never match.
+	neverMatches bool
+}
+
+type lintRule struct {
+	policy *lintPolicy
+	loc    Location
+	rule   *proto.Rule
+	// byVersion holds the rule filtered to IPv4 and IPv6, or nil if it doesn't apply to that
+	// version.
+	byVersion [2]*proto.Rule
+	// neverMatches is true if one of the rule's selectors can never match.
+	neverMatches bool
+}
+
+var ipVersions = [2]uint8{4, 6}
+
+func (p *lintPolicy) governs(direction string) bool {
+	if direction == string(apiv3.PolicyTypeIngress) {
+		return p.kv.GovernsIngress()
+	}
+	return p.kv.GovernsEgress()
+}
+
+func (l *linter) lintPolicy(t *lintTier, kv *calc.PolKV) error {
+	sel, err := selector.Parse(kv.Value.Selector)
+	if err != nil {
+		return fmt.Errorf("failed to parse selector of policy %s: %w", kv.Key.Name, err)
+	}
+	p := &lintPolicy{
+		tier:     t,
+		loc:      Location{Tier: t.name, Policy: kv.Key.Name},
+		kv:       kv,
+		selector: sel,
+		rules:    map[string][]*lintRule{},
+	}
+	for direction, rules := range map[string][]model.Rule{
+		string(apiv3.PolicyTypeIngress): kv.Value.InboundRules,
+		string(apiv3.PolicyTypeEgress):  kv.Value.OutboundRules,
+	} {
+		if !p.governs(direction) {
+			continue
+		}
+		for i := range rules {
+			r, ipSets := calc.RuleToProtoRule(&rules[i])
+			for _, ipSet := range ipSets {
+				l.ipSets[ipSet.UniqueID()] = ipSet
+			}
+			lr := &lintRule{
+				policy: p,
+				loc:    Location{Tier: t.name, Policy: kv.Key.Name, Direction: direction, Rule: i + 1},
+				rule:   r,
+			}
+			for v, version := range ipVersions {
+				lr.byVersion[v] = filterRuleToIPVersion(version, r)
+			}
+			p.rules[direction] = append(p.rules[direction], lr)
+		}
+	}
+
+	if selectorNeverMatches(sel) {
+		p.neverMatches = true
+		l.addFinding(&Finding{
+			Type:     ContradictorySelector,
+			Location: p.loc,
+			Selector: sel.String(),
+			Message:  "the policy's selector can never match, so the policy never applies",
+		})
+	}
+	duplicate := false
+	for _, q := range l.policies {
+		if policiesEqual(p, q) {
+			duplicate = true
+			l.addFinding(&Finding{
+				Type:     DuplicatePolicy,
+				Location: p.loc,
+				Related:  []Location{q.loc},
+				Message:  fmt.Sprintf("the policy has the same selector, types and rules as %s", q.loc),
+			})
+			break
+		}
+	}
+
+	for _, direction := range []string{string(apiv3.PolicyTypeIngress), string(apiv3.PolicyTypeEgress)} {
+		for _, r := range p.rules[direction] {
+			if !p.neverMatches {
+				l.lintRule(r, direction, duplicate)
+			}
+			if !r.neverMatches && !p.neverMatches {
+				switch r.rule.Action {
+				case "next-tier", "pass":
+					t.passes[direction] = true
+				}
+			}
+			l.rules[direction] = append(l.rules[direction], r)
+		}
+	}
+
+	l.policies = append(l.policies, p)
+	t.policies = append(t.policies, p)
+	return nil
+}
+
+func (l *linter) lintRule(r *lintRule, direction string, duplicatePolicy bool) {
+	for _, side := range []struct {
+		name   string
+		ipSets [][]string
+	}{
+		{"source", [][]string{r.rule.SrcIpSetIds, r.rule.SrcNamedPortIpSetIds}},
+		{"destination", [][]string{r.rule.DstIpSetIds, r.rule.DstNamedPortIpSetIds}},
+	} {
+		if sel := l.contradictorySelector(side.ipSets...); sel != nil {
+			r.neverMatches = true
+			l.addFinding(&Finding{
+				Type:     ContradictorySelector,
+				Location: r.loc,
+				Selector: sel.String(),
+				Message:  fmt.Sprintf("the rule's %s selector can never match, so the rule never matches", side.name),
+			})
+			return
+		}
+	}
+	if duplicatePolicy {
+		// All the rules of a duplicate policy are shadowed by the policy that it duplicates.
+		return
+	}
+
+	shadowing := l.shadowingRules(r, direction)
+	if shadowing == nil {
+		l.checkEndOfTiers(r, direction)
+		return
+	}
+	findingType := RedundantRule
+	var related []string
+	locs := make([]Location, len(shadowing))
+	for i, s := range shadowing {
+		locs[i] = s.loc
+		related = append(related, s.loc.String())
+		if normaliseAction(s.rule.Action) != normaliseAction(r.rule.Action) {
+			findingType = ShadowedRule
+		}
+	}
+	l.addFinding(&Finding{
+		Type:     findingType,
+		Location: r.loc,
+		Related:  locs,
+		Message:  fmt.Sprintf("the rule never matches, because %s matches all of its traffic first", strings.Join(related, " and ")),
+	})
+}
+
+// shadowingRules returns the earlier rules that match all of a rule's traffic, and that stop it
+// from reaching the rule, or nil if there aren't any.  There may be a different rule for each IP
+// version.
+func (l *linter) shadowingRules(r *lintRule, direction string) []*lintRule {
+	var shadowing []*lintRule
+	for v, rv := range r.byVersion {
+		if rv == nil {
+			continue
+		}
+		var found *lintRule
+		for _, earlier := range l.rules[direction] {
+			if earlier.byVersion[v] != nil && canShadow(earlier, r) && l.ruleCovers(earlier.byVersion[v], rv) {
+				found = earlier
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		if len(shadowing) == 0 || shadowing[0] != found {
+			shadowing = append(shadowing, found)
+		}
+	}
+	return shadowing
+}
+
+// checkEndOfTiers reports a rule that is shadowed by the end of an earlier tier, which drops all
+// the traffic that it doesn't allow or pass.
+func (l *linter) checkEndOfTiers(r *lintRule, direction string) {
+	for _, t := range l.tiers {
+		if t == r.policy.tier {
+			return
+		}
+		if t.passes[direction] {
+			continue
+		}
+		for _, p := range t.policies {
+			if p.neverMatches || !p.governs(direction) || !policyAppliesFirst(p, r.policy) {
+				continue
+			}
+			findingType := ShadowedRule
+			if normaliseAction(r.rule.Action) == "deny" {
+				findingType = RedundantRule
+			}
+			l.addFinding(&Finding{
+				Type:     findingType,
+				Location: r.loc,
+				Related:  []Location{p.loc},
+				Message: fmt.Sprintf("the rule never matches, because %s applies to all of its endpoints, "+
+					"and tier %s drops all the traffic that it doesn't allow, without passing any", p.loc, t.name),
+			})
+			return
+		}
+	}
+}
+
+// canShadow returns true if an earlier rule that matches a packet stops it from reaching a later
+// rule.
+func canShadow(earlier, r *lintRule) bool {
+	if earlier.neverMatches || earlier.policy.neverMatches || earlier.rule.ConnLimit > 0 {
+		return false
+	}
+	switch normaliseAction(earlier.rule.Action) {
+	case "allow", "deny":
+	case "pass":
+		// A pass rule only skips the rest of its own tier.
+		if earlier.policy.tier != r.policy.tier {
+			return false
+		}
+	default:
+		return false
+	}
+	return earlier.policy == r.policy || policyAppliesFirst(earlier.policy, r.policy)
+}
+
+// policyAppliesFirst returns true if an earlier policy applies to all the endpoints and traffic
+// that a later policy applies to.
+func policyAppliesFirst(earlier, p *lintPolicy) bool {
+	e, v := earlier.kv.Value, p.kv.Value
+	if e.DoNotTrack != v.DoNotTrack || e.PreDNAT != v.PreDNAT || v.ApplyOnForward && !e.ApplyOnForward {
+		return false
+	}
+	return selectorImplies(p.selector, earlier.selector)
+}
+
+func normaliseAction(action string) string {
+	switch action {
+	case "", "allow":
+		return "allow"
+	case "next-tier", "pass":
+		return "pass"
+	}
+	return action
+}
+
+// contradictorySelector returns the first selector of the given IP sets that can never match, or
+// nil if there isn't one.
+func (l *linter) contradictorySelector(ipSetIDs ...[]string) selector.Selector {
+	for _, ids := range ipSetIDs {
+		for _, id := range ids {
+			if sel := l.ipSets[id].Selector; sel != nil && selectorNeverMatches(sel) {
+				return sel
+			}
+		}
+	}
+	return nil
+}
+
+// policiesEqual returns true if two policies have the same selector, types and rules.
+func policiesEqual(a, b *lintPolicy) bool {
+	av, bv := a.kv.Value, b.kv.Value
+	if a.selector.String() != b.selector.String() ||
+		av.DoNotTrack != bv.DoNotTrack ||
+		av.PreDNAT != bv.PreDNAT ||
+		av.ApplyOnForward != bv.ApplyOnForward {
+		return false
+	}
+	for _, direction := range []string{string(apiv3.PolicyTypeIngress), string(apiv3.PolicyTypeEgress)} {
+		if a.governs(direction) != b.governs(direction) || len(a.rules[direction]) != len(b.rules[direction]) {
+			return false
+		}
+		for i, ar := range a.rules[direction] {
+			// Rule metadata doesn't affect what the rule matches.
+			r1, r2 := *ar.rule, *b.rules[direction][i].rule
+			r1.Metadata, r2.Metadata = nil, nil
+			if !reflect.DeepEqual(&r1, &r2) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (l *linter) addFinding(f *Finding) {
+	l.findings = append(l.findings, f)
+}
@@ -0,0 +1,435 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policytest
+
+import (
+	"reflect"
+
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector/parser"
+)
+
+// ruleSide is the match criteria of the source or destination of a rule.
+type ruleSide struct {
+	nets, notNets             []string
+	ipSets, notIPSets         []string
+	ports, notPorts           []*proto.PortRange
+	namedPorts, notNamedPorts []string
+}
+
+func srcSide(r *proto.Rule) ruleSide {
+	return ruleSide{
+		nets:          r.SrcNet,
+		notNets:       r.NotSrcNet,
+		ipSets:        r.SrcIpSetIds,
+		notIPSets:     r.NotSrcIpSetIds,
+		ports:         r.SrcPorts,
+		notPorts:      r.NotSrcPorts,
+		namedPorts:    r.SrcNamedPortIpSetIds,
+		notNamedPorts: r.NotSrcNamedPortIpSetIds,
+	}
+}
+
+func dstSide(r *proto.Rule) ruleSide {
+	return ruleSide{
+		nets:          r.DstNet,
+		notNets:       r.NotDstNet,
+		ipSets:        r.DstIpSetIds,
+		notIPSets:     r.NotDstIpSetIds,
+		ports:         r.DstPorts,
+		notPorts:      r.NotDstPorts,
+		namedPorts:    r.DstNamedPortIpSetIds,
+		notNamedPorts: r.NotDstNamedPortIpSetIds,
+	}
+}
+
+// ruleCovers returns true if a rule matches every packet that another rule matches.  Both rules
+// must already be filtered to the same IP version.
+func (l *linter) ruleCovers(outer, inner *proto.Rule) bool {
+	if !protocolCovers(outer, inner) || !icmpCovers(outer, inner) {
+		return false
+	}
+	if !l.sideCovers(srcSide(outer), srcSide(inner)) || !l.sideCovers(dstSide(outer), dstSide(inner)) {
+		return false
+	}
+	for _, id := range outer.DstIpPortSetIds {
+		if !containsString(inner.DstIpPortSetIds, id) {
+			return false
+		}
+	}
+	// HTTP matches are ignored by the iptables, nftables and BPF dataplanes, but not by
+	// application layer policy.
+	return outer.HttpMatch == nil || reflect.DeepEqual(outer.HttpMatch, inner.HttpMatch)
+}
+
+func protocolCovers(outer, inner *proto.Rule) bool {
+	if outer.Protocol != nil {
+		if inner.Protocol == nil || protocolNumber(outer.Protocol) != protocolNumber(inner.Protocol) {
+			return false
+		}
+	}
+	if outer.NotProtocol != nil {
+		excluded := protocolNumber(outer.NotProtocol)
+		if !(inner.Protocol != nil && protocolNumber(inner.Protocol) != excluded) &&
+			!(inner.NotProtocol != nil && protocolNumber(inner.NotProtocol) == excluded) {
+			return false
+		}
+	}
+	return true
+}
+
+func icmpCovers(outer, inner *proto.Rule) bool {
+	innerType, innerCode, innerOK := icmpMatch(inner.Icmp)
+	if outerType, outerCode, ok := icmpMatch(outer.Icmp); ok {
+		if !innerOK || innerType != outerType || outerCode >= 0 && innerCode != outerCode {
+			return false
+		}
+	}
+	if notType, notCode, ok := icmpMatch(outer.NotIcmp); ok {
+		// The inner rule must not match any of the ICMP packets that the outer rule excludes.
+		excluded := innerOK && (innerType != notType || notCode >= 0 && innerCode >= 0 && innerCode != notCode)
+		if innerNotType, innerNotCode, ok := icmpMatch(inner.NotIcmp); ok &&
+			innerNotType == notType && (innerNotCode < 0 || innerNotCode == notCode) {
+			excluded = true
+		}
+		if !excluded {
+			return false
+		}
+	}
+	return true
+}
+
+// icmpMatch returns the type and code of an ICMP match, or of a negated ICMP match, where the code
+// is -1 if the match is for any code.
+func icmpMatch(m interface{}) (icmpType, icmpCode int32, ok bool) {
+	switch m := m.(type) {
+	case *proto.Rule_IcmpType:
+		return m.IcmpType, -1, true
+	case *proto.Rule_IcmpTypeCode:
+		return m.IcmpTypeCode.Type, m.IcmpTypeCode.Code, true
+	case *proto.Rule_NotIcmpType:
+		return m.NotIcmpType, -1, true
+	case *proto.Rule_NotIcmpTypeCode:
+		return m.NotIcmpTypeCode.Type, m.NotIcmpTypeCode.Code, true
+	}
+	return 0, 0, false
+}
+
+// sideCovers returns true if the source or destination match of a rule matches every address and
+// port that another rule's matches.
+func (l *linter) sideCovers(outer, inner ruleSide) bool {
+	if len(outer.nets) > 0 {
+		if len(inner.nets) == 0 {
+			return false
+		}
+		for _, n := range inner.nets {
+			if !netsCoverNet(outer.nets, n) {
+				return false
+			}
+		}
+	}
+	for _, n := range outer.notNets {
+		if !netExcluded(inner, n) {
+			return false
+		}
+	}
+
+	// Selectors are and-ed together, so each of the outer rule's selectors must be implied by one
+	// of the inner rule's.  If the inner rule only has named ports, its selector is part of each
+	// named port's IP set instead.
+	onlyNamedPorts := len(inner.ports) == 0 && len(inner.namedPorts) > 0
+	for _, id := range outer.ipSets {
+		if !l.anyIPSetCovered(id, inner.ipSets) &&
+			!(onlyNamedPorts && l.allSelectorsImply(inner.namedPorts, id)) {
+			return false
+		}
+	}
+	for _, id := range outer.notIPSets {
+		// The outer rule excludes the members of the IP set, so the inner rule must exclude at
+		// least the same members.
+		if !l.anyIPSetCovers(inner.notIPSets, id) {
+			return false
+		}
+	}
+
+	if len(outer.ports)+len(outer.namedPorts) > 0 {
+		if len(inner.ports)+len(inner.namedPorts) == 0 || !portRangesCover(outer.ports, inner.ports) {
+			return false
+		}
+		for _, id := range inner.namedPorts {
+			if !l.anyIPSetCovers(outer.namedPorts, id) {
+				return false
+			}
+		}
+	}
+	for _, r := range outer.notPorts {
+		excluded := len(inner.ports) > 0 && len(inner.namedPorts) == 0 && portRangesDisjoint(inner.ports, r)
+		if !excluded && !portRangesCover(inner.notPorts, []*proto.PortRange{r}) {
+			return false
+		}
+	}
+	for _, id := range outer.notNamedPorts {
+		if !l.anyIPSetCovers(inner.notNamedPorts, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// netExcluded returns true if a rule's source or destination never matches an address in a CIDR.
+func netExcluded(s ruleSide, cidr string) bool {
+	n := parseNet(cidr)
+	if n == nil {
+		return false
+	}
+	if len(s.nets) > 0 {
+		disjoint := true
+		for _, sn := range s.nets {
+			if other := parseNet(sn); other == nil || other.Contains(n.IP) || n.Contains(other.IP) {
+				disjoint = false
+				break
+			}
+		}
+		if disjoint {
+			return true
+		}
+	}
+	return netsCoverNet(s.notNets, cidr)
+}
+
+// netsCoverNet returns true if one of a list of CIDRs contains another CIDR.
+func netsCoverNet(nets []string, cidr string) bool {
+	inner := parseNet(cidr)
+	if inner == nil {
+		return false
+	}
+	innerOnes, innerBits := inner.Mask.Size()
+	for _, n := range nets {
+		outer := parseNet(n)
+		if outer == nil {
+			continue
+		}
+		outerOnes, outerBits := outer.Mask.Size()
+		if outerBits == innerBits && outerOnes <= innerOnes && outer.Contains(inner.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// portRangesCover returns true if the union of a list of port ranges contains all the ports in
+// another list.
+func portRangesCover(outer, inner []*proto.PortRange) bool {
+	for _, r := range inner {
+		port := r.First
+		for port <= r.Last {
+			advanced := false
+			for _, o := range outer {
+				if o.First <= port && port <= o.Last {
+					port = o.Last + 1
+					advanced = true
+				}
+			}
+			if !advanced {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func portRangesDisjoint(ranges []*proto.PortRange, r *proto.PortRange) bool {
+	for _, o := range ranges {
+		if o.First <= r.Last && r.First <= o.Last {
+			return false
+		}
+	}
+	return true
+}
+
+// anyIPSetCovered returns true if one of a list of IP sets is a subset of another IP set.
+func (l *linter) anyIPSetCovered(outerID string, innerIDs []string) bool {
+	for _, id := range innerIDs {
+		if ipSetCovers(l.ipSets[outerID], l.ipSets[id]) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyIPSetCovers returns true if another IP set is a subset of one of a list of IP sets.
+func (l *linter) anyIPSetCovers(outerIDs []string, innerID string) bool {
+	for _, id := range outerIDs {
+		if ipSetCovers(l.ipSets[id], l.ipSets[innerID]) {
+			return true
+		}
+	}
+	return false
+}
+
+// allSelectorsImply returns true if the selector of each of a list of IP sets implies the selector
+// of another IP set.
+func (l *linter) allSelectorsImply(ids []string, impliedID string) bool {
+	implied := l.ipSets[impliedID].Selector
+	if implied == nil {
+		return false
+	}
+	for _, id := range ids {
+		sel := l.ipSets[id].Selector
+		if sel == nil || !selectorImplies(sel, implied) {
+			return false
+		}
+	}
+	return true
+}
+
+// ipSetCovers returns true if every member of an IP set is a member of another IP set.
+func ipSetCovers(outer, inner *calc.IPSetData) bool {
+	if outer.UniqueID() == inner.UniqueID() {
+		return true
+	}
+	if outer.Service != "" || inner.Service != "" ||
+		outer.NamedPort != inner.NamedPort ||
+		outer.NamedPortProtocol != inner.NamedPortProtocol ||
+		outer.Selector == nil || inner.Selector == nil {
+		return false
+	}
+	return selectorImplies(inner.Selector, outer.Selector)
+}
+
+// selectorNeverMatches returns true if a selector's label restrictions contradict each other,
+// such as "a == 'x' && a == 'y'".
+func selectorNeverMatches(sel selector.Selector) bool {
+	for _, r := range sel.LabelRestrictions() {
+		if !r.PossibleToSatisfy() {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorImplies returns true if every set of labels that matches a selector also matches another
+// selector.  The selectors are treated as conjunctions of their top-level and-ed terms: each of the
+// implied selector's terms must either be one of the first selector's terms, or be a label match,
+// such as "app == 'a'" or "has(tier)", that follows from the first selector's label restrictions.
+func selectorImplies(sel, implied selector.Selector) bool {
+	if sel.UniqueID() == implied.UniqueID() {
+		return true
+	}
+	selTerms, ok := conjuncts(sel)
+	if !ok {
+		return false
+	}
+	impliedTerms, ok := conjuncts(implied)
+	if !ok {
+		return false
+	}
+	restrictions := sel.LabelRestrictions()
+	for _, term := range impliedTerms {
+		if !containsTerm(selTerms, term) && !restrictionsImply(restrictions, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// conjuncts returns the nodes of a selector that are and-ed together at its top level.
+func conjuncts(sel selector.Selector) ([]interface{}, bool) {
+	ps, ok := sel.(parser.Selector)
+	if !ok {
+		return nil, false
+	}
+	v := &rootVisitor{}
+	ps.AcceptVisitor(v)
+	return flattenAnd(v.root), true
+}
+
+// rootVisitor records the root node of a selector, which is the first node that it visits.
+type rootVisitor struct {
+	root interface{}
+}
+
+func (v *rootVisitor) Visit(n interface{}) {
+	if v.root == nil {
+		v.root = n
+	}
+}
+
+func flattenAnd(n interface{}) []interface{} {
+	and, ok := n.(*parser.AndNode)
+	if !ok {
+		return []interface{}{n}
+	}
+	var terms []interface{}
+	for _, op := range and.Operands {
+		terms = append(terms, flattenAnd(op)...)
+	}
+	return terms
+}
+
+func containsTerm(terms []interface{}, term interface{}) bool {
+	for _, t := range terms {
+		if reflect.DeepEqual(t, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// restrictionsImply returns true if label restrictions imply a selector term whose own label
+// restrictions describe it exactly.
+func restrictionsImply(restrictions map[string]parser.LabelRestriction, term interface{}) bool {
+	switch term := term.(type) {
+	case *parser.AllNode:
+		return true
+	case *parser.HasNode, *parser.LabelEqValueNode, *parser.LabelInSetNode:
+	case *parser.NotNode:
+		if _, ok := term.Operand.(*parser.HasNode); !ok {
+			return false
+		}
+	default:
+		return false
+	}
+	for label, r := range term.(interface {
+		LabelRestrictions() map[string]parser.LabelRestriction
+	}).LabelRestrictions() {
+		s := restrictions[label]
+		if r.MustBePresent && !s.MustBePresent || r.MustBeAbsent && !s.MustBeAbsent {
+			return false
+		}
+		if r.MustHaveOneOfValues != nil {
+			if s.MustHaveOneOfValues == nil {
+				return false
+			}
+			for _, v := range s.MustHaveOneOfValues {
+				if !containsString(r.MustHaveOneOfValues, v) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func containsString(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policytest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/projectcalico/api/pkg/lib/numorstring"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	. "github.com/projectcalico/calico/felix/policytest"
+)
+
+func egressRule(action apiv3.Action, proto string, dst apiv3.EntityRule) apiv3.Rule {
+	r := apiv3.Rule{Action: action, Destination: dst}
+	if proto != "" {
+		r.Protocol = protocol(proto)
+	}
+	return r
+}
+
+func ports(ps ...numorstring.Port) []numorstring.Port {
+	return ps
+}
+
+var _ = Describe("Policy lint", func() {
+	lint := func(objs ...runtime.Object) []*Finding {
+		findings, err := Lint(objs)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return findings
+	}
+
+	It("should find nothing wrong with independent rules", func() {
+		Expect(lint(
+			gnp("", "web", 1, "app == 'web'",
+				egressRule(apiv3.Allow, "TCP", apiv3.EntityRule{Ports: ports(numorstring.SinglePort(80))}),
+				egressRule(apiv3.Allow, "TCP", apiv3.EntityRule{Ports: ports(numorstring.SinglePort(443))}),
+				egressRule(apiv3.Deny, "", apiv3.EntityRule{}),
+			),
+		)).To(BeEmpty())
+	})
+
+	It("should find a rule that an earlier rule in the same policy shadows", func() {
+		findings := lint(gnp("", "web", 1, "app == 'web'",
+			egressRule(apiv3.Deny, "", apiv3.EntityRule{Nets: []string{"10.0.0.0/8"}}),
+			egressRule(apiv3.Allow, "TCP", apiv3.EntityRule{Nets: []string{"10.1.0.0/16"}, Ports: ports(numorstring.SinglePort(80))}),
+			egressRule(apiv3.Deny, "UDP", apiv3.EntityRule{Nets: []string{"10.2.0.0/16", "10.3.0.0/16"}}),
+		))
+		rule1 := Location{Tier: "default", Policy: "default.web", Direction: "Egress", Rule: 1}
+		Expect(findings).To(Equal([]*Finding{
+			{
+				Type:     ShadowedRule,
+				Location: Location{Tier: "default", Policy: "default.web", Direction: "Egress", Rule: 2},
+				Related:  []Location{rule1},
+				Message:  "the rule never matches, because egress rule 1 of policy default.web in tier default matches all of its traffic first",
+			},
+			{
+				Type:     RedundantRule,
+				Location: Location{Tier: "default", Policy: "default.web", Direction: "Egress", Rule: 3},
+				Related:  []Location{rule1},
+				Message:  "the rule never matches, because egress rule 1 of policy default.web in tier default matches all of its traffic first",
+			},
+		}))
+	})
+
+	It("should use port ranges, negated matches and selectors", func() {
+		findings := lint(gnp("", "web", 1, "app == 'web'",
+			egressRule(apiv3.Allow, "TCP", apiv3.EntityRule{
+				Selector:    "role in {'db', 'cache'}",
+				NotSelector: "env == 'dev'",
+				Ports:       ports(numorstring.Port{MinPort: 1000, MaxPort: 2000}, numorstring.Port{MinPort: 2001, MaxPort: 3000}),
+				NotPorts:    ports(numorstring.SinglePort(1500)),
+			}),
+			// Shadowed: a narrower selector, port range and negated match.
+			egressRule(apiv3.Deny, "TCP", apiv3.EntityRule{
+				Selector:    "role == 'db' && has(zone)",
+				NotSelector: "env == 'dev'",
+				Ports:       ports(numorstring.Port{MinPort: 1900, MaxPort: 2100}),
+			}),
+			// Not shadowed: it matches UDP.
+			egressRule(apiv3.Deny, "UDP", apiv3.EntityRule{
+				Selector: "role == 'db'",
+				Ports:    ports(numorstring.SinglePort(1900)),
+			}),
+			// Not shadowed: it doesn't exclude env == 'dev'.
+			egressRule(apiv3.Deny, "TCP", apiv3.EntityRule{
+				Selector: "role == 'db'",
+				Ports:    ports(numorstring.SinglePort(1900)),
+			}),
+			// Not shadowed: the selector is an or, and not implied.
+			egressRule(apiv3.Deny, "TCP", apiv3.EntityRule{
+				Selector:    "role == 'db' || role == 'web'",
+				NotSelector: "env == 'dev'",
+				Ports:       ports(numorstring.SinglePort(1900)),
+			}),
+		))
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Type).To(Equal(ShadowedRule))
+		Expect(findings[0].Rule).To(Equal(2))
+	})
+
+	It("should find rules that earlier policies and tiers shadow", func() {
+		findings := lint(
+			tier("security", 10),
+			gnp("security", "security.block", 1, "all()",
+				egressRule(apiv3.Deny, "", apiv3.EntityRule{Nets: []string{"192.0.2.0/24"}}),
+				egressRule(apiv3.Pass, "", apiv3.EntityRule{}),
+			),
+			gnp("", "web", 1, "app == 'web'",
+				egressRule(apiv3.Allow, "TCP", apiv3.EntityRule{Nets: []string{"192.0.2.1/32"}}),
+			),
+			// This policy applies to a different set of endpoints, so it doesn't shadow the
+			// web policy.
+			gnp("", "other", 0, "app == 'other'",
+				egressRule(apiv3.Deny, "", apiv3.EntityRule{}),
+			),
+		)
+		Expect(findings).To(Equal([]*Finding{{
+			Type:     ShadowedRule,
+			Location: Location{Tier: "default", Policy: "default.web", Direction: "Egress", Rule: 1},
+			Related:  []Location{{Tier: "security", Policy: "security.block", Direction: "Egress", Rule: 1}},
+			Message:  "the rule never matches, because egress rule 1 of policy security.block in tier security matches all of its traffic first",
+		}}))
+	})
+
+	It("should find rules after a tier that doesn't pass any traffic", func() {
+		findings := lint(
+			tier("security", 10),
+			gnp("security", "security.allow-dns", 1, "all()",
+				egressRule(apiv3.Allow, "UDP", apiv3.EntityRule{Ports: ports(numorstring.SinglePort(53))}),
+			),
+			gnp("", "web", 1, "app == 'web'",
+				egressRule(apiv3.Allow, "TCP", apiv3.EntityRule{}),
+			),
+		)
+		Expect(findings).To(Equal([]*Finding{{
+			Type:     ShadowedRule,
+			Location: Location{Tier: "default", Policy: "default.web", Direction: "Egress", Rule: 1},
+			Related:  []Location{{Tier: "security", Policy: "security.allow-dns"}},
+			Message: "the rule never matches, because policy security.allow-dns in tier security applies to all of its " +
+				"endpoints, and tier security drops all the traffic that it doesn't allow, without passing any",
+		}}))
+	})
+
+	It("should find contradictory selectors and duplicate policies", func() {
+		findings := lint(
+			gnp("", "nothing", 1, "app == 'a' && app == 'b'", egressRule(apiv3.Allow, "", apiv3.EntityRule{})),
+			gnp("", "web", 2, "app == 'web'",
+				egressRule(apiv3.Allow, "", apiv3.EntityRule{Selector: "has(role) && !has(role)"}),
+			),
+			gnp("", "web-copy", 3, "app == 'web'",
+				egressRule(apiv3.Allow, "", apiv3.EntityRule{Selector: "has(role) && !has(role)"}),
+			),
+		)
+		Expect(findings).To(Equal([]*Finding{
+			{
+				Type:     ContradictorySelector,
+				Location: Location{Tier: "default", Policy: "default.nothing"},
+				Selector: "(app == \"a\" && app == \"b\")",
+				Message:  "the policy's selector can never match, so the policy never applies",
+			},
+			{
+				Type:     ContradictorySelector,
+				Location: Location{Tier: "default", Policy: "default.web", Direction: "Egress", Rule: 1},
+				Selector: "(has(role) && !has(role))",
+				Message:  "the rule's destination selector can never match, so the rule never matches",
+			},
+			{
+				Type:     DuplicatePolicy,
+				Location: Location{Tier: "default", Policy: "default.web-copy"},
+				Related:  []Location{{Tier: "default", Policy: "default.web"}},
+				Message:  "the policy has the same selector, types and rules as policy default.web in tier default",
+			},
+			{
+				Type:     ContradictorySelector,
+				Location: Location{Tier: "default", Policy: "default.web-copy", Direction: "Egress", Rule: 1},
+				Selector: "(has(role) && !has(role))",
+				Message:  "the rule's destination selector can never match, so the rule never matches",
+			},
+		}))
+	})
+})
@@ -0,0 +1,214 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policytest_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/projectcalico/api/pkg/lib/numorstring"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	. "github.com/projectcalico/calico/felix/policytest"
+)
+
+func gnp(tier, name string, order float64, sel string, egress ...apiv3.Rule) *apiv3.GlobalNetworkPolicy {
+	p := apiv3.NewGlobalNetworkPolicy()
+	p.Name = name
+	p.Spec.Tier = tier
+	p.Spec.Order = &order
+	p.Spec.Selector = sel
+	p.Spec.Egress = egress
+	return p
+}
+
+func tier(name string, order float64) *apiv3.Tier {
+	t := apiv3.NewTier()
+	t.Name = name
+	t.Spec.Order = &order
+	return t
+}
+
+func protocol(p string) *numorstring.Protocol {
+	proto := numorstring.ProtocolFromString(p)
+	return &proto
+}
+
+var _ = Describe("Policy tests", func() {
+	It("should run a suite file", func() {
+		s, err := LoadSuite("testdata/suite.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Objects).To(HaveLen(5))
+
+		results, err := Run(s)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(5))
+		for _, r := range results {
+			Expect(r.Passed()).To(BeTrue(), "test %q: got %s", r.Test.Name, r.Verdict)
+		}
+
+		Expect(results[0].Ingress).To(Equal(&Decision{
+			Verdict: Allow,
+			Reason:  "allowed by ingress rule 1 of policy shop/knp.default.allow-frontend in tier default",
+		}))
+		Expect(results[1].Ingress).To(Equal(&Decision{
+			Verdict: Deny,
+			Reason:  "no ingress policy in tier default allowed or passed the packet",
+		}))
+		Expect(results[3].Egress).To(Equal(&Decision{
+			Verdict: Allow,
+			Reason:  "allowed by egress rule 1 of profile kns.shop",
+		}))
+		Expect(results[3].Ingress).To(BeNil())
+		Expect(results[4].Egress).To(Equal(&Decision{
+			Verdict: Deny,
+			Reason:  "denied by egress rule 1 of policy security.quarantine in tier security",
+		}))
+	})
+
+	It("should reject a resource of an unsupported kind", func() {
+		dir, err := os.MkdirTemp("", "policytest")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		err = os.WriteFile(filepath.Join(dir, "pool.yaml"), []byte("apiVersion: projectcalico.org/v3\nkind: IPPool\nmetadata:\n  name: pool\n"), 0o644)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = LoadResources(dir)
+		Expect(err).To(MatchError(ContainSubstring(`unsupported resource kind "IPPool"`)))
+	})
+
+	Describe("evaluating tests", func() {
+		var s *Suite
+
+		BeforeEach(func() {
+			s = &Suite{
+				Namespaces: []Namespace{{Name: "prod", Labels: map[string]string{"env": "prod"}}},
+				ServiceAccounts: []ServiceAccount{
+					{Name: "db", Namespace: "prod", Labels: map[string]string{"role": "db"}},
+				},
+				Endpoints: []Endpoint{
+					{Name: "a", Namespace: "prod", Labels: map[string]string{"app": "a"}, IPs: []string{"10.0.0.1", "fd00::1"}},
+					{Name: "b", Namespace: "prod", Labels: map[string]string{"app": "b"}, IPs: []string{"10.0.0.2", "fd00::2"}, ServiceAccount: "db"},
+					{Name: "c", Labels: map[string]string{"app": "c"}, IPs: []string{"10.0.0.3"}},
+				},
+			}
+		})
+
+		run := func(t Test) *Result {
+			s.Tests = []Test{t}
+			results, err := Run(s)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			return results[0]
+		}
+		peer := func(ep string) Peer {
+			return Peer{Endpoint: ep}
+		}
+
+		It("should allow everything with no policy", func() {
+			r := run(Test{From: peer("prod/a"), To: peer("c"), Port: 80, Expect: Allow})
+			Expect(r.Passed()).To(BeTrue())
+			Expect(r.Ingress.Reason).To(Equal("allowed by ingress rule 1 of profile kns.default"))
+		})
+
+		It("should apply the next tier after a pass, and log rules shouldn't stop evaluation", func() {
+			s.Objects = []runtime.Object{
+				tier("first", 10),
+				gnp("first", "first.pass", 1, "all()",
+					apiv3.Rule{Action: apiv3.Log},
+					apiv3.Rule{Action: apiv3.Pass, Destination: apiv3.EntityRule{Selector: "app == 'b'"}}),
+				gnp("first", "first.deny", 2, "all()", apiv3.Rule{Action: apiv3.Deny}),
+				gnp("", "allow-tcp", 1, "all()", apiv3.Rule{Action: apiv3.Allow, Protocol: protocol("TCP")}),
+			}
+			r := run(Test{From: peer("prod/a"), To: peer("prod/b"), Port: 5432, Expect: Allow})
+			Expect(r.Egress.Reason).To(Equal("allowed by egress rule 1 of policy default.allow-tcp in tier default"))
+
+			r = run(Test{From: peer("prod/a"), To: peer("prod/b"), Protocol: "UDP", Port: 53, Expect: Deny})
+			Expect(r.Egress.Reason).To(Equal("no egress policy in tier default allowed or passed the packet"))
+
+			r = run(Test{From: peer("prod/a"), To: peer("c"), Port: 80, Expect: Deny})
+			Expect(r.Egress.Reason).To(Equal("denied by egress rule 1 of policy first.deny in tier first"))
+		})
+
+		It("should match namespace, service account, CIDR, ICMP and IP version", func() {
+			icmpType := 8
+			s.Objects = []runtime.Object{
+				gnp("", "policy", 1, "app == 'a'",
+					apiv3.Rule{
+						Action:      apiv3.Allow,
+						Destination: apiv3.EntityRule{NamespaceSelector: "env == 'prod'", ServiceAccounts: &apiv3.ServiceAccountMatch{Selector: "role == 'db'"}},
+					},
+					apiv3.Rule{
+						Action:   apiv3.Allow,
+						Protocol: protocol("ICMP"),
+						ICMP:     &apiv3.ICMPFields{Type: &icmpType},
+					},
+					apiv3.Rule{
+						Action:      apiv3.Allow,
+						Destination: apiv3.EntityRule{Nets: []string{"fd00::/64"}},
+					},
+				),
+			}
+			Expect(run(Test{From: peer("prod/a"), To: peer("prod/b"), Port: 80, Expect: Allow}).Passed()).To(BeTrue())
+			Expect(run(Test{From: peer("prod/a"), To: peer("c"), Port: 80, Expect: Deny}).Passed()).To(BeTrue())
+			Expect(run(Test{From: peer("prod/a"), To: peer("c"), Protocol: "ICMP", Expect: Allow}).Passed()).To(BeTrue())
+			reply := uint8(0)
+			Expect(run(Test{From: peer("prod/a"), To: peer("c"), Protocol: "ICMP", ICMPType: &reply, Expect: Deny}).Passed()).To(BeTrue())
+			Expect(run(Test{From: peer("prod/a"), To: Peer{IP: "fd00::99"}, Port: 80, Expect: Allow}).Passed()).To(BeTrue())
+			Expect(run(Test{From: peer("prod/a"), To: Peer{IP: "fd01::99"}, Port: 80, Expect: Deny}).Passed()).To(BeTrue())
+		})
+
+		It("should apply Kubernetes policies with namespace selectors", func() {
+			np := &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "from-prod", Namespace: "default"},
+				Spec: networkingv1.NetworkPolicySpec{
+					Ingress: []networkingv1.NetworkPolicyIngressRule{{
+						From: []networkingv1.NetworkPolicyPeer{{
+							NamespaceSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"kubernetes.io/metadata.name": "prod"},
+							},
+						}},
+					}},
+				},
+			}
+			s.Objects = []runtime.Object{np}
+			Expect(run(Test{From: peer("prod/a"), To: peer("c"), Port: 80, Expect: Allow}).Passed()).To(BeTrue())
+			Expect(run(Test{From: Peer{IP: "192.0.2.1"}, To: peer("c"), Port: 80, Expect: Deny}).Passed()).To(BeTrue())
+		})
+
+		It("should reject malformed tests", func() {
+			for _, t := range []Test{
+				{From: peer("prod/x"), To: peer("c"), Port: 80, Expect: Allow},
+				{From: Peer{IP: "192.0.2.1"}, To: Peer{IP: "192.0.2.2"}, Port: 80, Expect: Allow},
+				{From: peer("prod/a"), To: peer("c"), Expect: Allow},
+				{From: peer("prod/a"), To: peer("c"), Port: 80, Expect: "Maybe"},
+				{From: peer("prod/a"), To: peer("c"), Port: 80, IPVersion: 6, Expect: Allow},
+			} {
+				s.Tests = []Test{t}
+				_, err := Run(s)
+				Expect(err).To(HaveOccurred(), "test %+v", t)
+			}
+		})
+
+		It("should reject a policy in a tier that doesn't exist", func() {
+			s.Objects = []runtime.Object{gnp("missing", "missing.policy", 1, "all()", apiv3.Rule{Action: apiv3.Allow})}
+			_, err := Run(s)
+			Expect(err).To(MatchError("tier missing does not exist"))
+		})
+	})
+})
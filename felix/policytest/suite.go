@@ -0,0 +1,300 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policytest checks declarative connectivity assertions against a set of Calico and
+// Kubernetes network policies, without a cluster or a dataplane.
+//
+// The policies, and fake endpoints with labels and IPs, are fed through Felix's calculation graph,
+// as if all the endpoints were local to one Felix.  Each assertion is then evaluated against the
+// policies, profiles and IP sets that the calculation graph computes for the endpoints, using the
+// same semantics as the rules that Felix renders into the dataplane: the egress policy of the
+// source endpoint and the ingress policy of the destination endpoint must both allow the traffic.
+//
+// Only layer 3 and 4 matches are evaluated, as in Felix's dataplane; HTTP matches are ignored.
+// Kubernetes Services are not modelled, so rules that match services never match.
+//
+// Lint analyses the same resources statically, without endpoints or tests, to find rules that
+// earlier rules shadow, selectors that can never match, and duplicate policies.
+package policytest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	kapiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Suite is a set of connectivity tests, and the policies and endpoints that they test.
+type Suite struct {
+	// Resources are the files, or directories of files, that contain the policies and other
+	// resources to test.  Relative paths are relative to the directory of the suite file.
+	Resources []string `json:"resources,omitempty"`
+
+	// Namespaces are the namespaces of the endpoints and policies.  Namespaces that are used but
+	// not listed here, or in the resources, are created without labels.
+	Namespaces []Namespace `json:"namespaces,omitempty"`
+
+	// ServiceAccounts are the service accounts of the endpoints.  Service accounts that are used
+	// but not listed here, or in the resources, are created without labels.
+	ServiceAccounts []ServiceAccount `json:"serviceAccounts,omitempty"`
+
+	// Endpoints are the fake pods to test connectivity between.
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+
+	// Tests are the connectivity assertions.
+	Tests []Test `json:"tests"`
+
+	// Objects are the resources loaded from Resources.  Callers that construct a Suite in code
+	// may add their own.
+	Objects []runtime.Object `json:"-"`
+}
+
+// Namespace is a Kubernetes namespace.
+type Namespace struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ServiceAccount is a Kubernetes service account.
+type ServiceAccount struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// Endpoint is a fake pod.
+type Endpoint struct {
+	Name           string            `json:"name"`
+	Namespace      string            `json:"namespace,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	IPs            []string          `json:"ips"`
+	ServiceAccount string            `json:"serviceAccount,omitempty"`
+	Ports          []EndpointPort    `json:"ports,omitempty"`
+}
+
+// EndpointPort is a named port of an Endpoint, which rules can match by name.
+type EndpointPort struct {
+	Name string `json:"name"`
+	// Protocol is TCP, UDP or SCTP.  It defaults to TCP.
+	Protocol string `json:"protocol,omitempty"`
+	Port     int32  `json:"port"`
+}
+
+// Test asserts whether a connection is allowed.
+type Test struct {
+	Name string `json:"name"`
+	From Peer   `json:"from"`
+	To   Peer   `json:"to"`
+
+	// Protocol is a protocol name, such as TCP, UDP, SCTP, ICMP or ICMPv6, or number.  It
+	// defaults to TCP.
+	Protocol string `json:"protocol,omitempty"`
+	// Port is the destination port, which is required for TCP, UDP and SCTP.
+	Port uint16 `json:"port,omitempty"`
+	// SrcPort is the source port.  It defaults to 49152, the first of the dynamic ports.
+	SrcPort uint16 `json:"srcPort,omitempty"`
+	// ICMPType and ICMPCode are the type and code of an ICMP or ICMPv6 packet.  They default to
+	// an echo request.
+	ICMPType *uint8 `json:"icmpType,omitempty"`
+	ICMPCode *uint8 `json:"icmpCode,omitempty"`
+	// IPVersion is the IP version of the connection, 4 or 6.  If unset, IPv4 is used if both
+	// peers have an IPv4 address, otherwise IPv6.
+	IPVersion int `json:"ipVersion,omitempty"`
+
+	// Expect is the expected verdict.
+	Expect Verdict `json:"expect"`
+}
+
+// Peer is one end of a Test's connection: either an Endpoint, as "<namespace>/<name>", or
+// "<name>" for an Endpoint in the default namespace, or an IP address that is not an Endpoint.
+type Peer struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	IP       string `json:"ip,omitempty"`
+}
+
+func (p Peer) String() string {
+	if p.Endpoint != "" {
+		return p.Endpoint
+	}
+	return p.IP
+}
+
+// Verdict is whether a connection is allowed.
+type Verdict string
+
+const (
+	Allow Verdict = "Allow"
+	Deny  Verdict = "Deny"
+)
+
+// LoadSuite loads a suite file, and the resources that it refers to.
+func LoadSuite(filename string) (*Suite, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	s := &Suite{}
+	if err := yaml.UnmarshalStrict(b, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	for _, r := range s.Resources {
+		if !filepath.IsAbs(r) {
+			r = filepath.Join(filepath.Dir(filename), r)
+		}
+		objs, err := LoadResources(r)
+		if err != nil {
+			return nil, err
+		}
+		s.Objects = append(s.Objects, objs...)
+	}
+	return s, nil
+}
+
+// LoadResources loads the resources in a YAML or JSON file, which may contain several documents and
+// Kubernetes Lists, or in all the .yaml, .yml and .json files under a directory.
+func LoadResources(path string) ([]runtime.Object, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return loadResourceFile(path)
+	}
+
+	var objs []runtime.Object
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		switch filepath.Ext(p) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		fileObjs, err := loadResourceFile(p)
+		if err != nil {
+			return err
+		}
+		objs = append(objs, fileObjs...)
+		return nil
+	})
+	return objs, err
+}
+
+func loadResourceFile(filename string) ([]runtime.Object, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var objs []runtime.Object
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(f))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		docObjs, err := decodeResources(doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", filename, err)
+		}
+		objs = append(objs, docObjs...)
+	}
+	return objs, nil
+}
+
+// decodeResources decodes a single resource, or the items of a List.
+func decodeResources(doc []byte) ([]runtime.Object, error) {
+	var tm metav1.TypeMeta
+	if err := yaml.Unmarshal(doc, &tm); err != nil {
+		return nil, err
+	}
+	gvk := schema.FromAPIVersionAndKind(tm.APIVersion, tm.Kind)
+
+	if gvk == kapiv1.SchemeGroupVersion.WithKind("List") {
+		var list struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		if err := yaml.Unmarshal(doc, &list); err != nil {
+			return nil, err
+		}
+		var objs []runtime.Object
+		for _, item := range list.Items {
+			itemObjs, err := decodeResources(item)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, itemObjs...)
+		}
+		return objs, nil
+	}
+
+	obj, err := newObject(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(doc, obj); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", tm.Kind, err)
+	}
+	return []runtime.Object{obj}, nil
+}
+
+func newObject(gvk schema.GroupVersionKind) (runtime.Object, error) {
+	switch gvk {
+	case apiv3.SchemeGroupVersion.WithKind(apiv3.KindNetworkPolicy):
+		return apiv3.NewNetworkPolicy(), nil
+	case apiv3.SchemeGroupVersion.WithKind(apiv3.KindGlobalNetworkPolicy):
+		return apiv3.NewGlobalNetworkPolicy(), nil
+	case apiv3.SchemeGroupVersion.WithKind(apiv3.KindTier):
+		return apiv3.NewTier(), nil
+	case apiv3.SchemeGroupVersion.WithKind(apiv3.KindNetworkSet):
+		return apiv3.NewNetworkSet(), nil
+	case apiv3.SchemeGroupVersion.WithKind(apiv3.KindGlobalNetworkSet):
+		return apiv3.NewGlobalNetworkSet(), nil
+	case apiv3.SchemeGroupVersion.WithKind(apiv3.KindProfile):
+		return apiv3.NewProfile(), nil
+	case networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"):
+		return &networkingv1.NetworkPolicy{}, nil
+	case kapiv1.SchemeGroupVersion.WithKind("Namespace"):
+		return &kapiv1.Namespace{}, nil
+	case kapiv1.SchemeGroupVersion.WithKind("ServiceAccount"):
+		return &kapiv1.ServiceAccount{}, nil
+	case kapiv1.SchemeGroupVersion.WithKind("Pod"):
+		return &kapiv1.Pod{}, nil
+	}
+	return nil, fmt.Errorf("unsupported resource kind %q of apiVersion %q; supported resources are "+
+		"projectcalico.org/v3 NetworkPolicy, GlobalNetworkPolicy, Tier, NetworkSet, GlobalNetworkSet and Profile, "+
+		"networking.k8s.io/v1 NetworkPolicy, and v1 Namespace, ServiceAccount and Pod",
+		gvk.Kind, gvk.GroupVersion().String())
+}
@@ -50,6 +50,9 @@ It has these top-level messages:
 	HostEndpointStatusRemove
 	WorkloadEndpointStatusUpdate
 	WorkloadEndpointStatusRemove
+	PolicyStatusUpdate
+	PolicyStatusRemove
+	PolicyStatus
 	WireguardStatusUpdate
 	DataplaneInSync
 	HostMetadataV4V6Update
@@ -78,9 +81,12 @@ It has these top-level messages:
 	WireguardEndpointV6Update
 	WireguardEndpointV6Remove
 	GlobalBGPConfigUpdate
+	RoutePolicyUpdate
+	RoutePolicyRemove
 	ServicePort
 	ServiceUpdate
 	ServiceRemove
+	DataplaneSnapshot
 */
 package proto
 
@@ -276,6 +282,8 @@ type ToDataplane struct {
 	//	*ToDataplane_WireguardEndpointV6Remove
 	//	*ToDataplane_HostMetadataV6Update
 	//	*ToDataplane_HostMetadataV6Remove
+	//	*ToDataplane_RoutePolicyUpdate
+	//	*ToDataplane_RoutePolicyRemove
 	Payload isToDataplane_Payload `protobuf_oneof:"payload"`
 }
 
@@ -401,6 +409,12 @@ type ToDataplane_HostMetadataV6Update struct {
 type ToDataplane_HostMetadataV6Remove struct {
 	HostMetadataV6Remove *HostMetadataV6Remove `protobuf:"bytes,36,opt,name=host_metadata_v6_remove,json=hostMetadataV6Remove,oneof"`
 }
+type ToDataplane_RoutePolicyUpdate struct {
+	RoutePolicyUpdate *RoutePolicyUpdate `protobuf:"bytes,39,opt,name=route_policy_update,json=routePolicyUpdate,oneof"`
+}
+type ToDataplane_RoutePolicyRemove struct {
+	RoutePolicyRemove *RoutePolicyRemove `protobuf:"bytes,40,opt,name=route_policy_remove,json=routePolicyRemove,oneof"`
+}
 
 func (*ToDataplane_InSync) isToDataplane_Payload()                    {}
 func (*ToDataplane_IpsetUpdate) isToDataplane_Payload()               {}
@@ -439,6 +453,8 @@ func (*ToDataplane_WireguardEndpointV6Update) isToDataplane_Payload() {}
 func (*ToDataplane_WireguardEndpointV6Remove) isToDataplane_Payload() {}
 func (*ToDataplane_HostMetadataV6Update) isToDataplane_Payload()      {}
 func (*ToDataplane_HostMetadataV6Remove) isToDataplane_Payload()      {}
+func (*ToDataplane_RoutePolicyUpdate) isToDataplane_Payload()         {}
+func (*ToDataplane_RoutePolicyRemove) isToDataplane_Payload()         {}
 
 func (m *ToDataplane) GetPayload() isToDataplane_Payload {
 	if m != nil {
@@ -713,6 +729,20 @@ func (m *ToDataplane) GetHostMetadataV6Remove() *HostMetadataV6Remove {
 	return nil
 }
 
+func (m *ToDataplane) GetRoutePolicyUpdate() *RoutePolicyUpdate {
+	if x, ok := m.GetPayload().(*ToDataplane_RoutePolicyUpdate); ok {
+		return x.RoutePolicyUpdate
+	}
+	return nil
+}
+
+func (m *ToDataplane) GetRoutePolicyRemove() *RoutePolicyRemove {
+	if x, ok := m.GetPayload().(*ToDataplane_RoutePolicyRemove); ok {
+		return x.RoutePolicyRemove
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*ToDataplane) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer) error, func(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error), func(msg proto1.Message) (n int), []interface{}) {
 	return _ToDataplane_OneofMarshaler, _ToDataplane_OneofUnmarshaler, _ToDataplane_OneofSizer, []interface{}{
@@ -753,6 +783,8 @@ func (*ToDataplane) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer)
 		(*ToDataplane_WireguardEndpointV6Remove)(nil),
 		(*ToDataplane_HostMetadataV6Update)(nil),
 		(*ToDataplane_HostMetadataV6Remove)(nil),
+		(*ToDataplane_RoutePolicyUpdate)(nil),
+		(*ToDataplane_RoutePolicyRemove)(nil),
 	}
 }
 
@@ -945,6 +977,16 @@ func _ToDataplane_OneofMarshaler(msg proto1.Message, b *proto1.Buffer) error {
 		if err := b.EncodeMessage(x.HostMetadataV6Remove); err != nil {
 			return err
 		}
+	case *ToDataplane_RoutePolicyUpdate:
+		_ = b.EncodeVarint(39<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.RoutePolicyUpdate); err != nil {
+			return err
+		}
+	case *ToDataplane_RoutePolicyRemove:
+		_ = b.EncodeVarint(40<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.RoutePolicyRemove); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("ToDataplane.Payload has unexpected type %T", x)
@@ -1251,6 +1293,22 @@ func _ToDataplane_OneofUnmarshaler(msg proto1.Message, tag, wire int, b *proto1.
 		err := b.DecodeMessage(msg)
 		m.Payload = &ToDataplane_HostMetadataV6Remove{msg}
 		return true, err
+	case 39: // payload.route_policy_update
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(RoutePolicyUpdate)
+		err := b.DecodeMessage(msg)
+		m.Payload = &ToDataplane_RoutePolicyUpdate{msg}
+		return true, err
+	case 40: // payload.route_policy_remove
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(RoutePolicyRemove)
+		err := b.DecodeMessage(msg)
+		m.Payload = &ToDataplane_RoutePolicyRemove{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1445,6 +1503,16 @@ func _ToDataplane_OneofSizer(msg proto1.Message) (n int) {
 		n += proto1.SizeVarint(36<<3 | proto1.WireBytes)
 		n += proto1.SizeVarint(uint64(s))
 		n += s
+	case *ToDataplane_RoutePolicyUpdate:
+		s := proto1.Size(x.RoutePolicyUpdate)
+		n += proto1.SizeVarint(39<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case *ToDataplane_RoutePolicyRemove:
+		s := proto1.Size(x.RoutePolicyRemove)
+		n += proto1.SizeVarint(40<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1462,6 +1530,8 @@ type FromDataplane struct {
 	//	*FromDataplane_WorkloadEndpointStatusRemove
 	//	*FromDataplane_WireguardStatusUpdate
 	//	*FromDataplane_DataplaneInSync
+	//	*FromDataplane_PolicyStatusUpdate
+	//	*FromDataplane_PolicyStatusRemove
 	Payload isFromDataplane_Payload `protobuf_oneof:"payload"`
 }
 
@@ -1497,6 +1567,12 @@ type FromDataplane_WireguardStatusUpdate struct {
 type FromDataplane_DataplaneInSync struct {
 	DataplaneInSync *DataplaneInSync `protobuf:"bytes,10,opt,name=dataplane_in_sync,json=dataplaneInSync,oneof"`
 }
+type FromDataplane_PolicyStatusUpdate struct {
+	PolicyStatusUpdate *PolicyStatusUpdate `protobuf:"bytes,11,opt,name=policy_status_update,json=policyStatusUpdate,oneof"`
+}
+type FromDataplane_PolicyStatusRemove struct {
+	PolicyStatusRemove *PolicyStatusRemove `protobuf:"bytes,12,opt,name=policy_status_remove,json=policyStatusRemove,oneof"`
+}
 
 func (*FromDataplane_ProcessStatusUpdate) isFromDataplane_Payload()          {}
 func (*FromDataplane_HostEndpointStatusUpdate) isFromDataplane_Payload()     {}
@@ -1505,6 +1581,8 @@ func (*FromDataplane_WorkloadEndpointStatusUpdate) isFromDataplane_Payload() {}
 func (*FromDataplane_WorkloadEndpointStatusRemove) isFromDataplane_Payload() {}
 func (*FromDataplane_WireguardStatusUpdate) isFromDataplane_Payload()        {}
 func (*FromDataplane_DataplaneInSync) isFromDataplane_Payload()              {}
+func (*FromDataplane_PolicyStatusUpdate) isFromDataplane_Payload()           {}
+func (*FromDataplane_PolicyStatusRemove) isFromDataplane_Payload()           {}
 
 func (m *FromDataplane) GetPayload() isFromDataplane_Payload {
 	if m != nil {
@@ -1569,6 +1647,20 @@ func (m *FromDataplane) GetDataplaneInSync() *DataplaneInSync {
 	return nil
 }
 
+func (m *FromDataplane) GetPolicyStatusUpdate() *PolicyStatusUpdate {
+	if x, ok := m.GetPayload().(*FromDataplane_PolicyStatusUpdate); ok {
+		return x.PolicyStatusUpdate
+	}
+	return nil
+}
+
+func (m *FromDataplane) GetPolicyStatusRemove() *PolicyStatusRemove {
+	if x, ok := m.GetPayload().(*FromDataplane_PolicyStatusRemove); ok {
+		return x.PolicyStatusRemove
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*FromDataplane) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer) error, func(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error), func(msg proto1.Message) (n int), []interface{}) {
 	return _FromDataplane_OneofMarshaler, _FromDataplane_OneofUnmarshaler, _FromDataplane_OneofSizer, []interface{}{
@@ -1579,6 +1671,8 @@ func (*FromDataplane) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffe
 		(*FromDataplane_WorkloadEndpointStatusRemove)(nil),
 		(*FromDataplane_WireguardStatusUpdate)(nil),
 		(*FromDataplane_DataplaneInSync)(nil),
+		(*FromDataplane_PolicyStatusUpdate)(nil),
+		(*FromDataplane_PolicyStatusRemove)(nil),
 	}
 }
 
@@ -1621,6 +1715,16 @@ func _FromDataplane_OneofMarshaler(msg proto1.Message, b *proto1.Buffer) error {
 		if err := b.EncodeMessage(x.DataplaneInSync); err != nil {
 			return err
 		}
+	case *FromDataplane_PolicyStatusUpdate:
+		_ = b.EncodeVarint(11<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.PolicyStatusUpdate); err != nil {
+			return err
+		}
+	case *FromDataplane_PolicyStatusRemove:
+		_ = b.EncodeVarint(12<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.PolicyStatusRemove); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("FromDataplane.Payload has unexpected type %T", x)
@@ -1687,6 +1791,22 @@ func _FromDataplane_OneofUnmarshaler(msg proto1.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Payload = &FromDataplane_DataplaneInSync{msg}
 		return true, err
+	case 11: // payload.policy_status_update
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(PolicyStatusUpdate)
+		err := b.DecodeMessage(msg)
+		m.Payload = &FromDataplane_PolicyStatusUpdate{msg}
+		return true, err
+	case 12: // payload.policy_status_remove
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(PolicyStatusRemove)
+		err := b.DecodeMessage(msg)
+		m.Payload = &FromDataplane_PolicyStatusRemove{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1731,6 +1851,16 @@ func _FromDataplane_OneofSizer(msg proto1.Message) (n int) {
 		n += proto1.SizeVarint(10<<3 | proto1.WireBytes)
 		n += proto1.SizeVarint(uint64(s))
 		n += s
+	case *FromDataplane_PolicyStatusUpdate:
+		s := proto1.Size(x.PolicyStatusUpdate)
+		n += proto1.SizeVarint(11<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case *FromDataplane_PolicyStatusRemove:
+		s := proto1.Size(x.PolicyStatusRemove)
+		n += proto1.SizeVarint(12<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -2037,6 +2167,9 @@ type Policy struct {
 	Untracked        bool    `protobuf:"varint,3,opt,name=untracked,proto3" json:"untracked,omitempty"`
 	PreDnat          bool    `protobuf:"varint,4,opt,name=pre_dnat,json=preDnat,proto3" json:"pre_dnat,omitempty"`
 	OriginalSelector string  `protobuf:"bytes,6,opt,name=original_selector,json=originalSelector,proto3" json:"original_selector,omitempty"`
+	// Hash of the content of the policy in the datastore, used to identify the
+	// version of the policy in policy status reports.
+	ContentHash string `protobuf:"bytes,7,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
 }
 
 func (m *Policy) Reset()                    { *m = Policy{} }
@@ -2086,6 +2219,13 @@ func (m *Policy) GetOriginalSelector() string {
 	return ""
 }
 
+func (m *Policy) GetContentHash() string {
+	if m != nil {
+		return m.ContentHash
+	}
+	return ""
+}
+
 type Rule struct {
 	Action    string    `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
 	IpVersion IPVersion `protobuf:"varint,2,opt,name=ip_version,json=ipVersion,proto3,enum=felix.IPVersion" json:"ip_version,omitempty"`
@@ -2106,12 +2246,17 @@ type Rule struct {
 	SrcIpSetIds []string    `protobuf:"bytes,10,rep,name=src_ip_set_ids,json=srcIpSetIds" json:"src_ip_set_ids,omitempty"`
 	DstIpSetIds []string    `protobuf:"bytes,11,rep,name=dst_ip_set_ids,json=dstIpSetIds" json:"dst_ip_set_ids,omitempty"`
 	// IP sets on which we should match both IP and port.
-	DstIpPortSetIds []string     `protobuf:"bytes,15,rep,name=dst_ip_port_set_ids,json=dstIpPortSetIds" json:"dst_ip_port_set_ids,omitempty"`
-	NotProtocol     *Protocol    `protobuf:"bytes,102,opt,name=not_protocol,json=notProtocol" json:"not_protocol,omitempty"`
-	NotSrcNet       []string     `protobuf:"bytes,103,rep,name=not_src_net,json=notSrcNet" json:"not_src_net,omitempty"`
-	NotSrcPorts     []*PortRange `protobuf:"bytes,104,rep,name=not_src_ports,json=notSrcPorts" json:"not_src_ports,omitempty"`
-	NotDstNet       []string     `protobuf:"bytes,105,rep,name=not_dst_net,json=notDstNet" json:"not_dst_net,omitempty"`
-	NotDstPorts     []*PortRange `protobuf:"bytes,106,rep,name=not_dst_ports,json=notDstPorts" json:"not_dst_ports,omitempty"`
+	DstIpPortSetIds []string `protobuf:"bytes,15,rep,name=dst_ip_port_set_ids,json=dstIpPortSetIds" json:"dst_ip_port_set_ids,omitempty"`
+	// If non-zero, the rule only matches new connections from sources that already have more
+	// than this many tracked connections.
+	ConnLimit int32 `protobuf:"varint,16,opt,name=conn_limit,json=connLimit,proto3" json:"conn_limit,omitempty"`
+	// IP sets on which we should match the source IP, and the protocol and destination port.
+	SrcIpDstPortIpSetIds []string     `protobuf:"bytes,17,rep,name=src_ip_dst_port_ip_set_ids,json=srcIpDstPortIpSetIds" json:"src_ip_dst_port_ip_set_ids,omitempty"`
+	NotProtocol          *Protocol    `protobuf:"bytes,102,opt,name=not_protocol,json=notProtocol" json:"not_protocol,omitempty"`
+	NotSrcNet            []string     `protobuf:"bytes,103,rep,name=not_src_net,json=notSrcNet" json:"not_src_net,omitempty"`
+	NotSrcPorts          []*PortRange `protobuf:"bytes,104,rep,name=not_src_ports,json=notSrcPorts" json:"not_src_ports,omitempty"`
+	NotDstNet            []string     `protobuf:"bytes,105,rep,name=not_dst_net,json=notDstNet" json:"not_dst_net,omitempty"`
+	NotDstPorts          []*PortRange `protobuf:"bytes,106,rep,name=not_dst_ports,json=notDstPorts" json:"not_dst_ports,omitempty"`
 	// Types that are valid to be assigned to NotIcmp:
 	//	*Rule_NotIcmpType
 	//	*Rule_NotIcmpTypeCode
@@ -2289,6 +2434,20 @@ func (m *Rule) GetDstIpPortSetIds() []string {
 	return nil
 }
 
+func (m *Rule) GetConnLimit() int32 {
+	if m != nil {
+		return m.ConnLimit
+	}
+	return 0
+}
+
+func (m *Rule) GetSrcIpDstPortIpSetIds() []string {
+	if m != nil {
+		return m.SrcIpDstPortIpSetIds
+	}
+	return nil
+}
+
 func (m *Rule) GetNotProtocol() *Protocol {
 	if m != nil {
 		return m.NotProtocol
@@ -2612,8 +2771,12 @@ func (m *ServiceAccountMatch) GetNames() []string {
 }
 
 type HTTPMatch struct {
-	Methods []string               `protobuf:"bytes,1,rep,name=methods" json:"methods,omitempty"`
-	Paths   []*HTTPMatch_PathMatch `protobuf:"bytes,2,rep,name=paths" json:"paths,omitempty"`
+	Methods   []string                 `protobuf:"bytes,1,rep,name=methods" json:"methods,omitempty"`
+	Paths     []*HTTPMatch_PathMatch   `protobuf:"bytes,2,rep,name=paths" json:"paths,omitempty"`
+	Headers   []*HTTPMatch_HeaderMatch `protobuf:"bytes,3,rep,name=headers" json:"headers,omitempty"`
+	Hosts     []string                 `protobuf:"bytes,4,rep,name=hosts" json:"hosts,omitempty"`
+	Grpc      *HTTPMatch_GRPCMatch     `protobuf:"bytes,5,opt,name=grpc" json:"grpc,omitempty"`
+	JwtClaims []*HTTPMatch_ClaimMatch  `protobuf:"bytes,6,rep,name=jwt_claims,json=jwtClaims" json:"jwt_claims,omitempty"`
 }
 
 func (m *HTTPMatch) Reset()                    { *m = HTTPMatch{} }
@@ -2635,6 +2798,34 @@ func (m *HTTPMatch) GetPaths() []*HTTPMatch_PathMatch {
 	return nil
 }
 
+func (m *HTTPMatch) GetHeaders() []*HTTPMatch_HeaderMatch {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *HTTPMatch) GetHosts() []string {
+	if m != nil {
+		return m.Hosts
+	}
+	return nil
+}
+
+func (m *HTTPMatch) GetGrpc() *HTTPMatch_GRPCMatch {
+	if m != nil {
+		return m.Grpc
+	}
+	return nil
+}
+
+func (m *HTTPMatch) GetJwtClaims() []*HTTPMatch_ClaimMatch {
+	if m != nil {
+		return m.JwtClaims
+	}
+	return nil
+}
+
 type HTTPMatch_PathMatch struct {
 	// Types that are valid to be assigned to PathMatch:
 	//	*HTTPMatch_PathMatch_Exact
@@ -2752,6 +2943,213 @@ func _HTTPMatch_PathMatch_OneofSizer(msg proto1.Message) (n int) {
 	return n
 }
 
+type HTTPMatch_HeaderMatch struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// If none of these are set, the header only needs to be present.
+	//
+	// Types that are valid to be assigned to ValueMatch:
+	//	*HTTPMatch_HeaderMatch_Exact
+	//	*HTTPMatch_HeaderMatch_Prefix
+	//	*HTTPMatch_HeaderMatch_Regex
+	ValueMatch isHTTPMatch_HeaderMatch_ValueMatch `protobuf_oneof:"value_match"`
+}
+
+func (m *HTTPMatch_HeaderMatch) Reset()         { *m = HTTPMatch_HeaderMatch{} }
+func (m *HTTPMatch_HeaderMatch) String() string { return proto1.CompactTextString(m) }
+func (*HTTPMatch_HeaderMatch) ProtoMessage()    {}
+func (*HTTPMatch_HeaderMatch) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{19, 1}
+}
+
+type isHTTPMatch_HeaderMatch_ValueMatch interface {
+	isHTTPMatch_HeaderMatch_ValueMatch()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type HTTPMatch_HeaderMatch_Exact struct {
+	Exact string `protobuf:"bytes,2,opt,name=exact,proto3,oneof"`
+}
+type HTTPMatch_HeaderMatch_Prefix struct {
+	Prefix string `protobuf:"bytes,3,opt,name=prefix,proto3,oneof"`
+}
+type HTTPMatch_HeaderMatch_Regex struct {
+	Regex string `protobuf:"bytes,4,opt,name=regex,proto3,oneof"`
+}
+
+func (*HTTPMatch_HeaderMatch_Exact) isHTTPMatch_HeaderMatch_ValueMatch()  {}
+func (*HTTPMatch_HeaderMatch_Prefix) isHTTPMatch_HeaderMatch_ValueMatch() {}
+func (*HTTPMatch_HeaderMatch_Regex) isHTTPMatch_HeaderMatch_ValueMatch()  {}
+
+func (m *HTTPMatch_HeaderMatch) GetValueMatch() isHTTPMatch_HeaderMatch_ValueMatch {
+	if m != nil {
+		return m.ValueMatch
+	}
+	return nil
+}
+
+func (m *HTTPMatch_HeaderMatch) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HTTPMatch_HeaderMatch) GetExact() string {
+	if x, ok := m.GetValueMatch().(*HTTPMatch_HeaderMatch_Exact); ok {
+		return x.Exact
+	}
+	return ""
+}
+
+func (m *HTTPMatch_HeaderMatch) GetPrefix() string {
+	if x, ok := m.GetValueMatch().(*HTTPMatch_HeaderMatch_Prefix); ok {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (m *HTTPMatch_HeaderMatch) GetRegex() string {
+	if x, ok := m.GetValueMatch().(*HTTPMatch_HeaderMatch_Regex); ok {
+		return x.Regex
+	}
+	return ""
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*HTTPMatch_HeaderMatch) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer) error, func(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error), func(msg proto1.Message) (n int), []interface{}) {
+	return _HTTPMatch_HeaderMatch_OneofMarshaler, _HTTPMatch_HeaderMatch_OneofUnmarshaler, _HTTPMatch_HeaderMatch_OneofSizer, []interface{}{
+		(*HTTPMatch_HeaderMatch_Exact)(nil),
+		(*HTTPMatch_HeaderMatch_Prefix)(nil),
+		(*HTTPMatch_HeaderMatch_Regex)(nil),
+	}
+}
+
+func _HTTPMatch_HeaderMatch_OneofMarshaler(msg proto1.Message, b *proto1.Buffer) error {
+	m := msg.(*HTTPMatch_HeaderMatch)
+	// value_match
+	switch x := m.ValueMatch.(type) {
+	case *HTTPMatch_HeaderMatch_Exact:
+		_ = b.EncodeVarint(2<<3 | proto1.WireBytes)
+		_ = b.EncodeStringBytes(x.Exact)
+	case *HTTPMatch_HeaderMatch_Prefix:
+		_ = b.EncodeVarint(3<<3 | proto1.WireBytes)
+		_ = b.EncodeStringBytes(x.Prefix)
+	case *HTTPMatch_HeaderMatch_Regex:
+		_ = b.EncodeVarint(4<<3 | proto1.WireBytes)
+		_ = b.EncodeStringBytes(x.Regex)
+	case nil:
+	default:
+		return fmt.Errorf("HTTPMatch_HeaderMatch.ValueMatch has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _HTTPMatch_HeaderMatch_OneofUnmarshaler(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error) {
+	m := msg.(*HTTPMatch_HeaderMatch)
+	switch tag {
+	case 2: // value_match.exact
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.ValueMatch = &HTTPMatch_HeaderMatch_Exact{x}
+		return true, err
+	case 3: // value_match.prefix
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.ValueMatch = &HTTPMatch_HeaderMatch_Prefix{x}
+		return true, err
+	case 4: // value_match.regex
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.ValueMatch = &HTTPMatch_HeaderMatch_Regex{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _HTTPMatch_HeaderMatch_OneofSizer(msg proto1.Message) (n int) {
+	m := msg.(*HTTPMatch_HeaderMatch)
+	// value_match
+	switch x := m.ValueMatch.(type) {
+	case *HTTPMatch_HeaderMatch_Exact:
+		n += proto1.SizeVarint(2<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(len(x.Exact)))
+		n += len(x.Exact)
+	case *HTTPMatch_HeaderMatch_Prefix:
+		n += proto1.SizeVarint(3<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(len(x.Prefix)))
+		n += len(x.Prefix)
+	case *HTTPMatch_HeaderMatch_Regex:
+		n += proto1.SizeVarint(4<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(len(x.Regex)))
+		n += len(x.Regex)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type HTTPMatch_GRPCMatch struct {
+	Services []string `protobuf:"bytes,1,rep,name=services" json:"services,omitempty"`
+	Methods  []string `protobuf:"bytes,2,rep,name=methods" json:"methods,omitempty"`
+}
+
+func (m *HTTPMatch_GRPCMatch) Reset()         { *m = HTTPMatch_GRPCMatch{} }
+func (m *HTTPMatch_GRPCMatch) String() string { return proto1.CompactTextString(m) }
+func (*HTTPMatch_GRPCMatch) ProtoMessage()    {}
+func (*HTTPMatch_GRPCMatch) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{19, 2}
+}
+
+func (m *HTTPMatch_GRPCMatch) GetServices() []string {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+func (m *HTTPMatch_GRPCMatch) GetMethods() []string {
+	if m != nil {
+		return m.Methods
+	}
+	return nil
+}
+
+// Matches the claims of a JWT that Envoy's JWT authentication filter has verified.
+type HTTPMatch_ClaimMatch struct {
+	Name   string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values []string `protobuf:"bytes,2,rep,name=values" json:"values,omitempty"`
+}
+
+func (m *HTTPMatch_ClaimMatch) Reset()         { *m = HTTPMatch_ClaimMatch{} }
+func (m *HTTPMatch_ClaimMatch) String() string { return proto1.CompactTextString(m) }
+func (*HTTPMatch_ClaimMatch) ProtoMessage()    {}
+func (*HTTPMatch_ClaimMatch) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{19, 3}
+}
+
+func (m *HTTPMatch_ClaimMatch) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HTTPMatch_ClaimMatch) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 type RuleMetadata struct {
 	Annotations map[string]string `protobuf:"bytes,1,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
@@ -3417,58 +3815,133 @@ func (m *WorkloadEndpointStatusRemove) GetId() *WorkloadEndpointID {
 	return nil
 }
 
-type WireguardStatusUpdate struct {
-	// Wireguard public-key set on the interface.
-	PublicKey string `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
-	// The IP version of this update
-	IpVersion IPVersion `protobuf:"varint,2,opt,name=ip_version,json=ipVersion,proto3,enum=felix.IPVersion" json:"ip_version,omitempty"`
+type PolicyStatusUpdate struct {
+	Id     *PolicyID     `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Status *PolicyStatus `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
 }
 
-func (m *WireguardStatusUpdate) Reset()         { *m = WireguardStatusUpdate{} }
-func (m *WireguardStatusUpdate) String() string { return proto1.CompactTextString(m) }
-func (*WireguardStatusUpdate) ProtoMessage()    {}
-func (*WireguardStatusUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{40}
-}
+func (m *PolicyStatusUpdate) Reset()                    { *m = PolicyStatusUpdate{} }
+func (m *PolicyStatusUpdate) String() string            { return proto1.CompactTextString(m) }
+func (*PolicyStatusUpdate) ProtoMessage()               {}
+func (*PolicyStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{40} }
 
-func (m *WireguardStatusUpdate) GetPublicKey() string {
+func (m *PolicyStatusUpdate) GetId() *PolicyID {
 	if m != nil {
-		return m.PublicKey
+		return m.Id
 	}
-	return ""
+	return nil
 }
 
-func (m *WireguardStatusUpdate) GetIpVersion() IPVersion {
+func (m *PolicyStatusUpdate) GetStatus() *PolicyStatus {
 	if m != nil {
-		return m.IpVersion
+		return m.Status
 	}
-	return IPVersion_ANY
+	return nil
 }
 
-type DataplaneInSync struct {
+type PolicyStatusRemove struct {
+	Id *PolicyID `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 }
 
-func (m *DataplaneInSync) Reset()                    { *m = DataplaneInSync{} }
-func (m *DataplaneInSync) String() string            { return proto1.CompactTextString(m) }
-func (*DataplaneInSync) ProtoMessage()               {}
-func (*DataplaneInSync) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{41} }
+func (m *PolicyStatusRemove) Reset()                    { *m = PolicyStatusRemove{} }
+func (m *PolicyStatusRemove) String() string            { return proto1.CompactTextString(m) }
+func (*PolicyStatusRemove) ProtoMessage()               {}
+func (*PolicyStatusRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{41} }
 
-type HostMetadataV4V6Update struct {
-	Hostname string            `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	Ipv4Addr string            `protobuf:"bytes,2,opt,name=ipv4_addr,json=ipv4Addr,proto3" json:"ipv4_addr,omitempty"`
-	Ipv6Addr string            `protobuf:"bytes,3,opt,name=ipv6_addr,json=ipv6Addr,proto3" json:"ipv6_addr,omitempty"`
-	Asnumber string            `protobuf:"bytes,4,opt,name=asnumber,proto3" json:"asnumber,omitempty"`
-	Labels   map[string]string `protobuf:"bytes,5,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+func (m *PolicyStatusRemove) GetId() *PolicyID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
 }
 
-func (m *HostMetadataV4V6Update) Reset()         { *m = HostMetadataV4V6Update{} }
-func (m *HostMetadataV4V6Update) String() string { return proto1.CompactTextString(m) }
-func (*HostMetadataV4V6Update) ProtoMessage()    {}
-func (*HostMetadataV4V6Update) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{42}
+type PolicyStatus struct {
+	// One of "pending", "programmed" or "error".
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The content hash of the version of the policy that the status applies to.
+	ContentHash string `protobuf:"bytes,2,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	// The number of consecutive failed attempts to program the policy.
+	Errors int32 `protobuf:"varint,3,opt,name=errors,proto3" json:"errors,omitempty"`
 }
 
-func (m *HostMetadataV4V6Update) GetHostname() string {
+func (m *PolicyStatus) Reset()                    { *m = PolicyStatus{} }
+func (m *PolicyStatus) String() string            { return proto1.CompactTextString(m) }
+func (*PolicyStatus) ProtoMessage()               {}
+func (*PolicyStatus) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{42} }
+
+func (m *PolicyStatus) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *PolicyStatus) GetContentHash() string {
+	if m != nil {
+		return m.ContentHash
+	}
+	return ""
+}
+
+func (m *PolicyStatus) GetErrors() int32 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+type WireguardStatusUpdate struct {
+	// Wireguard public-key set on the interface.
+	PublicKey string `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// The IP version of this update
+	IpVersion IPVersion `protobuf:"varint,2,opt,name=ip_version,json=ipVersion,proto3,enum=felix.IPVersion" json:"ip_version,omitempty"`
+}
+
+func (m *WireguardStatusUpdate) Reset()         { *m = WireguardStatusUpdate{} }
+func (m *WireguardStatusUpdate) String() string { return proto1.CompactTextString(m) }
+func (*WireguardStatusUpdate) ProtoMessage()    {}
+func (*WireguardStatusUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{43}
+}
+
+func (m *WireguardStatusUpdate) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *WireguardStatusUpdate) GetIpVersion() IPVersion {
+	if m != nil {
+		return m.IpVersion
+	}
+	return IPVersion_ANY
+}
+
+type DataplaneInSync struct {
+}
+
+func (m *DataplaneInSync) Reset()                    { *m = DataplaneInSync{} }
+func (m *DataplaneInSync) String() string            { return proto1.CompactTextString(m) }
+func (*DataplaneInSync) ProtoMessage()               {}
+func (*DataplaneInSync) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{44} }
+
+type HostMetadataV4V6Update struct {
+	Hostname string            `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Ipv4Addr string            `protobuf:"bytes,2,opt,name=ipv4_addr,json=ipv4Addr,proto3" json:"ipv4_addr,omitempty"`
+	Ipv6Addr string            `protobuf:"bytes,3,opt,name=ipv6_addr,json=ipv6Addr,proto3" json:"ipv6_addr,omitempty"`
+	Asnumber string            `protobuf:"bytes,4,opt,name=asnumber,proto3" json:"asnumber,omitempty"`
+	Labels   map[string]string `protobuf:"bytes,5,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *HostMetadataV4V6Update) Reset()         { *m = HostMetadataV4V6Update{} }
+func (m *HostMetadataV4V6Update) String() string { return proto1.CompactTextString(m) }
+func (*HostMetadataV4V6Update) ProtoMessage()    {}
+func (*HostMetadataV4V6Update) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{45}
+}
+
+func (m *HostMetadataV4V6Update) GetHostname() string {
 	if m != nil {
 		return m.Hostname
 	}
@@ -3512,7 +3985,7 @@ func (m *HostMetadataV4V6Remove) Reset()         { *m = HostMetadataV4V6Remove{}
 func (m *HostMetadataV4V6Remove) String() string { return proto1.CompactTextString(m) }
 func (*HostMetadataV4V6Remove) ProtoMessage()    {}
 func (*HostMetadataV4V6Remove) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{43}
+	return fileDescriptorFelixbackend, []int{46}
 }
 
 func (m *HostMetadataV4V6Remove) GetHostname() string {
@@ -3537,7 +4010,7 @@ type HostMetadataUpdate struct {
 func (m *HostMetadataUpdate) Reset()                    { *m = HostMetadataUpdate{} }
 func (m *HostMetadataUpdate) String() string            { return proto1.CompactTextString(m) }
 func (*HostMetadataUpdate) ProtoMessage()               {}
-func (*HostMetadataUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{44} }
+func (*HostMetadataUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{47} }
 
 func (m *HostMetadataUpdate) GetHostname() string {
 	if m != nil {
@@ -3561,7 +4034,7 @@ type HostMetadataRemove struct {
 func (m *HostMetadataRemove) Reset()                    { *m = HostMetadataRemove{} }
 func (m *HostMetadataRemove) String() string            { return proto1.CompactTextString(m) }
 func (*HostMetadataRemove) ProtoMessage()               {}
-func (*HostMetadataRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{45} }
+func (*HostMetadataRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{48} }
 
 func (m *HostMetadataRemove) GetHostname() string {
 	if m != nil {
@@ -3586,7 +4059,7 @@ func (m *HostMetadataV6Update) Reset()         { *m = HostMetadataV6Update{} }
 func (m *HostMetadataV6Update) String() string { return proto1.CompactTextString(m) }
 func (*HostMetadataV6Update) ProtoMessage()    {}
 func (*HostMetadataV6Update) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{46}
+	return fileDescriptorFelixbackend, []int{49}
 }
 
 func (m *HostMetadataV6Update) GetHostname() string {
@@ -3612,7 +4085,7 @@ func (m *HostMetadataV6Remove) Reset()         { *m = HostMetadataV6Remove{} }
 func (m *HostMetadataV6Remove) String() string { return proto1.CompactTextString(m) }
 func (*HostMetadataV6Remove) ProtoMessage()    {}
 func (*HostMetadataV6Remove) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{47}
+	return fileDescriptorFelixbackend, []int{50}
 }
 
 func (m *HostMetadataV6Remove) GetHostname() string {
@@ -3637,7 +4110,7 @@ type IPAMPoolUpdate struct {
 func (m *IPAMPoolUpdate) Reset()                    { *m = IPAMPoolUpdate{} }
 func (m *IPAMPoolUpdate) String() string            { return proto1.CompactTextString(m) }
 func (*IPAMPoolUpdate) ProtoMessage()               {}
-func (*IPAMPoolUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{48} }
+func (*IPAMPoolUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{51} }
 
 func (m *IPAMPoolUpdate) GetId() string {
 	if m != nil {
@@ -3660,7 +4133,7 @@ type IPAMPoolRemove struct {
 func (m *IPAMPoolRemove) Reset()                    { *m = IPAMPoolRemove{} }
 func (m *IPAMPoolRemove) String() string            { return proto1.CompactTextString(m) }
 func (*IPAMPoolRemove) ProtoMessage()               {}
-func (*IPAMPoolRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{49} }
+func (*IPAMPoolRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{52} }
 
 func (m *IPAMPoolRemove) GetId() string {
 	if m != nil {
@@ -3679,7 +4152,7 @@ type IPAMPool struct {
 func (m *IPAMPool) Reset()                    { *m = IPAMPool{} }
 func (m *IPAMPool) String() string            { return proto1.CompactTextString(m) }
 func (*IPAMPool) ProtoMessage()               {}
-func (*IPAMPool) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{50} }
+func (*IPAMPool) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{53} }
 
 func (m *IPAMPool) GetCidr() string {
 	if m != nil {
@@ -3718,7 +4191,7 @@ type Encapsulation struct {
 func (m *Encapsulation) Reset()                    { *m = Encapsulation{} }
 func (m *Encapsulation) String() string            { return proto1.CompactTextString(m) }
 func (*Encapsulation) ProtoMessage()               {}
-func (*Encapsulation) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{51} }
+func (*Encapsulation) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{54} }
 
 func (m *Encapsulation) GetIpipEnabled() bool {
 	if m != nil {
@@ -3750,7 +4223,7 @@ func (m *ServiceAccountUpdate) Reset()         { *m = ServiceAccountUpdate{} }
 func (m *ServiceAccountUpdate) String() string { return proto1.CompactTextString(m) }
 func (*ServiceAccountUpdate) ProtoMessage()    {}
 func (*ServiceAccountUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{52}
+	return fileDescriptorFelixbackend, []int{55}
 }
 
 func (m *ServiceAccountUpdate) GetId() *ServiceAccountID {
@@ -3775,7 +4248,7 @@ func (m *ServiceAccountRemove) Reset()         { *m = ServiceAccountRemove{} }
 func (m *ServiceAccountRemove) String() string { return proto1.CompactTextString(m) }
 func (*ServiceAccountRemove) ProtoMessage()    {}
 func (*ServiceAccountRemove) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{53}
+	return fileDescriptorFelixbackend, []int{56}
 }
 
 func (m *ServiceAccountRemove) GetId() *ServiceAccountID {
@@ -3793,7 +4266,7 @@ type ServiceAccountID struct {
 func (m *ServiceAccountID) Reset()                    { *m = ServiceAccountID{} }
 func (m *ServiceAccountID) String() string            { return proto1.CompactTextString(m) }
 func (*ServiceAccountID) ProtoMessage()               {}
-func (*ServiceAccountID) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{54} }
+func (*ServiceAccountID) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{57} }
 
 func (m *ServiceAccountID) GetNamespace() string {
 	if m != nil {
@@ -3817,7 +4290,7 @@ type NamespaceUpdate struct {
 func (m *NamespaceUpdate) Reset()                    { *m = NamespaceUpdate{} }
 func (m *NamespaceUpdate) String() string            { return proto1.CompactTextString(m) }
 func (*NamespaceUpdate) ProtoMessage()               {}
-func (*NamespaceUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{55} }
+func (*NamespaceUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{58} }
 
 func (m *NamespaceUpdate) GetId() *NamespaceID {
 	if m != nil {
@@ -3840,7 +4313,7 @@ type NamespaceRemove struct {
 func (m *NamespaceRemove) Reset()                    { *m = NamespaceRemove{} }
 func (m *NamespaceRemove) String() string            { return proto1.CompactTextString(m) }
 func (*NamespaceRemove) ProtoMessage()               {}
-func (*NamespaceRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{56} }
+func (*NamespaceRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{59} }
 
 func (m *NamespaceRemove) GetId() *NamespaceID {
 	if m != nil {
@@ -3856,7 +4329,7 @@ type NamespaceID struct {
 func (m *NamespaceID) Reset()                    { *m = NamespaceID{} }
 func (m *NamespaceID) String() string            { return proto1.CompactTextString(m) }
 func (*NamespaceID) ProtoMessage()               {}
-func (*NamespaceID) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{57} }
+func (*NamespaceID) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{60} }
 
 func (m *NamespaceID) GetName() string {
 	if m != nil {
@@ -3874,7 +4347,7 @@ type TunnelType struct {
 func (m *TunnelType) Reset()                    { *m = TunnelType{} }
 func (m *TunnelType) String() string            { return proto1.CompactTextString(m) }
 func (*TunnelType) ProtoMessage()               {}
-func (*TunnelType) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{58} }
+func (*TunnelType) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{61} }
 
 func (m *TunnelType) GetIpip() bool {
 	if m != nil {
@@ -3914,7 +4387,7 @@ type RouteUpdate struct {
 func (m *RouteUpdate) Reset()                    { *m = RouteUpdate{} }
 func (m *RouteUpdate) String() string            { return proto1.CompactTextString(m) }
 func (*RouteUpdate) ProtoMessage()               {}
-func (*RouteUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{59} }
+func (*RouteUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{62} }
 
 func (m *RouteUpdate) GetType() RouteType {
 	if m != nil {
@@ -3986,7 +4459,7 @@ type RouteRemove struct {
 func (m *RouteRemove) Reset()                    { *m = RouteRemove{} }
 func (m *RouteRemove) String() string            { return proto1.CompactTextString(m) }
 func (*RouteRemove) ProtoMessage()               {}
-func (*RouteRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{60} }
+func (*RouteRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{63} }
 
 func (m *RouteRemove) GetDst() string {
 	if m != nil {
@@ -4009,7 +4482,7 @@ func (m *VXLANTunnelEndpointUpdate) Reset()         { *m = VXLANTunnelEndpointUp
 func (m *VXLANTunnelEndpointUpdate) String() string { return proto1.CompactTextString(m) }
 func (*VXLANTunnelEndpointUpdate) ProtoMessage()    {}
 func (*VXLANTunnelEndpointUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{61}
+	return fileDescriptorFelixbackend, []int{64}
 }
 
 func (m *VXLANTunnelEndpointUpdate) GetNode() string {
@@ -4069,7 +4542,7 @@ func (m *VXLANTunnelEndpointRemove) Reset()         { *m = VXLANTunnelEndpointRe
 func (m *VXLANTunnelEndpointRemove) String() string { return proto1.CompactTextString(m) }
 func (*VXLANTunnelEndpointRemove) ProtoMessage()    {}
 func (*VXLANTunnelEndpointRemove) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{62}
+	return fileDescriptorFelixbackend, []int{65}
 }
 
 func (m *VXLANTunnelEndpointRemove) GetNode() string {
@@ -4092,7 +4565,7 @@ func (m *WireguardEndpointUpdate) Reset()         { *m = WireguardEndpointUpdate
 func (m *WireguardEndpointUpdate) String() string { return proto1.CompactTextString(m) }
 func (*WireguardEndpointUpdate) ProtoMessage()    {}
 func (*WireguardEndpointUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{63}
+	return fileDescriptorFelixbackend, []int{66}
 }
 
 func (m *WireguardEndpointUpdate) GetHostname() string {
@@ -4125,7 +4598,7 @@ func (m *WireguardEndpointRemove) Reset()         { *m = WireguardEndpointRemove
 func (m *WireguardEndpointRemove) String() string { return proto1.CompactTextString(m) }
 func (*WireguardEndpointRemove) ProtoMessage()    {}
 func (*WireguardEndpointRemove) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{64}
+	return fileDescriptorFelixbackend, []int{67}
 }
 
 func (m *WireguardEndpointRemove) GetHostname() string {
@@ -4148,7 +4621,7 @@ func (m *WireguardEndpointV6Update) Reset()         { *m = WireguardEndpointV6Up
 func (m *WireguardEndpointV6Update) String() string { return proto1.CompactTextString(m) }
 func (*WireguardEndpointV6Update) ProtoMessage()    {}
 func (*WireguardEndpointV6Update) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{65}
+	return fileDescriptorFelixbackend, []int{68}
 }
 
 func (m *WireguardEndpointV6Update) GetHostname() string {
@@ -4181,7 +4654,7 @@ func (m *WireguardEndpointV6Remove) Reset()         { *m = WireguardEndpointV6Re
 func (m *WireguardEndpointV6Remove) String() string { return proto1.CompactTextString(m) }
 func (*WireguardEndpointV6Remove) ProtoMessage()    {}
 func (*WireguardEndpointV6Remove) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{66}
+	return fileDescriptorFelixbackend, []int{69}
 }
 
 func (m *WireguardEndpointV6Remove) GetHostname() string {
@@ -4201,7 +4674,7 @@ func (m *GlobalBGPConfigUpdate) Reset()         { *m = GlobalBGPConfigUpdate{} }
 func (m *GlobalBGPConfigUpdate) String() string { return proto1.CompactTextString(m) }
 func (*GlobalBGPConfigUpdate) ProtoMessage()    {}
 func (*GlobalBGPConfigUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptorFelixbackend, []int{67}
+	return fileDescriptorFelixbackend, []int{70}
 }
 
 func (m *GlobalBGPConfigUpdate) GetServiceClusterCidrs() []string {
@@ -4225,6 +4698,84 @@ func (m *GlobalBGPConfigUpdate) GetServiceLoadbalancerCidrs() []string {
 	return nil
 }
 
+type RoutePolicyUpdate struct {
+	// The name of the RoutePolicy resource.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The IP addresses (as CIDRs) of the local workload endpoints that the
+	// policy applies to.
+	SrcAddrs []string `protobuf:"bytes,2,rep,name=src_addrs,json=srcAddrs" json:"src_addrs,omitempty"`
+	// The destination CIDRs to route via the next hop and/or interface.
+	Destinations []string `protobuf:"bytes,3,rep,name=destinations" json:"destinations,omitempty"`
+	NextHop      string   `protobuf:"bytes,4,opt,name=next_hop,json=nextHop,proto3" json:"next_hop,omitempty"`
+	Interface    string   `protobuf:"bytes,5,opt,name=interface,proto3" json:"interface,omitempty"`
+	// The index of the routing table to program the routes into.
+	Table uint32 `protobuf:"varint,6,opt,name=table,proto3" json:"table,omitempty"`
+}
+
+func (m *RoutePolicyUpdate) Reset()                    { *m = RoutePolicyUpdate{} }
+func (m *RoutePolicyUpdate) String() string            { return proto1.CompactTextString(m) }
+func (*RoutePolicyUpdate) ProtoMessage()               {}
+func (*RoutePolicyUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{71} }
+
+func (m *RoutePolicyUpdate) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RoutePolicyUpdate) GetSrcAddrs() []string {
+	if m != nil {
+		return m.SrcAddrs
+	}
+	return nil
+}
+
+func (m *RoutePolicyUpdate) GetDestinations() []string {
+	if m != nil {
+		return m.Destinations
+	}
+	return nil
+}
+
+func (m *RoutePolicyUpdate) GetNextHop() string {
+	if m != nil {
+		return m.NextHop
+	}
+	return ""
+}
+
+func (m *RoutePolicyUpdate) GetInterface() string {
+	if m != nil {
+		return m.Interface
+	}
+	return ""
+}
+
+func (m *RoutePolicyUpdate) GetTable() uint32 {
+	if m != nil {
+		return m.Table
+	}
+	return 0
+}
+
+type RoutePolicyRemove struct {
+	// The name of the RoutePolicy resource.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (m *RoutePolicyRemove) Reset()                    { *m = RoutePolicyRemove{} }
+func (m *RoutePolicyRemove) String() string            { return proto1.CompactTextString(m) }
+func (*RoutePolicyRemove) ProtoMessage()               {}
+func (*RoutePolicyRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{72} }
+
+func (m *RoutePolicyRemove) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 type ServicePort struct {
 	Protocol string `protobuf:"bytes,1,opt,name=Protocol,proto3" json:"Protocol,omitempty"`
 	Port     int32  `protobuf:"varint,2,opt,name=Port,proto3" json:"Port,omitempty"`
@@ -4234,7 +4785,7 @@ type ServicePort struct {
 func (m *ServicePort) Reset()                    { *m = ServicePort{} }
 func (m *ServicePort) String() string            { return proto1.CompactTextString(m) }
 func (*ServicePort) ProtoMessage()               {}
-func (*ServicePort) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{68} }
+func (*ServicePort) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{73} }
 
 func (m *ServicePort) GetProtocol() string {
 	if m != nil {
@@ -4270,7 +4821,7 @@ type ServiceUpdate struct {
 func (m *ServiceUpdate) Reset()                    { *m = ServiceUpdate{} }
 func (m *ServiceUpdate) String() string            { return proto1.CompactTextString(m) }
 func (*ServiceUpdate) ProtoMessage()               {}
-func (*ServiceUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{69} }
+func (*ServiceUpdate) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{74} }
 
 func (m *ServiceUpdate) GetName() string {
 	if m != nil {
@@ -4329,7 +4880,7 @@ type ServiceRemove struct {
 func (m *ServiceRemove) Reset()                    { *m = ServiceRemove{} }
 func (m *ServiceRemove) String() string            { return proto1.CompactTextString(m) }
 func (*ServiceRemove) ProtoMessage()               {}
-func (*ServiceRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{70} }
+func (*ServiceRemove) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{75} }
 
 func (m *ServiceRemove) GetName() string {
 	if m != nil {
@@ -4345,6 +4896,74 @@ func (m *ServiceRemove) GetNamespace() string {
 	return ""
 }
 
+// DataplaneSnapshot is written to local disk by the Linux dataplane driver.  It
+// holds the calculation graph output that was last applied to the dataplane so
+// that, after a restart, the dataplane can start from that state instead of
+// waiting for a complete resync.
+type DataplaneSnapshot struct {
+	// Version of the snapshot format.  Snapshots with a different version are
+	// discarded.
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Version of Felix that wrote the snapshot.
+	FelixVersion string `protobuf:"bytes,2,opt,name=felix_version,json=felixVersion,proto3" json:"felix_version,omitempty"`
+	// Kernel boot ID at the time the snapshot was written; the kernel state is
+	// lost on reboot so a snapshot from an earlier boot is never valid.
+	BootId string `protobuf:"bytes,3,opt,name=boot_id,json=bootId,proto3" json:"boot_id,omitempty"`
+	// Hostname of the Felix that wrote the snapshot.
+	Hostname string `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// The current state, as a minimal sequence of updates.
+	Messages []*ToDataplane `protobuf:"bytes,5,rep,name=messages" json:"messages,omitempty"`
+	// Hash of the Felix configuration that the snapshot was calculated with.
+	ConfigHash string `protobuf:"bytes,6,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+}
+
+func (m *DataplaneSnapshot) Reset()                    { *m = DataplaneSnapshot{} }
+func (m *DataplaneSnapshot) String() string            { return proto1.CompactTextString(m) }
+func (*DataplaneSnapshot) ProtoMessage()               {}
+func (*DataplaneSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{76} }
+
+func (m *DataplaneSnapshot) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *DataplaneSnapshot) GetFelixVersion() string {
+	if m != nil {
+		return m.FelixVersion
+	}
+	return ""
+}
+
+func (m *DataplaneSnapshot) GetBootId() string {
+	if m != nil {
+		return m.BootId
+	}
+	return ""
+}
+
+func (m *DataplaneSnapshot) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *DataplaneSnapshot) GetMessages() []*ToDataplane {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func (m *DataplaneSnapshot) GetConfigHash() string {
+	if m != nil {
+		return m.ConfigHash
+	}
+	return ""
+}
+
 func init() {
 	proto1.RegisterType((*SyncRequest)(nil), "felix.SyncRequest")
 	proto1.RegisterType((*ToDataplane)(nil), "felix.ToDataplane")
@@ -4367,6 +4986,9 @@ func init() {
 	proto1.RegisterType((*ServiceAccountMatch)(nil), "felix.ServiceAccountMatch")
 	proto1.RegisterType((*HTTPMatch)(nil), "felix.HTTPMatch")
 	proto1.RegisterType((*HTTPMatch_PathMatch)(nil), "felix.HTTPMatch.PathMatch")
+	proto1.RegisterType((*HTTPMatch_HeaderMatch)(nil), "felix.HTTPMatch.HeaderMatch")
+	proto1.RegisterType((*HTTPMatch_GRPCMatch)(nil), "felix.HTTPMatch.GRPCMatch")
+	proto1.RegisterType((*HTTPMatch_ClaimMatch)(nil), "felix.HTTPMatch.ClaimMatch")
 	proto1.RegisterType((*RuleMetadata)(nil), "felix.RuleMetadata")
 	proto1.RegisterType((*IcmpTypeAndCode)(nil), "felix.IcmpTypeAndCode")
 	proto1.RegisterType((*Protocol)(nil), "felix.Protocol")
@@ -4387,6 +5009,9 @@ func init() {
 	proto1.RegisterType((*HostEndpointStatusRemove)(nil), "felix.HostEndpointStatusRemove")
 	proto1.RegisterType((*WorkloadEndpointStatusUpdate)(nil), "felix.WorkloadEndpointStatusUpdate")
 	proto1.RegisterType((*WorkloadEndpointStatusRemove)(nil), "felix.WorkloadEndpointStatusRemove")
+	proto1.RegisterType((*PolicyStatusUpdate)(nil), "felix.PolicyStatusUpdate")
+	proto1.RegisterType((*PolicyStatusRemove)(nil), "felix.PolicyStatusRemove")
+	proto1.RegisterType((*PolicyStatus)(nil), "felix.PolicyStatus")
 	proto1.RegisterType((*WireguardStatusUpdate)(nil), "felix.WireguardStatusUpdate")
 	proto1.RegisterType((*DataplaneInSync)(nil), "felix.DataplaneInSync")
 	proto1.RegisterType((*HostMetadataV4V6Update)(nil), "felix.HostMetadataV4V6Update")
@@ -4415,9 +5040,12 @@ func init() {
 	proto1.RegisterType((*WireguardEndpointV6Update)(nil), "felix.WireguardEndpointV6Update")
 	proto1.RegisterType((*WireguardEndpointV6Remove)(nil), "felix.WireguardEndpointV6Remove")
 	proto1.RegisterType((*GlobalBGPConfigUpdate)(nil), "felix.GlobalBGPConfigUpdate")
+	proto1.RegisterType((*RoutePolicyUpdate)(nil), "felix.RoutePolicyUpdate")
+	proto1.RegisterType((*RoutePolicyRemove)(nil), "felix.RoutePolicyRemove")
 	proto1.RegisterType((*ServicePort)(nil), "felix.ServicePort")
 	proto1.RegisterType((*ServiceUpdate)(nil), "felix.ServiceUpdate")
 	proto1.RegisterType((*ServiceRemove)(nil), "felix.ServiceRemove")
+	proto1.RegisterType((*DataplaneSnapshot)(nil), "felix.DataplaneSnapshot")
 	proto1.RegisterEnum("felix.IPVersion", IPVersion_name, IPVersion_value)
 	proto1.RegisterEnum("felix.RouteType", RouteType_name, RouteType_value)
 	proto1.RegisterEnum("felix.IPPoolType", IPPoolType_name, IPPoolType_value)
@@ -5173,6 +5801,38 @@ func (m *ToDataplane_HostMetadataV4V6Remove) MarshalTo(dAtA []byte) (int, error)
 	}
 	return i, nil
 }
+func (m *ToDataplane_RoutePolicyUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.RoutePolicyUpdate != nil {
+		dAtA[i] = 0xba
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.RoutePolicyUpdate.Size()))
+		n39, err := m.RoutePolicyUpdate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n39
+	}
+	return i, nil
+}
+func (m *ToDataplane_RoutePolicyRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.RoutePolicyRemove != nil {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.RoutePolicyRemove.Size()))
+		n40, err := m.RoutePolicyRemove.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n40
+	}
+	return i, nil
+}
 func (m *FromDataplane) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5189,11 +5849,11 @@ func (m *FromDataplane) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if m.Payload != nil {
-		nn39, err := m.Payload.MarshalTo(dAtA[i:])
+		nn41, err := m.Payload.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn39
+		i += nn41
 	}
 	if m.SequenceNumber != 0 {
 		dAtA[i] = 0x40
@@ -5209,11 +5869,11 @@ func (m *FromDataplane_ProcessStatusUpdate) MarshalTo(dAtA []byte) (int, error)
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ProcessStatusUpdate.Size()))
-		n40, err := m.ProcessStatusUpdate.MarshalTo(dAtA[i:])
+		n42, err := m.ProcessStatusUpdate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n40
+		i += n42
 	}
 	return i, nil
 }
@@ -5223,11 +5883,11 @@ func (m *FromDataplane_HostEndpointStatusUpdate) MarshalTo(dAtA []byte) (int, er
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostEndpointStatusUpdate.Size()))
-		n41, err := m.HostEndpointStatusUpdate.MarshalTo(dAtA[i:])
+		n43, err := m.HostEndpointStatusUpdate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n41
+		i += n43
 	}
 	return i, nil
 }
@@ -5237,11 +5897,11 @@ func (m *FromDataplane_HostEndpointStatusRemove) MarshalTo(dAtA []byte) (int, er
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostEndpointStatusRemove.Size()))
-		n42, err := m.HostEndpointStatusRemove.MarshalTo(dAtA[i:])
+		n44, err := m.HostEndpointStatusRemove.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n42
+		i += n44
 	}
 	return i, nil
 }
@@ -5251,11 +5911,11 @@ func (m *FromDataplane_WorkloadEndpointStatusUpdate) MarshalTo(dAtA []byte) (int
 		dAtA[i] = 0x32
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.WorkloadEndpointStatusUpdate.Size()))
-		n43, err := m.WorkloadEndpointStatusUpdate.MarshalTo(dAtA[i:])
+		n45, err := m.WorkloadEndpointStatusUpdate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n43
+		i += n45
 	}
 	return i, nil
 }
@@ -5265,11 +5925,11 @@ func (m *FromDataplane_WorkloadEndpointStatusRemove) MarshalTo(dAtA []byte) (int
 		dAtA[i] = 0x3a
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.WorkloadEndpointStatusRemove.Size()))
-		n44, err := m.WorkloadEndpointStatusRemove.MarshalTo(dAtA[i:])
+		n46, err := m.WorkloadEndpointStatusRemove.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n44
+		i += n46
 	}
 	return i, nil
 }
@@ -5279,11 +5939,11 @@ func (m *FromDataplane_WireguardStatusUpdate) MarshalTo(dAtA []byte) (int, error
 		dAtA[i] = 0x4a
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.WireguardStatusUpdate.Size()))
-		n45, err := m.WireguardStatusUpdate.MarshalTo(dAtA[i:])
+		n47, err := m.WireguardStatusUpdate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n45
+		i += n47
 	}
 	return i, nil
 }
@@ -5293,11 +5953,39 @@ func (m *FromDataplane_DataplaneInSync) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x52
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.DataplaneInSync.Size()))
-		n46, err := m.DataplaneInSync.MarshalTo(dAtA[i:])
+		n48, err := m.DataplaneInSync.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n46
+		i += n48
+	}
+	return i, nil
+}
+func (m *FromDataplane_PolicyStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.PolicyStatusUpdate != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.PolicyStatusUpdate.Size()))
+		n49, err := m.PolicyStatusUpdate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n49
+	}
+	return i, nil
+}
+func (m *FromDataplane_PolicyStatusRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.PolicyStatusRemove != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.PolicyStatusRemove.Size()))
+		n50, err := m.PolicyStatusRemove.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n50
 	}
 	return i, nil
 }
@@ -5352,11 +6040,11 @@ func (m *ConfigUpdate) MarshalTo(dAtA []byte) (int, error) {
 				dAtA[i] = 0x12
 				i++
 				i = encodeVarintFelixbackend(dAtA, i, uint64(v.Size()))
-				n47, err := v.MarshalTo(dAtA[i:])
+				n51, err := v.MarshalTo(dAtA[i:])
 				if err != nil {
 					return 0, err
 				}
-				i += n47
+				i += n51
 			}
 		}
 	}
@@ -5569,21 +6257,21 @@ func (m *ActiveProfileUpdate) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n48, err := m.Id.MarshalTo(dAtA[i:])
+		n52, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n48
+		i += n52
 	}
 	if m.Profile != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Profile.Size()))
-		n49, err := m.Profile.MarshalTo(dAtA[i:])
+		n53, err := m.Profile.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n49
+		i += n53
 	}
 	return i, nil
 }
@@ -5607,11 +6295,11 @@ func (m *ActiveProfileRemove) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n50, err := m.Id.MarshalTo(dAtA[i:])
+		n54, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n50
+		i += n54
 	}
 	return i, nil
 }
@@ -5701,21 +6389,21 @@ func (m *ActivePolicyUpdate) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n51, err := m.Id.MarshalTo(dAtA[i:])
+		n55, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n51
+		i += n55
 	}
 	if m.Policy != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Policy.Size()))
-		n52, err := m.Policy.MarshalTo(dAtA[i:])
+		n56, err := m.Policy.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n52
+		i += n56
 	}
 	return i, nil
 }
@@ -5739,11 +6427,11 @@ func (m *ActivePolicyRemove) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n53, err := m.Id.MarshalTo(dAtA[i:])
+		n57, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n53
+		i += n57
 	}
 	return i, nil
 }
@@ -5849,6 +6537,12 @@ func (m *Policy) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalSelector)))
 		i += copy(dAtA[i:], m.OriginalSelector)
 	}
+	if len(m.ContentHash) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.ContentHash)))
+		i += copy(dAtA[i:], m.ContentHash)
+	}
 	return i, nil
 }
 
@@ -5882,11 +6576,11 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Protocol.Size()))
-		n54, err := m.Protocol.MarshalTo(dAtA[i:])
+		n58, err := m.Protocol.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n54
+		i += n58
 	}
 	if len(m.SrcNet) > 0 {
 		for _, s := range m.SrcNet {
@@ -5943,11 +6637,11 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 		}
 	}
 	if m.Icmp != nil {
-		nn55, err := m.Icmp.MarshalTo(dAtA[i:])
+		nn59, err := m.Icmp.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn55
+		i += nn59
 	}
 	if len(m.SrcIpSetIds) > 0 {
 		for _, s := range m.SrcIpSetIds {
@@ -6024,17 +6718,41 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	if m.ConnLimit != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ConnLimit))
+	}
+	if len(m.SrcIpDstPortIpSetIds) > 0 {
+		for _, s := range m.SrcIpDstPortIpSetIds {
+			dAtA[i] = 0x8a
+			i++
+			dAtA[i] = 0x1
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
 	if m.NotProtocol != nil {
 		dAtA[i] = 0xb2
 		i++
 		dAtA[i] = 0x6
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.NotProtocol.Size()))
-		n56, err := m.NotProtocol.MarshalTo(dAtA[i:])
+		n60, err := m.NotProtocol.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n56
+		i += n60
 	}
 	if len(m.NotSrcNet) > 0 {
 		for _, s := range m.NotSrcNet {
@@ -6099,11 +6817,11 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 		}
 	}
 	if m.NotIcmp != nil {
-		nn57, err := m.NotIcmp.MarshalTo(dAtA[i:])
+		nn61, err := m.NotIcmp.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn57
+		i += nn61
 	}
 	if len(m.NotSrcIpSetIds) > 0 {
 		for _, s := range m.NotSrcIpSetIds {
@@ -6227,11 +6945,11 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x7
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.SrcServiceAccountMatch.Size()))
-		n58, err := m.SrcServiceAccountMatch.MarshalTo(dAtA[i:])
+		n62, err := m.SrcServiceAccountMatch.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n58
+		i += n62
 	}
 	if m.DstServiceAccountMatch != nil {
 		dAtA[i] = 0xca
@@ -6239,11 +6957,11 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x7
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.DstServiceAccountMatch.Size()))
-		n59, err := m.DstServiceAccountMatch.MarshalTo(dAtA[i:])
+		n63, err := m.DstServiceAccountMatch.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n59
+		i += n63
 	}
 	if m.HttpMatch != nil {
 		dAtA[i] = 0xd2
@@ -6251,11 +6969,11 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x7
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HttpMatch.Size()))
-		n60, err := m.HttpMatch.MarshalTo(dAtA[i:])
+		n64, err := m.HttpMatch.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n60
+		i += n64
 	}
 	if m.Metadata != nil {
 		dAtA[i] = 0xda
@@ -6263,11 +6981,11 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x7
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Metadata.Size()))
-		n61, err := m.Metadata.MarshalTo(dAtA[i:])
+		n65, err := m.Metadata.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n61
+		i += n65
 	}
 	if len(m.OriginalDstService) > 0 {
 		dAtA[i] = 0x92
@@ -6325,11 +7043,11 @@ func (m *Rule_IcmpTypeCode) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4a
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IcmpTypeCode.Size()))
-		n62, err := m.IcmpTypeCode.MarshalTo(dAtA[i:])
+		n66, err := m.IcmpTypeCode.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n62
+		i += n66
 	}
 	return i, nil
 }
@@ -6350,11 +7068,11 @@ func (m *Rule_NotIcmpTypeCode) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x6
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.NotIcmpTypeCode.Size()))
-		n63, err := m.NotIcmpTypeCode.MarshalTo(dAtA[i:])
+		n67, err := m.NotIcmpTypeCode.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n63
+		i += n67
 	}
 	return i, nil
 }
@@ -6439,15 +7157,64 @@ func (m *HTTPMatch) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	return i, nil
-}
-
-func (m *HTTPMatch_PathMatch) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if len(m.Headers) > 0 {
+		for _, msg := range m.Headers {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Hosts) > 0 {
+		for _, s := range m.Hosts {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Grpc != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Grpc.Size()))
+		n91, err := m.Grpc.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n91
+	}
+	if len(m.JwtClaims) > 0 {
+		for _, msg := range m.JwtClaims {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *HTTPMatch_PathMatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
 	return dAtA[:n], nil
 }
@@ -6458,11 +7225,11 @@ func (m *HTTPMatch_PathMatch) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if m.PathMatch != nil {
-		nn64, err := m.PathMatch.MarshalTo(dAtA[i:])
+		nn68, err := m.PathMatch.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn64
+		i += nn68
 	}
 	return i, nil
 }
@@ -6483,6 +7250,146 @@ func (m *HTTPMatch_PathMatch_Prefix) MarshalTo(dAtA []byte) (int, error) {
 	i += copy(dAtA[i:], m.Prefix)
 	return i, nil
 }
+func (m *HTTPMatch_HeaderMatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HTTPMatch_HeaderMatch) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.ValueMatch != nil {
+		nn70, err := m.ValueMatch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn70
+	}
+	return i, nil
+}
+func (m *HTTPMatch_HeaderMatch_Exact) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Exact)))
+	i += copy(dAtA[i:], m.Exact)
+	return i, nil
+}
+func (m *HTTPMatch_HeaderMatch_Prefix) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Prefix)))
+	i += copy(dAtA[i:], m.Prefix)
+	return i, nil
+}
+func (m *HTTPMatch_HeaderMatch_Regex) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Regex)))
+	i += copy(dAtA[i:], m.Regex)
+	return i, nil
+}
+func (m *HTTPMatch_GRPCMatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HTTPMatch_GRPCMatch) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for _, s := range m.Services {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Methods) > 0 {
+		for _, s := range m.Methods {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *HTTPMatch_ClaimMatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HTTPMatch_ClaimMatch) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Values) > 0 {
+		for _, s := range m.Values {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
 func (m *RuleMetadata) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -6562,11 +7469,11 @@ func (m *Protocol) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if m.NumberOrName != nil {
-		nn65, err := m.NumberOrName.MarshalTo(dAtA[i:])
+		nn69, err := m.NumberOrName.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn65
+		i += nn69
 	}
 	return i, nil
 }
@@ -6669,21 +7576,21 @@ func (m *WorkloadEndpointUpdate) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n66, err := m.Id.MarshalTo(dAtA[i:])
+		n70, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n66
+		i += n70
 	}
 	if m.Endpoint != nil {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Endpoint.Size()))
-		n67, err := m.Endpoint.MarshalTo(dAtA[i:])
+		n71, err := m.Endpoint.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n67
+		i += n71
 	}
 	return i, nil
 }
@@ -6856,11 +7763,11 @@ func (m *WorkloadEndpointRemove) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n68, err := m.Id.MarshalTo(dAtA[i:])
+		n72, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n68
+		i += n72
 	}
 	return i, nil
 }
@@ -6908,21 +7815,21 @@ func (m *HostEndpointUpdate) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n69, err := m.Id.MarshalTo(dAtA[i:])
+		n73, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n69
+		i += n73
 	}
 	if m.Endpoint != nil {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Endpoint.Size()))
-		n70, err := m.Endpoint.MarshalTo(dAtA[i:])
+		n74, err := m.Endpoint.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n70
+		i += n74
 	}
 	return i, nil
 }
@@ -7063,11 +7970,11 @@ func (m *HostEndpointRemove) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n71, err := m.Id.MarshalTo(dAtA[i:])
+		n75, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n71
+		i += n75
 	}
 	return i, nil
 }
@@ -7205,21 +8112,21 @@ func (m *HostEndpointStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n72, err := m.Id.MarshalTo(dAtA[i:])
+		n76, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n72
+		i += n76
 	}
 	if m.Status != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Status.Size()))
-		n73, err := m.Status.MarshalTo(dAtA[i:])
+		n77, err := m.Status.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n73
+		i += n77
 	}
 	return i, nil
 }
@@ -7267,11 +8174,11 @@ func (m *HostEndpointStatusRemove) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n74, err := m.Id.MarshalTo(dAtA[i:])
+		n78, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n74
+		i += n78
 	}
 	return i, nil
 }
@@ -7295,21 +8202,21 @@ func (m *WorkloadEndpointStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n75, err := m.Id.MarshalTo(dAtA[i:])
+		n79, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n75
+		i += n79
 	}
 	if m.Status != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Status.Size()))
-		n76, err := m.Status.MarshalTo(dAtA[i:])
+		n80, err := m.Status.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n76
+		i += n80
 	}
 	return i, nil
 }
@@ -7333,16 +8240,16 @@ func (m *WorkloadEndpointStatusRemove) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n77, err := m.Id.MarshalTo(dAtA[i:])
+		n81, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n77
+		i += n81
 	}
 	return i, nil
 }
 
-func (m *WireguardStatusUpdate) Marshal() (dAtA []byte, err error) {
+func (m *PolicyStatusUpdate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -7352,26 +8259,35 @@ func (m *WireguardStatusUpdate) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *WireguardStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+func (m *PolicyStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.PublicKey) > 0 {
+	if m.Id != nil {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.PublicKey)))
-		i += copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n82, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n82
 	}
-	if m.IpVersion != 0 {
-		dAtA[i] = 0x10
+	if m.Status != nil {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpVersion))
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Status.Size()))
+		n83, err := m.Status.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n83
 	}
 	return i, nil
 }
 
-func (m *DataplaneInSync) Marshal() (dAtA []byte, err error) {
+func (m *PolicyStatusRemove) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -7381,15 +8297,25 @@ func (m *DataplaneInSync) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DataplaneInSync) MarshalTo(dAtA []byte) (int, error) {
+func (m *PolicyStatusRemove) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if m.Id != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n84, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n84
+	}
 	return i, nil
 }
 
-func (m *HostMetadataV4V6Update) Marshal() (dAtA []byte, err error) {
+func (m *PolicyStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -7399,41 +8325,123 @@ func (m *HostMetadataV4V6Update) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HostMetadataV4V6Update) MarshalTo(dAtA []byte) (int, error) {
+func (m *PolicyStatus) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Hostname) > 0 {
+	if len(m.Status) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Hostname)))
-		i += copy(dAtA[i:], m.Hostname)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Status)))
+		i += copy(dAtA[i:], m.Status)
 	}
-	if len(m.Ipv4Addr) > 0 {
+	if len(m.ContentHash) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Ipv4Addr)))
-		i += copy(dAtA[i:], m.Ipv4Addr)
-	}
-	if len(m.Ipv6Addr) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Ipv6Addr)))
-		i += copy(dAtA[i:], m.Ipv6Addr)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.ContentHash)))
+		i += copy(dAtA[i:], m.ContentHash)
 	}
-	if len(m.Asnumber) > 0 {
-		dAtA[i] = 0x22
+	if m.Errors != 0 {
+		dAtA[i] = 0x18
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Asnumber)))
-		i += copy(dAtA[i:], m.Asnumber)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Errors))
 	}
-	if len(m.Labels) > 0 {
-		for k, _ := range m.Labels {
-			dAtA[i] = 0x2a
-			i++
-			v := m.Labels[k]
-			mapSize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
+	return i, nil
+}
+
+func (m *WireguardStatusUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WireguardStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.PublicKey) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.PublicKey)))
+		i += copy(dAtA[i:], m.PublicKey)
+	}
+	if m.IpVersion != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpVersion))
+	}
+	return i, nil
+}
+
+func (m *DataplaneInSync) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DataplaneInSync) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *HostMetadataV4V6Update) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HostMetadataV4V6Update) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Ipv4Addr) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Ipv4Addr)))
+		i += copy(dAtA[i:], m.Ipv4Addr)
+	}
+	if len(m.Ipv6Addr) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Ipv6Addr)))
+		i += copy(dAtA[i:], m.Ipv6Addr)
+	}
+	if len(m.Asnumber) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Asnumber)))
+		i += copy(dAtA[i:], m.Asnumber)
+	}
+	if len(m.Labels) > 0 {
+		for k, _ := range m.Labels {
+			dAtA[i] = 0x2a
+			i++
+			v := m.Labels[k]
+			mapSize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
 			i = encodeVarintFelixbackend(dAtA, i, uint64(mapSize))
 			dAtA[i] = 0xa
 			i++
@@ -7623,11 +8631,11 @@ func (m *IPAMPoolUpdate) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Pool.Size()))
-		n78, err := m.Pool.MarshalTo(dAtA[i:])
+		n85, err := m.Pool.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n78
+		i += n85
 	}
 	return i, nil
 }
@@ -7769,11 +8777,11 @@ func (m *ServiceAccountUpdate) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n79, err := m.Id.MarshalTo(dAtA[i:])
+		n86, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n79
+		i += n86
 	}
 	if len(m.Labels) > 0 {
 		for k, _ := range m.Labels {
@@ -7814,11 +8822,11 @@ func (m *ServiceAccountRemove) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n80, err := m.Id.MarshalTo(dAtA[i:])
+		n87, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n80
+		i += n87
 	}
 	return i, nil
 }
@@ -7872,11 +8880,11 @@ func (m *NamespaceUpdate) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n81, err := m.Id.MarshalTo(dAtA[i:])
+		n88, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n81
+		i += n88
 	}
 	if len(m.Labels) > 0 {
 		for k, _ := range m.Labels {
@@ -7917,11 +8925,11 @@ func (m *NamespaceRemove) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n82, err := m.Id.MarshalTo(dAtA[i:])
+		n89, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n82
+		i += n89
 	}
 	return i, nil
 }
@@ -8075,11 +9083,11 @@ func (m *RouteUpdate) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x52
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.TunnelType.Size()))
-		n83, err := m.TunnelType.MarshalTo(dAtA[i:])
+		n90, err := m.TunnelType.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n83
+		i += n90
 	}
 	return i, nil
 }
@@ -8375,6 +9383,101 @@ func (m *GlobalBGPConfigUpdate) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *RoutePolicyUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoutePolicyUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.SrcAddrs) > 0 {
+		for _, s := range m.SrcAddrs {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Destinations) > 0 {
+		for _, s := range m.Destinations {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.NextHop) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.NextHop)))
+		i += copy(dAtA[i:], m.NextHop)
+	}
+	if len(m.Interface) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Interface)))
+		i += copy(dAtA[i:], m.Interface)
+	}
+	if m.Table != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Table))
+	}
+	return i, nil
+}
+
+func (m *RoutePolicyRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoutePolicyRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	return i, nil
+}
+
 func (m *ServicePort) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -8523,6 +9626,65 @@ func (m *ServiceRemove) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *DataplaneSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DataplaneSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Version))
+	}
+	if len(m.FelixVersion) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.FelixVersion)))
+		i += copy(dAtA[i:], m.FelixVersion)
+	}
+	if len(m.BootId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.BootId)))
+		i += copy(dAtA[i:], m.BootId)
+	}
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Messages) > 0 {
+		for _, msg := range m.Messages {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.ConfigHash) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.ConfigHash)))
+		i += copy(dAtA[i:], m.ConfigHash)
+	}
+	return i, nil
+}
+
 func encodeVarintFelixbackend(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -8883,6 +10045,24 @@ func (m *ToDataplane_HostMetadataV4V6Remove) Size() (n int) {
 	}
 	return n
 }
+func (m *ToDataplane_RoutePolicyUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.RoutePolicyUpdate != nil {
+		l = m.RoutePolicyUpdate.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+func (m *ToDataplane_RoutePolicyRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.RoutePolicyRemove != nil {
+		l = m.RoutePolicyRemove.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
 func (m *FromDataplane) Size() (n int) {
 	var l int
 	_ = l
@@ -8958,6 +10138,24 @@ func (m *FromDataplane_DataplaneInSync) Size() (n int) {
 	}
 	return n
 }
+func (m *FromDataplane_PolicyStatusUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.PolicyStatusUpdate != nil {
+		l = m.PolicyStatusUpdate.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+func (m *FromDataplane_PolicyStatusRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.PolicyStatusRemove != nil {
+		l = m.PolicyStatusRemove.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
 func (m *ConfigUpdate) Size() (n int) {
 	var l int
 	_ = l
@@ -9183,6 +10381,10 @@ func (m *Policy) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
+	l = len(m.ContentHash)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
 	return n
 }
 
@@ -9257,9 +10459,18 @@ func (m *Rule) Size() (n int) {
 			n += 1 + l + sovFelixbackend(uint64(l))
 		}
 	}
-	if m.NotProtocol != nil {
-		l = m.NotProtocol.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
+	if m.ConnLimit != 0 {
+		n += 2 + sovFelixbackend(uint64(m.ConnLimit))
+	}
+	if len(m.SrcIpDstPortIpSetIds) > 0 {
+		for _, s := range m.SrcIpDstPortIpSetIds {
+			l = len(s)
+			n += 2 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if m.NotProtocol != nil {
+		l = m.NotProtocol.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
 	}
 	if len(m.NotSrcNet) > 0 {
 		for _, s := range m.NotSrcNet {
@@ -9436,6 +10647,28 @@ func (m *HTTPMatch) Size() (n int) {
 			n += 1 + l + sovFelixbackend(uint64(l))
 		}
 	}
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Hosts) > 0 {
+		for _, s := range m.Hosts {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if m.Grpc != nil {
+		l = m.Grpc.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.JwtClaims) > 0 {
+		for _, e := range m.JwtClaims {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -9462,6 +10695,72 @@ func (m *HTTPMatch_PathMatch_Prefix) Size() (n int) {
 	n += 1 + l + sovFelixbackend(uint64(l))
 	return n
 }
+func (m *HTTPMatch_HeaderMatch) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.ValueMatch != nil {
+		n += m.ValueMatch.Size()
+	}
+	return n
+}
+func (m *HTTPMatch_HeaderMatch_Exact) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Exact)
+	n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *HTTPMatch_HeaderMatch_Prefix) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Prefix)
+	n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *HTTPMatch_HeaderMatch_Regex) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Regex)
+	n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *HTTPMatch_GRPCMatch) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for _, s := range m.Services {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Methods) > 0 {
+		for _, s := range m.Methods {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HTTPMatch_ClaimMatch) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.Values) > 0 {
+		for _, s := range m.Values {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	return n
+}
 func (m *RuleMetadata) Size() (n int) {
 	var l int
 	_ = l
@@ -9825,6 +11124,47 @@ func (m *WorkloadEndpointStatusRemove) Size() (n int) {
 	return n
 }
 
+func (m *PolicyStatusUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Status != nil {
+		l = m.Status.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *PolicyStatusRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *PolicyStatus) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.ContentHash)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Errors != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Errors))
+	}
+	return n
+}
+
 func (m *WireguardStatusUpdate) Size() (n int) {
 	var l int
 	_ = l
@@ -10270,6 +11610,49 @@ func (m *GlobalBGPConfigUpdate) Size() (n int) {
 	return n
 }
 
+func (m *RoutePolicyUpdate) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.SrcAddrs) > 0 {
+		for _, s := range m.SrcAddrs {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Destinations) > 0 {
+		for _, s := range m.Destinations {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	l = len(m.NextHop)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Interface)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Table != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Table))
+	}
+	return n
+}
+
+func (m *RoutePolicyRemove) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
 func (m *ServicePort) Size() (n int) {
 	var l int
 	_ = l
@@ -10340,6 +11723,37 @@ func (m *ServiceRemove) Size() (n int) {
 	return n
 }
 
+func (m *DataplaneSnapshot) Size() (n int) {
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Version))
+	}
+	l = len(m.FelixVersion)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.BootId)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.Messages) > 0 {
+		for _, e := range m.Messages {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	l = len(m.ConfigHash)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
 func sovFelixbackend(x uint64) (n int) {
 	for {
 		n++
@@ -11635,6 +13049,70 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 			}
 			m.Payload = &ToDataplane_HostMetadataV4V6Remove{v}
 			iNdEx = postIndex
+		case 39:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoutePolicyUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RoutePolicyUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_RoutePolicyUpdate{v}
+			iNdEx = postIndex
+		case 40:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoutePolicyRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RoutePolicyRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_RoutePolicyRemove{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -11928,25 +13406,89 @@ func (m *FromDataplane) Unmarshal(dAtA []byte) error {
 			}
 			m.Payload = &FromDataplane_DataplaneInSync{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PolicyStatusUpdate", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
+			v := &PolicyStatusUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &FromDataplane_PolicyStatusUpdate{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PolicyStatusRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PolicyStatusRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &FromDataplane_PolicyStatusRemove{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
 	return nil
 }
 func (m *ConfigUpdate) Unmarshal(dAtA []byte) error {
@@ -13734,6 +15276,35 @@ func (m *Policy) Unmarshal(dAtA []byte) error {
 			}
 			m.OriginalSelector = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -14182,6 +15753,54 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			}
 			m.DstIpPortSetIds = append(m.DstIpPortSetIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnLimit", wireType)
+			}
+			m.ConnLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConnLimit |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SrcIpDstPortIpSetIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SrcIpDstPortIpSetIds = append(m.SrcIpDstPortIpSetIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		case 102:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field NotProtocol", wireType)
@@ -15172,6 +16791,130 @@ func (m *HTTPMatch) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Headers = append(m.Headers, &HTTPMatch_HeaderMatch{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hosts", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hosts = append(m.Hosts, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Grpc", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Grpc == nil {
+				m.Grpc = &HTTPMatch_GRPCMatch{}
+			}
+			if err := m.Grpc.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JwtClaims", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JwtClaims = append(m.JwtClaims, &HTTPMatch_ClaimMatch{})
+			if err := m.JwtClaims[len(m.JwtClaims)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -15301,7 +17044,7 @@ func (m *HTTPMatch_PathMatch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RuleMetadata) Unmarshal(dAtA []byte) error {
+func (m *HTTPMatch_HeaderMatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15324,17 +17067,17 @@ func (m *RuleMetadata) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RuleMetadata: wiretype end group for non-group")
+			return fmt.Errorf("proto: HeaderMatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RuleMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HeaderMatch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Annotations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -15344,165 +17087,55 @@ func (m *RuleMetadata) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Annotations == nil {
-				m.Annotations = make(map[string]string)
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exact", wireType)
 			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowFelixbackend
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowFelixbackend
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowFelixbackend
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipFelixbackend(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Annotations[mapkey] = mapvalue
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *IcmpTypeAndCode) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
-			}
-			if iNdEx >= l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: IcmpTypeAndCode: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IcmpTypeAndCode: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			m.ValueMatch = &HTTPMatch_HeaderMatch_Exact{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
 			}
-			m.Type = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -15512,16 +17145,26 @@ func (m *IcmpTypeAndCode) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= (int32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
 			}
-			m.Code = 0
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueMatch = &HTTPMatch_HeaderMatch_Prefix{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Regex", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -15531,11 +17174,21 @@ func (m *IcmpTypeAndCode) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= (int32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueMatch = &HTTPMatch_HeaderMatch_Regex{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -15557,7 +17210,7 @@ func (m *IcmpTypeAndCode) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Protocol) Unmarshal(dAtA []byte) error {
+func (m *HTTPMatch_GRPCMatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15580,17 +17233,17 @@ func (m *Protocol) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Protocol: wiretype end group for non-group")
+			return fmt.Errorf("proto: GRPCMatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Protocol: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GRPCMatch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Number", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
 			}
-			var v int32
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -15600,15 +17253,24 @@ func (m *Protocol) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.NumberOrName = &Protocol_Number{v}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Services = append(m.Services, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Methods", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -15633,7 +17295,7 @@ func (m *Protocol) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NumberOrName = &Protocol_Name{string(dAtA[iNdEx:postIndex])}
+			m.Methods = append(m.Methods, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -15656,7 +17318,7 @@ func (m *Protocol) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PortRange) Unmarshal(dAtA []byte) error {
+func (m *HTTPMatch_ClaimMatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15679,17 +17341,17 @@ func (m *PortRange) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PortRange: wiretype end group for non-group")
+			return fmt.Errorf("proto: ClaimMatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PortRange: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ClaimMatch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field First", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			m.First = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -15699,16 +17361,26 @@ func (m *PortRange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.First |= (int32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Last", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
-			m.Last = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -15718,11 +17390,21 @@ func (m *PortRange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Last |= (int32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -15744,7 +17426,7 @@ func (m *PortRange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WorkloadEndpointID) Unmarshal(dAtA []byte) error {
+func (m *RuleMetadata) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15767,46 +17449,17 @@ func (m *WorkloadEndpointID) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpointID: wiretype end group for non-group")
+			return fmt.Errorf("proto: RuleMetadata: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpointID: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RuleMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OrchestratorId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Annotations", wireType)
 			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.OrchestratorId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadId", wireType)
-			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -15816,49 +17469,109 @@ func (m *WorkloadEndpointID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.WorkloadId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndpointId", wireType)
+			if m.Annotations == nil {
+				m.Annotations = make(map[string]string)
 			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowFelixbackend
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipFelixbackend(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.EndpointId = string(dAtA[iNdEx:postIndex])
+			m.Annotations[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -15881,7 +17594,7 @@ func (m *WorkloadEndpointID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WorkloadEndpointUpdate) Unmarshal(dAtA []byte) error {
+func (m *IcmpTypeAndCode) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15904,17 +17617,17 @@ func (m *WorkloadEndpointUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpointUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: IcmpTypeAndCode: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpointUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IcmpTypeAndCode: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var msglen int
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -15924,30 +17637,16 @@ func (m *WorkloadEndpointUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Type |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Id == nil {
-				m.Id = &WorkloadEndpointID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			var msglen int
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -15957,25 +17656,11 @@ func (m *WorkloadEndpointUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Code |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Endpoint == nil {
-				m.Endpoint = &WorkloadEndpoint{}
-			}
-			if err := m.Endpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -15997,7 +17682,7 @@ func (m *WorkloadEndpointUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
+func (m *Protocol) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16020,17 +17705,17 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpoint: wiretype end group for non-group")
+			return fmt.Errorf("proto: Protocol: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Protocol: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Number", wireType)
 			}
-			var stringLen uint64
+			var v int32
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -16040,21 +17725,12 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.State = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.NumberOrName = &Protocol_Number{v}
 		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
@@ -16082,42 +17758,63 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.NumberOrName = &Protocol_Name{string(dAtA[iNdEx:postIndex])}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Mac", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Mac = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProfileIds", wireType)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PortRange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
 			}
-			var stringLen uint64
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PortRange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PortRange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field First", wireType)
+			}
+			m.First = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -16127,24 +17824,83 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.First |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Last", wireType)
+			}
+			m.Last = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Last |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ProfileIds = append(m.ProfileIds, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 5:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkloadEndpointID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkloadEndpointID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkloadEndpointID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Nets", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OrchestratorId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -16169,11 +17925,11 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ipv4Nets = append(m.Ipv4Nets, string(dAtA[iNdEx:postIndex]))
+			m.OrchestratorId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ipv6Nets", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -16198,13 +17954,13 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ipv6Nets = append(m.Ipv6Nets, string(dAtA[iNdEx:postIndex]))
+			m.WorkloadId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tiers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndpointId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -16214,26 +17970,74 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tiers = append(m.Tiers, &TierInfo{})
-			if err := m.Tiers[len(m.Tiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.EndpointId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 8:
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkloadEndpointUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkloadEndpointUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkloadEndpointUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Nat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16257,14 +18061,16 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ipv4Nat = append(m.Ipv4Nat, &NatInfo{})
-			if err := m.Ipv4Nat[len(m.Ipv4Nat)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Id == nil {
+				m.Id = &WorkloadEndpointID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ipv6Nat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16288,43 +18094,692 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ipv6Nat = append(m.Ipv6Nat, &NatInfo{})
-			if err := m.Ipv6Nat[len(m.Ipv6Nat)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Endpoint == nil {
+				m.Endpoint = &WorkloadEndpoint{}
+			}
+			if err := m.Endpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 10:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AllowSpoofedSourcePrefixes", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AllowSpoofedSourcePrefixes = append(m.AllowSpoofedSourcePrefixes, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 11:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Annotations", wireType)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkloadEndpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkloadEndpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mac", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mac = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProfileIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProfileIds = append(m.ProfileIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Nets", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ipv4Nets = append(m.Ipv4Nets, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ipv6Nets", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ipv6Nets = append(m.Ipv6Nets, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tiers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tiers = append(m.Tiers, &TierInfo{})
+			if err := m.Tiers[len(m.Tiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Nat", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ipv4Nat = append(m.Ipv4Nat, &NatInfo{})
+			if err := m.Ipv4Nat[len(m.Ipv4Nat)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ipv6Nat", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ipv6Nat = append(m.Ipv6Nat, &NatInfo{})
+			if err := m.Ipv6Nat[len(m.Ipv6Nat)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowSpoofedSourcePrefixes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowSpoofedSourcePrefixes = append(m.AllowSpoofedSourcePrefixes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Annotations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Annotations == nil {
+				m.Annotations = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowFelixbackend
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipFelixbackend(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Annotations[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkloadEndpointRemove) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkloadEndpointRemove: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkloadEndpointRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &WorkloadEndpointID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HostEndpointID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HostEndpointID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HostEndpointID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndpointId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndpointId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HostEndpointUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HostEndpointUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HostEndpointUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16348,151 +18803,246 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Annotations == nil {
-				m.Annotations = make(map[string]string)
+			if m.Id == nil {
+				m.Id = &HostEndpointID{}
 			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowFelixbackend
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowFelixbackend
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowFelixbackend
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipFelixbackend(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Endpoint == nil {
+				m.Endpoint = &HostEndpoint{}
+			}
+			if err := m.Endpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HostEndpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HostEndpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProfileIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProfileIds = append(m.ProfileIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tiers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Annotations[mapkey] = mapvalue
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tiers = append(m.Tiers, &TierInfo{})
+			if err := m.Tiers[len(m.Tiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedIpv4Addrs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *WorkloadEndpointRemove) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
+			m.ExpectedIpv4Addrs = append(m.ExpectedIpv4Addrs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedIpv6Addrs", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpointRemove: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpointRemove: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExpectedIpv6Addrs = append(m.ExpectedIpv6Addrs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UntrackedTiers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16516,68 +19066,47 @@ func (m *WorkloadEndpointRemove) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &WorkloadEndpointID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.UntrackedTiers = append(m.UntrackedTiers, &TierInfo{})
+			if err := m.UntrackedTiers[len(m.UntrackedTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreDnatTiers", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *HostEndpointID) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.PreDnatTiers = append(m.PreDnatTiers, &TierInfo{})
+			if err := m.PreDnatTiers[len(m.PreDnatTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpointID: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpointID: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 2:
+			iNdEx = postIndex
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndpointId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ForwardTiers", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -16587,20 +19116,22 @@ func (m *HostEndpointID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EndpointId = string(dAtA[iNdEx:postIndex])
+			m.ForwardTiers = append(m.ForwardTiers, &TierInfo{})
+			if err := m.ForwardTiers[len(m.ForwardTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -16623,7 +19154,7 @@ func (m *HostEndpointID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostEndpointUpdate) Unmarshal(dAtA []byte) error {
+func (m *HostEndpointRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16646,10 +19177,10 @@ func (m *HostEndpointUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpointUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: HostEndpointRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpointUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HostEndpointRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -16685,39 +19216,6 @@ func (m *HostEndpointUpdate) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Endpoint == nil {
-				m.Endpoint = &HostEndpoint{}
-			}
-			if err := m.Endpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -16739,7 +19237,7 @@ func (m *HostEndpointUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
+func (m *TierInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16762,10 +19260,10 @@ func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpoint: wiretype end group for non-group")
+			return fmt.Errorf("proto: TierInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TierInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -16799,7 +19297,7 @@ func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProfileIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IngressPolicies", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -16824,42 +19322,11 @@ func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ProfileIds = append(m.ProfileIds, string(dAtA[iNdEx:postIndex]))
+			m.IngressPolicies = append(m.IngressPolicies, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tiers", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Tiers = append(m.Tiers, &TierInfo{})
-			if err := m.Tiers[len(m.Tiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedIpv4Addrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EgressPolicies", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -16884,73 +19351,63 @@ func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ExpectedIpv4Addrs = append(m.ExpectedIpv4Addrs, string(dAtA[iNdEx:postIndex]))
+			m.EgressPolicies = append(m.EgressPolicies, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedIpv6Addrs", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ExpectedIpv6Addrs = append(m.ExpectedIpv6Addrs, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UntrackedTiers", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NatInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UntrackedTiers = append(m.UntrackedTiers, &TierInfo{})
-			if err := m.UntrackedTiers[len(m.UntrackedTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 7:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NatInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NatInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PreDnatTiers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtIp", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -16960,28 +19417,26 @@ func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PreDnatTiers = append(m.PreDnatTiers, &TierInfo{})
-			if err := m.PreDnatTiers[len(m.PreDnatTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ExtIp = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ForwardTiers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IntIp", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -16991,22 +19446,20 @@ func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ForwardTiers = append(m.ForwardTiers, &TierInfo{})
-			if err := m.ForwardTiers[len(m.ForwardTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.IntIp = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -17029,7 +19482,7 @@ func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostEndpointRemove) Unmarshal(dAtA []byte) error {
+func (m *ProcessStatusUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17052,17 +19505,17 @@ func (m *HostEndpointRemove) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpointRemove: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProcessStatusUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpointRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProcessStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IsoTimestamp", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -17072,25 +19525,32 @@ func (m *HostEndpointRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &HostEndpointID{}
+			m.IsoTimestamp = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uptime", wireType)
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Uptime = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -17112,7 +19572,7 @@ func (m *HostEndpointRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TierInfo) Unmarshal(dAtA []byte) error {
+func (m *HostEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17135,17 +19595,17 @@ func (m *TierInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TierInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: HostEndpointStatusUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TierInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HostEndpointStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -17155,55 +19615,30 @@ func (m *TierInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IngressPolicies", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
+			if m.Id == nil {
+				m.Id = &HostEndpointID{}
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.IngressPolicies = append(m.IngressPolicies, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EgressPolicies", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -17213,20 +19648,24 @@ func (m *TierInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EgressPolicies = append(m.EgressPolicies, string(dAtA[iNdEx:postIndex]))
+			if m.Status == nil {
+				m.Status = &EndpointStatus{}
+			}
+			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -17249,7 +19688,7 @@ func (m *TierInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NatInfo) Unmarshal(dAtA []byte) error {
+func (m *EndpointStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17272,44 +19711,15 @@ func (m *NatInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NatInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: EndpointStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NatInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EndpointStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExtIp", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ExtIp = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IntIp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -17334,7 +19744,7 @@ func (m *NatInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IntIp = string(dAtA[iNdEx:postIndex])
+			m.Status = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -17357,7 +19767,7 @@ func (m *NatInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProcessStatusUpdate) Unmarshal(dAtA []byte) error {
+func (m *HostEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17380,17 +19790,17 @@ func (m *ProcessStatusUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProcessStatusUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: HostEndpointStatusRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProcessStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HostEndpointStatusRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsoTimestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -17400,32 +19810,25 @@ func (m *ProcessStatusUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IsoTimestamp = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uptime", wireType)
+			if m.Id == nil {
+				m.Id = &HostEndpointID{}
 			}
-			var v uint64
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			m.Uptime = float64(math.Float64frombits(v))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -17447,7 +19850,7 @@ func (m *ProcessStatusUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
+func (m *WorkloadEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17470,10 +19873,10 @@ func (m *HostEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpointStatusUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: WorkloadEndpointStatusUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpointStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WorkloadEndpointStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -17503,7 +19906,7 @@ func (m *HostEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Id == nil {
-				m.Id = &HostEndpointID{}
+				m.Id = &WorkloadEndpointID{}
 			}
 			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -17563,7 +19966,7 @@ func (m *HostEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EndpointStatus) Unmarshal(dAtA []byte) error {
+func (m *WorkloadEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17586,17 +19989,17 @@ func (m *EndpointStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EndpointStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: WorkloadEndpointStatusRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EndpointStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WorkloadEndpointStatusRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -17606,20 +20009,24 @@ func (m *EndpointStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Status = string(dAtA[iNdEx:postIndex])
+			if m.Id == nil {
+				m.Id = &WorkloadEndpointID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -17642,7 +20049,7 @@ func (m *EndpointStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostEndpointStatusRemove) Unmarshal(dAtA []byte) error {
+func (m *PolicyStatusUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17665,10 +20072,10 @@ func (m *HostEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpointStatusRemove: wiretype end group for non-group")
+			return fmt.Errorf("proto: PolicyStatusUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpointStatusRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PolicyStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -17698,12 +20105,45 @@ func (m *HostEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Id == nil {
-				m.Id = &HostEndpointID{}
+				m.Id = &PolicyID{}
 			}
 			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Status == nil {
+				m.Status = &PolicyStatus{}
+			}
+			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -17725,7 +20165,7 @@ func (m *HostEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WorkloadEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
+func (m *PolicyStatusRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17748,10 +20188,10 @@ func (m *WorkloadEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpointStatusUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: PolicyStatusRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpointStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PolicyStatusRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -17781,45 +20221,12 @@ func (m *WorkloadEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Id == nil {
-				m.Id = &WorkloadEndpointID{}
+				m.Id = &PolicyID{}
 			}
 			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Status == nil {
-				m.Status = &EndpointStatus{}
-			}
-			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -17841,7 +20248,7 @@ func (m *WorkloadEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WorkloadEndpointStatusRemove) Unmarshal(dAtA []byte) error {
+func (m *PolicyStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17864,17 +20271,75 @@ func (m *WorkloadEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpointStatusRemove: wiretype end group for non-group")
+			return fmt.Errorf("proto: PolicyStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpointStatusRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PolicyStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
 			}
-			var msglen int
+			m.Errors = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -17884,25 +20349,11 @@ func (m *WorkloadEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Errors |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Id == nil {
-				m.Id = &WorkloadEndpointID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -21407,19 +23858,312 @@ func (m *GlobalBGPConfigUpdate) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServiceExternalCidrs = append(m.ServiceExternalCidrs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceLoadbalancerCidrs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServiceLoadbalancerCidrs = append(m.ServiceLoadbalancerCidrs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RoutePolicyUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoutePolicyUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoutePolicyUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SrcAddrs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SrcAddrs = append(m.SrcAddrs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Destinations", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Destinations = append(m.Destinations, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextHop", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextHop = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interface", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Interface = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Table", wireType)
+			}
+			m.Table = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Table |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ServiceExternalCidrs = append(m.ServiceExternalCidrs, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 3:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RoutePolicyRemove) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoutePolicyRemove: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoutePolicyRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServiceLoadbalancerCidrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -21444,7 +24188,7 @@ func (m *GlobalBGPConfigUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ServiceLoadbalancerCidrs = append(m.ServiceLoadbalancerCidrs, string(dAtA[iNdEx:postIndex]))
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -21947,6 +24691,222 @@ func (m *ServiceRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *DataplaneSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DataplaneSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DataplaneSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FelixVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FelixVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BootId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BootId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Messages = append(m.Messages, &ToDataplane{})
+			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConfigHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipFelixbackend(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -22055,269 +25015,298 @@ var (
 func init() { proto1.RegisterFile("felixbackend.proto", fileDescriptorFelixbackend) }
 
 var fileDescriptorFelixbackend = []byte{
-	// 4222 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5b, 0xcd, 0x73, 0x1c, 0x49,
-	0x56, 0x57, 0xb7, 0xa4, 0x56, 0xf7, 0xeb, 0xaf, 0x72, 0xea, 0xab, 0x25, 0xdb, 0xb2, 0xa7, 0x3c,
-	0xde, 0xd1, 0x78, 0x77, 0x3c, 0x46, 0x23, 0xb7, 0xd7, 0xc3, 0x32, 0x1b, 0x6d, 0xb5, 0x66, 0xd4,
-	0x33, 0xb6, 0x24, 0x4a, 0x1a, 0x0d, 0xb3, 0x6c, 0x44, 0x51, 0xaa, 0x4a, 0x49, 0x85, 0xab, 0xab,
-	0x6a, 0xaa, 0xb2, 0xf5, 0xb1, 0x9c, 0x80, 0x25, 0x02, 0x82, 0x03, 0x1c, 0x08, 0x82, 0x3f, 0x80,
-	0x13, 0xc1, 0x7f, 0xc0, 0x81, 0xeb, 0x6e, 0x70, 0x81, 0xe0, 0x4c, 0x04, 0x31, 0xdc, 0x08, 0x2e,
-	0x10, 0xc1, 0x9d, 0xc8, 0xcf, 0xfa, 0xe8, 0x6a, 0x59, 0x66, 0x07, 0x4e, 0xea, 0x7c, 0xf9, 0xde,
-	0x2f, 0x7f, 0xf9, 0xea, 0xe5, 0xcb, 0x4f, 0x01, 0x3a, 0xc1, 0x9e, 0x7b, 0x79, 0x6c, 0xd9, 0xaf,
-	0xb1, 0xef, 0x3c, 0x0e, 0xa3, 0x80, 0x04, 0x68, 0x96, 0xc9, 0xf4, 0x26, 0xd4, 0x0f, 0xae, 0x7c,
-	0xdb, 0xc0, 0xdf, 0x8c, 0x70, 0x4c, 0xf4, 0x7f, 0x58, 0x82, 0xfa, 0x61, 0xd0, 0xb7, 0x88, 0x15,
-	0x7a, 0x96, 0x8f, 0xd1, 0x3a, 0xcc, 0xb9, 0xbe, 0x19, 0x5f, 0xf9, 0x76, 0xa7, 0x74, 0xbf, 0xb4,
-	0x5e, 0xdf, 0x68, 0x3e, 0x66, 0x76, 0x8f, 0x07, 0x3e, 0x35, 0xdb, 0x99, 0x32, 0x2a, 0x2e, 0xfb,
-	0x85, 0x9e, 0x41, 0xc3, 0x0d, 0x63, 0x4c, 0xcc, 0x51, 0xe8, 0x58, 0x04, 0x77, 0xca, 0x4c, 0x1d,
-	0x49, 0xf5, 0xfd, 0x03, 0x4c, 0xbe, 0x64, 0x35, 0x3b, 0x53, 0x46, 0x9d, 0x69, 0xf2, 0x22, 0xfa,
-	0x0c, 0x10, 0x37, 0x74, 0xb0, 0x47, 0x2c, 0x69, 0x3e, 0xcd, 0xcc, 0x97, 0xd3, 0xe6, 0x7d, 0x5a,
-	0xaf, 0x30, 0x34, 0x66, 0x94, 0x92, 0x25, 0x0c, 0x22, 0x3c, 0x0c, 0xce, 0x71, 0x67, 0x66, 0x9c,
-	0x81, 0xc1, 0x6a, 0x14, 0x03, 0x5e, 0x44, 0xfb, 0xb0, 0x68, 0xd9, 0xc4, 0x3d, 0xc7, 0x66, 0x18,
-	0x05, 0x27, 0xae, 0x87, 0x25, 0x89, 0x59, 0x86, 0xb0, 0x2a, 0x10, 0x7a, 0x4c, 0x67, 0x9f, 0xab,
-	0x28, 0x1e, 0xf3, 0xd6, 0xb8, 0xb8, 0x00, 0x51, 0x70, 0xaa, 0x4c, 0x46, 0x54, 0xdc, 0xb2, 0x88,
-	0x82, 0xe3, 0x2b, 0x58, 0x90, 0x88, 0x81, 0xe7, 0xda, 0x57, 0x92, 0xe2, 0x1c, 0x03, 0x5c, 0xc9,
-	0x02, 0x32, 0x0d, 0xc5, 0x10, 0x59, 0x63, 0xd2, 0x71, 0x38, 0xc1, 0xaf, 0x3a, 0x11, 0x4e, 0xd1,
-	0xcb, 0xc0, 0x25, 0xec, 0xce, 0x82, 0x98, 0x98, 0xd8, 0x77, 0xc2, 0xc0, 0xf5, 0x55, 0x10, 0xd4,
-	0x32, 0x70, 0x3b, 0x41, 0x4c, 0xb6, 0x85, 0x46, 0xc2, 0xee, 0x6c, 0x4c, 0x3a, 0x0e, 0x27, 0xd8,
-	0xc1, 0x44, 0xb8, 0x84, 0xdd, 0xd9, 0x98, 0x14, 0x7d, 0x0d, 0x9d, 0x8b, 0x20, 0x7a, 0xed, 0x05,
-	0x96, 0x33, 0xc6, 0xb0, 0xce, 0x20, 0xef, 0x0a, 0xc8, 0xaf, 0x84, 0xda, 0x18, 0xcb, 0xa5, 0x8b,
-	0xc2, 0x9a, 0x62, 0x68, 0xc1, 0xb6, 0x71, 0x2d, 0xb4, 0x62, 0x3c, 0x06, 0x2d, 0x58, 0x7f, 0x0c,
-	0x4d, 0x3b, 0xf0, 0x4f, 0xdc, 0x53, 0x49, 0xb5, 0xc9, 0xf0, 0xe6, 0x05, 0xde, 0x16, 0xab, 0x53,
-	0x04, 0x1b, 0x76, 0xaa, 0xac, 0x1c, 0x38, 0xc4, 0xc4, 0x72, 0xac, 0x64, 0x54, 0xb5, 0xc6, 0x1c,
-	0xf8, 0x4a, 0x68, 0x64, 0xbf, 0x47, 0x56, 0x8a, 0xde, 0x83, 0x76, 0x4c, 0x13, 0x84, 0x6f, 0x63,
-	0xd3, 0x1f, 0x0d, 0x8f, 0x71, 0xd4, 0x69, 0xdf, 0x2f, 0xad, 0xcf, 0x18, 0x2d, 0x29, 0xde, 0x65,
-	0x52, 0xd4, 0x03, 0xcd, 0x0d, 0xad, 0xa1, 0x19, 0x06, 0x81, 0x27, 0xdb, 0xd4, 0x58, 0x9b, 0x8b,
-	0x6a, 0x18, 0xf6, 0x5e, 0xed, 0x07, 0x81, 0xa7, 0xda, 0x6b, 0x51, 0x83, 0x44, 0x92, 0x85, 0x10,
-	0x9e, 0xbc, 0x55, 0x08, 0xa1, 0x3c, 0xa8, 0x20, 0x72, 0xd1, 0xa8, 0x7a, 0x2f, 0x60, 0xd0, 0xc4,
-	0xde, 0x67, 0xc3, 0x27, 0x2b, 0x45, 0x07, 0xb0, 0x14, 0xe3, 0xe8, 0xdc, 0xb5, 0xb1, 0x69, 0xd9,
-	0x76, 0x30, 0x4a, 0x82, 0x67, 0x9e, 0x01, 0xde, 0x16, 0x80, 0x07, 0x5c, 0xa9, 0xc7, 0x75, 0x54,
-	0x07, 0x17, 0xe2, 0x02, 0x79, 0x11, 0xa8, 0x60, 0xb9, 0x70, 0x0d, 0xa8, 0xe2, 0x99, 0x03, 0x15,
-	0x4c, 0xb7, 0x40, 0xf3, 0xad, 0x21, 0x8e, 0x43, 0xcb, 0x56, 0x39, 0x6c, 0x91, 0xc1, 0x2d, 0x09,
-	0xb8, 0x5d, 0x59, 0xad, 0xe8, 0xb5, 0xfd, 0xac, 0x28, 0x0b, 0x22, 0x38, 0x2d, 0x15, 0x83, 0x28,
-	0x3a, 0x09, 0x88, 0x60, 0xf2, 0x0c, 0x1a, 0x51, 0x30, 0x22, 0x8a, 0xc5, 0x72, 0x26, 0x17, 0x1b,
-	0xb4, 0x2a, 0x99, 0x0d, 0xa2, 0xa4, 0x98, 0x18, 0x8a, 0x96, 0x3b, 0xe3, 0x86, 0x49, 0x12, 0x8f,
-	0x92, 0x22, 0xda, 0x82, 0xfa, 0x39, 0xc1, 0xa1, 0x6c, 0x70, 0x85, 0xd9, 0xdd, 0x17, 0x76, 0x47,
-	0xbf, 0xf5, 0xb2, 0xb7, 0x7b, 0x38, 0xf2, 0x7d, 0xec, 0x8d, 0x0d, 0x6d, 0xa0, 0x66, 0xaa, 0xef,
-	0x1c, 0x44, 0x34, 0xbe, 0xfa, 0x26, 0x10, 0x45, 0x85, 0x81, 0x08, 0x26, 0x3f, 0x85, 0x95, 0x0b,
-	0x37, 0xc2, 0xa7, 0x23, 0x2b, 0x1a, 0xcf, 0x37, 0xb7, 0x19, 0xe4, 0x9a, 0x4c, 0x0a, 0x52, 0x6f,
-	0x8c, 0xd5, 0xf2, 0x45, 0x71, 0xd5, 0x04, 0x74, 0x41, 0xf8, 0xce, 0xf5, 0xe8, 0x8a, 0xee, 0x38,
-	0xba, 0xe0, 0xfe, 0x15, 0x74, 0x4e, 0xbd, 0xe0, 0xd8, 0xf2, 0xcc, 0xe3, 0xd3, 0xd0, 0xcc, 0xe6,
-	0x9f, 0xbb, 0x0c, 0xfc, 0x8e, 0x00, 0xff, 0x8c, 0xa9, 0xbd, 0xf8, 0x6c, 0x3f, 0x97, 0x88, 0x16,
-	0xb9, 0xfd, 0x8b, 0xd3, 0x30, 0x5d, 0x81, 0x7e, 0x04, 0x4d, 0xec, 0xdb, 0x56, 0x18, 0x8f, 0x3c,
-	0x8b, 0xb8, 0x81, 0xdf, 0x59, 0x63, 0x68, 0x0b, 0x02, 0x6d, 0x3b, 0x5d, 0xb7, 0x33, 0x65, 0x64,
-	0x95, 0xd1, 0x6f, 0x40, 0x4b, 0x8e, 0x16, 0x41, 0xe6, 0x5e, 0xc6, 0x5c, 0x8c, 0x12, 0x45, 0xa2,
-	0x19, 0xa7, 0x05, 0x69, 0x73, 0xe1, 0xa8, 0xfb, 0x45, 0xe6, 0xca, 0x3d, 0xd2, 0x5c, 0x38, 0xc5,
-	0x86, 0x3b, 0x05, 0x2e, 0x3f, 0xef, 0x4a, 0x2e, 0xef, 0x64, 0xc2, 0x64, 0xcc, 0xeb, 0x47, 0x5d,
-	0xc5, 0x6b, 0xe5, 0x62, 0x52, 0xe5, 0xe4, 0x46, 0x04, 0x63, 0xfd, 0x4d, 0x8d, 0x28, 0xf6, 0x45,
-	0x8d, 0x88, 0x9e, 0x1c, 0xc2, 0x72, 0x36, 0x33, 0x26, 0x9d, 0x78, 0x90, 0x49, 0x3b, 0xe9, 0xe4,
-	0x98, 0xe2, 0xbf, 0x70, 0x56, 0x20, 0x2f, 0x44, 0x15, 0xac, 0xdf, 0xbd, 0x06, 0x35, 0x49, 0x66,
-	0x67, 0x05, 0x72, 0xf4, 0x13, 0x58, 0xc9, 0xa1, 0x6e, 0x26, 0x6c, 0x1f, 0x66, 0xe6, 0xd6, 0x0c,
-	0xee, 0x66, 0x8a, 0xef, 0x52, 0x06, 0x79, 0xf3, 0x5c, 0x32, 0x2e, 0xc6, 0x16, 0x9c, 0xbf, 0x77,
-	0x2d, 0x76, 0x32, 0x6f, 0xe7, 0xb1, 0x79, 0xcd, 0x8b, 0x1a, 0xcc, 0x85, 0xd6, 0x15, 0x9d, 0xd0,
-	0xf5, 0x7f, 0x9e, 0x85, 0xe6, 0xa7, 0x51, 0x30, 0x4c, 0xd6, 0xd3, 0xfb, 0xb0, 0x18, 0x46, 0x81,
-	0x8d, 0xe3, 0xd8, 0x8c, 0x89, 0x45, 0x46, 0x71, 0x76, 0xbd, 0x2b, 0x17, 0x86, 0xfb, 0x5c, 0xe7,
-	0x80, 0xa9, 0x24, 0x4b, 0xcd, 0x70, 0x5c, 0x8c, 0x7e, 0x07, 0x6e, 0x67, 0xd7, 0x4a, 0x59, 0x5c,
-	0xbe, 0x08, 0xbe, 0x57, 0xb0, 0x64, 0xca, 0x81, 0x77, 0xce, 0x26, 0xd4, 0x4d, 0x6c, 0x41, 0xb8,
-	0x6b, 0xf6, 0x0d, 0x2d, 0x28, 0x87, 0x15, 0xb4, 0x20, 0x3e, 0xb5, 0x07, 0xf7, 0xc6, 0x57, 0x51,
-	0xd9, 0x7e, 0xf0, 0x85, 0xf3, 0x83, 0x09, 0x8b, 0xa9, 0x5c, 0x5f, 0xee, 0x5c, 0x5c, 0x53, 0x7f,
-	0x6d, 0x6b, 0xa2, 0x4f, 0x73, 0x37, 0x68, 0x4d, 0xf5, 0x6b, 0x42, 0x6b, 0xa2, 0x6f, 0x05, 0x6b,
-	0xa7, 0x6a, 0xe1, 0xda, 0xe9, 0x08, 0x92, 0xac, 0x9c, 0xeb, 0x7c, 0x2d, 0x93, 0x79, 0xd5, 0xd8,
-	0xcf, 0xf5, 0x7a, 0xf1, 0xa2, 0xa8, 0x02, 0xf5, 0xe1, 0x96, 0x23, 0xe3, 0xcf, 0x94, 0x9b, 0x39,
-	0xc8, 0x4c, 0xe8, 0x2a, 0x3e, 0xd5, 0xae, 0xae, 0xed, 0x64, 0x45, 0xe9, 0xa8, 0xfe, 0xa7, 0x32,
-	0x34, 0x32, 0xb9, 0xfd, 0x19, 0x54, 0xf8, 0x4c, 0xd1, 0x29, 0xdd, 0x9f, 0x4e, 0xc5, 0x42, 0x5a,
-	0x49, 0x14, 0xb6, 0x7d, 0x12, 0x5d, 0x19, 0x42, 0x1d, 0xfd, 0x36, 0x2c, 0xc4, 0xc1, 0x28, 0xb2,
-	0xb1, 0x49, 0x02, 0x33, 0xb2, 0x2e, 0xc4, 0x84, 0xd3, 0x29, 0x33, 0x98, 0x47, 0x45, 0x30, 0x07,
-	0x4c, 0xff, 0x30, 0x30, 0xac, 0x8b, 0x34, 0xe2, 0xad, 0x38, 0x2f, 0x47, 0x1d, 0x98, 0x1b, 0xe2,
-	0x38, 0xb6, 0x4e, 0xf9, 0xe0, 0xaa, 0x19, 0xb2, 0xb8, 0xfa, 0x1c, 0xea, 0x29, 0x5b, 0xa4, 0xc1,
-	0xf4, 0x6b, 0x7c, 0xc5, 0xf6, 0xb7, 0x35, 0x83, 0xfe, 0x44, 0x0b, 0x30, 0x7b, 0x6e, 0x79, 0x23,
-	0xbe, 0x89, 0xad, 0x19, 0xbc, 0xf0, 0x71, 0xf9, 0x87, 0xa5, 0xd5, 0x23, 0x58, 0x2a, 0x66, 0x90,
-	0x46, 0x69, 0x72, 0x94, 0xef, 0xa5, 0x51, 0xea, 0x1b, 0x9a, 0x5c, 0xc3, 0x48, 0xbb, 0x14, 0xae,
-	0xfe, 0x17, 0x25, 0xa8, 0x25, 0xd4, 0x97, 0xa0, 0xc2, 0xfb, 0x23, 0x48, 0x89, 0x12, 0xda, 0x54,
-	0x8e, 0xe6, 0x1e, 0xba, 0x93, 0x87, 0x2c, 0xf2, 0xf2, 0xaf, 0xd0, 0x5d, 0xbd, 0x0a, 0x15, 0xfe,
-	0xfd, 0xf5, 0xbf, 0x2a, 0x41, 0x3d, 0xb5, 0x89, 0x47, 0x2d, 0x28, 0xbb, 0x8e, 0x00, 0x29, 0xbb,
-	0x0e, 0xf7, 0x36, 0x8d, 0xe3, 0x98, 0x71, 0x63, 0xde, 0x66, 0x45, 0xf4, 0x04, 0x66, 0xc8, 0x55,
-	0xc8, 0x3f, 0x42, 0x4b, 0x51, 0x4e, 0x61, 0xf1, 0xdf, 0x87, 0x57, 0x21, 0x36, 0x98, 0xa6, 0xfe,
-	0x01, 0xd4, 0x94, 0x08, 0x55, 0xa0, 0x3c, 0xd8, 0xd7, 0xa6, 0x50, 0x9b, 0xb6, 0x6f, 0xf6, 0x76,
-	0xfb, 0xe6, 0xfe, 0x9e, 0x71, 0xa8, 0x95, 0xd0, 0x1c, 0x4c, 0xef, 0x6e, 0x1f, 0x6a, 0x65, 0x3d,
-	0x04, 0x2d, 0x7f, 0x3e, 0x30, 0x46, 0xef, 0x01, 0x34, 0x2d, 0xc7, 0xc1, 0x8e, 0x99, 0x25, 0xd9,
-	0x60, 0xc2, 0x57, 0x82, 0xe9, 0x7b, 0xd0, 0xe6, 0xe3, 0x3f, 0x51, 0x9b, 0x66, 0x6a, 0x2d, 0x21,
-	0x16, 0x8a, 0xfa, 0x5d, 0xe1, 0x0b, 0x31, 0xc4, 0x73, 0x8d, 0xe9, 0x16, 0xcc, 0x17, 0x9c, 0x15,
-	0xa0, 0xfb, 0x4a, 0x2d, 0x09, 0x06, 0xa1, 0x31, 0xe8, 0x33, 0x96, 0xeb, 0x30, 0x27, 0xce, 0x0b,
-	0x44, 0xcc, 0xb4, 0xb2, 0x6a, 0x86, 0xac, 0xd6, 0x9f, 0xe5, 0x9a, 0x10, 0x4c, 0xde, 0xd8, 0x84,
-	0x7e, 0x0f, 0x6a, 0x4a, 0x80, 0x10, 0xcc, 0xd0, 0x85, 0xbb, 0xa0, 0xce, 0x7e, 0xeb, 0x01, 0xcc,
-	0x09, 0x05, 0xf4, 0x04, 0x9a, 0xae, 0x7f, 0x1c, 0x8c, 0x7c, 0xc7, 0x8c, 0x46, 0x1e, 0x8e, 0xc5,
-	0xf0, 0xae, 0xcb, 0xa8, 0x1b, 0x79, 0xd8, 0x68, 0x08, 0x0d, 0x5a, 0x88, 0xd1, 0x06, 0xb4, 0x82,
-	0x11, 0x49, 0x9b, 0x94, 0xc7, 0x4d, 0x9a, 0x52, 0x85, 0xd9, 0xe8, 0x3f, 0x05, 0x34, 0x7e, 0x6c,
-	0x81, 0xee, 0xa5, 0x7a, 0xd2, 0x96, 0x3d, 0x61, 0x0a, 0xc2, 0x57, 0x0f, 0xa1, 0xc2, 0x8f, 0x2e,
-	0x84, 0xab, 0x9a, 0x19, 0x25, 0x43, 0x54, 0xea, 0x4f, 0xb3, 0xe8, 0xc2, 0x4f, 0x6f, 0x42, 0xd7,
-	0x37, 0xa0, 0x2a, 0xcb, 0xd4, 0x4b, 0xc4, 0xc5, 0x91, 0xf4, 0x12, 0xfd, 0xad, 0x3c, 0x57, 0x4e,
-	0x79, 0xee, 0xbf, 0x4a, 0x50, 0xe1, 0x46, 0xff, 0x3f, 0x9e, 0x43, 0x77, 0xa0, 0x36, 0xf2, 0x49,
-	0x64, 0xd9, 0xaf, 0xb1, 0xc3, 0x86, 0x57, 0xd5, 0x48, 0x04, 0x68, 0x05, 0xaa, 0x61, 0x84, 0x4d,
-	0xc7, 0xb7, 0x08, 0x5b, 0x05, 0x54, 0x69, 0xf4, 0xe0, 0xbe, 0x6f, 0x11, 0x6a, 0xa8, 0x36, 0x6c,
-	0x6c, 0xfe, 0xae, 0x19, 0x89, 0x00, 0x7d, 0x1f, 0x6e, 0x05, 0x91, 0x7b, 0xea, 0xfa, 0x96, 0x67,
-	0xc6, 0xd8, 0xc3, 0x36, 0x09, 0x22, 0x36, 0xff, 0xd6, 0x0c, 0x4d, 0x56, 0x1c, 0x08, 0xb9, 0xfe,
-	0x37, 0x1a, 0xcc, 0x50, 0x36, 0x34, 0x67, 0x59, 0x36, 0x5b, 0xd9, 0x8b, 0x9c, 0xc5, 0x4b, 0xe8,
-	0x43, 0x00, 0x37, 0x34, 0xcf, 0x71, 0x14, 0xd3, 0xba, 0x32, 0x4b, 0x02, 0x9a, 0x4a, 0x02, 0x47,
-	0x5c, 0x6e, 0xd4, 0xdc, 0x50, 0xfc, 0x44, 0xdf, 0xa7, 0xbc, 0x03, 0x12, 0xd8, 0x81, 0x27, 0x56,
-	0x45, 0xed, 0x24, 0x92, 0x99, 0xd8, 0x50, 0x0a, 0x68, 0x19, 0xe6, 0xe2, 0xc8, 0x36, 0x7d, 0x4c,
-	0xfb, 0x38, 0xcd, 0x52, 0x65, 0x64, 0xef, 0x62, 0x82, 0x3e, 0x80, 0x1a, 0xad, 0x08, 0x83, 0x88,
-	0xc4, 0x9d, 0x59, 0xe6, 0x4a, 0x35, 0x20, 0x82, 0x88, 0x18, 0x96, 0x7f, 0x8a, 0x8d, 0x6a, 0x1c,
-	0xd9, 0xb4, 0x14, 0x53, 0x1c, 0x27, 0x26, 0x0c, 0xa7, 0xc2, 0x71, 0x9c, 0x98, 0x08, 0x1c, 0x5a,
-	0xc1, 0x71, 0xe6, 0x26, 0xe1, 0x38, 0x31, 0xe1, 0x38, 0x77, 0xa1, 0xe6, 0xda, 0xc3, 0xd0, 0x64,
-	0x19, 0x8f, 0xce, 0xf3, 0xb3, 0x3b, 0x53, 0x46, 0x95, 0x8a, 0x58, 0x32, 0xfb, 0x04, 0x5a, 0xaa,
-	0xda, 0xb4, 0x03, 0x47, 0x4e, 0xed, 0x72, 0x22, 0x1e, 0x08, 0xc5, 0x9e, 0xef, 0x6c, 0x05, 0x0e,
-	0x3b, 0xd7, 0x91, 0xb6, 0xb4, 0x8c, 0x1e, 0x40, 0x8b, 0xf6, 0xca, 0x0d, 0xcd, 0x18, 0x13, 0xd3,
-	0x75, 0xe2, 0x0e, 0x30, 0xb6, 0xf5, 0x38, 0xb2, 0x07, 0xe1, 0x01, 0x26, 0x03, 0x27, 0xa6, 0x4a,
-	0x94, 0x72, 0x4a, 0xa9, 0xce, 0x95, 0x9c, 0x98, 0x28, 0xa5, 0x67, 0xb0, 0xc2, 0x1c, 0x67, 0x0d,
-	0xb1, 0xc3, 0x7a, 0x97, 0xd6, 0x6f, 0x30, 0xfd, 0x05, 0xea, 0x4a, 0x5a, 0x4f, 0xbb, 0x96, 0x36,
-	0x64, 0x9e, 0x2a, 0x34, 0x6c, 0x72, 0x43, 0xea, 0xbb, 0x31, 0xc3, 0x1f, 0xc0, 0xbc, 0xa0, 0xc5,
-	0xac, 0xa4, 0x49, 0x9b, 0x99, 0xb4, 0x19, 0x37, 0xaa, 0x2f, 0xb4, 0x37, 0xa0, 0xe1, 0x07, 0xc4,
-	0x54, 0x91, 0x70, 0x52, 0x1c, 0x09, 0x75, 0x3f, 0x20, 0xb2, 0x80, 0xd6, 0x80, 0x16, 0x4d, 0x19,
-	0x10, 0xa7, 0x0c, 0xb9, 0xe6, 0x07, 0xe4, 0x80, 0xc7, 0xc4, 0x26, 0x34, 0x65, 0x3d, 0xff, 0x9e,
-	0x67, 0x13, 0xbe, 0x67, 0x9d, 0xdb, 0xf0, 0x4f, 0x2a, 0x50, 0x65, 0x78, 0xb8, 0x0a, 0xb5, 0xcf,
-	0x23, 0x44, 0xa0, 0x26, 0x51, 0xf2, 0xbb, 0xd7, 0xa0, 0xf6, 0x65, 0xa0, 0xbc, 0xcb, 0xad, 0x92,
-	0x60, 0x79, 0xcd, 0x82, 0xa5, 0xc4, 0xb4, 0x64, 0x18, 0xa0, 0x6d, 0x40, 0x19, 0x2d, 0x1e, 0x33,
-	0xde, 0xb5, 0x31, 0x53, 0x32, 0xda, 0x29, 0x08, 0x16, 0x36, 0x8f, 0x38, 0x4c, 0x2e, 0x74, 0x86,
-	0x7c, 0x6e, 0xe3, 0x7d, 0x55, 0x9f, 0x49, 0xe8, 0xe6, 0x22, 0xc8, 0x57, 0xba, 0xfd, 0x54, 0x10,
-	0x7d, 0x02, 0x77, 0x95, 0xc3, 0x0b, 0xe3, 0x21, 0x64, 0x66, 0xcb, 0xe2, 0x13, 0x8c, 0x85, 0x84,
-	0xb0, 0x9f, 0x1c, 0x4f, 0xdf, 0x28, 0xfb, 0x7e, 0x51, 0x48, 0x6d, 0xc0, 0x62, 0x92, 0xa9, 0x22,
-	0x3b, 0xc9, 0x56, 0x11, 0x4b, 0x41, 0xf3, 0x2a, 0x5b, 0x45, 0xb6, 0x4c, 0x58, 0x19, 0x1b, 0xda,
-	0xb0, 0xb2, 0x89, 0xb3, 0x36, 0xfd, 0x98, 0x28, 0x9b, 0x6d, 0xb8, 0x97, 0x69, 0x27, 0x39, 0x1f,
-	0x53, 0xd6, 0x84, 0x59, 0xdf, 0x49, 0xb5, 0xa8, 0x4e, 0xc9, 0x0a, 0x61, 0x64, 0x9f, 0x73, 0x30,
-	0xa3, 0x2c, 0x8c, 0xe8, 0x75, 0x16, 0xe6, 0x39, 0xac, 0x28, 0x18, 0xe9, 0x7e, 0x05, 0x70, 0xce,
-	0x00, 0x96, 0xa4, 0xc2, 0x2e, 0xf3, 0xfc, 0x44, 0xd3, 0x8c, 0x03, 0x2e, 0xc6, 0x4c, 0xd3, 0x3e,
-	0xf8, 0x92, 0x27, 0x8c, 0xfc, 0xa1, 0xe5, 0xd0, 0x22, 0xf6, 0x59, 0xe7, 0x32, 0xb3, 0x7b, 0xcd,
-	0x9e, 0x59, 0xbe, 0xa2, 0x1a, 0xc6, 0x52, 0x4c, 0x69, 0x8c, 0xc9, 0x29, 0x2c, 0x27, 0x51, 0x04,
-	0x7b, 0xf5, 0x66, 0x58, 0x87, 0x52, 0x1c, 0x87, 0xfd, 0x10, 0xe0, 0x8c, 0x90, 0x50, 0xe0, 0xfc,
-	0x2c, 0xb3, 0x20, 0xda, 0x39, 0x3c, 0xdc, 0xe7, 0xd6, 0x35, 0xaa, 0x23, 0x0d, 0xaa, 0xf2, 0x30,
-	0xa0, 0xf3, 0x7b, 0x99, 0x83, 0x76, 0x3a, 0xbb, 0xa9, 0x13, 0x61, 0xa5, 0x84, 0x7e, 0x0d, 0x16,
-	0x72, 0x71, 0xc4, 0x58, 0x74, 0xfe, 0x80, 0x4f, 0x7f, 0x28, 0x13, 0x47, 0xac, 0x0a, 0xf5, 0x61,
-	0xad, 0xc8, 0x24, 0x89, 0x83, 0xce, 0x1f, 0x72, 0xe3, 0xdb, 0xe3, 0xc6, 0x2a, 0x0c, 0x32, 0x0d,
-	0xa7, 0xbe, 0x48, 0xe7, 0xe7, 0xb9, 0x86, 0x0f, 0x94, 0xc3, 0x33, 0x0d, 0xa7, 0x3f, 0x62, 0xd2,
-	0xf0, 0x1f, 0xe5, 0x1a, 0x4e, 0x8c, 0x93, 0x86, 0x3b, 0x30, 0x47, 0x57, 0x26, 0xa6, 0xeb, 0x74,
-	0x7e, 0x29, 0xe6, 0x78, 0x5a, 0x1e, 0x38, 0x2f, 0x2a, 0x30, 0x43, 0x53, 0xd4, 0x0b, 0x80, 0xaa,
-	0x4c, 0x57, 0x9f, 0x57, 0xaa, 0xbf, 0x28, 0x69, 0xbf, 0x2c, 0x19, 0xe0, 0x05, 0xa7, 0x66, 0x18,
-	0xe1, 0x13, 0xf7, 0x52, 0xff, 0x0c, 0xe6, 0x8b, 0x3e, 0xd6, 0x2a, 0x54, 0x55, 0x10, 0x72, 0x60,
-	0x55, 0xa6, 0x7b, 0x13, 0xc6, 0x52, 0x2c, 0xd8, 0x79, 0x41, 0xff, 0xeb, 0x12, 0xd4, 0xd4, 0x67,
-	0xe4, 0x7b, 0x0f, 0x72, 0x16, 0x38, 0x7c, 0x9d, 0xc5, 0xf6, 0x1e, 0xac, 0x88, 0x9e, 0xc0, 0x6c,
-	0x68, 0x91, 0x33, 0xb9, 0x98, 0x5a, 0xcd, 0x47, 0xc0, 0xe3, 0x7d, 0x8b, 0x9c, 0xf1, 0x58, 0xe0,
-	0x8a, 0xab, 0x5f, 0x40, 0x4d, 0xc9, 0xd0, 0x12, 0xcc, 0xe2, 0x4b, 0xcb, 0x26, 0x9c, 0xd5, 0xce,
-	0x94, 0xc1, 0x8b, 0xa8, 0x03, 0x15, 0xde, 0x23, 0xbe, 0xfe, 0xdb, 0x99, 0x32, 0x44, 0xf9, 0x45,
-	0x03, 0x80, 0xe2, 0xf0, 0xb8, 0xd3, 0xff, 0xb2, 0x04, 0x8d, 0x74, 0xf8, 0xa0, 0x4f, 0xa1, 0x6e,
-	0xf9, 0x7e, 0x40, 0xd8, 0xa9, 0xa6, 0x5c, 0x15, 0xbe, 0x5b, 0x10, 0x68, 0x8f, 0x7b, 0x89, 0x1a,
-	0xdf, 0xcd, 0xa5, 0x0d, 0x57, 0x3f, 0x01, 0x2d, 0xaf, 0xf0, 0x56, 0xfb, 0xba, 0xe7, 0xd0, 0xce,
-	0x4d, 0x1b, 0x6c, 0x95, 0x4b, 0xe7, 0x21, 0x6a, 0x3f, 0xcb, 0x37, 0x62, 0x54, 0xc6, 0x26, 0x9c,
-	0x32, 0x97, 0xd1, 0xdf, 0xfa, 0x4b, 0xa8, 0xaa, 0x09, 0xb7, 0x03, 0x15, 0x71, 0xa4, 0x51, 0x12,
-	0x4b, 0x1d, 0x51, 0x46, 0x0b, 0xe9, 0xf5, 0xf1, 0xce, 0x14, 0x5f, 0x21, 0xbf, 0xd0, 0xa0, 0xc5,
-	0xeb, 0xcd, 0x20, 0x62, 0xc1, 0xa7, 0x3f, 0x85, 0x9a, 0x9a, 0x20, 0x29, 0xdf, 0x13, 0x37, 0x8a,
-	0x89, 0xe0, 0xc0, 0x0b, 0x94, 0x84, 0x67, 0xc5, 0x44, 0x92, 0xa0, 0xbf, 0xf5, 0x3f, 0x2b, 0x01,
-	0xca, 0x9f, 0xca, 0x0c, 0xfa, 0x74, 0x03, 0x17, 0x44, 0xf6, 0x19, 0x8e, 0x49, 0x64, 0x91, 0x20,
-	0xa2, 0x91, 0xca, 0xbb, 0xde, 0x4a, 0x8b, 0x07, 0x0e, 0xba, 0x07, 0x75, 0x75, 0x04, 0xe4, 0x3a,
-	0xe2, 0x7c, 0x00, 0xa4, 0x88, 0x2b, 0xa8, 0xa3, 0x21, 0xd7, 0x61, 0xeb, 0xe7, 0x9a, 0x01, 0x52,
-	0x34, 0x70, 0x3e, 0x9f, 0xa9, 0x96, 0xb4, 0xb2, 0x51, 0x3d, 0x0b, 0x62, 0xc2, 0x3a, 0x72, 0x09,
-	0x4b, 0xc5, 0x97, 0x87, 0xe8, 0xfd, 0xd4, 0x5e, 0x63, 0x65, 0xc2, 0x89, 0x92, 0xd8, 0xd3, 0x7c,
-	0x04, 0x55, 0xd9, 0x84, 0x38, 0x56, 0x5b, 0x9e, 0x74, 0x7b, 0xa8, 0x14, 0xf5, 0xff, 0x9e, 0x06,
-	0x2d, 0x5f, 0x4d, 0x5d, 0x19, 0x13, 0x8b, 0xc8, 0xad, 0x1d, 0x2f, 0x14, 0xed, 0x5a, 0x68, 0xd8,
-	0x0c, 0x2d, 0x5b, 0xb8, 0x80, 0xfe, 0xa4, 0x7d, 0x97, 0xb7, 0xd6, 0x74, 0x0e, 0xe6, 0xeb, 0x6a,
-	0x10, 0x22, 0x3a, 0xed, 0xde, 0x86, 0x9a, 0x1b, 0x9e, 0x6f, 0xd2, 0xe5, 0x10, 0x5f, 0x5b, 0xd7,
-	0x8c, 0x2a, 0x15, 0xec, 0x62, 0x22, 0x2b, 0xbb, 0xbc, 0xb2, 0xa2, 0x2a, 0xbb, 0xac, 0xf2, 0x21,
-	0xcc, 0xd2, 0xed, 0x93, 0x5c, 0x49, 0xcb, 0xe5, 0xdc, 0xa1, 0x8b, 0xa3, 0x81, 0x7f, 0x12, 0x18,
-	0xbc, 0x16, 0xbd, 0x0f, 0x55, 0xde, 0x80, 0x45, 0x3a, 0x55, 0xa6, 0xd9, 0x52, 0x57, 0x4f, 0x84,
-	0x29, 0xce, 0xb1, 0xf6, 0x2c, 0x22, 0x54, 0xbb, 0x4c, 0xb5, 0x36, 0x51, 0xb5, 0x4b, 0x55, 0x7b,
-	0x70, 0xd7, 0xf2, 0xbc, 0xe0, 0xc2, 0x8c, 0xc3, 0x20, 0x38, 0xc1, 0x8e, 0x29, 0xce, 0x9e, 0xf8,
-	0xd0, 0xc5, 0x72, 0x2d, 0xbd, 0xca, 0x94, 0x0e, 0xb8, 0x0e, 0x3f, 0xec, 0xd9, 0x17, 0x1a, 0xe8,
-	0xf3, 0xec, 0xf8, 0xad, 0xb3, 0x06, 0xd7, 0x27, 0x7c, 0xa3, 0xff, 0xe3, 0x31, 0xbc, 0x35, 0x1e,
-	0x71, 0x62, 0x77, 0x7b, 0xf3, 0x88, 0xd3, 0x7b, 0xd0, 0x4a, 0x9f, 0xd8, 0x0e, 0xfa, 0xf9, 0xc8,
-	0x2f, 0xbf, 0x31, 0xf2, 0x3d, 0x40, 0xe3, 0x17, 0xfb, 0xe8, 0x61, 0x8a, 0xc3, 0x62, 0xc1, 0xd9,
-	0xb0, 0x88, 0xf8, 0x0f, 0x53, 0x11, 0x3f, 0x9d, 0x99, 0x76, 0x33, 0xb7, 0xfb, 0x49, 0xb4, 0xff,
-	0x67, 0x19, 0x1a, 0xe9, 0xaa, 0xa2, 0x33, 0x8c, 0x7c, 0x04, 0x97, 0xc7, 0x22, 0x58, 0xc5, 0xe1,
-	0xf4, 0xb5, 0x71, 0xf8, 0x18, 0xe6, 0xf1, 0x65, 0x88, 0x6d, 0x82, 0x1d, 0x93, 0x05, 0xa4, 0xe5,
-	0x38, 0x91, 0x1c, 0x11, 0xb7, 0x64, 0xd5, 0x20, 0x3c, 0xdf, 0xec, 0xd1, 0x8a, 0xbc, 0x7e, 0x57,
-	0xe8, 0xcf, 0x8e, 0xe9, 0x77, 0xb9, 0xfe, 0x0f, 0xa1, 0xad, 0xf6, 0xeb, 0x26, 0x27, 0x54, 0x29,
-	0x26, 0xd4, 0x52, 0x7a, 0x87, 0x8c, 0xd9, 0x53, 0x68, 0xc9, 0xcd, 0xbd, 0x79, 0xed, 0x88, 0x6a,
-	0x88, 0x3d, 0x3f, 0x37, 0xdb, 0x84, 0xe6, 0x49, 0x10, 0x5d, 0x58, 0x91, 0x6c, 0xae, 0x3a, 0xc1,
-	0x4a, 0x68, 0x31, 0x2b, 0xfd, 0xd7, 0xb3, 0x5f, 0x58, 0x44, 0xd9, 0xcd, 0xbe, 0xb0, 0x1e, 0x41,
-	0x55, 0xc2, 0x16, 0x7e, 0xab, 0xf7, 0x41, 0x73, 0xfd, 0xd3, 0x08, 0xc7, 0x31, 0x7f, 0x8a, 0xe2,
-	0xaa, 0xb9, 0xbe, 0x2d, 0xe4, 0xfb, 0x42, 0x4c, 0xd3, 0x3b, 0xce, 0x69, 0x8a, 0xf3, 0x39, 0x9c,
-	0x51, 0xd4, 0x9f, 0xc1, 0x9c, 0x18, 0xfd, 0x68, 0x11, 0x2a, 0xf8, 0x92, 0xee, 0x29, 0x64, 0x26,
-	0xc4, 0x97, 0x64, 0x10, 0x52, 0x31, 0x0b, 0xf0, 0x50, 0x8e, 0x2b, 0x4a, 0x38, 0xd4, 0x0d, 0x98,
-	0x2f, 0xb8, 0x7a, 0x41, 0x0f, 0xa0, 0xe9, 0xc6, 0x81, 0x49, 0xdc, 0x21, 0x8e, 0x89, 0x35, 0x94,
-	0x58, 0x0d, 0x37, 0x0e, 0x0e, 0xa5, 0x0c, 0x2d, 0x41, 0x65, 0x14, 0x52, 0x15, 0x06, 0x59, 0x32,
-	0x44, 0x49, 0x0f, 0xa1, 0x33, 0xe9, 0xda, 0xe5, 0xa6, 0xa3, 0xe4, 0x03, 0xa8, 0xf0, 0x0b, 0x01,
-	0x71, 0xd6, 0xb5, 0xa8, 0x6e, 0x4d, 0x33, 0x17, 0x0e, 0x42, 0x49, 0x5f, 0x87, 0x56, 0xb6, 0x86,
-	0x1d, 0x28, 0x73, 0x00, 0x79, 0xa0, 0xcc, 0x35, 0x7b, 0x45, 0xdc, 0xde, 0xee, 0xfb, 0x5e, 0xc2,
-	0x9d, 0xeb, 0x6e, 0x63, 0xde, 0x66, 0xfa, 0x7b, 0xcb, 0x6e, 0x0e, 0x26, 0xb5, 0xfc, 0xf6, 0x69,
-	0xf0, 0x14, 0x16, 0x0b, 0x6f, 0x55, 0xd0, 0x5d, 0x80, 0x70, 0x74, 0xec, 0xb9, 0xb6, 0x99, 0xe4,
-	0xe5, 0x1a, 0x97, 0x7c, 0x81, 0xaf, 0xde, 0xfa, 0x70, 0x4b, 0xbf, 0x05, 0xed, 0xdc, 0x65, 0x8b,
-	0xfe, 0xc7, 0x65, 0x58, 0x2a, 0xbe, 0xc0, 0xa4, 0x0b, 0x63, 0x99, 0x66, 0xe5, 0xc2, 0x58, 0x96,
-	0xd5, 0x24, 0x4c, 0x53, 0x8c, 0x08, 0x62, 0x36, 0x69, 0xd2, 0xcc, 0xa2, 0x26, 0x61, 0x56, 0x39,
-	0xad, 0x2a, 0x59, 0xda, 0xa1, 0xa8, 0x56, 0x2c, 0xd6, 0x6d, 0x7c, 0x61, 0xa3, 0xca, 0xa8, 0x07,
-	0x15, 0xcf, 0x3a, 0xc6, 0x9e, 0x3c, 0x33, 0x7b, 0xff, 0xda, 0x1b, 0xd6, 0xc7, 0x2f, 0x99, 0xae,
-	0xb8, 0x6e, 0xe0, 0x86, 0xab, 0xcf, 0xa1, 0x9e, 0x12, 0xbf, 0xd5, 0x94, 0xf6, 0x9b, 0xe3, 0x9e,
-	0x10, 0xdf, 0xf2, 0x7f, 0xeb, 0x09, 0xfd, 0x15, 0xcf, 0x5d, 0xb9, 0x07, 0x4d, 0xdf, 0x15, 0xdc,
-	0xaf, 0xca, 0x6e, 0x0f, 0x16, 0x8a, 0x6e, 0xda, 0x6f, 0x00, 0xd8, 0xcd, 0x03, 0x76, 0x8b, 0x01,
-	0x6f, 0xcc, 0x70, 0x02, 0xe0, 0x36, 0xb4, 0xb2, 0x4f, 0xb6, 0x0a, 0xae, 0x56, 0x66, 0xc2, 0x20,
-	0xf0, 0xc4, 0x98, 0x6d, 0xe7, 0x1f, 0x69, 0xb1, 0x4a, 0xfd, 0x7e, 0x02, 0x33, 0xe1, 0xd2, 0xe4,
-	0x67, 0x50, 0x95, 0x1a, 0x6c, 0xdf, 0xe1, 0x3a, 0xea, 0xc4, 0x9d, 0xfe, 0x46, 0x6b, 0x00, 0x43,
-	0x2b, 0xfe, 0x66, 0x84, 0x23, 0x4b, 0xec, 0x48, 0xaa, 0x46, 0x4a, 0xc2, 0x7b, 0xe1, 0x86, 0xe6,
-	0x90, 0x6e, 0x58, 0x54, 0xc8, 0xbb, 0xe1, 0x2b, 0xba, 0xb9, 0xb9, 0x0b, 0x70, 0x7e, 0xe9, 0x59,
-	0x3e, 0xaf, 0xe5, 0x41, 0x5f, 0x63, 0x12, 0x5a, 0xad, 0xff, 0x7e, 0x09, 0x9a, 0x99, 0x17, 0x28,
-	0xe8, 0x1d, 0x68, 0x30, 0x34, 0xec, 0x5b, 0xc7, 0x1e, 0xe6, 0x3c, 0xab, 0x46, 0x9d, 0xca, 0xb6,
-	0xb9, 0x88, 0x4e, 0x0a, 0x1c, 0x53, 0xea, 0x70, 0x4e, 0x0d, 0x26, 0x94, 0x4a, 0xeb, 0xa0, 0x65,
-	0x94, 0xcc, 0xf3, 0xae, 0x38, 0xa9, 0x6f, 0xa5, 0xf5, 0x8e, 0xba, 0xfa, 0xdf, 0x95, 0x60, 0xa1,
-	0xe8, 0x05, 0x19, 0x7a, 0x2f, 0x95, 0xc6, 0x96, 0x0b, 0x8f, 0x42, 0x44, 0xfa, 0xfc, 0xb1, 0x1a,
-	0xbb, 0x7c, 0xb7, 0xfb, 0xde, 0x35, 0xef, 0xd2, 0xbe, 0xeb, 0x91, 0xfb, 0xe3, 0x3c, 0x79, 0x75,
-	0xfb, 0x7d, 0x33, 0xf2, 0x7a, 0x1f, 0xb4, 0xbc, 0x3c, 0x7b, 0x4d, 0x51, 0xca, 0x5f, 0x53, 0x14,
-	0x5d, 0xc1, 0xfc, 0x6d, 0x09, 0xda, 0xb9, 0x27, 0x6e, 0x48, 0x4f, 0x51, 0x40, 0xf9, 0x17, 0x6c,
-	0xc2, 0x75, 0x1f, 0xe7, 0x5c, 0xa7, 0x17, 0x3f, 0x97, 0xfb, 0xae, 0xbd, 0xf6, 0x34, 0xc5, 0x56,
-	0x38, 0xec, 0x06, 0x6c, 0xf5, 0x77, 0xa0, 0x9e, 0x12, 0x15, 0xde, 0xe2, 0x1d, 0x02, 0xf0, 0x97,
-	0x6a, 0x87, 0x62, 0x1f, 0x4f, 0x23, 0x57, 0x44, 0x31, 0xfb, 0xcd, 0x58, 0xd1, 0x08, 0x14, 0x61,
-	0xcb, 0x0b, 0xd4, 0xe5, 0xea, 0x15, 0x81, 0xbc, 0x52, 0x52, 0x02, 0xfd, 0x5f, 0xca, 0x50, 0x4f,
-	0xbd, 0xdd, 0x43, 0xef, 0xa6, 0xce, 0x0c, 0x92, 0x89, 0x8f, 0x69, 0x24, 0xd7, 0xb9, 0xe8, 0x23,
-	0x3a, 0x96, 0xf8, 0x7b, 0x4e, 0xa6, 0xcd, 0xa7, 0xc9, 0x5b, 0x2a, 0x51, 0xd0, 0x21, 0xcf, 0xd4,
-	0xc1, 0x0d, 0xe5, 0x6f, 0xea, 0x46, 0x27, 0x26, 0x72, 0x5b, 0xea, 0xc4, 0x04, 0xe9, 0xd0, 0x64,
-	0x87, 0xa6, 0x81, 0xc3, 0x0f, 0xae, 0xc4, 0x30, 0xae, 0x3b, 0x31, 0xd9, 0x0d, 0x1c, 0x76, 0x4e,
-	0x85, 0xd6, 0xa0, 0xae, 0x74, 0xdc, 0x50, 0x5e, 0x6d, 0x09, 0x8d, 0x41, 0x48, 0x37, 0x06, 0xb1,
-	0x35, 0xc4, 0x66, 0x3c, 0x3a, 0xf6, 0x31, 0x61, 0xcf, 0x3c, 0xaa, 0x06, 0x50, 0xd1, 0x01, 0x93,
-	0xd0, 0x71, 0x4f, 0x97, 0xd4, 0xc1, 0x88, 0x9c, 0x06, 0xae, 0x7f, 0xca, 0xae, 0x70, 0xaa, 0x46,
-	0xdd, 0xb7, 0xc8, 0x9e, 0x10, 0xa1, 0x87, 0xd0, 0xf2, 0x02, 0xdb, 0xf2, 0x4c, 0x79, 0x5c, 0xc0,
-	0xee, 0x70, 0xaa, 0x46, 0x93, 0x49, 0xe5, 0x02, 0x03, 0x6d, 0x40, 0x9d, 0xb0, 0x2f, 0xc0, 0x3b,
-	0xcd, 0x1f, 0x5c, 0xc8, 0x4e, 0x27, 0xdf, 0xc6, 0x00, 0xa2, 0x7e, 0xeb, 0xf7, 0x84, 0x7b, 0x45,
-	0x2c, 0x08, 0x1f, 0x94, 0x95, 0x0f, 0xf4, 0x7f, 0x2f, 0xc1, 0xca, 0xc4, 0xb7, 0x8c, 0x2c, 0x10,
-	0x68, 0x7e, 0x93, 0x81, 0x40, 0x33, 0x9f, 0xd8, 0xde, 0x97, 0x93, 0xed, 0x7d, 0x66, 0x42, 0x9a,
-	0xce, 0x2d, 0x1c, 0xd6, 0x41, 0x0b, 0xad, 0x08, 0xfb, 0xc4, 0x74, 0x30, 0x3b, 0x22, 0x74, 0x43,
-	0xe1, 0xe7, 0x16, 0x97, 0xf7, 0x99, 0x98, 0xaf, 0xa0, 0x87, 0x96, 0x4d, 0xf3, 0x19, 0xf7, 0xf2,
-	0xec, 0xd0, 0xb2, 0x8f, 0xba, 0xd9, 0xc9, 0xa4, 0x92, 0x5b, 0x79, 0xfc, 0x00, 0x50, 0x1e, 0xfd,
-	0xbc, 0xcb, 0xbe, 0x42, 0xcd, 0xd0, 0xb2, 0xf8, 0xe7, 0x5d, 0xfd, 0xc3, 0xc2, 0xbe, 0x0a, 0xdf,
-	0x14, 0xf4, 0x55, 0xff, 0x79, 0x09, 0x96, 0x27, 0xbc, 0xa8, 0xbc, 0x76, 0x02, 0xcc, 0x2e, 0xf2,
-	0xca, 0xf9, 0x45, 0xde, 0x63, 0x98, 0x77, 0x7d, 0x82, 0xa3, 0x13, 0x8b, 0x33, 0xce, 0xb8, 0xee,
-	0x96, 0xaa, 0x92, 0xdb, 0x40, 0xfd, 0x69, 0x01, 0x8b, 0x37, 0x4f, 0xc3, 0xfa, 0x9f, 0x96, 0x60,
-	0x65, 0xe2, 0xdb, 0xc1, 0x6b, 0xf9, 0xeb, 0xd0, 0x4c, 0xf8, 0xd3, 0x2f, 0xc2, 0xbb, 0x50, 0x57,
-	0x5d, 0x38, 0xea, 0x8e, 0x75, 0xa2, 0x3b, 0xb1, 0x13, 0x7c, 0xde, 0x7f, 0x56, 0x48, 0xe6, 0x06,
-	0xdd, 0xf8, 0xfb, 0x12, 0x2c, 0x16, 0xbe, 0x0d, 0x45, 0x1b, 0xb0, 0x28, 0x0f, 0x9e, 0x6d, 0x6f,
-	0x14, 0x13, 0x1c, 0x99, 0x74, 0x66, 0x97, 0x87, 0xb6, 0xf3, 0xa2, 0x72, 0x8b, 0xd7, 0x6d, 0xd1,
-	0x2a, 0xb4, 0x99, 0x3c, 0x93, 0xc6, 0x97, 0x04, 0x47, 0xbe, 0xe5, 0x09, 0xa3, 0xb2, 0xb8, 0xa3,
-	0xe4, 0xb5, 0xdb, 0xa2, 0x92, 0x5b, 0xfd, 0x08, 0x56, 0xa5, 0x15, 0x1d, 0x8b, 0xc7, 0x96, 0x67,
-	0xf9, 0xb6, 0x6a, 0x8e, 0xef, 0x19, 0x3b, 0x42, 0xe3, 0x65, 0x4a, 0x81, 0x59, 0xeb, 0x5f, 0x43,
-	0x5d, 0x4c, 0x45, 0xfb, 0x41, 0x44, 0x68, 0x67, 0xe5, 0x81, 0xa7, 0xec, 0xac, 0x3a, 0x00, 0x45,
-	0x30, 0x43, 0x75, 0xe4, 0xd9, 0xa4, 0xd4, 0xa7, 0xd9, 0x86, 0xc9, 0xa7, 0x99, 0x5c, 0x95, 0xf5,
-	0xff, 0x28, 0x41, 0x33, 0xf3, 0x56, 0xb5, 0x70, 0x4b, 0x9c, 0x99, 0xf7, 0xca, 0x05, 0xf3, 0x9e,
-	0x7a, 0x4f, 0x53, 0x13, 0x29, 0xf6, 0x1e, 0xd4, 0xa5, 0x4b, 0xdd, 0x50, 0x1d, 0xd9, 0x09, 0xd1,
-	0x20, 0x64, 0x5b, 0xe7, 0x8c, 0x27, 0x54, 0x72, 0x6c, 0xa5, 0xc5, 0x83, 0x90, 0x26, 0x40, 0xe5,
-	0x68, 0x0a, 0xc5, 0x4f, 0xf0, 0xea, 0x52, 0x46, 0xb1, 0xd6, 0x61, 0x36, 0x7d, 0x1d, 0x8e, 0xb2,
-	0xd3, 0x3a, 0x3b, 0xce, 0xe5, 0x0a, 0x7a, 0x4f, 0xf5, 0x36, 0x35, 0x6a, 0xdf, 0xaa, 0xb7, 0x8f,
-	0xd6, 0xa1, 0xa6, 0x36, 0x52, 0x68, 0x0e, 0xa6, 0x7b, 0xbb, 0x5f, 0x6b, 0x53, 0xa8, 0x0a, 0x33,
-	0x83, 0xfd, 0xa3, 0x4d, 0x6d, 0x46, 0xfc, 0xea, 0x6a, 0x95, 0x47, 0x7f, 0x52, 0x82, 0x9a, 0x9a,
-	0x7a, 0x50, 0x13, 0x6a, 0x5b, 0x83, 0xbe, 0x61, 0x0e, 0x76, 0x3f, 0xdd, 0xd3, 0xa6, 0xd0, 0x3c,
-	0xb4, 0x8d, 0xed, 0x57, 0x7b, 0x87, 0xdb, 0xe6, 0x57, 0x7b, 0xc6, 0x17, 0x2f, 0xf7, 0x7a, 0x7d,
-	0xad, 0x84, 0xda, 0x50, 0x17, 0xc2, 0x9d, 0xbd, 0x83, 0x43, 0xad, 0x8c, 0x10, 0xb4, 0x5e, 0xee,
-	0x6d, 0xf5, 0x5e, 0x26, 0x4a, 0xd3, 0xa8, 0x05, 0xc0, 0x65, 0x4c, 0x67, 0x06, 0xdd, 0x82, 0xa6,
-	0x30, 0x3a, 0xfc, 0x72, 0x77, 0x77, 0xfb, 0xa5, 0x36, 0x8b, 0x34, 0x68, 0x70, 0x15, 0x21, 0xa9,
-	0x3c, 0x7a, 0x0e, 0x90, 0xcc, 0x6b, 0x94, 0xe3, 0xee, 0xde, 0xee, 0xb6, 0x36, 0x85, 0x1a, 0x50,
-	0xdd, 0xdd, 0x33, 0xb7, 0x77, 0xb7, 0x7a, 0xfb, 0x5a, 0x09, 0xd5, 0x60, 0x96, 0x25, 0x38, 0xad,
-	0xcc, 0xbb, 0x31, 0xd8, 0xd7, 0xa6, 0x37, 0x3e, 0x01, 0xe0, 0x8f, 0x48, 0xd8, 0x7f, 0x55, 0x3d,
-	0x81, 0x19, 0xf6, 0x57, 0x39, 0x39, 0xf9, 0x5f, 0xad, 0x55, 0x29, 0x4b, 0xfd, 0xbf, 0xd6, 0x93,
-	0xd2, 0x8b, 0xe5, 0x5f, 0x7c, 0xbb, 0x56, 0xfa, 0xc7, 0x6f, 0xd7, 0x4a, 0xff, 0xfa, 0xed, 0x5a,
-	0xe9, 0xcf, 0xff, 0x6d, 0x6d, 0xea, 0x27, 0xb3, 0xec, 0x12, 0xfd, 0xb8, 0xc2, 0xfe, 0x7c, 0xf4,
-	0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x45, 0xcb, 0xa6, 0xd7, 0x0d, 0x36, 0x00, 0x00,
+	// 4687 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5b, 0xcd, 0x73, 0xe3, 0x46,
+	0x76, 0x17, 0x29, 0x89, 0x22, 0x1f, 0x45, 0x8a, 0x6a, 0x7d, 0x51, 0x9a, 0x4f, 0xc3, 0x9e, 0x1d,
+	0x79, 0xbc, 0x96, 0x27, 0x63, 0x0d, 0xc7, 0x76, 0x36, 0xde, 0xe2, 0x48, 0xf2, 0x88, 0xf6, 0x8c,
+	0xa4, 0x40, 0xf2, 0x38, 0xde, 0x6c, 0x15, 0x02, 0x01, 0x2d, 0x11, 0x36, 0x09, 0xc0, 0x40, 0x53,
+	0x1f, 0xce, 0x29, 0xd9, 0x4d, 0x55, 0x52, 0x39, 0x24, 0x87, 0x24, 0x95, 0x3f, 0x22, 0xf7, 0x1c,
+	0x72, 0xc8, 0x75, 0xf7, 0x96, 0x9c, 0x92, 0x4b, 0xaa, 0x52, 0xde, 0x5b, 0x2a, 0x97, 0x3d, 0xe4,
+	0x9e, 0xea, 0x4f, 0xa0, 0x01, 0x50, 0xa3, 0x89, 0x37, 0x7b, 0x12, 0xfb, 0xf5, 0x7b, 0xbf, 0x7e,
+	0xfd, 0xf0, 0xfa, 0xf5, 0xeb, 0xd7, 0x2d, 0x40, 0x27, 0x78, 0xe0, 0x5d, 0x1c, 0xdb, 0xce, 0xd7,
+	0xd8, 0x77, 0x37, 0xc2, 0x28, 0x20, 0x01, 0x9a, 0x66, 0x34, 0xa3, 0x01, 0xf5, 0xc3, 0x4b, 0xdf,
+	0x31, 0xf1, 0x37, 0x23, 0x1c, 0x13, 0xe3, 0x57, 0x2b, 0x50, 0x3f, 0x0a, 0xb6, 0x6d, 0x62, 0x87,
+	0x03, 0xdb, 0xc7, 0x68, 0x1d, 0x66, 0x3c, 0xdf, 0x8a, 0x2f, 0x7d, 0xa7, 0x5d, 0xba, 0x5b, 0x5a,
+	0xaf, 0x3f, 0x6a, 0x6c, 0x30, 0xb9, 0x8d, 0x9e, 0x4f, 0xc5, 0x76, 0x27, 0xcc, 0x8a, 0xc7, 0x7e,
+	0xa1, 0x27, 0x30, 0xeb, 0x85, 0x31, 0x26, 0xd6, 0x28, 0x74, 0x6d, 0x82, 0xdb, 0x65, 0xc6, 0x8e,
+	0x24, 0xfb, 0xc1, 0x21, 0x26, 0x9f, 0xb3, 0x9e, 0xdd, 0x09, 0xb3, 0xce, 0x38, 0x79, 0x13, 0x3d,
+	0x03, 0xc4, 0x05, 0x5d, 0x3c, 0x20, 0xb6, 0x14, 0x9f, 0x64, 0xe2, 0x2b, 0x69, 0xf1, 0x6d, 0xda,
+	0xaf, 0x30, 0x5a, 0x4c, 0x28, 0x45, 0x4b, 0x34, 0x88, 0xf0, 0x30, 0x38, 0xc3, 0xed, 0xa9, 0xbc,
+	0x06, 0x26, 0xeb, 0x51, 0x1a, 0xf0, 0x26, 0x3a, 0x80, 0x25, 0xdb, 0x21, 0xde, 0x19, 0xb6, 0xc2,
+	0x28, 0x38, 0xf1, 0x06, 0x58, 0x2a, 0x31, 0xcd, 0x10, 0xd6, 0x04, 0x42, 0x97, 0xf1, 0x1c, 0x70,
+	0x16, 0xa5, 0xc7, 0x82, 0x9d, 0x27, 0x17, 0x20, 0x0a, 0x9d, 0x2a, 0xe3, 0x11, 0x95, 0x6e, 0x0b,
+	0x76, 0x9e, 0x8c, 0x5e, 0xc0, 0xa2, 0x44, 0x0c, 0x06, 0x9e, 0x73, 0x29, 0x55, 0x9c, 0x61, 0x80,
+	0xab, 0x3a, 0x20, 0xe3, 0x50, 0x1a, 0x22, 0x3b, 0x47, 0xcd, 0xc3, 0x09, 0xfd, 0xaa, 0x63, 0xe1,
+	0x94, 0x7a, 0xc8, 0xce, 0x51, 0x29, 0x5c, 0x3f, 0x88, 0x89, 0x85, 0x7d, 0x37, 0x0c, 0x3c, 0x5f,
+	0x39, 0x41, 0x4d, 0x83, 0xdb, 0x0d, 0x62, 0xb2, 0x23, 0x38, 0x12, 0xed, 0xfa, 0x39, 0x6a, 0x1e,
+	0x4e, 0x68, 0x07, 0x63, 0xe1, 0x12, 0xed, 0xfa, 0x39, 0x2a, 0xfa, 0x12, 0xda, 0xe7, 0x41, 0xf4,
+	0xf5, 0x20, 0xb0, 0xdd, 0x9c, 0x86, 0x75, 0x06, 0x79, 0x4b, 0x40, 0x7e, 0x21, 0xd8, 0x72, 0x5a,
+	0x2e, 0x9f, 0x17, 0xf6, 0x14, 0x43, 0x0b, 0x6d, 0x67, 0xaf, 0x84, 0x56, 0x1a, 0x2f, 0x9f, 0x17,
+	0xf6, 0xa0, 0x8f, 0xa0, 0xe1, 0x04, 0xfe, 0x89, 0x77, 0x2a, 0x55, 0x6d, 0x30, 0xbc, 0x05, 0x81,
+	0xb7, 0xc5, 0xfa, 0x94, 0x82, 0xb3, 0x4e, 0xaa, 0xad, 0x0c, 0x38, 0xc4, 0xc4, 0x76, 0xed, 0x64,
+	0x55, 0x35, 0x73, 0x06, 0x7c, 0x21, 0x38, 0xf4, 0xef, 0xa1, 0x53, 0xd1, 0x7d, 0x98, 0x8b, 0x69,
+	0x80, 0xf0, 0x1d, 0x6c, 0xf9, 0xa3, 0xe1, 0x31, 0x8e, 0xda, 0x73, 0x77, 0x4b, 0xeb, 0x53, 0x66,
+	0x53, 0x92, 0xf7, 0x18, 0x15, 0x75, 0xa1, 0xe5, 0x85, 0xf6, 0xd0, 0x0a, 0x83, 0x60, 0x20, 0xc7,
+	0x6c, 0xb1, 0x31, 0x97, 0xd4, 0x32, 0xec, 0xbe, 0x38, 0x08, 0x82, 0x81, 0x1a, 0xaf, 0x49, 0x05,
+	0x12, 0x8a, 0x0e, 0x21, 0x2c, 0x39, 0x5f, 0x08, 0xa1, 0x2c, 0xa8, 0x20, 0x32, 0xde, 0xa8, 0x66,
+	0x2f, 0x60, 0xd0, 0xd8, 0xd9, 0xeb, 0xee, 0xa3, 0x53, 0xd1, 0x21, 0x2c, 0xc7, 0x38, 0x3a, 0xf3,
+	0x1c, 0x6c, 0xd9, 0x8e, 0x13, 0x8c, 0x12, 0xe7, 0x59, 0x60, 0x80, 0x37, 0x04, 0xe0, 0x21, 0x67,
+	0xea, 0x72, 0x1e, 0x35, 0xc1, 0xc5, 0xb8, 0x80, 0x5e, 0x04, 0x2a, 0xb4, 0x5c, 0xbc, 0x02, 0x54,
+	0xe9, 0xb9, 0x18, 0x17, 0xd0, 0xd1, 0x16, 0xb4, 0x7c, 0x7b, 0x88, 0xe3, 0xd0, 0x76, 0x54, 0x0c,
+	0x5b, 0x62, 0x70, 0xcb, 0x02, 0x6e, 0x4f, 0x76, 0x2b, 0xf5, 0xe6, 0x7c, 0x9d, 0xa4, 0x83, 0x08,
+	0x9d, 0x96, 0x8b, 0x41, 0x94, 0x3a, 0x73, 0xbe, 0x4e, 0xa2, 0xb1, 0x38, 0x0a, 0x46, 0x44, 0x69,
+	0xb1, 0xa2, 0xc5, 0x62, 0x93, 0x76, 0x25, 0xbb, 0x41, 0x94, 0x34, 0x13, 0x41, 0x31, 0x72, 0x3b,
+	0x2f, 0x98, 0x04, 0xf1, 0x28, 0x69, 0xa2, 0x2d, 0xa8, 0x9f, 0x11, 0x1c, 0xca, 0x01, 0x57, 0x99,
+	0xdc, 0x5d, 0x21, 0xf7, 0xf2, 0x0f, 0x9e, 0x77, 0xf7, 0x8e, 0x46, 0xbe, 0x8f, 0x07, 0xb9, 0xa5,
+	0x0d, 0x54, 0x4c, 0xcd, 0x9d, 0x83, 0x88, 0xc1, 0xd7, 0x5e, 0x05, 0xa2, 0x54, 0x61, 0x20, 0x42,
+	0x93, 0x9f, 0xc2, 0xea, 0xb9, 0x17, 0xe1, 0xd3, 0x91, 0x1d, 0xe5, 0xe3, 0xcd, 0x0d, 0x06, 0x79,
+	0x5b, 0x06, 0x05, 0xc9, 0x97, 0xd3, 0x6a, 0xe5, 0xbc, 0xb8, 0x6b, 0x0c, 0xba, 0x50, 0xf8, 0xe6,
+	0xd5, 0xe8, 0x4a, 0xdd, 0x95, 0xf3, 0xe2, 0x2e, 0xf4, 0x05, 0xb4, 0x4f, 0x07, 0xc1, 0xb1, 0x3d,
+	0xb0, 0x8e, 0x4f, 0x43, 0x4b, 0x8f, 0x3f, 0xb7, 0x18, 0xf8, 0x4d, 0x01, 0xfe, 0x8c, 0xb1, 0x3d,
+	0x7d, 0x76, 0x90, 0x09, 0x44, 0x4b, 0x5c, 0xfe, 0xe9, 0x69, 0x98, 0xee, 0x40, 0x3f, 0x82, 0x06,
+	0xf6, 0x1d, 0x3b, 0x8c, 0x47, 0x03, 0x9b, 0x78, 0x81, 0xdf, 0xbe, 0xcd, 0xd0, 0x16, 0x05, 0xda,
+	0x4e, 0xba, 0x6f, 0x77, 0xc2, 0xd4, 0x99, 0xd1, 0xef, 0x41, 0x53, 0xae, 0x16, 0xa1, 0xcc, 0x1d,
+	0x4d, 0x5c, 0xac, 0x12, 0xa5, 0x44, 0x23, 0x4e, 0x13, 0xd2, 0xe2, 0xc2, 0x50, 0x77, 0x8b, 0xc4,
+	0x95, 0x79, 0x1a, 0x71, 0x9a, 0x80, 0x1c, 0xb8, 0x59, 0x60, 0xf2, 0xb3, 0x8e, 0xd4, 0xe5, 0x0d,
+	0xcd, 0x4d, 0x72, 0x56, 0x7f, 0xd9, 0x51, 0x7a, 0xad, 0x9e, 0x8f, 0xeb, 0x1c, 0x3f, 0x88, 0xd0,
+	0xd8, 0x78, 0xd5, 0x20, 0x4a, 0xfb, 0xd5, 0xf3, 0x71, 0x9d, 0xe8, 0x08, 0x56, 0xf4, 0xc8, 0x98,
+	0x4c, 0xe2, 0x4d, 0x2d, 0xec, 0xa4, 0x83, 0x63, 0x4a, 0xff, 0xc5, 0x7e, 0x01, 0xbd, 0x10, 0x55,
+	0x68, 0xfd, 0xd6, 0x15, 0xa8, 0x49, 0x30, 0xeb, 0x17, 0xd0, 0xd1, 0x4f, 0x60, 0x35, 0x83, 0xba,
+	0x99, 0x68, 0x7b, 0x4f, 0xdb, 0x5b, 0x35, 0xdc, 0xcd, 0x94, 0xbe, 0xcb, 0x1a, 0xf2, 0xe6, 0x99,
+	0xd4, 0xb8, 0x18, 0x5b, 0xe8, 0xfc, 0x83, 0x2b, 0xb1, 0x93, 0x7d, 0x3b, 0x8b, 0x2d, 0xf4, 0xfe,
+	0x14, 0x16, 0x78, 0x04, 0xd3, 0x13, 0xb5, 0xfb, 0x0c, 0xb5, 0x9d, 0x0e, 0x64, 0x99, 0x3c, 0x6d,
+	0x3e, 0xca, 0x12, 0x73, 0x58, 0x42, 0xc3, 0xf5, 0x71, 0x58, 0x4a, 0xb9, 0xf9, 0x28, 0x4b, 0x7c,
+	0x5a, 0x83, 0x99, 0xd0, 0xbe, 0xa4, 0x89, 0x86, 0xf1, 0xeb, 0x0a, 0x34, 0x3e, 0x89, 0x82, 0x61,
+	0x92, 0xe7, 0x1f, 0xc0, 0x52, 0x18, 0x05, 0x0e, 0x8e, 0x63, 0x2b, 0x26, 0x36, 0x19, 0xc5, 0x7a,
+	0x1e, 0x2e, 0x13, 0xd6, 0x03, 0xce, 0x73, 0xc8, 0x58, 0x92, 0x14, 0x38, 0xcc, 0x93, 0xd1, 0x1f,
+	0xc1, 0x0d, 0x3d, 0x87, 0xd3, 0x71, 0x79, 0x72, 0x7e, 0xa7, 0x20, 0x95, 0xcb, 0x80, 0xb7, 0xfb,
+	0x63, 0xfa, 0xc6, 0x8e, 0x20, 0x8c, 0x34, 0xfd, 0x8a, 0x11, 0x94, 0xad, 0xda, 0xfd, 0x31, 0x7d,
+	0x68, 0x00, 0x77, 0xf2, 0xd9, 0x9d, 0x3e, 0x0f, 0x9e, 0xd0, 0xbf, 0x39, 0x26, 0xc9, 0xcb, 0xcc,
+	0xe5, 0xe6, 0xf9, 0x15, 0xfd, 0x57, 0x8e, 0x26, 0xe6, 0x34, 0x73, 0x8d, 0xd1, 0xd4, 0xbc, 0x6e,
+	0x9e, 0x5f, 0xd1, 0x5f, 0x94, 0xd3, 0x55, 0x0b, 0x73, 0xba, 0x97, 0x90, 0xec, 0x16, 0x99, 0xc9,
+	0xd7, 0xb4, 0x1d, 0x41, 0xc5, 0xa4, 0xcc, 0xac, 0x97, 0xce, 0x8b, 0x3a, 0xd0, 0x36, 0xcc, 0xbb,
+	0xd2, 0xff, 0x2c, 0x79, 0xc8, 0x04, 0x2d, 0xd1, 0x50, 0xfe, 0xa9, 0x4e, 0x9b, 0x73, 0xae, 0x4e,
+	0xa2, 0xb9, 0x9e, 0x58, 0x1b, 0xba, 0x6a, 0x75, 0x2d, 0xd7, 0xe3, 0x0b, 0x21, 0xa3, 0x17, 0x0a,
+	0x73, 0xd4, 0x3c, 0x9c, 0x96, 0xcb, 0x17, 0xc1, 0x25, 0xa9, 0x63, 0x98, 0xa3, 0xa6, 0xd7, 0xdc,
+	0xbf, 0x96, 0x61, 0x56, 0xdb, 0x11, 0x9f, 0x40, 0x85, 0xef, 0xaf, 0xed, 0xd2, 0xdd, 0xc9, 0x94,
+	0xa7, 0xa6, 0x99, 0x44, 0x63, 0xc7, 0x27, 0xd1, 0xa5, 0x29, 0xd8, 0xd1, 0x1f, 0xc2, 0x62, 0x1c,
+	0x8c, 0x22, 0x07, 0x5b, 0x24, 0xb0, 0x22, 0xfb, 0x5c, 0x6c, 0xd3, 0xed, 0x32, 0x83, 0x79, 0x50,
+	0x04, 0x73, 0xc8, 0xf8, 0x8f, 0x02, 0xd3, 0x3e, 0x4f, 0x23, 0xce, 0xc7, 0x59, 0x3a, 0x6a, 0xc3,
+	0xcc, 0x10, 0xc7, 0xb1, 0x7d, 0xca, 0x97, 0x7e, 0xcd, 0x94, 0xcd, 0xb5, 0x0f, 0xa1, 0x9e, 0x92,
+	0x45, 0x2d, 0x98, 0xfc, 0x1a, 0x5f, 0xb2, 0xaa, 0x40, 0xcd, 0xa4, 0x3f, 0xd1, 0x22, 0x4c, 0x9f,
+	0xd9, 0x83, 0x11, 0x3f, 0xfa, 0xd7, 0x4c, 0xde, 0xf8, 0xa8, 0xfc, 0x41, 0x69, 0xed, 0x25, 0x2c,
+	0x17, 0x6b, 0x90, 0x46, 0x69, 0x70, 0x94, 0x1f, 0xa4, 0x51, 0xea, 0x8f, 0x5a, 0x32, 0xc8, 0x49,
+	0xb9, 0x14, 0xae, 0xf1, 0x37, 0x25, 0xa8, 0x25, 0xaa, 0x2f, 0x43, 0x85, 0xcf, 0x47, 0x28, 0x25,
+	0x5a, 0x68, 0x13, 0x2a, 0x9a, 0x85, 0x6e, 0x66, 0x21, 0x8b, 0xac, 0xfc, 0x3d, 0xa6, 0x6b, 0x54,
+	0xa1, 0xc2, 0xbd, 0xd3, 0xf8, 0xfb, 0x12, 0xd4, 0x53, 0xa5, 0x0f, 0xd4, 0x84, 0xb2, 0xe7, 0x0a,
+	0x90, 0xb2, 0xe7, 0x72, 0x6b, 0xd3, 0x55, 0x16, 0x33, 0xdd, 0x6a, 0xa6, 0x6c, 0xa2, 0x87, 0x30,
+	0x45, 0x2e, 0x43, 0xfe, 0x11, 0x9a, 0x4a, 0xe5, 0x14, 0x16, 0xff, 0x7d, 0x74, 0x19, 0x62, 0x93,
+	0x71, 0x1a, 0xef, 0x42, 0x4d, 0x91, 0x50, 0x05, 0xca, 0xbd, 0x83, 0xd6, 0x04, 0x9a, 0xa3, 0xe3,
+	0x5b, 0xdd, 0xbd, 0x6d, 0xeb, 0x60, 0xdf, 0x3c, 0x6a, 0x95, 0xd0, 0x0c, 0x4c, 0xee, 0xed, 0x1c,
+	0xb5, 0xca, 0x46, 0x08, 0xad, 0x6c, 0x55, 0x25, 0xa7, 0xde, 0x9b, 0xd0, 0xb0, 0x5d, 0x17, 0xbb,
+	0x96, 0xae, 0xe4, 0x2c, 0x23, 0xbe, 0x10, 0x9a, 0xde, 0x87, 0x39, 0xbe, 0x48, 0x12, 0xb6, 0x49,
+	0xc6, 0xd6, 0x14, 0x64, 0xc1, 0x68, 0xdc, 0x12, 0xb6, 0x10, 0x01, 0x28, 0x33, 0x98, 0x61, 0xc3,
+	0x42, 0x41, 0x85, 0x05, 0xdd, 0x55, 0x6c, 0x89, 0x33, 0x08, 0x8e, 0xde, 0x36, 0xd3, 0x72, 0x1d,
+	0x66, 0x44, 0x95, 0x45, 0xf8, 0x4c, 0x53, 0x67, 0x33, 0x65, 0xb7, 0xf1, 0x24, 0x33, 0x84, 0xd0,
+	0xe4, 0x95, 0x43, 0x18, 0x77, 0xa0, 0xa6, 0x08, 0x08, 0xc1, 0x14, 0x3d, 0xee, 0x08, 0xd5, 0xd9,
+	0x6f, 0x23, 0x80, 0x19, 0xc1, 0x80, 0x1e, 0x42, 0xc3, 0xf3, 0x8f, 0x83, 0x91, 0xef, 0x5a, 0xd1,
+	0x68, 0x80, 0x63, 0xb1, 0xbc, 0xeb, 0xd2, 0xeb, 0x46, 0x03, 0x6c, 0xce, 0x0a, 0x0e, 0xda, 0x88,
+	0xd1, 0x23, 0x68, 0x06, 0x23, 0x92, 0x16, 0x29, 0xe7, 0x45, 0x1a, 0x92, 0x85, 0xc9, 0x18, 0x3f,
+	0x05, 0x94, 0x2f, 0xf6, 0xa0, 0x3b, 0xa9, 0x99, 0xcc, 0x69, 0xc1, 0x4a, 0xd8, 0xea, 0x1e, 0x54,
+	0x78, 0x98, 0x6a, 0x97, 0xb5, 0x72, 0x1e, 0x67, 0x32, 0x45, 0xa7, 0xf1, 0x58, 0x47, 0x17, 0x76,
+	0x7a, 0x15, 0xba, 0xf1, 0x08, 0xaa, 0xb2, 0x4d, 0xad, 0x44, 0x3c, 0x1c, 0x49, 0x2b, 0xd1, 0xdf,
+	0xca, 0x72, 0xe5, 0x94, 0xe5, 0xfe, 0xb6, 0x0c, 0x15, 0x2e, 0xf4, 0xdb, 0xb1, 0x1c, 0xba, 0x09,
+	0xb5, 0x91, 0x4f, 0x22, 0x5a, 0x0c, 0x75, 0xd9, 0xf2, 0xaa, 0x9a, 0x09, 0x01, 0xad, 0x42, 0x35,
+	0x8c, 0xb0, 0xe5, 0xfa, 0x36, 0x61, 0x39, 0x4a, 0x95, 0x7a, 0x0f, 0xde, 0xf6, 0x6d, 0x42, 0x05,
+	0xd5, 0x31, 0x97, 0x65, 0x17, 0x35, 0x33, 0x21, 0xa0, 0x77, 0x60, 0x3e, 0x88, 0xbc, 0x53, 0xcf,
+	0xb7, 0x07, 0x56, 0x8c, 0x07, 0xd8, 0x21, 0x41, 0xc4, 0xb2, 0x83, 0x9a, 0xd9, 0x92, 0x1d, 0x87,
+	0x82, 0x8e, 0xde, 0x00, 0x5a, 0xaf, 0x21, 0xd8, 0x27, 0x56, 0xdf, 0x8e, 0xfb, 0x6c, 0x5f, 0xaf,
+	0x99, 0x75, 0x41, 0xdb, 0xb5, 0xe3, 0xbe, 0xf1, 0xb3, 0x79, 0x98, 0xa2, 0x0a, 0xd3, 0xb0, 0x66,
+	0x3b, 0xec, 0xc8, 0x24, 0xc2, 0x1a, 0x6f, 0xa1, 0xf7, 0x00, 0xbc, 0xd0, 0x3a, 0xc3, 0x51, 0x4c,
+	0xfb, 0xca, 0x2c, 0x4e, 0xb4, 0x54, 0x9c, 0x78, 0xc9, 0xe9, 0x66, 0xcd, 0x0b, 0xc5, 0x4f, 0xf4,
+	0x0e, 0x9d, 0x5a, 0x40, 0x02, 0x27, 0x18, 0xb4, 0x27, 0xf5, 0x8f, 0x28, 0xc8, 0xa6, 0x62, 0x40,
+	0x2b, 0x30, 0x13, 0x47, 0x8e, 0xe5, 0x63, 0x6a, 0x86, 0x49, 0x16, 0x4d, 0x23, 0x67, 0x0f, 0x13,
+	0xf4, 0x2e, 0xd4, 0x68, 0x47, 0x18, 0x44, 0x24, 0x6e, 0x4f, 0x33, 0x6b, 0xab, 0x35, 0x13, 0x44,
+	0xc4, 0xb4, 0xfd, 0x53, 0x6c, 0x56, 0xe3, 0xc8, 0xa1, 0xad, 0x98, 0xe2, 0xb8, 0x31, 0x61, 0x38,
+	0x15, 0x8e, 0xe3, 0xc6, 0x44, 0xe0, 0xd0, 0x0e, 0x8e, 0x33, 0x33, 0x0e, 0xc7, 0x8d, 0x09, 0xc7,
+	0xb9, 0x05, 0x35, 0xcf, 0x19, 0x86, 0x16, 0x0b, 0x8a, 0x34, 0x51, 0x99, 0xde, 0x9d, 0x30, 0xab,
+	0x94, 0xc4, 0xe2, 0xdd, 0xc7, 0xd0, 0x54, 0xdd, 0x96, 0x13, 0xb8, 0x32, 0x37, 0x91, 0x99, 0x44,
+	0x4f, 0x30, 0x76, 0x7d, 0x77, 0x2b, 0x70, 0x59, 0xc1, 0x4c, 0xca, 0xd2, 0x36, 0x7a, 0x13, 0x9a,
+	0x74, 0x56, 0x5e, 0x68, 0xd1, 0x02, 0xb2, 0xe7, 0xc6, 0x6d, 0x60, 0xda, 0xd6, 0xe3, 0xc8, 0xe9,
+	0x85, 0x87, 0x98, 0xf4, 0xdc, 0x98, 0x32, 0x51, 0x95, 0x53, 0x4c, 0x75, 0xce, 0xe4, 0xc6, 0x44,
+	0x31, 0x3d, 0x81, 0x55, 0x66, 0x38, 0x7b, 0x88, 0x5d, 0x36, 0xbb, 0x34, 0xff, 0x2c, 0xe3, 0x5f,
+	0xa4, 0xa6, 0xa4, 0xfd, 0x74, 0x6a, 0x69, 0x41, 0x66, 0xa9, 0x42, 0xc1, 0x06, 0x17, 0xa4, 0xb6,
+	0xcb, 0x09, 0xfe, 0x10, 0x16, 0x84, 0x5a, 0x4c, 0x4a, 0x8a, 0xcc, 0x31, 0x91, 0x39, 0xa6, 0x1b,
+	0xe5, 0x17, 0xdc, 0xb7, 0x00, 0x9c, 0xc0, 0xf7, 0xad, 0x81, 0x37, 0xf4, 0x08, 0x2b, 0xce, 0x4d,
+	0x9b, 0x35, 0x4a, 0x79, 0x4e, 0x09, 0xe8, 0x11, 0xcc, 0xfa, 0x01, 0xb1, 0x94, 0xa3, 0x9c, 0x14,
+	0x3b, 0x4a, 0xdd, 0x0f, 0x88, 0x6c, 0xa0, 0xdb, 0x40, 0x9b, 0x96, 0xf4, 0x97, 0x53, 0x36, 0x70,
+	0xcd, 0x0f, 0xc8, 0x21, 0x77, 0x99, 0x4d, 0x68, 0xc8, 0x7e, 0xfe, 0xb9, 0xfb, 0x63, 0x3e, 0x77,
+	0x9d, 0xcb, 0xf0, 0x2f, 0x2e, 0x50, 0xa5, 0xf7, 0x78, 0x0a, 0x75, 0x3b, 0x26, 0x29, 0xd4, 0xc4,
+	0x89, 0xbe, 0xba, 0x02, 0x75, 0x5b, 0xfa, 0xd1, 0x5b, 0x5c, 0x2a, 0xf1, 0xa5, 0xaf, 0x99, 0x2f,
+	0x95, 0x18, 0x97, 0xf4, 0x12, 0xb4, 0x03, 0x48, 0xe3, 0xe2, 0x2e, 0x35, 0xb8, 0xd2, 0xa5, 0x4a,
+	0xe6, 0x5c, 0x0a, 0x82, 0x92, 0xd0, 0x03, 0x40, 0x72, 0xe2, 0xa9, 0x6f, 0x39, 0xe4, 0xbb, 0x23,
+	0x9f, 0xab, 0xfa, 0x8a, 0x82, 0x37, 0xe3, 0x60, 0xbe, 0xe2, 0xdd, 0x4e, 0xf9, 0xd8, 0xc7, 0x70,
+	0x4b, 0x19, 0xbc, 0xd0, 0x5d, 0x42, 0x26, 0xb6, 0x22, 0x3e, 0x41, 0xce, 0x63, 0x84, 0xfc, 0x78,
+	0x77, 0xfb, 0x46, 0xc9, 0x6f, 0x17, 0x79, 0xdc, 0x23, 0x58, 0x4a, 0x62, 0x5d, 0xe4, 0x24, 0xf1,
+	0x2e, 0x62, 0x11, 0x6a, 0x41, 0xc5, 0xbb, 0xc8, 0x51, 0x21, 0x2f, 0x2d, 0x43, 0x07, 0x56, 0x32,
+	0xb1, 0x2e, 0xb3, 0x1d, 0x13, 0x25, 0xb3, 0x03, 0x77, 0xb4, 0x71, 0x92, 0xba, 0xa4, 0x92, 0x26,
+	0x4c, 0xfa, 0x66, 0x6a, 0x44, 0x55, 0x9d, 0x2c, 0x84, 0x91, 0x73, 0xce, 0xc0, 0x8c, 0x74, 0x18,
+	0x31, 0x6b, 0x1d, 0xe6, 0x43, 0x58, 0x55, 0x30, 0xd2, 0xfc, 0x0a, 0xe0, 0x8c, 0x01, 0x2c, 0x4b,
+	0x86, 0x3d, 0x66, 0xf9, 0xb1, 0xa2, 0x9a, 0x01, 0xce, 0x73, 0xa2, 0x69, 0x1b, 0x7c, 0xce, 0xe3,
+	0x49, 0xb6, 0x58, 0x3c, 0xb4, 0x89, 0xd3, 0x6f, 0x5f, 0x68, 0xa7, 0x73, 0xbd, 0x56, 0xfc, 0x82,
+	0x72, 0x98, 0xcb, 0x71, 0xe4, 0x14, 0xd0, 0x29, 0x2c, 0x57, 0xa2, 0x08, 0xf6, 0xf2, 0xd5, 0xb0,
+	0x6e, 0x4c, 0x0a, 0xe8, 0x74, 0x53, 0xea, 0x13, 0x12, 0x0a, 0x9c, 0x6f, 0xb5, 0x94, 0x6a, 0xf7,
+	0xe8, 0xe8, 0x80, 0x4b, 0xd7, 0x28, 0x8f, 0x14, 0xa8, 0xca, 0x22, 0x4c, 0xfb, 0x8f, 0xb5, 0x0b,
+	0x0e, 0xba, 0xf9, 0xa9, 0x4a, 0xbc, 0x62, 0x42, 0xbf, 0x03, 0x8b, 0x19, 0x3f, 0x62, 0x5a, 0xb4,
+	0xff, 0x94, 0xef, 0x8e, 0x48, 0xf3, 0x23, 0xd6, 0x85, 0xb6, 0xe1, 0x76, 0x91, 0x48, 0xe2, 0x07,
+	0xed, 0x9f, 0x71, 0xe1, 0x1b, 0x79, 0x61, 0xe5, 0x06, 0xda, 0xc0, 0xa9, 0x2f, 0xd2, 0xfe, 0x79,
+	0x66, 0xe0, 0xc3, 0xc8, 0x29, 0x1a, 0x38, 0xfd, 0x11, 0x93, 0x81, 0xff, 0x2c, 0x33, 0x70, 0x22,
+	0x9c, 0x0c, 0xdc, 0x86, 0x19, 0x9a, 0xdb, 0x58, 0x9e, 0xdb, 0xfe, 0xa5, 0x48, 0x01, 0x68, 0xbb,
+	0xe7, 0xa2, 0x0f, 0x60, 0x4d, 0xc4, 0x16, 0x19, 0x05, 0xd3, 0x8b, 0x78, 0x5e, 0x6d, 0x36, 0xbd,
+	0x50, 0xc4, 0x3f, 0xb9, 0x82, 0x9f, 0x56, 0x60, 0x8a, 0x06, 0xb7, 0xa7, 0x00, 0x55, 0x19, 0xe8,
+	0x3e, 0xad, 0x54, 0x7f, 0x51, 0x6a, 0xfd, 0xb2, 0x64, 0xc2, 0x20, 0x38, 0xb5, 0xc2, 0x08, 0x9f,
+	0x78, 0x17, 0xc6, 0x33, 0x58, 0x28, 0xfa, 0xcc, 0x6b, 0x50, 0x55, 0xee, 0xcb, 0x55, 0x52, 0x6d,
+	0x7a, 0x2e, 0x62, 0xf3, 0x13, 0x87, 0x05, 0xde, 0x30, 0xfe, 0x6d, 0x0a, 0x6a, 0xca, 0x01, 0xf8,
+	0xb9, 0x87, 0xf4, 0x03, 0x97, 0xe7, 0x78, 0x35, 0x53, 0x36, 0xd1, 0x43, 0x98, 0x0e, 0x6d, 0xd2,
+	0x97, 0x89, 0xdc, 0x5a, 0xd6, 0x77, 0x36, 0x0e, 0x6c, 0xd2, 0x67, 0xbf, 0x4c, 0xce, 0x88, 0x3a,
+	0x30, 0xd3, 0xc7, 0xb6, 0x2b, 0xcf, 0x1d, 0xc9, 0xf9, 0x2e, 0x91, 0xd9, 0x65, 0xfd, 0x5c, 0x4a,
+	0x32, 0x53, 0x3d, 0x69, 0xe1, 0x27, 0x16, 0xf9, 0x0d, 0x6f, 0xa0, 0x0d, 0x98, 0x3a, 0x8d, 0x42,
+	0x27, 0x73, 0xf5, 0x9b, 0x40, 0x3d, 0x33, 0x0f, 0xb6, 0x38, 0x10, 0xe3, 0x43, 0x1f, 0x01, 0x7c,
+	0x75, 0x4e, 0x2c, 0x67, 0x60, 0x7b, 0xc3, 0x98, 0xa5, 0x38, 0xa9, 0x72, 0xa7, 0x92, 0xda, 0xa2,
+	0xdd, 0xc2, 0xf7, 0xbf, 0x3a, 0x27, 0xac, 0x19, 0xaf, 0x7d, 0x06, 0x35, 0x35, 0x1b, 0xb4, 0x0c,
+	0xd3, 0xf8, 0xc2, 0x76, 0x08, 0xb7, 0xe7, 0xee, 0x84, 0xc9, 0x9b, 0xa8, 0x0d, 0x15, 0xfe, 0x2d,
+	0x78, 0xd6, 0x4c, 0x6f, 0xdc, 0x79, 0xfb, 0xe9, 0x2c, 0x00, 0xb5, 0x00, 0x5f, 0x6b, 0x6b, 0xdf,
+	0x42, 0x3d, 0x35, 0xcd, 0xa2, 0x43, 0x4a, 0x32, 0x44, 0x79, 0xdc, 0x10, 0x93, 0xfa, 0x10, 0x54,
+	0x22, 0xc2, 0xa7, 0xf8, 0xa2, 0x3d, 0x25, 0x3a, 0x78, 0xf3, 0x69, 0x03, 0xea, 0xec, 0xb8, 0x2b,
+	0xc6, 0xee, 0x42, 0x4d, 0xd9, 0x85, 0xfb, 0x06, 0x73, 0x19, 0xf9, 0x71, 0x55, 0x3b, 0xfd, 0xdd,
+	0xcb, 0xda, 0x77, 0x5f, 0xfb, 0x00, 0x20, 0x31, 0xd2, 0x18, 0xed, 0x2b, 0x6c, 0x4c, 0x29, 0x2a,
+	0x5a, 0xc6, 0xdf, 0x95, 0x60, 0x36, 0x1d, 0x2b, 0xd0, 0x27, 0x50, 0xb7, 0x7d, 0x3f, 0x20, 0xec,
+	0xea, 0x40, 0x1e, 0x22, 0xde, 0x2a, 0x88, 0x2a, 0x1b, 0xdd, 0x84, 0x8d, 0x1f, 0xfe, 0xd3, 0x82,
+	0x6b, 0x1f, 0x43, 0x2b, 0xcb, 0xf0, 0x5a, 0x65, 0x80, 0x0f, 0x61, 0x2e, 0x93, 0x23, 0xd0, 0x79,
+	0xb1, 0xa4, 0xa3, 0xc4, 0xd2, 0x2e, 0xf6, 0x9b, 0xd2, 0x58, 0x76, 0x51, 0xe6, 0x34, 0xfa, 0xdb,
+	0x78, 0x0e, 0x55, 0x95, 0x5d, 0xb5, 0xa1, 0x22, 0xea, 0x73, 0x25, 0x91, 0xf6, 0x8a, 0x36, 0x5a,
+	0x4c, 0x1f, 0xa7, 0x76, 0x27, 0xb8, 0x9d, 0x9e, 0xb6, 0xa0, 0xc9, 0xfb, 0xad, 0x20, 0x62, 0x91,
+	0xc6, 0x78, 0x0c, 0x35, 0x95, 0x0d, 0x51, 0x7d, 0x4f, 0xbc, 0x28, 0x26, 0x42, 0x07, 0xde, 0xa0,
+	0x4a, 0x0c, 0xec, 0x98, 0x48, 0x25, 0xe8, 0x6f, 0xe3, 0xaf, 0x4a, 0x80, 0xb2, 0x25, 0xc6, 0xde,
+	0x36, 0x3d, 0xef, 0x07, 0x91, 0xd3, 0xc7, 0x31, 0x89, 0x6c, 0x12, 0x44, 0x34, 0x2c, 0xf1, 0xa9,
+	0x37, 0xd3, 0xe4, 0x9e, 0x8b, 0xee, 0x40, 0x5d, 0xd5, 0x33, 0x3d, 0x57, 0x94, 0x93, 0x40, 0x92,
+	0x38, 0x83, 0xaa, 0x73, 0x7a, 0x2e, 0xf7, 0x31, 0x13, 0x24, 0xa9, 0xe7, 0x7e, 0x3a, 0x55, 0x2d,
+	0xb5, 0xca, 0x66, 0x95, 0xae, 0x4c, 0x36, 0x91, 0x0b, 0x58, 0x2e, 0xbe, 0xa1, 0x47, 0x6f, 0xa7,
+	0x8e, 0xa6, 0xab, 0x63, 0xca, 0xa3, 0xe2, 0x08, 0xfc, 0x3e, 0x54, 0xe5, 0x10, 0xed, 0x69, 0xed,
+	0x95, 0x49, 0x56, 0xc0, 0x54, 0x8c, 0xc6, 0xff, 0x4c, 0x42, 0x2b, 0xdb, 0x4d, 0x4d, 0x19, 0x13,
+	0x9b, 0x48, 0x37, 0xe5, 0x8d, 0xa2, 0x43, 0x2e, 0x75, 0x9b, 0xa1, 0xed, 0x08, 0x13, 0xd0, 0x9f,
+	0x74, 0xee, 0xf2, 0x69, 0x88, 0xe7, 0xca, 0x18, 0x04, 0x82, 0x44, 0x73, 0xac, 0x1b, 0x50, 0xf3,
+	0xc2, 0xb3, 0x4d, 0x9a, 0xfb, 0xf2, 0x73, 0x56, 0xcd, 0xac, 0x52, 0xc2, 0x1e, 0x26, 0xb2, 0xb3,
+	0xc3, 0x3b, 0x2b, 0xaa, 0xb3, 0xc3, 0x3a, 0xef, 0xc1, 0x34, 0x3d, 0x6d, 0xcb, 0x53, 0x95, 0xcc,
+	0xdd, 0x8f, 0x3c, 0x1c, 0xf5, 0xfc, 0x93, 0xc0, 0xe4, 0xbd, 0xe8, 0x6d, 0xa8, 0xf2, 0x01, 0x6c,
+	0xd2, 0xae, 0xde, 0x9d, 0x4c, 0xd5, 0x4d, 0xf6, 0x6c, 0xc2, 0x18, 0x67, 0xd8, 0x78, 0x36, 0x11,
+	0xac, 0x1d, 0xc6, 0x5a, 0x1b, 0xcb, 0xda, 0xa1, 0xac, 0x5d, 0xb8, 0x65, 0x0f, 0x06, 0xc1, 0xb9,
+	0x15, 0x87, 0x41, 0x70, 0x82, 0x5d, 0x4b, 0x94, 0x2a, 0x79, 0x40, 0xc1, 0xf2, 0x5c, 0xb5, 0xc6,
+	0x98, 0x0e, 0x39, 0x0f, 0xaf, 0x0d, 0x1e, 0x08, 0x0e, 0xf4, 0xa9, 0xbe, 0x7e, 0xeb, 0x6c, 0xc0,
+	0xf5, 0x31, 0xdf, 0xe8, 0xff, 0x79, 0x0d, 0x6f, 0xe5, 0x3d, 0x4e, 0x14, 0x43, 0xae, 0xef, 0x71,
+	0x46, 0x17, 0x9a, 0xe9, 0xeb, 0x87, 0xde, 0x76, 0xd6, 0xf3, 0xcb, 0xaf, 0xf4, 0xfc, 0x01, 0xa0,
+	0xfc, 0xeb, 0x19, 0x74, 0x2f, 0xa5, 0xc3, 0x52, 0xc1, 0x45, 0x87, 0xf0, 0xf8, 0xf7, 0x52, 0x1e,
+	0x3f, 0xa9, 0xe5, 0x58, 0x69, 0xe6, 0x94, 0xb7, 0xff, 0xba, 0x0c, 0xb3, 0xe9, 0xae, 0xc2, 0x78,
+	0x9c, 0xf1, 0xe0, 0x72, 0xce, 0x83, 0x95, 0x1f, 0x4e, 0x5e, 0xe9, 0x87, 0x1b, 0xb0, 0x80, 0x2f,
+	0x42, 0xec, 0x10, 0xec, 0x5a, 0xcc, 0x21, 0x6d, 0xd7, 0x8d, 0xe4, 0x8a, 0x98, 0x97, 0x5d, 0xbd,
+	0xf0, 0x6c, 0xb3, 0xeb, 0xba, 0x79, 0xfe, 0x8e, 0xe0, 0x9f, 0xce, 0xf1, 0x77, 0x38, 0xff, 0x07,
+	0x30, 0xa7, 0xca, 0x3b, 0x16, 0x57, 0xa8, 0x52, 0xac, 0x50, 0x53, 0xf1, 0x1d, 0x31, 0xcd, 0x1e,
+	0x43, 0x53, 0xd6, 0x82, 0xac, 0x2b, 0x57, 0xd4, 0xac, 0x28, 0x11, 0x71, 0xb1, 0x4d, 0x68, 0x9c,
+	0x04, 0xd1, 0x39, 0xbd, 0x2e, 0xe1, 0x52, 0xd5, 0x31, 0x52, 0x82, 0x8b, 0x49, 0x19, 0xbf, 0xab,
+	0x7f, 0x61, 0xe1, 0x65, 0xd7, 0xfb, 0xc2, 0x46, 0x04, 0x55, 0x09, 0x5b, 0xf8, 0xad, 0xde, 0x86,
+	0x96, 0xe7, 0x9f, 0x46, 0xf4, 0x7a, 0x8f, 0x55, 0xf8, 0x3c, 0xb5, 0x8b, 0xce, 0x09, 0xfa, 0x81,
+	0x20, 0xd3, 0xf0, 0x8e, 0x33, 0x9c, 0xa2, 0x9c, 0x8b, 0x35, 0x46, 0xe3, 0x09, 0xcc, 0x88, 0xd5,
+	0x8f, 0x96, 0xa0, 0x82, 0x2f, 0x68, 0xee, 0x29, 0x23, 0x21, 0xbe, 0x20, 0xbd, 0x90, 0x92, 0x99,
+	0x83, 0x87, 0x72, 0x5d, 0x51, 0x85, 0x43, 0xc3, 0x84, 0x85, 0x82, 0x7b, 0x44, 0x5a, 0x6c, 0xf6,
+	0xe2, 0xc0, 0x22, 0xde, 0x10, 0xc7, 0xc4, 0x1e, 0x4a, 0xac, 0x59, 0x2f, 0x0e, 0x8e, 0x24, 0x8d,
+	0x26, 0x01, 0xa3, 0x90, 0xb2, 0x30, 0xc8, 0x92, 0x29, 0x5a, 0x46, 0x08, 0xed, 0x71, 0x77, 0x88,
+	0xd7, 0x5d, 0x25, 0xef, 0x42, 0x85, 0xdf, 0xf9, 0xb4, 0xcb, 0x1a, 0xab, 0x8e, 0x69, 0x0a, 0x26,
+	0x63, 0x1d, 0x9a, 0x7a, 0x0f, 0xd5, 0x4d, 0x00, 0xc8, 0xfb, 0x07, 0xce, 0xd9, 0x2d, 0xd2, 0xed,
+	0xf5, 0xbe, 0xef, 0x05, 0xdc, 0xbc, 0xea, 0x6a, 0xf1, 0x75, 0xb6, 0xbf, 0xd7, 0x9c, 0x66, 0x6f,
+	0xdc, 0xc8, 0xaf, 0x1f, 0x06, 0x8f, 0x01, 0xe5, 0xef, 0xe1, 0x5e, 0x5d, 0xb2, 0x7e, 0x27, 0xa3,
+	0xf0, 0x42, 0xd1, 0x25, 0x9c, 0x54, 0xf7, 0xb1, 0x3e, 0xc6, 0x75, 0x0b, 0xd7, 0x36, 0xcc, 0xa6,
+	0xc5, 0xc6, 0x7d, 0xca, 0x5c, 0xdd, 0xb6, 0x9c, 0xab, 0xdb, 0x52, 0x51, 0x1c, 0x45, 0x01, 0x0b,
+	0x7b, 0x34, 0x97, 0x12, 0x2d, 0xe3, 0x14, 0x96, 0x0a, 0x2f, 0x48, 0x69, 0x41, 0x2e, 0x1c, 0x1d,
+	0x0f, 0x3c, 0xc7, 0x4a, 0x76, 0xa5, 0x1a, 0xa7, 0x7c, 0x86, 0x2f, 0x5f, 0xbb, 0xcc, 0x6b, 0xcc,
+	0xc3, 0x5c, 0xe6, 0xde, 0xd4, 0xf8, 0xf3, 0x32, 0x2c, 0x17, 0xbf, 0x91, 0xa0, 0xd9, 0xba, 0xdc,
+	0x64, 0xe4, 0x49, 0x4e, 0xb6, 0x55, 0x0a, 0x42, 0x03, 0xac, 0x98, 0x2a, 0x4b, 0x19, 0x68, 0x5c,
+	0x55, 0x29, 0x08, 0xeb, 0x9c, 0x54, 0x9d, 0x2c, 0xe8, 0x52, 0x54, 0x3b, 0x16, 0x59, 0x2b, 0x4f,
+	0xeb, 0x54, 0x1b, 0x75, 0xa1, 0x32, 0xb0, 0x8f, 0xf1, 0x40, 0x56, 0x8f, 0xdf, 0xbe, 0xf2, 0x11,
+	0xc7, 0xc6, 0x73, 0xc6, 0x2b, 0xee, 0xe6, 0xb8, 0x20, 0xbd, 0x9b, 0x4b, 0x91, 0x5f, 0x6b, 0x43,
+	0xff, 0xfd, 0xbc, 0x25, 0x84, 0x93, 0xfc, 0x5f, 0x2d, 0x61, 0xbc, 0x00, 0x94, 0x86, 0xfc, 0x9e,
+	0x86, 0xcd, 0xc2, 0x7d, 0x5f, 0xed, 0xf6, 0x61, 0xb1, 0xe8, 0x31, 0xcf, 0x35, 0x00, 0x3b, 0x59,
+	0xc0, 0x4e, 0x31, 0xe0, 0xb5, 0x35, 0x1c, 0x03, 0xb8, 0x03, 0x4d, 0xfd, 0x55, 0x68, 0xc1, 0x3d,
+	0xe4, 0x54, 0x18, 0x04, 0x83, 0x76, 0x59, 0x5b, 0xc1, 0x52, 0xc8, 0x64, 0x9d, 0xc6, 0xdd, 0x04,
+	0x66, 0xcc, 0x0d, 0xe3, 0xb7, 0x50, 0x95, 0x1c, 0xec, 0xd4, 0xe5, 0xb9, 0xea, 0x7a, 0x8a, 0xfe,
+	0x46, 0xb7, 0x01, 0x86, 0x76, 0xfc, 0xcd, 0x08, 0x47, 0xb6, 0x38, 0x8f, 0x55, 0xcd, 0x14, 0x85,
+	0xcf, 0xc2, 0x0b, 0xad, 0x21, 0x3d, 0xae, 0x29, 0x97, 0xf7, 0xc2, 0x17, 0xf4, 0x68, 0x77, 0x0b,
+	0xe0, 0xec, 0x62, 0x60, 0xfb, 0xbc, 0x97, 0x3b, 0x7d, 0x8d, 0x51, 0x68, 0xb7, 0xf1, 0x27, 0x25,
+	0x68, 0x68, 0x8f, 0xdc, 0x68, 0x2c, 0x61, 0x68, 0xd8, 0xb7, 0x8f, 0x07, 0x98, 0xeb, 0x59, 0xa5,
+	0x0f, 0xd3, 0xbd, 0x70, 0x87, 0x93, 0xe8, 0x96, 0xc8, 0x31, 0x25, 0x0f, 0xd7, 0x69, 0x96, 0x11,
+	0x25, 0xd3, 0x3a, 0xb4, 0x34, 0x26, 0xeb, 0xac, 0x23, 0xae, 0xb5, 0x9a, 0x69, 0xbe, 0x97, 0x1d,
+	0xe3, 0x9f, 0x4a, 0xb0, 0x58, 0xf4, 0x48, 0x15, 0xdd, 0x4f, 0xc5, 0xc7, 0x95, 0xc2, 0xaa, 0x9f,
+	0x88, 0xc5, 0x3f, 0x56, 0x6b, 0x97, 0x97, 0x67, 0xee, 0x5f, 0xf1, 0xf4, 0xf5, 0x37, 0xbd, 0x72,
+	0x7f, 0x9c, 0x55, 0x5e, 0x3d, 0x64, 0xb9, 0x9e, 0xf2, 0xc6, 0x36, 0xb4, 0xb2, 0x74, 0xfd, 0x4e,
+	0xaf, 0x94, 0xbd, 0xd3, 0x2b, 0xba, 0xaf, 0xfc, 0x87, 0x12, 0xcc, 0x65, 0x5e, 0xd1, 0x22, 0x23,
+	0xa5, 0x02, 0xca, 0x3e, 0x92, 0x15, 0xa6, 0xfb, 0x28, 0x63, 0x3a, 0xa3, 0xf8, 0x45, 0xee, 0x6f,
+	0xda, 0x6a, 0x8f, 0x53, 0xda, 0x0a, 0x83, 0x5d, 0x43, 0x5b, 0xe3, 0x0d, 0xa8, 0xa7, 0x48, 0x85,
+	0x57, 0xde, 0x47, 0x00, 0xfc, 0x31, 0xec, 0x91, 0xa8, 0x62, 0x50, 0xcf, 0x15, 0x5e, 0xcc, 0x7e,
+	0x33, 0xad, 0xa8, 0x07, 0x0a, 0xb7, 0xe5, 0x0d, 0x6a, 0x72, 0xf5, 0x20, 0x48, 0xde, 0xbf, 0x2a,
+	0x82, 0xf1, 0x1f, 0x65, 0xa8, 0xa7, 0x9e, 0x07, 0xa3, 0xb7, 0x52, 0x15, 0x93, 0x64, 0xe3, 0x63,
+	0x1c, 0xc9, 0xdb, 0x07, 0xf4, 0x3e, 0x5d, 0x4b, 0xfc, 0xc9, 0x38, 0xe3, 0xe6, 0xdb, 0xe4, 0xbc,
+	0x0a, 0x14, 0x74, 0xc9, 0x33, 0x76, 0xf0, 0x42, 0xf9, 0x9b, 0x9a, 0xd1, 0x8d, 0x89, 0x3c, 0x94,
+	0xbb, 0x31, 0x41, 0x06, 0x34, 0xd8, 0xfd, 0x40, 0xe0, 0xf2, 0x1a, 0xad, 0x58, 0xc6, 0xf4, 0x7e,
+	0x6f, 0x2f, 0x70, 0x59, 0x49, 0x96, 0x5e, 0x4b, 0x29, 0x1e, 0x2f, 0x94, 0xf7, 0xc0, 0x82, 0xa3,
+	0x17, 0xd2, 0x63, 0x51, 0x6c, 0x0f, 0xb1, 0x15, 0x8f, 0x8e, 0xe9, 0xb5, 0xd5, 0x0c, 0x8f, 0x22,
+	0x94, 0x74, 0xc8, 0x28, 0x74, 0xdd, 0xd3, 0x03, 0x45, 0x30, 0x22, 0xa7, 0x81, 0xe7, 0x9f, 0xb2,
+	0xcb, 0xcc, 0xaa, 0x59, 0xf7, 0x6d, 0xb2, 0x2f, 0x48, 0xe8, 0x1e, 0x34, 0x07, 0x81, 0x63, 0x0f,
+	0x2c, 0x59, 0x2c, 0x61, 0xb7, 0x99, 0x55, 0xb3, 0xc1, 0xa8, 0x32, 0xbd, 0x42, 0x8f, 0xa0, 0x4e,
+	0xd8, 0x17, 0xe0, 0x93, 0xe6, 0x6f, 0xa7, 0xe4, 0xa4, 0x93, 0x6f, 0x63, 0x02, 0x51, 0xbf, 0x8d,
+	0x3b, 0xc2, 0xbc, 0xc2, 0x17, 0x84, 0x0d, 0xca, 0xca, 0x06, 0xc6, 0x7f, 0x95, 0x60, 0x75, 0xec,
+	0x73, 0x69, 0xe6, 0x08, 0x81, 0xcb, 0x3f, 0x07, 0x75, 0x84, 0xc0, 0x55, 0xc5, 0x8d, 0x72, 0x52,
+	0xdc, 0xd0, 0x36, 0xa4, 0xc9, 0x4c, 0xe2, 0xb0, 0x0e, 0xad, 0xd0, 0x8e, 0x68, 0x0a, 0xe5, 0x62,
+	0x56, 0x0d, 0xf7, 0x42, 0x61, 0xe7, 0x26, 0xa7, 0x6f, 0x33, 0x32, 0x3f, 0x3f, 0x0c, 0x6d, 0x87,
+	0xc6, 0x33, 0x6e, 0xe5, 0xe9, 0xa1, 0xed, 0xbc, 0xec, 0xe8, 0x9b, 0x49, 0x25, 0x93, 0x79, 0xfc,
+	0x10, 0x50, 0x16, 0xfd, 0xac, 0x23, 0xee, 0xd7, 0x5b, 0x3a, 0xfe, 0x59, 0xc7, 0x78, 0xaf, 0x70,
+	0xae, 0xc2, 0x36, 0x05, 0x73, 0x35, 0x7e, 0x5e, 0x82, 0x95, 0x31, 0x8f, 0xb6, 0xaf, 0xdc, 0x00,
+	0xf5, 0x24, 0xaf, 0x9c, 0x4d, 0xf2, 0x36, 0x60, 0xc1, 0xf3, 0x09, 0x8e, 0x4e, 0x6c, 0xae, 0xb1,
+	0x66, 0xba, 0x79, 0xd5, 0x25, 0x0f, 0xc1, 0xc6, 0xe3, 0x02, 0x2d, 0x5e, 0xbd, 0x0d, 0x1b, 0x7f,
+	0x59, 0x82, 0xd5, 0xb1, 0xcf, 0x93, 0xaf, 0xd4, 0xdf, 0x80, 0x46, 0xa2, 0x3f, 0xfd, 0x22, 0x22,
+	0xf3, 0x55, 0x53, 0x78, 0xd9, 0xc9, 0x4d, 0xa2, 0x33, 0x76, 0x12, 0x7c, 0xdf, 0x7f, 0x52, 0xa8,
+	0xcc, 0x35, 0xa6, 0xf1, 0xcf, 0x25, 0x58, 0x2a, 0x7c, 0x7e, 0x4e, 0x2f, 0x19, 0xe5, 0x1d, 0x8b,
+	0x33, 0x18, 0xc5, 0x04, 0x47, 0x16, 0xdd, 0xd9, 0x65, 0x21, 0x7a, 0x41, 0x74, 0x6e, 0xf1, 0xbe,
+	0x2d, 0xda, 0x85, 0x36, 0x93, 0xff, 0xc4, 0xc0, 0x17, 0x04, 0x47, 0xf4, 0xb2, 0x86, 0x0b, 0x95,
+	0xc5, 0x05, 0x0a, 0xef, 0xdd, 0x11, 0x9d, 0x5c, 0xea, 0x47, 0xb0, 0x26, 0xa5, 0xe8, 0x5a, 0x3c,
+	0xb6, 0x07, 0xb6, 0xef, 0xa8, 0xe1, 0xf8, 0x89, 0xb9, 0x2d, 0x38, 0x9e, 0xa7, 0x18, 0x98, 0xb4,
+	0xf1, 0x8f, 0x25, 0x98, 0xcf, 0x3d, 0x01, 0x2e, 0x3c, 0xb9, 0xdf, 0xe0, 0xcf, 0x2d, 0x6c, 0x37,
+	0x51, 0x88, 0x3e, 0xae, 0xe0, 0xa5, 0x0d, 0x03, 0x66, 0x5d, 0x1c, 0x13, 0xcf, 0x17, 0xa5, 0x32,
+	0x3e, 0xac, 0x46, 0xa3, 0x0f, 0x5a, 0x7c, 0x7a, 0x38, 0xef, 0x07, 0x72, 0x99, 0xcd, 0xd0, 0xf6,
+	0x6e, 0x10, 0xd2, 0x48, 0xac, 0xbe, 0x8a, 0x0c, 0x64, 0x8a, 0x40, 0xa3, 0x37, 0xa1, 0x99, 0x03,
+	0x5b, 0x62, 0x0d, 0x93, 0x37, 0x8c, 0xfb, 0x9a, 0xe2, 0xa9, 0x95, 0x92, 0xdd, 0x1e, 0xbe, 0x84,
+	0xba, 0xd8, 0x6d, 0x69, 0xed, 0x19, 0xad, 0x25, 0x15, 0x6d, 0xf9, 0x3d, 0x65, 0x9b, 0x8a, 0x53,
+	0x1e, 0x59, 0x7c, 0x96, 0xfc, 0x34, 0xa0, 0x32, 0x3a, 0x3f, 0x48, 0xa9, 0xb6, 0xf1, 0xdf, 0x25,
+	0x68, 0x68, 0x2f, 0xfe, 0x0b, 0x2d, 0xa7, 0x6d, 0xed, 0xe5, 0x82, 0xad, 0x5d, 0xbd, 0xaf, 0xab,
+	0x89, 0x5d, 0xe4, 0x0e, 0xd4, 0xa5, 0xd7, 0x78, 0xa1, 0xaa, 0xc9, 0x0a, 0x52, 0x2f, 0x64, 0xb5,
+	0x11, 0xed, 0x63, 0xab, 0xf8, 0xdf, 0x4c, 0x93, 0x7b, 0x21, 0x8d, 0xf1, 0xca, 0x97, 0xbc, 0x90,
+	0x17, 0x9c, 0x6a, 0x66, 0x5d, 0xd2, 0x28, 0xd6, 0x3a, 0x4c, 0xa7, 0xdf, 0xbe, 0x20, 0x3d, 0x73,
+	0xa1, 0xf3, 0x34, 0x39, 0x83, 0xd1, 0x55, 0xb3, 0x1d, 0x6f, 0xee, 0xab, 0x67, 0x6b, 0xfc, 0x7b,
+	0x09, 0xe6, 0xd5, 0xa1, 0xf0, 0xd0, 0xb7, 0xc3, 0xb8, 0x1f, 0xd0, 0x7b, 0x9f, 0x19, 0x79, 0xae,
+	0xe4, 0x0f, 0x30, 0x65, 0x93, 0x26, 0x9e, 0x4c, 0x1d, 0xed, 0xdc, 0x59, 0x33, 0x67, 0x19, 0x51,
+	0xbe, 0x27, 0x5a, 0x81, 0x99, 0xe3, 0x20, 0x20, 0x49, 0x6d, 0xbf, 0x42, 0x9b, 0x3d, 0x57, 0x5b,
+	0xbb, 0x53, 0x99, 0x40, 0xb2, 0x01, 0x55, 0xf1, 0xa0, 0x54, 0x9e, 0xff, 0xe4, 0xcc, 0x53, 0xff,
+	0x76, 0x6a, 0x2a, 0x1e, 0xf6, 0x4d, 0xf8, 0x7f, 0xa1, 0xb0, 0x03, 0x37, 0x0f, 0xf7, 0xc0, 0x49,
+	0xf4, 0xbc, 0xfd, 0x60, 0x9d, 0x3e, 0x7b, 0x94, 0x2a, 0xcd, 0xc0, 0x64, 0x77, 0xef, 0xcb, 0xd6,
+	0x04, 0xaa, 0xc2, 0x54, 0xef, 0xe0, 0xe5, 0x66, 0x6b, 0x4a, 0xfc, 0xea, 0xb4, 0x2a, 0x0f, 0xfe,
+	0x82, 0xbe, 0x16, 0x95, 0x89, 0x03, 0x6a, 0x40, 0x6d, 0xab, 0xb7, 0x6d, 0x5a, 0xbd, 0xbd, 0x4f,
+	0xf6, 0x5b, 0x13, 0x68, 0x01, 0xe6, 0xcc, 0x9d, 0x17, 0xfb, 0x47, 0x3b, 0xd6, 0x17, 0xfb, 0xe6,
+	0x67, 0xcf, 0xf7, 0xbb, 0xdb, 0xad, 0x12, 0x7d, 0x3d, 0x29, 0x88, 0xbb, 0xfb, 0x87, 0x47, 0xad,
+	0x32, 0x42, 0xd0, 0x7c, 0xbe, 0xbf, 0xd5, 0x7d, 0x9e, 0x30, 0x4d, 0xa2, 0x26, 0x00, 0xa7, 0x31,
+	0x9e, 0x29, 0x34, 0x0f, 0x0d, 0x21, 0x74, 0xf4, 0xf9, 0xde, 0xde, 0xce, 0xf3, 0xd6, 0x34, 0x6a,
+	0xc1, 0x2c, 0x67, 0x11, 0x94, 0xca, 0x83, 0x0f, 0x01, 0x92, 0xac, 0x84, 0xea, 0xb8, 0xb7, 0xbf,
+	0xb7, 0xd3, 0x9a, 0x40, 0xb3, 0x50, 0xdd, 0xdb, 0xb7, 0x76, 0xf6, 0xb6, 0xba, 0x07, 0xad, 0x12,
+	0xaa, 0xc1, 0x34, 0xdb, 0x9e, 0x5a, 0x65, 0x3e, 0x8d, 0xde, 0x41, 0x6b, 0xf2, 0xd1, 0xc7, 0x00,
+	0xa2, 0x56, 0x41, 0xdf, 0x3f, 0x3f, 0x84, 0x29, 0xf6, 0x57, 0xf9, 0x4f, 0xf2, 0xcf, 0xbc, 0x6b,
+	0x05, 0x96, 0x7d, 0x58, 0x7a, 0xba, 0xf2, 0x8b, 0xef, 0x6e, 0x97, 0xfe, 0xe5, 0xbb, 0xdb, 0xa5,
+	0xff, 0xfc, 0xee, 0x76, 0xe9, 0xaf, 0x7f, 0x75, 0x7b, 0xe2, 0x27, 0xd3, 0xec, 0xb5, 0xcf, 0x71,
+	0x85, 0xfd, 0x79, 0xff, 0x7f, 0x07, 0x00, 0x39, 0x69, 0x37, 0x21, 0x2e, 0x3c, 0x00, 0x00,
 }
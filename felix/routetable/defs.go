@@ -39,6 +39,7 @@ const (
 	RouteClassVXLANSameSubnet
 	RouteClassVXLANTunnel
 	RouteClassIPAMBlockDrop
+	RouteClassRoutePolicy
 
 	RouteClassMax
 )
@@ -14,12 +14,13 @@ func _() {
 	_ = x[RouteClassVXLANSameSubnet-3]
 	_ = x[RouteClassVXLANTunnel-4]
 	_ = x[RouteClassIPAMBlockDrop-5]
-	_ = x[RouteClassMax-6]
+	_ = x[RouteClassRoutePolicy-6]
+	_ = x[RouteClassMax-7]
 }
 
-const _RouteClass_name = "RouteClassLocalWorkloadRouteClassBPFSpecialRouteClassWireguardRouteClassVXLANSameSubnetRouteClassVXLANTunnelRouteClassIPAMBlockDropRouteClassMax"
+const _RouteClass_name = "RouteClassLocalWorkloadRouteClassBPFSpecialRouteClassWireguardRouteClassVXLANSameSubnetRouteClassVXLANTunnelRouteClassIPAMBlockDropRouteClassRoutePolicyRouteClassMax"
 
-var _RouteClass_index = [...]uint8{0, 23, 43, 62, 87, 108, 131, 144}
+var _RouteClass_index = [...]uint8{0, 23, 43, 62, 87, 108, 131, 152, 165}
 
 func (i RouteClass) String() string {
 	if i < 0 || i >= RouteClass(len(_RouteClass_index)-1) {
@@ -24,6 +24,7 @@ import (
 	"github.com/projectcalico/calico/felix/hashutils"
 	"github.com/projectcalico/calico/felix/ipsets"
 	"github.com/projectcalico/calico/felix/iptables"
+	"github.com/projectcalico/calico/felix/nftables"
 	"github.com/projectcalico/calico/felix/proto"
 )
 
@@ -193,6 +194,10 @@ func (r *DefaultRuleRenderer) ProtoRuleToIptablesRules(pRule *proto.Rule, ipVers
 	//    - negative match blocks don't use the scratch bit, they simply clear the "AllBlocks" bit
 	//      immediately if any of their rules match.
 	//
+	// None of the above applies to nftables, which can match any number of ports or CIDRs in a
+	// single rule using anonymous sets.  When rendering for nftables, we only need to render a block
+	// to "or" named ports with numeric ports.
+	//
 	// The matchBlockBuilder wraps up the above logic:
 	matchBlockBuilder := matchBlockBuilder{
 		actions:           r.ActionFactory,
@@ -212,7 +217,7 @@ func (r *DefaultRuleRenderer) ProtoRuleToIptablesRules(pRule *proto.Rule, ipVers
 	} else {
 		ipSetConfig = r.IPSetConfigV6
 	}
-	srcPortSplits := SplitPortList(ruleCopy.SrcPorts)
+	srcPortSplits := splitPortList(r.NewMatch(), ruleCopy.SrcPorts)
 	if len(srcPortSplits)+len(ruleCopy.SrcNamedPortIpSetIds) > 1 {
 		// Render a block for the source ports.
 		matchBlockBuilder.AppendPortMatchBlock(ipSetConfig, ruleCopy.Protocol, srcPortSplits, ruleCopy.SrcNamedPortIpSetIds, src)
@@ -220,7 +225,7 @@ func (r *DefaultRuleRenderer) ProtoRuleToIptablesRules(pRule *proto.Rule, ipVers
 		ruleCopy.SrcPorts = nil
 		ruleCopy.SrcNamedPortIpSetIds = nil
 	}
-	dstPortSplits := SplitPortList(ruleCopy.DstPorts)
+	dstPortSplits := splitPortList(r.NewMatch(), ruleCopy.DstPorts)
 	if len(dstPortSplits)+len(ruleCopy.DstNamedPortIpSetIds) > 1 {
 		// Render a block for the destination ports.
 		matchBlockBuilder.AppendPortMatchBlock(ipSetConfig, ruleCopy.Protocol, dstPortSplits, ruleCopy.DstNamedPortIpSetIds, dst)
@@ -230,13 +235,15 @@ func (r *DefaultRuleRenderer) ProtoRuleToIptablesRules(pRule *proto.Rule, ipVers
 	}
 
 	// If there's more than one positive source/destination CIDR match, we have to render a block.
-	// Otherwise, if there's exactly one, we'll include it in the main rule below.
-	if len(ruleCopy.SrcNet) > 1 {
+	// Otherwise, if there's exactly one, we'll include it in the main rule below.  With nftables,
+	// all the CIDR matches are collapsed into set lookups in the main rule.
+	_, collapseCIDRs := r.NewMatch().(nftables.NFTMatchCriteria)
+	if len(ruleCopy.SrcNet) > 1 && !collapseCIDRs {
 		matchBlockBuilder.AppendCIDRMatchBlock(ruleCopy.SrcNet, src)
 		// Since we're using a block for this, nil out the match.
 		ruleCopy.SrcNet = nil
 	}
-	if len(ruleCopy.DstNet) > 1 {
+	if len(ruleCopy.DstNet) > 1 && !collapseCIDRs {
 		matchBlockBuilder.AppendCIDRMatchBlock(ruleCopy.DstNet, dst)
 		// Since we're using a block for this, nil out the match.
 		ruleCopy.DstNet = nil
@@ -252,7 +259,7 @@ func (r *DefaultRuleRenderer) ProtoRuleToIptablesRules(pRule *proto.Rule, ipVers
 	// any negative matches will tip the count over 1.  Otherwise, we'll need 2 or more negative
 	// matches to make the count more than 1.
 	totalSrcMatches := len(ruleCopy.SrcNet) + len(ruleCopy.NotSrcNet)
-	if totalSrcMatches > 1 {
+	if totalSrcMatches > 1 && !collapseCIDRs {
 		// We have some negated source CIDR matches and the total number of source
 		// CIDR matches won't fit in the rule.  Render a block of rules to do the
 		// negated match.
@@ -261,7 +268,7 @@ func (r *DefaultRuleRenderer) ProtoRuleToIptablesRules(pRule *proto.Rule, ipVers
 		ruleCopy.NotSrcNet = nil
 	}
 	totalDstMatches := len(ruleCopy.DstNet) + len(ruleCopy.NotDstNet)
-	if totalDstMatches > 1 {
+	if totalDstMatches > 1 && !collapseCIDRs {
 		// We have some negated dest CIDR matches and the total number of dest
 		// CIDR matches won't fit in the rule.  Render a block of rules to do the
 		// negated match.
@@ -493,6 +500,19 @@ func (sod srcOrDst) MatchIPPortIPSet(m generictables.MatchCriteria, setID string
 	return nil
 }
 
+// splitPortList splits the port list into groups that can each be matched by a single rule built
+// with the given match.  nftables has no limit on the number of ports in an anonymous set so, for
+// an nftables match, all the ports are returned in a single group.
+func splitPortList(match generictables.MatchCriteria, ports []*proto.PortRange) [][]*proto.PortRange {
+	if _, ok := match.(nftables.NFTMatchCriteria); !ok {
+		return SplitPortList(ports)
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	return [][]*proto.PortRange{ports}
+}
+
 // SplitPortList splits the input list of ports into groups containing up to 15 port numbers.
 // If the input list is empty, it returns an empty slice.
 //
@@ -582,9 +602,11 @@ func (r *DefaultRuleRenderer) CalculateRuleMatch(pRule *proto.Rule, ipVersion ui
 	if len(pRule.SrcNet) == 1 {
 		logCxt.WithField("cidr", pRule.SrcNet[0]).Debug("Adding src CIDR match")
 		match = match.SourceNet(pRule.SrcNet[0])
+	} else if m, ok := match.(nftables.NFTMatchCriteria); ok && len(pRule.SrcNet) > 1 {
+		logCxt.WithField("cidrs", pRule.SrcNet).Debug("Adding src CIDR set match")
+		match = m.SourceNets(pRule.SrcNet)
 	} else if len(pRule.SrcNet) > 1 {
-		log.WithField("rule", pRule).Panic(
-			"CalculateRuleMatch() passed more than one CIDR in SrcNet.")
+		log.WithField("rule", pRule).Panic("CalculateRuleMatch() passed more than one CIDR in SrcNet.")
 	}
 
 	nameForIPSet := func(ipsetID string) string {
@@ -627,9 +649,11 @@ func (r *DefaultRuleRenderer) CalculateRuleMatch(pRule *proto.Rule, ipVersion ui
 	if len(pRule.DstNet) == 1 {
 		logCxt.WithField("cidr", pRule.DstNet[0]).Debug("Adding dest CIDR match")
 		match = match.DestNet(pRule.DstNet[0])
+	} else if m, ok := match.(nftables.NFTMatchCriteria); ok && len(pRule.DstNet) > 1 {
+		logCxt.WithField("cidrs", pRule.DstNet).Debug("Adding dst CIDR set match")
+		match = m.DestNets(pRule.DstNet)
 	} else if len(pRule.DstNet) > 1 {
-		log.WithField("rule", pRule).Panic(
-			"CalculateRuleMatch() passed more than one CIDR in DstNet.")
+		log.WithField("rule", pRule).Panic("CalculateRuleMatch() passed more than one CIDR in DstNet.")
 	}
 
 	for _, ipsetID := range pRule.DstIpSetIds {
@@ -650,6 +674,19 @@ func (r *DefaultRuleRenderer) CalculateRuleMatch(pRule *proto.Rule, ipVersion ui
 		}).Debug("Adding dst IP+port set match")
 	}
 
+	for _, ipsetID := range pRule.SrcIpDstPortIpSetIds {
+		m, ok := match.(nftables.NFTMatchCriteria)
+		if !ok {
+			log.WithField("rule", pRule).Panic("CalculateRuleMatch() passed a src IP, dst port set without nftables.")
+		}
+		ipsetName := nameForIPSet(ipsetID)
+		match = m.SourceIPDestPortSet(ipsetName)
+		logCxt.WithFields(log.Fields{
+			"ipsetID":   ipsetID,
+			"ipSetName": ipsetName,
+		}).Debug("Adding src IP, dst port set match")
+	}
+
 	if len(pRule.DstPorts) > 0 {
 		logCxt.WithFields(log.Fields{
 			"ports": pRule.SrcPorts,
@@ -692,6 +729,12 @@ func (r *DefaultRuleRenderer) CalculateRuleMatch(pRule *proto.Rule, ipVersion ui
 		}
 	}
 
+	if pRule.ConnLimit > 0 {
+		logCxt.WithField("connLimit", pRule.ConnLimit).Debug("Adding connection limit match.")
+		// Key the connection counts on the rule ID so that each rule has its own counts.
+		match = match.ConnLimitAbove(pRule.RuleId, uint32(pRule.ConnLimit))
+	}
+
 	// Now, the negated versions.
 
 	if pRule.NotProtocol != nil {
@@ -708,6 +751,9 @@ func (r *DefaultRuleRenderer) CalculateRuleMatch(pRule *proto.Rule, ipVersion ui
 	if len(pRule.NotSrcNet) == 1 {
 		logCxt.WithField("cidr", pRule.NotSrcNet[0]).Debug("Adding !src CIDR match")
 		match = match.NotSourceNet(pRule.NotSrcNet[0])
+	} else if m, ok := match.(nftables.NFTMatchCriteria); ok && len(pRule.NotSrcNet) > 1 {
+		logCxt.WithField("cidrs", pRule.NotSrcNet).Debug("Adding !src CIDR set match")
+		match = m.NotSourceNets(pRule.NotSrcNet)
 	} else if len(pRule.NotSrcNet) > 1 {
 		log.WithField("rule", pRule).Panic("CalculateRuleMatch() passed more than one CIDR in NotSrcNet.")
 	}
@@ -725,7 +771,7 @@ func (r *DefaultRuleRenderer) CalculateRuleMatch(pRule *proto.Rule, ipVersion ui
 		logCxt.WithFields(log.Fields{
 			"ports": pRule.NotSrcPorts,
 		}).Debug("Adding src port match")
-		for _, portSplit := range SplitPortList(pRule.NotSrcPorts) {
+		for _, portSplit := range splitPortList(match, pRule.NotSrcPorts) {
 			match = match.NotSourcePortRanges(portSplit)
 		}
 	}
@@ -742,6 +788,9 @@ func (r *DefaultRuleRenderer) CalculateRuleMatch(pRule *proto.Rule, ipVersion ui
 	if len(pRule.NotDstNet) == 1 {
 		logCxt.WithField("cidr", pRule.NotDstNet[0]).Debug("Adding !dst CIDR match")
 		match = match.NotDestNet(pRule.NotDstNet[0])
+	} else if m, ok := match.(nftables.NFTMatchCriteria); ok && len(pRule.NotDstNet) > 1 {
+		logCxt.WithField("cidrs", pRule.NotDstNet).Debug("Adding !dst CIDR set match")
+		match = m.NotDestNets(pRule.NotDstNet)
 	} else if len(pRule.NotDstNet) > 1 {
 		log.WithField("rule", pRule).Panic("CalculateRuleMatch() passed more than one CIDR in NotDstNet.")
 	}
@@ -759,7 +808,7 @@ func (r *DefaultRuleRenderer) CalculateRuleMatch(pRule *proto.Rule, ipVersion ui
 		logCxt.WithFields(log.Fields{
 			"ports": pRule.NotSrcPorts,
 		}).Debug("Adding dst port match")
-		for _, portSplit := range SplitPortList(pRule.NotDstPorts) {
+		for _, portSplit := range splitPortList(match, pRule.NotDstPorts) {
 			match = match.NotDestPortRanges(portSplit)
 		}
 	}
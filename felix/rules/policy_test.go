@@ -71,6 +71,9 @@ var ruleTestData = []TableEntry{
 	Entry("ICMP with code", 6,
 		proto.Rule{Icmp: &proto.Rule_IcmpTypeCode{IcmpTypeCode: &proto.IcmpTypeAndCode{Type: 10, Code: 12}}},
 		"-m icmp6 --icmpv6-type 10/12"),
+	Entry("Connection limit", 4,
+		proto.Rule{ConnLimit: 10},
+		"-m connlimit --connlimit-above 10 --connlimit-saddr"),
 
 	Entry("Dest net", 4,
 		proto.Rule{DstNet: []string{"10.0.0.0/16"}},
@@ -1043,6 +1046,80 @@ var _ = DescribeTable("Port split tests",
 	}}),
 )
 
+var _ = Describe("nftables rule rendering", func() {
+	var renderer RuleRenderer
+	BeforeEach(func() {
+		renderer = NewRenderer(Config{
+			NFTables:             true,
+			IPSetConfigV4:        ipsets.NewIPVersionConfig(ipsets.IPFamilyV4, "cali", nil, nil),
+			IPSetConfigV6:        ipsets.NewIPVersionConfig(ipsets.IPFamilyV6, "cali", nil, nil),
+			IptablesMarkAccept:   0x80,
+			IptablesMarkPass:     0x100,
+			IptablesMarkScratch0: 0x200,
+			IptablesMarkScratch1: 0x400,
+			IptablesMarkEndpoint: 0xff000,
+			IptablesLogPrefix:    "calico-packet",
+		})
+	})
+
+	It("should collapse CIDR matches into set lookups", func() {
+		rules := renderer.ProtoRuleToIptablesRules(&proto.Rule{
+			SrcNet:    []string{"10.0.0.0/24", "11.0.0.0/24"},
+			NotSrcNet: []string{"10.0.0.1"},
+			DstNet:    []string{"12.0.0.0/24"},
+			NotDstNet: []string{"12.0.0.1", "12.0.0.2"},
+		}, 4)
+		Expect(rules).To(HaveLen(2))
+		Expect(rules[0].Match.Render()).To(Equal(
+			"ip saddr { 10.0.0.0/24, 11.0.0.0/24 } ip daddr 12.0.0.0/24 " +
+				"ip saddr != 10.0.0.1 ip daddr != { 12.0.0.1, 12.0.0.2 }"))
+	})
+
+	It("should render more than 15 ports in a single rule", func() {
+		var ports []*proto.PortRange
+		for i := int32(1); i <= 20; i++ {
+			ports = append(ports, &proto.PortRange{First: i, Last: i})
+		}
+		rules := renderer.ProtoRuleToIptablesRules(&proto.Rule{
+			Protocol:    &proto.Protocol{NumberOrName: &proto.Protocol_Name{Name: "tcp"}},
+			DstPorts:    ports,
+			NotSrcPorts: ports,
+		}, 4)
+		Expect(rules).To(HaveLen(2))
+		Expect(rules[0].Match.Render()).To(Equal(
+			"meta l4proto tcp tcp dport { 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20 } " +
+				"tcp sport != { 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20 }"))
+	})
+
+	It("should still use a match block to combine named and numeric ports", func() {
+		rules := renderer.ProtoRuleToIptablesRules(&proto.Rule{
+			Protocol:             &proto.Protocol{NumberOrName: &proto.Protocol_Name{Name: "tcp"}},
+			DstPorts:             []*proto.PortRange{{First: 80, Last: 80}},
+			DstNamedPortIpSetIds: []string{"named-port"},
+		}, 4)
+		var matches []string
+		for _, r := range rules {
+			if r.Match != nil {
+				matches = append(matches, r.Match.Render())
+			}
+		}
+		Expect(matches).To(ContainElements(
+			"meta l4proto tcp tcp dport { 80 }",
+			"ip daddr . meta l4proto . th dport @cali40named-port",
+		))
+	})
+
+	It("should match a source selector and destination ports with one set lookup", func() {
+		rules := renderer.ProtoRuleToIptablesRules(&proto.Rule{
+			Protocol:             &proto.Protocol{NumberOrName: &proto.Protocol_Name{Name: "tcp"}},
+			SrcIpDstPortIpSetIds: []string{"src-and-ports"},
+		}, 4)
+		Expect(rules).To(HaveLen(2))
+		Expect(rules[0].Match.Render()).To(Equal(
+			"meta l4proto tcp ip saddr . meta l4proto . th dport @cali40src-and-ports"))
+	})
+})
+
 var _ = Describe("rule metadata tests", func() {
 	rule := &proto.Rule{
 		Metadata: &proto.RuleMetadata{Annotations: map[string]string{
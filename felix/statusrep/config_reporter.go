@@ -0,0 +1,122 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusrep
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcalico/calico/felix/config"
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+const configReportRetryInterval = 10 * time.Second
+
+// ConfigReporter writes the configuration that Felix is running with, and the source of each
+// parameter, to the FelixConfigReport with the same name as this host.
+type ConfigReporter struct {
+	hostname      string
+	datastore     configDatastore
+	updates       chan []libapiv3.FelixConfigParameter
+	retryInterval time.Duration
+}
+
+// configDatastore is the part of the backend client API that the ConfigReporter needs.
+type configDatastore interface {
+	Apply(ctx context.Context, object *model.KVPair) (*model.KVPair, error)
+}
+
+func NewConfigReporter(hostname string, datastore configDatastore) *ConfigReporter {
+	return &ConfigReporter{
+		hostname:      hostname,
+		datastore:     datastore,
+		updates:       make(chan []libapiv3.FelixConfigParameter, 1),
+		retryInterval: configReportRetryInterval,
+	}
+}
+
+// OnConfigUpdate queues a report of the given config, replacing any report that hasn't been
+// written yet.
+func (r *ConfigReporter) OnConfigUpdate(cfg *config.Config) {
+	params := ConfigParameters(cfg)
+	select {
+	case <-r.updates:
+	default:
+	}
+	r.updates <- params
+}
+
+// ConfigParameters returns the parameters of cfg that aren't set to their defaults, with their
+// sources, sorted by name.
+func ConfigParameters(cfg *config.Config) []libapiv3.FelixConfigParameter {
+	sources := cfg.RawValueSources()
+	var params []libapiv3.FelixConfigParameter
+	for name, value := range cfg.RawValues() {
+		params = append(params, libapiv3.FelixConfigParameter{
+			Name:   name,
+			Value:  value,
+			Source: sources[name].String(),
+		})
+	}
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Name < params[j].Name
+	})
+	return params
+}
+
+func (r *ConfigReporter) Start() {
+	go r.loop()
+}
+
+func (r *ConfigReporter) loop() {
+	var retryC <-chan time.Time
+	var pending []libapiv3.FelixConfigParameter
+	for {
+		select {
+		case pending = <-r.updates:
+		case <-retryC:
+		}
+		retryC = nil
+		if err := r.write(pending); err != nil {
+			log.WithError(err).Warn("Failed to write Felix config report; will retry")
+			retryC = time.After(r.retryInterval)
+		}
+	}
+}
+
+func (r *ConfigReporter) write(params []libapiv3.FelixConfigParameter) error {
+	report := libapiv3.NewFelixConfigReport()
+	report.Name = r.hostname
+	report.Spec = libapiv3.FelixConfigReportSpec{
+		Node:       r.hostname,
+		Time:       metav1.Now(),
+		Parameters: params,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.datastore.Apply(ctx, &model.KVPair{
+		Key:   model.ResourceKey{Kind: libapiv3.KindFelixConfigReport, Name: r.hostname},
+		Value: report,
+	})
+	if err == nil {
+		log.WithField("numParams", len(params)).Info("Wrote Felix config report")
+	}
+	return err
+}
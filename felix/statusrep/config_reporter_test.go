@@ -0,0 +1,87 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusrep
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/config"
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+var _ = Describe("Felix config reporter", func() {
+	var datastore *mockDatastore
+	var reporter *ConfigReporter
+	var cfg *config.Config
+	reportKey := model.ResourceKey{Kind: libapiv3.KindFelixConfigReport, Name: hostname}
+
+	reportedParams := func() []libapiv3.FelixConfigParameter {
+		report, ok := datastore.snapshot()[reportKey].(libapiv3.FelixConfigReport)
+		if !ok {
+			return nil
+		}
+		return report.Spec.Parameters
+	}
+
+	BeforeEach(func() {
+		datastore = newMockDatastore()
+		reporter = NewConfigReporter(hostname, datastore)
+		reporter.retryInterval = 10 * time.Millisecond
+
+		cfg = config.New()
+		_, err := cfg.UpdateFrom(map[string]string{
+			"LogSeverityScreen": "Debug",
+			"InterfacePrefix":   "cali",
+		}, config.DatastoreGlobal)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cfg.UpdateFrom(map[string]string{"InterfacePrefix": "tap"}, config.DatastoreNodeSelector)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cfg.UpdateFrom(map[string]string{"LogSeverityScreen": "Info"}, config.EnvironmentVariable)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should report each parameter with its source", func() {
+		reporter.Start()
+		reporter.OnConfigUpdate(cfg)
+		Eventually(reportedParams).Should(Equal([]libapiv3.FelixConfigParameter{
+			{Name: "InterfacePrefix", Value: "tap", Source: "datastore (node selector)"},
+			{Name: "LogSeverityScreen", Value: "Info", Source: "environment variable"},
+		}))
+		report := datastore.snapshot()[reportKey].(libapiv3.FelixConfigReport)
+		Expect(report.Spec.Node).To(Equal(hostname))
+	})
+
+	It("should retry a failed write", func() {
+		datastore.ApplyErrs = []error{errors.New("dummy error")}
+		reporter.Start()
+		reporter.OnConfigUpdate(cfg)
+		Eventually(reportedParams).Should(HaveLen(2))
+	})
+
+	It("should report the latest config", func() {
+		reporter.OnConfigUpdate(cfg)
+		_, err := cfg.UpdateFrom(map[string]string{"InterfacePrefix": "eni"}, config.DatastorePerHost)
+		Expect(err).NotTo(HaveOccurred())
+		reporter.OnConfigUpdate(cfg)
+		reporter.Start()
+		Eventually(reportedParams).Should(ContainElement(
+			libapiv3.FelixConfigParameter{Name: "InterfacePrefix", Value: "eni", Source: "datastore (per-host)"}))
+	})
+})
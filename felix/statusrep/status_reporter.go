@@ -34,14 +34,18 @@ type EndpointStatusReporter struct {
 	stop               chan bool
 	datastore          datastore
 	epStatusIDToStatus map[model.Key]string
-	queuedDirtyIDs     set.Set[model.Key]
-	activeDirtyIDs     set.Set[model.Key]
-	reportingDelay     time.Duration
-	resyncInterval     time.Duration
-	resyncTicker       stoppable
-	resyncTickerC      <-chan time.Time
-	rateLimitTicker    stoppable
-	rateLimitTickerC   <-chan time.Time
+	// policyStatusIDToStatus holds the status of each policy, keyed on its PolicyStatusKey.
+	// Policy statuses carry more than a status string so they're tracked separately from the
+	// endpoint statuses, but they share the dirty sets.
+	policyStatusIDToStatus map[model.Key]model.PolicyStatus
+	queuedDirtyIDs         set.Set[model.Key]
+	activeDirtyIDs         set.Set[model.Key]
+	reportingDelay         time.Duration
+	resyncInterval         time.Duration
+	resyncTicker           stoppable
+	resyncTickerC          <-chan time.Time
+	rateLimitTicker        stoppable
+	rateLimitTickerC       <-chan time.Time
 }
 
 func NewEndpointStatusReporter(hostname string,
@@ -81,20 +85,21 @@ func newEndpointStatusReporterWithTickerChans(hostname string,
 	reportingDelay time.Duration,
 	resyncInterval time.Duration) *EndpointStatusReporter {
 	return &EndpointStatusReporter{
-		hostname:           hostname,
-		region:             region,
-		endpointUpdates:    endpointUpdates,
-		datastore:          datastore,
-		stop:               make(chan bool),
-		epStatusIDToStatus: make(map[model.Key]string),
-		queuedDirtyIDs:     set.New[model.Key](),
-		activeDirtyIDs:     set.New[model.Key](),
-		resyncTicker:       resyncTicker,
-		resyncTickerC:      resyncTickerChan,
-		rateLimitTicker:    rateLimitTicker,
-		rateLimitTickerC:   rateLimitTickerChan,
-		reportingDelay:     reportingDelay,
-		resyncInterval:     resyncInterval,
+		hostname:               hostname,
+		region:                 region,
+		endpointUpdates:        endpointUpdates,
+		datastore:              datastore,
+		stop:                   make(chan bool),
+		epStatusIDToStatus:     make(map[model.Key]string),
+		policyStatusIDToStatus: make(map[model.Key]model.PolicyStatus),
+		queuedDirtyIDs:         set.New[model.Key](),
+		activeDirtyIDs:         set.New[model.Key](),
+		resyncTicker:           resyncTicker,
+		resyncTickerC:          resyncTickerChan,
+		rateLimitTicker:        rateLimitTicker,
+		rateLimitTickerC:       rateLimitTickerChan,
+		reportingDelay:         reportingDelay,
+		resyncInterval:         resyncInterval,
 	}
 }
 
@@ -176,6 +181,16 @@ loop:
 					Hostname:   esr.hostname,
 					EndpointID: msg.Id.EndpointId,
 				}
+			case *proto.PolicyStatusUpdate:
+				esr.updatePolicyStatus(esr.policyStatusKey(msg.Id), model.PolicyStatus{
+					Status:      msg.Status.Status,
+					ContentHash: msg.Status.ContentHash,
+					Errors:      int(msg.Status.Errors),
+				})
+				break selectUpdates
+			case *proto.PolicyStatusRemove:
+				esr.updatePolicyStatus(esr.policyStatusKey(msg.Id), model.PolicyStatus{})
+				break selectUpdates
 			case *proto.DataplaneInSync:
 				datamodelInSync = true
 				break selectUpdates
@@ -188,13 +203,7 @@ loop:
 				} else {
 					delete(esr.epStatusIDToStatus, statID)
 				}
-				if !esr.activeDirtyIDs.Contains(statID) &&
-					!esr.queuedDirtyIDs.Contains(statID) {
-					// Add the update into the queued set so that
-					// we delay its initial update.  That prevent
-					// flapping at start of day.
-					esr.queuedDirtyIDs.Add(statID)
-				}
+				esr.markDirty(statID)
 			}
 		}
 
@@ -223,9 +232,20 @@ loop:
 				// Then try to write the update to the datastore.
 				// Note: the update could be a deletion, in which case
 				// the read from the cache will return nil.
-				err := esr.writeEndpointStatus(ctx, statID,
-					esr.epStatusIDToStatus[statID])
-				if err != nil {
+				var err error
+				if _, ok := statID.(model.PolicyStatusKey); ok {
+					err = esr.writePolicyStatus(ctx, statID,
+						esr.policyStatusIDToStatus[statID])
+				} else {
+					err = esr.writeEndpointStatus(ctx, statID,
+						esr.epStatusIDToStatus[statID])
+				}
+				if _, ok := err.(errors.ErrorOperationNotSupported); ok {
+					// The Kubernetes datastore only stores policy statuses, drop
+					// the endpoint status rather than retrying it forever.
+					log.WithField("statID", statID).Debug("Datastore doesn't support status")
+					esr.activeDirtyIDs.Discard(statID)
+				} else if err != nil {
 					log.WithError(err).Warn(
 						"Failed to write endpoint status; is datastore up?")
 				} else {
@@ -251,6 +271,39 @@ loop:
 	}
 }
 
+func (esr *EndpointStatusReporter) policyStatusKey(id *proto.PolicyID) model.PolicyStatusKey {
+	return model.PolicyStatusKey{
+		Hostname:     esr.hostname,
+		Tier:         id.Tier,
+		Name:         id.Name,
+		RegionString: model.RegionString(esr.region),
+	}
+}
+
+// updatePolicyStatus updates the cached status of a policy; a zero status means that the
+// policy's status should be removed.
+func (esr *EndpointStatusReporter) updatePolicyStatus(statID model.Key, status model.PolicyStatus) {
+	if esr.policyStatusIDToStatus[statID] == status {
+		return
+	}
+	if status.Status != "" {
+		esr.policyStatusIDToStatus[statID] = status
+	} else {
+		delete(esr.policyStatusIDToStatus, statID)
+	}
+	esr.markDirty(statID)
+}
+
+func (esr *EndpointStatusReporter) markDirty(statID model.Key) {
+	if !esr.activeDirtyIDs.Contains(statID) &&
+		!esr.queuedDirtyIDs.Contains(statID) {
+		// Add the update into the queued set so that
+		// we delay its initial update.  That prevent
+		// flapping at start of day.
+		esr.queuedDirtyIDs.Add(statID)
+	}
+}
+
 func (esr *EndpointStatusReporter) attemptResync(ctx context.Context) {
 	var kvs []*model.KVPair
 
@@ -261,6 +314,9 @@ func (esr *EndpointStatusReporter) attemptResync(ctx context.Context) {
 	kvl, err := esr.datastore.List(ctx, wlListOpts, "")
 	if err == nil {
 		kvs = kvl.KVPairs
+	} else if _, ok := err.(errors.ErrorOperationNotSupported); ok {
+		log.Debug("Datastore doesn't support workload endpoint statuses")
+		kvs = nil
 	} else {
 		log.WithError(err).Errorf("Failed to load workload endpoint statuses")
 		kvs = nil // Skip the following loop and try host endpoints.
@@ -288,6 +344,9 @@ func (esr *EndpointStatusReporter) attemptResync(ctx context.Context) {
 	kvl, err = esr.datastore.List(ctx, hostListOpts, "")
 	if err == nil {
 		kvs = kvl.KVPairs
+	} else if _, ok := err.(errors.ErrorOperationNotSupported); ok {
+		log.Debug("Datastore doesn't support host endpoint statuses")
+		kvs = nil
 	} else {
 		log.WithError(err).Error("Failed to load host endpoint statuses")
 		kvs = nil // Make sure we skip the following loop.
@@ -308,6 +367,34 @@ func (esr *EndpointStatusReporter) attemptResync(ctx context.Context) {
 			}
 		}
 	}
+
+	policyListOpts := model.PolicyStatusListOptions{
+		Hostname:     esr.hostname,
+		RegionString: model.RegionString(esr.region),
+	}
+	kvl, err = esr.datastore.List(ctx, policyListOpts, "")
+	if err == nil {
+		kvs = kvl.KVPairs
+	} else {
+		log.WithError(err).Error("Failed to load policy statuses")
+		kvs = nil // Make sure we skip the following loop.
+	}
+	for _, kv := range kvs {
+		if kv.Value == nil {
+			// Parse error, needs refresh.
+			esr.activeDirtyIDs.Add(kv.Key)
+		} else {
+			status := *kv.Value.(*model.PolicyStatus)
+			if status != esr.policyStatusIDToStatus[kv.Key] {
+				log.WithFields(log.Fields{
+					"key":            kv.Key,
+					"datastoreState": status,
+					"desiredState":   esr.policyStatusIDToStatus[kv.Key],
+				}).Info("Found out-of-sync policy status")
+				esr.activeDirtyIDs.Add(kv.Key)
+			}
+		}
+	}
 }
 
 func (esr *EndpointStatusReporter) writeEndpointStatus(ctx context.Context, epID model.Key, status string) (err error) {
@@ -338,6 +425,27 @@ func (esr *EndpointStatusReporter) writeEndpointStatus(ctx context.Context, epID
 	return
 }
 
+func (esr *EndpointStatusReporter) writePolicyStatus(ctx context.Context, statID model.Key, status model.PolicyStatus) (err error) {
+	logCxt := log.WithFields(log.Fields{
+		"newStatus": status,
+		"policyID":  statID,
+	})
+	if status.Status != "" {
+		logCxt.Info("Writing policy status")
+		applyCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		_, err = esr.datastore.Apply(applyCtx, &model.KVPair{Key: statID, Value: &status})
+		cancel()
+	} else {
+		logCxt.Info("Deleting policy status")
+		_, err = esr.datastore.Delete(ctx, statID, "")
+		if _, ok := err.(errors.ErrorResourceDoesNotExist); ok {
+			// Ignore nonexistent resource.
+			err = nil
+		}
+	}
+	return
+}
+
 func (esr *EndpointStatusReporter) Stop() {
 	log.Info("Stopping endpoint status reporter")
 	esr.stop <- true
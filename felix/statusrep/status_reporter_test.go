@@ -125,6 +125,39 @@ var updatedHostEPKey = model.HostEndpointStatusKey{
 	EndpointID: "updatedEP",
 }
 
+var protoPolicyID = proto.PolicyID{
+	Tier: "default",
+	Name: "default.updated-policy",
+}
+var policyProgrammed = model.PolicyStatus{
+	Status:      model.PolicyStatusProgrammed,
+	ContentHash: "hash2",
+}
+var policyUpdateProgrammed = proto.PolicyStatusUpdate{
+	Id: &protoPolicyID,
+	Status: &proto.PolicyStatus{
+		Status:      model.PolicyStatusProgrammed,
+		ContentHash: "hash2",
+	},
+}
+var policyUpdateError = proto.PolicyStatusUpdate{
+	Id: &protoPolicyID,
+	Status: &proto.PolicyStatus{
+		Status:      model.PolicyStatusError,
+		ContentHash: "hash1",
+		Errors:      1,
+	},
+}
+var policyRemove = proto.PolicyStatusRemove{
+	Id: &protoPolicyID,
+}
+var updatedPolicyKey = model.PolicyStatusKey{
+	Hostname:     hostname,
+	Tier:         "default",
+	Name:         "default.updated-policy",
+	RegionString: "no-region",
+}
+
 var _ = Describe("Status", func() {
 	var esr *EndpointStatusReporter
 	var epUpdates chan interface{}
@@ -226,6 +259,27 @@ var _ = Describe("Status", func() {
 				Eventually(datastore.snapshot).Should(BeEmpty())
 			})
 
+			It("should coalesce flapping policy updates", func() {
+				epUpdates <- &policyUpdateError
+				epUpdates <- &policyUpdateProgrammed
+				epUpdates <- &policyUpdateError
+				epUpdates <- &policyUpdateProgrammed
+				rateLimitTickerChan <- time.Now()
+				rateLimitTickerChan <- time.Now()
+				Eventually(datastore.snapshot).Should(Equal(map[model.Key]interface{}{
+					updatedPolicyKey: policyProgrammed,
+				}))
+			})
+			It("should coalesce flapping policy create/deletes", func() {
+				epUpdates <- &policyUpdateProgrammed
+				epUpdates <- &policyRemove
+				epUpdates <- &policyUpdateError
+				epUpdates <- &policyRemove
+				rateLimitTickerChan <- time.Now()
+				rateLimitTickerChan <- time.Now()
+				Eventually(datastore.snapshot).Should(BeEmpty())
+			})
+
 			Describe("with an error on the first 2 Apply() calls", func() {
 				JustBeforeEach(func() {
 					datastore.ApplyErrs = []error{
@@ -477,6 +531,7 @@ type mockDatastore struct {
 	mutex                           sync.Mutex
 	kvs                             map[model.Key]interface{}
 	workloadsListed, hostsListed    bool
+	policiesListed                  bool
 	ListErrs, ApplyErrs, DeleteErrs []error
 	numDeletes                      int
 }
@@ -543,6 +598,9 @@ func (d *mockDatastore) List(ctx context.Context, list model.ListInterface, revi
 	case model.HostEndpointStatusListOptions:
 		d.hostsListed = true
 		Expect(list.Hostname).To(Equal("localhostname"))
+	case model.PolicyStatusListOptions:
+		d.policiesListed = true
+		Expect(list.Hostname).To(Equal("localhostname"))
 	default:
 		log.Panicf("Unexpected list type: %#v", list)
 	}
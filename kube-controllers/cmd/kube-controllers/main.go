@@ -38,10 +38,13 @@ import (
 	"github.com/projectcalico/calico/kube-controllers/pkg/config"
 	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/controller"
 	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/flannelmigration"
+	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/ippoolmigration"
 	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/namespace"
 	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/networkpolicy"
 	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/node"
 	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/pod"
+	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/policystatus"
+	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/routereflector"
 	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/serviceaccount"
 	"github.com/projectcalico/calico/kube-controllers/pkg/status"
 	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
@@ -183,6 +186,11 @@ func main() {
 		// any subsequent changes trigger a restart
 		controllerCtrl.restart = cCtrlr.ConfigChan()
 		controllerCtrl.InitControllers(ctx, runCfg, k8sClientset, calicoClient)
+
+		if runCfg.Controllers.Policy != nil {
+			policyStatusController := policystatus.NewPolicyStatusController(ctx, calicoClient, *runCfg.Controllers.Policy)
+			controllerCtrl.controllers["PolicyStatus"] = policyStatusController
+		}
 	}
 
 	if cfg.DatastoreType == "etcdv3" {
@@ -449,11 +457,20 @@ func (cc *controllerControl) InitControllers(ctx context.Context, cfg config.Run
 		nodeController := node.NewNodeController(ctx, k8sClientset, calicoClient, *cfg.Controllers.Node, nodeInformer, podInformer)
 		cc.controllers["Node"] = nodeController
 		cc.registerInformers(podInformer, nodeInformer)
+
+		// IP pool migrations are part of managing IPAM, so run alongside the node controller.
+		ipPoolMigrationController := ippoolmigration.NewIPPoolMigrationController(ctx, k8sClientset, calicoClient)
+		cc.controllers["IPPoolMigration"] = ipPoolMigrationController
 	}
 	if cfg.Controllers.ServiceAccount != nil {
 		serviceAccountController := serviceaccount.NewServiceAccountController(ctx, k8sClientset, calicoClient, *cfg.Controllers.ServiceAccount)
 		cc.controllers["ServiceAccount"] = serviceAccountController
 	}
+	if cfg.Controllers.RouteReflector != nil {
+		routeReflectorController := routereflector.NewRouteReflectorController(ctx, calicoClient, *cfg.Controllers.RouteReflector, nodeInformer)
+		cc.controllers["RouteReflector"] = routeReflectorController
+		cc.registerInformers(nodeInformer)
+	}
 }
 
 // registerInformers registers the given informers, if not already registered. Registered informers
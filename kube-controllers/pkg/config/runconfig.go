@@ -90,6 +90,7 @@ type ControllersConfig struct {
 	WorkloadEndpoint *GenericControllerConfig
 	ServiceAccount   *GenericControllerConfig
 	Namespace        *GenericControllerConfig
+	RouteReflector   *RouteReflectorControllerConfig
 }
 
 type GenericControllerConfig struct {
@@ -108,8 +109,56 @@ type NodeControllerConfig struct {
 	// The grace period used by the controller to determine if an IP address is leaked.
 	// Set to 0 to disable IP address garbage collection.
 	LeakGracePeriod *v1.Duration
+
+	// Configuration for periodic defragmentation of IPAM blocks.  Nil if defragmentation
+	// is disabled.
+	IPAMDefrag *IPAMDefragConfig
+
+	// Retention limits for the IPAM event log.  Nil if not configured, in which case the
+	// default limits apply.
+	IPAMEventRetention *IPAMEventRetentionConfig
+}
+
+type IPAMDefragConfig struct {
+	// The period between defragmentation passes.
+	Interval time.Duration
+
+	// The fraction of a block's addresses that its node must be using to keep the block.
+	SparseThreshold float64
+
+	// Whether pods that have borrowed addresses from released blocks are evicted.
+	ConsolidateBorrowed bool
+}
+
+type RouteReflectorControllerConfig struct {
+	ReconcilerPeriod time.Duration
+
+	// The node label that divides the nodes into zones.
+	ZoneLabel string
+
+	// The number of route reflectors to elect in each zone.
+	ReflectorsPerZone int
+
+	// Selects the nodes that may be elected as route reflectors.
+	NodeSelector string
+
+	// The cluster ID of the first zone's route reflectors.
+	ClusterIDBase string
+}
+
+type IPAMEventRetentionConfig struct {
+	// How long IPAM events are kept for.
+	MaxAge time.Duration
+
+	// The maximum number of IPAM events that are kept.
+	MaxEvents int
 }
 
+const (
+	DefaultIPAMEventMaxAge    = 7 * 24 * time.Hour
+	DefaultIPAMEventMaxEvents = 100000
+)
+
 type RunConfigController struct {
 	out chan RunConfig
 }
@@ -310,6 +359,10 @@ func mergeConfig(envVars map[string]string, envCfg Config, apiCfg v3.KubeControl
 
 	mergeEnabledControllers(envVars, &status, &rCfg, apiCfg)
 
+	if rc.RouteReflector != nil {
+		mergeRouteReflector(&status, &rCfg, apiCfg)
+	}
+
 	mergeReconcilerPeriod(envVars, &status, &rCfg)
 
 	mergeCompactionPeriod(envVars, &status, &rCfg, apiCfg)
@@ -334,6 +387,9 @@ func mergeConfig(envVars map[string]string, envCfg Config, apiCfg v3.KubeControl
 		if apiCfg.Controllers.Node != nil {
 			rc.Node.LeakGracePeriod = apiCfg.Controllers.Node.LeakGracePeriod
 			status.RunningConfig.Controllers.Node.LeakGracePeriod = apiCfg.Controllers.Node.LeakGracePeriod
+
+			mergeIPAMDefrag(&status, &rCfg, apiCfg)
+			mergeIPAMEventRetention(&status, &rCfg, apiCfg)
 		}
 
 		if envCfg.DatastoreType != "kubernetes" {
@@ -387,6 +443,106 @@ func mergeAutoHostEndpoints(envVars map[string]string, status *v3.KubeController
 	}
 }
 
+func mergeIPAMDefrag(status *v3.KubeControllersConfigurationStatus, rCfg *RunConfig, apiCfg v3.KubeControllersConfigurationSpec) {
+	// make these names shorter
+	rc := &rCfg.Controllers
+	ac := &apiCfg.Controllers
+	sc := &status.RunningConfig.Controllers
+
+	// There is no env var config for this, so only merge from the API config.
+	if ac.Node.IPAMDefrag == nil {
+		return
+	}
+	interval := time.Hour
+	if ac.Node.IPAMDefrag.Interval != nil && ac.Node.IPAMDefrag.Interval.Duration > 0 {
+		interval = ac.Node.IPAMDefrag.Interval.Duration
+	}
+	threshold := 25
+	if ac.Node.IPAMDefrag.SparseThresholdPercent != nil {
+		threshold = *ac.Node.IPAMDefrag.SparseThresholdPercent
+	}
+	consolidate := ac.Node.IPAMDefrag.ConsolidateBorrowed
+	if consolidate == "" {
+		consolidate = "Disabled"
+	}
+	rc.Node.IPAMDefrag = &IPAMDefragConfig{
+		Interval:            interval,
+		SparseThreshold:     float64(threshold) / 100,
+		ConsolidateBorrowed: consolidate == "Enabled",
+	}
+	sc.Node.IPAMDefrag = &v3.IPAMDefragConfig{
+		Interval:               &v1.Duration{Duration: interval},
+		SparseThresholdPercent: &threshold,
+		ConsolidateBorrowed:    consolidate,
+	}
+}
+
+func mergeRouteReflector(status *v3.KubeControllersConfigurationStatus, rCfg *RunConfig, apiCfg v3.KubeControllersConfigurationSpec) {
+	// make these names shorter
+	rc := rCfg.Controllers.RouteReflector
+	sc := status.RunningConfig.Controllers.RouteReflector
+	ac := apiCfg.Controllers.RouteReflector
+	if ac == nil {
+		// Enabled by environment variable, so use the defaults.
+		ac = &v3.RouteReflectorControllerConfig{}
+	}
+
+	rc.ReconcilerPeriod = 30 * time.Second
+	if ac.ReconcilerPeriod != nil && ac.ReconcilerPeriod.Duration > 0 {
+		rc.ReconcilerPeriod = ac.ReconcilerPeriod.Duration
+	}
+	rc.ZoneLabel = "topology.kubernetes.io/zone"
+	if ac.ZoneLabel != "" {
+		rc.ZoneLabel = ac.ZoneLabel
+	}
+	rc.ReflectorsPerZone = 2
+	if ac.ReflectorsPerZone != nil && *ac.ReflectorsPerZone > 0 {
+		rc.ReflectorsPerZone = *ac.ReflectorsPerZone
+	}
+	rc.NodeSelector = "all()"
+	if ac.NodeSelector != "" {
+		rc.NodeSelector = ac.NodeSelector
+	}
+	rc.ClusterIDBase = "244.0.0.1"
+	if ac.ClusterIDBase != "" {
+		rc.ClusterIDBase = ac.ClusterIDBase
+	}
+
+	sc.ReconcilerPeriod = &v1.Duration{Duration: rc.ReconcilerPeriod}
+	sc.ZoneLabel = rc.ZoneLabel
+	sc.ReflectorsPerZone = &rc.ReflectorsPerZone
+	sc.NodeSelector = rc.NodeSelector
+	sc.ClusterIDBase = rc.ClusterIDBase
+}
+
+func mergeIPAMEventRetention(status *v3.KubeControllersConfigurationStatus, rCfg *RunConfig, apiCfg v3.KubeControllersConfigurationSpec) {
+	// make these names shorter
+	rc := &rCfg.Controllers
+	ac := &apiCfg.Controllers
+	sc := &status.RunningConfig.Controllers
+
+	// There is no env var config for this, so only merge from the API config.
+	if ac.Node.IPAMEventRetention == nil {
+		return
+	}
+	maxAge := DefaultIPAMEventMaxAge
+	if ac.Node.IPAMEventRetention.MaxAge != nil && ac.Node.IPAMEventRetention.MaxAge.Duration > 0 {
+		maxAge = ac.Node.IPAMEventRetention.MaxAge.Duration
+	}
+	maxEvents := DefaultIPAMEventMaxEvents
+	if ac.Node.IPAMEventRetention.MaxEvents != nil && *ac.Node.IPAMEventRetention.MaxEvents > 0 {
+		maxEvents = *ac.Node.IPAMEventRetention.MaxEvents
+	}
+	rc.Node.IPAMEventRetention = &IPAMEventRetentionConfig{
+		MaxAge:    maxAge,
+		MaxEvents: maxEvents,
+	}
+	sc.Node.IPAMEventRetention = &v3.IPAMEventRetentionConfig{
+		MaxAge:    &v1.Duration{Duration: maxAge},
+		MaxEvents: &maxEvents,
+	}
+}
+
 func mergeSyncNodeLabels(envVars map[string]string, status *v3.KubeControllersConfigurationStatus, rCfg *RunConfig, apiCfg v3.KubeControllersConfigurationSpec, cfg Config) {
 	// make these names shorter
 	rc := &rCfg.Controllers
@@ -532,6 +688,9 @@ func mergeEnabledControllers(envVars map[string]string, status *v3.KubeControlle
 			case "serviceaccount":
 				rc.ServiceAccount = &GenericControllerConfig{}
 				sc.ServiceAccount = &v3.ServiceAccountControllerConfig{}
+			case "routereflector":
+				rc.RouteReflector = &RouteReflectorControllerConfig{}
+				sc.RouteReflector = &v3.RouteReflectorControllerConfig{}
 			case "flannelmigration":
 				log.WithField(EnvEnabledControllers, v).Fatal("cannot run flannelmigration with other controllers")
 			default:
@@ -570,6 +729,11 @@ func mergeEnabledControllers(envVars map[string]string, status *v3.KubeControlle
 			rc.Namespace = &GenericControllerConfig{}
 			sc.Namespace = &v3.NamespaceControllerConfig{}
 		}
+
+		if ac.RouteReflector != nil {
+			rc.RouteReflector = &RouteReflectorControllerConfig{}
+			sc.RouteReflector = &v3.RouteReflectorControllerConfig{}
+		}
 	}
 
 	// Set reconciler periods, if enabled
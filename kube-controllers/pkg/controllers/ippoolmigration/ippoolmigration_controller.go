@@ -0,0 +1,249 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ippoolmigration
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/controller"
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/migrate"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+const (
+	// checkInterval is how often the controller looks for migrations to perform.
+	checkInterval = 10 * time.Second
+
+	// statusRetries is the number of times a status update is retried after a conflict.
+	statusRetries = 5
+)
+
+// ipPoolMigrationController implements the Controller interface.  It performs the IP pool
+// migrations described by IPPoolMigration resources, one at a time in order of creation, and
+// reports their progress in the resources' status.
+//
+// A migration that is interrupted, for example by the controller restarting, is resumed from where
+// it got to, since the migration works out what is left to do from the datastore.  A migration
+// that fails is not retried; it must be deleted and created again.
+type ipPoolMigrationController struct {
+	ctx          context.Context
+	calicoClient client.Interface
+	k8sClient    kubernetes.Interface
+
+	// running is the name of the migration that is running, cancel stops it, and done is
+	// signalled when it finishes.
+	running string
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewIPPoolMigrationController returns a controller which performs IP pool migrations.
+func NewIPPoolMigrationController(ctx context.Context, k8sClient kubernetes.Interface, c client.Interface) controller.Controller {
+	return &ipPoolMigrationController{
+		ctx:          ctx,
+		calicoClient: c,
+		k8sClient:    k8sClient,
+	}
+}
+
+// Run starts the controller.
+func (c *ipPoolMigrationController) Run(stopCh chan struct{}) {
+	log.Info("Starting IPPoolMigration controller")
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+
+	t := time.NewTicker(checkInterval)
+	defer t.Stop()
+	c.startNextMigration(ctx)
+	for {
+		select {
+		case <-t.C:
+			if c.done == nil {
+				c.startNextMigration(ctx)
+			} else {
+				c.checkRunningMigration(ctx)
+			}
+		case <-c.done:
+			c.done = nil
+			c.cancel()
+			c.startNextMigration(ctx)
+		case <-stopCh:
+			log.Info("Stopping IPPoolMigration controller")
+			cancel()
+			if c.done != nil {
+				// Wait for the running migration to notice, so that it doesn't outlive us.
+				<-c.done
+			}
+			return
+		}
+	}
+}
+
+// startNextMigration starts the oldest migration that hasn't finished, if any, in the background.
+func (c *ipPoolMigrationController) startNextMigration(ctx context.Context) {
+	list, err := c.calicoClient.IPPoolMigrations().List(ctx, options.ListOptions{})
+	if err != nil {
+		log.WithError(err).Warn("Failed to list IP pool migrations")
+		return
+	}
+	m := nextMigration(list.Items)
+	if m == nil {
+		return
+	}
+
+	c.running = m.Name
+	c.done = make(chan struct{})
+	var migrationCtx context.Context
+	migrationCtx, c.cancel = context.WithCancel(ctx)
+	go func(done chan struct{}) {
+		defer close(done)
+		c.runMigration(migrationCtx, m)
+	}(c.done)
+}
+
+// checkRunningMigration stops the running migration if its resource has been deleted.
+func (c *ipPoolMigrationController) checkRunningMigration(ctx context.Context) {
+	_, err := c.calicoClient.IPPoolMigrations().Get(ctx, c.running, options.GetOptions{})
+	if _, ok := err.(cerrors.ErrorResourceDoesNotExist); ok {
+		log.WithField("migration", c.running).Info("IP pool migration deleted, stopping it")
+		c.cancel()
+	}
+}
+
+// nextMigration returns the oldest migration that hasn't finished.
+func nextMigration(migrations []apiv3.IPPoolMigration) *apiv3.IPPoolMigration {
+	var pending []*apiv3.IPPoolMigration
+	for i := range migrations {
+		switch migrations[i].Status.Phase {
+		case apiv3.IPPoolMigrationPhaseCompleted, apiv3.IPPoolMigrationPhaseFailed:
+		default:
+			pending = append(pending, &migrations[i])
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		if !pending[i].CreationTimestamp.Equal(&pending[j].CreationTimestamp) {
+			return pending[i].CreationTimestamp.Before(&pending[j].CreationTimestamp)
+		}
+		return pending[i].Name < pending[j].Name
+	})
+	return pending[0]
+}
+
+// runMigration performs the migration and keeps its status up to date.
+func (c *ipPoolMigrationController) runMigration(ctx context.Context, m *apiv3.IPPoolMigration) {
+	logCtx := log.WithField("migration", m.Name)
+	logCtx.Info("Starting IP pool migration")
+
+	// Pods that were migrated before the migration was interrupted are no longer in the source
+	// pool, so carry their count over.
+	var resumed int
+	if m.Status.Phase == apiv3.IPPoolMigrationPhaseRunning {
+		resumed = m.Status.MigratedPods
+	}
+	c.updateStatus(ctx, m.Name, func(s *apiv3.IPPoolMigrationStatus) {
+		s.Phase = apiv3.IPPoolMigrationPhaseRunning
+		if s.StartTime == nil {
+			now := metav1.Now()
+			s.StartTime = &now
+		}
+	})
+
+	cfg := migrate.Config{From: m.Spec.From, To: m.Spec.To}
+	if m.Spec.BatchSize != nil {
+		cfg.BatchSize = *m.Spec.BatchSize
+	}
+	if m.Spec.PodTimeout != nil {
+		cfg.PodTimeout = m.Spec.PodTimeout.Duration
+	}
+	migrator, err := migrate.New(c.calicoClient, c.k8sClient, cfg)
+	if err == nil {
+		_, err = migrator.Run(ctx, func(p migrate.Progress) {
+			c.updateStatus(ctx, m.Name, func(s *apiv3.IPPoolMigrationStatus) {
+				applyProgress(s, resumed, p)
+			})
+		})
+	}
+	if ctx.Err() != nil {
+		// We're shutting down or the migration has been deleted; leave it to be resumed.
+		logCtx.Info("IP pool migration interrupted")
+		return
+	}
+
+	c.updateStatus(ctx, m.Name, func(s *apiv3.IPPoolMigrationStatus) {
+		finish(s, err)
+	})
+	if err != nil {
+		logCtx.WithError(err).Warn("IP pool migration failed")
+		return
+	}
+	logCtx.Info("IP pool migration completed")
+}
+
+// applyProgress copies the migrator's progress into the status, adding the pods that were migrated
+// before the migration was resumed.
+func applyProgress(s *apiv3.IPPoolMigrationStatus, resumed int, p migrate.Progress) {
+	s.TotalPods = resumed + p.TotalPods
+	s.MigratedPods = resumed + p.MigratedPods
+	s.SkippedPods = p.SkippedPods
+	s.ReleasedBlocks = p.ReleasedBlocks
+	s.Message = p.Message
+}
+
+// finish sets the final phase of the migration.
+func finish(s *apiv3.IPPoolMigrationStatus, err error) {
+	now := metav1.Now()
+	s.CompletionTime = &now
+	if err != nil {
+		s.Phase = apiv3.IPPoolMigrationPhaseFailed
+		s.Message = err.Error()
+		return
+	}
+	s.Phase = apiv3.IPPoolMigrationPhaseCompleted
+}
+
+// updateStatus applies the update to the migration's status, retrying on conflicts with other
+// updates to the resource.
+func (c *ipPoolMigrationController) updateStatus(ctx context.Context, name string, update func(*apiv3.IPPoolMigrationStatus)) {
+	logCtx := log.WithField("migration", name)
+	for i := 0; i < statusRetries; i++ {
+		m, err := c.calicoClient.IPPoolMigrations().Get(ctx, name, options.GetOptions{})
+		if err != nil {
+			logCtx.WithError(err).Warn("Failed to get IP pool migration")
+			return
+		}
+		update(&m.Status)
+		_, err = c.calicoClient.IPPoolMigrations().Update(ctx, m, options.SetOptions{})
+		if _, ok := err.(cerrors.ErrorResourceUpdateConflict); ok {
+			logCtx.Debug("Conflict updating IP pool migration status, retrying")
+			continue
+		} else if err != nil {
+			logCtx.WithError(err).Warn("Failed to update IP pool migration status")
+		}
+		return
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ippoolmigration
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/migrate"
+)
+
+var _ = Describe("IP pool migration status", func() {
+	migration := func(name string, age time.Duration, phase apiv3.IPPoolMigrationPhase) apiv3.IPPoolMigration {
+		return apiv3.IPPoolMigration{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(time.Now().Add(-age))},
+			Status:     apiv3.IPPoolMigrationStatus{Phase: phase},
+		}
+	}
+
+	It("should pick the oldest migration that hasn't finished", func() {
+		Expect(nextMigration(nil)).To(BeNil())
+		Expect(nextMigration([]apiv3.IPPoolMigration{
+			migration("done", time.Hour, apiv3.IPPoolMigrationPhaseCompleted),
+			migration("failed", time.Hour, apiv3.IPPoolMigrationPhaseFailed),
+		})).To(BeNil())
+
+		m := nextMigration([]apiv3.IPPoolMigration{
+			migration("done", 3*time.Hour, apiv3.IPPoolMigrationPhaseCompleted),
+			migration("new", time.Minute, ""),
+			migration("running", time.Hour, apiv3.IPPoolMigrationPhaseRunning),
+		})
+		Expect(m.Name).To(Equal("running"))
+	})
+
+	It("should carry over the pods migrated before a migration was resumed", func() {
+		s := apiv3.IPPoolMigrationStatus{Phase: apiv3.IPPoolMigrationPhaseRunning, TotalPods: 10, MigratedPods: 4}
+		applyProgress(&s, 4, migrate.Progress{TotalPods: 6, MigratedPods: 1, SkippedPods: 1, Message: "Migrated"})
+		Expect(s.TotalPods).To(Equal(10))
+		Expect(s.MigratedPods).To(Equal(5))
+		Expect(s.SkippedPods).To(Equal(1))
+		Expect(s.Message).To(Equal("Migrated"))
+	})
+
+	It("should record the outcome of the migration", func() {
+		s := apiv3.IPPoolMigrationStatus{Phase: apiv3.IPPoolMigrationPhaseRunning, Message: "Migrated 2 pods"}
+		finish(&s, nil)
+		Expect(s.Phase).To(Equal(apiv3.IPPoolMigrationPhaseCompleted))
+		Expect(s.Message).To(Equal("Migrated 2 pods"))
+		Expect(s.CompletionTime).NotTo(BeNil())
+
+		finish(&s, errors.New("timed out"))
+		Expect(s.Phase).To(Equal(apiv3.IPPoolMigrationPhaseFailed))
+		Expect(s.Message).To(Equal("timed out"))
+	})
+})
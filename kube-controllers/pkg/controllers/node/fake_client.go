@@ -109,6 +109,14 @@ func (f *FakeCalicoClient) BGPFilter() clientv3.BGPFilterInterface {
 	panic("not implemented")
 }
 
+func (f *FakeCalicoClient) RoutePolicies() clientv3.RoutePolicyInterface {
+	panic("not implemented")
+}
+
+func (f *FakeCalicoClient) IPPoolMigrations() clientv3.IPPoolMigrationInterface {
+	panic("not implemented")
+}
+
 // BGPPeers returns an interface for managing BGP peer resources.
 func (f *FakeCalicoClient) BGPPeers() clientv3.BGPPeerInterface {
 	panic("not implemented")
@@ -214,6 +222,7 @@ type fakeIPAMClient struct {
 	sync.Mutex
 	affinitiesReleased map[string]bool
 	handlesReleased    map[string]bool
+	strictAffinity     bool
 }
 
 func (f *fakeIPAMClient) affinityReleased(aff string) bool {
@@ -335,7 +344,9 @@ func (f *fakeIPAMClient) ReleasePoolAffinities(ctx context.Context, pool cnet.IP
 // has been set, returns a default configuration with StrictAffinity disabled
 // and AutoAllocateBlocks enabled.
 func (f *fakeIPAMClient) GetIPAMConfig(ctx context.Context) (*ipam.IPAMConfig, error) {
-	panic("not implemented") // TODO: Implement
+	f.Lock()
+	defer f.Unlock()
+	return &ipam.IPAMConfig{StrictAffinity: f.strictAffinity, AutoAllocateBlocks: true}, nil
 }
 
 // SetIPAMConfig sets global IPAM configuration.  This can only
@@ -46,6 +46,7 @@ import (
 	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
 	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
 	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/defrag"
 	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
 )
@@ -65,6 +66,10 @@ var (
 	legacyAllocationsGauge *prometheus.GaugeVec
 	legacyBlocksGauge      *prometheus.GaugeVec
 	legacyBorrowedGauge    *prometheus.GaugeVec
+
+	// Defragmentation metrics.
+	defragBlocksCounter    prometheus.Counter
+	defragReclaimedCounter prometheus.Counter
 )
 
 const (
@@ -74,6 +79,9 @@ const (
 
 	// Used to label an allocation that does not have its node attribute set.
 	unknownNodeLabel = "unknown_node"
+
+	// How often the IPAM event log is checked against its retention limits.
+	ipamEventPruneInterval = 10 * time.Minute
 )
 
 func init() {
@@ -113,6 +121,18 @@ func init() {
 		Help: "Number of blocks in IPAM",
 	}, []string{"node"})
 	prometheus.MustRegister(legacyBlocksGauge)
+
+	defragBlocksCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ipam_defrag_blocks_released",
+		Help: "Number of blocks whose affinity has been released by IPAM defragmentation.",
+	})
+	prometheus.MustRegister(defragBlocksCounter)
+
+	defragReclaimedCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ipam_defrag_addresses_reclaimed",
+		Help: "Number of addresses made available to all nodes by IPAM defragmentation.",
+	})
+	prometheus.MustRegister(defragReclaimedCounter)
 }
 
 type rateLimiterItemKey struct {
@@ -249,6 +269,19 @@ func (c *ipamController) acceptScheduleRequests(stopCh <-chan struct{}) {
 	}
 	t := time.NewTicker(period)
 	log.Infof("Will run periodic IPAM sync every %s", period)
+
+	// Defragmentation is only enabled on request.
+	var defragC <-chan time.Time
+	if c.config.IPAMDefrag != nil {
+		dt := time.NewTicker(c.config.IPAMDefrag.Interval)
+		defer dt.Stop()
+		defragC = dt.C
+		log.Infof("Will run periodic IPAM defragmentation every %s", c.config.IPAMDefrag.Interval)
+	}
+
+	// The IPAM event log is always pruned, so that it stays bounded whenever it is enabled.
+	pt := time.NewTicker(ipamEventPruneInterval)
+	defer pt.Stop()
 	for {
 		// Wait until something wakes us up, or we are stopped.
 		select {
@@ -293,6 +326,10 @@ func (c *ipamController) acceptScheduleRequests(stopCh <-chan struct{}) {
 			// Update prometheus metrics.
 			c.updateMetrics()
 			log.Debug("Triggered IPAM sync complete")
+		case <-defragC:
+			c.defragmentBlocks()
+		case <-pt.C:
+			c.pruneIPAMEvents()
 		case req := <-c.pauseRequestChannel:
 			// For testing purposes - allow the tests to pause the main processing loop.
 			log.Warn("Pausing main loop so tests can read state")
@@ -701,6 +738,99 @@ func (c *ipamController) checkEmptyBlocks() error {
 	return nil
 }
 
+// defragmentBlocks releases the affinity of empty and sparsely used blocks, so that nodes don't
+// hold on to address space that they aren't using, and optionally evicts the pods that have
+// borrowed addresses from them.  See the defrag package for the rules that decide which blocks are
+// released.  Blocks are checked against the datastore as they are released, so it's safe to work
+// from the cached blocks.
+func (c *ipamController) defragmentBlocks() {
+	if !c.datastoreReady || c.syncStatus != bapi.InSync {
+		log.Debug("Not in sync with datastore, skipping IPAM defragmentation")
+		return
+	}
+
+	ipamCfg, err := c.client.IPAM().GetIPAMConfig(context.TODO())
+	if err != nil {
+		log.WithError(err).Warn("Failed to get IPAM configuration, skipping IPAM defragmentation")
+		return
+	}
+
+	blocks := make([]*model.AllocationBlock, 0, len(c.allBlocks))
+	for _, kvp := range c.allBlocks {
+		blocks = append(blocks, kvp.Value.(*model.AllocationBlock))
+	}
+	plan := defrag.NewPlan(blocks, defrag.Config{
+		SparseThreshold:     c.config.IPAMDefrag.SparseThreshold,
+		StrictAffinity:      ipamCfg.StrictAffinity,
+		ConsolidateBorrowed: c.config.IPAMDefrag.ConsolidateBorrowed,
+		SkipNode: func(node string) bool {
+			// During a Flannel migration, leave the blocks of nodes that are being migrated alone.
+			migrating, err := c.nodeIsBeingMigrated(node)
+			if err != nil {
+				log.WithError(err).WithField("node", node).Warn("Failed to check if node is being migrated from Flannel, skipping defragmentation")
+				return true
+			}
+			return migrating
+		},
+	})
+	if len(plan.Actions) == 0 {
+		log.Debug("No IPAM blocks to defragment")
+		return
+	}
+
+	var evict defrag.Evictor
+	if c.config.IPAMDefrag.ConsolidateBorrowed {
+		evict = defrag.NewKubernetesEvictor(c.clientset)
+	}
+	result := plan.Apply(context.TODO(), c.client.IPAM(), evict)
+	summary := result.Summary()
+	defragBlocksCounter.Add(float64(summary.BlocksReleased))
+	defragReclaimedCounter.Add(float64(summary.AddressesReclaimed + summary.AddressesUnpinned))
+	log.WithFields(log.Fields{
+		"blocksReleased":     summary.BlocksReleased,
+		"blocksFailed":       len(result.Failed),
+		"addressesReclaimed": summary.AddressesReclaimed,
+		"addressesUnpinned":  summary.AddressesUnpinned,
+		"podsEvicted":        summary.AddressesConsolidated,
+		"podsFailed":         len(result.FailedConsolidations),
+	}).Info("IPAM defragmentation complete")
+}
+
+// pruneIPAMEvents deletes the IPAM events that are beyond the configured retention limits, or the
+// default limits if none are configured.  Nothing is pruned while the event log is disabled, so
+// that clusters that don't use it aren't listing the events periodically.
+func (c *ipamController) pruneIPAMEvents() {
+	type accessor interface {
+		Backend() bapi.Client
+	}
+	a, ok := c.client.(accessor)
+	if !ok || a.Backend() == nil {
+		return
+	}
+
+	ipamCfg, err := c.client.IPAM().GetIPAMConfig(context.TODO())
+	if err != nil {
+		log.WithError(err).Warn("Failed to get IPAM configuration, skipping IPAM event pruning")
+		return
+	}
+	if !ipamCfg.EventLog {
+		log.Debug("IPAM event log is disabled, skipping IPAM event pruning")
+		return
+	}
+
+	maxAge, maxEvents := config.DefaultIPAMEventMaxAge, config.DefaultIPAMEventMaxEvents
+	if r := c.config.IPAMEventRetention; r != nil {
+		maxAge, maxEvents = r.MaxAge, r.MaxEvents
+	}
+	deleted, err := ipam.PruneEvents(context.TODO(), a.Backend(), maxAge, maxEvents)
+	if err != nil {
+		log.WithError(err).Warn("Failed to prune IPAM event log")
+	}
+	if deleted > 0 {
+		log.WithField("deleted", deleted).Info("Pruned IPAM event log")
+	}
+}
+
 // checkAllocations scans Calico IPAM and determines if any IPs appear to be leaks, and if any nodes should have their
 // block affinities released.
 //
@@ -1526,4 +1526,78 @@ var _ = Describe("IPAM controller UTs", func() {
 		Eventually(numBlocks, 1*time.Second, 100*time.Millisecond).Should(Equal(1))
 		Consistently(numBlocks, assertionTimeout, 100*time.Millisecond).Should(Equal(1))
 	})
+
+	It("should defragment sparsely used blocks", func() {
+		// Create Calico and k8s nodes for the test.
+		n := libapiv3.Node{}
+		n.Name = "cnode"
+		n.Spec.OrchRefs = []libapiv3.OrchRef{{NodeName: "kname", Orchestrator: apiv3.OrchestratorKubernetes}}
+		_, err := cli.Nodes().Create(context.TODO(), &n, options.SetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		kn := v1.Node{}
+		kn.Name = "kname"
+		_, err = cs.CoreV1().Nodes().Create(context.TODO(), &kn, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		var node *v1.Node
+		Eventually(nodes).WithTimeout(time.Second).Should(Receive(&node))
+
+		// Enable defragmentation.  The test triggers defragmentation itself, so the interval
+		// doesn't matter.
+		c.config.IPAMDefrag = &config.IPAMDefragConfig{Interval: time.Hour, SparseThreshold: 0.25}
+		c.Start(stopChan)
+
+		// Give the node a well used block, a block with a single address in use and an empty
+		// block.
+		for i, inUse := range []int{40, 1, 0} {
+			cidr := net.MustParseCIDR(fmt.Sprintf("10.0.%d.0/26", i))
+			aff := "host:cnode"
+			b := model.AllocationBlock{
+				CIDR:        cidr,
+				Affinity:    &aff,
+				Allocations: make([]*int, 64),
+			}
+			handle := "handle"
+			b.Attributes = []model.AllocationAttribute{{
+				AttrPrimary:   &handle,
+				AttrSecondary: map[string]string{ipam.AttributeNode: "cnode"},
+			}}
+			for ord := 0; ord < 64; ord++ {
+				if ord < inUse {
+					b.Allocations[ord] = new(int)
+				} else {
+					b.Unallocated = append(b.Unallocated, ord)
+				}
+			}
+			kvp := model.KVPair{Key: model.BlockKey{CIDR: cidr}, Value: &b}
+			c.onUpdate(bapi.Update{KVPair: kvp, UpdateType: bapi.UpdateTypeKVNew})
+		}
+		c.onStatusUpdate(bapi.InSync)
+		Eventually(func() bool {
+			done := c.pause()
+			defer done()
+			return len(c.allBlocks) == 3 && c.syncStatus == bapi.InSync
+		}, 1*time.Second, 100*time.Millisecond).Should(BeTrue())
+
+		// Run a defragmentation pass while the main loop is paused.
+		done := c.pause()
+		c.defragmentBlocks()
+		done()
+
+		fakeClient := cli.IPAM().(*fakeIPAMClient)
+		Expect(fakeClient.affinitiesReleased).To(Equal(map[string]bool{
+			"10.0.1.0/26/cnode": true,
+			"10.0.2.0/26/cnode": true,
+		}))
+
+		// With strict affinity, no other node could use the sparse block, so only the empty
+		// block is released.
+		fakeClient.affinitiesReleased = map[string]bool{}
+		fakeClient.strictAffinity = true
+		done = c.pause()
+		c.defragmentBlocks()
+		done()
+		Expect(fakeClient.affinitiesReleased).To(Equal(map[string]bool{
+			"10.0.2.0/26/cnode": true,
+		}))
+	})
 })
@@ -0,0 +1,342 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystatus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/kube-controllers/pkg/config"
+	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/controller"
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	kdd "github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/conversion"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/syncersv1/updateprocessors"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/watchersyncer"
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+const (
+	// batchUpdateSize is the number of syncer updates to handle before triggering a sync.
+	batchUpdateSize = 1000
+
+	// retryInterval is the interval after which a failed sync is retried.
+	retryInterval = 5 * time.Second
+)
+
+// policyStatusController implements the Controller interface.  It aggregates the per-node policy
+// status that Felix reports into the datastore onto the Status of each NetworkPolicy and
+// GlobalNetworkPolicy.
+//
+// Felix reports the content hash of the version of each policy that it has programmed, so the
+// controller compares the reported hashes with the hash of the current version of the policy to
+// work out how many nodes are up to date.  The hash is calculated over the policy as Felix sees it
+// which does not include the status, so writing the status does not invalidate the reports.
+type policyStatusController struct {
+	ctx          context.Context
+	calicoClient client.Interface
+	cfg          config.GenericControllerConfig
+	syncer       bapi.Syncer
+
+	syncerUpdates chan interface{}
+	syncChan      chan interface{}
+	inSync        bool
+
+	// policies maps the v1 key of each policy to the content hash of its current version.
+	policies map[model.PolicyKey]string
+	// statuses maps the v1 key of each policy to the status reported by each node.
+	statuses map[model.PolicyKey]map[string]model.PolicyStatus
+	// dirty contains the policies whose status needs to be recalculated.
+	dirty set.Set[model.PolicyKey]
+}
+
+// NewPolicyStatusController returns a controller which writes the status of Calico policies, aggregated
+// from the per-node status reported by Felix.
+func NewPolicyStatusController(ctx context.Context, c client.Interface, cfg config.GenericControllerConfig) controller.Controller {
+	psc := &policyStatusController{
+		ctx:           ctx,
+		calicoClient:  c,
+		cfg:           cfg,
+		syncerUpdates: make(chan interface{}, batchUpdateSize),
+		syncChan:      make(chan interface{}, 1),
+		policies:      map[model.PolicyKey]string{},
+		statuses:      map[model.PolicyKey]map[string]model.PolicyStatus{},
+		dirty:         set.New[model.PolicyKey](),
+	}
+
+	resourceTypes := []watchersyncer.ResourceType{
+		{
+			ListInterface:   model.ResourceListOptions{Kind: apiv3.KindNetworkPolicy},
+			UpdateProcessor: updateprocessors.NewNetworkPolicyUpdateProcessor(),
+		},
+		{
+			ListInterface:   model.ResourceListOptions{Kind: apiv3.KindGlobalNetworkPolicy},
+			UpdateProcessor: updateprocessors.NewGlobalNetworkPolicyUpdateProcessor(),
+		},
+		{
+			ListInterface: model.PolicyStatusListOptions{},
+		},
+	}
+	type accessor interface {
+		Backend() bapi.Client
+	}
+	psc.syncer = watchersyncer.New(c.(accessor).Backend(), resourceTypes, psc)
+	return psc
+}
+
+// Run starts the controller.
+func (c *policyStatusController) Run(stopCh chan struct{}) {
+	log.Info("Starting PolicyStatus controller")
+	c.syncer.Start()
+	defer c.syncer.Stop()
+
+	period := c.cfg.ReconcilerPeriod
+	if period <= 0 {
+		period = 5 * time.Minute
+	}
+	t := time.NewTicker(period)
+	defer t.Stop()
+
+	var retryC <-chan time.Time
+	for {
+		select {
+		case upd := <-c.syncerUpdates:
+			c.handleUpdate(upd)
+
+			// Handle any other updates that are already queued before triggering a sync.
+		consolidationLoop:
+			for i := 1; i < batchUpdateSize; i++ {
+				select {
+				case upd = <-c.syncerUpdates:
+					c.handleUpdate(upd)
+				default:
+					break consolidationLoop
+				}
+			}
+			kick(c.syncChan)
+		case <-t.C:
+			// Periodically recalculate the status of every policy, in case the status has been
+			// overwritten.
+			log.Debug("Periodic policy status sync")
+			for k := range c.policies {
+				c.dirty.Add(k)
+			}
+			kick(c.syncChan)
+		case <-retryC:
+			retryC = nil
+			kick(c.syncChan)
+		case <-c.syncChan:
+			if !c.inSync {
+				log.Debug("Not in sync with datastore, deferring policy status sync")
+				continue
+			}
+			if err := c.syncStatuses(); err != nil {
+				log.WithError(err).Warn("Failed to sync policy status, will retry")
+				retryC = time.After(retryInterval)
+			}
+		case <-stopCh:
+			log.Info("Stopping PolicyStatus controller")
+			return
+		}
+	}
+}
+
+func (c *policyStatusController) OnStatusUpdated(status bapi.SyncStatus) {
+	c.syncerUpdates <- status
+}
+
+func (c *policyStatusController) OnUpdates(updates []bapi.Update) {
+	for _, upd := range updates {
+		c.syncerUpdates <- upd.KVPair
+	}
+}
+
+func (c *policyStatusController) handleUpdate(upd interface{}) {
+	switch upd := upd.(type) {
+	case bapi.SyncStatus:
+		log.WithField("status", upd).Info("PolicyStatus controller syncer status updated")
+		c.inSync = upd == bapi.InSync
+	case model.KVPair:
+		switch key := upd.Key.(type) {
+		case model.PolicyKey:
+			if upd.Value == nil {
+				delete(c.policies, key)
+				c.dirty.Discard(key)
+				return
+			}
+			c.policies[key] = model.PolicyContentHash(upd.Value.(*model.Policy))
+			c.dirty.Add(key)
+		case model.PolicyStatusKey:
+			pk := model.PolicyKey{Tier: key.Tier, Name: key.Name}
+			if upd.Value == nil {
+				delete(c.statuses[pk], key.Hostname)
+				if len(c.statuses[pk]) == 0 {
+					delete(c.statuses, pk)
+				}
+			} else {
+				if c.statuses[pk] == nil {
+					c.statuses[pk] = map[string]model.PolicyStatus{}
+				}
+				c.statuses[pk][key.Hostname] = *upd.Value.(*model.PolicyStatus)
+			}
+			if _, ok := c.policies[pk]; ok {
+				c.dirty.Add(pk)
+			}
+		default:
+			log.WithField("key", upd.Key).Warn("Unexpected key received over syncer")
+		}
+	}
+}
+
+// syncStatuses writes the status of each dirty policy.  Policies whose status is written
+// successfully are removed from the dirty set.
+func (c *policyStatusController) syncStatuses() error {
+	var lastErr error
+	c.dirty.Iter(func(key model.PolicyKey) error {
+		hash, ok := c.policies[key]
+		if !ok {
+			return set.RemoveItem
+		}
+		if err := c.writeStatus(key, hash); err != nil {
+			log.WithError(err).WithField("policy", key).Debug("Failed to write policy status")
+			lastErr = err
+			return nil
+		}
+		return set.RemoveItem
+	})
+	return lastErr
+}
+
+func (c *policyStatusController) writeStatus(key model.PolicyKey, hash string) error {
+	if strings.Contains(key.Name, "/") {
+		parts := strings.SplitN(key.Name, "/", 2)
+		namespace, name := parts[0], parts[1]
+		if strings.HasPrefix(name, kdd.K8sNetworkPolicyNamePrefix) {
+			// Kubernetes network policies are owned by the policy controller, which would
+			// revert any status that we write.
+			return nil
+		}
+		p, err := c.calicoClient.NetworkPolicies().Get(c.ctx, namespace, name, options.GetOptions{})
+		if err != nil {
+			return ignoreDoesNotExist(err)
+		}
+		if !calculateStatus(&p.Status, p.Generation, hash, c.statuses[key]) {
+			return nil
+		}
+		log.WithFields(log.Fields{"policy": key, "status": p.Status}).Debug("Updating NetworkPolicy status")
+		_, err = c.calicoClient.NetworkPolicies().Update(c.ctx, p, options.SetOptions{})
+		return ignoreDoesNotExist(err)
+	}
+
+	p, err := c.calicoClient.GlobalNetworkPolicies().Get(c.ctx, key.Name, options.GetOptions{})
+	if err != nil {
+		return ignoreDoesNotExist(err)
+	}
+	if !calculateStatus(&p.Status, p.Generation, hash, c.statuses[key]) {
+		return nil
+	}
+	log.WithFields(log.Fields{"policy": key, "status": p.Status}).Debug("Updating GlobalNetworkPolicy status")
+	_, err = c.calicoClient.GlobalNetworkPolicies().Update(c.ctx, p, options.SetOptions{})
+	return ignoreDoesNotExist(err)
+}
+
+// calculateStatus updates the given policy status from the status reported by each node for the
+// version of the policy with the given content hash.  It returns true if the status was changed.
+//
+// Felix reports the policy as pending on a node as soon as the policy applies to one of the node's
+// endpoints, so the nodes that the policy is selected on are the nodes with any report, whichever
+// version of the policy it is for.  Only the reports for the current version count towards the
+// programmed and failed nodes.
+func calculateStatus(status *apiv3.PolicyStatus, generation int64, hash string, nodeStatuses map[string]model.PolicyStatus) bool {
+	newStatus := apiv3.PolicyStatus{
+		Conditions: append([]metav1.Condition(nil), status.Conditions...),
+	}
+	for node, ns := range nodeStatuses {
+		newStatus.NodesSelected++
+		if ns.ContentHash != hash {
+			// The node hasn't yet reported on the current version of the policy.
+			continue
+		}
+		switch ns.Status {
+		case model.PolicyStatusProgrammed:
+			newStatus.NodesProgrammed++
+		case model.PolicyStatusError:
+			newStatus.NodesFailed++
+			newStatus.NodeErrors = append(newStatus.NodeErrors, apiv3.PolicyNodeError{Node: node, Errors: ns.Errors})
+		}
+	}
+	sort.Slice(newStatus.NodeErrors, func(i, j int) bool {
+		return newStatus.NodeErrors[i].Node < newStatus.NodeErrors[j].Node
+	})
+
+	cond := metav1.Condition{
+		Type:               apiv3.PolicyConditionProgrammed,
+		ObservedGeneration: generation,
+		Message:            fmt.Sprintf("%d/%d nodes programmed", newStatus.NodesProgrammed, newStatus.NodesSelected),
+	}
+	switch {
+	case newStatus.NodesSelected == 0:
+		// Either the policy doesn't apply to any nodes, or none of them have reported on it yet; we
+		// can't tell which, so we can't claim that the policy is programmed.
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = apiv3.PolicyReasonNotReported
+	case newStatus.NodesProgrammed == newStatus.NodesSelected:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = apiv3.PolicyReasonProgrammed
+	case newStatus.NodesFailed > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = apiv3.PolicyReasonFailed
+	default:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = apiv3.PolicyReasonPending
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, cond)
+
+	if reflect.DeepEqual(*status, newStatus) {
+		return false
+	}
+	*status = newStatus
+	return true
+}
+
+func ignoreDoesNotExist(err error) error {
+	if _, ok := err.(cerrors.ErrorResourceDoesNotExist); ok {
+		// The policy has been deleted, the syncer will tell us about it shortly.
+		return nil
+	}
+	return err
+}
+
+func kick(c chan<- interface{}) {
+	select {
+	case c <- nil:
+		// pass
+	default:
+		// pass
+	}
+}
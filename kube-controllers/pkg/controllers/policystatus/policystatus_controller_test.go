@@ -0,0 +1,113 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystatus
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+var _ = Describe("Policy status calculation", func() {
+	var status apiv3.PolicyStatus
+
+	BeforeEach(func() {
+		status = apiv3.PolicyStatus{}
+	})
+
+	programmedCondition := func() metav1.Condition {
+		Expect(status.Conditions).To(HaveLen(1))
+		c := status.Conditions[0]
+		Expect(c.Type).To(Equal(apiv3.PolicyConditionProgrammed))
+		Expect(c.LastTransitionTime.IsZero()).To(BeFalse())
+		return c
+	}
+
+	It("should not report a policy that no nodes have reported on as programmed", func() {
+		Expect(calculateStatus(&status, 1, "hash", nil)).To(BeTrue())
+		Expect(status.NodesSelected).To(Equal(0))
+		c := programmedCondition()
+		Expect(c.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(c.Reason).To(Equal(apiv3.PolicyReasonNotReported))
+		Expect(c.Message).To(Equal("0/0 nodes programmed"))
+	})
+
+	It("should count nodes that report the policy as pending as selected", func() {
+		Expect(calculateStatus(&status, 1, "hash", map[string]model.PolicyStatus{
+			"node1": {Status: model.PolicyStatusProgrammed, ContentHash: "hash"},
+			"node2": {Status: model.PolicyStatusPending, ContentHash: "hash"},
+		})).To(BeTrue())
+		Expect(status.NodesSelected).To(Equal(2))
+		Expect(status.NodesProgrammed).To(Equal(1))
+		c := programmedCondition()
+		Expect(c.Status).To(Equal(metav1.ConditionFalse))
+		Expect(c.Reason).To(Equal(apiv3.PolicyReasonPending))
+		Expect(c.Message).To(Equal("1/2 nodes programmed"))
+	})
+
+	It("should report a policy as programmed once every selected node has programmed it", func() {
+		Expect(calculateStatus(&status, 1, "hash", map[string]model.PolicyStatus{
+			"node1": {Status: model.PolicyStatusProgrammed, ContentHash: "hash"},
+			"node2": {Status: model.PolicyStatusProgrammed, ContentHash: "hash"},
+		})).To(BeTrue())
+		c := programmedCondition()
+		Expect(c.Status).To(Equal(metav1.ConditionTrue))
+		Expect(c.Reason).To(Equal(apiv3.PolicyReasonProgrammed))
+		Expect(c.Message).To(Equal("2/2 nodes programmed"))
+	})
+
+	It("should only count nodes that have programmed the current version", func() {
+		Expect(calculateStatus(&status, 2, "hash2", map[string]model.PolicyStatus{
+			"node1": {Status: model.PolicyStatusProgrammed, ContentHash: "hash2"},
+			"node2": {Status: model.PolicyStatusProgrammed, ContentHash: "hash1"},
+		})).To(BeTrue())
+		Expect(status.NodesSelected).To(Equal(2))
+		Expect(status.NodesProgrammed).To(Equal(1))
+		Expect(status.NodesFailed).To(Equal(0))
+		c := programmedCondition()
+		Expect(c.Status).To(Equal(metav1.ConditionFalse))
+		Expect(c.Reason).To(Equal(apiv3.PolicyReasonPending))
+		Expect(c.ObservedGeneration).To(Equal(int64(2)))
+		Expect(c.Message).To(Equal("1/2 nodes programmed"))
+	})
+
+	It("should report per-node errors", func() {
+		Expect(calculateStatus(&status, 1, "hash", map[string]model.PolicyStatus{
+			"node1": {Status: model.PolicyStatusProgrammed, ContentHash: "hash"},
+			"node3": {Status: model.PolicyStatusError, ContentHash: "hash", Errors: 1},
+			"node2": {Status: model.PolicyStatusError, ContentHash: "hash", Errors: 4},
+		})).To(BeTrue())
+		Expect(status.NodesFailed).To(Equal(2))
+		Expect(status.NodeErrors).To(Equal([]apiv3.PolicyNodeError{
+			{Node: "node2", Errors: 4},
+			{Node: "node3", Errors: 1},
+		}))
+		Expect(programmedCondition().Reason).To(Equal(apiv3.PolicyReasonFailed))
+	})
+
+	It("should not report a change if the status is unchanged", func() {
+		nodeStatuses := map[string]model.PolicyStatus{
+			"node1": {Status: model.PolicyStatusProgrammed, ContentHash: "hash"},
+		}
+		Expect(calculateStatus(&status, 1, "hash", nodeStatuses)).To(BeTrue())
+		transitionTime := programmedCondition().LastTransitionTime
+		Expect(calculateStatus(&status, 1, "hash", nodeStatuses)).To(BeFalse())
+		Expect(programmedCondition().LastTransitionTime).To(Equal(transitionTime))
+	})
+})
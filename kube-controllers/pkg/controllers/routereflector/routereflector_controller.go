@@ -0,0 +1,530 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routereflector
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/kube-controllers/pkg/config"
+	"github.com/projectcalico/calico/kube-controllers/pkg/controllers/controller"
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector"
+)
+
+const (
+	// meshDisabledAnnotation is set on the default BGPConfiguration when the controller has disabled the
+	// node-to-node mesh, so that it knows to enable the mesh again if the topology breaks.
+	meshDisabledAnnotation = "projectcalico.org/route-reflector-controller-disabled-mesh"
+
+	// statusUpdatePeriod is how often the route reflectors report their BGP sessions, and
+	// statusMaxAge is how old a report can be before the controller stops trusting it.
+	statusUpdatePeriod = 10 * time.Second
+	statusMaxAge       = 3 * statusUpdatePeriod
+)
+
+// routeReflectorController implements the Controller interface.  It elects route reflectors in each
+// zone, sets their cluster IDs, and maintains the BGPPeer resources that connect the other nodes to
+// them.  Once every zone has a route reflector, and a later pass confirms that the reflectors are
+// configured and have established BGP sessions with all of their clients, it disables the
+// node-to-node mesh.  It enables the mesh again if a zone is left without a reflector.
+//
+// Route reflectors that become NotReady or are cordoned are replaced by other nodes in their zone.
+// New reflectors are promoted first, and the old ones are only demoted once the new ones have
+// established BGP sessions, so that nodes always have a reflector to peer with.
+type routeReflectorController struct {
+	ctx          context.Context
+	calicoClient client.Interface
+	nodeInformer cache.SharedIndexInformer
+	cfg          config.RouteReflectorControllerConfig
+	selector     selector.Selector
+	kickChan     chan interface{}
+}
+
+// NewRouteReflectorController returns a controller which manages the route reflector topology.
+func NewRouteReflectorController(ctx context.Context, c client.Interface, cfg config.RouteReflectorControllerConfig, nodeInformer cache.SharedIndexInformer) controller.Controller {
+	sel, err := selector.Parse(cfg.NodeSelector)
+	if err != nil {
+		log.WithError(err).Fatal("Invalid route reflector node selector")
+	}
+	rc := &routeReflectorController{
+		ctx:          ctx,
+		calicoClient: c,
+		nodeInformer: nodeInformer,
+		cfg:          cfg,
+		selector:     sel,
+		kickChan:     make(chan interface{}, 1),
+	}
+
+	// Node readiness and cordoning come from Kubernetes, so recheck the topology whenever a
+	// node changes.
+	_, err = nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { kick(rc.kickChan) },
+		UpdateFunc: func(_, obj interface{}) { kick(rc.kickChan) },
+		DeleteFunc: func(obj interface{}) { kick(rc.kickChan) },
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to add event handler for node")
+	}
+	return rc
+}
+
+// Run starts the controller.
+func (c *routeReflectorController) Run(stopCh chan struct{}) {
+	log.Info("Starting RouteReflector controller")
+	if !cache.WaitForNamedCacheSync("nodes", stopCh, c.nodeInformer.HasSynced) {
+		log.Info("Failed to sync resources, received signal for controller to shut down.")
+		return
+	}
+
+	t := time.NewTicker(c.cfg.ReconcilerPeriod)
+	defer t.Stop()
+	c.reconcile()
+	for {
+		select {
+		case <-t.C:
+			c.reconcile()
+		case <-c.kickChan:
+			c.reconcile()
+		case <-stopCh:
+			log.Info("Stopping RouteReflector controller")
+			return
+		}
+	}
+}
+
+// reconcile elects the route reflectors and brings the nodes, BGP peers and mesh setting into line.
+func (c *routeReflectorController) reconcile() {
+	nodes, err := c.calicoClient.Nodes().List(c.ctx, options.ListOptions{})
+	if err != nil {
+		log.WithError(err).Warn("Failed to list nodes")
+		return
+	}
+
+	candidates := make([]candidate, 0, len(nodes.Items))
+	for i := range nodes.Items {
+		candidates = append(candidates, c.candidateForNode(&nodes.Items[i]))
+	}
+	t, err := electReflectors(candidates, c.cfg.ReflectorsPerZone, c.cfg.ClusterIDBase)
+	if err != nil {
+		log.WithError(err).Error("Unable to elect route reflectors")
+		return
+	}
+
+	// Enabling the mesh is always safe, so do it straight away if a zone has no reflector.
+	if !t.healthy() {
+		c.syncMesh(false)
+	}
+
+	// Promote the new reflectors before demoting the old ones, so that every zone keeps a reflector
+	// while the topology changes.  The old reflectors are only demoted, and the mesh only disabled,
+	// once a pass finds nothing else to change, since writes in this pass, failed or not, haven't
+	// been picked up by the nodes yet.
+	settled := true
+	for i := range nodes.Items {
+		n := &nodes.Items[i]
+		if id, ok := t.clusterIDs[n.Name]; ok {
+			changed, err := c.setClusterID(n, id)
+			if err != nil {
+				// Don't demote the old reflectors until the new ones are in place.
+				return
+			}
+			settled = settled && !changed
+		}
+	}
+	changed, err := c.syncPeers(t)
+	if err != nil {
+		log.WithError(err).Warn("Failed to update route reflector BGP peers")
+		return
+	}
+	settled = settled && !changed
+	changed, err = c.syncStatusRequests(t)
+	if err != nil {
+		log.WithError(err).Warn("Failed to update route reflector status requests")
+		return
+	}
+	settled = settled && !changed
+
+	if !t.healthy() || !settled {
+		return
+	}
+	if err := c.checkSessions(nodes.Items, t); err != nil {
+		log.WithError(err).Info("Route reflectors are not ready yet, leaving the old reflectors and the node-to-node mesh alone")
+		return
+	}
+
+	// The new reflectors have established sessions with their clients, so the old ones can go.
+	// Leave the mesh until a later pass has checked the sessions without them.
+	demoted := false
+	for i := range nodes.Items {
+		n := &nodes.Items[i]
+		if _, ok := t.clusterIDs[n.Name]; !ok && n.Labels[reflectorLabel] != "" {
+			changed, err := c.setClusterID(n, "")
+			demoted = demoted || changed || err != nil
+		}
+	}
+	if demoted {
+		return
+	}
+	c.syncMesh(true)
+}
+
+// candidateForNode returns the node's details for route reflector election.
+func (c *routeReflectorController) candidateForNode(n *libapiv3.Node) candidate {
+	cand := candidate{
+		name:     n.Name,
+		zone:     n.Labels[c.cfg.ZoneLabel],
+		eligible: n.Spec.BGP != nil && c.selector.Evaluate(n.Labels),
+		managed:  n.Labels[reflectorLabel] != "",
+	}
+	if n.Spec.BGP != nil {
+		cand.clusterID = n.Spec.BGP.RouteReflectorClusterID
+	}
+
+	// Readiness and cordoning come from the Kubernetes node.
+	if k8sNode := c.k8sNode(n); k8sNode != nil {
+		cand.healthy = nodeIsHealthy(k8sNode)
+	}
+	return cand
+}
+
+// k8sNode returns the Kubernetes node for the Calico node, or nil if there isn't one.
+func (c *routeReflectorController) k8sNode(n *libapiv3.Node) *v1.Node {
+	k8sName := n.Name
+	for _, ref := range n.Spec.OrchRefs {
+		if ref.Orchestrator == "k8s" && ref.NodeName != "" {
+			k8sName = ref.NodeName
+		}
+	}
+	obj, exists, err := c.nodeInformer.GetStore().GetByKey(k8sName)
+	if err != nil || !exists {
+		return nil
+	}
+	return obj.(*v1.Node)
+}
+
+// nodeIsReady returns whether the Calico node's Kubernetes node is Ready.  Nodes that aren't
+// managed by Kubernetes are assumed to be.
+func (c *routeReflectorController) nodeIsReady(n *libapiv3.Node) bool {
+	k8sNode := c.k8sNode(n)
+	if k8sNode == nil {
+		return true
+	}
+	for _, cond := range k8sNode.Status.Conditions {
+		if cond.Type == v1.NodeReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// nodeIsHealthy returns whether the node can act as a route reflector: it must be Ready and not
+// cordoned.
+func nodeIsHealthy(n *v1.Node) bool {
+	if n.Spec.Unschedulable {
+		return false
+	}
+	for _, cond := range n.Status.Conditions {
+		if cond.Type == v1.NodeReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// setClusterID makes the node a route reflector with the given cluster ID, or stops it being one if
+// the cluster ID is empty.  It returns whether the node needed changing.
+func (c *routeReflectorController) setClusterID(n *libapiv3.Node, clusterID string) (bool, error) {
+	if n.Spec.BGP == nil || (n.Spec.BGP.RouteReflectorClusterID == clusterID && n.Labels[reflectorLabel] == clusterID) {
+		return false, nil
+	}
+	logCtx := log.WithFields(log.Fields{"node": n.Name, "clusterID": clusterID})
+	n.Spec.BGP.RouteReflectorClusterID = clusterID
+	if n.Labels == nil {
+		n.Labels = map[string]string{}
+	}
+	if clusterID == "" {
+		logCtx.Info("Demoting route reflector")
+		delete(n.Labels, reflectorLabel)
+	} else {
+		logCtx.Info("Promoting node to route reflector")
+		n.Labels[reflectorLabel] = clusterID
+	}
+	if _, err := c.calicoClient.Nodes().Update(c.ctx, n, options.SetOptions{}); err != nil {
+		// We'll try again on the next reconcile.
+		logCtx.WithError(err).Warn("Failed to update node")
+		return true, err
+	}
+	return true, nil
+}
+
+// syncPeers creates and updates the BGP peers for the topology, and deletes the ones the controller
+// created for zones that no longer exist.  It returns whether any peers needed changing.
+func (c *routeReflectorController) syncPeers(t *topology) (bool, error) {
+	existing, err := c.calicoClient.BGPPeers().List(c.ctx, options.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	changed := false
+	current := map[string]*apiv3.BGPPeer{}
+	for i := range existing.Items {
+		p := &existing.Items[i]
+		if p.Labels[managedByLabel] == managedByValue {
+			current[p.Name] = p
+		}
+	}
+
+	for _, want := range desiredPeers(t, c.cfg.ZoneLabel) {
+		have, ok := current[want.Name]
+		delete(current, want.Name)
+		if !ok {
+			log.WithField("peer", want.Name).Info("Creating route reflector BGP peer")
+			changed = true
+			if _, err := c.calicoClient.BGPPeers().Create(c.ctx, &want, options.SetOptions{}); err != nil {
+				return changed, err
+			}
+			continue
+		}
+		if reflect.DeepEqual(have.Spec, want.Spec) {
+			continue
+		}
+		log.WithField("peer", want.Name).Info("Updating route reflector BGP peer")
+		changed = true
+		have.Spec = want.Spec
+		if _, err := c.calicoClient.BGPPeers().Update(c.ctx, have, options.SetOptions{}); err != nil {
+			return changed, err
+		}
+	}
+
+	for name := range current {
+		log.WithField("peer", name).Info("Deleting route reflector BGP peer")
+		changed = true
+		if _, err := c.calicoClient.BGPPeers().Delete(c.ctx, name, options.DeleteOptions{}); err != nil {
+			if _, ok := err.(cerrors.ErrorResourceDoesNotExist); !ok {
+				return changed, err
+			}
+		}
+	}
+	return changed, nil
+}
+
+// syncStatusRequests creates a CalicoNodeStatus for each route reflector, so that calico-node reports
+// the reflector's BGP sessions, and deletes the ones for nodes that are no longer reflectors.  It
+// returns whether any needed changing.
+func (c *routeReflectorController) syncStatusRequests(t *topology) (bool, error) {
+	existing, err := c.calicoClient.CalicoNodeStatus().List(c.ctx, options.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	current := map[string]bool{}
+	changed := false
+	for _, s := range existing.Items {
+		if s.Labels[managedByLabel] != managedByValue {
+			continue
+		}
+		if _, ok := t.clusterIDs[s.Spec.Node]; ok && s.Name == statusName(s.Spec.Node) {
+			current[s.Spec.Node] = true
+			continue
+		}
+		log.WithField("status", s.Name).Info("Deleting route reflector status request")
+		changed = true
+		if _, err := c.calicoClient.CalicoNodeStatus().Delete(c.ctx, s.Name, options.DeleteOptions{}); err != nil {
+			if _, ok := err.(cerrors.ErrorResourceDoesNotExist); !ok {
+				return changed, err
+			}
+		}
+	}
+
+	for node := range t.clusterIDs {
+		if current[node] {
+			continue
+		}
+		log.WithField("node", node).Info("Creating route reflector status request")
+		changed = true
+		period := uint32(statusUpdatePeriod / time.Second)
+		s := apiv3.NewCalicoNodeStatus()
+		s.Name = statusName(node)
+		s.Labels = map[string]string{managedByLabel: managedByValue}
+		s.Spec = apiv3.CalicoNodeStatusSpec{
+			Node:                node,
+			Classes:             []apiv3.NodeStatusClassType{apiv3.NodeStatusClassTypeBGP},
+			UpdatePeriodSeconds: &period,
+		}
+		if _, err := c.calicoClient.CalicoNodeStatus().Create(c.ctx, s, options.SetOptions{}); err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
+
+// statusName returns the name of the CalicoNodeStatus for the route reflector on the given node.
+func statusName(node string) string {
+	return "route-reflector-" + node
+}
+
+// checkSessions returns an error unless the route reflectors' recent status reports show that every
+// reflector has established BGP sessions with the other reflectors, and that every other BGP node has
+// an established session with a reflector in its zone.  Nodes that aren't Ready can't be expected to
+// have sessions, so they are skipped; they peer with the reflectors when they come back.
+func (c *routeReflectorController) checkSessions(nodes []libapiv3.Node, t *topology) error {
+	statuses, err := c.calicoClient.CalicoNodeStatus().List(c.ctx, options.ListOptions{})
+	if err != nil {
+		return err
+	}
+	// The established peer IPs of each reflector.
+	established := map[string]map[string]bool{}
+	for _, s := range statuses.Items {
+		if s.Labels[managedByLabel] != managedByValue || s.Name != statusName(s.Spec.Node) {
+			continue
+		}
+		if s.Status.LastUpdated.IsZero() || time.Since(s.Status.LastUpdated.Time) > statusMaxAge {
+			continue
+		}
+		peers := map[string]bool{}
+		for _, p := range append(s.Status.BGP.PeersV4, s.Status.BGP.PeersV6...) {
+			if p.Type == apiv3.BGPPeerTypeNodePeer && p.State == apiv3.BGPSessionStateEstablished {
+				peers[p.PeerIP] = true
+			}
+		}
+		established[s.Spec.Node] = peers
+	}
+
+	reflectorsByZone := map[string][]string{}
+	for _, z := range t.zones {
+		reflectorsByZone[z.zone] = z.reflectors
+	}
+	for i := range nodes {
+		n := &nodes[i]
+		ips := nodeBGPIPs(n)
+		if len(ips) == 0 || !c.nodeIsReady(n) {
+			continue
+		}
+		// Reflectors must have sessions with all of the other reflectors, and the other nodes with at
+		// least one of the reflectors in their zone.
+		if _, ok := t.clusterIDs[n.Name]; ok {
+			for r := range t.clusterIDs {
+				if r != n.Name && !hasSession(established[r], ips) {
+					return fmt.Errorf("route reflector %s has no established session with route reflector %s", r, n.Name)
+				}
+			}
+			continue
+		}
+		reflectors := reflectorsByZone[n.Labels[c.cfg.ZoneLabel]]
+		found := false
+		for _, r := range reflectors {
+			found = found || hasSession(established[r], ips)
+		}
+		if len(reflectors) > 0 && !found {
+			return fmt.Errorf("node %s has no established session with a route reflector", n.Name)
+		}
+	}
+	return nil
+}
+
+// hasSession returns whether any of the IPs are in the set of established peers.
+func hasSession(established map[string]bool, ips []string) bool {
+	for _, ip := range ips {
+		if established[ip] {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeBGPIPs returns the addresses that the node uses for BGP.
+func nodeBGPIPs(n *libapiv3.Node) []string {
+	if n.Spec.BGP == nil {
+		return nil
+	}
+	var ips []string
+	for _, addr := range []string{n.Spec.BGP.IPv4Address, n.Spec.BGP.IPv6Address} {
+		if addr != "" {
+			ips = append(ips, strings.Split(addr, "/")[0])
+		}
+	}
+	return ips
+}
+
+// syncMesh disables the node-to-node mesh once the topology is healthy, and enables it again if the
+// controller disabled it and the topology is no longer healthy.
+func (c *routeReflectorController) syncMesh(healthy bool) {
+	bgpConfig, err := c.calicoClient.BGPConfigurations().Get(c.ctx, "default", options.GetOptions{})
+	if _, ok := err.(cerrors.ErrorResourceDoesNotExist); ok {
+		if !healthy {
+			// The mesh is enabled by default.
+			return
+		}
+		bgpConfig = apiv3.NewBGPConfiguration()
+		bgpConfig.Name = "default"
+		err = nil
+	}
+	if err != nil {
+		log.WithError(err).Warn("Failed to get the default BGP configuration")
+		return
+	}
+
+	meshEnabled := bgpConfig.Spec.NodeToNodeMeshEnabled == nil || *bgpConfig.Spec.NodeToNodeMeshEnabled
+	disabledByUs := bgpConfig.Annotations[meshDisabledAnnotation] == "true"
+	switch {
+	case healthy && meshEnabled:
+		log.Info("Route reflector topology is healthy, disabling the node-to-node mesh")
+		disabled := false
+		bgpConfig.Spec.NodeToNodeMeshEnabled = &disabled
+		if bgpConfig.Annotations == nil {
+			bgpConfig.Annotations = map[string]string{}
+		}
+		bgpConfig.Annotations[meshDisabledAnnotation] = "true"
+	case !healthy && !meshEnabled && disabledByUs:
+		log.Warn("A zone has no route reflector, enabling the node-to-node mesh")
+		enabled := true
+		bgpConfig.Spec.NodeToNodeMeshEnabled = &enabled
+		delete(bgpConfig.Annotations, meshDisabledAnnotation)
+	default:
+		return
+	}
+
+	if bgpConfig.ResourceVersion == "" {
+		_, err = c.calicoClient.BGPConfigurations().Create(c.ctx, bgpConfig, options.SetOptions{})
+	} else {
+		_, err = c.calicoClient.BGPConfigurations().Update(c.ctx, bgpConfig, options.SetOptions{})
+	}
+	if err != nil {
+		log.WithError(err).Warn("Failed to update the node-to-node mesh setting")
+	}
+}
+
+// kick puts an item on the channel in non-blocking write. This means if there
+// is already something pending, it has no effect. This allows us to coalesce
+// multiple requests into a single pending request.
+func kick(c chan<- interface{}) {
+	select {
+	case c <- nil:
+		// pass
+	default:
+		// pass
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routereflector
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/kube-controllers/pkg/config"
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector"
+)
+
+// fakeClient is an in-memory Calico client with just the resources that the controller uses.
+type fakeClient struct {
+	client.Interface
+	nodes    *fakeNodes
+	peers    *fakePeers
+	configs  *fakeConfigs
+	statuses *fakeStatuses
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		nodes:    &fakeNodes{items: map[string]libapiv3.Node{}, updateErrs: map[string]error{}},
+		peers:    &fakePeers{items: map[string]apiv3.BGPPeer{}},
+		configs:  &fakeConfigs{items: map[string]apiv3.BGPConfiguration{}},
+		statuses: &fakeStatuses{items: map[string]apiv3.CalicoNodeStatus{}},
+	}
+}
+
+func (f *fakeClient) Nodes() client.NodeInterface                         { return f.nodes }
+func (f *fakeClient) BGPPeers() client.BGPPeerInterface                   { return f.peers }
+func (f *fakeClient) BGPConfigurations() client.BGPConfigurationInterface { return f.configs }
+func (f *fakeClient) CalicoNodeStatus() client.CalicoNodeStatusInterface  { return f.statuses }
+
+func notFound(name string) error {
+	return cerrors.ErrorResourceDoesNotExist{Identifier: name}
+}
+
+type fakeNodes struct {
+	client.NodeInterface
+	items      map[string]libapiv3.Node
+	updateErrs map[string]error
+}
+
+func (f *fakeNodes) Update(_ context.Context, res *libapiv3.Node, _ options.SetOptions) (*libapiv3.Node, error) {
+	if err := f.updateErrs[res.Name]; err != nil {
+		return nil, err
+	}
+	f.items[res.Name] = *res.DeepCopy()
+	return res, nil
+}
+
+func (f *fakeNodes) List(_ context.Context, _ options.ListOptions) (*libapiv3.NodeList, error) {
+	l := libapiv3.NewNodeList()
+	for _, n := range f.items {
+		l.Items = append(l.Items, *n.DeepCopy())
+	}
+	sort.Slice(l.Items, func(i, j int) bool { return l.Items[i].Name < l.Items[j].Name })
+	return l, nil
+}
+
+type fakePeers struct {
+	client.BGPPeerInterface
+	items map[string]apiv3.BGPPeer
+}
+
+func (f *fakePeers) Create(_ context.Context, res *apiv3.BGPPeer, _ options.SetOptions) (*apiv3.BGPPeer, error) {
+	f.items[res.Name] = *res.DeepCopy()
+	return res, nil
+}
+
+func (f *fakePeers) Update(_ context.Context, res *apiv3.BGPPeer, _ options.SetOptions) (*apiv3.BGPPeer, error) {
+	f.items[res.Name] = *res.DeepCopy()
+	return res, nil
+}
+
+func (f *fakePeers) Delete(_ context.Context, name string, _ options.DeleteOptions) (*apiv3.BGPPeer, error) {
+	p, ok := f.items[name]
+	if !ok {
+		return nil, notFound(name)
+	}
+	delete(f.items, name)
+	return &p, nil
+}
+
+func (f *fakePeers) List(_ context.Context, _ options.ListOptions) (*apiv3.BGPPeerList, error) {
+	l := &apiv3.BGPPeerList{}
+	for _, p := range f.items {
+		l.Items = append(l.Items, *p.DeepCopy())
+	}
+	return l, nil
+}
+
+type fakeConfigs struct {
+	client.BGPConfigurationInterface
+	items map[string]apiv3.BGPConfiguration
+}
+
+func (f *fakeConfigs) Create(_ context.Context, res *apiv3.BGPConfiguration, _ options.SetOptions) (*apiv3.BGPConfiguration, error) {
+	res = res.DeepCopy()
+	res.ResourceVersion = "1"
+	f.items[res.Name] = *res
+	return res, nil
+}
+
+func (f *fakeConfigs) Update(_ context.Context, res *apiv3.BGPConfiguration, _ options.SetOptions) (*apiv3.BGPConfiguration, error) {
+	f.items[res.Name] = *res.DeepCopy()
+	return res, nil
+}
+
+func (f *fakeConfigs) Get(_ context.Context, name string, _ options.GetOptions) (*apiv3.BGPConfiguration, error) {
+	c, ok := f.items[name]
+	if !ok {
+		return nil, notFound(name)
+	}
+	return c.DeepCopy(), nil
+}
+
+type fakeStatuses struct {
+	client.CalicoNodeStatusInterface
+	items map[string]apiv3.CalicoNodeStatus
+}
+
+func (f *fakeStatuses) Create(_ context.Context, res *apiv3.CalicoNodeStatus, _ options.SetOptions) (*apiv3.CalicoNodeStatus, error) {
+	f.items[res.Name] = *res.DeepCopy()
+	return res, nil
+}
+
+func (f *fakeStatuses) Delete(_ context.Context, name string, _ options.DeleteOptions) (*apiv3.CalicoNodeStatus, error) {
+	s, ok := f.items[name]
+	if !ok {
+		return nil, notFound(name)
+	}
+	delete(f.items, name)
+	return &s, nil
+}
+
+func (f *fakeStatuses) List(_ context.Context, _ options.ListOptions) (*apiv3.CalicoNodeStatusList, error) {
+	l := &apiv3.CalicoNodeStatusList{}
+	for _, s := range f.items {
+		l.Items = append(l.Items, *s.DeepCopy())
+	}
+	return l, nil
+}
+
+var _ = Describe("Route reflector controller", func() {
+	var (
+		cli          *fakeClient
+		nodeInformer cache.SharedIndexInformer
+		c            *routeReflectorController
+	)
+
+	addNode := func(name, zone, ip string, ready bool) {
+		n := libapiv3.NewNode()
+		n.Name = name
+		n.Labels = map[string]string{"zone": zone}
+		n.Spec.BGP = &libapiv3.NodeBGPSpec{IPv4Address: ip + "/24"}
+		cli.nodes.items[name] = *n
+		setReady(nodeInformer, name, ready)
+	}
+
+	// reportSessions records the reflector's established sessions with the given peers, as
+	// calico-node would in the reflector's CalicoNodeStatus.
+	reportSessions := func(reflector string, peerIPs ...string) {
+		s, ok := cli.statuses.items[statusName(reflector)]
+		Expect(ok).To(BeTrue(), "no status request for "+reflector)
+		s.Status.LastUpdated = metav1.Now()
+		s.Status.BGP.PeersV4 = nil
+		for _, ip := range peerIPs {
+			s.Status.BGP.PeersV4 = append(s.Status.BGP.PeersV4, apiv3.CalicoNodePeer{
+				PeerIP: ip,
+				Type:   apiv3.BGPPeerTypeNodePeer,
+				State:  apiv3.BGPSessionStateEstablished,
+			})
+		}
+		cli.statuses.items[s.Name] = s
+	}
+
+	meshEnabled := func() bool {
+		cfg, ok := cli.configs.items["default"]
+		return !ok || cfg.Spec.NodeToNodeMeshEnabled == nil || *cfg.Spec.NodeToNodeMeshEnabled
+	}
+
+	reflectorOf := func(name string) string {
+		return cli.nodes.items[name].Labels[reflectorLabel]
+	}
+
+	BeforeEach(func() {
+		cli = newFakeClient()
+		nodeInformer = cache.NewSharedIndexInformer(&cache.ListWatch{}, &v1.Node{}, 0, cache.Indexers{})
+		sel, err := selector.Parse("all()")
+		Expect(err).NotTo(HaveOccurred())
+		c = &routeReflectorController{
+			ctx:          context.Background(),
+			calicoClient: cli,
+			nodeInformer: nodeInformer,
+			cfg: config.RouteReflectorControllerConfig{
+				ReconcilerPeriod:  time.Minute,
+				ZoneLabel:         "zone",
+				ReflectorsPerZone: 1,
+				NodeSelector:      "all()",
+				ClusterIDBase:     "244.0.0.1",
+			},
+			selector: sel,
+			kickChan: make(chan interface{}, 1),
+		}
+
+		addNode("a", "z1", "10.0.0.1", true)
+		addNode("b", "z1", "10.0.0.2", true)
+		addNode("c", "z2", "10.0.0.3", true)
+		addNode("d", "z2", "10.0.0.4", true)
+	})
+
+	It("should only disable the mesh once the reflectors have established sessions", func() {
+		// The first pass promotes the reflectors and creates their peers and status requests, but
+		// leaves the mesh alone.
+		c.reconcile()
+		Expect(reflectorOf("a")).To(Equal("244.0.0.1"))
+		Expect(reflectorOf("c")).To(Equal("244.0.0.2"))
+		Expect(cli.peers.items).To(HaveLen(3))
+		Expect(cli.statuses.items).To(HaveKey(statusName("a")))
+		Expect(cli.statuses.items).To(HaveKey(statusName("c")))
+		Expect(meshEnabled()).To(BeTrue())
+
+		// Nothing has changed, but the reflectors haven't reported any sessions yet.
+		c.reconcile()
+		Expect(meshEnabled()).To(BeTrue())
+
+		// Client d hasn't peered with its reflector yet.
+		reportSessions("a", "10.0.0.2", "10.0.0.3")
+		reportSessions("c", "10.0.0.1")
+		c.reconcile()
+		Expect(meshEnabled()).To(BeTrue())
+
+		reportSessions("c", "10.0.0.1", "10.0.0.4")
+		c.reconcile()
+		Expect(meshEnabled()).To(BeFalse())
+		Expect(cli.configs.items["default"].Annotations).To(HaveKeyWithValue(meshDisabledAnnotation, "true"))
+	})
+
+	It("should not trust stale session reports", func() {
+		c.reconcile()
+		reportSessions("a", "10.0.0.2", "10.0.0.3")
+		reportSessions("c", "10.0.0.1", "10.0.0.4")
+		s := cli.statuses.items[statusName("c")]
+		s.Status.LastUpdated = metav1.NewTime(time.Now().Add(-2 * statusMaxAge))
+		cli.statuses.items[s.Name] = s
+
+		c.reconcile()
+		Expect(meshEnabled()).To(BeTrue())
+	})
+
+	It("should leave the mesh and the old reflector alone if a promotion fails", func() {
+		c.reconcile()
+		reportSessions("a", "10.0.0.2", "10.0.0.3")
+		reportSessions("c", "10.0.0.1", "10.0.0.4")
+		c.reconcile()
+		Expect(meshEnabled()).To(BeFalse())
+
+		// Replace a with b, but fail to promote b.
+		setReady(nodeInformer, "a", false)
+		cli.nodes.updateErrs["b"] = errors.New("update failed")
+		c.reconcile()
+		Expect(reflectorOf("a")).To(Equal("244.0.0.1"))
+		Expect(reflectorOf("b")).To(BeEmpty())
+		Expect(meshEnabled()).To(BeFalse())
+
+		// Once b is promoted, a is kept until b reports its sessions.
+		delete(cli.nodes.updateErrs, "b")
+		c.reconcile()
+		Expect(reflectorOf("b")).To(Equal("244.0.0.1"))
+		Expect(reflectorOf("a")).To(Equal("244.0.0.1"))
+		Expect(cli.statuses.items).NotTo(HaveKey(statusName("a")))
+		Expect(cli.statuses.items).To(HaveKey(statusName("b")))
+		c.reconcile()
+		Expect(reflectorOf("a")).To(Equal("244.0.0.1"))
+
+		// a is NotReady, so it isn't expected to have a session with b.
+		reportSessions("b", "10.0.0.3")
+		reportSessions("c", "10.0.0.2", "10.0.0.4")
+		c.reconcile()
+		Expect(reflectorOf("a")).To(BeEmpty())
+		Expect(meshEnabled()).To(BeFalse())
+	})
+
+	It("should not demote a reflector until its replacement has established sessions", func() {
+		c.reconcile()
+		reportSessions("a", "10.0.0.2", "10.0.0.3")
+		reportSessions("c", "10.0.0.1", "10.0.0.4")
+		c.reconcile()
+		Expect(meshEnabled()).To(BeFalse())
+
+		// Cordon a, so that b replaces it.  a stays a reflector, peered with b, until b has
+		// established sessions with a and with the other reflector.
+		n := &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "a"},
+			Spec:       v1.NodeSpec{Unschedulable: true},
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+			},
+		}
+		Expect(nodeInformer.GetStore().Update(n)).To(Succeed())
+		c.reconcile()
+		c.reconcile()
+		Expect(reflectorOf("b")).To(Equal("244.0.0.1"))
+		Expect(reflectorOf("a")).To(Equal("244.0.0.1"))
+
+		reportSessions("b", "10.0.0.3")
+		reportSessions("c", "10.0.0.2", "10.0.0.4")
+		c.reconcile()
+		Expect(reflectorOf("a")).To(Equal("244.0.0.1"))
+
+		reportSessions("b", "10.0.0.1", "10.0.0.3")
+		c.reconcile()
+		Expect(reflectorOf("a")).To(BeEmpty())
+		Expect(meshEnabled()).To(BeFalse())
+	})
+
+	It("should not disable the mesh in a pass where a promotion fails", func() {
+		cli.nodes.updateErrs["c"] = errors.New("update failed")
+		c.reconcile()
+		c.reconcile()
+		Expect(reflectorOf("c")).To(BeEmpty())
+		Expect(cli.peers.items).To(BeEmpty())
+		Expect(cli.configs.items).To(BeEmpty())
+	})
+
+	It("should enable the mesh again when a zone loses its reflectors", func() {
+		c.reconcile()
+		reportSessions("a", "10.0.0.2", "10.0.0.3")
+		reportSessions("c", "10.0.0.1", "10.0.0.4")
+		c.reconcile()
+		Expect(meshEnabled()).To(BeFalse())
+
+		setReady(nodeInformer, "c", false)
+		setReady(nodeInformer, "d", false)
+		c.reconcile()
+		Expect(meshEnabled()).To(BeTrue())
+		Expect(cli.configs.items["default"].Annotations).NotTo(HaveKey(meshDisabledAnnotation))
+	})
+})
+
+// setReady adds or updates the Kubernetes node with the given readiness.
+func setReady(nodeInformer cache.SharedIndexInformer, name string, ready bool) {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	n := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: status}},
+		},
+	}
+	Expect(nodeInformer.GetStore().Update(n)).To(Succeed())
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routereflector
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+)
+
+const (
+	// reflectorLabel is set on the nodes that the controller has elected as route reflectors.  Its
+	// value is the node's cluster ID, so that each zone's nodes can select their reflectors.
+	reflectorLabel = "projectcalico.org/route-reflector"
+
+	// managedByLabel marks the BGPPeer resources that the controller owns.
+	managedByLabel = "projectcalico.org/managed-by"
+	managedByValue = "route-reflector-controller"
+
+	// meshPeerName is the name of the BGPPeer that peers the route reflectors with each other.
+	meshPeerName = "route-reflector-mesh"
+)
+
+// candidate is a node as far as route reflector election is concerned.
+type candidate struct {
+	name string
+	zone string
+
+	// eligible is whether the node runs BGP and matches the controller's node selector.
+	eligible bool
+
+	// healthy is whether the node is Ready and not cordoned.
+	healthy bool
+
+	// clusterID is the node's current route reflector cluster ID, and managed is whether it was
+	// set by the controller.  Nodes that were made route reflectors by hand are left alone.
+	clusterID string
+	managed   bool
+}
+
+// zoneTopology is the route reflector topology of a zone.
+type zoneTopology struct {
+	zone       string
+	clusterID  string
+	reflectors []string
+
+	// eligible is whether any of the zone's nodes is eligible to be a route reflector.
+	eligible bool
+}
+
+// topology is the route reflector topology of the cluster.
+type topology struct {
+	zones []zoneTopology
+
+	// clusterIDs maps each elected route reflector to its cluster ID.
+	clusterIDs map[string]string
+}
+
+// healthy returns whether every zone has at least one route reflector, so that every node has
+// something to peer with.  Zones without any eligible nodes are left out, since no reflector can be
+// elected in them, but at least one zone must have a reflector.
+func (t *topology) healthy() bool {
+	found := false
+	for _, z := range t.zones {
+		if !z.eligible {
+			continue
+		}
+		if len(z.reflectors) == 0 {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// electReflectors works out the route reflectors of each zone.  Healthy route reflectors are kept
+// where possible, so that the topology only changes when it has to.  The other reflectors are
+// chosen from the zone's healthy, eligible nodes in name order.
+func electReflectors(nodes []candidate, perZone int, clusterIDBase string) (*topology, error) {
+	base := net.ParseIP(clusterIDBase).To4()
+	if base == nil {
+		return nil, fmt.Errorf("invalid route reflector cluster ID base %q", clusterIDBase)
+	}
+
+	byZone := map[string][]candidate{}
+	for _, n := range nodes {
+		byZone[n.zone] = append(byZone[n.zone], n)
+	}
+	var zones []string
+	for z := range byZone {
+		zones = append(zones, z)
+	}
+	sort.Strings(zones)
+
+	// Keep each zone's cluster ID, if its reflectors already have one.
+	zoneIDs := map[string]string{}
+	usedIDs := map[string]bool{}
+	for _, z := range zones {
+		counts := map[string]int{}
+		for _, n := range byZone[z] {
+			if n.managed && n.clusterID != "" {
+				counts[n.clusterID]++
+			}
+		}
+		best := ""
+		for id, c := range counts {
+			if usedIDs[id] {
+				continue
+			}
+			if best == "" || c > counts[best] || (c == counts[best] && id < best) {
+				best = id
+			}
+		}
+		if best != "" {
+			zoneIDs[z] = best
+			usedIDs[best] = true
+		}
+	}
+
+	t := &topology{clusterIDs: map[string]string{}}
+	next := binary.BigEndian.Uint32(base)
+	for _, z := range zones {
+		if zoneIDs[z] == "" {
+			for {
+				id := make(net.IP, 4)
+				binary.BigEndian.PutUint32(id, next)
+				next++
+				if !usedIDs[id.String()] {
+					zoneIDs[z] = id.String()
+					usedIDs[id.String()] = true
+					break
+				}
+			}
+		}
+
+		candidates := byZone[z]
+		sort.Slice(candidates, func(i, j int) bool {
+			// Current reflectors first, so they keep their role.
+			iCurrent := candidates[i].managed && candidates[i].clusterID == zoneIDs[z]
+			jCurrent := candidates[j].managed && candidates[j].clusterID == zoneIDs[z]
+			if iCurrent != jCurrent {
+				return iCurrent
+			}
+			return candidates[i].name < candidates[j].name
+		})
+
+		zt := zoneTopology{zone: z, clusterID: zoneIDs[z]}
+		for _, n := range candidates {
+			zt.eligible = zt.eligible || n.eligible
+		}
+		for _, n := range candidates {
+			if len(zt.reflectors) == perZone {
+				break
+			}
+			if !n.eligible || !n.healthy || (n.clusterID != "" && !n.managed) {
+				continue
+			}
+			zt.reflectors = append(zt.reflectors, n.name)
+			t.clusterIDs[n.name] = zt.clusterID
+		}
+		t.zones = append(t.zones, zt)
+	}
+	return t, nil
+}
+
+// desiredPeers returns the BGPPeer resources for the topology: one that peers the route reflectors
+// with each other, and one for each zone that peers the zone's other nodes with its reflectors.
+func desiredPeers(t *topology, zoneLabel string) []apiv3.BGPPeer {
+	peers := []apiv3.BGPPeer{
+		newPeer(meshPeerName,
+			fmt.Sprintf("has(%s)", reflectorLabel),
+			fmt.Sprintf("has(%s)", reflectorLabel)),
+	}
+	for _, z := range t.zones {
+		nodeSelector := fmt.Sprintf("!has(%s) && %s == '%s'", reflectorLabel, zoneLabel, z.zone)
+		if z.zone == "" {
+			nodeSelector = fmt.Sprintf("!has(%s) && !has(%s)", reflectorLabel, zoneLabel)
+		}
+		peers = append(peers, newPeer(zonePeerName(z.clusterID),
+			nodeSelector,
+			fmt.Sprintf("%s == '%s'", reflectorLabel, z.clusterID)))
+	}
+	return peers
+}
+
+// zonePeerName returns the name of the BGPPeer for the zone with the given cluster ID, e.g.
+// "route-reflector-244-0-0-1".
+func zonePeerName(clusterID string) string {
+	return "route-reflector-" + strings.ReplaceAll(clusterID, ".", "-")
+}
+
+func newPeer(name, nodeSelector, peerSelector string) apiv3.BGPPeer {
+	p := apiv3.NewBGPPeer()
+	p.Name = name
+	p.Labels = map[string]string{managedByLabel: managedByValue}
+	p.Spec.NodeSelector = nodeSelector
+	p.Spec.PeerSelector = peerSelector
+	return *p
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routereflector
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+func node(name, zone string) candidate {
+	return candidate{name: name, zone: zone, eligible: true, healthy: true}
+}
+
+func reflector(name, zone, clusterID string) candidate {
+	n := node(name, zone)
+	n.clusterID = clusterID
+	n.managed = true
+	return n
+}
+
+var _ = Describe("Route reflector election", func() {
+	It("should elect reflectors in each zone in name order", func() {
+		t, err := electReflectors([]candidate{
+			node("c", "a"), node("b", "a"), node("a", "a"),
+			node("e", "b"), node("d", "b"),
+		}, 2, "244.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.zones).To(Equal([]zoneTopology{
+			{zone: "a", clusterID: "244.0.0.1", reflectors: []string{"a", "b"}, eligible: true},
+			{zone: "b", clusterID: "244.0.0.2", reflectors: []string{"d", "e"}, eligible: true},
+		}))
+		Expect(t.clusterIDs).To(Equal(map[string]string{
+			"a": "244.0.0.1", "b": "244.0.0.1", "d": "244.0.0.2", "e": "244.0.0.2",
+		}))
+		Expect(t.healthy()).To(BeTrue())
+	})
+
+	It("should keep the current reflectors and cluster IDs", func() {
+		t, err := electReflectors([]candidate{
+			node("a", "a"), reflector("z", "a", "244.0.0.7"),
+			node("b", "b"),
+		}, 1, "244.0.0.7")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.zones).To(Equal([]zoneTopology{
+			{zone: "a", clusterID: "244.0.0.7", reflectors: []string{"z"}, eligible: true},
+			{zone: "b", clusterID: "244.0.0.8", reflectors: []string{"b"}, eligible: true},
+		}))
+	})
+
+	It("should replace unhealthy and ineligible reflectors", func() {
+		notReady := reflector("a", "a", "244.0.0.1")
+		notReady.healthy = false
+		excluded := node("b", "a")
+		excluded.eligible = false
+		t, err := electReflectors([]candidate{notReady, excluded, node("c", "a")}, 1, "244.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.zones).To(Equal([]zoneTopology{
+			{zone: "a", clusterID: "244.0.0.1", reflectors: []string{"c"}, eligible: true},
+		}))
+	})
+
+	It("should leave manually configured reflectors alone", func() {
+		manual := node("a", "a")
+		manual.clusterID = "10.0.0.1"
+		t, err := electReflectors([]candidate{manual, node("b", "a")}, 2, "244.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.zones).To(Equal([]zoneTopology{
+			{zone: "a", clusterID: "244.0.0.1", reflectors: []string{"b"}, eligible: true},
+		}))
+		Expect(t.clusterIDs).NotTo(HaveKey("a"))
+	})
+
+	It("should report an unhealthy topology when a zone has no reflector", func() {
+		cordoned := node("b", "b")
+		cordoned.healthy = false
+		t, err := electReflectors([]candidate{node("a", "a"), cordoned}, 1, "244.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.healthy()).To(BeFalse())
+
+		t, err = electReflectors(nil, 1, "244.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.healthy()).To(BeFalse())
+	})
+
+	It("should leave zones without eligible nodes out of the topology's health", func() {
+		excluded := node("b", "b")
+		excluded.eligible = false
+		t, err := electReflectors([]candidate{node("a", "a"), excluded}, 1, "244.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.zones[1]).To(Equal(zoneTopology{zone: "b", clusterID: "244.0.0.2"}))
+		Expect(t.healthy()).To(BeTrue())
+
+		// But a topology with no reflectors at all is never healthy.
+		t, err = electReflectors([]candidate{excluded}, 1, "244.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.healthy()).To(BeFalse())
+	})
+
+	It("should reject an invalid cluster ID base", func() {
+		_, err := electReflectors([]candidate{node("a", "a")}, 1, "fd00::1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should peer each zone with its reflectors", func() {
+		t := &topology{zones: []zoneTopology{
+			{zone: "a", clusterID: "244.0.0.1", reflectors: []string{"a"}},
+			{zone: "", clusterID: "244.0.0.2", reflectors: []string{"b"}},
+		}}
+		peers := desiredPeers(t, "topology.kubernetes.io/zone")
+		Expect(peers).To(HaveLen(3))
+
+		Expect(peers[0].Name).To(Equal(meshPeerName))
+		Expect(peers[0].Spec.NodeSelector).To(Equal("has(projectcalico.org/route-reflector)"))
+		Expect(peers[0].Spec.PeerSelector).To(Equal("has(projectcalico.org/route-reflector)"))
+
+		Expect(peers[1].Name).To(Equal("route-reflector-244-0-0-1"))
+		Expect(peers[1].Labels).To(HaveKeyWithValue(managedByLabel, managedByValue))
+		Expect(peers[1].Spec.NodeSelector).To(Equal("!has(projectcalico.org/route-reflector) && topology.kubernetes.io/zone == 'a'"))
+		Expect(peers[1].Spec.PeerSelector).To(Equal("projectcalico.org/route-reflector == '244.0.0.1'"))
+
+		Expect(peers[2].Spec.NodeSelector).To(Equal("!has(projectcalico.org/route-reflector) && !has(topology.kubernetes.io/zone)"))
+	})
+
+	It("should only use Ready, schedulable nodes", func() {
+		n := &v1.Node{Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+			{Type: v1.NodeReady, Status: v1.ConditionTrue},
+		}}}
+		Expect(nodeIsHealthy(n)).To(BeTrue())
+		n.Spec.Unschedulable = true
+		Expect(nodeIsHealthy(n)).To(BeFalse())
+		n.Spec.Unschedulable = false
+		n.Status.Conditions[0].Status = v1.ConditionFalse
+		Expect(nodeIsHealthy(n)).To(BeFalse())
+		Expect(nodeIsHealthy(&v1.Node{})).To(BeFalse())
+	})
+})
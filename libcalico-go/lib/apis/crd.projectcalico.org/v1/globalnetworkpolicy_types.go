@@ -29,4 +29,5 @@ type GlobalNetworkPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 	Spec              v3.GlobalNetworkPolicySpec `json:"spec,omitempty"`
+	Status            v3.PolicyStatus            `json:"status,omitempty"`
 }
@@ -28,4 +28,5 @@ type NetworkPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 	Spec              v3.NetworkPolicySpec `json:"spec,omitempty"`
+	Status            v3.PolicyStatus      `json:"status,omitempty"`
 }
@@ -0,0 +1,93 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+)
+
+const (
+	KindFelixConfigReport     = "FelixConfigReport"
+	KindFelixConfigReportList = "FelixConfigReportList"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FelixConfigReport is the configuration that Felix is running with on a particular node, as reported
+// by Felix.  It has the same name as the node.
+type FelixConfigReport struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Specification of the FelixConfigReport.
+	Spec FelixConfigReportSpec `json:"spec,omitempty"`
+}
+
+// FelixConfigReportSpec contains the specification for a FelixConfigReport resource.
+type FelixConfigReportSpec struct {
+	// Node is the node that the report is from.
+	Node string `json:"node"`
+	// Time is when Felix last updated the report.
+	Time metav1.Time `json:"time"`
+	// Parameters are the configuration parameters that are not set to their default values, sorted
+	// by name.
+	// +optional
+	Parameters []FelixConfigParameter `json:"parameters,omitempty"`
+}
+
+// FelixConfigParameter is the value of a Felix configuration parameter, and where it comes from.
+type FelixConfigParameter struct {
+	// Name is the name of the parameter.
+	Name string `json:"name"`
+	// Value is the value of the parameter, as it was given to Felix.
+	Value string `json:"value"`
+	// Source is where Felix took the value from, for example "datastore (per-host)" or
+	// "environment variable".
+	Source string `json:"source"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FelixConfigReportList contains a list of FelixConfigReport resources.
+type FelixConfigReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []FelixConfigReport `json:"items"`
+}
+
+// NewFelixConfigReport creates a new (zeroed) FelixConfigReport struct with the TypeMetadata initialised
+// to the current version.
+func NewFelixConfigReport() *FelixConfigReport {
+	return &FelixConfigReport{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindFelixConfigReport,
+			APIVersion: apiv3.GroupVersionCurrent,
+		},
+	}
+}
+
+// NewFelixConfigReportList creates a new (zeroed) FelixConfigReportList struct with the TypeMetadata
+// initialised to the current version.
+func NewFelixConfigReportList() *FelixConfigReportList {
+	return &FelixConfigReportList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindFelixConfigReportList,
+			APIVersion: apiv3.GroupVersionCurrent,
+		},
+	}
+}
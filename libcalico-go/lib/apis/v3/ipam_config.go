@@ -51,6 +51,10 @@ type IPAMConfigSpec struct {
 	// +kubebuilder:validation:Maximum:=2147483647
 	// +optional
 	MaxBlocksPerHost int `json:"maxBlocksPerHost,omitempty"`
+
+	// EventLog, if true, records each address assignment and release as an IPAMEvent.
+	// +optional
+	EventLog bool `json:"eventLog,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
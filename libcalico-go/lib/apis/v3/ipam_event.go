@@ -0,0 +1,102 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+)
+
+const (
+	KindIPAMEvent     = "IPAMEvent"
+	KindIPAMEventList = "IPAMEventList"
+)
+
+type IPAMEventType string
+
+const (
+	IPAMEventTypeAssign  IPAMEventType = "Assign"
+	IPAMEventTypeRelease IPAMEventType = "Release"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPAMEvent records the assignment or release of an IP address, when the IPAM event log is enabled.
+type IPAMEvent struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Specification of the IPAMEvent.
+	Spec IPAMEventSpec `json:"spec,omitempty"`
+}
+
+// IPAMEventSpec contains the specification for an IPAMEvent resource.
+type IPAMEventSpec struct {
+	// Type is whether the address was assigned or released.
+	Type IPAMEventType `json:"type"`
+	// Time is when the address was assigned or released.
+	Time metav1.Time `json:"time"`
+	// IP is the address that was assigned or released.
+	IP string `json:"ip"`
+
+	// HandleID is the IPAM handle that the address was, or is, allocated to.
+	// +optional
+	HandleID string `json:"handleID,omitempty"`
+	// Namespace is the namespace of the pod that the address was allocated to, if any.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Pod is the name of the pod that the address was allocated to, if any.
+	// +optional
+	Pod string `json:"pod,omitempty"`
+	// Node is the node that the address was allocated to.
+	// +optional
+	Node string `json:"node,omitempty"`
+	// ContainerID is the ID of the container that the address was allocated to, if known.
+	// +optional
+	ContainerID string `json:"containerID,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPAMEventList contains a list of IPAMEvent resources.
+type IPAMEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []IPAMEvent `json:"items"`
+}
+
+// NewIPAMEvent creates a new (zeroed) IPAMEvent struct with the TypeMetadata initialised to the current
+// version.
+func NewIPAMEvent() *IPAMEvent {
+	return &IPAMEvent{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindIPAMEvent,
+			APIVersion: apiv3.GroupVersionCurrent,
+		},
+	}
+}
+
+// NewIPAMEventList creates a new (zeroed) IPAMEventList struct with the TypeMetadata initialised to the current
+// version.
+func NewIPAMEventList() *IPAMEventList {
+	return &IPAMEventList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindIPAMEventList,
+			APIVersion: apiv3.GroupVersionCurrent,
+		},
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+)
+
+const (
+	KindIPAMQuotaCounter     = "IPAMQuotaCounter"
+	KindIPAMQuotaCounterList = "IPAMQuotaCounterList"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPAMQuotaCounter serializes the assignment of addresses under one of an IP pool's quotas, for a
+// single namespace or node.  Each assignment reserves its addresses by updating the counter before it
+// writes them to their block, so that concurrent assignments can't together exceed the quota.
+type IPAMQuotaCounter struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Specification of the IPAMQuotaCounter.
+	Spec IPAMQuotaCounterSpec `json:"spec,omitempty"`
+}
+
+// IPAMQuotaCounterSpec contains the specification for an IPAMQuotaCounter resource.
+type IPAMQuotaCounterSpec struct {
+	// Pool is the name of the IP pool that the quota belongs to.
+	Pool string `json:"pool"`
+	// Namespace is the namespace that the quota limits, for a quota with a namespace selector.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Node is the node that the quota limits, for a quota with a node selector.
+	// +optional
+	Node string `json:"node,omitempty"`
+	// Reservations are the addresses that have recently been reserved under the quota.  They
+	// count against the quota until they appear in their blocks, or until they expire.
+	// +optional
+	Reservations []IPAMQuotaReservation `json:"reservations,omitempty"`
+}
+
+// IPAMQuotaReservation is an address that has been reserved under a quota.
+type IPAMQuotaReservation struct {
+	// IP is the reserved address.
+	IP string `json:"ip"`
+	// Time is when the address was reserved.
+	Time metav1.Time `json:"time"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPAMQuotaCounterList contains a list of IPAMQuotaCounter resources.
+type IPAMQuotaCounterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []IPAMQuotaCounter `json:"items"`
+}
+
+// NewIPAMQuotaCounter creates a new (zeroed) IPAMQuotaCounter struct with the TypeMetadata initialised to the current
+// version.
+func NewIPAMQuotaCounter() *IPAMQuotaCounter {
+	return &IPAMQuotaCounter{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindIPAMQuotaCounter,
+			APIVersion: apiv3.GroupVersionCurrent,
+		},
+	}
+}
+
+// NewIPAMQuotaCounterList creates a new (zeroed) IPAMQuotaCounterList struct with the TypeMetadata initialised to the current
+// version.
+func NewIPAMQuotaCounterList() *IPAMQuotaCounterList {
+	return &IPAMQuotaCounterList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindIPAMQuotaCounterList,
+			APIVersion: apiv3.GroupVersionCurrent,
+		},
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+)
+
+const (
+	KindPolicyStatusReport     = "PolicyStatusReport"
+	KindPolicyStatusReportList = "PolicyStatusReportList"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicyStatusReport is the status of a policy on a particular node, as reported by Felix.  The reports
+// are aggregated onto the status of the policy by kube-controllers.
+type PolicyStatusReport struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Specification of the PolicyStatusReport.
+	Spec PolicyStatusReportSpec `json:"spec,omitempty"`
+}
+
+// PolicyStatusReportSpec contains the specification for a PolicyStatusReport resource.
+type PolicyStatusReportSpec struct {
+	// Node is the node that the report is from.
+	Node string `json:"node"`
+	// Tier is the tier of the policy.
+	Tier string `json:"tier"`
+	// Policy is the name of the policy, prefixed with its namespace and a "/" if it is namespaced.
+	Policy string `json:"policy"`
+	// Status is one of "pending", "programmed" or "error".
+	Status string `json:"status"`
+	// ContentHash identifies the version of the policy that the status applies to.
+	// +optional
+	ContentHash string `json:"contentHash,omitempty"`
+	// Errors is the number of consecutive failed attempts to program the policy.
+	// +optional
+	Errors int `json:"errors,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicyStatusReportList contains a list of PolicyStatusReport resources.
+type PolicyStatusReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []PolicyStatusReport `json:"items"`
+}
+
+// NewPolicyStatusReport creates a new (zeroed) PolicyStatusReport struct with the TypeMetadata initialised
+// to the current version.
+func NewPolicyStatusReport() *PolicyStatusReport {
+	return &PolicyStatusReport{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindPolicyStatusReport,
+			APIVersion: apiv3.GroupVersionCurrent,
+		},
+	}
+}
+
+// NewPolicyStatusReportList creates a new (zeroed) PolicyStatusReportList struct with the TypeMetadata
+// initialised to the current version.
+func NewPolicyStatusReportList() *PolicyStatusReportList {
+	return &PolicyStatusReportList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindPolicyStatusReportList,
+			APIVersion: apiv3.GroupVersionCurrent,
+		},
+	}
+}
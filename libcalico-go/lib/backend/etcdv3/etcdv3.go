@@ -27,6 +27,7 @@ import (
 	"go.etcd.io/etcd/client/pkg/v3/srv"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc"
 
 	calicotls "github.com/projectcalico/calico/crypto/pkg/tls"
 
@@ -53,6 +54,7 @@ const (
 
 type etcdV3Client struct {
 	etcdClient *clientv3.Client
+	leases     *leaseManager
 }
 
 func NewEtcdV3Client(config *apiconfig.EtcdConfig) (api.Client, error) {
@@ -94,6 +96,7 @@ func NewEtcdV3Client(config *apiconfig.EtcdConfig) (api.Client, error) {
 		return nil, fmt.Errorf("Cannot mix inline certificate-key and certificate / key files")
 	}
 
+	var reloader *tlsFileReloader
 	if haveInline {
 		tlsInfo := &TlsInlineCertKey{
 			CACert: config.EtcdCACert,
@@ -101,26 +104,22 @@ func NewEtcdV3Client(config *apiconfig.EtcdConfig) (api.Client, error) {
 			Key:    config.EtcdKey,
 		}
 		tlsConfig, err = tlsInfo.ClientConfigInlineCertKey()
+		if err == nil {
+			applyBaseTLSSettings(tlsConfig)
+		}
 	} else {
-		tlsInfo := &transport.TLSInfo{
+		tlsInfo := transport.TLSInfo{
 			TrustedCAFile: config.EtcdCACertFile,
 			CertFile:      config.EtcdCertFile,
 			KeyFile:       config.EtcdKeyFile,
 		}
-		tlsConfig, err = tlsInfo.ClientConfig()
+		reloader, tlsConfig, err = newTLSFileReloader(tlsInfo, applyBaseTLSSettings)
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize etcdv3 client: %+v", err)
 	}
 
-	baseTLSConfig := calicotls.NewTLSConfig()
-	tlsConfig.MaxVersion = baseTLSConfig.MaxVersion
-	tlsConfig.MinVersion = baseTLSConfig.MinVersion
-	tlsConfig.CipherSuites = baseTLSConfig.CipherSuites
-	tlsConfig.CurvePreferences = baseTLSConfig.CurvePreferences
-	tlsConfig.Renegotiation = baseTLSConfig.Renegotiation
-
 	// Build the etcdv3 config.
 	cfg := clientv3.Config{
 		Endpoints:            etcdLocation,
@@ -130,6 +129,13 @@ func NewEtcdV3Client(config *apiconfig.EtcdConfig) (api.Client, error) {
 		DialKeepAliveTimeout: keepaliveTimeout,
 	}
 
+	// If the certificates were loaded from files, use credentials that pick up changes to
+	// those files on each new connection.  The etcd client only uses TLS for https endpoints,
+	// so we only override its credentials if that's all we have.
+	if haveFiles && allEndpointsUseTLS(etcdLocation) {
+		cfg.DialOptions = append(cfg.DialOptions, grpc.WithTransportCredentials(reloader.transportCredentials()))
+	}
+
 	// Plumb through the username and password if both are configured.
 	if config.EtcdUsername != "" && config.EtcdPassword != "" {
 		cfg.Username = config.EtcdUsername
@@ -141,7 +147,26 @@ func NewEtcdV3Client(config *apiconfig.EtcdConfig) (api.Client, error) {
 		return nil, err
 	}
 
-	return &etcdV3Client{etcdClient: client}, nil
+	return &etcdV3Client{etcdClient: client, leases: newLeaseManager(client.Lease)}, nil
+}
+
+// applyBaseTLSSettings applies Calico's standard TLS version and cipher settings.
+func applyBaseTLSSettings(tlsConfig *tls.Config) {
+	baseTLSConfig := calicotls.NewTLSConfig()
+	tlsConfig.MaxVersion = baseTLSConfig.MaxVersion
+	tlsConfig.MinVersion = baseTLSConfig.MinVersion
+	tlsConfig.CipherSuites = baseTLSConfig.CipherSuites
+	tlsConfig.CurvePreferences = baseTLSConfig.CurvePreferences
+	tlsConfig.Renegotiation = baseTLSConfig.Renegotiation
+}
+
+func allEndpointsUseTLS(endpoints []string) bool {
+	for _, ep := range endpoints {
+		if !strings.HasPrefix(ep, "https://") {
+			return false
+		}
+	}
+	return true
 }
 
 // Create an entry in the datastore.  If the entry already exists, this will return
@@ -156,21 +181,20 @@ func (c *etcdV3Client) Create(ctx context.Context, d *model.KVPair) (*model.KVPa
 	}
 	logCxt = logCxt.WithField("etcdv3-etcdKey", key)
 
-	putOpts, err := c.getTTLOption(ctx, d)
-	if err != nil {
-		return nil, err
-	}
-
 	// Checking for 0 version of the etcdKey, which means it doesn't exists yet,
 	// and if it does, get the current value.
 	logCxt.Debug("Performing etcdv3 transaction for Create request")
-	txnResp, err := c.etcdClient.Txn(ctx).If(
-		clientv3.Compare(clientv3.Version(key), "=", 0),
-	).Then(
-		clientv3.OpPut(key, value, putOpts...),
-	).Else(
-		clientv3.OpGet(key),
-	).Commit()
+	var txnResp *clientv3.TxnResponse
+	err = c.putWithTTL(ctx, key, d, func(putOpts []clientv3.OpOption) (err error) {
+		txnResp, err = c.etcdClient.Txn(ctx).If(
+			clientv3.Compare(clientv3.Version(key), "=", 0),
+		).Then(
+			clientv3.OpPut(key, value, putOpts...),
+		).Else(
+			clientv3.OpGet(key),
+		).Commit()
+		return
+	})
 	if err != nil {
 		logCxt.WithError(err).Warning("Create failed")
 		return nil, cerrors.ErrorDatastoreError{Err: err}
@@ -210,11 +234,6 @@ func (c *etcdV3Client) Update(ctx context.Context, d *model.KVPair) (*model.KVPa
 	}
 	logCxt = logCxt.WithField("etcdv3-etcdKey", key)
 
-	opts, err := c.getTTLOption(ctx, d)
-	if err != nil {
-		return nil, err
-	}
-
 	// ResourceVersion must be set for an Update.
 	rev, err := parseRevision(d.Revision)
 	if err != nil {
@@ -223,14 +242,17 @@ func (c *etcdV3Client) Update(ctx context.Context, d *model.KVPair) (*model.KVPa
 	conds := []clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(key), "=", rev)}
 
 	logCxt.Debug("Performing etcdv3 transaction for Update request")
-	txnResp, err := c.etcdClient.Txn(ctx).If(
-		conds...,
-	).Then(
-		clientv3.OpPut(key, value, opts...),
-	).Else(
-		clientv3.OpGet(key),
-	).Commit()
-
+	var txnResp *clientv3.TxnResponse
+	err = c.putWithTTL(ctx, key, d, func(opts []clientv3.OpOption) (err error) {
+		txnResp, err = c.etcdClient.Txn(ctx).If(
+			conds...,
+		).Then(
+			clientv3.OpPut(key, value, opts...),
+		).Else(
+			clientv3.OpGet(key),
+		).Commit()
+		return
+	})
 	if err != nil {
 		logCxt.WithError(err).Warning("Update failed")
 		return nil, cerrors.ErrorDatastoreError{Err: err}
@@ -272,13 +294,12 @@ func (c *etcdV3Client) Apply(ctx context.Context, d *model.KVPair) (*model.KVPai
 		return nil, err
 	}
 
-	putOpts, err := c.getTTLOption(ctx, d)
-	if err != nil {
-		return nil, err
-	}
-
 	logCxt.Debug("Performing etcdv3 Put for Apply request")
-	resp, err := c.etcdClient.Put(ctx, key, value, putOpts...)
+	var resp *clientv3.PutResponse
+	err = c.putWithTTL(ctx, key, d, func(putOpts []clientv3.OpOption) (err error) {
+		resp, err = c.etcdClient.Put(ctx, key, value, putOpts...)
+		return
+	})
 	if err != nil {
 		logCxt.WithError(err).Warning("Apply failed")
 		return nil, cerrors.ErrorDatastoreError{Err: err}
@@ -512,21 +533,52 @@ func (c *etcdV3Client) IsClean() (bool, error) {
 	return len(resp.Kvs) == 0, nil
 }
 
-// getTTLOption returns a OpOption slice containing a Lease granted for the TTL.
-func (c *etcdV3Client) getTTLOption(ctx context.Context, d *model.KVPair) ([]clientv3.OpOption, error) {
-	putOpts := []clientv3.OpOption{}
-
-	if d.TTL != 0 {
-		resp, err := c.etcdClient.Lease.Grant(ctx, int64(d.TTL.Seconds()))
+// putWithTTL calls put with the options required to write the KVPair to the given etcd key.  If
+// the KVPair has a TTL then the options attach the shared lease for the TTL; if that lease no
+// longer exists (e.g. because it was revoked) then the lease is discarded and the put is retried
+// once with a newly granted lease.
+func (c *etcdV3Client) putWithTTL(ctx context.Context, key string, d *model.KVPair, put func([]clientv3.OpOption) error) error {
+	for attempt := 0; ; attempt++ {
+		putOpts, leaseID, err := c.getTTLOption(ctx, d)
 		if err != nil {
-			log.WithError(err).Error("Failed to grant a lease")
-			return nil, cerrors.ErrorDatastoreError{Err: err}
+			return err
+		}
+		err = put(putOpts)
+		if attempt == 0 && leaseID != clientv3.NoLease && isLeaseNotFound(err) {
+			log.WithFields(log.Fields{"key": key, "lease": leaseID}).Info("Lease no longer exists, granting a new one")
+			c.leases.invalidate(ttlSeconds(d), leaseID)
+			continue
 		}
+		return err
+	}
+}
+
+// getTTLOption returns a OpOption slice containing the shared Lease for the TTL, along with
+// the ID of that lease.
+func (c *etcdV3Client) getTTLOption(ctx context.Context, d *model.KVPair) ([]clientv3.OpOption, clientv3.LeaseID, error) {
+	putOpts := []clientv3.OpOption{}
+
+	if d.TTL == 0 {
+		return putOpts, clientv3.NoLease, nil
+	}
 
-		putOpts = append(putOpts, clientv3.WithLease(resp.ID))
+	id, err := c.leases.leaseFor(ctx, ttlSeconds(d))
+	if err != nil {
+		log.WithError(err).Error("Failed to grant a lease")
+		return nil, clientv3.NoLease, err
 	}
+	putOpts = append(putOpts, clientv3.WithLease(id))
 
-	return putOpts, nil
+	return putOpts, id, nil
+}
+
+// ttlSeconds returns the TTL of the KVPair in whole seconds, as required for an etcd lease.
+// etcd enforces its own minimum TTL, so we only need to avoid rounding a sub-second TTL to 0.
+func ttlSeconds(d *model.KVPair) int64 {
+	if secs := int64(d.TTL.Seconds()); secs > 0 {
+		return secs
+	}
+	return 1
 }
 
 // getKeyValueStrings returns the etcdv3 etcdKey and serialized value calculated from the
@@ -0,0 +1,103 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdv3
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// leaseManager shares leases between the TTL'd writes of a client, rather than granting a new
+// lease for every write.  A shared lease can't be kept alive for the life of the client, since
+// then no key would expire while the client is running.  Instead, writes with a given TTL share
+// the current lease for that TTL for a rotation window of half the TTL, after which the next write
+// grants a fresh lease.  Each lease is granted for the TTL plus the window, so a key expires
+// between TTL and 1.5*TTL after it was last written, and the client grants at most two leases
+// per TTL value per TTL period, however many keys it writes.
+type leaseManager struct {
+	lease clientv3.Lease
+
+	// lock is held while looking up and granting leases, so that concurrent writes with the
+	// same TTL share one lease, and a write never uses a lease that has been replaced.
+	lock   sync.Mutex
+	leases map[int64]sharedLease
+}
+
+type sharedLease struct {
+	id clientv3.LeaseID
+	// rotateAt is the time after which writes must use a new lease.
+	rotateAt time.Time
+}
+
+func newLeaseManager(lease clientv3.Lease) *leaseManager {
+	return &leaseManager{
+		lease:  lease,
+		leases: map[int64]sharedLease{},
+	}
+}
+
+// rotationWindow returns how long writes with the given TTL share a lease.
+func rotationWindow(ttlSecs int64) int64 {
+	if ttlSecs < 2 {
+		return 1
+	}
+	return ttlSecs / 2
+}
+
+// leaseFor returns the lease to use for a write with the given TTL, granting a new lease if the
+// current one has reached the end of its rotation window.
+func (m *leaseManager) leaseFor(ctx context.Context, ttlSecs int64) (clientv3.LeaseID, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	start := time.Now()
+	if l, ok := m.leases[ttlSecs]; ok && start.Before(l.rotateAt) {
+		return l.id, nil
+	}
+
+	window := rotationWindow(ttlSecs)
+	resp, err := m.lease.Grant(ctx, ttlSecs+window)
+	if err != nil {
+		return clientv3.NoLease, err
+	}
+	log.WithFields(log.Fields{"lease": resp.ID, "ttl": ttlSecs}).Debug("Granted shared lease")
+	m.leases[ttlSecs] = sharedLease{
+		id:       resp.ID,
+		rotateAt: start.Add(time.Duration(window) * time.Second),
+	}
+	return resp.ID, nil
+}
+
+// invalidate forgets the given lease, if it is still the current lease for the TTL.  Called if
+// the lease no longer exists, for example because it has been revoked.
+func (m *leaseManager) invalidate(ttlSecs int64, id clientv3.LeaseID) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.leases[ttlSecs].id == id {
+		delete(m.leases, ttlSecs)
+	}
+}
+
+// isLeaseNotFound returns true if the error indicates that a put referenced a lease that has
+// already expired.
+func isLeaseNotFound(err error) bool {
+	return errors.Is(err, rpctypes.ErrLeaseNotFound)
+}
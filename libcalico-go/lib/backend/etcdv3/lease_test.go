@@ -0,0 +1,127 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdv3
+
+import (
+	"context"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+var _ = Describe("etcdv3 shared leases", func() {
+	var (
+		dir    string
+		server *embed.Etcd
+		c      *etcdV3Client
+		ctx    context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "etcdv3-lease")
+		Expect(err).NotTo(HaveOccurred())
+		var endpoint string
+		server, endpoint = startEmbeddedEtcd(dir, nil)
+		client, err := NewEtcdV3Client(&apiconfig.EtcdConfig{EtcdEndpoints: endpoint})
+		Expect(err).NotTo(HaveOccurred())
+		c = client.(*etcdV3Client)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		_ = c.etcdClient.Close()
+		server.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	apply := func(name string, ttl time.Duration) clientv3.LeaseID {
+		_, err := c.Apply(ctx, &model.KVPair{Key: model.GlobalConfigKey{Name: name}, Value: "value", TTL: ttl})
+		Expect(err).NotTo(HaveOccurred())
+		key, err := model.KeyToDefaultPath(model.GlobalConfigKey{Name: name})
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := c.etcdClient.Get(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Kvs).To(HaveLen(1))
+		return clientv3.LeaseID(resp.Kvs[0].Lease)
+	}
+
+	numLeases := func() int {
+		resp, err := c.etcdClient.Leases(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		return len(resp.Leases)
+	}
+
+	exists := func(name string) func() int64 {
+		return func() int64 {
+			key, err := model.KeyToDefaultPath(model.GlobalConfigKey{Name: name})
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := c.etcdClient.Get(ctx, key)
+			Expect(err).NotTo(HaveOccurred())
+			return resp.Count
+		}
+	}
+
+	It("should share a lease between writes with the same TTL", func() {
+		first := apply("a", 30*time.Second)
+		Expect(first).NotTo(Equal(clientv3.NoLease))
+		Expect(apply("a", 30*time.Second)).To(Equal(first))
+		Expect(apply("b", 30*time.Second)).To(Equal(first))
+		Expect(apply("c", 60*time.Second)).NotTo(Equal(first))
+		Expect(apply("d", 0)).To(Equal(clientv3.NoLease))
+		Expect(numLeases()).To(Equal(2))
+	})
+
+	It("should grant a new lease once the rotation window has passed", func() {
+		first := apply("a", 2*time.Second)
+		Expect(apply("b", 2*time.Second)).To(Equal(first))
+		time.Sleep(1100 * time.Millisecond)
+		second := apply("b", 2*time.Second)
+		Expect(second).NotTo(Equal(first))
+		Expect(apply("a", 2*time.Second)).To(Equal(second))
+	})
+
+	It("should expire keys that are not rewritten, even if other keys are", func() {
+		apply("a", 2*time.Second)
+		apply("b", 2*time.Second)
+		deadline := time.Now().Add(6 * time.Second)
+		for time.Now().Before(deadline) {
+			apply("b", 2*time.Second)
+			time.Sleep(500 * time.Millisecond)
+		}
+		Expect(exists("a")()).To(BeEquivalentTo(0))
+		Expect(exists("b")()).To(BeEquivalentTo(1))
+		Eventually(exists("b"), 6*time.Second, 500*time.Millisecond).Should(BeEquivalentTo(0))
+	})
+
+	It("should grant a new lease if the shared lease has been revoked", func() {
+		first := apply("a", 30*time.Second)
+		_, err := c.etcdClient.Revoke(ctx, first)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Revoking the lease deletes the key; the next write should succeed with a new lease.
+		second := apply("a", 30*time.Second)
+		Expect(second).NotTo(Equal(clientv3.NoLease))
+		Expect(second).NotTo(Equal(first))
+		Expect(apply("b", 30*time.Second)).To(Equal(second))
+	})
+})
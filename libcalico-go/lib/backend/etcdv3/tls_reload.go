@@ -0,0 +1,156 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdv3
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	"google.golang.org/grpc/credentials"
+)
+
+// tlsFileReloader builds the client TLS configuration from the certificate, key and CA files and
+// rebuilds it whenever one of those files changes, so that rotated certificates are picked up
+// without restarting.  The files are checked at each TLS handshake, i.e. whenever the etcd
+// client (re)connects.
+type tlsFileReloader struct {
+	info     transport.TLSInfo
+	finalize func(*tls.Config)
+
+	lock   sync.Mutex
+	stamps []fileStamp
+	creds  credentials.TransportCredentials
+}
+
+// fileStamp records enough about a file to detect that it has been replaced.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// newTLSFileReloader returns a reloader for the given files.  finalize is applied to each TLS
+// configuration that is built.  An error is returned if the initial configuration can't be built.
+func newTLSFileReloader(info transport.TLSInfo, finalize func(*tls.Config)) (*tlsFileReloader, *tls.Config, error) {
+	r := &tlsFileReloader{info: info, finalize: finalize}
+	stamps := r.statFiles()
+	tlsConfig, err := r.build()
+	if err != nil {
+		return nil, nil, err
+	}
+	r.stamps = stamps
+	r.creds = credentials.NewTLS(tlsConfig)
+	return r, tlsConfig, nil
+}
+
+func (r *tlsFileReloader) files() []string {
+	return []string{r.info.CertFile, r.info.KeyFile, r.info.TrustedCAFile}
+}
+
+func (r *tlsFileReloader) statFiles() []fileStamp {
+	var stamps []fileStamp
+	for _, f := range r.files() {
+		var s fileStamp
+		if f != "" {
+			if fi, err := os.Stat(f); err == nil {
+				s = fileStamp{modTime: fi.ModTime(), size: fi.Size()}
+			}
+		}
+		stamps = append(stamps, s)
+	}
+	return stamps
+}
+
+func (r *tlsFileReloader) build() (*tls.Config, error) {
+	tlsConfig, err := r.info.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	r.finalize(tlsConfig)
+	return tlsConfig, nil
+}
+
+// current returns the credentials built from the latest version of the files.  If the files
+// have changed but can't be loaded (for example, because we've caught them part way through
+// being updated) then we log and carry on using the previous credentials.
+func (r *tlsFileReloader) current() credentials.TransportCredentials {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	stamps := r.statFiles()
+	if stampsEqual(stamps, r.stamps) {
+		return r.creds
+	}
+	tlsConfig, err := r.build()
+	if err != nil {
+		log.WithError(err).Warning("Failed to reload etcd TLS files, continuing with previous certificates")
+		return r.creds
+	}
+	log.WithField("files", r.files()).Info("Reloaded etcd TLS files")
+	r.stamps = stamps
+	r.creds = credentials.NewTLS(tlsConfig)
+	return r.creds
+}
+
+func stampsEqual(a, b []fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].modTime.Equal(b[i].modTime) || a[i].size != b[i].size {
+			return false
+		}
+	}
+	return true
+}
+
+// transportCredentials returns gRPC transport credentials that use the latest TLS configuration
+// for each handshake.
+func (r *tlsFileReloader) transportCredentials() credentials.TransportCredentials {
+	return &reloadingCredentials{reloader: r}
+}
+
+// reloadingCredentials implements credentials.TransportCredentials by delegating each handshake
+// to the credentials built from the latest version of the TLS files.
+type reloadingCredentials struct {
+	reloader *tlsFileReloader
+}
+
+func (c *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return c.reloader.current().ClientHandshake(ctx, authority, rawConn)
+}
+
+func (c *reloadingCredentials) ServerHandshake(net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("etcd TLS credentials only support client handshakes")
+}
+
+func (c *reloadingCredentials) Info() credentials.ProtocolInfo {
+	return c.reloader.current().Info()
+}
+
+func (c *reloadingCredentials) Clone() credentials.TransportCredentials {
+	return &reloadingCredentials{reloader: c.reloader}
+}
+
+func (c *reloadingCredentials) OverrideServerName(string) error {
+	// Deprecated in gRPC and not used by the etcd client.
+	return nil
+}
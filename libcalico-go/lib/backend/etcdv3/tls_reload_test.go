@@ -0,0 +1,151 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdv3
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	"go.etcd.io/etcd/server/v3/embed"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+var _ = Describe("etcdv3 TLS file reloading", func() {
+	var (
+		dir                string
+		serverCA, clientCA *testCA
+		otherCA            *testCA
+		certFile, keyFile  string
+		caFile             string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "etcdv3-tls")
+		Expect(err).NotTo(HaveOccurred())
+		serverCA = newTestCA("server-ca")
+		clientCA = newTestCA("client-ca")
+		otherCA = newTestCA("other-ca")
+		certFile = dir + "/client.crt"
+		keyFile = dir + "/client.key"
+		caFile = dir + "/ca.crt"
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	writeClientCert := func(ca *testCA) {
+		cert, key := ca.issue("client")
+		writeFileAtomically(certFile, cert)
+		writeFileAtomically(keyFile, key)
+	}
+
+	It("should keep the previous certificates if the new files are invalid", func() {
+		writeClientCert(clientCA)
+		writeFileAtomically(caFile, serverCA.pem)
+		var finalized int
+		r, _, err := newTLSFileReloader(
+			transport.TLSInfo{CertFile: certFile, KeyFile: keyFile, TrustedCAFile: caFile},
+			func(*tls.Config) { finalized++ },
+		)
+		Expect(err).NotTo(HaveOccurred())
+		initial := r.current()
+		Expect(finalized).To(Equal(1))
+
+		// Unchanged files shouldn't be reloaded.
+		Expect(r.current()).To(BeIdenticalTo(initial))
+		Expect(finalized).To(Equal(1))
+
+		// A certificate that doesn't match the key should be ignored.
+		cert, _ := clientCA.issue("client")
+		writeFileAtomically(certFile, append(cert, '\n'))
+		Expect(r.current()).To(BeIdenticalTo(initial))
+
+		// Once the key is updated to match, the new files should be loaded.
+		writeClientCert(clientCA)
+		Expect(r.current()).NotTo(BeIdenticalTo(initial))
+		Expect(finalized).To(Equal(2))
+	})
+
+	Describe("with an etcd server that requires client certificates", func() {
+		var (
+			server   *embed.Etcd
+			endpoint string
+		)
+
+		BeforeEach(func() {
+			serverCert, serverKey := serverCA.issue("etcd")
+			server, endpoint = startEmbeddedEtcd(dir+"/data", &transport.TLSInfo{
+				CertFile:       writeTestFile(dir, "server.crt", serverCert),
+				KeyFile:        writeTestFile(dir, "server.key", serverKey),
+				TrustedCAFile:  writeTestFile(dir, "server-ca.crt", clientCA.pem),
+				ClientCertAuth: true,
+			})
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		newClient := func() api.Client {
+			c, err := NewEtcdV3Client(&apiconfig.EtcdConfig{
+				EtcdEndpoints:  endpoint,
+				EtcdCertFile:   certFile,
+				EtcdKeyFile:    keyFile,
+				EtcdCACertFile: caFile,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return c
+		}
+
+		write := func(c api.Client) error {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_, err := c.Apply(ctx, &model.KVPair{Key: model.GlobalConfigKey{Name: "a"}, Value: "1"})
+			return err
+		}
+
+		It("should pick up a rotated client certificate", func() {
+			writeClientCert(otherCA)
+			writeFileAtomically(caFile, serverCA.pem)
+			c := newClient()
+			defer c.(*etcdV3Client).etcdClient.Close()
+			Expect(write(c)).To(HaveOccurred())
+
+			writeClientCert(clientCA)
+			Eventually(func() error { return write(c) }, 30*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("should pick up a rotated CA", func() {
+			writeClientCert(clientCA)
+			writeFileAtomically(caFile, otherCA.pem)
+			c := newClient()
+			defer c.(*etcdV3Client).etcdClient.Close()
+			Expect(write(c)).To(HaveOccurred())
+
+			writeFileAtomically(caFile, append(otherCA.pem, serverCA.pem...))
+			Eventually(func() error { return write(c) }, 30*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+	})
+})
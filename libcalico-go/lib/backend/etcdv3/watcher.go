@@ -30,6 +30,11 @@ const (
 	resultsBufSize = 100
 )
 
+// maxResumeKeys is the largest number of keys for which a watcher tracks revisions so that it can
+// resume after a compaction.  Watches over more keys than this return the compaction error
+// instead, and the consumer resyncs.
+var maxResumeKeys = 10000
+
 // Watch entries in the datastore matching the resources specified by the ListInterface.
 func (c *etcdV3Client) Watch(cxt context.Context, l model.ListInterface, revision string) (api.WatchInterface, error) {
 	var rev int64
@@ -46,6 +51,7 @@ func (c *etcdV3Client) Watch(cxt context.Context, l model.ListInterface, revisio
 		list:       l,
 		initialRev: rev,
 		resultChan: make(chan api.WatchEvent, resultsBufSize),
+		known:      map[string]int64{},
 	}
 	wc.ctx, wc.cancel = context.WithCancel(cxt)
 	go wc.watchLoop()
@@ -61,6 +67,15 @@ type watcher struct {
 	resultChan chan api.WatchEvent
 	list       model.ListInterface
 	terminated uint32
+
+	// filterDefaultAllow is set if the watch covers the default-allow profile, which is
+	// returned by List but should not be sent as a watch event.
+	filterDefaultAllow bool
+
+	// known tracks the revision of each key that we have told the consumer about, indexed by
+	// etcd key.  It is used to work out what we missed if our revision is compacted.  It is nil
+	// if there are too many keys to track, in which case a compaction is returned as an error.
+	known map[string]int64
 }
 
 // Stop stops the watcher and releases associated resources.
@@ -88,6 +103,7 @@ func (wc *watcher) watchLoop() {
 	// If we are not watching a specific resource then this is a prefix watch.
 	logCxt := log.WithField("list", wc.list)
 	key, opts := calculateListKeyAndOptions(logCxt, wc.list)
+	wc.filterDefaultAllow = key == profilesKey || key == defaultAllowProfileKey
 
 	log.Debug("Starting watcher.watchLoop")
 	if wc.initialRev == 0 {
@@ -102,38 +118,83 @@ func (wc *watcher) watchLoop() {
 			return
 		}
 
-		// If we're handling profiles, filter out the default-allow profile.
-		if len(kvps.KVPairs) > 0 && (key == profilesKey || key == defaultAllowProfileKey) {
-			wc.removeDefaultAllowProfile(kvps)
-		}
-
 		// We are sending an initial sync of entries to the watcher to provide current
 		// state.  To the perspective of the watcher, these are added entries, so set the
 		// event type to WatchAdded.
 		log.WithField("NumEntries", len(kvps.KVPairs)).Debug("Sending create events for each existing entry")
 		wc.sendAddedEvents(kvps)
+	} else if err := wc.loadKnownKeys(key, opts); err != nil {
+		// We need to know which keys the consumer has seen to be able to resume the watch
+		// after a compaction.  If the requested revision has already been compacted then we
+		// get an error here, which the consumer handles in the same way as a compaction.
+		log.WithError(err).Info("Failed to load keys at the requested revision")
+		wc.sendError(err)
+		return
+	}
+
+	wc.watchAndResume(key, opts)
+}
+
+// watchAndResume watches from the current revision, resuming the watch if the revision is
+// compacted.  It returns when the watch is stopped or fails.
+func (wc *watcher) watchAndResume(key string, opts []clientv3.OpOption) {
+	opts = append(opts, clientv3.WithPrevKV())
+	for {
+		logCxt := log.WithFields(log.Fields{
+			"list":           wc.list,
+			"etcdv3-etcdKey": key,
+			"rev":            wc.initialRev,
+		})
+		logCxt.Debug("Starting etcdv3 watch")
+		if compacted := wc.watch(key, append(opts, clientv3.WithRev(wc.initialRev+1))); !compacted {
+			return
+		}
+
+		// The revision we were watching from has been compacted, so we may have missed some
+		// events.  Rather than failing the watch, which would force the consumer to re-list
+		// everything, work out what changed from a fresh list and resume the watch from there.
+		logCxt.Info("Watch revision has been compacted, resuming from a fresh list")
+		if err := wc.resyncAfterCompaction(); err != nil {
+			log.WithError(err).Warning("Failed to resync after compaction")
+			wc.sendError(err)
+			return
+		}
 	}
+}
 
-	opts = append(opts, clientv3.WithRev(wc.initialRev+1), clientv3.WithPrevKV())
-	logCxt = logCxt.WithFields(log.Fields{
-		"etcdv3-etcdKey": key,
-		"rev":            wc.initialRev,
-	})
-	logCxt.Debug("Starting etcdv3 watch")
-	wch := wc.client.etcdClient.Watch(wc.ctx, key, opts...)
+// watch runs an etcd watch from the given options, sending the events to the results channel.
+// It returns true if the watch stopped because the revision was compacted; any other reason
+// for the watch stopping is terminal.
+func (wc *watcher) watch(key string, opts []clientv3.OpOption) (compacted bool) {
+	ctx, cancel := context.WithCancel(wc.ctx)
+	defer cancel()
+	wch := wc.client.etcdClient.Watch(ctx, key, opts...)
 	for wres := range wch {
+		if wres.CompactRevision != 0 && wc.known != nil {
+			return true
+		}
 		if wres.Err() != nil {
 			// A watch channel error is a terminating event, so exit the loop.
 			err := wres.Err()
 			log.WithError(err).Warning("Watch channel error")
 			wc.sendError(err)
-			return
+			return false
 		}
 		for _, e := range wres.Events {
+			// Track the latest revision and the state of each key so that we can resume
+			// after a compaction.
+			wc.initialRev = e.Kv.ModRevision
+			if e.Type == clientv3.EventTypeDelete && wc.known != nil {
+				delete(wc.known, string(e.Kv.Key))
+			}
+
 			// Convert the etcdv3 event to the equivalent Watcher event.  An error
 			// parsing the event is returned as an error, but don't exit the watcher as
 			// restarting the watcher is unlikely to fix the conversion error.
 			if ae, err := convertWatchEvent(e, wc.list); ae != nil {
+				if ae.New != nil {
+					wc.trackKey(string(e.Kv.Key), e.Kv.ModRevision)
+				}
 				wc.sendEvent(ae)
 			} else if err != nil {
 				wc.sendError(err)
@@ -143,6 +204,7 @@ func (wc *watcher) watchLoop() {
 
 	// If we exit the loop, it means the watcher has closed for some reason.
 	log.Warn("etcdv3 watch channel closed")
+	return false
 }
 
 // listCurrent retrieves the existing entries.
@@ -159,9 +221,105 @@ func (wc *watcher) listCurrent() (*model.KVPairList, error) {
 		return nil, err
 	}
 
+	// If we're handling profiles, filter out the default-allow profile.
+	if len(list.KVPairs) > 0 && wc.filterDefaultAllow {
+		wc.removeDefaultAllowProfile(list)
+	}
+
+	for _, kvp := range list.KVPairs {
+		if err := wc.recordKnown(kvp); err != nil {
+			return nil, err
+		}
+	}
+
 	return list, nil
 }
 
+// trackKey records the revision of a key that the consumer has been told about, giving up on
+// tracking keys if there are too many.
+func (wc *watcher) trackKey(etcdKey string, revision int64) {
+	if wc.known == nil {
+		return
+	}
+	wc.known[etcdKey] = revision
+	if len(wc.known) > maxResumeKeys {
+		log.WithField("list", wc.list).Info("Too many keys to resume watch after compaction; a compaction will need a resync")
+		wc.known = nil
+	}
+}
+
+// loadKnownKeys populates the known keys from the keys that existed at the initial revision.  It
+// fetches at most maxResumeKeys+1 keys, and tracks none if there are more than maxResumeKeys.
+func (wc *watcher) loadKnownKeys(key string, opts []clientv3.OpOption) error {
+	opts = append(opts, clientv3.WithRev(wc.initialRev), clientv3.WithKeysOnly(), clientv3.WithLimit(int64(maxResumeKeys+1)))
+	resp, err := wc.client.etcdClient.Get(wc.ctx, key, opts...)
+	if err != nil {
+		return err
+	}
+	for _, kv := range resp.Kvs {
+		if k := wc.list.KeyFromDefaultPath(string(kv.Key)); k != nil {
+			wc.trackKey(string(kv.Key), kv.ModRevision)
+		}
+	}
+	return nil
+}
+
+// resyncAfterCompaction lists the current entries and sends events for the differences between
+// those and the entries that the consumer already knows about.  The watch can then be resumed
+// from the revision of the list.
+func (wc *watcher) resyncAfterCompaction() error {
+	previous := wc.known
+	wc.known = map[string]int64{}
+	kvps, err := wc.listCurrent()
+	if err != nil {
+		return err
+	}
+
+	var added, modified, deleted int
+	for _, kvp := range kvps.KVPairs {
+		etcdKey, _ := model.KeyToDefaultPath(kvp.Key)
+		prev, ok := previous[etcdKey]
+		delete(previous, etcdKey)
+		switch {
+		case !ok:
+			added++
+			wc.sendEvent(&api.WatchEvent{Type: api.WatchAdded, New: kvp})
+		case prev != wc.known[etcdKey]:
+			modified++
+			wc.sendEvent(&api.WatchEvent{Type: api.WatchModified, New: kvp})
+		}
+	}
+	for etcdKey := range previous {
+		deleted++
+		wc.sendEvent(&api.WatchEvent{
+			Type: api.WatchDeleted,
+			Old:  &model.KVPair{Key: wc.list.KeyFromDefaultPath(etcdKey), Revision: kvps.Revision},
+		})
+	}
+	log.WithFields(log.Fields{
+		"list":     wc.list,
+		"added":    added,
+		"modified": modified,
+		"deleted":  deleted,
+		"rev":      wc.initialRev,
+	}).Info("Resynced watch after compaction")
+	return nil
+}
+
+// recordKnown records that the consumer has been told about the given entry.
+func (wc *watcher) recordKnown(kvp *model.KVPair) error {
+	etcdKey, err := model.KeyToDefaultPath(kvp.Key)
+	if err != nil {
+		return err
+	}
+	rev, err := strconv.ParseInt(kvp.Revision, 10, 64)
+	if err != nil {
+		return err
+	}
+	wc.trackKey(etcdKey, rev)
+	return nil
+}
+
 // removeDefaultAllowProfile filters out the default-allow profile out of the
 // given kvps list.
 func (wc *watcher) removeDefaultAllowProfile(list *model.KVPairList) {
@@ -0,0 +1,169 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdv3
+
+import (
+	"context"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+	"go.etcd.io/etcd/server/v3/embed"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+var _ = Describe("etcdv3 watcher compaction", func() {
+	var (
+		dir    string
+		server *embed.Etcd
+		c      *etcdV3Client
+		ctx    context.Context
+		cancel context.CancelFunc
+		list   = model.GlobalConfigListOptions{}
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "etcdv3-watch")
+		Expect(err).NotTo(HaveOccurred())
+		var endpoint string
+		server, endpoint = startEmbeddedEtcd(dir, nil)
+		client, err := NewEtcdV3Client(&apiconfig.EtcdConfig{EtcdEndpoints: endpoint})
+		Expect(err).NotTo(HaveOccurred())
+		c = client.(*etcdV3Client)
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+		_ = c.etcdClient.Close()
+		server.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	apply := func(name, value string) *model.KVPair {
+		kvp, err := c.Apply(ctx, &model.KVPair{Key: model.GlobalConfigKey{Name: name}, Value: value})
+		Expect(err).NotTo(HaveOccurred())
+		return kvp
+	}
+
+	del := func(name string) {
+		_, err := c.Delete(ctx, model.GlobalConfigKey{Name: name}, "")
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	compact := func() {
+		resp, err := c.etcdClient.Get(ctx, "/")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = c.etcdClient.Compact(ctx, resp.Header.Revision)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	receive := func(w *watcher, n int) []api.WatchEvent {
+		var events []api.WatchEvent
+		for i := 0; i < n; i++ {
+			select {
+			case e := <-w.ResultChan():
+				events = append(events, e)
+			case <-time.After(10 * time.Second):
+				Fail("timed out waiting for watch event")
+			}
+		}
+		return events
+	}
+
+	It("should resume after compaction, sending only the changes", func() {
+		apply("a", "1")
+		apply("b", "1")
+		apply("unchanged", "1")
+
+		// Simulate a watcher that has seen the current state and has then fallen behind.
+		w := &watcher{client: c, list: list, known: map[string]int64{}, resultChan: make(chan api.WatchEvent, resultsBufSize)}
+		w.ctx, w.cancel = context.WithCancel(ctx)
+		_, err := w.listCurrent()
+		Expect(err).NotTo(HaveOccurred())
+
+		a := apply("a", "2")
+		del("b")
+		cNew := apply("c", "1")
+		compact()
+
+		key, opts := calculateListKeyAndOptions(log.WithField("list", list), list)
+		go w.watchAndResume(key, opts)
+
+		events := receive(w, 3)
+		Expect(events).To(ConsistOf(
+			api.WatchEvent{Type: api.WatchModified, New: a},
+			api.WatchEvent{Type: api.WatchAdded, New: cNew},
+			api.WatchEvent{Type: api.WatchDeleted, Old: &model.KVPair{Key: model.GlobalConfigKey{Name: "b"}, Revision: cNew.Revision}},
+		))
+
+		// The watch should then continue from the resynced revision.
+		d := apply("d", "1")
+		Expect(receive(w, 1)).To(Equal([]api.WatchEvent{{Type: api.WatchAdded, New: d}}))
+		Consistently(w.ResultChan()).ShouldNot(Receive())
+	})
+
+	It("should return an error if the requested revision has been compacted", func() {
+		first := apply("a", "1")
+		apply("a", "2")
+		compact()
+
+		w, err := c.Watch(ctx, list, first.Revision)
+		Expect(err).NotTo(HaveOccurred())
+		var e api.WatchEvent
+		Eventually(w.ResultChan(), 10*time.Second).Should(Receive(&e))
+		Expect(e.Type).To(Equal(api.WatchError))
+	})
+
+	It("should return the compaction as an error if there are too many keys to track", func() {
+		defer func(max int) { maxResumeKeys = max }(maxResumeKeys)
+		maxResumeKeys = 1
+
+		apply("a", "1")
+		apply("b", "1")
+		w := &watcher{client: c, list: list, known: map[string]int64{}, resultChan: make(chan api.WatchEvent, resultsBufSize)}
+		w.ctx, w.cancel = context.WithCancel(ctx)
+		_, err := w.listCurrent()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.known).To(BeNil())
+
+		apply("a", "2")
+		apply("b", "2")
+		compact()
+
+		key, opts := calculateListKeyAndOptions(log.WithField("list", list), list)
+		go w.watchAndResume(key, opts)
+		events := receive(w, 1)
+		Expect(events[0].Type).To(Equal(api.WatchError))
+	})
+
+	It("should track changes from a watch started at a revision", func() {
+		first := apply("a", "1")
+		apply("b", "1")
+		w, err := c.Watch(ctx, list, first.Revision)
+		Expect(err).NotTo(HaveOccurred())
+		var e api.WatchEvent
+		Eventually(w.ResultChan(), 10*time.Second).Should(Receive(&e))
+		Expect(e.Type).To(Equal(api.WatchAdded))
+		Expect(e.New.Key).To(Equal(model.GlobalConfigKey{Name: "b"}))
+		Expect(w.(*watcher).known).To(HaveLen(2))
+	})
+})
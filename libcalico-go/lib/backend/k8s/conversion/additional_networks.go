@@ -0,0 +1,88 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/json"
+)
+
+// DefaultPodInterface is the name of the pod's primary interface.
+const DefaultPodInterface = "eth0"
+
+// AdditionalNetwork is an entry in the AnnotationAdditionalNetworks annotation.  The CNI plugin
+// adds an interface to the pod for each entry, with IPs from the given pools, and each interface
+// has its own WorkloadEndpoint.
+type AdditionalNetwork struct {
+	// Name of the network.  The network's WorkloadEndpoint has the LabelNetwork label set to
+	// this value so that policy can select it.
+	Name string `json:"name"`
+
+	// Interface is the name of the interface in the pod.  Defaults to "eth<N>" where N is one
+	// more than the index of the entry in the list.
+	Interface string `json:"interface,omitempty"`
+
+	// IPv4Pools and IPv6Pools are the names or CIDRs of the IP pools to assign the
+	// interface's addresses from.  At least one pool must be given.
+	IPv4Pools []string `json:"ipv4Pools,omitempty"`
+	IPv6Pools []string `json:"ipv6Pools,omitempty"`
+}
+
+// AnnotationPodIPsForInterface returns the annotation that the CNI plugin uses to record the IPs
+// of one of the pod's additional interfaces.
+func AnnotationPodIPsForInterface(iface string) string {
+	return AnnotationPodIPs + "." + iface
+}
+
+// ParseAdditionalNetworks returns the additional networks requested by the pod's annotations,
+// with defaults filled in.
+func ParseAdditionalNetworks(annotations map[string]string) ([]AdditionalNetwork, error) {
+	annotation, ok := annotations[AnnotationAdditionalNetworks]
+	if !ok || annotation == "" {
+		return nil, nil
+	}
+	var networks []AdditionalNetwork
+	if err := json.Unmarshal([]byte(annotation), &networks); err != nil {
+		return nil, fmt.Errorf("failed to parse '%s' as JSON: %s", annotation, err)
+	}
+
+	seenIfaces := map[string]bool{DefaultPodInterface: true}
+	for i := range networks {
+		n := &networks[i]
+		if errs := validation.IsDNS1123Label(n.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid additional network name %q: %v", n.Name, errs)
+		}
+		if n.Interface == "" {
+			n.Interface = fmt.Sprintf("eth%d", i+1)
+		}
+		if len(n.Interface) > 15 {
+			return nil, fmt.Errorf("interface name %q for network %s is longer than 15 characters", n.Interface, n.Name)
+		}
+		if errs := validation.IsDNS1123Label(n.Interface); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid interface name %q for network %s: %v", n.Interface, n.Name, errs)
+		}
+		if seenIfaces[n.Interface] {
+			return nil, fmt.Errorf("interface %s is used by more than one network", n.Interface)
+		}
+		seenIfaces[n.Interface] = true
+		if len(n.IPv4Pools) == 0 && len(n.IPv6Pools) == 0 {
+			return nil, fmt.Errorf("additional network %s has no IP pools", n.Name)
+		}
+	}
+	return networks, nil
+}
@@ -41,6 +41,14 @@ const (
 	// on older Pods.
 	AnnotationContainerID = "cni.projectcalico.org/containerID"
 
+	// AnnotationAdditionalNetworks lists the Calico networks, in addition to the primary network, that
+	// the pod should be attached to.  See AdditionalNetwork for the format.
+	AnnotationAdditionalNetworks = "cni.projectcalico.org/additionalNetworks"
+
+	// LabelNetwork is set on the WorkloadEndpoints for a pod's additional networks to the name of
+	// the network, so that policy can select a particular interface of the pod.
+	LabelNetwork = "projectcalico.org/network"
+
 	// NameLabel is a label that can be used to match a serviceaccount or namespace
 	// name exactly.
 	NameLabel = "projectcalico.org/name"
@@ -1187,6 +1187,83 @@ var _ = Describe("Test Pod conversion", func() {
 	})
 })
 
+var _ = Describe("Test Pod conversion with additional networks", func() {
+	c := NewConverter()
+
+	makePod := func(networks string) *kapiv1.Pod {
+		return &kapiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "podA",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "telco"},
+				Annotations: map[string]string{
+					AnnotationAdditionalNetworks:          networks,
+					AnnotationContainerID:                 "abcde",
+					AnnotationPodIPsForInterface("eth1"):  "10.10.0.5/32",
+					AnnotationPodIPsForInterface("data0"): "10.20.0.5/32,fd00::5/128",
+				},
+			},
+			Spec: kapiv1.PodSpec{
+				NodeName:           "nodeA",
+				ServiceAccountName: "sa",
+			},
+			Status: kapiv1.PodStatus{
+				PodIP: "192.168.0.1",
+			},
+		}
+	}
+
+	It("should return an endpoint per network", func() {
+		pod := makePod(`[{"name": "net-a", "ipv4Pools": ["pool-a"]}, {"name": "net-b", "interface": "data0", "ipv4Pools": ["pool-b"]}]`)
+		kvps, err := c.PodToWorkloadEndpoints(pod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kvps).To(HaveLen(3))
+
+		primary := kvps[0].Value.(*libapiv3.WorkloadEndpoint)
+		Expect(primary.Name).To(Equal("nodeA-k8s-podA-eth0"))
+		Expect(primary.Labels).NotTo(HaveKey(LabelNetwork))
+
+		netA := kvps[1].Value.(*libapiv3.WorkloadEndpoint)
+		Expect(netA.Name).To(Equal("nodeA-k8s-podA-eth1"))
+		Expect(netA.Spec.Endpoint).To(Equal("eth1"))
+		Expect(netA.Spec.IPNetworks).To(Equal([]string{"10.10.0.5/32"}))
+		Expect(netA.Spec.ContainerID).To(Equal("abcde"))
+		Expect(netA.Spec.Profiles).To(Equal(primary.Spec.Profiles))
+		Expect(netA.Labels).To(HaveKeyWithValue(LabelNetwork, "net-a"))
+		Expect(netA.Labels).To(HaveKeyWithValue("app", "telco"))
+		Expect(netA.Spec.InterfaceName).To(Equal(c.VethNameForEndpoint("default", "podA", "eth1")))
+		Expect(netA.Spec.InterfaceName).NotTo(Equal(primary.Spec.InterfaceName))
+
+		netB := kvps[2].Value.(*libapiv3.WorkloadEndpoint)
+		Expect(netB.Name).To(Equal("nodeA-k8s-podA-data0"))
+		Expect(netB.Spec.IPNetworks).To(Equal([]string{"10.20.0.5/32", "fd00::5/128"}))
+		Expect(netB.Labels).To(HaveKeyWithValue(LabelNetwork, "net-b"))
+	})
+
+	It("should ignore an invalid annotation", func() {
+		for _, networks := range []string{
+			`not json`,
+			`[{"name": "net-a"}]`,
+			`[{"name": "net-a", "interface": "eth0", "ipv4Pools": ["pool-a"]}]`,
+			`[{"name": "Net_A", "ipv4Pools": ["pool-a"]}]`,
+			`[{"name": "net-a", "interface": "averyveryverylongname", "ipv4Pools": ["pool-a"]}]`,
+		} {
+			kvps, err := c.PodToWorkloadEndpoints(makePod(networks))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kvps).To(HaveLen(1), networks)
+		}
+	})
+
+	It("should not give a finished pod's additional endpoints any IPs", func() {
+		pod := makePod(`[{"name": "net-a", "ipv4Pools": ["pool-a"]}]`)
+		pod.Status.Phase = kapiv1.PodSucceeded
+		kvps, err := c.PodToWorkloadEndpoints(pod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kvps).To(HaveLen(2))
+		Expect(kvps[1].Value.(*libapiv3.WorkloadEndpoint).Spec.IPNetworks).To(BeEmpty())
+	})
+})
+
 var _ = Describe("Test UID conversion", func() {
 	It("should parse a UID to a Calico ID", func() {
 		By("Converting a UID")
@@ -24,6 +24,7 @@ import (
 
 type WorkloadEndpointConverter interface {
 	VethNameForWorkload(namespace, podName string) string
+	VethNameForEndpoint(namespace, podName, endpoint string) string
 	PodToWorkloadEndpoints(pod *kapiv1.Pod) ([]*model.KVPair, error)
 }
 
@@ -40,10 +40,23 @@ type defaultWorkloadEndpointConverter struct{}
 // VethNameForWorkload returns a deterministic veth name
 // for the given Kubernetes workload (WEP) name and namespace.
 func (wc defaultWorkloadEndpointConverter) VethNameForWorkload(namespace, podname string) string {
+	return vethName(fmt.Sprintf("%s.%s", namespace, podname))
+}
+
+// VethNameForEndpoint returns a deterministic veth name for the given interface of a Kubernetes
+// workload.  The primary interface uses the same name as VethNameForWorkload.
+func (wc defaultWorkloadEndpointConverter) VethNameForEndpoint(namespace, podname, endpoint string) string {
+	if endpoint == "" || endpoint == DefaultPodInterface {
+		return wc.VethNameForWorkload(namespace, podname)
+	}
+	return vethName(fmt.Sprintf("%s.%s.%s", namespace, podname, endpoint))
+}
+
+func vethName(id string) string {
 	// A SHA1 is always 20 bytes long, and so is sufficient for generating the
 	// veth name and mac addr.
 	h := sha1.New()
-	h.Write([]byte(fmt.Sprintf("%s.%s", namespace, podname)))
+	h.Write([]byte(id))
 	prefix := os.Getenv("FELIX_INTERFACEPREFIX")
 	if prefix == "" {
 		// Prefix is not set. Default to "cali"
@@ -63,7 +76,91 @@ func (wc defaultWorkloadEndpointConverter) PodToWorkloadEndpoints(pod *kapiv1.Po
 		return nil, err
 	}
 
-	return []*model.KVPair{wep}, nil
+	kvps := []*model.KVPair{wep}
+
+	// Add an endpoint for each of the pod's additional networks.  A bad annotation shouldn't
+	// stop us from handling the primary endpoint; the CNI plugin rejects it when it adds the pod.
+	networks, err := ParseAdditionalNetworks(pod.Annotations)
+	if err != nil {
+		log.WithError(err).WithField("pod", pod.Name).Warn("Ignoring invalid additional networks annotation")
+		return kvps, nil
+	}
+	for _, network := range networks {
+		kvp, err := wc.podToAdditionalWorkloadEndpoint(pod, wep.Value.(*libapiv3.WorkloadEndpoint), network)
+		if err != nil {
+			return nil, err
+		}
+		kvps = append(kvps, kvp)
+	}
+	return kvps, nil
+}
+
+// podToAdditionalWorkloadEndpoint returns the WorkloadEndpoint for one of the pod's additional
+// networks.  It shares the identity of the pod's primary endpoint but has its own interface, IPs
+// and network label.  Ports, floating IPs and source spoofing only apply to the primary endpoint.
+func (wc defaultWorkloadEndpointConverter) podToAdditionalWorkloadEndpoint(
+	pod *kapiv1.Pod,
+	primary *libapiv3.WorkloadEndpoint,
+	network AdditionalNetwork,
+) (*model.KVPair, error) {
+	wepids := names.WorkloadEndpointIdentifiers{
+		Node:         pod.Spec.NodeName,
+		Orchestrator: apiv3.OrchestratorKubernetes,
+		Endpoint:     network.Interface,
+		Pod:          pod.Name,
+	}
+	wepName, err := wepids.CalculateWorkloadEndpointName(false)
+	if err != nil {
+		return nil, err
+	}
+
+	ipNets := []string{}
+	if ips := pod.Annotations[AnnotationPodIPsForInterface(network.Interface)]; ips != "" && !IsFinished(pod) {
+		for _, ip := range strings.Split(ips, ",") {
+			_, ipNet, err := cnet.ParseCIDROrIP(ip)
+			if err != nil {
+				return nil, err
+			}
+			ipNets = append(ipNets, ipNet.String())
+		}
+	}
+
+	labels := make(map[string]string, len(primary.Labels)+1)
+	for k, v := range primary.Labels {
+		labels[k] = v
+	}
+	labels[LabelNetwork] = network.Name
+
+	wep := libapiv3.NewWorkloadEndpoint()
+	wep.ObjectMeta = metav1.ObjectMeta{
+		Name:              wepName,
+		Namespace:         pod.Namespace,
+		CreationTimestamp: pod.CreationTimestamp,
+		UID:               pod.UID,
+		Labels:            labels,
+		GenerateName:      pod.GenerateName,
+	}
+	wep.Spec = libapiv3.WorkloadEndpointSpec{
+		Orchestrator:       "k8s",
+		Node:               pod.Spec.NodeName,
+		Pod:                pod.Name,
+		ContainerID:        primary.Spec.ContainerID,
+		Endpoint:           network.Interface,
+		InterfaceName:      wc.VethNameForEndpoint(pod.Namespace, pod.Name, network.Interface),
+		Profiles:           primary.Spec.Profiles,
+		IPNetworks:         ipNets,
+		ServiceAccountName: pod.Spec.ServiceAccountName,
+	}
+
+	return &model.KVPair{
+		Key: model.ResourceKey{
+			Name:      wepName,
+			Namespace: pod.Namespace,
+			Kind:      libapiv3.KindWorkloadEndpoint,
+		},
+		Value:    wep,
+		Revision: pod.ResourceVersion,
+	}, nil
 }
 
 // PodToWorkloadEndpoint converts a Pod to a WorkloadEndpoint.  It assumes the calling code
@@ -86,7 +183,7 @@ func (wc defaultWorkloadEndpointConverter) podToDefaultWorkloadEndpoint(pod *kap
 	wepids := names.WorkloadEndpointIdentifiers{
 		Node:         pod.Spec.NodeName,
 		Orchestrator: apiv3.OrchestratorKubernetes,
-		Endpoint:     "eth0",
+		Endpoint:     DefaultPodInterface,
 		Pod:          pod.Name,
 	}
 	wepName, err := wepids.CalculateWorkloadEndpointName(false)
@@ -238,7 +335,7 @@ func (wc defaultWorkloadEndpointConverter) podToDefaultWorkloadEndpoint(pod *kap
 		Node:                       pod.Spec.NodeName,
 		Pod:                        pod.Name,
 		ContainerID:                containerID,
-		Endpoint:                   "eth0",
+		Endpoint:                   DefaultPodInterface,
 		InterfaceName:              interfaceName,
 		Profiles:                   profiles,
 		IPNetworks:                 ipNets,
@@ -236,6 +236,49 @@ func NewKubeClient(ca *apiconfig.CalicoAPIConfigSpec) (api.Client, error) {
 		apiv3.KindBGPFilter,
 		resources.NewBGPFilterClient(cs, crdClientV1),
 	)
+	kubeClient.registerResourceClient(
+		reflect.TypeOf(model.ResourceKey{}),
+		reflect.TypeOf(model.ResourceListOptions{}),
+		apiv3.KindRoutePolicy,
+		resources.NewRoutePolicyClient(cs, crdClientV1),
+	)
+	kubeClient.registerResourceClient(
+		reflect.TypeOf(model.ResourceKey{}),
+		reflect.TypeOf(model.ResourceListOptions{}),
+		apiv3.KindIPPoolMigration,
+		resources.NewIPPoolMigrationClient(cs, crdClientV1),
+	)
+	kubeClient.registerResourceClient(
+		reflect.TypeOf(model.ResourceKey{}),
+		reflect.TypeOf(model.ResourceListOptions{}),
+		libapiv3.KindIPAMEvent,
+		resources.NewIPAMEventClient(cs, crdClientV1),
+	)
+	kubeClient.registerResourceClient(
+		reflect.TypeOf(model.ResourceKey{}),
+		reflect.TypeOf(model.ResourceListOptions{}),
+		libapiv3.KindIPAMQuotaCounter,
+		resources.NewIPAMQuotaCounterClient(cs, crdClientV1),
+	)
+	kubeClient.registerResourceClient(
+		reflect.TypeOf(model.ResourceKey{}),
+		reflect.TypeOf(model.ResourceListOptions{}),
+		libapiv3.KindFelixConfigReport,
+		resources.NewFelixConfigReportClient(cs, crdClientV1),
+	)
+	policyStatusReportClient := resources.NewPolicyStatusReportClient(cs, crdClientV1)
+	kubeClient.registerResourceClient(
+		reflect.TypeOf(model.ResourceKey{}),
+		reflect.TypeOf(model.ResourceListOptions{}),
+		libapiv3.KindPolicyStatusReport,
+		policyStatusReportClient,
+	)
+	kubeClient.registerResourceClient(
+		reflect.TypeOf(model.PolicyStatusKey{}),
+		reflect.TypeOf(model.PolicyStatusListOptions{}),
+		libapiv3.KindPolicyStatusReport,
+		policyStatusReportClient,
+	)
 
 	if !ca.K8sUsePodCIDR {
 		// Using Calico IPAM - use CRDs to back IPAM resources.
@@ -439,6 +482,12 @@ func (c *KubeClient) Clean() error {
 		libapiv3.KindIPAMConfig,
 		libapiv3.KindBlockAffinity,
 		apiv3.KindBGPFilter,
+		apiv3.KindRoutePolicy,
+		apiv3.KindIPPoolMigration,
+		libapiv3.KindIPAMEvent,
+		libapiv3.KindIPAMQuotaCounter,
+		libapiv3.KindPolicyStatusReport,
+		libapiv3.KindFelixConfigReport,
 	}
 	ctx := context.Background()
 	for _, k := range kinds {
@@ -564,6 +613,18 @@ func buildCRDClientV1(cfg rest.Config) (*rest.RESTClient, error) {
 					&apiv3.CalicoNodeStatusList{},
 					&apiv3.BGPFilter{},
 					&apiv3.BGPFilterList{},
+					&apiv3.RoutePolicy{},
+					&apiv3.RoutePolicyList{},
+					&apiv3.IPPoolMigration{},
+					&apiv3.IPPoolMigrationList{},
+					&libapiv3.IPAMEvent{},
+					&libapiv3.IPAMEventList{},
+					&libapiv3.IPAMQuotaCounter{},
+					&libapiv3.IPAMQuotaCounterList{},
+					&libapiv3.PolicyStatusReport{},
+					&libapiv3.PolicyStatusReportList{},
+					&libapiv3.FelixConfigReport{},
+					&libapiv3.FelixConfigReportList{},
 				)
 				return nil
 			})
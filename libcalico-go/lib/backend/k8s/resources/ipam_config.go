@@ -75,6 +75,7 @@ func (c ipamConfigClient) toV1(kvpv3 *model.KVPair) (*model.KVPair, error) {
 			StrictAffinity:     v3obj.Spec.StrictAffinity,
 			AutoAllocateBlocks: v3obj.Spec.AutoAllocateBlocks,
 			MaxBlocksPerHost:   v3obj.Spec.MaxBlocksPerHost,
+			EventLog:           v3obj.Spec.EventLog,
 		},
 		Revision: kvpv3.Revision,
 		UID:      &kvpv3.Value.(*libapiv3.IPAMConfig).UID,
@@ -106,6 +107,7 @@ func (c ipamConfigClient) toV3(kvpv1 *model.KVPair) *model.KVPair {
 				StrictAffinity:     v1obj.StrictAffinity,
 				AutoAllocateBlocks: v1obj.AutoAllocateBlocks,
 				MaxBlocksPerHost:   v1obj.MaxBlocksPerHost,
+				EventLog:           v1obj.EventLog,
 			},
 		},
 		Revision: kvpv1.Revision,
@@ -127,6 +127,12 @@ func (c ipamHandleClient) toV3(kvpv1 *model.KVPair) *model.KVPair {
 	}
 }
 
+// IPAMHandleV1toV3 converts an IPAM handle KVPair in the form used by the IPAM client into
+// its v3 resource form.
+func IPAMHandleV1toV3(kvpv1 *model.KVPair) *model.KVPair {
+	return ipamHandleClient{}.toV3(kvpv1)
+}
+
 func (c *ipamHandleClient) Create(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
 	nkvp := c.toV3(kvp)
 	kvp, err := c.rc.Create(ctx, nkvp)
@@ -0,0 +1,274 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+const (
+	PolicyStatusReportResourceName = "PolicyStatusReports"
+	PolicyStatusReportCRDName      = "policystatusreports.crd.projectcalico.org"
+
+	// policyStatusReportNodeLabel labels each report with (a form of) its node name, so that
+	// Felix can list its own reports without listing those of every other node.
+	policyStatusReportNodeLabel = "projectcalico.org/node"
+)
+
+func NewPolicyStatusReportClient(c *kubernetes.Clientset, r *rest.RESTClient) K8sResourceClient {
+	// Create a resource client which manages k8s CRDs.
+	rc := customK8sResourceClient{
+		clientSet:       c,
+		restClient:      r,
+		name:            PolicyStatusReportCRDName,
+		resource:        PolicyStatusReportResourceName,
+		description:     "Calico policy status reports",
+		k8sResourceType: reflect.TypeOf(libapiv3.PolicyStatusReport{}),
+		k8sResourceTypeMeta: metav1.TypeMeta{
+			Kind:       libapiv3.KindPolicyStatusReport,
+			APIVersion: apiv3.GroupVersionCurrent,
+		},
+		k8sListType:  reflect.TypeOf(libapiv3.PolicyStatusReportList{}),
+		resourceKind: libapiv3.KindPolicyStatusReport,
+	}
+
+	return &policyStatusReportClient{rc: rc}
+}
+
+// policyStatusReportClient implements the api.Client interface for PolicyStatusReport objects.
+// It handles the translation between the v1 policy status reported by Felix and the CRDs which are
+// used to store the reports in the Kubernetes API.  v3 keys and list options are passed straight
+// through to the underlying customK8sResourceClient.
+type policyStatusReportClient struct {
+	rc customK8sResourceClient
+}
+
+// toV1 converts the given v3 CRD KVPair into a v1 model representation.
+func (c policyStatusReportClient) toV1(kvpv3 *model.KVPair) *model.KVPair {
+	report := kvpv3.Value.(*libapiv3.PolicyStatusReport)
+	return &model.KVPair{
+		Key: model.PolicyStatusKey{
+			Hostname:     report.Spec.Node,
+			Tier:         report.Spec.Tier,
+			Name:         report.Spec.Policy,
+			RegionString: model.RegionString(""),
+		},
+		Value: &model.PolicyStatus{
+			Status:      report.Spec.Status,
+			ContentHash: report.Spec.ContentHash,
+			Errors:      report.Spec.Errors,
+		},
+		Revision: kvpv3.Revision,
+		UID:      &report.UID,
+	}
+}
+
+// parseKey returns the name of the report for the given key.  Policy names may contain a "/", so we
+// use a hash of the tier and policy name, prefixed with the node name for readability.
+func (c policyStatusReportClient) parseKey(k model.PolicyStatusKey) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s/%s", k.Tier, k.Name)))
+	hash := hex.EncodeToString(h[:])[:16]
+	node := k.Hostname
+	if max := validation.DNS1123SubdomainMaxLength - len(hash) - 1; len(node) > max {
+		node = node[:max]
+	}
+	return fmt.Sprintf("%s.%s", node, hash)
+}
+
+// nodeLabelValue returns the value of the node label for the given node.  Node names can be
+// longer than a label value allows, in which case we use a hash of the name instead.
+func nodeLabelValue(node string) string {
+	if len(validation.IsValidLabelValue(node)) == 0 {
+		return node
+	}
+	h := sha256.Sum256([]byte(node))
+	return hex.EncodeToString(h[:])[:validation.LabelValueMaxLength]
+}
+
+// toV3 takes the given v1 KVPair and converts it into a v3 representation, suitable
+// for writing as a CRD to the Kubernetes API.
+func (c policyStatusReportClient) toV3(kvpv1 *model.KVPair) *model.KVPair {
+	key := kvpv1.Key.(model.PolicyStatusKey)
+	name := c.parseKey(key)
+	status := kvpv1.Value.(*model.PolicyStatus)
+
+	var uid types.UID
+	if kvpv1.UID != nil {
+		uid = *kvpv1.UID
+	}
+
+	report := libapiv3.NewPolicyStatusReport()
+	report.ObjectMeta = metav1.ObjectMeta{
+		Name:            name,
+		ResourceVersion: kvpv1.Revision,
+		UID:             uid,
+		Labels:          map[string]string{policyStatusReportNodeLabel: nodeLabelValue(key.Hostname)},
+	}
+	report.Spec = libapiv3.PolicyStatusReportSpec{
+		Node:        key.Hostname,
+		Tier:        key.Tier,
+		Policy:      key.Name,
+		Status:      status.Status,
+		ContentHash: status.ContentHash,
+		Errors:      status.Errors,
+	}
+	return &model.KVPair{
+		Key: model.ResourceKey{
+			Name: name,
+			Kind: libapiv3.KindPolicyStatusReport,
+		},
+		Value:    report,
+		Revision: kvpv1.Revision,
+	}
+}
+
+func (c *policyStatusReportClient) Create(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	if _, ok := kvp.Key.(model.PolicyStatusKey); !ok {
+		return c.rc.Create(ctx, kvp)
+	}
+	kvp, err := c.rc.Create(ctx, c.toV3(kvp))
+	if err != nil {
+		return nil, err
+	}
+	return c.toV1(kvp), nil
+}
+
+func (c *policyStatusReportClient) Update(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	if _, ok := kvp.Key.(model.PolicyStatusKey); !ok {
+		return c.rc.Update(ctx, kvp)
+	}
+	if kvp.Revision == "" {
+		// Felix applies its status without a revision, but the Kubernetes API doesn't allow
+		// unconditional updates of custom resources.  Update the current revision.
+		current, err := c.Get(ctx, kvp.Key, "")
+		if err != nil {
+			return nil, err
+		}
+		kvp = &model.KVPair{Key: kvp.Key, Value: kvp.Value, Revision: current.Revision, UID: current.UID}
+	}
+	kvp, err := c.rc.Update(ctx, c.toV3(kvp))
+	if err != nil {
+		return nil, err
+	}
+	return c.toV1(kvp), nil
+}
+
+func (c *policyStatusReportClient) DeleteKVP(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	return c.Delete(ctx, kvp.Key, kvp.Revision, kvp.UID)
+}
+
+func (c *policyStatusReportClient) Delete(ctx context.Context, key model.Key, revision string, uid *types.UID) (*model.KVPair, error) {
+	k, ok := key.(model.PolicyStatusKey)
+	if !ok {
+		return c.rc.Delete(ctx, key, revision, uid)
+	}
+	kvp, err := c.rc.Delete(ctx, model.ResourceKey{Name: c.parseKey(k), Kind: libapiv3.KindPolicyStatusReport}, revision, uid)
+	if err != nil {
+		return nil, err
+	}
+	return c.toV1(kvp), nil
+}
+
+func (c *policyStatusReportClient) Get(ctx context.Context, key model.Key, revision string) (*model.KVPair, error) {
+	k, ok := key.(model.PolicyStatusKey)
+	if !ok {
+		return c.rc.Get(ctx, key, revision)
+	}
+	kvp, err := c.rc.Get(ctx, model.ResourceKey{Name: c.parseKey(k), Kind: libapiv3.KindPolicyStatusReport}, revision)
+	if err != nil {
+		return nil, err
+	}
+	return c.toV1(kvp), nil
+}
+
+func (c *policyStatusReportClient) List(ctx context.Context, list model.ListInterface, revision string) (*model.KVPairList, error) {
+	l, ok := list.(model.PolicyStatusListOptions)
+	if !ok {
+		return c.rc.List(ctx, list, revision)
+	}
+
+	opts := metav1.ListOptions{ResourceVersion: revision}
+	if l.Hostname != "" {
+		opts.LabelSelector = fmt.Sprintf("%s=%s", policyStatusReportNodeLabel, nodeLabelValue(l.Hostname))
+	}
+	reports := libapiv3.NewPolicyStatusReportList()
+	err := c.rc.restClient.Get().
+		Resource(c.rc.resource).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).Into(reports)
+	if err != nil {
+		return nil, K8sErrorToCalico(err, list)
+	}
+
+	kvpl := &model.KVPairList{KVPairs: []*model.KVPair{}, Revision: reports.ResourceVersion}
+	for i := range reports.Items {
+		kvp, err := c.rc.convertResourceToKVPair(&reports.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		v1kvp := c.toV1(kvp)
+		k := v1kvp.Key.(model.PolicyStatusKey)
+		if (l.Hostname != "" && k.Hostname != l.Hostname) ||
+			(l.Tier != "" && k.Tier != l.Tier) ||
+			(l.Name != "" && k.Name != l.Name) {
+			continue
+		}
+		kvpl.KVPairs = append(kvpl.KVPairs, v1kvp)
+	}
+	return kvpl, nil
+}
+
+func (c *policyStatusReportClient) toKVPairV1(r Resource) (*model.KVPair, error) {
+	conv, err := c.rc.convertResourceToKVPair(r)
+	if err != nil {
+		return nil, err
+	}
+	return c.toV1(conv), nil
+}
+
+func (c *policyStatusReportClient) Watch(ctx context.Context, list model.ListInterface, revision string) (api.WatchInterface, error) {
+	if _, ok := list.(model.PolicyStatusListOptions); !ok {
+		return c.rc.Watch(ctx, list, revision)
+	}
+	k8sWatchClient := cache.NewListWatchFromClient(c.rc.restClient, c.rc.resource, "", fields.Everything())
+	k8sWatch, err := k8sWatchClient.WatchFunc(metav1.ListOptions{ResourceVersion: revision})
+	if err != nil {
+		return nil, K8sErrorToCalico(err, list)
+	}
+	return newK8sWatcherConverter(ctx, libapiv3.KindPolicyStatusReport+" (custom)", c.toKVPairV1, k8sWatch), nil
+}
+
+func (c *policyStatusReportClient) EnsureInitialized() error {
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/testutils"
+)
+
+var _ = testutils.E2eDatastoreDescribe("Policy status k8s backend tests", testutils.DatastoreK8s, func(config apiconfig.CalicoAPIConfig) {
+	It("should store and list policy status per node", func() {
+		be, err := backend.NewClient(config)
+		Expect(err).NotTo(HaveOccurred())
+		be.Clean()
+
+		key := func(node string) model.PolicyStatusKey {
+			return model.PolicyStatusKey{
+				Hostname:     node,
+				Tier:         "default",
+				Name:         "default/default.allow-dns",
+				RegionString: model.RegionString(""),
+			}
+		}
+		for _, node := range []string{"node-1", "node-2"} {
+			_, err = be.Apply(context.Background(), &model.KVPair{
+				Key:   key(node),
+				Value: &model.PolicyStatus{Status: model.PolicyStatusPending, ContentHash: "abcd"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		// Applying again updates the existing status.
+		_, err = be.Apply(context.Background(), &model.KVPair{
+			Key:   key("node-1"),
+			Value: &model.PolicyStatus{Status: model.PolicyStatusProgrammed, ContentHash: "abcd"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		kvps, err := be.List(context.Background(), model.PolicyStatusListOptions{Hostname: "node-1"}, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kvps.KVPairs).To(HaveLen(1))
+		Expect(kvps.KVPairs[0].Key).To(Equal(key("node-1")))
+		Expect(kvps.KVPairs[0].Value).To(Equal(&model.PolicyStatus{Status: model.PolicyStatusProgrammed, ContentHash: "abcd"}))
+
+		kvps, err = be.List(context.Background(), model.PolicyStatusListOptions{}, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kvps.KVPairs).To(HaveLen(2))
+
+		_, err = be.Delete(context.Background(), key("node-2"), "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = be.Get(context.Background(), key("node-2"), "")
+		Expect(err).To(BeAssignableToTypeOf(errors.ErrorResourceDoesNotExist{}))
+	})
+})
@@ -114,6 +114,12 @@ func (c *WorkloadEndpointClient) calcCNIAnnotations(kvp *model.KVPair) map[strin
 	}
 	log.Debugf("PATCHing pod with IPs: %v", ips)
 
+	if wep.Spec.Endpoint != "" && wep.Spec.Endpoint != conversion.DefaultPodInterface {
+		// This is one of the pod's additional interfaces, which has its own annotation.
+		annotations[conversion.AnnotationPodIPsForInterface(wep.Spec.Endpoint)] = strings.Join(ips, ",")
+		return annotations
+	}
+
 	// Write the IP addresses into annotations.  This generates an event more quickly than
 	// waiting for kubelet to update the PodStatus PodIP and PodIPs fields.
 	firstIP := ""
@@ -134,6 +140,16 @@ func (c *WorkloadEndpointClient) calcCNIAnnotations(kvp *model.KVPair) map[strin
 // patchOutAnnotations sets our pod IP annotations to empty strings; this is used to signal that the IP has been removed
 // from the pod at teardown.
 func (c *WorkloadEndpointClient) patchOutAnnotations(ctx context.Context, key model.Key, revision string, uid *types.UID) (*model.KVPair, error) {
+	wepID, err := c.converter.ParseWorkloadEndpointName(key.(model.ResourceKey).Name)
+	if err != nil {
+		return nil, err
+	}
+	if wepID.Endpoint != "" && wepID.Endpoint != conversion.DefaultPodInterface {
+		// One of the pod's additional interfaces; only remove that interface's IPs.
+		annotations := map[string]string{conversion.AnnotationPodIPsForInterface(wepID.Endpoint): ""}
+		return c.patchPodAnnotations(ctx, key, revision, uid, annotations)
+	}
+
 	// Passing nil for annotations will result in all annotations being explicitly set to the empty string.
 	// Setting the podIPs to empty string is used to signal that the CNI DEL has removed the IP from the Pod.
 	// We leave the container ID in place to allow any repeat invocations of the CNI DEL to tell which instance of a Pod they are seeing.
@@ -170,6 +186,13 @@ func (c *WorkloadEndpointClient) patchPodAnnotations(ctx context.Context, key mo
 		return nil, err
 	}
 
+	// Return the WorkloadEndpoint that we were asked to update, which may be one of the pod's
+	// additional interfaces.
+	for _, kvp := range kvps {
+		if kvp.Value.(*libapiv3.WorkloadEndpoint).Name == key.(model.ResourceKey).Name {
+			return kvp, nil
+		}
+	}
 	return kvps[0], nil
 }
 
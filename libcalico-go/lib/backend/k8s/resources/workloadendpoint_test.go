@@ -153,6 +153,69 @@ var _ = Describe("WorkloadEndpointClient", func() {
 				}))
 			})
 		})
+		Context("WorkloadEndpoint is for an additional network", func() {
+			It("sets and clears only the annotation for that interface", func() {
+				k8sClient := fake.NewSimpleClientset(&k8sapi.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "simplePod",
+						Namespace: "testNamespace",
+						Annotations: map[string]string{
+							conversion.AnnotationPodIPs:             "192.168.91.117/32",
+							conversion.AnnotationAdditionalNetworks: `[{"name": "net-a", "ipv4Pools": ["pool-a"]}]`,
+						},
+					},
+					Spec: k8sapi.PodSpec{
+						NodeName: "test-node",
+					},
+				})
+
+				wepClient := resources.NewWorkloadEndpointClient(k8sClient)
+				wepIDs := names.WorkloadEndpointIdentifiers{
+					Orchestrator: "k8s",
+					Node:         "test-node",
+					Pod:          "simplePod",
+					Endpoint:     "eth1",
+				}
+
+				wepName, err := wepIDs.CalculateWorkloadEndpointName(false)
+				Expect(err).ShouldNot(HaveOccurred())
+				wep := &libapiv3.WorkloadEndpoint{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      wepName,
+						Namespace: "testNamespace",
+					},
+					Spec: libapiv3.WorkloadEndpointSpec{
+						ContainerID: "abcde12345",
+						Endpoint:    "eth1",
+						IPNetworks:  []string{"10.10.0.5/32"},
+					},
+				}
+
+				key := model.ResourceKey{
+					Name:      wep.Name,
+					Namespace: wep.Namespace,
+					Kind:      libapiv3.KindWorkloadEndpoint,
+				}
+
+				ctxCNI := resources.ContextWithPatchMode(context.Background(), resources.PatchModeCNI)
+				kvp, err := wepClient.Create(ctxCNI, &model.KVPair{Key: key, Value: wep})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(kvp.Value.(*libapiv3.WorkloadEndpoint).Name).To(Equal(wepName))
+				Expect(kvp.Value.(*libapiv3.WorkloadEndpoint).Spec.IPNetworks).To(Equal([]string{"10.10.0.5/32"}))
+
+				pod, err := k8sClient.CoreV1().Pods("testNamespace").Get(ctx, "simplePod", metav1.GetOptions{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(pod.GetAnnotations()).Should(HaveKeyWithValue(conversion.AnnotationPodIPsForInterface("eth1"), "10.10.0.5/32"))
+				Expect(pod.GetAnnotations()).Should(HaveKeyWithValue(conversion.AnnotationPodIPs, "192.168.91.117/32"))
+
+				_, err = wepClient.Delete(context.Background(), key, "", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+				pod, err = k8sClient.CoreV1().Pods("testNamespace").Get(ctx, "simplePod", metav1.GetOptions{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(pod.GetAnnotations()).Should(HaveKeyWithValue(conversion.AnnotationPodIPsForInterface("eth1"), ""))
+				Expect(pod.GetAnnotations()).Should(HaveKeyWithValue(conversion.AnnotationPodIPs, "192.168.91.117/32"))
+			})
+		})
 	})
 	Describe("Update", func() {
 		Context("WorkloadEndpoint has no IPs set", func() {
@@ -50,4 +50,5 @@ type IPAMConfig struct {
 	StrictAffinity     bool `json:"strict_affinity,omitempty"`
 	AutoAllocateBlocks bool `json:"auto_allocate_blocks,omitempty"`
 	MaxBlocksPerHost   int  `json:"maxBlocksPerHost,omitempty"`
+	EventLog           bool `json:"eventLog,omitempty"`
 }
@@ -451,6 +451,8 @@ func keyFromDefaultPathInner(path string, parts []string) Key {
 				return LastStatusReportListOptions{}.KeyFromDefaultPath(path)
 			case "workload":
 				return WorkloadEndpointStatusListOptions{}.KeyFromDefaultPath(path)
+			case "policy":
+				return PolicyStatusListOptions{}.KeyFromDefaultPath(path)
 			}
 		}
 	}
@@ -571,6 +573,8 @@ func OldKeyFromDefaultPath(path string) Key {
 		return k
 	} else if k := (WorkloadEndpointStatusListOptions{}).KeyFromDefaultPath(path); k != nil {
 		return k
+	} else if k := (PolicyStatusListOptions{}).KeyFromDefaultPath(path); k != nil {
+		return k
 	} else if k := (ActiveStatusReportListOptions{}).KeyFromDefaultPath(path); k != nil {
 		return k
 	} else if k := (LastStatusReportListOptions{}).KeyFromDefaultPath(path); k != nil {
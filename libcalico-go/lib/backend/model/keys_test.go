@@ -196,6 +196,28 @@ var _ = Describe("keys with region component", func() {
 		}))
 	})
 
+	It("should parse policy status with any region", func() {
+		Expect((PolicyStatusListOptions{}).KeyFromDefaultPath("/calico/felix/v2/region-Europe/host/h1/policy/t1/t1.p1")).To(Equal(PolicyStatusKey{
+			Hostname:     "h1",
+			Tier:         "t1",
+			Name:         "t1.p1",
+			RegionString: RegionString("Europe"),
+		}))
+	})
+
+	It("should not parse policy status with wrong hostname", func() {
+		Expect((PolicyStatusListOptions{Hostname: "h2"}).KeyFromDefaultPath("/calico/felix/v2/region-Europe/host/h1/policy/t1/t1.p1")).To(BeNil())
+	})
+
+	It("should give the same policy content hash for equal policies", func() {
+		order := 10.0
+		p1 := &Policy{Order: &order, Selector: "all()", InboundRules: []Rule{{Action: "allow"}}}
+		p2 := &Policy{Order: &order, Selector: "all()", InboundRules: []Rule{{Action: "allow"}}}
+		Expect(PolicyContentHash(p1)).To(Equal(PolicyContentHash(p2)))
+		p2.Selector = "has(foo)"
+		Expect(PolicyContentHash(p1)).NotTo(Equal(PolicyContentHash(p2)))
+	})
+
 	It("should parse active Felix status with any region", func() {
 		Expect((ActiveStatusReportListOptions{}).KeyFromDefaultPath("/calico/felix/v2/region-Europe/host/h1/status")).To(Equal(ActiveStatusReportKey{
 			Hostname:     "h1",
@@ -362,6 +384,12 @@ var _ = DescribeTable(
 		WorkloadEndpointStatusKey{Hostname: "h1", EndpointID: "e1", RegionString: "region-Europe", WorkloadID: "w1", OrchestratorID: "o1"},
 		false,
 	),
+	Entry(
+		"policy status",
+		"/calico/felix/v2/region-Europe/host/h1/policy/default/ns1%2fdefault.p1",
+		PolicyStatusKey{Hostname: "h1", Tier: "default", Name: "ns1/default.p1", RegionString: "region-Europe"},
+		false,
+	),
 	Entry(
 		"Felix active status",
 		"/calico/felix/v2/region-Europe/host/h1/status",
@@ -0,0 +1,176 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/errors"
+)
+
+const (
+	// PolicyStatusPending indicates that a policy applies to the host, but hasn't yet been programmed
+	// into the dataplane.
+	PolicyStatusPending = "pending"
+	// PolicyStatusProgrammed indicates that a policy has been programmed into the dataplane.
+	PolicyStatusProgrammed = "programmed"
+	// PolicyStatusError indicates that the dataplane failed to program a policy.
+	PolicyStatusError = "error"
+)
+
+var (
+	matchPolicyStatus = regexp.MustCompile("^/?calico/felix/v2/([^/]+)/host/([^/]+)/policy/([^/]+)/([^/]+)$")
+	typePolicyStatus  = reflect.TypeOf(PolicyStatus{})
+)
+
+// PolicyStatusKey is the key for the status of a policy on a particular host, as reported by Felix.
+// The Tier and Name match those of the PolicyKey of the policy.
+type PolicyStatusKey struct {
+	Hostname     string `json:"-"`
+	Tier         string `json:"-"`
+	Name         string `json:"-"`
+	RegionString string
+}
+
+func (key PolicyStatusKey) defaultPath() (string, error) {
+	if key.Hostname == "" {
+		return "", errors.ErrorInsufficientIdentifiers{Name: "hostname"}
+	}
+	if key.Tier == "" {
+		return "", errors.ErrorInsufficientIdentifiers{Name: "tier"}
+	}
+	if key.Name == "" {
+		return "", errors.ErrorInsufficientIdentifiers{Name: "name"}
+	}
+	if key.RegionString == "" {
+		return "", errors.ErrorInsufficientIdentifiers{Name: "regionString"}
+	}
+	if strings.Contains(key.RegionString, "/") {
+		return "", ErrorSlashInRegionString(key.RegionString)
+	}
+	return fmt.Sprintf("/calico/felix/v2/%s/host/%s/policy/%s/%s",
+		key.RegionString, key.Hostname, escapeName(key.Tier), escapeName(key.Name)), nil
+}
+
+func (key PolicyStatusKey) defaultDeletePath() (string, error) {
+	return key.defaultPath()
+}
+
+func (key PolicyStatusKey) defaultDeleteParentPaths() ([]string, error) {
+	return nil, nil
+}
+
+func (key PolicyStatusKey) valueType() (reflect.Type, error) {
+	return typePolicyStatus, nil
+}
+
+func (key PolicyStatusKey) String() string {
+	return fmt.Sprintf("PolicyStatus(hostname=%s, tier=%s, name=%s)", key.Hostname, key.Tier, key.Name)
+}
+
+type PolicyStatusListOptions struct {
+	Hostname     string
+	Tier         string
+	Name         string
+	RegionString string
+}
+
+func (options PolicyStatusListOptions) defaultPathRoot() string {
+	k := "/calico/felix/v2/"
+	if options.RegionString == "" {
+		return k
+	}
+	k = k + options.RegionString + "/host"
+	if options.Hostname == "" {
+		return k
+	}
+	k = k + fmt.Sprintf("/%s/policy", options.Hostname)
+	if options.Tier == "" {
+		return k
+	}
+	k = k + fmt.Sprintf("/%s", escapeName(options.Tier))
+	if options.Name == "" {
+		return k
+	}
+	k = k + fmt.Sprintf("/%s", escapeName(options.Name))
+	return k
+}
+
+func (options PolicyStatusListOptions) KeyFromDefaultPath(ekey string) Key {
+	log.Debugf("Get PolicyStatus key from %s", ekey)
+	r := matchPolicyStatus.FindAllStringSubmatch(ekey, -1)
+	if len(r) != 1 {
+		log.Debugf("Didn't match regex")
+		return nil
+	}
+	regionString := r[0][1]
+	hostname := r[0][2]
+	tier := unescapeName(r[0][3])
+	name := unescapeName(r[0][4])
+	if options.RegionString != "" && regionString != options.RegionString {
+		log.Debugf("Didn't match region %s != %s", options.RegionString, regionString)
+		return nil
+	}
+	if options.Hostname != "" && hostname != options.Hostname {
+		log.Debugf("Didn't match hostname %s != %s", options.Hostname, hostname)
+		return nil
+	}
+	if options.Tier != "" && tier != options.Tier {
+		log.Debugf("Didn't match tier %s != %s", options.Tier, tier)
+		return nil
+	}
+	if options.Name != "" && name != options.Name {
+		log.Debugf("Didn't match name %s != %s", options.Name, name)
+		return nil
+	}
+	return PolicyStatusKey{
+		Hostname:     hostname,
+		Tier:         tier,
+		Name:         name,
+		RegionString: regionString,
+	}
+}
+
+// PolicyStatus is the status of a policy on a particular host.
+type PolicyStatus struct {
+	// Status is one of PolicyStatusPending, PolicyStatusProgrammed or PolicyStatusError.
+	Status string `json:"status"`
+	// ContentHash is the PolicyContentHash of the version of the policy that the status applies to.
+	ContentHash string `json:"contentHash,omitempty"`
+	// Errors is the number of consecutive failed attempts to program the policy.
+	Errors int `json:"errors,omitempty"`
+}
+
+// PolicyContentHash returns a hash of the content of a policy.  Felix includes the hash in its policy
+// status reports so that consumers of the status can tell which version of the policy it refers to.
+func PolicyContentHash(p *Policy) string {
+	if p == nil {
+		return ""
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		log.WithError(err).Panic("Failed to marshal policy")
+	}
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
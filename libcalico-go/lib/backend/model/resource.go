@@ -105,6 +105,16 @@ func init() {
 		"ipreservations",
 		reflect.TypeOf(apiv3.IPReservation{}),
 	)
+	registerResourceInfo(
+		apiv3.KindIPPoolMigration,
+		"ippoolmigrations",
+		reflect.TypeOf(apiv3.IPPoolMigration{}),
+	)
+	registerResourceInfo(
+		apiv3.KindRoutePolicy,
+		"routepolicies",
+		reflect.TypeOf(apiv3.RoutePolicy{}),
+	)
 	registerResourceInfo(
 		apiv3.KindNetworkPolicy,
 		"networkpolicies",
@@ -155,6 +165,26 @@ func init() {
 		"ipamconfigs",
 		reflect.TypeOf(libapiv3.IPAMConfig{}),
 	)
+	registerResourceInfo(
+		libapiv3.KindIPAMEvent,
+		"ipamevents",
+		reflect.TypeOf(libapiv3.IPAMEvent{}),
+	)
+	registerResourceInfo(
+		libapiv3.KindIPAMQuotaCounter,
+		"ipamquotacounters",
+		reflect.TypeOf(libapiv3.IPAMQuotaCounter{}),
+	)
+	registerResourceInfo(
+		libapiv3.KindFelixConfigReport,
+		"felixconfigreports",
+		reflect.TypeOf(libapiv3.FelixConfigReport{}),
+	)
+	registerResourceInfo(
+		libapiv3.KindPolicyStatusReport,
+		"policystatusreports",
+		reflect.TypeOf(libapiv3.PolicyStatusReport{}),
+	)
 	registerResourceInfo(
 		apiv3.KindKubeControllersConfiguration,
 		"kubecontrollersconfigurations",
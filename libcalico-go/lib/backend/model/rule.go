@@ -67,6 +67,10 @@ type Rule struct {
 	NotDstNets     []*net.IPNet       `json:"!dst_nets,omitempty" validate:"omitempty"`
 	NotDstPorts    []numorstring.Port `json:"!dst_ports,omitempty" validate:"omitempty,dive"`
 
+	// ConnLimit, if non-zero, restricts the rule to new connections from sources that already
+	// have more than ConnLimit tracked connections.
+	ConnLimit int `json:"conn_limit,omitempty" validate:"omitempty,gte=0"`
+
 	// These fields allow us to pass through the raw match criteria from the V3 datamodel unmodified.
 	// The selectors above are formed in the update processor layer by combining the original
 	// selectors, namespace selectors and service account selectors into one.
@@ -90,8 +94,12 @@ type Rule struct {
 }
 
 type HTTPMatch struct {
-	Methods []string         `json:"methods,omitempty" validate:"omitempty"`
-	Paths   []apiv3.HTTPPath `json:"paths,omitempty" validate:"omitempty"`
+	Methods   []string                `json:"methods,omitempty" validate:"omitempty"`
+	Paths     []apiv3.HTTPPath        `json:"paths,omitempty" validate:"omitempty"`
+	Headers   []apiv3.HTTPHeaderMatch `json:"headers,omitempty" validate:"omitempty"`
+	Hosts     []string                `json:"hosts,omitempty" validate:"omitempty"`
+	GRPC      *apiv3.GRPCMatch        `json:"grpc,omitempty" validate:"omitempty"`
+	JWTClaims []apiv3.JWTClaimMatch   `json:"jwt_claims,omitempty" validate:"omitempty"`
 }
 
 type RuleMetadata struct {
@@ -164,6 +172,9 @@ func (r Rule) String() string {
 	if r.NotICMPCode != nil {
 		parts = append(parts, "!code", strconv.Itoa(*r.NotICMPCode))
 	}
+	if r.ConnLimit != 0 {
+		parts = append(parts, "connlimit", strconv.Itoa(r.ConnLimit))
+	}
 
 	{
 		// Source attributes.  New block ensures that fromParts goes out-of-scope before
@@ -256,6 +267,18 @@ func (r Rule) String() string {
 			if len(r.HTTPMatch.Paths) > 0 {
 				toParts = append(toParts, "httpPaths", fmt.Sprintf("%+v", r.HTTPMatch.Paths))
 			}
+			if len(r.HTTPMatch.Headers) > 0 {
+				toParts = append(toParts, "httpHeaders", fmt.Sprintf("%+v", r.HTTPMatch.Headers))
+			}
+			if len(r.HTTPMatch.Hosts) > 0 {
+				toParts = append(toParts, "httpHosts", fmt.Sprintf("%+v", r.HTTPMatch.Hosts))
+			}
+			if r.HTTPMatch.GRPC != nil {
+				toParts = append(toParts, "grpc", fmt.Sprintf("%+v", *r.HTTPMatch.GRPC))
+			}
+			if len(r.HTTPMatch.JWTClaims) > 0 {
+				toParts = append(toParts, "jwtClaims", fmt.Sprintf("%+v", r.HTTPMatch.JWTClaims))
+			}
 		}
 
 		if len(toParts) > 0 {
@@ -51,6 +51,8 @@ var ports2 = []numorstring.Port{
 var _, cidr, _ = net.ParseCIDR("10.0.0.0/16")
 var httpMethod = &model.HTTPMatch{Methods: []string{"GET", "PUT"}}
 var httpPath = &model.HTTPMatch{Paths: []apiv3.HTTPPath{{Exact: "/foo"}, {Prefix: "/bar"}}}
+var httpHeader = &model.HTTPMatch{Headers: []apiv3.HTTPHeaderMatch{{Name: "x-tenant", Prefix: "a"}}}
+var httpGRPC = &model.HTTPMatch{GRPC: &apiv3.GRPCMatch{Methods: []string{"SayHello"}}}
 
 var ruleStringTests = []ruleTest{
 	// Empty
@@ -100,6 +102,8 @@ var ruleStringTests = []ruleTest{
 	// Application layer rules.
 	{model.Rule{HTTPMatch: httpMethod}, "Allow to httpMethods [GET PUT]"},
 	{model.Rule{HTTPMatch: httpPath}, "Allow to httpPaths [{Exact:/foo Prefix:} {Exact: Prefix:/bar}]"},
+	{model.Rule{HTTPMatch: httpHeader}, "Allow to httpHeaders [{Name:x-tenant Exact: Prefix:a Regex:}]"},
+	{model.Rule{HTTPMatch: httpGRPC}, "Allow to grpc {Services:[] Methods:[SayHello]}"},
 
 	// Complex rule.
 	{model.Rule{Protocol: &tcpProto,
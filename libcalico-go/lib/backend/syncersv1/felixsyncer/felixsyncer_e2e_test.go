@@ -651,6 +651,29 @@ var _ = testutils.E2eDatastoreDescribe("Felix syncer tests", testutils.Datastore
 			})
 			syncTester.ExpectCacheSize(expectedCacheSize)
 
+			By("Creating a RoutePolicy")
+			rp, err := c.RoutePolicies().Create(
+				ctx,
+				&apiv3.RoutePolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "myroutepolicy"},
+					Spec: apiv3.RoutePolicySpec{
+						Selector:     "app == 'scanner'",
+						Destinations: []string{"0.0.0.0/0"},
+						NextHop:      "10.0.0.5",
+						Table:        200,
+					},
+				},
+				options.SetOptions{},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			expectedCacheSize += 1
+			syncTester.ExpectData(model.KVPair{
+				Key:      model.ResourceKey{Kind: apiv3.KindRoutePolicy, Name: "myroutepolicy"},
+				Value:    rp,
+				Revision: rp.ResourceVersion,
+			})
+			syncTester.ExpectCacheSize(expectedCacheSize)
+
 			By("Starting a new syncer and verifying that all current entries are returned before sync status")
 			// We need to create a new syncTester and syncer.
 			current := syncTester.GetCacheEntries()
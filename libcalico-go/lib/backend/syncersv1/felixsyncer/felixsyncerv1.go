@@ -85,6 +85,9 @@ func New(client api.Client, cfg apiconfig.CalicoAPIConfigSpec, callbacks api.Syn
 			{
 				ListInterface: model.ResourceListOptions{Kind: apiv3.KindBGPConfiguration},
 			},
+			{
+				ListInterface: model.ResourceListOptions{Kind: apiv3.KindRoutePolicy},
+			},
 		}
 
 		// If running in kdd mode, also watch Kubernetes network policies directly.
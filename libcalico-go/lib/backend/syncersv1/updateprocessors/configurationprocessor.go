@@ -41,7 +41,8 @@ const (
 // we expand that to a delete for the associated key.
 //
 // If the field specifies a "confignamev1" tag, then the value in that tag is used
-// as the config name, otherwise the struct field name is used.
+// as the config name, otherwise the struct field name is used.  Fields with the
+// tag value "-" are not config values, and are skipped.
 //
 // A set of ValueToStringFn can be specified for each of the (converted) field names
 // to handle marshaling the field value into the string value required in the v1
@@ -66,7 +67,9 @@ func NewConfigUpdateProcessor(
 ) watchersyncer.SyncerUpdateProcessor {
 	names := make(map[string]struct{}, specType.NumField())
 	for i := 0; i < specType.NumField(); i++ {
-		names[getConfigName(specType.Field(i))] = struct{}{}
+		if name := getConfigName(specType.Field(i)); name != skipConfigName {
+			names[name] = struct{}{}
+		}
 	}
 	return &configUpdateProcessor{
 		specType:          specType,
@@ -92,6 +95,7 @@ var (
 	globalConfigName        = "default"
 	perNodeConfigNamePrefix = "node."
 	annotationConfigPrefix  = "config.projectcalico.org/"
+	skipConfigName          = "-"
 )
 
 // configUpdateProcessor implements the SyncerUpdateProcessor interface for converting
@@ -183,10 +187,13 @@ func (c *configUpdateProcessor) processAddOrModified(kvp *model.KVPair) ([]*mode
 
 	// Create a KVP for each field in the Spec struct.
 	var kvps []*model.KVPair
-	numFields := len(c.names)
+	numFields := c.specType.NumField()
 	for i := 0; i < numFields; i++ {
 		fieldInfo := c.specType.Field(i)
 		name := getConfigName(fieldInfo)
+		if name == skipConfigName {
+			continue
+		}
 
 		key := c.createV1Key(node, name)
 		if key == nil {
@@ -45,7 +45,7 @@ const (
 )
 
 const (
-	numBaseFelixConfigs = 140
+	numBaseFelixConfigs = 143
 )
 
 var _ = Describe("Test the generic configuration update processor and the concrete implementations", func() {
@@ -167,18 +167,53 @@ var _ = Describe("Test the generic configuration update processor and the concre
 		})
 		Expect(err).To(HaveOccurred())
 
-		By("Testing incorrect name structure on Process with add/mod")
-		_, err = cc.Process(&model.KVPair{
-			Key:   invalidFelixKey,
-			Value: apiv3.NewFelixConfiguration(),
+		By("Testing incorrect name structure on ClusterInformation Process with delete")
+		_, err = updateprocessors.NewClusterInfoUpdateProcessor().Process(&model.KVPair{
+			Key: model.ResourceKey{Kind: apiv3.KindClusterInformation, Name: "foobar"},
 		})
 		Expect(err).To(HaveOccurred())
+	})
 
-		By("Testing incorrect name structure on Process with delete")
-		_, err = cc.Process(&model.KVPair{
-			Key: invalidFelixKey,
-		})
-		Expect(err).To(HaveOccurred())
+	It("should pass through FelixConfigurations with a node selector", func() {
+		cc := updateprocessors.NewFelixConfigUpdateProcessor()
+		res := apiv3.NewFelixConfiguration()
+		res.Name = "foobar"
+		res.Spec.NodeSelector = "rack == 'a'"
+		kvp := &model.KVPair{Key: invalidFelixKey, Value: res}
+		kvps, err := cc.Process(kvp)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kvps).To(Equal([]*model.KVPair{kvp}))
+
+		By("passing through the delete")
+		kvp = &model.KVPair{Key: invalidFelixKey}
+		kvps, err = cc.Process(kvp)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kvps).To(Equal([]*model.KVPair{kvp}))
+	})
+
+	It("should not apply FelixConfigurations without a node selector", func() {
+		cc := updateprocessors.NewFelixConfigUpdateProcessor()
+		res := apiv3.NewFelixConfiguration()
+		res.Name = "foobar"
+		res.Spec.InterfacePrefix = "califoobar"
+		kvps, err := cc.Process(&model.KVPair{Key: invalidFelixKey, Value: res, Revision: "1234"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kvps).To(Equal([]*model.KVPair{{Key: invalidFelixKey, Revision: "1234"}}))
+	})
+
+	It("should return the config values of a FelixConfiguration", func() {
+		res := apiv3.NewFelixConfiguration()
+		res.Spec.NodeSelector = "rack == 'a'"
+		res.Spec.InterfacePrefix = "califoobar"
+		res.Spec.RouteTableRanges = &apiv3.RouteTableRanges{{Min: 43, Max: 211}}
+		res.Annotations = map[string]string{"config.projectcalico.org/NewConfigType": "foo"}
+		values, err := updateprocessors.FelixConfigValues(res)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(map[string]string{
+			"InterfacePrefix":  "califoobar",
+			"RouteTableRanges": "43-211",
+			"NewConfigType":    "foo",
+		}))
 	})
 
 	It("should handle different field types being assigned", func() {
@@ -276,6 +311,30 @@ var _ = Describe("Test the generic configuration update processor and the concre
 		)
 	})
 
+	It("should handle ConntrackTimeouts", func() {
+		cc := updateprocessors.NewFelixConfigUpdateProcessor()
+		By("converting a per-node felix KVPair with conntrack timeouts and checking for the correct number of fields")
+		res := apiv3.NewFelixConfiguration()
+		res.Spec.ConntrackTimeouts = &apiv3.ConntrackTimeouts{
+			TCPEstablished: &metav1.Duration{Duration: 2 * time.Hour},
+			UDPTimeout:     &metav1.Duration{Duration: 10 * time.Second},
+		}
+		expected := map[string]interface{}{
+			"ConntrackTimeouts": "TCPEstablished=2h0m0s,UDPTimeout=10s",
+		}
+		kvps, err := cc.Process(&model.KVPair{
+			Key:   perNodeFelixKey,
+			Value: res,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		checkExpectedConfigs(
+			kvps,
+			isNodeFelixConfig,
+			numFelixConfigs,
+			expected,
+		)
+	})
+
 	It("should handle cluster config string slice field", func() {
 		cc := updateprocessors.NewClusterInfoUpdateProcessor()
 		By("converting a global cluster info KVPair with values assigned")
@@ -1,4 +1,4 @@
-// Copyright (c) 2017,2020 Tigera, Inc. All rights reserved.
+// Copyright (c) 2017-2024 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -20,30 +20,94 @@ import (
 	"strings"
 
 	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
 
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/watchersyncer"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
 	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
 )
 
 // Create a new SyncerUpdateProcessor to sync FelixConfiguration data in v1 format for
 // consumption by Felix.
+//
+// The "default" and "node.<nodename>" FelixConfigurations are expanded into global and per-node
+// config values.  Any other FelixConfiguration applies to the nodes that its NodeSelector selects,
+// which is only known to Felix, so it is passed through unchanged.  Without a NodeSelector, it
+// doesn't apply to any node, and is passed through as a deletion.
 func NewFelixConfigUpdateProcessor() watchersyncer.SyncerUpdateProcessor {
+	return &felixConfigUpdateProcessor{
+		configUpdateProcessor: newFelixConfigUpdateProcessor(),
+	}
+}
+
+func newFelixConfigUpdateProcessor() *configUpdateProcessor {
 	return NewConfigUpdateProcessor(
 		reflect.TypeOf(apiv3.FelixConfigurationSpec{}),
 		AllowAnnotations,
 		func(node, name string) model.Key { return model.HostConfigKey{Hostname: node, Name: name} },
 		func(name string) model.Key { return model.GlobalConfigKey{Name: name} },
 		map[string]ConfigFieldValueToV1ModelValue{
-			"FailsafeInboundHostPorts":  protoPortSliceToString,
-			"FailsafeOutboundHostPorts": protoPortSliceToString,
-			"RouteTableRange":           routeTableRangeToString,
-			"RouteTableRanges":          routeTableRangeListToString,
-			"HealthTimeoutOverrides":    healthTimeoutOverridesToString,
+			"FailsafeInboundHostPorts":   protoPortSliceToString,
+			"FailsafeOutboundHostPorts":  protoPortSliceToString,
+			"RouteTableRange":            routeTableRangeToString,
+			"RouteTableRanges":           routeTableRangeListToString,
+			"RoutePolicyRouteTableRange": routeTableIDRangeToString,
+			"HealthTimeoutOverrides":     healthTimeoutOverridesToString,
+			"ConntrackTimeouts":          conntrackTimeoutsToString,
 		},
-	)
+	).(*configUpdateProcessor)
+}
+
+// felixConfigUpdateProcessor passes the FelixConfigurations with a NodeSelector through, and
+// converts the others with the configUpdateProcessor.
+type felixConfigUpdateProcessor struct {
+	*configUpdateProcessor
+}
+
+func (c *felixConfigUpdateProcessor) Process(kvp *model.KVPair) ([]*model.KVPair, error) {
+	if k, ok := kvp.Key.(model.ResourceKey); ok && IsNodeSelectorFelixConfigName(k.Name) {
+		if kvp.Value != nil {
+			fc, ok := kvp.Value.(*apiv3.FelixConfiguration)
+			if !ok {
+				log.WithField("Key", kvp.Key).Warning("Unexpected value type for FelixConfiguration")
+				return nil, cerrors.ErrorParsingDatastoreEntry{RawKey: k.Name}
+			}
+			if fc.Spec.NodeSelector == "" {
+				log.WithField("Key", kvp.Key).Debug("FelixConfiguration has no node selector, not applied")
+				return []*model.KVPair{{Key: kvp.Key, Revision: kvp.Revision}}, nil
+			}
+		}
+		return []*model.KVPair{kvp}, nil
+	}
+	return c.configUpdateProcessor.Process(kvp)
+}
+
+// IsNodeSelectorFelixConfigName returns true if the named FelixConfiguration applies to the
+// nodes selected by its NodeSelector, rather than being "default" or "node.<nodename>".
+func IsNodeSelectorFelixConfigName(name string) bool {
+	return name != globalConfigName && !strings.HasPrefix(name, perNodeConfigNamePrefix)
+}
+
+// FelixConfigValues returns the config values that a FelixConfiguration sets, by config name, in
+// the format used by Felix.
+func FelixConfigValues(res *apiv3.FelixConfiguration) (map[string]string, error) {
+	kvps, err := newFelixConfigUpdateProcessor().processAddOrModified(&model.KVPair{
+		Key:   model.ResourceKey{Kind: apiv3.KindFelixConfiguration, Name: globalConfigName},
+		Value: res,
+	})
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]string{}
+	for _, kvp := range kvps {
+		if s, ok := kvp.Value.(string); ok {
+			values[kvp.Key.(model.GlobalConfigKey).Name] = s
+		}
+	}
+	return values, nil
 }
 
 // Convert a slice of ProtoPorts to the string representation required by Felix.
@@ -89,6 +153,13 @@ func routeTableRangeToString(value interface{}) interface{} {
 	return fmt.Sprintf("%d-%d", r.Min, r.Max)
 }
 
+// Converts a route table ID range to its string config representation.
+// e.g. RouteTableIDRange{Min: 1000, Max: 1249} => "1000-1249"
+func routeTableIDRangeToString(value interface{}) interface{} {
+	r := value.(apiv3.RouteTableIDRange)
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
 func healthTimeoutOverridesToString(value interface{}) interface{} {
 	htos := value.([]apiv3.HealthTimeoutOverride)
 	if len(htos) == 0 {
@@ -100,3 +171,30 @@ func healthTimeoutOverridesToString(value interface{}) interface{} {
 	}
 	return strings.Join(parts, ",")
 }
+
+// Converts the conntrack timeouts to the key/duration list representation required by Felix.
+// e.g. ConntrackTimeouts{UDPTimeout: 10s} => "UDPTimeout=10s"
+func conntrackTimeoutsToString(value interface{}) interface{} {
+	ct := value.(apiv3.ConntrackTimeouts)
+	var parts []string
+	for _, t := range []struct {
+		name string
+		d    *metav1.Duration
+	}{
+		{"TCPSynSent", ct.TCPSynSent},
+		{"TCPEstablished", ct.TCPEstablished},
+		{"TCPFinsSeen", ct.TCPFinsSeen},
+		{"TCPResetSeen", ct.TCPResetSeen},
+		{"UDPTimeout", ct.UDPTimeout},
+		{"ICMPTimeout", ct.ICMPTimeout},
+		{"GenericTimeout", ct.GenericTimeout},
+	} {
+		if t.d != nil {
+			parts = append(parts, t.name+"="+t.d.Duration.String())
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	return strings.Join(parts, ",")
+}
@@ -203,7 +203,17 @@ func RuleAPIV3ToBackend(ar apiv3.Rule, ns string) model.Rule {
 		OriginalDstServiceAccountSelector: dstServiceAcctMatch.Selector,
 	}
 	if ar.HTTP != nil {
-		r.HTTPMatch = &model.HTTPMatch{Methods: ar.HTTP.Methods, Paths: ar.HTTP.Paths}
+		r.HTTPMatch = &model.HTTPMatch{
+			Methods:   ar.HTTP.Methods,
+			Paths:     ar.HTTP.Paths,
+			Headers:   ar.HTTP.Headers,
+			Hosts:     ar.HTTP.Hosts,
+			GRPC:      ar.HTTP.GRPC,
+			JWTClaims: ar.HTTP.JWTClaims,
+		}
+	}
+	if ar.ConnLimit != nil {
+		r.ConnLimit = ar.ConnLimit.MaxConnections
 	}
 	if ar.Metadata != nil {
 		if ar.Metadata.Annotations != nil {
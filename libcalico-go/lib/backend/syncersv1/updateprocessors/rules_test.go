@@ -72,6 +72,12 @@ var _ = Describe("Test the Rules Conversion Functions", func() {
 			HTTP: &apiv3.HTTPMatch{
 				Methods: []string{"GET", "PUT"},
 				Paths:   []apiv3.HTTPPath{{Exact: "/bar"}, {Prefix: "/foo1"}},
+				Headers: []apiv3.HTTPHeaderMatch{{Name: "x-tenant", Exact: "a"}},
+				Hosts:   []string{"*.example.com"},
+				GRPC:    &apiv3.GRPCMatch{Services: []string{"helloworld.Greeter"}},
+				JWTClaims: []apiv3.JWTClaimMatch{
+					{Name: "iss", Values: []string{"https://issuer.example.com"}},
+				},
 			},
 			Metadata: &apiv3.RuleMetadata{
 				Annotations: map[string]string{"fizz": "buzz"}},
@@ -121,6 +127,12 @@ var _ = Describe("Test the Rules Conversion Functions", func() {
 
 		Expect(rulev1.HTTPMatch.Methods).To(Equal([]string{"GET", "PUT"}))
 		Expect(rulev1.HTTPMatch.Paths).To(Equal([]apiv3.HTTPPath{{Exact: "/bar"}, {Prefix: "/foo1"}}))
+		Expect(rulev1.HTTPMatch.Headers).To(Equal([]apiv3.HTTPHeaderMatch{{Name: "x-tenant", Exact: "a"}}))
+		Expect(rulev1.HTTPMatch.Hosts).To(Equal([]string{"*.example.com"}))
+		Expect(rulev1.HTTPMatch.GRPC).To(Equal(&apiv3.GRPCMatch{Services: []string{"helloworld.Greeter"}}))
+		Expect(rulev1.HTTPMatch.JWTClaims).To(Equal([]apiv3.JWTClaimMatch{
+			{Name: "iss", Values: []string{"https://issuer.example.com"}},
+		}))
 
 		Expect(rulev1.Metadata.Annotations).To(Equal(map[string]string{"fizz": "buzz"}))
 
@@ -378,6 +390,21 @@ var _ = Describe("Test the Rules Conversion Functions", func() {
 		})
 	})
 
+	It("should only prefix the matched side of a label reference in a namespace selector", func() {
+		r := apiv3.Rule{
+			Action: apiv3.Allow,
+			Source: apiv3.EntityRule{
+				Selector:          "app == label(app)",
+				NamespaceSelector: "tenant == label(pcns.tenant)",
+			},
+		}
+
+		// Process the rule and get the corresponding v1 representation.
+		rulev1 := updateprocessors.RuleAPIV3ToBackend(r, "namespace")
+
+		Expect(rulev1.SrcSelector).To(Equal("(pcns.tenant == label(pcns.tenant)) && (app == label(app))"))
+	})
+
 	It("should parse a serviceaccount match", func() {
 		srce := fmt.Sprintf("(%s == 'namespace') && ((%skey == \"value1\") && (%s in {\"%s\", \"%s\"}))", apiv3.LabelNamespace, conversion.ServiceAccountLabelPrefix, apiv3.LabelServiceAccount, "sa1", "sa2")
 		dste := fmt.Sprintf("(pcns.nskey == \"nsvalue\") && ((%skey == \"value2\") && (%s in {\"%s\"}))", conversion.ServiceAccountLabelPrefix, apiv3.LabelServiceAccount, "sa3")
@@ -21,7 +21,6 @@ import (
 
 	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
-	validator "github.com/projectcalico/calico/libcalico-go/lib/validator/v3"
 	"github.com/projectcalico/calico/libcalico-go/lib/watch"
 )
 
@@ -44,7 +43,7 @@ type bgpConfigurations struct {
 // Returns the stored representation of the BGPConfiguration, and an error
 // if there is any.
 func (r bgpConfigurations) Create(ctx context.Context, res *apiv3.BGPConfiguration, opts options.SetOptions) (*apiv3.BGPConfiguration, error) {
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
@@ -63,7 +62,7 @@ func (r bgpConfigurations) Create(ctx context.Context, res *apiv3.BGPConfigurati
 // Returns the stored representation of the BGPConfiguration, and an error
 // if there is any.
 func (r bgpConfigurations) Update(ctx context.Context, res *apiv3.BGPConfiguration, opts options.SetOptions) (*apiv3.BGPConfiguration, error) {
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
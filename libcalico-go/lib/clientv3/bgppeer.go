@@ -22,7 +22,6 @@ import (
 	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
 	"github.com/projectcalico/calico/libcalico-go/lib/set"
-	validator "github.com/projectcalico/calico/libcalico-go/lib/validator/v3"
 	"github.com/projectcalico/calico/libcalico-go/lib/watch"
 )
 
@@ -88,7 +87,7 @@ func (r bgpPeers) Create(ctx context.Context, res *apiv3.BGPPeer, opts options.S
 		return nil, err
 	}
 
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
@@ -106,7 +105,7 @@ func (r bgpPeers) Update(ctx context.Context, res *apiv3.BGPPeer, opts options.S
 	if err := r.validate(ctx, res); err != nil {
 		return nil, err
 	}
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
@@ -182,6 +182,16 @@ func (c client) BGPFilter() BGPFilterInterface {
 	return BGPFilter{client: c}
 }
 
+// RoutePolicies returns an interface for managing route policy resources.
+func (c client) RoutePolicies() RoutePolicyInterface {
+	return routePolicies{client: c}
+}
+
+// IPPoolMigrations returns an interface for managing IP pool migration resources.
+func (c client) IPPoolMigrations() IPPoolMigrationInterface {
+	return ipPoolMigrations{client: c}
+}
+
 type poolAccessor struct {
 	client *client
 }
@@ -20,7 +20,6 @@ import (
 	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
 
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
-	validator "github.com/projectcalico/calico/libcalico-go/lib/validator/v3"
 	"github.com/projectcalico/calico/libcalico-go/lib/watch"
 )
 
@@ -44,7 +43,7 @@ type felixConfigurations struct {
 // if there is any.
 func (r felixConfigurations) Create(ctx context.Context, res *apiv3.FelixConfiguration, opts options.SetOptions) (*apiv3.FelixConfiguration, error) {
 	setDefaults(res)
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
@@ -60,7 +59,7 @@ func (r felixConfigurations) Create(ctx context.Context, res *apiv3.FelixConfigu
 // if there is any.
 func (r felixConfigurations) Update(ctx context.Context, res *apiv3.FelixConfiguration, opts options.SetOptions) (*apiv3.FelixConfiguration, error) {
 	setDefaults(res)
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
@@ -21,7 +21,6 @@ import (
 
 	"github.com/projectcalico/calico/libcalico-go/lib/names"
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
-	validator "github.com/projectcalico/calico/libcalico-go/lib/validator/v3"
 	"github.com/projectcalico/calico/libcalico-go/lib/watch"
 
 	log "github.com/sirupsen/logrus"
@@ -60,7 +59,7 @@ func (r globalNetworkPolicies) Create(ctx context.Context, res *apiv3.GlobalNetw
 	}
 	defaultPolicyTypesField(res.Spec.Ingress, res.Spec.Egress, &res.Spec.Types)
 
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
@@ -100,7 +99,7 @@ func (r globalNetworkPolicies) Update(ctx context.Context, res *apiv3.GlobalNetw
 	}
 	defaultPolicyTypesField(res.Spec.Ingress, res.Spec.Egress, &res.Spec.Types)
 
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
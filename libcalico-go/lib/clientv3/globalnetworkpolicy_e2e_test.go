@@ -29,6 +29,7 @@ import (
 	"github.com/projectcalico/calico/libcalico-go/lib/backend"
 	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
 	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/errors"
 	"github.com/projectcalico/calico/libcalico-go/lib/names"
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
 	"github.com/projectcalico/calico/libcalico-go/lib/testutils"
@@ -344,6 +345,34 @@ var _ = testutils.E2eDatastoreDescribe("GlobalNetworkPolicy tests", testutils.Da
 		Entry("Two fully populated GlobalNetworkPolicySpecs", tier, tier+"."+name1, tier+"."+name2, spec1, spec2, ingressEgress, ingressEgress),
 	)
 
+	Describe("GlobalNetworkPolicy selector extensions", func() {
+		It("should only accept comparison, matches and label() operators once the feature gate is enabled", func() {
+			gnp := &apiv3.GlobalNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name1},
+				Spec:       *spec1.DeepCopy(),
+			}
+			gnp.Spec.Selector = "tier > 2 && app matches 'web-.*'"
+			gnp.Spec.Ingress[0].Source.Selector = "app == label(app)"
+
+			By("Rejecting the policy when the feature gate is not set")
+			_, outError := c.GlobalNetworkPolicies().Create(ctx, gnp, options.SetOptions{})
+			Expect(outError).To(HaveOccurred())
+			Expect(outError.Error()).To(ContainSubstring("SelectorExtensions=enabled"))
+			Expect(outError.(errors.ErrorValidation).ErroredFields).To(HaveLen(2))
+
+			By("Enabling the feature gate")
+			_, outError = c.FelixConfigurations().Create(ctx, &apiv3.FelixConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       apiv3.FelixConfigurationSpec{FeatureGates: "SelectorExtensions=enabled"},
+			}, options.SetOptions{})
+			Expect(outError).NotTo(HaveOccurred())
+
+			By("Accepting the policy")
+			_, outError = c.GlobalNetworkPolicies().Create(ctx, gnp, options.SetOptions{})
+			Expect(outError).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("GlobalNetworkPolicy watch functionality", func() {
 		It("should handle watch events for different resource versions and event types", func() {
 			By("Listing GlobalNetworkPolicies with the latest resource version and checking for two results with name1/spec2 and name2/spec2")
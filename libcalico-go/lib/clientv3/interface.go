@@ -41,6 +41,8 @@ type Interface interface {
 	CalicoNodeStatusClient
 	IPAMConfigClient
 	BlockAffinitiesClient
+	RoutePoliciesClient
+	IPPoolMigrationsClient
 	// Tiers returns an interface for managing tier resources.
 	Tiers() TierInterface
 
@@ -78,6 +80,16 @@ type IPReservationsClient interface {
 	IPReservations() IPReservationInterface
 }
 
+type RoutePoliciesClient interface {
+	// RoutePolicies returns an interface for managing route policy resources.
+	RoutePolicies() RoutePolicyInterface
+}
+
+type IPPoolMigrationsClient interface {
+	// IPPoolMigrations returns an interface for managing IP pool migration resources.
+	IPPoolMigrations() IPPoolMigrationInterface
+}
+
 type ProfilesClient interface {
 	// Profiles returns an interface for managing profile resources.
 	Profiles() ProfileInterface
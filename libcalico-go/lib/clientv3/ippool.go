@@ -63,7 +63,7 @@ func (r ipPools) Create(ctx context.Context, res *apiv3.IPPool, opts options.Set
 		return nil, err
 	}
 
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
@@ -135,7 +135,7 @@ func (r ipPools) Update(ctx context.Context, res *apiv3.IPPool, opts options.Set
 		return nil, err
 	}
 
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
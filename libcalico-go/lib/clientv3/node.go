@@ -218,6 +218,14 @@ func (r nodes) Delete(ctx context.Context, name string, opts options.DeleteOptio
 		return nil, err
 	}
 
+	// Delete the config that Felix reported for the node.
+	_, err = r.client.resources.Delete(ctx, options.DeleteOptions{}, libapiv3.KindFelixConfigReport, noNamespace, name)
+	switch err.(type) {
+	case nil, errors.ErrorResourceDoesNotExist, errors.ErrorOperationNotSupported:
+	default:
+		return nil, err
+	}
+
 	// Delete any host endpoints for this node.
 	heps, err := r.client.HostEndpoints().List(ctx, options.ListOptions{})
 	if err != nil {
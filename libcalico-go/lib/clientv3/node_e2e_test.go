@@ -32,6 +32,7 @@ import (
 	"github.com/projectcalico/calico/libcalico-go/lib/backend"
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
 	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
 	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
 	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
@@ -347,6 +348,14 @@ var _ = testutils.E2eDatastoreDescribe("Node tests (etcdv3)", testutils.Datastor
 			_, err = c.BGPConfigurations().Create(ctx, &bgpConf, options.SetOptions{})
 			Expect(err).ShouldNot(HaveOccurred())
 
+			report := libapiv3.NewFelixConfigReport()
+			report.Name = name1
+			report.Spec.Node = name1
+			report.Spec.Time = metav1.Now()
+			reportKey := model.ResourceKey{Kind: libapiv3.KindFelixConfigReport, Name: name1}
+			_, err = be.Create(ctx, &model.KVPair{Key: reportKey, Value: report})
+			Expect(err).ShouldNot(HaveOccurred())
+
 			// Create a HEP on this node and a HEP on another node.
 			hep1 := apiv3.NewHostEndpoint()
 			hep1.Name = "host-endpoint-1"
@@ -417,6 +426,10 @@ var _ = testutils.E2eDatastoreDescribe("Node tests (etcdv3)", testutils.Datastor
 			Expect(bconfig).Should(BeNil())
 			Expect(err).To(HaveOccurred())
 
+			// Check that the Felix config report was deleted
+			_, err = be.Get(ctx, reportKey, "")
+			Expect(err).To(BeAssignableToTypeOf(cerrors.ErrorResourceDoesNotExist{}))
+
 			// Check that the HEP was deleted.
 			hep, err := c.HostEndpoints().Get(ctx, hep1.Name, options.GetOptions{})
 			Expect(hep).Should(BeNil())
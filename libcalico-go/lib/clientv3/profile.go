@@ -23,7 +23,6 @@ import (
 	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
 	cresources "github.com/projectcalico/calico/libcalico-go/lib/resources"
-	validator "github.com/projectcalico/calico/libcalico-go/lib/validator/v3"
 	"github.com/projectcalico/calico/libcalico-go/lib/watch"
 )
 
@@ -52,7 +51,7 @@ func (r profiles) Create(ctx context.Context, res *apiv3.Profile, opts options.S
 		}
 	}
 
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
@@ -74,7 +73,7 @@ func (r profiles) Update(ctx context.Context, res *apiv3.Profile, opts options.S
 		}
 	}
 
-	if err := validator.Validate(res); err != nil {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
 		return nil, err
 	}
 
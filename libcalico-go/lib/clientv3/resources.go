@@ -16,6 +16,7 @@ package clientv3
 
 import (
 	"context"
+	"strings"
 	"sync/atomic"
 
 	log "github.com/sirupsen/logrus"
@@ -32,6 +33,7 @@ import (
 	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
 	"github.com/projectcalico/calico/libcalico-go/lib/namespace"
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	validator "github.com/projectcalico/calico/libcalico-go/lib/validator/v3"
 	"github.com/projectcalico/calico/libcalico-go/lib/watch"
 )
 
@@ -39,6 +41,10 @@ const (
 	noNamespace      = ""
 	defaultNamespace = "default"
 	maxApplyRetries  = 10
+
+	// selectorExtensionsFeatureGate is the FelixConfiguration feature gate that allows selectors
+	// to use the numeric comparison and "matches" operators.
+	selectorExtensionsFeatureGate = "SelectorExtensions"
 )
 
 // All Calico resources implement the resource interface.
@@ -163,6 +169,50 @@ func (c *resources) Update(ctx context.Context, opts options.SetOptions, kind st
 	return nil, err
 }
 
+// validateSelectorGated validates a resource that has selectors, and rejects it if its selectors
+// use the numeric comparison, "matches" or label() operators while the SelectorExtensions feature
+// gate is disabled in the default FelixConfiguration.  Felix versions that predate those operators
+// fail to parse such selectors, so the gate should only be enabled once every node has been
+// upgraded.  The default FelixConfiguration is only read if a selector uses the operators.
+//
+// The gate is only enforced by this client, which includes the Calico API server.  Writing the
+// backing CRDs directly in Kubernetes datastore mode bypasses it.
+func (c client) validateSelectorGated(ctx context.Context, res interface{}) error {
+	extensions, err := validator.ValidateAndFindSelectorExtensions(res)
+	if err != nil || len(extensions) == 0 {
+		return err
+	}
+	kvp, err := c.backend.Get(ctx, model.ResourceKey{
+		Kind: apiv3.KindFelixConfiguration,
+		Name: "default",
+	}, "")
+	if err != nil {
+		if _, ok := err.(cerrors.ErrorResourceDoesNotExist); !ok {
+			return err
+		}
+	} else if featureGateEnabled(kvp.Value.(*apiv3.FelixConfiguration).Spec.FeatureGates, selectorExtensionsFeatureGate) {
+		return nil
+	}
+	log.WithField("fields", extensions).Info("Rejecting resource with selector extensions; feature gate is not enabled")
+	for i := range extensions {
+		extensions[i].Reason = "the comparison, matches and label() selector operators require the " +
+			selectorExtensionsFeatureGate + "=enabled feature gate in the default FelixConfiguration, " +
+			"which should only be set once all nodes have been upgraded"
+	}
+	return cerrors.ErrorValidation{ErroredFields: extensions}
+}
+
+// featureGateEnabled returns true if the comma-separated list of key=value feature gates
+// enables the named gate.
+func featureGateEnabled(gates, name string) bool {
+	for _, gate := range strings.Split(gates, ",") {
+		if k, v, ok := strings.Cut(gate, "="); ok && k == name {
+			return v == "enabled"
+		}
+	}
+	return false
+}
+
 // Delete deletes a resource from the backend datastore.
 func (c *resources) Delete(ctx context.Context, opts options.DeleteOptions, kind, ns, name string) (resource, error) {
 	if err := c.checkNamespace(ns, kind); err != nil {
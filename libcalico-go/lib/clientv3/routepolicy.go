@@ -0,0 +1,108 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	"github.com/projectcalico/calico/libcalico-go/lib/watch"
+)
+
+// RoutePolicyInterface has methods to work with RoutePolicy resources.
+type RoutePolicyInterface interface {
+	Create(ctx context.Context, res *apiv3.RoutePolicy, opts options.SetOptions) (*apiv3.RoutePolicy, error)
+	Update(ctx context.Context, res *apiv3.RoutePolicy, opts options.SetOptions) (*apiv3.RoutePolicy, error)
+	Delete(ctx context.Context, name string, opts options.DeleteOptions) (*apiv3.RoutePolicy, error)
+	Get(ctx context.Context, name string, opts options.GetOptions) (*apiv3.RoutePolicy, error)
+	List(ctx context.Context, opts options.ListOptions) (*apiv3.RoutePolicyList, error)
+	Watch(ctx context.Context, opts options.ListOptions) (watch.Interface, error)
+}
+
+// routePolicies implements RoutePolicyInterface
+type routePolicies struct {
+	client client
+}
+
+// Create takes the representation of a RoutePolicy and creates it.  Returns the stored
+// representation of the RoutePolicy, and an error, if there is any.
+func (r routePolicies) Create(ctx context.Context, res *apiv3.RoutePolicy, opts options.SetOptions) (*apiv3.RoutePolicy, error) {
+	// Validate the RoutePolicy before creating the resource.
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
+		return nil, err
+	}
+
+	out, err := r.client.resources.Create(ctx, opts, apiv3.KindRoutePolicy, res)
+	if out != nil {
+		return out.(*apiv3.RoutePolicy), err
+	}
+	return nil, err
+
+}
+
+// Update takes the representation of a RoutePolicy and updates it. Returns the stored
+// representation of the RoutePolicy, and an error, if there is any.
+func (r routePolicies) Update(ctx context.Context, res *apiv3.RoutePolicy, opts options.SetOptions) (*apiv3.RoutePolicy, error) {
+	if err := r.client.validateSelectorGated(ctx, res); err != nil {
+		return nil, err
+	}
+
+	out, err := r.client.resources.Update(ctx, opts, apiv3.KindRoutePolicy, res)
+	if out != nil {
+		return out.(*apiv3.RoutePolicy), err
+	}
+	return nil, err
+}
+
+// Delete takes name of the RoutePolicy and deletes it. Returns an error if one occurs.
+func (r routePolicies) Delete(ctx context.Context, name string, opts options.DeleteOptions) (*apiv3.RoutePolicy, error) {
+	log.WithField("name", name).Info("Deleting route policy")
+	out, err := r.client.resources.Delete(ctx, opts, apiv3.KindRoutePolicy, noNamespace, name)
+	if out != nil {
+		return out.(*apiv3.RoutePolicy), err
+	}
+	return nil, err
+}
+
+// Get takes name of the RoutePolicy, and returns the corresponding RoutePolicy object,
+// and an error if there is any.
+func (r routePolicies) Get(ctx context.Context, name string, opts options.GetOptions) (*apiv3.RoutePolicy, error) {
+	out, err := r.client.resources.Get(ctx, opts, apiv3.KindRoutePolicy, noNamespace, name)
+	if out != nil {
+		return out.(*apiv3.RoutePolicy), err
+	}
+
+	return nil, err
+}
+
+// List returns the list of RoutePolicy objects that match the supplied options.
+func (r routePolicies) List(ctx context.Context, opts options.ListOptions) (*apiv3.RoutePolicyList, error) {
+	res := &apiv3.RoutePolicyList{}
+	if err := r.client.resources.List(ctx, opts, apiv3.KindRoutePolicy, apiv3.KindRoutePolicyList, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Watch returns a watch.Interface that watches the RoutePolicies that match the
+// supplied options.
+func (r routePolicies) Watch(ctx context.Context, opts options.ListOptions) (watch.Interface, error) {
+	return r.client.resources.Watch(ctx, opts, apiv3.KindRoutePolicy, nil)
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend"
+	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	"github.com/projectcalico/calico/libcalico-go/lib/testutils"
+	"github.com/projectcalico/calico/libcalico-go/lib/watch"
+)
+
+var _ = testutils.E2eDatastoreDescribe("RoutePolicy tests", testutils.DatastoreAll, func(config apiconfig.CalicoAPIConfig) {
+	ctx := context.Background()
+	name1 := "route-policy-1"
+	name2 := "route-policy-2"
+	spec1 := apiv3.RoutePolicySpec{
+		Selector:     "app == 'scanner'",
+		Destinations: []string{"0.0.0.0/0"},
+		NextHop:      "10.0.0.5",
+		Table:        200,
+	}
+	spec2 := apiv3.RoutePolicySpec{
+		Selector:     "all()",
+		Destinations: []string{"192.168.0.0/16", "dead:beef::/64"},
+		Interface:    "eth1",
+		Table:        201,
+	}
+
+	DescribeTable("RoutePolicy e2e CRUD tests",
+		func(name1, name2 string, spec1, spec2 apiv3.RoutePolicySpec) {
+			c, err := clientv3.New(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			be, err := backend.NewClient(config)
+			Expect(err).NotTo(HaveOccurred())
+			be.Clean()
+
+			By("Updating the RoutePolicy before it is created")
+			_, outError := c.RoutePolicies().Update(ctx, &apiv3.RoutePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name1, ResourceVersion: "1234", CreationTimestamp: metav1.Now(), UID: uid},
+				Spec:       spec1,
+			}, options.SetOptions{})
+			Expect(outError).To(HaveOccurred())
+			Expect(outError.Error()).To(ContainSubstring("resource does not exist: RoutePolicy(" + name1 + ") with error:"))
+
+			By("Attempting to create a RoutePolicy with an invalid table")
+			_, outError = c.RoutePolicies().Create(ctx, &apiv3.RoutePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name1},
+				Spec: apiv3.RoutePolicySpec{
+					Selector:     spec1.Selector,
+					Destinations: spec1.Destinations,
+					NextHop:      spec1.NextHop,
+					Table:        254,
+				},
+			}, options.SetOptions{})
+			Expect(outError).To(HaveOccurred())
+
+			By("Creating a new RoutePolicy with name1/spec1")
+			res1, outError := c.RoutePolicies().Create(ctx, &apiv3.RoutePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name1},
+				Spec:       spec1,
+			}, options.SetOptions{})
+			Expect(outError).NotTo(HaveOccurred())
+			Expect(res1).To(MatchResource(apiv3.KindRoutePolicy, testutils.ExpectNoNamespace, name1, spec1))
+
+			By("Attempting to create the same RoutePolicy with name1 but with spec2")
+			_, outError = c.RoutePolicies().Create(ctx, &apiv3.RoutePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name1},
+				Spec:       spec2,
+			}, options.SetOptions{})
+			Expect(outError).To(HaveOccurred())
+			Expect(outError.Error()).To(Equal("resource already exists: RoutePolicy(" + name1 + ")"))
+
+			By("Getting RoutePolicy (name1) and comparing the output against spec1")
+			res, outError := c.RoutePolicies().Get(ctx, name1, options.GetOptions{})
+			Expect(outError).NotTo(HaveOccurred())
+			Expect(res).To(MatchResource(apiv3.KindRoutePolicy, testutils.ExpectNoNamespace, name1, spec1))
+			Expect(res.ResourceVersion).To(Equal(res1.ResourceVersion))
+
+			By("Creating a new RoutePolicy with name2/spec2")
+			_, outError = c.RoutePolicies().Create(ctx, &apiv3.RoutePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name2},
+				Spec:       spec2,
+			}, options.SetOptions{})
+			Expect(outError).NotTo(HaveOccurred())
+
+			By("Listing all the RoutePolicies, expecting two results")
+			outList, outError := c.RoutePolicies().List(ctx, options.ListOptions{})
+			Expect(outError).NotTo(HaveOccurred())
+			Expect(outList.Items).To(ConsistOf(
+				testutils.Resource(apiv3.KindRoutePolicy, testutils.ExpectNoNamespace, name1, spec1),
+				testutils.Resource(apiv3.KindRoutePolicy, testutils.ExpectNoNamespace, name2, spec2),
+			))
+
+			By("Updating RoutePolicy name1 with spec2")
+			res1.Spec = spec2
+			res1, outError = c.RoutePolicies().Update(ctx, res1, options.SetOptions{})
+			Expect(outError).NotTo(HaveOccurred())
+			Expect(res1).To(MatchResource(apiv3.KindRoutePolicy, testutils.ExpectNoNamespace, name1, spec2))
+
+			By("Deleting RoutePolicy (name1)")
+			dres, outError := c.RoutePolicies().Delete(ctx, name1, options.DeleteOptions{ResourceVersion: res1.ResourceVersion})
+			Expect(outError).NotTo(HaveOccurred())
+			Expect(dres).To(MatchResource(apiv3.KindRoutePolicy, testutils.ExpectNoNamespace, name1, spec2))
+
+			By("Deleting RoutePolicy (name2)")
+			_, outError = c.RoutePolicies().Delete(ctx, name2, options.DeleteOptions{})
+			Expect(outError).NotTo(HaveOccurred())
+
+			By("Attempting to delete RoutePolicy (name2) again")
+			_, outError = c.RoutePolicies().Delete(ctx, name2, options.DeleteOptions{})
+			Expect(outError).To(HaveOccurred())
+			Expect(outError.Error()).To(ContainSubstring("resource does not exist: RoutePolicy(" + name2 + ") with error:"))
+
+			By("Listing all RoutePolicies and expecting no items")
+			outList, outError = c.RoutePolicies().List(ctx, options.ListOptions{})
+			Expect(outError).NotTo(HaveOccurred())
+			Expect(outList.Items).To(HaveLen(0))
+		},
+
+		// Test 1: Pass two fully populated RoutePolicySpecs and expect the series of operations to succeed.
+		Entry("Two fully populated RoutePolicySpecs", name1, name2, spec1, spec2),
+	)
+
+	Describe("RoutePolicy watch functionality", func() {
+		It("should handle watch events for different resource versions and event types", func() {
+			c, err := clientv3.New(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			be, err := backend.NewClient(config)
+			Expect(err).NotTo(HaveOccurred())
+			be.Clean()
+
+			By("Configuring a RoutePolicy name1/spec1 and storing the response")
+			outRes1, err := c.RoutePolicies().Create(ctx, &apiv3.RoutePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name1},
+				Spec:       spec1,
+			}, options.SetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			rev1 := outRes1.ResourceVersion
+
+			By("Configuring a RoutePolicy name2/spec2 and storing the response")
+			outRes2, err := c.RoutePolicies().Create(ctx, &apiv3.RoutePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name2},
+				Spec:       spec2,
+			}, options.SetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Starting a watcher from revision rev1 - this should skip the first creation")
+			w, err := c.RoutePolicies().Watch(ctx, options.ListOptions{ResourceVersion: rev1})
+			Expect(err).NotTo(HaveOccurred())
+			testWatcher1 := testutils.NewTestResourceWatch(config.Spec.DatastoreType, w)
+			defer testWatcher1.Stop()
+
+			By("Deleting res1")
+			_, err = c.RoutePolicies().Delete(ctx, name1, options.DeleteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking for two events, create res2 and delete res1")
+			testWatcher1.ExpectEvents(apiv3.KindRoutePolicy, []watch.Event{
+				{
+					Type:   watch.Added,
+					Object: outRes2,
+				},
+				{
+					Type:     watch.Deleted,
+					Previous: outRes1,
+				},
+			})
+			testWatcher1.Stop()
+		})
+	})
+})
@@ -0,0 +1,437 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package defrag plans and applies the defragmentation of Calico IPAM blocks.
+//
+// As nodes churn, each node can end up with many partially used affine blocks, so that an IP pool
+// looks exhausted while most of its addresses are free.  Defragmentation releases a node's affinity
+// to the blocks it is making the least use of, provided that the node's remaining blocks have room
+// for the addresses that it is using.  Empty blocks are deleted straight away.  Blocks that are still
+// in use lose their affinity, so that the node stops allocating from them and other nodes may borrow
+// their free addresses; IPAM deletes each such block once its last address is released.
+//
+// Optionally, the pods on other nodes that have borrowed addresses from a released block are evicted,
+// provided that their own nodes' blocks have room, so that the borrowed addresses are released and
+// the block drains.  Their replacements are assigned addresses from their own nodes' blocks.
+package defrag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+// DefaultSparseThreshold is the default fraction of a block's addresses that its node must be using
+// for the node to keep its affinity to the block.
+const DefaultSparseThreshold = 0.25
+
+// Reason is the reason that a block's affinity is released.
+type Reason string
+
+const (
+	// ReasonEmpty is used for blocks with no addresses in use.  The block is deleted.
+	ReasonEmpty Reason = "Empty"
+	// ReasonBorrowed is used for blocks whose addresses are only in use by other nodes.
+	ReasonBorrowed Reason = "Borrowed"
+	// ReasonSparse is used for blocks whose node is using fewer addresses than the threshold.
+	ReasonSparse Reason = "Sparse"
+)
+
+// Config configures the planning of a defragmentation pass.
+type Config struct {
+	// SparseThreshold is the fraction of a block's addresses that its node must be using for the
+	// node to keep its affinity to the block.  Zero means that only empty blocks and blocks that
+	// are only in use by other nodes are released.
+	SparseThreshold float64
+
+	// SkipNode, if set, is called for each node with affine blocks.  Nodes for which it returns
+	// true are left alone.
+	SkipNode func(node string) bool
+
+	// StrictAffinity should be set when the IPAM configuration has strict affinity enabled.  No
+	// node can then allocate from a block without an affinity, so only empty blocks are released.
+	StrictAffinity bool
+
+	// ConsolidateBorrowed plans the eviction of the pods that have borrowed addresses from the
+	// blocks that are released, when their own nodes' blocks have room for them.
+	ConsolidateBorrowed bool
+}
+
+// Action is the release of the affinity of a single block.
+type Action struct {
+	Block  *model.AllocationBlock
+	Node   string
+	Reason Reason
+
+	// InUse is the number of addresses in use by the block's node, Borrowed is the number in
+	// use by other nodes, and Free is the number that are unallocated.
+	InUse    int
+	Borrowed int
+	Free     int
+}
+
+// Consolidation is the eviction of a pod that has borrowed an address from a block whose affinity
+// is released, so that the address is released and the block can drain.
+type Consolidation struct {
+	Block     *model.AllocationBlock
+	IP        cnet.IP
+	Node      string
+	Namespace string
+	Pod       string
+}
+
+// Plan is the list of block affinities that a defragmentation pass will release, and the pods that
+// it will evict to consolidate their addresses.
+type Plan struct {
+	Actions        []Action
+	Consolidations []Consolidation
+}
+
+// Summary describes how much address space a plan reclaims.
+type Summary struct {
+	// BlocksReleased is the number of blocks whose affinity is released.
+	BlocksReleased int
+	// AddressesReclaimed is the number of addresses in empty blocks, which are deleted straight
+	// away.
+	AddressesReclaimed int
+	// AddressesUnpinned is the number of free addresses in blocks that are still in use.  These
+	// become available to any node, and the blocks are deleted once they are drained.
+	AddressesUnpinned int
+	// AddressesConsolidated is the number of borrowed addresses whose pods are evicted.
+	AddressesConsolidated int
+}
+
+// blockStats summarises how the addresses of an affine block are being used.
+type blockStats struct {
+	block    *model.AllocationBlock
+	inUse    int
+	borrowed int
+	reserved int
+	free     int
+
+	// borrowers are the pods on other nodes with addresses in the block.  Borrowed addresses that
+	// don't belong to a pod, such as tunnel addresses, aren't included.
+	borrowers []Consolidation
+}
+
+func newBlockStats(b *model.AllocationBlock) blockStats {
+	s := blockStats{block: b, free: b.NumAddresses()}
+	node := b.Host()
+	for ord, idx := range b.Allocations {
+		if idx == nil {
+			continue
+		}
+		s.free--
+		if *idx >= len(b.Attributes) {
+			// Be conservative and treat the address as in use by the block's node.
+			s.inUse++
+			continue
+		}
+		attrs := b.Attributes[*idx]
+		if attrs.AttrPrimary != nil && strings.ToLower(*attrs.AttrPrimary) == ipam.WindowsReservedHandle {
+			s.reserved++
+			continue
+		}
+		// Addresses that don't record their node are assumed to belong to the block's node.
+		if n := attrs.AttrSecondary[ipam.AttributeNode]; n != "" && n != node {
+			s.borrowed++
+			ns, pod := attrs.AttrSecondary[ipam.AttributeNamespace], attrs.AttrSecondary[ipam.AttributePod]
+			if ns != "" && pod != "" && attrs.AttrSecondary[ipam.AttributeType] == "" {
+				s.borrowers = append(s.borrowers, Consolidation{
+					Block:     b,
+					IP:        b.OrdinalToIP(ord),
+					Node:      n,
+					Namespace: ns,
+					Pod:       pod,
+				})
+			}
+		} else {
+			s.inUse++
+		}
+	}
+	return s
+}
+
+// NewPlan works out which of the given blocks should have their affinity released.
+//
+// The blocks affine to each node are considered separately for each IP version, least used first.
+// A block is only released if the node's other blocks have enough free addresses to hold the
+// addresses that the node is using in it, so every node keeps at least one block.  With
+// ConsolidateBorrowed, each pod that has borrowed an address from a released block is evicted if
+// the blocks that its own node keeps have room for it.
+func NewPlan(blocks []*model.AllocationBlock, cfg Config) *Plan {
+	type group struct {
+		node    string
+		version int
+	}
+	groups := map[group][]blockStats{}
+	skip := map[string]bool{}
+	for _, b := range blocks {
+		node := b.Host()
+		if node == "" || b.IsDeleted() {
+			continue
+		}
+		if cfg.SkipNode != nil {
+			if _, ok := skip[node]; !ok {
+				skip[node] = cfg.SkipNode(node)
+			}
+			if skip[node] {
+				continue
+			}
+		}
+		g := group{node: node, version: b.CIDR.Version()}
+		groups[g] = append(groups[g], newBlockStats(b))
+	}
+
+	plan := &Plan{}
+	keptFreeByGroup := map[group]int{}
+	var released []blockStats
+	for g, stats := range groups {
+		// Consider the least used blocks first.
+		sort.Slice(stats, func(i, j int) bool {
+			if stats[i].inUse != stats[j].inUse {
+				return stats[i].inUse < stats[j].inUse
+			}
+			if stats[i].borrowed != stats[j].borrowed {
+				return stats[i].borrowed < stats[j].borrowed
+			}
+			return stats[i].block.CIDR.String() < stats[j].block.CIDR.String()
+		})
+
+		// Track the free addresses in the blocks that the node keeps.
+		kept := len(stats)
+		keptFree := 0
+		for _, s := range stats {
+			keptFree += s.free
+		}
+
+		for _, s := range stats {
+			if kept == 1 {
+				break
+			}
+			var reason Reason
+			switch {
+			case s.inUse == 0 && s.borrowed == 0:
+				reason = ReasonEmpty
+			case s.reserved > 0:
+				// The reserved addresses are never released, so the block would never drain.
+				continue
+			case cfg.StrictAffinity:
+				// No other node could use the block's free addresses.
+				continue
+			case s.inUse == 0:
+				reason = ReasonBorrowed
+			case float64(s.inUse) < cfg.SparseThreshold*float64(s.block.NumAddresses()):
+				reason = ReasonSparse
+			default:
+				// This block is well used, and any remaining blocks are used more.
+				continue
+			}
+
+			// The node's other blocks must have room for the addresses that the node is using
+			// here, or for at least one address, so that it doesn't immediately claim a new block.
+			remainingFree := keptFree - s.free
+			needed := s.inUse
+			if needed == 0 {
+				needed = 1
+			}
+			if remainingFree < needed {
+				log.WithFields(log.Fields{"block": s.block.CIDR, "node": g.node}).Debug(
+					"Not releasing block, node's other blocks don't have room")
+				continue
+			}
+			keptFree = remainingFree - s.inUse
+			kept--
+			released = append(released, s)
+
+			plan.Actions = append(plan.Actions, Action{
+				Block:    s.block,
+				Node:     g.node,
+				Reason:   reason,
+				InUse:    s.inUse,
+				Borrowed: s.borrowed,
+				Free:     s.free,
+			})
+		}
+		keptFreeByGroup[g] = keptFree
+	}
+
+	if cfg.ConsolidateBorrowed {
+		for _, s := range released {
+			for _, c := range s.borrowers {
+				g := group{node: c.Node, version: s.block.CIDR.Version()}
+				if keptFreeByGroup[g] == 0 {
+					// The pod would just borrow another address.
+					continue
+				}
+				keptFreeByGroup[g]--
+				plan.Consolidations = append(plan.Consolidations, c)
+			}
+		}
+		sort.Slice(plan.Consolidations, func(i, j int) bool {
+			return plan.Consolidations[i].IP.String() < plan.Consolidations[j].IP.String()
+		})
+	}
+
+	sort.Slice(plan.Actions, func(i, j int) bool {
+		if plan.Actions[i].Node != plan.Actions[j].Node {
+			return plan.Actions[i].Node < plan.Actions[j].Node
+		}
+		return plan.Actions[i].Block.CIDR.String() < plan.Actions[j].Block.CIDR.String()
+	})
+	return plan
+}
+
+// Summary returns how much address space the plan reclaims.
+func (p *Plan) Summary() Summary {
+	return summarize(p.Actions, p.Consolidations)
+}
+
+func summarize(actions []Action, consolidations []Consolidation) Summary {
+	s := Summary{AddressesConsolidated: len(consolidations)}
+	for _, a := range actions {
+		s.BlocksReleased++
+		if a.Reason == ReasonEmpty {
+			s.AddressesReclaimed += a.Block.NumAddresses()
+		} else {
+			s.AddressesUnpinned += a.Free
+		}
+	}
+	return s
+}
+
+// Failure records an action that could not be applied.
+type Failure struct {
+	Action Action
+	Err    error
+}
+
+// ConsolidationFailure records a pod that could not be evicted.
+type ConsolidationFailure struct {
+	Consolidation Consolidation
+	Err           error
+}
+
+// Result is the outcome of applying a plan.
+type Result struct {
+	Released []Action
+	Failed   []Failure
+
+	Consolidated         []Consolidation
+	FailedConsolidations []ConsolidationFailure
+}
+
+// Summary returns how much address space was reclaimed.
+func (r *Result) Summary() Summary {
+	return summarize(r.Released, r.Consolidated)
+}
+
+// ErrNoController is returned by an Evictor for a pod that has no controller to recreate it.  Such
+// pods are left alone.
+var ErrNoController = errors.New("pod has no controller")
+
+// Evictor evicts the given pod.  It is expected to release the pod's addresses when the pod is
+// deleted, and for the pod's controller to recreate it.
+type Evictor func(ctx context.Context, namespace, name string) error
+
+// NewKubernetesEvictor returns an Evictor that uses the Kubernetes eviction API, so that
+// PodDisruptionBudgets are respected.  Pods that an eviction isn't allowed for yet are reported as
+// failures and can be retried by a later pass.
+func NewKubernetesEvictor(k kubernetes.Interface) Evictor {
+	return func(ctx context.Context, namespace, name string) error {
+		pod, err := k.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if metav1.GetControllerOf(pod) == nil {
+			return ErrNoController
+		}
+		err = k.PolicyV1().Evictions(namespace).Evict(ctx, &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			DeleteOptions: &metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{UID: &pod.UID},
+			},
+		})
+		if kerrors.IsNotFound(err) || kerrors.IsConflict(err) {
+			// The pod has already gone.
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to evict pod %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	}
+}
+
+// Apply releases the block affinities in the plan.  It is safe to apply a plan while addresses are
+// being allocated: an empty block is only deleted if it is still empty, and releasing the affinity
+// of a block that is in use does not affect the addresses that are allocated from it.  Actions that
+// fail are reported in the result and can be retried by a later pass.
+//
+// The plan's consolidations are applied with the given Evictor, once the affinity of their block
+// has been released.  They are skipped if the Evictor is nil.
+func (p *Plan) Apply(ctx context.Context, c ipam.Interface, evict Evictor) *Result {
+	r := &Result{}
+	releasedBlocks := map[string]bool{}
+	for _, a := range p.Actions {
+		logCtx := log.WithFields(log.Fields{"block": a.Block.CIDR, "node": a.Node, "reason": a.Reason})
+		err := c.ReleaseBlockAffinity(ctx, a.Block, a.Reason == ReasonEmpty)
+		if err != nil {
+			logCtx.WithError(err).Warn("Failed to release block affinity")
+			r.Failed = append(r.Failed, Failure{Action: a, Err: err})
+			continue
+		}
+		logCtx.Info("Released block affinity")
+		r.Released = append(r.Released, a)
+		releasedBlocks[a.Block.CIDR.String()] = true
+	}
+
+	if evict == nil {
+		return r
+	}
+	for _, cons := range p.Consolidations {
+		if !releasedBlocks[cons.Block.CIDR.String()] {
+			// The node may still allocate from the block, so draining it won't help.
+			continue
+		}
+		logCtx := log.WithFields(log.Fields{
+			"block": cons.Block.CIDR, "ip": cons.IP, "namespace": cons.Namespace, "pod": cons.Pod,
+		})
+		err := evict(ctx, cons.Namespace, cons.Pod)
+		if errors.Is(err, ErrNoController) {
+			logCtx.Info("Not evicting pod with a borrowed address, it has no controller")
+			continue
+		} else if err != nil {
+			logCtx.WithError(err).Warn("Failed to evict pod with a borrowed address")
+			r.FailedConsolidations = append(r.FailedConsolidations, ConsolidationFailure{Consolidation: cons, Err: err})
+			continue
+		}
+		logCtx.Info("Evicted pod with a borrowed address")
+		r.Consolidated = append(r.Consolidated, cons)
+	}
+	return r
+}
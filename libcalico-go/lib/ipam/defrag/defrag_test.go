@@ -0,0 +1,243 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package defrag_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/defrag"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+// block returns a /29 block affine to the given node.  Each entry in allocs is the node that the
+// address with that ordinal is allocated to; "reserved" allocates a Windows reserved address.  Each
+// address belongs to a pod named after its node and IP, except for "tunnel" addresses of "node2".
+func block(cidr, node string, allocs map[int]string) *model.AllocationBlock {
+	affinity := "host:" + node
+	b := &model.AllocationBlock{
+		CIDR:        cnet.MustParseCIDR(cidr),
+		Affinity:    &affinity,
+		Allocations: make([]*int, 8),
+	}
+	for ord, n := range allocs {
+		idx := len(b.Attributes)
+		b.Allocations[ord] = &idx
+		handle := "handle"
+		attrs := model.AllocationAttribute{AttrPrimary: &handle}
+		if n == "reserved" {
+			handle = ipam.WindowsReservedHandle
+		} else if n == "tunnel" {
+			attrs.AttrSecondary = map[string]string{ipam.AttributeNode: "node2", ipam.AttributeType: ipam.AttributeTypeVXLAN}
+		} else {
+			attrs.AttrSecondary = map[string]string{
+				ipam.AttributeNode:      n,
+				ipam.AttributeNamespace: "default",
+				ipam.AttributePod:       fmt.Sprintf("%s-%s", n, b.OrdinalToIP(ord)),
+			}
+		}
+		b.Attributes = append(b.Attributes, attrs)
+	}
+	return b
+}
+
+func cidrs(p *defrag.Plan) map[string]defrag.Reason {
+	m := map[string]defrag.Reason{}
+	for _, a := range p.Actions {
+		m[a.Block.CIDR.String()] = a.Reason
+	}
+	return m
+}
+
+type fakeIPAM struct {
+	ipam.Interface
+	released map[string]bool
+	fail     map[string]bool
+}
+
+func (f *fakeIPAM) ReleaseBlockAffinity(ctx context.Context, b *model.AllocationBlock, mustBeEmpty bool) error {
+	if f.fail[b.CIDR.String()] {
+		return errors.New("block is not empty")
+	}
+	f.released[b.CIDR.String()] = mustBeEmpty
+	return nil
+}
+
+var _ = Describe("IPAM defragmentation", func() {
+	cfg := defrag.Config{SparseThreshold: defrag.DefaultSparseThreshold}
+
+	It("should release empty blocks while the node has room elsewhere", func() {
+		plan := defrag.NewPlan([]*model.AllocationBlock{
+			block("10.0.0.0/29", "node1", map[int]string{0: "node1"}),
+			block("10.0.0.8/29", "node1", nil),
+			block("10.0.0.16/29", "node1", nil),
+		}, cfg)
+		Expect(cidrs(plan)).To(Equal(map[string]defrag.Reason{
+			"10.0.0.8/29":  defrag.ReasonEmpty,
+			"10.0.0.16/29": defrag.ReasonEmpty,
+		}))
+		Expect(plan.Summary()).To(Equal(defrag.Summary{BlocksReleased: 2, AddressesReclaimed: 16}))
+	})
+
+	It("should keep a node's only block", func() {
+		plan := defrag.NewPlan([]*model.AllocationBlock{
+			block("10.0.0.0/29", "node1", nil),
+			block("fd00::/125", "node1", nil),
+		}, cfg)
+		Expect(plan.Actions).To(BeEmpty())
+	})
+
+	It("should keep an empty block if the node's other blocks are full", func() {
+		full := map[int]string{}
+		for i := 0; i < 8; i++ {
+			full[i] = "node1"
+		}
+		plan := defrag.NewPlan([]*model.AllocationBlock{
+			block("10.0.0.0/29", "node1", full),
+			block("10.0.0.8/29", "node1", nil),
+		}, cfg)
+		Expect(plan.Actions).To(BeEmpty())
+	})
+
+	It("should release blocks that are only in use by other nodes", func() {
+		plan := defrag.NewPlan([]*model.AllocationBlock{
+			block("10.0.0.0/29", "node1", map[int]string{0: "node1", 1: "node1"}),
+			block("10.0.0.8/29", "node1", map[int]string{0: "node2"}),
+		}, cfg)
+		Expect(cidrs(plan)).To(Equal(map[string]defrag.Reason{"10.0.0.8/29": defrag.ReasonBorrowed}))
+		Expect(plan.Summary()).To(Equal(defrag.Summary{BlocksReleased: 1, AddressesUnpinned: 7}))
+	})
+
+	It("should release sparse blocks only when the node's other blocks have room", func() {
+		blocks := []*model.AllocationBlock{
+			block("10.0.0.0/29", "node1", map[int]string{0: "node1", 1: "node1", 2: "node1", 3: "node1", 4: "node1"}),
+			block("10.0.0.8/29", "node1", map[int]string{0: "node1"}),
+		}
+		Expect(cidrs(defrag.NewPlan(blocks, cfg))).To(Equal(map[string]defrag.Reason{"10.0.0.8/29": defrag.ReasonSparse}))
+
+		// Fill the busier block so it has no room for the address in the sparse one.
+		blocks[0] = block("10.0.0.0/29", "node1", map[int]string{0: "node1", 1: "node1", 2: "node1", 3: "node1", 4: "node1", 5: "node1", 6: "node1", 7: "node1"})
+		Expect(defrag.NewPlan(blocks, cfg).Actions).To(BeEmpty())
+
+		// With no threshold, sparse blocks are kept.
+		blocks[0] = block("10.0.0.0/29", "node1", map[int]string{0: "node1"})
+		Expect(defrag.NewPlan(blocks, defrag.Config{}).Actions).To(BeEmpty())
+	})
+
+	It("should not release blocks with reserved addresses that are in use", func() {
+		plan := defrag.NewPlan([]*model.AllocationBlock{
+			block("10.0.0.0/29", "node1", map[int]string{0: "node1", 1: "node1"}),
+			block("10.0.0.8/29", "node1", map[int]string{0: "reserved", 1: "node2"}),
+			block("10.0.0.16/29", "node1", map[int]string{0: "reserved"}),
+		}, cfg)
+		Expect(cidrs(plan)).To(Equal(map[string]defrag.Reason{"10.0.0.16/29": defrag.ReasonEmpty}))
+	})
+
+	It("should skip nodes", func() {
+		plan := defrag.NewPlan([]*model.AllocationBlock{
+			block("10.0.0.0/29", "node1", map[int]string{0: "node1"}),
+			block("10.0.0.8/29", "node1", nil),
+		}, defrag.Config{SkipNode: func(n string) bool { return n == "node1" }})
+		Expect(plan.Actions).To(BeEmpty())
+	})
+
+	It("should apply the plan and report failures", func() {
+		plan := defrag.NewPlan([]*model.AllocationBlock{
+			block("10.0.0.0/29", "node1", map[int]string{0: "node1", 1: "node1"}),
+			block("10.0.0.8/29", "node1", nil),
+			block("10.0.0.16/29", "node1", map[int]string{0: "node2"}),
+			block("10.0.1.0/29", "node2", map[int]string{0: "node2"}),
+			block("10.0.1.8/29", "node2", nil),
+		}, cfg)
+		Expect(plan.Actions).To(HaveLen(3))
+
+		f := &fakeIPAM{released: map[string]bool{}, fail: map[string]bool{"10.0.1.8/29": true}}
+		result := plan.Apply(context.Background(), f, nil)
+		Expect(f.released).To(Equal(map[string]bool{"10.0.0.8/29": true, "10.0.0.16/29": false}))
+		Expect(result.Failed).To(HaveLen(1))
+		Expect(result.Failed[0].Action.Block.CIDR.String()).To(Equal("10.0.1.8/29"))
+		Expect(result.Summary()).To(Equal(defrag.Summary{BlocksReleased: 2, AddressesReclaimed: 8, AddressesUnpinned: 7}))
+	})
+
+	It("should only release empty blocks with strict affinity", func() {
+		plan := defrag.NewPlan([]*model.AllocationBlock{
+			block("10.0.0.0/29", "node1", map[int]string{0: "node1", 1: "node1"}),
+			block("10.0.0.8/29", "node1", nil),
+			block("10.0.0.16/29", "node1", map[int]string{0: "node2"}),
+			block("10.0.0.24/29", "node1", map[int]string{0: "node1"}),
+		}, defrag.Config{SparseThreshold: defrag.DefaultSparseThreshold, StrictAffinity: true})
+		Expect(cidrs(plan)).To(Equal(map[string]defrag.Reason{"10.0.0.8/29": defrag.ReasonEmpty}))
+	})
+
+	Describe("with borrowed addresses consolidated", func() {
+		cfg := defrag.Config{SparseThreshold: defrag.DefaultSparseThreshold, ConsolidateBorrowed: true}
+		blocks := func() []*model.AllocationBlock {
+			return []*model.AllocationBlock{
+				block("10.0.0.0/29", "node1", map[int]string{0: "node1", 1: "node1"}),
+				block("10.0.0.8/29", "node1", map[int]string{0: "node2", 1: "node2", 2: "node3", 3: "tunnel"}),
+				block("10.0.1.0/29", "node2", map[int]string{0: "node2", 1: "node2", 2: "node2", 3: "node2", 4: "node2", 5: "node2"}),
+			}
+		}
+
+		It("should only evict pods whose node has room", func() {
+			plan := defrag.NewPlan(blocks(), cfg)
+			Expect(cidrs(plan)).To(Equal(map[string]defrag.Reason{"10.0.0.8/29": defrag.ReasonBorrowed}))
+
+			// node2 keeps two free addresses, node3 has no blocks and the tunnel address
+			// doesn't belong to a pod.
+			var pods []string
+			for _, c := range plan.Consolidations {
+				Expect(c.Node).To(Equal("node2"))
+				pods = append(pods, c.Pod)
+			}
+			Expect(pods).To(ConsistOf("node2-10.0.0.8", "node2-10.0.0.9"))
+			Expect(plan.Summary().AddressesConsolidated).To(Equal(2))
+
+			Expect(defrag.NewPlan(blocks(), defrag.Config{SparseThreshold: defrag.DefaultSparseThreshold}).Consolidations).To(BeEmpty())
+		})
+
+		It("should evict pods once their block has been released", func() {
+			plan := defrag.NewPlan(blocks(), cfg)
+			Expect(plan.Consolidations).To(HaveLen(2))
+
+			var evicted []string
+			evict := func(ctx context.Context, namespace, name string) error {
+				evicted = append(evicted, name)
+				if name == "node2-10.0.0.9" {
+					return defrag.ErrNoController
+				}
+				return nil
+			}
+
+			f := &fakeIPAM{released: map[string]bool{}, fail: map[string]bool{"10.0.0.8/29": true}}
+			result := plan.Apply(context.Background(), f, evict)
+			Expect(evicted).To(BeEmpty())
+			Expect(result.Consolidated).To(BeEmpty())
+
+			f.fail = nil
+			result = plan.Apply(context.Background(), f, evict)
+			Expect(evicted).To(ConsistOf("node2-10.0.0.8", "node2-10.0.0.9"))
+			Expect(result.Consolidated).To(HaveLen(1))
+			Expect(result.FailedConsolidations).To(BeEmpty())
+			Expect(result.Summary()).To(Equal(defrag.Summary{BlocksReleased: 1, AddressesUnpinned: 4, AddressesConsolidated: 1}))
+		})
+	})
+})
@@ -0,0 +1,123 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package external contains the allocators that Calico IPAM uses to claim the blocks of IP pools
+// whose addresses are managed by an external IPAM system.
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+const defaultTimeout = 10 * time.Second
+
+var (
+	// ErrNoFreeBlocks is returned by AllocateBlock when the external IPAM system has no more
+	// blocks available for the pool.
+	ErrNoFreeBlocks = errors.New("no free blocks available from external IPAM")
+
+	// ErrBlockUnavailable is returned by AllocateBlock when the requested block cannot be
+	// allocated to the host, for example because it is allocated to a different host.
+	ErrBlockUnavailable = errors.New("block is not available from external IPAM")
+)
+
+// BlockRequest identifies a block of an IP pool.
+type BlockRequest struct {
+	// Pool and PoolCIDR are the name and CIDR of the IP pool.
+	Pool     string
+	PoolCIDR cnet.IPNet
+	// BlockSize is the prefix length of the pool's blocks.
+	BlockSize int
+	// Host is the host that the block is claimed for.  It may be empty when releasing a block
+	// that no longer has an affinity.
+	Host string
+	// CIDR is the CIDR of the block.  When allocating, it may be nil to let the external system
+	// choose the block.
+	CIDR *cnet.IPNet
+}
+
+// BlockAllocator allocates the blocks of an IP pool from an external IPAM system.
+type BlockAllocator interface {
+	// AllocateBlock allocates a block to the host and returns its CIDR.  If req.CIDR is set, the
+	// allocator must allocate exactly that block or return an error; allocating a block that is
+	// already allocated to the same host succeeds.
+	AllocateBlock(ctx context.Context, req BlockRequest) (*cnet.IPNet, error)
+
+	// ReleaseBlock releases the allocation of the block req.CIDR.  If req.Host is set, a block
+	// that is allocated to a different host is left alone.  Releasing a block that is not
+	// allocated succeeds.
+	ReleaseBlock(ctx context.Context, req BlockRequest) error
+
+	// Close releases the resources held by the allocator, such as its connection to the
+	// external system.  The allocator must not be used afterwards.
+	Close() error
+}
+
+// NewBlockAllocator returns the BlockAllocator configured by the given spec.
+func NewBlockAllocator(spec v3.ExternalIPAMSpec) (BlockAllocator, error) {
+	var a BlockAllocator
+	switch spec.Type {
+	case v3.ExternalIPAMTypeGRPC:
+		if spec.Endpoint == "" {
+			return nil, fmt.Errorf("external IPAM type %s requires an endpoint", spec.Type)
+		}
+		var err error
+		a, err = NewGRPCAllocator(spec.Endpoint, spec.TLS)
+		if err != nil {
+			return nil, err
+		}
+	case v3.ExternalIPAMTypeFile:
+		if spec.Path == "" {
+			return nil, fmt.Errorf("external IPAM type %s requires a path", spec.Type)
+		}
+		a = NewFileAllocator(spec.Path)
+	default:
+		return nil, fmt.Errorf("unknown external IPAM type %q", spec.Type)
+	}
+
+	timeout := defaultTimeout
+	if spec.Timeout != nil && spec.Timeout.Duration > 0 {
+		timeout = spec.Timeout.Duration
+	}
+	return &timeoutAllocator{allocator: a, timeout: timeout}, nil
+}
+
+// timeoutAllocator applies a timeout to each request to the wrapped allocator.
+type timeoutAllocator struct {
+	allocator BlockAllocator
+	timeout   time.Duration
+}
+
+func (t *timeoutAllocator) AllocateBlock(ctx context.Context, req BlockRequest) (*cnet.IPNet, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.allocator.AllocateBlock(ctx, req)
+}
+
+func (t *timeoutAllocator) ReleaseBlock(ctx context.Context, req BlockRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.allocator.ReleaseBlock(ctx, req)
+}
+
+func (t *timeoutAllocator) Close() error {
+	return t.allocator.Close()
+}
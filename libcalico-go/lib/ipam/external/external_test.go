@@ -0,0 +1,274 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/external"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/external/proto"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+var _ = Describe("External IPAM allocators", func() {
+	var (
+		ctx     context.Context
+		dir     string
+		path    string
+		request func(host, cidr string) external.BlockRequest
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		dir, err = os.MkdirTemp("", "external-ipam")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, "state.json")
+		data, err := json.Marshal(external.FileState{Ranges: []string{"10.0.0.0/30", "10.1.0.0/16"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(path, data, 0o600)).To(Succeed())
+
+		request = func(host, cidr string) external.BlockRequest {
+			req := external.BlockRequest{
+				Pool:      "pool1",
+				PoolCIDR:  cnet.MustParseCIDR("10.0.0.0/8"),
+				BlockSize: 31,
+				Host:      host,
+			}
+			if cidr != "" {
+				c := cnet.MustParseCIDR(cidr)
+				req.CIDR = &c
+			}
+			return req
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	readState := func() external.FileState {
+		var state external.FileState
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(json.Unmarshal(data, &state)).To(Succeed())
+		return state
+	}
+
+	// testAllocator runs the tests that apply to every allocator that is backed by the file.
+	testAllocator := func(getAllocator func() external.BlockAllocator) {
+		var a external.BlockAllocator
+
+		BeforeEach(func() {
+			a = getAllocator()
+		})
+
+		AfterEach(func() {
+			Expect(a.Close()).To(Succeed())
+		})
+
+		It("should allocate free blocks in order across the ranges", func() {
+			var blocks []string
+			for i := 0; i < 3; i++ {
+				cidr, err := a.AllocateBlock(ctx, request("host1", ""))
+				Expect(err).NotTo(HaveOccurred())
+				blocks = append(blocks, cidr.String())
+			}
+			Expect(blocks).To(Equal([]string{"10.0.0.0/31", "10.0.0.2/31", "10.1.0.0/31"}))
+			Expect(readState().Allocations).To(HaveLen(3))
+		})
+
+		It("should only allocate blocks within the pool", func() {
+			req := request("host1", "")
+			req.PoolCIDR = cnet.MustParseCIDR("10.0.0.0/30")
+			for i := 0; i < 2; i++ {
+				_, err := a.AllocateBlock(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			_, err := a.AllocateBlock(ctx, req)
+			Expect(err).To(MatchError(ContainSubstring(external.ErrNoFreeBlocks.Error())))
+		})
+
+		It("should allocate a requested block and be idempotent for the same host", func() {
+			cidr, err := a.AllocateBlock(ctx, request("host1", "10.1.0.4/31"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidr.String()).To(Equal("10.1.0.4/31"))
+			_, err = a.AllocateBlock(ctx, request("host1", "10.1.0.4/31"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(readState().Allocations).To(Equal([]external.FileAllocation{
+				{CIDR: "10.1.0.4/31", Pool: "pool1", Host: "host1"},
+			}))
+		})
+
+		It("should refuse a block that is allocated to another host", func() {
+			_, err := a.AllocateBlock(ctx, request("host1", "10.1.0.4/31"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = a.AllocateBlock(ctx, request("host2", "10.1.0.4/31"))
+			Expect(err).To(MatchError(ContainSubstring(external.ErrBlockUnavailable.Error())))
+		})
+
+		It("should refuse a block outside the ranges", func() {
+			_, err := a.AllocateBlock(ctx, request("host1", "10.2.0.0/31"))
+			Expect(err).To(MatchError(ContainSubstring(external.ErrBlockUnavailable.Error())))
+		})
+
+		It("should release blocks", func() {
+			_, err := a.AllocateBlock(ctx, request("host1", "10.1.0.4/31"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(a.ReleaseBlock(ctx, request("host1", "10.1.0.4/31"))).To(Succeed())
+			Expect(readState().Allocations).To(BeEmpty())
+
+			// Releasing an unallocated block succeeds.
+			Expect(a.ReleaseBlock(ctx, request("host1", "10.1.0.4/31"))).To(Succeed())
+
+			// The block can now be allocated to another host.
+			_, err = a.AllocateBlock(ctx, request("host2", "10.1.0.4/31"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not release a block that is allocated to another host", func() {
+			_, err := a.AllocateBlock(ctx, request("host1", "10.1.0.4/31"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(a.ReleaseBlock(ctx, request("host2", "10.1.0.4/31"))).To(Succeed())
+			Expect(readState().Allocations).To(HaveLen(1))
+
+			// Without a host, the block is released whoever it is allocated to.
+			Expect(a.ReleaseBlock(ctx, request("", "10.1.0.4/31"))).To(Succeed())
+			Expect(readState().Allocations).To(BeEmpty())
+		})
+	}
+
+	Describe("File allocator", func() {
+		testAllocator(func() external.BlockAllocator {
+			a, err := external.NewBlockAllocator(v3.ExternalIPAMSpec{Type: v3.ExternalIPAMTypeFile, Path: path})
+			Expect(err).NotTo(HaveOccurred())
+			return a
+		})
+	})
+
+	Describe("GRPC allocator", func() {
+		var server *grpc.Server
+
+		AfterEach(func() {
+			server.Stop()
+		})
+
+		testAllocator(func() external.BlockAllocator {
+			sock := filepath.Join(dir, "ipam.sock")
+			lis, err := net.Listen("unix", sock)
+			Expect(err).NotTo(HaveOccurred())
+			server = grpc.NewServer()
+			proto.RegisterExternalIPAMServer(server, external.NewServer(external.NewFileAllocator(path)))
+			go func() {
+				defer GinkgoRecover()
+				Expect(server.Serve(lis)).To(Succeed())
+			}()
+
+			a, err := external.NewBlockAllocator(v3.ExternalIPAMSpec{Type: v3.ExternalIPAMTypeGRPC, Endpoint: "unix://" + sock})
+			Expect(err).NotTo(HaveOccurred())
+			return a
+		})
+	})
+
+	Describe("GRPC allocator over TLS", func() {
+		var server *grpc.Server
+
+		AfterEach(func() {
+			server.Stop()
+		})
+
+		testAllocator(func() external.BlockAllocator {
+			certFile, keyFile := writeSelfSignedCert(dir)
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			Expect(err).NotTo(HaveOccurred())
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			server = grpc.NewServer(grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+			proto.RegisterExternalIPAMServer(server, external.NewServer(external.NewFileAllocator(path)))
+			go func() {
+				defer GinkgoRecover()
+				Expect(server.Serve(lis)).To(Succeed())
+			}()
+
+			a, err := external.NewBlockAllocator(v3.ExternalIPAMSpec{
+				Type:     v3.ExternalIPAMTypeGRPC,
+				Endpoint: lis.Addr().String(),
+				TLS:      &v3.ExternalIPAMTLS{CACertFile: certFile},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return a
+		})
+	})
+
+	It("should require TLS for a host:port endpoint", func() {
+		_, err := external.NewBlockAllocator(v3.ExternalIPAMSpec{Type: v3.ExternalIPAMTypeGRPC, Endpoint: "127.0.0.1:9000"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject specs without the allocator's location", func() {
+		_, err := external.NewBlockAllocator(v3.ExternalIPAMSpec{Type: v3.ExternalIPAMTypeGRPC})
+		Expect(err).To(HaveOccurred())
+		_, err = external.NewBlockAllocator(v3.ExternalIPAMSpec{Type: v3.ExternalIPAMTypeFile})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+// writeSelfSignedCert writes a self-signed certificate for 127.0.0.1, and its key, to dir and
+// returns their paths.
+func writeSelfSignedCert(dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ipam"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+	Expect(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)).To(Succeed())
+	Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)).To(Succeed())
+	return certFile, keyFile
+}
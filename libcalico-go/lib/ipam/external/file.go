@@ -0,0 +1,267 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+const (
+	lockRetryInterval = 10 * time.Millisecond
+	staleLockAge      = 30 * time.Second
+)
+
+// FileState is the content of the file that backs a file allocator.  Ranges lists the CIDRs that
+// the allocator may allocate blocks from, and Allocations records the blocks that it has allocated.
+type FileState struct {
+	Ranges      []string         `json:"ranges"`
+	Allocations []FileAllocation `json:"allocations,omitempty"`
+}
+
+// FileAllocation records the allocation of a block to a host.
+type FileAllocation struct {
+	CIDR string `json:"cidr"`
+	Pool string `json:"pool,omitempty"`
+	Host string `json:"host,omitempty"`
+}
+
+// fileAllocator is a BlockAllocator that allocates blocks from the ranges listed in a local JSON
+// file, recording its allocations in the same file.  It stands in for an external IPAM system when
+// testing.  Updates to the file are serialized with a lock file alongside it.
+type fileAllocator struct {
+	path string
+}
+
+// NewFileAllocator returns a BlockAllocator that is backed by the file at the given path.
+func NewFileAllocator(path string) BlockAllocator {
+	return &fileAllocator{path: path}
+}
+
+func (f *fileAllocator) AllocateBlock(ctx context.Context, req BlockRequest) (*cnet.IPNet, error) {
+	var allocated *cnet.IPNet
+	err := f.update(ctx, func(state *FileState) error {
+		if req.CIDR != nil {
+			if err := f.checkRequestedBlock(state, req); err != nil {
+				return err
+			}
+			allocated = req.CIDR
+		} else {
+			var err error
+			allocated, err = f.findFreeBlock(state, req)
+			if err != nil {
+				return err
+			}
+		}
+		for _, a := range state.Allocations {
+			if a.CIDR == allocated.String() {
+				// Already allocated to this host.
+				return nil
+			}
+		}
+		state.Allocations = append(state.Allocations, FileAllocation{
+			CIDR: allocated.String(),
+			Pool: req.Pool,
+			Host: req.Host,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(log.Fields{"host": req.Host, "cidr": allocated}).Info("Allocated block from file")
+	return allocated, nil
+}
+
+func (f *fileAllocator) ReleaseBlock(ctx context.Context, req BlockRequest) error {
+	if req.CIDR == nil {
+		return errors.New("no block specified to release")
+	}
+	return f.update(ctx, func(state *FileState) error {
+		for i, a := range state.Allocations {
+			if a.CIDR == req.CIDR.String() {
+				if req.Host != "" && a.Host != req.Host {
+					// Allocated to another host, so not ours to release.
+					return nil
+				}
+				state.Allocations = append(state.Allocations[:i], state.Allocations[i+1:]...)
+				log.WithField("cidr", req.CIDR).Info("Released block to file")
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func (f *fileAllocator) Close() error {
+	return nil
+}
+
+// checkRequestedBlock checks that the requested block lies within one of the ranges and is not
+// allocated to a different host.
+func (f *fileAllocator) checkRequestedBlock(state *FileState, req BlockRequest) error {
+	inRange := false
+	for _, r := range state.Ranges {
+		_, rangeNet, err := cnet.ParseCIDR(r)
+		if err != nil {
+			return fmt.Errorf("invalid range %q in %s: %w", r, f.path, err)
+		}
+		if rangeNet.Covers(req.CIDR.IPNet) {
+			inRange = true
+			break
+		}
+	}
+	if !inRange {
+		return fmt.Errorf("%w: %s is not within the allocatable ranges", ErrBlockUnavailable, req.CIDR)
+	}
+	for _, a := range state.Allocations {
+		if a.CIDR == req.CIDR.String() && a.Host != req.Host {
+			return fmt.Errorf("%w: %s is allocated to host %q", ErrBlockUnavailable, req.CIDR, a.Host)
+		}
+	}
+	return nil
+}
+
+// findFreeBlock returns the first block in the ranges that lies within the pool and is not allocated.
+func (f *fileAllocator) findFreeBlock(state *FileState, req BlockRequest) (*cnet.IPNet, error) {
+	allocated := map[string]bool{}
+	for _, a := range state.Allocations {
+		allocated[a.CIDR] = true
+	}
+	for _, r := range state.Ranges {
+		_, rangeNet, err := cnet.ParseCIDR(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range %q in %s: %w", r, f.path, err)
+		}
+		// Blocks must lie within both the range and the pool, so iterate over the smaller of
+		// the two.
+		var cidr cnet.IPNet
+		switch {
+		case req.PoolCIDR.Covers(rangeNet.IPNet):
+			cidr = *rangeNet
+		case rangeNet.Covers(req.PoolCIDR.IPNet):
+			cidr = req.PoolCIDR
+		default:
+			continue
+		}
+		if ones, _ := cidr.Mask.Size(); ones > req.BlockSize {
+			continue
+		}
+		blocks := blockGenerator(cidr, req.BlockSize)
+		for block := blocks(); block != nil; block = blocks() {
+			if !allocated[block.String()] {
+				return block, nil
+			}
+		}
+	}
+	return nil, ErrNoFreeBlocks
+}
+
+// blockGenerator returns a generator of the blocks of the given size in the CIDR.  The generator
+// returns nil once there are no more blocks.
+func blockGenerator(cidr cnet.IPNet, blockSize int) func() *cnet.IPNet {
+	bits := 128
+	if cidr.Version() == 4 {
+		bits = 32
+	}
+	mask := net.CIDRMask(blockSize, bits)
+	step := new(big.Int).Lsh(big.NewInt(1), uint(bits-blockSize))
+	ip := cnet.IP{IP: cidr.IP}
+	return func() *cnet.IPNet {
+		if !cidr.Contains(ip.IP) {
+			return nil
+		}
+		block := &cnet.IPNet{IPNet: net.IPNet{IP: ip.IP, Mask: mask}}
+		ip = cnet.IncrementIP(ip, step)
+		return block
+	}
+}
+
+// update applies the given function to the state in the file while holding the lock, and writes
+// the state back if the function succeeds.
+func (f *fileAllocator) update(ctx context.Context, fn func(*FileState) error) error {
+	unlock, err := f.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var state FileState
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to read external IPAM file: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to parse external IPAM file %s: %w", f.path, err)
+	}
+
+	if err := fn(&state); err != nil {
+		return err
+	}
+
+	data, err = json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// lock takes the lock file for the allocator's file, returning a function that releases it.
+func (f *fileAllocator) lock(ctx context.Context) (func(), error) {
+	lockPath := f.path + ".lock"
+	for {
+		lf, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			lf.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			log.WithField("lock", lockPath).Warn("Removing stale external IPAM lock file")
+			_ = os.Remove(lockPath)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for lock on %s: %w", f.path, ctx.Err())
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
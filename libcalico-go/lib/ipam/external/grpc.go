@@ -0,0 +1,194 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	calicotls "github.com/projectcalico/calico/crypto/pkg/tls"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/external/proto"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+// grpcAllocator is a BlockAllocator that delegates to an IPAM plugin that serves the ExternalIPAM
+// gRPC service.
+type grpcAllocator struct {
+	conn   *grpc.ClientConn
+	client proto.ExternalIPAMClient
+}
+
+// NewGRPCAllocator returns a BlockAllocator that delegates to the IPAM plugin at the given endpoint,
+// which is either a "unix://" socket path or a host:port.  The connection uses TLS if tlsSpec is
+// set, which it must be for a host:port.  The connection is established lazily.
+func NewGRPCAllocator(endpoint string, tlsSpec *v3.ExternalIPAMTLS) (BlockAllocator, error) {
+	creds := insecure.NewCredentials()
+	if tlsSpec != nil {
+		tlsConfig, err := grpcTLSConfig(tlsSpec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure TLS for external IPAM plugin at %s: %w", endpoint, err)
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	} else if !strings.HasPrefix(endpoint, "unix:") {
+		return nil, fmt.Errorf("external IPAM plugin at %s requires TLS, since it isn't a unix socket", endpoint)
+	}
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for external IPAM plugin at %s: %w", endpoint, err)
+	}
+	return &grpcAllocator{conn: conn, client: proto.NewExternalIPAMClient(conn)}, nil
+}
+
+func grpcTLSConfig(spec *v3.ExternalIPAMTLS) (*tls.Config, error) {
+	tlsConfig := calicotls.NewTLSConfig()
+	tlsConfig.ServerName = spec.ServerName
+	if spec.CACertFile != "" {
+		caPEM, err := os.ReadFile(spec.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in %s", spec.CACertFile)
+		}
+	}
+	if spec.CertFile != "" || spec.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(spec.CertFile, spec.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+func (g *grpcAllocator) AllocateBlock(ctx context.Context, req BlockRequest) (*cnet.IPNet, error) {
+	in := &proto.AllocateBlockRequest{
+		Pool:      req.Pool,
+		PoolCidr:  req.PoolCIDR.String(),
+		BlockSize: int32(req.BlockSize),
+		Host:      req.Host,
+	}
+	if req.CIDR != nil {
+		in.Cidr = req.CIDR.String()
+	}
+	reply, err := g.client.AllocateBlock(ctx, in)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.ResourceExhausted:
+			return nil, fmt.Errorf("%w: %s", ErrNoFreeBlocks, status.Convert(err).Message())
+		case codes.FailedPrecondition, codes.AlreadyExists:
+			return nil, fmt.Errorf("%w: %s", ErrBlockUnavailable, status.Convert(err).Message())
+		}
+		return nil, fmt.Errorf("external IPAM plugin failed to allocate block: %w", err)
+	}
+	if reply.Cidr == "" {
+		return nil, ErrNoFreeBlocks
+	}
+	_, cidr, err := cnet.ParseCIDR(reply.Cidr)
+	if err != nil {
+		return nil, fmt.Errorf("external IPAM plugin returned invalid block %q: %w", reply.Cidr, err)
+	}
+	return cidr, nil
+}
+
+func (g *grpcAllocator) ReleaseBlock(ctx context.Context, req BlockRequest) error {
+	if req.CIDR == nil {
+		return errors.New("no block specified to release")
+	}
+	_, err := g.client.ReleaseBlock(ctx, &proto.ReleaseBlockRequest{
+		Pool:     req.Pool,
+		PoolCidr: req.PoolCIDR.String(),
+		Host:     req.Host,
+		Cidr:     req.CIDR.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("external IPAM plugin failed to release block: %w", err)
+	}
+	return nil
+}
+
+func (g *grpcAllocator) Close() error {
+	return g.conn.Close()
+}
+
+// NewServer returns an implementation of the ExternalIPAM gRPC service that delegates to the given
+// BlockAllocator.  IPAM plugins that are written in Go can use it to serve their allocator.
+func NewServer(allocator BlockAllocator) proto.ExternalIPAMServer {
+	return &server{allocator: allocator}
+}
+
+type server struct {
+	allocator BlockAllocator
+}
+
+func (s *server) AllocateBlock(ctx context.Context, in *proto.AllocateBlockRequest) (*proto.AllocateBlockReply, error) {
+	req, err := blockRequest(in.Pool, in.PoolCidr, in.Host, in.Cidr)
+	if err != nil {
+		return nil, err
+	}
+	req.BlockSize = int(in.BlockSize)
+	cidr, err := s.allocator.AllocateBlock(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNoFreeBlocks):
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		case errors.Is(err, ErrBlockUnavailable):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+	return &proto.AllocateBlockReply{Cidr: cidr.String()}, nil
+}
+
+func (s *server) ReleaseBlock(ctx context.Context, in *proto.ReleaseBlockRequest) (*proto.ReleaseBlockReply, error) {
+	req, err := blockRequest(in.Pool, in.PoolCidr, in.Host, in.Cidr)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.allocator.ReleaseBlock(ctx, req); err != nil {
+		return nil, err
+	}
+	return &proto.ReleaseBlockReply{}, nil
+}
+
+func blockRequest(pool, poolCIDR, host, cidr string) (BlockRequest, error) {
+	req := BlockRequest{Pool: pool, Host: host}
+	_, pn, err := cnet.ParseCIDR(poolCIDR)
+	if err != nil {
+		return req, status.Errorf(codes.InvalidArgument, "invalid pool CIDR %q", poolCIDR)
+	}
+	req.PoolCIDR = *pn
+	if cidr != "" {
+		_, req.CIDR, err = cnet.ParseCIDR(cidr)
+		if err != nil {
+			return req, status.Errorf(codes.InvalidArgument, "invalid block CIDR %q", cidr)
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,7 @@
+//go:generate protoc -I=. --gogofaster_out=plugins=grpc:. ./externalipam.proto
+
+package proto
+
+// The proto package defines the protocol between Calico IPAM and an external IPAM plugin,
+// which allows the blocks of a Calico IP pool to be allocated by an external IPAM system such
+// as a cloud provider's or an on-premises IPAM service.
@@ -78,9 +78,11 @@ func NewIPAMClient(client bapi.Client, pools PoolAccessorInterface, reservations
 		pools:        pools,
 		reservations: reservations,
 		blockReaderWriter: blockReaderWriter{
-			client: client,
-			pools:  pools,
+			client:     client,
+			pools:      pools,
+			allocators: newExternalAllocators(),
 		},
+		eventLog: &eventLogCache{},
 	}
 }
 
@@ -90,6 +92,7 @@ type ipamClient struct {
 	pools             PoolAccessorInterface
 	blockReaderWriter blockReaderWriter
 	reservations      IPReservationInterface
+	eventLog          *eventLogCache
 }
 
 // AutoAssign automatically assigns one or more IP addresses as specified by the
@@ -549,6 +552,12 @@ func (s *blockAssignState) findOrClaimBlock(ctx context.Context, minFreeIps int)
 			logCtx := log.WithFields(log.Fields{"host": s.host, "subnet": subnet})
 			logCtx.Info("Found unclaimed block")
 
+			// If the block was allocated by an external IPAM system, it must be released if we
+			// don't manage to claim it.
+			releaseUnclaimed := func() {
+				s.client.blockReaderWriter.releaseUnclaimedExternalBlock(ctx, s.host, *subnet)
+			}
+
 			for j := 0; j < datastoreRetries; j++ {
 				// We found an unclaimed block - claim affinity for it.
 				pa, err := s.client.blockReaderWriter.getPendingAffinity(ctx, s.host, *subnet)
@@ -558,6 +567,7 @@ func (s *blockAssignState) findOrClaimBlock(ctx context.Context, minFreeIps int)
 						continue
 					}
 					logCtx.WithError(err).Errorf("Error claiming pending affinity")
+					releaseUnclaimed()
 					return nil, false, err
 				}
 
@@ -575,6 +585,7 @@ func (s *blockAssignState) findOrClaimBlock(ctx context.Context, minFreeIps int)
 						break
 					}
 					logCtx.WithError(err).Errorf("Error getting block for affinity")
+					releaseUnclaimed()
 					return nil, false, err
 				}
 
@@ -592,6 +603,7 @@ func (s *blockAssignState) findOrClaimBlock(ctx context.Context, minFreeIps int)
 					return nil, false, errors.New(errString)
 				}
 			}
+			releaseUnclaimed()
 			s.datastoreRetryCount++
 		}
 		return nil, false, errors.New("Max retries hit - excessive concurrent IPAM requests")
@@ -665,6 +677,23 @@ func (c ipamClient) autoAssign(ctx context.Context, num int, handleID *string, a
 
 	logCtx.Debugf("Found %d affine IPv%d blocks for host: %v", len(affBlocks), version, affBlocks)
 
+	// Leave out any pools in which the assignment would take the workload's namespace or node over
+	// a quota.
+	var quotas []appliedQuota
+	if use == v3.IPPoolAllowedUseWorkload {
+		quotas, err = c.applicableQuotas(ctx, pools, host, attrs)
+		if err != nil {
+			return nil, err
+		}
+		if len(quotas) > 0 {
+			pools, affBlocks, err = c.filterPoolsByQuota(ctx, quotas, pools, affBlocks, num, version)
+			if err != nil {
+				logCtx.WithError(err).Warn("Unable to assign addresses")
+				return nil, err
+			}
+		}
+	}
+
 	// Record how many blocks we own so we can check against the limit later.
 	numBlocksOwned := len(affBlocks)
 
@@ -744,7 +773,7 @@ func (c ipamClient) autoAssign(ctx context.Context, num int, handleID *string, a
 
 		// We have got a block b.
 		for i := 0; i < datastoreRetries; i++ {
-			newIPs, err := c.assignFromExistingBlock(ctx, b, rem, handleID, attrs, host, config.StrictAffinity, reservations)
+			newIPs, err := c.assignFromExistingBlock(ctx, b, rem, handleID, attrs, host, config.StrictAffinity, reservations, quotas)
 			if err != nil {
 				if _, ok := err.(cerrors.ErrorResourceUpdateConflict); ok {
 					log.WithError(err).Debug("CAS Error assigning from new block - retry")
@@ -761,6 +790,9 @@ func (c ipamClient) autoAssign(ctx context.Context, num int, handleID *string, a
 					// Block b is in sync with datastore. Retry assigning IP.
 					continue
 				}
+				if errors.Is(err, ErrQuotaExceeded) {
+					return ia, c.abandonOverQuota(ctx, ia, quotas, err)
+				}
 				logCtx.WithError(err).Warningf("Failed to assign IPs in newly allocated block")
 				ia.AddMsg("Failed to assign IPs in newly allocated block")
 				break
@@ -820,12 +852,15 @@ func (c ipamClient) autoAssign(ctx context.Context, num int, handleID *string, a
 
 					// Attempt to assign from the block.
 					logCtx.Infof("Attempting to assign IPs from non-affine block %s", blockCIDR.String())
-					newIPs, err := c.assignFromExistingBlock(ctx, b, rem, handleID, attrs, host, false, reservations)
+					newIPs, err := c.assignFromExistingBlock(ctx, b, rem, handleID, attrs, host, false, reservations, quotas)
 					if err != nil {
 						if _, ok := err.(cerrors.ErrorResourceUpdateConflict); ok {
 							logCtx.WithError(err).Debug("CAS error assigning from non-affine block - retry")
 							continue
 						}
+						if errors.Is(err, ErrQuotaExceeded) {
+							return ia, c.abandonOverQuota(ctx, ia, quotas, err)
+						}
 						logCtx.WithError(err).Warningf("Failed to assign IPs from non-affine block in pool %s", p.Spec.CIDR)
 						break
 					}
@@ -844,6 +879,12 @@ func (c ipamClient) autoAssign(ctx context.Context, num int, handleID *string, a
 		}
 	}
 
+	var events []*libapiv3.IPAMEvent
+	for _, ip := range ia.IPs {
+		events = append(events, newIPAMEvent(libapiv3.IPAMEventTypeAssign, net.IP{IP: ip.IP}, handleID, attrs, host))
+	}
+	c.recordEvents(ctx, events)
+
 	logCtx.Infof("Auto-assigned %d out of %d IPv%ds: %v", len(ia.IPs), num, version, ia.IPs)
 	return ia, nil
 }
@@ -945,6 +986,7 @@ func (c ipamClient) AssignIP(ctx context.Context, args AssignIPArgs) error {
 			}
 			return err
 		}
+		c.recordEvents(ctx, []*libapiv3.IPAMEvent{newIPAMEvent(libapiv3.IPAMEventTypeAssign, args.IP, args.HandleID, args.Attrs, hostname)})
 		return nil
 	}
 	return errors.New("Max retries hit - excessive concurrent IPAM requests")
@@ -953,6 +995,12 @@ func (c ipamClient) AssignIP(ctx context.Context, args AssignIPArgs) error {
 // ReleaseIPs releases any of the given IP addresses that are currently assigned,
 // so that they are available to be used in another assignment.
 func (c ipamClient) ReleaseIPs(ctx context.Context, ips ...ReleaseOptions) ([]net.IP, error) {
+	return c.releaseIPs(ctx, true, ips...)
+}
+
+// releaseIPs releases the addresses, recording their release in the IPAM event log if record is
+// set.
+func (c ipamClient) releaseIPs(ctx context.Context, record bool, ips ...ReleaseOptions) ([]net.IP, error) {
 	for i := 0; i < len(ips); i++ {
 		// Validate the input.
 		if ips[i].Address == "" {
@@ -1068,7 +1116,7 @@ func (c ipamClient) ReleaseIPs(ctx context.Context, ips ...ReleaseOptions) ([]ne
 		go func(cidr net.IPNet, ips []ReleaseOptions, hm map[string]*model.KVPair) {
 			defer sem.Release(1)
 			r := retVal{}
-			unalloc, err := c.releaseIPsFromBlock(ctx, hm, ips, cidr)
+			unalloc, err := c.releaseIPsFromBlock(ctx, hm, ips, cidr, record)
 			if err != nil {
 				log.Errorf("Error releasing IPs: %v", err)
 				r.Error = err
@@ -1091,7 +1139,7 @@ func (c ipamClient) ReleaseIPs(ctx context.Context, ips ...ReleaseOptions) ([]ne
 	return unallocated, err
 }
 
-func (c ipamClient) releaseIPsFromBlock(ctx context.Context, handleMap map[string]*model.KVPair, ips []ReleaseOptions, blockCIDR net.IPNet) ([]net.IP, error) {
+func (c ipamClient) releaseIPsFromBlock(ctx context.Context, handleMap map[string]*model.KVPair, ips []ReleaseOptions, blockCIDR net.IPNet, record bool) ([]net.IP, error) {
 	logCtx := log.WithField("cidr", blockCIDR)
 	for i := 0; i < datastoreRetries; i++ {
 		logCtx.Debug("Getting block so we can release IPs")
@@ -1116,8 +1164,9 @@ func (c ipamClient) releaseIPsFromBlock(ctx context.Context, handleMap map[strin
 			}
 		}
 
-		// Release the IPs.
+		// Release the IPs, noting what they were assigned to for the event log.
 		b := allocationBlock{obj.Value.(*model.AllocationBlock)}
+		events := releaseEvents(b, ips)
 		unallocated, handles, err2 := b.release(ips)
 		if err2 != nil {
 			return nil, err2
@@ -1161,6 +1210,9 @@ func (c ipamClient) releaseIPsFromBlock(ctx context.Context, handleMap map[strin
 				logCtx.WithError(err).Warn("Failed to decrement handle")
 			}
 		}
+		if record {
+			c.recordEvents(ctx, releasedEvents(b, events))
+		}
 
 		// Determine whether or not the block's pool still matches the node.
 		if err := c.ensureConsistentAffinity(ctx, obj.Value.(*model.AllocationBlock)); err != nil {
@@ -1171,7 +1223,7 @@ func (c ipamClient) releaseIPsFromBlock(ctx context.Context, handleMap map[strin
 	return nil, errors.New("Max retries hit - excessive concurrent IPAM requests")
 }
 
-func (c ipamClient) assignFromExistingBlock(ctx context.Context, block *model.KVPair, num int, handleID *string, attrs map[string]string, host string, affCheck bool, reservations addrFilter) ([]net.IPNet, error) {
+func (c ipamClient) assignFromExistingBlock(ctx context.Context, block *model.KVPair, num int, handleID *string, attrs map[string]string, host string, affCheck bool, reservations addrFilter, quotas []appliedQuota) ([]net.IPNet, error) {
 	blockCIDR := block.Key.(model.BlockKey).CIDR
 	logCtx := log.WithFields(log.Fields{"host": host, "block": blockCIDR})
 	if handleID != nil {
@@ -1192,6 +1244,15 @@ func (c ipamClient) assignFromExistingBlock(ctx context.Context, block *model.KV
 		return []net.IPNet{}, nil
 	}
 
+	// Reserve the addresses under the namespace or node's quotas before claiming them, so that
+	// concurrent assignments can't take it over a quota between them.
+	if len(quotas) > 0 {
+		if err := c.reserveQuotas(ctx, quotas, blockCIDR, ips); err != nil {
+			logCtx.WithError(err).Warn("Unable to reserve addresses under quota")
+			return nil, err
+		}
+	}
+
 	// Increment handle count.
 	if handleID != nil {
 		logCtx.Debug("Incrementing handle")
@@ -1205,6 +1266,9 @@ func (c ipamClient) assignFromExistingBlock(ctx context.Context, block *model.KV
 	_, err = c.blockReaderWriter.updateBlock(ctx, block)
 	if err != nil {
 		logCtx.WithError(err).Infof("Failed to update block")
+		if len(quotas) > 0 {
+			c.releaseQuotaReservations(ctx, quotas, ips)
+		}
 		if handleID != nil {
 			logCtx.Debug("Decrementing handle since we failed to allocate IP(s)")
 			if err := c.decrementHandle(ctx, *handleID, blockCIDR, num, nil); err != nil {
@@ -1617,6 +1681,7 @@ func (c ipamClient) releaseByHandle(ctx context.Context, blockCIDR net.IPNet, op
 
 		// Release the IP by handle.
 		block := allocationBlock{obj.Value.(*model.AllocationBlock)}
+		events := releaseByHandleEvents(block, opts.Handle)
 		num := block.releaseByHandle(opts)
 		if num == 0 {
 			// Block has no addresses with this handle, so
@@ -1664,6 +1729,7 @@ func (c ipamClient) releaseByHandle(ctx context.Context, blockCIDR net.IPNet, op
 		if err = c.decrementHandle(ctx, opts.Handle, blockCIDR, num, nil); err != nil {
 			logCtx.WithError(err).Warn("Failed to decrement handle")
 		}
+		c.recordEvents(ctx, releasedEvents(block, events))
 
 		// Determine whether or not the block's pool still matches the node.
 		if err = c.ensureConsistentAffinity(ctx, block.AllocationBlock); err != nil {
@@ -1919,6 +1985,7 @@ func (c ipamClient) SetIPAMConfig(ctx context.Context, cfg IPAMConfig) error {
 		log.Errorf("Error applying IPAMConfig: %v", err)
 		return err
 	}
+	c.eventLog.invalidate()
 
 	return nil
 }
@@ -1928,6 +1995,7 @@ func (c ipamClient) convertIPAMConfigToBackend(cfg *IPAMConfig) *model.IPAMConfi
 		StrictAffinity:     cfg.StrictAffinity,
 		AutoAllocateBlocks: cfg.AutoAllocateBlocks,
 		MaxBlocksPerHost:   cfg.MaxBlocksPerHost,
+		EventLog:           cfg.EventLog,
 	}
 }
 
@@ -1936,6 +2004,7 @@ func (c ipamClient) convertBackendToIPAMConfig(cfg *model.IPAMConfig) *IPAMConfi
 		StrictAffinity:     cfg.StrictAffinity,
 		AutoAllocateBlocks: cfg.AutoAllocateBlocks,
 		MaxBlocksPerHost:   cfg.MaxBlocksPerHost,
+		EventLog:           cfg.EventLog,
 	}
 }
 
@@ -2041,14 +2110,19 @@ func (c ipamClient) GetUtilization(ctx context.Context, args GetUtilizationArgs)
 	// Identify the ones we want and create a PoolUtilization for each of those.
 	wantAllPools := len(args.Pools) == 0
 	wantedPools := set.FromArray(args.Pools)
+	poolsWithQuotas := map[*PoolUtilization]v3.IPPool{}
 	for _, pool := range allPools {
 		if wantAllPools ||
 			wantedPools.Contains(pool.Name) ||
 			wantedPools.Contains(pool.Spec.CIDR) {
-			usage = append(usage, &PoolUtilization{
+			poolUse := &PoolUtilization{
 				Name: pool.Name,
 				CIDR: net.MustParseNetwork(pool.Spec.CIDR).IPNet,
-			})
+			}
+			usage = append(usage, poolUse)
+			if len(pool.Spec.Quotas) > 0 {
+				poolsWithQuotas[poolUse] = pool
+			}
 		}
 	}
 
@@ -2085,6 +2159,15 @@ func (c ipamClient) GetUtilization(ctx context.Context, args GetUtilizationArgs)
 			}
 		}
 	}
+
+	// Report the addresses held by the namespaces and nodes that are subject to each pool's quotas.
+	labels := newQuotaLabels(c)
+	for poolUse, pool := range poolsWithQuotas {
+		poolUse.Quotas, err = c.quotaUtilization(ctx, labels, pool, blocks.KVPairs)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return usage, nil
 }
 
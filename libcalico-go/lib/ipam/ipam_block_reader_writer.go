@@ -37,6 +37,15 @@ import (
 type blockReaderWriter struct {
 	client bapi.Client
 	pools  PoolAccessorInterface
+
+	// allocators holds the allocators for pools whose blocks are allocated by an external IPAM system.
+	allocators *externalAllocators
+}
+
+// blockInfo summarises an existing block when searching for a usable block.
+type blockInfo struct {
+	numFree  int
+	affinity string
 }
 
 // getAffineBlocks gets all the IPAM blocks that are affine to this host and returns them as a slice of CIDRs.
@@ -122,11 +131,6 @@ func (rw blockReaderWriter) findUsableBlock(ctx context.Context, host string, ve
 		return nil, err
 	}
 
-	type blockInfo struct {
-		numFree  int
-		affinity string
-	}
-
 	// Build a map for faster lookups.
 	exists := map[string]blockInfo{}
 	for _, e := range existingBlocks.KVPairs {
@@ -140,6 +144,17 @@ func (rw blockReaderWriter) findUsableBlock(ctx context.Context, host string, ve
 		// Use a block generator to iterate through all of the blocks
 		// that fall within the pool.
 		log.Debugf("Looking for blocks in pool %+v", pool)
+		if pool.Spec.ExternalIPAM != nil {
+			// Blocks in this pool are allocated by an external IPAM system.
+			subnet, err := rw.findExternalBlock(ctx, host, &pool, exists)
+			if err != nil {
+				return nil, err
+			}
+			if subnet != nil {
+				return subnet, nil
+			}
+			continue
+		}
 		blocks := randomBlockGenerator(pool, host)
 		for subnet := blocks(); subnet != nil; subnet = blocks() {
 			// Check if the whole subnet is reserved.
@@ -215,6 +230,16 @@ func (rw blockReaderWriter) claimAffineBlock(ctx context.Context, aff *model.KVP
 	block := newBlock(subnet, rsvdAttr)
 	block.Affinity = &affinityKeyStr
 
+	// If the block is allocated by an external IPAM system, it must confirm the allocation before
+	// we create the block.
+	if err := rw.allocateExternalBlock(ctx, host, subnet); err != nil {
+		logCtx.WithError(err).Warn("External IPAM did not allocate block, delete our pending affinity")
+		if err := rw.deleteAffinity(ctx, aff); err != nil {
+			logCtx.WithError(err).Errorf("Error deleting block affinity")
+		}
+		return nil, err
+	}
+
 	// Create the new block in the datastore.
 	o := model.KVPair{
 		Key:   model.BlockKey{CIDR: block.CIDR},
@@ -254,9 +279,11 @@ func (rw blockReaderWriter) claimAffineBlock(ctx context.Context, aff *model.KVP
 				// Failed to clean up our claim to this block.
 				logCtx.WithError(err).Errorf("Error deleting block affinity")
 			}
+			rw.releaseUnclaimedExternalBlock(ctx, host, subnet)
 			return nil, errBlockClaimConflict{Block: b}
 		}
 		logCtx.WithError(err).Warningf("Problem creating block while claiming block")
+		rw.releaseUnclaimedExternalBlock(ctx, host, subnet)
 		return nil, err
 	}
 
@@ -418,6 +445,10 @@ func (rw blockReaderWriter) updateBlock(ctx context.Context, b *model.KVPair) (*
 // deleteBlock deletes the given block.
 func (rw blockReaderWriter) deleteBlock(ctx context.Context, b *model.KVPair) error {
 	_, err := rw.client.DeleteKVP(ctx, b)
+	if err == nil {
+		block := b.Value.(*model.AllocationBlock)
+		rw.releaseExternalBlock(ctx, block.Host(), block.CIDR)
+	}
 	return err
 }
 
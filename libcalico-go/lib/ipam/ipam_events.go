@@ -0,0 +1,255 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/semaphore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+// newIPAMEvent returns an event recording the assignment or release of the given address, which is,
+// or was, allocated to the given handle with the given attributes.
+func newIPAMEvent(t libapiv3.IPAMEventType, ip net.IP, handleID *string, attrs map[string]string, host string) *libapiv3.IPAMEvent {
+	now := time.Now()
+	e := libapiv3.NewIPAMEvent()
+	e.Name = fmt.Sprintf("%s-%s-%d", strings.ToLower(string(t)), strings.NewReplacer(".", "-", ":", "-").Replace(ip.String()), now.UnixNano())
+	e.Spec = libapiv3.IPAMEventSpec{
+		Type:      t,
+		Time:      metav1.NewTime(now),
+		IP:        ip.String(),
+		Namespace: attrs[AttributeNamespace],
+		Pod:       attrs[AttributePod],
+		Node:      attrs[AttributeNode],
+	}
+	if e.Spec.Node == "" {
+		e.Spec.Node = host
+	}
+	if handleID != nil {
+		e.Spec.HandleID = *handleID
+		// The CNI plugin's handles are of the form <network>.<container ID>.
+		if e.Spec.Pod != "" {
+			if i := strings.LastIndex(*handleID, "."); i >= 0 {
+				e.Spec.ContainerID = (*handleID)[i+1:]
+			}
+		}
+	}
+	return e
+}
+
+// releaseEvents returns the events to record for releasing the given addresses from the block.  It
+// must be called before the addresses are released, while their attributes are still in the block.
+func releaseEvents(b allocationBlock, ips []ReleaseOptions) []*libapiv3.IPAMEvent {
+	var events []*libapiv3.IPAMEvent
+	for _, opts := range ips {
+		ip := net.ParseIP(opts.Address)
+		if ip == nil {
+			continue
+		}
+		attrs, err := b.attributesForIP(*ip)
+		if err != nil {
+			// Not assigned, so it won't be released.
+			continue
+		}
+		handleID, _ := b.handleForIP(*ip)
+		events = append(events, newIPAMEvent(libapiv3.IPAMEventTypeRelease, *ip, handleID, attrs, hostFromAffinity(b)))
+	}
+	return events
+}
+
+// releaseByHandleEvents returns the events to record for releasing the addresses with the given
+// handle from the block.  Like releaseEvents, it must be called before the addresses are released.
+func releaseByHandleEvents(b allocationBlock, handleID string) []*libapiv3.IPAMEvent {
+	var opts []ReleaseOptions
+	for _, ip := range b.ipsByHandle(handleID) {
+		opts = append(opts, ReleaseOptions{Address: ip.String()})
+	}
+	return releaseEvents(b, opts)
+}
+
+// releasedEvents returns the events for the addresses that are no longer assigned in the block, leaving
+// out any that the release skipped.
+func releasedEvents(b allocationBlock, events []*libapiv3.IPAMEvent) []*libapiv3.IPAMEvent {
+	var released []*libapiv3.IPAMEvent
+	for _, e := range events {
+		if _, err := b.attributesForIP(*net.ParseIP(e.Spec.IP)); err != nil {
+			released = append(released, e)
+		}
+	}
+	return released
+}
+
+// hostFromAffinity returns the host that the block is affine to, if any.
+func hostFromAffinity(b allocationBlock) string {
+	if b.Affinity != nil && strings.HasPrefix(*b.Affinity, "host:") {
+		return strings.TrimPrefix(*b.Affinity, "host:")
+	}
+	return ""
+}
+
+const (
+	// eventLogConfigTTL is how long the client caches whether the IPAM event log is enabled, so
+	// that assignments and releases don't each have to read the IPAM config.
+	eventLogConfigTTL = 30 * time.Second
+
+	// maxConcurrentEventWrites limits the number of events that are written to the datastore in
+	// parallel.
+	maxConcurrentEventWrites = 10
+)
+
+// eventLogCache caches whether the IPAM event log is enabled.
+type eventLogCache struct {
+	lock    sync.Mutex
+	enabled bool
+	expiry  time.Time
+}
+
+// invalidate forces the next lookup to read the IPAM config.
+func (e *eventLogCache) invalidate() {
+	if e == nil {
+		return
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.expiry = time.Time{}
+}
+
+// eventLogEnabled returns whether the IPAM event log is enabled, reading the IPAM config if the
+// cached value has expired.
+func (c ipamClient) eventLogEnabled(ctx context.Context) (bool, error) {
+	if c.eventLog != nil {
+		c.eventLog.lock.Lock()
+		defer c.eventLog.lock.Unlock()
+		if time.Now().Before(c.eventLog.expiry) {
+			return c.eventLog.enabled, nil
+		}
+	}
+	cfg, err := c.GetIPAMConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+	if c.eventLog != nil {
+		c.eventLog.enabled = cfg.EventLog
+		c.eventLog.expiry = time.Now().Add(eventLogConfigTTL)
+	}
+	return cfg.EventLog, nil
+}
+
+// recordEvents writes the events to the datastore, if the IPAM event log is enabled.  The events
+// are written in parallel, so that recording the events of a large assignment or release costs
+// roughly one round trip.  The event log is best-effort: failing to record an event doesn't fail
+// the assignment or release.
+func (c ipamClient) recordEvents(ctx context.Context, events []*libapiv3.IPAMEvent) {
+	if len(events) == 0 {
+		return
+	}
+	enabled, err := c.eventLogEnabled(ctx)
+	if err != nil {
+		log.WithError(err).Debug("Unable to get IPAM config, not recording IPAM events")
+		return
+	}
+	if !enabled {
+		return
+	}
+
+	var wg sync.WaitGroup
+	sem := semaphore.NewWeighted(maxConcurrentEventWrites)
+	for _, e := range events {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			log.WithError(err).Warn("Failed to record IPAM events")
+			break
+		}
+		wg.Add(1)
+		go func(e *libapiv3.IPAMEvent) {
+			defer wg.Done()
+			defer sem.Release(1)
+			_, err := c.client.Create(ctx, &model.KVPair{
+				Key:   model.ResourceKey{Kind: libapiv3.KindIPAMEvent, Name: e.Name},
+				Value: e,
+			})
+			if err != nil {
+				log.WithError(err).WithField("event", e.Name).Warn("Failed to record IPAM event")
+			}
+		}(e)
+	}
+	wg.Wait()
+}
+
+// ListEvents returns the events in the IPAM event log, oldest first.
+func ListEvents(ctx context.Context, client bapi.Client) ([]*libapiv3.IPAMEvent, error) {
+	kvps, err := client.List(ctx, model.ResourceListOptions{Kind: libapiv3.KindIPAMEvent}, "")
+	if err != nil {
+		return nil, err
+	}
+	var events []*libapiv3.IPAMEvent
+	for _, kvp := range kvps.KVPairs {
+		events = append(events, kvp.Value.(*libapiv3.IPAMEvent))
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Spec.Time.Equal(&events[j].Spec.Time) {
+			return events[i].Spec.Time.Before(&events[j].Spec.Time)
+		}
+		return events[i].Name < events[j].Name
+	})
+	return events, nil
+}
+
+// PruneEvents deletes the events in the IPAM event log that are older than maxAge, and then the
+// oldest events until at most maxEvents remain.  A zero maxAge or maxEvents disables that limit.
+// It returns the number of events that were deleted.
+func PruneEvents(ctx context.Context, client bapi.Client, maxAge time.Duration, maxEvents int) (int, error) {
+	events, err := ListEvents(ctx, client)
+	if err != nil {
+		return 0, err
+	}
+
+	var prune []*libapiv3.IPAMEvent
+	if maxAge > 0 {
+		cutoff := time.Now().Add(-maxAge)
+		for len(events) > 0 && events[0].Spec.Time.Time.Before(cutoff) {
+			prune = append(prune, events[0])
+			events = events[1:]
+		}
+	}
+	if maxEvents > 0 && len(events) > maxEvents {
+		prune = append(prune, events[:len(events)-maxEvents]...)
+	}
+
+	deleted := 0
+	for _, e := range prune {
+		_, err := client.Delete(ctx, model.ResourceKey{Kind: libapiv3.KindIPAMEvent, Name: e.Name}, "")
+		if err != nil {
+			if _, ok := err.(cerrors.ErrorResourceDoesNotExist); ok {
+				continue
+			}
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
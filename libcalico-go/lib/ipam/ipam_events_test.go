@@ -0,0 +1,168 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+// fakeEventBackend implements the parts of the backend client that the event log uses.
+type fakeEventBackend struct {
+	bapi.Client
+	events map[string]*libapiv3.IPAMEvent
+}
+
+func (f *fakeEventBackend) List(ctx context.Context, list model.ListInterface, revision string) (*model.KVPairList, error) {
+	kvps := &model.KVPairList{}
+	for name, e := range f.events {
+		kvps.KVPairs = append(kvps.KVPairs, &model.KVPair{Key: model.ResourceKey{Kind: libapiv3.KindIPAMEvent, Name: name}, Value: e})
+	}
+	return kvps, nil
+}
+
+func (f *fakeEventBackend) Delete(ctx context.Context, key model.Key, revision string) (*model.KVPair, error) {
+	delete(f.events, key.(model.ResourceKey).Name)
+	return nil, nil
+}
+
+// fakeEventRecordingBackend implements the parts of the backend client that recording events uses.
+type fakeEventRecordingBackend struct {
+	bapi.Client
+	lock       sync.Mutex
+	eventLog   bool
+	configGets int
+	events     map[string]*libapiv3.IPAMEvent
+}
+
+func (f *fakeEventRecordingBackend) Get(ctx context.Context, key model.Key, revision string) (*model.KVPair, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.configGets++
+	return &model.KVPair{Key: key, Value: &model.IPAMConfig{AutoAllocateBlocks: true, EventLog: f.eventLog}}, nil
+}
+
+func (f *fakeEventRecordingBackend) Create(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	e := kvp.Value.(*libapiv3.IPAMEvent)
+	f.events[e.Name] = e
+	return kvp, nil
+}
+
+func TestIPAMEventRecording(t *testing.T) {
+	RegisterTestingT(t)
+
+	f := &fakeEventRecordingBackend{eventLog: true, events: map[string]*libapiv3.IPAMEvent{}}
+	c := ipamClient{client: f, eventLog: &eventLogCache{}}
+	newEvents := func(n int) []*libapiv3.IPAMEvent {
+		var events []*libapiv3.IPAMEvent
+		for i := 0; i < n; i++ {
+			events = append(events, newIPAMEvent(libapiv3.IPAMEventTypeAssign, *net.ParseIP(fmt.Sprintf("10.0.0.%d", len(f.events)+i)), nil, nil, "node1"))
+		}
+		return events
+	}
+
+	// All the events are written, and the IPAM config is read once for both batches.
+	c.recordEvents(context.Background(), newEvents(25))
+	c.recordEvents(context.Background(), newEvents(25))
+	Expect(f.events).To(HaveLen(50))
+	Expect(f.configGets).To(Equal(1))
+
+	// Once the cached config is invalidated, disabling the event log takes effect.
+	f.eventLog = false
+	c.eventLog.invalidate()
+	c.recordEvents(context.Background(), newEvents(5))
+	Expect(f.events).To(HaveLen(50))
+	Expect(f.configGets).To(Equal(2))
+}
+
+func TestIPAMEventPruning(t *testing.T) {
+	RegisterTestingT(t)
+
+	now := time.Now()
+	f := &fakeEventBackend{events: map[string]*libapiv3.IPAMEvent{}}
+	for name, age := range map[string]time.Duration{
+		"old":    48 * time.Hour,
+		"older":  72 * time.Hour,
+		"recent": time.Hour,
+		"newer":  time.Minute,
+		"newest": time.Second,
+	} {
+		e := libapiv3.NewIPAMEvent()
+		e.Name = name
+		e.Spec.Time = metav1.NewTime(now.Add(-age))
+		f.events[name] = e
+	}
+
+	events, err := ListEvents(context.Background(), f)
+	Expect(err).NotTo(HaveOccurred())
+	var names []string
+	for _, e := range events {
+		names = append(names, e.Name)
+	}
+	Expect(names).To(Equal([]string{"older", "old", "recent", "newer", "newest"}))
+
+	// Events older than a day are removed, and then the oldest until only two remain.
+	deleted, err := PruneEvents(context.Background(), f, 24*time.Hour, 2)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(deleted).To(Equal(3))
+	Expect(f.events).To(HaveLen(2))
+	Expect(f.events).To(HaveKey("newer"))
+	Expect(f.events).To(HaveKey("newest"))
+
+	// No limits, nothing to do.
+	deleted, err = PruneEvents(context.Background(), f, 0, 0)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(deleted).To(BeZero())
+}
+
+func TestIPAMEventFromAllocation(t *testing.T) {
+	RegisterTestingT(t)
+
+	handle := "k8s-pod-network.0123abcd"
+	e := newIPAMEvent(libapiv3.IPAMEventTypeAssign, *net.ParseIP("fd00::1"), &handle, map[string]string{
+		AttributeNamespace: "default",
+		AttributePod:       "pod1",
+	}, "node1")
+	Expect(e.Name).To(HavePrefix("assign-fd00--1-"))
+	Expect(e.Spec.IP).To(Equal("fd00::1"))
+	Expect(e.Spec.HandleID).To(Equal(handle))
+	Expect(e.Spec.ContainerID).To(Equal("0123abcd"))
+	Expect(e.Spec.Namespace).To(Equal("default"))
+	Expect(e.Spec.Pod).To(Equal("pod1"))
+	Expect(e.Spec.Node).To(Equal("node1"))
+
+	// Tunnel addresses have no container.
+	handle = "ipip-tunnel-addr-node1.example.com"
+	e = newIPAMEvent(libapiv3.IPAMEventTypeRelease, *net.ParseIP("10.0.0.1"), &handle, map[string]string{
+		AttributeNode: "node1.example.com",
+		AttributeType: AttributeTypeIPIP,
+	}, "")
+	Expect(e.Name).To(HavePrefix("release-10-0-0-1-"))
+	Expect(e.Spec.ContainerID).To(BeEmpty())
+	Expect(e.Spec.Node).To(Equal("node1.example.com"))
+}
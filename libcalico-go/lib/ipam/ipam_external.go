@@ -0,0 +1,257 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/external"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+// externalAllocators caches the allocators for IP pools whose blocks are allocated by an external
+// IPAM system, so that connections to IPAM plugins are reused across requests.
+type externalAllocators struct {
+	lock       sync.Mutex
+	allocators map[string]cachedAllocator
+}
+
+type cachedAllocator struct {
+	spec      v3.ExternalIPAMSpec
+	allocator external.BlockAllocator
+}
+
+func newExternalAllocators() *externalAllocators {
+	return &externalAllocators{allocators: map[string]cachedAllocator{}}
+}
+
+// get returns the allocator for the given pool, creating it if the pool's external IPAM
+// configuration has changed since it was last used.  The caller must call the returned release
+// function once it has finished with the allocator.
+func (e *externalAllocators) get(pool *v3.IPPool) (external.BlockAllocator, func(), error) {
+	if e == nil {
+		// No cache, so the allocator is only used once.
+		a, err := external.NewBlockAllocator(*pool.Spec.ExternalIPAM)
+		if err != nil {
+			return nil, nil, err
+		}
+		return a, func() { closeAllocator(a) }, nil
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	c, ok := e.allocators[pool.Name]
+	if ok && reflect.DeepEqual(c.spec, *pool.Spec.ExternalIPAM) {
+		return c.allocator, func() {}, nil
+	}
+	a, err := external.NewBlockAllocator(*pool.Spec.ExternalIPAM)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ok {
+		// The pool's configuration has changed.  Any request that is still using the old
+		// allocator fails, and is retried by its caller.
+		closeAllocator(c.allocator)
+	}
+	e.allocators[pool.Name] = cachedAllocator{spec: *pool.Spec.ExternalIPAM.DeepCopy(), allocator: a}
+	return a, func() {}, nil
+}
+
+func closeAllocator(a external.BlockAllocator) {
+	if err := a.Close(); err != nil {
+		log.WithError(err).Warn("Failed to close external IPAM allocator")
+	}
+}
+
+// blockRequest returns the request to the external IPAM system for the given block of the pool.
+func blockRequest(pool *v3.IPPool, host string, cidr *cnet.IPNet) (external.BlockRequest, error) {
+	_, poolNet, err := cnet.ParseCIDR(pool.Spec.CIDR)
+	if err != nil {
+		return external.BlockRequest{}, err
+	}
+	return external.BlockRequest{
+		Pool:      pool.Name,
+		PoolCIDR:  *poolNet,
+		BlockSize: pool.Spec.BlockSize,
+		Host:      host,
+		CIDR:      cidr,
+	}, nil
+}
+
+// findExternalBlock finds a usable block in a pool whose blocks are allocated by an external IPAM
+// system.  It prefers an existing block that is affine to the host and has free space, and otherwise
+// asks the external system for a new block.  The caller must claim a new block, or release it with
+// releaseUnclaimedExternalBlock if it can't.
+func (rw blockReaderWriter) findExternalBlock(ctx context.Context, host string, pool *v3.IPPool, existing map[string]blockInfo) (*cnet.IPNet, error) {
+	logCtx := log.WithFields(log.Fields{"host": host, "pool": pool.Name})
+	_, poolNet, err := cnet.ParseCIDR(pool.Spec.CIDR)
+	if err != nil {
+		return nil, err
+	}
+	for cidr, info := range existing {
+		_, blockNet, err := cnet.ParseCIDR(cidr)
+		if err != nil || !poolNet.Contains(blockNet.IP) {
+			continue
+		}
+		if info.affinity == host && info.numFree != 0 {
+			logCtx.Debugf("Block %s already assigned to host, has free space", cidr)
+			return blockNet, nil
+		}
+	}
+
+	allocator, release, err := rw.allocators.get(pool)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	req, err := blockRequest(pool, host, nil)
+	if err != nil {
+		return nil, err
+	}
+	subnet, err := allocator.AllocateBlock(ctx, req)
+	if err != nil {
+		if errors.Is(err, external.ErrNoFreeBlocks) {
+			logCtx.Info("External IPAM has no free blocks in pool")
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Check that the external system has honoured the pool's CIDR and block size.  If not, give
+	// the block back, since we won't claim it.
+	if ones, _ := subnet.Mask.Size(); ones != pool.Spec.BlockSize || !poolNet.Contains(subnet.IP) {
+		rw.releaseExternalBlock(ctx, host, *subnet)
+		return nil, fmt.Errorf("external IPAM allocated block %s which is not a /%d block of pool %s", subnet, pool.Spec.BlockSize, pool.Name)
+	}
+	if info, ok := existing[subnet.String()]; ok && info.affinity != host {
+		rw.releaseExternalBlock(ctx, host, *subnet)
+		return nil, fmt.Errorf("external IPAM allocated block %s which is already in use by host %q", subnet, info.affinity)
+	}
+	logCtx.Infof("External IPAM allocated block %s", subnet)
+	return subnet, nil
+}
+
+// allocateExternalBlock confirms the allocation of the given block to the host with the external
+// IPAM system, if the block belongs to a pool whose blocks are allocated externally.  If the block
+// then can't be claimed, it must be released with releaseUnclaimedExternalBlock.
+func (rw blockReaderWriter) allocateExternalBlock(ctx context.Context, host string, subnet cnet.IPNet) error {
+	pool, allocator, release, err := rw.externalAllocatorForBlock(subnet)
+	if err != nil || allocator == nil {
+		return err
+	}
+	defer release()
+	req, err := blockRequest(pool, host, &subnet)
+	if err != nil {
+		return err
+	}
+	_, err = allocator.AllocateBlock(ctx, req)
+	return err
+}
+
+// releaseUnclaimedExternalBlock releases the host's allocation of the given block to the external
+// IPAM system, if the block belongs to a pool whose blocks are allocated externally and the host
+// hasn't claimed the block in the datastore.  It is used on the error paths after the external
+// system has allocated a block, so that the allocation isn't leaked.
+func (rw blockReaderWriter) releaseUnclaimedExternalBlock(ctx context.Context, host string, subnet cnet.IPNet) {
+	logCtx := log.WithFields(log.Fields{"host": host, "cidr": subnet})
+	if isExternal, err := rw.isExternalBlock(subnet); err != nil || !isExternal {
+		// releaseExternalBlock would do nothing either.
+		return
+	}
+	b, err := rw.queryBlock(ctx, subnet, "")
+	if err == nil {
+		if aff := b.Value.(*model.AllocationBlock).Affinity; aff != nil && *aff == "host:"+host {
+			logCtx.Debug("Block is claimed by this host, not releasing it to external IPAM")
+			return
+		}
+	} else if _, ok := err.(cerrors.ErrorResourceDoesNotExist); !ok {
+		// We can't tell whether the block was claimed, so leave the allocation in place.
+		logCtx.WithError(err).Warn("Failed to read block, not releasing it to external IPAM")
+		return
+	}
+	rw.releaseExternalBlock(ctx, host, subnet)
+}
+
+// releaseExternalBlock releases the given block to the external IPAM system, if it belongs to a pool
+// whose blocks are allocated externally.  Failures are logged, since the block is already gone
+// from the datastore or was never claimed, so there is nothing to roll back.
+func (rw blockReaderWriter) releaseExternalBlock(ctx context.Context, host string, subnet cnet.IPNet) {
+	logCtx := log.WithField("cidr", subnet)
+	pool, allocator, release, err := rw.externalAllocatorForBlock(subnet)
+	if err != nil {
+		logCtx.WithError(err).Warn("Failed to determine whether block is allocated by external IPAM")
+		return
+	}
+	if allocator == nil {
+		return
+	}
+	defer release()
+	req, err := blockRequest(pool, host, &subnet)
+	if err != nil {
+		logCtx.WithError(err).Warn("Failed to release block to external IPAM")
+		return
+	}
+	if err := allocator.ReleaseBlock(ctx, req); err != nil {
+		logCtx.WithError(err).Warn("Failed to release block to external IPAM")
+		return
+	}
+	logCtx.Info("Released block to external IPAM")
+}
+
+// externalAllocatorForBlock returns the pool containing the given block and its allocator, or a nil
+// allocator if the block isn't in a pool whose blocks are allocated externally.  Disabled pools are
+// included so that their blocks are still released.  If the allocator is not nil, the caller must
+// call the returned release function once it has finished with it.
+func (rw blockReaderWriter) externalAllocatorForBlock(subnet cnet.IPNet) (*v3.IPPool, external.BlockAllocator, func(), error) {
+	pool, err := rw.externalPoolForBlock(subnet)
+	if err != nil || pool == nil {
+		return nil, nil, nil, err
+	}
+	allocator, release, err := rw.allocators.get(pool)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return pool, allocator, release, nil
+}
+
+// isExternalBlock returns true if the given block is in a pool whose blocks are allocated
+// externally.
+func (rw blockReaderWriter) isExternalBlock(subnet cnet.IPNet) (bool, error) {
+	pool, err := rw.externalPoolForBlock(subnet)
+	return pool != nil, err
+}
+
+// externalPoolForBlock returns the pool containing the given block, or nil if the block isn't in a
+// pool whose blocks are allocated externally.
+func (rw blockReaderWriter) externalPoolForBlock(subnet cnet.IPNet) (*v3.IPPool, error) {
+	pools, err := rw.pools.GetAllPools()
+	if err != nil {
+		return nil, err
+	}
+	pool, err := findContainingPool(pools, subnet.IP)
+	if err != nil || pool == nil || pool.Spec.ExternalIPAM == nil {
+		return nil, err
+	}
+	return pool, nil
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend"
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/external"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+	"github.com/projectcalico/calico/libcalico-go/lib/testutils"
+)
+
+var _ = testutils.E2eDatastoreDescribe("IPAM tests with an external allocator", testutils.DatastoreAll, func(config apiconfig.CalicoAPIConfig) {
+	const (
+		poolCIDR = "10.0.0.0/24"
+		host1    = "host-external-1"
+		host2    = "host-external-2"
+	)
+	var (
+		ctx  context.Context
+		bc   bapi.Client
+		kc   *kubernetes.Clientset
+		ic   Interface
+		dir  string
+		path string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		bc, err = backend.NewClient(config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bc.Clean()).To(Succeed())
+		if config.Spec.DatastoreType == "kubernetes" {
+			kc = bc.(*k8s.KubeClient).ClientSet
+		}
+		Expect(applyNode(bc, kc, host1, nil)).To(Succeed())
+		Expect(applyNode(bc, kc, host2, nil)).To(Succeed())
+
+		// The file allocator stands in for the external IPAM system.  It only hands out the first
+		// two blocks of the pool.
+		dir, err = os.MkdirTemp("", "ipam-external")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, "state.json")
+		data, err := json.Marshal(external.FileState{Ranges: []string{"10.0.0.0/29"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(path, data, 0o600)).To(Succeed())
+
+		pools := &ipPoolAccessor{pools: map[string]pool{
+			poolCIDR: {
+				cidr:         poolCIDR,
+				blockSize:    30,
+				enabled:      true,
+				externalIPAM: &v3.ExternalIPAMSpec{Type: v3.ExternalIPAMTypeFile, Path: path},
+			},
+		}}
+		ic = NewIPAMClient(bc, pools, &fakeReservations{})
+	})
+
+	AfterEach(func() {
+		deleteNode(bc, kc, host1)
+		deleteNode(bc, kc, host2)
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	// allocations returns the blocks that the external system has allocated, mapped to their hosts.
+	allocations := func() map[string]string {
+		var state external.FileState
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(json.Unmarshal(data, &state)).To(Succeed())
+		allocs := map[string]string{}
+		for _, a := range state.Allocations {
+			allocs[a.CIDR] = a.Host
+		}
+		return allocs
+	}
+
+	// blocks returns the blocks in the datastore, mapped to their affinities.
+	blocks := func() map[string]string {
+		kvps, err := bc.List(ctx, model.BlockListOptions{}, "")
+		Expect(err).NotTo(HaveOccurred())
+		blocks := map[string]string{}
+		for _, kvp := range kvps.KVPairs {
+			b := kvp.Value.(*model.AllocationBlock)
+			aff := ""
+			if b.Affinity != nil {
+				aff = *b.Affinity
+			}
+			blocks[b.CIDR.String()] = aff
+		}
+		return blocks
+	}
+
+	// claimWithoutExternalIPAM claims the block for the host as if the pool were not using
+	// the external IPAM system, to set up blocks that the external system doesn't know about.
+	claimWithoutExternalIPAM := func(cidr, host string) {
+		pools := &ipPoolAccessor{pools: map[string]pool{
+			poolCIDR: {cidr: poolCIDR, blockSize: 30, enabled: true},
+		}}
+		c := NewIPAMClient(bc, pools, &fakeReservations{})
+		_, _, err := c.ClaimAffinity(ctx, cnet.MustParseCIDR(cidr), host)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("should claim blocks that the external system allocates and release them when they are deleted", func() {
+		handle := "external-handle"
+		v4, _, err := ic.AutoAssign(ctx, AutoAssignArgs{Num4: 1, Hostname: host1, HandleID: &handle, IntendedUse: v3.IPPoolAllowedUseWorkload})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v4.IPs).To(HaveLen(1))
+		block := cnet.MustParseCIDR("10.0.0.0/30")
+		Expect(block.Contains(v4.IPs[0].IP)).To(BeTrue())
+		Expect(allocations()).To(Equal(map[string]string{"10.0.0.0/30": host1}))
+		Expect(blocks()).To(Equal(map[string]string{"10.0.0.0/30": "host:" + host1}))
+
+		// Releasing the address and then the affinity deletes the block, which releases it to
+		// the external system.
+		Expect(ic.ReleaseByHandle(ctx, handle)).To(Succeed())
+		Expect(ic.ReleaseAffinity(ctx, cnet.MustParseCIDR("10.0.0.0/30"), host1, true)).To(Succeed())
+		Expect(blocks()).To(BeEmpty())
+		Expect(allocations()).To(BeEmpty())
+	})
+
+	It("should confirm explicitly claimed blocks with the external system", func() {
+		claimed, failed, err := ic.ClaimAffinity(ctx, cnet.MustParseCIDR("10.0.0.4/30"), host1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).To(HaveLen(1))
+		Expect(failed).To(BeEmpty())
+		Expect(allocations()).To(Equal(map[string]string{"10.0.0.4/30": host1}))
+		Expect(blocks()).To(Equal(map[string]string{"10.0.0.4/30": "host:" + host1}))
+
+		Expect(ic.ReleaseHostAffinities(ctx, host1, true)).To(Succeed())
+		Expect(blocks()).To(BeEmpty())
+		Expect(allocations()).To(BeEmpty())
+	})
+
+	It("should refuse to claim a block that the external system doesn't allocate", func() {
+		_, _, err := ic.ClaimAffinity(ctx, cnet.MustParseCIDR("10.0.0.8/30"), host1)
+		Expect(err).To(HaveOccurred())
+		Expect(blocks()).To(BeEmpty())
+		Expect(allocations()).To(BeEmpty())
+	})
+
+	It("should release the allocation if the block is claimed by another host", func() {
+		claimWithoutExternalIPAM("10.0.0.0/30", host2)
+
+		claimed, failed, err := ic.ClaimAffinity(ctx, cnet.MustParseCIDR("10.0.0.0/30"), host1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).To(BeEmpty())
+		Expect(failed).To(HaveLen(1))
+		Expect(blocks()).To(Equal(map[string]string{"10.0.0.0/30": "host:" + host2}))
+		Expect(allocations()).To(BeEmpty())
+	})
+
+	It("should release the allocation if auto-assignment can't use the block", func() {
+		// The external system doesn't know that host2 already holds the first block, so it offers
+		// that block to host1.
+		claimWithoutExternalIPAM("10.0.0.0/30", host2)
+
+		v4, _, _ := ic.AutoAssign(ctx, AutoAssignArgs{Num4: 1, Hostname: host1, IntendedUse: v3.IPPoolAllowedUseWorkload})
+		if v4 != nil {
+			Expect(v4.IPs).To(BeEmpty())
+		}
+		Expect(blocks()).To(Equal(map[string]string{"10.0.0.0/30": "host:" + host2}))
+		Expect(allocations()).To(BeEmpty())
+	})
+
+	It("should not release a block that is claimed by the host", func() {
+		v4, _, err := ic.AutoAssign(ctx, AutoAssignArgs{Num4: 1, Hostname: host1, IntendedUse: v3.IPPoolAllowedUseWorkload})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v4.IPs).To(HaveLen(1))
+
+		ipam := ic.(*ipamClient)
+		ipam.blockReaderWriter.releaseUnclaimedExternalBlock(ctx, host1, cnet.MustParseCIDR("10.0.0.0/30"))
+		Expect(allocations()).To(Equal(map[string]string{"10.0.0.0/30": host1}))
+	})
+})
+
+var _ = Describe("External allocator cache", func() {
+	pool := func(endpoint string) *v3.IPPool {
+		return &v3.IPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool1"},
+			Spec: v3.IPPoolSpec{
+				CIDR:         "10.0.0.0/24",
+				ExternalIPAM: &v3.ExternalIPAMSpec{Type: v3.ExternalIPAMTypeGRPC, Endpoint: endpoint},
+			},
+		}
+	}
+
+	It("should reuse the allocator until the pool's configuration changes, then close it", func() {
+		allocators := newExternalAllocators()
+		a1, release, err := allocators.get(pool("unix:///tmp/ipam-1.sock"))
+		Expect(err).NotTo(HaveOccurred())
+		release()
+		a2, release, err := allocators.get(pool("unix:///tmp/ipam-1.sock"))
+		Expect(err).NotTo(HaveOccurred())
+		release()
+		Expect(a2).To(BeIdenticalTo(a1))
+
+		a3, release, err := allocators.get(pool("unix:///tmp/ipam-2.sock"))
+		Expect(err).NotTo(HaveOccurred())
+		release()
+		Expect(a3).NotTo(BeIdenticalTo(a1))
+		_, err = a1.AllocateBlock(context.Background(), external.BlockRequest{Pool: "pool1"})
+		Expect(err).To(MatchError(ContainSubstring("closing")))
+	})
+
+	It("should close an uncached allocator when it is released", func() {
+		var allocators *externalAllocators
+		a, release, err := allocators.get(pool("unix:///tmp/ipam-1.sock"))
+		Expect(err).NotTo(HaveOccurred())
+		release()
+		_, err = a.AllocateBlock(context.Background(), external.BlockRequest{Pool: "pool1"})
+		Expect(err).To(MatchError(ContainSubstring("closing")))
+	})
+})
@@ -0,0 +1,519 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/conversion"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/net"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector"
+)
+
+// ErrQuotaExceeded is returned when assigning the requested addresses would take the workload's
+// namespace or node over one of the quotas of every IP pool that could be used.
+var ErrQuotaExceeded = errors.New("IP address quota exceeded")
+
+// appliedQuota is an IP pool quota that applies to an address assignment.
+type appliedQuota struct {
+	pool  *v3.IPPool
+	cidr  net.IPNet
+	quota v3.IPPoolQuota
+
+	// namespace or node is the namespace or node that the quota limits, depending on the quota's
+	// selector.
+	namespace string
+	node      string
+}
+
+// holder describes the namespace or node that the quota limits, for use in messages.
+func (q appliedQuota) holder() string {
+	if q.namespace != "" {
+		return fmt.Sprintf("namespace %q", q.namespace)
+	}
+	return fmt.Sprintf("node %q", q.node)
+}
+
+// inUse returns the number of addresses that the quota's namespace or node holds, given the
+// holdings in the quota's pool.
+func (q appliedQuota) inUse(h quotaHoldings) int {
+	if q.namespace != "" {
+		return h.namespaces[q.namespace]
+	}
+	return h.nodes[q.node]
+}
+
+// quotaHoldings is the number of workload addresses that each namespace and each node holds in an
+// IP pool.
+type quotaHoldings struct {
+	namespaces map[string]int
+	nodes      map[string]int
+}
+
+// holdingsInPool counts the workload addresses in the given blocks that belong to the pool.
+// Tunnel addresses are not counted against node quotas, and addresses that don't belong to a pod
+// are not counted against namespace quotas.
+func holdingsInPool(blocks []*model.KVPair, pool net.IPNet) quotaHoldings {
+	h := quotaHoldings{namespaces: map[string]int{}, nodes: map[string]int{}}
+	for _, kvp := range blocks {
+		b := kvp.Value.(*model.AllocationBlock)
+		if !b.CIDR.IsNetOverlap(pool.IPNet) {
+			continue
+		}
+		for _, idx := range b.Allocations {
+			if idx == nil || *idx >= len(b.Attributes) {
+				continue
+			}
+			attrs := b.Attributes[*idx].AttrSecondary
+			if ns := attrs[AttributeNamespace]; ns != "" {
+				h.namespaces[ns]++
+			}
+			if node := attrs[AttributeNode]; node != "" && attrs[AttributeType] == "" {
+				h.nodes[node]++
+			}
+		}
+	}
+	return h
+}
+
+// quotaLabels looks up, and caches, the labels that quota selectors are evaluated against.
+type quotaLabels struct {
+	client     ipamClient
+	namespaces map[string]map[string]string
+	nodes      map[string]map[string]string
+}
+
+func newQuotaLabels(c ipamClient) *quotaLabels {
+	return &quotaLabels{
+		client:     c,
+		namespaces: map[string]map[string]string{},
+		nodes:      map[string]map[string]string{},
+	}
+}
+
+// namespace returns the labels of the namespace, read from the profile that represents it.  If
+// there is no such profile, only the name label is returned.
+func (l *quotaLabels) namespace(ctx context.Context, ns string) (map[string]string, error) {
+	if labels, ok := l.namespaces[ns]; ok {
+		return labels, nil
+	}
+	labels := map[string]string{conversion.NameLabel: ns}
+	kvp, err := l.client.client.Get(ctx, model.ResourceKey{Kind: v3.KindProfile, Name: conversion.NamespaceProfileNamePrefix + ns}, "")
+	if err == nil {
+		for k, v := range kvp.Value.(*v3.Profile).Spec.LabelsToApply {
+			if strings.HasPrefix(k, conversion.NamespaceLabelPrefix) {
+				labels[strings.TrimPrefix(k, conversion.NamespaceLabelPrefix)] = v
+			}
+		}
+	} else if _, ok := err.(cerrors.ErrorResourceDoesNotExist); !ok {
+		return nil, err
+	}
+	l.namespaces[ns] = labels
+	return labels, nil
+}
+
+// node returns the labels of the node.  A node that no longer exists has no labels.
+func (l *quotaLabels) node(ctx context.Context, node string) (map[string]string, error) {
+	if labels, ok := l.nodes[node]; ok {
+		return labels, nil
+	}
+	var labels map[string]string
+	kvp, err := l.client.client.Get(ctx, model.ResourceKey{Kind: libapiv3.KindNode, Name: node}, "")
+	if err == nil {
+		labels = kvp.Value.(*libapiv3.Node).Labels
+	} else if _, ok := err.(cerrors.ErrorResourceDoesNotExist); !ok {
+		return nil, err
+	}
+	l.nodes[node] = labels
+	return labels, nil
+}
+
+// selects returns whether the quota selects the namespace or node.
+func (l *quotaLabels) selects(ctx context.Context, q v3.IPPoolQuota, namespace, node string) (bool, error) {
+	var sel string
+	var labels map[string]string
+	var err error
+	if q.NamespaceSelector != "" {
+		if namespace == "" {
+			return false, nil
+		}
+		sel = q.NamespaceSelector
+		labels, err = l.namespace(ctx, namespace)
+	} else {
+		if node == "" {
+			return false, nil
+		}
+		sel = q.NodeSelector
+		labels, err = l.node(ctx, node)
+	}
+	if err != nil {
+		return false, err
+	}
+	parsed, err := selector.Parse(sel)
+	if err != nil {
+		return false, err
+	}
+	return parsed.Evaluate(labels), nil
+}
+
+// applicableQuotas returns the quotas of the given pools that apply to an assignment of workload
+// addresses with the given attributes.
+func (c ipamClient) applicableQuotas(ctx context.Context, pools []v3.IPPool, host string, attrs map[string]string) ([]appliedQuota, error) {
+	labels := newQuotaLabels(c)
+	namespace := attrs[AttributeNamespace]
+	var applied []appliedQuota
+	for i := range pools {
+		if len(pools[i].Spec.Quotas) == 0 {
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(pools[i].Spec.CIDR)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range pools[i].Spec.Quotas {
+			ok, err := labels.selects(ctx, q, namespace, host)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate quota of IP pool %s: %w", pools[i].Name, err)
+			}
+			if !ok {
+				continue
+			}
+			aq := appliedQuota{pool: &pools[i], cidr: *cidr, quota: q}
+			if q.NamespaceSelector != "" {
+				aq.namespace = namespace
+			} else {
+				aq.node = host
+			}
+			applied = append(applied, aq)
+		}
+	}
+	return applied, nil
+}
+
+// exceededQuotas returns the CIDRs of the pools in which assigning extra addresses would exceed
+// one of the quotas, given the current holdings in each pool, along with a description of each
+// quota that would be exceeded.
+func exceededQuotas(quotas []appliedQuota, holdings map[string]quotaHoldings, extra int) (map[string]bool, []string) {
+	pools := map[string]bool{}
+	var reasons []string
+	for _, q := range quotas {
+		inUse := q.inUse(holdings[q.pool.Spec.CIDR])
+		if inUse+extra > q.quota.MaxAddresses {
+			pools[q.pool.Spec.CIDR] = true
+			reasons = append(reasons, fmt.Sprintf("%s holds %d of its %d addresses in IP pool %s",
+				q.holder(), inUse, q.quota.MaxAddresses, q.pool.Name))
+		}
+	}
+	return pools, reasons
+}
+
+// quotaHoldings counts the workload addresses held in each of the pools that the quotas belong to,
+// keyed by the pool's CIDR.
+func (c ipamClient) quotaHoldings(ctx context.Context, quotas []appliedQuota, version int) (map[string]quotaHoldings, error) {
+	blocks, err := c.client.List(ctx, model.BlockListOptions{IPVersion: version}, "")
+	if err != nil {
+		return nil, err
+	}
+	holdings := map[string]quotaHoldings{}
+	for _, q := range quotas {
+		if _, ok := holdings[q.pool.Spec.CIDR]; !ok {
+			holdings[q.pool.Spec.CIDR] = holdingsInPool(blocks.KVPairs, q.cidr)
+		}
+	}
+	return holdings, nil
+}
+
+// filterPoolsByQuota removes the pools, and the host's affine blocks in them, in which assigning num
+// addresses would exceed one of the quotas.  It returns ErrQuotaExceeded if no pools remain.
+func (c ipamClient) filterPoolsByQuota(ctx context.Context, quotas []appliedQuota, pools []v3.IPPool, affBlocks []net.IPNet, num, version int) ([]v3.IPPool, []net.IPNet, error) {
+	holdings, err := c.quotaHoldings(ctx, quotas, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	exceeded, reasons := exceededQuotas(quotas, holdings, num)
+	if len(exceeded) == 0 {
+		return pools, affBlocks, nil
+	}
+
+	var allowed []v3.IPPool
+	for _, p := range pools {
+		if !exceeded[p.Spec.CIDR] {
+			allowed = append(allowed, p)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrQuotaExceeded, strings.Join(reasons, "; "))
+	}
+	log.WithField("quotas", reasons).Info("Not assigning from IP pools with exceeded quotas")
+	affBlocks, _, err = filterBlocksByPools(affBlocks, allowed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return allowed, affBlocks, nil
+}
+
+// quotaReservationGracePeriod is how long a reservation counts against its quota while its address
+// hasn't appeared in its block.  It comfortably outlasts the block write of an assignment that is
+// still in progress, so the addresses of assignments that failed are only counted until it expires.
+const quotaReservationGracePeriod = 2 * time.Minute
+
+// counterName returns the name of the IPAMQuotaCounter that serializes assignments under the quota.
+// Quotas of the same pool that select the same namespace or node share a counter.
+func (q appliedQuota) counterName() string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", q.pool.Spec.CIDR, q.namespace, q.node)))
+	return "quota-" + hex.EncodeToString(h[:])[:32]
+}
+
+// reserveQuotas reserves the addresses that are about to be written to the given block under each
+// quota of the block's pool.  Each namespace or node that a quota limits has a counter that is
+// updated with compare-and-swap, so concurrent assignments under the same quota are serialized:
+// the assignment that loses the race re-reads the counter, and so sees the addresses that the
+// winner reserved, before checking the quota again.  It returns ErrQuotaExceeded if the addresses
+// would take the namespace or node over a quota, in which case nothing is left reserved.
+func (c ipamClient) reserveQuotas(ctx context.Context, quotas []appliedQuota, block net.IPNet, ips []net.IPNet) error {
+	// Check each counter against the tightest of the quotas that share it.
+	var names []string
+	tightest := map[string]appliedQuota{}
+	for _, q := range quotas {
+		if !q.cidr.IsNetOverlap(block.IPNet) {
+			continue
+		}
+		name := q.counterName()
+		cur, ok := tightest[name]
+		if !ok {
+			names = append(names, name)
+		}
+		if !ok || q.quota.MaxAddresses < cur.quota.MaxAddresses {
+			tightest[name] = q
+		}
+	}
+
+	for i, name := range names {
+		if err := c.reserveQuota(ctx, tightest[name], name, block.Version(), ips); err != nil {
+			for _, reserved := range names[:i] {
+				c.releaseQuotaReservation(ctx, tightest[reserved], reserved, ips)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// reserveQuota adds the addresses to the reservations of the quota's counter, provided that they
+// don't take the quota's namespace or node over the quota.  The namespace or node's usage is the
+// addresses it holds in the pool's blocks, plus the addresses reserved by assignments that haven't
+// yet been written to their blocks.
+func (c ipamClient) reserveQuota(ctx context.Context, q appliedQuota, name string, version int, ips []net.IPNet) error {
+	logCtx := log.WithFields(log.Fields{"pool": q.pool.Name, "quota": q.holder(), "counter": name})
+	key := model.ResourceKey{Kind: libapiv3.KindIPAMQuotaCounter, Name: name}
+	for i := 0; i < datastoreRetries; i++ {
+		// Read the counter before the blocks, so that an address that it reserves is either in
+		// the blocks that we list, or is still in its reservation.
+		kvp, err := c.client.Get(ctx, key, "")
+		if _, ok := err.(cerrors.ErrorResourceDoesNotExist); ok {
+			counter := libapiv3.NewIPAMQuotaCounter()
+			counter.Name = name
+			counter.Spec = libapiv3.IPAMQuotaCounterSpec{Pool: q.pool.Name, Namespace: q.namespace, Node: q.node}
+			kvp = &model.KVPair{Key: key, Value: counter}
+		} else if err != nil {
+			return err
+		}
+		counter := kvp.Value.(*libapiv3.IPAMQuotaCounter)
+
+		blocks, err := c.client.List(ctx, model.BlockListOptions{IPVersion: version}, "")
+		if err != nil {
+			return err
+		}
+		allocated := map[string]bool{}
+		for _, b := range blocks.KVPairs {
+			block := allocationBlock{b.Value.(*model.AllocationBlock)}
+			if !block.CIDR.IsNetOverlap(q.cidr.IPNet) {
+				continue
+			}
+			for _, ip := range block.inUseIPs() {
+				allocated[ip] = true
+			}
+		}
+
+		// Drop the reservations of addresses that have been written to their blocks, and so are
+		// counted in the holdings, and of those that have expired.
+		now := time.Now()
+		var pending []libapiv3.IPAMQuotaReservation
+		for _, r := range counter.Spec.Reservations {
+			if allocated[r.IP] || now.Sub(r.Time.Time) > quotaReservationGracePeriod {
+				continue
+			}
+			pending = append(pending, r)
+		}
+		inUse := q.inUse(holdingsInPool(blocks.KVPairs, q.cidr)) + len(pending)
+		if inUse+len(ips) > q.quota.MaxAddresses {
+			return fmt.Errorf("%w: %s holds %d of its %d addresses in IP pool %s",
+				ErrQuotaExceeded, q.holder(), inUse, q.quota.MaxAddresses, q.pool.Name)
+		}
+		for _, ip := range ips {
+			pending = append(pending, libapiv3.IPAMQuotaReservation{IP: ip.IP.String(), Time: metav1.NewTime(now)})
+		}
+		counter.Spec.Reservations = pending
+
+		if kvp.Revision == "" {
+			_, err = c.client.Create(ctx, kvp)
+		} else {
+			_, err = c.client.Update(ctx, kvp)
+		}
+		switch err.(type) {
+		case nil:
+			logCtx.Debugf("Reserved %d addresses under quota", len(ips))
+			return nil
+		case cerrors.ErrorResourceUpdateConflict, cerrors.ErrorResourceAlreadyExists:
+			logCtx.WithError(err).Debug("CAS error reserving addresses under quota - retry")
+			continue
+		default:
+			return err
+		}
+	}
+	return errors.New("Max retries hit - excessive concurrent IPAM requests")
+}
+
+// releaseQuotaReservations removes the reservations of addresses that weren't assigned after all,
+// so that they stop counting against the quotas straight away.  It is best effort: reservations
+// that aren't removed expire.
+func (c ipamClient) releaseQuotaReservations(ctx context.Context, quotas []appliedQuota, ips []net.IPNet) {
+	released := map[string]bool{}
+	for _, q := range quotas {
+		name := q.counterName()
+		if released[name] {
+			continue
+		}
+		for _, ip := range ips {
+			if q.cidr.Contains(ip.IP) {
+				c.releaseQuotaReservation(ctx, q, name, ips)
+				released[name] = true
+				break
+			}
+		}
+	}
+}
+
+func (c ipamClient) releaseQuotaReservation(ctx context.Context, q appliedQuota, name string, ips []net.IPNet) {
+	logCtx := log.WithFields(log.Fields{"pool": q.pool.Name, "quota": q.holder(), "counter": name})
+	drop := map[string]bool{}
+	for _, ip := range ips {
+		drop[ip.IP.String()] = true
+	}
+	key := model.ResourceKey{Kind: libapiv3.KindIPAMQuotaCounter, Name: name}
+	for i := 0; i < datastoreRetries; i++ {
+		kvp, err := c.client.Get(ctx, key, "")
+		if err != nil {
+			logCtx.WithError(err).Warn("Failed to get IPAM quota counter to release reservations")
+			return
+		}
+		counter := kvp.Value.(*libapiv3.IPAMQuotaCounter)
+		var kept []libapiv3.IPAMQuotaReservation
+		for _, r := range counter.Spec.Reservations {
+			if !drop[r.IP] {
+				kept = append(kept, r)
+			}
+		}
+		if len(kept) == len(counter.Spec.Reservations) {
+			return
+		}
+		counter.Spec.Reservations = kept
+		_, err = c.client.Update(ctx, kvp)
+		if _, ok := err.(cerrors.ErrorResourceUpdateConflict); ok {
+			continue
+		} else if err != nil {
+			logCtx.WithError(err).Warn("Failed to release reservations under quota")
+		}
+		return
+	}
+	logCtx.Warn("Max retries hit releasing reservations under quota, leaving them to expire")
+}
+
+// abandonOverQuota releases the addresses that an assignment has already claimed when a further
+// block would take the namespace or node over a quota, so that the assignment is all or nothing.
+// The assignment of the addresses hasn't been recorded in the IPAM event log, so neither is their
+// release.  It returns the quota error.
+func (c ipamClient) abandonOverQuota(ctx context.Context, ia *IPAMAssignments, quotas []appliedQuota, err error) error {
+	if len(ia.IPs) == 0 {
+		return err
+	}
+	log.WithError(err).Warnf("Releasing %d addresses assigned before the quota was exceeded", len(ia.IPs))
+	var opts []ReleaseOptions
+	for _, ip := range ia.IPs {
+		opts = append(opts, ReleaseOptions{Address: ip.IP.String()})
+	}
+	if _, relErr := c.releaseIPs(ctx, false, opts...); relErr != nil {
+		log.WithError(relErr).Error("Failed to release IPs assigned over quota")
+	}
+	c.releaseQuotaReservations(ctx, quotas, ia.IPs)
+	ia.IPs = nil
+	return err
+}
+
+// quotaUtilization reports the addresses held in the pool by each namespace and node that one of
+// the pool's quotas selects.
+func (c ipamClient) quotaUtilization(ctx context.Context, labels *quotaLabels, pool v3.IPPool, blocks []*model.KVPair) ([]QuotaUtilization, error) {
+	_, cidr, err := net.ParseCIDR(pool.Spec.CIDR)
+	if err != nil {
+		return nil, err
+	}
+	h := holdingsInPool(blocks, *cidr)
+	var usage []QuotaUtilization
+	for _, q := range pool.Spec.Quotas {
+		holders := h.nodes
+		if q.NamespaceSelector != "" {
+			holders = h.namespaces
+		}
+		var names []string
+		for name := range holders {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			namespace, node := "", name
+			if q.NamespaceSelector != "" {
+				namespace, node = name, ""
+			}
+			ok, err := labels.selects(ctx, q, namespace, node)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				usage = append(usage, QuotaUtilization{
+					Quota:     q,
+					Namespace: namespace,
+					Node:      node,
+					InUse:     holders[name],
+				})
+			}
+		}
+	}
+	return usage, nil
+}
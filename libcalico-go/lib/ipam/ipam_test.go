@@ -21,6 +21,7 @@ import (
 	"net"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -59,6 +60,8 @@ type pool struct {
 	enabled      bool
 	nodeSelector string
 	allowedUses  []v3.IPPoolAllowedUse
+	quotas       []v3.IPPoolQuota
+	externalIPAM *v3.ExternalIPAMSpec
 }
 
 func (i *ipPoolAccessor) GetEnabledPools(ipVersion int) ([]v3.IPPool, error) {
@@ -87,6 +90,8 @@ func (i *ipPoolAccessor) getPools(sorted []string, ipVersion int, caller string)
 				CIDR:         p,
 				NodeSelector: i.pools[p].nodeSelector,
 				AllowedUses:  i.pools[p].allowedUses,
+				Quotas:       i.pools[p].quotas,
+				ExternalIPAM: i.pools[p].externalIPAM,
 			}}
 			if len(pool.Spec.AllowedUses) == 0 {
 				pool.Spec.AllowedUses = []v3.IPPoolAllowedUse{v3.IPPoolAllowedUseWorkload, v3.IPPoolAllowedUseTunnel}
@@ -2141,6 +2146,175 @@ var _ = testutils.E2eDatastoreDescribe("IPAM tests", testutils.DatastoreAll, fun
 		})
 	})
 
+	Describe("IPAM AutoAssign with ip pool quotas", func() {
+		host := "host"
+		pool1 := cnet.MustParseNetwork("10.0.0.0/24")
+		pool2 := cnet.MustParseNetwork("20.0.0.0/24")
+
+		BeforeEach(func() {
+			bc.Clean()
+			deleteAllPools()
+			applyNode(bc, kc, host, map[string]string{"foo": "bar"})
+		})
+
+		assign := func(namespace, pod string) (*IPAMAssignments, error) {
+			handle := namespace + "." + pod
+			v4ia, _, err := ic.AutoAssign(context.Background(), AutoAssignArgs{
+				IntendedUse: v3.IPPoolAllowedUseWorkload,
+				Num4:        1,
+				Hostname:    host,
+				HandleID:    &handle,
+				Attrs: map[string]string{
+					AttributeNode:      host,
+					AttributeNamespace: namespace,
+					AttributePod:       pod,
+				},
+			})
+			return v4ia, err
+		}
+
+		It("should refuse to assign more addresses to a namespace than its quota allows", func() {
+			applyPoolWithQuotas(pool1.String(), []v3.IPPoolQuota{
+				{NamespaceSelector: "projectcalico.org/name == 'ns1'", MaxAddresses: 2},
+			})
+
+			for _, pod := range []string{"pod1", "pod2"} {
+				_, err := assign("ns1", pod)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			v4ia, err := assign("ns1", "pod3")
+			Expect(errors.Is(err, ErrQuotaExceeded)).To(BeTrue(), fmt.Sprintf("unexpected error: %v", err))
+			Expect(err.Error()).To(ContainSubstring(`namespace "ns1" holds 2 of its 2 addresses`))
+			Expect(v4ia).To(BeNil())
+
+			// Other namespaces aren't affected.
+			_, err = assign("ns2", "pod1")
+			Expect(err).NotTo(HaveOccurred())
+
+			usage, err := ic.GetUtilization(context.Background(), GetUtilizationArgs{Pools: []string{pool1.String()}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(HaveLen(1))
+			Expect(usage[0].Quotas).To(Equal([]QuotaUtilization{{
+				Quota:     v3.IPPoolQuota{NamespaceSelector: "projectcalico.org/name == 'ns1'", MaxAddresses: 2},
+				Namespace: "ns1",
+				InUse:     2,
+			}}))
+		})
+
+		It("should assign from another pool once a node's quota is used up", func() {
+			applyPoolWithQuotas(pool1.String(), []v3.IPPoolQuota{
+				{NodeSelector: `foo == "bar"`, MaxAddresses: 1},
+			})
+			applyPool(pool2.String(), true, "")
+
+			v4ia, err := assign("ns1", "pod1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v4ia.IPs).To(HaveLen(1))
+			Expect(pool1.IPNet.Contains(v4ia.IPs[0].IP)).To(BeTrue())
+
+			v4ia, err = assign("ns1", "pod2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v4ia.IPs).To(HaveLen(1))
+			Expect(pool2.IPNet.Contains(v4ia.IPs[0].IP)).To(BeTrue())
+		})
+
+		It("should not count tunnel addresses against a node's quota", func() {
+			applyPoolWithQuotas(pool1.String(), []v3.IPPoolQuota{
+				{NodeSelector: "all()", MaxAddresses: 1},
+			})
+
+			v4ia, _, err := ic.AutoAssign(context.Background(), AutoAssignArgs{
+				IntendedUse: v3.IPPoolAllowedUseTunnel,
+				Num4:        1,
+				Hostname:    host,
+				Attrs:       map[string]string{AttributeNode: host, AttributeType: AttributeTypeVXLAN},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v4ia.IPs).To(HaveLen(1))
+
+			_, err = assign("ns1", "pod1")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = assign("ns1", "pod2")
+			Expect(errors.Is(err, ErrQuotaExceeded)).To(BeTrue(), fmt.Sprintf("unexpected error: %v", err))
+		})
+
+		It("should count addresses reserved by assignments in progress against the quota", func() {
+			applyPoolWithQuotas(pool1.String(), []v3.IPPoolQuota{
+				{NamespaceSelector: "projectcalico.org/name == 'ns1'", MaxAddresses: 1},
+			})
+			pools, err := ipPools.GetEnabledPools(4)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Another assignment has reserved an address, but hasn't yet written it to its block.
+			name := appliedQuota{pool: &pools[0], namespace: "ns1"}.counterName()
+			counter := libapiv3.NewIPAMQuotaCounter()
+			counter.Name = name
+			counter.Spec = libapiv3.IPAMQuotaCounterSpec{
+				Namespace:    "ns1",
+				Reservations: []libapiv3.IPAMQuotaReservation{{IP: "10.0.0.200", Time: metav1.Now()}},
+			}
+			key := model.ResourceKey{Kind: libapiv3.KindIPAMQuotaCounter, Name: name}
+			_, err = bc.Create(context.Background(), &model.KVPair{Key: key, Value: counter})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = assign("ns1", "pod1")
+			Expect(errors.Is(err, ErrQuotaExceeded)).To(BeTrue(), fmt.Sprintf("unexpected error: %v", err))
+			Expect(err.Error()).To(ContainSubstring(`namespace "ns1" holds 1 of its 1 addresses`))
+
+			// Once the reservation has expired, it no longer counts.
+			kvp, err := bc.Get(context.Background(), key, "")
+			Expect(err).NotTo(HaveOccurred())
+			reservations := kvp.Value.(*libapiv3.IPAMQuotaCounter).Spec.Reservations
+			Expect(reservations).To(HaveLen(1))
+			reservations[0].Time = metav1.NewTime(time.Now().Add(-2 * quotaReservationGracePeriod))
+			_, err = bc.Update(context.Background(), kvp)
+			Expect(err).NotTo(HaveOccurred())
+
+			v4ia, err := assign("ns1", "pod1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v4ia.IPs).To(HaveLen(1))
+
+			// The expired reservation has been replaced by that of the new address.
+			kvp, err = bc.Get(context.Background(), key, "")
+			Expect(err).NotTo(HaveOccurred())
+			reservations = kvp.Value.(*libapiv3.IPAMQuotaCounter).Spec.Reservations
+			Expect(reservations).To(HaveLen(1))
+			Expect(reservations[0].IP).To(Equal(v4ia.IPs[0].IP.String()))
+		})
+
+		It("should not let concurrent assignments take a namespace over its quota", func() {
+			applyPoolWithQuotas(pool1.String(), []v3.IPPoolQuota{
+				{NamespaceSelector: "projectcalico.org/name == 'ns1'", MaxAddresses: 3},
+			})
+
+			var wg sync.WaitGroup
+			errs := make([]error, 8)
+			for i := range errs {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					_, errs[i] = assign("ns1", fmt.Sprintf("pod%d", i))
+				}(i)
+			}
+			wg.Wait()
+
+			assigned := 0
+			for _, err := range errs {
+				if err == nil {
+					assigned++
+				}
+			}
+			Expect(assigned).To(BeNumerically("<=", 3))
+
+			usage, err := ic.GetUtilization(context.Background(), GetUtilizationArgs{Pools: []string{pool1.String()}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(HaveLen(1))
+			Expect(usage[0].Quotas).To(HaveLen(1))
+			Expect(usage[0].Quotas[0].InUse).To(Equal(assigned))
+		})
+	})
+
 	Describe("IPAM AutoAssign from different pools - multi", func() {
 		host := "host-a"
 		pool1 := cnet.MustParseNetwork("10.0.0.0/24")
@@ -3397,6 +3571,10 @@ func applyPoolWithUses(cidr string, enabled bool, nodeSelector string, uses []v3
 	ipPools.pools[cidr] = pool{enabled: enabled, nodeSelector: nodeSelector, allowedUses: uses}
 }
 
+func applyPoolWithQuotas(cidr string, quotas []v3.IPPoolQuota) {
+	ipPools.pools[cidr] = pool{enabled: true, quotas: quotas}
+}
+
 func applyPoolWithBlockSize(cidr string, enabled bool, nodeSelector string, blockSize int) {
 	ipPools.pools[cidr] = pool{enabled: enabled, nodeSelector: nodeSelector, blockSize: blockSize}
 }
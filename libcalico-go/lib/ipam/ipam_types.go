@@ -105,6 +105,11 @@ type IPAMConfig struct {
 	// If non-zero, MaxBlocksPerHost specifies the max number of blocks that may
 	// be affine to a node.
 	MaxBlocksPerHost int
+
+	// When EventLog is true, each address assignment and release is recorded as an
+	// IPAMEvent, so that the history of an address can be queried later.  The default
+	// value is false.
+	EventLog bool
 }
 
 // GetUtilizationArgs defines the set of arguments for requesting IP utilization.
@@ -136,6 +141,23 @@ type PoolUtilization struct {
 
 	// Utilization for each of this pool's blocks.
 	Blocks []BlockUtilization
+
+	// Addresses held by each namespace or node that is subject to one of this pool's quotas.
+	Quotas []QuotaUtilization
+}
+
+// QuotaUtilization reports the addresses held in an IP pool by a namespace or node that is subject
+// to one of the pool's quotas.
+type QuotaUtilization struct {
+	// The quota.
+	Quota v3.IPPoolQuota
+
+	// The namespace or node that holds the addresses, depending on the quota's selector.
+	Namespace string
+	Node      string
+
+	// Number of addresses held.
+	InUse int
 }
 
 type HostReservedAttr struct {
@@ -0,0 +1,490 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package migrate moves running Kubernetes workloads from one IP pool to another.
+//
+// The source pool is disabled so that no new addresses are allocated from it, and the pods with
+// addresses in it are found from the attributes of the IPAM allocations in its blocks.  The pods
+// are then evicted in batches using the eviction API, so that PodDisruptionBudgets are respected,
+// and each batch must be replaced by pods with addresses from the destination pool before the next
+// batch is evicted.  Finally, the source pool's empty blocks are released.
+//
+// A migration holds no state outside the datastore, so an interrupted migration is resumed by
+// running it again.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+const (
+	// DefaultBatchSize is the default number of pods that are evicted at a time.
+	DefaultBatchSize = 1
+
+	// DefaultPodTimeout is the default time to wait for an evicted pod to be replaced.
+	DefaultPodTimeout = 10 * time.Minute
+
+	defaultPollInterval = 2 * time.Second
+)
+
+// Config configures a migration.
+type Config struct {
+	// From and To are the CIDRs of the source and destination IP pools.
+	From string
+	To   string
+
+	// BatchSize is the number of pods that are evicted at a time.
+	BatchSize int
+
+	// PodTimeout is how long to wait for an evicted pod to be replaced, including any time spent
+	// waiting for a PodDisruptionBudget to allow the eviction.
+	PodTimeout time.Duration
+
+	// PollInterval is how often evictions are retried and replacement pods are checked for.
+	PollInterval time.Duration
+}
+
+// Progress describes how far a migration has got.
+type Progress struct {
+	// TotalPods is the number of pods with addresses in the source pool when the migration
+	// started.
+	TotalPods int
+	// MigratedPods is the number of pods that have been replaced by pods with addresses in the
+	// destination pool.
+	MigratedPods int
+	// SkippedPods is the number of pods that are not evicted because they have no controller
+	// to recreate them.
+	SkippedPods int
+	// ReleasedBlocks is the number of empty blocks released from the source pool.
+	ReleasedBlocks int
+	// Message describes what the migration is doing.
+	Message string
+}
+
+// Migrator moves the pods with addresses in one IP pool to another.
+type Migrator struct {
+	calicoClient client.Interface
+	k8sClient    kubernetes.Interface
+	cfg          Config
+
+	from, to *cnet.IPNet
+}
+
+// New returns a Migrator for the given configuration.  The Kubernetes client is used to evict pods
+// and to watch for their replacements.
+func New(c client.Interface, k kubernetes.Interface, cfg Config) (*Migrator, error) {
+	_, from, err := cnet.ParseCIDR(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source pool CIDR %q: %w", cfg.From, err)
+	}
+	_, to, err := cnet.ParseCIDR(cfg.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination pool CIDR %q: %w", cfg.To, err)
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.PodTimeout <= 0 {
+		cfg.PodTimeout = DefaultPodTimeout
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	return &Migrator{calicoClient: c, k8sClient: k, cfg: cfg, from: from, to: to}, nil
+}
+
+// podRef identifies a pod with an address in the source pool.
+type podRef struct {
+	namespace string
+	name      string
+}
+
+func (p podRef) String() string {
+	return p.namespace + "/" + p.name
+}
+
+// Run performs the migration, calling report each time progress is made.  It returns the final
+// progress, and an error if the migration could not be completed.  Pods that are not replaced
+// within the pod timeout stop the migration, so that a problem with the destination pool does not
+// take down every workload.
+func (m *Migrator) Run(ctx context.Context, report func(Progress)) (Progress, error) {
+	var p Progress
+	update := func(msg string, args ...interface{}) {
+		p.Message = fmt.Sprintf(msg, args...)
+		log.Info(p.Message)
+		if report != nil {
+			report(p)
+		}
+	}
+
+	if err := m.disableSourcePool(ctx); err != nil {
+		return p, err
+	}
+
+	pods, err := m.podsInSourcePool(ctx)
+	if err != nil {
+		return p, err
+	}
+
+	// Work out which pods can be evicted.  A pod without a controller would not be recreated, so
+	// it is left for the user to move.
+	var toEvict []*corev1.Pod
+	for _, ref := range pods {
+		pod, err := m.k8sClient.CoreV1().Pods(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			// The pod has gone, its address will be garbage collected.
+			continue
+		} else if err != nil {
+			return p, fmt.Errorf("failed to get pod %s: %w", ref, err)
+		}
+		if metav1.GetControllerOf(pod) == nil {
+			log.WithField("pod", ref).Warn("Not evicting pod with no controller, it must be moved manually")
+			p.SkippedPods++
+			continue
+		}
+		toEvict = append(toEvict, pod)
+	}
+	p.TotalPods = len(toEvict) + p.SkippedPods
+	update("Found %d pods with addresses in pool %s", p.TotalPods, m.from)
+
+	for start := 0; start < len(toEvict); start += m.cfg.BatchSize {
+		end := start + m.cfg.BatchSize
+		if end > len(toEvict) {
+			end = len(toEvict)
+		}
+		if err := m.migrateBatch(ctx, toEvict[start:end]); err != nil {
+			update("Migration stopped: %v", err)
+			return p, err
+		}
+		p.MigratedPods += end - start
+		update("Migrated %d of %d pods to pool %s", p.MigratedPods, p.TotalPods, m.to)
+	}
+
+	p.ReleasedBlocks, err = m.releaseEmptyBlocks(ctx)
+	if err != nil {
+		update("Failed to release empty blocks: %v", err)
+		return p, err
+	}
+	if p.SkippedPods > 0 {
+		update("Migrated %d pods, %d pods with no controller must be moved manually", p.MigratedPods, p.SkippedPods)
+	} else {
+		update("Migrated %d pods and released %d empty blocks", p.MigratedPods, p.ReleasedBlocks)
+	}
+	return p, nil
+}
+
+// disableSourcePool checks the pools and disables the source pool, so that evicted pods are
+// recreated with addresses from another pool.
+func (m *Migrator) disableSourcePool(ctx context.Context) error {
+	pools, err := m.calicoClient.IPPools().List(ctx, options.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list IP pools: %w", err)
+	}
+	var from, to *apiv3.IPPool
+	for i := range pools.Items {
+		_, cidr, err := cnet.ParseCIDR(pools.Items[i].Spec.CIDR)
+		if err != nil {
+			continue
+		}
+		switch cidr.String() {
+		case m.from.String():
+			from = &pools.Items[i]
+		case m.to.String():
+			to = &pools.Items[i]
+		}
+	}
+	if from == nil {
+		return fmt.Errorf("no IP pool with CIDR %s", m.from)
+	}
+	if to == nil {
+		return fmt.Errorf("no IP pool with CIDR %s", m.to)
+	}
+	if to.Spec.Disabled {
+		return fmt.Errorf("destination IP pool %s is disabled", to.Name)
+	}
+	if m.from.Version() != m.to.Version() {
+		return fmt.Errorf("IP pools %s and %s are of different IP versions", from.Name, to.Name)
+	}
+	if from.Spec.Disabled {
+		return nil
+	}
+
+	log.WithField("pool", from.Name).Info("Disabling source IP pool")
+	from.Spec.Disabled = true
+	if _, err := m.calicoClient.IPPools().Update(ctx, from, options.SetOptions{}); err != nil {
+		return fmt.Errorf("failed to disable IP pool %s: %w", from.Name, err)
+	}
+	return nil
+}
+
+// sourceBlocks returns the IPAM blocks in the source pool.
+func (m *Migrator) sourceBlocks(ctx context.Context) ([]*model.AllocationBlock, error) {
+	type accessor interface {
+		Backend() bapi.Client
+	}
+	kvps, err := m.calicoClient.(accessor).Backend().List(ctx, model.BlockListOptions{IPVersion: m.from.Version()}, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IPAM blocks: %w", err)
+	}
+	var blocks []*model.AllocationBlock
+	for _, kvp := range kvps.KVPairs {
+		b := kvp.Value.(*model.AllocationBlock)
+		if m.from.Contains(b.CIDR.IP) {
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks, nil
+}
+
+// podsInSourcePool returns the pods that have addresses allocated from the source pool, according
+// to the attributes of their IPAM handles.
+func (m *Migrator) podsInSourcePool(ctx context.Context) ([]podRef, error) {
+	blocks, err := m.sourceBlocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[podRef]bool{}
+	var pods []podRef
+	for _, b := range blocks {
+		for _, idx := range b.Allocations {
+			if idx == nil || *idx >= len(b.Attributes) {
+				continue
+			}
+			attrs := b.Attributes[*idx].AttrSecondary
+			ref := podRef{namespace: attrs[ipam.AttributeNamespace], name: attrs[ipam.AttributePod]}
+			if ref.namespace == "" || ref.name == "" || seen[ref] {
+				// Not a pod, e.g. a tunnel address, which calico/node moves itself.
+				continue
+			}
+			seen[ref] = true
+			pods = append(pods, ref)
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].String() < pods[j].String()
+	})
+	return pods, nil
+}
+
+// migrateBatch evicts the given pods and waits for each of them to be replaced by a ready pod with
+// an address in the destination pool.
+func (m *Migrator) migrateBatch(ctx context.Context, pods []*corev1.Pod) error {
+	deadline := time.Now().Add(m.cfg.PodTimeout)
+
+	// Note the pods that each controller already has, so that their replacements can be told apart.
+	existing := map[types.UID]bool{}
+	for _, pod := range pods {
+		siblings, err := m.podsOf(ctx, pod)
+		if err != nil {
+			return err
+		}
+		for _, s := range siblings {
+			existing[s.UID] = true
+		}
+	}
+
+	for _, pod := range pods {
+		if err := m.evict(ctx, pod, deadline); err != nil {
+			return err
+		}
+	}
+
+	claimed := map[types.UID]bool{}
+	for _, pod := range pods {
+		if err := m.waitForReplacement(ctx, pod, deadline, existing, claimed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evict evicts the pod, retrying for as long as a PodDisruptionBudget prevents it.
+func (m *Migrator) evict(ctx context.Context, pod *corev1.Pod, deadline time.Time) error {
+	logCtx := log.WithFields(log.Fields{"namespace": pod.Namespace, "pod": pod.Name})
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		DeleteOptions: &metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{UID: &pod.UID},
+		},
+	}
+	err := wait.PollUntilContextTimeout(ctx, m.cfg.PollInterval, time.Until(deadline), true, func(ctx context.Context) (bool, error) {
+		err := m.k8sClient.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction)
+		switch {
+		case err == nil:
+			logCtx.Info("Evicted pod")
+			return true, nil
+		case kerrors.IsNotFound(err) || kerrors.IsConflict(err):
+			// The pod has already gone.
+			return true, nil
+		case kerrors.IsTooManyRequests(err):
+			logCtx.WithError(err).Debug("Eviction not allowed yet, retrying")
+			return false, nil
+		default:
+			return false, err
+		}
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timed out evicting pod %s/%s, check its PodDisruptionBudgets", pod.Namespace, pod.Name)
+	} else if err != nil {
+		return fmt.Errorf("failed to evict pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// podsOf returns the pods in the pod's namespace that have the same controller.
+func (m *Migrator) podsOf(ctx context.Context, pod *corev1.Pod) ([]corev1.Pod, error) {
+	owner := metav1.GetControllerOf(pod)
+	list, err := m.k8sClient.CoreV1().Pods(pod.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", pod.Namespace, err)
+	}
+	var pods []corev1.Pod
+	for _, p := range list.Items {
+		if o := metav1.GetControllerOf(&p); o != nil && o.UID == owner.UID {
+			pods = append(pods, p)
+		}
+	}
+	return pods, nil
+}
+
+// waitForReplacement waits for the evicted pod to be deleted and for its controller to have a new,
+// ready, pod with an address in the destination pool.  Replacements are claimed so that each one
+// only counts for a single evicted pod.
+func (m *Migrator) waitForReplacement(ctx context.Context, pod *corev1.Pod, deadline time.Time, existing, claimed map[types.UID]bool) error {
+	var reason string
+	err := wait.PollUntilContextTimeout(ctx, m.cfg.PollInterval, time.Until(deadline), true, func(ctx context.Context) (bool, error) {
+		current, err := m.k8sClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err == nil && current.UID == pod.UID {
+			reason = "the pod has not been deleted"
+			return false, nil
+		} else if err != nil && !kerrors.IsNotFound(err) {
+			return false, err
+		}
+
+		siblings, err := m.podsOf(ctx, pod)
+		if err != nil {
+			return false, err
+		}
+		reason = "no replacement pod has been created"
+		for i := range siblings {
+			s := &siblings[i]
+			if existing[s.UID] || claimed[s.UID] {
+				continue
+			}
+			if ok, why := m.isMigrated(s); !ok {
+				reason = fmt.Sprintf("replacement pod %s %s", s.Name, why)
+				continue
+			}
+			claimed[s.UID] = true
+			return true, nil
+		}
+		return false, nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timed out waiting for pod %s/%s to be replaced: %s", pod.Namespace, pod.Name, reason)
+	}
+	return err
+}
+
+// isMigrated returns whether the pod is ready with addresses only from the destination pool, and
+// if not, why.
+func (m *Migrator) isMigrated(pod *corev1.Pod) (bool, string) {
+	if len(pod.Status.PodIPs) == 0 {
+		return false, "has no address"
+	}
+	inDest := false
+	for _, ip := range pod.Status.PodIPs {
+		addr := cnet.ParseIP(ip.IP)
+		if addr == nil {
+			continue
+		}
+		if m.from.Contains(addr.IP) {
+			return false, fmt.Sprintf("has address %s in the source pool", ip.IP)
+		}
+		if m.to.Contains(addr.IP) {
+			inDest = true
+		}
+	}
+	if !inDest {
+		return false, "has no address in the destination pool"
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+			return true, ""
+		}
+	}
+	return false, "is not ready"
+}
+
+// releaseEmptyBlocks releases the empty blocks in the source pool and returns how many it released.
+func (m *Migrator) releaseEmptyBlocks(ctx context.Context) (int, error) {
+	blocks, err := m.sourceBlocks(ctx)
+	if err != nil {
+		return 0, err
+	}
+	released := 0
+	var errs []error
+	for _, b := range blocks {
+		if b.Affinity == nil || !isEmpty(b) {
+			continue
+		}
+		// The block is only deleted if it is still empty.
+		if err := m.calicoClient.IPAM().ReleaseBlockAffinity(ctx, b, true); err != nil {
+			errs = append(errs, fmt.Errorf("block %s: %w", b.CIDR, err))
+			continue
+		}
+		released++
+	}
+	return released, errors.Join(errs...)
+}
+
+// isEmpty returns whether the block has no addresses in use, ignoring the addresses that IPAM
+// reserves for Windows nodes.
+func isEmpty(b *model.AllocationBlock) bool {
+	for _, idx := range b.Allocations {
+		if idx == nil {
+			continue
+		}
+		if *idx < len(b.Attributes) {
+			if h := b.Attributes[*idx].AttrPrimary; h != nil && strings.ToLower(*h) == ipam.WindowsReservedHandle {
+				continue
+			}
+		}
+		return false
+	}
+	return true
+}
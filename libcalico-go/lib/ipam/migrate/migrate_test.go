@@ -0,0 +1,264 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam/migrate"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+// fakeCalico implements the parts of the Calico client that the migrator uses.
+type fakeCalico struct {
+	client.Interface
+	pools    []apiv3.IPPool
+	blocks   []*model.AllocationBlock
+	released []string
+}
+
+func (f *fakeCalico) IPPools() client.IPPoolInterface {
+	return fakePools{f: f}
+}
+
+func (f *fakeCalico) IPAM() ipam.Interface {
+	return fakeIPAM{f: f}
+}
+
+func (f *fakeCalico) Backend() bapi.Client {
+	return fakeBackend{f: f}
+}
+
+// release removes the pod's allocation from the blocks, as the CNI plugin would when it is deleted.
+func (f *fakeCalico) release(namespace, name string) {
+	for _, b := range f.blocks {
+		for ord, idx := range b.Allocations {
+			if idx == nil {
+				continue
+			}
+			attrs := b.Attributes[*idx].AttrSecondary
+			if attrs[ipam.AttributeNamespace] == namespace && attrs[ipam.AttributePod] == name {
+				b.Allocations[ord] = nil
+			}
+		}
+	}
+}
+
+type fakePools struct {
+	client.IPPoolInterface
+	f *fakeCalico
+}
+
+func (p fakePools) List(ctx context.Context, opts options.ListOptions) (*apiv3.IPPoolList, error) {
+	return &apiv3.IPPoolList{Items: p.f.pools}, nil
+}
+
+func (p fakePools) Update(ctx context.Context, res *apiv3.IPPool, opts options.SetOptions) (*apiv3.IPPool, error) {
+	for i := range p.f.pools {
+		if p.f.pools[i].Name == res.Name {
+			p.f.pools[i] = *res
+		}
+	}
+	return res, nil
+}
+
+type fakeBackend struct {
+	bapi.Client
+	f *fakeCalico
+}
+
+func (b fakeBackend) List(ctx context.Context, list model.ListInterface, revision string) (*model.KVPairList, error) {
+	kvps := &model.KVPairList{}
+	for _, blk := range b.f.blocks {
+		kvps.KVPairs = append(kvps.KVPairs, &model.KVPair{Key: model.BlockKey{CIDR: blk.CIDR}, Value: blk})
+	}
+	return kvps, nil
+}
+
+type fakeIPAM struct {
+	ipam.Interface
+	f *fakeCalico
+}
+
+func (i fakeIPAM) ReleaseBlockAffinity(ctx context.Context, b *model.AllocationBlock, mustBeEmpty bool) error {
+	i.f.released = append(i.f.released, b.CIDR.String())
+	return nil
+}
+
+func pool(name, cidr string) apiv3.IPPool {
+	return apiv3.IPPool{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: apiv3.IPPoolSpec{CIDR: cidr}}
+}
+
+// block returns a /30 block with an address allocated to each of the given pods.
+func block(cidr string, pods ...string) *model.AllocationBlock {
+	affinity := "host:node1"
+	b := &model.AllocationBlock{
+		CIDR:        cnet.MustParseCIDR(cidr),
+		Affinity:    &affinity,
+		Allocations: make([]*int, 4),
+	}
+	for ord, name := range pods {
+		idx := ord
+		b.Allocations[ord] = &idx
+		handle := "k8s-pod-network." + name
+		b.Attributes = append(b.Attributes, model.AllocationAttribute{
+			AttrPrimary: &handle,
+			AttrSecondary: map[string]string{
+				ipam.AttributeNamespace: "default",
+				ipam.AttributePod:       name,
+				ipam.AttributeNode:      "node1",
+			},
+		})
+	}
+	return b
+}
+
+func pod(name, ip string, owner *metav1.OwnerReference) *corev1.Pod {
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+		Status: corev1.PodStatus{
+			PodIPs:     []corev1.PodIP{{IP: ip}},
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	if owner != nil {
+		p.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return p
+}
+
+var _ = Describe("IP pool migration", func() {
+	var (
+		ctx        context.Context
+		calico     *fakeCalico
+		k8s        *fake.Clientset
+		owner      *metav1.OwnerReference
+		evictions  []string
+		nextIP     string
+		refuseNext int
+	)
+
+	podsGVR := corev1.SchemeGroupVersion.WithResource("pods")
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		controller := true
+		owner = &metav1.OwnerReference{Kind: "ReplicaSet", Name: "rs1", UID: "rs1", Controller: &controller}
+		calico = &fakeCalico{
+			pools: []apiv3.IPPool{pool("old", "10.0.0.0/24"), pool("new", "10.1.0.0/24")},
+			blocks: []*model.AllocationBlock{
+				block("10.0.0.0/30", "a", "b"),
+				block("10.0.0.4/30", "bare"),
+			},
+		}
+		k8s = fake.NewSimpleClientset(
+			pod("a", "10.0.0.0", owner),
+			pod("b", "10.0.0.1", owner),
+			pod("bare", "10.0.0.4", nil),
+		)
+		evictions = nil
+		nextIP = "10.1.0.1"
+		refuseNext = 0
+
+		// Evicting a pod deletes it, and its controller replaces it with a pod with the next IP.
+		k8s.PrependReactor("create", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "eviction" {
+				return false, nil, nil
+			}
+			if refuseNext > 0 {
+				refuseNext--
+				return true, nil, kerrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+			}
+			ev := action.(ktesting.CreateAction).GetObject().(*policyv1.Eviction)
+			evictions = append(evictions, ev.Name)
+			Expect(k8s.Tracker().Delete(podsGVR, ev.Namespace, ev.Name)).To(Succeed())
+			calico.release(ev.Namespace, ev.Name)
+			Expect(k8s.Tracker().Add(pod(ev.Name+"-new", nextIP, owner))).To(Succeed())
+			return true, nil, nil
+		})
+	})
+
+	newMigrator := func(batchSize int) *migrate.Migrator {
+		m, err := migrate.New(calico, k8s, migrate.Config{
+			From:         "10.0.0.0/24",
+			To:           "10.1.0.0/24",
+			BatchSize:    batchSize,
+			PodTimeout:   time.Second,
+			PollInterval: 10 * time.Millisecond,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return m
+	}
+
+	It("should move pods with controllers to the destination pool", func() {
+		var reports []migrate.Progress
+		p, err := newMigrator(2).Run(ctx, func(p migrate.Progress) {
+			reports = append(reports, p)
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evictions).To(Equal([]string{"a", "b"}))
+		Expect(calico.pools[0].Spec.Disabled).To(BeTrue())
+		Expect(calico.released).To(Equal([]string{"10.0.0.0/30"}))
+
+		p.Message = ""
+		Expect(p).To(Equal(migrate.Progress{TotalPods: 3, MigratedPods: 2, SkippedPods: 1, ReleasedBlocks: 1}))
+		Expect(reports).To(HaveLen(3))
+		Expect(reports[1].MigratedPods).To(Equal(2))
+	})
+
+	It("should retry evictions that are refused by a disruption budget", func() {
+		refuseNext = 2
+		p, err := newMigrator(1).Run(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evictions).To(Equal([]string{"a", "b"}))
+		Expect(p.MigratedPods).To(Equal(2))
+	})
+
+	It("should stop if a pod is not replaced with an address in the destination pool", func() {
+		nextIP = "10.2.0.1"
+		p, err := newMigrator(1).Run(ctx, nil)
+		Expect(err).To(MatchError(ContainSubstring("no address in the destination pool")))
+		Expect(evictions).To(Equal([]string{"a"}))
+		Expect(p.MigratedPods).To(BeZero())
+		Expect(calico.released).To(BeEmpty())
+	})
+
+	It("should refuse to migrate to a disabled pool", func() {
+		calico.pools[1].Spec.Disabled = true
+		_, err := newMigrator(1).Run(ctx, nil)
+		Expect(err).To(MatchError(ContainSubstring("is disabled")))
+		Expect(calico.pools[0].Spec.Disabled).To(BeFalse())
+		Expect(evictions).To(BeEmpty())
+	})
+})
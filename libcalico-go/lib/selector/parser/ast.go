@@ -17,6 +17,9 @@ package parser
 import (
 	_ "crypto/sha256" // register hash func
 	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -116,6 +119,16 @@ func (v PrefixVisitor) Visit(n interface{}) {
 		np.LabelName = fmt.Sprintf("%s%s", v.Prefix, np.LabelName)
 	case *LabelNotInSetNode:
 		np.LabelName = fmt.Sprintf("%s%s", v.Prefix, np.LabelName)
+	case *LabelCompareNode:
+		np.LabelName = fmt.Sprintf("%s%s", v.Prefix, np.LabelName)
+	case *LabelMatchesNode:
+		np.LabelName = fmt.Sprintf("%s%s", v.Prefix, np.LabelName)
+	case *LabelEqLabelRefNode:
+		// Only the label of the matched endpoint is prefixed; the reference names a label of
+		// the other endpoint, which the prefix doesn't apply to.
+		np.LabelName = fmt.Sprintf("%s%s", v.Prefix, np.LabelName)
+	case *LabelNeLabelRefNode:
+		np.LabelName = fmt.Sprintf("%s%s", v.Prefix, np.LabelName)
 	default:
 		log.Debug("Node is a no-op")
 	}
@@ -292,6 +305,362 @@ func (node *LabelEndsWithValueNode) collectFragments(fragments []string) []strin
 	return appendLabelOpAndQuotedString(fragments, node.LabelName, " ends with ", node.Value)
 }
 
+// CompareOp is a numeric comparison operator.
+type CompareOp uint8
+
+const (
+	CompareLt CompareOp = iota
+	CompareLe
+	CompareGt
+	CompareGe
+)
+
+func (op CompareOp) String() string {
+	switch op {
+	case CompareLt:
+		return "<"
+	case CompareLe:
+		return "<="
+	case CompareGt:
+		return ">"
+	case CompareGe:
+		return ">="
+	}
+	return fmt.Sprintf("CompareOp(%d)", uint8(op))
+}
+
+// LabelCompareNode compares the numeric value of a label with a number.  It doesn't match if the
+// label is absent, or if its value isn't a plain decimal number, such as "3", "-1" or "2.5".
+type LabelCompareNode struct {
+	LabelName string
+	Op        CompareOp
+	Value     float64
+}
+
+func (node *LabelCompareNode) Evaluate(labels Labels) bool {
+	val, ok := labels.Get(node.LabelName)
+	if !ok {
+		return false
+	}
+	f, ok := parseDecimal(val)
+	if !ok {
+		return false
+	}
+	switch node.Op {
+	case CompareLt:
+		return f < node.Value
+	case CompareLe:
+		return f <= node.Value
+	case CompareGt:
+		return f > node.Value
+	case CompareGe:
+		return f >= node.Value
+	}
+	return false
+}
+
+// parseDecimal parses a plain decimal number: an optional minus sign, digits, and optionally a
+// decimal point followed by more digits.  Unlike strconv.ParseFloat, it doesn't accept exponents,
+// hex, underscores, "Inf" or "NaN".
+func parseDecimal(s string) (float64, bool) {
+	digits := s
+	if strings.HasPrefix(digits, "-") {
+		digits = digits[1:]
+	}
+	intPart, fracPart, hasPoint := strings.Cut(digits, ".")
+	if !isDigits(intPart) || (hasPoint && !isDigits(fracPart)) {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (node *LabelCompareNode) LabelRestrictions() map[string]LabelRestriction {
+	return map[string]LabelRestriction{
+		node.LabelName: {
+			MustBePresent: true,
+		},
+	}
+}
+
+func (node *LabelCompareNode) AcceptVisitor(v Visitor) {
+	v.Visit(node)
+}
+
+func (node *LabelCompareNode) collectFragments(fragments []string) []string {
+	return append(fragments, node.LabelName, " ", node.Op.String(), " ", strconv.FormatFloat(node.Value, 'f', -1, 64))
+}
+
+// LabelMatchesNode matches a label whose whole value matches a regular expression, in RE2
+// syntax.  The expression is compiled once, when the selector is parsed.
+type LabelMatchesNode struct {
+	LabelName string
+	Pattern   string
+
+	regexp *regexp.Regexp
+	// values are the only values that the expression matches, or nil if there are too many to
+	// list.
+	values []string
+}
+
+// NewLabelMatchesNode compiles a regular expression and returns a LabelMatchesNode for it.
+func NewLabelMatchesNode(labelName, pattern string) (*LabelMatchesNode, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+	}
+	return &LabelMatchesNode{
+		LabelName: labelName,
+		Pattern:   pattern,
+		regexp:    re,
+		values:    regexpLiterals(pattern),
+	}, nil
+}
+
+// maxRegexpValues is the largest number of values that regexpValues expands a regular expression
+// to, so that the label index can look up selectors by value.
+const maxRegexpValues = 100
+
+// regexpLiterals returns the values that a regular expression matches, if it only matches a small
+// set of values, such as "prod|staging" or "v[1-3]".  Otherwise, it returns nil.
+func regexpLiterals(pattern string) []string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	values, ok := regexpValues(re.Simplify())
+	if !ok {
+		return nil
+	}
+	return ConvertToStringSetInPlace(values)
+}
+
+func regexpValues(re *syntax.Regexp) ([]string, bool) {
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		return []string{""}, true
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return nil, false
+		}
+		return []string{string(re.Rune)}, true
+	case syntax.OpCharClass:
+		var values []string
+		for i := 0; i < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				if len(values) == maxRegexpValues {
+					return nil, false
+				}
+				values = append(values, string(r))
+			}
+		}
+		return values, true
+	case syntax.OpCapture:
+		return regexpValues(re.Sub[0])
+	case syntax.OpQuest:
+		values, ok := regexpValues(re.Sub[0])
+		if !ok || len(values) == maxRegexpValues {
+			return nil, false
+		}
+		return append(values, ""), true
+	case syntax.OpAlternate:
+		var values []string
+		for _, sub := range re.Sub {
+			subValues, ok := regexpValues(sub)
+			if !ok || len(values)+len(subValues) > maxRegexpValues {
+				return nil, false
+			}
+			values = append(values, subValues...)
+		}
+		return values, true
+	case syntax.OpConcat:
+		values := []string{""}
+		for _, sub := range re.Sub {
+			subValues, ok := regexpValues(sub)
+			if !ok || len(values)*len(subValues) > maxRegexpValues {
+				return nil, false
+			}
+			var product []string
+			for _, v := range values {
+				for _, sv := range subValues {
+					product = append(product, v+sv)
+				}
+			}
+			values = product
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+func (node *LabelMatchesNode) Evaluate(labels Labels) bool {
+	val, ok := labels.Get(node.LabelName)
+	if ok {
+		return node.regexp.MatchString(val)
+	}
+	return false
+}
+
+func (node *LabelMatchesNode) LabelRestrictions() map[string]LabelRestriction {
+	var values []string
+	if node.values != nil {
+		// The restrictions may be modified in place, so copy the values.
+		values = append([]string{}, node.values...)
+	}
+	return map[string]LabelRestriction{
+		node.LabelName: {
+			MustBePresent:       true,
+			MustHaveOneOfValues: values,
+		},
+	}
+}
+
+func (node *LabelMatchesNode) AcceptVisitor(v Visitor) {
+	v.Visit(node)
+}
+
+func (node *LabelMatchesNode) collectFragments(fragments []string) []string {
+	return appendLabelOpAndQuotedString(fragments, node.LabelName, " matches ", node.Pattern)
+}
+
+// LabelEqLabelRefNode matches if the label has the same value as a label of another endpoint,
+// written "label(name)".  In a rule, the other endpoint is the one that the policy applies to.
+// The reference has no value until it is resolved with ResolveLabelRefs; until then, the node
+// never matches.
+type LabelEqLabelRefNode struct {
+	LabelName    string
+	RefLabelName string
+}
+
+func (node *LabelEqLabelRefNode) Evaluate(labels Labels) bool {
+	return false
+}
+
+func (node *LabelEqLabelRefNode) LabelRestrictions() map[string]LabelRestriction {
+	return map[string]LabelRestriction{
+		node.LabelName: {
+			MustBePresent: true,
+		},
+	}
+}
+
+func (node *LabelEqLabelRefNode) AcceptVisitor(v Visitor) {
+	v.Visit(node)
+}
+
+func (node *LabelEqLabelRefNode) collectFragments(fragments []string) []string {
+	return append(fragments, node.LabelName, " == label(", node.RefLabelName, ")")
+}
+
+// LabelNeLabelRefNode is the negation of LabelEqLabelRefNode.  Like LabelEqLabelRefNode, it never
+// matches until it is resolved.
+type LabelNeLabelRefNode struct {
+	LabelName    string
+	RefLabelName string
+}
+
+func (node *LabelNeLabelRefNode) Evaluate(labels Labels) bool {
+	return false
+}
+
+func (node *LabelNeLabelRefNode) LabelRestrictions() map[string]LabelRestriction {
+	return nil
+}
+
+func (node *LabelNeLabelRefNode) AcceptVisitor(v Visitor) {
+	v.Visit(node)
+}
+
+func (node *LabelNeLabelRefNode) collectFragments(fragments []string) []string {
+	return append(fragments, node.LabelName, " != label(", node.RefLabelName, ")")
+}
+
+// LabelRefs returns the names of the labels that the selector refers to with label(), in the
+// order that they first appear.
+func LabelRefs(sel Selector) []string {
+	v := &labelRefVisitor{}
+	sel.AcceptVisitor(v)
+	return v.names
+}
+
+type labelRefVisitor struct {
+	names []string
+}
+
+func (v *labelRefVisitor) Visit(n interface{}) {
+	var name string
+	switch np := n.(type) {
+	case *LabelEqLabelRefNode:
+		name = np.RefLabelName
+	case *LabelNeLabelRefNode:
+		name = np.RefLabelName
+	default:
+		return
+	}
+	for _, seen := range v.names {
+		if seen == name {
+			return
+		}
+	}
+	v.names = append(v.names, name)
+}
+
+// ResolveLabelRefs returns a copy of the selector in which each label(name) reference is replaced
+// by the value of that label in refLabels, so "a == label(b)" becomes "a == 'v'" if refLabels has
+// b=v.  If refLabels doesn't have the label, "a == label(b)" becomes "!all()", which never
+// matches, and "a != label(b)" becomes "all()".
+func ResolveLabelRefs(sel Selector, refLabels Labels) Selector {
+	// Work on a fresh copy of the tree; the nodes are shared with the cached selector.
+	cp, err := Parse(sel.String())
+	if err != nil {
+		log.WithError(err).WithField("selector", sel.String()).Panic("Failed to re-parse selector")
+	}
+	root := cp.(*selectorRoot)
+	root.root = resolveLabelRefs(root.root, refLabels)
+	return root
+}
+
+func resolveLabelRefs(n node, refLabels Labels) node {
+	switch np := n.(type) {
+	case *LabelEqLabelRefNode:
+		if value, ok := refLabels.Get(np.RefLabelName); ok {
+			return &LabelEqValueNode{np.LabelName, value}
+		}
+		return &NotNode{&AllNode{}}
+	case *LabelNeLabelRefNode:
+		if value, ok := refLabels.Get(np.RefLabelName); ok {
+			return &LabelNeValueNode{np.LabelName, value}
+		}
+		return &AllNode{}
+	case *NotNode:
+		np.Operand = resolveLabelRefs(np.Operand, refLabels)
+	case *AndNode:
+		for i, operand := range np.Operands {
+			np.Operands[i] = resolveLabelRefs(operand, refLabels)
+		}
+	case *OrNode:
+		for i, operand := range np.Operands {
+			np.Operands[i] = resolveLabelRefs(operand, refLabels)
+		}
+	}
+	return n
+}
+
 type LabelInSetNode struct {
 	LabelName string
 	Value     StringSet
@@ -50,6 +50,27 @@ var labelRestrictionsTests = []struct {
 		"a": {MustBePresent: true},
 	}},
 	{"a != 'value'", nil},
+	{"a > 2", map[string]LabelRestriction{
+		"a": {MustBePresent: true},
+	}},
+	{"a <= -1.5", map[string]LabelRestriction{
+		"a": {MustBePresent: true},
+	}},
+	{"a matches 'v[0-9]+'", map[string]LabelRestriction{
+		"a": {MustBePresent: true},
+	}},
+	{"a matches 'v2|v1|v2'", map[string]LabelRestriction{
+		"a": {MustBePresent: true, MustHaveOneOfValues: []string{"v1", "v2"}},
+	}},
+	{"a matches '(?i)v1'", map[string]LabelRestriction{
+		"a": {MustBePresent: true},
+	}},
+	{"a matches 'v[1-3](-canary)?'", map[string]LabelRestriction{
+		"a": {MustBePresent: true, MustHaveOneOfValues: []string{"v1", "v1-canary", "v2", "v2-canary", "v3", "v3-canary"}},
+	}},
+	{"a matches '[a-z]+'", map[string]LabelRestriction{
+		"a": {MustBePresent: true},
+	}},
 
 	// AND
 	{"a == 'v1' && a == 'v1'", map[string]LabelRestriction{
@@ -83,6 +104,9 @@ var labelRestrictionsTests = []struct {
 	{"all() && a == 'v1'", map[string]LabelRestriction{
 		"a": {MustBePresent: true, MustHaveOneOfValues: []string{"v1"}},
 	}},
+	{"a matches 'v1|v2' && a matches 'v2|v3'", map[string]LabelRestriction{
+		"a": {MustBePresent: true, MustHaveOneOfValues: []string{"v2"}},
+	}},
 
 	// OR
 	{"a == 'v1' || a == 'v2'", map[string]LabelRestriction{
@@ -161,13 +161,38 @@ func parseOperation(tokens []tokenizer.Token) (sel node, remTokens []tokenizer.T
 			if tokens[2].Kind == tokenizer.TokStringLiteral {
 				sel = &LabelEqValueNode{tokens[0].Value.(string), tokens[2].Value.(string)}
 				remTokens = tokens[3:]
+			} else if tokens[2].Kind == tokenizer.TokLabelRef {
+				sel = &LabelEqLabelRefNode{tokens[0].Value.(string), tokens[2].Value.(string)}
+				remTokens = tokens[3:]
 			} else {
-				err = errors.New("Expected string")
+				err = errors.New("Expected string or label()")
 			}
 		case tokenizer.TokNe:
 			if tokens[2].Kind == tokenizer.TokStringLiteral {
 				sel = &LabelNeValueNode{tokens[0].Value.(string), tokens[2].Value.(string)}
 				remTokens = tokens[3:]
+			} else if tokens[2].Kind == tokenizer.TokLabelRef {
+				sel = &LabelNeLabelRefNode{tokens[0].Value.(string), tokens[2].Value.(string)}
+				remTokens = tokens[3:]
+			} else {
+				err = errors.New("Expected string or label()")
+			}
+		case tokenizer.TokLt, tokenizer.TokLe, tokenizer.TokGt, tokenizer.TokGe:
+			if tokens[2].Kind == tokenizer.TokNumber {
+				value, ok := parseDecimal(tokens[2].Value.(string))
+				if !ok {
+					err = fmt.Errorf("invalid number %v", tokens[2].Value)
+					return
+				}
+				sel = &LabelCompareNode{tokens[0].Value.(string), compareOpForToken(tokens[1]), value}
+				remTokens = tokens[3:]
+			} else {
+				err = errors.New("Expected number")
+			}
+		case tokenizer.TokMatches:
+			if tokens[2].Kind == tokenizer.TokStringLiteral {
+				sel, err = NewLabelMatchesNode(tokens[0].Value.(string), tokens[2].Value.(string))
+				remTokens = tokens[3:]
 			} else {
 				err = errors.New("Expected string")
 			}
@@ -253,3 +278,16 @@ func parseOperation(tokens []tokenizer.Token) (sel node, remTokens []tokenizer.T
 	}
 	return
 }
+
+// compareOpForToken returns the CompareOp for a numeric comparison token.
+func compareOpForToken(t tokenizer.Token) CompareOp {
+	switch t.Kind {
+	case tokenizer.TokLt:
+		return CompareLt
+	case tokenizer.TokLe:
+		return CompareLe
+	case tokenizer.TokGt:
+		return CompareGt
+	}
+	return CompareGe
+}
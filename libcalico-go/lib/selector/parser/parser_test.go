@@ -165,6 +165,50 @@ var selectorTests = []selectorTest{
 	{`a != 'a1' || b == 'b1'`, []map[string]string{{"a": "a1", "b": "b1"}}, []map[string]string{}},
 	{`a != 'a1' || b != 'b1'`, []map[string]string{}, []map[string]string{{"a": "a1", "b": "b1"}}},
 	{`! a == 'a1' || ! b == 'b1'`, []map[string]string{}, []map[string]string{{"a": "a1", "b": "b1"}}},
+
+	// Numeric comparisons.
+	{`tier > 2`,
+		[]map[string]string{
+			{"tier": "3"},
+			{"tier": "2.5"},
+			{"tier": "003"},
+		},
+		[]map[string]string{
+			{},
+			{"tier": "2"},
+			{"tier": "-3"},
+			{"tier": "three"},
+			{"tier": ""},
+			{"tier": "1e3"},
+			{"tier": "0x10"},
+			{"tier": "Inf"},
+			{"tier": "+Inf"},
+			{"tier": "+3"},
+			{"tier": "3."},
+			{"tier": ".5e1"},
+			{"tier": "1_000"},
+			{"tier": " 3"},
+		}},
+	{`tier >= 2`,
+		[]map[string]string{{"tier": "2"}, {"tier": "2.0"}, {"tier": "10"}},
+		[]map[string]string{{}, {"tier": "1.99"}, {"tier": "NaN"}, {"tier": "-2"}}},
+	{`tier < -1.5`,
+		[]map[string]string{{"tier": "-2"}},
+		[]map[string]string{{}, {"tier": "-1.5"}, {"tier": "0"}}},
+	{`tier <= 0 || !has(tier)`,
+		[]map[string]string{{}, {"tier": "0"}, {"tier": "-1"}},
+		[]map[string]string{{"tier": "1"}, {"tier": "x"}}},
+
+	// Regular expressions, which must match the whole value.
+	{`a matches "v[0-9]+"`,
+		[]map[string]string{{"a": "v1"}, {"a": "v123"}},
+		[]map[string]string{{}, {"a": "v"}, {"a": "xv1"}, {"a": "v1x"}}},
+	{`a matches 'prod|staging'`,
+		[]map[string]string{{"a": "prod"}, {"a": "staging"}},
+		[]map[string]string{{}, {"a": "dev"}, {"a": "production"}}},
+	{`!a matches '.*-canary'`,
+		[]map[string]string{{}, {"a": "web"}},
+		[]map[string]string{{"a": "web-canary"}}},
 }
 
 var badSelectors = []string{
@@ -194,6 +238,19 @@ var badSelectors = []string{
 	`a == "b" || %`,   // Unexpected char
 	`a `,              // should be followed by operator
 	`has(foo) &&`,     // should be followed by operator
+	`a > "2"`,         // comparison with a string
+	`a > b`,           // comparison with a label
+	`a > 2.`,          // incomplete number
+	`a < -Inf`,        // comparison with an infinity
+	`a > 0x10`,        // comparison with a hex number
+	`a > 1e3`,         // comparison with an exponent
+	`a >`,             // missing number
+	`a matches "("`,   // invalid regex
+	`a matches b`,     // regex must be a string
+	`label(b) == a`,   // label reference must be on the right
+	`a > label(b)`,    // label reference can't be compared numerically
+	`a in {label(b)}`, // label reference isn't a string
+	`a == label()`,    // label reference needs a label name
 }
 
 var canonicalisationTests = []struct {
@@ -219,6 +276,14 @@ var canonicalisationTests = []struct {
 	{`a in {"d", "a", "b"}`, `a in {"a", "b", "d"}`, ""},
 	{`a in {"z", "x", "y", "a"}`, `a in {"a", "x", "y", "z"}`, ""},
 	{`a in {"z", "z", "x", "y", "x", "a"}`, `a in {"a", "x", "y", "z"}`, ""},
+	{`a>2`, `a > 2`, ""},
+	{`a >= 02.50`, `a >= 2.5`, ""},
+	{`a<-1`, `a < -1`, ""},
+	{`a <= 10000000000000000000000`, `a <= 10000000000000000000000`, ""},
+	{`a matches'v[0-9]+'`, `a matches "v[0-9]+"`, ""},
+	{`a matches '"'`, `a matches '"'`, ""},
+	{`a==label( b )`, `a == label(b)`, ""},
+	{`a!=label(b/c)`, `a != label(b/c)`, ""},
 }
 
 var _ = Describe("Parser", func() {
@@ -334,6 +399,10 @@ var _ = Describe("Visitor", func() {
 		Entry("should visit a NotNode", "!(k == 'v')", "!visited/k == \"v\"", testVisitor),
 		Entry("should visit a LabelInSetNode", "k in {'v'}", "visited/k in {\"v\"}", testVisitor),
 		Entry("should visit a LabelNotInSetNode", "k not in {'v'}", "visited/k not in {\"v\"}", testVisitor),
+		Entry("should visit a LabelCompareNode", "k >= 1", "visited/k >= 1", testVisitor),
+		Entry("should visit a LabelMatchesNode", "k matches 'v.*'", "visited/k matches \"v.*\"", testVisitor),
+		Entry("should visit a LabelEqLabelRefNode", "k == label(r)", "visited/k == label(r)", testVisitor),
+		Entry("should visit a LabelNeLabelRefNode", "k != label(r)", "visited/k != label(r)", testVisitor),
 		Entry("should visit a big complex selector",
 			"!(!(k == 'v' && has(t) || all()) && (a in {'b', 'c'}))",
 			"!(!((visited/k == \"v\" && has(visited/t)) || all()) && visited/a in {\"b\", \"c\"})",
@@ -341,3 +410,50 @@ var _ = Describe("Visitor", func() {
 		),
 	)
 })
+
+var _ = Describe("Label references", func() {
+	It("should list the referenced labels once each, in order", func() {
+		sel, err := parser.Parse("a == label(x) && (b != label(y) || c == label(x)) && d == 'x'")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parser.LabelRefs(sel)).To(Equal([]string{"x", "y"}))
+	})
+
+	It("should return no references for a plain selector", func() {
+		sel, err := parser.Parse("a == 'x'")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parser.LabelRefs(sel)).To(BeEmpty())
+	})
+
+	It("should never match before it is resolved", func() {
+		sel, err := parser.Parse("a == label(x) || a != label(x)")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sel.Evaluate(map[string]string{"a": "1", "x": "1"})).To(BeFalse())
+	})
+
+	DescribeTable("Resolving references",
+		func(in string, refLabels map[string]string, out string) {
+			sel, err := parser.Parse(in)
+			Expect(err).NotTo(HaveOccurred())
+			resolved := parser.ResolveLabelRefs(sel, parser.MapAsLabels(refLabels))
+			Expect(resolved.String()).To(Equal(out))
+			By("leaving the original selector alone")
+			Expect(sel.String()).To(Equal(in))
+		},
+		Entry("equal", "a == label(x)", map[string]string{"x": "1"}, `a == "1"`),
+		Entry("not equal", "a != label(x)", map[string]string{"x": "1"}, `a != "1"`),
+		Entry("equal, missing label", "a == label(x)", map[string]string{}, "!all()"),
+		Entry("not equal, missing label", "a != label(x)", map[string]string{}, "all()"),
+		Entry("nested",
+			"!(a == label(x) && (b != label(y) || has(c)))", map[string]string{"x": "1", "y": "2"},
+			`!(a == "1" && (b != "2" || has(c)))`),
+	)
+
+	It("should match the resolved selector per referenced value", func() {
+		sel, err := parser.Parse("tenant == label(tenant)")
+		Expect(err).NotTo(HaveOccurred())
+		red := parser.ResolveLabelRefs(sel, parser.MapAsLabels{"tenant": "red"})
+		Expect(red.Evaluate(map[string]string{"tenant": "red"})).To(BeTrue())
+		Expect(red.Evaluate(map[string]string{"tenant": "blue"})).To(BeFalse())
+		Expect(red.Evaluate(map[string]string{})).To(BeFalse())
+	})
+})
@@ -47,6 +47,13 @@ const (
 	TokAnd
 	TokOr
 	TokGlobal
+	TokLt
+	TokLe
+	TokGt
+	TokGe
+	TokNumber
+	TokMatches
+	TokLabelRef
 	TokEOF
 )
 
@@ -68,6 +75,8 @@ const (
 	notInExpr       = `not\s*in\b`
 	inExpr          = `in\b`
 	globalExpr      = `global\(\s*\)`
+	labelRefExpr    = `label\(\s*(` + LabelKeyMatcher + `)\s*\)`
+	numberExpr      = `-?[0-9]+(\.[0-9]+)?`
 )
 
 var (
@@ -75,11 +84,14 @@ var (
 	containsRegex   = regexp.MustCompile(`^contains`)
 	startsWithRegex = regexp.MustCompile(`^starts\s*with`)
 	endsWithRegex   = regexp.MustCompile(`^ends\s*with`)
+	matchesRegex    = regexp.MustCompile(`^matches\b`)
 	hasRegex        = regexp.MustCompile("^" + hasExpr)
 	allRegex        = regexp.MustCompile("^" + allExpr)
 	notInRegex      = regexp.MustCompile("^" + notInExpr)
 	inRegex         = regexp.MustCompile("^" + inExpr)
 	globalRegex     = regexp.MustCompile("^" + globalExpr)
+	numberRegex     = regexp.MustCompile("^" + numberExpr)
+	labelRefRegex   = regexp.MustCompile("^" + labelRefExpr)
 )
 
 // Tokenize transforms string to token slice
@@ -147,6 +159,22 @@ func Tokenize(input string) (tokens []Token, err error) {
 				tokens = append(tokens, Token{TokNot, nil})
 				input = input[1:]
 			}
+		case '<':
+			if len(input) > 1 && input[1] == '=' {
+				tokens = append(tokens, Token{TokLe, nil})
+				input = input[2:]
+			} else {
+				tokens = append(tokens, Token{TokLt, nil})
+				input = input[1:]
+			}
+		case '>':
+			if len(input) > 1 && input[1] == '=' {
+				tokens = append(tokens, Token{TokGe, nil})
+				input = input[2:]
+			} else {
+				tokens = append(tokens, Token{TokGt, nil})
+				input = input[1:]
+			}
 		case '&':
 			if len(input) > 1 && input[1] == '&' {
 				tokens = append(tokens, Token{TokAnd, nil})
@@ -163,7 +191,17 @@ func Tokenize(input string) (tokens []Token, err error) {
 			}
 		default:
 			// Handle less-simple cases with regex matches.  We've already stripped any whitespace.
-			if lastTokKind == TokLabel {
+			if isComparison(lastTokKind) {
+				// A numeric comparison must be followed by a number, which would otherwise look
+				// like a label.
+				if idxs := numberRegex.FindStringIndex(input); idxs != nil {
+					tokens = append(tokens, Token{TokNumber, input[:idxs[1]]})
+					input = input[idxs[1]:]
+				} else {
+					err = errors.New("expected a number after comparison operator")
+					return
+				}
+			} else if lastTokKind == TokLabel {
 				// If we just saw a label, look for a contains/starts with/ends with operator instead of another label.
 				if idxs := containsRegex.FindStringIndex(input); idxs != nil {
 					// Found "all"
@@ -177,6 +215,10 @@ func Tokenize(input string) (tokens []Token, err error) {
 					// Found "all"
 					tokens = append(tokens, Token{TokEndsWith, nil})
 					input = input[idxs[1]:]
+				} else if idxs := matchesRegex.FindStringIndex(input); idxs != nil {
+					// Found "matches"
+					tokens = append(tokens, Token{TokMatches, nil})
+					input = input[idxs[1]:]
 				} else if idxs := notInRegex.FindStringIndex(input); idxs != nil {
 					// Found "not in"
 					tokens = append(tokens, Token{TokNotIn, nil})
@@ -206,6 +248,11 @@ func Tokenize(input string) (tokens []Token, err error) {
 				// Found "global"
 				tokens = append(tokens, Token{TokGlobal, nil})
 				input = input[idxs[1]:]
+			} else if idxs := labelRefRegex.FindStringSubmatchIndex(input); idxs != nil {
+				// Found "label(labelName)"
+				labelName := input[idxs[2]:idxs[3]]
+				tokens = append(tokens, Token{TokLabelRef, labelName})
+				input = input[idxs[1]:]
 			} else if idxs := identifierRegex.FindStringIndex(input); idxs != nil {
 				// Found "label"
 				endIndex := idxs[1]
@@ -224,3 +271,12 @@ func Tokenize(input string) (tokens []Token, err error) {
 		}
 	}
 }
+
+// isComparison returns true if a token is one of the numeric comparison operators.
+func isComparison(kind tokenKind) bool {
+	switch kind {
+	case TokLt, TokLe, TokGt, TokGe:
+		return true
+	}
+	return false
+}
@@ -215,6 +215,48 @@ var tokenTests = []struct {
 		{tokenizer.TokAll, nil},
 		{tokenizer.TokEOF, nil},
 	}},
+	{`a > 2 && b<=-1.5 && c>=3 && d <0`, []tokenizer.Token{
+		{tokenizer.TokLabel, "a"},
+		{tokenizer.TokGt, nil},
+		{tokenizer.TokNumber, "2"},
+		{tokenizer.TokAnd, nil},
+		{tokenizer.TokLabel, "b"},
+		{tokenizer.TokLe, nil},
+		{tokenizer.TokNumber, "-1.5"},
+		{tokenizer.TokAnd, nil},
+		{tokenizer.TokLabel, "c"},
+		{tokenizer.TokGe, nil},
+		{tokenizer.TokNumber, "3"},
+		{tokenizer.TokAnd, nil},
+		{tokenizer.TokLabel, "d"},
+		{tokenizer.TokLt, nil},
+		{tokenizer.TokNumber, "0"},
+		{tokenizer.TokEOF, nil},
+	}},
+	{`a > b`, nil},
+	{`matches matches "v.*"`, []tokenizer.Token{
+		{tokenizer.TokLabel, "matches"},
+		{tokenizer.TokMatches, nil},
+		{tokenizer.TokStringLiteral, "v.*"},
+		{tokenizer.TokEOF, nil},
+	}},
+	{`a matchesb`, nil},
+	{`label == "a"`, []tokenizer.Token{
+		{tokenizer.TokLabel, "label"},
+		{tokenizer.TokEq, nil},
+		{tokenizer.TokStringLiteral, "a"},
+		{tokenizer.TokEOF, nil},
+	}},
+	{`a == label( b/c ) || label != label(label)`, []tokenizer.Token{
+		{tokenizer.TokLabel, "a"},
+		{tokenizer.TokEq, nil},
+		{tokenizer.TokLabelRef, "b/c"},
+		{tokenizer.TokOr, nil},
+		{tokenizer.TokLabel, "label"},
+		{tokenizer.TokNe, nil},
+		{tokenizer.TokLabelRef, "label"},
+		{tokenizer.TokEOF, nil},
+	}},
 }
 
 var _ = Describe("Token", func() {
@@ -31,7 +31,7 @@ import (
 	"github.com/projectcalico/calico/libcalico-go/lib/errors"
 	calinet "github.com/projectcalico/calico/libcalico-go/lib/net"
 	"github.com/projectcalico/calico/libcalico-go/lib/scope"
-	"github.com/projectcalico/calico/libcalico-go/lib/selector"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector/parser"
 	"github.com/projectcalico/calico/libcalico-go/lib/selector/tokenizer"
 	v3 "github.com/projectcalico/calico/libcalico-go/lib/validator/v3"
 )
@@ -206,11 +206,19 @@ func validateSelector(fl validator.FieldLevel) bool {
 	log.Debugf("Validate selector: %s", s)
 
 	// We use the selector parser to validate a selector string.
-	_, err := selector.Parse(s)
+	sel, err := parser.Parse(s)
 	if err != nil {
 		log.Debugf("Selector %#v was invalid: %v", s, err)
 		return false
 	}
+
+	// Only the selectors in a rule can refer to the labels of the endpoint that the policy
+	// applies to.
+	if len(parser.LabelRefs(sel)) > 0 && (fl.Parent().Type() != reflect.TypeOf(model.Rule{}) ||
+		strings.HasSuffix(fl.StructFieldName(), "ServiceAccountSelector")) {
+		log.Debugf("Selector %#v uses label() outside a rule's source or destination", s)
+		return false
+	}
 	return true
 }
 
@@ -193,6 +193,20 @@ func init() {
 			DstSelector:                    "projectcalico.org/serviceaccount in {\"summary\"}",
 			OriginalDstServiceAccountNames: []string{"summary"},
 		}, true),
+		Entry("should accept a label reference in a source selector (m)", model.Rule{
+			SrcSelector:                  "(pcns.tenant == label(pcns.tenant)) && (app == label(app))",
+			OriginalSrcSelector:          "app == label(app)",
+			OriginalSrcNamespaceSelector: "tenant == label(pcns.tenant)",
+		}, true),
+		Entry("should accept a label reference in a not destination selector (m)", model.Rule{
+			NotDstSelector: "app != label(app)",
+		}, true),
+		Entry("should reject a label reference in a service account selector (m)", model.Rule{
+			OriginalSrcServiceAccountSelector: "app == label(app)",
+		}, false),
+		Entry("should reject a label reference in a policy selector (m)", model.Policy{
+			Selector: "app == label(app)",
+		}, false),
 		Entry("should reject original source selector with error (m)", model.Rule{
 			OriginalSrcSelector: "not a selector",
 		}, false),
@@ -15,12 +15,14 @@
 package v3
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/go-playground/validator.v9"
@@ -37,6 +39,7 @@ import (
 	"github.com/projectcalico/calico/libcalico-go/lib/names"
 	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
 	"github.com/projectcalico/calico/libcalico-go/lib/selector"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector/parser"
 	"github.com/projectcalico/calico/libcalico-go/lib/set"
 )
 
@@ -52,6 +55,9 @@ const (
 	routeTableRangeMaxTables uint32 = 0xffff
 
 	globalSelector = "global()"
+
+	// Maximum length of a regular expression in a selector's "matches" operator.
+	selectorRegexMaxLen = 1024
 )
 
 var (
@@ -111,6 +117,9 @@ var (
 	number                  = regexp.MustCompile(`(\d+)`)
 	IPv4PortFormat          = regexp.MustCompile(`^(\d+).(\d+).(\d+).(\d+):(\d+)$`)
 	IPv6PortFormat          = regexp.MustCompile(`^\[[0-9a-fA-F:.]+\]:(\d+)$`)
+	httpHeaderNameRegex     = regexp.MustCompile("^:?[a-zA-Z0-9!#$%&'*+.^_`|~-]+$")
+	grpcServiceRegex        = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*$`)
+	grpcMethodRegex         = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
 	reasonString            = "Reason: "
 	poolUnstictCIDR         = "IP pool CIDR is not strictly masked"
 	overlapsV4LinkLocal     = "IP pool range overlaps with IPv4 Link Local range 169.254.0.0/16"
@@ -151,6 +160,23 @@ func Validate(current interface{}) error {
 	return nil
 }
 
+// selectorExtensionsKey is the context key under which validation records the selector fields that
+// use the selector extensions.
+type selectorExtensionsKey struct{}
+
+// ValidateAndFindSelectorExtensions validates the supplied structure in the same way as Validate,
+// and also returns the selector fields that use the numeric comparison, "matches" or label()
+// operators.  Older versions of Felix can't parse those operators, so the client uses this to stop
+// them being written before every node has been upgraded.
+func ValidateAndFindSelectorExtensions(current interface{}) ([]errors.ErroredField, error) {
+	var extensions []errors.ErroredField
+	ctx := context.WithValue(context.Background(), selectorExtensionsKey{}, &extensions)
+	if err := validate.StructCtx(ctx, current); err != nil {
+		return nil, convertError(err)
+	}
+	return extensions, nil
+}
+
 func convertError(err error) errors.ErrorValidation {
 	verr := errors.ErrorValidation{}
 	for _, f := range err.(validator.ValidationErrors) {
@@ -178,7 +204,7 @@ func init() {
 	registerFieldValidator("datastoreType", validateDatastoreType)
 	registerFieldValidator("name", validateName)
 	registerFieldValidator("containerID", validateContainerID)
-	registerFieldValidator("selector", validateSelector)
+	registerFieldValidatorCtx("selector", validateSelector)
 	registerFieldValidator("labels", validateLabels)
 	registerFieldValidator("ipVersion", validateIPVersion)
 	registerFieldValidator("ipIpMode", validateIPIPMode)
@@ -247,6 +273,7 @@ func init() {
 	registerStructValidator(validate, validateTier, api.Tier{})
 	registerStructValidator(validate, validateHTTPRule, api.HTTPMatch{})
 	registerStructValidator(validate, validateFelixConfigSpec, api.FelixConfigurationSpec{})
+	registerStructValidator(validate, validateFelixConfiguration, api.FelixConfiguration{})
 	registerStructValidator(validate, validateWorkloadEndpointSpec, libapi.WorkloadEndpointSpec{})
 	registerStructValidator(validate, validateHostEndpointSpec, api.HostEndpointSpec{})
 	registerStructValidator(validate, validateRule, api.Rule{})
@@ -264,6 +291,10 @@ func init() {
 	registerStructValidator(validate, validateBGPConfigurationSpec, api.BGPConfigurationSpec{})
 	registerStructValidator(validate, validateBlockAffinitySpec, libapi.BlockAffinitySpec{})
 	registerStructValidator(validate, validateHealthTimeoutOverride, api.HealthTimeoutOverride{})
+	registerStructValidator(validate, validateConntrackTimeouts, api.ConntrackTimeouts{})
+	registerStructValidator(validate, validateRoutePolicySpec, api.RoutePolicySpec{})
+	registerStructValidator(validate, validateIPPoolMigrationSpec, api.IPPoolMigrationSpec{})
+	registerStructValidator(validate, validateProfileSpec, api.ProfileSpec{})
 }
 
 // reason returns the provided error reason prefixed with an identifier that
@@ -292,6 +323,10 @@ func registerFieldValidator(key string, fn validator.Func) {
 	validate.RegisterValidation(key, fn)
 }
 
+func registerFieldValidatorCtx(key string, fn validator.FuncCtx) {
+	validate.RegisterValidationCtx(key, fn)
+}
+
 func registerStructValidator(validator *validator.Validate, fn validator.StructLevelFunc, t ...interface{}) {
 	validator.RegisterStructValidation(fn, t...)
 }
@@ -547,19 +582,69 @@ func validateDropReject(fl validator.FieldLevel) bool {
 	return dropRejectRegex.MatchString(s)
 }
 
-func validateSelector(fl validator.FieldLevel) bool {
+func validateSelector(ctx context.Context, fl validator.FieldLevel) bool {
 	s := fl.Field().String()
 	log.Debugf("Validate selector: %s", s)
 
 	// We use the selector parser to validate a selector string.
-	_, err := selector.Parse(s)
+	sel, err := parser.Parse(s)
 	if err != nil {
 		log.Debugf("Selector %#v was invalid: %v", s, err)
 		return false
 	}
+	v := &selectorRegexVisitor{}
+	sel.AcceptVisitor(v)
+	if v.tooLong {
+		log.Debugf("Selector %#v has a regular expression longer than %d characters", s, selectorRegexMaxLen)
+		return false
+	}
+	if v.usesLabelRefs && !isRuleEntitySelector(fl) {
+		log.Debugf("Selector %#v uses label() outside a rule's source or destination", s)
+		return false
+	}
+	if extensions, ok := ctx.Value(selectorExtensionsKey{}).(*[]errors.ErroredField); ok && v.usesExtensions {
+		*extensions = append(*extensions, errors.ErroredField{Name: fl.StructFieldName(), Value: s})
+	}
 	return true
 }
 
+// isRuleEntitySelector returns true if the field is one of the selectors in a rule's source or
+// destination.  Only those selectors have another endpoint, the one the policy applies to, for
+// label() to refer to.
+func isRuleEntitySelector(fl validator.FieldLevel) bool {
+	if fl.Parent().Type() != reflect.TypeOf(api.EntityRule{}) {
+		return false
+	}
+	switch fl.StructFieldName() {
+	case "Selector", "NotSelector", "NamespaceSelector":
+		return true
+	}
+	return false
+}
+
+// selectorRegexVisitor checks the length of the regular expressions in a selector, and records
+// whether the selector uses the comparison, matches or label() operators.
+type selectorRegexVisitor struct {
+	tooLong        bool
+	usesExtensions bool
+	usesLabelRefs  bool
+}
+
+func (v *selectorRegexVisitor) Visit(n interface{}) {
+	switch np := n.(type) {
+	case *parser.LabelMatchesNode:
+		v.usesExtensions = true
+		if len(np.Pattern) > selectorRegexMaxLen {
+			v.tooLong = true
+		}
+	case *parser.LabelCompareNode:
+		v.usesExtensions = true
+	case *parser.LabelEqLabelRefNode, *parser.LabelNeLabelRefNode:
+		v.usesExtensions = true
+		v.usesLabelRefs = true
+	}
+}
+
 func validateTag(fl validator.FieldLevel) bool {
 	s := fl.Field().String()
 	log.Debugf("Validate tag: %s", s)
@@ -838,6 +923,80 @@ func validateHTTPPaths(paths []api.HTTPPath) error {
 	return nil
 }
 
+// validateHTTPHeaders checks if the HTTP header match clauses are valid.
+func validateHTTPHeaders(headers []api.HTTPHeaderMatch) error {
+	for _, header := range headers {
+		if !httpHeaderNameRegex.MatchString(header.Name) {
+			return fmt.Errorf("Invalid header name %q", header.Name)
+		}
+		numSet := 0
+		for _, v := range []string{header.Exact, header.Prefix, header.Regex} {
+			if v != "" {
+				numSet++
+			}
+		}
+		if numSet > 1 {
+			return fmt.Errorf("Invalid header match for %s. At most one of 'exact', 'prefix' and 'regex' may be set", header.Name)
+		}
+		if header.Regex != "" {
+			if _, err := regexp.Compile(header.Regex); err != nil {
+				return fmt.Errorf("Invalid header regex %q: %v", header.Regex, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateHTTPHosts checks if the HTTP host match clauses are valid.
+func validateHTTPHosts(hosts []string) error {
+	for _, host := range hosts {
+		h := strings.ToLower(host)
+		var errs []string
+		if strings.HasPrefix(h, "*.") {
+			errs = k8svalidation.IsWildcardDNS1123Subdomain(h)
+		} else {
+			errs = k8svalidation.IsDNS1123Subdomain(h)
+		}
+		if len(errs) != 0 {
+			return fmt.Errorf("Invalid host %s: %s", host, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// validateGRPCMatch checks if the gRPC service and method match clauses are valid.
+func validateGRPCMatch(g *api.GRPCMatch) error {
+	if g == nil {
+		return nil
+	}
+	for _, svc := range g.Services {
+		if !grpcServiceRegex.MatchString(svc) {
+			return fmt.Errorf("Invalid gRPC service %q (must be a fully-qualified service name)", svc)
+		}
+	}
+	for _, method := range g.Methods {
+		if !grpcMethodRegex.MatchString(method) {
+			return fmt.Errorf("Invalid gRPC method %q", method)
+		}
+	}
+	return nil
+}
+
+// validateJWTClaims checks if the JWT claim match clauses are valid.
+func validateJWTClaims(claims []api.JWTClaimMatch) error {
+	for _, claim := range claims {
+		for _, part := range strings.Split(claim.Name, ".") {
+			if part == "" {
+				return fmt.Errorf("Invalid JWT claim name %q", claim.Name)
+			}
+		}
+		if len(claim.Values) == 0 {
+			return fmt.Errorf("Invalid JWT claim match for %s. At least one value must be set", claim.Name)
+		}
+	}
+	return nil
+}
+
 func validateHTTPRule(structLevel validator.StructLevel) {
 	h := structLevel.Current().Interface().(api.HTTPMatch)
 	log.Debugf("Validate HTTP Rule: %v", h)
@@ -847,6 +1006,18 @@ func validateHTTPRule(structLevel validator.StructLevel) {
 	if err := validateHTTPPaths(h.Paths); err != nil {
 		structLevel.ReportError(reflect.ValueOf(h.Paths), "Paths", "", reason(err.Error()), "")
 	}
+	if err := validateHTTPHeaders(h.Headers); err != nil {
+		structLevel.ReportError(reflect.ValueOf(h.Headers), "Headers", "", reason(err.Error()), "")
+	}
+	if err := validateHTTPHosts(h.Hosts); err != nil {
+		structLevel.ReportError(reflect.ValueOf(h.Hosts), "Hosts", "", reason(err.Error()), "")
+	}
+	if err := validateGRPCMatch(h.GRPC); err != nil {
+		structLevel.ReportError(reflect.ValueOf(h.GRPC), "GRPC", "", reason(err.Error()), "")
+	}
+	if err := validateJWTClaims(h.JWTClaims); err != nil {
+		structLevel.ReportError(reflect.ValueOf(h.JWTClaims), "JWTClaims", "", reason(err.Error()), "")
+	}
 }
 
 func validatePort(structLevel validator.StructLevel) {
@@ -981,6 +1152,55 @@ func validateFelixConfigSpec(structLevel validator.StructLevel) {
 		structLevel.ReportError(reflect.ValueOf(c.RouteTableRanges),
 			"RouteTableRanges", "", reason("targets too many tables"), "")
 	}
+
+	// The RoutePolicy tables are owned exclusively by RoutePolicy resources.  Only the ranges in the
+	// same resource can be checked here; Felix also checks the merged configuration.
+	if r := c.RoutePolicyRouteTableRange; r != nil {
+		for _, t := range routeTablesReservedLinux {
+			if r.Min <= t && t <= r.Max {
+				structLevel.ReportError(reflect.ValueOf(c.RoutePolicyRouteTableRange),
+					"RoutePolicyRouteTableRange", "", reason("must not include the reserved tables 253-255"), "")
+				break
+			}
+		}
+		if r.Max-r.Min > int(routeTableRangeMaxTables) {
+			structLevel.ReportError(reflect.ValueOf(c.RoutePolicyRouteTableRange),
+				"RoutePolicyRouteTableRange", "", reason("targets too many tables"), "")
+		}
+		var ranges []api.RouteTableIDRange
+		if c.RouteTableRanges != nil {
+			ranges = *c.RouteTableRanges
+		} else if c.RouteTableRange != nil {
+			ranges = []api.RouteTableIDRange{{Min: c.RouteTableRange.Min, Max: c.RouteTableRange.Max}}
+		}
+		for _, o := range ranges {
+			if r.Min <= o.Max && o.Min <= r.Max {
+				structLevel.ReportError(reflect.ValueOf(c.RoutePolicyRouteTableRange),
+					"RoutePolicyRouteTableRange", "", reason("must not overlap RouteTableRanges"), "")
+				break
+			}
+		}
+	}
+}
+
+func validateFelixConfiguration(structLevel validator.StructLevel) {
+	fc := structLevel.Current().Interface().(api.FelixConfiguration)
+
+	// The "default" and "node.<nodename>" FelixConfigurations apply to all nodes and to the named
+	// node, any other FelixConfiguration applies to the nodes that its selector selects.  Without a
+	// selector, other FelixConfigurations are not applied, as before node selectors were added.
+	if fc.Name == "default" || strings.HasPrefix(fc.Name, "node.") {
+		if fc.Spec.NodeSelector != "" {
+			structLevel.ReportError(reflect.ValueOf(fc.Spec.NodeSelector),
+				"FelixConfigurationSpec.NodeSelector", "",
+				reason("nodeSelector may not be set on the default and node.<nodename> FelixConfigurations"), "")
+		}
+		if fc.Spec.Priority != nil {
+			structLevel.ReportError(reflect.ValueOf(fc.Spec.Priority),
+				"FelixConfigurationSpec.Priority", "",
+				reason("priority may not be set on the default and node.<nodename> FelixConfigurations"), "")
+		}
+	}
 }
 
 func validateWorkloadEndpointSpec(structLevel validator.StructLevel) {
@@ -1166,6 +1386,35 @@ func validateIPPoolSpec(structLevel validator.StructLevel) {
 				"IPpool.AllowedUses", "", reason("unknown use: "+string(a)), "")
 		}
 	}
+
+	// External IPAM must be given the location of its allocator.
+	if e := pool.ExternalIPAM; e != nil {
+		if e.Type == api.ExternalIPAMTypeGRPC && e.Endpoint == "" {
+			structLevel.ReportError(reflect.ValueOf(e.Endpoint),
+				"IPpool.ExternalIPAM.Endpoint", "", reason("endpoint must be specified for GRPC external IPAM"), "")
+		}
+		// Only a unix socket may be used without TLS, since it can't be reached from the network.
+		if e.Type == api.ExternalIPAMTypeGRPC && e.Endpoint != "" && !strings.HasPrefix(e.Endpoint, "unix:") && e.TLS == nil {
+			structLevel.ReportError(reflect.ValueOf(e.TLS),
+				"IPpool.ExternalIPAM.TLS", "", reason("tls must be specified for a host:port GRPC endpoint"), "")
+		}
+		if e.TLS != nil && (e.TLS.CertFile == "") != (e.TLS.KeyFile == "") {
+			structLevel.ReportError(reflect.ValueOf(e.TLS),
+				"IPpool.ExternalIPAM.TLS", "", reason("certFile and keyFile must be specified together"), "")
+		}
+		if e.Type == api.ExternalIPAMTypeFile && e.Path == "" {
+			structLevel.ReportError(reflect.ValueOf(e.Path),
+				"IPpool.ExternalIPAM.Path", "", reason("path must be specified for File external IPAM"), "")
+		}
+	}
+
+	// Each quota must select either namespaces or nodes.
+	for _, q := range pool.Quotas {
+		if (q.NamespaceSelector == "") == (q.NodeSelector == "") {
+			structLevel.ReportError(reflect.ValueOf(q),
+				"IPpool.Quotas", "", reason("exactly one of namespaceSelector and nodeSelector must be specified for a quota"), "")
+		}
+	}
 }
 
 func vxLanModeEnabled(mode api.VXLANMode) bool {
@@ -1287,6 +1536,31 @@ func validateRule(structLevel validator.StructLevel) {
 	}
 }
 
+func validateProfileSpec(structLevel validator.StructLevel) {
+	spec := structLevel.Current().Interface().(api.ProfileSpec)
+
+	// Felix renders a profile's rules once for all of its endpoints, so a profile's rules can't
+	// refer to the labels of the endpoint that they apply to.
+	for _, rules := range [][]api.Rule{spec.Ingress, spec.Egress} {
+		for _, rule := range rules {
+			for _, er := range []api.EntityRule{rule.Source, rule.Destination} {
+				for _, sel := range []string{er.Selector, er.NotSelector, er.NamespaceSelector} {
+					if selectorUsesLabelRefs(sel) {
+						structLevel.ReportError(reflect.ValueOf(sel), "Selector", "",
+							reason("label() is not supported in profile rules"), "")
+					}
+				}
+			}
+		}
+	}
+}
+
+// selectorUsesLabelRefs returns true if the selector is valid and uses label().
+func selectorUsesLabelRefs(s string) bool {
+	sel, err := parser.Parse(s)
+	return err == nil && len(parser.LabelRefs(sel)) > 0
+}
+
 func validateEntityRule(structLevel validator.StructLevel) {
 	rule := structLevel.Current().Interface().(api.EntityRule)
 	if strings.Contains(rule.Selector, globalSelector) {
@@ -1375,6 +1649,23 @@ func validateBGPPeerSpec(structLevel validator.StructLevel) {
 		structLevel.ReportError(reflect.ValueOf(ps.ReachableBy), "ReachableBy", "",
 			reason(msg), "")
 	}
+
+	// Each address family must be known, and listed at most once.
+	seen := map[api.BGPAddressFamily]bool{}
+	for _, f := range ps.AddressFamilies {
+		switch f {
+		case api.BGPAddressFamilyIPv4, api.BGPAddressFamilyIPv6:
+		default:
+			structLevel.ReportError(reflect.ValueOf(ps.AddressFamilies), "AddressFamilies", "",
+				reason("unknown address family: "+string(f)), "")
+			continue
+		}
+		if seen[f] {
+			structLevel.ReportError(reflect.ValueOf(ps.AddressFamilies), "AddressFamilies", "",
+				reason("duplicate address family: "+string(f)), "")
+		}
+		seen[f] = true
+	}
 }
 
 func validateReachableBy(reachableBy, peerIP string) (bool, string) {
@@ -1974,9 +2265,9 @@ func validateBGPConfigurationSpec(structLevel validator.StructLevel) {
 		}
 	}
 
-	if (len(spec.PrefixAdvertisements) == 0) && (len(communities) != 0) {
+	if (len(spec.PrefixAdvertisements) == 0) && (len(spec.WorkloadAdvertisements) == 0) && (len(communities) != 0) {
 		structLevel.ReportError(reflect.ValueOf(communities), "Spec.Communities[]", "",
-			reason("communities are defined but not used in Spec.PrefixAdvertisement[]."), "")
+			reason("communities are defined but not used in Spec.PrefixAdvertisement[] or Spec.WorkloadAdvertisements[]."), "")
 	}
 
 	// check if Spec.PrefixAdvertisement.Communities are valid
@@ -1999,6 +2290,19 @@ func validateBGPConfigurationSpec(structLevel validator.StructLevel) {
 		}
 	}
 
+	// check if Spec.WorkloadAdvertisements.Communities are valid
+	for _, wa := range spec.WorkloadAdvertisements {
+		for _, v := range wa.Communities {
+			isValid := isValidCommunity(v, "Spec.WorkloadAdvertisements[].Communities[]", structLevel)
+			if !isValid {
+				if !isCommunityDefined(v, communities) {
+					structLevel.ReportError(reflect.ValueOf(v), "Spec.WorkloadAdvertisements[].Communities[]", "",
+						reason("community used is invalid or not defined."), "")
+				}
+			}
+		}
+	}
+
 	// Check that node mesh password cannot be set if node to node mesh is disabled.
 	if spec.NodeMeshPassword != nil && spec.NodeToNodeMeshEnabled != nil && !*spec.NodeToNodeMeshEnabled {
 		structLevel.ReportError(reflect.ValueOf(spec), "Spec.NodeMeshPassword", "", reason("spec.NodeMeshPassword cannot be set if spec.NodeToNodeMesh is disabled"), "")
@@ -2017,6 +2321,76 @@ func validateBlockAffinitySpec(structLevel validator.StructLevel) {
 	}
 }
 
+func validateRoutePolicySpec(structLevel validator.StructLevel) {
+	spec := structLevel.Current().Interface().(api.RoutePolicySpec)
+
+	if spec.Selector == "" {
+		structLevel.ReportError(reflect.ValueOf(spec.Selector), "RoutePolicySpec.Selector", "",
+			reason("selector must be specified"), "")
+	}
+	if len(spec.Destinations) == 0 {
+		structLevel.ReportError(reflect.ValueOf(spec.Destinations), "RoutePolicySpec.Destinations", "",
+			reason("at least one destination must be specified"), "")
+	}
+	if spec.NextHop == "" && spec.Interface == "" {
+		structLevel.ReportError(reflect.ValueOf(spec.NextHop), "RoutePolicySpec.NextHop", "",
+			reason("one of nextHop or interface must be specified"), "")
+	}
+	for _, t := range routeTablesReservedLinux {
+		if spec.Table == t {
+			structLevel.ReportError(reflect.ValueOf(spec.Table), "RoutePolicySpec.Table", "",
+				reason("must not be one of the reserved tables 253-255"), "")
+		}
+	}
+
+	// If a next hop is specified then it can only be used for destinations of the same IP family.
+	if spec.NextHop == "" {
+		return
+	}
+	nextHop := cnet.ParseIP(spec.NextHop)
+	if nextHop == nil {
+		// Already reported by the field validator.
+		return
+	}
+	for _, d := range spec.Destinations {
+		_, dest, err := cnet.ParseCIDROrIP(d)
+		if err != nil {
+			// Already reported by the field validator.
+			continue
+		}
+		if dest.Version() != nextHop.Version() {
+			structLevel.ReportError(reflect.ValueOf(d), "RoutePolicySpec.Destinations", "",
+				reason("destination IP version does not match the next hop IP version"), "")
+		}
+	}
+}
+
+func validateIPPoolMigrationSpec(structLevel validator.StructLevel) {
+	spec := structLevel.Current().Interface().(api.IPPoolMigrationSpec)
+
+	_, from, err := cnet.ParseCIDR(spec.From)
+	if err != nil {
+		// Already reported by the field validator.
+		return
+	}
+	_, to, err := cnet.ParseCIDR(spec.To)
+	if err != nil {
+		return
+	}
+	if from.String() == to.String() {
+		structLevel.ReportError(reflect.ValueOf(spec.To), "IPPoolMigrationSpec.To", "",
+			reason("destination pool must be different from the source pool"), "")
+	}
+	if from.Version() != to.Version() {
+		structLevel.ReportError(reflect.ValueOf(spec.To), "IPPoolMigrationSpec.To", "",
+			reason("destination pool IP version does not match the source pool IP version"), "")
+	}
+	if spec.PodTimeout != nil && spec.PodTimeout.Duration <= 0 {
+		structLevel.ReportError(reflect.ValueOf(spec.PodTimeout), "IPPoolMigrationSpec.PodTimeout", "",
+			reason("podTimeout must be positive"), "")
+	}
+}
+
 var htoNameRegex = regexp.MustCompile("^[a-zA-Z0-9_ -]+$")
 
 func validateHealthTimeoutOverride(structLevel validator.StructLevel) {
@@ -2029,6 +2403,23 @@ func validateHealthTimeoutOverride(structLevel validator.StructLevel) {
 	}
 }
 
+func validateConntrackTimeouts(structLevel validator.StructLevel) {
+	ct := structLevel.Current().Interface().(api.ConntrackTimeouts)
+	for field, d := range map[string]*metav1.Duration{
+		"TCPSynSent":     ct.TCPSynSent,
+		"TCPEstablished": ct.TCPEstablished,
+		"TCPFinsSeen":    ct.TCPFinsSeen,
+		"TCPResetSeen":   ct.TCPResetSeen,
+		"UDPTimeout":     ct.UDPTimeout,
+		"ICMPTimeout":    ct.ICMPTimeout,
+		"GenericTimeout": ct.GenericTimeout,
+	} {
+		if d != nil && d.Duration < time.Second {
+			structLevel.ReportError(reflect.ValueOf(d), "ConntrackTimeouts."+field, "", reason("timeout must be at least 1s"), "")
+		}
+	}
+}
+
 func isCommunityDefined(community string, communityKVPairs []api.Community) bool {
 	for _, val := range communityKVPairs {
 		if val.Name == community {
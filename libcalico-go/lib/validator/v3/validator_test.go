@@ -15,6 +15,7 @@
 package v3_test
 
 import (
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -124,6 +125,8 @@ func init() {
 	windowsManageFirewallRulesDisabled := api.WindowsManageFirewallRulesDisabled
 	var windowsManageFirewallRulesBlah api.WindowsManageFirewallRulesMode = "blah"
 
+	priority := 10
+
 	// Perform validation on error messages from validator
 	DescribeTable("Validator errors",
 		func(input interface{}, e string) {
@@ -144,6 +147,28 @@ func init() {
 			}, "error with field Port = '0' (port range invalid, port number must be between 1 and 65535)"),
 	)
 
+	DescribeTable("Finding selector extensions",
+		func(input interface{}, expected []string) {
+			extensions, err := v3.ValidateAndFindSelectorExtensions(input)
+			Expect(err).NotTo(HaveOccurred())
+			var fields []string
+			for _, e := range extensions {
+				fields = append(fields, e.Name)
+			}
+			Expect(fields).To(Equal(expected))
+		},
+		Entry("should find nothing in a plain selector", api.EntityRule{Selector: "foo == 'bar'"}, nil),
+		Entry("should find a numeric comparison", api.EntityRule{Selector: "tier > 2"}, []string{"Selector"}),
+		Entry("should find a regular expression", api.EntityRule{NotSelector: "app matches 'web-.*'"}, []string{"NotSelector"}),
+		Entry("should find a label reference", api.EntityRule{Selector: "app == label(app)"}, []string{"Selector"}),
+		Entry("should find extensions in a rule",
+			api.Rule{
+				Action:      "Allow",
+				Source:      api.EntityRule{Selector: "has(a) && tier <= 10"},
+				Destination: api.EntityRule{NamespaceSelector: "team != label(team)"},
+			}, []string{"Selector", "NamespaceSelector"}),
+	)
+
 	// Perform basic validation of different fields and structures to test simple valid/invalid
 	// scenarios.  This does not test precise error strings - but does cover a lot of the validation
 	// code paths.
@@ -551,6 +576,23 @@ func init() {
 		Entry("should accept valid selector with 'has' and two '/'", api.EntityRule{Selector: "has(calico/k8s_ns/role)"}, true),
 		Entry("should accept valid selector with 'has' and two '/' and '-.'", api.EntityRule{Selector: "has(calico/k8s_NS-.1/role)"}, true),
 		Entry("should reject invalid selector", api.EntityRule{Selector: "thing=hello &"}, false),
+		Entry("should accept a numeric comparison", api.EntityRule{Selector: "tier > 2 && tier <= 10"}, true),
+		Entry("should reject a numeric comparison with a string", api.EntityRule{Selector: "tier > 'two'"}, false),
+		Entry("should accept a regular expression", api.EntityRule{Selector: "app matches 'web-[a-z0-9]+'"}, true),
+		Entry("should reject an invalid regular expression", api.EntityRule{Selector: "app matches 'web-[a-z'"}, false),
+		Entry("should reject a regular expression that is too long",
+			api.EntityRule{Selector: "app matches '" + strings.Repeat("a", 1025) + "'"}, false),
+		Entry("should reject a numeric comparison with an exponent", api.EntityRule{Selector: "tier > 1e3"}, false),
+		Entry("should accept a label reference in a rule selector", api.EntityRule{Selector: "app == label(app)"}, true),
+		Entry("should accept a label reference in a rule namespace selector", api.EntityRule{NamespaceSelector: "team != label(pcns.team)"}, true),
+		Entry("should reject a label reference in a service account selector",
+			api.EntityRule{ServiceAccounts: &api.ServiceAccountMatch{Selector: "app == label(app)"}}, false),
+		Entry("should reject a label reference in a policy selector",
+			api.GlobalNetworkPolicySpec{Selector: "app == label(app)"}, false),
+		Entry("should reject a label reference in a profile rule",
+			api.ProfileSpec{Ingress: []api.Rule{{Action: "Allow", Source: api.EntityRule{Selector: "app == label(app)"}}}}, false),
+		Entry("should accept a label reference in a policy rule",
+			api.GlobalNetworkPolicySpec{Ingress: []api.Rule{{Action: "Allow", Source: api.EntityRule{Selector: "app == label(app)"}}}}, true),
 
 		// (API) Labels and Annotations.
 		Entry("should accept a valid labelsToApply", api.ProfileSpec{LabelsToApply: map[string]string{"project.calico.org/my-valid-label": value63}}, true),
@@ -730,6 +772,19 @@ func init() {
 		Entry("should reject an invalid list of ExternalNodesCIDRList", api.FelixConfigurationSpec{ExternalNodesCIDRList: &[]string{"foobar", "1.1.1.1"}}, false),
 		Entry("should reject IPv6 list of ExternalNodesCIDRList", api.FelixConfigurationSpec{ExternalNodesCIDRList: &[]string{"abcd::1", "abef::2/128"}}, false),
 
+		Entry("should accept a default FelixConfiguration without a nodeSelector",
+			&api.FelixConfiguration{ObjectMeta: v1.ObjectMeta{Name: "default"}}, true),
+		Entry("should reject a default FelixConfiguration with a nodeSelector",
+			&api.FelixConfiguration{ObjectMeta: v1.ObjectMeta{Name: "default"}, Spec: api.FelixConfigurationSpec{NodeSelector: "all()"}}, false),
+		Entry("should reject a per-node FelixConfiguration with a priority",
+			&api.FelixConfiguration{ObjectMeta: v1.ObjectMeta{Name: "node.node1"}, Spec: api.FelixConfigurationSpec{Priority: &priority}}, false),
+		Entry("should accept a FelixConfiguration with a nodeSelector and priority",
+			&api.FelixConfiguration{ObjectMeta: v1.ObjectMeta{Name: "rack-a"}, Spec: api.FelixConfigurationSpec{NodeSelector: "rack == 'a'", Priority: &priority}}, true),
+		Entry("should accept a FelixConfiguration without a nodeSelector",
+			&api.FelixConfiguration{ObjectMeta: v1.ObjectMeta{Name: "rack-a"}}, true),
+		Entry("should reject a FelixConfiguration with an invalid nodeSelector",
+			&api.FelixConfiguration{ObjectMeta: v1.ObjectMeta{Name: "rack-a"}, Spec: api.FelixConfigurationSpec{NodeSelector: "rack == "}}, false),
+
 		Entry("should accept aan empty OpenStackRegion", api.FelixConfigurationSpec{OpenstackRegion: ""}, true),
 		Entry("should accept a valid OpenStackRegion", api.FelixConfigurationSpec{OpenstackRegion: "foo"}, true),
 		Entry("should reject an invalid OpenStackRegion", api.FelixConfigurationSpec{OpenstackRegion: "FOO"}, false),
@@ -770,6 +825,15 @@ func init() {
 		Entry("should reject route table range max < min", api.FelixConfigurationSpec{RouteTableRange: &api.RouteTableRange{Min: 50, Max: 45}}, false),
 		Entry("should reject route table range max too large", api.FelixConfigurationSpec{RouteTableRange: &api.RouteTableRange{Min: 1, Max: 253}}, false),
 		Entry("should accept route table range with min == max", api.FelixConfigurationSpec{RouteTableRange: &api.RouteTableRange{Min: 8, Max: 8}}, true),
+		Entry("should accept valid route policy route table range", api.FelixConfigurationSpec{RoutePolicyRouteTableRange: &api.RouteTableIDRange{Min: 1000, Max: 1249}}, true),
+		Entry("should accept route policy route table range above the kernel's reserved tables", api.FelixConfigurationSpec{RoutePolicyRouteTableRange: &api.RouteTableIDRange{Min: 256, Max: 0xffff}}, true),
+		Entry("should reject route policy route table range including reserved tables", api.FelixConfigurationSpec{RoutePolicyRouteTableRange: &api.RouteTableIDRange{Min: 200, Max: 253}}, false),
+		Entry("should reject route policy route table range targeting too many tables", api.FelixConfigurationSpec{RoutePolicyRouteTableRange: &api.RouteTableIDRange{Min: 1000, Max: 0x20000}}, false),
+		Entry("should reject route policy route table range overlapping route table ranges", api.FelixConfigurationSpec{RoutePolicyRouteTableRange: &api.RouteTableIDRange{Min: 200, Max: 249}, RouteTableRanges: &api.RouteTableRanges{{Min: 1, Max: 200}}}, false),
+		Entry("should reject route policy route table range overlapping route table range", api.FelixConfigurationSpec{RoutePolicyRouteTableRange: &api.RouteTableIDRange{Min: 200, Max: 249}, RouteTableRange: &api.RouteTableRange{Min: 249, Max: 250}}, false),
+		Entry("should accept route policy route table range outside route table ranges", api.FelixConfigurationSpec{RoutePolicyRouteTableRange: &api.RouteTableIDRange{Min: 200, Max: 249}, RouteTableRanges: &api.RouteTableRanges{{Min: 1, Max: 199}, {Min: 256, Max: 1000}}}, true),
+		Entry("should accept valid route policy rule priority", api.FelixConfigurationSpec{RoutePolicyRoutingRulePriority: &V128}, true),
+		Entry("should reject route policy rule priority of 0", api.FelixConfigurationSpec{RoutePolicyRoutingRulePriority: &V0}, false),
 
 		Entry("should accept valid route table ranges", api.FelixConfigurationSpec{RouteTableRanges: &api.RouteTableRanges{{Min: 1, Max: 10000}}}, true),
 		Entry("should accept route table ranges with min == max", api.FelixConfigurationSpec{RouteTableRanges: &api.RouteTableRanges{{Min: 8, Max: 8}}}, true),
@@ -797,6 +861,8 @@ func init() {
 		Entry("should reject HealthTimeoutOverride -1", api.FelixConfigurationSpec{HealthTimeoutOverrides: []api.HealthTimeoutOverride{{Name: "Valid", Timeout: metav1.Duration{Duration: -1}}}}, false),
 		Entry("should reject HealthTimeoutOverride with bad name", api.FelixConfigurationSpec{HealthTimeoutOverrides: []api.HealthTimeoutOverride{{Name: "%", Timeout: metav1.Duration{Duration: 10}}}}, false),
 		Entry("should reject HealthTimeoutOverride with no name", api.FelixConfigurationSpec{HealthTimeoutOverrides: []api.HealthTimeoutOverride{{Name: "", Timeout: metav1.Duration{Duration: 10}}}}, false),
+		Entry("should accept ConntrackTimeouts", api.FelixConfigurationSpec{ConntrackTimeouts: &api.ConntrackTimeouts{UDPTimeout: &metav1.Duration{Duration: 10 * time.Second}}}, true),
+		Entry("should reject ConntrackTimeouts under 1s", api.FelixConfigurationSpec{ConntrackTimeouts: &api.ConntrackTimeouts{TCPEstablished: &metav1.Duration{Duration: 500 * time.Millisecond}}}, false),
 
 		// (API) Protocol
 		Entry("should accept protocol TCP", protocolFromString("TCP"), true),
@@ -1050,6 +1116,293 @@ func init() {
 					},
 				},
 			}, false),
+		Entry("should accept IP pool with GRPC external IPAM",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR: netv4_4,
+					ExternalIPAM: &api.ExternalIPAMSpec{
+						Type:     api.ExternalIPAMTypeGRPC,
+						Endpoint: "unix:///var/run/ipam.sock",
+					},
+				},
+			}, true),
+		Entry("should reject IP pool with GRPC external IPAM and no endpoint",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR:         netv4_4,
+					ExternalIPAM: &api.ExternalIPAMSpec{Type: api.ExternalIPAMTypeGRPC},
+				},
+			}, false),
+		Entry("should accept IP pool with GRPC external IPAM over TLS",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR: netv4_4,
+					ExternalIPAM: &api.ExternalIPAMSpec{
+						Type:     api.ExternalIPAMTypeGRPC,
+						Endpoint: "ipam.example.com:9000",
+						TLS: &api.ExternalIPAMTLS{
+							CACertFile: "/etc/ipam/ca.crt",
+							CertFile:   "/etc/ipam/tls.crt",
+							KeyFile:    "/etc/ipam/tls.key",
+						},
+					},
+				},
+			}, true),
+		Entry("should reject IP pool with GRPC external IPAM at a host:port without TLS",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR: netv4_4,
+					ExternalIPAM: &api.ExternalIPAMSpec{
+						Type:     api.ExternalIPAMTypeGRPC,
+						Endpoint: "ipam.example.com:9000",
+					},
+				},
+			}, false),
+		Entry("should reject IP pool with GRPC external IPAM and a client certificate without a key",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR: netv4_4,
+					ExternalIPAM: &api.ExternalIPAMSpec{
+						Type:     api.ExternalIPAMTypeGRPC,
+						Endpoint: "ipam.example.com:9000",
+						TLS:      &api.ExternalIPAMTLS{CertFile: "/etc/ipam/tls.crt"},
+					},
+				},
+			}, false),
+		Entry("should reject IP pool with File external IPAM and no path",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR:         netv4_4,
+					ExternalIPAM: &api.ExternalIPAMSpec{Type: api.ExternalIPAMTypeFile},
+				},
+			}, false),
+		Entry("should reject IP pool with unknown external IPAM type",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR:         netv4_4,
+					ExternalIPAM: &api.ExternalIPAMSpec{Type: "DHCP", Path: "/tmp/ipam"},
+				},
+			}, false),
+		Entry("should accept IP pool with namespace and node quotas",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR: netv4_4,
+					Quotas: []api.IPPoolQuota{
+						{NamespaceSelector: "team == 'a'", MaxAddresses: 100},
+						{NodeSelector: "all()", MaxAddresses: 0},
+					},
+				},
+			}, true),
+		Entry("should reject IP pool quota with no selector",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR:   netv4_4,
+					Quotas: []api.IPPoolQuota{{MaxAddresses: 10}},
+				},
+			}, false),
+		Entry("should reject IP pool quota with both selectors",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR:   netv4_4,
+					Quotas: []api.IPPoolQuota{{NamespaceSelector: "all()", NodeSelector: "all()", MaxAddresses: 10}},
+				},
+			}, false),
+		Entry("should reject IP pool quota with a bad selector",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR:   netv4_4,
+					Quotas: []api.IPPoolQuota{{NamespaceSelector: "team ==", MaxAddresses: 10}},
+				},
+			}, false),
+		Entry("should reject IP pool quota with negative max addresses",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{Name: "pool.name"},
+				Spec: api.IPPoolSpec{
+					CIDR:   netv4_4,
+					Quotas: []api.IPPoolQuota{{NodeSelector: "all()", MaxAddresses: -1}},
+				},
+			}, false),
+
+		// (API) IPPoolMigration
+		Entry("should accept IPPoolMigration between pools of the same family",
+			api.IPPoolMigration{
+				ObjectMeta: v1.ObjectMeta{Name: "migration"},
+				Spec:       api.IPPoolMigrationSpec{From: "10.0.0.0/16", To: "10.1.0.0/16"},
+			}, true),
+		Entry("should reject IPPoolMigration to the same pool",
+			api.IPPoolMigration{
+				ObjectMeta: v1.ObjectMeta{Name: "migration"},
+				Spec:       api.IPPoolMigrationSpec{From: "10.0.0.0/16", To: "10.0.0.0/16"},
+			}, false),
+		Entry("should reject IPPoolMigration between IP families",
+			api.IPPoolMigration{
+				ObjectMeta: v1.ObjectMeta{Name: "migration"},
+				Spec:       api.IPPoolMigrationSpec{From: "10.0.0.0/16", To: "fd00::/64"},
+			}, false),
+		Entry("should reject IPPoolMigration with a zero batch size",
+			api.IPPoolMigration{
+				ObjectMeta: v1.ObjectMeta{Name: "migration"},
+				Spec:       api.IPPoolMigrationSpec{From: "10.0.0.0/16", To: "10.1.0.0/16", BatchSize: &V0},
+			}, false),
+
+		// (API) RoutePolicy
+		Entry("should accept RoutePolicy with a next hop",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "app == 'scanner'",
+					Destinations: []string{"0.0.0.0/0"},
+					NextHop:      "10.0.0.5",
+					Table:        200,
+				},
+			}, true),
+		Entry("should accept RoutePolicy with an interface and mixed destinations",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "all()",
+					Destinations: []string{"10.0.0.0/8", "dead:beef::/64"},
+					Interface:    "eth1",
+					Table:        1,
+				},
+			}, true),
+		Entry("should reject RoutePolicy without a next hop or interface",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "all()",
+					Destinations: []string{"10.0.0.0/8"},
+					Table:        200,
+				},
+			}, false),
+		Entry("should reject RoutePolicy without a selector",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Destinations: []string{"10.0.0.0/8"},
+					NextHop:      "10.0.0.5",
+					Table:        200,
+				},
+			}, false),
+		Entry("should reject RoutePolicy with a bad selector",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "app == ",
+					Destinations: []string{"10.0.0.0/8"},
+					NextHop:      "10.0.0.5",
+					Table:        200,
+				},
+			}, false),
+		Entry("should reject RoutePolicy without destinations",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector: "all()",
+					NextHop:  "10.0.0.5",
+					Table:    200,
+				},
+			}, false),
+		Entry("should reject RoutePolicy with a bad destination",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "all()",
+					Destinations: []string{"10.0.0.0/33"},
+					NextHop:      "10.0.0.5",
+					Table:        200,
+				},
+			}, false),
+		Entry("should reject RoutePolicy with a next hop of the wrong IP version",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "all()",
+					Destinations: []string{"dead:beef::/64"},
+					NextHop:      "10.0.0.5",
+					Table:        200,
+				},
+			}, false),
+		Entry("should reject RoutePolicy with a bad next hop",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "all()",
+					Destinations: []string{"10.0.0.0/8"},
+					NextHop:      "10.0.0.300",
+					Table:        200,
+				},
+			}, false),
+		Entry("should reject RoutePolicy with a bad interface",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "all()",
+					Destinations: []string{"10.0.0.0/8"},
+					Interface:    "eth1*",
+					Table:        200,
+				},
+			}, false),
+		Entry("should reject RoutePolicy with a reserved table",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "all()",
+					Destinations: []string{"10.0.0.0/8"},
+					NextHop:      "10.0.0.5",
+					Table:        254,
+				},
+			}, false),
+		Entry("should accept RoutePolicy with a table in the default RoutePolicyRouteTableRange",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "all()",
+					Destinations: []string{"10.0.0.0/8"},
+					NextHop:      "10.0.0.5",
+					Table:        1000,
+				},
+			}, true),
+		Entry("should accept RoutePolicy with the highest table",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "all()",
+					Destinations: []string{"10.0.0.0/8"},
+					NextHop:      "10.0.0.5",
+					Table:        0xffffffff,
+				},
+			}, true),
+		Entry("should reject RoutePolicy with a table that is too high",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "all()",
+					Destinations: []string{"10.0.0.0/8"},
+					NextHop:      "10.0.0.5",
+					Table:        0x100000000,
+				},
+			}, false),
+		Entry("should reject RoutePolicy without a table",
+			api.RoutePolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "route-policy.name"},
+				Spec: api.RoutePolicySpec{
+					Selector:     "all()",
+					Destinations: []string{"10.0.0.0/8"},
+					NextHop:      "10.0.0.5",
+				},
+			}, false),
 
 		// (API) IPReservation
 		Entry("should accept IPReservation with an IP",
@@ -1138,6 +1491,16 @@ func init() {
 				Action:   "Allow",
 				Protocol: protocolFromString("SCTP"),
 			}, true),
+		Entry("should accept Rule with connLimit",
+			api.Rule{
+				Action:    "Deny",
+				ConnLimit: &api.ConnLimitMatch{MaxConnections: 10},
+			}, true),
+		Entry("should reject Rule with zero connLimit",
+			api.Rule{
+				Action:    "Deny",
+				ConnLimit: &api.ConnLimitMatch{MaxConnections: 0},
+			}, false),
 		Entry("should accept Rule with source ports and protocol type 6",
 			api.Rule{
 				Action:   "Allow",
@@ -1913,6 +2276,22 @@ func init() {
 			PeerIP:      peerv6_1,
 			ReachableBy: ipv4_1,
 		}, false),
+		Entry("should accept BGPPeerSpec with both address families", api.BGPPeerSpec{
+			PeerIP:          ipv6_1,
+			AddressFamilies: []api.BGPAddressFamily{api.BGPAddressFamilyIPv6, api.BGPAddressFamilyIPv4},
+		}, true),
+		Entry("should accept BGPPeerSpec with IPv4 routes over an IPv6 peering", api.BGPPeerSpec{
+			PeerIP:          ipv6_1,
+			AddressFamilies: []api.BGPAddressFamily{api.BGPAddressFamilyIPv4},
+		}, true),
+		Entry("should reject BGPPeerSpec with an unknown address family", api.BGPPeerSpec{
+			PeerIP:          ipv4_1,
+			AddressFamilies: []api.BGPAddressFamily{"L2VPN"},
+		}, false),
+		Entry("should reject BGPPeerSpec with a duplicate address family", api.BGPPeerSpec{
+			PeerSelector:    "has(mesh)",
+			AddressFamilies: []api.BGPAddressFamily{api.BGPAddressFamilyIPv4, api.BGPAddressFamilyIPv4},
+		}, false),
 		Entry("should accept BGPPeerSpec with Password", api.BGPPeerSpec{
 			PeerIP: ipv4_1,
 			Password: &api.BGPPassword{
@@ -3021,6 +3400,78 @@ func init() {
 			&api.HTTPMatch{Methods: []string{"GET", "GET", "Foo"}},
 			false,
 		),
+		Entry("allow HTTP Headers with permitted match clauses",
+			&api.HTTPMatch{Headers: []api.HTTPHeaderMatch{
+				{Name: "X-Tenant", Exact: "a"},
+				{Name: "user-agent", Prefix: "curl/"},
+				{Name: ":authority", Regex: "^.*\\.example\\.com$"},
+				{Name: "x-present"},
+			}},
+			true,
+		),
+		Entry("disallow HTTP Header with several match clauses",
+			&api.HTTPMatch{Headers: []api.HTTPHeaderMatch{{Name: "x-tenant", Exact: "a", Prefix: "a"}}},
+			false,
+		),
+		Entry("disallow HTTP Header with an invalid name",
+			&api.HTTPMatch{Headers: []api.HTTPHeaderMatch{{Name: "x tenant", Exact: "a"}}},
+			false,
+		),
+		Entry("disallow HTTP Header with no name",
+			&api.HTTPMatch{Headers: []api.HTTPHeaderMatch{{Exact: "a"}}},
+			false,
+		),
+		Entry("disallow HTTP Header with an invalid regex",
+			&api.HTTPMatch{Headers: []api.HTTPHeaderMatch{{Name: "x-tenant", Regex: "a("}}},
+			false,
+		),
+		Entry("allow HTTP Hosts",
+			&api.HTTPMatch{Hosts: []string{"api.example.com", "*.Example.com"}},
+			true,
+		),
+		Entry("disallow HTTP Host with a port",
+			&api.HTTPMatch{Hosts: []string{"api.example.com:8080"}},
+			false,
+		),
+		Entry("disallow HTTP Host with a wildcard in the middle",
+			&api.HTTPMatch{Hosts: []string{"api.*.com"}},
+			false,
+		),
+		Entry("allow gRPC services and methods",
+			&api.HTTPMatch{GRPC: &api.GRPCMatch{Services: []string{"helloworld.Greeter"}, Methods: []string{"SayHello"}}},
+			true,
+		),
+		Entry("allow an empty gRPC match",
+			&api.HTTPMatch{GRPC: &api.GRPCMatch{}},
+			true,
+		),
+		Entry("disallow an invalid gRPC service",
+			&api.HTTPMatch{GRPC: &api.GRPCMatch{Services: []string{"/helloworld.Greeter"}}},
+			false,
+		),
+		Entry("disallow an invalid gRPC method",
+			&api.HTTPMatch{GRPC: &api.GRPCMatch{Methods: []string{"Greeter/SayHello"}}},
+			false,
+		),
+		Entry("allow JWT claims",
+			&api.HTTPMatch{JWTClaims: []api.JWTClaimMatch{
+				{Name: "iss", Values: []string{"https://issuer.example.com"}},
+				{Name: "realm_access.roles", Values: []string{"admin", "viewer"}},
+			}},
+			true,
+		),
+		Entry("disallow a JWT claim with no values",
+			&api.HTTPMatch{JWTClaims: []api.JWTClaimMatch{{Name: "iss", Values: []string{}}}},
+			false,
+		),
+		Entry("disallow a JWT claim with no name",
+			&api.HTTPMatch{JWTClaims: []api.JWTClaimMatch{{Values: []string{"a"}}}},
+			false,
+		),
+		Entry("disallow a JWT claim with an empty nested name",
+			&api.HTTPMatch{JWTClaims: []api.JWTClaimMatch{{Name: "realm_access..roles", Values: []string{"a"}}}},
+			false,
+		),
 		Entry("should not accept an invalid IP address",
 			api.FelixConfigurationSpec{NATOutgoingAddress: bad_ipv4_1}, false,
 		),
@@ -3190,6 +3641,22 @@ func init() {
 		Entry("should not accept CIDR without communities in PrefixAdvertisement", api.BGPConfigurationSpec{
 			PrefixAdvertisements: []api.PrefixAdvertisement{{CIDR: "192.168.10.0/28"}},
 		}, false),
+		Entry("should accept workload advertisement with defined and literal communities", api.BGPConfigurationSpec{
+			Communities:            []api.Community{{Name: "community-test", Value: "100:520"}},
+			WorkloadAdvertisements: []api.WorkloadAdvertisement{{Selector: "app == 'dns'", Communities: []string{"community-test", "100:5964:50"}}},
+		}, true),
+		Entry("should accept workload advertisement without communities", api.BGPConfigurationSpec{
+			WorkloadAdvertisements: []api.WorkloadAdvertisement{{Selector: "app == 'dns'"}},
+		}, true),
+		Entry("should not accept workload advertisement with an undefined community", api.BGPConfigurationSpec{
+			WorkloadAdvertisements: []api.WorkloadAdvertisement{{Selector: "app == 'dns'", Communities: []string{"community-test"}}},
+		}, false),
+		Entry("should not accept workload advertisement with an invalid selector", api.BGPConfigurationSpec{
+			WorkloadAdvertisements: []api.WorkloadAdvertisement{{Selector: "app === 'dns'"}},
+		}, false),
+		Entry("should not accept workload advertisement without a selector", api.BGPConfigurationSpec{
+			WorkloadAdvertisements: []api.WorkloadAdvertisement{{Communities: []string{"100:5964"}}},
+		}, false),
 		Entry("should accept IPv4 CIDR in PrefixAdvertisement", api.BGPConfigurationSpec{
 			PrefixAdvertisements: []api.PrefixAdvertisement{{CIDR: "192.168.10.0/28", Communities: []string{"100:5964:50"}}},
 		}, true),
@@ -79,6 +79,9 @@ var confdRunOnce = flagSet.Bool("confd-run-once", false, "Run confd in oneshot m
 var confdKeep = flagSet.Bool("confd-keep-stage-file", false, "Keep stage file when running confd")
 var confdConfDir = flagSet.String("confd-confdir", "/etc/calico/confd", "Confd configuration directory.")
 
+// Native BGP daemon flags
+var runBGPDaemon = flagSet.Bool("bgp-daemon", false, "Run the native BGP daemon instead of BIRD and confd")
+
 // non-root hostpath init flags
 var initHostpaths = flagSet.Bool("hostpath-init", false, "Initialize hostpaths for non-root access")
 
@@ -99,7 +102,7 @@ func main() {
 
 	// Perform some validation on the parsed flags. Only one of the following may be
 	// specified at a time.
-	onlyOne := []*bool{version, runFelix, runStartup, runConfd, monitorAddrs}
+	onlyOne := []*bool{version, runFelix, runStartup, runConfd, runBGPDaemon, monitorAddrs}
 	oneSelected := false
 	for _, o := range onlyOne {
 		if oneSelected && *o {
@@ -155,6 +158,9 @@ func main() {
 		cfg.KeepStageFile = *confdKeep
 		cfg.Onetime = *confdRunOnce
 		confd.Run(cfg)
+	} else if *runBGPDaemon {
+		logrus.SetFormatter(&logutils.Formatter{Component: "bgp-daemon"})
+		runBGPDaemonCmd()
 	} else if *runAllocateTunnelAddrs {
 		logrus.SetFormatter(&logutils.Formatter{Component: "tunnel-ip-allocator"})
 		if *allocateTunnelAddrsRunOnce {
@@ -1519,6 +1519,14 @@ func (c shimClient) BGPFilter() client.BGPFilterInterface {
 	return c.client.BGPFilter()
 }
 
+func (c shimClient) RoutePolicies() client.RoutePolicyInterface {
+	return c.client.RoutePolicies()
+}
+
+func (c shimClient) IPPoolMigrations() client.IPPoolMigrationInterface {
+	return c.client.IPPoolMigrations()
+}
+
 // BGPPeers returns an interface for managing BGP peer resources.
 func (c shimClient) BGPPeers() client.BGPPeerInterface {
 	return c.client.BGPPeers()
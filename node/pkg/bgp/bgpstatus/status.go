@@ -0,0 +1,145 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bgpstatus is the status interface of the native BGP daemon.  The daemon serves its
+// status as JSON on a Unix socket, next to where BIRD's control sockets would be, for the status
+// reporter, the health checks, the BGP metrics and calicoctl.
+package bgpstatus
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// SocketName is the name of the status socket, in the same directory as BIRD's sockets.
+	SocketName = "bgp-daemon.ctl"
+
+	// Peer types, which match the prefixes of the BIRD protocol names generated by confd.
+	PeerTypeMesh   = "Mesh"
+	PeerTypeGlobal = "Global"
+	PeerTypeNode   = "Node"
+
+	// Sources of the routes originated by the daemon.
+	SourceKernel = "kernel"
+	SourceStatic = "static"
+)
+
+// SocketPath is the path of the status socket in calico/node.
+var SocketPath = filepath.Join("/var/run/calico", SocketName)
+
+// timeout bounds reading and writing the status.
+var timeout = 2 * time.Second
+
+// Status is the status of the daemon.
+type Status struct {
+	Version  string `json:"version"`
+	RouterID string `json:"routerID"`
+	AS       uint32 `json:"as"`
+	// Started is when the daemon started, and LastReconfiguration when it was last configured.
+	Started             time.Time `json:"started"`
+	LastReconfiguration time.Time `json:"lastReconfiguration,omitempty"`
+	// InSync is set once the daemon has been configured, and has given the peers that it had
+	// before restarting the time to restore their routes.
+	InSync bool    `json:"inSync"`
+	Peers  []Peer  `json:"peers"`
+	Routes []Route `json:"routes"`
+}
+
+// Peer is the status of a BGP peer.
+type Peer struct {
+	Address string `json:"address"`
+	AS      uint32 `json:"as"`
+	// Type is one of the PeerType values.
+	Type string `json:"type"`
+	// State is the state of the session, as named in RFC 4271, and Since is when the session
+	// was last established or closed.
+	State string    `json:"state"`
+	Since time.Time `json:"since,omitempty"`
+	// Number of prefixes imported from and exported to the peer.
+	Imported int `json:"imported"`
+	Exported int `json:"exported"`
+}
+
+// Established returns true if the session with the peer is established.
+func (p *Peer) Established() bool {
+	return p.State == "Established"
+}
+
+// Route is the best route to a prefix.
+type Route struct {
+	Prefix  string `json:"prefix"`
+	NextHop string `json:"nextHop,omitempty"`
+	// Peer is the address of the peer that the route was learned from, or empty if the route is
+	// originated by the daemon from the given Source.
+	Peer   string `json:"peer,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
+// Serve serves the status returned by get on the socket at path, until stop is closed.  Each
+// connection is sent the status and then closed.
+func Serve(path string, get func() *Status, stop <-chan struct{}) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-stop
+		l.Close()
+	}()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			select {
+			case <-stop:
+				return nil
+			default:
+			}
+			return err
+		}
+		_ = conn.SetWriteDeadline(time.Now().Add(timeout))
+		if err := json.NewEncoder(conn).Encode(get()); err != nil {
+			log.WithError(err).Debug("Failed to write BGP daemon status")
+		}
+		conn.Close()
+	}
+}
+
+// ErrNotRunning is returned by Read if the daemon isn't running.
+var ErrNotRunning = errors.New("the native BGP daemon is not running")
+
+// Read reads the status from the socket at path.
+func Read(path string) (*Status, error) {
+	conn, err := net.DialTimeout("unix", path, timeout)
+	if err != nil {
+		log.WithError(err).WithField("path", path).Debug("Failed to connect to the BGP daemon status socket")
+		return nil, ErrNotRunning
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+	var status Status
+	if err := json.NewDecoder(conn).Decode(&status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
@@ -0,0 +1,902 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	log "github.com/sirupsen/logrus"
+
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector"
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
+	"github.com/projectcalico/calico/node/pkg/bgp/speaker"
+)
+
+const (
+	defaultASNumber = 64512
+
+	globalConfigName        = "default"
+	perNodeConfigNamePrefix = "node."
+
+	// excludeServiceAdvertisementLabel excludes a node from advertising the service address
+	// ranges.
+	excludeServiceAdvertisementLabel = "node.kubernetes.io/exclude-from-external-load-balancers"
+)
+
+// state holds the resources from the BGP syncer.
+type state struct {
+	nodename string
+
+	nodes      map[string]*libapiv3.Node
+	bgpConfigs map[string]*apiv3.BGPConfiguration
+	bgpPeers   map[string]*apiv3.BGPPeer
+	bgpFilters map[string]*apiv3.BGPFilter
+	pools      map[string]*model.IPPool
+	blocks     map[string]*model.BlockAffinity
+
+	// services are the Kubernetes services, keyed on namespace/name, if they are watched.
+	services map[string]service
+	// workloads are the workload endpoints on this node, keyed on the namespace/name of their pod,
+	// if they are watched.
+	workloads map[string][]workload
+	// secrets are the data of the secrets that hold the BGP passwords, keyed on the secret name.
+	secrets map[string]map[string][]byte
+}
+
+func newState(nodename string) *state {
+	return &state{
+		nodename:   nodename,
+		nodes:      map[string]*libapiv3.Node{},
+		bgpConfigs: map[string]*apiv3.BGPConfiguration{},
+		bgpPeers:   map[string]*apiv3.BGPPeer{},
+		bgpFilters: map[string]*apiv3.BGPFilter{},
+		pools:      map[string]*model.IPPool{},
+		blocks:     map[string]*model.BlockAffinity{},
+	}
+}
+
+// onUpdate applies an update from the syncer.
+func (st *state) onUpdate(u api.Update) {
+	deleted := u.UpdateType == api.UpdateTypeKVDeleted || u.Value == nil
+	switch k := u.Key.(type) {
+	case model.ResourceKey:
+		switch k.Kind {
+		case libapiv3.KindNode:
+			updateMap(st.nodes, k.Name, u.Value, deleted)
+		case apiv3.KindBGPConfiguration:
+			updateMap(st.bgpConfigs, k.Name, u.Value, deleted)
+		case apiv3.KindBGPPeer:
+			updateMap(st.bgpPeers, k.Name, u.Value, deleted)
+		case apiv3.KindBGPFilter:
+			updateMap(st.bgpFilters, k.Name, u.Value, deleted)
+		default:
+			log.WithField("key", k).Debug("Ignoring update for unexpected resource")
+		}
+	case model.IPPoolKey:
+		updateMap(st.pools, k.CIDR.String(), u.Value, deleted)
+	case model.BlockAffinityKey:
+		if k.Host != st.nodename {
+			return
+		}
+		updateMap(st.blocks, k.CIDR.String(), u.Value, deleted)
+	default:
+		log.WithField("key", u.Key).Debug("Ignoring update for unexpected key")
+	}
+}
+
+func updateMap[T any](m map[string]*T, name string, value interface{}, deleted bool) {
+	if deleted {
+		delete(m, name)
+		return
+	}
+	v, ok := value.(*T)
+	if !ok {
+		log.WithField("name", name).Warnf("Ignoring update with unexpected value type %T", value)
+		return
+	}
+	m[name] = v
+}
+
+// nodeAddresses are the BGP addresses of a node.
+type nodeAddresses struct {
+	// v4 and v6 hold the address and the network of the node; either may be invalid.
+	v4, v6 netip.Prefix
+}
+
+func (n nodeAddresses) forFamily(ipv6 bool) netip.Prefix {
+	if ipv6 {
+		return n.v6
+	}
+	return n.v4
+}
+
+func parseNodeAddress(cidr string) netip.Prefix {
+	if cidr == "" {
+		return netip.Prefix{}
+	}
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		// Older nodes may have an address without a prefix length.
+		addr, err := netip.ParseAddr(cidr)
+		if err != nil {
+			log.WithError(err).WithField("address", cidr).Warn("Ignoring invalid node address")
+			return netip.Prefix{}
+		}
+		return netip.PrefixFrom(addr, addr.BitLen())
+	}
+	return prefix
+}
+
+func (st *state) nodeAddresses(node *libapiv3.Node) nodeAddresses {
+	if node == nil || node.Spec.BGP == nil {
+		return nodeAddresses{}
+	}
+	return nodeAddresses{
+		v4: parseNodeAddress(node.Spec.BGP.IPv4Address),
+		v6: parseNodeAddress(node.Spec.BGP.IPv6Address),
+	}
+}
+
+func (st *state) globalAS() uint32 {
+	if c := st.bgpConfigs[globalConfigName]; c != nil && c.Spec.ASNumber != nil {
+		return uint32(*c.Spec.ASNumber)
+	}
+	return defaultASNumber
+}
+
+func (st *state) nodeAS(node *libapiv3.Node) uint32 {
+	if node != nil && node.Spec.BGP != nil && node.Spec.BGP.ASNumber != nil {
+		return uint32(*node.Spec.BGP.ASNumber)
+	}
+	return st.globalAS()
+}
+
+func (st *state) clusterID(node *libapiv3.Node) string {
+	if node == nil || node.Spec.BGP == nil {
+		return ""
+	}
+	return node.Spec.BGP.RouteReflectorClusterID
+}
+
+// listenPort returns the BGP port of the node, or 0 for the default port.
+func (st *state) listenPort(nodename string) uint16 {
+	if c := st.bgpConfigs[perNodeConfigNamePrefix+nodename]; c != nil && c.Spec.ListenPort != 0 {
+		return c.Spec.ListenPort
+	}
+	if c := st.bgpConfigs[globalConfigName]; c != nil {
+		return c.Spec.ListenPort
+	}
+	return 0
+}
+
+// nodesMatching returns the names of the nodes that match the selector, in order.
+func (st *state) nodesMatching(sel string) []string {
+	parsed, err := selector.Parse(sel)
+	if err != nil {
+		log.WithError(err).WithField("selector", sel).Warn("Ignoring invalid selector")
+		return nil
+	}
+	var names []string
+	for name, node := range st.nodes {
+		if parsed.Evaluate(node.Labels) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// routerID returns the BGP router ID.  routerIDEnv is the value of CALICO_ROUTER_ID, which may be
+// an address or "hash".
+func routerID(routerIDEnv, nodename string, addrs nodeAddresses) (netip.Addr, error) {
+	switch {
+	case routerIDEnv == "hash":
+		return hashToIPv4(nodename), nil
+	case routerIDEnv != "":
+		id, err := netip.ParseAddr(routerIDEnv)
+		if err != nil || !id.Is4() {
+			return netip.Addr{}, fmt.Errorf("invalid router ID %q", routerIDEnv)
+		}
+		return id, nil
+	case addrs.v4.IsValid():
+		return addrs.v4.Addr(), nil
+	default:
+		// An IPv6-only node needs a router ID that isn't its address.
+		return hashToIPv4(nodename), nil
+	}
+}
+
+// hashToIPv4 hashes the node name into an IPv4 address, as BIRD's configuration does for
+// CALICO_ROUTER_ID=hash.
+func hashToIPv4(nodename string) netip.Addr {
+	hash := sha256.Sum256([]byte(nodename))
+	ip := [4]byte(hash[:4])
+	// BGP doesn't allow router IDs in special IP ranges (e.g., 224.x.x.x).
+	if ip[0] > 223 {
+		ip[0] -= 32
+	}
+	return netip.AddrFrom4(ip)
+}
+
+// peer is a BGP peering computed from the BGPPeer resources and the node-to-node mesh.
+type peer struct {
+	addr netip.Addr
+	port uint16
+	as   uint32
+
+	rrClusterID string
+	// calicoNode is set if the peer is a Calico node.
+	calicoNode bool
+	// peerType is how the peering is configured, as one of the bgpstatus.PeerType values.
+	peerType    string
+	sourceAddr  apiv3.SourceAddress
+	keepNextHop bool
+	restartTime time.Duration
+	allowLocal  int
+	filters     []string
+	password    *apiv3.BGPPassword
+	ttlSecurity uint8
+	reachableBy string
+	families    []apiv3.BGPAddressFamily
+}
+
+func parseIPPort(ipPort string) (string, uint16) {
+	host, port, err := net.SplitHostPort(ipPort)
+	if err != nil {
+		return ipPort, 0
+	}
+	p, err := strconv.ParseUint(port, 0, 16)
+	if err != nil {
+		return ipPort, 0
+	}
+	return host, uint16(p)
+}
+
+// nodeAsPeers returns the peerings with each of the node's addresses in the given families.
+func (st *state) nodeAsPeers(nodename string, v4, v6 bool) []*peer {
+	node := st.nodes[nodename]
+	addrs := st.nodeAddresses(node)
+	var peers []*peer
+	for _, a := range []struct {
+		include bool
+		prefix  netip.Prefix
+	}{{v4, addrs.v4}, {v6, addrs.v6}} {
+		if !a.include || !a.prefix.IsValid() {
+			continue
+		}
+		peers = append(peers, &peer{
+			addr:        a.prefix.Addr(),
+			port:        st.listenPort(nodename),
+			as:          st.nodeAS(node),
+			rrClusterID: st.clusterID(node),
+			calicoNode:  true,
+		})
+	}
+	return peers
+}
+
+// sessionFamilies returns the families of the node addresses to peer with for a BGPPeer with a
+// peer selector: a peering that exchanges several address families is made over a single session,
+// with the address in the first of them.
+func sessionFamilies(families []apiv3.BGPAddressFamily) (v4, v6 bool) {
+	if len(families) == 0 {
+		return true, true
+	}
+	return families[0] == apiv3.BGPAddressFamilyIPv4, families[0] == apiv3.BGPAddressFamilyIPv6
+}
+
+// isCalicoNode returns true if the address is one of the nodes' BGP addresses.
+func (st *state) isCalicoNode(addr netip.Addr) bool {
+	for _, node := range st.nodes {
+		addrs := st.nodeAddresses(node)
+		if (addrs.v4.IsValid() && addrs.v4.Addr() == addr) || (addrs.v6.IsValid() && addrs.v6.Addr() == addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodesWithIPPortAndAS returns the nodes with the address and AS of a BGPPeer, and the port, if
+// it is set.
+func (st *state) nodesWithIPPortAndAS(addr netip.Addr, as uint32, port uint16) []string {
+	if as == 0 {
+		as = st.globalAS()
+	}
+	var names []string
+	for name, node := range st.nodes {
+		addrs := st.nodeAddresses(node)
+		if !(addrs.v4.IsValid() && addrs.v4.Addr() == addr) && !(addrs.v6.IsValid() && addrs.v6.Addr() == addr) {
+			continue
+		}
+		if st.nodeAS(node) != as {
+			continue
+		}
+		if port != 0 && st.listenPort(name) != 0 && st.listenPort(name) != port {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (st *state) setPeerFields(peers []*peer, res *apiv3.BGPPeer, peerType string) {
+	for _, p := range peers {
+		p.peerType = peerType
+		p.password = res.Spec.Password
+		p.sourceAddr = res.Spec.SourceAddress
+		if p.sourceAddr == "" {
+			p.sourceAddr = apiv3.SourceAddressUseNodeIP
+		}
+		if res.Spec.MaxRestartTime != nil {
+			p.restartTime = res.Spec.MaxRestartTime.Duration
+		}
+		p.filters = res.Spec.Filters
+		if res.Spec.TTLSecurity != nil {
+			p.ttlSecurity = *res.Spec.TTLSecurity
+		}
+		p.reachableBy = res.Spec.ReachableBy
+		p.families = res.Spec.AddressFamilies
+	}
+}
+
+// explicitPeers returns the peerings of this node that are configured by the BGPPeer resources,
+// following the same rules as confd: peerings that apply to all nodes take precedence over
+// node-specific ones, and a peering from another node to this one implies the reverse peering.
+func (st *state) explicitPeers() []*peer {
+	type peerKey struct {
+		addr netip.Addr
+		port uint16
+	}
+	var peers []*peer
+	seen := map[peerKey]bool{}
+	emit := func(p *peer) {
+		k := peerKey{p.addr, p.port}
+		if seen[k] {
+			return
+		}
+		seen[k] = true
+		peers = append(peers, p)
+	}
+
+	names := make([]string, 0, len(st.bgpPeers))
+	for name := range st.bgpPeers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, globalPass := range []bool{true, false} {
+		for _, name := range names {
+			res := st.bgpPeers[name]
+			if globalPass != (res.Spec.NodeSelector == "" && res.Spec.Node == "") {
+				continue
+			}
+			if res.Spec.NodeSelector != "" && !slices.Contains(st.nodesMatching(res.Spec.NodeSelector), st.nodename) {
+				continue
+			}
+			if res.Spec.Node != "" && res.Spec.Node != st.nodename {
+				continue
+			}
+
+			var ps []*peer
+			if res.Spec.PeerSelector != "" {
+				v4, v6 := sessionFamilies(res.Spec.AddressFamilies)
+				for _, peerNode := range st.nodesMatching(res.Spec.PeerSelector) {
+					ps = append(ps, st.nodeAsPeers(peerNode, v4, v6)...)
+				}
+			} else {
+				host, port := parseIPPort(res.Spec.PeerIP)
+				addr, err := netip.ParseAddr(host)
+				if err != nil {
+					log.WithField("peer", name).Error("PeerIP is not assigned or is malformed")
+					continue
+				}
+				if port == 0 {
+					if nodes := st.nodesWithIPPortAndAS(addr, uint32(res.Spec.ASNumber), port); len(nodes) > 0 {
+						port = st.listenPort(nodes[0])
+					}
+				}
+				p := &peer{
+					addr:        addr,
+					port:        port,
+					as:          uint32(res.Spec.ASNumber),
+					calicoNode:  st.isCalicoNode(addr),
+					keepNextHop: res.Spec.KeepOriginalNextHop,
+				}
+				if res.Spec.NumAllowedLocalASNumbers != nil {
+					p.allowLocal = int(*res.Spec.NumAllowedLocalASNumbers)
+				}
+				ps = append(ps, p)
+			}
+			peerType := bgpstatus.PeerTypeNode
+			if globalPass {
+				peerType = bgpstatus.PeerTypeGlobal
+			}
+			st.setPeerFields(ps, res, peerType)
+			for _, p := range ps {
+				emit(p)
+			}
+		}
+	}
+
+	// Add the reverse of the peerings from other nodes to this one.
+	for _, name := range names {
+		res := st.bgpPeers[name]
+		// Peerings on a label selector are reversed over IPv4 and IPv6, or over the session family
+		// if they list address families; peerings on an IP only over the same IP version.
+		includeV4, includeV6 := sessionFamilies(res.Spec.AddressFamilies)
+		var localNodes []string
+		if res.Spec.PeerSelector != "" {
+			localNodes = st.nodesMatching(res.Spec.PeerSelector)
+		} else {
+			host, port := parseIPPort(res.Spec.PeerIP)
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				continue
+			}
+			localNodes = st.nodesWithIPPortAndAS(addr, uint32(res.Spec.ASNumber), port)
+			includeV4, includeV6 = addr.Is4(), addr.Is6()
+		}
+		if !slices.Contains(localNodes, st.nodename) {
+			continue
+		}
+
+		var peerNodes []string
+		switch {
+		case res.Spec.NodeSelector != "":
+			peerNodes = st.nodesMatching(res.Spec.NodeSelector)
+		case res.Spec.Node != "":
+			peerNodes = []string{res.Spec.Node}
+		default:
+			peerNodes = st.nodesMatching("all()")
+		}
+		var ps []*peer
+		for _, peerNode := range peerNodes {
+			ps = append(ps, st.nodeAsPeers(peerNode, includeV4, includeV6)...)
+		}
+		st.setPeerFields(ps, res, bgpstatus.PeerTypeNode)
+		for _, p := range ps {
+			emit(p)
+		}
+	}
+	return peers
+}
+
+// parseCommunities parses community values, and the names of the defined communities, into
+// standard and large communities.
+func parseCommunities(values []string, defined []apiv3.Community) ([]uint32, []speaker.LargeCommunity) {
+	var communities []uint32
+	var large []speaker.LargeCommunity
+	for _, v := range values {
+		for _, d := range defined {
+			if d.Name == v {
+				v = d.Value
+				break
+			}
+		}
+		var parts []uint32
+		for _, s := range strings.Split(v, ":") {
+			n, err := strconv.ParseUint(s, 10, 32)
+			if err != nil {
+				parts = nil
+				break
+			}
+			parts = append(parts, uint32(n))
+		}
+		switch {
+		case len(parts) == 2 && parts[0] <= 0xffff && parts[1] <= 0xffff:
+			communities = append(communities, parts[0]<<16|parts[1])
+		case len(parts) == 3:
+			large = append(large, speaker.LargeCommunity{Global: parts[0], Local1: parts[1], Local2: parts[2]})
+		default:
+			log.WithField("community", v).Warn("Ignoring invalid BGP community")
+		}
+	}
+	return communities, large
+}
+
+func parseCIDRs(cidrs []string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, c := range cidrs {
+		prefix, err := netip.ParsePrefix(c)
+		if err != nil {
+			log.WithError(err).WithField("cidr", c).Warn("Ignoring invalid CIDR")
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
+}
+
+// daemonConfig is the configuration computed from the datastore: the configuration of the
+// speaker, and how to program the routes that it learns.
+type daemonConfig struct {
+	speaker speaker.Config
+	export  *exportPolicy
+	kernel  *kernelPolicy
+	// watchServices is set if any service addresses are advertised, so the Kubernetes services
+	// are needed.
+	watchServices bool
+	// watchWorkloads is set if any workload addresses are advertised, so the pods on this node are
+	// needed.
+	watchWorkloads bool
+	// secrets are the names of the secrets that hold the BGP passwords.
+	secrets []string
+	// peerTypes are the bgpstatus.PeerType values of the peers.
+	peerTypes map[netip.Addr]string
+	// unsupported describes the configuration that the daemon can't honour.  The daemon isn't
+	// ready while there is any.
+	unsupported []string
+}
+
+// config computes the configuration of the speaker.  kernelRoutes are the routes learned from the
+// kernel, which the speaker originates along with the blocks and service address ranges.
+func (st *state) config(routerIDEnv string, kernelRoutes map[netip.Prefix]kernelRoute) (*daemonConfig, error) {
+	node := st.nodes[st.nodename]
+	if node == nil || node.Spec.BGP == nil {
+		return nil, fmt.Errorf("node %s has no BGP configuration", st.nodename)
+	}
+	addrs := st.nodeAddresses(node)
+	id, err := routerID(routerIDEnv, st.nodename, addrs)
+	if err != nil {
+		return nil, err
+	}
+	as := st.nodeAS(node)
+	global := st.bgpConfigs[globalConfigName]
+	if global == nil {
+		global = &apiv3.BGPConfiguration{}
+	}
+	nodeConfig := st.bgpConfigs[perNodeConfigNamePrefix+st.nodename]
+
+	cfg := &daemonConfig{
+		speaker: speaker.Config{
+			RouterID:  id,
+			AS:        as,
+			NextHopV4: addrs.v4.Addr(),
+			NextHopV6: addrs.v6.Addr(),
+		},
+		export:    &exportPolicy{kernelRoutes: kernelRoutes},
+		peerTypes: map[netip.Addr]string{},
+		kernel: &kernelPolicy{
+			networkV4: addrs.v4.Masked(),
+			networkV6: addrs.v6.Masked(),
+			static:    map[netip.Prefix]netip.Addr{},
+		},
+	}
+	clusterID := st.clusterID(node)
+	if clusterID != "" {
+		cfg.speaker.ClusterID, err = netip.ParseAddr(clusterID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route reflector cluster ID %q", clusterID)
+		}
+	}
+
+	listenHost := ""
+	if global.Spec.BindMode != nil && *global.Spec.BindMode == apiv3.BindModeNodeIP {
+		if addrs.v4.IsValid() {
+			listenHost = addrs.v4.Addr().String()
+		} else if addrs.v6.IsValid() {
+			listenHost = addrs.v6.Addr().String()
+		}
+	}
+	listenPort := st.listenPort(st.nodename)
+	if listenPort == 0 {
+		listenPort = speaker.DefaultPort
+	}
+	cfg.speaker.ListenAddress = net.JoinHostPort(listenHost, strconv.Itoa(int(listenPort)))
+
+	// Pools, blocks and the kernel programming policy.
+	poolCIDRs := make([]string, 0, len(st.pools))
+	for c := range st.pools {
+		poolCIDRs = append(poolCIDRs, c)
+	}
+	sort.Strings(poolCIDRs)
+	for _, c := range poolCIDRs {
+		p := st.pools[c]
+		prefix, err := netip.ParsePrefix(p.CIDR.String())
+		if err != nil {
+			continue
+		}
+		cfg.export.pools = append(cfg.export.pools, pool{
+			CIDR:             prefix.Masked(),
+			IPIPInterface:    p.IPIPInterface,
+			IPIPMode:         p.IPIPMode,
+			VXLANMode:        p.VXLANMode,
+			DisableBGPExport: p.DisableBGPExport,
+		})
+	}
+	cfg.kernel.pools = cfg.export.pools
+
+	var blockCIDRs []string
+	for c, b := range st.blocks {
+		if b.State == "" || b.State == model.StateConfirmed {
+			blockCIDRs = append(blockCIDRs, c)
+		}
+	}
+	sort.Strings(blockCIDRs)
+	for _, block := range parseCIDRs(blockCIDRs) {
+		cfg.export.blocks = append(cfg.export.blocks, block)
+		if block.Bits() < block.Addr().BitLen() {
+			cfg.speaker.Routes = append(cfg.speaker.Routes, speaker.LocalRoute{Prefix: block})
+			cfg.kernel.blackholes = append(cfg.kernel.blackholes, block)
+		}
+	}
+
+	// The service address ranges are advertised from every node that isn't excluded, and the
+	// routes to them learned from other nodes are never programmed.
+	var serviceCIDRs, clusterCIDRs, externalCIDRs, lbCIDRs []string
+	for _, b := range global.Spec.ServiceClusterIPs {
+		serviceCIDRs = append(serviceCIDRs, b.CIDR)
+		clusterCIDRs = append(clusterCIDRs, b.CIDR)
+	}
+	for _, b := range global.Spec.ServiceExternalIPs {
+		serviceCIDRs = append(serviceCIDRs, b.CIDR)
+		externalCIDRs = append(externalCIDRs, b.CIDR)
+	}
+	for _, b := range global.Spec.ServiceLoadBalancerIPs {
+		lbCIDRs = append(lbCIDRs, b.CIDR)
+		// Single addresses are advertised per service, by the nodes that host its endpoints.
+		if prefix, err := netip.ParsePrefix(b.CIDR); err == nil && prefix.Bits() < prefix.Addr().BitLen() {
+			serviceCIDRs = append(serviceCIDRs, b.CIDR)
+		}
+	}
+	cfg.kernel.reject = parseCIDRs(serviceCIDRs)
+	cfg.watchServices = len(clusterCIDRs) > 0 || len(externalCIDRs) > 0 || len(lbCIDRs) > 0
+	if node.Labels[excludeServiceAdvertisementLabel] != "true" {
+		cfg.export.staticRoutes = slices.Clone(cfg.kernel.reject)
+
+		// The addresses of the individual services that this node advertises.
+		clusterRanges, externalRanges, lbRanges := parseCIDRs(clusterCIDRs), parseCIDRs(externalCIDRs), parseCIDRs(lbCIDRs)
+		keys := make([]string, 0, len(st.services))
+		for key := range st.services {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			svc := st.services[key]
+			for _, prefix := range svc.serviceRoutes(clusterRanges, externalRanges, lbRanges) {
+				if !slices.Contains(cfg.export.staticRoutes, prefix) {
+					cfg.export.staticRoutes = append(cfg.export.staticRoutes, prefix)
+				}
+			}
+		}
+		for _, prefix := range cfg.export.staticRoutes {
+			cfg.speaker.Routes = append(cfg.speaker.Routes, speaker.LocalRoute{Prefix: prefix})
+		}
+	}
+	if clusterID != "" {
+		cfg.export.loadBalancerRanges = parseCIDRs(lbCIDRs)
+	}
+
+	// Communities are added to the routes within the prefix advertisements of the node's
+	// BGPConfiguration if it has any, and otherwise of the default BGPConfiguration.
+	advertConfig := global
+	if nodeConfig != nil && len(nodeConfig.Spec.PrefixAdvertisements) > 0 {
+		advertConfig = nodeConfig
+	}
+	for _, pa := range advertConfig.Spec.PrefixAdvertisements {
+		prefixes := parseCIDRs([]string{pa.CIDR})
+		if len(prefixes) == 0 {
+			continue
+		}
+		communities, large := parseCommunities(pa.Communities, advertConfig.Spec.Communities)
+		cfg.export.prefixAdvertisements = append(cfg.export.prefixAdvertisements, prefixAdvertisement{
+			CIDR:             prefixes[0],
+			Communities:      communities,
+			LargeCommunities: large,
+		})
+	}
+
+	// The addresses of the local workloads that are selected by the workload advertisements of the
+	// default BGPConfiguration.  They are exported with the routes to the workloads that Felix
+	// programs, so they are withdrawn as soon as a workload leaves the node.
+	cfg.watchWorkloads = len(global.Spec.WorkloadAdvertisements) > 0
+	if cfg.watchWorkloads {
+		cfg.export.workloadRoutes = st.workloadRoutes(global)
+	}
+
+	// Routes learned from the kernel are originated, and exported as the policy allows.
+	for prefix := range kernelRoutes {
+		if !slices.ContainsFunc(cfg.speaker.Routes, func(r speaker.LocalRoute) bool { return r.Prefix == prefix }) {
+			cfg.speaker.Routes = append(cfg.speaker.Routes, speaker.LocalRoute{Prefix: prefix})
+		}
+	}
+
+	// The node-to-node mesh, which is disabled on route reflectors.
+	meshEnabled := global.Spec.NodeToNodeMeshEnabled == nil || *global.Spec.NodeToNodeMeshEnabled
+	var peers []*peer
+	if clusterID == "" && meshEnabled {
+		var meshRestartTime time.Duration
+		if global.Spec.NodeMeshMaxRestartTime != nil {
+			meshRestartTime = global.Spec.NodeMeshMaxRestartTime.Duration
+		}
+		names := make([]string, 0, len(st.nodes))
+		for name := range st.nodes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if name == st.nodename || st.clusterID(st.nodes[name]) != "" {
+				continue
+			}
+			for _, p := range st.nodeAsPeers(name, addrs.v4.IsValid(), addrs.v6.IsValid()) {
+				p.sourceAddr = apiv3.SourceAddressUseNodeIP
+				p.restartTime = meshRestartTime
+				p.password = global.Spec.NodeMeshPassword
+				p.peerType = bgpstatus.PeerTypeMesh
+				peers = append(peers, p)
+			}
+		}
+	}
+	peers = append(peers, st.explicitPeers()...)
+
+	filters := map[string]*bgpFilter{}
+	for name, f := range st.bgpFilters {
+		filters[name] = newBGPFilter(f)
+	}
+	seen := map[netip.Addr]bool{}
+	for _, p := range peers {
+		local := addrs.forFamily(p.addr.Is6())
+		if !local.IsValid() || p.addr == local.Addr() || seen[p.addr] {
+			continue
+		}
+		password := ""
+		if p.password != nil && p.password.SecretKeyRef != nil {
+			ref := p.password.SecretKeyRef
+			if !slices.Contains(cfg.secrets, ref.Name) {
+				cfg.secrets = append(cfg.secrets, ref.Name)
+			}
+			if data, ok := st.secrets[ref.Name]; !ok {
+				// As for confd, the peering is made without the password until the secret can be
+				// read.
+				log.WithFields(log.Fields{"peer": p.addr, "secret": ref.Name}).Warn("BGP password secret isn't available, peering without a password")
+			} else if value, ok := data[ref.Key]; !ok {
+				log.WithFields(log.Fields{"peer": p.addr, "secret": ref.Name, "key": ref.Key}).Warn("BGP password secret doesn't have the key, peering without a password")
+			} else if len(value) > speaker.MaxPasswordLen {
+				cfg.unsupported = append(cfg.unsupported,
+					fmt.Sprintf("peer %s has a BGP password longer than %d characters", p.addr, speaker.MaxPasswordLen))
+				continue
+			} else {
+				password = string(value)
+			}
+		}
+		if p.reachableBy != "" {
+			// The peer is reached through a static route, as for confd.
+			gw, err := netip.ParseAddr(p.reachableBy)
+			if err != nil || gw.Is6() != p.addr.Is6() {
+				log.WithField("peer", p.addr).Errorf("Skipping peer with invalid reachableBy address %q", p.reachableBy)
+				continue
+			}
+			cfg.kernel.static[netip.PrefixFrom(p.addr, p.addr.BitLen())] = gw
+		}
+		seen[p.addr] = true
+		pc := st.peerConfig(p, as, local.Addr(), clusterID, cfg.export, filters)
+		pc.Password = password
+		cfg.speaker.Peers = append(cfg.speaker.Peers, pc)
+		cfg.peerTypes[p.addr] = p.peerType
+	}
+	return cfg, nil
+}
+
+// workloadRoutes returns the addresses of the local workloads that are selected by the workload
+// advertisements, with the communities to add to each, as for confd.
+func (st *state) workloadRoutes(global *apiv3.BGPConfiguration) map[netip.Prefix]prefixAdvertisement {
+	type workloadAdvertisement struct {
+		selector    selector.Selector
+		communities []uint32
+		large       []speaker.LargeCommunity
+	}
+	var advertisements []workloadAdvertisement
+	for _, wa := range global.Spec.WorkloadAdvertisements {
+		sel, err := selector.Parse(wa.Selector)
+		if err != nil {
+			log.WithError(err).WithField("selector", wa.Selector).Warn("Ignoring workload advertisement with invalid selector")
+			continue
+		}
+		communities, large := parseCommunities(wa.Communities, global.Spec.Communities)
+		advertisements = append(advertisements, workloadAdvertisement{sel, communities, large})
+	}
+
+	routes := map[netip.Prefix]prefixAdvertisement{}
+	for _, workloads := range st.workloads {
+		for _, w := range workloads {
+			for _, wa := range advertisements {
+				if !wa.selector.Evaluate(w.Labels) {
+					continue
+				}
+				for _, prefix := range parseCIDRs(w.Prefixes) {
+					r := routes[prefix]
+					r.CIDR = prefix
+					for _, c := range wa.communities {
+						if !slices.Contains(r.Communities, c) {
+							r.Communities = append(r.Communities, c)
+						}
+					}
+					for _, c := range wa.large {
+						if !slices.Contains(r.LargeCommunities, c) {
+							r.LargeCommunities = append(r.LargeCommunities, c)
+						}
+					}
+					routes[prefix] = r
+				}
+			}
+		}
+	}
+	return routes
+}
+
+// peerConfig returns the speaker configuration for a peering.
+func (st *state) peerConfig(p *peer, as uint32, local netip.Addr, clusterID string,
+	export *exportPolicy, filters map[string]*bgpFilter,
+) speaker.PeerConfig {
+	pc := speaker.PeerConfig{
+		Address:        p.addr,
+		Port:           p.port,
+		AS:             p.as,
+		AllowedLocalAS: p.allowLocal,
+	}
+	for _, f := range p.families {
+		switch f {
+		case apiv3.BGPAddressFamilyIPv4:
+			pc.Families = append(pc.Families, speaker.FamilyIPv4Unicast)
+		case apiv3.BGPAddressFamilyIPv6:
+			pc.Families = append(pc.Families, speaker.FamilyIPv6Unicast)
+		}
+	}
+	if len(pc.Families) == 0 {
+		pc.Families = []speaker.Family{speaker.FamilyOf(netip.PrefixFrom(p.addr, 0))}
+	}
+	if pc.AS == 0 {
+		pc.AS = st.globalAS()
+	}
+	if p.sourceAddr == apiv3.SourceAddressUseNodeIP {
+		pc.LocalAddress = local
+	}
+	// Peerings between Calico nodes are made by only one of them, to avoid aborting a graceful
+	// restart.  The comparison is the same as BIRD's, so that this works between nodes running
+	// either.
+	if p.calicoNode && p.addr.String() > local.String() {
+		pc.Passive = true
+	}
+	if pc.AS == as && clusterID != "" && p.rrClusterID != clusterID {
+		pc.RouteReflectorClient = true
+	}
+	if pc.AS != as {
+		pc.KeepOriginalNextHop = p.keepNextHop
+	}
+	pc.RestartTime = p.restartTime
+	pc.TTLSecurity = p.ttlSecurity
+
+	pp := &peerPolicy{export: export, internal: pc.AS == as}
+	for _, name := range p.filters {
+		if f := filters[name]; f != nil {
+			pp.filters = append(pp.filters, f)
+		}
+	}
+	pc.Import, pc.Export = pp.Import, pp.Export
+	return pc
+}
@@ -0,0 +1,485 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"net/netip"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/projectcalico/api/pkg/lib/numorstring"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/encap"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
+	"github.com/projectcalico/calico/node/pkg/bgp/speaker"
+)
+
+func nodeUpdate(name, ipv4 string, labels map[string]string, clusterID string) api.Update {
+	node := libapiv3.NewNode()
+	node.Name = name
+	node.Labels = labels
+	node.Spec.BGP = &libapiv3.NodeBGPSpec{IPv4Address: ipv4, RouteReflectorClusterID: clusterID}
+	return api.Update{
+		KVPair:     model.KVPair{Key: model.ResourceKey{Kind: libapiv3.KindNode, Name: name}, Value: node},
+		UpdateType: api.UpdateTypeKVNew,
+	}
+}
+
+func resourceUpdate(kind, name string, value interface{}) api.Update {
+	return api.Update{
+		KVPair:     model.KVPair{Key: model.ResourceKey{Kind: kind, Name: name}, Value: value},
+		UpdateType: api.UpdateTypeKVNew,
+	}
+}
+
+func peerAddresses(cfg *daemonConfig) []string {
+	var addrs []string
+	for _, pc := range cfg.speaker.Peers {
+		addrs = append(addrs, pc.Address.String())
+	}
+	return addrs
+}
+
+func peerConfigFor(cfg *daemonConfig, addr string) *speaker.PeerConfig {
+	for i := range cfg.speaker.Peers {
+		if cfg.speaker.Peers[i].Address.String() == addr {
+			return &cfg.speaker.Peers[i]
+		}
+	}
+	return nil
+}
+
+func localRoutes(cfg *daemonConfig) []string {
+	var routes []string
+	for _, r := range cfg.speaker.Routes {
+		routes = append(routes, r.Prefix.String())
+	}
+	return routes
+}
+
+var _ = Describe("BGP daemon configuration", func() {
+	var st *state
+
+	BeforeEach(func() {
+		st = newState("node-b")
+		st.onUpdate(nodeUpdate("node-a", "10.0.0.1/24", map[string]string{"rack": "1"}, ""))
+		st.onUpdate(nodeUpdate("node-b", "10.0.0.2/24", map[string]string{"rack": "1"}, ""))
+		st.onUpdate(nodeUpdate("node-c", "10.0.0.3/24", map[string]string{"rack": "2"}, ""))
+	})
+
+	It("should fail without the local node", func() {
+		_, err := newState("node-x").config("", nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should mesh with the other nodes", func() {
+		cfg, err := st.config("", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.speaker.RouterID).To(Equal(netip.MustParseAddr("10.0.0.2")))
+		Expect(cfg.speaker.AS).To(BeEquivalentTo(defaultASNumber))
+		Expect(cfg.speaker.ListenAddress).To(Equal(":179"))
+		Expect(peerAddresses(cfg)).To(ConsistOf("10.0.0.1", "10.0.0.3"))
+
+		// Only the node with the lower address initiates the session.
+		Expect(peerConfigFor(cfg, "10.0.0.1").Passive).To(BeFalse())
+		Expect(peerConfigFor(cfg, "10.0.0.3").Passive).To(BeTrue())
+		Expect(peerConfigFor(cfg, "10.0.0.1").LocalAddress).To(Equal(netip.MustParseAddr("10.0.0.2")))
+		Expect(cfg.peerTypes).To(Equal(map[netip.Addr]string{
+			netip.MustParseAddr("10.0.0.1"): bgpstatus.PeerTypeMesh,
+			netip.MustParseAddr("10.0.0.3"): bgpstatus.PeerTypeMesh,
+		}))
+	})
+
+	It("should not mesh when the mesh is disabled", func() {
+		disabled := false
+		bgpConfig := apiv3.NewBGPConfiguration()
+		bgpConfig.Spec.NodeToNodeMeshEnabled = &disabled
+		st.onUpdate(resourceUpdate(apiv3.KindBGPConfiguration, "default", bgpConfig))
+		cfg, err := st.config("", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.speaker.Peers).To(BeEmpty())
+	})
+
+	It("should use the per-node listen port and the mesh restart time", func() {
+		bgpConfig := apiv3.NewBGPConfiguration()
+		bgpConfig.Spec.NodeMeshMaxRestartTime = &metav1.Duration{Duration: 300e9}
+		st.onUpdate(resourceUpdate(apiv3.KindBGPConfiguration, "default", bgpConfig))
+		nodeConfig := apiv3.NewBGPConfiguration()
+		nodeConfig.Spec.ListenPort = 1790
+		st.onUpdate(resourceUpdate(apiv3.KindBGPConfiguration, "node.node-a", nodeConfig))
+
+		cfg, err := st.config("", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(peerConfigFor(cfg, "10.0.0.1").Port).To(BeEquivalentTo(1790))
+		Expect(peerConfigFor(cfg, "10.0.0.1").RestartTime).To(BeEquivalentTo(300e9))
+		Expect(peerConfigFor(cfg, "10.0.0.3").Port).To(BeZero())
+	})
+
+	It("should use a hashed router ID", func() {
+		cfg, err := st.config("hash", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.speaker.RouterID).To(Equal(hashToIPv4("node-b")))
+		Expect(cfg.speaker.RouterID.As4()[0]).To(BeNumerically("<=", 223))
+
+		_, err = st.config("not-an-ip", nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should originate the confirmed blocks", func() {
+		for cidr, state := range map[string]model.BlockAffinityState{
+			"192.168.1.0/26":  model.StateConfirmed,
+			"192.168.1.64/26": model.StatePending,
+		} {
+			st.onUpdate(api.Update{
+				KVPair: model.KVPair{
+					Key:   model.BlockAffinityKey{CIDR: cnet.MustParseCIDR(cidr), Host: "node-b"},
+					Value: &model.BlockAffinity{State: state},
+				},
+				UpdateType: api.UpdateTypeKVNew,
+			})
+		}
+		st.onUpdate(api.Update{
+			KVPair: model.KVPair{
+				Key:   model.BlockAffinityKey{CIDR: cnet.MustParseCIDR("192.168.2.0/26"), Host: "node-a"},
+				Value: &model.BlockAffinity{State: model.StateConfirmed},
+			},
+			UpdateType: api.UpdateTypeKVNew,
+		})
+		cfg, err := st.config("", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(localRoutes(cfg)).To(ConsistOf("192.168.1.0/26"))
+		Expect(cfg.kernel.blackholes).To(ConsistOf(netip.MustParsePrefix("192.168.1.0/26")))
+	})
+
+	It("should advertise the service ranges unless the node is excluded", func() {
+		bgpConfig := apiv3.NewBGPConfiguration()
+		bgpConfig.Spec.ServiceClusterIPs = []apiv3.ServiceClusterIPBlock{{CIDR: "10.96.0.0/12"}}
+		bgpConfig.Spec.ServiceLoadBalancerIPs = []apiv3.ServiceLoadBalancerIPBlock{{CIDR: "172.16.0.0/24"}, {CIDR: "172.16.1.1/32"}}
+		st.onUpdate(resourceUpdate(apiv3.KindBGPConfiguration, "default", bgpConfig))
+
+		cfg, err := st.config("", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(localRoutes(cfg)).To(ConsistOf("10.96.0.0/12", "172.16.0.0/24"))
+		Expect(cfg.kernel.reject).To(ConsistOf(netip.MustParsePrefix("10.96.0.0/12"), netip.MustParsePrefix("172.16.0.0/24")))
+
+		st.onUpdate(nodeUpdate("node-b", "10.0.0.2/24", map[string]string{excludeServiceAdvertisementLabel: "true"}, ""))
+		cfg, err = st.config("", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(localRoutes(cfg)).To(BeEmpty())
+		Expect(cfg.kernel.reject).To(HaveLen(2))
+	})
+
+	It("should advertise the selected local workloads", func() {
+		bgpConfig := apiv3.NewBGPConfiguration()
+		bgpConfig.Spec.Communities = []apiv3.Community{{Name: "anycast", Value: "65000:300"}}
+		bgpConfig.Spec.WorkloadAdvertisements = []apiv3.WorkloadAdvertisement{
+			{Selector: "app == 'dns'", Communities: []string{"65000:100"}},
+			{Selector: "has(anycast)", Communities: []string{"anycast"}},
+		}
+		st.onUpdate(resourceUpdate(apiv3.KindBGPConfiguration, "default", bgpConfig))
+		st.workloads = map[string][]workload{
+			"kube-system/dns": {{Labels: map[string]string{"app": "dns", "anycast": ""}, Prefixes: []string{"192.168.1.1/32", "fd00::1/128"}}},
+			"default/web":     {{Labels: map[string]string{"app": "web"}, Prefixes: []string{"192.168.1.2/32"}}},
+		}
+
+		cfg, err := st.config("", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.watchWorkloads).To(BeTrue())
+		Expect(cfg.export.workloadRoutes).To(HaveLen(2))
+		r := cfg.export.workloadRoutes[netip.MustParsePrefix("192.168.1.1/32")]
+		Expect(r.Communities).To(ConsistOf(uint32(65000<<16|100), uint32(65000<<16|300)))
+		Expect(cfg.export.workloadRoutes).To(HaveKey(netip.MustParsePrefix("fd00::1/128")))
+	})
+
+	It("should advertise the services with local endpoints", func() {
+		bgpConfig := apiv3.NewBGPConfiguration()
+		bgpConfig.Spec.ServiceClusterIPs = []apiv3.ServiceClusterIPBlock{{CIDR: "10.96.0.0/12"}}
+		bgpConfig.Spec.ServiceExternalIPs = []apiv3.ServiceExternalIPBlock{{CIDR: "192.0.2.0/24"}}
+		bgpConfig.Spec.ServiceLoadBalancerIPs = []apiv3.ServiceLoadBalancerIPBlock{{CIDR: "172.16.0.0/24"}, {CIDR: "172.16.1.1/32"}}
+		st.onUpdate(resourceUpdate(apiv3.KindBGPConfiguration, "default", bgpConfig))
+		st.services = map[string]service{
+			"default/local": {
+				Type:                  k8sv1.ServiceTypeLoadBalancer,
+				ClusterIP:             "10.96.0.10",
+				ExternalIPs:           []string{"192.0.2.1", "198.51.100.1"},
+				IngressIPs:            []string{"172.16.0.1"},
+				ExternalTrafficPolicy: k8sv1.ServiceExternalTrafficPolicyTypeLocal,
+				LocalEndpoint:         true,
+			},
+			"default/remote": {
+				Type:                  k8sv1.ServiceTypeClusterIP,
+				ClusterIP:             "10.96.0.11",
+				ExternalTrafficPolicy: k8sv1.ServiceExternalTrafficPolicyTypeLocal,
+			},
+			"default/single-lb": {
+				Type:                  k8sv1.ServiceTypeLoadBalancer,
+				ClusterIP:             "10.96.0.12",
+				LoadBalancerIP:        "172.16.1.1",
+				IngressIPs:            []string{"172.16.1.1"},
+				ExternalTrafficPolicy: k8sv1.ServiceExternalTrafficPolicyTypeCluster,
+			},
+			"default/headless": {
+				Type:                  k8sv1.ServiceTypeClusterIP,
+				ClusterIP:             k8sv1.ClusterIPNone,
+				ExternalTrafficPolicy: k8sv1.ServiceExternalTrafficPolicyTypeLocal,
+				LocalEndpoint:         true,
+			},
+		}
+
+		cfg, err := st.config("", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.watchServices).To(BeTrue())
+		Expect(localRoutes(cfg)).To(ConsistOf(
+			"10.96.0.0/12", "192.0.2.0/24", "172.16.0.0/24",
+			"10.96.0.10/32", "192.0.2.1/32", "172.16.0.1/32",
+			"10.96.0.12/32", "172.16.1.1/32",
+		))
+
+		st.onUpdate(nodeUpdate("node-b", "10.0.0.2/24", map[string]string{excludeServiceAdvertisementLabel: "true"}, ""))
+		cfg, err = st.config("", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(localRoutes(cfg)).To(BeEmpty())
+	})
+
+	It("should originate the routes learned from the kernel", func() {
+		cfg, err := st.config("", map[netip.Prefix]kernelRoute{netip.MustParsePrefix("10.10.0.0/16"): {Interface: "eth1"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(localRoutes(cfg)).To(ConsistOf("10.10.0.0/16"))
+	})
+
+	It("should add the pools in order", func() {
+		for _, cidr := range []string{"192.168.0.0/16", "10.244.0.0/16"} {
+			st.onUpdate(api.Update{
+				KVPair: model.KVPair{
+					Key:   model.IPPoolKey{CIDR: cnet.MustParseCIDR(cidr)},
+					Value: &model.IPPool{CIDR: cnet.MustParseCIDR(cidr), IPIPMode: encap.CrossSubnet, IPIPInterface: "tunl0"},
+				},
+				UpdateType: api.UpdateTypeKVNew,
+			})
+		}
+		cfg, err := st.config("", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.kernel.pools).To(HaveLen(2))
+		Expect(cfg.kernel.pools[0].CIDR).To(Equal(netip.MustParsePrefix("10.244.0.0/16")))
+		Expect(cfg.kernel.pools[1].IPIPInterface).To(Equal("tunl0"))
+		Expect(cfg.kernel.networkV4).To(Equal(netip.MustParsePrefix("10.0.0.0/24")))
+	})
+
+	Describe("with route reflectors", func() {
+		BeforeEach(func() {
+			disabled := false
+			bgpConfig := apiv3.NewBGPConfiguration()
+			bgpConfig.Spec.NodeToNodeMeshEnabled = &disabled
+			st.onUpdate(resourceUpdate(apiv3.KindBGPConfiguration, "default", bgpConfig))
+
+			// Every node peers with the route reflectors in its rack.
+			rr := apiv3.NewBGPPeer()
+			rr.Spec.NodeSelector = "all()"
+			rr.Spec.PeerSelector = "has(rr)"
+			st.onUpdate(resourceUpdate(apiv3.KindBGPPeer, "peer-with-rr", rr))
+			st.onUpdate(nodeUpdate("node-rr", "10.0.0.10/24", map[string]string{"rr": ""}, "224.0.0.1"))
+		})
+
+		It("should peer with the route reflector", func() {
+			cfg, err := st.config("", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peerAddresses(cfg)).To(ConsistOf("10.0.0.10"))
+			Expect(peerConfigFor(cfg, "10.0.0.10").RouteReflectorClient).To(BeFalse())
+			Expect(peerConfigFor(cfg, "10.0.0.10").Passive).To(BeFalse())
+		})
+
+		It("should peer with the clients from the route reflector", func() {
+			st.nodename = "node-rr"
+			cfg, err := st.config("", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.speaker.ClusterID).To(Equal(netip.MustParseAddr("224.0.0.1")))
+			Expect(peerAddresses(cfg)).To(ConsistOf("10.0.0.1", "10.0.0.2", "10.0.0.3"))
+			for _, pc := range cfg.speaker.Peers {
+				Expect(pc.RouteReflectorClient).To(BeTrue())
+			}
+			// The addresses are compared as strings, as BIRD does.
+			Expect(peerConfigFor(cfg, "10.0.0.1").Passive).To(BeFalse())
+			Expect(peerConfigFor(cfg, "10.0.0.2").Passive).To(BeTrue())
+		})
+	})
+
+	Describe("with explicit peers", func() {
+		It("should peer with a router from the selected nodes", func() {
+			p := apiv3.NewBGPPeer()
+			p.Spec.NodeSelector = "rack == '1'"
+			p.Spec.PeerIP = "10.0.0.254:1179"
+			p.Spec.ASNumber = numorstring.ASNumber(65001)
+			p.Spec.KeepOriginalNextHop = true
+			st.onUpdate(resourceUpdate(apiv3.KindBGPPeer, "tor", p))
+
+			cfg, err := st.config("", nil)
+			Expect(err).NotTo(HaveOccurred())
+			pc := peerConfigFor(cfg, "10.0.0.254")
+			Expect(pc).NotTo(BeNil())
+			Expect(pc.Port).To(BeEquivalentTo(1179))
+			Expect(pc.AS).To(BeEquivalentTo(65001))
+			Expect(pc.KeepOriginalNextHop).To(BeTrue())
+			Expect(pc.Passive).To(BeFalse())
+			Expect(cfg.peerTypes[pc.Address]).To(Equal(bgpstatus.PeerTypeNode))
+
+			st.nodename = "node-c"
+			cfg, err = st.config("", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peerConfigFor(cfg, "10.0.0.254")).To(BeNil())
+		})
+
+		It("should add the reverse of a peering with this node", func() {
+			disabled := false
+			bgpConfig := apiv3.NewBGPConfiguration()
+			bgpConfig.Spec.NodeToNodeMeshEnabled = &disabled
+			st.onUpdate(resourceUpdate(apiv3.KindBGPConfiguration, "default", bgpConfig))
+
+			p := apiv3.NewBGPPeer()
+			p.Spec.Node = "node-a"
+			p.Spec.PeerIP = "10.0.0.2"
+			p.Spec.ASNumber = numorstring.ASNumber(defaultASNumber)
+			st.onUpdate(resourceUpdate(apiv3.KindBGPPeer, "a-to-b", p))
+
+			cfg, err := st.config("", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peerAddresses(cfg)).To(ConsistOf("10.0.0.1"))
+			Expect(cfg.peerTypes[netip.MustParseAddr("10.0.0.1")]).To(Equal(bgpstatus.PeerTypeNode))
+		})
+
+		It("should exchange the listed address families over one session", func() {
+			disabled := false
+			bgpConfig := apiv3.NewBGPConfiguration()
+			bgpConfig.Spec.NodeToNodeMeshEnabled = &disabled
+			st.onUpdate(resourceUpdate(apiv3.KindBGPConfiguration, "default", bgpConfig))
+			for _, n := range []struct{ name, ipv4, ipv6 string }{
+				{"node-a", "10.0.0.1/24", "fd00::1/64"},
+				{"node-b", "10.0.0.2/24", "fd00::2/64"},
+			} {
+				u := nodeUpdate(n.name, n.ipv4, map[string]string{"rack": "1"}, "")
+				u.Value.(*libapiv3.Node).Spec.BGP.IPv6Address = n.ipv6
+				st.onUpdate(u)
+			}
+
+			// The nodes peer over their IPv6 addresses only; node-c has none.
+			p := apiv3.NewBGPPeer()
+			p.Spec.PeerSelector = "all()"
+			p.Spec.AddressFamilies = []apiv3.BGPAddressFamily{apiv3.BGPAddressFamilyIPv6, apiv3.BGPAddressFamilyIPv4}
+			st.onUpdate(resourceUpdate(apiv3.KindBGPPeer, "nodes", p))
+			tor := apiv3.NewBGPPeer()
+			tor.Spec.PeerIP = "fd00::fe"
+			tor.Spec.ASNumber = numorstring.ASNumber(65001)
+			tor.Spec.AddressFamilies = []apiv3.BGPAddressFamily{apiv3.BGPAddressFamilyIPv4, apiv3.BGPAddressFamilyIPv6}
+			st.onUpdate(resourceUpdate(apiv3.KindBGPPeer, "tor", tor))
+			v4 := apiv3.NewBGPPeer()
+			v4.Spec.PeerIP = "10.0.0.254"
+			v4.Spec.ASNumber = numorstring.ASNumber(65001)
+			st.onUpdate(resourceUpdate(apiv3.KindBGPPeer, "tor-v4", v4))
+
+			cfg, err := st.config("", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peerAddresses(cfg)).To(ConsistOf("fd00::1", "fd00::fe", "10.0.0.254"))
+			Expect(peerConfigFor(cfg, "fd00::1").Families).To(Equal([]speaker.Family{speaker.FamilyIPv6Unicast, speaker.FamilyIPv4Unicast}))
+			Expect(peerConfigFor(cfg, "fd00::1").LocalAddress).To(Equal(netip.MustParseAddr("fd00::2")))
+			Expect(peerConfigFor(cfg, "fd00::fe").Families).To(Equal([]speaker.Family{speaker.FamilyIPv4Unicast, speaker.FamilyIPv6Unicast}))
+			Expect(peerConfigFor(cfg, "10.0.0.254").Families).To(Equal([]speaker.Family{speaker.FamilyIPv4Unicast}))
+		})
+
+		It("should use the BGP passwords from the secrets", func() {
+			p2 := apiv3.NewBGPPeer()
+			p2.Spec.PeerIP = "10.0.0.253"
+			p2.Spec.ASNumber = numorstring.ASNumber(65001)
+			p2.Spec.Password = &apiv3.BGPPassword{SecretKeyRef: &k8sv1.SecretKeySelector{
+				LocalObjectReference: k8sv1.LocalObjectReference{Name: "bgp-secrets"},
+				Key:                  "tor",
+			}}
+			st.onUpdate(resourceUpdate(apiv3.KindBGPPeer, "password", p2))
+
+			// The peering is made without the password until the secret can be read.
+			cfg, err := st.config("", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.secrets).To(Equal([]string{"bgp-secrets"}))
+			Expect(peerAddresses(cfg)).To(ConsistOf("10.0.0.1", "10.0.0.3", "10.0.0.253"))
+			Expect(peerConfigFor(cfg, "10.0.0.253").Password).To(BeEmpty())
+			Expect(cfg.peerTypes[netip.MustParseAddr("10.0.0.253")]).To(Equal(bgpstatus.PeerTypeGlobal))
+
+			st.secrets = map[string]map[string][]byte{"bgp-secrets": {"tor": []byte("s3cret"), "mesh": []byte("m3sh")}}
+			cfg, err = st.config("", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peerConfigFor(cfg, "10.0.0.253").Password).To(Equal("s3cret"))
+			Expect(peerConfigFor(cfg, "10.0.0.1").Password).To(BeEmpty())
+			Expect(cfg.unsupported).To(BeEmpty())
+
+			bgpConfig := apiv3.NewBGPConfiguration()
+			bgpConfig.Spec.NodeMeshPassword = &apiv3.BGPPassword{SecretKeyRef: &k8sv1.SecretKeySelector{
+				LocalObjectReference: k8sv1.LocalObjectReference{Name: "bgp-secrets"},
+				Key:                  "mesh",
+			}}
+			st.onUpdate(resourceUpdate(apiv3.KindBGPConfiguration, "default", bgpConfig))
+			cfg, err = st.config("", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peerConfigFor(cfg, "10.0.0.1").Password).To(Equal("m3sh"))
+			Expect(peerConfigFor(cfg, "10.0.0.3").Password).To(Equal("m3sh"))
+			Expect(peerConfigFor(cfg, "10.0.0.253").Password).To(Equal("s3cret"))
+		})
+
+		It("should report passwords that are too long as unsupported", func() {
+			p2 := apiv3.NewBGPPeer()
+			p2.Spec.PeerIP = "10.0.0.253"
+			p2.Spec.ASNumber = numorstring.ASNumber(65001)
+			p2.Spec.Password = &apiv3.BGPPassword{SecretKeyRef: &k8sv1.SecretKeySelector{
+				LocalObjectReference: k8sv1.LocalObjectReference{Name: "bgp-secrets"},
+				Key:                  "tor",
+			}}
+			st.onUpdate(resourceUpdate(apiv3.KindBGPPeer, "password", p2))
+			st.secrets = map[string]map[string][]byte{"bgp-secrets": {"tor": make([]byte, speaker.MaxPasswordLen+1)}}
+
+			cfg, err := st.config("", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peerAddresses(cfg)).To(ConsistOf("10.0.0.1", "10.0.0.3"))
+			Expect(cfg.unsupported).To(ConsistOf("peer 10.0.0.253 has a BGP password longer than 80 characters"))
+		})
+
+		It("should use TTL security and reachableBy", func() {
+			ttl := uint8(2)
+			p := apiv3.NewBGPPeer()
+			p.Spec.PeerIP = "172.17.0.1"
+			p.Spec.ASNumber = numorstring.ASNumber(65001)
+			p.Spec.TTLSecurity = &ttl
+			p.Spec.ReachableBy = "10.0.0.254"
+			st.onUpdate(resourceUpdate(apiv3.KindBGPPeer, "tor", p))
+			invalid := apiv3.NewBGPPeer()
+			invalid.Spec.PeerIP = "172.17.0.2"
+			invalid.Spec.ASNumber = numorstring.ASNumber(65001)
+			invalid.Spec.ReachableBy = "fd00::1"
+			st.onUpdate(resourceUpdate(apiv3.KindBGPPeer, "invalid", invalid))
+
+			cfg, err := st.config("", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peerAddresses(cfg)).To(ConsistOf("10.0.0.1", "10.0.0.3", "172.17.0.1"))
+			Expect(peerConfigFor(cfg, "172.17.0.1").TTLSecurity).To(BeEquivalentTo(2))
+			Expect(peerConfigFor(cfg, "10.0.0.1").TTLSecurity).To(BeZero())
+			Expect(cfg.kernel.static).To(Equal(map[netip.Prefix]netip.Addr{
+				netip.MustParsePrefix("172.17.0.1/32"): netip.MustParseAddr("10.0.0.254"),
+			}))
+		})
+	})
+})
@@ -0,0 +1,393 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bgp implements the native BGP daemon of calico/node, an alternative to BIRD and confd.
+// It peers according to the BGPConfiguration, BGPPeer, BGPFilter and Node resources, advertises
+// the node's IPAM blocks, the service address ranges and the addresses of the services with local
+// endpoints, and programs the routes learned from its peers in the same way as the BIRD
+// configuration generated by confd.
+//
+// Configuration that the daemon can't honour, such as BGP passwords that are too long for
+// TCP-MD5, is written to health.BGPDaemonConfigErrorFile, which fails the readiness check of
+// calico/node.
+package bgp
+
+import (
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/syncersv1/bgpsyncer"
+	"github.com/projectcalico/calico/libcalico-go/lib/winutils"
+	"github.com/projectcalico/calico/node/buildinfo"
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
+	"github.com/projectcalico/calico/node/pkg/bgp/speaker"
+	"github.com/projectcalico/calico/node/pkg/calicoclient"
+	"github.com/projectcalico/calico/node/pkg/health"
+	"github.com/projectcalico/calico/typha/pkg/syncclientutils"
+	"github.com/projectcalico/calico/typha/pkg/syncproto"
+)
+
+type backendClientAccessor interface {
+	Backend() bapi.Client
+}
+
+// Run runs the BGP daemon.  It doesn't return.
+func Run() {
+	// This binary is only ever invoked _after_ the startup binary has been invoked and the
+	// modified environments have been sourced.  Therefore, the NODENAME environment will always
+	// be set at this point.
+	nodename := os.Getenv("NODENAME")
+	if nodename == "" {
+		log.Panic("NODENAME environment is not set")
+	}
+
+	cfg, c := calicoclient.CreateClient()
+
+	d := newDaemon(nodename, os.Getenv("CALICO_ROUTER_ID"))
+	d.configErrorFile = health.BGPDaemonConfigErrorFile
+	// The endpoints and pods are matched with the node's name in Kubernetes, which can differ from
+	// the Calico node name.
+	k8sNodename := os.Getenv("CALICO_K8S_NODE_REF")
+	if k8sNodename == "" {
+		k8sNodename = nodename
+	}
+	d.newServiceWatcher = func() (*serviceWatcher, error) {
+		clientset, err := buildClientset()
+		if err != nil {
+			return nil, err
+		}
+		return newServiceWatcher(clientset, k8sNodename), nil
+	}
+	d.newWorkloadWatcher = func() (*workloadWatcher, error) {
+		clientset, err := buildClientset()
+		if err != nil {
+			return nil, err
+		}
+		return newWorkloadWatcher(clientset, k8sNodename), nil
+	}
+	d.newSecretWatcher = func() (*secretWatcher, error) {
+		// The secrets are in the namespace that calico/node runs in, as for confd.
+		namespace := os.Getenv("NAMESPACE")
+		if namespace == "" {
+			namespace = "kube-system"
+		}
+		clientset, err := buildClientset()
+		if err != nil {
+			return nil, err
+		}
+		return newSecretWatcher(clientset, namespace), nil
+	}
+
+	// Read Typha settings from the environment.  When Typha is in use, there will already be
+	// variables prefixed with FELIX_, so it's convenient if we honor those as well as the CALICO
+	// variables.
+	typhaConfig := syncclientutils.ReadTyphaConfig([]string{"FELIX_", "CALICO_"})
+	if syncclientutils.MustStartSyncerClientIfTyphaConfigured(
+		&typhaConfig, syncproto.SyncerTypeBGP,
+		buildinfo.GitVersion, nodename, fmt.Sprintf("bgp-daemon %s", buildinfo.GitVersion),
+		d,
+	) {
+		log.Debug("Using typha syncclient")
+	} else {
+		log.Debug("Using local syncer")
+		syncer := bgpsyncer.New(c.(backendClientAccessor).Backend(), d, nodename, cfg.Spec)
+		syncer.Start()
+	}
+
+	go func() {
+		if err := bgpstatus.Serve(bgpstatus.SocketPath, d.status, nil); err != nil {
+			log.WithError(err).Error("Failed to serve the BGP daemon status")
+		}
+	}()
+
+	d.run(nil)
+}
+
+// daemon drives the speaker and the routing table from the syncer.
+type daemon struct {
+	state       *state
+	routerIDEnv string
+
+	speaker *speaker.Speaker
+	kernel  *kernel
+
+	// configErrorFile, if set, is where the configuration that can't be honoured is written.
+	configErrorFile string
+	// newServiceWatcher, if set, creates the watcher for the Kubernetes services, which is
+	// started once a service address range is configured.
+	newServiceWatcher func() (*serviceWatcher, error)
+	services          <-chan map[string]service
+	// newWorkloadWatcher, if set, creates the watcher for the pods on this node, which is started
+	// once a workload advertisement is configured.
+	newWorkloadWatcher func() (*workloadWatcher, error)
+	workloads          <-chan map[string][]workload
+	// newSecretWatcher, if set, creates the watcher for the secrets that hold the BGP passwords,
+	// which is started once a password is configured.
+	newSecretWatcher func() (*secretWatcher, error)
+	secretWatcher    *secretWatcher
+	secrets          <-chan map[string]map[string][]byte
+
+	updates  chan []bapi.Update
+	inSyncCh chan struct{}
+
+	// statusLock guards the state that the status socket reports, besides the speaker's.
+	statusLock    sync.Mutex
+	started       time.Time
+	lastReconfig  time.Time
+	lastConfig    *daemonConfig
+	speakerSynced bool
+}
+
+func newDaemon(nodename, routerIDEnv string) *daemon {
+	k := newKernel()
+	return &daemon{
+		state:       newState(nodename),
+		routerIDEnv: routerIDEnv,
+		speaker:     speaker.New(k.onBestRoute),
+		kernel:      k,
+		updates:     make(chan []bapi.Update),
+		inSyncCh:    make(chan struct{}),
+		started:     time.Now(),
+	}
+}
+
+// OnStatusUpdated handles the syncer status callback method.
+func (d *daemon) OnStatusUpdated(status bapi.SyncStatus) {
+	if status == bapi.InSync {
+		d.inSyncCh <- struct{}{}
+	}
+}
+
+// OnUpdates handles the syncer resource updates.
+func (d *daemon) OnUpdates(updates []bapi.Update) {
+	d.updates <- updates
+}
+
+// run is the main loop, it loops until done.
+func (d *daemon) run(done <-chan struct{}) {
+	stopKernel := make(chan struct{})
+	defer close(stopKernel)
+	go d.kernel.run(stopKernel)
+	defer d.speaker.Stop()
+	defer func() {
+		if d.secretWatcher != nil {
+			d.secretWatcher.stop()
+		}
+	}()
+
+	var (
+		inSync        bool
+		dirty         bool
+		kernelRoutes  map[netip.Prefix]kernelRoute
+		speakerInSync = d.speaker.InSync()
+	)
+	for {
+		select {
+		case <-done:
+			return
+		case updates := <-d.updates:
+			for _, u := range updates {
+				d.state.onUpdate(u)
+			}
+			dirty = true
+		case <-d.inSyncCh:
+			log.Info("Datastore is in sync")
+			inSync = true
+			dirty = true
+		case d.state.services = <-d.services:
+			log.WithField("numServices", len(d.state.services)).Debug("Kubernetes services changed")
+			dirty = true
+		case d.state.workloads = <-d.workloads:
+			log.WithField("numPods", len(d.state.workloads)).Debug("Kubernetes pods changed")
+			dirty = true
+		case d.state.secrets = <-d.secrets:
+			log.WithField("numSecrets", len(d.state.secrets)).Debug("BGP password secrets changed")
+			dirty = true
+		case kernelRoutes = <-d.kernel.learned:
+			log.WithField("numRoutes", len(kernelRoutes)).Debug("Routes learned from the kernel changed")
+			dirty = true
+		case <-speakerInSync:
+			log.Info("BGP speaker is in sync, removing old routes")
+			d.kernel.setInSync()
+			speakerInSync = nil
+			d.statusLock.Lock()
+			d.speakerSynced = true
+			d.statusLock.Unlock()
+		}
+		if !dirty || !inSync {
+			continue
+		}
+		dirty = false
+
+		cfg, err := d.state.config(d.routerIDEnv, kernelRoutes)
+		if err != nil {
+			log.WithError(err).Warn("Unable to compute the BGP configuration")
+			continue
+		}
+		d.kernel.setPolicy(cfg.kernel)
+		if err := d.speaker.Configure(cfg.speaker); err != nil {
+			log.WithError(err).Fatal("Failed to configure the BGP speaker")
+		}
+		d.statusLock.Lock()
+		d.lastConfig, d.lastReconfig = cfg, time.Now()
+		d.statusLock.Unlock()
+		d.reportUnsupported(cfg.unsupported)
+		if cfg.watchServices {
+			d.startServiceWatcher(done)
+		}
+		if cfg.watchWorkloads {
+			d.startWorkloadWatcher(done)
+		}
+		d.watchSecrets(cfg.secrets)
+	}
+}
+
+// status returns the status of the daemon, for the status socket.
+func (d *daemon) status() *bgpstatus.Status {
+	d.statusLock.Lock()
+	status := &bgpstatus.Status{
+		Version:             buildinfo.GitVersion,
+		Started:             d.started,
+		LastReconfiguration: d.lastReconfig,
+		InSync:              d.speakerSynced,
+	}
+	cfg := d.lastConfig
+	d.statusLock.Unlock()
+	if cfg == nil {
+		return status
+	}
+	status.RouterID = cfg.speaker.RouterID.String()
+	status.AS = cfg.speaker.AS
+
+	for _, ps := range d.speaker.Status() {
+		status.Peers = append(status.Peers, bgpstatus.Peer{
+			Address:  ps.Address.String(),
+			AS:       ps.AS,
+			Type:     cfg.peerTypes[ps.Address],
+			State:    ps.State,
+			Since:    ps.Since,
+			Imported: ps.Imported,
+			Exported: ps.Exported,
+		})
+	}
+	for _, r := range d.speaker.Routes() {
+		sr := bgpstatus.Route{Prefix: r.Prefix.String()}
+		if r.Attrs.NextHop.IsValid() {
+			sr.NextHop = r.Attrs.NextHop.String()
+		}
+		_, fromKernel := cfg.export.kernelRoutes[r.Prefix]
+		switch {
+		case !r.Local():
+			sr.Peer = r.Peer.String()
+		case fromKernel:
+			sr.Source = bgpstatus.SourceKernel
+		default:
+			sr.Source = bgpstatus.SourceStatic
+		}
+		status.Routes = append(status.Routes, sr)
+	}
+	return status
+}
+
+// reportUnsupported writes the configuration that can't be honoured to the error file, or
+// removes it if there is none.
+func (d *daemon) reportUnsupported(unsupported []string) {
+	for _, u := range unsupported {
+		log.Errorf("Unsupported BGP configuration: %s", u)
+	}
+	if d.configErrorFile == "" {
+		return
+	}
+	if len(unsupported) == 0 {
+		if err := os.Remove(d.configErrorFile); err != nil && !os.IsNotExist(err) {
+			log.WithError(err).Warn("Failed to remove the BGP configuration error file")
+		}
+		return
+	}
+	msg := "unsupported BGP configuration: " + strings.Join(unsupported, ", ")
+	if err := os.WriteFile(d.configErrorFile, []byte(msg), 0o644); err != nil {
+		log.WithError(err).Warn("Failed to write the BGP configuration error file")
+	}
+}
+
+// startServiceWatcher starts watching the Kubernetes services, if it hasn't already.  Failing to
+// is logged, as the service address ranges are still advertised.
+func (d *daemon) startServiceWatcher(done <-chan struct{}) {
+	if d.newServiceWatcher == nil || d.services != nil {
+		return
+	}
+	w, err := d.newServiceWatcher()
+	d.newServiceWatcher = nil
+	if err != nil {
+		log.WithError(err).Error("Failed to watch Kubernetes services, not advertising individual services")
+		return
+	}
+	log.Info("Watching Kubernetes services")
+	d.services = w.services
+	go w.run(done)
+}
+
+// startWorkloadWatcher starts watching the pods on this node, if it hasn't already.  Failing to is
+// logged, as the blocks are still advertised.
+func (d *daemon) startWorkloadWatcher(done <-chan struct{}) {
+	if d.newWorkloadWatcher == nil || d.workloads != nil {
+		return
+	}
+	w, err := d.newWorkloadWatcher()
+	d.newWorkloadWatcher = nil
+	if err != nil {
+		log.WithError(err).Error("Failed to watch Kubernetes pods, not advertising individual workloads")
+		return
+	}
+	log.Info("Watching Kubernetes pods")
+	d.workloads = w.workloads
+	go w.run(done)
+}
+
+// watchSecrets watches the secrets that hold the BGP passwords, starting the watcher once there
+// are any.  Failing to start it is logged, and the peers are configured without their passwords.
+func (d *daemon) watchSecrets(names []string) {
+	if d.secretWatcher == nil {
+		if d.newSecretWatcher == nil || len(names) == 0 {
+			return
+		}
+		w, err := d.newSecretWatcher()
+		d.newSecretWatcher = nil
+		if err != nil {
+			log.WithError(err).Error("Failed to watch the secrets, peering without BGP passwords")
+			return
+		}
+		d.secretWatcher = w
+		d.secrets = w.secrets
+	}
+	d.secretWatcher.watch(names)
+}
+
+// buildClientset returns a Kubernetes clientset from the kubeconfig, if there is one, or the
+// in-cluster configuration.
+func buildClientset() (*kubernetes.Clientset, error) {
+	cfg, err := winutils.BuildConfigFromFlags("", os.Getenv("KUBECONFIG"))
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(cfg)
+}
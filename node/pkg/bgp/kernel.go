@@ -0,0 +1,433 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"maps"
+	"net"
+	"net/netip"
+	"slices"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/encap"
+	"github.com/projectcalico/calico/node/pkg/bgp/speaker"
+)
+
+const (
+	// routeProtocol is the protocol of the routes that we program.  It is the same as BIRD's, so
+	// that the routes are taken over when switching between the two.
+	routeProtocol = unix.RTPROT_BIRD
+
+	kernelScanInterval  = 2 * time.Second
+	kernelRetryInterval = time.Second
+)
+
+// kernelPolicy determines how the best routes are programmed: the calico_kernel_programming filter
+// of the BIRD configuration.
+type kernelPolicy struct {
+	// networkV4 and networkV6 are the networks of the node's addresses.
+	networkV4, networkV6 netip.Prefix
+	pools                []pool
+	// reject are the service address ranges, which are never programmed.
+	reject []netip.Prefix
+	// blackholes are the blocks affine to this node.
+	blackholes []netip.Prefix
+	// static are the routes to the BGP peers that are reachable through a gateway, keyed on the
+	// peer's address as a host prefix: the peers' reachableBy.
+	static map[netip.Prefix]netip.Addr
+}
+
+// nexthop is a next hop of a route in the kernel.
+type nexthop struct {
+	Gateway   netip.Addr
+	Interface string
+}
+
+// programmedRoute is a route in the kernel.  It has several next hops if it is an ECMP route.
+type programmedRoute struct {
+	Nexthops  []nexthop
+	Blackhole bool
+}
+
+func (pr programmedRoute) equal(other programmedRoute) bool {
+	return pr.Blackhole == other.Blackhole && slices.Equal(pr.Nexthops, other.Nexthops)
+}
+
+// route returns the route to program for the best route to a prefix and the routes that may be
+// used alongside it, or false if there shouldn't be one.
+func (kp *kernelPolicy) route(prefix netip.Prefix, best *speaker.Route, multipath []*speaker.Route) (programmedRoute, bool) {
+	if gw, ok := kp.static[prefix]; ok {
+		return programmedRoute{Nexthops: []nexthop{{Gateway: gw}}}, true
+	}
+	if best == nil {
+		return programmedRoute{}, false
+	}
+	for _, cidr := range kp.reject {
+		if within(prefix, cidr) {
+			return programmedRoute{}, false
+		}
+	}
+	if best.Local() {
+		for _, block := range kp.blackholes {
+			if prefix == block {
+				return programmedRoute{Blackhole: true}, true
+			}
+		}
+		// Other local routes are learned from the kernel, or are service address ranges.
+		return programmedRoute{}, false
+	}
+
+	network := kp.networkV4
+	if prefix.Addr().Is6() {
+		network = kp.networkV6
+	}
+	var inPool *pool
+	if network.IsValid() {
+		for i, pl := range kp.pools {
+			if within(prefix, pl.CIDR) {
+				inPool = &kp.pools[i]
+				break
+			}
+		}
+	}
+	if inPool != nil && inPool.VXLANMode != encap.Undefined {
+		// Felix programs the routes over VXLAN.
+		return programmedRoute{}, false
+	}
+
+	var pr programmedRoute
+	for _, r := range append([]*speaker.Route{best}, multipath...) {
+		gw := r.Attrs.NextHop
+		if !gw.IsValid() || slices.ContainsFunc(pr.Nexthops, func(nh nexthop) bool { return nh.Gateway == gw }) {
+			continue
+		}
+		nh := nexthop{Gateway: gw}
+		// An IPv4 route with an IPv6 gateway, learned with an extended next hop, can't be
+		// programmed over IPIP.
+		if inPool != nil && gw.Is4() && !(inPool.IPIPMode == encap.CrossSubnet && network.Contains(gw)) {
+			nh.Interface = inPool.IPIPInterface
+		}
+		pr.Nexthops = append(pr.Nexthops, nh)
+	}
+	return pr, len(pr.Nexthops) > 0
+}
+
+// kernel programs the best routes into the main routing table, and learns the routes that other
+// agents program into it.
+type kernel struct {
+	lock       sync.Mutex
+	policy     *kernelPolicy
+	best       map[netip.Prefix]*speaker.Route
+	multipath  map[netip.Prefix][]*speaker.Route
+	dirty      map[netip.Prefix]bool
+	programmed map[netip.Prefix]programmedRoute
+	// sweep is set once the speaker is in sync, to remove our routes that are no longer wanted.
+	sweep bool
+	wake  chan struct{}
+
+	// learned receives the routes learned from the kernel whenever they change.
+	learned chan map[netip.Prefix]kernelRoute
+}
+
+func newKernel() *kernel {
+	return &kernel{
+		best:       map[netip.Prefix]*speaker.Route{},
+		multipath:  map[netip.Prefix][]*speaker.Route{},
+		dirty:      map[netip.Prefix]bool{},
+		programmed: map[netip.Prefix]programmedRoute{},
+		wake:       make(chan struct{}, 1),
+		learned:    make(chan map[netip.Prefix]kernelRoute, 1),
+	}
+}
+
+func (k *kernel) wakeLockHeld() {
+	select {
+	case k.wake <- struct{}{}:
+	default:
+	}
+}
+
+// onBestRoute records a change in the best route to a prefix, or in the routes that may be used
+// alongside it.  It is the speaker's route handler, so it must not block.
+func (k *kernel) onBestRoute(prefix netip.Prefix, best *speaker.Route, multipath []*speaker.Route) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if best == nil {
+		delete(k.best, prefix)
+	} else {
+		k.best[prefix] = best
+	}
+	if len(multipath) == 0 {
+		delete(k.multipath, prefix)
+	} else {
+		k.multipath[prefix] = multipath
+	}
+	k.dirty[prefix] = true
+	k.wakeLockHeld()
+}
+
+// setPolicy applies a new programming policy to all the routes.
+func (k *kernel) setPolicy(policy *kernelPolicy) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.policy = policy
+	for prefix := range k.best {
+		k.dirty[prefix] = true
+	}
+	for prefix := range k.programmed {
+		k.dirty[prefix] = true
+	}
+	for prefix := range policy.static {
+		k.dirty[prefix] = true
+	}
+	k.wakeLockHeld()
+}
+
+// setInSync triggers the removal of the routes that we didn't program, left by a previous run.
+func (k *kernel) setInSync() {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.sweep = true
+	k.wakeLockHeld()
+}
+
+// run programs the routes, and scans the routing table for routes to learn, until stop is closed.
+func (k *kernel) run(stop <-chan struct{}) {
+	scan := time.NewTicker(kernelScanInterval)
+	defer scan.Stop()
+	var learned map[netip.Prefix]kernelRoute
+	var retry <-chan time.Time
+	k.learn(&learned)
+	for {
+		select {
+		case <-stop:
+			return
+		case <-scan.C:
+			k.learn(&learned)
+			continue
+		case <-k.wake:
+		case <-retry:
+		}
+		retry = nil
+		if !k.program() {
+			retry = time.After(kernelRetryInterval)
+		}
+	}
+}
+
+// learn scans the main routing table for the routes programmed by other agents, and sends them to
+// k.learned if they have changed since the last scan.
+func (k *kernel) learn(last *map[netip.Prefix]kernelRoute) {
+	routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: unix.RT_TABLE_MAIN}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		log.WithError(err).Warn("Failed to list routes")
+		return
+	}
+	ifnames := map[int]string{}
+	learned := map[netip.Prefix]kernelRoute{}
+	for _, r := range routes {
+		switch r.Protocol {
+		case unix.RTPROT_KERNEL, unix.RTPROT_REDIRECT, routeProtocol:
+			continue
+		}
+		if r.Type != unix.RTN_UNICAST || r.Dst == nil {
+			continue
+		}
+		prefix, ok := prefixFromIPNet(r.Dst)
+		if !ok {
+			continue
+		}
+		if _, ok := ifnames[r.LinkIndex]; !ok && r.LinkIndex != 0 {
+			if link, err := netlink.LinkByIndex(r.LinkIndex); err == nil {
+				ifnames[r.LinkIndex] = link.Attrs().Name
+			}
+		}
+		learned[prefix] = kernelRoute{Interface: ifnames[r.LinkIndex]}
+	}
+	if *last != nil && maps.Equal(*last, learned) {
+		return
+	}
+	*last = learned
+	// Replace any routes that haven't been consumed.
+	select {
+	case <-k.learned:
+	default:
+	}
+	k.learned <- learned
+}
+
+func prefixFromIPNet(n *net.IPNet) (netip.Prefix, bool) {
+	addr, ok := netip.AddrFromSlice(n.IP)
+	if !ok {
+		return netip.Prefix{}, false
+	}
+	ones, _ := n.Mask.Size()
+	return netip.PrefixFrom(addr.Unmap(), ones).Masked(), true
+}
+
+// program brings the routing table up to date.  It returns false if it should be retried.
+func (k *kernel) program() bool {
+	k.lock.Lock()
+	policy := k.policy
+	sweep := k.sweep
+	type paths struct {
+		best      *speaker.Route
+		multipath []*speaker.Route
+	}
+	work := map[netip.Prefix]paths{}
+	if policy != nil {
+		for prefix := range k.dirty {
+			work[prefix] = paths{k.best[prefix], k.multipath[prefix]}
+		}
+		k.dirty = map[netip.Prefix]bool{}
+	}
+	k.lock.Unlock()
+
+	if policy == nil {
+		return true
+	}
+	ok := true
+	for prefix, p := range work {
+		desired, want := policy.route(prefix, p.best, p.multipath)
+		current, have := k.programmed[prefix]
+		if want == have && desired.equal(current) {
+			continue
+		}
+		var err error
+		if want {
+			err = replaceRoute(prefix, desired)
+		} else {
+			err = deleteRoute(prefix, current)
+		}
+		if err != nil {
+			log.WithError(err).WithField("prefix", prefix).Warn("Failed to program route, will retry")
+			k.lock.Lock()
+			k.dirty[prefix] = true
+			k.lock.Unlock()
+			ok = false
+			continue
+		}
+		if want {
+			k.programmed[prefix] = desired
+		} else {
+			delete(k.programmed, prefix)
+		}
+	}
+
+	if sweep && ok {
+		if err := k.removeUnknownRoutes(); err != nil {
+			log.WithError(err).Warn("Failed to remove old routes, will retry")
+			return false
+		}
+		k.lock.Lock()
+		k.sweep = false
+		k.lock.Unlock()
+	}
+	return ok
+}
+
+// removeUnknownRoutes removes the routes with our protocol that we haven't programmed.
+func (k *kernel) removeUnknownRoutes() error {
+	routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL,
+		&netlink.Route{Table: unix.RT_TABLE_MAIN, Protocol: routeProtocol},
+		netlink.RT_FILTER_TABLE|netlink.RT_FILTER_PROTOCOL)
+	if err != nil {
+		return err
+	}
+	for _, r := range routes {
+		if r.Dst == nil {
+			continue
+		}
+		if prefix, ok := prefixFromIPNet(r.Dst); ok {
+			if _, programmed := k.programmed[prefix]; programmed {
+				continue
+			}
+		}
+		log.WithField("route", r).Info("Removing old route")
+		if err := netlink.RouteDel(&r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func netlinkRoute(prefix netip.Prefix, pr programmedRoute) (*netlink.Route, error) {
+	r := &netlink.Route{
+		Dst: &net.IPNet{
+			IP:   prefix.Addr().AsSlice(),
+			Mask: net.CIDRMask(prefix.Bits(), prefix.Addr().BitLen()),
+		},
+		Table:    unix.RT_TABLE_MAIN,
+		Protocol: routeProtocol,
+		Type:     unix.RTN_UNICAST,
+	}
+	if pr.Blackhole {
+		r.Type = unix.RTN_BLACKHOLE
+		return r, nil
+	}
+	for _, nh := range pr.Nexthops {
+		info := &netlink.NexthopInfo{Gw: nh.Gateway.AsSlice()}
+		if nh.Gateway.Is6() && prefix.Addr().Is4() {
+			// The gateway of an IPv4 route learned with an extended next hop (RFC 8950).
+			info.Gw = nil
+			info.Via = &netlink.Via{AddrFamily: netlink.FAMILY_V6, Addr: nh.Gateway.AsSlice()}
+		}
+		if nh.Interface != "" {
+			link, err := netlink.LinkByName(nh.Interface)
+			if err != nil {
+				return nil, err
+			}
+			info.LinkIndex = link.Attrs().Index
+			info.Flags = int(netlink.FLAG_ONLINK)
+		}
+		r.MultiPath = append(r.MultiPath, info)
+	}
+	if len(r.MultiPath) == 1 {
+		// A route with a single next hop is programmed without RTA_MULTIPATH.
+		r.Gw, r.Via, r.LinkIndex, r.Flags = r.MultiPath[0].Gw, r.MultiPath[0].Via, r.MultiPath[0].LinkIndex, r.MultiPath[0].Flags
+		r.MultiPath = nil
+	}
+	return r, nil
+}
+
+func replaceRoute(prefix netip.Prefix, pr programmedRoute) error {
+	r, err := netlinkRoute(prefix, pr)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{"prefix": prefix, "route": pr}).Debug("Programming route")
+	return netlink.RouteReplace(r)
+}
+
+func deleteRoute(prefix netip.Prefix, pr programmedRoute) error {
+	r := &netlink.Route{
+		Dst: &net.IPNet{
+			IP:   prefix.Addr().AsSlice(),
+			Mask: net.CIDRMask(prefix.Bits(), prefix.Addr().BitLen()),
+		},
+		Table:    unix.RT_TABLE_MAIN,
+		Protocol: routeProtocol,
+	}
+	log.WithFields(log.Fields{"prefix": prefix, "route": pr}).Debug("Removing route")
+	if err := netlink.RouteDel(r); err != nil && err != unix.ESRCH {
+		return err
+	}
+	return nil
+}
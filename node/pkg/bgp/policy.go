@@ -0,0 +1,313 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"net/netip"
+	"path"
+	"slices"
+	"strings"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/encap"
+	"github.com/projectcalico/calico/node/pkg/bgp/speaker"
+)
+
+// within returns true if the prefix is the same as, or more specific than, the CIDR.  It is the
+// equivalent of BIRD's "net ~ cidr".
+func within(prefix, cidr netip.Prefix) bool {
+	return cidr.Bits() <= prefix.Bits() && cidr.Contains(prefix.Addr())
+}
+
+// pool is the part of an IP pool that determines how routes within it are exported and
+// programmed.
+type pool struct {
+	CIDR             netip.Prefix
+	IPIPInterface    string
+	IPIPMode         encap.Mode
+	VXLANMode        encap.Mode
+	DisableBGPExport bool
+}
+
+// prefixAdvertisement holds the communities to add to the routes within a CIDR.
+type prefixAdvertisement struct {
+	CIDR             netip.Prefix
+	Communities      []uint32
+	LargeCommunities []speaker.LargeCommunity
+}
+
+func (pa *prefixAdvertisement) addCommunities(attrs *speaker.PathAttrs) {
+	for _, c := range pa.Communities {
+		if !slices.Contains(attrs.Communities, c) {
+			attrs.Communities = append(attrs.Communities, c)
+		}
+	}
+	for _, c := range pa.LargeCommunities {
+		if !slices.Contains(attrs.LargeCommunities, c) {
+			attrs.LargeCommunities = append(attrs.LargeCommunities, c)
+		}
+	}
+}
+
+// kernelRoute describes a route learned from the kernel.
+type kernelRoute struct {
+	Interface string
+}
+
+// exportPolicy implements the default Calico export policy: the calico_export_to_bgp_peers()
+// function of the BIRD configuration.
+type exportPolicy struct {
+	// pools are sorted by CIDR.
+	pools []pool
+	// blocks are the confirmed IPAM blocks affine to this node.
+	blocks []netip.Prefix
+	// staticRoutes are the service address ranges, and the addresses of individual services,
+	// advertised by this node.
+	staticRoutes []netip.Prefix
+	// loadBalancerRanges are the load balancer address ranges, which a route reflector passes on.
+	loadBalancerRanges   []netip.Prefix
+	prefixAdvertisements []prefixAdvertisement
+	// workloadRoutes are the addresses of the local workloads that are advertised, with their
+	// communities.
+	workloadRoutes map[netip.Prefix]prefixAdvertisement
+
+	// kernelRoutes are the routes learned from the kernel, which are originated by the speaker.
+	kernelRoutes map[netip.Prefix]kernelRoute
+}
+
+// ifname returns the interface of a route learned from the kernel, or the empty string for other
+// routes.
+func (p *exportPolicy) ifname(r *speaker.Route) string {
+	if !r.Local() {
+		return ""
+	}
+	return p.kernelRoutes[r.Prefix].Interface
+}
+
+// export returns true if the route should be exported to a peer, adding any communities to its
+// attributes.  internal is true for peers in our AS.
+func (p *exportPolicy) export(r *speaker.Route, attrs *speaker.PathAttrs, internal bool) bool {
+	prefix := r.Prefix
+	for _, pl := range p.pools {
+		if pl.DisableBGPExport && within(prefix, pl.CIDR) {
+			return false
+		}
+	}
+	ifname := p.ifname(r)
+	if internal && (strings.HasSuffix(ifname, ".cali") || strings.HasSuffix(ifname, ".calico")) {
+		// Don't export tunnel routes to other nodes, Felix programs them.
+		return false
+	}
+	if strings.HasPrefix(ifname, "bpf") && strings.HasSuffix(ifname, ".cali") {
+		// Routes learned via BPF should never leave the node.
+		return false
+	}
+
+	for _, pa := range p.prefixAdvertisements {
+		if within(prefix, pa.CIDR) {
+			pa.addCommunities(attrs)
+		}
+	}
+
+	// Export the routes to the advertised local workloads before the block check, as the
+	// bird_ipam templates do.
+	if wr, ok := p.workloadRoutes[prefix]; ok && r.Local() {
+		wr.addCommunities(attrs)
+		return true
+	}
+
+	// Export the blocks, but not the routes beneath them.
+	for _, block := range p.blocks {
+		if prefix == block {
+			return true
+		}
+		if within(prefix, block) {
+			return false
+		}
+	}
+	for _, cidr := range p.staticRoutes {
+		if within(prefix, cidr) {
+			return true
+		}
+	}
+	for _, cidr := range p.loadBalancerRanges {
+		if within(prefix, cidr) {
+			return true
+		}
+	}
+	for _, pl := range p.pools {
+		if !pl.DisableBGPExport && within(prefix, pl.CIDR) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterRule is a rule of a BGPFilter, for either IPv4 or IPv6.
+type filterRule struct {
+	operator apiv3.BGPFilterMatchOperator
+	cidr     netip.Prefix
+	// minLen and maxLen bound the prefix length of the routes that match an In or NotIn rule.
+	minLen, maxLen int
+	source         apiv3.BGPFilterMatchSource
+	iface          string
+	accept         bool
+}
+
+// bgpFilter holds the rules of a BGPFilter, for each direction and IP version.
+type bgpFilter struct {
+	importV4, exportV4, importV6, exportV6 []filterRule
+}
+
+func newFilterRule(operator apiv3.BGPFilterMatchOperator, cidr string, minLen, maxLen *int32,
+	source apiv3.BGPFilterMatchSource, iface string, action apiv3.BGPFilterAction,
+) (filterRule, bool) {
+	r := filterRule{operator: operator, source: source, iface: iface, accept: action == apiv3.Accept}
+	if cidr != "" {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			log.WithError(err).WithField("cidr", cidr).Warn("Ignoring BGPFilter rule with invalid CIDR")
+			return r, false
+		}
+		r.cidr = prefix.Masked()
+		r.minLen, r.maxLen = r.cidr.Bits(), r.cidr.Addr().BitLen()
+		if minLen != nil {
+			r.minLen = max(r.minLen, int(*minLen))
+		}
+		if maxLen != nil {
+			r.maxLen = min(r.maxLen, int(*maxLen))
+		}
+	}
+	return r, true
+}
+
+func newBGPFilter(f *apiv3.BGPFilter) *bgpFilter {
+	bf := &bgpFilter{}
+	v4 := func(rules []apiv3.BGPFilterRuleV4) (out []filterRule) {
+		for _, rule := range rules {
+			var minLen, maxLen *int32
+			if rule.PrefixLength != nil {
+				minLen, maxLen = rule.PrefixLength.Min, rule.PrefixLength.Max
+			}
+			if r, ok := newFilterRule(rule.MatchOperator, rule.CIDR, minLen, maxLen, rule.Source, rule.Interface, rule.Action); ok {
+				out = append(out, r)
+			}
+		}
+		return
+	}
+	v6 := func(rules []apiv3.BGPFilterRuleV6) (out []filterRule) {
+		for _, rule := range rules {
+			var minLen, maxLen *int32
+			if rule.PrefixLength != nil {
+				minLen, maxLen = rule.PrefixLength.Min, rule.PrefixLength.Max
+			}
+			if r, ok := newFilterRule(rule.MatchOperator, rule.CIDR, minLen, maxLen, rule.Source, rule.Interface, rule.Action); ok {
+				out = append(out, r)
+			}
+		}
+		return
+	}
+	bf.importV4, bf.exportV4 = v4(f.Spec.ImportV4), v4(f.Spec.ExportV4)
+	bf.importV6, bf.exportV6 = v6(f.Spec.ImportV6), v6(f.Spec.ExportV6)
+	return bf
+}
+
+// matches returns true if the rule matches the route.
+func (fr *filterRule) matches(r *speaker.Route, ifname string) bool {
+	if fr.cidr.IsValid() {
+		var match bool
+		switch fr.operator {
+		case apiv3.Equal, apiv3.NotEqual:
+			match = r.Prefix == fr.cidr
+		default:
+			match = within(r.Prefix, fr.cidr) && r.Prefix.Bits() >= fr.minLen && r.Prefix.Bits() <= fr.maxLen
+		}
+		if fr.operator == apiv3.NotEqual || fr.operator == apiv3.NotIn {
+			match = !match
+		}
+		if !match {
+			return false
+		}
+	}
+	if fr.source == apiv3.BGPFilterSourceRemotePeers && r.Local() {
+		return false
+	}
+	if fr.iface != "" {
+		if ifname == "" {
+			return false
+		}
+		if ok, _ := path.Match(fr.iface, ifname); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluateFilters applies the rules of the filters in order.  It returns whether the first
+// matching rule accepts the route, and false for matched if no rule matches.
+func evaluateFilters(rules [][]filterRule, r *speaker.Route, ifname string) (accept, matched bool) {
+	for _, rs := range rules {
+		for i := range rs {
+			if rs[i].matches(r, ifname) {
+				return rs[i].accept, true
+			}
+		}
+	}
+	return false, false
+}
+
+// peerPolicy is the import and export policy for a peer.
+type peerPolicy struct {
+	export   *exportPolicy
+	filters  []*bgpFilter
+	internal bool
+}
+
+func (pp *peerPolicy) rules(prefix netip.Prefix, export bool) [][]filterRule {
+	var rules [][]filterRule
+	for _, f := range pp.filters {
+		switch {
+		case prefix.Addr().Is4() && export:
+			rules = append(rules, f.exportV4)
+		case prefix.Addr().Is4():
+			rules = append(rules, f.importV4)
+		case export:
+			rules = append(rules, f.exportV6)
+		default:
+			rules = append(rules, f.importV6)
+		}
+	}
+	return rules
+}
+
+// Import applies the peer's BGPFilters to a route learned from it, accepting the routes that
+// don't match any rule.
+func (pp *peerPolicy) Import(r *speaker.Route) bool {
+	if accept, matched := evaluateFilters(pp.rules(r.Prefix, false), r, ""); matched {
+		return accept
+	}
+	return true
+}
+
+// Export applies the peer's BGPFilters, and then the default Calico export policy, to a route to
+// be advertised to it.
+func (pp *peerPolicy) Export(r *speaker.Route, attrs *speaker.PathAttrs) bool {
+	if accept, matched := evaluateFilters(pp.rules(r.Prefix, true), r, pp.export.ifname(r)); matched {
+		return accept
+	}
+	return pp.export.export(r, attrs, pp.internal)
+}
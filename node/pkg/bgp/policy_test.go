@@ -0,0 +1,179 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"net/netip"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/encap"
+	"github.com/projectcalico/calico/node/pkg/bgp/speaker"
+)
+
+func localRoute(prefix string) *speaker.Route {
+	return &speaker.Route{Prefix: netip.MustParsePrefix(prefix), Attrs: &speaker.PathAttrs{}}
+}
+
+func peerRoute(prefix, nextHop string) *speaker.Route {
+	return &speaker.Route{
+		Prefix: netip.MustParsePrefix(prefix),
+		Attrs:  &speaker.PathAttrs{NextHop: netip.MustParseAddr(nextHop)},
+		Peer:   netip.MustParseAddr(nextHop),
+	}
+}
+
+var _ = Describe("BGP daemon policy", func() {
+	var export *exportPolicy
+
+	BeforeEach(func() {
+		export = &exportPolicy{
+			pools: []pool{
+				{CIDR: netip.MustParsePrefix("192.168.0.0/16")},
+				{CIDR: netip.MustParsePrefix("10.244.0.0/16"), DisableBGPExport: true},
+			},
+			blocks:       []netip.Prefix{netip.MustParsePrefix("192.168.1.0/26")},
+			staticRoutes: []netip.Prefix{netip.MustParsePrefix("10.96.0.0/12")},
+			prefixAdvertisements: []prefixAdvertisement{{
+				CIDR:        netip.MustParsePrefix("192.168.0.0/16"),
+				Communities: []uint32{65000<<16 | 100},
+			}},
+			kernelRoutes: map[netip.Prefix]kernelRoute{
+				netip.MustParsePrefix("192.168.5.0/26"): {Interface: "tunl0.calico"},
+			},
+		}
+	})
+
+	DescribeTable("default export policy",
+		func(r *speaker.Route, internal, expected bool) {
+			Expect(export.export(r, &speaker.PathAttrs{}, internal)).To(Equal(expected))
+		},
+		Entry("local block", localRoute("192.168.1.0/26"), true, true),
+		Entry("route within a local block", localRoute("192.168.1.1/32"), true, false),
+		Entry("service range", localRoute("10.96.0.0/12"), false, true),
+		Entry("route within a pool", peerRoute("192.168.2.0/26", "10.0.0.1"), false, true),
+		Entry("route within a pool with export disabled", peerRoute("10.244.1.0/26", "10.0.0.1"), true, false),
+		Entry("route outside the pools", peerRoute("172.16.0.0/24", "10.0.0.1"), true, false),
+		Entry("tunnel route to an internal peer", localRoute("192.168.5.0/26"), true, false),
+		Entry("tunnel route to an external peer", localRoute("192.168.5.0/26"), false, true),
+	)
+
+	It("should add the communities of the prefix advertisements", func() {
+		attrs := &speaker.PathAttrs{}
+		Expect(export.export(localRoute("192.168.1.0/26"), attrs, true)).To(BeTrue())
+		Expect(attrs.Communities).To(ConsistOf(uint32(65000<<16 | 100)))
+	})
+
+	It("should export the advertised workload routes with their communities", func() {
+		export.workloadRoutes = map[netip.Prefix]prefixAdvertisement{
+			netip.MustParsePrefix("192.168.1.1/32"): {
+				CIDR:        netip.MustParsePrefix("192.168.1.1/32"),
+				Communities: []uint32{65000<<16 | 200},
+			},
+		}
+		attrs := &speaker.PathAttrs{}
+		Expect(export.export(localRoute("192.168.1.1/32"), attrs, true)).To(BeTrue())
+		Expect(attrs.Communities).To(ConsistOf(uint32(65000<<16|100), uint32(65000<<16|200)))
+		Expect(export.export(localRoute("192.168.1.2/32"), &speaker.PathAttrs{}, true)).To(BeFalse())
+		Expect(export.export(peerRoute("192.168.1.1/32", "10.0.0.1"), &speaker.PathAttrs{}, true)).To(BeFalse())
+	})
+
+	It("should apply the BGPFilters before the default policy", func() {
+		f := apiv3.NewBGPFilter()
+		f.Spec.ExportV4 = []apiv3.BGPFilterRuleV4{
+			{CIDR: "192.168.1.0/24", MatchOperator: apiv3.In, Action: apiv3.Reject},
+			{CIDR: "172.16.0.0/24", MatchOperator: apiv3.Equal, Action: apiv3.Accept},
+		}
+		f.Spec.ImportV4 = []apiv3.BGPFilterRuleV4{
+			{Source: apiv3.BGPFilterSourceRemotePeers, Action: apiv3.Reject},
+		}
+		pp := &peerPolicy{export: export, filters: []*bgpFilter{newBGPFilter(f)}, internal: true}
+
+		Expect(pp.Export(localRoute("192.168.1.0/26"), &speaker.PathAttrs{})).To(BeFalse())
+		Expect(pp.Export(peerRoute("172.16.0.0/24", "10.0.0.1"), &speaker.PathAttrs{})).To(BeTrue())
+		Expect(pp.Export(peerRoute("192.168.2.0/26", "10.0.0.1"), &speaker.PathAttrs{})).To(BeTrue())
+		Expect(pp.Import(peerRoute("192.168.2.0/26", "10.0.0.1"))).To(BeFalse())
+		Expect(pp.Import(peerRoute("2001:db8::/64", "2001:db8::1"))).To(BeTrue())
+	})
+
+	It("should bound the prefix length of In rules", func() {
+		maxLen := int32(26)
+		r, ok := newFilterRule(apiv3.In, "192.168.0.0/16", nil, &maxLen, "", "", apiv3.Accept)
+		Expect(ok).To(BeTrue())
+		Expect(r.matches(localRoute("192.168.1.0/26"), "")).To(BeTrue())
+		Expect(r.matches(localRoute("192.168.1.0/28"), "")).To(BeFalse())
+		Expect(r.matches(localRoute("10.0.0.0/26"), "")).To(BeFalse())
+
+		r, ok = newFilterRule(apiv3.NotIn, "", nil, nil, "", "eth*", apiv3.Accept)
+		Expect(ok).To(BeTrue())
+		Expect(r.matches(localRoute("10.0.0.0/26"), "eth1")).To(BeTrue())
+		Expect(r.matches(localRoute("10.0.0.0/26"), "cali1234")).To(BeFalse())
+	})
+
+	Describe("kernel programming", func() {
+		var kp *kernelPolicy
+
+		BeforeEach(func() {
+			kp = &kernelPolicy{
+				networkV4: netip.MustParsePrefix("10.0.0.0/24"),
+				pools: []pool{
+					{CIDR: netip.MustParsePrefix("10.244.0.0/16"), VXLANMode: encap.Always},
+					{CIDR: netip.MustParsePrefix("192.168.0.0/16"), IPIPMode: encap.CrossSubnet, IPIPInterface: "tunl0"},
+				},
+				reject:     []netip.Prefix{netip.MustParsePrefix("10.96.0.0/12")},
+				blackholes: []netip.Prefix{netip.MustParsePrefix("192.168.1.0/26")},
+				static:     map[netip.Prefix]netip.Addr{netip.MustParsePrefix("172.17.0.1/32"): netip.MustParseAddr("10.0.0.254")},
+			}
+		})
+
+		DescribeTable("routes",
+			func(prefix string, best *speaker.Route, multipath []*speaker.Route, expected programmedRoute, program bool) {
+				pr, ok := kp.route(netip.MustParsePrefix(prefix), best, multipath)
+				Expect(ok).To(Equal(program))
+				Expect(pr).To(Equal(expected))
+			},
+			Entry("no route", "192.168.2.0/26", nil, nil, programmedRoute{}, false),
+			Entry("local block", "192.168.1.0/26", localRoute("192.168.1.0/26"), nil, programmedRoute{Blackhole: true}, true),
+			Entry("other local route", "172.16.0.0/24", localRoute("172.16.0.0/24"), nil, programmedRoute{}, false),
+			Entry("service range", "10.96.0.0/12", peerRoute("10.96.0.0/12", "10.0.0.1"), nil, programmedRoute{}, false),
+			Entry("VXLAN pool", "10.244.1.0/26", peerRoute("10.244.1.0/26", "10.0.0.1"), nil, programmedRoute{}, false),
+			Entry("IPIP cross-subnet on the same subnet", "192.168.2.0/26", peerRoute("192.168.2.0/26", "10.0.0.1"), nil,
+				programmedRoute{Nexthops: []nexthop{{Gateway: netip.MustParseAddr("10.0.0.1")}}}, true),
+			Entry("IPIP cross-subnet on another subnet", "192.168.2.0/26", peerRoute("192.168.2.0/26", "10.0.1.1"), nil,
+				programmedRoute{Nexthops: []nexthop{{Gateway: netip.MustParseAddr("10.0.1.1"), Interface: "tunl0"}}}, true),
+			Entry("IPIP cross-subnet with an extended next hop", "192.168.2.0/26", peerRoute("192.168.2.0/26", "fd00::1"), nil,
+				programmedRoute{Nexthops: []nexthop{{Gateway: netip.MustParseAddr("fd00::1")}}}, true),
+			Entry("outside the pools", "172.16.0.0/24", peerRoute("172.16.0.0/24", "10.0.1.1"), nil,
+				programmedRoute{Nexthops: []nexthop{{Gateway: netip.MustParseAddr("10.0.1.1")}}}, true),
+			Entry("multipath", "172.16.0.0/24", peerRoute("172.16.0.0/24", "10.0.1.1"),
+				[]*speaker.Route{peerRoute("172.16.0.0/24", "10.0.1.2"), peerRoute("172.16.0.0/24", "10.0.1.1")},
+				programmedRoute{Nexthops: []nexthop{
+					{Gateway: netip.MustParseAddr("10.0.1.1")},
+					{Gateway: netip.MustParseAddr("10.0.1.2")},
+				}}, true),
+			Entry("multipath across subnets", "192.168.2.0/26", peerRoute("192.168.2.0/26", "10.0.0.1"),
+				[]*speaker.Route{peerRoute("192.168.2.0/26", "10.0.1.1")},
+				programmedRoute{Nexthops: []nexthop{
+					{Gateway: netip.MustParseAddr("10.0.0.1")},
+					{Gateway: netip.MustParseAddr("10.0.1.1"), Interface: "tunl0"},
+				}}, true),
+			Entry("reachableBy", "172.17.0.1/32", nil, nil,
+				programmedRoute{Nexthops: []nexthop{{Gateway: netip.MustParseAddr("10.0.0.254")}}}, true),
+		)
+	})
+})
@@ -0,0 +1,121 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"maps"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// secretWatcher watches the Kubernetes secrets that hold the BGP passwords, and sends their data,
+// keyed on the secret name, to secrets whenever it changes.  As for confd, each secret is watched
+// by name, so that calico/node only needs access to the secrets that the BGP peers refer to.
+type secretWatcher struct {
+	clientset kubernetes.Interface
+	namespace string
+
+	lock     sync.Mutex
+	watches  map[string]chan struct{}
+	current  map[string]map[string][]byte
+	secrets  chan map[string]map[string][]byte
+	stopping bool
+}
+
+// newSecretWatcher creates a watcher for the secrets in the given namespace.
+func newSecretWatcher(clientset kubernetes.Interface, namespace string) *secretWatcher {
+	return &secretWatcher{
+		clientset: clientset,
+		namespace: namespace,
+		watches:   map[string]chan struct{}{},
+		current:   map[string]map[string][]byte{},
+		secrets:   make(chan map[string]map[string][]byte, 1),
+	}
+}
+
+// watch starts watching the named secrets, and stops watching any others.
+func (w *secretWatcher) watch(names []string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+		if _, ok := w.watches[name]; ok || w.stopping {
+			continue
+		}
+		log.WithFields(log.Fields{"name": name, "namespace": w.namespace}).Info("Watching secret for BGP password")
+		lw := cache.NewListWatchFromClient(w.clientset.CoreV1().RESTClient(), "secrets", w.namespace,
+			fields.OneTermEqualSelector("metadata.name", name))
+		_, informer := cache.NewInformer(lw, &v1.Secret{}, 0, cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { w.onUpdate(name, obj) },
+			UpdateFunc: func(_, obj interface{}) { w.onUpdate(name, obj) },
+			DeleteFunc: func(interface{}) { w.onUpdate(name, nil) },
+		})
+		stop := make(chan struct{})
+		w.watches[name] = stop
+		go informer.Run(stop)
+	}
+	for name, stop := range w.watches {
+		if !wanted[name] {
+			log.WithField("name", name).Info("Stopped watching secret for BGP password")
+			close(stop)
+			delete(w.watches, name)
+			if _, ok := w.current[name]; ok {
+				delete(w.current, name)
+				w.sendLockHeld()
+			}
+		}
+	}
+}
+
+// stop stops all the watches.
+func (w *secretWatcher) stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.stopping = true
+	for name, stop := range w.watches {
+		close(stop)
+		delete(w.watches, name)
+	}
+}
+
+func (w *secretWatcher) onUpdate(name string, obj interface{}) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if _, ok := w.watches[name]; !ok {
+		// An update that raced with the end of the watch.
+		return
+	}
+	if s, ok := obj.(*v1.Secret); ok {
+		w.current[name] = s.Data
+	} else {
+		delete(w.current, name)
+	}
+	w.sendLockHeld()
+}
+
+// sendLockHeld sends the current secrets, replacing any that haven't been consumed.
+func (w *secretWatcher) sendLockHeld() {
+	select {
+	case <-w.secrets:
+	default:
+	}
+	w.secrets <- maps.Clone(w.current)
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"maps"
+	"net/netip"
+	"slices"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// service is the part of a Kubernetes service, and its endpoints, that determines whether this
+// node advertises the service's addresses.
+type service struct {
+	Type                  v1.ServiceType
+	ClusterIP             string
+	ExternalIPs           []string
+	LoadBalancerIP        string
+	IngressIPs            []string
+	ExternalTrafficPolicy v1.ServiceExternalTrafficPolicyType
+	// LocalEndpoint is set if the service has a ready endpoint on this node, in the same IP
+	// family as its cluster IP.
+	LocalEndpoint bool
+}
+
+// serviceRoutes returns the host routes to advertise for the service, following the same rules as
+// confd's route generator: the addresses of services with a local external traffic policy are
+// advertised by the nodes that host their endpoints, and load balancer addresses that are
+// configured as single addresses are advertised by every node.  clusterCIDRs, externalCIDRs and
+// lbCIDRs are the service address ranges of the BGPConfiguration.
+func (svc *service) serviceRoutes(clusterCIDRs, externalCIDRs, lbCIDRs []netip.Prefix) []netip.Prefix {
+	switch svc.Type {
+	case v1.ServiceTypeClusterIP, v1.ServiceTypeNodePort, v1.ServiceTypeLoadBalancer:
+	default:
+		return nil
+	}
+	if svc.ClusterIP == "" || svc.ClusterIP == v1.ClusterIPNone {
+		return nil
+	}
+
+	advertise := false
+	if svc.Type == v1.ServiceTypeLoadBalancer && svc.ExternalTrafficPolicy == v1.ServiceExternalTrafficPolicyTypeCluster {
+		if addr, err := netip.ParseAddr(svc.LoadBalancerIP); err == nil {
+			for _, cidr := range lbCIDRs {
+				if cidr.IsSingleIP() && cidr.Addr() == addr {
+					advertise = true
+				}
+			}
+		}
+	}
+	if svc.ExternalTrafficPolicy == v1.ServiceExternalTrafficPolicyTypeLocal && svc.LocalEndpoint {
+		advertise = true
+	}
+	if !advertise {
+		return nil
+	}
+
+	var routes []netip.Prefix
+	add := func(ip string, allowed []netip.Prefix) {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			log.WithField("ip", ip).Debug("Ignoring invalid service address")
+			return
+		}
+		for _, cidr := range allowed {
+			if cidr.Contains(addr) {
+				routes = append(routes, netip.PrefixFrom(addr, addr.BitLen()))
+				return
+			}
+		}
+	}
+	if len(clusterCIDRs) > 0 {
+		// Cluster IPs are only advertised if the cluster IP range is.
+		add(svc.ClusterIP, []netip.Prefix{netip.MustParsePrefix("0.0.0.0/0"), netip.MustParsePrefix("::/0")})
+	}
+	for _, ip := range svc.ExternalIPs {
+		add(ip, externalCIDRs)
+	}
+	for _, ip := range svc.IngressIPs {
+		add(ip, lbCIDRs)
+	}
+	return routes
+}
+
+// serviceWatcher watches the Kubernetes services and endpoints, and sends the services to
+// services whenever they change.
+type serviceWatcher struct {
+	nodename string
+
+	svcIndexer, epIndexer   cache.Indexer
+	svcInformer, epInformer cache.Controller
+
+	lock     sync.Mutex
+	synced   bool
+	current  map[string]service
+	services chan map[string]service
+}
+
+// newServiceWatcher creates a watcher for the services.  nodename is the name of this node in the
+// Kubernetes API.
+func newServiceWatcher(clientset kubernetes.Interface, nodename string) *serviceWatcher {
+	w := &serviceWatcher{
+		nodename: nodename,
+		current:  map[string]service{},
+		services: make(chan map[string]service, 1),
+	}
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.onUpdate,
+		UpdateFunc: func(_, obj interface{}) { w.onUpdate(obj) },
+		DeleteFunc: w.onUpdate,
+	}
+	svcWatcher := cache.NewListWatchFromClient(clientset.CoreV1().RESTClient(), "services", "", fields.Everything())
+	w.svcIndexer, w.svcInformer = cache.NewIndexerInformer(svcWatcher, &v1.Service{}, 0, handler, cache.Indexers{})
+	epWatcher := cache.NewListWatchFromClient(clientset.CoreV1().RESTClient(), "endpoints", "", fields.Everything())
+	w.epIndexer, w.epInformer = cache.NewIndexerInformer(epWatcher, &v1.Endpoints{}, 0, handler, cache.Indexers{})
+	return w
+}
+
+// run runs the informers until stop is closed.  The services are first sent once the informers
+// are in sync.
+func (w *serviceWatcher) run(stop <-chan struct{}) {
+	go w.svcInformer.Run(stop)
+	go w.epInformer.Run(stop)
+	if !cache.WaitForCacheSync(stop, w.svcInformer.HasSynced, w.epInformer.HasSynced) {
+		return
+	}
+	log.Info("Kubernetes services are in sync")
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.synced = true
+	w.sendLockHeld()
+}
+
+// onUpdate recomputes the service that a service or endpoints object belongs to.
+func (w *serviceWatcher) onUpdate(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.WithError(err).Warn("Failed to get key for service update")
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	svc, ok := w.service(key)
+	if old, had := w.current[key]; ok == had && (!ok || equalServices(old, svc)) {
+		return
+	}
+	if ok {
+		w.current[key] = svc
+	} else {
+		delete(w.current, key)
+	}
+	if w.synced {
+		w.sendLockHeld()
+	}
+}
+
+// service returns the service with the given key, or false if there isn't one.
+func (w *serviceWatcher) service(key string) (service, bool) {
+	obj, exists, err := w.svcIndexer.GetByKey(key)
+	if err != nil || !exists {
+		return service{}, false
+	}
+	s := obj.(*v1.Service)
+	svc := service{
+		Type:                  s.Spec.Type,
+		ClusterIP:             s.Spec.ClusterIP,
+		ExternalIPs:           s.Spec.ExternalIPs,
+		LoadBalancerIP:        s.Spec.LoadBalancerIP,
+		ExternalTrafficPolicy: s.Spec.ExternalTrafficPolicy,
+	}
+	for _, ingress := range s.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			svc.IngressIPs = append(svc.IngressIPs, ingress.IP)
+		}
+	}
+	if obj, exists, err := w.epIndexer.GetByKey(key); err == nil && exists {
+		svcIsIPv6 := isIPv6(svc.ClusterIP)
+		for _, subset := range obj.(*v1.Endpoints).Subsets {
+			for _, address := range subset.Addresses {
+				if address.NodeName != nil && *address.NodeName == w.nodename && isIPv6(address.IP) == svcIsIPv6 {
+					svc.LocalEndpoint = true
+				}
+			}
+		}
+	}
+	return svc, true
+}
+
+func isIPv6(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && addr.Is6()
+}
+
+func equalServices(a, b service) bool {
+	return a.Type == b.Type && a.ClusterIP == b.ClusterIP && a.LoadBalancerIP == b.LoadBalancerIP &&
+		a.ExternalTrafficPolicy == b.ExternalTrafficPolicy && a.LocalEndpoint == b.LocalEndpoint &&
+		slices.Equal(a.ExternalIPs, b.ExternalIPs) && slices.Equal(a.IngressIPs, b.IngressIPs)
+}
+
+// sendLockHeld sends the current services, replacing any that haven't been consumed.
+func (w *serviceWatcher) sendLockHeld() {
+	select {
+	case <-w.services:
+	default:
+	}
+	w.services <- maps.Clone(w.current)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package speaker
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// birdConfig is the configuration of a BIRD peer of the speaker at 127.0.0.1, which originates
+// 10.66.0.0/26 from 127.0.0.3.
+const birdConfig = `
+router id 127.0.0.3;
+listen bgp address 127.0.0.3 port %[1]d;
+
+protocol device {
+}
+
+protocol static {
+	route 10.66.0.0/26 blackhole;
+}
+
+protocol bgp speaker {
+	local 127.0.0.3 as 64513;
+	neighbor 127.0.0.1 port %[1]d as %[2]d;
+	import all;
+	export all;
+	connect retry time 1;
+	error wait time 1, 2;
+	%[3]s
+}
+`
+
+// runBIRD runs BIRD with the given AS for the speaker, and extra configuration of the peering.
+// It returns the path of BIRD's control socket, and a function that stops BIRD.
+func runBIRD(peerAS uint32, extra string) (string, func()) {
+	dir, err := os.MkdirTemp("", "bird")
+	Expect(err).NotTo(HaveOccurred())
+	conf := filepath.Join(dir, "bird.conf")
+	Expect(os.WriteFile(conf, []byte(fmt.Sprintf(birdConfig, testPort, peerAS, extra)), 0o644)).To(Succeed())
+	socket := filepath.Join(dir, "bird.ctl")
+
+	cmd := exec.Command("bird", "-f", "-c", conf, "-s", socket)
+	cmd.Stdout, cmd.Stderr = GinkgoWriter, GinkgoWriter
+	Expect(cmd.Start()).To(Succeed())
+	stop := func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		_ = os.RemoveAll(dir)
+	}
+	Eventually(func() error {
+		_, err := os.Stat(socket)
+		return err
+	}, "5s").Should(Succeed())
+	return socket, stop
+}
+
+// birdRoutes returns the output of BIRD's "show route" command.
+func birdRoutes(socket string) (string, error) {
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(2 * time.Second))
+	if _, err := conn.Write([]byte("show route\n")); err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "0000") {
+			break
+		}
+		out.WriteString(line + "\n")
+	}
+	return out.String(), scanner.Err()
+}
+
+var _ = Describe("BGP speaker interoperability with BIRD", func() {
+	var a *testSpeaker
+	var stopBIRD func()
+
+	BeforeEach(func() {
+		a, stopBIRD = nil, nil
+		if _, err := exec.LookPath("bird"); err != nil {
+			Skip("BIRD is not installed")
+		}
+		a = newTestSpeaker("127.0.0.1")
+	})
+
+	AfterEach(func() {
+		if a != nil {
+			a.Stop()
+		}
+		if stopBIRD != nil {
+			stopBIRD()
+		}
+	})
+
+	DescribeTable("should exchange routes with BIRD",
+		func(as uint32, password, birdExtra string) {
+			birdPeerAS := as
+			if as > 0xffff && strings.Contains(birdExtra, "enable as4 off") {
+				// A BIRD without four-octet AS support peers with AS_TRANS.
+				birdPeerAS = 23456
+			}
+			var socket string
+			socket, stopBIRD = runBIRD(birdPeerAS, birdExtra)
+
+			cfg := a.config(as, "10.65.0.0/26")
+			cfg.Peers = []PeerConfig{{
+				Address:      netip.MustParseAddr("127.0.0.3"),
+				Port:         testPort,
+				AS:           64513,
+				LocalAddress: a.addr,
+				Password:     password,
+			}}
+			Expect(a.Configure(cfg)).To(Succeed())
+
+			Eventually(a.established(), "10s").Should(Equal([]bool{true}))
+			Eventually(a.route("10.66.0.0/26"), "5s").ShouldNot(BeNil())
+			Expect(a.route("10.66.0.0/26")().Attrs.ASPath).To(Equal([]ASPathSegment{{ASNs: []uint32{64513}}}))
+			Eventually(func() (string, error) { return birdRoutes(socket) }, "5s").Should(
+				ContainSubstring("10.65.0.0/26"))
+		},
+		Entry("over eBGP", uint32(64512), "", ""),
+		Entry("with TCP-MD5", uint32(64512), "s3cret", `password "s3cret";`),
+		Entry("without four-octet AS support", uint32(4200000001), "", "enable as4 off;"),
+	)
+})
@@ -0,0 +1,60 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package speaker
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// MaxPasswordLen is the longest TCP-MD5 key that the kernel accepts.
+const MaxPasswordLen = unix.TCP_MD5SIG_MAXKEYLEN
+
+// setTCPMD5 sets the TCP-MD5 signature key (RFC 2385) for the peer's address on a socket, or
+// removes it if the key is empty.  This is BIRD's "password".  On a listening socket, the key
+// applies to the connections that the peer makes to us.  ipv6 is set for an IPv6 socket, on which
+// an IPv4 address is given in its IPv4-mapped form.
+func setTCPMD5(c syscall.RawConn, ipv6 bool, addr netip.Addr, key string) error {
+	if len(key) > MaxPasswordLen {
+		return fmt.Errorf("TCP-MD5 key is longer than %d bytes", MaxPasswordLen)
+	}
+	sig := unix.TCPMD5Sig{Keylen: uint16(len(key))}
+	copy(sig.Key[:], key)
+	if ipv6 {
+		sa := (*unix.RawSockaddrInet6)(unsafe.Pointer(&sig.Addr))
+		sa.Family = unix.AF_INET6
+		sa.Addr = addr.As16()
+	} else {
+		sa := (*unix.RawSockaddrInet4)(unsafe.Pointer(&sig.Addr))
+		sa.Family = unix.AF_INET
+		sa.Addr = addr.As4()
+	}
+	var err error
+	if cerr := c.Control(func(fd uintptr) {
+		err = unix.SetsockoptTCPMD5Sig(int(fd), unix.IPPROTO_TCP, unix.TCP_MD5SIG, &sig)
+	}); cerr != nil {
+		return cerr
+	}
+	if key == "" && errors.Is(err, unix.ENOENT) {
+		// There was no key to remove.
+		return nil
+	}
+	return err
+}
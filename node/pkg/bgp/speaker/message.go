@@ -0,0 +1,901 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package speaker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/netip"
+)
+
+const (
+	msgTypeOpen         = 1
+	msgTypeUpdate       = 2
+	msgTypeNotification = 3
+	msgTypeKeepalive    = 4
+
+	headerLen     = 19
+	maxMessageLen = 4096
+
+	bgpVersion = 4
+	asTrans    = 23456
+)
+
+// Path attribute type codes.
+const (
+	attrOrigin           = 1
+	attrASPath           = 2
+	attrNextHop          = 3
+	attrMED              = 4
+	attrLocalPref        = 5
+	attrCommunities      = 8
+	attrAggregator       = 7
+	attrOriginatorID     = 9
+	attrClusterList      = 10
+	attrMPReachNLRI      = 14
+	attrMPUnreachNLRI    = 15
+	attrAS4Path          = 17
+	attrAS4Aggregator    = 18
+	attrLargeCommunities = 32
+)
+
+// Path attribute flags.
+const (
+	flagOptional   = 0x80
+	flagTransitive = 0x40
+	flagPartial    = 0x20
+	flagExtLen     = 0x10
+)
+
+// Capability codes.
+const (
+	capMultiprotocol   = 1
+	capExtendedNextHop = 5
+	capGracefulRestart = 64
+	capFourOctetAS     = 65
+)
+
+const (
+	asPathSegmentSet      = 1
+	asPathSegmentSequence = 2
+)
+
+// Origin attribute values.
+const (
+	OriginIGP        = 0
+	OriginEGP        = 1
+	OriginIncomplete = 2
+)
+
+// NOTIFICATION error codes and subcodes.
+const (
+	errCodeMessageHeader    = 1
+	errCodeOpenMessage      = 2
+	errCodeUpdateMessage    = 3
+	errCodeHoldTimerExpired = 4
+	errCodeFSM              = 5
+	errCodeCease            = 6
+
+	errSubcodeBadMessageLength      = 2
+	errSubcodeBadMessageType        = 3
+	errSubcodeUnsupportedVersion    = 1
+	errSubcodeBadPeerAS             = 2
+	errSubcodeBadBGPIdentifier      = 3
+	errSubcodeUnacceptableHoldTime  = 6
+	errSubcodeUnsupportedCapability = 7
+	errSubcodeMalformedAttributes   = 1
+	errSubcodeAdminShutdown         = 2
+	errSubcodeConnectionRejected    = 5
+	errSubcodeOtherConfigChange     = 6
+	errSubcodeConnectionCollision   = 7
+)
+
+// Family is a BGP address family.
+type Family struct {
+	AFI  uint16
+	SAFI uint8
+}
+
+var (
+	FamilyIPv4Unicast = Family{AFI: 1, SAFI: 1}
+	FamilyIPv6Unicast = Family{AFI: 2, SAFI: 1}
+)
+
+func (f Family) String() string {
+	switch f {
+	case FamilyIPv4Unicast:
+		return "ipv4-unicast"
+	case FamilyIPv6Unicast:
+		return "ipv6-unicast"
+	}
+	return fmt.Sprintf("afi-%d-safi-%d", f.AFI, f.SAFI)
+}
+
+// FamilyOf returns the unicast family of the prefix.
+func FamilyOf(p netip.Prefix) Family {
+	if p.Addr().Is4() {
+		return FamilyIPv4Unicast
+	}
+	return FamilyIPv6Unicast
+}
+
+func (f Family) addrLen() int {
+	if f == FamilyIPv4Unicast {
+		return 4
+	}
+	return 16
+}
+
+// Open is a BGP OPEN message.  Only the capabilities that we use are represented.
+type Open struct {
+	AS          uint32
+	HoldTime    uint16
+	RouterID    netip.Addr
+	Families    []Family
+	FourOctetAS bool
+	// ExtendedNextHop is set if the speaker supports IPv4 unicast routes with IPv6 next hops
+	// (RFC 8950).
+	ExtendedNextHop bool
+
+	// GracefulRestart is the graceful restart capability, or nil if it is absent.
+	GracefulRestart *GracefulRestart
+}
+
+// GracefulRestart is the graceful restart capability (RFC 4724).
+type GracefulRestart struct {
+	// Restarting is set by a speaker that has just restarted.
+	Restarting bool
+	// Time is how long, in seconds, the peer should retain our routes after the session fails.
+	Time uint16
+	// Families are the families whose forwarding state has been preserved.
+	Families []Family
+}
+
+// Notification is a BGP NOTIFICATION message.  It is also used as the error for a protocol error
+// that should be reported to the peer.
+type Notification struct {
+	Code    uint8
+	Subcode uint8
+	Data    []byte
+}
+
+func (n *Notification) Error() string {
+	return fmt.Sprintf("BGP notification code %d subcode %d", n.Code, n.Subcode)
+}
+
+// ASPathSegment is an AS_SEQUENCE or AS_SET segment of an AS path.
+type ASPathSegment struct {
+	Set  bool
+	ASNs []uint32
+}
+
+// LargeCommunity is a large BGP community (RFC 8092).
+type LargeCommunity struct {
+	Global, Local1, Local2 uint32
+}
+
+// PathAttrs are the path attributes of a route.
+type PathAttrs struct {
+	Origin           uint8
+	ASPath           []ASPathSegment
+	NextHop          netip.Addr
+	MED              *uint32
+	LocalPref        *uint32
+	Communities      []uint32
+	LargeCommunities []LargeCommunity
+	OriginatorID     netip.Addr
+	ClusterList      []netip.Addr
+
+	// Unknown holds the optional transitive attributes that we don't understand, which we pass on.
+	Unknown []RawAttr
+}
+
+// RawAttr is an undecoded path attribute.
+type RawAttr struct {
+	Flags uint8
+	Type  uint8
+	Value []byte
+}
+
+// Clone returns a copy of the attributes that can be modified without affecting the original.
+func (a *PathAttrs) Clone() *PathAttrs {
+	c := *a
+	c.ASPath = make([]ASPathSegment, len(a.ASPath))
+	for i, seg := range a.ASPath {
+		c.ASPath[i] = ASPathSegment{Set: seg.Set, ASNs: append([]uint32(nil), seg.ASNs...)}
+	}
+	c.Communities = append([]uint32(nil), a.Communities...)
+	c.LargeCommunities = append([]LargeCommunity(nil), a.LargeCommunities...)
+	c.ClusterList = append([]netip.Addr(nil), a.ClusterList...)
+	c.Unknown = append([]RawAttr(nil), a.Unknown...)
+	return &c
+}
+
+// ASPathLen returns the length of the AS path for route selection; an AS_SET counts as one.
+func (a *PathAttrs) ASPathLen() int {
+	n := 0
+	for _, seg := range a.ASPath {
+		if seg.Set {
+			n++
+		} else {
+			n += len(seg.ASNs)
+		}
+	}
+	return n
+}
+
+// countAS returns the number of times the AS appears in the AS path.
+func (a *PathAttrs) countAS(as uint32) int {
+	n := 0
+	for _, seg := range a.ASPath {
+		for _, asn := range seg.ASNs {
+			if asn == as {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// neighborAS returns the first AS in the AS path, or 0 for a route from our own AS.
+func (a *PathAttrs) neighborAS() uint32 {
+	if len(a.ASPath) == 0 || a.ASPath[0].Set || len(a.ASPath[0].ASNs) == 0 {
+		return 0
+	}
+	return a.ASPath[0].ASNs[0]
+}
+
+// prependAS prepends the AS to the AS path.
+func (a *PathAttrs) prependAS(as uint32) {
+	if len(a.ASPath) > 0 && !a.ASPath[0].Set && len(a.ASPath[0].ASNs) < 255 {
+		a.ASPath[0].ASNs = append([]uint32{as}, a.ASPath[0].ASNs...)
+		return
+	}
+	a.ASPath = append([]ASPathSegment{{ASNs: []uint32{as}}}, a.ASPath...)
+}
+
+// Update holds the routes of one address family in a BGP UPDATE message.  An update without
+// any withdrawn or advertised routes is the End-of-RIB marker for its family.
+type Update struct {
+	Family    Family
+	Withdrawn []netip.Prefix
+	NLRI      []netip.Prefix
+	// Attrs are the attributes of the advertised routes.  They are nil if there are none.
+	Attrs *PathAttrs
+}
+
+// IsEndOfRIB returns true if the update is an End-of-RIB marker.
+func (u *Update) IsEndOfRIB() bool {
+	return len(u.Withdrawn) == 0 && len(u.NLRI) == 0
+}
+
+// writeMessage writes a BGP message with the given type and body.
+func writeMessage(w io.Writer, msgType uint8, body []byte) error {
+	_, err := w.Write(encodeMessage(msgType, body))
+	return err
+}
+
+func encodeMessage(msgType uint8, body []byte) []byte {
+	msg := make([]byte, headerLen+len(body))
+	for i := 0; i < 16; i++ {
+		msg[i] = 0xff
+	}
+	binary.BigEndian.PutUint16(msg[16:], uint16(len(msg)))
+	msg[18] = msgType
+	copy(msg[headerLen:], body)
+	return msg
+}
+
+// readMessage reads a BGP message, returning its type and body.
+func readMessage(r io.Reader) (uint8, []byte, error) {
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	for i := 0; i < 16; i++ {
+		if header[i] != 0xff {
+			return 0, nil, &Notification{Code: errCodeMessageHeader, Subcode: 1}
+		}
+	}
+	length := int(binary.BigEndian.Uint16(header[16:]))
+	if length < headerLen || length > maxMessageLen {
+		return 0, nil, &Notification{Code: errCodeMessageHeader, Subcode: errSubcodeBadMessageLength, Data: header[16:18]}
+	}
+	body := make([]byte, length-headerLen)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header[18], body, nil
+}
+
+func (o *Open) marshal() []byte {
+	var caps bytes.Buffer
+	writeCap := func(code uint8, value []byte) {
+		caps.WriteByte(code)
+		caps.WriteByte(uint8(len(value)))
+		caps.Write(value)
+	}
+	for _, f := range o.Families {
+		writeCap(capMultiprotocol, []byte{byte(f.AFI >> 8), byte(f.AFI), 0, f.SAFI})
+	}
+	if o.ExtendedNextHop {
+		// The NLRI AFI, NLRI SAFI and next hop AFI of the routes with extended next hops.
+		writeCap(capExtendedNextHop, []byte{
+			0, byte(FamilyIPv4Unicast.AFI), 0, FamilyIPv4Unicast.SAFI, 0, byte(FamilyIPv6Unicast.AFI),
+		})
+	}
+	if gr := o.GracefulRestart; gr != nil {
+		value := make([]byte, 2, 2+4*len(gr.Families))
+		flagsAndTime := gr.Time & 0x0fff
+		if gr.Restarting {
+			flagsAndTime |= 0x8000
+		}
+		binary.BigEndian.PutUint16(value, flagsAndTime)
+		for _, f := range gr.Families {
+			// Set the forwarding state bit: routes stay in the kernel while we restart.
+			value = append(value, byte(f.AFI>>8), byte(f.AFI), f.SAFI, 0x80)
+		}
+		writeCap(capGracefulRestart, value)
+	}
+	if o.FourOctetAS {
+		asValue := make([]byte, 4)
+		binary.BigEndian.PutUint32(asValue, o.AS)
+		writeCap(capFourOctetAS, asValue)
+	}
+
+	body := make([]byte, 10, 10+2+caps.Len())
+	body[0] = bgpVersion
+	myAS := o.AS
+	if myAS > 0xffff {
+		myAS = asTrans
+	}
+	binary.BigEndian.PutUint16(body[1:], uint16(myAS))
+	binary.BigEndian.PutUint16(body[3:], o.HoldTime)
+	id := o.RouterID.As4()
+	copy(body[5:], id[:])
+	// Each capability goes in its own optional parameter of type 2.
+	body[9] = uint8(2 + caps.Len())
+	body = append(body, 2, uint8(caps.Len()))
+	return append(body, caps.Bytes()...)
+}
+
+func parseOpen(body []byte) (*Open, error) {
+	if len(body) < 10 {
+		return nil, &Notification{Code: errCodeMessageHeader, Subcode: errSubcodeBadMessageLength}
+	}
+	if body[0] != bgpVersion {
+		return nil, &Notification{Code: errCodeOpenMessage, Subcode: errSubcodeUnsupportedVersion, Data: []byte{0, bgpVersion}}
+	}
+	o := &Open{
+		AS:       uint32(binary.BigEndian.Uint16(body[1:])),
+		HoldTime: binary.BigEndian.Uint16(body[3:]),
+		RouterID: netip.AddrFrom4([4]byte(body[5:9])),
+	}
+	params := body[10:]
+	if int(body[9]) != len(params) {
+		return nil, &Notification{Code: errCodeOpenMessage}
+	}
+	for len(params) > 0 {
+		if len(params) < 2 || len(params) < 2+int(params[1]) {
+			return nil, &Notification{Code: errCodeOpenMessage}
+		}
+		paramType, value := params[0], params[2:2+int(params[1])]
+		params = params[2+int(params[1]):]
+		if paramType != 2 {
+			// Not a capabilities parameter.
+			continue
+		}
+		for len(value) > 0 {
+			if len(value) < 2 || len(value) < 2+int(value[1]) {
+				return nil, &Notification{Code: errCodeOpenMessage}
+			}
+			code, capValue := value[0], value[2:2+int(value[1])]
+			value = value[2+int(value[1]):]
+			switch code {
+			case capMultiprotocol:
+				if len(capValue) == 4 {
+					o.Families = append(o.Families, Family{AFI: binary.BigEndian.Uint16(capValue), SAFI: capValue[3]})
+				}
+			case capExtendedNextHop:
+				for tuples := capValue; len(tuples) >= 6; tuples = tuples[6:] {
+					f := Family{AFI: binary.BigEndian.Uint16(tuples), SAFI: uint8(binary.BigEndian.Uint16(tuples[2:]))}
+					if f == FamilyIPv4Unicast && binary.BigEndian.Uint16(tuples[4:]) == FamilyIPv6Unicast.AFI {
+						o.ExtendedNextHop = true
+					}
+				}
+			case capFourOctetAS:
+				if len(capValue) == 4 {
+					o.FourOctetAS = true
+					o.AS = binary.BigEndian.Uint32(capValue)
+				}
+			case capGracefulRestart:
+				if len(capValue) < 2 {
+					continue
+				}
+				flagsAndTime := binary.BigEndian.Uint16(capValue)
+				gr := &GracefulRestart{Restarting: flagsAndTime&0x8000 != 0, Time: flagsAndTime & 0x0fff}
+				for fams := capValue[2:]; len(fams) >= 4; fams = fams[4:] {
+					if fams[3]&0x80 != 0 {
+						gr.Families = append(gr.Families, Family{AFI: binary.BigEndian.Uint16(fams), SAFI: fams[2]})
+					}
+				}
+				o.GracefulRestart = gr
+			}
+		}
+	}
+	if len(o.Families) == 0 {
+		// A speaker that doesn't advertise multiprotocol capabilities only supports IPv4 unicast.
+		o.Families = []Family{FamilyIPv4Unicast}
+	}
+	return o, nil
+}
+
+func (n *Notification) marshal() []byte {
+	return append([]byte{n.Code, n.Subcode}, n.Data...)
+}
+
+func parseNotification(body []byte) *Notification {
+	n := &Notification{}
+	if len(body) >= 2 {
+		n.Code, n.Subcode, n.Data = body[0], body[1], body[2:]
+	}
+	return n
+}
+
+func appendPrefix(b []byte, p netip.Prefix) []byte {
+	b = append(b, uint8(p.Bits()))
+	addr := p.Addr().AsSlice()
+	return append(b, addr[:(p.Bits()+7)/8]...)
+}
+
+func parsePrefixes(b []byte, f Family) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for len(b) > 0 {
+		bits := int(b[0])
+		n := (bits + 7) / 8
+		if bits > f.addrLen()*8 || len(b) < 1+n {
+			return nil, &Notification{Code: errCodeUpdateMessage, Subcode: 10}
+		}
+		addr := make([]byte, f.addrLen())
+		copy(addr, b[1:1+n])
+		ip, _ := netip.AddrFromSlice(addr)
+		prefixes = append(prefixes, netip.PrefixFrom(ip, bits).Masked())
+		b = b[1+n:]
+	}
+	return prefixes, nil
+}
+
+func appendAttr(b []byte, flags, attrType uint8, value []byte) []byte {
+	if len(value) > 255 {
+		b = append(b, flags|flagExtLen, attrType, byte(len(value)>>8), byte(len(value)))
+	} else {
+		b = append(b, flags, attrType, byte(len(value)))
+	}
+	return append(b, value...)
+}
+
+// marshalASPath encodes the AS path with four-octet AS numbers, or with two-octet AS numbers for
+// a peer without four-octet AS support, in which case the AS numbers that don't fit are replaced
+// by AS_TRANS.  It returns true if any were replaced.
+func marshalASPath(segs []ASPathSegment, as4 bool) ([]byte, bool) {
+	var path []byte
+	replaced := false
+	for _, seg := range segs {
+		segType := uint8(asPathSegmentSequence)
+		if seg.Set {
+			segType = asPathSegmentSet
+		}
+		path = append(path, segType, uint8(len(seg.ASNs)))
+		for _, asn := range seg.ASNs {
+			switch {
+			case as4:
+				path = binary.BigEndian.AppendUint32(path, asn)
+			case asn > 0xffff:
+				path = binary.BigEndian.AppendUint16(path, asTrans)
+				replaced = true
+			default:
+				path = binary.BigEndian.AppendUint16(path, uint16(asn))
+			}
+		}
+	}
+	return path, replaced
+}
+
+// marshal encodes the path attributes, apart from those carrying the routes.  as4 is false for a
+// peer without four-octet AS support, to which the full AS path is sent in AS4_PATH (RFC 6793).
+func (a *PathAttrs) marshal(f Family, as4 bool) []byte {
+	var b []byte
+	b = appendAttr(b, flagTransitive, attrOrigin, []byte{a.Origin})
+
+	path, replaced := marshalASPath(a.ASPath, as4)
+	b = appendAttr(b, flagTransitive, attrASPath, path)
+	if replaced {
+		as4Path, _ := marshalASPath(a.ASPath, true)
+		b = appendAttr(b, flagOptional|flagTransitive, attrAS4Path, as4Path)
+	}
+
+	if f == FamilyIPv4Unicast && a.NextHop.Is4() {
+		nh := a.NextHop.As4()
+		b = appendAttr(b, flagTransitive, attrNextHop, nh[:])
+	}
+	if a.MED != nil {
+		b = appendAttr(b, flagOptional, attrMED, binary.BigEndian.AppendUint32(nil, *a.MED))
+	}
+	if a.LocalPref != nil {
+		b = appendAttr(b, flagTransitive, attrLocalPref, binary.BigEndian.AppendUint32(nil, *a.LocalPref))
+	}
+	if len(a.Communities) > 0 {
+		var v []byte
+		for _, c := range a.Communities {
+			v = binary.BigEndian.AppendUint32(v, c)
+		}
+		b = appendAttr(b, flagOptional|flagTransitive, attrCommunities, v)
+	}
+	if a.OriginatorID.IsValid() {
+		id := a.OriginatorID.As4()
+		b = appendAttr(b, flagOptional, attrOriginatorID, id[:])
+	}
+	if len(a.ClusterList) > 0 {
+		var v []byte
+		for _, id := range a.ClusterList {
+			id4 := id.As4()
+			v = append(v, id4[:]...)
+		}
+		b = appendAttr(b, flagOptional, attrClusterList, v)
+	}
+	if len(a.LargeCommunities) > 0 {
+		var v []byte
+		for _, c := range a.LargeCommunities {
+			v = binary.BigEndian.AppendUint32(v, c.Global)
+			v = binary.BigEndian.AppendUint32(v, c.Local1)
+			v = binary.BigEndian.AppendUint32(v, c.Local2)
+		}
+		b = appendAttr(b, flagOptional|flagTransitive, attrLargeCommunities, v)
+	}
+	for _, u := range a.Unknown {
+		b = appendAttr(b, u.Flags&^flagExtLen|flagPartial, u.Type, u.Value)
+	}
+	return b
+}
+
+// marshal encodes the update as one or more UPDATE message bodies, each of which fits in a
+// BGP message.  as4 is false for a peer without four-octet AS support.
+func (u *Update) marshal(as4 bool) [][]byte {
+	const maxBody = maxMessageLen - headerLen
+	var attrs []byte
+	if u.Attrs != nil {
+		attrs = u.Attrs.marshal(u.Family, as4)
+	}
+
+	var bodies [][]byte
+	withdrawn, nlri := u.Withdrawn, u.NLRI
+	for first := true; first || len(withdrawn) > 0 || len(nlri) > 0; first = false {
+		// Fill the message with as many of the routes as will fit.
+		var w, n []byte
+		var wi, ni int
+		size := 4
+		if u.multiprotocol() {
+			// The AFI, SAFI and next hop go in the MP_REACH_NLRI or MP_UNREACH_NLRI attributes.
+			size += 2 * (4 + 3 + 1 + 16 + 1)
+		}
+		for ; wi < len(withdrawn); wi++ {
+			p := withdrawn[wi]
+			if size+1+(p.Bits()+7)/8 > maxBody {
+				break
+			}
+			w = appendPrefix(w, p)
+			size += 1 + (p.Bits()+7)/8
+		}
+		if wi == len(withdrawn) && len(nlri) > 0 {
+			size += len(attrs)
+			for ; ni < len(nlri); ni++ {
+				p := nlri[ni]
+				if size+1+(p.Bits()+7)/8 > maxBody {
+					break
+				}
+				n = appendPrefix(n, p)
+				size += 1 + (p.Bits()+7)/8
+			}
+		}
+		withdrawn, nlri = withdrawn[wi:], nlri[ni:]
+		bodies = append(bodies, u.marshalBody(w, n, ni > 0, attrs))
+	}
+	return bodies
+}
+
+// multiprotocol returns true if the routes are carried in the MP_REACH_NLRI and MP_UNREACH_NLRI
+// attributes (RFC 4760): all routes apart from IPv4 unicast routes with IPv4 next hops.
+func (u *Update) multiprotocol() bool {
+	return u.Family != FamilyIPv4Unicast || (u.Attrs != nil && u.Attrs.NextHop.Is6())
+}
+
+func (u *Update) marshalBody(withdrawn, nlri []byte, hasNLRI bool, attrs []byte) []byte {
+	var body []byte
+	if !u.multiprotocol() {
+		body = binary.BigEndian.AppendUint16(body, uint16(len(withdrawn)))
+		body = append(body, withdrawn...)
+		var pathAttrs []byte
+		if hasNLRI {
+			pathAttrs = attrs
+		}
+		body = binary.BigEndian.AppendUint16(body, uint16(len(pathAttrs)))
+		body = append(body, pathAttrs...)
+		return append(body, nlri...)
+	}
+
+	afiSafi := []byte{byte(u.Family.AFI >> 8), byte(u.Family.AFI), u.Family.SAFI}
+	var pathAttrs []byte
+	if hasNLRI {
+		nh := u.Attrs.NextHop.As16()
+		reach := append(append(afiSafi, 16), nh[:]...)
+		reach = append(append(reach, 0), nlri...)
+		pathAttrs = appendAttr(attrs, flagOptional, attrMPReachNLRI, reach)
+	}
+	if len(withdrawn) > 0 || !hasNLRI {
+		// An empty MP_UNREACH_NLRI is the End-of-RIB marker.
+		pathAttrs = appendAttr(pathAttrs, flagOptional, attrMPUnreachNLRI, append(afiSafi, withdrawn...))
+	}
+	body = binary.BigEndian.AppendUint16(body, 0)
+	body = binary.BigEndian.AppendUint16(body, uint16(len(pathAttrs)))
+	return append(body, pathAttrs...)
+}
+
+func malformed() error {
+	return &Notification{Code: errCodeUpdateMessage, Subcode: errSubcodeMalformedAttributes}
+}
+
+// parseUpdate decodes an UPDATE message body into an update for each address family that it
+// contains.  as4 is false for a peer without four-octet AS support, whose AS paths are rebuilt
+// from its AS_PATH and AS4_PATH attributes.
+func parseUpdate(body []byte, as4 bool) ([]*Update, error) {
+	if len(body) < 4 {
+		return nil, malformed()
+	}
+	wLen := int(binary.BigEndian.Uint16(body))
+	if len(body) < 4+wLen {
+		return nil, malformed()
+	}
+	withdrawn, err := parsePrefixes(body[2:2+wLen], FamilyIPv4Unicast)
+	if err != nil {
+		return nil, err
+	}
+	aLen := int(binary.BigEndian.Uint16(body[2+wLen:]))
+	if len(body) < 4+wLen+aLen {
+		return nil, malformed()
+	}
+	nlri, err := parsePrefixes(body[4+wLen+aLen:], FamilyIPv4Unicast)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := &PathAttrs{}
+	var mpReach, mpUnreach *Update
+	var as4Path []ASPathSegment
+	for b := body[4+wLen : 4+wLen+aLen]; len(b) > 0; {
+		if len(b) < 3 {
+			return nil, malformed()
+		}
+		flags, attrType := b[0], b[1]
+		var value []byte
+		if flags&flagExtLen != 0 {
+			if len(b) < 4 || len(b) < 4+int(binary.BigEndian.Uint16(b[2:])) {
+				return nil, malformed()
+			}
+			value, b = b[4:4+int(binary.BigEndian.Uint16(b[2:]))], b[4+int(binary.BigEndian.Uint16(b[2:])):]
+		} else {
+			if len(b) < 3+int(b[2]) {
+				return nil, malformed()
+			}
+			value, b = b[3:3+int(b[2])], b[3+int(b[2]):]
+		}
+		if !as4 && attrType == attrAS4Path {
+			if as4Path, err = parseASPath(value, 4); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := attrs.parseAttr(flags, attrType, value, as4, &mpReach, &mpUnreach); err != nil {
+			return nil, err
+		}
+	}
+	if as4Path != nil {
+		attrs.ASPath = mergeAS4Path(attrs.ASPath, as4Path)
+	}
+
+	var updates []*Update
+	if len(withdrawn) > 0 || len(nlri) > 0 || (mpReach == nil && mpUnreach == nil) {
+		u := &Update{Family: FamilyIPv4Unicast, Withdrawn: withdrawn, NLRI: nlri}
+		if len(nlri) > 0 {
+			if !attrs.NextHop.IsValid() {
+				return nil, &Notification{Code: errCodeUpdateMessage, Subcode: 3, Data: []byte{attrNextHop}}
+			}
+			u.Attrs = attrs
+		}
+		updates = append(updates, u)
+	}
+	if mpUnreach != nil {
+		updates = append(updates, mpUnreach)
+	}
+	if mpReach != nil {
+		mpAttrs := attrs.Clone()
+		mpAttrs.NextHop = mpReach.Attrs.NextHop
+		mpReach.Attrs = mpAttrs
+		updates = append(updates, mpReach)
+	}
+	return updates, nil
+}
+
+// parseASPath decodes an AS path with AS numbers of the given size in octets.
+func parseASPath(value []byte, asnLen int) ([]ASPathSegment, error) {
+	var segs []ASPathSegment
+	for len(value) > 0 {
+		if len(value) < 2 || len(value) < 2+asnLen*int(value[1]) {
+			return nil, malformed()
+		}
+		seg := ASPathSegment{Set: value[0] == asPathSegmentSet}
+		for i := 0; i < int(value[1]); i++ {
+			if asnLen == 4 {
+				seg.ASNs = append(seg.ASNs, binary.BigEndian.Uint32(value[2+4*i:]))
+			} else {
+				seg.ASNs = append(seg.ASNs, uint32(binary.BigEndian.Uint16(value[2+2*i:])))
+			}
+		}
+		segs = append(segs, seg)
+		value = value[2+asnLen*int(value[1]):]
+	}
+	return segs, nil
+}
+
+// mergeAS4Path rebuilds the AS path of a route from a peer without four-octet AS support: the AS
+// numbers at the start of AS_PATH, which were added by other speakers without four-octet AS
+// support, followed by AS4_PATH (RFC 6793 section 4.2.3).  AS4_PATH is ignored if it is longer.
+func mergeAS4Path(path, as4Path []ASPathSegment) []ASPathSegment {
+	keep := (&PathAttrs{ASPath: path}).ASPathLen() - (&PathAttrs{ASPath: as4Path}).ASPathLen()
+	if keep < 0 {
+		return path
+	}
+	var merged []ASPathSegment
+	for _, seg := range path {
+		if keep == 0 {
+			break
+		}
+		if seg.Set {
+			merged = append(merged, seg)
+			keep--
+			continue
+		}
+		n := min(keep, len(seg.ASNs))
+		merged = append(merged, ASPathSegment{ASNs: seg.ASNs[:n]})
+		keep -= n
+	}
+	return append(merged, as4Path...)
+}
+
+func (a *PathAttrs) parseAttr(flags, attrType uint8, value []byte, as4 bool, mpReach, mpUnreach **Update) error {
+	switch attrType {
+	case attrOrigin:
+		if len(value) != 1 {
+			return malformed()
+		}
+		a.Origin = value[0]
+	case attrASPath:
+		asnLen := 2
+		if as4 {
+			asnLen = 4
+		}
+		path, err := parseASPath(value, asnLen)
+		if err != nil {
+			return err
+		}
+		a.ASPath = path
+	case attrNextHop:
+		if len(value) != 4 {
+			return malformed()
+		}
+		a.NextHop = netip.AddrFrom4([4]byte(value))
+	case attrMED:
+		if len(value) != 4 {
+			return malformed()
+		}
+		med := binary.BigEndian.Uint32(value)
+		a.MED = &med
+	case attrLocalPref:
+		if len(value) != 4 {
+			return malformed()
+		}
+		pref := binary.BigEndian.Uint32(value)
+		a.LocalPref = &pref
+	case attrCommunities:
+		if len(value)%4 != 0 {
+			return malformed()
+		}
+		for ; len(value) > 0; value = value[4:] {
+			a.Communities = append(a.Communities, binary.BigEndian.Uint32(value))
+		}
+	case attrOriginatorID:
+		if len(value) != 4 {
+			return malformed()
+		}
+		a.OriginatorID = netip.AddrFrom4([4]byte(value))
+	case attrClusterList:
+		if len(value)%4 != 0 {
+			return malformed()
+		}
+		for ; len(value) > 0; value = value[4:] {
+			a.ClusterList = append(a.ClusterList, netip.AddrFrom4([4]byte(value)))
+		}
+	case attrLargeCommunities:
+		if len(value)%12 != 0 {
+			return malformed()
+		}
+		for ; len(value) > 0; value = value[12:] {
+			a.LargeCommunities = append(a.LargeCommunities, LargeCommunity{
+				Global: binary.BigEndian.Uint32(value),
+				Local1: binary.BigEndian.Uint32(value[4:]),
+				Local2: binary.BigEndian.Uint32(value[8:]),
+			})
+		}
+	case attrMPReachNLRI:
+		if len(value) < 5 || len(value) < 5+int(value[3]) {
+			return malformed()
+		}
+		f := Family{AFI: binary.BigEndian.Uint16(value), SAFI: value[2]}
+		nhLen := int(value[3])
+		var nh netip.Addr
+		switch {
+		case f != FamilyIPv4Unicast && f != FamilyIPv6Unicast:
+			// We only negotiate unicast, so ignore anything else.
+			return nil
+		case nhLen == 16 || nhLen == 32:
+			// If there is a link-local next hop as well as the global one, we use the global
+			// one.  An IPv4 route with an IPv6 next hop is only accepted from a peer with which
+			// we have negotiated extended next hops, which the speaker checks.
+			nh = netip.AddrFrom16([16]byte(value[4:20]))
+		case nhLen == 4 && f == FamilyIPv4Unicast:
+			nh = netip.AddrFrom4([4]byte(value[4:8]))
+		default:
+			return malformed()
+		}
+		prefixes, err := parsePrefixes(value[5+nhLen:], f)
+		if err != nil {
+			return err
+		}
+		*mpReach = &Update{Family: f, NLRI: prefixes, Attrs: &PathAttrs{NextHop: nh}}
+	case attrMPUnreachNLRI:
+		if len(value) < 3 {
+			return malformed()
+		}
+		f := Family{AFI: binary.BigEndian.Uint16(value), SAFI: value[2]}
+		if f != FamilyIPv4Unicast && f != FamilyIPv6Unicast {
+			return nil
+		}
+		prefixes, err := parsePrefixes(value[3:], f)
+		if err != nil {
+			return err
+		}
+		*mpUnreach = &Update{Family: f, Withdrawn: prefixes}
+	case attrAggregator, attrAS4Aggregator, attrAS4Path:
+		// The encoding of the aggregator depends on whether each peer supports four-octet AS
+		// numbers, and we don't aggregate routes, so it isn't passed on.  AS4_PATH is only
+		// meaningful from a peer without four-octet AS support, and is handled by parseUpdate.
+	default:
+		if flags&flagOptional != 0 && flags&flagTransitive != 0 {
+			a.Unknown = append(a.Unknown, RawAttr{Flags: flags, Type: attrType, Value: append([]byte(nil), value...)})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package speaker
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+// The parsers handle messages from peers, so they must reject, rather than panic on, any input.
+// Protocol errors must be returned as a Notification so that they can be reported to the peer.
+
+func checkParseError(t *testing.T, err error) {
+	var n *Notification
+	if !errors.As(err, &n) {
+		t.Fatalf("parse error %v is not a Notification", err)
+	}
+}
+
+// fuzzUpdates are the seed updates for the UPDATE fuzzers.
+var fuzzUpdates = []*Update{
+	{
+		Family: FamilyIPv4Unicast,
+		NLRI:   []netip.Prefix{netip.MustParsePrefix("10.65.0.0/26"), netip.MustParsePrefix("0.0.0.0/0")},
+		Attrs: &PathAttrs{
+			Origin:           OriginIGP,
+			ASPath:           []ASPathSegment{{ASNs: []uint32{64512, 4200000001}}, {Set: true, ASNs: []uint32{65001}}},
+			NextHop:          netip.MustParseAddr("172.16.0.1"),
+			MED:              uint32Ptr(10),
+			LocalPref:        uint32Ptr(200),
+			Communities:      []uint32{65000<<16 | 100},
+			LargeCommunities: []LargeCommunity{{Global: 64512, Local1: 1, Local2: 2}},
+			OriginatorID:     netip.MustParseAddr("172.16.0.2"),
+			ClusterList:      []netip.Addr{netip.MustParseAddr("1.0.0.1")},
+			Unknown:          []RawAttr{{Flags: flagOptional | flagTransitive, Type: 99, Value: []byte{1, 2}}},
+		},
+	},
+	{Family: FamilyIPv4Unicast, Withdrawn: []netip.Prefix{netip.MustParsePrefix("10.65.0.0/26")}},
+	{Family: FamilyIPv4Unicast},
+	{
+		Family: FamilyIPv6Unicast,
+		NLRI:   []netip.Prefix{netip.MustParsePrefix("fd00:10:244::/122")},
+		Attrs: &PathAttrs{
+			ASPath:  []ASPathSegment{{ASNs: []uint32{64512}}},
+			NextHop: netip.MustParseAddr("fd00::1"),
+		},
+	},
+	{Family: FamilyIPv6Unicast, Withdrawn: []netip.Prefix{netip.MustParsePrefix("fd00:10:244::/122")}},
+	{Family: FamilyIPv6Unicast},
+}
+
+func FuzzParseOpen(f *testing.F) {
+	f.Add((&Open{AS: 65000, HoldTime: 90, RouterID: netip.MustParseAddr("10.0.0.1")}).marshal())
+	f.Add((&Open{
+		AS:          4200000001,
+		HoldTime:    90,
+		RouterID:    netip.MustParseAddr("10.0.0.1"),
+		Families:    []Family{FamilyIPv4Unicast, FamilyIPv6Unicast},
+		FourOctetAS: true,
+		GracefulRestart: &GracefulRestart{
+			Restarting: true,
+			Time:       120,
+			Families:   []Family{FamilyIPv4Unicast, FamilyIPv6Unicast},
+		},
+	}).marshal())
+	f.Add([]byte{4, 0xfd, 0xe8, 0, 90, 10, 0, 0, 1, 0})
+	f.Fuzz(func(t *testing.T, body []byte) {
+		o, err := parseOpen(body)
+		if err != nil {
+			checkParseError(t, err)
+			return
+		}
+		if len(o.Families) == 0 {
+			t.Fatalf("OPEN %x parsed without any families", body)
+		}
+		// Whatever we understood must survive being sent on.
+		reparsed, err := parseOpen(o.marshal())
+		if err != nil {
+			t.Fatalf("failed to reparse OPEN %+v: %v", o, err)
+		}
+		if !reflect.DeepEqual(reparsed, o) {
+			t.Fatalf("OPEN %+v reparsed as %+v", o, reparsed)
+		}
+	})
+}
+
+func FuzzParseUpdate(f *testing.F) {
+	for _, u := range fuzzUpdates {
+		for _, as4 := range []bool{true, false} {
+			for _, body := range u.marshal(as4) {
+				f.Add(body, as4)
+			}
+		}
+	}
+	f.Fuzz(func(t *testing.T, body []byte, as4 bool) {
+		updates, err := parseUpdate(body, as4)
+		if err != nil {
+			checkParseError(t, err)
+			return
+		}
+		for _, u := range updates {
+			for _, p := range append(u.Withdrawn, u.NLRI...) {
+				if p != p.Masked() || FamilyOf(p) != u.Family {
+					t.Fatalf("UPDATE %x parsed with bad prefix %v for %v", body, p, u.Family)
+				}
+			}
+			if len(u.NLRI) > 0 && (u.Attrs == nil || !u.Attrs.NextHop.IsValid()) {
+				t.Fatalf("UPDATE %x parsed with routes but no next hop", body)
+			}
+			if len(u.NLRI) == 0 {
+				continue
+			}
+			// A route that we accept may be passed on to other peers, so it must be possible
+			// to encode it again.
+			var nlri []netip.Prefix
+			for _, reencoded := range u.marshal(as4) {
+				reparsed, err := parseUpdate(reencoded, as4)
+				if err != nil {
+					t.Fatalf("failed to reparse update %+v: %v", u, err)
+				}
+				for _, r := range reparsed {
+					nlri = append(nlri, r.NLRI...)
+				}
+			}
+			if !reflect.DeepEqual(nlri, u.NLRI) {
+				t.Fatalf("routes %v reparsed as %v", u.NLRI, nlri)
+			}
+		}
+	})
+}
+
+func FuzzParseAttr(f *testing.F) {
+	for _, u := range fuzzUpdates {
+		if u.Attrs == nil {
+			continue
+		}
+		// Split the encoded attributes back into individual attributes for the seeds.
+		b := u.Attrs.marshal(u.Family, true)
+		for len(b) >= 3 {
+			flags, attrType := b[0], b[1]
+			var value []byte
+			if flags&flagExtLen != 0 {
+				n := int(b[2])<<8 | int(b[3])
+				value, b = b[4:4+n], b[4+n:]
+			} else {
+				n := int(b[2])
+				value, b = b[3:3+n], b[3+n:]
+			}
+			f.Add(flags, attrType, value, true)
+			f.Add(flags, attrType, value, false)
+		}
+	}
+	f.Fuzz(func(t *testing.T, flags, attrType uint8, value []byte, as4 bool) {
+		attrs := &PathAttrs{}
+		var mpReach, mpUnreach *Update
+		err := attrs.parseAttr(flags, attrType, value, as4, &mpReach, &mpUnreach)
+		if err != nil {
+			checkParseError(t, err)
+			return
+		}
+		if mpReach != nil && (mpReach.Attrs == nil || !mpReach.Attrs.NextHop.IsValid()) {
+			t.Fatalf("MP_REACH_NLRI %x parsed without a next hop", value)
+		}
+		for _, raw := range attrs.Unknown {
+			if len(value) > 0 && &raw.Value[0] == &value[0] {
+				t.Fatalf("unknown attribute aliases the message buffer")
+			}
+		}
+	})
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package speaker
+
+import (
+	"bytes"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+// roundTrip marshals the update and parses the messages that it produces.
+func roundTrip(u *Update) []*Update {
+	var parsed []*Update
+	for _, body := range u.marshal(true) {
+		var buf bytes.Buffer
+		Expect(writeMessage(&buf, msgTypeUpdate, body)).To(Succeed())
+		msgType, readBody, err := readMessage(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msgType).To(Equal(uint8(msgTypeUpdate)))
+		updates, err := parseUpdate(readBody, true)
+		Expect(err).NotTo(HaveOccurred())
+		parsed = append(parsed, updates...)
+	}
+	return parsed
+}
+
+var _ = Describe("BGP messages", func() {
+	It("should round trip an OPEN message", func() {
+		o := &Open{
+			AS:              4200000001,
+			HoldTime:        90,
+			RouterID:        netip.MustParseAddr("10.0.0.1"),
+			Families:        []Family{FamilyIPv4Unicast, FamilyIPv6Unicast},
+			FourOctetAS:     true,
+			ExtendedNextHop: true,
+			GracefulRestart: &GracefulRestart{
+				Restarting: true,
+				Time:       120,
+				Families:   []Family{FamilyIPv4Unicast},
+			},
+		}
+		parsed, err := parseOpen(o.marshal())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(o))
+	})
+
+	It("should default an OPEN without capabilities to IPv4 unicast", func() {
+		body := []byte{4, 0xfd, 0xe8, 0, 90, 10, 0, 0, 1, 0}
+		parsed, err := parseOpen(body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.AS).To(Equal(uint32(65000)))
+		Expect(parsed.FourOctetAS).To(BeFalse())
+		Expect(parsed.Families).To(Equal([]Family{FamilyIPv4Unicast}))
+	})
+
+	It("should reject an OPEN with the wrong version", func() {
+		body := []byte{3, 0xfd, 0xe8, 0, 90, 10, 0, 0, 1, 0}
+		_, err := parseOpen(body)
+		Expect(err).To(Equal(&Notification{Code: errCodeOpenMessage, Subcode: errSubcodeUnsupportedVersion, Data: []byte{0, 4}}))
+	})
+
+	It("should reject a message with a bad length", func() {
+		msg := encodeMessage(msgTypeKeepalive, nil)
+		msg[17] = 5
+		_, _, err := readMessage(bytes.NewReader(msg))
+		Expect(err).To(BeAssignableToTypeOf(&Notification{}))
+	})
+
+	DescribeTable("should round trip UPDATE messages",
+		func(u *Update) {
+			Expect(roundTrip(u)).To(Equal([]*Update{u}))
+		},
+		Entry("IPv4 advertisement", &Update{
+			Family: FamilyIPv4Unicast,
+			NLRI:   []netip.Prefix{netip.MustParsePrefix("10.65.0.0/26"), netip.MustParsePrefix("192.168.0.0/16")},
+			Attrs: &PathAttrs{
+				Origin:           OriginIGP,
+				ASPath:           []ASPathSegment{{ASNs: []uint32{64512, 4200000001}}},
+				NextHop:          netip.MustParseAddr("172.16.0.1"),
+				MED:              uint32Ptr(10),
+				LocalPref:        uint32Ptr(200),
+				Communities:      []uint32{65000<<16 | 100},
+				LargeCommunities: []LargeCommunity{{Global: 64512, Local1: 1, Local2: 2}},
+				OriginatorID:     netip.MustParseAddr("172.16.0.2"),
+				ClusterList:      []netip.Addr{netip.MustParseAddr("1.0.0.1")},
+				Unknown:          []RawAttr{{Flags: flagOptional | flagTransitive | flagPartial, Type: 99, Value: []byte{1, 2}}},
+			},
+		}),
+		Entry("IPv4 withdrawal", &Update{
+			Family:    FamilyIPv4Unicast,
+			Withdrawn: []netip.Prefix{netip.MustParsePrefix("10.65.0.0/26")},
+		}),
+		Entry("IPv4 End-of-RIB", &Update{Family: FamilyIPv4Unicast}),
+		Entry("IPv4 advertisement with an IPv6 next hop", &Update{
+			Family: FamilyIPv4Unicast,
+			NLRI:   []netip.Prefix{netip.MustParsePrefix("10.65.0.0/26")},
+			Attrs: &PathAttrs{
+				Origin:  OriginIGP,
+				ASPath:  []ASPathSegment{{ASNs: []uint32{64512}}},
+				NextHop: netip.MustParseAddr("fd00::1"),
+			},
+		}),
+		Entry("IPv6 advertisement", &Update{
+			Family: FamilyIPv6Unicast,
+			NLRI:   []netip.Prefix{netip.MustParsePrefix("fd00:10:244::/122")},
+			Attrs: &PathAttrs{
+				Origin:  OriginIncomplete,
+				ASPath:  []ASPathSegment{{ASNs: []uint32{64512}}},
+				NextHop: netip.MustParseAddr("fd00::1"),
+			},
+		}),
+		Entry("IPv6 withdrawal", &Update{
+			Family:    FamilyIPv6Unicast,
+			Withdrawn: []netip.Prefix{netip.MustParsePrefix("fd00:10:244::/122")},
+		}),
+		Entry("IPv6 End-of-RIB", &Update{Family: FamilyIPv6Unicast}),
+	)
+
+	It("should send four-octet AS numbers in AS4_PATH to a two-octet AS peer", func() {
+		u := &Update{
+			Family: FamilyIPv4Unicast,
+			NLRI:   []netip.Prefix{netip.MustParsePrefix("10.65.0.0/26")},
+			Attrs: &PathAttrs{
+				ASPath:  []ASPathSegment{{ASNs: []uint32{64512, 4200000001}}},
+				NextHop: netip.MustParseAddr("172.16.0.1"),
+			},
+		}
+		bodies := u.marshal(false)
+		Expect(bodies).To(HaveLen(1))
+
+		parsed, err := parseUpdate(bodies[0], false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed[0].Attrs.ASPath).To(Equal(u.Attrs.ASPath))
+	})
+
+	It("should merge AS4_PATH with the AS numbers added by two-octet AS speakers", func() {
+		path := []ASPathSegment{{ASNs: []uint32{65001, 65002, asTrans}}}
+		as4Path := []ASPathSegment{{ASNs: []uint32{65002, 4200000001}}}
+		Expect(mergeAS4Path(path, as4Path)).To(Equal([]ASPathSegment{
+			{ASNs: []uint32{65001}}, {ASNs: []uint32{65002, 4200000001}},
+		}))
+		// An AS4_PATH that is longer than AS_PATH is ignored.
+		Expect(mergeAS4Path(path[:1], append(as4Path, as4Path...))).To(Equal(path[:1]))
+	})
+
+	It("should split a large update across messages", func() {
+		u := &Update{
+			Family: FamilyIPv4Unicast,
+			Attrs:  &PathAttrs{ASPath: []ASPathSegment{}, NextHop: netip.MustParseAddr("172.16.0.1")},
+		}
+		for i := 0; i < 2000; i++ {
+			u.NLRI = append(u.NLRI, netip.PrefixFrom(netip.AddrFrom4([4]byte{10, byte(i >> 8), byte(i), 0}), 24))
+		}
+		bodies := u.marshal(true)
+		Expect(len(bodies)).To(BeNumerically(">", 1))
+
+		var nlri []netip.Prefix
+		for _, parsed := range roundTrip(u) {
+			Expect(parsed.Attrs).To(Equal(&PathAttrs{NextHop: u.Attrs.NextHop}))
+			nlri = append(nlri, parsed.NLRI...)
+		}
+		Expect(nlri).To(Equal(u.NLRI))
+	})
+
+	It("should reject an IPv4 advertisement without a next hop", func() {
+		u := &Update{
+			Family: FamilyIPv4Unicast,
+			NLRI:   []netip.Prefix{netip.MustParsePrefix("10.65.0.0/26")},
+			Attrs:  &PathAttrs{},
+		}
+		_, err := parseUpdate(u.marshal(true)[0], true)
+		Expect(err).To(BeAssignableToTypeOf(&Notification{}))
+	})
+})
@@ -0,0 +1,206 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package speaker
+
+import (
+	"net/netip"
+	"slices"
+)
+
+const defaultLocalPref = 100
+
+// Route is a path to a prefix, either originated by this speaker or learned from a peer.  A Route
+// must not be modified once it has been added to the RIB.
+type Route struct {
+	Prefix netip.Prefix
+	Attrs  *PathAttrs
+
+	// Peer is the address of the peer that the route was learned from.  It is not valid for
+	// routes originated by this speaker.
+	Peer netip.Addr
+	// IBGP is true for routes learned from iBGP peers.
+	IBGP bool
+	// Stale is true for routes retained from a peer whose session has failed, while it restarts.
+	Stale bool
+
+	peerRouterID netip.Addr
+	// fromRRClient is true for routes learned from a route reflector client.
+	fromRRClient bool
+}
+
+// Local returns true if the route is originated by this speaker.
+func (r *Route) Local() bool {
+	return !r.Peer.IsValid()
+}
+
+func (r *Route) localPref() uint32 {
+	if r.IBGP && r.Attrs.LocalPref != nil {
+		return *r.Attrs.LocalPref
+	}
+	return defaultLocalPref
+}
+
+func (r *Route) med() uint32 {
+	if r.Attrs.MED != nil {
+		return *r.Attrs.MED
+	}
+	return 0
+}
+
+// originatorID returns the router ID that the route is compared on: the ORIGINATOR_ID for a
+// reflected route, and the peer's router ID otherwise.
+func (r *Route) originatorID() netip.Addr {
+	if r.Attrs.OriginatorID.IsValid() {
+		return r.Attrs.OriginatorID
+	}
+	return r.peerRouterID
+}
+
+// better returns true if route a is preferred to route b, following the BGP decision process
+// (RFC 4271 section 9.1.2.2) with the usual extensions.
+func better(a, b *Route) bool {
+	if a.Local() != b.Local() {
+		return a.Local()
+	}
+	if a.Stale != b.Stale {
+		return !a.Stale
+	}
+	if a.localPref() != b.localPref() {
+		return a.localPref() > b.localPref()
+	}
+	if a.Attrs.ASPathLen() != b.Attrs.ASPathLen() {
+		return a.Attrs.ASPathLen() < b.Attrs.ASPathLen()
+	}
+	if a.Attrs.Origin != b.Attrs.Origin {
+		return a.Attrs.Origin < b.Attrs.Origin
+	}
+	if a.Attrs.neighborAS() == b.Attrs.neighborAS() && a.med() != b.med() {
+		return a.med() < b.med()
+	}
+	if a.IBGP != b.IBGP {
+		return !a.IBGP
+	}
+	if c := a.originatorID().Compare(b.originatorID()); c != 0 {
+		return c < 0
+	}
+	if len(a.Attrs.ClusterList) != len(b.Attrs.ClusterList) {
+		return len(a.Attrs.ClusterList) < len(b.Attrs.ClusterList)
+	}
+	return a.Peer.Compare(b.Peer) < 0
+}
+
+// multipathEqual returns true if route b may be used alongside the best route a for equal-cost
+// multipath forwarding: both are learned from peers and they tie in the decision process up to
+// the comparison of the peers' router IDs.  This follows BIRD's "merge paths".
+func multipathEqual(a, b *Route) bool {
+	if a.Local() || b.Local() || a.Stale != b.Stale {
+		return false
+	}
+	if a.localPref() != b.localPref() || a.Attrs.ASPathLen() != b.Attrs.ASPathLen() ||
+		a.Attrs.Origin != b.Attrs.Origin || a.IBGP != b.IBGP {
+		return false
+	}
+	if a.Attrs.neighborAS() == b.Attrs.neighborAS() && a.med() != b.med() {
+		return false
+	}
+	return a.Attrs.NextHop.IsValid() && b.Attrs.NextHop.IsValid()
+}
+
+// destination holds the routes to a prefix.
+type destination struct {
+	// routes are keyed on the address of the peer that they were learned from; the local route
+	// has the zero address.
+	routes map[netip.Addr]*Route
+	best   *Route
+	// multipath are the other routes that are as good as the best route for forwarding, in
+	// order of preference.
+	multipath []*Route
+}
+
+// rib is the Loc-RIB: all the routes that have passed the import policy, and the best route to
+// each prefix.
+type rib struct {
+	dests map[netip.Prefix]*destination
+}
+
+func newRIB() *rib {
+	return &rib{dests: map[netip.Prefix]*destination{}}
+}
+
+// set adds or replaces the route from its source, returning true if the best route or the
+// multipath routes changed.
+func (r *rib) set(route *Route) bool {
+	d := r.dests[route.Prefix]
+	if d == nil {
+		d = &destination{routes: map[netip.Addr]*Route{}}
+		r.dests[route.Prefix] = d
+	}
+	d.routes[route.Peer] = route
+	return r.selectBest(route.Prefix, d)
+}
+
+// remove removes the route to the prefix from the given source, returning true if the best route
+// or the multipath routes changed.
+func (r *rib) remove(prefix netip.Prefix, source netip.Addr) bool {
+	d := r.dests[prefix]
+	if d == nil || d.routes[source] == nil {
+		return false
+	}
+	delete(d.routes, source)
+	return r.selectBest(prefix, d)
+}
+
+func (r *rib) selectBest(prefix netip.Prefix, d *destination) bool {
+	var best *Route
+	for _, route := range d.routes {
+		if best == nil || better(route, best) {
+			best = route
+		}
+	}
+	var multipath []*Route
+	for _, route := range d.routes {
+		if route != best && multipathEqual(best, route) {
+			multipath = append(multipath, route)
+		}
+	}
+	slices.SortFunc(multipath, func(a, b *Route) int {
+		if better(a, b) {
+			return -1
+		}
+		return 1
+	})
+	if len(d.routes) == 0 {
+		delete(r.dests, prefix)
+	}
+	changed := best != d.best || !slices.Equal(multipath, d.multipath)
+	d.best, d.multipath = best, multipath
+	return changed
+}
+
+// best returns the best route to the prefix, or nil if there is none.
+func (r *rib) best(prefix netip.Prefix) *Route {
+	if d := r.dests[prefix]; d != nil {
+		return d.best
+	}
+	return nil
+}
+
+// multipath returns the routes to the prefix that may be used alongside the best route.
+func (r *rib) multipath(prefix netip.Prefix) []*Route {
+	if d := r.dests[prefix]; d != nil {
+		return d.multipath
+	}
+	return nil
+}
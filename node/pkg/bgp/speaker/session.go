@@ -0,0 +1,673 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package speaker
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Session states, as named in RFC 4271.
+const (
+	StateIdle        = "Idle"
+	StateConnect     = "Connect"
+	StateActive      = "Active"
+	StateOpenSent    = "OpenSent"
+	StateOpenConfirm = "OpenConfirm"
+	StateEstablished = "Established"
+)
+
+var (
+	// connectRetryInterval is the average time between attempts to connect to a peer.  Each
+	// interval is jittered so that two peers that connect to each other at the same time don't
+	// keep colliding.
+	connectRetryInterval = 5 * time.Second
+	dialTimeout          = 10 * time.Second
+	openTimeout          = 60 * time.Second
+	writeTimeout         = 10 * time.Second
+)
+
+// peer is a configured BGP peer.  It owns a goroutine that maintains the session with the peer,
+// and the routes learned from it.  Its fields are protected by the speaker's lock, apart from the
+// channels.
+type peer struct {
+	speaker *Speaker
+	cfg     PeerConfig
+
+	// incoming receives the connections that the peer makes to us.
+	incoming chan net.Conn
+	stop     chan struct{}
+	stopped  bool
+
+	// session is the established session, or nil, and state is the state of the session as
+	// named in RFC 4271.  since is when the session was last established or closed.
+	session *session
+	state   string
+	since   time.Time
+
+	// adjRibIn holds the routes learned from the peer, before the import policy is applied.
+	adjRibIn map[netip.Prefix]*Route
+
+	// staleTimer flushes the routes retained after the session failed, if the peer doesn't
+	// return in time.
+	staleTimer *time.Timer
+}
+
+func newPeer(s *Speaker, cfg PeerConfig) *peer {
+	p := &peer{
+		speaker:  s,
+		cfg:      cfg,
+		incoming: make(chan net.Conn),
+		stop:     make(chan struct{}),
+		state:    StateIdle,
+		since:    time.Now(),
+		adjRibIn: map[netip.Prefix]*Route{},
+	}
+	go p.run()
+	return p
+}
+
+// shutdown stops the peer's goroutine, which closes the session with a Cease notification.  It is
+// called with the speaker's lock held.
+func (p *peer) shutdown() {
+	if p.stopped {
+		return
+	}
+	p.stopped = true
+	close(p.stop)
+	if p.staleTimer != nil {
+		p.staleTimer.Stop()
+	}
+}
+
+func (p *peer) logCxt() *log.Entry {
+	return log.WithFields(log.Fields{"peer": p.cfg.Address, "as": p.cfg.AS})
+}
+
+func (p *peer) families() []Family {
+	if len(p.cfg.Families) > 0 {
+		return p.cfg.Families
+	}
+	return []Family{FamilyOf(netip.PrefixFrom(p.cfg.Address, 0))}
+}
+
+// extendedNextHop returns true if we offer the peer IPv4 routes with IPv6 next hops: if IPv4
+// routes are exchanged over an IPv6 session.
+func (p *peer) extendedNextHop() bool {
+	return p.cfg.Address.Is6() && slices.Contains(p.families(), FamilyIPv4Unicast)
+}
+
+func (p *peer) run() {
+	var delay time.Duration
+	for {
+		sess := p.connect(delay)
+		if sess == nil {
+			return
+		}
+		err := p.runSession(sess)
+		p.logCxt().WithError(err).Info("BGP session closed")
+		delay = jitter(connectRetryInterval)
+	}
+}
+
+func jitter(d time.Duration) time.Duration {
+	return d/2 + time.Duration(rand.Int63n(int64(d)))
+}
+
+// setState records the state of the session with the peer, for Status.
+func (p *peer) setState(state string) {
+	p.speaker.lock.Lock()
+	defer p.speaker.lock.Unlock()
+	p.state = state
+}
+
+// openAttempt is a connection with the peer on which we are exchanging OPEN messages.
+type openAttempt struct {
+	conn net.Conn
+	// outgoing is set if we made the connection, rather than the peer.
+	outgoing bool
+
+	local, remote *Open
+	err           error
+}
+
+// connect establishes a session with the peer, over a connection that we make or one that the
+// peer makes to us, or returns nil if the peer is stopped.  It waits for the given delay before
+// connecting to the peer, but accepts a connection from the peer in the meantime.
+//
+// If we and the peer connect to each other at the same time, OPEN messages are exchanged on both
+// connections, and the collision is resolved by the BGP Identifiers (RFC 4271 section 6.8): the
+// connection made by the speaker with the higher router ID is kept, and the other is closed with
+// a Cease notification.
+func (p *peer) connect(delay time.Duration) *session {
+	done := make(chan struct{})
+	defer close(done)
+	dialed := make(chan net.Conn)
+	opened := make(chan *openAttempt)
+	attempts := map[*openAttempt]bool{}
+	defer func() {
+		for a := range attempts {
+			_ = a.conn.Close()
+		}
+	}()
+	dialing := false
+
+	retry := time.NewTimer(delay)
+	defer retry.Stop()
+	p.setState(StateActive)
+	scheduleRetry := func() {
+		if len(attempts) == 0 && !dialing {
+			retry.Reset(jitter(connectRetryInterval))
+			p.setState(StateActive)
+		}
+	}
+	start := func(conn net.Conn, outgoing bool) {
+		a := &openAttempt{conn: conn, outgoing: outgoing}
+		attempts[a] = true
+		p.setState(StateOpenSent)
+		go func() {
+			a.local, a.remote, a.err = p.open(conn)
+			select {
+			case opened <- a:
+			case <-done:
+				_ = conn.Close()
+			}
+		}()
+	}
+
+	for {
+		select {
+		case <-p.stop:
+			return nil
+		case <-retry.C:
+			if p.cfg.Passive || dialing || len(attempts) > 0 {
+				continue
+			}
+			dialing = true
+			p.setState(StateConnect)
+			go func() {
+				conn := p.dial()
+				select {
+				case dialed <- conn:
+				case <-done:
+					if conn != nil {
+						_ = conn.Close()
+					}
+				}
+			}()
+		case conn := <-dialed:
+			dialing = false
+			if conn == nil {
+				scheduleRetry()
+				continue
+			}
+			start(conn, true)
+		case conn := <-p.incoming:
+			p.logCxt().Debug("Accepted connection from BGP peer")
+			start(conn, false)
+		case a := <-opened:
+			if !attempts[a] {
+				// The connection lost a collision while we were waiting for the peer's OPEN.
+				_ = a.conn.Close()
+				continue
+			}
+			delete(attempts, a)
+			if a.err != nil {
+				p.logCxt().WithError(a.err).Info("Failed to open BGP session")
+				abort(a.conn, a.err)
+				scheduleRetry()
+				continue
+			}
+
+			// The peer's router ID is the same on both connections, so we can resolve a
+			// collision as soon as we have received an OPEN on either of them.
+			keepOutgoing := a.local.RouterID.Compare(a.remote.RouterID) > 0
+			if a.outgoing != keepOutgoing && p.hasAttempt(attempts, keepOutgoing) {
+				p.logCxt().Debug("Closing colliding connection with BGP peer")
+				abort(a.conn, &Notification{Code: errCodeCease, Subcode: errSubcodeConnectionCollision})
+				continue
+			}
+			for other := range attempts {
+				p.logCxt().Debug("Closing colliding connection with BGP peer")
+				delete(attempts, other)
+				abort(other.conn, &Notification{Code: errCodeCease, Subcode: errSubcodeConnectionCollision})
+			}
+
+			p.setState(StateOpenConfirm)
+			sess, err := p.confirm(a.conn, a.local, a.remote)
+			if err != nil {
+				p.logCxt().WithError(err).Info("Failed to open BGP session")
+				abort(a.conn, err)
+				scheduleRetry()
+				continue
+			}
+			return sess
+		}
+	}
+}
+
+// hasAttempt returns true if there is a connection in the given direction in attempts.
+func (p *peer) hasAttempt(attempts map[*openAttempt]bool, outgoing bool) bool {
+	for a := range attempts {
+		if a.outgoing == outgoing {
+			return true
+		}
+	}
+	return false
+}
+
+// abort closes a connection on which the session couldn't be opened, sending a NOTIFICATION for
+// a protocol error that we detected.
+func abort(conn net.Conn, err error) {
+	var n *Notification
+	if errors.As(err, &n) {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		_ = writeMessage(conn, msgTypeNotification, n.marshal())
+	}
+	_ = conn.Close()
+}
+
+func (p *peer) dial() net.Conn {
+	d := net.Dialer{Timeout: dialTimeout}
+	if p.cfg.LocalAddress.IsValid() {
+		d.LocalAddr = &net.TCPAddr{IP: p.cfg.LocalAddress.AsSlice()}
+	}
+	d.Control = func(network, _ string, c syscall.RawConn) error {
+		if p.cfg.Password != "" {
+			if err := setTCPMD5(c, network == "tcp6", p.cfg.Address, p.cfg.Password); err != nil {
+				return err
+			}
+		}
+		if hops := p.cfg.TTLSecurity; hops != 0 {
+			return setTTLSecurity(c, p.cfg.Address.Is6(), hops)
+		}
+		return nil
+	}
+	address := net.JoinHostPort(p.cfg.Address.String(), strconv.Itoa(int(p.cfg.Port)))
+	conn, err := d.Dial("tcp", address)
+	if err != nil {
+		p.logCxt().WithError(err).Debug("Failed to connect to BGP peer")
+		return nil
+	}
+	return conn
+}
+
+// notificationReceived is the error for a session that was closed by the peer.
+type notificationReceived struct {
+	*Notification
+}
+
+func (n notificationReceived) Error() string {
+	return "received " + n.Notification.Error()
+}
+
+// runSession runs the BGP protocol on the established session until it fails or the peer is
+// stopped.
+func (p *peer) runSession(sess *session) error {
+	p.logCxt().WithFields(log.Fields{
+		"families":        sess.remote.Families,
+		"extendedNextHop": sess.extendedNextHop,
+	}).Info("BGP session established")
+
+	s := p.speaker
+	s.lock.Lock()
+	if p.stopped {
+		s.lock.Unlock()
+		_ = sess.conn.Close()
+		return errors.New("peer stopped")
+	}
+	p.session = sess
+	p.state = StateEstablished
+	p.since = time.Now()
+	s.restartedLockHeld(p)
+	for prefix := range s.rib.dests {
+		s.exportLockHeld(p, prefix)
+	}
+	for f := range sess.families {
+		sess.send(&Update{Family: f})
+	}
+	s.lock.Unlock()
+
+	go sess.writeLoop()
+	err := sess.readLoop()
+	sess.close(err)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p.session = nil
+	p.state = StateIdle
+	p.since = time.Now()
+	if !p.stopped {
+		s.sessionDownLockHeld(p, sess, err)
+	}
+	return err
+}
+
+// open sends our OPEN message to the peer and validates the peer's, returning both.
+func (p *peer) open(conn net.Conn) (*Open, *Open, error) {
+	s := p.speaker
+	s.lock.Lock()
+	cfg := s.cfg
+	restartTime := cfg.RestartTime
+	if p.cfg.RestartTime != 0 {
+		restartTime = p.cfg.RestartTime
+	}
+	local := &Open{
+		AS:              cfg.AS,
+		HoldTime:        uint16(cfg.HoldTime / time.Second),
+		RouterID:        cfg.RouterID,
+		Families:        p.families(),
+		FourOctetAS:     true,
+		ExtendedNextHop: p.extendedNextHop(),
+		GracefulRestart: &GracefulRestart{
+			Restarting: s.restarting,
+			Time:       uint16(restartTime / time.Second),
+			Families:   p.families(),
+		},
+	}
+	s.lock.Unlock()
+
+	_ = conn.SetDeadline(time.Now().Add(openTimeout))
+	if err := writeMessage(conn, msgTypeOpen, local.marshal()); err != nil {
+		return nil, nil, err
+	}
+	msgType, body, err := readMessage(conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch msgType {
+	case msgTypeOpen:
+	case msgTypeNotification:
+		return nil, nil, notificationReceived{parseNotification(body)}
+	default:
+		return nil, nil, &Notification{Code: errCodeFSM}
+	}
+	remote, err := parseOpen(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if remote.AS != p.cfg.AS {
+		// A peer without four-octet AS support can't have an AS number above 65535; it would
+		// send AS_TRANS, which doesn't match.
+		p.logCxt().WithField("remoteAS", remote.AS).Warn("BGP peer has the wrong AS number")
+		return nil, nil, &Notification{Code: errCodeOpenMessage, Subcode: errSubcodeBadPeerAS}
+	}
+	if remote.RouterID == cfg.RouterID && remote.AS == cfg.AS {
+		return nil, nil, &Notification{Code: errCodeOpenMessage, Subcode: errSubcodeBadBGPIdentifier}
+	}
+	if remote.HoldTime == 1 || remote.HoldTime == 2 {
+		return nil, nil, &Notification{Code: errCodeOpenMessage, Subcode: errSubcodeUnacceptableHoldTime}
+	}
+	return local, remote, nil
+}
+
+// confirm exchanges KEEPALIVE messages with the peer once the OPEN messages have been exchanged,
+// returning the established session.
+func (p *peer) confirm(conn net.Conn, local, remote *Open) (*session, error) {
+	if err := writeMessage(conn, msgTypeKeepalive, nil); err != nil {
+		return nil, err
+	}
+	msgType, body, err := readMessage(conn)
+	if err != nil {
+		return nil, err
+	}
+	switch msgType {
+	case msgTypeKeepalive:
+	case msgTypeNotification:
+		return nil, notificationReceived{parseNotification(body)}
+	default:
+		return nil, &Notification{Code: errCodeFSM}
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	sess := &session{
+		peer:     p,
+		conn:     conn,
+		local:    addrPortOf(conn.LocalAddr()).Addr(),
+		remote:   remote,
+		holdTime: time.Duration(min(local.HoldTime, remote.HoldTime)) * time.Second,
+		families: map[Family]bool{},
+		endOfRIB: map[Family]bool{},
+		ribOut:   map[netip.Prefix]*PathAttrs{},
+		queued:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	for _, f := range local.Families {
+		for _, rf := range remote.Families {
+			if f == rf {
+				sess.families[f] = true
+			}
+		}
+	}
+	// Both speakers must support extended next hops for either to send them.
+	sess.extendedNextHop = local.ExtendedNextHop && remote.ExtendedNextHop && sess.families[FamilyIPv4Unicast]
+	return sess, nil
+}
+
+// restartedLockHeld handles the routes retained from the peer's previous session, now that it
+// has a new one.  They are kept until the peer sends End-of-RIB, unless it has not preserved its
+// forwarding state.
+func (s *Speaker) restartedLockHeld(p *peer) {
+	preserved := map[Family]bool{}
+	if gr := p.session.remote.GracefulRestart; gr != nil {
+		for _, f := range gr.Families {
+			preserved[f] = true
+		}
+	}
+	s.flushPeerLockHeld(p, func(r *Route) bool {
+		return r.Stale && !preserved[FamilyOf(r.Prefix)]
+	})
+}
+
+// sessionDownLockHeld handles the failure of the session.  If the peer supports graceful restart,
+// and the session failed without a NOTIFICATION, its routes are retained for its restart time.
+func (s *Speaker) sessionDownLockHeld(p *peer, sess *session, err error) {
+	var n *Notification
+	var received notificationReceived
+	gr := sess.remote.GracefulRestart
+	if gr == nil || gr.Time == 0 || errors.As(err, &n) || errors.As(err, &received) {
+		s.flushPeerLockHeld(p, func(*Route) bool { return true })
+		return
+	}
+	p.logCxt().WithField("restartTime", gr.Time).Info("Retaining routes from BGP peer while it restarts")
+	s.markStaleLockHeld(p)
+	if p.staleTimer != nil {
+		p.staleTimer.Stop()
+	}
+	p.staleTimer = time.AfterFunc(time.Duration(gr.Time)*time.Second, func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if p.stopped {
+			return
+		}
+		p.logCxt().Info("BGP peer did not restart in time, flushing its routes")
+		s.flushPeerLockHeld(p, func(r *Route) bool { return r.Stale })
+	})
+}
+
+// session is an established BGP session.
+type session struct {
+	peer     *peer
+	conn     net.Conn
+	local    netip.Addr
+	remote   *Open
+	holdTime time.Duration
+
+	// families are the address families negotiated with the peer, and endOfRIB records those
+	// for which the peer has sent all its routes.
+	families map[Family]bool
+	endOfRIB map[Family]bool
+	// extendedNextHop is set if IPv4 routes may be exchanged with IPv6 next hops (RFC 8950).
+	extendedNextHop bool
+
+	// ribOut holds the attributes of the routes advertised to the peer.
+	ribOut map[netip.Prefix]*PathAttrs
+
+	queueLock sync.Mutex
+	queue     [][]byte
+	final     []byte
+	queued    chan struct{}
+
+	closeOnce sync.Once
+	done      chan struct{}
+	finished  chan struct{}
+}
+
+// send queues the update to be sent to the peer.  It never blocks.
+func (sess *session) send(u *Update) {
+	sess.queueLock.Lock()
+	defer sess.queueLock.Unlock()
+	for _, body := range u.marshal(sess.remote.FourOctetAS) {
+		sess.queue = append(sess.queue, encodeMessage(msgTypeUpdate, body))
+	}
+	select {
+	case sess.queued <- struct{}{}:
+	default:
+	}
+}
+
+// nextHopSelf returns the next hop for the routes that we advertise as the next hop.  IPv4
+// routes are advertised with an IPv6 next hop if extended next hops have been negotiated, since
+// the peer may have no route to our IPv4 address.
+func (sess *session) nextHopSelf(f Family) netip.Addr {
+	cfg := &sess.peer.speaker.cfg
+	if f == FamilyIPv4Unicast && sess.extendedNextHop {
+		f = FamilyIPv6Unicast
+	}
+	if f == FamilyIPv4Unicast && cfg.NextHopV4.IsValid() {
+		return cfg.NextHopV4
+	}
+	if f == FamilyIPv6Unicast && cfg.NextHopV6.IsValid() {
+		return cfg.NextHopV6
+	}
+	if FamilyOf(netip.PrefixFrom(sess.local, 0)) == f {
+		return sess.local
+	}
+	return netip.Addr{}
+}
+
+// writeLoop sends the queued messages and the keepalives, until the session is closed.
+func (sess *session) writeLoop() {
+	defer close(sess.finished)
+	defer sess.conn.Close()
+
+	var keepalives <-chan time.Time
+	if sess.holdTime > 0 {
+		t := time.NewTicker(sess.holdTime / 3)
+		defer t.Stop()
+		keepalives = t.C
+	}
+	write := func(msg []byte) bool {
+		_ = sess.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if _, err := sess.conn.Write(msg); err != nil {
+			sess.peer.logCxt().WithError(err).Debug("Failed to write to BGP peer")
+			return false
+		}
+		return true
+	}
+	for {
+		select {
+		case <-sess.peer.stop:
+			write(encodeMessage(msgTypeNotification, (&Notification{
+				Code: errCodeCease, Subcode: errSubcodeAdminShutdown,
+			}).marshal()))
+			return
+		case <-sess.done:
+			sess.queueLock.Lock()
+			final := sess.final
+			sess.queueLock.Unlock()
+			if final != nil {
+				write(final)
+			}
+			return
+		case <-keepalives:
+			if !write(encodeMessage(msgTypeKeepalive, nil)) {
+				return
+			}
+		case <-sess.queued:
+			sess.queueLock.Lock()
+			queue := sess.queue
+			sess.queue = nil
+			sess.queueLock.Unlock()
+			for _, msg := range queue {
+				if !write(msg) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// readLoop processes the messages from the peer until the session fails.
+func (sess *session) readLoop() error {
+	s := sess.peer.speaker
+	for {
+		if sess.holdTime > 0 {
+			_ = sess.conn.SetReadDeadline(time.Now().Add(sess.holdTime))
+		}
+		msgType, body, err := readMessage(sess.conn)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return &Notification{Code: errCodeHoldTimerExpired}
+			}
+			return err
+		}
+		switch msgType {
+		case msgTypeKeepalive:
+		case msgTypeNotification:
+			return notificationReceived{parseNotification(body)}
+		case msgTypeUpdate:
+			updates, err := parseUpdate(body, sess.remote.FourOctetAS)
+			if err != nil {
+				return err
+			}
+			s.lock.Lock()
+			if !sess.peer.stopped {
+				for _, u := range updates {
+					s.receiveLockHeld(sess.peer, u)
+				}
+			}
+			s.lock.Unlock()
+		case msgTypeOpen:
+			return &Notification{Code: errCodeFSM}
+		default:
+			return &Notification{Code: errCodeMessageHeader, Subcode: errSubcodeBadMessageType, Data: []byte{msgType}}
+		}
+	}
+}
+
+// close closes the session, sending a NOTIFICATION for a protocol error that we detected, and
+// waits for the writer to finish.
+func (sess *session) close(err error) {
+	var n *Notification
+	if errors.As(err, &n) {
+		sess.queueLock.Lock()
+		sess.final = encodeMessage(msgTypeNotification, n.marshal())
+		sess.queueLock.Unlock()
+	}
+	sess.closeOnce.Do(func() { close(sess.done) })
+	<-sess.finished
+}
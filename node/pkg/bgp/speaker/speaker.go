@@ -0,0 +1,702 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package speaker implements a BGP-4 speaker, with multiprotocol support for IPv6 unicast,
+// four-octet AS numbers, communities, route reflection, graceful restart, TTL security and
+// equal-cost multipath.  It has no knowledge of Calico's resources: the caller supplies the peers,
+// the routes to originate and the import and export policy, and is told of changes to the best
+// route to each prefix.
+//
+// The speaker is deliberately small rather than built on a general-purpose BGP library such as
+// GoBGP.  Calico only needs unicast routing with the attributes above; GoBGP would bring its gRPC
+// API, its configuration subsystem and their dependencies into calico-node, whose versions would
+// then be tied to the rest of the repository's.  Interoperability is tested against BIRD (see
+// bird_test.go) and the parsers for messages from peers are fuzzed (see message_fuzz_test.go).
+package speaker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"reflect"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultPort        = 179
+	DefaultHoldTime    = 90 * time.Second
+	DefaultRestartTime = 120 * time.Second
+)
+
+// Config is the configuration of a Speaker.
+type Config struct {
+	RouterID netip.Addr
+	AS       uint32
+
+	// ClusterID is the route reflector cluster ID.  If it is set, the speaker reflects routes
+	// between its iBGP peers and the peers that are route reflector clients.
+	ClusterID netip.Addr
+
+	// ListenAddress is the address on which to accept BGP connections, for example ":179".  If
+	// it is empty, the speaker only makes outgoing connections.
+	ListenAddress string
+
+	// NextHopV4 and NextHopV6 are the next hops for the routes that this speaker advertises with
+	// itself as the next hop.  If one is not set, the local address of the session is used.
+	NextHopV4, NextHopV6 netip.Addr
+
+	HoldTime time.Duration
+
+	// RestartTime is the graceful restart time that we advertise: how long peers should retain
+	// our routes after our session with them fails.
+	RestartTime time.Duration
+
+	Peers []PeerConfig
+
+	// Routes are the routes that this speaker originates.
+	Routes []LocalRoute
+}
+
+// PeerConfig is the configuration of a BGP peer.
+type PeerConfig struct {
+	Address netip.Addr
+	Port    uint16
+	AS      uint32
+
+	// LocalAddress is the source address for outgoing connections.  It is optional.
+	LocalAddress netip.Addr
+
+	// Passive peers are never connected to; we wait for them to connect to us.
+	Passive bool
+
+	// RouteReflectorClient is set if we are a route reflector and the peer is one of our clients.
+	RouteReflectorClient bool
+
+	// KeepOriginalNextHop keeps the next hop of the routes we advertise to an eBGP peer,
+	// rather than setting it to ourselves.
+	KeepOriginalNextHop bool
+
+	// AllowedLocalAS is the number of times our own AS may appear in the AS path of a route
+	// learned from the peer.
+	AllowedLocalAS int
+
+	// Families are the address families to exchange with the peer.  IPv4 routes are exchanged
+	// with IPv6 next hops over an IPv6 session if the peer supports extended next hops (RFC 8950).
+	Families []Family
+
+	// RestartTime, if set, overrides the graceful restart time that we advertise to the peer.
+	RestartTime time.Duration
+
+	// TTLSecurity, if set, is the maximum number of hops to the peer, which is enforced with the
+	// Generalized TTL Security Mechanism (RFC 5082).
+	TTLSecurity uint8
+
+	// Password, if set, is the TCP-MD5 key (RFC 2385) of the connections with the peer.  It may
+	// be at most MaxPasswordLen bytes.
+	Password string
+
+	// Import and Export return whether a route may be accepted from, or advertised to, the
+	// peer.  A nil function accepts all routes.  Export may modify the attributes that are
+	// advertised, which are a copy of the route's.
+	Import func(r *Route) bool
+	Export func(r *Route, attrs *PathAttrs) bool
+}
+
+// sessionKey returns the part of the peer configuration that requires the session to be reset
+// when it changes.
+func (c *PeerConfig) sessionKey() PeerConfig {
+	k := *c
+	k.Import, k.Export = nil, nil
+	return k
+}
+
+// LocalRoute is a route originated by this speaker.
+type LocalRoute struct {
+	Prefix           netip.Prefix
+	Communities      []uint32
+	LargeCommunities []LargeCommunity
+}
+
+// RouteHandler is called, with the speaker's lock held, when the best route to a prefix, or the
+// routes that may be used alongside it for equal-cost multipath forwarding, change.  best is nil
+// when there is no longer a route to the prefix.
+type RouteHandler func(prefix netip.Prefix, best *Route, multipath []*Route)
+
+// Speaker is a BGP speaker.
+type Speaker struct {
+	handler RouteHandler
+
+	lock     sync.Mutex
+	cfg      Config
+	peers    map[netip.Addr]*peer
+	rib      *rib
+	local    map[netip.Prefix]LocalRoute
+	listener net.Listener
+
+	// listenerConn is the listening socket, listenerIPv6 is set if it is an IPv6 socket, and
+	// listenerKeys are the TCP-MD5 keys installed on it for each peer.
+	listenerConn syscall.RawConn
+	listenerIPv6 bool
+	listenerKeys map[netip.Addr]string
+
+	// restarting is set until the speaker has learned its initial routes from its peers, and
+	// is advertised to them in the graceful restart capability.
+	restarting bool
+	inSync     chan struct{}
+	stopped    bool
+}
+
+// New creates a speaker.  It doesn't do anything until it is configured.
+func New(handler RouteHandler) *Speaker {
+	return &Speaker{
+		handler:    handler,
+		peers:      map[netip.Addr]*peer{},
+		rib:        newRIB(),
+		local:      map[netip.Prefix]LocalRoute{},
+		restarting: true,
+		inSync:     make(chan struct{}),
+	}
+}
+
+// InSync returns a channel that is closed once the speaker has learned the routes from all of its
+// peers, or the restart time has passed since it was first configured.
+func (s *Speaker) InSync() <-chan struct{} {
+	return s.inSync
+}
+
+// Configure applies the configuration, starting and stopping peers as required.
+func (s *Speaker) Configure(cfg Config) error {
+	if cfg.HoldTime == 0 {
+		cfg.HoldTime = DefaultHoldTime
+	}
+	if cfg.RestartTime == 0 {
+		cfg.RestartTime = DefaultRestartTime
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stopped {
+		return errors.New("speaker has been stopped")
+	}
+
+	firstConfig := !s.cfg.RouterID.IsValid()
+	resetAll := cfg.RouterID != s.cfg.RouterID || cfg.AS != s.cfg.AS || cfg.ClusterID != s.cfg.ClusterID ||
+		cfg.NextHopV4 != s.cfg.NextHopV4 || cfg.NextHopV6 != s.cfg.NextHopV6 || cfg.HoldTime != s.cfg.HoldTime
+	if cfg.ListenAddress != s.cfg.ListenAddress {
+		if err := s.listen(cfg.ListenAddress); err != nil {
+			return err
+		}
+	}
+	s.cfg = cfg
+	if firstConfig {
+		time.AfterFunc(cfg.RestartTime, func() {
+			s.lock.Lock()
+			defer s.lock.Unlock()
+			s.setInSyncLockHeld()
+		})
+	}
+
+	// Stop the peers that have been removed, or whose sessions must be reset, and update the
+	// policy of the others.
+	wanted := map[netip.Addr]PeerConfig{}
+	for _, pc := range cfg.Peers {
+		if pc.Port == 0 {
+			pc.Port = DefaultPort
+		}
+		wanted[pc.Address] = pc
+	}
+	for addr, p := range s.peers {
+		pc, ok := wanted[addr]
+		if !ok || resetAll || !reflect.DeepEqual(pc.sessionKey(), p.cfg.sessionKey()) {
+			log.WithField("peer", addr).Info("Stopping BGP peer")
+			s.stopPeerLockHeld(p)
+			continue
+		}
+		// Only the policy has changed.  The rest of the configuration is read by the peer's
+		// goroutine without the lock.
+		p.cfg.Import, p.cfg.Export = pc.Import, pc.Export
+		s.reimportLockHeld(p)
+	}
+	for addr, pc := range wanted {
+		if s.peers[addr] == nil {
+			log.WithFields(log.Fields{"peer": addr, "as": pc.AS}).Info("Starting BGP peer")
+			s.peers[addr] = newPeer(s, pc)
+		}
+	}
+	s.setListenerKeysLockHeld(wanted)
+
+	// Update the local routes.
+	wantedLocal := map[netip.Prefix]LocalRoute{}
+	for _, lr := range cfg.Routes {
+		wantedLocal[lr.Prefix.Masked()] = lr
+	}
+	for prefix := range s.local {
+		if _, ok := wantedLocal[prefix]; !ok {
+			delete(s.local, prefix)
+			s.ribRemoveLockHeld(prefix, netip.Addr{})
+		}
+	}
+	for prefix, lr := range wantedLocal {
+		if old, ok := s.local[prefix]; ok && !resetAll && reflect.DeepEqual(old, lr) {
+			continue
+		}
+		s.local[prefix] = lr
+		s.ribSetLockHeld(s.localRoute(prefix, lr))
+	}
+
+	// The export policy may have changed, so bring all the sessions up to date.
+	for _, p := range s.peers {
+		if p.session != nil {
+			for prefix := range s.rib.dests {
+				s.exportLockHeld(p, prefix)
+			}
+			for prefix := range p.session.ribOut {
+				s.exportLockHeld(p, prefix)
+			}
+		}
+	}
+	return nil
+}
+
+// Stop stops all the peers and the listener.  Peers are sent a Cease notification, and withdraw
+// our routes.
+func (s *Speaker) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stopped = true
+	for _, p := range s.peers {
+		s.stopPeerLockHeld(p)
+	}
+	if s.listener != nil {
+		_ = s.listener.Close()
+		s.listener = nil
+	}
+}
+
+func (s *Speaker) localRoute(prefix netip.Prefix, lr LocalRoute) *Route {
+	attrs := &PathAttrs{
+		Origin:           OriginIGP,
+		Communities:      lr.Communities,
+		LargeCommunities: lr.LargeCommunities,
+	}
+	return &Route{Prefix: prefix, Attrs: attrs}
+}
+
+func (s *Speaker) listen(address string) error {
+	if s.listener != nil {
+		_ = s.listener.Close()
+		s.listener = nil
+	}
+	if address == "" {
+		return nil
+	}
+	lc := net.ListenConfig{Control: func(network, _ string, c syscall.RawConn) error {
+		s.listenerIPv6 = network == "tcp6"
+		return setListenerTTL(network, c)
+	}}
+	l, err := lc.Listen(context.Background(), "tcp", address)
+	if err != nil {
+		return err
+	}
+	conn, err := l.(*net.TCPListener).SyscallConn()
+	if err != nil {
+		_ = l.Close()
+		return err
+	}
+	log.WithField("address", address).Info("Listening for BGP connections")
+	s.listener = l
+	s.listenerConn = conn
+	s.listenerKeys = map[netip.Addr]string{}
+	go s.acceptLoop(l)
+	return nil
+}
+
+// setListenerKeysLockHeld installs the TCP-MD5 keys of the peers on the listening socket, and
+// removes those of the peers that no longer have one, so that the connections that the peers make
+// to us are signed.  A peer whose key can't be installed can still be connected to.
+func (s *Speaker) setListenerKeysLockHeld(peers map[netip.Addr]PeerConfig) {
+	if s.listener == nil {
+		return
+	}
+	for addr, key := range s.listenerKeys {
+		if peers[addr].Password == "" {
+			if err := setTCPMD5(s.listenerConn, s.listenerIPv6, addr, ""); err != nil {
+				log.WithError(err).WithField("peer", addr).Warn("Failed to remove TCP-MD5 key from BGP listener")
+			}
+			delete(s.listenerKeys, addr)
+		} else if peers[addr].Password != key {
+			delete(s.listenerKeys, addr)
+		}
+	}
+	for addr, pc := range peers {
+		if pc.Password == "" || s.listenerKeys[addr] == pc.Password {
+			continue
+		}
+		if !s.listenerIPv6 && addr.Is6() {
+			continue
+		}
+		if err := setTCPMD5(s.listenerConn, s.listenerIPv6, addr, pc.Password); err != nil {
+			log.WithError(err).WithField("peer", addr).Error("Failed to set TCP-MD5 key on BGP listener")
+			continue
+		}
+		s.listenerKeys[addr] = pc.Password
+	}
+}
+
+func (s *Speaker) acceptLoop(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.WithError(err).Error("Failed to accept BGP connection")
+			}
+			return
+		}
+		remote := addrPortOf(conn.RemoteAddr()).Addr()
+		s.lock.Lock()
+		p := s.peers[remote]
+		var ttlSecurity uint8
+		if p != nil {
+			ttlSecurity = p.cfg.TTLSecurity
+		}
+		s.lock.Unlock()
+		if p == nil {
+			log.WithField("remote", remote).Info("Rejecting BGP connection from unknown peer")
+			_ = writeMessage(conn, msgTypeNotification, (&Notification{
+				Code: errCodeCease, Subcode: errSubcodeConnectionRejected,
+			}).marshal())
+			_ = conn.Close()
+			continue
+		}
+		if err := setConnTTLSecurity(conn, addrPortOf(conn.LocalAddr()).Addr().Is6(), ttlSecurity); err != nil {
+			log.WithError(err).WithField("remote", remote).Warn("Failed to set TTL, rejecting BGP connection")
+			_ = conn.Close()
+			continue
+		}
+		select {
+		case p.incoming <- conn:
+		default:
+			// The peer already has a session.
+			log.WithField("remote", remote).Debug("Rejecting BGP connection from peer with a session")
+			_ = conn.Close()
+		}
+	}
+}
+
+func addrPortOf(a net.Addr) netip.AddrPort {
+	if tcp, ok := a.(*net.TCPAddr); ok {
+		ap := tcp.AddrPort()
+		return netip.AddrPortFrom(ap.Addr().Unmap(), ap.Port())
+	}
+	return netip.AddrPort{}
+}
+
+func (s *Speaker) stopPeerLockHeld(p *peer) {
+	delete(s.peers, p.cfg.Address)
+	p.shutdown()
+	s.flushPeerLockHeld(p, func(*Route) bool { return true })
+	p.adjRibIn = map[netip.Prefix]*Route{}
+}
+
+// ribSetLockHeld adds a route to the RIB and propagates any change in the best route.
+func (s *Speaker) ribSetLockHeld(r *Route) {
+	if s.rib.set(r) {
+		s.bestChangedLockHeld(r.Prefix)
+	}
+}
+
+func (s *Speaker) ribRemoveLockHeld(prefix netip.Prefix, source netip.Addr) {
+	if s.rib.remove(prefix, source) {
+		s.bestChangedLockHeld(prefix)
+	}
+}
+
+func (s *Speaker) bestChangedLockHeld(prefix netip.Prefix) {
+	if s.handler != nil {
+		s.handler(prefix, s.rib.best(prefix), s.rib.multipath(prefix))
+	}
+	for _, p := range s.peers {
+		if p.session != nil {
+			s.exportLockHeld(p, prefix)
+		}
+	}
+}
+
+// exportLockHeld brings the peer's view of the prefix up to date with the best route, applying
+// the export policy.
+func (s *Speaker) exportLockHeld(p *peer, prefix netip.Prefix) {
+	sess := p.session
+	if !sess.families[FamilyOf(prefix)] {
+		return
+	}
+	attrs := s.exportAttrsLockHeld(p, s.rib.best(prefix))
+	old, advertised := sess.ribOut[prefix]
+	if attrs == nil {
+		if advertised {
+			delete(sess.ribOut, prefix)
+			sess.send(&Update{Family: FamilyOf(prefix), Withdrawn: []netip.Prefix{prefix}})
+		}
+		return
+	}
+	if advertised && reflect.DeepEqual(old, attrs) {
+		return
+	}
+	sess.ribOut[prefix] = attrs
+	sess.send(&Update{Family: FamilyOf(prefix), NLRI: []netip.Prefix{prefix}, Attrs: attrs})
+}
+
+// exportAttrsLockHeld returns the attributes with which to advertise the route to the peer, or
+// nil if it should not be advertised.
+func (s *Speaker) exportAttrsLockHeld(p *peer, r *Route) *PathAttrs {
+	if r == nil || r.Peer == p.cfg.Address {
+		return nil
+	}
+	ibgpPeer := p.cfg.AS == s.cfg.AS
+	reflected := !r.Local() && r.IBGP && ibgpPeer
+	if reflected {
+		// Routes learned over iBGP are only advertised to other iBGP peers by a route
+		// reflector, from its clients to everyone and from everyone to its clients.
+		if !s.cfg.ClusterID.IsValid() || (!r.fromRRClient && !p.cfg.RouteReflectorClient) {
+			return nil
+		}
+	}
+
+	attrs := r.Attrs.Clone()
+	nextHopSelf := true
+	if ibgpPeer {
+		if attrs.LocalPref == nil || !r.IBGP {
+			lp := r.localPref()
+			attrs.LocalPref = &lp
+		}
+		if reflected {
+			if !attrs.OriginatorID.IsValid() {
+				attrs.OriginatorID = r.peerRouterID
+			}
+			attrs.ClusterList = append([]netip.Addr{s.cfg.ClusterID}, attrs.ClusterList...)
+			nextHopSelf = false
+		}
+	} else {
+		attrs.LocalPref = nil
+		attrs.OriginatorID = netip.Addr{}
+		attrs.ClusterList = nil
+		if !r.Local() {
+			// MEDs aren't passed on to other ASes.
+			attrs.MED = nil
+		}
+		attrs.prependAS(s.cfg.AS)
+		if p.cfg.KeepOriginalNextHop && !r.Local() {
+			nextHopSelf = false
+		}
+	}
+	if nextHopSelf {
+		attrs.NextHop = p.session.nextHopSelf(FamilyOf(r.Prefix))
+		if !attrs.NextHop.IsValid() {
+			return nil
+		}
+	} else if r.Prefix.Addr().Is4() && attrs.NextHop.Is6() && !p.session.extendedNextHop {
+		// An IPv4 route learned with an IPv6 next hop can only be passed on with it to a peer
+		// that supports extended next hops.
+		return nil
+	}
+
+	if p.cfg.Export != nil && !p.cfg.Export(r, attrs) {
+		return nil
+	}
+	return attrs
+}
+
+// receiveLockHeld processes an update received from the peer.
+func (s *Speaker) receiveLockHeld(p *peer, u *Update) {
+	sess := p.session
+	if !sess.families[u.Family] {
+		log.WithFields(log.Fields{"peer": p.cfg.Address, "family": u.Family}).Debug("Ignoring update for unnegotiated family")
+		return
+	}
+	if u.IsEndOfRIB() {
+		log.WithFields(log.Fields{"peer": p.cfg.Address, "family": u.Family}).Debug("Received End-of-RIB")
+		sess.endOfRIB[u.Family] = true
+		s.flushPeerLockHeld(p, func(r *Route) bool { return r.Stale && FamilyOf(r.Prefix) == u.Family })
+		s.checkInSyncLockHeld()
+		return
+	}
+	for _, prefix := range u.Withdrawn {
+		delete(p.adjRibIn, prefix)
+		s.ribRemoveLockHeld(prefix, p.cfg.Address)
+	}
+	if u.Family == FamilyIPv4Unicast && u.Attrs != nil && u.Attrs.NextHop.Is6() && !sess.extendedNextHop {
+		// The peer should only send IPv4 routes with IPv6 next hops if we have negotiated
+		// extended next hops; treat them as withdrawn (RFC 7606).
+		log.WithField("peer", p.cfg.Address).Warn("Ignoring IPv4 routes with IPv6 next hops from BGP peer without extended next hop support")
+		for _, prefix := range u.NLRI {
+			delete(p.adjRibIn, prefix)
+			s.ribRemoveLockHeld(prefix, p.cfg.Address)
+		}
+		return
+	}
+	for _, prefix := range u.NLRI {
+		if FamilyOf(prefix) != u.Family {
+			continue
+		}
+		r := &Route{
+			Prefix:       prefix,
+			Attrs:        u.Attrs,
+			Peer:         p.cfg.Address,
+			IBGP:         p.cfg.AS == s.cfg.AS,
+			peerRouterID: sess.remote.RouterID,
+			fromRRClient: p.cfg.RouteReflectorClient,
+		}
+		p.adjRibIn[prefix] = r
+		s.importLockHeld(p, r)
+	}
+}
+
+// importLockHeld adds the route learned from the peer to the RIB if it passes the import policy,
+// or removes it if it doesn't.
+func (s *Speaker) importLockHeld(p *peer, r *Route) {
+	if s.acceptLockHeld(p, r) {
+		s.ribSetLockHeld(r)
+	} else {
+		s.ribRemoveLockHeld(r.Prefix, r.Peer)
+	}
+}
+
+func (s *Speaker) acceptLockHeld(p *peer, r *Route) bool {
+	if r.IBGP {
+		// Discard reflected routes that have looped back to us.
+		if r.Attrs.OriginatorID == s.cfg.RouterID {
+			return false
+		}
+		if s.cfg.ClusterID.IsValid() && slices.Contains(r.Attrs.ClusterList, s.cfg.ClusterID) {
+			return false
+		}
+	} else if r.Attrs.countAS(s.cfg.AS) > p.cfg.AllowedLocalAS {
+		return false
+	}
+	return p.cfg.Import == nil || p.cfg.Import(r)
+}
+
+// reimportLockHeld reapplies the import policy to the routes learned from the peer.
+func (s *Speaker) reimportLockHeld(p *peer) {
+	for _, r := range p.adjRibIn {
+		s.importLockHeld(p, r)
+	}
+}
+
+// flushPeerLockHeld removes the routes learned from the peer that match the predicate.
+func (s *Speaker) flushPeerLockHeld(p *peer, match func(*Route) bool) {
+	for prefix, r := range p.adjRibIn {
+		if match(r) {
+			delete(p.adjRibIn, prefix)
+			s.ribRemoveLockHeld(prefix, p.cfg.Address)
+		}
+	}
+}
+
+// markStaleLockHeld marks the peer's routes as stale, to be retained while it restarts.
+func (s *Speaker) markStaleLockHeld(p *peer) {
+	for prefix, r := range p.adjRibIn {
+		stale := *r
+		stale.Stale = true
+		p.adjRibIn[prefix] = &stale
+		s.importLockHeld(p, &stale)
+	}
+}
+
+func (s *Speaker) checkInSyncLockHeld() {
+	if !s.restarting {
+		return
+	}
+	for _, p := range s.peers {
+		if p.session == nil {
+			return
+		}
+		for f := range p.session.families {
+			if !p.session.endOfRIB[f] {
+				return
+			}
+		}
+	}
+	s.setInSyncLockHeld()
+}
+
+func (s *Speaker) setInSyncLockHeld() {
+	if s.restarting {
+		log.Info("BGP speaker has learned its initial routes")
+		s.restarting = false
+		close(s.inSync)
+	}
+}
+
+// PeerStatus is the state of a peer.
+type PeerStatus struct {
+	Address netip.Addr
+	AS      uint32
+	// State is the state of the session, as named in RFC 4271, and Since is when the session
+	// was last established or closed.
+	State       string
+	Established bool
+	Since       time.Time
+	// RouterID is the peer's BGP Identifier, while the session is established.
+	RouterID netip.Addr
+	Imported int
+	Exported int
+}
+
+// Status returns the state of each peer.
+func (s *Speaker) Status() []PeerStatus {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var status []PeerStatus
+	for addr, p := range s.peers {
+		ps := PeerStatus{Address: addr, AS: p.cfg.AS, State: p.state, Since: p.since}
+		if p.session != nil {
+			ps.Established = true
+			ps.RouterID = p.session.remote.RouterID
+			ps.Exported = len(p.session.ribOut)
+			for _, r := range p.adjRibIn {
+				if s.rib.dests[r.Prefix] != nil && s.rib.dests[r.Prefix].routes[addr] == r {
+					ps.Imported++
+				}
+			}
+		}
+		status = append(status, ps)
+	}
+	slices.SortFunc(status, func(a, b PeerStatus) int { return a.Address.Compare(b.Address) })
+	return status
+}
+
+// Routes returns the best route to each prefix, in order of prefix.
+func (s *Speaker) Routes() []*Route {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	routes := make([]*Route, 0, len(s.rib.dests))
+	for _, d := range s.rib.dests {
+		if d.best != nil {
+			routes = append(routes, d.best)
+		}
+	}
+	slices.SortFunc(routes, func(a, b *Route) int {
+		if c := a.Prefix.Addr().Compare(b.Prefix.Addr()); c != 0 {
+			return c
+		}
+		return a.Prefix.Bits() - b.Prefix.Bits()
+	})
+	return routes
+}
@@ -0,0 +1,22 @@
+package speaker
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+
+	"github.com/onsi/ginkgo/reporters"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/testutils"
+)
+
+func init() {
+	testutils.HookLogrusForGinkgo()
+}
+
+func TestSpeaker(t *testing.T) {
+	RegisterFailHandler(Fail)
+	junitReporter := reporters.NewJUnitReporter("../../../report/bgp_speaker_suite.xml")
+	RunSpecsWithDefaultAndCustomReporters(t, "BGP Speaker Suite", []Reporter{junitReporter})
+}
@@ -0,0 +1,590 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package speaker
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// testSpeaker is a speaker listening on its own loopback address, which records its best routes
+// and the routes that may be used alongside them.
+type testSpeaker struct {
+	*Speaker
+	addr netip.Addr
+
+	lock      sync.Mutex
+	routes    map[netip.Prefix]*Route
+	multipath map[netip.Prefix][]*Route
+}
+
+const testPort = 17900
+
+func init() {
+	connectRetryInterval = 100 * time.Millisecond
+}
+
+func newTestSpeaker(addr string) *testSpeaker {
+	ts := &testSpeaker{
+		addr:      netip.MustParseAddr(addr),
+		routes:    map[netip.Prefix]*Route{},
+		multipath: map[netip.Prefix][]*Route{},
+	}
+	ts.Speaker = New(func(prefix netip.Prefix, best *Route, multipath []*Route) {
+		ts.lock.Lock()
+		defer ts.lock.Unlock()
+		if best == nil {
+			delete(ts.routes, prefix)
+		} else {
+			ts.routes[prefix] = best
+		}
+		ts.multipath[prefix] = multipath
+	})
+	return ts
+}
+
+func (ts *testSpeaker) config(as uint32, routes ...string) Config {
+	cfg := Config{
+		RouterID:      ts.addr,
+		AS:            as,
+		ListenAddress: fmt.Sprintf("%s:%d", ts.addr, testPort),
+		HoldTime:      9 * time.Second,
+		RestartTime:   time.Second,
+	}
+	for _, r := range routes {
+		cfg.Routes = append(cfg.Routes, LocalRoute{Prefix: netip.MustParsePrefix(r)})
+	}
+	return cfg
+}
+
+// peerConfig returns the configuration for the local speaker to peer with this one.
+func (ts *testSpeaker) peerConfig(local *testSpeaker, as uint32) PeerConfig {
+	return PeerConfig{Address: ts.addr, Port: testPort, AS: as, LocalAddress: local.addr}
+}
+
+// route returns the best route to the prefix, or nil.
+func (ts *testSpeaker) route(prefix string) func() *Route {
+	return func() *Route {
+		ts.lock.Lock()
+		defer ts.lock.Unlock()
+		return ts.routes[netip.MustParsePrefix(prefix)]
+	}
+}
+
+// nextHops returns the next hops of the best route to the prefix and of the routes that may be
+// used alongside it.
+func (ts *testSpeaker) nextHops(prefix string) func() []netip.Addr {
+	return func() []netip.Addr {
+		ts.lock.Lock()
+		defer ts.lock.Unlock()
+		p := netip.MustParsePrefix(prefix)
+		if ts.routes[p] == nil {
+			return nil
+		}
+		nextHops := []netip.Addr{ts.routes[p].Attrs.NextHop}
+		for _, r := range ts.multipath[p] {
+			nextHops = append(nextHops, r.Attrs.NextHop)
+		}
+		return nextHops
+	}
+}
+
+func (ts *testSpeaker) established() func() []bool {
+	return func() []bool {
+		var established []bool
+		for _, ps := range ts.Status() {
+			established = append(established, ps.Established)
+		}
+		return established
+	}
+}
+
+func (ts *testSpeaker) sessions() []*session {
+	ts.Speaker.lock.Lock()
+	defer ts.Speaker.lock.Unlock()
+	var sessions []*session
+	for _, p := range ts.peers {
+		if p.session != nil {
+			sessions = append(sessions, p.session)
+		}
+	}
+	return sessions
+}
+
+// expectMessage reads a message of the given type from a peer played by the test.
+func expectMessage(conn net.Conn, msgType uint8) []byte {
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	t, body, err := readMessage(conn)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	ExpectWithOffset(1, t).To(Equal(msgType))
+	return body
+}
+
+var _ = Describe("BGP speaker", func() {
+	var a, b, c *testSpeaker
+
+	BeforeEach(func() {
+		a = newTestSpeaker("127.0.0.1")
+		b = newTestSpeaker("127.0.0.2")
+		c = newTestSpeaker("127.0.0.3")
+	})
+
+	AfterEach(func() {
+		for _, ts := range []*testSpeaker{a, b, c} {
+			ts.Stop()
+		}
+	})
+
+	It("should exchange routes over iBGP", func() {
+		cfgA := a.config(64512, "10.65.0.0/26")
+		cfgA.Peers = []PeerConfig{b.peerConfig(a, 64512)}
+		cfgA.Routes[0].Communities = []uint32{65000<<16 | 100}
+		cfgA.Routes[0].LargeCommunities = []LargeCommunity{{Global: 64512, Local1: 1, Local2: 2}}
+		Expect(a.Configure(cfgA)).To(Succeed())
+
+		cfgB := b.config(64512, "10.65.1.0/26")
+		peerA := a.peerConfig(b, 64512)
+		peerA.Passive = true
+		cfgB.Peers = []PeerConfig{peerA}
+		Expect(b.Configure(cfgB)).To(Succeed())
+
+		Eventually(b.route("10.65.0.0/26"), "5s").ShouldNot(BeNil())
+		r := b.route("10.65.0.0/26")()
+		Expect(r.Peer).To(Equal(a.addr))
+		Expect(r.IBGP).To(BeTrue())
+		Expect(r.Attrs.NextHop).To(Equal(a.addr))
+		Expect(r.Attrs.ASPath).To(BeEmpty())
+		Expect(*r.Attrs.LocalPref).To(Equal(uint32(100)))
+		Expect(r.Attrs.Communities).To(Equal([]uint32{65000<<16 | 100}))
+		Expect(r.Attrs.LargeCommunities).To(Equal([]LargeCommunity{{Global: 64512, Local1: 1, Local2: 2}}))
+
+		Eventually(a.route("10.65.1.0/26"), "5s").ShouldNot(BeNil())
+		Eventually(a.InSync(), "5s").Should(BeClosed())
+
+		By("withdrawing a route")
+		cfgA.Routes = nil
+		Expect(a.Configure(cfgA)).To(Succeed())
+		Eventually(b.route("10.65.0.0/26"), "5s").Should(BeNil())
+		Expect(b.route("10.65.1.0/26")()).NotTo(BeNil())
+	})
+
+	It("should prepend the AS and apply policy over eBGP", func() {
+		cfgA := a.config(64512, "10.65.0.0/26", "10.66.0.0/26")
+		peerB := b.peerConfig(a, 64513)
+		peerB.Export = func(r *Route, attrs *PathAttrs) bool {
+			return r.Prefix != netip.MustParsePrefix("10.66.0.0/26")
+		}
+		cfgA.Peers = []PeerConfig{peerB}
+		Expect(a.Configure(cfgA)).To(Succeed())
+
+		cfgB := b.config(64513)
+		cfgB.Peers = []PeerConfig{a.peerConfig(b, 64512)}
+		Expect(b.Configure(cfgB)).To(Succeed())
+
+		Eventually(b.route("10.65.0.0/26"), "5s").ShouldNot(BeNil())
+		r := b.route("10.65.0.0/26")()
+		Expect(r.IBGP).To(BeFalse())
+		Expect(r.Attrs.ASPath).To(Equal([]ASPathSegment{{ASNs: []uint32{64512}}}))
+		Expect(r.Attrs.LocalPref).To(BeNil())
+		Consistently(b.route("10.66.0.0/26"), "500ms").Should(BeNil())
+
+		By("changing the export policy")
+		peerB.Export = nil
+		cfgA.Peers = []PeerConfig{peerB}
+		Expect(a.Configure(cfgA)).To(Succeed())
+		Eventually(b.route("10.66.0.0/26"), "5s").ShouldNot(BeNil())
+
+		By("changing the import policy")
+		peerA := a.peerConfig(b, 64512)
+		peerA.Import = func(r *Route) bool {
+			return r.Prefix != netip.MustParsePrefix("10.65.0.0/26")
+		}
+		cfgB.Peers = []PeerConfig{peerA}
+		Expect(b.Configure(cfgB)).To(Succeed())
+		Expect(b.route("10.65.0.0/26")()).To(BeNil())
+		Expect(b.route("10.66.0.0/26")()).NotTo(BeNil())
+	})
+
+	It("should not establish a session with the wrong AS", func() {
+		cfgA := a.config(64512, "10.65.0.0/26")
+		cfgA.Peers = []PeerConfig{b.peerConfig(a, 64513)}
+		Expect(a.Configure(cfgA)).To(Succeed())
+
+		cfgB := b.config(64514)
+		cfgB.Peers = []PeerConfig{a.peerConfig(b, 64512)}
+		Expect(b.Configure(cfgB)).To(Succeed())
+
+		Consistently(b.established(), "1s").Should(Equal([]bool{false}))
+		Expect(b.route("10.65.0.0/26")()).To(BeNil())
+	})
+
+	DescribeTable("TTL security",
+		func(ttlSecurityB uint8, established bool) {
+			cfgA := a.config(64512, "10.65.0.0/26")
+			peerB := b.peerConfig(a, 64512)
+			peerB.TTLSecurity = 1
+			cfgA.Peers = []PeerConfig{peerB}
+			Expect(a.Configure(cfgA)).To(Succeed())
+
+			cfgB := b.config(64512)
+			peerA := a.peerConfig(b, 64512)
+			peerA.TTLSecurity = ttlSecurityB
+			cfgB.Peers = []PeerConfig{peerA}
+			Expect(b.Configure(cfgB)).To(Succeed())
+
+			if established {
+				Eventually(b.route("10.65.0.0/26"), "5s").ShouldNot(BeNil())
+				Eventually(a.established(), "5s").Should(Equal([]bool{true}))
+			} else {
+				// b sends with the default TTL, so a drops its packets.
+				Consistently(a.established(), "1s").Should(Equal([]bool{false}))
+			}
+		},
+		Entry("is used by both peers", uint8(1), true),
+		Entry("is only used by one peer", uint8(0), false),
+	)
+
+	DescribeTable("TCP-MD5",
+		func(passwordB string, established bool) {
+			cfgA := a.config(64512, "10.65.0.0/26")
+			peerB := b.peerConfig(a, 64512)
+			peerB.Password = "secret"
+			cfgA.Peers = []PeerConfig{peerB}
+			Expect(a.Configure(cfgA)).To(Succeed())
+
+			cfgB := b.config(64512)
+			peerA := a.peerConfig(b, 64512)
+			peerA.Password = passwordB
+			cfgB.Peers = []PeerConfig{peerA}
+			Expect(b.Configure(cfgB)).To(Succeed())
+
+			if established {
+				Eventually(b.route("10.65.0.0/26"), "5s").ShouldNot(BeNil())
+				Eventually(a.established(), "5s").Should(Equal([]bool{true}))
+			} else {
+				Consistently(a.established(), "1s").Should(Equal([]bool{false}))
+			}
+		},
+		Entry("with the same password", "secret", true),
+		Entry("with a different password", "other", false),
+		Entry("with one password", "", false),
+	)
+
+	DescribeTable("should resolve a connection collision by router ID",
+		func(routerIDB string, keepOutgoing bool) {
+			// b is played by the test.  It accepts the connection that a makes, and connects to a
+			// at the same time.
+			l, err := net.Listen("tcp", net.JoinHostPort(b.addr.String(), strconv.Itoa(testPort)))
+			Expect(err).NotTo(HaveOccurred())
+			defer l.Close()
+
+			cfgA := a.config(64512)
+			cfgA.Peers = []PeerConfig{b.peerConfig(a, 64512)}
+			Expect(a.Configure(cfgA)).To(Succeed())
+			accepted, err := l.Accept()
+			Expect(err).NotTo(HaveOccurred())
+			defer accepted.Close()
+			expectMessage(accepted, msgTypeOpen)
+
+			d := net.Dialer{LocalAddr: &net.TCPAddr{IP: b.addr.AsSlice()}}
+			dialed, err := d.Dial("tcp", net.JoinHostPort(a.addr.String(), strconv.Itoa(testPort)))
+			Expect(err).NotTo(HaveOccurred())
+			defer dialed.Close()
+			openB := &Open{
+				AS:          64512,
+				HoldTime:    9,
+				RouterID:    netip.MustParseAddr(routerIDB),
+				Families:    []Family{FamilyIPv4Unicast},
+				FourOctetAS: true,
+			}
+			Expect(writeMessage(dialed, msgTypeOpen, openB.marshal())).To(Succeed())
+			expectMessage(dialed, msgTypeOpen)
+
+			kept, closed := dialed, accepted
+			if keepOutgoing {
+				// a's connection is the one that b accepted.
+				kept, closed = accepted, dialed
+				Expect(writeMessage(accepted, msgTypeOpen, openB.marshal())).To(Succeed())
+			}
+			n := parseNotification(expectMessage(closed, msgTypeNotification))
+			Expect(n.Code).To(Equal(uint8(errCodeCease)))
+			Expect(n.Subcode).To(Equal(uint8(errSubcodeConnectionCollision)))
+
+			expectMessage(kept, msgTypeKeepalive)
+			Expect(writeMessage(kept, msgTypeKeepalive, nil)).To(Succeed())
+			Eventually(a.established(), "5s").Should(Equal([]bool{true}))
+		},
+		Entry("keeping the connection made by the peer", "127.0.0.2", false),
+		Entry("keeping our connection", "127.0.0.0", true),
+	)
+
+	It("should peer with a speaker without four-octet AS support", func() {
+		// b is played by the test, as a speaker that only supports two-octet AS numbers.
+		l, err := net.Listen("tcp", net.JoinHostPort(b.addr.String(), strconv.Itoa(testPort)))
+		Expect(err).NotTo(HaveOccurred())
+		defer l.Close()
+
+		cfgA := a.config(4200000001, "10.65.0.0/26")
+		cfgA.Peers = []PeerConfig{b.peerConfig(a, 65001)}
+		Expect(a.Configure(cfgA)).To(Succeed())
+		conn, err := l.Accept()
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+
+		openA, err := parseOpen(expectMessage(conn, msgTypeOpen))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(openA.AS).To(Equal(uint32(4200000001)))
+		openB := &Open{AS: 65001, HoldTime: 9, RouterID: b.addr, Families: []Family{FamilyIPv4Unicast}}
+		Expect(writeMessage(conn, msgTypeOpen, openB.marshal())).To(Succeed())
+		Expect(writeMessage(conn, msgTypeKeepalive, nil)).To(Succeed())
+		expectMessage(conn, msgTypeKeepalive)
+
+		updates, err := parseUpdate(expectMessage(conn, msgTypeUpdate), false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updates).To(HaveLen(1))
+		Expect(updates[0].NLRI).To(Equal([]netip.Prefix{netip.MustParsePrefix("10.65.0.0/26")}))
+		Expect(updates[0].Attrs.ASPath).To(Equal([]ASPathSegment{{ASNs: []uint32{4200000001}}}))
+		Eventually(a.established(), "5s").Should(Equal([]bool{true}))
+	})
+
+	DescribeTable("should exchange IPv4 routes over an IPv6 session",
+		func(extendedNextHop bool) {
+			// The peer on ::1 is played by the test.
+			l, err := net.Listen("tcp", net.JoinHostPort("::1", strconv.Itoa(testPort)))
+			Expect(err).NotTo(HaveOccurred())
+			defer l.Close()
+
+			v6 := newTestSpeaker("::1")
+			defer v6.Stop()
+			cfg := v6.config(64512, "10.65.0.0/26", "fd00:10:244::/122")
+			cfg.RouterID = netip.MustParseAddr("127.0.0.1")
+			cfg.ListenAddress = ""
+			cfg.Peers = []PeerConfig{{
+				Address:      v6.addr,
+				Port:         testPort,
+				AS:           64513,
+				LocalAddress: v6.addr,
+				Families:     []Family{FamilyIPv6Unicast, FamilyIPv4Unicast},
+			}}
+			Expect(v6.Configure(cfg)).To(Succeed())
+			conn, err := l.Accept()
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			open, err := parseOpen(expectMessage(conn, msgTypeOpen))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(open.Families).To(ConsistOf(FamilyIPv4Unicast, FamilyIPv6Unicast))
+			Expect(open.ExtendedNextHop).To(BeTrue())
+			peerOpen := &Open{
+				AS:              64513,
+				HoldTime:        9,
+				RouterID:        netip.MustParseAddr("127.0.0.2"),
+				Families:        []Family{FamilyIPv4Unicast, FamilyIPv6Unicast},
+				FourOctetAS:     true,
+				ExtendedNextHop: extendedNextHop,
+			}
+			Expect(writeMessage(conn, msgTypeOpen, peerOpen.marshal())).To(Succeed())
+			Expect(writeMessage(conn, msgTypeKeepalive, nil)).To(Succeed())
+			expectMessage(conn, msgTypeKeepalive)
+			Eventually(v6.established(), "5s").Should(Equal([]bool{true}))
+
+			// The IPv4 route can only be advertised with our IPv6 address as its next hop.
+			nextHops := map[netip.Prefix]netip.Addr{}
+			endOfRIB := map[Family]bool{}
+			for len(endOfRIB) < 2 {
+				updates, err := parseUpdate(expectMessage(conn, msgTypeUpdate), true)
+				Expect(err).NotTo(HaveOccurred())
+				for _, u := range updates {
+					if len(u.NLRI) == 0 && len(u.Withdrawn) == 0 {
+						endOfRIB[u.Family] = true
+					}
+					for _, prefix := range u.NLRI {
+						nextHops[prefix] = u.Attrs.NextHop
+					}
+				}
+			}
+			expected := map[netip.Prefix]netip.Addr{netip.MustParsePrefix("fd00:10:244::/122"): v6.addr}
+			if extendedNextHop {
+				expected[netip.MustParsePrefix("10.65.0.0/26")] = v6.addr
+			}
+			Expect(nextHops).To(Equal(expected))
+
+			// An IPv4 route with an IPv6 next hop is only accepted with extended next hops.
+			u := &Update{
+				Family: FamilyIPv4Unicast,
+				NLRI:   []netip.Prefix{netip.MustParsePrefix("10.66.0.0/26")},
+				Attrs: &PathAttrs{
+					Origin:  OriginIGP,
+					ASPath:  []ASPathSegment{{ASNs: []uint32{64513}}},
+					NextHop: netip.MustParseAddr("fd00::2"),
+				},
+			}
+			for _, body := range u.marshal(true) {
+				Expect(writeMessage(conn, msgTypeUpdate, body)).To(Succeed())
+			}
+			if extendedNextHop {
+				Eventually(v6.nextHops("10.66.0.0/26"), "5s").Should(Equal([]netip.Addr{netip.MustParseAddr("fd00::2")}))
+			} else {
+				Consistently(v6.route("10.66.0.0/26"), "500ms").Should(BeNil())
+			}
+		},
+		Entry("with extended next hops", true),
+		Entry("without extended next hops", false),
+	)
+
+	It("should use routes from several peers for multipath", func() {
+		cfgB := b.config(64512)
+		cfgB.Peers = []PeerConfig{a.peerConfig(b, 64512), c.peerConfig(b, 64512)}
+		Expect(b.Configure(cfgB)).To(Succeed())
+
+		for _, ts := range []*testSpeaker{a, c} {
+			cfg := ts.config(64512, "10.96.0.10/32")
+			cfg.Peers = []PeerConfig{b.peerConfig(ts, 64512)}
+			Expect(ts.Configure(cfg)).To(Succeed())
+		}
+		Eventually(b.nextHops("10.96.0.10/32"), "5s").Should(Equal([]netip.Addr{a.addr, c.addr}))
+
+		By("withdrawing one of the routes")
+		cfgC := c.config(64512)
+		cfgC.Peers = []PeerConfig{b.peerConfig(c, 64512)}
+		Expect(c.Configure(cfgC)).To(Succeed())
+		Eventually(b.nextHops("10.96.0.10/32"), "5s").Should(Equal([]netip.Addr{a.addr}))
+
+		By("advertising a route that isn't as good")
+		cfgC = c.config(64512, "10.96.0.10/32")
+		cfgC.Peers = []PeerConfig{b.peerConfig(c, 64512)}
+		cfgC.Peers[0].Export = func(r *Route, attrs *PathAttrs) bool {
+			attrs.prependAS(64512)
+			return true
+		}
+		Expect(c.Configure(cfgC)).To(Succeed())
+		Consistently(b.nextHops("10.96.0.10/32"), "500ms").Should(Equal([]netip.Addr{a.addr}))
+	})
+
+	It("should reflect routes between route reflector clients", func() {
+		// b is a route reflector, and a and c are its clients.
+		cfgB := b.config(64512)
+		cfgB.ClusterID = netip.MustParseAddr("1.0.0.1")
+		peerA, peerC := a.peerConfig(b, 64512), c.peerConfig(b, 64512)
+		peerA.RouteReflectorClient, peerC.RouteReflectorClient = true, true
+		cfgB.Peers = []PeerConfig{peerA, peerC}
+		Expect(b.Configure(cfgB)).To(Succeed())
+
+		for _, client := range []*testSpeaker{a, c} {
+			cfg := client.config(64512)
+			cfg.Peers = []PeerConfig{b.peerConfig(client, 64512)}
+			if client == a {
+				cfg.Routes = []LocalRoute{{Prefix: netip.MustParsePrefix("10.65.0.0/26")}}
+			}
+			Expect(client.Configure(cfg)).To(Succeed())
+		}
+
+		Eventually(c.route("10.65.0.0/26"), "5s").ShouldNot(BeNil())
+		r := c.route("10.65.0.0/26")()
+		Expect(r.Peer).To(Equal(b.addr))
+		Expect(r.Attrs.NextHop).To(Equal(a.addr))
+		Expect(r.Attrs.OriginatorID).To(Equal(a.addr))
+		Expect(r.Attrs.ClusterList).To(Equal([]netip.Addr{netip.MustParseAddr("1.0.0.1")}))
+
+		// The route isn't reflected back to the client that it came from.
+		Consistently(func() *Route {
+			r := a.route("10.65.0.0/26")()
+			if r != nil && !r.Local() {
+				return r
+			}
+			return nil
+		}, "500ms").Should(BeNil())
+	})
+
+	It("should not pass iBGP routes to other iBGP peers without route reflection", func() {
+		cfgB := b.config(64512)
+		cfgB.Peers = []PeerConfig{a.peerConfig(b, 64512), c.peerConfig(b, 64512)}
+		Expect(b.Configure(cfgB)).To(Succeed())
+
+		cfgA := a.config(64512, "10.65.0.0/26")
+		cfgA.Peers = []PeerConfig{b.peerConfig(a, 64512)}
+		Expect(a.Configure(cfgA)).To(Succeed())
+		cfgC := c.config(64512)
+		cfgC.Peers = []PeerConfig{b.peerConfig(c, 64512)}
+		Expect(c.Configure(cfgC)).To(Succeed())
+
+		Eventually(b.route("10.65.0.0/26"), "5s").ShouldNot(BeNil())
+		Eventually(b.established(), "5s").Should(Equal([]bool{true, true}))
+		Consistently(c.route("10.65.0.0/26"), "500ms").Should(BeNil())
+	})
+
+	It("should retain routes while a peer restarts", func() {
+		cfgA := a.config(64512, "10.65.0.0/26")
+		cfgA.Peers = []PeerConfig{b.peerConfig(a, 64512)}
+		Expect(a.Configure(cfgA)).To(Succeed())
+
+		cfgB := b.config(64512)
+		cfgB.Peers = []PeerConfig{a.peerConfig(b, 64512)}
+		Expect(b.Configure(cfgB)).To(Succeed())
+
+		Eventually(b.route("10.65.0.0/26"), "5s").ShouldNot(BeNil())
+
+		By("breaking the connection without a notification")
+		Expect(a.sessions()).To(HaveLen(1))
+		_ = a.sessions()[0].conn.Close()
+		Eventually(func() bool {
+			r := b.route("10.65.0.0/26")()
+			return r != nil && r.Stale
+		}, "5s").Should(BeTrue())
+
+		By("re-establishing the session")
+		Eventually(func() bool {
+			r := b.route("10.65.0.0/26")()
+			return r != nil && !r.Stale
+		}, "5s").Should(BeTrue())
+
+		By("stopping the peer")
+		a.Stop()
+		Eventually(b.route("10.65.0.0/26"), "5s").Should(BeNil())
+	})
+
+	It("should flush stale routes if the peer doesn't return", func() {
+		cfgA := a.config(64512, "10.65.0.0/26")
+		cfgA.Peers = []PeerConfig{b.peerConfig(a, 64512)}
+		Expect(a.Configure(cfgA)).To(Succeed())
+
+		cfgB := b.config(64512)
+		cfgB.Peers = []PeerConfig{a.peerConfig(b, 64512)}
+		Expect(b.Configure(cfgB)).To(Succeed())
+		Eventually(b.route("10.65.0.0/26"), "5s").ShouldNot(BeNil())
+
+		// Stop a's peer goroutine without sending a notification, so that it doesn't return.
+		a.Speaker.lock.Lock()
+		for _, p := range a.peers {
+			p.stopped = true
+			close(p.stop)
+			_ = p.session.conn.Close()
+		}
+		a.Speaker.lock.Unlock()
+		Eventually(func() bool {
+			r := b.route("10.65.0.0/26")()
+			return r != nil && r.Stale
+		}, "5s").Should(BeTrue())
+		Eventually(b.route("10.65.0.0/26"), "5s").Should(BeNil())
+	})
+})
@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package speaker
+
+import (
+	"errors"
+	"net"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// setTTLSecurity applies the Generalized TTL Security Mechanism (RFC 5082) to a socket: we send
+// with the maximum TTL, and the kernel drops the packets from the peer that have crossed more than
+// the given number of hops.  This is BIRD's "ttl security on; multihop <hops>".  If hops is zero,
+// the socket is restored to the default TTL, without a minimum.
+func setTTLSecurity(c syscall.RawConn, ipv6 bool, hops uint8) error {
+	level, ttlOpt, minTTLOpt := unix.IPPROTO_IP, unix.IP_TTL, unix.IP_MINTTL
+	if ipv6 {
+		level, ttlOpt, minTTLOpt = unix.IPPROTO_IPV6, unix.IPV6_UNICAST_HOPS, unix.IPV6_MINHOPCOUNT
+	}
+	// -1 selects the system default TTL.
+	ttl, minTTL := -1, 0
+	if hops != 0 {
+		ttl, minTTL = 255, 256-int(hops)
+	}
+	var err error
+	if cerr := c.Control(func(fd uintptr) {
+		err = unix.SetsockoptInt(int(fd), level, ttlOpt, ttl)
+		if err == nil {
+			err = unix.SetsockoptInt(int(fd), level, minTTLOpt, minTTL)
+		}
+	}); cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+// setListenerTTL makes the listening socket send with the maximum TTL, so that the handshake of a
+// connection made by a peer that uses TTL security gets through.  The connections from peers that
+// use TTL security are then restricted by setConnTTLSecurity once they have been accepted, and the
+// others are returned to the default TTL.
+func setListenerTTL(network string, c syscall.RawConn) error {
+	var err error
+	if cerr := c.Control(func(fd uintptr) {
+		if network == "tcp6" {
+			err = unix.SetsockoptInt(int(fd), unix.IPPROTO_IPV6, unix.IPV6_UNICAST_HOPS, 255)
+			// A dual-stack socket also needs the TTL for IPv4.
+			_ = unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_TTL, 255)
+		} else {
+			err = unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_TTL, 255)
+		}
+	}); cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+// setConnTTLSecurity applies TTL security to a connection that the peer made to us, or restores
+// the default TTL if hops is zero.
+func setConnTTLSecurity(conn net.Conn, ipv6 bool, hops uint8) error {
+	tcp, ok := conn.(*net.TCPConn)
+	if !ok {
+		return errors.New("not a TCP connection")
+	}
+	c, err := tcp.SyscallConn()
+	if err != nil {
+		return err
+	}
+	return setTTLSecurity(c, ipv6, hops)
+}
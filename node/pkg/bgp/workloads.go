@@ -0,0 +1,144 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/conversion"
+)
+
+// workload is the part of a workload endpoint on this node that determines whether its addresses
+// are advertised.
+type workload struct {
+	Labels   map[string]string
+	Prefixes []string
+}
+
+// workloadWatcher watches the pods on this node, and sends their workload endpoints, keyed on the
+// pod's namespace/name, to workloads whenever they change.
+type workloadWatcher struct {
+	converter conversion.Converter
+
+	indexer  cache.Indexer
+	informer cache.Controller
+
+	lock      sync.Mutex
+	synced    bool
+	current   map[string][]workload
+	workloads chan map[string][]workload
+}
+
+// newWorkloadWatcher creates a watcher for the pods on the node.  nodename is the name of this
+// node in the Kubernetes API.
+func newWorkloadWatcher(clientset kubernetes.Interface, nodename string) *workloadWatcher {
+	w := &workloadWatcher{
+		converter: conversion.NewConverter(),
+		current:   map[string][]workload{},
+		workloads: make(chan map[string][]workload, 1),
+	}
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.onUpdate,
+		UpdateFunc: func(_, obj interface{}) { w.onUpdate(obj) },
+		DeleteFunc: w.onUpdate,
+	}
+	podWatcher := cache.NewListWatchFromClient(clientset.CoreV1().RESTClient(), "pods", "",
+		fields.OneTermEqualSelector("spec.nodeName", nodename))
+	w.indexer, w.informer = cache.NewIndexerInformer(podWatcher, &v1.Pod{}, 0, handler, cache.Indexers{})
+	return w
+}
+
+// run runs the informer until stop is closed.  The workloads are first sent once the informer is
+// in sync.
+func (w *workloadWatcher) run(stop <-chan struct{}) {
+	go w.informer.Run(stop)
+	if !cache.WaitForCacheSync(stop, w.informer.HasSynced) {
+		return
+	}
+	log.Info("Kubernetes pods are in sync")
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.synced = true
+	w.sendLockHeld()
+}
+
+// onUpdate recomputes the workload endpoints of a pod.
+func (w *workloadWatcher) onUpdate(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.WithError(err).Warn("Failed to get key for pod update")
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	workloads := w.podWorkloads(key)
+	if old, had := w.current[key]; had == (workloads != nil) && slices.EqualFunc(old, workloads, equalWorkloads) {
+		return
+	}
+	if workloads != nil {
+		w.current[key] = workloads
+	} else {
+		delete(w.current, key)
+	}
+	if w.synced {
+		w.sendLockHeld()
+	}
+}
+
+// podWorkloads returns the workload endpoints of the pod with the given key, or nil if it has no
+// addresses that Felix routes to this node.
+func (w *workloadWatcher) podWorkloads(key string) []workload {
+	obj, exists, err := w.indexer.GetByKey(key)
+	if err != nil || !exists {
+		return nil
+	}
+	pod := obj.(*v1.Pod)
+	if !w.converter.IsReadyCalicoPod(pod) || conversion.IsFinished(pod) {
+		return nil
+	}
+	kvps, err := w.converter.PodToWorkloadEndpoints(pod)
+	if err != nil {
+		log.WithError(err).WithField("pod", key).Warn("Failed to convert pod to workload endpoints")
+		return nil
+	}
+	var workloads []workload
+	for _, kvp := range kvps {
+		wep := kvp.Value.(*libapiv3.WorkloadEndpoint)
+		workloads = append(workloads, workload{Labels: wep.Labels, Prefixes: wep.Spec.IPNetworks})
+	}
+	return workloads
+}
+
+func equalWorkloads(a, b workload) bool {
+	return maps.Equal(a.Labels, b.Labels) && slices.Equal(a.Prefixes, b.Prefixes)
+}
+
+// sendLockHeld sends the current workloads, replacing any that haven't been consumed.
+func (w *workloadWatcher) sendLockHeld() {
+	select {
+	case <-w.workloads:
+	default:
+	}
+	w.workloads <- maps.Clone(w.current)
+}
@@ -0,0 +1,22 @@
+package bgpmetrics
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+
+	"github.com/onsi/ginkgo/reporters"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/testutils"
+)
+
+func init() {
+	testutils.HookLogrusForGinkgo()
+}
+
+func TestCommands(t *testing.T) {
+	RegisterFailHandler(Fail)
+	junitReporter := reporters.NewJUnitReporter("../../report/bgpmetrics_suite.xml")
+	RunSpecsWithDefaultAndCustomReporters(t, "BGP Metrics Suite", []Reporter{junitReporter})
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgpmetrics
+
+import (
+	"sync"
+	"time"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	populator "github.com/projectcalico/calico/node/pkg/status/populators"
+)
+
+var (
+	peerLabels = []string{"ip_version", "peer_ip", "peer_type"}
+
+	birdUpDesc = prometheus.NewDesc(
+		"calico_bgp_bird_up",
+		"Whether BIRD could be queried for the state of its BGP sessions.",
+		[]string{"ip_version"}, nil,
+	)
+	peerStateDesc = prometheus.NewDesc(
+		"calico_bgp_peer_state",
+		"BGP session state: 1 for the session's current state, 0 for the others.",
+		append(peerLabels, "state"), nil,
+	)
+	peerUptimeDesc = prometheus.NewDesc(
+		"calico_bgp_peer_uptime_seconds",
+		"Number of seconds that the BGP session has been established, 0 if it is not established.",
+		peerLabels, nil,
+	)
+	peerImportedDesc = prometheus.NewDesc(
+		"calico_bgp_peer_prefixes_imported",
+		"Number of prefixes imported from the BGP peer.",
+		peerLabels, nil,
+	)
+	peerExportedDesc = prometheus.NewDesc(
+		"calico_bgp_peer_prefixes_exported",
+		"Number of prefixes exported to the BGP peer.",
+		peerLabels, nil,
+	)
+	peerFilteredDesc = prometheus.NewDesc(
+		"calico_bgp_peer_prefixes_filtered",
+		"Number of prefixes from the BGP peer that were rejected by the import filter.",
+		peerLabels, nil,
+	)
+	peerFlapsDesc = prometheus.NewDesc(
+		"calico_bgp_peer_flaps_total",
+		"Number of times that the BGP session has gone down after being established.",
+		peerLabels, nil,
+	)
+
+	sessionStates = []apiv3.BGPSessionState{
+		apiv3.BGPSessionStateIdle,
+		apiv3.BGPSessionStateConnect,
+		apiv3.BGPSessionStateActive,
+		apiv3.BGPSessionStateOpenSent,
+		apiv3.BGPSessionStateOpenConfirm,
+		apiv3.BGPSessionStateEstablished,
+		apiv3.BGPSessionStateClose,
+	}
+)
+
+type peerKey struct {
+	ipv      populator.IPFamily
+	peerIP   string
+	peerType apiv3.BGPPeerType
+}
+
+func (k peerKey) labels() []string {
+	return []string{k.ipv.String(), k.peerIP, string(k.peerType)}
+}
+
+type peerState struct {
+	stats populator.BGPPeerStats
+
+	// establishedAt is when the session was established, or zero if it is down.
+	establishedAt time.Time
+
+	flaps int
+}
+
+// collector is a prometheus.Collector that exports the state of BIRD's BGP sessions.  BIRD is only
+// queried when poll is called, so that scrapes don't load BIRD, and so that sessions that go down
+// between scrapes are still counted as flaps.
+type collector struct {
+	// read returns the BGP sessions for the IP family.
+	read func(populator.IPFamily) ([]populator.BGPPeerStats, error)
+	now  func() time.Time
+
+	lock   sync.Mutex
+	birdUp map[populator.IPFamily]bool
+	peers  map[peerKey]*peerState
+}
+
+func newCollector(read func(populator.IPFamily) ([]populator.BGPPeerStats, error)) *collector {
+	return &collector{
+		read:   read,
+		now:    time.Now,
+		birdUp: map[populator.IPFamily]bool{},
+		peers:  map[peerKey]*peerState{},
+	}
+}
+
+// poll queries BIRD and updates the state of each session.
+func (c *collector) poll() {
+	for _, ipv := range []populator.IPFamily{populator.IPFamilyV4, populator.IPFamilyV6} {
+		stats, err := c.read(ipv)
+		if err != nil {
+			if _, ok := err.(populator.ErrorSocketConnection); ok {
+				log.WithError(err).Debug("BIRD is not running")
+			} else {
+				log.WithError(err).Warnf("Failed to query BIRDv%s for BGP metrics", ipv)
+			}
+		}
+		c.update(ipv, stats, err == nil)
+	}
+}
+
+func (c *collector) update(ipv populator.IPFamily, stats []populator.BGPPeerStats, birdUp bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	c.birdUp[ipv] = birdUp
+	seen := map[peerKey]bool{}
+	for _, s := range stats {
+		key := peerKey{ipv: ipv, peerIP: s.PeerIP, peerType: s.Type}
+		seen[key] = true
+		p, ok := c.peers[key]
+		if !ok {
+			p = &peerState{}
+			c.peers[key] = p
+		}
+
+		if s.State != apiv3.BGPSessionStateEstablished {
+			if !p.establishedAt.IsZero() {
+				p.flaps++
+			}
+			p.establishedAt = time.Time{}
+		} else if since, ok := parseSince(s.Since, now); ok {
+			p.establishedAt = since
+		} else if p.establishedAt.IsZero() {
+			// We can't tell from BIRD when the session came up, so use when we first saw it up.
+			p.establishedAt = now
+		}
+		p.stats = s
+	}
+
+	// Forget the sessions that have been removed, and all of them if BIRD has gone.
+	for key := range c.peers {
+		if key.ipv == ipv && !seen[key] {
+			delete(c.peers, key)
+		}
+	}
+}
+
+// parseSince parses the time in BIRD's "since" column, which is the time of day for recent changes
+// and the date for older ones.
+func parseSince(since string, now time.Time) (time.Time, bool) {
+	if t, err := time.ParseInLocation("15:04:05", since, now.Location()); err == nil {
+		t = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location())
+		if t.After(now) {
+			// It was yesterday.
+			t = t.AddDate(0, 0, -1)
+		}
+		return t, true
+	}
+	if t, err := time.ParseInLocation("2006-01-02", since, now.Location()); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- birdUpDesc
+	ch <- peerStateDesc
+	ch <- peerUptimeDesc
+	ch <- peerImportedDesc
+	ch <- peerExportedDesc
+	ch <- peerFilteredDesc
+	ch <- peerFlapsDesc
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	for ipv, up := range c.birdUp {
+		ch <- prometheus.MustNewConstMetric(birdUpDesc, prometheus.GaugeValue, boolToFloat(up), ipv.String())
+	}
+	for key, p := range c.peers {
+		labels := key.labels()
+		for _, state := range sessionStates {
+			ch <- prometheus.MustNewConstMetric(peerStateDesc, prometheus.GaugeValue,
+				boolToFloat(p.stats.State == state), append(labels, string(state))...)
+		}
+		uptime := 0.0
+		if !p.establishedAt.IsZero() {
+			uptime = now.Sub(p.establishedAt).Seconds()
+		}
+		ch <- prometheus.MustNewConstMetric(peerUptimeDesc, prometheus.GaugeValue, uptime, labels...)
+		ch <- prometheus.MustNewConstMetric(peerImportedDesc, prometheus.GaugeValue, float64(p.stats.Imported), labels...)
+		ch <- prometheus.MustNewConstMetric(peerExportedDesc, prometheus.GaugeValue, float64(p.stats.Exported), labels...)
+		ch <- prometheus.MustNewConstMetric(peerFilteredDesc, prometheus.GaugeValue, float64(p.stats.Filtered), labels...)
+		ch <- prometheus.MustNewConstMetric(peerFlapsDesc, prometheus.CounterValue, float64(p.flaps), labels...)
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
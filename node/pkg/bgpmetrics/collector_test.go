@@ -0,0 +1,175 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgpmetrics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	populator "github.com/projectcalico/calico/node/pkg/status/populators"
+	"github.com/projectcalico/calico/node/pkg/status/populators/fakebird"
+)
+
+const showProtocols = `2002-name     proto    table    state  since       info
+1002-kernel1  Kernel   master   up     2024-03-01
+ Mesh_10_0_0_2 BGP      master   up     09:30:00    Established
+0000
+`
+
+const meshDetails = `1002-Mesh_10_0_0_2 BGP      master   up     09:30:00    Established
+1006-  Preference:     100
+  Routes:         5 imported, 1 filtered, 7 exported, 5 preferred
+  BGP state:          Established
+    Neighbor address: 10.0.0.2
+0000
+`
+
+const meshDown = `2002-name     proto    table    state  since       info
+ Mesh_10_0_0_2 BGP      master   start  09:45:00    Active
+0000
+`
+
+var _ = Describe("BGP metrics collector", func() {
+	var dir string
+	var bird *fakebird.Server
+	var c *collector
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "bird")
+		Expect(err).NotTo(HaveOccurred())
+		bird, err = fakebird.Start(filepath.Join(dir, "bird.ctl"))
+		Expect(err).NotTo(HaveOccurred())
+		bird.SetResponse("show protocols", showProtocols)
+		bird.SetResponse("show protocols all Mesh_10_0_0_2", meshDetails)
+
+		c = newCollector(func(ipv populator.IPFamily) ([]populator.BGPPeerStats, error) {
+			return populator.ReadBGPPeerStats(ipv, dir)
+		})
+		c.now = func() time.Time {
+			return time.Date(2024, 3, 2, 10, 0, 0, 0, time.Local)
+		}
+	})
+
+	AfterEach(func() {
+		bird.Stop()
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should export the state of each session", func() {
+		c.poll()
+		Expect(testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP calico_bgp_bird_up Whether BIRD could be queried for the state of its BGP sessions.
+# TYPE calico_bgp_bird_up gauge
+calico_bgp_bird_up{ip_version="4"} 1
+calico_bgp_bird_up{ip_version="6"} 0
+# HELP calico_bgp_peer_uptime_seconds Number of seconds that the BGP session has been established, 0 if it is not established.
+# TYPE calico_bgp_peer_uptime_seconds gauge
+calico_bgp_peer_uptime_seconds{ip_version="4",peer_ip="10.0.0.2",peer_type="NodeMesh"} 1800
+# HELP calico_bgp_peer_prefixes_imported Number of prefixes imported from the BGP peer.
+# TYPE calico_bgp_peer_prefixes_imported gauge
+calico_bgp_peer_prefixes_imported{ip_version="4",peer_ip="10.0.0.2",peer_type="NodeMesh"} 5
+# HELP calico_bgp_peer_prefixes_exported Number of prefixes exported to the BGP peer.
+# TYPE calico_bgp_peer_prefixes_exported gauge
+calico_bgp_peer_prefixes_exported{ip_version="4",peer_ip="10.0.0.2",peer_type="NodeMesh"} 7
+# HELP calico_bgp_peer_prefixes_filtered Number of prefixes from the BGP peer that were rejected by the import filter.
+# TYPE calico_bgp_peer_prefixes_filtered gauge
+calico_bgp_peer_prefixes_filtered{ip_version="4",peer_ip="10.0.0.2",peer_type="NodeMesh"} 1
+# HELP calico_bgp_peer_flaps_total Number of times that the BGP session has gone down after being established.
+# TYPE calico_bgp_peer_flaps_total counter
+calico_bgp_peer_flaps_total{ip_version="4",peer_ip="10.0.0.2",peer_type="NodeMesh"} 0
+`),
+			"calico_bgp_bird_up",
+			"calico_bgp_peer_uptime_seconds",
+			"calico_bgp_peer_prefixes_imported",
+			"calico_bgp_peer_prefixes_exported",
+			"calico_bgp_peer_prefixes_filtered",
+			"calico_bgp_peer_flaps_total",
+		)).To(Succeed())
+		Expect(testutil.CollectAndCount(c, "calico_bgp_peer_state")).To(Equal(len(sessionStates)))
+	})
+
+	It("should count the session going down as a flap", func() {
+		c.poll()
+		bird.SetResponse("show protocols", meshDown)
+		bird.SetResponse("show protocols all Mesh_10_0_0_2", "  BGP state:          Active\n    Neighbor address: 10.0.0.2\n0000\n")
+		c.poll()
+		c.poll()
+
+		key := peerKey{ipv: populator.IPFamilyV4, peerIP: "10.0.0.2", peerType: apiv3.BGPPeerTypeNodeMesh}
+		Expect(c.peers).To(HaveKey(key))
+		Expect(c.peers[key].flaps).To(Equal(1))
+		Expect(c.peers[key].establishedAt.IsZero()).To(BeTrue())
+	})
+
+	It("should forget the sessions if BIRD stops", func() {
+		c.poll()
+		bird.Stop()
+		c.poll()
+		Expect(c.peers).To(BeEmpty())
+		Expect(c.birdUp[populator.IPFamilyV4]).To(BeFalse())
+	})
+
+	It("should parse BIRD's since column", func() {
+		now := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+		t, ok := parseSince("09:15:30", now)
+		Expect(ok).To(BeTrue())
+		Expect(t).To(Equal(time.Date(2024, 3, 2, 9, 15, 30, 0, time.UTC)))
+
+		t, ok = parseSince("23:00:00", now)
+		Expect(ok).To(BeTrue())
+		Expect(t).To(Equal(time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC)))
+
+		t, ok = parseSince("2024-02-28", now)
+		Expect(ok).To(BeTrue())
+		Expect(t).To(Equal(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)))
+
+		_, ok = parseSince("Feb28", now)
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("BGP metrics configuration", func() {
+	It("should use the defaults without any BGPConfiguration", func() {
+		Expect(configFromBGPConfigurations(nil, nil)).To(Equal(Config{Port: 9900, PollInterval: 10 * time.Second}))
+	})
+
+	It("should let the node's BGPConfiguration override the default one", func() {
+		enabled := true
+		globalPort, nodePort := 9901, 9902
+		global := apiv3.NewBGPConfiguration()
+		global.Spec.PrometheusMetricsEnabled = &enabled
+		global.Spec.PrometheusMetricsPort = &globalPort
+		global.Spec.PrometheusMetricsPollInterval = &metav1.Duration{Duration: time.Minute}
+		node := apiv3.NewBGPConfiguration()
+		node.Spec.PrometheusMetricsHost = "127.0.0.1"
+		node.Spec.PrometheusMetricsPort = &nodePort
+
+		Expect(configFromBGPConfigurations(global, node)).To(Equal(Config{
+			Enabled:      true,
+			Host:         "127.0.0.1",
+			Port:         9902,
+			PollInterval: time.Minute,
+		}))
+	})
+})
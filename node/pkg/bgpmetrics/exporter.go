@@ -0,0 +1,201 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bgpmetrics implements a Prometheus metrics server that exports the state of BIRD's BGP
+// sessions.  It is configured through the BGPConfiguration resources.
+package bgpmetrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	populator "github.com/projectcalico/calico/node/pkg/status/populators"
+)
+
+const (
+	defaultPort         = 9900
+	defaultPollInterval = 10 * time.Second
+
+	// How often the BGPConfiguration resources are checked for changes.
+	configRefreshInterval = 30 * time.Second
+)
+
+// Config is the configuration of the BGP metrics server.
+type Config struct {
+	Enabled      bool
+	Host         string
+	Port         int
+	PollInterval time.Duration
+}
+
+// configFromBGPConfigurations returns the configuration of the metrics server on a node.  Fields
+// set in the node's BGPConfiguration override those in the default BGPConfiguration.  Either may
+// be nil.
+func configFromBGPConfigurations(global, node *apiv3.BGPConfiguration) Config {
+	cfg := Config{Port: defaultPort, PollInterval: defaultPollInterval}
+	for _, bgpConfig := range []*apiv3.BGPConfiguration{global, node} {
+		if bgpConfig == nil {
+			continue
+		}
+		spec := bgpConfig.Spec
+		if spec.PrometheusMetricsEnabled != nil {
+			cfg.Enabled = *spec.PrometheusMetricsEnabled
+		}
+		if spec.PrometheusMetricsHost != "" {
+			cfg.Host = spec.PrometheusMetricsHost
+		}
+		if spec.PrometheusMetricsPort != nil {
+			cfg.Port = *spec.PrometheusMetricsPort
+		}
+		if spec.PrometheusMetricsPollInterval != nil && spec.PrometheusMetricsPollInterval.Duration > 0 {
+			cfg.PollInterval = spec.PrometheusMetricsPollInterval.Duration
+		}
+	}
+	return cfg
+}
+
+// loadConfig reads the metrics server configuration for the node from the datastore.
+func loadConfig(ctx context.Context, c client.Interface, nodename string) (Config, error) {
+	var bgpConfigs []*apiv3.BGPConfiguration
+	for _, name := range []string{"default", "node." + nodename} {
+		bgpConfig, err := c.BGPConfigurations().Get(ctx, name, options.GetOptions{})
+		if err != nil {
+			if _, ok := err.(cerrors.ErrorResourceDoesNotExist); !ok {
+				return Config{}, err
+			}
+			bgpConfig = nil
+		}
+		bgpConfigs = append(bgpConfigs, bgpConfig)
+	}
+	return configFromBGPConfigurations(bgpConfigs[0], bgpConfigs[1]), nil
+}
+
+// Run runs the BGP metrics server for the node until the context is cancelled.  The server is
+// started, stopped and reconfigured as the BGPConfiguration resources change.
+func Run(ctx context.Context, c client.Interface, nodename string) {
+	e := newExporter(func(ipv populator.IPFamily) ([]populator.BGPPeerStats, error) {
+		return populator.ReadBGPPeerStats(ipv, "")
+	})
+	defer e.stop()
+
+	configTicker := time.NewTicker(configRefreshInterval)
+	defer configTicker.Stop()
+	for {
+		cfg, err := loadConfig(ctx, c, nodename)
+		if err != nil {
+			// Keep the current configuration, and try again later.
+			log.WithError(err).Warn("Failed to read the BGP metrics configuration")
+		} else {
+			e.configure(cfg)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-configTicker.C:
+		}
+	}
+}
+
+// exporter owns the metrics server and the polling of BIRD.
+type exporter struct {
+	collector *collector
+	handler   http.Handler
+
+	cfg      Config
+	server   *http.Server
+	stopPoll chan struct{}
+}
+
+func newExporter(read func(populator.IPFamily) ([]populator.BGPPeerStats, error)) *exporter {
+	col := newCollector(read)
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(col)
+	return &exporter{
+		collector: col,
+		handler:   promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+	}
+}
+
+// configure starts, stops or restarts the metrics server if its configuration has changed.
+func (e *exporter) configure(cfg Config) {
+	if cfg == e.cfg {
+		return
+	}
+	e.stop()
+	e.cfg = cfg
+	if !cfg.Enabled {
+		log.Info("BGP metrics server disabled")
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", e.handler)
+	e.server = &http.Server{
+		Addr:              net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func(server *http.Server) {
+		log.WithFields(log.Fields{
+			"host": cfg.Host,
+			"port": cfg.Port,
+		}).Info("Starting BGP metrics server")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("BGP metrics server failed")
+		}
+	}(e.server)
+
+	e.stopPoll = make(chan struct{})
+	go e.pollForever(cfg.PollInterval, e.stopPoll)
+}
+
+// pollForever polls BIRD at the given interval until the channel is closed.
+func (e *exporter) pollForever(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		e.collector.poll()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stop stops the metrics server, if it is running.
+func (e *exporter) stop() {
+	if e.server == nil {
+		return
+	}
+	close(e.stopPoll)
+	if err := e.server.Close(); err != nil {
+		log.WithError(err).Warn("Failed to stop the BGP metrics server")
+	}
+	e.server = nil
+	e.stopPoll = nil
+}
@@ -27,9 +27,15 @@ import (
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
 	"github.com/projectcalico/calico/node/pkg/health/bird"
+	"github.com/projectcalico/calico/node/pkg/lifecycle/utils"
 )
 
+// BGPDaemonConfigErrorFile is written by the native BGP daemon while it has configuration that it
+// can't honour, which fails the BGP readiness check.
+const BGPDaemonConfigErrorFile = "/var/run/calico/bgp-daemon-config-error"
+
 var (
 	felixReadinessEp string
 	felixLivenessEp  string
@@ -66,6 +72,28 @@ func Run(bird, bird6, felixReady, felixLive, birdLive, bird6Live bool, threshold
 	defer cancel()
 	g, ctx := errgroup.WithContext(ctx)
 
+	if utils.BGPDaemon() == utils.BGPDaemonNative {
+		// The native BGP daemon runs instead of BIRD, so the BIRD checks become a check that
+		// it is running.
+		if bird || bird6 || birdLive || bird6Live {
+			g.Go(func() error {
+				if err := checkService("calico-bgp-daemon"); err != nil {
+					return fmt.Errorf("calico/node is not ready: calico-bgp-daemon is not live: %+v", err)
+				}
+				return nil
+			})
+		}
+		if bird || bird6 {
+			g.Go(func() error {
+				if err := checkBGPDaemonReady(thresholdTime); err != nil {
+					return fmt.Errorf("calico/node is not ready: calico-bgp-daemon is not ready: %+v", err)
+				}
+				return nil
+			})
+		}
+		bird, bird6, birdLive, bird6Live = false, false, false, false
+	}
+
 	if felixLive {
 		g.Go(func() error {
 			if err := checkFelixHealth(ctx, felixLivenessEp, "liveness"); err != nil {
@@ -170,6 +198,67 @@ func checkService(serviceName string) error {
 	return nil
 }
 
+// checkBGPDaemonConfig returns an error if the native BGP daemon has reported configuration that
+// it can't honour.
+func checkBGPDaemonConfig(errorFile string) error {
+	msg, err := os.ReadFile(errorFile)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return errors.New(strings.TrimSpace(string(msg)))
+}
+
+// checkBGPDaemonReady checks if the native BGP daemon is ready: that it can honour its
+// configuration, and that its sessions are established by the same rules as checkBIRDReady.
+func checkBGPDaemonReady(thresholdTime time.Duration) error {
+	if err := checkBGPDaemonConfig(BGPDaemonConfigErrorFile); err != nil {
+		return err
+	}
+	nodenameFileStat, err := os.Stat("/var/lib/calico/nodename")
+	if err != nil {
+		return fmt.Errorf("Failed to stat() nodename file: %v", err)
+	}
+	status, err := bgpstatus.Read(bgpstatus.SocketPath)
+	if err != nil {
+		return err
+	}
+	return checkBGPDaemonStatus(status, time.Since(nodenameFileStat.ModTime()) < thresholdTime)
+}
+
+// checkBGPDaemonStatus checks the status of the native BGP daemon.  starting is true within the
+// threshold time of startup, when all the peerings must be established, and the peers that the
+// daemon had before restarting must have restored their routes.  After that, a single established
+// peering is enough.
+func checkBGPDaemonStatus(status *bgpstatus.Status, starting bool) error {
+	if status.LastReconfiguration.IsZero() {
+		return errors.New("BGP daemon has not been configured")
+	}
+	var notEstablished []string
+	for _, peer := range status.Peers {
+		if !peer.Established() {
+			notEstablished = append(notEstablished, peer.Address)
+		}
+	}
+	numEstablishedPeer := len(status.Peers) - len(notEstablished)
+	log.Infof("Number of node(s) with BGP peering established = %v", numEstablishedPeer)
+
+	if len(status.Peers) == 0 {
+		log.Debugf("There are no bgp peers, returning ready.")
+	} else if starting {
+		if len(notEstablished) > 0 {
+			return fmt.Errorf("BGP not established with %+v", strings.Join(notEstablished, ","))
+		}
+		if !status.InSync {
+			return errors.New("graceful restart in progress")
+		}
+	} else if numEstablishedPeer == 0 {
+		return fmt.Errorf("BGP not established with %+v", strings.Join(notEstablished, ","))
+	}
+	return nil
+}
+
 // checkBIRDReady checks if BIRD is ready by connecting to the BIRD
 // socket to gather all BGP peer connection status, and overall graceful
 // restart status.
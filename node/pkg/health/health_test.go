@@ -0,0 +1,55 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
+)
+
+var _ = Describe("Native BGP daemon readiness", func() {
+	status := func(inSync bool, states ...string) *bgpstatus.Status {
+		s := &bgpstatus.Status{LastReconfiguration: time.Now(), InSync: inSync}
+		for i, state := range states {
+			s.Peers = append(s.Peers, bgpstatus.Peer{Address: "10.0.0." + string(rune('1'+i)), State: state})
+		}
+		return s
+	}
+
+	DescribeTable("should require established sessions",
+		func(s *bgpstatus.Status, starting bool, expectedErr string) {
+			err := checkBGPDaemonStatus(s, starting)
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(expectedErr))
+			}
+		},
+		Entry("before it is configured", &bgpstatus.Status{}, true, "BGP daemon has not been configured"),
+		Entry("without peers", status(false), true, ""),
+		Entry("with all peers established", status(true, "Established", "Established"), true, ""),
+		Entry("with a peer not established at startup", status(true, "Established", "Active"), true,
+			"BGP not established with 10.0.0.2"),
+		Entry("with a graceful restart in progress", status(false, "Established"), true, "graceful restart in progress"),
+		Entry("with one peer established later", status(true, "Established", "Active"), false, ""),
+		Entry("with no peers established later", status(true, "Connect", "Active"), false,
+			"BGP not established with 10.0.0.1,10.0.0.2"),
+	)
+})
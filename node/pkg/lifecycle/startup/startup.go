@@ -210,6 +210,7 @@ func Run() {
 
 	// Write config files now that we are ready to start other components.
 	utils.WriteNodeConfig(nodeName)
+	utils.WriteBGPDaemon(utils.SelectBGPDaemon(node.Labels))
 
 	// Tell the user what the name of the node is.
 	log.Infof("Using node name: %s", nodeName)
@@ -38,6 +38,13 @@ const (
 	defaultShutdownTimestampFileWindows = `c:\CalicoWindows\shutdownTS`
 	defaultNodenameFileLinux            = `/var/lib/calico/nodename`
 	defaultNodenameFileWindows          = `c:\CalicoWindows\nodename`
+	bgpDaemonFileLinux                  = `/var/lib/calico/bgp_daemon`
+
+	// BGPDaemonLabel is the node label that selects the BGP daemon of the node, overriding the
+	// CALICO_BGP_DAEMON environment variable.
+	BGPDaemonLabel  = "projectcalico.org/bgp-daemon"
+	BGPDaemonBIRD   = "bird"
+	BGPDaemonNative = "native"
 )
 
 // For testing purposes we define an exit function that we can override.
@@ -181,6 +188,49 @@ func WriteNodeConfig(nodeName string) {
 	}
 }
 
+// SelectBGPDaemon returns the BGP daemon to run on a node with the given labels: the value of its
+// BGPDaemonLabel, if it has a valid one, or otherwise the CALICO_BGP_DAEMON environment variable.
+func SelectBGPDaemon(labels map[string]string) string {
+	switch daemon := labels[BGPDaemonLabel]; daemon {
+	case BGPDaemonBIRD, BGPDaemonNative:
+		return daemon
+	case "":
+	default:
+		log.Warnf("Ignoring invalid %s label %q, it must be %q or %q", BGPDaemonLabel, daemon, BGPDaemonBIRD, BGPDaemonNative)
+	}
+	if os.Getenv("CALICO_BGP_DAEMON") == BGPDaemonNative {
+		return BGPDaemonNative
+	}
+	return BGPDaemonBIRD
+}
+
+// WriteBGPDaemon writes the BGP daemon selected for this node to disk, for rc.local, which starts
+// it, and the health checks.
+func WriteBGPDaemon(daemon string) {
+	if runtime.GOOS == "windows" {
+		// Windows nodes don't run a BGP daemon in calico/node.
+		return
+	}
+	log.Infof("Using BGP daemon %s", daemon)
+	if err := os.WriteFile(bgpDaemonFileLinux, []byte(daemon), 0644); err != nil {
+		log.WithError(err).Error("Unable to write to " + bgpDaemonFileLinux)
+		Terminate()
+	}
+}
+
+// BGPDaemon returns the BGP daemon that runs on this node, as written at startup, or selected by
+// the CALICO_BGP_DAEMON environment variable if it hasn't been.
+func BGPDaemon() string {
+	data, err := os.ReadFile(bgpDaemonFileLinux)
+	if err == nil {
+		return strings.TrimSpace(string(data))
+	}
+	if !os.IsNotExist(err) {
+		log.WithError(err).Warn("Failed to read " + bgpDaemonFileLinux)
+	}
+	return SelectBGPDaemon(nil)
+}
+
 // Set Kubernetes NodeNetworkUnavailable to false when starting
 // https://kubernetes.io/docs/concepts/architecture/nodes/#condition
 func SetNodeNetworkUnavailableCondition(clientset kubernetes.Clientset,
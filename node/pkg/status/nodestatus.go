@@ -15,6 +15,7 @@
 package status
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/syncersv1/nodestatussyncer"
 	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
 	"github.com/projectcalico/calico/node/buildinfo"
+	"github.com/projectcalico/calico/node/pkg/bgpmetrics"
 	"github.com/projectcalico/calico/node/pkg/calicoclient"
 	"github.com/projectcalico/calico/node/pkg/lifecycle/startup"
 	populator "github.com/projectcalico/calico/node/pkg/status/populators"
@@ -53,6 +55,9 @@ func Run() {
 	// Load the client config from environment.
 	cfg, c := calicoclient.CreateClient()
 
+	// Run the BGP metrics server alongside the reporter, since both query BIRD.
+	go bgpmetrics.Run(context.Background(), c, nodename)
+
 	// This is running as a daemon. Create a long-running NodeStatusReporter.
 	r := NewNodeStatusReporter(nodename, cfg, c, GetPopulators())
 
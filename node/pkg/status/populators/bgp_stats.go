@@ -0,0 +1,79 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package populator
+
+import (
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
+)
+
+// BGPPeerStats is the state of a BGP session, and the number of prefixes that it carries.
+type BGPPeerStats struct {
+	PeerIP string
+	Type   apiv3.BGPPeerType
+
+	// State is the BGP state of the session, and Since is when the session entered it, as
+	// reported by BIRD.
+	State apiv3.BGPSessionState
+	Since string
+
+	// Number of prefixes imported from, filtered from and exported to the peer.
+	Imported int
+	Filtered int
+	Exported int
+}
+
+// ReadBGPPeerStats queries BIRD, or the native BGP daemon if it runs instead, for the state of its
+// BGP sessions.  socketDir is the directory that contains the sockets; if it is empty, the default
+// locations are tried.  If neither is running, an ErrorSocketConnection is returned.
+func ReadBGPPeerStats(ipv IPFamily, socketDir string) ([]BGPPeerStats, error) {
+	dirs := birdSocketDirs
+	if socketDir != "" {
+		dirs = []string{socketDir}
+	}
+	var peers []*bgpPeer
+	if status, err := readNativeStatus(dirs); err != bgpstatus.ErrNotRunning {
+		if err != nil {
+			return nil, err
+		}
+		peers = nativePeers(status, ipv)
+	} else {
+		bc, err := getBirdConnInDirs(ipv, dirs)
+		if err != nil {
+			return nil, err
+		}
+		defer bc.Close()
+
+		peers, err = readBIRDPeers(bc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stats := make([]BGPPeerStats, 0, len(peers))
+	for _, p := range peers {
+		stats = append(stats, BGPPeerStats{
+			PeerIP:   p.peerIP,
+			Type:     bgpTypeMap[p.peerType],
+			State:    birdStateToBGPState[p.bgpState],
+			Since:    p.since,
+			Imported: p.importedRoutes,
+			Filtered: p.filteredRoutes,
+			Exported: p.exportedRoutes,
+		})
+	}
+	return stats, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package populator
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/node/pkg/status/populators/fakebird"
+)
+
+var _ = Describe("Test BIRD BGP peer stats", func() {
+	var dir string
+	var bird *fakebird.Server
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "bird")
+		Expect(err).NotTo(HaveOccurred())
+		bird, err = fakebird.Start(filepath.Join(dir, "bird.ctl"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		bird.Stop()
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should read the state and prefix counts of each session", func() {
+		bird.SetResponse("show protocols", `2002-name     proto    table    state  since       info
+1002-kernel1  Kernel   master   up     2016-11-21
+ Mesh_172_17_8_102 BGP      master   up     2016-11-21  Established
+ Node_172_17_8_104 BGP      master   start  10:15:03    Connect
+0000
+`)
+		bird.SetResponse("show protocols all Mesh_172_17_8_102", `1002-Mesh_172_17_8_102 BGP      master   up     2016-11-21  Established
+1006-  Preference:     100
+  Input filter:   ACCEPT
+  Output filter:  calico_export_to_bgp_peers
+  Routes:         4 imported, 2 filtered, 3 exported, 4 preferred
+  BGP state:          Established
+    Neighbor address: 172.17.8.102
+0000
+`)
+		bird.SetResponse("show protocols all Node_172_17_8_104", `1002-Node_172_17_8_104 BGP      master   start  10:15:03    Connect
+1006-  Preference:     100
+  BGP state:          Connect
+    Neighbor address: 172.17.8.104
+0000
+`)
+
+		stats, err := ReadBGPPeerStats(IPFamilyV4, dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(Equal([]BGPPeerStats{
+			{
+				PeerIP:   "172.17.8.102",
+				Type:     v3.BGPPeerTypeNodeMesh,
+				State:    v3.BGPSessionStateEstablished,
+				Since:    "2016-11-21",
+				Imported: 4,
+				Filtered: 2,
+				Exported: 3,
+			},
+			{
+				PeerIP: "172.17.8.104",
+				Type:   v3.BGPPeerTypeNodePeer,
+				State:  v3.BGPSessionStateConnect,
+				Since:  "10:15:03",
+			},
+		}))
+	})
+
+	It("should return a socket connection error if BIRD is not running", func() {
+		_, err := ReadBGPPeerStats(IPFamilyV6, dir)
+		Expect(err).To(BeAssignableToTypeOf(ErrorSocketConnection{}))
+	})
+})
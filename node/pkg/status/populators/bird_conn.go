@@ -17,6 +17,7 @@ package populator
 import (
 	"fmt"
 	"net"
+	"path/filepath"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -37,23 +38,31 @@ func (bc *birdConn) Close() {
 	bc.conn.Close()
 }
 
+// Directories containing the bird sockets, in the order to try them: `/var/run/calico/` for
+// calico/node, then `/var/run/bird` (which is the default socket location for bird install) for
+// non-containerized installs.
+var birdSocketDirs = []string{"/var/run/calico", "/var/run/bird"}
+
 // getBirdConn return a connection to bird socket.
 func getBirdConn(ipv IPFamily) (*birdConn, error) {
-	birdSuffix := ipv.BirdSuffix()
+	return getBirdConnInDirs(ipv, birdSocketDirs)
+}
 
-	// Try connecting to the bird socket in `/var/run/calico/` first to get the data
-	c, err := net.Dial("unix", fmt.Sprintf("/var/run/calico/bird%s.ctl", birdSuffix))
-	if err != nil {
-		// If that fails, try connecting to bird socket in `/var/run/bird` (which is the
-		// default socket location for bird install) for non-containerized installs
-		log.Debugln("Failed to connect to BIRD socket in /var/run/calico, trying /var/run/bird")
-		c, err = net.Dial("unix", fmt.Sprintf("/var/run/bird/bird%s.ctl", birdSuffix))
-		if err != nil {
-			return nil, ErrorSocketConnection{Err: err, ipv: ipv}
+// getBirdConnInDirs returns a connection to the first bird socket that it can connect to in the given
+// directories.
+func getBirdConnInDirs(ipv IPFamily, dirs []string) (*birdConn, error) {
+	socket := fmt.Sprintf("bird%s.ctl", ipv.BirdSuffix())
+
+	var err error
+	for _, dir := range dirs {
+		var c net.Conn
+		c, err = net.Dial("unix", filepath.Join(dir, socket))
+		if err == nil {
+			return &birdConn{conn: c, ipv: ipv}, nil
 		}
+		log.Debugf("Failed to connect to BIRD socket in %s", dir)
 	}
-
-	return &birdConn{conn: c, ipv: ipv}, nil
+	return nil, ErrorSocketConnection{Err: err, ipv: ipv}
 }
 
 // Error indicating connection to bird socket failed.
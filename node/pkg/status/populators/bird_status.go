@@ -29,6 +29,8 @@ import (
 	"github.com/olekukonko/tablewriter"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
 )
 
 // birdStatus is a structure containing details about bird.
@@ -165,6 +167,13 @@ func scanBIRDStatus(conn net.Conn) (*birdStatus, error) {
 }
 
 func getBirdStatus(ipv IPFamily) (*birdStatus, error) {
+	if status, err := readNativeStatus(birdSocketDirs); err != bgpstatus.ErrNotRunning {
+		if err != nil {
+			return nil, err
+		}
+		return nativeBirdStatus(status), nil
+	}
+
 	bc, err := getBirdConn(ipv)
 	if err != nil {
 		return nil, err
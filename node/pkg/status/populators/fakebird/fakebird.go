@@ -0,0 +1,91 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fakebird implements a fake BIRD control socket, for testing the code that queries BIRD.
+package fakebird
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Greeting is sent by BIRD when a client connects to its control socket.
+const Greeting = "0001 BIRD v0.3.3+birdv1.6.8 ready.\n"
+
+// Server listens on a unix socket and answers each command with a canned response.
+type Server struct {
+	listener net.Listener
+
+	lock      sync.Mutex
+	responses map[string]string
+}
+
+// Start starts a fake BIRD listening on the socket at the given path.
+func Start(path string) (*Server, error) {
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{listener: l, responses: map[string]string{}}
+	go s.accept()
+	return s, nil
+}
+
+// SetResponse sets the output that the fake BIRD sends in reply to the given command, for example
+// "show protocols".  Commands without a response are answered with an empty reply.
+func (s *Server) SetResponse(cmd, output string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.responses[cmd] = output
+}
+
+// Stop closes the socket.
+func (s *Server) Stop() {
+	_ = s.listener.Close()
+}
+
+func (s *Server) accept() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.serve(c)
+	}
+}
+
+func (s *Server) serve(c net.Conn) {
+	defer c.Close()
+	if _, err := c.Write([]byte(Greeting)); err != nil {
+		return
+	}
+	scanner := bufio.NewScanner(c)
+	for scanner.Scan() {
+		cmd := strings.TrimSpace(scanner.Text())
+		s.lock.Lock()
+		output, ok := s.responses[cmd]
+		s.lock.Unlock()
+		if !ok {
+			output = "0000\n"
+		}
+		if _, err := c.Write([]byte(output)); err != nil {
+			log.WithError(err).Debug("Fake BIRD failed to write response")
+			return
+		}
+	}
+}
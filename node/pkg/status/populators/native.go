@@ -0,0 +1,118 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package populator
+
+import (
+	"net/netip"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
+)
+
+// readNativeStatus reads the status of the native BGP daemon, which runs instead of BIRD, from the
+// first of the directories that has its socket.  bgpstatus.ErrNotRunning is returned if there
+// isn't one, and the status is then read from BIRD.
+func readNativeStatus(dirs []string) (*bgpstatus.Status, error) {
+	for _, dir := range dirs {
+		status, err := bgpstatus.Read(filepath.Join(dir, bgpstatus.SocketName))
+		if err != bgpstatus.ErrNotRunning {
+			return status, err
+		}
+	}
+	return nil, bgpstatus.ErrNotRunning
+}
+
+func inFamily(addr string, ipv IPFamily) bool {
+	a, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+	return a.Is6() == (ipv == IPFamilyV6)
+}
+
+// nativeSessionName returns the BIRD protocol name that confd would generate for a peer.
+func nativeSessionName(peerType, addr string, ipv IPFamily) string {
+	return peerType + "_" + strings.ReplaceAll(addr, ipv.Separator(), "_")
+}
+
+func formatNativeTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+// nativePeers returns the native BGP daemon's peers in the given family, as BIRD would report them.
+func nativePeers(status *bgpstatus.Status, ipv IPFamily) []*bgpPeer {
+	peers := []*bgpPeer{}
+	for _, p := range status.Peers {
+		if !inFamily(p.Address, ipv) {
+			continue
+		}
+		state := "start"
+		if p.Established() {
+			state = "up"
+		}
+		peers = append(peers, &bgpPeer{
+			session:        nativeSessionName(p.Type, p.Address, ipv),
+			peerIP:         p.Address,
+			peerType:       p.Type,
+			state:          state,
+			since:          formatNativeTime(p.Since),
+			bgpState:       p.State,
+			importedRoutes: p.Imported,
+			exportedRoutes: p.Exported,
+		})
+	}
+	return peers
+}
+
+// nativeBirdStatus returns the native BGP daemon's status as BIRD would report it.
+func nativeBirdStatus(status *bgpstatus.Status) *birdStatus {
+	return &birdStatus{
+		ready:            status.InSync,
+		version:          "native " + status.Version,
+		routerID:         status.RouterID,
+		serverTime:       formatNativeTime(time.Now()),
+		lastBootTime:     formatNativeTime(status.Started),
+		lastReconfigTime: formatNativeTime(status.LastReconfiguration),
+	}
+}
+
+// nativeRoutes returns the native BGP daemon's best routes in the given family, as BIRD would
+// report them.
+func nativeRoutes(status *bgpstatus.Status, ipv IPFamily) []route {
+	peerTypes := map[string]string{}
+	for _, p := range status.Peers {
+		peerTypes[p.Address] = p.Type
+	}
+	routes := []route{}
+	for _, r := range status.Routes {
+		prefix, err := netip.ParsePrefix(r.Prefix)
+		if err != nil || !inFamily(prefix.Addr().String(), ipv) {
+			continue
+		}
+		rt := route{dest: r.Prefix, gateway: r.NextHop, primary: true}
+		if r.Peer != "" {
+			rt.learnedFrom = nativeSessionName(peerTypes[r.Peer], r.Peer, ipv)
+		} else {
+			rt.learnedFrom = r.Source + "1"
+		}
+		routes = append(routes, rt)
+	}
+	return routes
+}
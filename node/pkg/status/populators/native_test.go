@@ -0,0 +1,149 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package populator
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
+)
+
+var _ = Describe("Test native BGP daemon status", func() {
+	var dir string
+	var stop chan struct{}
+	since := time.Date(2024, 5, 1, 10, 15, 3, 0, time.UTC)
+	status := &bgpstatus.Status{
+		Version:  "v3.29.0",
+		RouterID: "172.17.8.101",
+		AS:       64512,
+		InSync:   true,
+		Peers: []bgpstatus.Peer{
+			{Address: "172.17.8.102", Type: bgpstatus.PeerTypeMesh, State: "Established", Since: since, Imported: 4, Exported: 3},
+			{Address: "172.17.8.104", Type: bgpstatus.PeerTypeNode, State: "Active", Since: since},
+			{Address: "2001:20::8", Type: bgpstatus.PeerTypeGlobal, State: "Established", Since: since},
+		},
+		Routes: []bgpstatus.Route{
+			{Prefix: "192.168.110.128/26", NextHop: "172.17.8.102", Peer: "172.17.8.102"},
+			{Prefix: "192.168.162.128/26", Source: bgpstatus.SourceStatic},
+			{Prefix: "10.10.0.0/16", NextHop: "172.17.8.1", Source: bgpstatus.SourceKernel},
+			{Prefix: "2001:30::/64", NextHop: "2001:20::8", Peer: "2001:20::8"},
+		},
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "bgp")
+		Expect(err).NotTo(HaveOccurred())
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			Expect(bgpstatus.Serve(filepath.Join(dir, bgpstatus.SocketName), func() *bgpstatus.Status { return status }, stop)).To(Succeed())
+		}()
+		Eventually(func() error {
+			_, err := readNativeStatus([]string{dir})
+			return err
+		}).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		close(stop)
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should read the state and prefix counts of each session", func() {
+		stats, err := ReadBGPPeerStats(IPFamilyV4, dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(Equal([]BGPPeerStats{
+			{
+				PeerIP:   "172.17.8.102",
+				Type:     v3.BGPPeerTypeNodeMesh,
+				State:    v3.BGPSessionStateEstablished,
+				Since:    formatNativeTime(since),
+				Imported: 4,
+				Exported: 3,
+			},
+			{
+				PeerIP: "172.17.8.104",
+				Type:   v3.BGPPeerTypeNodePeer,
+				State:  v3.BGPSessionStateActive,
+				Since:  formatNativeTime(since),
+			},
+		}))
+
+		stats, err = ReadBGPPeerStats(IPFamilyV6, dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(HaveLen(1))
+		Expect(stats[0].Type).To(Equal(v3.BGPPeerTypeGlobalPeer))
+	})
+
+	It("should report the peers and routes as BIRD's", func() {
+		s, err := readNativeStatus([]string{dir})
+		Expect(err).NotTo(HaveOccurred())
+
+		peers := nativePeers(s, IPFamilyV4)
+		Expect(peers).To(HaveLen(2))
+		Expect(peers[0].toNodeStatusAPI()).To(Equal(v3.CalicoNodePeer{
+			PeerIP: "172.17.8.102",
+			Type:   v3.BGPPeerTypeNodeMesh,
+			State:  v3.BGPSessionStateEstablished,
+			Since:  formatNativeTime(since),
+		}))
+		Expect(peers[1].state).To(Equal("start"))
+
+		Expect(nativeBirdStatus(s).toNodeStatusAPI()).To(MatchFields(IgnoreExtras, Fields{
+			"State":    Equal(v3.BGPDaemonStateReady),
+			"RouterID": Equal("172.17.8.101"),
+		}))
+
+		var routes []*v3.CalicoNodeRoute
+		for _, r := range nativeRoutes(s, IPFamilyV4) {
+			api, err := r.toNodeStatusAPI()
+			Expect(err).NotTo(HaveOccurred())
+			routes = append(routes, api)
+		}
+		Expect(routes).To(Equal([]*v3.CalicoNodeRoute{
+			{
+				Type:        v3.RouteTypeFIB,
+				Destination: "192.168.110.128/26",
+				Gateway:     "172.17.8.102",
+				LearnedFrom: v3.CalicoNodeRouteLearnedFrom{SourceType: v3.RouteSourceTypeNodeMesh, PeerIP: "172.17.8.102"},
+			},
+			{
+				Type:        v3.RouteTypeFIB,
+				Destination: "192.168.162.128/26",
+				LearnedFrom: v3.CalicoNodeRouteLearnedFrom{SourceType: v3.RouteSourceTypeStatic},
+			},
+			{
+				Type:        v3.RouteTypeFIB,
+				Destination: "10.10.0.0/16",
+				Gateway:     "172.17.8.1",
+				LearnedFrom: v3.CalicoNodeRouteLearnedFrom{SourceType: v3.RouteSourceTypeKernel},
+			},
+		}))
+
+		v6Routes := nativeRoutes(s, IPFamilyV6)
+		Expect(v6Routes).To(HaveLen(1))
+		api, err := v6Routes[0].toNodeStatusAPI()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(api.LearnedFrom).To(Equal(v3.CalicoNodeRouteLearnedFrom{SourceType: v3.RouteSourceTypeBGPPeer, PeerIP: "2001:20::8"}))
+	})
+})
@@ -22,6 +22,7 @@ import (
 	"net"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +33,8 @@ import (
 	"github.com/olekukonko/tablewriter"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
 )
 
 // Check for Word_<IP> where every octate is separated by "_", regardless of IP protocols
@@ -45,6 +48,10 @@ var bgpTypeMap = map[string]apiv3.BGPPeerType{
 	"Node":   apiv3.BGPPeerTypeNodePeer,
 }
 
+// Match the prefix counts in the "Routes:" line of BIRD's protocol details, e.g.
+// "Routes:         2 imported, 1 filtered, 3 exported, 2 preferred".
+var bgpRoutesRegex = regexp.MustCompile(`(\d+) (imported|filtered|exported)`)
+
 // Expected BIRD protocol table columns
 var birdExpectedHeadings = []string{"name", "proto", "table", "state", "since", "info"}
 
@@ -57,6 +64,11 @@ type bgpPeer struct {
 	since    string
 	bgpState string
 	info     string
+
+	// Number of prefixes imported from, filtered from and exported to the peer.
+	importedRoutes int
+	filteredRoutes int
+	exportedRoutes int
 }
 
 var birdStateToBGPState map[string]apiv3.BGPSessionState = map[string]apiv3.BGPSessionState{
@@ -140,7 +152,7 @@ func (b *bgpPeer) unmarshalBIRD(line, ipSep string) bool {
 }
 
 // Complete reads detailed information for a BGP session and fill in bgpPeer structure.
-// Currently we only set BGP state and PeerIP but could extend to other fields later.
+// Currently we only set BGP state, PeerIP and the prefix counts but could extend to other fields later.
 func (b *bgpPeer) complete(bc *birdConn) error {
 	// Send the request.
 	cmd := fmt.Sprintf("show protocols all %s\n", b.session)
@@ -202,6 +214,8 @@ func (b *bgpPeer) complete(bc *birdConn) error {
 			b.bgpState = state
 		} else if ip, ok := getValue(str, "Neighbor address:"); ok {
 			b.peerIP = ip
+		} else if routes, ok := getValue(str, "Routes:"); ok {
+			b.unmarshalRoutes(routes)
 		}
 
 		// Before reading the next line, adjust the time-out for
@@ -215,6 +229,25 @@ func (b *bgpPeer) complete(bc *birdConn) error {
 	return scanner.Err()
 }
 
+// unmarshalRoutes sets the prefix counts from the value of the "Routes:" line.  BIRD only includes
+// the filtered count when the protocol keeps filtered routes.
+func (b *bgpPeer) unmarshalRoutes(routes string) {
+	for _, m := range bgpRoutesRegex.FindAllStringSubmatch(routes, -1) {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		switch m[2] {
+		case "imported":
+			b.importedRoutes = n
+		case "filtered":
+			b.filteredRoutes = n
+		case "exported":
+			b.exportedRoutes = n
+		}
+	}
+}
+
 // readBIRDPeers queries BIRD and return BGP peer info.
 func readBIRDPeers(bc *birdConn) ([]*bgpPeer, error) {
 	c := bc.conn
@@ -328,6 +361,13 @@ func scanBIRDPeers(ipv IPFamily, conn net.Conn) ([]*bgpPeer, error) {
 }
 
 func getBGPPeers(ipv IPFamily) ([]*bgpPeer, error) {
+	if status, err := readNativeStatus(birdSocketDirs); err != bgpstatus.ErrNotRunning {
+		if err != nil {
+			return nil, err
+		}
+		return nativePeers(status, ipv), nil
+	}
+
 	bc, err := getBirdConn(ipv)
 	if err != nil {
 		return nil, err
@@ -114,6 +114,8 @@ BGP state:          OpenSent
 				since:    "2016-11-21",
 				bgpState: "Established",
 				info:     "",
+
+				exportedRoutes: 1,
 			},
 			{
 				session:  "Global_172_17_8_103",
@@ -123,6 +125,8 @@ BGP state:          OpenSent
 				since:    "2016-11-21",
 				bgpState: "Established",
 				info:     "",
+
+				exportedRoutes: 1,
 			},
 			{
 				session:  "Node_172_17_8_104",
@@ -132,6 +136,8 @@ BGP state:          OpenSent
 				since:    "2016-11-21",
 				bgpState: "OpenSent",
 				info:     "Socket: error",
+
+				exportedRoutes: 1,
 			},
 		}
 		bgpPeers, err := readBIRDPeers(getMockBirdConn(IPFamilyV4, table))
@@ -217,6 +223,8 @@ BGP state:          Established
 				since:    "2016-11-21",
 				bgpState: "Established",
 				info:     "",
+
+				exportedRoutes: 1,
 			},
 		}
 		bgpPeers, err := readBIRDPeers(getMockBirdConn(IPFamilyV6, table))
@@ -30,6 +30,8 @@ import (
 	"github.com/olekukonko/tablewriter"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/node/pkg/bgp/bgpstatus"
 )
 
 var (
@@ -292,6 +294,13 @@ func scanBIRDRoutes(ipv IPFamily, conn net.Conn) ([]route, error) {
 }
 
 func getRoutes(ipv IPFamily) ([]route, error) {
+	if status, err := readNativeStatus(birdSocketDirs); err != bgpstatus.ErrNotRunning {
+		if err != nil {
+			return nil, err
+		}
+		return nativeRoutes(status, ipv), nil
+	}
+
 	bc, err := getBirdConn(ipv)
 	if err != nil {
 		return nil, err
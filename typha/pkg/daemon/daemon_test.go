@@ -338,6 +338,14 @@ func (b *mockDatastore) BGPFilter() clientv3.BGPFilterInterface {
 	panic("not implemented")
 }
 
+func (b *mockDatastore) RoutePolicies() clientv3.RoutePolicyInterface {
+	panic("not implemented")
+}
+
+func (b *mockDatastore) IPPoolMigrations() clientv3.IPPoolMigrationInterface {
+	panic("not implemented")
+}
+
 // BGPPeers returns an interface for managing BGP peer resources.
 func (b *mockDatastore) BGPPeers() clientv3.BGPPeerInterface {
 	panic("not implemented")